@@ -0,0 +1,30 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+type apiHandshaker interface {
+	APIHandshake(ctx context.Context, clientVersion types.APIVersion) (types.APIHandshake, error)
+}
+
+// apiVersionsHandler serves the versions and the deprecated methods of the rpc apis as json, letting the clients
+// pick the api they are built against before connecting.
+func apiVersionsHandler(h apiHandshaker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hs, err := h.APIHandshake(r.Context(), 0)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(hs.APIVersions); err != nil {
+			log.Warnf("failed to write api versions response: %v", err)
+		}
+	})
+}
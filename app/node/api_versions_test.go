@@ -0,0 +1,39 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+type staticHandshake types.APIVersions
+
+func (h staticHandshake) APIHandshake(ctx context.Context, clientVersion types.APIVersion) (types.APIHandshake, error) {
+	return types.APIHandshake{APIVersions: types.APIVersions(h)}, nil
+}
+
+func TestAPIVersionsHandler(t *testing.T) {
+	tf.UnitTest(t)
+
+	versions := staticHandshake{
+		Version: "1.0.0",
+		APIs: []types.APINamespaceVersion{
+			{Path: "/rpc/v1", Namespace: "Filecoin", APIVersion: types.NewVer(2, 3, 0)},
+		},
+	}
+
+	rec := httptest.NewRecorder()
+	apiVersionsHandler(versions).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/rpc/versions", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var got types.APIVersions
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	require.Equal(t, types.APIVersions(versions), got)
+}
@@ -3,6 +3,7 @@ package node
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"time"
 
 	"github.com/filecoin-project/venus/app/submodule/dagservice"
@@ -30,11 +31,15 @@ import (
 	"github.com/filecoin-project/venus/app/submodule/wallet"
 	chain2 "github.com/filecoin-project/venus/pkg/chain"
 	"github.com/filecoin-project/venus/pkg/clock"
+	"github.com/filecoin-project/venus/pkg/config"
 	"github.com/filecoin-project/venus/pkg/journal"
+	"github.com/filecoin-project/venus/pkg/maintenance"
+	evtjournal "github.com/filecoin-project/venus/pkg/messagepool/journal"
 	"github.com/filecoin-project/venus/pkg/paychmgr"
 	"github.com/filecoin-project/venus/pkg/repo"
 	"github.com/filecoin-project/venus/pkg/util/ffiwrapper"
 	"github.com/filecoin-project/venus/pkg/util/ffiwrapper/impl"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
 	"github.com/filecoin-project/venus/venus-shared/types"
 	"github.com/ipfs-force-community/metrics/ratelimit"
 )
@@ -48,11 +53,14 @@ type Builder struct {
 	propDelay      time.Duration
 	repo           repo.Repo
 	journal        journal.Journal
+	eventJournal   evtjournal.Journal
+	journalEvents  *evtjournal.MemorySink
 	isRelay        bool
 	chainClock     clock.ChainEpochClock
 	genBlk         types.BlockHeader
 	walletPassword []byte
 	authURL        string
+	configProfile  string
 }
 
 // New creates a new node.
@@ -86,6 +94,10 @@ func (b *Builder) build(ctx context.Context) (*Node, error) {
 	if b.journal == nil {
 		b.journal = journal.NewNoopJournal()
 	}
+	b.eventJournal, b.journalEvents, err = evtjournal.Open(b.repo)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open the journal")
+	}
 
 	b.genBlk, err = chain2.GenesisBlock(ctx, b.repo.ChainDatastore(), b.repo.Datastore())
 	if err != nil {
@@ -102,6 +114,7 @@ func (b *Builder) build(ctx context.Context) (*Node, error) {
 		offlineMode: b.offlineMode,
 		repo:        b.repo,
 		chainClock:  b.chainClock,
+		journal:     b.eventJournal,
 	}
 
 	// services
@@ -112,17 +125,17 @@ func (b *Builder) build(ctx context.Context) (*Node, error) {
 		return nil, errors.Wrap(err, "failed to build node.blockstore")
 	}
 
-	nd.chain, err = chain.NewChainSubmodule(ctx, (*builder)(b))
+	nd.chain, err = chain.NewChainSubmodule(ctx, (*builder)(b), nd.blockstore)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to build node.Chain")
 	}
 
-	nd.network, err = network.NewNetworkSubmodule(ctx, nd.chain.ChainReader, nd.chain.MessageStore, (*builder)(b))
+	nd.network, err = network.NewNetworkSubmodule(ctx, nd.blockstore, nd.chain.ChainReader, nd.chain.MessageStore, (*builder)(b))
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to build node.Network")
 	}
 
-	nd.blockservice, err = dagservice.NewDagserviceSubmodule(ctx, (*builder)(b), nd.network)
+	nd.blockservice, err = dagservice.NewDagserviceSubmodule(ctx, (*builder)(b), nd.blockstore, nd.network)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to build node.dagservice")
 	}
@@ -151,7 +164,7 @@ func (b *Builder) build(ctx context.Context) (*Node, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to build node.storageNetworking")
 	}
-	nd.mining = mining.NewMiningModule(nd.syncer.Stmgr, (*builder)(b), nd.chain, nd.blockstore, nd.syncer, *nd.wallet)
+	nd.mining = mining.NewMiningModule(nd.syncer.Stmgr, (*builder)(b), nd.chain, nd.blockstore, nd.syncer, *nd.wallet, nd.mpool.API())
 
 	mgrps := &paychmgr.ManagerParams{
 		MPoolAPI:     nd.mpool.API(),
@@ -162,16 +175,13 @@ func (b *Builder) build(ctx context.Context) (*Node, error) {
 	if nd.paychan, err = paych.NewPaychSubmodule(ctx, b.repo.PaychDatastore(), mgrps); err != nil {
 		return nil, err
 	}
-	nd.market = market.NewMarketModule(nd.chain.API(), nd.syncer.Stmgr)
-
-	blockDelay := b.repo.Config().NetworkParams.BlockDelay
-	nd.common = common.NewCommonModule(nd.chain, nd.network, blockDelay)
+	nd.market = market.NewMarketModule(nd.chain.API(), nd.mpool.API(), nd.syncer.Stmgr, b.repo.MetaDatastore())
 
 	sqlitePath, err := b.repo.SqlitePath()
 	if err != nil {
 		return nil, err
 	}
-	if nd.eth, err = eth.NewEthSubModule(ctx, b.repo.Config(), nd.chain, nd.mpool, sqlitePath, nd.syncer.API()); err != nil {
+	if nd.eth, err = eth.NewEthSubModule(ctx, b.repo.Config(), nd.chain, nd.mpool, sqlitePath, nd.syncer.API(), nd.f3.API()); err != nil {
 		return nil, err
 	}
 
@@ -179,6 +189,51 @@ func (b *Builder) build(ctx context.Context) (*Node, error) {
 		return nil, err
 	}
 
+	if nd.maintenance, err = maintenance.New(b.repo.Config().Maintenance); err != nil {
+		return nil, errors.Wrap(err, "failed to build maintenance scheduler")
+	}
+	if gc, ok := b.repo.Datastore().(blockstoreutil.BlockstoreGC); ok {
+		fullGC := b.repo.Config().Maintenance.FullGC
+		nd.maintenance.Register("blockstore-gc", func(_ context.Context) error {
+			unlock, err := nd.chain.ChainReader.TryLockPrune()
+			if err != nil {
+				return err
+			}
+			defer unlock()
+			return gc.CollectGarbage(blockstoreutil.WithFullGC(fullGC))
+		})
+	}
+	nd.maintenance.Register("sqlite-vacuum", func(ctx context.Context) error {
+		if err := nd.chain.Vacuum(ctx); err != nil {
+			return err
+		}
+		return nd.eth.Vacuum(ctx)
+	})
+
+	repoPath, err := b.repo.Path()
+	if err != nil {
+		return nil, err
+	}
+	if nd.configReloader, err = config.NewReloader(filepath.Join(repoPath, "config.json"), b.configProfile, b.repo.Config(), b.repo.ReplaceConfig); err != nil {
+		return nil, errors.Wrap(err, "failed to build config reloader")
+	}
+	nd.configReloader.Register(func(cfg *config.Config) error {
+		for subsystem, level := range cfg.Observability.Logging.Levels {
+			if err := logging.SetLogLevel(subsystem, level); err != nil {
+				return fmt.Errorf("set log level of %s: %w", subsystem, err)
+			}
+		}
+		return nil
+	})
+	nd.configReloader.Register(func(cfg *config.Config) error {
+		return nd.network.SetProtectedPeers(cfg.Swarm.ProtectedPeers)
+	})
+	nd.configReloader.Register(nd.eth.Reload)
+	nd.configReloader.Register(nd.actorEvent.Reload)
+
+	blockDelay := b.repo.Config().NetworkParams.BlockDelay
+	nd.common = common.NewCommonModule(nd.chain, nd.network, nd.mpool, nd.eth.GetEventFilterManager(), nd.maintenance, nd.configReloader, b.repo, blockDelay, b.repo.Config().Health, b.repo.Config().API.RPCVersions, b.journalEvents)
+
 	apiBuilder := NewBuilder()
 	apiBuilder.NameSpace("Filecoin")
 
@@ -223,7 +278,12 @@ func (b *Builder) build(ctx context.Context) (*Node, error) {
 		_ = logging.SetLogLevel("rate-limit", "warn")
 	}
 
+	if metricsCfg := cfg.Observability.Metrics; metricsCfg.APIMetricsEnabled {
+		apiBuilder.EnableMetrics(time.Duration(metricsCfg.SlowCallThreshold))
+	}
+
 	nd.jsonRPCServiceV1 = apiBuilder.Build("v1", ratelimiter)
 	nd.jsonRPCService = apiBuilder.Build("v0", ratelimiter)
+	nd.fullNode = apiBuilder.FullNode(ratelimiter)
 	return nd, nil
 }
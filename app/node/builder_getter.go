@@ -5,6 +5,7 @@ import (
 
 	"github.com/filecoin-project/venus/pkg/clock"
 	"github.com/filecoin-project/venus/pkg/journal"
+	evtjournal "github.com/filecoin-project/venus/pkg/messagepool/journal"
 	"github.com/filecoin-project/venus/pkg/repo"
 	"github.com/filecoin-project/venus/pkg/util/ffiwrapper"
 	"github.com/ipfs/go-cid"
@@ -56,6 +57,11 @@ func (b builder) Journal() journal.Journal {
 	return b.journal
 }
 
+// EventJournal get the journal recording the lifecycle events of the node
+func (b builder) EventJournal() evtjournal.Journal {
+	return b.eventJournal
+}
+
 // Libp2pOpts get libp2p option
 func (b builder) Libp2pOpts() []libp2p.Option {
 	return b.libp2pOpts
@@ -70,3 +76,11 @@ func (b builder) OfflineMode() bool {
 func (b builder) Verifier() ffiwrapper.Verifier {
 	return b.verifier
 }
+
+// PropagationDelay get the time to wait for blocks to arrive before mining
+func (b builder) PropagationDelay() time.Duration {
+	if b.propDelay == 0 {
+		return clock.DefaultPropagationDelay
+	}
+	return b.propDelay
+}
@@ -65,6 +65,14 @@ func SetAuthURL(url string) BuilderOpt {
 	}
 }
 
+// ConfigProfile sets the config profile applied to the config of the repo, the reloads of the config file apply it
+func ConfigProfile(name string) BuilderOpt {
+	return func(c *Builder) error {
+		c.configProfile = name
+		return nil
+	}
+}
+
 // Libp2pOptions returns a builder option that sets up the libp2p node
 func Libp2pOptions(opts ...libp2p.Option) BuilderOpt {
 	return func(b *Builder) error {
@@ -1,13 +1,19 @@
 package node
 
 import (
+	"time"
+
 	libp2p "github.com/libp2p/go-libp2p"
 	ci "github.com/libp2p/go-libp2p/core/crypto"
 	errors "github.com/pkg/errors"
 
 	"github.com/filecoin-project/venus/pkg/repo"
+	"github.com/filecoin-project/venus/pkg/util/ffiwrapper/impl"
 )
 
+// devnetPropagationDelay is the time a devnet node waits before mining, there are no other miners to wait for.
+const devnetPropagationDelay = 100 * time.Millisecond
+
 // OptionsFromRepo takes a repo and returns options that configure a node
 // to use the given repo.
 func OptionsFromRepo(r repo.Repo) ([]BuilderOpt, error) {
@@ -30,7 +36,18 @@ func OptionsFromRepo(r repo.Repo) ([]BuilderOpt, error) {
 		return nil
 	}
 
-	return append(cfgopts, dsopt), nil
+	cfgopts = append(cfgopts, dsopt)
+
+	// the sectors of the devnet miners are fake, so are their proofs
+	if cfg.Devnet != nil && cfg.Devnet.Enable {
+		cfgopts = append(cfgopts,
+			BlockTime(time.Duration(cfg.NetworkParams.BlockDelay)*time.Second),
+			PropagationDelay(devnetPropagationDelay),
+			VerifierConfigOption(&impl.FakeVerifier{}),
+		)
+	}
+
+	return cfgopts, nil
 }
 
 func loadPrivKeyFromKeystore(r repo.Repo) (ci.PrivKey, error) {
@@ -0,0 +1,129 @@
+package node
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ipfs-force-community/sophon-auth/auth"
+	"github.com/ipfs-force-community/sophon-auth/core"
+	"github.com/ipfs-force-community/sophon-auth/jwtclient"
+	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/venus-shared/api"
+	"github.com/filecoin-project/venus/venus-shared/api/grpcapi"
+)
+
+// runGRPCAPI starts the grpc server of the chain, state and message pool apis when it is enabled, the calls are
+// authorized by the tokens of the json rpc api.
+func (node *Node) runGRPCAPI(ctx context.Context, local jwtclient.IJwtAuthClient) error {
+	cfg := node.repo.Config().API
+	if cfg.GRPCAddress == "" {
+		return nil
+	}
+	sel, err := chain.ParseTipSetSelector(cfg.TipSetSelector)
+	if err != nil {
+		return err
+	}
+	mAddr, err := ma.NewMultiaddr(cfg.GRPCAddress)
+	if err != nil {
+		return err
+	}
+	lis, err := manet.Listen(mAddr)
+	if err != nil {
+		return err
+	}
+
+	a := &grpcAuth{local: local, remote: node.remoteAuth, selector: sel}
+	node.grpcServer = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(a.unary),
+		grpc.ChainStreamInterceptor(a.stream),
+	)
+	if err := grpcapi.Register(node.grpcServer, node.fullNode); err != nil {
+		_ = lis.Close()
+		return err
+	}
+
+	log.Infof("grpc api listening on %s", lis.Multiaddr())
+	go func() {
+		if err := node.grpcServer.Serve(manet.NetListener(lis)); err != nil {
+			log.Errorf("grpc api stopped: %v", err)
+		}
+	}()
+	return nil
+}
+
+// grpcAuth verifies the tokens of the calls like the auth mux of the http api, the permissions of the tokens are
+// checked by the api. The empty tipset keys refer to the tipset of the x-tipset-selector metadata, or of selector.
+type grpcAuth struct {
+	local, remote jwtclient.IJwtAuthClient
+	selector      chain.TipSetSelector
+}
+
+func (a *grpcAuth) unary(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := a.authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *grpcAuth) stream(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authorize(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authorizedStream{ServerStream: ss, ctx: ctx})
+}
+
+func (a *grpcAuth) authorize(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	var token string
+	if vals := md.Get(api.AuthorizationHeader); len(vals) > 0 {
+		token = vals[0]
+	}
+	if !strings.HasPrefix(token, "Bearer ") {
+		return nil, status.Error(codes.Unauthenticated, "missing Bearer token")
+	}
+	token = strings.TrimPrefix(token, "Bearer ")
+
+	if p, ok := peer.FromContext(ctx); ok {
+		ctx = core.CtxWithTokenLocation(ctx, p.Addr.String())
+	}
+	perm, err := a.local.Verify(ctx, token)
+	if err != nil && a.remote != nil {
+		perm, err = a.remote.Verify(ctx, token)
+	}
+	if err != nil {
+		log.Warnf("JWT Verification failed for the grpc api: %s", err)
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	ctx = core.CtxWithPerm(ctx, perm)
+	if name, _ := auth.JwtUserFromToken(token); len(name) != 0 {
+		ctx = core.CtxWithName(ctx, name)
+	}
+
+	sel := a.selector
+	if vals := md.Get(chain.TipSetSelectorHeader); len(vals) > 0 {
+		if sel, err = chain.ParseTipSetSelector(vals[0]); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	return chain.WithTipSetSelector(ctx, sel), nil
+}
+
+type authorizedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authorizedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,35 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+type healthReporter interface {
+	NodeHealth(ctx context.Context) (types.NodeHealth, error)
+}
+
+// healthHandler serves the node health as json, answering 503 when ready is required
+// and not met, or when the node is not live.
+func healthHandler(reporter healthReporter, requireReady bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		health, err := reporter.NodeHealth(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		status := http.StatusOK
+		if !health.Live || (requireReady && !health.Ready) {
+			status = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if err := json.NewEncoder(w).Encode(health); err != nil {
+			log.Warnf("failed to write health response: %v", err)
+		}
+	})
+}
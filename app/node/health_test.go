@@ -0,0 +1,54 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+type staticHealth types.NodeHealth
+
+func (h staticHealth) NodeHealth(ctx context.Context) (types.NodeHealth, error) {
+	return types.NodeHealth(h), nil
+}
+
+func TestHealthHandler(t *testing.T) {
+	tf.UnitTest(t)
+
+	lagging := staticHealth{
+		Live:  true,
+		Ready: false,
+		Checks: []types.NodeHealthCheck{
+			{Name: "sync_lag", OK: false, Value: 20, Threshold: 10},
+		},
+	}
+
+	serve := func(h http.Handler) (int, types.NodeHealth) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		var health types.NodeHealth
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &health))
+		return rec.Code, health
+	}
+
+	code, health := serve(healthHandler(lagging, false))
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, types.NodeHealth(lagging), health)
+
+	code, _ = serve(healthHandler(lagging, true))
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+
+	code, _ = serve(healthHandler(staticHealth{Live: true, Ready: true}, true))
+	assert.Equal(t, http.StatusOK, code)
+
+	code, _ = serve(healthHandler(staticHealth{}, false))
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+}
@@ -6,7 +6,9 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"slices"
 	"syscall"
+	"time"
 
 	"github.com/awnumar/memguard"
 	"github.com/etherlabsio/healthcheck/v2"
@@ -27,13 +29,17 @@ import (
 	"github.com/filecoin-project/venus/app/submodule/storagenetworking"
 	syncer2 "github.com/filecoin-project/venus/app/submodule/syncer"
 	"github.com/filecoin-project/venus/app/submodule/wallet"
+	"github.com/filecoin-project/venus/pkg/chain"
 	"github.com/filecoin-project/venus/pkg/clock"
 	"github.com/filecoin-project/venus/pkg/config"
 	_ "github.com/filecoin-project/venus/pkg/crypto/bls"       // enable bls signatures
 	_ "github.com/filecoin-project/venus/pkg/crypto/delegated" // enable delegated signatures
 	_ "github.com/filecoin-project/venus/pkg/crypto/secp"      // enable secp signatures
+	"github.com/filecoin-project/venus/pkg/maintenance"
+	evtjournal "github.com/filecoin-project/venus/pkg/messagepool/journal"
 	metricsPKG "github.com/filecoin-project/venus/pkg/metrics"
 	"github.com/filecoin-project/venus/pkg/repo"
+	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 	"github.com/ipfs-force-community/metrics"
 	"github.com/ipfs-force-community/sophon-auth/jwtclient"
 	cmds "github.com/ipfs/go-ipfs-cmds"
@@ -44,6 +50,7 @@ import (
 	"github.com/pkg/errors"
 	"go.opencensus.io/tag"
 	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc"
 )
 
 var log = logging.Logger("node") // nolint: deadcode
@@ -100,6 +107,13 @@ type Node struct {
 
 	common *common.CommonModule
 
+	// maintenance runs the disk maintenance in the maintenance windows
+	maintenance *maintenance.Scheduler
+	// configReloader applies the changes of the config to the running node
+	configReloader *config.Reloader
+	// journal records the lifecycle events of the node
+	journal evtjournal.Journal
+
 	eth        *eth.EthSubModule
 	actorEvent *actorevent.ActorEventSubModule
 
@@ -107,8 +121,11 @@ type Node struct {
 	// Jsonrpc
 	//
 	jsonRPCService, jsonRPCServiceV1 *jsonrpc.RPCServer
+	// fullNode is the v1 api served by the grpc server and the streamed responses
+	fullNode   *v1api.FullNodeStruct
+	grpcServer *grpc.Server
 
-	jaeger     *tracesdk.TracerProvider
+	tracer     *tracesdk.TracerProvider
 	remoteAuth jwtclient.IJwtAuthClient
 }
 
@@ -167,14 +184,27 @@ func (node *Node) Start(ctx context.Context) error {
 		return errors.Wrap(err, "failed to setup metrics")
 	}
 
-	if node.jaeger, err = metricsPKG.SetupJaegerTracing(node.network.Host.ID().String(),
-		node.repo.Config().Observability.Tracing); err != nil {
+	tracingCfg := node.repo.Config().Observability.Tracing
+	if tracingCfg.JaegerTracingEnabled && tracingCfg.OTLPTracingEnabled {
+		return errors.New("jaeger and otlp tracing can not be enabled at the same time")
+	}
+	if node.tracer, err = metricsPKG.SetupJaegerTracing(node.network.Host.ID().String(), tracingCfg); err != nil {
 		return errors.Wrap(err, "failed to setup tracing")
 	}
+	if node.tracer == nil {
+		if node.tracer, err = metricsPKG.SetupOTLPTracing(ctx, node.network.Host.ID().String(), tracingCfg); err != nil {
+			return errors.Wrap(err, "failed to setup otlp tracing")
+		}
+	}
 
 	var syncCtx context.Context
 	syncCtx, node.syncer.CancelChainSync = context.WithCancel(context.Background())
 
+	// probe the drand servers, so the beacon requests go to the healthy ones
+	if beaconCfg := node.repo.Config().Beacon; beaconCfg != nil {
+		node.chain.Drand.StartHealthCheck(syncCtx, time.Duration(beaconCfg.HealthCheckInterval))
+	}
+
 	// start syncer module to receive new blocks and start sync to latest height
 	err = node.syncer.Start(syncCtx)
 	if err != nil {
@@ -192,6 +222,11 @@ func (node *Node) Start(ctx context.Context) error {
 		return err
 	}
 
+	err = node.market.Start(ctx)
+	if err != nil {
+		return err
+	}
+
 	// network should start late,
 	err = node.network.Start(syncCtx)
 	if err != nil {
@@ -202,11 +237,27 @@ func (node *Node) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to start eth module %v", err)
 	}
 
+	// mining needs the other modules running
+	if err := node.mining.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start mining module %v", err)
+	}
+
+	node.maintenance.Start(ctx)
+	node.configReloader.Start(ctx)
+
 	return nil
 }
 
 // Stop initiates the shutdown of the node.
 func (node *Node) Stop(ctx context.Context) {
+	// stop mining submodule
+	log.Infof("shutting down mining...")
+	node.mining.Stop()
+
+	node.configReloader.Stop()
+	// interrupt the maintenance before closing the stores it works on
+	node.maintenance.Stop()
+
 	// stop eth submodule
 	log.Infof("closing eth ...")
 	if err := node.eth.Close(ctx); err != nil {
@@ -217,6 +268,10 @@ func (node *Node) Stop(ctx context.Context) {
 	log.Infof("shutting down mpool...")
 	node.mpool.Stop(ctx)
 
+	// stop wallet submodule
+	log.Infof("shutting down wallet...")
+	node.wallet.Stop(ctx)
+
 	// stop syncer submodule
 	log.Infof("shutting down chain syncer...")
 	node.syncer.Stop(ctx)
@@ -233,6 +288,10 @@ func (node *Node) Stop(ctx context.Context) {
 	log.Infof("shutting down pay channel...")
 	node.paychan.Stop()
 
+	// Stop market submodule
+	log.Infof("shutting down market fund manager...")
+	node.market.Stop()
+
 	log.Infof("closing repository...")
 	if err := node.repo.Close(); err != nil {
 		log.Warnf("error closing repo: %s", err)
@@ -244,9 +303,9 @@ func (node *Node) Stop(ctx context.Context) {
 		_ = logging.Logger(name).Sync()
 	}
 
-	if node.jaeger != nil {
-		if err := metricsPKG.ShutdownJaeger(ctx, node.jaeger); err != nil {
-			log.Warnf("error shutdown jaeger-tracing: %w", err)
+	if node.tracer != nil {
+		if err := metricsPKG.ShutdownTracing(ctx, node.tracer); err != nil {
+			log.Warnf("error shutdown tracing: %w", err)
 		}
 	}
 
@@ -255,6 +314,17 @@ func (node *Node) Stop(ctx context.Context) {
 	if err := node.f3.Stop(ctx); err != nil {
 		log.Warnf("error closing f3: %w", err)
 	}
+
+	if err := node.journal.Close(); err != nil {
+		log.Warnf("error closing journal: %s", err)
+	}
+}
+
+// APIEvt is the journal event of a start or a stop of the api server, on a signal or an error.
+type APIEvt struct {
+	Address string
+	Signal  string `json:",omitempty"`
+	Error   string `json:",omitempty"`
 }
 
 // RunRPCAndWait start rpc server and listen to signal to exit
@@ -294,9 +364,16 @@ func (node *Node) RunRPCAndWait(ctx context.Context, rootCmdDaemon *cmds.Command
 		return fmt.Errorf("set token fail: %w", err)
 	}
 
+	if err := node.runGRPCAPI(ctx, localVerifer); err != nil {
+		return err
+	}
+
 	authMux := jwtclient.NewAuthMux(localVerifer, node.remoteAuth, mux)
 	authMux.TrustHandle("/debug/pprof/", http.DefaultServeMux)
 	authMux.TrustHandle("/healthcheck", healthcheck.Handler())
+	authMux.TrustHandle("/healthz", healthHandler(node.common, false))
+	authMux.TrustHandle("/readyz", healthHandler(node.common, true))
+	authMux.TrustHandle("/rpc/versions", apiVersionsHandler(node.common))
 
 	apiKey, _ := tag.NewKey("api")
 	apiServ := &http.Server{
@@ -308,11 +385,16 @@ func (node *Node) RunRPCAndWait(ctx context.Context, rootCmdDaemon *cmds.Command
 		},
 	}
 
+	evtAPIStart := node.journal.RegisterEventType("api", "start")
+	evtAPIStop := node.journal.RegisterEventType("api", "stop")
 	go func() {
 		apiStatusGauge.Set(ctx, 1)
 		err := apiServ.Serve(netListener) // nolint
 		if err != nil && err != http.ErrServerClosed {
 			apiStatusGauge.Set(ctx, 0)
+			node.journal.RecordEvent(evtAPIStop, func() interface{} {
+				return APIEvt{Address: cfg.API.APIAddress, Error: err.Error()}
+			})
 			return
 		}
 	}()
@@ -323,6 +405,9 @@ func (node *Node) RunRPCAndWait(ctx context.Context, rootCmdDaemon *cmds.Command
 		log.Error("Could not save API address to repo")
 		return err
 	}
+	node.journal.RecordEvent(evtAPIStart, func() interface{} {
+		return APIEvt{Address: cfg.API.APIAddress}
+	})
 
 	terminate := make(chan error, 1)
 
@@ -333,7 +418,13 @@ func (node *Node) RunRPCAndWait(ctx context.Context, rootCmdDaemon *cmds.Command
 		if err := apiServ.Shutdown(ctx); err != nil {
 			log.Warnf("failed to shutdown server: %v", err)
 		}
+		if node.grpcServer != nil {
+			node.grpcServer.GracefulStop()
+		}
 		apiStatusGauge.Set(ctx, 0)
+		node.journal.RecordEvent(evtAPIStop, func() interface{} {
+			return APIEvt{Address: cfg.API.APIAddress, Signal: signal.String()}
+		})
 		node.Stop(ctx)
 		memguard.Purge()
 		log.Infof("venus shutdown gracefully ...")
@@ -364,11 +455,53 @@ func (node *Node) runRestfulAPI(ctx context.Context, handler *http.ServeMux, roo
 }
 
 func (node *Node) runJsonrpcAPI(_ context.Context, handler *http.ServeMux) error { // nolint
-	handler.Handle("/rpc/v0", node.jsonRPCService)
-	handler.Handle("/rpc/v1", node.jsonRPCServiceV1)
+	sel, err := chain.ParseTipSetSelector(node.repo.Config().API.TipSetSelector)
+	if err != nil {
+		return err
+	}
+
+	// the v0 api is served by shims over the v1 api, it may be turned off
+	services := map[string]http.Handler{
+		"v0": node.jsonRPCService,
+		"v1": node.jsonRPCServiceV1,
+	}
+	versions := node.repo.Config().API.RPCVersions
+	if !slices.Contains(versions, "v1") {
+		return fmt.Errorf("the v1 rpc api must be served, got %v", versions)
+	}
+	for _, v := range versions {
+		rpc, ok := services[v]
+		if !ok {
+			return fmt.Errorf("invalid rpc api version %q, expected v0 or v1", v)
+		}
+		if node.repo.Config().Observability.Metrics.APIMetricsEnabled {
+			rpc = rpcPayloadMetrics(rpc, jsonrpc.DEFAULT_MAX_REQUEST_SIZE)
+		}
+		if v == "v1" {
+			rpc = streamHandler(&nodeStreamedAPI{FullNodeStruct: node.fullNode, chain: node.chain}, rpc)
+		}
+		rpc = node.eth.WebsocketLimits(rpc)
+		handler.Handle("/rpc/"+v, tipSetSelectorHandler(sel, rpc))
+	}
 	return nil
 }
 
+// tipSetSelectorHandler makes the empty tipset keys of the requests refer to the tipset selected by their
+// X-Tipset-Selector header, or by def.
+func tipSetSelectorHandler(def chain.TipSetSelector, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sel := def
+		if h := r.Header.Get(chain.TipSetSelectorHeader); h != "" {
+			var err error
+			if sel, err = chain.ParseTipSetSelector(h); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		next.ServeHTTP(w, r.WithContext(chain.WithTipSetSelector(r.Context(), sel)))
+	})
+}
+
 // createServerEnv create server for cmd server env
 func (node *Node) createServerEnv(ctx context.Context) *Env {
 	env := Env{
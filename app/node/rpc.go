@@ -3,6 +3,7 @@ package node
 import (
 	"errors"
 	"reflect"
+	"time"
 
 	"github.com/filecoin-project/go-jsonrpc"
 	"github.com/filecoin-project/venus/app/submodule/actorevent"
@@ -20,6 +21,9 @@ type RPCBuilder struct {
 	namespace   []string
 	v0APIStruct []interface{}
 	v1APIStruct []interface{}
+
+	apiMetrics bool
+	slowCall   time.Duration
 }
 
 func NewBuilder() *RPCBuilder {
@@ -31,6 +35,13 @@ func (builder *RPCBuilder) NameSpace(nameSpaece string) *RPCBuilder {
 	return builder
 }
 
+// EnableMetrics records per-method metrics for the built servers and logs calls slower than slowCall.
+func (builder *RPCBuilder) EnableMetrics(slowCall time.Duration) *RPCBuilder {
+	builder.apiMetrics = true
+	builder.slowCall = slowCall
+	return builder
+}
+
 func (builder *RPCBuilder) AddServices(services ...RPCService) error {
 	for _, service := range services {
 		err := builder.AddService(service)
@@ -149,6 +160,12 @@ func (builder *RPCBuilder) Build(version string, limiter *ratelimit.RateLimiter)
 			fullNodeV0 = rateLimitAPI
 		}
 
+		if builder.apiMetrics {
+			var metricsAPI v0api.FullNodeStruct
+			MetricsProxy(&fullNodeV0, &metricsAPI, builder.slowCall)
+			fullNodeV0 = metricsAPI
+		}
+
 		for _, nameSpace := range builder.namespace {
 			server.Register(nameSpace, &fullNodeV0)
 		}
@@ -156,19 +173,9 @@ func (builder *RPCBuilder) Build(version string, limiter *ratelimit.RateLimiter)
 		serverOptions = append(serverOptions, jsonrpc.WithReverseClient[v1api.EthSubscriberMethods](v1api.MethodNamespace))
 		server = jsonrpc.NewServer(serverOptions...)
 
-		var fullNode v1api.FullNodeStruct
-		for _, apiStruct := range builder.v1APIStruct {
-			permission.PermissionProxy(apiStruct, &fullNode)
-		}
-
-		if limiter != nil {
-			var rateLimitAPI v1api.FullNodeStruct
-			limiter.WraperLimiter(fullNode, &rateLimitAPI)
-			fullNode = rateLimitAPI
-		}
-
+		fullNode := builder.FullNode(limiter)
 		for _, nameSpace := range builder.namespace {
-			server.Register(nameSpace, &fullNode)
+			server.Register(nameSpace, fullNode)
 		}
 	default:
 		panic("invalid version: " + version)
@@ -178,6 +185,28 @@ func (builder *RPCBuilder) Build(version string, limiter *ratelimit.RateLimiter)
 	return server
 }
 
+// FullNode returns the v1 api checking the permissions of the calls, served by the json rpc and the grpc servers.
+func (builder *RPCBuilder) FullNode(limiter *ratelimit.RateLimiter) *v1api.FullNodeStruct {
+	var fullNode v1api.FullNodeStruct
+	for _, apiStruct := range builder.v1APIStruct {
+		permission.PermissionProxy(apiStruct, &fullNode)
+	}
+
+	if limiter != nil {
+		var rateLimitAPI v1api.FullNodeStruct
+		limiter.WraperLimiter(fullNode, &rateLimitAPI)
+		fullNode = rateLimitAPI
+	}
+
+	if builder.apiMetrics {
+		var metricsAPI v1api.FullNodeStruct
+		MetricsProxy(&fullNode, &metricsAPI, builder.slowCall)
+		fullNode = metricsAPI
+	}
+
+	return &fullNode
+}
+
 func aliasETHAPI(rpcServer *jsonrpc.RPCServer) {
 	// TODO: use reflect to automatically register all the eth aliases
 	rpcServer.AliasMethod("eth_accounts", "Filecoin.EthAccounts")
@@ -198,6 +227,7 @@ func aliasETHAPI(rpcServer *jsonrpc.RPCServer) {
 
 	rpcServer.AliasMethod("eth_getCode", "Filecoin.EthGetCode")
 	rpcServer.AliasMethod("eth_getStorageAt", "Filecoin.EthGetStorageAt")
+	rpcServer.AliasMethod("eth_getProof", "Filecoin.EthGetProof")
 	rpcServer.AliasMethod("eth_getBalance", "Filecoin.EthGetBalance")
 	rpcServer.AliasMethod("eth_chainId", "Filecoin.EthChainId")
 	rpcServer.AliasMethod("eth_syncing", "Filecoin.EthSyncing")
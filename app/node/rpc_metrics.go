@@ -0,0 +1,162 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/ipfs-force-community/metrics"
+	"github.com/ipfs-force-community/sophon-auth/core"
+	"go.opencensus.io/tag"
+
+	"github.com/filecoin-project/venus/venus-shared/api"
+)
+
+var (
+	tagKeyAPIMethod = tag.MustNewKey("method")
+
+	// [>=0B, >=256B, >=1KiB, >=4KiB, >=16KiB, >=64KiB, >=256KiB, >=1MiB, >=4MiB, >=16MiB]
+	payloadBounds = []float64{256, 1 << 10, 4 << 10, 16 << 10, 64 << 10, 256 << 10, 1 << 20, 4 << 20, 16 << 20}
+
+	apiCallDuration  = metrics.NewTimerMs("api/call_duration", "Duration of json-rpc calls in milliseconds", tagKeyAPIMethod)
+	apiCallErrors    = metrics.NewCounter("api/call_error", "Number of json-rpc calls that returned an error", tagKeyAPIMethod)
+	apiRequestBytes  = metrics.NewInt64WithBuckets("api/request_bytes", "Size of json-rpc http request bodies", "By", payloadBounds, tagKeyAPIMethod)
+	apiResponseBytes = metrics.NewInt64WithBuckets("api/response_bytes", "Size of json-rpc http response bodies", "By", payloadBounds, tagKeyAPIMethod)
+)
+
+// methodSniffLen is how much of a request body is kept to find the called method, clients send
+// the method ahead of the params in practice.
+const methodSniffLen = 4 << 10
+
+// MetricsProxy wraps every method of in, records its latency and errors tagged by the method name,
+// and logs calls slower than slowCall together with the name of the token that issued them.
+// A zero slowCall disables the slow call log.
+func MetricsProxy(in interface{}, out interface{}, slowCall time.Duration) {
+	ra := reflect.ValueOf(in)
+	outs := api.GetInternalStructs(out)
+	for _, out := range outs {
+		rint := reflect.ValueOf(out).Elem()
+		for i := 0; i < ra.NumMethod(); i++ {
+			methodName := ra.Type().Method(i).Name
+			field, exists := rint.Type().FieldByName(methodName)
+			if !exists {
+				continue
+			}
+
+			fn := ra.Method(i)
+			rint.FieldByName(methodName).Set(reflect.MakeFunc(field.Type, func(args []reflect.Value) []reflect.Value {
+				ctx, _ := tag.New(args[0].Interface().(context.Context), tag.Upsert(tagKeyAPIMethod, methodName))
+				stop := apiCallDuration.Start()
+
+				results := fn.Call(args)
+
+				elapsed := stop(ctx)
+				if len(results) > 0 {
+					if err, _ := results[len(results)-1].Interface().(error); err != nil {
+						apiCallErrors.Tick(ctx)
+					}
+				}
+				if slowCall > 0 && elapsed >= slowCall {
+					token, _ := core.CtxGetName(ctx)
+					log.Warnw("slow api call", "method", methodName, "took", elapsed, "token", token)
+				}
+
+				return results
+			}))
+		}
+	}
+}
+
+// rpcMethodName returns the metrics tag for the start of a json-rpc request body, batched requests
+// are tagged as "batch". The body may be truncated, the method is found as long as it comes first.
+func rpcMethodName(head []byte) string {
+	head = bytes.TrimSpace(head)
+	if len(head) > 0 && head[0] == '[' {
+		return "batch"
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(head))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return "unknown"
+	}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			break
+		}
+		if key == "method" {
+			if method, ok := tokenString(dec); ok && method != "" {
+				return strings.TrimPrefix(method, "Filecoin.")
+			}
+			break
+		}
+		var skip json.RawMessage
+		if err := dec.Decode(&skip); err != nil {
+			break
+		}
+	}
+	return "unknown"
+}
+
+func tokenString(dec *json.Decoder) (string, bool) {
+	tok, err := dec.Token()
+	if err != nil {
+		return "", false
+	}
+	s, ok := tok.(string)
+	return s, ok
+}
+
+// countingReader counts the bytes read from a request body and keeps its start to tag the method.
+type countingReader struct {
+	io.ReadCloser
+	read int64
+	head []byte
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if room := methodSniffLen - len(r.head); room > 0 {
+		r.head = append(r.head, p[:min(n, room)]...)
+	}
+	r.read += int64(n)
+	return n, err
+}
+
+type countingResponseWriter struct {
+	http.ResponseWriter
+	written int64
+}
+
+func (w *countingResponseWriter) Write(p []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(p)
+	w.written += int64(n)
+	return n, err
+}
+
+// rpcPayloadMetrics records the request and response body sizes of http json-rpc calls,
+// websocket connections are passed through untouched. The bodies are counted while the rpc
+// server reads them, and bodies over maxRequestSize are cut off before they reach it.
+func rpcPayloadMetrics(next http.Handler, maxRequestSize int64) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Upgrade") != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cr := &countingReader{ReadCloser: http.MaxBytesReader(w, r.Body, maxRequestSize)}
+		r.Body = cr
+
+		cw := &countingResponseWriter{ResponseWriter: w}
+		next.ServeHTTP(cw, r)
+
+		ctx, _ := tag.New(r.Context(), tag.Upsert(tagKeyAPIMethod, rpcMethodName(cr.head)))
+		apiRequestBytes.Set(ctx, cr.read)
+		apiResponseBytes.Set(ctx, cw.written)
+	})
+}
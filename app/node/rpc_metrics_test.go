@@ -0,0 +1,88 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+type failingAPI struct{}
+
+func (m *failingAPI) Test1(ctx context.Context) (string, error) {
+	return "", errors.New("failed")
+}
+
+func TestMetricsProxy(t *testing.T) {
+	tf.UnitTest(t)
+
+	var ok, failed Adapter1
+	MetricsProxy(&mockAPI1{}, &ok, time.Nanosecond)
+	MetricsProxy(&failingAPI{}, &failed, 0)
+
+	res, err := ok.Test1(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "test", res)
+
+	_, err = failed.Test1(context.Background())
+	require.EqualError(t, err, "failed")
+
+	rows, err := view.RetrieveData("api/call_error")
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "Test1", rows[0].Tags[0].Value)
+	assert.Equal(t, int64(1), rows[0].Data.(*view.CountData).Value)
+
+	rows, err = view.RetrieveData("api/call_duration")
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, int64(2), rows[0].Data.(*view.DistributionData).Count)
+}
+
+func TestRPCMethodName(t *testing.T) {
+	tf.UnitTest(t)
+
+	assert.Equal(t, "ChainHead", rpcMethodName([]byte(`{"jsonrpc":"2.0","method":"Filecoin.ChainHead","id":1}`)))
+	assert.Equal(t, "eth_call", rpcMethodName([]byte(`{"jsonrpc":"2.0","method":"eth_call","id":1}`)))
+	assert.Equal(t, "batch", rpcMethodName([]byte(` [{"method":"Filecoin.ChainHead"}]`)))
+	assert.Equal(t, "unknown", rpcMethodName([]byte(`not json`)))
+	// only the start of large bodies is kept
+	assert.Equal(t, "ChainHead", rpcMethodName([]byte(`{"jsonrpc":"2.0","method":"Filecoin.ChainHead","params":["bafy`)))
+	assert.Equal(t, "unknown", rpcMethodName([]byte(`{"jsonrpc":"2.0","params":["bafy`)))
+}
+
+func TestRPCPayloadMetrics(t *testing.T) {
+	tf.UnitTest(t)
+
+	var readErr error
+	handler := rpcPayloadMetrics(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, readErr = io.ReadAll(r.Body)
+		_, _ = w.Write([]byte("ok"))
+	}), 64)
+
+	body := `{"jsonrpc":"2.0","method":"Filecoin.ChainHead","id":1}`
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/rpc/v1", strings.NewReader(body)))
+	require.NoError(t, readErr)
+
+	rows, err := view.RetrieveData("api/request_bytes")
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "ChainHead", rows[0].Tags[0].Value)
+	assert.Equal(t, float64(len(body)), rows[0].Data.(*view.DistributionData).Max)
+
+	// the bodies over the limit are cut off
+	big := `{"jsonrpc":"2.0","method":"Filecoin.MpoolPush","params":["` + strings.Repeat("a", 128) + `"]}`
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/rpc/v1", strings.NewReader(big)))
+	var maxErr *http.MaxBytesError
+	require.ErrorAs(t, readErr, &maxErr)
+}
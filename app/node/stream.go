@@ -0,0 +1,248 @@
+package node
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-jsonrpc"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs-force-community/sophon-auth/core"
+
+	chain2 "github.com/filecoin-project/venus/app/submodule/chain"
+	"github.com/filecoin-project/venus/venus-shared/api"
+	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// maxStreamRequestSize bounds the json rpc requests read by the stream handler, the requests of the streamed
+// methods only carry a few params.
+const maxStreamRequestSize = 1 << 20
+
+// streamedAPI serves the streamed methods, the walks resolve the state first and return a func visiting
+// its entries one at a time, so the entries are sent as they are reached.
+type streamedAPI interface {
+	MarketDealsWalk(ctx context.Context, tsk types.TipSetKey) (func(cb func(abi.DealID, *types.MarketDeal) error) error, error)
+	ActorsWalk(ctx context.Context, tsk types.TipSetKey) (func(cb func(address.Address) error) error, error)
+	ChainExport(ctx context.Context, nroots abi.ChainEpoch, skipoldmsgs bool, tsk types.TipSetKey) (<-chan []byte, error)
+	ChainExportRange(ctx context.Context, from, to abi.ChainEpoch, tsk types.TipSetKey) (<-chan []byte, error)
+}
+
+// streamFunc calls a streamed method with params, the returned func sends the elements of its result.
+type streamFunc func(ctx context.Context, a streamedAPI, params []json.RawMessage) (func(enc *api.StreamEncoder) error, error)
+
+var streamedMethods = map[string]streamFunc{
+	"StateMarketDeals": func(ctx context.Context, a streamedAPI, params []json.RawMessage) (func(enc *api.StreamEncoder) error, error) {
+		var tsk types.TipSetKey
+		if err := decodeStreamParams(params, &tsk); err != nil {
+			return nil, err
+		}
+		walk, err := a.MarketDealsWalk(ctx, tsk)
+		if err != nil {
+			return nil, err
+		}
+		return func(enc *api.StreamEncoder) error {
+			return walk(func(id abi.DealID, deal *types.MarketDeal) error {
+				return enc.Encode(&types.MarketDealEntry{DealID: id, Deal: *deal})
+			})
+		}, nil
+	},
+	"StateListActors": func(ctx context.Context, a streamedAPI, params []json.RawMessage) (func(enc *api.StreamEncoder) error, error) {
+		var tsk types.TipSetKey
+		if err := decodeStreamParams(params, &tsk); err != nil {
+			return nil, err
+		}
+		walk, err := a.ActorsWalk(ctx, tsk)
+		if err != nil {
+			return nil, err
+		}
+		return func(enc *api.StreamEncoder) error {
+			return walk(func(addr address.Address) error {
+				return enc.Encode(&addr)
+			})
+		}, nil
+	},
+	"ChainExport": func(ctx context.Context, a streamedAPI, params []json.RawMessage) (func(enc *api.StreamEncoder) error, error) {
+		var (
+			nroots      abi.ChainEpoch
+			skipoldmsgs bool
+			tsk         types.TipSetKey
+		)
+		if err := decodeStreamParams(params, &nroots, &skipoldmsgs, &tsk); err != nil {
+			return nil, err
+		}
+		ch, err := a.ChainExport(ctx, nroots, skipoldmsgs, tsk)
+		if err != nil {
+			return nil, err
+		}
+		return sendChunks(ch), nil
+	},
+	"ChainExportRange": func(ctx context.Context, a streamedAPI, params []json.RawMessage) (func(enc *api.StreamEncoder) error, error) {
+		var (
+			from, to abi.ChainEpoch
+			tsk      types.TipSetKey
+		)
+		if err := decodeStreamParams(params, &from, &to, &tsk); err != nil {
+			return nil, err
+		}
+		ch, err := a.ChainExportRange(ctx, from, to, tsk)
+		if err != nil {
+			return nil, err
+		}
+		return sendChunks(ch), nil
+	},
+}
+
+// nodeStreamedAPI walks the state of the chain submodule, the walks are not served through the api structs
+// so their read permission is checked here, the exports go through the permission checked full node api.
+type nodeStreamedAPI struct {
+	*v1api.FullNodeStruct
+	chain *chain2.ChainSubmodule
+}
+
+func (a *nodeStreamedAPI) MarketDealsWalk(ctx context.Context, tsk types.TipSetKey) (func(cb func(abi.DealID, *types.MarketDeal) error) error, error) {
+	if err := checkStreamPerm(ctx, "StateMarketDeals"); err != nil {
+		return nil, err
+	}
+	return a.chain.MarketDealsWalk(ctx, tsk)
+}
+
+func (a *nodeStreamedAPI) ActorsWalk(ctx context.Context, tsk types.TipSetKey) (func(cb func(address.Address) error) error, error) {
+	if err := checkStreamPerm(ctx, "StateListActors"); err != nil {
+		return nil, err
+	}
+	return a.chain.ActorsWalk(ctx, tsk)
+}
+
+// checkStreamPerm checks the read permission of the streamed methods as the api permission proxy does.
+func checkStreamPerm(ctx context.Context, method string) error {
+	if !core.HasPerm(ctx, []core.Permission{core.PermRead}, core.PermRead) {
+		return fmt.Errorf("missing permission to invoke '%s'  (need '%s')", method, core.PermRead)
+	}
+	return nil
+}
+
+func sendChunks(ch <-chan []byte) func(enc *api.StreamEncoder) error {
+	return func(enc *api.StreamEncoder) error {
+		for chunk := range ch {
+			if err := enc.Encode(chunk); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+var errInvalidStreamParams = errors.New("invalid params")
+
+func decodeStreamParams(params []json.RawMessage, dst ...interface{}) error {
+	if len(params) != len(dst) {
+		return fmt.Errorf("%w: expected %d params, got %d", errInvalidStreamParams, len(dst), len(params))
+	}
+	for i, p := range params {
+		if err := json.Unmarshal(p, dst[i]); err != nil {
+			return fmt.Errorf("%w: param %d: %v", errInvalidStreamParams, i, err)
+		}
+	}
+	return nil
+}
+
+// streamContentType returns the stream content type accepted by r, empty when it only accepts json.
+func streamContentType(r *http.Request) string {
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		mt, _, err := mime.ParseMediaType(strings.TrimSpace(accept))
+		if err != nil {
+			continue
+		}
+		if mt == api.ContentTypeNDJSON || mt == api.ContentTypeCBOR {
+			return mt
+		}
+	}
+	return ""
+}
+
+type streamRequest struct {
+	ID     interface{}       `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+// streamHandler streams the results of the heavy methods called by the json rpc requests accepting ndjson or cbor,
+// so they are not buffered as a single json response, the other requests are served by next.
+func streamHandler(a streamedAPI, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType := streamContentType(r)
+		if r.Method != http.MethodPost || contentType == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxStreamRequestSize))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		var req streamRequest
+		var call streamFunc
+		if json.Unmarshal(body, &req) == nil {
+			call = streamedMethods[strings.TrimPrefix(req.Method, v1api.MethodNamespace+".")]
+		}
+		if call == nil {
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		send, err := call(r.Context(), a, req.Params)
+		if err != nil {
+			writeStreamError(w, req.ID, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		bw := bufio.NewWriterSize(w, 1<<16)
+		enc, err := api.NewStreamEncoder(contentType, bw)
+		if err == nil {
+			err = send(enc)
+		}
+		if err == nil {
+			err = bw.Flush()
+		}
+		if err != nil {
+			// the response is cut short, the clients see a truncated stream
+			log.Warnf("failed to stream the result of %s: %v", req.Method, err)
+		}
+	})
+}
+
+// writeStreamError writes the error of a streamed method as a json rpc error response.
+func writeStreamError(w http.ResponseWriter, id interface{}, err error) {
+	code := 1
+	var errCode jsonrpc.ErrorCode
+	if errors.Is(err, errInvalidStreamParams) {
+		code = -32602
+	} else if errors.As(err, &errCode) {
+		code = int(errCode)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	resp := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      id,
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": err.Error(),
+		},
+	}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Warnf("failed to write stream error response: %v", err)
+	}
+}
@@ -0,0 +1,148 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/api"
+	"github.com/filecoin-project/venus/venus-shared/testutil"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+type stubStreamedAPI struct {
+	dealIDs []abi.DealID
+	deals   []types.MarketDeal
+	addrs   []address.Address
+}
+
+func (s *stubStreamedAPI) MarketDealsWalk(ctx context.Context, tsk types.TipSetKey) (func(cb func(abi.DealID, *types.MarketDeal) error) error, error) {
+	return func(cb func(abi.DealID, *types.MarketDeal) error) error {
+		for i := range s.deals {
+			if err := cb(s.dealIDs[i], &s.deals[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, nil
+}
+
+func (s *stubStreamedAPI) ActorsWalk(ctx context.Context, tsk types.TipSetKey) (func(cb func(address.Address) error) error, error) {
+	if !tsk.IsEmpty() {
+		return nil, errors.New("tipset not found")
+	}
+	return func(cb func(address.Address) error) error {
+		for _, addr := range s.addrs {
+			if err := cb(addr); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, nil
+}
+
+func (s *stubStreamedAPI) ChainExport(ctx context.Context, nroots abi.ChainEpoch, skipoldmsgs bool, tsk types.TipSetKey) (<-chan []byte, error) {
+	ch := make(chan []byte, 2)
+	ch <- []byte("car")
+	ch <- []byte{}
+	close(ch)
+	return ch, nil
+}
+
+func (s *stubStreamedAPI) ChainExportRange(ctx context.Context, from, to abi.ChainEpoch, tsk types.TipSetKey) (<-chan []byte, error) {
+	return s.ChainExport(ctx, 0, false, tsk)
+}
+
+func TestStreamHandler(t *testing.T) {
+	tf.UnitTest(t)
+
+	var deals [2]types.MarketDeal
+	testutil.Provide(t, &deals)
+	a := &stubStreamedAPI{
+		dealIDs: []abi.DealID{2, 10},
+		deals:   deals[:],
+		addrs:   []address.Address{address.TestAddress, address.TestAddress2},
+	}
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	})
+	h := streamHandler(a, next)
+
+	serve := func(accept, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/rpc/v1", strings.NewReader(body))
+		req.Header.Set("Accept", accept)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for _, ct := range []string{api.ContentTypeNDJSON, api.ContentTypeCBOR} {
+		rec := serve(ct, `{"jsonrpc":"2.0","id":1,"method":"Filecoin.StateMarketDeals","params":[null]}`)
+		require.Equal(t, ct, rec.Header().Get("Content-Type"))
+		dec, err := api.NewStreamDecoder(ct, rec.Body)
+		require.NoError(t, err)
+		for i, id := range []abi.DealID{2, 10} {
+			var entry types.MarketDealEntry
+			require.NoError(t, dec.Decode(&entry), ct)
+			require.Equal(t, id, entry.DealID, ct)
+			require.Equal(t, deals[i].State, entry.Deal.State, ct)
+			require.Equal(t, deals[i].Proposal.PieceCID, entry.Deal.Proposal.PieceCID, ct)
+		}
+		require.Equal(t, io.EOF, dec.Decode(&types.MarketDealEntry{}), ct)
+
+		rec = serve("application/json, "+ct, `{"jsonrpc":"2.0","id":1,"method":"Filecoin.StateListActors","params":[null]}`)
+		dec, err = api.NewStreamDecoder(ct, rec.Body)
+		require.NoError(t, err)
+		for _, expected := range a.addrs {
+			var addr address.Address
+			require.NoError(t, dec.Decode(&addr), ct)
+			require.Equal(t, expected, addr, ct)
+		}
+
+		rec = serve(ct, `{"jsonrpc":"2.0","id":1,"method":"Filecoin.ChainExport","params":[0,false,null]}`)
+		dec, err = api.NewStreamDecoder(ct, rec.Body)
+		require.NoError(t, err)
+		var chunk []byte
+		require.NoError(t, dec.Decode(&chunk), ct)
+		require.Equal(t, "car", string(chunk), ct)
+		require.NoError(t, dec.Decode(&chunk), ct)
+		require.Empty(t, chunk, ct)
+	}
+
+	// the errors of the calls are json rpc errors
+	rec := serve(api.ContentTypeNDJSON, `{"jsonrpc":"2.0","id":7,"method":"Filecoin.StateListActors","params":[]}`)
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var resp struct {
+		ID    int
+		Error struct {
+			Code int
+		}
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, 7, resp.ID)
+	require.Equal(t, -32602, resp.Error.Code)
+
+	// the state is resolved before streaming, so its errors are json rpc errors too
+	rec = serve(api.ContentTypeNDJSON, `{"jsonrpc":"2.0","id":8,"method":"Filecoin.StateListActors","params":[[{"/":"bafy2bzacecu7n7wbtogznrtuuvf73dsz7wasgyneqasksdblxupnyovmtwxxu"}]]}`)
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, 8, resp.ID)
+	require.Equal(t, 1, resp.Error.Code)
+
+	// the other requests are served by next
+	body := `{"jsonrpc":"2.0","id":1,"method":"Filecoin.ChainHead","params":[]}`
+	require.Equal(t, body, serve(api.ContentTypeCBOR, body).Body.String())
+	body = `{"jsonrpc":"2.0","id":1,"method":"Filecoin.StateListActors","params":[null]}`
+	require.Equal(t, body, serve("application/json", body).Body.String())
+}
@@ -3,6 +3,7 @@ package actorevent
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/ipfs/go-cid"
@@ -40,7 +41,7 @@ type ActorEventHandler struct { // nolint
 	chain                ChainAccessor
 	eventFilterManager   EventFilterManager
 	blockDelay           time.Duration
-	maxFilterHeightRange abi.ChainEpoch
+	maxFilterHeightRange atomic.Int64
 	clock                clock.Clock
 }
 
@@ -52,13 +53,14 @@ func NewActorEventHandler(
 	blockDelay time.Duration,
 	maxFilterHeightRange abi.ChainEpoch,
 ) *ActorEventHandler {
-	return &ActorEventHandler{
-		chain:                chain,
-		eventFilterManager:   eventFilterManager,
-		blockDelay:           blockDelay,
-		maxFilterHeightRange: maxFilterHeightRange,
-		clock:                clock.New(),
+	a := &ActorEventHandler{
+		chain:              chain,
+		eventFilterManager: eventFilterManager,
+		blockDelay:         blockDelay,
+		clock:              clock.New(),
 	}
+	a.maxFilterHeightRange.Store(int64(maxFilterHeightRange))
+	return a
 }
 
 func NewActorEventHandlerWithClock(
@@ -68,13 +70,19 @@ func NewActorEventHandlerWithClock(
 	maxFilterHeightRange abi.ChainEpoch,
 	clock clock.Clock,
 ) *ActorEventHandler {
-	return &ActorEventHandler{
-		chain:                chain,
-		eventFilterManager:   eventFilterManager,
-		blockDelay:           blockDelay,
-		maxFilterHeightRange: maxFilterHeightRange,
-		clock:                clock,
+	a := &ActorEventHandler{
+		chain:              chain,
+		eventFilterManager: eventFilterManager,
+		blockDelay:         blockDelay,
+		clock:              clock,
 	}
+	a.maxFilterHeightRange.Store(int64(maxFilterHeightRange))
+	return a
+}
+
+// SetMaxFilterHeightRange changes the maximum range of heights of the filters, on a config reload.
+func (a *ActorEventHandler) SetMaxFilterHeightRange(maxFilterHeightRange abi.ChainEpoch) {
+	a.maxFilterHeightRange.Store(int64(maxFilterHeightRange))
 }
 
 func (a *ActorEventHandler) GetActorEventsRaw(ctx context.Context, evtFilter *types.ActorEventFilter) ([]*types.ActorEvent, error) {
@@ -134,7 +142,7 @@ func (a *ActorEventHandler) parseFilter(f types.ActorEventFilter) (*filterParams
 		}, nil
 	}
 
-	min, max, err := parseHeightRange(a.chain.GetHead().Height(), f.FromHeight, f.ToHeight, a.maxFilterHeightRange)
+	min, max, err := parseHeightRange(a.chain.GetHead().Height(), f.FromHeight, f.ToHeight, abi.ChainEpoch(a.maxFilterHeightRange.Load()))
 	if err != nil {
 		return nil, err
 	}
@@ -176,13 +184,13 @@ func parseHeightRange(heaviest abi.ChainEpoch, fromHeight, toHeight *abi.ChainEp
 	} else if minHeight >= 0 && maxHeight == -1 {
 		// Here the client is looking for events between some time in the past and the current head
 		if heaviest-minHeight > maxRange {
-			return 0, 0, fmt.Errorf("invalid epoch range: 'from' height is too far in the past (maximum: %d)", maxRange)
+			return 0, 0, types.NewLookbackBeyondLimitError("invalid epoch range: 'from' height is too far in the past (maximum: %d)", maxRange)
 		}
 	} else if minHeight >= 0 && maxHeight >= 0 {
 		if minHeight > maxHeight {
 			return 0, 0, fmt.Errorf("invalid epoch range: 'to' height (%d) must be after 'from' height (%d)", minHeight, maxHeight)
 		} else if maxHeight-minHeight > maxRange {
-			return 0, 0, fmt.Errorf("invalid epoch range: range between to and 'from' heights is too large (maximum: %d)", maxRange)
+			return 0, 0, types.NewLookbackBeyondLimitError("invalid epoch range: range between to and 'from' heights is too large (maximum: %d)", maxRange)
 		}
 	}
 	return minHeight, maxHeight, nil
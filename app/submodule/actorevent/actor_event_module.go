@@ -54,6 +54,14 @@ func NewActorEventSubModule(ctx context.Context,
 	return aem, nil
 }
 
+// Reload applies the maximum range of heights of the filters of cfg.
+func (aem *ActorEventSubModule) Reload(cfg *config.Config) error {
+	if h, ok := aem.actorEventHandler.(*ActorEventHandler); ok {
+		h.SetMaxFilterHeightRange(abi.ChainEpoch(cfg.FevmConfig.Event.MaxFilterHeightRange))
+	}
+	return nil
+}
+
 func (aem *ActorEventSubModule) API() v1api.IActorEvent {
 	return aem.actorEventHandler
 }
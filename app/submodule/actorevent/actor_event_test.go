@@ -586,6 +586,10 @@ func (m *mockFilter) LastTaken() time.Time {
 	return m.lastTaken
 }
 
+func (m *mockFilter) Usage() filter.Usage {
+	return filter.Usage{}
+}
+
 func (m *mockFilter) SetSubChannel(ch chan<- interface{}) {
 	m.t.Helper()
 	m.lk.Lock()
@@ -0,0 +1,161 @@
+package actorevent
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/market"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// the codec of the values of the builtin actor events, the multicodec of raw cbor
+const cborCodec = 0x51
+
+// GetDealEvents returns the deal events of the builtin market actor matching the filter.
+func (a *ActorEventHandler) GetDealEvents(ctx context.Context, evtFilter *types.DealEventFilter) ([]*types.DealEvent, error) {
+	raw, err := dealActorEventFilter(evtFilter)
+	if err != nil {
+		return nil, err
+	}
+	evs, err := a.GetActorEventsRaw(ctx, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*types.DealEvent, 0, len(evs))
+	for _, ev := range evs {
+		dev, err := decodeDealEvent(ev)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, dev)
+	}
+	return out, nil
+}
+
+// SubscribeDealEvents streams the deal events of the builtin market actor matching the filter, like
+// SubscribeActorEventsRaw.
+func (a *ActorEventHandler) SubscribeDealEvents(ctx context.Context, evtFilter *types.DealEventFilter) (<-chan *types.DealEvent, error) {
+	raw, err := dealActorEventFilter(evtFilter)
+	if err != nil {
+		return nil, err
+	}
+	evs, err := a.SubscribeActorEventsRaw(ctx, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan *types.DealEvent, 16)
+	go func() {
+		defer close(out)
+		for ev := range evs {
+			dev, err := decodeDealEvent(ev)
+			if err != nil {
+				log.Warnf("decoding deal event of message %s: %v", ev.MsgCid, err)
+				continue
+			}
+			select {
+			case out <- dev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+func dealActorEventFilter(f *types.DealEventFilter) (*types.ActorEventFilter, error) {
+	if f == nil {
+		f = &types.DealEventFilter{}
+	}
+
+	kinds := f.Kinds
+	if len(kinds) == 0 {
+		kinds = []string{types.DealPublished, types.DealActivated, types.DealTerminated, types.DealCompleted}
+	}
+	fields := map[string][]types.ActorEventBlock{}
+	for _, kind := range kinds {
+		switch kind {
+		case types.DealPublished, types.DealActivated, types.DealTerminated, types.DealCompleted:
+		default:
+			return nil, fmt.Errorf("unknown deal event kind %q", kind)
+		}
+		fields["$type"] = append(fields["$type"], types.ActorEventBlock{
+			Codec: cborCodec,
+			Value: append(cbg.CborEncodeMajorType(cbg.MajTextString, uint64(len(kind))), kind...),
+		})
+	}
+	for key, addr := range map[string]*address.Address{"provider": f.Provider, "client": f.Client} {
+		if addr == nil {
+			continue
+		}
+		id, err := address.IDFromAddress(*addr)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be an ID address: %w", key, err)
+		}
+		fields[key] = []types.ActorEventBlock{{Codec: cborCodec, Value: cbg.CborEncodeMajorType(cbg.MajUnsignedInt, id)}}
+	}
+
+	return &types.ActorEventFilter{
+		Addresses:  []address.Address{market.Address},
+		Fields:     fields,
+		FromHeight: f.FromHeight,
+		ToHeight:   f.ToHeight,
+	}, nil
+}
+
+func decodeDealEvent(ev *types.ActorEvent) (*types.DealEvent, error) {
+	out := &types.DealEvent{
+		Reverted:  ev.Reverted,
+		Height:    ev.Height,
+		TipSetKey: ev.TipSetKey,
+		MsgCid:    ev.MsgCid,
+	}
+	for _, entry := range ev.Entries {
+		if entry.Codec != cborCodec {
+			continue
+		}
+		cr := cbg.NewCborReader(bytes.NewReader(entry.Value))
+		maj, extra, err := cr.ReadHeader()
+		if err != nil {
+			return nil, fmt.Errorf("decoding %s: %w", entry.Key, err)
+		}
+
+		switch entry.Key {
+		case "$type":
+			if maj != cbg.MajTextString || extra > uint64(len(entry.Value)) {
+				return nil, fmt.Errorf("event type must be a string")
+			}
+			kind := make([]byte, extra)
+			if _, err := io.ReadFull(cr, kind); err != nil {
+				return nil, fmt.Errorf("decoding event type: %w", err)
+			}
+			out.Kind = string(kind)
+		case "id", "client", "provider":
+			if maj != cbg.MajUnsignedInt {
+				return nil, fmt.Errorf("%s must be an unsigned integer", entry.Key)
+			}
+			switch entry.Key {
+			case "id":
+				out.DealID = abi.DealID(extra)
+			case "client":
+				out.Client, err = address.NewIDAddress(extra)
+			case "provider":
+				out.Provider, err = address.NewIDAddress(extra)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	if out.Kind == "" {
+		return nil, fmt.Errorf("event without type")
+	}
+	return out, nil
+}
@@ -0,0 +1,67 @@
+package actorevent
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/market"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestDealEvents(t *testing.T) {
+	provider, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+
+	t.Run("filter", func(t *testing.T) {
+		f, err := dealActorEventFilter(&types.DealEventFilter{
+			Kinds:    []string{types.DealActivated},
+			Provider: &provider,
+		})
+		require.NoError(t, err)
+		require.Equal(t, []address.Address{market.Address}, f.Addresses)
+		require.Equal(t, []types.ActorEventBlock{{Codec: cborCodec, Value: append([]byte{0x6e}, types.DealActivated...)}}, f.Fields["$type"])
+		require.Equal(t, []types.ActorEventBlock{{Codec: cborCodec, Value: []byte{0x19, 0x03, 0xe8}}}, f.Fields["provider"])
+
+		all, err := dealActorEventFilter(nil)
+		require.NoError(t, err)
+		require.Len(t, all.Fields["$type"], 4)
+
+		_, err = dealActorEventFilter(&types.DealEventFilter{Kinds: []string{"deal-unknown"}})
+		require.Error(t, err)
+		robust, err := address.NewSecp256k1Address([]byte("key"))
+		require.NoError(t, err)
+		_, err = dealActorEventFilter(&types.DealEventFilter{Client: &robust})
+		require.Error(t, err)
+	})
+
+	t.Run("decode", func(t *testing.T) {
+		f, err := dealActorEventFilter(&types.DealEventFilter{Kinds: []string{types.DealTerminated}})
+		require.NoError(t, err)
+
+		ev, err := decodeDealEvent(&types.ActorEvent{
+			Entries: []types.EventEntry{
+				{Key: "$type", Codec: cborCodec, Value: f.Fields["$type"][0].Value},
+				{Key: "id", Codec: cborCodec, Value: []byte{0x18, 0x2a}},
+				{Key: "client", Codec: cborCodec, Value: []byte{0x19, 0x03, 0xe9}},
+				{Key: "provider", Codec: cborCodec, Value: []byte{0x19, 0x03, 0xe8}},
+			},
+			Emitter: market.Address,
+			Height:  10,
+			MsgCid:  testCid,
+		})
+		require.NoError(t, err)
+		require.Equal(t, types.DealTerminated, ev.Kind)
+		require.EqualValues(t, 42, ev.DealID)
+		require.Equal(t, provider, ev.Provider)
+		client, err := address.NewIDAddress(1001)
+		require.NoError(t, err)
+		require.Equal(t, client, ev.Client)
+		require.EqualValues(t, 10, ev.Height)
+		require.Equal(t, testCid, ev.MsgCid)
+
+		_, err = decodeDealEvent(&types.ActorEvent{})
+		require.Error(t, err)
+	})
+}
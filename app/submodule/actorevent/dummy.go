@@ -20,4 +20,12 @@ func (a *ActorEventDummy) SubscribeActorEventsRaw(ctx context.Context, filter *t
 	return nil, ErrActorEventModuleDisabled
 }
 
+func (a *ActorEventDummy) GetDealEvents(ctx context.Context, filter *types.DealEventFilter) ([]*types.DealEvent, error) {
+	return nil, ErrActorEventModuleDisabled
+}
+
+func (a *ActorEventDummy) SubscribeDealEvents(ctx context.Context, filter *types.DealEventFilter) (<-chan *types.DealEvent, error) {
+	return nil, ErrActorEventModuleDisabled
+}
+
 var _ v1api.IActorEvent = &ActorEventDummy{}
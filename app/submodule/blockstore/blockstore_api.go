@@ -3,16 +3,12 @@ package blockstore
 import (
 	"context"
 	"fmt"
-	"sync"
 
+	"github.com/filecoin-project/venus/pkg/chain"
 	"github.com/filecoin-project/venus/venus-shared/types"
 
-	"github.com/ipfs/boxo/blockservice"
-	offline "github.com/ipfs/boxo/exchange/offline"
-	"github.com/ipfs/boxo/ipld/merkledag"
 	blocks "github.com/ipfs/go-block-format"
 	"github.com/ipfs/go-cid"
-	ipld "github.com/ipfs/go-ipld-format"
 
 	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 )
@@ -41,51 +37,7 @@ func (blockstoreAPI *blockstoreAPI) ChainHasObj(ctx context.Context, obj cid.Cid
 }
 
 func (blockstoreAPI *blockstoreAPI) ChainStatObj(ctx context.Context, obj cid.Cid, base cid.Cid) (types.ObjStat, error) {
-	bs := blockstoreAPI.blockstore.Blockstore
-	bsvc := blockservice.New(bs, offline.Exchange(bs))
-
-	dag := merkledag.NewDAGService(bsvc)
-
-	seen := cid.NewSet()
-
-	var statslk sync.Mutex
-	var stats types.ObjStat
-	collect := true
-
-	walker := func(ctx context.Context, c cid.Cid) ([]*ipld.Link, error) {
-		if c.Prefix().Codec == cid.FilCommitmentSealed || c.Prefix().Codec == cid.FilCommitmentUnsealed {
-			return []*ipld.Link{}, nil
-		}
-
-		nd, err := dag.Get(ctx, c)
-		if err != nil {
-			return nil, err
-		}
-
-		if collect {
-			s := uint64(len(nd.RawData()))
-			statslk.Lock()
-			stats.Size = stats.Size + s
-			stats.Links = stats.Links + 1
-			statslk.Unlock()
-		}
-
-		return nd.Links(), nil
-	}
-
-	if base != cid.Undef {
-		collect = false
-		if err := merkledag.Walk(ctx, walker, base, seen.Visit, merkledag.Concurrent()); err != nil {
-			return types.ObjStat{}, err
-		}
-		collect = true
-	}
-
-	if err := merkledag.Walk(ctx, walker, obj, seen.Visit, merkledag.Concurrent()); err != nil {
-		return types.ObjStat{}, err
-	}
-
-	return stats, nil
+	return chain.StatObj(ctx, blockstoreAPI.blockstore.Blockstore, obj, base)
 }
 
 func (blockstoreAPI *blockstoreAPI) ChainPutObj(ctx context.Context, blk blocks.Block) error {
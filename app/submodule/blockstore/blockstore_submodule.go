@@ -3,6 +3,7 @@ package blockstore
 import (
 	"context"
 
+	"github.com/filecoin-project/venus/pkg/chain"
 	"github.com/filecoin-project/venus/pkg/repo"
 	v0api "github.com/filecoin-project/venus/venus-shared/api/chain/v0"
 	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
@@ -13,7 +14,8 @@ import (
 // - `blockstore` is shared by chain/graphsync and piece/bitswap data
 // - `cborStore` is used for chain state and shared with piece data exchange for deals at the moment.
 type BlockstoreSubmodule struct { //nolint
-	// blockstore is the un-networked blocks interface
+	// blockstore is the un-networked blocks interface, all the writers of the chain objects share it so
+	// that their writes are protected from the prunes
 	Blockstore blockstoreutil.Blockstore
 }
 
@@ -24,7 +26,7 @@ type blockstoreRepo interface {
 // NewBlockstoreSubmodule creates a new block store submodule.
 func NewBlockstoreSubmodule(ctx context.Context, repo blockstoreRepo) (*BlockstoreSubmodule, error) {
 	// set up block store
-	bs := repo.Repo().Datastore()
+	bs := chain.NewPruneBlockstore(repo.Repo().Datastore())
 	return &BlockstoreSubmodule{
 		Blockstore: bs,
 	}, nil
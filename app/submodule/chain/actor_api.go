@@ -2,12 +2,22 @@ package chain
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin"
 	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 	"github.com/filecoin-project/venus/venus-shared/types"
 )
 
+// maxActorHeadHistoryRange is the number of epochs StateActorHeadHistory walks at most, a week.
+const maxActorHeadHistoryRange = 7 * builtin.EpochsInDay
+
 var _ v1api.IActor = &actorAPI{}
 
 type actorAPI struct {
@@ -24,6 +34,133 @@ func (actorAPI *actorAPI) StateGetActor(ctx context.Context, actor address.Addre
 	return actorAPI.chain.Stmgr.GetActorAtTsk(ctx, actor, tsk)
 }
 
+// StateGetActors returns the actors of addrs, loading the state tree of tsk once.
+func (actorAPI *actorAPI) StateGetActors(ctx context.Context, addrs []address.Address, tsk types.TipSetKey) ([]types.ActorLookup, error) {
+	ts, err := actorAPI.chain.ChainReader.GetTipSet(ctx, tsk)
+	if err != nil {
+		return nil, err
+	}
+	return actorAPI.chain.Stmgr.GetActorsAt(ctx, addrs, ts)
+}
+
+// StateActorStats returns the size of the states of the actors of addrs at tsk, less the blocks of their states at
+// base when base is not empty.
+func (actorAPI *actorAPI) StateActorStats(ctx context.Context, addrs []address.Address, base types.TipSetKey, tsk types.TipSetKey) ([]types.ActorStat, error) {
+	actors, err := actorAPI.StateGetActors(ctx, addrs, tsk)
+	if err != nil {
+		return nil, err
+	}
+	var baseActors []types.ActorLookup
+	if !base.IsEmpty() {
+		if baseActors, err = actorAPI.StateGetActors(ctx, addrs, base); err != nil {
+			return nil, fmt.Errorf("loading the actors at base: %w", err)
+		}
+	}
+
+	bs := actorAPI.chain.ChainReader.Blockstore()
+	out := make([]types.ActorStat, len(actors))
+	for i, lookup := range actors {
+		out[i].Address = lookup.Address
+		if !lookup.Found {
+			continue
+		}
+		baseHead := cid.Undef
+		if baseActors != nil && baseActors[i].Found {
+			baseHead = baseActors[i].Actor.Head
+		}
+		stat, err := chain.StatObj(ctx, bs, lookup.Actor.Head, baseHead)
+		if err != nil {
+			return nil, fmt.Errorf("walking the state of %s: %w", lookup.Address, err)
+		}
+		out[i].Found = true
+		out[i].Head = lookup.Actor.Head
+		out[i].ObjStat = stat
+	}
+	return out, nil
+}
+
+// StateSizeReport attributes the blocks of the state of tsk to the actors, keeping the top actors of the largest
+// states.
+func (actorAPI *actorAPI) StateSizeReport(ctx context.Context, top int, tsk types.TipSetKey) (*types.StateSizeReport, error) {
+	ts, err := actorAPI.chain.ChainReader.GetTipSet(ctx, tsk)
+	if err != nil {
+		return nil, err
+	}
+	return chain.StateSize(ctx, actorAPI.chain.ChainReader.Blockstore(), ts.ParentState(), top)
+}
+
+// StateActorHeadHistory returns the head and the balance of addr in the tipsets with a height in [from, to], the
+// first at from and then one at each change.
+func (actorAPI *actorAPI) StateActorHeadHistory(ctx context.Context, addr address.Address, from, to abi.ChainEpoch, tsk types.TipSetKey) ([]types.ActorHeadChange, error) {
+	if to < from {
+		return nil, fmt.Errorf("to %d must not be below from %d", to, from)
+	}
+	if to-from > maxActorHeadHistoryRange {
+		return nil, fmt.Errorf("range of %d epochs, at most %d", to-from, maxActorHeadHistoryRange)
+	}
+	ts, err := actorAPI.chain.ChainReader.GetTipSet(ctx, tsk)
+	if err != nil {
+		return nil, err
+	}
+	ts, err = actorAPI.chain.ChainReader.GetTipSetByHeight(ctx, ts, to, true)
+	if err != nil {
+		return nil, fmt.Errorf("loading tipset at %d: %w", to, err)
+	}
+
+	// the tipsets of the range from the last one, from is the first one at or below it
+	var tipsets []*types.TipSet
+	for {
+		tipsets = append(tipsets, ts)
+		if ts.Height() <= from || ts.Height() == 0 {
+			break
+		}
+		if ts, err = actorAPI.chain.ChainReader.GetTipSet(ctx, ts.Parents()); err != nil {
+			return nil, fmt.Errorf("loading the parent of %d: %w", tipsets[len(tipsets)-1].Height(), err)
+		}
+	}
+
+	return actorHeadChanges(ctx, tipsets, func(ts *types.TipSet) (types.ActorLookup, error) {
+		lookups, err := actorAPI.chain.Stmgr.GetActorsAt(ctx, []address.Address{addr}, ts)
+		if err != nil {
+			return types.ActorLookup{}, err
+		}
+		return lookups[0], nil
+	})
+}
+
+// actorHeadChanges returns the head and the balance of the actor in the first of the tipsets, ordered from the last
+// one, and then in each tipset where they change.
+func actorHeadChanges(ctx context.Context, tipsets []*types.TipSet, lookup func(*types.TipSet) (types.ActorLookup, error)) ([]types.ActorHeadChange, error) {
+	var out []types.ActorHeadChange
+	for i := len(tipsets) - 1; i >= 0; i-- {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		actor, err := lookup(tipsets[i])
+		if err != nil {
+			return nil, fmt.Errorf("loading the actor at %d: %w", tipsets[i].Height(), err)
+		}
+		change := types.ActorHeadChange{
+			Height:  tipsets[i].Height(),
+			TipSet:  tipsets[i].Key(),
+			Balance: big.Zero(),
+		}
+		if actor.Found {
+			change.Found = true
+			change.Head = actor.Actor.Head
+			change.Balance = actor.Actor.Balance
+		}
+		if len(out) > 0 {
+			last := out[len(out)-1]
+			if last.Found == change.Found && last.Head == change.Head && last.Balance.Equals(change.Balance) {
+				continue
+			}
+		}
+		out = append(out, change)
+	}
+	return out, nil
+}
+
 // ListActor returns a channel with actors from the latest state on the chain
 func (actorAPI *actorAPI) ListActor(ctx context.Context) (map[address.Address]*types.Actor, error) {
 	return actorAPI.chain.ChainReader.LsActors(ctx)
@@ -0,0 +1,74 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestActorHeadChanges(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	maddr, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+	head := func(name string) cid.Cid {
+		c, err := abi.CidBuilder.Sum([]byte(name))
+		require.NoError(t, err)
+		return c
+	}
+
+	// the actor is created at 2, its head changes at 4 and its balance at 5
+	actors := map[abi.ChainEpoch]*types.Actor{
+		2: {Head: head("a"), Balance: big.NewInt(10)},
+		3: {Head: head("a"), Balance: big.NewInt(10)},
+		4: {Head: head("b"), Balance: big.NewInt(10)},
+		5: {Head: head("b"), Balance: big.NewInt(20)},
+		6: {Head: head("b"), Balance: big.NewInt(20)},
+	}
+	lookup := func(ts *types.TipSet) (types.ActorLookup, error) {
+		act, ok := actors[ts.Height()]
+		return types.ActorLookup{Address: maddr, Found: ok, Actor: act}, nil
+	}
+	// the tipsets from the last one, without the null round at 3
+	var tipsets []*types.TipSet
+	for _, h := range []abi.ChainEpoch{6, 5, 4, 2, 1} {
+		tipsets = append(tipsets, newTestTipSet(t, maddr, h))
+	}
+
+	changes, err := actorHeadChanges(ctx, tipsets, lookup)
+	require.NoError(t, err)
+	assert.Equal(t, []types.ActorHeadChange{
+		{Height: 1, TipSet: tipsets[4].Key(), Balance: big.Zero()},
+		{Height: 2, TipSet: tipsets[3].Key(), Found: true, Head: head("a"), Balance: big.NewInt(10)},
+		{Height: 4, TipSet: tipsets[2].Key(), Found: true, Head: head("b"), Balance: big.NewInt(10)},
+		{Height: 5, TipSet: tipsets[1].Key(), Found: true, Head: head("b"), Balance: big.NewInt(20)},
+	}, changes)
+
+	// the first tipset is always returned
+	changes, err = actorHeadChanges(ctx, tipsets[:2], lookup)
+	require.NoError(t, err)
+	assert.Equal(t, []types.ActorHeadChange{
+		{Height: 5, TipSet: tipsets[1].Key(), Found: true, Head: head("b"), Balance: big.NewInt(20)},
+	}, changes)
+
+	_, err = actorHeadChanges(ctx, tipsets, func(*types.TipSet) (types.ActorLookup, error) {
+		return types.ActorLookup{}, errors.New("no state")
+	})
+	assert.EqualError(t, err, "loading the actor at 1: no state")
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = actorHeadChanges(canceled, tipsets, lookup)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,126 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/filecoin-project/go-address"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// StateAddressActivitySubscribe notifies the messages from or to addrs once they are executed, and again with
+// Reverted set when the tipset holding their receipts is reverted. The ID and the robust addresses of the watched
+// actors are matched.
+func (msa *minerStateAPI) StateAddressActivitySubscribe(ctx context.Context, addrs []address.Address) (<-chan *types.AddressActivity, error) {
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("no address to watch")
+	}
+
+	w := &activityWatcher{
+		msa:     msa,
+		watched: addrs,
+		forms:   make(map[address.Address]address.Address, 2*len(addrs)),
+	}
+	w.resolve(ctx, msa.ChainReader.GetHead())
+
+	heads := msa.ChainReader.SubHeadChanges(ctx)
+	out := make(chan *types.AddressActivity, 16)
+	go func() {
+		defer close(out)
+
+		for changes := range heads {
+			for _, change := range changes {
+				if change.Type == types.HCCurrent {
+					continue
+				}
+				if change.Type == types.HCApply {
+					w.resolve(ctx, change.Val)
+				}
+				activities, err := w.activities(ctx, change.Val, change.Type == types.HCRevert)
+				if err != nil {
+					log.Warnf("collecting the activity of the watched addresses at %d: %v", change.Val.Height(), err)
+					continue
+				}
+				for _, activity := range activities {
+					select {
+					case out <- activity:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+type activityWatcher struct {
+	msa     *minerStateAPI
+	watched []address.Address
+	// forms maps the ID and robust addresses of the watched actors to the watched address
+	forms map[address.Address]address.Address
+}
+
+// resolve records the ID and robust addresses of the watched actors at ts, the actors created since the
+// subscription are resolved once they exist.
+func (w *activityWatcher) resolve(ctx context.Context, ts *types.TipSet) {
+	for _, addr := range w.watched {
+		w.forms[addr] = addr
+		if addr.Protocol() != address.ID {
+			if id, err := w.msa.StateLookupID(ctx, addr, ts.Key()); err == nil {
+				w.forms[id] = addr
+			}
+		}
+		if robust, err := w.msa.Stmgr.ResolveToDeterministicAddress(ctx, addr, ts); err == nil {
+			w.forms[robust] = addr
+		}
+	}
+}
+
+// activities returns the messages of the watched addresses executed at ts, that is included in its parent.
+func (w *activityWatcher) activities(ctx context.Context, ts *types.TipSet, reverted bool) ([]*types.AddressActivity, error) {
+	if ts.Height() == 0 {
+		return nil, nil
+	}
+	parent, err := w.msa.ChainReader.GetTipSet(ctx, ts.Parents())
+	if err != nil {
+		return nil, fmt.Errorf("loading parent tipset: %w", err)
+	}
+	msgs, err := w.msa.MessageStore.MessagesForTipset(parent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get messages for tipset (%s): %w", parent.Key(), err)
+	}
+
+	var receipts []types.MessageReceipt
+	var out []*types.AddressActivity
+	for i, msg := range msgs {
+		vmsg := msg.VMMessage()
+		watched, ok := w.forms[vmsg.From]
+		if !ok {
+			if watched, ok = w.forms[vmsg.To]; !ok {
+				continue
+			}
+		}
+
+		if receipts == nil {
+			if receipts, err = w.msa.MessageStore.LoadReceipts(ctx, ts.Blocks()[0].ParentMessageReceipts); err != nil {
+				return nil, fmt.Errorf("loading receipts: %w", err)
+			}
+		}
+		activity := &types.AddressActivity{
+			Address:  watched,
+			MsgCid:   msg.Cid(),
+			Message:  vmsg,
+			TipSet:   parent.Key(),
+			Height:   parent.Height(),
+			Reverted: reverted,
+		}
+		if i < len(receipts) {
+			activity.Receipt = &receipts[i]
+		}
+		out = append(out, activity)
+	}
+	return out, nil
+}
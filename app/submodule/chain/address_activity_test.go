@@ -0,0 +1,140 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/pkg/consensus/chainselector"
+	"github.com/filecoin-project/venus/pkg/repo"
+	"github.com/filecoin-project/venus/pkg/state/tree"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestActivityWatcher(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	r := repo.NewInMemoryRepo()
+	bs := r.Datastore()
+	cst := cbor.NewCborStore(bs)
+	mstore := chain.NewMessageStore(bs, config.DefaultForkUpgradeParam)
+
+	newAddr := func(id uint64) address.Address {
+		addr, err := address.NewIDAddress(id)
+		require.NoError(t, err)
+		return addr
+	}
+	watched, other := newAddr(100), newAddr(200)
+	robust, err := address.NewActorAddress([]byte("watched"))
+	require.NoError(t, err)
+	sender, err := address.NewActorAddress([]byte("sender"))
+	require.NoError(t, err)
+
+	msgs := []*types.Message{
+		// from the robust address of the watched actor
+		{From: robust, To: other, Nonce: 0},
+		{From: sender, To: other, Nonce: 0},
+		// to the watched actor
+		{From: sender, To: watched, Nonce: 1},
+	}
+	for _, msg := range msgs {
+		msg.Value, msg.GasFeeCap, msg.GasPremium = big.Zero(), big.Zero(), big.Zero()
+	}
+	txMeta, err := mstore.StoreMessages(ctx, nil, msgs)
+	require.NoError(t, err)
+	emptyMeta, err := mstore.StoreMessages(ctx, nil, nil)
+	require.NoError(t, err)
+	receipts := []types.MessageReceipt{
+		{ExitCode: exitcode.Ok, GasUsed: 10},
+		{ExitCode: exitcode.Ok, GasUsed: 20},
+		{ExitCode: exitcode.ErrInsufficientFunds, GasUsed: 30},
+	}
+	receiptsRoot, err := mstore.StoreReceipts(ctx, receipts)
+	require.NoError(t, err)
+	emptyReceipts, err := mstore.StoreReceipts(ctx, nil)
+	require.NoError(t, err)
+
+	st, err := tree.NewState(cst, tree.StateTreeVersion5)
+	require.NoError(t, err)
+	root, err := st.Flush(ctx)
+	require.NoError(t, err)
+
+	// the messages of the tipset at 1 are executed at 2
+	var tipsets []*types.TipSet
+	for i := 0; i < 3; i++ {
+		blk := &types.BlockHeader{
+			Miner:                 other,
+			Ticket:                &types.Ticket{VRFProof: []byte{byte(i)}},
+			ParentWeight:          big.NewInt(int64(i)),
+			Height:                abi.ChainEpoch(i),
+			ParentStateRoot:       root,
+			ParentMessageReceipts: emptyReceipts,
+			Messages:              emptyMeta,
+			ParentBaseFee:         big.Zero(),
+		}
+		if i > 0 {
+			blk.Parents = tipsets[i-1].Cids()
+		}
+		if i == 1 {
+			blk.Messages = txMeta
+		}
+		if i == 2 {
+			blk.ParentMessageReceipts = receiptsRoot
+		}
+		_, err := cst.Put(ctx, blk)
+		require.NoError(t, err)
+		ts, err := types.NewTipSet([]*types.BlockHeader{blk})
+		require.NoError(t, err)
+		tipsets = append(tipsets, ts)
+	}
+
+	store := chain.NewStore(r.ChainDatastore(), bs, tipsets[0].At(0).Cid(), chainselector.Weight)
+	w := &activityWatcher{
+		msa:     &minerStateAPI{ChainSubmodule: &ChainSubmodule{ChainReader: store, MessageStore: mstore}},
+		watched: []address.Address{watched},
+		forms:   map[address.Address]address.Address{watched: watched, robust: watched},
+	}
+
+	expected := func(reverted bool) []*types.AddressActivity {
+		var out []*types.AddressActivity
+		for _, i := range []int{0, 2} {
+			out = append(out, &types.AddressActivity{
+				Address:  watched,
+				MsgCid:   msgs[i].Cid(),
+				Message:  msgs[i],
+				Receipt:  &receipts[i],
+				TipSet:   tipsets[1].Key(),
+				Height:   1,
+				Reverted: reverted,
+			})
+		}
+		return out
+	}
+
+	// applied then reverted, the same messages are notified
+	activities, err := w.activities(ctx, tipsets[2], false)
+	require.NoError(t, err)
+	assert.Equal(t, expected(false), activities)
+	activities, err = w.activities(ctx, tipsets[2], true)
+	require.NoError(t, err)
+	assert.Equal(t, expected(true), activities)
+
+	// no message of the watched addresses
+	activities, err = w.activities(ctx, tipsets[1], false)
+	require.NoError(t, err)
+	assert.Empty(t, activities)
+	activities, err = w.activities(ctx, tipsets[0], true)
+	require.NoError(t, err)
+	assert.Empty(t, activities)
+}
@@ -0,0 +1,128 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/filecoin-project/go-bitfield"
+	rlepluslazy "github.com/filecoin-project/go-bitfield/rle"
+
+	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
+)
+
+// maxUnionBitfields is the number of bitfields BitfieldUnion merges at most.
+const maxUnionBitfields = 1024
+
+var _ v1api.IBitfield = &bitfieldAPI{}
+
+type bitfieldAPI struct{}
+
+// NewBitfieldAPI create a new bitfield api
+func NewBitfieldAPI() v1api.IBitfield {
+	return &bitfieldAPI{}
+}
+
+// BitfieldUnion returns the bits set in any of the bitfields
+func (bitfieldAPI *bitfieldAPI) BitfieldUnion(ctx context.Context, bitfields [][]byte) ([]byte, error) {
+	if len(bitfields) > maxUnionBitfields {
+		return nil, fmt.Errorf("%d bitfields, at most %d are merged", len(bitfields), maxUnionBitfields)
+	}
+	bfs := make([]bitfield.BitField, 0, len(bitfields))
+	for i, data := range bitfields {
+		bf, err := decodeBitfield(data)
+		if err != nil {
+			return nil, fmt.Errorf("bitfield %d: %w", i, err)
+		}
+		bfs = append(bfs, bf)
+	}
+	union, err := bitfield.MultiMerge(bfs...)
+	if err != nil {
+		return nil, err
+	}
+	return encodeBitfield(union)
+}
+
+// BitfieldIntersect returns the bits set in both a and b
+func (bitfieldAPI *bitfieldAPI) BitfieldIntersect(ctx context.Context, a, b []byte) ([]byte, error) {
+	return combineBitfields(a, b, bitfield.IntersectBitField)
+}
+
+// BitfieldSubtract returns the bits set in a and not in b
+func (bitfieldAPI *bitfieldAPI) BitfieldSubtract(ctx context.Context, a, b []byte) ([]byte, error) {
+	return combineBitfields(a, b, bitfield.SubtractBitField)
+}
+
+// BitfieldCount returns the number of bits set in the bitfield
+func (bitfieldAPI *bitfieldAPI) BitfieldCount(ctx context.Context, data []byte) (uint64, error) {
+	bf, err := decodeBitfield(data)
+	if err != nil {
+		return 0, err
+	}
+	return bf.Count()
+}
+
+// BitfieldSlice returns count bits set in the bitfield, skipping the first start of them
+func (bitfieldAPI *bitfieldAPI) BitfieldSlice(ctx context.Context, data []byte, start, count uint64) ([]byte, error) {
+	bf, err := decodeBitfield(data)
+	if err != nil {
+		return nil, err
+	}
+	slice, err := bf.Slice(start, count)
+	if err != nil {
+		return nil, err
+	}
+	return encodeBitfield(slice)
+}
+
+// BitfieldEncode returns the bitfield of the bits, eg. sector numbers
+func (bitfieldAPI *bitfieldAPI) BitfieldEncode(ctx context.Context, bits []uint64) ([]byte, error) {
+	return encodeBitfield(bitfield.NewFromSet(bits))
+}
+
+// BitfieldDecode returns the bits set in the bitfield, it fails when more than max bits are set
+func (bitfieldAPI *bitfieldAPI) BitfieldDecode(ctx context.Context, data []byte, max uint64) ([]uint64, error) {
+	bf, err := decodeBitfield(data)
+	if err != nil {
+		return nil, err
+	}
+	return bf.All(max)
+}
+
+func combineBitfields(a, b []byte, combine func(a, b bitfield.BitField) (bitfield.BitField, error)) ([]byte, error) {
+	bfA, err := decodeBitfield(a)
+	if err != nil {
+		return nil, fmt.Errorf("bitfield a: %w", err)
+	}
+	bfB, err := decodeBitfield(b)
+	if err != nil {
+		return nil, fmt.Errorf("bitfield b: %w", err)
+	}
+	out, err := combine(bfA, bfB)
+	if err != nil {
+		return nil, err
+	}
+	return encodeBitfield(out)
+}
+
+// decodeBitfield decodes the RLE+ data, the empty data is the empty bitfield.
+func decodeBitfield(data []byte) (bitfield.BitField, error) {
+	if len(data) > bitfield.MaxEncodedSize {
+		return bitfield.BitField{}, fmt.Errorf("bitfield of %d bytes, at most %d", len(data), bitfield.MaxEncodedSize)
+	}
+	return bitfield.NewFromBytes(data)
+}
+
+func encodeBitfield(bf bitfield.BitField) ([]byte, error) {
+	runs, err := bf.RunIterator()
+	if err != nil {
+		return nil, err
+	}
+	data, err := rlepluslazy.EncodeRuns(runs, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > bitfield.MaxEncodedSize {
+		return nil, fmt.Errorf("bitfield of %d bytes, at most %d", len(data), bitfield.MaxEncodedSize)
+	}
+	return data, nil
+}
@@ -0,0 +1,76 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestBitfieldAPI(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	api := NewBitfieldAPI()
+
+	encode := func(bits ...uint64) []byte {
+		data, err := api.BitfieldEncode(ctx, bits)
+		require.NoError(t, err)
+		return data
+	}
+	decode := func(data []byte, err error) []uint64 {
+		require.NoError(t, err)
+		bits, err := api.BitfieldDecode(ctx, data, 1000)
+		require.NoError(t, err)
+		return bits
+	}
+
+	a, b := encode(1, 2, 3, 10, 11), encode(3, 4, 10, 100)
+	assert.Equal(t, []uint64{1, 2, 3, 10, 11}, decode(a, nil))
+	// the encoding is the one of go-bitfield
+	expected, err := bitfield.NewFromSet([]uint64{1, 2, 3, 10, 11}).RunIterator()
+	require.NoError(t, err)
+	fromBytes, err := bitfield.NewFromBytes(a)
+	require.NoError(t, err)
+	runs, err := fromBytes.RunIterator()
+	require.NoError(t, err)
+	for expected.HasNext() {
+		require.True(t, runs.HasNext())
+		want, err := expected.NextRun()
+		require.NoError(t, err)
+		got, err := runs.NextRun()
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	assert.Equal(t, []uint64{1, 2, 3, 4, 10, 11, 100, 200}, decode(api.BitfieldUnion(ctx, [][]byte{a, b, encode(200)})))
+	assert.Equal(t, []uint64{3, 10}, decode(api.BitfieldIntersect(ctx, a, b)))
+	assert.Equal(t, []uint64{1, 2, 11}, decode(api.BitfieldSubtract(ctx, a, b)))
+	assert.Equal(t, []uint64{2, 3, 10}, decode(api.BitfieldSlice(ctx, a, 1, 3)))
+	count, err := api.BitfieldCount(ctx, a)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), count)
+
+	// the empty data is the empty bitfield
+	assert.Empty(t, decode(api.BitfieldUnion(ctx, nil)))
+	assert.Equal(t, []uint64{1, 2, 3, 10, 11}, decode(api.BitfieldSubtract(ctx, a, nil)))
+	count, err = api.BitfieldCount(ctx, nil)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	_, err = api.BitfieldDecode(ctx, a, 4)
+	assert.Error(t, err)
+	_, err = api.BitfieldSlice(ctx, a, 3, 3)
+	assert.Error(t, err)
+	_, err = api.BitfieldIntersect(ctx, a, []byte{0xff})
+	assert.ErrorContains(t, err, "bitfield b")
+	_, err = api.BitfieldUnion(ctx, [][]byte{a, {0xff}})
+	assert.ErrorContains(t, err, "bitfield 1")
+	_, err = api.BitfieldCount(ctx, make([]byte, bitfield.MaxEncodedSize+1))
+	assert.Error(t, err)
+	_, err = api.BitfieldUnion(ctx, make([][]byte, maxUnionBitfields+1))
+	assert.Error(t, err)
+}
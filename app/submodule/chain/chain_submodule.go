@@ -2,22 +2,31 @@ package chain
 
 import (
 	"context"
+	"fmt"
+	"path/filepath"
 	"time"
 
 	"github.com/ipfs/go-cid"
 	cbor "github.com/ipfs/go-ipld-cbor"
 
+	"github.com/filecoin-project/venus/app/submodule/blockstore"
 	apiwrapper "github.com/filecoin-project/venus/app/submodule/chain/v0api"
 	"github.com/filecoin-project/venus/pkg/beacon"
 	"github.com/filecoin-project/venus/pkg/chain"
 	"github.com/filecoin-project/venus/pkg/consensus"
 	"github.com/filecoin-project/venus/pkg/consensus/chainselector"
 	"github.com/filecoin-project/venus/pkg/consensusfault"
+	"github.com/filecoin-project/venus/pkg/ethabi"
 	"github.com/filecoin-project/venus/pkg/fork"
+	"github.com/filecoin-project/venus/pkg/messagepool/journal"
+	"github.com/filecoin-project/venus/pkg/msgindex"
+	"github.com/filecoin-project/venus/pkg/reorgjournal"
 	"github.com/filecoin-project/venus/pkg/repo"
+	"github.com/filecoin-project/venus/pkg/state"
 	"github.com/filecoin-project/venus/pkg/statemanger"
 	"github.com/filecoin-project/venus/pkg/util/ffiwrapper"
 	"github.com/filecoin-project/venus/pkg/vm"
+	"github.com/filecoin-project/venus/pkg/vm/vmcontext"
 	"github.com/filecoin-project/venus/pkg/vmsupport"
 	v0api "github.com/filecoin-project/venus/venus-shared/api/chain/v0"
 	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
@@ -39,6 +48,17 @@ type ChainSubmodule struct { //nolint
 	Stmgr *statemanger.Stmgr
 	// Wait for confirm message
 	Waiter *chain.Waiter
+	// ABIRegistry holds the abi of the evm contracts registered to decode their calls
+	ABIRegistry *ethabi.Registry
+
+	// sectorCountCache keeps the sector counts of the miners across the tipsets
+	sectorCountCache *state.SectorCountCache
+	// messageIndex is nil unless Datastore.EnableMessageIndex is set
+	messageIndex *messageIndexManager
+	reorgJournal *reorgJournalManager
+
+	journal       journal.Journal
+	evtHeadChange journal.EventType
 }
 
 type chainConfig interface {
@@ -46,39 +66,73 @@ type chainConfig interface {
 	BlockTime() time.Duration
 	Repo() repo.Repo
 	Verifier() ffiwrapper.Verifier
+	EventJournal() journal.Journal
 }
 
 // NewChainSubmodule creates a new chain submodule.
 func NewChainSubmodule(ctx context.Context,
 	config chainConfig,
+	blockstore *blockstore.BlockstoreSubmodule,
 ) (*ChainSubmodule, error) {
 	repo := config.Repo()
 	// initialize chain store
-	chainStore := chain.NewStore(repo.ChainDatastore(), repo.Datastore(), config.GenesisCid(), chainselector.Weight)
+	chainStore := chain.NewStore(repo.ChainDatastore(), blockstore.Blockstore, config.GenesisCid(), chainselector.Weight)
+	actorStateCache, err := state.NewActorStateCache(repo.Config().API.ActorStateCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	chainStore.SetActorStateCache(actorStateCache)
 	// drand
 	genBlk, err := chainStore.GetGenesisBlock(context.TODO())
 	if err != nil {
 		return nil, err
 	}
 
-	drand, err := beacon.DrandConfigSchedule(genBlk.Timestamp, repo.Config().NetworkParams.BlockDelay, repo.Config().NetworkParams.DrandSchedule)
-	if err != nil {
-		return nil, err
+	var extraDrandServers []string
+	if repo.Config().Beacon != nil {
+		extraDrandServers = repo.Config().Beacon.ExtraServers
+	}
+	var drand beacon.Schedule
+	if devnet := repo.Config().Devnet; devnet != nil && devnet.Enable {
+		drand = beacon.NewMockSchedule(time.Duration(repo.Config().NetworkParams.BlockDelay) * time.Second)
+	} else {
+		drand, err = beacon.DrandConfigSchedule(genBlk.Timestamp, repo.Config().NetworkParams.BlockDelay, repo.Config().NetworkParams.DrandSchedule, extraDrandServers)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	messageStore := chain.NewMessageStore(config.Repo().Datastore(), repo.Config().NetworkParams.ForkUpgradeParam)
-	fork, err := fork.NewChainFork(ctx, chainStore, cbor.NewCborStore(config.Repo().Datastore()), config.Repo().Datastore(), repo.Config().NetworkParams, config.Repo().MetaDatastore())
+	if execCfg := repo.Config().Execution; execCfg != nil {
+		err := vmcontext.ConfigureExecutionLanes(vmcontext.ExecutionLanes{
+			Available:       execCfg.Concurrency,
+			Reserved:        execCfg.ReservedConcurrency,
+			API:             execCfg.APIConcurrency,
+			APIQueueSize:    execCfg.APIQueueSize,
+			APIQueueTimeout: time.Duration(execCfg.APIQueueTimeout),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("configuring the execution lanes: %w", err)
+		}
+	}
+
+	messageStore := chain.NewMessageStore(blockstore.Blockstore, repo.Config().NetworkParams.ForkUpgradeParam)
+	fork, err := fork.NewChainFork(ctx, chainStore, cbor.NewCborStore(blockstore.Blockstore), blockstore.Blockstore, repo.Config().NetworkParams, config.Repo().MetaDatastore())
 	if err != nil {
 		return nil, err
 	}
 
-	circulatingSupplyCalculator := chain.NewCirculatingSupplyCalculator(repo.Datastore(), genBlk.ParentStateRoot, repo.Config().NetworkParams, fork.GetNetworkVersion)
+	circulatingSupplyCalculator := chain.NewCirculatingSupplyCalculator(blockstore.Blockstore, genBlk.ParentStateRoot, repo.Config().NetworkParams, fork.GetNetworkVersion)
 
 	faultChecker := consensusfault.NewFaultChecker(chainStore, fork)
 	syscalls := vmsupport.NewSyscalls(faultChecker, config.Verifier())
 	processor := consensus.NewDefaultProcessor(syscalls, circulatingSupplyCalculator, chainStore, config.Repo().Config().NetworkParams)
 
-	waiter := chain.NewWaiter(chainStore, messageStore, config.Repo().Datastore(), cbor.NewCborStore(config.Repo().Datastore()))
+	sectorCountCache, err := state.NewSectorCountCache(state.SectorCountCacheSize)
+	if err != nil {
+		return nil, err
+	}
+
+	waiter := chain.NewWaiter(chainStore, messageStore, blockstore.Blockstore, cbor.NewCborStore(blockstore.Blockstore))
 
 	store := &ChainSubmodule{
 		ChainReader:                 chainStore,
@@ -90,7 +144,27 @@ func NewChainSubmodule(ctx context.Context,
 		Drand:                       drand,
 		config:                      config,
 		Waiter:                      waiter,
+		ABIRegistry:                 ethabi.NewRegistry(config.Repo().MetaDatastore()),
+		sectorCountCache:            sectorCountCache,
+		journal:                     config.EventJournal(),
+	}
+	store.evtHeadChange = store.journal.RegisterEventType("chain", "head_change")
+	if repo.Config().Datastore.EnableMessageIndex {
+		sqlitePath, err := repo.SqlitePath()
+		if err != nil {
+			return nil, err
+		}
+		index, err := msgindex.NewMsgIndex(filepath.Join(sqlitePath, "messages.db"))
+		if err != nil {
+			return nil, fmt.Errorf("opening the message index: %w", err)
+		}
+		store.messageIndex = &messageIndexManager{chain: store, index: index}
+	}
+	reorgs, err := reorgjournal.New(ctx, repo.MetaDatastore())
+	if err != nil {
+		return nil, fmt.Errorf("loading the reorg journal: %w", err)
 	}
+	store.reorgJournal = &reorgJournalManager{chain: store, journal: reorgs}
 	err = store.ChainReader.Load(context.TODO())
 	if err != nil {
 		return nil, err
@@ -100,12 +174,30 @@ func NewChainSubmodule(ctx context.Context,
 
 // Start loads the chain from disk.
 func (chain *ChainSubmodule) Start(ctx context.Context) error {
+	if chain.messageIndex != nil {
+		chain.ChainReader.SubscribeHeadChanges(chain.messageIndex.headChanged)
+	}
+	chain.ChainReader.SubscribeHeadChanges(chain.reorgJournal.headChanged)
+	chain.ChainReader.SubscribeHeadChanges(chain.journalHeadChange)
 	return chain.Fork.Start(ctx)
 }
 
 // Stop stop the chain head event
 func (chain *ChainSubmodule) Stop(ctx context.Context) {
 	chain.ChainReader.Stop()
+	if chain.messageIndex != nil {
+		if err := chain.messageIndex.index.Close(); err != nil {
+			log.Warnf("closing the message index: %v", err)
+		}
+	}
+}
+
+// Vacuum reclaims the space of the rows deleted from the message index.
+func (chain *ChainSubmodule) Vacuum(ctx context.Context) error {
+	if chain.messageIndex == nil {
+		return nil
+	}
+	return chain.messageIndex.index.Vacuum(ctx)
 }
 
 // API chain module api implement
@@ -115,6 +207,7 @@ func (chain *ChainSubmodule) API() v1api.IChain {
 		IActor:      NewActorAPI(chain),
 		IChainInfo:  NewChainInfoAPI(chain),
 		IMinerState: NewMinerStateAPI(chain),
+		IBitfield:   NewBitfieldAPI(),
 	}
 }
 
@@ -9,6 +9,8 @@ import (
 	"io"
 	"math"
 	"reflect"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/filecoin-project/go-address"
@@ -30,6 +32,7 @@ import (
 	"github.com/filecoin-project/venus/pkg/fork"
 	"github.com/filecoin-project/venus/pkg/statemanger"
 	"github.com/filecoin-project/venus/venus-shared/actors"
+	"github.com/filecoin-project/venus/venus-shared/actors/policy"
 	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 	"github.com/filecoin-project/venus/venus-shared/types"
 	"github.com/filecoin-project/venus/venus-shared/utils"
@@ -124,7 +127,7 @@ func (cia *chainInfoAPI) ChainGetTipSet(ctx context.Context, key types.TipSetKey
 func (cia *chainInfoAPI) ChainGetTipSetByHeight(ctx context.Context, height abi.ChainEpoch, tsk types.TipSetKey) (*types.TipSet, error) {
 	ts, err := cia.chain.ChainReader.GetTipSet(ctx, tsk)
 	if err != nil {
-		return nil, fmt.Errorf("fail to load tipset %v", err)
+		return nil, fmt.Errorf("fail to load tipset: %w", err)
 	}
 	return cia.chain.ChainReader.GetTipSetByHeight(ctx, ts, height, true)
 }
@@ -324,6 +327,14 @@ func (cia *chainInfoAPI) ChainNotify(ctx context.Context) (<-chan []*types.HeadC
 	return cia.chain.ChainReader.SubHeadChanges(ctx), nil
 }
 
+// ChainNotifyStable subscribe to chain head change event, delivering tipsets once they are confidence epochs deep
+func (cia *chainInfoAPI) ChainNotifyStable(ctx context.Context, confidence abi.ChainEpoch) (<-chan []*types.HeadChange, error) {
+	if confidence < 0 {
+		return nil, fmt.Errorf("confidence must not be negative, got %d", confidence)
+	}
+	return cia.chain.ChainReader.SubStableHeadChanges(ctx, confidence), nil
+}
+
 //************Drand****************//
 
 // GetEntry retrieves an entry from the drand server
@@ -340,6 +351,16 @@ func (cia *chainInfoAPI) GetEntry(ctx context.Context, height abi.ChainEpoch, ro
 	}
 }
 
+// BeaconStatus returns the latest round fetched and the health of the servers of every drand network.
+func (cia *chainInfoAPI) BeaconStatus(ctx context.Context) ([]types.BeaconStatus, error) {
+	return cia.chain.Drand.Status(), nil
+}
+
+// StateMigrationStatus returns the progress of the state migrations of the network upgrades.
+func (cia *chainInfoAPI) StateMigrationStatus(ctx context.Context) ([]types.MigrationStatus, error) {
+	return cia.chain.Fork.MigrationStatus(), nil
+}
+
 // VerifyEntry verifies that child is a valid entry if its parent is.
 func (cia *chainInfoAPI) VerifyEntry(parent, child *types.BeaconEntry, height abi.ChainEpoch) bool {
 	return cia.chain.Drand.BeaconForEpoch(height).VerifyEntry(*parent, child.Data) != nil
@@ -477,7 +498,7 @@ func (cia *chainInfoAPI) StateGetRandomnessDigestFromBeacon(ctx context.Context,
 func (cia *chainInfoAPI) StateNetworkVersion(ctx context.Context, tsk types.TipSetKey) (network.Version, error) {
 	ts, err := cia.chain.ChainReader.GetTipSet(ctx, tsk)
 	if err != nil {
-		return network.VersionMax, fmt.Errorf("loading tipset %s: %v", tsk, err)
+		return network.VersionMax, fmt.Errorf("loading tipset %s: %w", tsk, err)
 	}
 	return cia.chain.Fork.GetNetworkVersion(ctx, ts.Height()), nil
 }
@@ -485,7 +506,7 @@ func (cia *chainInfoAPI) StateNetworkVersion(ctx context.Context, tsk types.TipS
 func (cia *chainInfoAPI) StateVerifiedRegistryRootKey(ctx context.Context, tsk types.TipSetKey) (address.Address, error) {
 	ts, err := cia.chain.ChainReader.GetTipSet(ctx, tsk)
 	if err != nil {
-		return address.Undef, fmt.Errorf("loading tipset %s: %v", tsk, err)
+		return address.Undef, fmt.Errorf("loading tipset %s: %w", tsk, err)
 	}
 	_, view, err := cia.chain.Stmgr.ParentStateView(ctx, ts)
 	if err != nil {
@@ -503,7 +524,7 @@ func (cia *chainInfoAPI) StateVerifiedRegistryRootKey(ctx context.Context, tsk t
 func (cia *chainInfoAPI) StateVerifierStatus(ctx context.Context, addr address.Address, tsk types.TipSetKey) (*abi.StoragePower, error) {
 	ts, err := cia.chain.ChainReader.GetTipSet(ctx, tsk)
 	if err != nil {
-		return nil, fmt.Errorf("loading tipset %s: %v", tsk, err)
+		return nil, fmt.Errorf("loading tipset %s: %w", tsk, err)
 	}
 	_, view, err := cia.chain.Stmgr.ParentStateView(ctx, ts)
 	if err != nil {
@@ -559,6 +580,43 @@ func (cia *chainInfoAPI) StateSearchMsg(ctx context.Context, from types.TipSetKe
 	return nil, nil
 }
 
+// StateSearchMsgWithReplacement searches for the message executed with the sender and nonce of mCid, and reports whether it replaced mCid
+func (cia *chainInfoAPI) StateSearchMsgWithReplacement(ctx context.Context, from types.TipSetKey, mCid cid.Cid, lookbackLimit abi.ChainEpoch) (*types.MsgReplacementLookup, error) {
+	chainMsg, err := cia.chain.MessageStore.LoadMessage(ctx, mCid)
+	if err != nil {
+		return nil, err
+	}
+	head, err := cia.chain.ChainReader.GetTipSet(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	msgResult, found, err := cia.chain.Waiter.FindByNonce(ctx, chainMsg, lookbackLimit, head)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+	return msgReplacementLookup(chainMsg, msgResult), nil
+}
+
+// msgReplacementLookup returns the lookup of the message msgResult executed with the sender and nonce of the searched
+// message, flagging whether it replaced it.
+func msgReplacementLookup(searched types.ChainMsg, msgResult *types.ChainMessage) *types.MsgReplacementLookup {
+	// compare the unsigned messages, the searched message may be either the signed or the unsigned message
+	replaced := msgResult.Message.VMMessage().Cid() != searched.VMMessage().Cid()
+	return &types.MsgReplacementLookup{
+		MsgLookup: types.MsgLookup{
+			Message: msgResult.Message.Cid(),
+			Receipt: *msgResult.Receipt,
+			TipSet:  msgResult.TS.Key(),
+			Height:  msgResult.TS.Height(),
+		},
+		Replaced:       replaced,
+		GasReplacement: replaced && msgResult.Message.VMMessage().EqualCall(searched.VMMessage()),
+	}
+}
+
 var ErrMetadataNotFound = errors.New("actor metadata not found")
 
 func (cia *chainInfoAPI) getReturnType(ctx context.Context, to address.Address, method abi.MethodNum) (cbg.CBORUnmarshaler, error) {
@@ -628,14 +686,67 @@ func (cia *chainInfoAPI) StateWaitMsg(ctx context.Context, mCid cid.Cid, confide
 func (cia *chainInfoAPI) ChainExport(ctx context.Context, nroots abi.ChainEpoch, skipoldmsgs bool, tsk types.TipSetKey) (<-chan []byte, error) {
 	ts, err := cia.chain.ChainReader.GetTipSet(ctx, tsk)
 	if err != nil {
-		return nil, fmt.Errorf("loading tipset %s: %v", tsk, err)
+		return nil, fmt.Errorf("loading tipset %s: %w", tsk, err)
+	}
+
+	return streamExport(ctx, func(w io.Writer) error {
+		return cia.chain.ChainReader.Export(ctx, ts, nroots, skipoldmsgs, w)
+	}), nil
+}
+
+// ChainExportRange exports the blocks with a height in (from, to] of the chain of tsk, with their messages,
+// receipts and the state objects changed since the parent state of the tipset at from.
+func (cia *chainInfoAPI) ChainExportRange(ctx context.Context, from, to abi.ChainEpoch, tsk types.TipSetKey) (<-chan []byte, error) {
+	ts, err := cia.chain.ChainReader.GetTipSet(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("loading tipset %s: %w", tsk, err)
 	}
+	base, head, err := exportRangeTipSets(ctx, cia.chain.ChainReader.GetTipSetByHeight, ts, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return streamExport(ctx, func(w io.Writer) error {
+		return cia.chain.ChainReader.ExportRange(ctx, base, head, w)
+	}), nil
+}
+
+// exportRangeTipSets returns the tipsets at from and to of the chain of ts, the bounds of the range exported by
+// ChainExportRange.
+func exportRangeTipSets(ctx context.Context,
+	getTipSetByHeight func(context.Context, *types.TipSet, abi.ChainEpoch, bool) (*types.TipSet, error),
+	ts *types.TipSet,
+	from, to abi.ChainEpoch,
+) (*types.TipSet, *types.TipSet, error) {
+	if to <= from {
+		return nil, nil, fmt.Errorf("to %d must be above from %d", to, from)
+	}
+	if from < 0 {
+		return nil, nil, fmt.Errorf("from %d is negative", from)
+	}
+	if to > ts.Height() {
+		return nil, nil, fmt.Errorf("to %d is beyond the tipset at %d", to, ts.Height())
+	}
+
+	head, err := getTipSetByHeight(ctx, ts, to, true)
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading tipset at %d: %w", to, err)
+	}
+	base, err := getTipSetByHeight(ctx, head, from, true)
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading tipset at %d: %w", from, err)
+	}
+	return base, head, nil
+}
+
+// streamExport sends the car written by export in chunks, an empty chunk marks the end of the car.
+func streamExport(ctx context.Context, export func(w io.Writer) error) <-chan []byte {
 	r, w := io.Pipe()
 	out := make(chan []byte)
 	go func() {
 		bw := bufio.NewWriterSize(w, 1<<20)
 
-		err := cia.chain.ChainReader.Export(ctx, ts, nroots, skipoldmsgs, bw)
+		err := export(bw)
 		bw.Flush()            //nolint:errcheck // it is a write to a pipe
 		w.CloseWithError(err) //nolint:errcheck // it is a pipe
 	}()
@@ -671,7 +782,7 @@ func (cia *chainInfoAPI) ChainExport(ctx context.Context, nroots abi.ChainEpoch,
 		}
 	}()
 
-	return out, nil
+	return out
 }
 
 // ChainGetPath returns a set of revert/apply operations needed to get from
@@ -703,19 +814,7 @@ func (cia *chainInfoAPI) ChainGetPath(ctx context.Context, from types.TipSetKey,
 		return nil, fmt.Errorf("loading to tipset %s: %w", to, err)
 	}
 
-	revert, apply, err := chain.ReorgOps(ctx, cia.chain.ChainReader.GetTipSet, fts, tts)
-	if err != nil {
-		return nil, fmt.Errorf("error getting tipset branches: %w", err)
-	}
-
-	path := make([]*types.HeadChange, len(revert)+len(apply))
-	for i, r := range revert {
-		path[i] = &types.HeadChange{Type: types.HCRevert, Val: r}
-	}
-	for j, i := 0, len(apply)-1; i >= 0; j, i = j+1, i-1 {
-		path[j+len(revert)] = &types.HeadChange{Type: types.HCApply, Val: apply[i]}
-	}
-	return path, nil
+	return cia.chain.ChainReader.GetPath(ctx, fts, tts)
 }
 
 // StateGetNetworkParams returns current network params
@@ -770,6 +869,46 @@ func (cia *chainInfoAPI) StateGetNetworkParams(ctx context.Context) (*types.Netw
 	return params, nil
 }
 
+// StateActorMethods returns the methods exported by the builtin actor with the code.
+func (cia *chainInfoAPI) StateActorMethods(ctx context.Context, code cid.Cid) ([]types.ActorMethod, error) {
+	return utils.ActorMethods(code)
+}
+
+// ChainPrune removes the messages, receipts and state of the tipsets below the final height opts.Height.
+// It fails with chain.ErrPruneRunning while another prune or the blockstore gc is running.
+func (cia *chainInfoAPI) ChainPrune(ctx context.Context, opts types.ChainPruneOpts) (*types.ChainPruneResult, error) {
+	head := cia.chain.ChainReader.GetHead()
+	if finality := head.Height() - policy.ChainFinality; opts.Height > finality {
+		return nil, fmt.Errorf("prune height %d is above the final height %d", opts.Height, finality)
+	}
+	return cia.chain.ChainReader.Prune(ctx, head, opts.Height, opts.DryRun)
+}
+
+// ChainListReorgs returns the reorgs of the head recorded in the journal, from the latest one.
+func (cia *chainInfoAPI) ChainListReorgs(ctx context.Context, minDepth abi.ChainEpoch, since time.Time, limit int) ([]types.ChainReorg, error) {
+	return cia.chain.reorgJournal.journal.List(ctx, minDepth, since, limit)
+}
+
+// StateUpgradeSchedule returns the upgrade heights of the network by height.
+func (cia *chainInfoAPI) StateUpgradeSchedule(ctx context.Context) ([]types.UpgradeHeight, error) {
+	params := cia.chain.config.Repo().Config().NetworkParams
+	var out []types.UpgradeHeight
+	for name, height := range params.ForkUpgradeParam.Heights() {
+		if !strings.HasSuffix(name, "Height") {
+			continue
+		}
+		_, overridden := params.UpgradeHeightOverrides[name]
+		out = append(out, types.UpgradeHeight{Name: name, Height: height, Overridden: overridden})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Height != out[j].Height {
+			return out[i].Height < out[j].Height
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out, nil
+}
+
 // StateActorCodeCIDs returns the CIDs of all the builtin actors for the given network version
 func (cia *chainInfoAPI) StateActorCodeCIDs(ctx context.Context, nv network.Version) (map[string]cid.Cid, error) {
 	actorVersion, err := actorstypes.VersionForNetwork(nv)
@@ -818,7 +957,7 @@ func (cia *chainInfoAPI) StateActorManifestCID(ctx context.Context, nv network.V
 func (cia *chainInfoAPI) StateCall(ctx context.Context, msg *types.Message, tsk types.TipSetKey) (*types.InvocResult, error) {
 	ts, err := cia.chain.ChainReader.GetTipSet(ctx, tsk)
 	if err != nil {
-		return nil, fmt.Errorf("loading tipset %s: %v", tsk, err)
+		return nil, fmt.Errorf("loading tipset %s: %w", tsk, err)
 	}
 	var res *types.InvocResult
 	for {
@@ -893,7 +1032,7 @@ func (cia *chainInfoAPI) StateReplay(ctx context.Context, tsk types.TipSetKey, m
 		errstr = r.ActorErr.Error()
 	}
 
-	return &types.InvocResult{
+	ir := &types.InvocResult{
 		MsgCid:         msgToReplay,
 		Msg:            m,
 		MsgRct:         &r.Receipt,
@@ -901,7 +1040,9 @@ func (cia *chainInfoAPI) StateReplay(ctx context.Context, tsk types.TipSetKey, m
 		ExecutionTrace: r.GasTracker.ExecutionTrace,
 		Error:          errstr,
 		Duration:       r.Duration,
-	}, nil
+	}
+	ir.DecodeExit()
+	return ir, nil
 }
 
 // ChainGetEvents returns the events under an event AMT root CID.
@@ -929,6 +1070,20 @@ func (cia *chainInfoAPI) ChainGetEvents(ctx context.Context, root cid.Cid) ([]ty
 	return ret, err
 }
 
+// ChainGetEventsDecoded returns the events under an event AMT root CID with their entry values decoded.
+func (cia *chainInfoAPI) ChainGetEventsDecoded(ctx context.Context, root cid.Cid) ([]types.DecodedEvent, error) {
+	events, err := cia.ChainGetEvents(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]types.DecodedEvent, 0, len(events))
+	for _, evt := range events {
+		ret = append(ret, types.DecodeEvent(evt))
+	}
+	return ret, nil
+}
+
 func (cia *chainInfoAPI) StateCompute(ctx context.Context, height abi.ChainEpoch, msgs []*types.Message, tsk types.TipSetKey) (*types.ComputeStateOutput, error) {
 	ts, err := cia.ChainGetTipSet(ctx, tsk)
 	if err != nil {
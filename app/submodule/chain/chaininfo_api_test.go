@@ -0,0 +1,106 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestMsgReplacementLookup(t *testing.T) {
+	tf.UnitTest(t)
+
+	from, err := address.NewIDAddress(100)
+	require.NoError(t, err)
+	to, err := address.NewIDAddress(200)
+	require.NoError(t, err)
+	ts := newTestTipSet(t, to, 10)
+	receipt := &types.MessageReceipt{ExitCode: exitcode.Ok, GasUsed: 100}
+
+	searched := &types.Message{
+		From:       from,
+		To:         to,
+		Nonce:      3,
+		Value:      big.NewInt(10),
+		GasLimit:   1000,
+		GasFeeCap:  big.NewInt(100),
+		GasPremium: big.NewInt(10),
+	}
+	signed := func(msg *types.Message) *types.SignedMessage {
+		return &types.SignedMessage{Message: *msg, Signature: crypto.Signature{Type: crypto.SigTypeSecp256k1, Data: []byte{1}}}
+	}
+	executed := func(msg types.ChainMsg) *types.ChainMessage {
+		return &types.ChainMessage{TS: ts, Message: msg, Receipt: receipt}
+	}
+
+	t.Run("executed", func(t *testing.T) {
+		// the unsigned message is searched while the signed one was executed
+		res := msgReplacementLookup(searched, executed(signed(searched)))
+		assert.Equal(t, &types.MsgReplacementLookup{
+			MsgLookup: types.MsgLookup{
+				Message: signed(searched).Cid(),
+				Receipt: *receipt,
+				TipSet:  ts.Key(),
+				Height:  ts.Height(),
+			},
+		}, res)
+	})
+
+	t.Run("fee bump", func(t *testing.T) {
+		bumped := *searched
+		bumped.GasFeeCap = big.NewInt(200)
+		bumped.GasPremium = big.NewInt(20)
+		res := msgReplacementLookup(signed(searched), executed(signed(&bumped)))
+		assert.Equal(t, signed(&bumped).Cid(), res.Message)
+		assert.True(t, res.Replaced)
+		assert.True(t, res.GasReplacement)
+	})
+
+	t.Run("other message", func(t *testing.T) {
+		other := *searched
+		other.Value = big.NewInt(20)
+		res := msgReplacementLookup(searched, executed(&other))
+		assert.Equal(t, other.Cid(), res.Message)
+		assert.True(t, res.Replaced)
+		assert.False(t, res.GasReplacement)
+	})
+}
+
+func TestExportRangeTipSets(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	builder := chain.NewBuilder(t, address.Undef)
+	base := builder.AppendManyOn(ctx, 3, builder.Genesis())
+	mid := builder.AppendManyOn(ctx, 2, base)
+	head := builder.AppendManyOn(ctx, 2, mid)
+
+	from, to, err := exportRangeTipSets(ctx, builder.GetTipSetByHeight, head, base.Height(), mid.Height())
+	require.NoError(t, err)
+	assert.Equal(t, base.Key(), from.Key())
+	assert.Equal(t, mid.Key(), to.Key())
+
+	// the whole chain
+	from, to, err = exportRangeTipSets(ctx, builder.GetTipSetByHeight, head, 0, head.Height())
+	require.NoError(t, err)
+	assert.Equal(t, builder.Genesis().Key(), from.Key())
+	assert.Equal(t, head.Key(), to.Key())
+
+	_, _, err = exportRangeTipSets(ctx, builder.GetTipSetByHeight, head, mid.Height(), base.Height())
+	assert.ErrorContains(t, err, "must be above from")
+	_, _, err = exportRangeTipSets(ctx, builder.GetTipSetByHeight, head, mid.Height(), mid.Height())
+	assert.ErrorContains(t, err, "must be above from")
+	_, _, err = exportRangeTipSets(ctx, builder.GetTipSetByHeight, head, -1, mid.Height())
+	assert.ErrorContains(t, err, "is negative")
+	_, _, err = exportRangeTipSets(ctx, builder.GetTipSetByHeight, head, mid.Height(), head.Height()+1)
+	assert.ErrorContains(t, err, "is beyond the tipset")
+}
@@ -0,0 +1,152 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/filecoin-project/go-address"
+	cborutil "github.com/filecoin-project/go-cbor-util"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	builtintypes "github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/network"
+	runtime7 "github.com/filecoin-project/specs-actors/v7/actors/runtime"
+
+	"github.com/filecoin-project/venus/pkg/state"
+	"github.com/filecoin-project/venus/pkg/state/tree"
+	"github.com/filecoin-project/venus/pkg/vm"
+	"github.com/filecoin-project/venus/pkg/vm/vmcontext"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/miner"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// faultStateView is the state view the syscalls check a consensus fault against, outside of a message execution.
+type faultStateView struct {
+	*state.View
+	chain *ChainSubmodule
+}
+
+var _ vm.SyscallsStateView = (*faultStateView)(nil)
+
+func (v *faultStateView) TotalFilCircSupply(height abi.ChainEpoch, st tree.Tree) (abi.TokenAmount, error) {
+	return v.chain.CirculatingSupplyCalculator.GetCirculatingSupply(context.TODO(), height, st)
+}
+
+func (v *faultStateView) GetNetworkVersion(ctx context.Context, ce abi.ChainEpoch) network.Version {
+	return v.chain.Fork.GetNetworkVersion(ctx, ce)
+}
+
+func consensusFaultType(t runtime7.ConsensusFaultType) string {
+	switch t {
+	case runtime7.ConsensusFaultDoubleForkMining:
+		return "double-fork mining"
+	case runtime7.ConsensusFaultParentGrinding:
+		return "parent grinding"
+	case runtime7.ConsensusFaultTimeOffsetMining:
+		return "time-offset mining"
+	default:
+		return fmt.Sprintf("unknown(%d)", t)
+	}
+}
+
+// StateConsensusFaultReport checks the consensus fault proven by the block headers against the state of the tipset,
+// the way the miner actor checks a ReportConsensusFault message, and builds this message.
+func (msa *minerStateAPI) StateConsensusFaultReport(ctx context.Context, from address.Address, h1, h2, extra *types.BlockHeader, tsk types.TipSetKey) (*types.ConsensusFaultReport, error) {
+	if h1 == nil || h2 == nil {
+		return nil, errors.New("two block headers are required")
+	}
+	// the miner actor expects the lower block first
+	if h1.Height > h2.Height {
+		h1, h2 = h2, h1
+	}
+
+	ts, err := msa.ChainReader.GetTipSet(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("loading tipset %s: %w", tsk, err)
+	}
+	_, view, err := msa.Stmgr.ParentStateView(ctx, ts)
+	if err != nil {
+		return nil, fmt.Errorf("loading tipset(%s) parent state failed: %w", tsk, err)
+	}
+
+	params := miner.ReportConsensusFaultParams{}
+	if params.BlockHeader1, err = cborutil.Dump(h1); err != nil {
+		return nil, fmt.Errorf("serializing block %s: %w", h1.Cid(), err)
+	}
+	if params.BlockHeader2, err = cborutil.Dump(h2); err != nil {
+		return nil, fmt.Errorf("serializing block %s: %w", h2.Cid(), err)
+	}
+	if extra != nil {
+		if params.BlockHeaderExtra, err = cborutil.Dump(extra); err != nil {
+			return nil, fmt.Errorf("serializing block %s: %w", extra.Cid(), err)
+		}
+	}
+
+	fault, err := msa.SystemCall.VerifyConsensusFault(ctx, params.BlockHeader1, params.BlockHeader2, params.BlockHeaderExtra, ts.Height(),
+		vm.VmMessage{From: from, To: h1.Miner}, msa.ChainReader.Store(ctx), &faultStateView{View: view, chain: msa.ChainSubmodule},
+		vmcontext.LookbackStateGetterForTipset(ctx, msa.ChainReader, msa.Fork, ts))
+	if err != nil {
+		return nil, err
+	}
+
+	mas, err := view.LoadMinerState(ctx, fault.Target)
+	if err != nil {
+		return nil, fmt.Errorf("loading miner actor state: %w", err)
+	}
+	info, err := mas.Info()
+	if err != nil {
+		return nil, fmt.Errorf("loading miner info: %w", err)
+	}
+	// a miner is charged once for the faults before the end of the exclusion period of the last reported one
+	if fault.Epoch < info.ConsensusFaultElapsed {
+		return nil, fmt.Errorf("fault epoch %d is too old, last exclusion period ended at %d", fault.Epoch, info.ConsensusFaultElapsed)
+	}
+
+	rewardState, err := view.LoadRewardState(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading reward actor state: %w", err)
+	}
+	rewardSmoothed, err := rewardState.ThisEpochRewardSmoothed()
+	if err != nil {
+		return nil, fmt.Errorf("loading smoothed reward: %w", err)
+	}
+	penalty := builtin.ConsensusFaultPenalty(rewardSmoothed)
+
+	minerActor, err := view.LoadActor(ctx, fault.Target)
+	if err != nil {
+		return nil, fmt.Errorf("loading miner actor: %w", err)
+	}
+	locked, err := mas.LockedFunds()
+	if err != nil {
+		return nil, fmt.Errorf("loading miner locked funds: %w", err)
+	}
+	available, err := mas.AvailableBalance(minerActor.Balance)
+	if err != nil {
+		return nil, fmt.Errorf("loading miner available balance: %w", err)
+	}
+	reward := consensusFaultReward(builtin.RewardForConsensusSlashReport(rewardSmoothed), penalty, locked.VestingFunds, available)
+
+	proto, err := minerMessage(fault.Target, from, builtintypes.MethodsMiner.ReportConsensusFault, &params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ConsensusFaultReport{
+		Miner:   fault.Target,
+		Type:    consensusFaultType(fault.Type),
+		Epoch:   fault.Epoch,
+		Penalty: penalty,
+		Reward:  reward,
+		Message: &proto.Message,
+	}, nil
+}
+
+// consensusFaultReward returns the reward of the reporter of a fault, which is slashReward bounded by the part of
+// penalty the miner pays: the penalty is taken from the vesting funds then from the available balance, the rest
+// becomes fee debt which the reporter is not rewarded from.
+func consensusFaultReward(slashReward, penalty, vesting, available abi.TokenAmount) abi.TokenAmount {
+	payable := big.Add(vesting, big.Max(available, big.Zero()))
+	return big.Min(slashReward, big.Min(penalty, payable))
+}
@@ -0,0 +1,31 @@
+package chain
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestConsensusFaultReward(t *testing.T) {
+	tf.UnitTest(t)
+
+	for _, tc := range []struct {
+		name                                     string
+		slashReward, penalty, vesting, available int64
+		expected                                 int64
+	}{
+		{name: "slash reward", slashReward: 10, penalty: 100, vesting: 50, available: 50, expected: 10},
+		{name: "clamped to the penalty", slashReward: 10, penalty: 5, vesting: 50, available: 50, expected: 5},
+		{name: "clamped to the vesting funds and the balance", slashReward: 10, penalty: 100, vesting: 3, available: 4, expected: 7},
+		{name: "negative available balance", slashReward: 10, penalty: 100, vesting: 3, available: -20, expected: 3},
+		{name: "nothing to pay", slashReward: 10, penalty: 100, vesting: 0, available: -1, expected: 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			reward := consensusFaultReward(big.NewInt(tc.slashReward), big.NewInt(tc.penalty), big.NewInt(tc.vesting), big.NewInt(tc.available))
+			assert.Equal(t, big.NewInt(tc.expected), reward)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package chain
+
+import (
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// HeadChangeEvt is the journal event of a change of the head from From to To at Height, reverting and applying
+// the given numbers of tipsets.
+type HeadChangeEvt struct {
+	From     types.TipSetKey
+	To       types.TipSetKey
+	Height   abi.ChainEpoch
+	Reverted int
+	Applied  int
+}
+
+func (chain *ChainSubmodule) journalHeadChange(rev, app []*types.TipSet) error {
+	if len(app) == 0 {
+		return nil
+	}
+	chain.journal.RecordEvent(chain.evtHeadChange, func() interface{} {
+		from := app[0].Parents()
+		if len(rev) > 0 {
+			from = rev[0].Key()
+		}
+		to := app[len(app)-1]
+		return HeadChangeEvt{
+			From:     from,
+			To:       to.Key(),
+			Height:   to.Height(),
+			Reverted: len(rev),
+			Applied:  len(app),
+		}
+	})
+	return nil
+}
@@ -0,0 +1,88 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/constants"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// ChainGetReceiptProof returns an inclusion proof of the receipt of msg in the receipts committed to
+// by the tipset following the one which executed msg.
+func (cia *chainInfoAPI) ChainGetReceiptProof(ctx context.Context, from types.TipSetKey, msg cid.Cid) (*types.ReceiptInclusionProof, error) {
+	lookup, err := cia.StateSearchMsg(ctx, from, msg, constants.LookbackNoLimit, true)
+	if err != nil {
+		return nil, fmt.Errorf("searching message %s: %w", msg, err)
+	}
+	if lookup == nil {
+		return nil, fmt.Errorf("message %s not found", msg)
+	}
+
+	ts, err := cia.chain.ChainReader.GetTipSet(ctx, lookup.TipSet)
+	if err != nil {
+		return nil, fmt.Errorf("loading tipset %s: %w", lookup.TipSet, err)
+	}
+	pts, err := cia.chain.ChainReader.GetTipSet(ctx, ts.Parents())
+	if err != nil {
+		return nil, fmt.Errorf("loading parent tipset %s: %w", ts.Parents(), err)
+	}
+	msgs, err := cia.chain.MessageStore.MessagesForTipset(pts)
+	if err != nil {
+		return nil, fmt.Errorf("loading messages of tipset %s: %w", pts.Key(), err)
+	}
+	index := -1
+	for i, m := range msgs {
+		if m.Cid() == lookup.Message {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, fmt.Errorf("message %s not found in tipset %s", lookup.Message, pts.Key())
+	}
+
+	header := ts.Blocks()[0]
+	rec, proofBlocks, err := chain.ProveReceipt(ctx, cia.chain.ChainReader.Blockstore(), header.ParentMessageReceipts, uint64(index))
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ReceiptInclusionProof{
+		TipSet:       ts.Key(),
+		Header:       header,
+		ReceiptsRoot: header.ParentMessageReceipts,
+		Index:        uint64(index),
+		Message:      lookup.Message,
+		Receipt:      *rec,
+		Blocks:       proofBlocks,
+	}, nil
+}
+
+// ChainGetEventProof returns the receipt inclusion proof of msg, and an inclusion proof of the event
+// at eventIndex in the events emitted by msg.
+func (cia *chainInfoAPI) ChainGetEventProof(ctx context.Context, from types.TipSetKey, msg cid.Cid, eventIndex uint64) (*types.EventInclusionProof, error) {
+	recProof, err := cia.ChainGetReceiptProof(ctx, from, msg)
+	if err != nil {
+		return nil, err
+	}
+	if recProof.Receipt.EventsRoot == nil {
+		return nil, fmt.Errorf("message %s emitted no events", msg)
+	}
+
+	evt, proofBlocks, err := chain.ProveEvent(ctx, cia.chain.ChainReader.Blockstore(), *recProof.Receipt.EventsRoot, eventIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.EventInclusionProof{
+		Receipt:    *recProof,
+		EventsRoot: *recProof.Receipt.EventsRoot,
+		Index:      eventIndex,
+		Event:      *evt,
+		Blocks:     proofBlocks,
+	}, nil
+}
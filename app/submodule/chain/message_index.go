@@ -0,0 +1,153 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+
+	"github.com/filecoin-project/venus/pkg/msgindex"
+	"github.com/filecoin-project/venus/pkg/state/tree"
+	"github.com/filecoin-project/venus/venus-shared/actors"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// messageIndexManager indexes the messages of the applied tipsets with the type of their recipients in the parent
+// state of the tipsets.
+type messageIndexManager struct {
+	chain *ChainSubmodule
+	index *msgindex.MsgIndex
+}
+
+func (m *messageIndexManager) headChanged(rev, app []*types.TipSet) error {
+	ctx := context.TODO()
+	for _, ts := range rev {
+		key, err := ts.Key().Cid()
+		if err != nil {
+			return err
+		}
+		if err := m.index.RemoveTipSet(key); err != nil {
+			return fmt.Errorf("removing the messages of %d: %w", ts.Height(), err)
+		}
+	}
+	for _, ts := range app {
+		if err := m.indexTipSet(ctx, ts); err != nil {
+			return fmt.Errorf("indexing the messages of %d: %w", ts.Height(), err)
+		}
+	}
+	return nil
+}
+
+func (m *messageIndexManager) indexTipSet(ctx context.Context, ts *types.TipSet) error {
+	key, err := ts.Key().Cid()
+	if err != nil {
+		return err
+	}
+	msgs, err := m.chain.MessageStore.MessagesForTipset(ts)
+	if err != nil {
+		return err
+	}
+	toTypes, err := newRecipientTypes(ctx, m.chain, ts)
+	if err != nil {
+		return err
+	}
+
+	out := make([]msgindex.Message, 0, len(msgs))
+	for _, msg := range msgs {
+		vmsg := msg.VMMessage()
+		toType, err := toTypes.get(ctx, vmsg.To)
+		if err != nil {
+			return err
+		}
+		out = append(out, msgindex.Message{
+			Cid:         msg.Cid(),
+			TipSet:      key,
+			Height:      ts.Height(),
+			From:        vmsg.From,
+			To:          vmsg.To,
+			ToActorType: toType,
+			Method:      vmsg.Method,
+		})
+	}
+	return m.index.IndexTipSet(key, ts.Height(), out)
+}
+
+// recipientTypes resolves the types of the recipients of the messages of a tipset in its parent state, eg.
+// storagemarket, the type of a recipient created by the tipset is empty.
+type recipientTypes struct {
+	st    tree.Tree
+	types map[address.Address]string
+}
+
+func newRecipientTypes(ctx context.Context, chain *ChainSubmodule, ts *types.TipSet) (*recipientTypes, error) {
+	st, err := tree.LoadState(ctx, cbor.NewCborStore(chain.ChainReader.Blockstore()), ts.ParentState())
+	if err != nil {
+		return nil, fmt.Errorf("loading the parent state of %d: %w", ts.Height(), err)
+	}
+	return &recipientTypes{st: st, types: make(map[address.Address]string)}, nil
+}
+
+func (r *recipientTypes) get(ctx context.Context, to address.Address) (string, error) {
+	if toType, ok := r.types[to]; ok {
+		return toType, nil
+	}
+	act, found, err := r.st.GetActor(ctx, to)
+	if err != nil {
+		return "", fmt.Errorf("loading the recipient %s: %w", to, err)
+	}
+	var toType string
+	if found {
+		toType = actors.CanonicalName(builtin.ActorNameByCode(act.Code))
+	}
+	r.types[to] = toType
+	return toType, nil
+}
+
+// listMessages returns the messages matching match in the tipsets of the chain of ts with a height in
+// [toheight, ts.Height()], false when the index does not hold all of these tipsets.
+func (m *messageIndexManager) listMessages(ctx context.Context, match *types.MessageMatch, ts *types.TipSet, toheight abi.ChainEpoch) ([]cid.Cid, bool, error) {
+	from, ok, err := m.index.IndexedFrom()
+	if err != nil {
+		return nil, false, err
+	}
+	if !ok || from > toheight {
+		return nil, false, nil
+	}
+	// the chain of ts is only in the index when ts is, eg. it is not when ts is on a fork
+	key, err := ts.Key().Cid()
+	if err != nil {
+		return nil, false, err
+	}
+	if indexed, err := m.index.HasTipSet(key); err != nil || !indexed {
+		return nil, false, err
+	}
+	msgs, err := m.index.ListMessages(match, toheight, ts.Height())
+	if err != nil {
+		return nil, false, err
+	}
+
+	// the index holds the tipsets of the chain of the head, keep those on the chain of ts
+	onChain := make(map[abi.ChainEpoch]cid.Cid)
+	var out []cid.Cid
+	for _, msg := range msgs {
+		key, ok := onChain[msg.Height]
+		if !ok {
+			chainTS, err := m.chain.ChainReader.GetTipSetByHeight(ctx, ts, msg.Height, true)
+			if err != nil {
+				return nil, false, fmt.Errorf("loading tipset at %d: %w", msg.Height, err)
+			}
+			if key, err = chainTS.Key().Cid(); err != nil {
+				return nil, false, err
+			}
+			onChain[msg.Height] = key
+		}
+		if key == msg.TipSet {
+			out = append(out, msg.Cid)
+		}
+	}
+	return out, true, nil
+}
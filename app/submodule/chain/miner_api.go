@@ -8,13 +8,15 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"slices"
 
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-bitfield"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
-	"github.com/filecoin-project/go-state-types/cbor"
+	builtintypes "github.com/filecoin-project/go-state-types/builtin"
 	"github.com/filecoin-project/go-state-types/dline"
+	"github.com/filecoin-project/go-state-types/manifest"
 	"github.com/filecoin-project/go-state-types/network"
 	"github.com/ipfs/go-cid"
 	cbornode "github.com/ipfs/go-ipld-cbor"
@@ -25,11 +27,14 @@ import (
 	market12 "github.com/filecoin-project/go-state-types/builtin/v12/market"
 	market2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/market"
 	market5 "github.com/filecoin-project/specs-actors/v5/actors/builtin/market"
+	"github.com/filecoin-project/venus/pkg/constants"
+	"github.com/filecoin-project/venus/pkg/ethabi"
 	"github.com/filecoin-project/venus/pkg/state/tree"
 	"github.com/filecoin-project/venus/pkg/vm/register"
 	"github.com/filecoin-project/venus/venus-shared/actors"
 	"github.com/filecoin-project/venus/venus-shared/actors/adt"
 	"github.com/filecoin-project/venus/venus-shared/actors/builtin"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/evm"
 	_init "github.com/filecoin-project/venus/venus-shared/actors/builtin/init"
 	"github.com/filecoin-project/venus/venus-shared/actors/builtin/market"
 	"github.com/filecoin-project/venus/venus-shared/actors/builtin/miner"
@@ -61,7 +66,7 @@ func (msa *minerStateAPI) StateMinerSectorAllocated(ctx context.Context, maddr a
 	}
 	mas, err := view.LoadMinerState(ctx, maddr)
 	if err != nil {
-		return false, fmt.Errorf("failed to load miner actor state: %v", err)
+		return false, fmt.Errorf("failed to load miner actor state: %w", err)
 	}
 	return mas.IsAllocated(s)
 }
@@ -70,7 +75,7 @@ func (msa *minerStateAPI) StateMinerSectorAllocated(ctx context.Context, maddr a
 func (msa *minerStateAPI) StateSectorPreCommitInfo(ctx context.Context, maddr address.Address, n abi.SectorNumber, tsk types.TipSetKey) (*types.SectorPreCommitOnChainInfo, error) {
 	_, view, err := msa.Stmgr.ParentStateViewTsk(ctx, tsk)
 	if err != nil {
-		return nil, fmt.Errorf("loading tipset:%s parent state view: %v", tsk, err)
+		return nil, fmt.Errorf("loading tipset:%s parent state view: %w", tsk, err)
 	}
 
 	return view.SectorPreCommitInfo(ctx, maddr, n)
@@ -83,7 +88,7 @@ func (msa *minerStateAPI) StateSectorPreCommitInfo(ctx context.Context, maddr ad
 func (msa *minerStateAPI) StateSectorGetInfo(ctx context.Context, maddr address.Address, n abi.SectorNumber, tsk types.TipSetKey) (*miner.SectorOnChainInfo, error) {
 	_, view, err := msa.Stmgr.ParentStateViewTsk(ctx, tsk)
 	if err != nil {
-		return nil, fmt.Errorf("loading tipset %s: %v", tsk, err)
+		return nil, fmt.Errorf("loading tipset %s: %w", tsk, err)
 	}
 
 	return view.MinerSectorInfo(ctx, maddr, n)
@@ -185,7 +190,7 @@ func (msa *minerStateAPI) StateMinerRecoveries(ctx context.Context, maddr addres
 
 	mas, err := view.LoadMinerState(ctx, maddr)
 	if err != nil {
-		return bitfield.BitField{}, fmt.Errorf("failed to load miner actor state: %v", err)
+		return bitfield.BitField{}, fmt.Errorf("failed to load miner actor state: %w", err)
 	}
 
 	return miner.AllPartSectors(mas, miner.Partition.RecoveringSectors)
@@ -200,7 +205,7 @@ func (msa *minerStateAPI) StateMinerFaults(ctx context.Context, maddr address.Ad
 
 	mas, err := view.LoadMinerState(ctx, maddr)
 	if err != nil {
-		return bitfield.BitField{}, fmt.Errorf("failed to load miner actor state: %v", err)
+		return bitfield.BitField{}, fmt.Errorf("failed to load miner actor state: %w", err)
 	}
 
 	return miner.AllPartSectors(mas, miner.Partition.FaultySectors)
@@ -224,7 +229,7 @@ func (msa *minerStateAPI) StateMinerProvingDeadline(ctx context.Context, maddr a
 	}
 	mas, err := view.LoadMinerState(ctx, maddr)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load miner actor state: %v", err)
+		return nil, fmt.Errorf("failed to load miner actor state: %w", err)
 	}
 
 	di, err := mas.DeadlineInfo(ts.Height())
@@ -244,7 +249,7 @@ func (msa *minerStateAPI) StateMinerPartitions(ctx context.Context, maddr addres
 
 	mas, err := view.LoadMinerState(ctx, maddr)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load miner actor state: %v", err)
+		return nil, fmt.Errorf("failed to load miner actor state: %w", err)
 	}
 
 	dl, err := mas.LoadDeadline(dlIdx)
@@ -301,7 +306,7 @@ func (msa *minerStateAPI) StateMinerDeadlines(ctx context.Context, maddr address
 
 	mas, err := view.LoadMinerState(ctx, maddr)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load miner actor state: %v", err)
+		return nil, fmt.Errorf("failed to load miner actor state: %w", err)
 	}
 
 	deadlines, err := mas.NumDeadlines()
@@ -337,6 +342,214 @@ func (msa *minerStateAPI) StateMinerDeadlines(ctx context.Context, maddr address
 	return out, nil
 }
 
+// StateGetDisputableWindowedPoSts returns the deadlines of the miner with window PoSts which may still be disputed at
+// the tipset, a deadline's proofs being disputable from its close until the dispute window ends.
+func (msa *minerStateAPI) StateGetDisputableWindowedPoSts(ctx context.Context, maddr address.Address, tsk types.TipSetKey) ([]types.DisputableDeadline, error) {
+	ts, err := msa.ChainReader.GetTipSet(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("GetTipset failed:%v", err)
+	}
+
+	_, view, err := msa.Stmgr.ParentStateView(ctx, ts)
+	if err != nil {
+		return nil, fmt.Errorf("Stmgr.ParentStateViewTsk failed:%v", err)
+	}
+	mas, err := view.LoadMinerState(ctx, maddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load miner actor state: %w", err)
+	}
+
+	return disputableDeadlines(mas, ts.Height())
+}
+
+// disputableDeadlines returns the deadlines of mas with window PoSts which may still be disputed at height.
+func disputableDeadlines(mas miner.State, height abi.ChainEpoch) ([]types.DisputableDeadline, error) {
+	di, err := mas.DeadlineInfo(height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deadline info: %v", err)
+	}
+
+	var out []types.DisputableDeadline
+	if err := mas.ForEachDeadline(func(idx uint64, dl miner.Deadline) error {
+		// the proofs are those of the last instance of the deadline, disputable until the dispute window ends
+		dlInfo := dline.NewInfo(di.PeriodStart, idx, height, miner.WPoStPeriodDeadlines, miner.WPoStProvingPeriod(),
+			miner.WPoStChallengeWindow(), miner.WPoStChallengeLookback, miner.FaultDeclarationCutoff).NextNotElapsed()
+		if dlInfo.IsOpen() {
+			return nil
+		}
+		closed := dlInfo.Close - miner.WPoStProvingPeriod()
+		disputeEnd := closed + miner.WPoStDisputeWindow()
+		if height >= disputeEnd {
+			return nil
+		}
+
+		proofs, err := dl.DisputableProofs()
+		if err != nil {
+			return fmt.Errorf("loading the proofs of deadline %d: %w", idx, err)
+		}
+		if len(proofs) == 0 {
+			return nil
+		}
+
+		dd := types.DisputableDeadline{
+			Deadline:   idx,
+			Close:      closed,
+			DisputeEnd: disputeEnd,
+			Proofs:     make([]types.DisputableWindowedPoSt, 0, len(proofs)),
+		}
+		for _, proof := range proofs {
+			dd.Proofs = append(dd.Proofs, types.DisputableWindowedPoSt{Index: proof.Index, Partitions: proof.Partitions})
+		}
+		out = append(out, dd)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StateMinerCompactionPlan returns a CompactPartitions message for each deadline of the miner which may be compacted at
+// the tipset, compacting the partitions whose ratio of terminated sectors is at least minDeadRatio. The partitions
+// with faulty or unproven sectors can't be compacted and are left out.
+func (msa *minerStateAPI) StateMinerCompactionPlan(ctx context.Context, maddr address.Address, minDeadRatio float64, tsk types.TipSetKey) ([]types.CompactionPlan, error) {
+	if minDeadRatio <= 0 || minDeadRatio > 1 {
+		return nil, fmt.Errorf("the dead sectors ratio must be in (0, 1], got %f", minDeadRatio)
+	}
+
+	ts, err := msa.ChainReader.GetTipSet(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("GetTipset failed:%v", err)
+	}
+
+	_, view, err := msa.Stmgr.ParentStateView(ctx, ts)
+	if err != nil {
+		return nil, fmt.Errorf("Stmgr.ParentStateViewTsk failed:%v", err)
+	}
+	mas, err := view.LoadMinerState(ctx, maddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load miner actor state: %w", err)
+	}
+	info, err := mas.Info()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load miner info: %v", err)
+	}
+	di, err := mas.DeadlineInfo(ts.Height())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deadline info: %v", err)
+	}
+
+	var out []types.CompactionPlan
+	if err := mas.ForEachDeadline(func(idx uint64, dl miner.Deadline) error {
+		if !deadlineCompactable(di.PeriodStart, idx, ts.Height()) {
+			return nil
+		}
+		partitions, live, dead, err := compactablePartitions(dl, minDeadRatio)
+		if err != nil {
+			return fmt.Errorf("loading the partitions of deadline %d: %w", idx, err)
+		}
+		if len(partitions) == 0 {
+			return nil
+		}
+
+		plan := types.CompactionPlan{
+			Deadline:    idx,
+			Partitions:  bitfield.NewFromSet(partitions),
+			LiveSectors: live,
+			DeadSectors: dead,
+		}
+		proto, err := minerMessage(maddr, info.Worker, builtintypes.MethodsMiner.CompactPartitions, &miner.CompactPartitionsParams{
+			Deadline:   idx,
+			Partitions: plan.Partitions,
+		})
+		if err != nil {
+			return err
+		}
+		plan.Message = &proto.Message
+
+		estimate := *plan.Message
+		estimate.GasLimit = constants.BlockGasLimit
+		estimate.GasFeeCap = big.NewInt(int64(constants.MinimumBaseFee) + 1)
+		estimate.GasPremium = big.NewInt(1)
+		res, callErr := msa.Stmgr.CallWithGas(ctx, &estimate, nil, ts, false)
+		switch {
+		case callErr != nil:
+			plan.Error = callErr.Error()
+		case res.MsgRct.ExitCode.IsError():
+			plan.Error = fmt.Sprintf("exit code %d: %s", res.MsgRct.ExitCode, res.Error)
+		default:
+			plan.GasUsed = res.MsgRct.GasUsed
+		}
+
+		out = append(out, plan)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// deadlineCompactable tells whether the deadline idx of the proving period starting at periodStart may be compacted
+// at height. Like the actor, only the deadlines not about to be proven and out of their dispute window may be.
+func deadlineCompactable(periodStart abi.ChainEpoch, idx uint64, height abi.ChainEpoch) bool {
+	dlInfo := dline.NewInfo(periodStart, idx, height, miner.WPoStPeriodDeadlines, miner.WPoStProvingPeriod(),
+		miner.WPoStChallengeWindow(), miner.WPoStChallengeLookback, miner.FaultDeclarationCutoff).NextNotElapsed()
+	if height >= dlInfo.Open-miner.WPoStChallengeWindow() {
+		return false
+	}
+	return height >= dlInfo.Close-miner.WPoStProvingPeriod()+miner.WPoStDisputeWindow()
+}
+
+// compactablePartitions returns the partitions of dl without faulty nor unproven sectors whose ratio of terminated
+// sectors is at least minDeadRatio, with the numbers of their live and terminated sectors.
+func compactablePartitions(dl miner.Deadline, minDeadRatio float64) ([]uint64, uint64, uint64, error) {
+	var partitions []uint64
+	var liveSectors, deadSectors uint64
+	if err := dl.ForEachPartition(func(partIdx uint64, part miner.Partition) error {
+		faults, err := part.FaultySectors()
+		if err != nil {
+			return err
+		}
+		unproven, err := part.UnprovenSectors()
+		if err != nil {
+			return err
+		}
+		if empty, err := faults.IsEmpty(); err != nil || !empty {
+			return err
+		}
+		if empty, err := unproven.IsEmpty(); err != nil || !empty {
+			return err
+		}
+
+		all, err := part.AllSectors()
+		if err != nil {
+			return err
+		}
+		live, err := part.LiveSectors()
+		if err != nil {
+			return err
+		}
+		allCount, err := all.Count()
+		if err != nil {
+			return err
+		}
+		liveCount, err := live.Count()
+		if err != nil {
+			return err
+		}
+		if allCount == 0 || float64(allCount-liveCount)/float64(allCount) < minDeadRatio {
+			return nil
+		}
+
+		partitions = append(partitions, partIdx)
+		liveSectors += liveCount
+		deadSectors += allCount - liveCount
+		return nil
+	}); err != nil {
+		return nil, 0, 0, err
+	}
+	return partitions, liveSectors, deadSectors, nil
+}
+
 // StateMinerSectors returns info about the given miner's sectors. If the filter bitfield is nil, all sectors are included.
 func (msa *minerStateAPI) StateMinerSectors(ctx context.Context, maddr address.Address, sectorNos *bitfield.BitField, tsk types.TipSetKey) ([]*miner.SectorOnChainInfo, error) {
 	_, view, err := msa.Stmgr.ParentStateViewTsk(ctx, tsk)
@@ -346,7 +559,7 @@ func (msa *minerStateAPI) StateMinerSectors(ctx context.Context, maddr address.A
 
 	mas, err := view.LoadMinerState(ctx, maddr)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load miner actor state: %v", err)
+		return nil, fmt.Errorf("failed to load miner actor state: %w", err)
 	}
 
 	return mas.LoadSectors(sectorNos)
@@ -361,7 +574,7 @@ func (msa *minerStateAPI) StateMarketStorageDeal(ctx context.Context, dealID abi
 
 	mas, err := view.LoadMarketState(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load miner actor state: %v", err)
+		return nil, fmt.Errorf("failed to load miner actor state: %w", err)
 	}
 
 	proposals, err := mas.Proposals()
@@ -397,6 +610,110 @@ func (msa *minerStateAPI) StateMarketStorageDeal(ctx context.Context, dealID abi
 	}, nil
 }
 
+// StateFindSectorForDeal returns the sector of the miner holding the deal. The activated deals record their sector
+// since the market actor v13, the sectors of the deals activated before are searched in the sectors of the miner.
+func (msa *minerStateAPI) StateFindSectorForDeal(ctx context.Context, maddr address.Address, dealID abi.DealID, tsk types.TipSetKey) (*types.DealSector, error) {
+	_, view, err := msa.Stmgr.ParentStateViewTsk(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("Stmgr.ParentStateViewTsk failed:%v", err)
+	}
+
+	provider, err := view.LookupID(ctx, maddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve miner address %s: %v", maddr, err)
+	}
+
+	marketState, err := view.LoadMarketState(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load market actor state: %w", err)
+	}
+	proposals, err := marketState.Proposals()
+	if err != nil {
+		return nil, err
+	}
+	proposal, found, err := proposals.Get(dealID)
+	if err != nil {
+		return nil, err
+	} else if !found {
+		return nil, fmt.Errorf("deal %d not found", dealID)
+	}
+	if proposal.Provider != provider {
+		return nil, fmt.Errorf("deal %d is made with provider %s, not %s", dealID, proposal.Provider, maddr)
+	}
+
+	states, err := marketState.States()
+	if err != nil {
+		return nil, err
+	}
+	st, activated, err := states.Get(dealID)
+	if err != nil {
+		return nil, err
+	}
+
+	minerState, err := view.LoadMinerState(ctx, provider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load miner actor state: %w", err)
+	}
+	return dealSector(minerState, maddr, dealID, st, activated, marketState.ActorVersion() < actorstypes.Version13)
+}
+
+// dealSector returns the sector of the miner state mas holding the deal, st being the state of the activated deal.
+// The sectors of the deals activated before the deals recorded their sector are searched in the sectors of the miner.
+func dealSector(mas miner.State, maddr address.Address, dealID abi.DealID, st market.DealState, activated, searchSectors bool) (*types.DealSector, error) {
+	if !activated {
+		var out *types.DealSector
+		if err := mas.ForEachPrecommittedSector(func(pci miner.SectorPreCommitOnChainInfo) error {
+			for _, id := range pci.Info.DealIDs {
+				if id == dealID {
+					out = &types.DealSector{Sector: pci.Info.SectorNumber}
+				}
+			}
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("loading the precommitted sectors: %v", err)
+		}
+		if out == nil {
+			return nil, fmt.Errorf("deal %d is not in a sector of %s", dealID, maddr)
+		}
+		return out, nil
+	}
+
+	out := &types.DealSector{Sector: st.SectorNumber(), Activated: true}
+	if searchSectors {
+		sectors, err := mas.LoadSectors(nil)
+		if err != nil {
+			return nil, fmt.Errorf("loading the sectors: %v", err)
+		}
+		found := false
+		for _, sector := range sectors {
+			for _, id := range sector.DeprecatedDealIDs {
+				if id == dealID {
+					out.Sector = sector.SectorNumber
+					found = true
+				}
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("deal %d is not in a live sector of %s", dealID, maddr)
+		}
+	}
+
+	// the terminated sectors lose their info and may be compacted out of their partition
+	info, err := mas.GetSector(out.Sector)
+	if err != nil {
+		return nil, fmt.Errorf("loading sector %d: %v", out.Sector, err)
+	}
+	if info == nil {
+		return out, nil
+	}
+	loc, err := mas.FindSector(out.Sector)
+	if err != nil {
+		return nil, fmt.Errorf("locating sector %d: %v", out.Sector, err)
+	}
+	out.Location = &types.SectorPartition{Deadline: loc.Deadline, Partition: loc.Partition}
+	return out, nil
+}
+
 func (msa *minerStateAPI) StateGetAllocationIdForPendingDeal(ctx context.Context, dealID abi.DealID, tsk types.TipSetKey) (verifreg.AllocationId, error) {
 	_, view, err := msa.Stmgr.ParentStateViewTsk(ctx, tsk)
 	if err != nil {
@@ -405,7 +722,7 @@ func (msa *minerStateAPI) StateGetAllocationIdForPendingDeal(ctx context.Context
 
 	mas, err := view.LoadMarketState(ctx)
 	if err != nil {
-		return verifreg.NoAllocationID, fmt.Errorf("failed to load miner actor state: %v", err)
+		return verifreg.NoAllocationID, fmt.Errorf("failed to load miner actor state: %w", err)
 	}
 
 	allocationID, err := mas.GetAllocationIdForPendingDeal(dealID)
@@ -450,7 +767,7 @@ func (msa *minerStateAPI) StateGetAllocation(ctx context.Context, clientAddr add
 
 	st, err := view.LoadVerifregActor(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load verifreg actor state: %v", err)
+		return nil, fmt.Errorf("failed to load verifreg actor state: %w", err)
 	}
 
 	allocation, found, err := st.GetAllocation(idAddr, allocationID)
@@ -472,7 +789,7 @@ func (msa *minerStateAPI) StateGetAllAllocations(ctx context.Context, tsk types.
 
 	st, err := view.LoadVerifregActor(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load verifreg actor state: %v", err)
+		return nil, fmt.Errorf("failed to load verifreg actor state: %w", err)
 	}
 
 	allocations, err := st.GetAllAllocations()
@@ -497,7 +814,7 @@ func (msa *minerStateAPI) StateGetAllocations(ctx context.Context, clientAddr ad
 
 	st, err := view.LoadVerifregActor(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load verifreg actor state: %v", err)
+		return nil, fmt.Errorf("failed to load verifreg actor state: %w", err)
 	}
 
 	allocations, err := st.GetAllocations(idAddr)
@@ -522,7 +839,7 @@ func (msa *minerStateAPI) StateGetClaim(ctx context.Context, providerAddr addres
 
 	st, err := view.LoadVerifregActor(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load verifreg actor state: %v", err)
+		return nil, fmt.Errorf("failed to load verifreg actor state: %w", err)
 	}
 
 	claim, found, err := st.GetClaim(idAddr, claimID)
@@ -550,7 +867,7 @@ func (msa *minerStateAPI) StateGetClaims(ctx context.Context, providerAddr addre
 
 	st, err := view.LoadVerifregActor(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load verifreg actor state: %v", err)
+		return nil, fmt.Errorf("failed to load verifreg actor state: %w", err)
 	}
 
 	claims, err := st.GetClaims(idAddr)
@@ -569,7 +886,7 @@ func (msa *minerStateAPI) StateGetAllClaims(ctx context.Context, tsk types.TipSe
 
 	st, err := view.LoadVerifregActor(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load verifreg actor state: %v", err)
+		return nil, fmt.Errorf("failed to load verifreg actor state: %w", err)
 	}
 
 	claims, err := st.GetAllClaims()
@@ -773,66 +1090,180 @@ func (msa *minerStateAPI) pledgeCalculationInputs(ctx context.Context, state tre
 
 // StateMinerPreCommitDepositForPower returns the precommit deposit for the specified miner's sector
 func (msa *minerStateAPI) StateMinerPreCommitDepositForPower(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (big.Int, error) {
+	breakdowns, err := msa.StateMinerPreCommitDepositForPowerBatch(ctx, maddr, []types.SectorPreCommitInfo{pci}, tsk)
+	if err != nil {
+		return types.EmptyInt, err
+	}
+	return breakdowns[0].Deposit, nil
+}
+
+// StateMinerPreCommitDepositForPowerBatch returns the precommit deposit of each sector and the inputs of its calculation
+func (msa *minerStateAPI) StateMinerPreCommitDepositForPowerBatch(ctx context.Context, maddr address.Address, pcis []types.SectorPreCommitInfo, tsk types.TipSetKey) ([]types.PreCommitDepositBreakdown, error) {
 	ts, err := msa.ChainReader.GetTipSet(ctx, tsk)
 	if err != nil {
-		return big.Int{}, err
+		return nil, err
 	}
 
 	var sTree *tree.State
 	_, sTree, err = msa.Stmgr.ParentState(ctx, ts)
 	if err != nil {
-		return big.Int{}, fmt.Errorf("ParentState failed:%v", err)
+		return nil, fmt.Errorf("ParentState failed:%v", err)
 	}
 
 	rewardActor, found, err := sTree.GetActor(ctx, reward.Address)
 	if err != nil {
-		return types.EmptyInt, fmt.Errorf("loading reward actor: %w", err)
+		return nil, fmt.Errorf("loading reward actor: %w", err)
 	}
 	if !found {
-		return types.EmptyInt, fmt.Errorf("reward actor not found")
+		return nil, fmt.Errorf("reward actor not found")
 	}
 
 	rewardState, err := reward.Load(msa.ChainReader.Store(ctx), rewardActor)
 	if err != nil {
-		return types.EmptyInt, fmt.Errorf("loading reward actor state: %w", err)
+		return nil, fmt.Errorf("loading reward actor state: %w", err)
+	}
+	rewardSmoothed, err := rewardState.ThisEpochRewardSmoothed()
+	if err != nil {
+		return nil, fmt.Errorf("loading smoothed reward: %w", err)
+	}
+	baselinePower, err := rewardState.ThisEpochBaselinePower()
+	if err != nil {
+		return nil, fmt.Errorf("loading baseline power: %w", err)
 	}
 
-	var sectorWeight abi.StoragePower
-	if msa.ChainSubmodule.Fork.GetNetworkVersion(ctx, ts.Height()) <= network.Version16 {
-		if sectorWeight, err = msa.calculateSectorWeight(ctx, maddr, pci, ts.Height(), sTree); err != nil {
-			return types.EmptyInt, err
+	_, powerSmoothed, err := msa.pledgeCalculationInputs(ctx, sTree)
+	if err != nil {
+		return nil, err
+	}
+
+	nv := msa.ChainSubmodule.Fork.GetNetworkVersion(ctx, ts.Height())
+	breakdowns := make([]types.PreCommitDepositBreakdown, 0, len(pcis))
+	for _, pci := range pcis {
+		var sectorWeight abi.StoragePower
+		if nv <= network.Version16 {
+			if sectorWeight, err = msa.calculateSectorWeight(ctx, maddr, pci, ts.Height(), sTree); err != nil {
+				return nil, fmt.Errorf("sector %d: %w", pci.SectorNumber, err)
+			}
+		} else {
+			ssize, err := pci.SealProof.SectorSize()
+			if err != nil {
+				return nil, fmt.Errorf("sector %d: failed to resolve sector size for seal proof: %w", pci.SectorNumber, err)
+			}
+			sectorWeight = miner.QAPowerMax(ssize)
 		}
-	} else {
-		ssize, err := pci.SealProof.SectorSize()
+
+		deposit, err := rewardState.PreCommitDepositForPower(*powerSmoothed, sectorWeight)
 		if err != nil {
-			return types.EmptyInt, fmt.Errorf("failed to resolve sector size for seal proof: %w", err)
+			return nil, fmt.Errorf("sector %d: calculating precommit deposit: %w", pci.SectorNumber, err)
 		}
-		sectorWeight = miner.QAPowerMax(ssize)
+
+		breakdowns = append(breakdowns, types.PreCommitDepositBreakdown{
+			SectorNumber:            pci.SectorNumber,
+			Deposit:                 types.BigDiv(types.BigMul(deposit, initialPledgeNum), initialPledgeDen),
+			SectorWeight:            sectorWeight,
+			QualityAdjPowerSmoothed: *powerSmoothed,
+			RewardSmoothed:          rewardSmoothed,
+			BaselinePower:           baselinePower,
+		})
+	}
+
+	return breakdowns, nil
+}
+
+// StateSectorPenaltyForFaults estimates the fee charged for each WindowPoSt missed by the sectors
+func (msa *minerStateAPI) StateSectorPenaltyForFaults(ctx context.Context, maddr address.Address, sectors bitfield.BitField, tsk types.TipSetKey) (*types.SectorFaultPenalty, error) {
+	ts, err := msa.ChainReader.GetTipSet(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("loading tipset %s: %w", tsk, err)
+	}
+
+	_, sTree, err := msa.Stmgr.ParentState(ctx, ts)
+	if err != nil {
+		return nil, fmt.Errorf("loading tipset(%s) parent state failed: %w", tsk, err)
+	}
+	store := msa.ChainReader.Store(ctx)
+
+	minerActor, found, err := sTree.GetActor(ctx, maddr)
+	if err != nil {
+		return nil, fmt.Errorf("loading miner actor: %w", err)
+	}
+	if !found {
+		return nil, fmt.Errorf("miner actor %s not found", maddr)
+	}
+	mas, err := miner.Load(store, minerActor)
+	if err != nil {
+		return nil, fmt.Errorf("loading miner actor state: %w", err)
+	}
+
+	rewardActor, found, err := sTree.GetActor(ctx, reward.Address)
+	if err != nil {
+		return nil, fmt.Errorf("loading reward actor: %w", err)
+	}
+	if !found {
+		return nil, fmt.Errorf("reward actor not found")
+	}
+	rewardState, err := reward.Load(store, rewardActor)
+	if err != nil {
+		return nil, fmt.Errorf("loading reward actor state: %w", err)
+	}
+	rewardSmoothed, err := rewardState.ThisEpochRewardSmoothed()
+	if err != nil {
+		return nil, fmt.Errorf("loading smoothed reward: %w", err)
 	}
 
 	_, powerSmoothed, err := msa.pledgeCalculationInputs(ctx, sTree)
 	if err != nil {
-		return types.EmptyInt, err
+		return nil, err
+	}
+
+	var penalized, missing []uint64
+	qaPower := big.Zero()
+	err = sectors.ForEach(func(sno uint64) error {
+		sector, err := mas.GetSector(abi.SectorNumber(sno))
+		if err != nil {
+			return fmt.Errorf("loading sector %d: %w", sno, err)
+		}
+		if sector == nil {
+			missing = append(missing, sno)
+			return nil
+		}
+		ssize, err := sector.SealProof.SectorSize()
+		if err != nil {
+			return fmt.Errorf("failed to resolve sector size of sector %d: %w", sno, err)
+		}
+		qaPower = big.Add(qaPower, builtin.QAPowerForWeight(ssize, sector.Expiration-sector.PowerBaseEpoch, sector.VerifiedDealWeight))
+		penalized = append(penalized, sno)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	deposit, err := rewardState.PreCommitDepositForPower(*powerSmoothed, sectorWeight)
+	penalty, err := policy.PledgePenaltyForContinuedFault(msa.Fork.GetNetworkVersion(ctx, ts.Height()), rewardSmoothed, *powerSmoothed, qaPower)
 	if err != nil {
-		return types.EmptyInt, fmt.Errorf("calculating precommit deposit: %w", err)
+		return nil, fmt.Errorf("computing the fault fee: %w", err)
 	}
 
-	return types.BigDiv(types.BigMul(deposit, initialPledgeNum), initialPledgeDen), nil
+	return &types.SectorFaultPenalty{
+		Sectors:                 bitfield.NewFromSet(penalized),
+		Missing:                 bitfield.NewFromSet(missing),
+		QAPower:                 qaPower,
+		Penalty:                 penalty,
+		RewardSmoothed:          rewardSmoothed,
+		QualityAdjPowerSmoothed: *powerSmoothed,
+	}, nil
 }
 
 // StateMinerInitialPledgeCollateral returns the initial pledge collateral for the specified miner's sector
 func (msa *minerStateAPI) StateMinerInitialPledgeCollateral(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (big.Int, error) {
 	ts, err := msa.ChainReader.GetTipSet(ctx, tsk)
 	if err != nil {
-		return big.Int{}, fmt.Errorf("loading tipset %s: %v", tsk, err)
+		return big.Int{}, fmt.Errorf("loading tipset %s: %w", tsk, err)
 	}
 
 	_, state, err := msa.Stmgr.ParentState(ctx, ts)
 	if err != nil {
-		return big.Int{}, fmt.Errorf("loading tipset(%s) parent state failed: %v", tsk, err)
+		return big.Int{}, fmt.Errorf("loading tipset(%s) parent state failed: %w", tsk, err)
 	}
 
 	rewardActor, found, err := state.GetActor(ctx, reward.Address)
@@ -915,7 +1346,7 @@ func (msa *minerStateAPI) StateMinerInitialPledgeForSector(ctx context.Context,
 
 	_, state, err := msa.Stmgr.ParentState(ctx, ts)
 	if err != nil {
-		return big.Int{}, fmt.Errorf("loading tipset(%s) parent state failed: %v", tsk, err)
+		return big.Int{}, fmt.Errorf("loading tipset(%s) parent state failed: %w", tsk, err)
 	}
 
 	rewardActor, found, err := state.GetActor(ctx, reward.Address)
@@ -1091,19 +1522,109 @@ func (msa *minerStateAPI) StateListMiners(ctx context.Context, tsk types.TipSetK
 	return view.StateListMiners(ctx, tsk)
 }
 
+// the largest page of StateListActorsPage
+const maxActorsPage = 10000
+
+// errPageFull stops the iteration of the state tree once a page is full
+var errPageFull = errors.New("page full")
+
+// StateListActorsPage returns up to limit actors selected by filter following the cursor, in the order of the state
+// tree, the pages of a listing must be read at the same tipset with the same filter
+func (msa *minerStateAPI) StateListActorsPage(ctx context.Context, filter types.ActorFilter, cursor address.Address, limit int, tsk types.TipSetKey) (*types.ActorsPage, error) {
+	if limit <= 0 || limit > maxActorsPage {
+		return nil, fmt.Errorf("limit must be in [1, %d], got %d", maxActorsPage, limit)
+	}
+	match, err := actorFilterMatcher(filter)
+	if err != nil {
+		return nil, err
+	}
+	_, stat, err := msa.Stmgr.TipsetStateTsk(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("load tipset state from key:%s failed:%w", tsk.String(), err)
+	}
+
+	page, err := listActorsPage(ctx, stat, match, cursor, limit)
+	if errors.Is(err, tree.ErrCursorNotFound) {
+		return nil, fmt.Errorf("cursor %s is not an actor at %s", cursor, tsk)
+	}
+	return page, err
+}
+
+// listActorsPage returns up to limit actors of st selected by match following the cursor, the state is
+// seeked to the cursor so that every page costs the same.
+func listActorsPage(ctx context.Context, st *tree.State, match func(code cid.Cid) bool, cursor address.Address, limit int) (*types.ActorsPage, error) {
+	out := &types.ActorsPage{Actors: make([]address.Address, 0, limit)}
+	collect := func(addr tree.ActorKey, act *types.Actor) error {
+		if !match(act.Code) {
+			return nil
+		}
+		if len(out.Actors) == limit {
+			out.Next = out.Actors[limit-1]
+			return errPageFull
+		}
+		out.Actors = append(out.Actors, addr)
+		return nil
+	}
+
+	var err error
+	if cursor.Empty() {
+		err = st.ForEach(collect)
+	} else {
+		err = st.ForEachFrom(ctx, cursor, collect)
+	}
+	if err != nil && !errors.Is(err, errPageFull) {
+		return nil, err
+	}
+	return out, nil
+}
+
+// actorFilterMatcher returns whether the actors of a code are selected by filter.
+func actorFilterMatcher(filter types.ActorFilter) (func(code cid.Cid) bool, error) {
+	if len(filter.Codes) == 0 && len(filter.Types) == 0 {
+		return func(cid.Cid) bool { return true }, nil
+	}
+
+	codes := make(map[cid.Cid]struct{}, len(filter.Codes))
+	for _, c := range filter.Codes {
+		codes[c] = struct{}{}
+	}
+	names := make(map[string]struct{}, len(filter.Types))
+	for _, name := range filter.Types {
+		if !slices.Contains(manifest.GetBuiltinActorsKeys(actorstypes.Version(actors.LatestVersion)), name) {
+			return nil, fmt.Errorf("unknown actor type %q", name)
+		}
+		names[name] = struct{}{}
+	}
+
+	// the names of the codes are cached, the state holds a few codes per actor type
+	known := make(map[cid.Cid]bool)
+	return func(code cid.Cid) bool {
+		if _, ok := codes[code]; ok {
+			return true
+		}
+		if len(names) == 0 {
+			return false
+		}
+		selected, ok := known[code]
+		if !ok {
+			_, selected = names[actors.CanonicalName(builtin.ActorNameByCode(code))]
+			known[code] = selected
+		}
+		return selected
+	}, nil
+}
+
 // StateListActors returns the addresses of every actor in the state
 func (msa *minerStateAPI) StateListActors(ctx context.Context, tsk types.TipSetKey) ([]address.Address, error) {
-	_, stat, err := msa.Stmgr.TipsetStateTsk(ctx, tsk)
+	walk, err := msa.ActorsWalk(ctx, tsk)
 	if err != nil {
-		return nil, fmt.Errorf("load tipset state from key:%s failed:%v",
-			tsk.String(), err)
+		return nil, err
 	}
 	var out []address.Address
-	err = stat.ForEach(func(addr tree.ActorKey, act *types.Actor) error {
+	if err := walk(func(addr address.Address) error {
 		out = append(out, addr)
 		return nil
-	})
-	if err != nil {
+	}); err != nil {
 		return nil, err
 	}
 
@@ -1132,7 +1653,7 @@ func (msa *minerStateAPI) StateMinerPower(ctx context.Context, addr address.Addr
 func (msa *minerStateAPI) StateMinerAvailableBalance(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (big.Int, error) {
 	ts, err := msa.ChainReader.GetTipSet(ctx, tsk)
 	if err != nil {
-		return big.Int{}, fmt.Errorf("failed to get tipset for %s, %v", tsk.String(), err)
+		return big.Int{}, fmt.Errorf("failed to get tipset for %s, %w", tsk.String(), err)
 	}
 	_, view, err := msa.Stmgr.ParentStateView(ctx, ts)
 	if err != nil {
@@ -1163,37 +1684,7 @@ func (msa *minerStateAPI) StateMinerSectorCount(ctx context.Context, addr addres
 	if err != nil {
 		return types.MinerSectors{}, err
 	}
-
-	var activeCount, liveCount, faultyCount uint64
-	if err := mas.ForEachDeadline(func(_ uint64, dl miner.Deadline) error {
-		return dl.ForEachPartition(func(_ uint64, part miner.Partition) error {
-			if active, err := part.ActiveSectors(); err != nil {
-				return err
-			} else if count, err := active.Count(); err != nil {
-				return err
-			} else {
-				activeCount += count
-			}
-			if live, err := part.LiveSectors(); err != nil {
-				return err
-			} else if count, err := live.Count(); err != nil {
-				return err
-			} else {
-				liveCount += count
-			}
-			if faulty, err := part.FaultySectors(); err != nil {
-				return err
-			} else if count, err := faulty.Count(); err != nil {
-				return err
-			} else {
-				faultyCount += count
-			}
-			return nil
-		})
-	}); err != nil {
-		return types.MinerSectors{}, err
-	}
-	return types.MinerSectors{Live: liveCount, Active: activeCount, Faulty: faultyCount}, nil
+	return msa.sectorCountCache.MinerSectors(mas)
 }
 
 // StateMarketBalance looks up the Escrow and Locked balances of the given address in the Storage Market
@@ -1251,12 +1742,12 @@ func (msa *minerStateAPI) StateDealProviderCollateralBounds(ctx context.Context,
 
 	pst, err := view.LoadPowerState(ctx)
 	if err != nil {
-		return types.DealCollateralBounds{}, fmt.Errorf("failed to load power actor state: %v", err)
+		return types.DealCollateralBounds{}, fmt.Errorf("failed to load power actor state: %w", err)
 	}
 
 	rst, err := view.LoadRewardState(ctx)
 	if err != nil {
-		return types.DealCollateralBounds{}, fmt.Errorf("failed to load reward actor state: %v", err)
+		return types.DealCollateralBounds{}, fmt.Errorf("failed to load reward actor state: %w", err)
 	}
 
 	circ, err := msa.StateVMCirculatingSupplyInternal(ctx, ts.Key())
@@ -1364,7 +1855,7 @@ func (msa *minerStateAPI) StateChangedActors(ctx context.Context, old cid.Cid, n
 func (msa *minerStateAPI) StateReadState(ctx context.Context, actor address.Address, tsk types.TipSetKey) (*types.ActorState, error) {
 	_, view, err := msa.Stmgr.ParentStateViewTsk(ctx, tsk)
 	if err != nil {
-		return nil, fmt.Errorf("loading tipset:%s parent state view: %v", tsk, err)
+		return nil, fmt.Errorf("loading tipset:%s parent state view: %w", tsk, err)
 	}
 
 	act, err := view.LoadActor(ctx, actor)
@@ -1392,7 +1883,7 @@ func (msa *minerStateAPI) StateReadState(ctx context.Context, actor address.Addr
 func (msa *minerStateAPI) StateDecodeParams(ctx context.Context, toAddr address.Address, method abi.MethodNum, params []byte, tsk types.TipSetKey) (interface{}, error) {
 	_, view, err := msa.Stmgr.ParentStateViewTsk(ctx, tsk)
 	if err != nil {
-		return nil, fmt.Errorf("loading tipset:%s parent state view: %v", tsk, err)
+		return nil, fmt.Errorf("loading tipset:%s parent state view: %w", tsk, err)
 	}
 
 	act, err := view.LoadActor(ctx, toAddr)
@@ -1400,6 +1891,18 @@ func (msa *minerStateAPI) StateDecodeParams(ctx context.Context, toAddr address.
 		return nil, err
 	}
 
+	contractABI, err := msa.contractABI(ctx, toAddr, act, method, tsk)
+	if err != nil {
+		return nil, err
+	}
+	if contractABI != nil {
+		callData, err := decodeCborBytes(params)
+		if err != nil {
+			return nil, err
+		}
+		return contractABI.DecodeParams(callData)
+	}
+
 	methodMeta, found := utils.MethodsMap[act.Code][method]
 	if !found {
 		return nil, fmt.Errorf("method %d not found on actor %s", method, act.Code)
@@ -1414,24 +1917,94 @@ func (msa *minerStateAPI) StateDecodeParams(ctx context.Context, toAddr address.
 	return paramType, nil
 }
 
-func (msa *minerStateAPI) StateEncodeParams(ctx context.Context, toActCode cid.Cid, method abi.MethodNum, params json.RawMessage) ([]byte, error) {
-	methodMeta, found := utils.MethodsMap[toActCode][method]
+func (msa *minerStateAPI) StateDecodeReturn(ctx context.Context, toAddr address.Address, method abi.MethodNum, params []byte, ret []byte, tsk types.TipSetKey) (interface{}, error) {
+	_, view, err := msa.Stmgr.ParentStateViewTsk(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("loading tipset:%s parent state view: %w", tsk, err)
+	}
+
+	act, err := view.LoadActor(ctx, toAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	contractABI, err := msa.contractABI(ctx, toAddr, act, method, tsk)
+	if err != nil {
+		return nil, err
+	}
+	if contractABI != nil {
+		callData, err := decodeCborBytes(params)
+		if err != nil {
+			return nil, err
+		}
+		retData, err := decodeCborBytes(ret)
+		if err != nil {
+			return nil, err
+		}
+		return contractABI.DecodeReturn(callData, retData)
+	}
+
+	methodMeta, found := utils.MethodsMap[act.Code][method]
 	if !found {
-		return nil, fmt.Errorf("method %d not found on actor %s", method, toActCode)
+		return nil, fmt.Errorf("method %d not found on actor %s", method, act.Code)
 	}
 
-	paramType := reflect.New(methodMeta.Params.Elem()).Interface().(cbg.CBORUnmarshaler)
+	retType := reflect.New(methodMeta.Ret.Elem()).Interface().(cbg.CBORUnmarshaler)
+
+	if err = retType.UnmarshalCBOR(bytes.NewReader(ret)); err != nil {
+		return nil, err
+	}
+
+	return retType, nil
+}
+
+func (msa *minerStateAPI) StateRegisterContractABI(ctx context.Context, contract address.Address, contractABI json.RawMessage) error {
+	_, view, err := msa.Stmgr.ParentStateViewTsk(ctx, types.EmptyTSK)
+	if err != nil {
+		return fmt.Errorf("loading head parent state view: %v", err)
+	}
+	act, err := view.LoadActor(ctx, contract)
+	if err != nil {
+		return err
+	}
+	if !builtin.IsEvmActor(act.Code) {
+		return fmt.Errorf("%s is not an evm contract", contract)
+	}
+	id, err := view.LookupID(ctx, contract)
+	if err != nil {
+		return err
+	}
+	return msa.ABIRegistry.Register(ctx, id, contractABI)
+}
 
-	if err := json.Unmarshal(params, &paramType); err != nil {
-		return nil, fmt.Errorf("json unmarshal: %w", err)
+// contractABI returns the abi registered for the evm contract called by the message, nil when
+// the message is not a contract call or no abi is registered for the contract.
+func (msa *minerStateAPI) contractABI(ctx context.Context, toAddr address.Address, act *types.Actor, method abi.MethodNum, tsk types.TipSetKey) (*ethabi.ABI, error) {
+	if method != evm.Methods.InvokeContract || !builtin.IsEvmActor(act.Code) {
+		return nil, nil
+	}
+	id, err := msa.ChainSubmodule.API().StateLookupID(ctx, toAddr, tsk)
+	if err != nil {
+		return nil, err
 	}
+	contractABI, err := msa.ABIRegistry.Get(ctx, id)
+	if errors.Is(err, ethabi.ErrNoABI) {
+		return nil, nil
+	}
+	return contractABI, err
+}
 
-	var cbb bytes.Buffer
-	if err := paramType.(cbor.Marshaler).MarshalCBOR(&cbb); err != nil {
-		return nil, fmt.Errorf("cbor marshal: %w", err)
+// decodeCborBytes unwraps the call data and the return of the evm contract calls.
+func decodeCborBytes(data []byte) ([]byte, error) {
+	var b abi.CborBytes
+	if err := b.UnmarshalCBOR(bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("decoding the evm call data: %w", err)
 	}
+	return b, nil
+}
 
-	return cbb.Bytes(), nil
+func (msa *minerStateAPI) StateEncodeParams(ctx context.Context, toActCode cid.Cid, method abi.MethodNum, params json.RawMessage) ([]byte, error) {
+	return utils.EncodeParams(toActCode, method, params)
 }
 
 func (msa *minerStateAPI) StateListMessages(ctx context.Context, match *types.MessageMatch, tsk types.TipSetKey, toheight abi.ChainEpoch) ([]cid.Cid, error) {
@@ -1444,8 +2017,8 @@ func (msa *minerStateAPI) StateListMessages(ctx context.Context, match *types.Me
 		ts = msa.ChainReader.GetHead()
 	}
 
-	if match.To == address.Undef && match.From == address.Undef {
-		return nil, fmt.Errorf("must specify at least To or From in message filter")
+	if match.To == address.Undef && match.From == address.Undef && match.ToActorType == "" && match.Method == nil {
+		return nil, fmt.Errorf("must specify at least To, From, ToActorType or Method in message filter")
 	} else if match.To != address.Undef {
 		_, err := msa.StateLookupID(ctx, match.To, tsk)
 
@@ -1470,6 +2043,16 @@ func (msa *minerStateAPI) StateListMessages(ctx context.Context, match *types.Me
 		}
 	}
 
+	if msa.messageIndex != nil {
+		out, ok, err := msa.messageIndex.listMessages(ctx, match, ts, toheight)
+		if err != nil {
+			return nil, fmt.Errorf("listing the messages from the index: %w", err)
+		}
+		if ok {
+			return out, nil
+		}
+	}
+
 	// TODO: This should probably match on both ID and robust address, no?
 	matchFunc := func(msg *types.Message) bool {
 		if match.From != address.Undef && match.From != msg.From {
@@ -1480,6 +2063,10 @@ func (msa *minerStateAPI) StateListMessages(ctx context.Context, match *types.Me
 			return false
 		}
 
+		if match.Method != nil && *match.Method != msg.Method {
+			return false
+		}
+
 		return true
 	}
 
@@ -1490,10 +2077,26 @@ func (msa *minerStateAPI) StateListMessages(ctx context.Context, match *types.Me
 			return nil, fmt.Errorf("failed to get messages for tipset (%s): %w", ts.Key(), err)
 		}
 
+		var toTypes *recipientTypes
+		if match.ToActorType != "" {
+			if toTypes, err = newRecipientTypes(ctx, msa.ChainSubmodule, ts); err != nil {
+				return nil, err
+			}
+		}
 		for _, msg := range msgs {
-			if matchFunc(msg.VMMessage()) {
-				out = append(out, msg.Cid())
+			if !matchFunc(msg.VMMessage()) {
+				continue
+			}
+			if toTypes != nil {
+				toType, err := toTypes.get(ctx, msg.VMMessage().To)
+				if err != nil {
+					return nil, err
+				}
+				if toType != match.ToActorType {
+					continue
+				}
 			}
+			out = append(out, msg.Cid())
 		}
 
 		if ts.Height() == 0 {
@@ -1515,7 +2118,7 @@ func (msa *minerStateAPI) StateListMessages(ctx context.Context, match *types.Me
 func (msa *minerStateAPI) StateMinerAllocated(ctx context.Context, addr address.Address, tsk types.TipSetKey) (*bitfield.BitField, error) {
 	_, view, err := msa.Stmgr.ParentStateViewTsk(ctx, tsk)
 	if err != nil {
-		return nil, fmt.Errorf("loading tipset:%s parent state view: %v", tsk, err)
+		return nil, fmt.Errorf("loading tipset:%s parent state view: %w", tsk, err)
 	}
 
 	act, err := view.LoadActor(ctx, addr)
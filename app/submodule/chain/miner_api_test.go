@@ -0,0 +1,399 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/dline"
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/state/tree"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/market"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/miner"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestListActorsPage(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	cst := cbor.NewMemCborStore()
+	st, err := tree.NewState(cst, tree.StateTreeVersion5)
+	require.NoError(t, err)
+
+	even, err := cid.Decode("bafy2bzacecu7n7wbtogznrtuuvf73dsz7wasgyneqasksdblxupnyovmtwxxu")
+	require.NoError(t, err)
+	odd, err := cid.Decode("bafy2bzaceamis23jp44ofm4fh6jwc4gkxlzhnvxrdw4zsn3v2fj6at6pf2m4y")
+	require.NoError(t, err)
+	for i := 0; i < 250; i++ {
+		addr, err := address.NewIDAddress(uint64(1000 + i))
+		require.NoError(t, err)
+		code := even
+		if i%2 == 1 {
+			code = odd
+		}
+		require.NoError(t, st.SetActor(ctx, addr, &types.Actor{Code: code, Head: code, Balance: abi.NewTokenAmount(0)}))
+	}
+	root, err := st.Flush(ctx)
+	require.NoError(t, err)
+	st, err = tree.LoadState(ctx, cst, root)
+	require.NoError(t, err)
+
+	var all []address.Address
+	require.NoError(t, st.ForEach(func(addr tree.ActorKey, act *types.Actor) error {
+		if act.Code == even {
+			all = append(all, addr)
+		}
+		return nil
+	}))
+	require.Len(t, all, 125)
+
+	matchEven := func(code cid.Cid) bool { return code == even }
+	t.Run("cursor", func(t *testing.T) {
+		var listed []address.Address
+		cursor := address.Undef
+		for pages := 0; ; pages++ {
+			require.Less(t, pages, 13)
+			page, err := listActorsPage(ctx, st, matchEven, cursor, 10)
+			require.NoError(t, err)
+			listed = append(listed, page.Actors...)
+			if page.Next.Empty() {
+				break
+			}
+			assert.Len(t, page.Actors, 10)
+			assert.Equal(t, page.Actors[9], page.Next)
+			cursor = page.Next
+		}
+		assert.Equal(t, all, listed)
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		page, err := listActorsPage(ctx, st, matchEven, all[119], 10)
+		require.NoError(t, err)
+		assert.Equal(t, all[120:], page.Actors)
+		assert.True(t, page.Next.Empty())
+
+		// a full last page has no next page either
+		page, err = listActorsPage(ctx, st, matchEven, all[114], 10)
+		require.NoError(t, err)
+		assert.Equal(t, all[115:], page.Actors)
+		assert.True(t, page.Next.Empty())
+
+		page, err = listActorsPage(ctx, st, matchEven, all[124], 10)
+		require.NoError(t, err)
+		assert.Empty(t, page.Actors)
+		assert.True(t, page.Next.Empty())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		page, err := listActorsPage(ctx, st, func(cid.Cid) bool { return false }, address.Undef, 10)
+		require.NoError(t, err)
+		assert.Empty(t, page.Actors)
+		assert.True(t, page.Next.Empty())
+
+		missing, err := address.NewIDAddress(1)
+		require.NoError(t, err)
+		_, err = listActorsPage(ctx, st, matchEven, missing, 10)
+		assert.ErrorIs(t, err, tree.ErrCursorNotFound)
+	})
+}
+
+// testMinerState is a miner state with the deadlines of a proving period starting at periodStart.
+type testMinerState struct {
+	miner.State
+
+	periodStart abi.ChainEpoch
+	deadlines   map[uint64]*testDeadline
+	sectors     []*miner.SectorOnChainInfo
+	locations   map[abi.SectorNumber]*miner.SectorLocation
+	precommits  []miner.SectorPreCommitOnChainInfo
+}
+
+func (s *testMinerState) DeadlineInfo(epoch abi.ChainEpoch) (*dline.Info, error) {
+	return dline.NewInfo(s.periodStart, uint64((epoch-s.periodStart)/miner.WPoStChallengeWindow()), epoch, miner.WPoStPeriodDeadlines,
+		miner.WPoStProvingPeriod(), miner.WPoStChallengeWindow(), miner.WPoStChallengeLookback, miner.FaultDeclarationCutoff), nil
+}
+
+func (s *testMinerState) ForEachDeadline(cb func(idx uint64, dl miner.Deadline) error) error {
+	for idx := uint64(0); idx < miner.WPoStPeriodDeadlines; idx++ {
+		dl, ok := s.deadlines[idx]
+		if !ok {
+			dl = &testDeadline{}
+		}
+		if err := cb(idx, dl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *testMinerState) LoadDeadline(idx uint64) (miner.Deadline, error) {
+	dl, ok := s.deadlines[idx]
+	if !ok {
+		return &testDeadline{}, nil
+	}
+	return dl, nil
+}
+
+func (s *testMinerState) LoadSectors(sectorNos *bitfield.BitField) ([]*miner.SectorOnChainInfo, error) {
+	if sectorNos == nil {
+		return s.sectors, nil
+	}
+	var out []*miner.SectorOnChainInfo
+	for _, sector := range s.sectors {
+		if set, err := sectorNos.IsSet(uint64(sector.SectorNumber)); err != nil {
+			return nil, err
+		} else if set {
+			out = append(out, sector)
+		}
+	}
+	return out, nil
+}
+
+func (s *testMinerState) GetSector(sno abi.SectorNumber) (*miner.SectorOnChainInfo, error) {
+	for _, sector := range s.sectors {
+		if sector.SectorNumber == sno {
+			return sector, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *testMinerState) FindSector(sno abi.SectorNumber) (*miner.SectorLocation, error) {
+	loc, ok := s.locations[sno]
+	if !ok {
+		return nil, errors.New("sector not found")
+	}
+	return loc, nil
+}
+
+func (s *testMinerState) ForEachPrecommittedSector(cb func(miner.SectorPreCommitOnChainInfo) error) error {
+	for _, pci := range s.precommits {
+		if err := cb(pci); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type testDeadline struct {
+	miner.Deadline
+
+	proofs     []miner.DisputableProof
+	proofsErr  error
+	partitions []*testPartition
+	posted     []uint64
+}
+
+func (dl *testDeadline) PartitionsPoSted() (bitfield.BitField, error) {
+	return bitfield.NewFromSet(dl.posted), nil
+}
+
+func (dl *testDeadline) DisputableProofs() ([]miner.DisputableProof, error) {
+	return dl.proofs, dl.proofsErr
+}
+
+func (dl *testDeadline) ForEachPartition(cb func(idx uint64, part miner.Partition) error) error {
+	for idx, part := range dl.partitions {
+		if err := cb(uint64(idx), part); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type testPartition struct {
+	miner.Partition
+
+	all, live, faulty, unproven []uint64
+}
+
+func (p *testPartition) AllSectors() (bitfield.BitField, error) {
+	return bitfield.NewFromSet(p.all), nil
+}
+
+func (p *testPartition) LiveSectors() (bitfield.BitField, error) {
+	return bitfield.NewFromSet(p.live), nil
+}
+
+func (p *testPartition) FaultySectors() (bitfield.BitField, error) {
+	return bitfield.NewFromSet(p.faulty), nil
+}
+
+func (p *testPartition) UnprovenSectors() (bitfield.BitField, error) {
+	return bitfield.NewFromSet(p.unproven), nil
+}
+
+func TestDisputableDeadlines(t *testing.T) {
+	tf.UnitTest(t)
+
+	window := miner.WPoStChallengeWindow()
+	proofs := func(partitions ...uint64) []miner.DisputableProof {
+		return []miner.DisputableProof{{Index: 0, Partitions: bitfield.NewFromSet(partitions)}}
+	}
+	mas := &testMinerState{
+		periodStart: miner.WPoStProvingPeriod(),
+		deadlines: map[uint64]*testDeadline{
+			// closed in the current proving period
+			9: {proofs: proofs(0, 1)},
+			// open
+			10: {proofs: proofs(0)},
+			// closed in the previous proving period, before the dispute window
+			20: {proofs: proofs(0)},
+			// closed in the previous proving period, within the dispute window
+			47: {proofs: proofs(2)},
+			// without proofs
+			8: {},
+		},
+	}
+	// deadline 10 of the current proving period is open
+	height := mas.periodStart + 10*window + 5
+	require.Less(t, 21*window+miner.WPoStDisputeWindow(), height)
+	require.Greater(t, 48*window+miner.WPoStDisputeWindow(), height)
+
+	out, err := disputableDeadlines(mas, height)
+	require.NoError(t, err)
+	assert.Equal(t, []types.DisputableDeadline{
+		{
+			Deadline:   9,
+			Close:      mas.periodStart + 10*window,
+			DisputeEnd: mas.periodStart + 10*window + miner.WPoStDisputeWindow(),
+			Proofs:     []types.DisputableWindowedPoSt{{Index: 0, Partitions: bitfield.NewFromSet([]uint64{0, 1})}},
+		},
+		{
+			Deadline:   47,
+			Close:      mas.periodStart,
+			DisputeEnd: mas.periodStart + miner.WPoStDisputeWindow(),
+			Proofs:     []types.DisputableWindowedPoSt{{Index: 0, Partitions: bitfield.NewFromSet([]uint64{2})}},
+		},
+	}, out)
+
+	mas.deadlines[47].proofsErr = errors.New("not found")
+	_, err = disputableDeadlines(mas, height)
+	assert.ErrorContains(t, err, "loading the proofs of deadline 47: not found")
+}
+
+func TestCompactablePartitions(t *testing.T) {
+	tf.UnitTest(t)
+
+	sectors := func(from, to uint64) []uint64 {
+		var out []uint64
+		for i := from; i < to; i++ {
+			out = append(out, i)
+		}
+		return out
+	}
+	dl := &testDeadline{partitions: []*testPartition{
+		// half of the sectors terminated
+		{all: sectors(0, 10), live: sectors(0, 5)},
+		// less than half of the sectors terminated
+		{all: sectors(10, 20), live: sectors(10, 16)},
+		// all the sectors terminated
+		{all: sectors(20, 30)},
+		// faulty or unproven sectors
+		{all: sectors(30, 40), live: sectors(30, 34), faulty: []uint64{30}},
+		{all: sectors(40, 50), live: sectors(40, 44), unproven: []uint64{40}},
+		// empty
+		{},
+	}}
+
+	partitions, live, dead, err := compactablePartitions(dl, 0.5)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{0, 2}, partitions)
+	assert.Equal(t, uint64(5), live)
+	assert.Equal(t, uint64(15), dead)
+
+	partitions, live, dead, err = compactablePartitions(dl, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{2}, partitions)
+	assert.Equal(t, uint64(0), live)
+	assert.Equal(t, uint64(10), dead)
+
+	partitions, _, _, err = compactablePartitions(&testDeadline{partitions: dl.partitions[1:2]}, 0.5)
+	require.NoError(t, err)
+	assert.Empty(t, partitions)
+}
+
+func TestDeadlineCompactable(t *testing.T) {
+	tf.UnitTest(t)
+
+	window := miner.WPoStChallengeWindow()
+	periodStart := miner.WPoStProvingPeriod()
+	// deadline 10 of the proving period is open
+	height := periodStart + 10*window + 5
+	for idx, compactable := range map[uint64]bool{
+		// within their dispute window
+		0: false,
+		9: false,
+		// open
+		10: false,
+		// about to open
+		11: false,
+		12: true,
+		// closed in the previous proving period, after their dispute window
+		20: true,
+		47: false,
+	} {
+		assert.Equal(t, compactable, deadlineCompactable(periodStart, idx, height), "deadline %d", idx)
+	}
+}
+
+type testDealState struct {
+	market.DealState
+
+	sector abi.SectorNumber
+}
+
+func (st *testDealState) SectorNumber() abi.SectorNumber {
+	return st.sector
+}
+
+func TestDealSector(t *testing.T) {
+	tf.UnitTest(t)
+
+	maddr, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+	const dealID = abi.DealID(5)
+	mas := &testMinerState{
+		sectors: []*miner.SectorOnChainInfo{
+			{SectorNumber: 3},
+			{SectorNumber: 4, DeprecatedDealIDs: []abi.DealID{4, dealID}},
+		},
+		locations: map[abi.SectorNumber]*miner.SectorLocation{3: {Deadline: 2, Partition: 1}, 4: {Deadline: 3}},
+		precommits: []miner.SectorPreCommitOnChainInfo{
+			{Info: miner.SectorPreCommitInfo{SectorNumber: 6, DealIDs: []abi.DealID{6}}},
+			{Info: miner.SectorPreCommitInfo{SectorNumber: 7, DealIDs: []abi.DealID{4, dealID}}},
+		},
+	}
+
+	// the deal is in a precommitted sector
+	out, err := dealSector(mas, maddr, dealID, nil, false, false)
+	require.NoError(t, err)
+	assert.Equal(t, &types.DealSector{Sector: 7}, out)
+	_, err = dealSector(mas, maddr, 8, nil, false, false)
+	assert.ErrorContains(t, err, "deal 8 is not in a sector of")
+
+	// the deal state records its sector
+	out, err = dealSector(mas, maddr, dealID, &testDealState{sector: 3}, true, false)
+	require.NoError(t, err)
+	assert.Equal(t, &types.DealSector{Sector: 3, Activated: true, Location: &types.SectorPartition{Deadline: 2, Partition: 1}}, out)
+	// the sector was terminated
+	out, err = dealSector(mas, maddr, dealID, &testDealState{sector: 9}, true, false)
+	require.NoError(t, err)
+	assert.Equal(t, &types.DealSector{Sector: 9, Activated: true}, out)
+
+	// the sector is searched in the sectors of the miner
+	out, err = dealSector(mas, maddr, dealID, &testDealState{}, true, true)
+	require.NoError(t, err)
+	assert.Equal(t, &types.DealSector{Sector: 4, Activated: true, Location: &types.SectorPartition{Deadline: 3}}, out)
+	_, err = dealSector(mas, maddr, 8, &testDealState{}, true, true)
+	assert.ErrorContains(t, err, "deal 8 is not in a live sector of")
+}
@@ -0,0 +1,129 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	builtintypes "github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/cbor"
+
+	"github.com/filecoin-project/venus/venus-shared/actors"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// StateMinerPendingBeneficiaryChange returns the pending beneficiary change of the miner and who still has to approve it
+func (msa *minerStateAPI) StateMinerPendingBeneficiaryChange(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (*types.PendingBeneficiaryChangeStatus, error) {
+	mi, err := msa.StateMinerInfo(ctx, maddr, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("loading miner info: %w", err)
+	}
+
+	status := &types.PendingBeneficiaryChangeStatus{
+		Beneficiary: mi.Beneficiary,
+		Pending:     mi.PendingBeneficiaryTerm,
+	}
+	if mi.PendingBeneficiaryTerm == nil {
+		status.Status = "no pending beneficiary change"
+		return status, nil
+	}
+
+	var waiting []string
+	if !mi.PendingBeneficiaryTerm.ApprovedByBeneficiary {
+		status.WaitingFor = append(status.WaitingFor, mi.Beneficiary)
+		waiting = append(waiting, fmt.Sprintf("%s (current beneficiary)", mi.Beneficiary))
+	}
+	if !mi.PendingBeneficiaryTerm.ApprovedByNominee {
+		status.WaitingFor = append(status.WaitingFor, mi.PendingBeneficiaryTerm.NewBeneficiary)
+		waiting = append(waiting, fmt.Sprintf("%s (nominee)", mi.PendingBeneficiaryTerm.NewBeneficiary))
+	}
+	status.Status = fmt.Sprintf("change to %s waiting for approval of %s", mi.PendingBeneficiaryTerm.NewBeneficiary, strings.Join(waiting, ", "))
+
+	return status, nil
+}
+
+// MinerProposeChangeBeneficiary builds the message the owner sends to propose a new beneficiary
+func (msa *minerStateAPI) MinerProposeChangeBeneficiary(ctx context.Context, maddr address.Address, newBeneficiary address.Address, quota abi.TokenAmount, expiration abi.ChainEpoch) (*types.MessagePrototype, error) {
+	mi, err := msa.StateMinerInfo(ctx, maddr, types.EmptyTSK)
+	if err != nil {
+		return nil, fmt.Errorf("loading miner info: %w", err)
+	}
+	nominee, err := msa.API().StateLookupID(ctx, newBeneficiary, types.EmptyTSK)
+	if err != nil {
+		return nil, fmt.Errorf("looking up new beneficiary %s: %w", newBeneficiary, err)
+	}
+
+	if quota.LessThan(big.Zero()) {
+		return nil, fmt.Errorf("beneficiary quota must not be negative")
+	}
+	if nominee == mi.Owner {
+		if !quota.IsZero() || expiration != 0 {
+			return nil, fmt.Errorf("quota and expiration must be zero when the owner is the new beneficiary")
+		}
+	} else if head := msa.ChainReader.GetHead(); expiration <= head.Height() {
+		return nil, fmt.Errorf("expiration %d must be after the current epoch %d", expiration, head.Height())
+	}
+	if nominee == mi.Beneficiary && quota.Equals(mi.BeneficiaryTerm.Quota) && expiration == mi.BeneficiaryTerm.Expiration {
+		return nil, fmt.Errorf("%s is already the beneficiary with the same terms", newBeneficiary)
+	}
+
+	return minerMessage(maddr, mi.Owner, builtintypes.MethodsMiner.ChangeBeneficiary, &types.ChangeBeneficiaryParams{
+		NewBeneficiary: newBeneficiary,
+		NewQuota:       quota,
+		NewExpiration:  expiration,
+	})
+}
+
+// MinerApproveChangeBeneficiary builds the message approver sends to approve the pending beneficiary change
+func (msa *minerStateAPI) MinerApproveChangeBeneficiary(ctx context.Context, maddr address.Address, approver address.Address) (*types.MessagePrototype, error) {
+	status, err := msa.StateMinerPendingBeneficiaryChange(ctx, maddr, types.EmptyTSK)
+	if err != nil {
+		return nil, err
+	}
+	if status.Pending == nil {
+		return nil, fmt.Errorf("miner %s has no pending beneficiary change", maddr)
+	}
+
+	approverID, err := msa.API().StateLookupID(ctx, approver, types.EmptyTSK)
+	if err != nil {
+		return nil, fmt.Errorf("looking up approver %s: %w", approver, err)
+	}
+	expected := false
+	for _, addr := range status.WaitingFor {
+		if id, err := msa.API().StateLookupID(ctx, addr, types.EmptyTSK); err == nil && id == approverID {
+			expected = true
+			break
+		}
+	}
+	if !expected {
+		return nil, fmt.Errorf("%s is not expected to approve the change: %s", approver, status.Status)
+	}
+
+	return minerMessage(maddr, approver, builtintypes.MethodsMiner.ChangeBeneficiary, &types.ChangeBeneficiaryParams{
+		NewBeneficiary: status.Pending.NewBeneficiary,
+		NewQuota:       status.Pending.NewQuota,
+		NewExpiration:  status.Pending.NewExpiration,
+	})
+}
+
+// minerMessage builds a message calling method of the miner actor, the nonce and gas are left to the sender.
+func minerMessage(maddr, from address.Address, method abi.MethodNum, params cbor.Marshaler) (*types.MessagePrototype, error) {
+	sp, err := actors.SerializeParams(params)
+	if err != nil {
+		return nil, fmt.Errorf("serializing params: %w", err)
+	}
+
+	return &types.MessagePrototype{
+		Message: types.Message{
+			To:     maddr,
+			From:   from,
+			Value:  big.Zero(),
+			Method: method,
+			Params: sp,
+		},
+		ValidNonce: false,
+	}, nil
+}
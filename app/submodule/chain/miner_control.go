@@ -0,0 +1,139 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	builtintypes "github.com/filecoin-project/go-state-types/builtin"
+
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// maxControlAddresses is the maximum number of control addresses a miner actor accepts.
+const maxControlAddresses = 10
+
+// MinerChangeWorkerAddress builds the message the owner sends to change the worker and control addresses
+func (msa *minerStateAPI) MinerChangeWorkerAddress(ctx context.Context, maddr address.Address, newWorker address.Address, controlAddrs []address.Address) (*types.MessagePrototype, error) {
+	mi, err := msa.StateMinerInfo(ctx, maddr, types.EmptyTSK)
+	if err != nil {
+		return nil, fmt.Errorf("loading miner info: %w", err)
+	}
+
+	workerKey, err := msa.API().StateAccountKey(ctx, newWorker, types.EmptyTSK)
+	if err != nil {
+		return nil, fmt.Errorf("worker %s must be an account actor: %w", newWorker, err)
+	}
+	if workerKey.Protocol() != address.BLS {
+		return nil, fmt.Errorf("worker %s must be a BLS account, got %s", newWorker, workerKey)
+	}
+	workerID, err := msa.API().StateLookupID(ctx, newWorker, types.EmptyTSK)
+	if err != nil {
+		return nil, fmt.Errorf("looking up worker %s: %w", newWorker, err)
+	}
+
+	if len(controlAddrs) > maxControlAddresses {
+		return nil, fmt.Errorf("too many control addresses, %d > %d", len(controlAddrs), maxControlAddresses)
+	}
+	controlIDs := make([]address.Address, 0, len(controlAddrs))
+	seen := make(map[address.Address]struct{}, len(controlAddrs))
+	for _, addr := range controlAddrs {
+		id, err := msa.resolveControlAddress(ctx, addr)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[id]; ok {
+			return nil, fmt.Errorf("duplicated control address %s", addr)
+		}
+		seen[id] = struct{}{}
+		controlIDs = append(controlIDs, id)
+	}
+
+	if workerID == mi.Worker && sameAddresses(controlIDs, mi.ControlAddresses) {
+		return nil, fmt.Errorf("worker and control addresses are unchanged")
+	}
+
+	return minerMessage(maddr, mi.Owner, builtintypes.MethodsMiner.ChangeWorkerAddress, &types.ChangeWorkerAddressParams{
+		NewWorker:       newWorker,
+		NewControlAddrs: controlAddrs,
+	})
+}
+
+// MinerConfirmChangeWorker builds the message the owner sends to confirm the pending worker change
+func (msa *minerStateAPI) MinerConfirmChangeWorker(ctx context.Context, maddr address.Address) (*types.MessagePrototype, error) {
+	mi, err := msa.StateMinerInfo(ctx, maddr, types.EmptyTSK)
+	if err != nil {
+		return nil, fmt.Errorf("loading miner info: %w", err)
+	}
+	if mi.NewWorker == address.Undef {
+		return nil, fmt.Errorf("miner %s has no pending worker change", maddr)
+	}
+	if head := msa.ChainReader.GetHead(); head.Height() < mi.WorkerChangeEpoch {
+		return nil, fmt.Errorf("worker change to %s can be confirmed from epoch %d, current epoch is %d", mi.NewWorker, mi.WorkerChangeEpoch, head.Height())
+	}
+
+	return minerMessage(maddr, mi.Owner, builtintypes.MethodsMiner.ConfirmChangeWorkerAddress, &abi.EmptyValue{})
+}
+
+// MinerChangeOwnerAddress builds the message sender sends to change the owner, the current owner proposes
+// newOwner and newOwner confirms the change by sending the same message
+func (msa *minerStateAPI) MinerChangeOwnerAddress(ctx context.Context, maddr address.Address, sender address.Address, newOwner address.Address) (*types.MessagePrototype, error) {
+	mi, err := msa.StateMinerInfo(ctx, maddr, types.EmptyTSK)
+	if err != nil {
+		return nil, fmt.Errorf("loading miner info: %w", err)
+	}
+
+	newOwnerID, err := msa.resolveControlAddress(ctx, newOwner)
+	if err != nil {
+		return nil, err
+	}
+	senderID, err := msa.API().StateLookupID(ctx, sender, types.EmptyTSK)
+	if err != nil {
+		return nil, fmt.Errorf("looking up sender %s: %w", sender, err)
+	}
+
+	switch {
+	case senderID == mi.Owner:
+		if newOwnerID == mi.Owner && mi.PendingOwnerAddress == nil {
+			return nil, fmt.Errorf("%s is already the owner", newOwner)
+		}
+	case mi.PendingOwnerAddress != nil && senderID == *mi.PendingOwnerAddress:
+		if newOwnerID != senderID {
+			return nil, fmt.Errorf("the proposed owner %s can only confirm itself as the new owner", sender)
+		}
+	default:
+		return nil, fmt.Errorf("sender %s is neither the owner %s nor the proposed owner", sender, mi.Owner)
+	}
+
+	return minerMessage(maddr, sender, builtintypes.MethodsMiner.ChangeOwnerAddress, &newOwner)
+}
+
+// resolveControlAddress returns the ID address of addr, which must be an account or a multisig actor.
+func (msa *minerStateAPI) resolveControlAddress(ctx context.Context, addr address.Address) (address.Address, error) {
+	id, err := msa.API().StateLookupID(ctx, addr, types.EmptyTSK)
+	if err != nil {
+		return address.Undef, fmt.Errorf("looking up %s: %w", addr, err)
+	}
+	act, err := msa.API().StateGetActor(ctx, id, types.EmptyTSK)
+	if err != nil {
+		return address.Undef, fmt.Errorf("loading actor %s: %w", addr, err)
+	}
+	if !builtin.IsAccountActor(act.Code) && !builtin.IsMultisigActor(act.Code) {
+		return address.Undef, fmt.Errorf("%s must be an account or multisig actor, got %s", addr, builtin.ActorNameByCode(act.Code))
+	}
+	return id, nil
+}
+
+func sameAddresses(a, b []address.Address) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
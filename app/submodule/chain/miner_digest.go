@@ -0,0 +1,219 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/miner"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// StateMinerDigestSubscribe emits a digest of each deadline of the miners when it closes: the sectors which became
+// faulty, the partitions without window PoSt and the live sectors expiring within expiringWithin epochs. The digests
+// are computed from the states of the new heads, the first deadline closing after the subscription being the first
+// one reported.
+func (msa *minerStateAPI) StateMinerDigestSubscribe(ctx context.Context, miners []address.Address, expiringWithin abi.ChainEpoch) (<-chan *types.MinerDeadlineDigest, error) {
+	if len(miners) == 0 {
+		return nil, fmt.Errorf("no miner to watch")
+	}
+	if expiringWithin < 0 {
+		return nil, fmt.Errorf("expiring epochs must not be negative, got %d", expiringWithin)
+	}
+
+	w := &digestWatcher{
+		msa:            msa,
+		expiringWithin: expiringWithin,
+		open:           make(map[address.Address]*openDeadline, len(miners)),
+	}
+	heads := msa.ChainReader.SubHeadChanges(ctx)
+	out := make(chan *types.MinerDeadlineDigest, 16)
+	go func() {
+		defer close(out)
+
+		for changes := range heads {
+			for _, change := range changes {
+				if change.Type == types.HCRevert {
+					continue
+				}
+				for _, maddr := range miners {
+					digest, err := w.update(ctx, maddr, change.Val)
+					if err != nil {
+						log.Warnf("computing the deadline digest of %s at %d: %v", maddr, change.Val.Height(), err)
+						continue
+					}
+					if digest == nil {
+						continue
+					}
+					select {
+					case out <- digest:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// openDeadline is the last seen state of the open deadline of a miner.
+type openDeadline struct {
+	index       uint64
+	periodStart abi.ChainEpoch
+	partitions  uint64
+	faults      bitfield.BitField
+	posted      bitfield.BitField
+}
+
+type digestWatcher struct {
+	msa            *minerStateAPI
+	expiringWithin abi.ChainEpoch
+	open           map[address.Address]*openDeadline
+}
+
+// update records the open deadline of the miner at ts, it returns the digest of the previous deadline once it is closed.
+func (w *digestWatcher) update(ctx context.Context, maddr address.Address, ts *types.TipSet) (*types.MinerDeadlineDigest, error) {
+	_, view, err := w.msa.Stmgr.ParentStateView(ctx, ts)
+	if err != nil {
+		return nil, err
+	}
+	mas, err := view.LoadMinerState(ctx, maddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load miner actor state: %w", err)
+	}
+	return w.updateState(mas, maddr, ts)
+}
+
+// updateState records the open deadline of the miner state mas at ts, it returns the digest of the previous deadline
+// once it is closed.
+func (w *digestWatcher) updateState(mas miner.State, maddr address.Address, ts *types.TipSet) (*types.MinerDeadlineDigest, error) {
+	di, err := mas.DeadlineInfo(ts.Height())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deadline info: %w", err)
+	}
+
+	var digest *types.MinerDeadlineDigest
+	if prev, ok := w.open[maddr]; ok && (prev.index != di.Index || prev.periodStart != di.PeriodStart) {
+		if digest, err = w.digest(mas, maddr, prev, ts); err != nil {
+			return nil, err
+		}
+	}
+
+	dl, err := mas.LoadDeadline(di.Index)
+	if err != nil {
+		return nil, fmt.Errorf("loading deadline %d: %w", di.Index, err)
+	}
+	cur := &openDeadline{index: di.Index, periodStart: di.PeriodStart}
+	if cur.posted, err = dl.PartitionsPoSted(); err != nil {
+		return nil, err
+	}
+	if cur.faults, cur.partitions, err = deadlineFaults(dl); err != nil {
+		return nil, err
+	}
+	w.open[maddr] = cur
+
+	return digest, nil
+}
+
+func (w *digestWatcher) digest(mas miner.State, maddr address.Address, prev *openDeadline, ts *types.TipSet) (*types.MinerDeadlineDigest, error) {
+	dl, err := mas.LoadDeadline(prev.index)
+	if err != nil {
+		return nil, fmt.Errorf("loading deadline %d: %w", prev.index, err)
+	}
+	digest := &types.MinerDeadlineDigest{
+		Miner:            maddr,
+		Deadline:         prev.index,
+		TipSet:           ts.Key(),
+		Height:           ts.Height(),
+		MissedPartitions: bitfield.New(),
+		ExpiringSectors:  bitfield.New(),
+	}
+
+	faults, _, err := deadlineFaults(dl)
+	if err != nil {
+		return nil, err
+	}
+	if digest.NewFaults, err = bitfield.SubtractBitField(faults, prev.faults); err != nil {
+		return nil, err
+	}
+
+	// the PoSts of the last epoch of the deadline may not be in the last seen state, a partition without PoSt
+	// is only missed when the closing of the deadline marked its sectors faulty
+	var missed []uint64
+	var live []bitfield.BitField
+	if err := dl.ForEachPartition(func(idx uint64, part miner.Partition) error {
+		partLive, err := part.LiveSectors()
+		if err != nil {
+			return err
+		}
+		live = append(live, partLive)
+
+		if idx >= prev.partitions {
+			return nil
+		}
+		if posted, err := prev.posted.IsSet(idx); err != nil || posted {
+			return err
+		}
+		partFaults, err := part.FaultySectors()
+		if err != nil {
+			return err
+		}
+		newFaults, err := bitfield.IntersectBitField(partFaults, digest.NewFaults)
+		if err != nil {
+			return err
+		}
+		if empty, err := newFaults.IsEmpty(); err != nil || empty {
+			return err
+		}
+		missed = append(missed, idx)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	digest.MissedPartitions = bitfield.NewFromSet(missed)
+
+	if w.expiringWithin > 0 {
+		liveSectors, err := bitfield.MultiMerge(live...)
+		if err != nil {
+			return nil, err
+		}
+		sectors, err := mas.LoadSectors(&liveSectors)
+		if err != nil {
+			return nil, fmt.Errorf("loading the live sectors of deadline %d: %w", prev.index, err)
+		}
+		var expiring []uint64
+		for _, sector := range sectors {
+			if sector.Expiration <= ts.Height()+w.expiringWithin {
+				expiring = append(expiring, uint64(sector.SectorNumber))
+			}
+		}
+		digest.ExpiringSectors = bitfield.NewFromSet(expiring)
+	}
+
+	return digest, nil
+}
+
+// deadlineFaults returns the faulty sectors of all the partitions of dl and the number of partitions.
+func deadlineFaults(dl miner.Deadline) (bitfield.BitField, uint64, error) {
+	var faults []bitfield.BitField
+	if err := dl.ForEachPartition(func(_ uint64, part miner.Partition) error {
+		partFaults, err := part.FaultySectors()
+		if err != nil {
+			return err
+		}
+		faults = append(faults, partFaults)
+		return nil
+	}); err != nil {
+		return bitfield.BitField{}, 0, err
+	}
+	merged, err := bitfield.MultiMerge(faults...)
+	if err != nil {
+		return bitfield.BitField{}, 0, err
+	}
+	return merged, uint64(len(faults)), nil
+}
@@ -0,0 +1,110 @@
+package chain
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/miner"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func newTestTipSet(t *testing.T, maddr address.Address, height abi.ChainEpoch) *types.TipSet {
+	root, err := abi.CidBuilder.Sum([]byte("state"))
+	require.NoError(t, err)
+	ts, err := types.NewTipSet([]*types.BlockHeader{{
+		Miner:                 maddr,
+		Ticket:                &types.Ticket{VRFProof: []byte{byte(height)}},
+		ParentWeight:          big.Zero(),
+		Height:                height,
+		ParentStateRoot:       root,
+		ParentMessageReceipts: root,
+		Messages:              root,
+		ParentBaseFee:         big.Zero(),
+	}})
+	require.NoError(t, err)
+	return ts
+}
+
+func bitfieldSet(t *testing.T, bf bitfield.BitField) []uint64 {
+	set, err := bf.All(1 << 20)
+	require.NoError(t, err)
+	return set
+}
+
+func TestDigestWatcher(t *testing.T) {
+	tf.UnitTest(t)
+
+	maddr, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+	periodStart := miner.WPoStProvingPeriod()
+	w := &digestWatcher{expiringWithin: 100, open: map[address.Address]*openDeadline{}}
+
+	// deadline 0 is open, the first partition was proven and the sector 20 of the third one is faulty
+	open := &testMinerState{periodStart: periodStart, deadlines: map[uint64]*testDeadline{0: {
+		posted: []uint64{0},
+		partitions: []*testPartition{
+			{all: []uint64{0, 1}, live: []uint64{0, 1}},
+			{all: []uint64{10, 11}, live: []uint64{10, 11}},
+			{all: []uint64{20, 21}, live: []uint64{20, 21}, faulty: []uint64{20}},
+		},
+	}}}
+	digest, err := w.updateState(open, maddr, newTestTipSet(t, maddr, periodStart+5))
+	require.NoError(t, err)
+	assert.Nil(t, digest)
+	digest, err = w.updateState(open, maddr, newTestTipSet(t, maddr, periodStart+6))
+	require.NoError(t, err)
+	assert.Nil(t, digest)
+
+	// deadline 0 closed, the sectors of the second partition were marked faulty for missing their PoSt, a partition
+	// was added meanwhile
+	height := periodStart + miner.WPoStChallengeWindow() + 1
+	closed := &testMinerState{
+		periodStart: periodStart,
+		deadlines: map[uint64]*testDeadline{0: {
+			posted: []uint64{0},
+			partitions: []*testPartition{
+				{all: []uint64{0, 1}, live: []uint64{0, 1}},
+				{all: []uint64{10, 11}, live: []uint64{10, 11}, faulty: []uint64{10, 11}},
+				{all: []uint64{20, 21}, live: []uint64{20, 21}, faulty: []uint64{20}},
+				{all: []uint64{30}, live: []uint64{30}, faulty: []uint64{30}},
+			},
+		}},
+	}
+	for sno, expiration := range map[abi.SectorNumber]abi.ChainEpoch{
+		0: height + 50, 1: height + 1000, 10: height + 1000, 11: height + 1000, 20: height + 1000, 21: height + 100, 30: height + 10,
+	} {
+		closed.sectors = append(closed.sectors, &miner.SectorOnChainInfo{SectorNumber: sno, Expiration: expiration})
+	}
+	ts := newTestTipSet(t, maddr, height)
+	digest, err = w.updateState(closed, maddr, ts)
+	require.NoError(t, err)
+	require.NotNil(t, digest)
+	assert.Equal(t, maddr, digest.Miner)
+	assert.Equal(t, uint64(0), digest.Deadline)
+	assert.Equal(t, ts.Key(), digest.TipSet)
+	assert.Equal(t, height, digest.Height)
+	assert.Equal(t, []uint64{10, 11, 30}, bitfieldSet(t, digest.NewFaults))
+	assert.Equal(t, []uint64{1}, bitfieldSet(t, digest.MissedPartitions))
+	assert.Equal(t, []uint64{0, 21, 30}, bitfieldSet(t, digest.ExpiringSectors))
+
+	// the digest of a deadline is emitted once
+	digest, err = w.updateState(closed, maddr, newTestTipSet(t, maddr, height+1))
+	require.NoError(t, err)
+	assert.Nil(t, digest)
+
+	// the sectors expiring are not loaded when not requested
+	w = &digestWatcher{open: map[address.Address]*openDeadline{}}
+	_, err = w.updateState(open, maddr, newTestTipSet(t, maddr, periodStart+5))
+	require.NoError(t, err)
+	digest, err = w.updateState(closed, maddr, ts)
+	require.NoError(t, err)
+	require.NotNil(t, digest)
+	assert.Empty(t, bitfieldSet(t, digest.ExpiringSectors))
+}
@@ -0,0 +1,92 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs-force-community/metrics"
+	"go.opencensus.io/tag"
+
+	"github.com/filecoin-project/venus/pkg/constants"
+	"github.com/filecoin-project/venus/pkg/reorgjournal"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+var (
+	tagKeyReorgDepth = tag.MustNewKey("depth")
+	chainReorgs      = metrics.NewCounter("chain/reorgs", "Number of reorgs of the head observed, by depth in epochs", tagKeyReorgDepth)
+)
+
+// reorgDepthBuckets are the upper bounds of the depth buckets of the reorgs metric, the last bucket is unbounded
+var reorgDepthBuckets = []struct {
+	max  abi.ChainEpoch
+	name string
+}{
+	{1, "1"},
+	{2, "2"},
+	{5, "3-5"},
+	{20, "6-20"},
+	{constants.Finality, fmt.Sprintf("21-%d", constants.Finality)},
+}
+
+func reorgDepthBucket(depth abi.ChainEpoch) string {
+	for _, b := range reorgDepthBuckets {
+		if depth <= b.max {
+			return b.name
+		}
+	}
+	return fmt.Sprintf(">%d", constants.Finality)
+}
+
+// reorgJournalManager records the reorgs of the head in the journal.
+type reorgJournalManager struct {
+	chain   *ChainSubmodule
+	journal *reorgjournal.Journal
+}
+
+func (m *reorgJournalManager) headChanged(rev, app []*types.TipSet) error {
+	if len(rev) == 0 {
+		return nil
+	}
+	ctx := context.TODO()
+
+	// rev goes down from the old head, the parent of its last tipset is the common ancestor
+	oldHead := rev[0]
+	common, err := m.chain.ChainReader.GetTipSet(ctx, rev[len(rev)-1].Parents())
+	if err != nil {
+		return fmt.Errorf("loading the common ancestor of the reorg: %w", err)
+	}
+	r := &types.ChainReorg{
+		Time:         time.Now(),
+		Depth:        oldHead.Height() - common.Height(),
+		OldHead:      oldHead.Key(),
+		OldHeight:    oldHead.Height(),
+		NewHead:      common.Key(),
+		NewHeight:    common.Height(),
+		CommonHeight: common.Height(),
+		Dropped:      make([]types.TipSetKey, 0, len(rev)),
+		Added:        make([]types.TipSetKey, 0, len(app)),
+	}
+	if len(app) > 0 {
+		newHead := app[len(app)-1]
+		r.NewHead = newHead.Key()
+		r.NewHeight = newHead.Height()
+	}
+	for _, ts := range rev {
+		r.Dropped = append(r.Dropped, ts.Key())
+	}
+	for _, ts := range app {
+		r.Added = append(r.Added, ts.Key())
+	}
+
+	tagCtx, _ := tag.New(ctx, tag.Upsert(tagKeyReorgDepth, reorgDepthBucket(r.Depth)))
+	chainReorgs.Tick(tagCtx)
+	log.Infow("chain reorg", "depth", r.Depth, "from", r.OldHeight, "to", r.NewHeight, "dropped", len(rev), "added", len(app))
+
+	if err := m.journal.Record(ctx, r); err != nil {
+		return fmt.Errorf("recording the reorg: %w", err)
+	}
+	return nil
+}
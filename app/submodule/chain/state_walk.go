@@ -0,0 +1,37 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/venus/pkg/state/tree"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// MarketDealsWalk resolves the market state at tsk, the returned func calls cb on its deals by increasing id,
+// so the deals can be streamed without holding them all in memory.
+func (chain *ChainSubmodule) MarketDealsWalk(ctx context.Context, tsk types.TipSetKey) (func(cb func(abi.DealID, *types.MarketDeal) error) error, error) {
+	_, view, err := chain.Stmgr.ParentStateViewTsk(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("Stmgr.ParentStateViewTsk failed:%w", err)
+	}
+	return func(cb func(abi.DealID, *types.MarketDeal) error) error {
+		return view.ForEachMarketDeal(ctx, cb)
+	}, nil
+}
+
+// ActorsWalk resolves the state at tsk, the returned func calls cb on its actors in the order of the state tree.
+func (chain *ChainSubmodule) ActorsWalk(ctx context.Context, tsk types.TipSetKey) (func(cb func(address.Address) error) error, error) {
+	_, stat, err := chain.Stmgr.TipsetStateTsk(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("load tipset state from key:%s failed:%w", tsk.String(), err)
+	}
+	return func(cb func(address.Address) error) error {
+		return stat.ForEach(func(addr tree.ActorKey, _ *types.Actor) error {
+			return cb(addr)
+		})
+	}, nil
+}
@@ -2,15 +2,26 @@ package common
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"sort"
 	"time"
 
+	logging "github.com/ipfs/go-log/v2"
 	"github.com/libp2p/go-libp2p/core/peer"
 
 	chain2 "github.com/filecoin-project/venus/app/submodule/chain"
 	apiwrapper "github.com/filecoin-project/venus/app/submodule/common/v0api"
+	"github.com/filecoin-project/venus/app/submodule/mpool"
 	"github.com/filecoin-project/venus/app/submodule/network"
+	"github.com/filecoin-project/venus/pkg/backup"
+	"github.com/filecoin-project/venus/pkg/config"
 	"github.com/filecoin-project/venus/pkg/constants"
+	"github.com/filecoin-project/venus/pkg/events/filter"
+	"github.com/filecoin-project/venus/pkg/maintenance"
+	"github.com/filecoin-project/venus/pkg/messagepool/journal"
 	"github.com/filecoin-project/venus/pkg/net"
+	"github.com/filecoin-project/venus/pkg/repo"
 	"github.com/filecoin-project/venus/venus-shared/api/chain"
 	v0api "github.com/filecoin-project/venus/venus-shared/api/chain/v0"
 	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
@@ -20,18 +31,48 @@ import (
 var _ v1api.ICommon = (*CommonModule)(nil)
 
 type CommonModule struct { // nolint
-	chainModule    *chain2.ChainSubmodule
-	netModule      *network.NetworkSubmodule
-	blockDelaySecs uint64
-	start          time.Time
+	chainModule        *chain2.ChainSubmodule
+	netModule          *network.NetworkSubmodule
+	mpoolModule        *mpool.MessagePoolSubmodule
+	eventFilterManager *filter.EventFilterManager
+	maintenance        *maintenance.Scheduler
+	configReloader     *config.Reloader
+	repo               repo.Repo
+	blockDelaySecs     uint64
+	healthCfg          *config.HealthConfig
+	rpcVersions        []string
+	journalEvents      *journal.MemorySink
+	start              time.Time
 }
 
-func NewCommonModule(chainModule *chain2.ChainSubmodule, netModule *network.NetworkSubmodule, blockDelaySecs uint64) *CommonModule {
+// NewCommonModule create a common module, eventFilterManager is nil when the event index is disabled,
+// rpcVersions are the versions of the rpc api served by the node, journalEvents is nil unless the journal keeps
+// events in memory.
+func NewCommonModule(chainModule *chain2.ChainSubmodule,
+	netModule *network.NetworkSubmodule,
+	mpoolModule *mpool.MessagePoolSubmodule,
+	eventFilterManager *filter.EventFilterManager,
+	maintenance *maintenance.Scheduler,
+	configReloader *config.Reloader,
+	repo repo.Repo,
+	blockDelaySecs uint64,
+	healthCfg *config.HealthConfig,
+	rpcVersions []string,
+	journalEvents *journal.MemorySink,
+) *CommonModule {
 	return &CommonModule{
-		chainModule:    chainModule,
-		netModule:      netModule,
-		blockDelaySecs: blockDelaySecs,
-		start:          time.Now(),
+		chainModule:        chainModule,
+		netModule:          netModule,
+		mpoolModule:        mpoolModule,
+		eventFilterManager: eventFilterManager,
+		maintenance:        maintenance,
+		configReloader:     configReloader,
+		repo:               repo,
+		blockDelaySecs:     blockDelaySecs,
+		healthCfg:          healthCfg,
+		rpcVersions:        rpcVersions,
+		journalEvents:      journalEvents,
+		start:              time.Now(),
 	}
 }
 
@@ -42,6 +83,37 @@ func (cm *CommonModule) Version(ctx context.Context) (types.Version, error) {
 	}, nil
 }
 
+func (cm *CommonModule) APIHandshake(ctx context.Context, clientVersion types.APIVersion) (types.APIHandshake, error) {
+	hs := types.APIHandshake{
+		APIVersions: types.APIVersions{Version: constants.UserVersion()},
+		Compatible:  chain.FullAPIVersion1.Compatible(clientVersion),
+	}
+	for _, v := range cm.rpcVersions {
+		switch v {
+		case "v0":
+			hs.APIs = append(hs.APIs, types.APINamespaceVersion{
+				Path:         chain.FullAPIPath0,
+				Namespace:    v0api.MethodNamespace,
+				APIVersion:   chain.FullAPIVersion0,
+				Shim:         true,
+				Deprecations: chain.FullAPIDeprecations0,
+			})
+		case "v1":
+			hs.APIs = append(hs.APIs, types.APINamespaceVersion{
+				Path:         chain.FullAPIPath1,
+				Namespace:    v1api.MethodNamespace,
+				APIVersion:   chain.FullAPIVersion1,
+				Deprecations: chain.FullAPIDeprecations1,
+			})
+		}
+	}
+	if !hs.Compatible {
+		hs.Notice = fmt.Sprintf("the node serves the v1 api %s, which is not compatible with the client built against %s", chain.FullAPIVersion1, clientVersion)
+	}
+
+	return hs, nil
+}
+
 func (cm *CommonModule) NodeStatus(ctx context.Context, inclChainStatus bool) (status types.NodeStatus, err error) {
 	curTS, err := cm.chainModule.API().ChainHead(ctx)
 	if err != nil {
@@ -119,6 +191,16 @@ func (cm *CommonModule) StartTime(ctx context.Context) (time.Time, error) {
 	return cm.start, nil
 }
 
+func (cm *CommonModule) LogList(ctx context.Context) ([]string, error) {
+	subsystems := logging.GetSubsystems()
+	sort.Strings(subsystems)
+	return subsystems, nil
+}
+
+func (cm *CommonModule) LogSetLevel(ctx context.Context, subsystem, level string) error {
+	return logging.SetLogLevel(subsystem, level)
+}
+
 func (cm *CommonModule) API() v1api.ICommon {
 	return cm
 }
@@ -126,3 +208,65 @@ func (cm *CommonModule) API() v1api.ICommon {
 func (cm *CommonModule) V0API() v0api.ICommon {
 	return &apiwrapper.WrapperV1ICommon{ICommon: cm}
 }
+
+// MaintenanceSchedule returns the maintenance windows, whether the maintenance is allowed now and the last
+// run of the maintenance tasks.
+func (cm *CommonModule) MaintenanceSchedule(ctx context.Context) (*types.MaintenanceSchedule, error) {
+	return cm.maintenance.Schedule(), nil
+}
+
+// MaintenanceOverride opens or closes the maintenance until a time, regardless of the windows.
+func (cm *CommonModule) MaintenanceOverride(ctx context.Context, override types.MaintenanceOverride) error {
+	return cm.maintenance.Override(override)
+}
+
+// NodeBackup writes a backup of the node metadata to opts.Path in the directory named by the
+// VENUS_BACKUP_BASE_PATH environment variable of the node, the file must not exist.
+func (cm *CommonModule) NodeBackup(ctx context.Context, opts types.NodeBackupOpts) (*types.NodeBackupResult, error) {
+	path, err := backup.FilePath(os.Getenv(backup.BasePathEnv), opts.Path)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("creating the backup file: %w", err)
+	}
+	res, err := backup.Backup(ctx, cm.repo, f, opts.Password)
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return nil, fmt.Errorf("writing the backup: %w", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.NodeBackupResult{
+		Path:        path,
+		Keys:        res.Keys,
+		MetaEntries: res.MetaEntries,
+		SqliteFiles: res.SqliteFiles,
+		Bytes:       info.Size(),
+		Took:        time.Since(start),
+	}, nil
+}
+
+// SetConfig sets a config field, applies it when it is reloadable and saves the config.
+func (cm *CommonModule) SetConfig(ctx context.Context, key string, value string) (*types.ConfigReload, error) {
+	return cm.configReloader.Set(key, value)
+}
+
+// JournalEvents returns the latest events of the journal kept in memory, selected by filter.
+func (cm *CommonModule) JournalEvents(ctx context.Context, filter types.JournalFilter) ([]types.JournalEvent, error) {
+	if cm.journalEvents == nil {
+		return nil, fmt.Errorf("the journal keeps no events in memory, see journal.memoryEvents in the config")
+	}
+	return cm.journalEvents.Events(filter), nil
+}
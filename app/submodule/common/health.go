@@ -0,0 +1,82 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+const (
+	HealthCheckSyncLag       = "sync_lag"
+	HealthCheckPeers         = "peers"
+	HealthCheckMpoolPending  = "mpool_pending"
+	HealthCheckEventIndexLag = "event_index_lag"
+)
+
+// NodeHealth evaluates the readiness checks against the configured thresholds, the node is
+// live as long as it can serve its chain head.
+func (cm *CommonModule) NodeHealth(ctx context.Context) (types.NodeHealth, error) {
+	health := types.NodeHealth{Ready: true}
+	head := cm.chainModule.ChainReader.GetHead()
+	if head == nil {
+		health.Ready = false
+		return health, nil
+	}
+	health.Live = true
+
+	addCheck := func(check types.NodeHealthCheck) {
+		health.Checks = append(health.Checks, check)
+		health.Ready = health.Ready && check.OK
+	}
+	cfg := cm.healthCfg
+
+	lag := int64(time.Since(time.Unix(int64(head.MinTimestamp()), 0)).Seconds()) / int64(cm.blockDelaySecs)
+	if lag < 0 {
+		lag = 0
+	}
+	addCheck(types.NodeHealthCheck{
+		Name:      HealthCheckSyncLag,
+		OK:        lag <= cfg.MaxSyncLagEpochs,
+		Value:     lag,
+		Threshold: cfg.MaxSyncLagEpochs,
+	})
+
+	if cm.netModule.Host != nil {
+		peers := int64(len(cm.netModule.Host.Network().Peers()))
+		addCheck(types.NodeHealthCheck{
+			Name:      HealthCheckPeers,
+			OK:        peers >= cfg.MinPeers,
+			Value:     peers,
+			Threshold: cfg.MinPeers,
+		})
+	}
+
+	if cm.mpoolModule != nil {
+		pending := int64(cm.mpoolModule.MPool.PendingCount())
+		addCheck(types.NodeHealthCheck{
+			Name:      HealthCheckMpoolPending,
+			OK:        pending <= cfg.MaxMpoolPending,
+			Value:     pending,
+			Threshold: cfg.MaxMpoolPending,
+		})
+	}
+
+	if cm.eventFilterManager != nil && cm.eventFilterManager.EventIndex != nil {
+		check := types.NodeHealthCheck{
+			Name:      HealthCheckEventIndexLag,
+			Threshold: cfg.MaxEventIndexLagEpochs,
+		}
+		indexed, err := cm.eventFilterManager.EventIndex.GetMaxHeightInIndex(ctx)
+		if err != nil {
+			check.Message = fmt.Sprintf("failed to get max height of event index: %v", err)
+		} else {
+			check.Value = int64(head.Height()) - int64(indexed)
+			check.OK = check.Value <= cfg.MaxEventIndexLagEpochs
+		}
+		addCheck(check)
+	}
+
+	return health, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 
+	"github.com/filecoin-project/venus/app/submodule/blockstore"
 	"github.com/filecoin-project/venus/app/submodule/network"
 	"github.com/filecoin-project/venus/pkg/repo"
 	"github.com/filecoin-project/venus/pkg/util/dag"
@@ -35,8 +36,8 @@ type dagConfig interface {
 }
 
 // NewDagserviceSubmodule creates a new block service submodule.
-func NewDagserviceSubmodule(ctx context.Context, dagCfg dagConfig, network *network.NetworkSubmodule) (*DagServiceSubmodule, error) {
-	bservice := bserv.New(dagCfg.Repo().Datastore(), network.Bitswap)
+func NewDagserviceSubmodule(ctx context.Context, dagCfg dagConfig, blockstore *blockstore.BlockstoreSubmodule, network *network.NetworkSubmodule) (*DagServiceSubmodule, error) {
+	bservice := bserv.New(blockstore.Blockstore, network.Bitswap)
 	dag := dag.NewDAG(merkledag.NewDAGService(bservice))
 	return &DagServiceSubmodule{
 		Blockservice: bservice,
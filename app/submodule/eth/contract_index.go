@@ -0,0 +1,160 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/filecoin-project/venus/pkg/contractindex"
+	builtinactors "github.com/filecoin-project/venus/venus-shared/actors/builtin"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// maxContractCreations bounds the number of contracts returned by a single EthGetContractsByCreator call.
+const maxContractCreations = 1000
+
+var errContractIndexDisabled = errors.New("contract index disabled, enable with Fevm.EnableContractIndex")
+
+// contractIndexManager indexes the evm contracts created by the messages of the applied tipsets from their
+// execution traces.
+type contractIndexManager struct {
+	api   *ethAPI
+	index *contractindex.ContractIndex
+}
+
+func (m *contractIndexManager) Apply(ctx context.Context, from, to *types.TipSet) error {
+	blkHash, creations, err := m.contractCreations(ctx, to)
+	if err != nil {
+		return fmt.Errorf("collecting the contracts created at %d: %w", to.Height(), err)
+	}
+	return m.index.IndexBlock(blkHash, creations)
+}
+
+func (m *contractIndexManager) Revert(ctx context.Context, from, to *types.TipSet) error {
+	blkHash, err := ethBlockHash(from)
+	if err != nil {
+		return err
+	}
+	return m.index.RemoveBlock(blkHash)
+}
+
+func ethBlockHash(ts *types.TipSet) (types.EthHash, error) {
+	c, err := ts.Key().Cid()
+	if err != nil {
+		return types.EmptyEthHash, fmt.Errorf("failed to get tipset key cid: %w", err)
+	}
+	return types.EthHashFromCid(c)
+}
+
+// contractCreations returns the contracts successfully created by the messages of ts, the creations reverted with
+// one of their callers are left out.
+func (m *contractIndexManager) contractCreations(ctx context.Context, ts *types.TipSet) (types.EthHash, []types.EthContractCreation, error) {
+	blkHash, err := ethBlockHash(ts)
+	if err != nil {
+		return types.EmptyEthHash, nil, err
+	}
+	_, trace, err := m.api.em.chainModule.Stmgr.ExecutionTrace(ctx, ts)
+	if err != nil {
+		return types.EmptyEthHash, nil, fmt.Errorf("failed when calling ExecutionTrace: %w", err)
+	}
+	state, err := m.api.em.chainModule.ChainReader.GetTipSetState(ctx, ts)
+	if err != nil {
+		return types.EmptyEthHash, nil, fmt.Errorf("failed to get state view: %w", err)
+	}
+
+	var out []types.EthContractCreation
+	for _, ir := range trace {
+		if ir.Msg.From == builtinactors.SystemActorAddr || !ir.MsgRct.ExitCode.IsSuccess() {
+			continue
+		}
+
+		env, err := baseEnvironment(ctx, ir.Msg.From, state)
+		if err != nil {
+			return types.EmptyEthHash, nil, fmt.Errorf("when processing message %s: %w", ir.MsgCid, err)
+		}
+		if err := buildTraces(env, []int{}, &ir.ExecutionTrace); err != nil {
+			return types.EmptyEthHash, nil, fmt.Errorf("failed building traces for msg %s: %w", ir.MsgCid, err)
+		}
+
+		var failed [][]int
+		for _, trace := range env.traces {
+			if trace.Error != "" {
+				failed = append(failed, trace.TraceAddress)
+			}
+		}
+		var txHash *types.EthHash
+		for _, trace := range env.traces {
+			action, ok := trace.Action.(*types.EthCreateTraceAction)
+			if !ok {
+				continue
+			}
+			result, _ := trace.Result.(*types.EthCreateTraceResult)
+			if result == nil || result.Address == nil || traceReverted(trace.TraceAddress, failed) {
+				continue
+			}
+
+			if txHash == nil {
+				if txHash, err = m.api.EthGetTransactionHashByCid(ctx, ir.MsgCid); err != nil {
+					return types.EmptyEthHash, nil, fmt.Errorf("failed to get transaction hash by cid: %w", err)
+				}
+				if txHash == nil {
+					return types.EmptyEthHash, nil, fmt.Errorf("cannot find transaction hash for cid %s", ir.MsgCid)
+				}
+			}
+			out = append(out, types.EthContractCreation{
+				Contract:        *result.Address,
+				Creator:         action.From,
+				InitCodeHash:    types.EthHashFromTxBytes(action.Init),
+				TransactionHash: *txHash,
+				BlockHash:       blkHash,
+				BlockNumber:     types.EthUint64(ts.Height()),
+			})
+		}
+	}
+	return blkHash, out, nil
+}
+
+// traceReverted tells whether the trace at addr, or one of its callers, failed.
+func traceReverted(addr []int, failed [][]int) bool {
+	for _, f := range failed {
+		if len(f) <= len(addr) && slices.Equal(f, addr[:len(f)]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *ethAPI) EthGetContractCreation(ctx context.Context, contract types.EthAddress) (*types.EthContractCreation, error) {
+	if a.contractIndex == nil {
+		return nil, errContractIndexDisabled
+	}
+	c, err := a.contractIndex.index.GetContract(contract)
+	if errors.Is(err, contractindex.ErrNotFound) {
+		return nil, nil
+	}
+	return c, err
+}
+
+func (a *ethAPI) EthGetContractsByCreator(ctx context.Context, creator types.EthAddress, after *types.EthAddress, limit int) (*types.EthContractCreations, error) {
+	if a.contractIndex == nil {
+		return nil, errContractIndexDisabled
+	}
+	if limit <= 0 || limit > maxContractCreations {
+		return nil, fmt.Errorf("limit must be in [1, %d], got %d", maxContractCreations, limit)
+	}
+
+	creations, err := a.contractIndex.index.ListByCreator(creator, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	out := &types.EthContractCreations{Creations: creations}
+	if len(creations) == limit {
+		next := creations[limit-1].Contract
+		out.Next = &next
+	}
+	if out.Creations == nil {
+		out.Creations = []types.EthContractCreation{}
+	}
+	return out, nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-jsonrpc"
 	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/venus/pkg/config"
 	v1 "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 	"github.com/filecoin-project/venus/venus-shared/types"
 )
@@ -145,10 +146,26 @@ func (e *ethAPIDummy) EthMaxPriorityFeePerGas(ctx context.Context) (types.EthBig
 	return types.EthBigIntZero, ErrModuleDisabled
 }
 
+func (e *ethAPIDummy) EthGetContractStorage(ctx context.Context, address types.EthAddress, after *types.EthHash, limit int, blkParam types.EthBlockNumberOrHash) (*types.EthContractStorage, error) {
+	return nil, ErrModuleDisabled
+}
+
+func (e *ethAPIDummy) EthGetContractState(ctx context.Context, address types.EthAddress, blkParam types.EthBlockNumberOrHash) (*types.EthContractState, error) {
+	return nil, ErrModuleDisabled
+}
+
+func (e *ethAPIDummy) EthGetProof(ctx context.Context, address types.EthAddress, storageKeys []types.EthBytes, blkParam types.EthBlockNumberOrHash) (*types.EthProof, error) {
+	return nil, ErrModuleDisabled
+}
+
 func (e *ethAPIDummy) EthSendRawTransaction(ctx context.Context, rawTx types.EthBytes) (types.EthHash, error) {
 	return types.EthHash{}, ErrModuleDisabled
 }
 
+func (e *ethAPIDummy) EthSendRawTransactionUntrusted(ctx context.Context, rawTx types.EthBytes) (types.EthHash, error) {
+	return types.EthHash{}, ErrModuleDisabled
+}
+
 func (e *ethAPIDummy) Web3ClientVersion(ctx context.Context) (string, error) {
 	return "", ErrModuleDisabled
 }
@@ -169,6 +186,38 @@ func (e *ethAPIDummy) EthTraceFilter(ctx context.Context, filter types.EthTraceF
 	return nil, ErrModuleDisabled
 }
 
+func (e *ethAPIDummy) EthGetContractCreation(ctx context.Context, contract types.EthAddress) (*types.EthContractCreation, error) {
+	return nil, ErrModuleDisabled
+}
+
+func (e *ethAPIDummy) EthGetContractsByCreator(ctx context.Context, creator types.EthAddress, after *types.EthAddress, limit int) (*types.EthContractCreations, error) {
+	return nil, ErrModuleDisabled
+}
+
+func (e *ethAPIDummy) EthGetTokenTransfers(ctx context.Context, holder types.EthAddress, token *types.EthAddress, after uint64, limit int) (*types.EthTokenTransfers, error) {
+	return nil, ErrModuleDisabled
+}
+
+func (e *ethAPIDummy) EthGetTokenBalanceHistory(ctx context.Context, holder types.EthAddress, token types.EthAddress) ([]types.EthTokenBalanceChange, error) {
+	return nil, ErrModuleDisabled
+}
+
+func (e *ethAPIDummy) EthGetNFTTransfers(ctx context.Context, collection types.EthAddress, tokenID *types.BigInt, after uint64, limit int) (*types.EthNFTTransfers, error) {
+	return nil, ErrModuleDisabled
+}
+
+func (e *ethAPIDummy) EthGetNFTOwners(ctx context.Context, collection types.EthAddress, tokenID types.BigInt) ([]types.EthNFTHolding, error) {
+	return nil, ErrModuleDisabled
+}
+
+func (e *ethAPIDummy) EthGetNFTHoldings(ctx context.Context, owner types.EthAddress, collection *types.EthAddress) ([]types.EthNFTHolding, error) {
+	return nil, ErrModuleDisabled
+}
+
+func (e *ethAPIDummy) EthNodeInfo(ctx context.Context) (*types.EthNodeInfo, error) {
+	return nil, ErrModuleDisabled
+}
+
 func (e *ethAPIDummy) start(_ context.Context) error {
 	return nil
 }
@@ -177,5 +226,13 @@ func (e *ethAPIDummy) close() error {
 	return nil
 }
 
+func (e *ethAPIDummy) vacuum(_ context.Context) error {
+	return nil
+}
+
+func (e *ethAPIDummy) reload(_ *config.FevmConfig) error {
+	return nil
+}
+
 var _ v1.IETH = &ethAPIDummy{}
 var _ ethAPIAdapter = &ethAPIDummy{}
@@ -9,23 +9,25 @@ import (
 	"path/filepath"
 	"sort"
 	"strconv"
+	"sync/atomic"
 
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-jsonrpc"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/go-state-types/builtin"
-	"github.com/filecoin-project/go-state-types/builtin/v10/evm"
 	"github.com/filecoin-project/go-state-types/exitcode"
 	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/config"
 	"github.com/filecoin-project/venus/pkg/constants"
+	"github.com/filecoin-project/venus/pkg/contractindex"
 	"github.com/filecoin-project/venus/pkg/crypto"
 	"github.com/filecoin-project/venus/pkg/ethhashlookup"
 	"github.com/filecoin-project/venus/pkg/events"
 	"github.com/filecoin-project/venus/pkg/fork"
 	"github.com/filecoin-project/venus/pkg/messagepool"
 	"github.com/filecoin-project/venus/pkg/statemanger"
-	"github.com/filecoin-project/venus/venus-shared/actors"
+	"github.com/filecoin-project/venus/pkg/tokenindex"
 	builtinactors "github.com/filecoin-project/venus/venus-shared/actors/builtin"
 	builtinevm "github.com/filecoin-project/venus/venus-shared/actors/builtin/evm"
 	types2 "github.com/filecoin-project/venus/venus-shared/actors/types"
@@ -35,6 +37,7 @@ import (
 	"github.com/ipfs/go-cid"
 	logging "github.com/ipfs/go-log/v2"
 	cbg "github.com/whyrusleeping/cbor-gen"
+	"go.opencensus.io/trace"
 )
 
 const maxEthFeeHistoryRewardPercentiles = 100
@@ -77,7 +80,27 @@ func newEthAPI(em *EthSubModule) (*ethAPI, error) {
 		}
 	}
 
+	a.evmStates, err = newEVMStateCache(em.chainModule.ChainReader.Blockstore(), em.chainModule.ChainReader, em.chainModule.Fork)
+	if err != nil {
+		return nil, err
+	}
+
 	cfg := em.cfg.FevmConfig
+	if cfg.EnableContractIndex {
+		index, err := contractindex.NewContractIndex(filepath.Join(a.em.sqlitePath, "contracts.db"))
+		if err != nil {
+			return nil, err
+		}
+		a.contractIndex = &contractIndexManager{api: a, index: index}
+	}
+	if cfg.EnableTokenIndex {
+		index, err := tokenindex.NewTokenIndex(filepath.Join(a.em.sqlitePath, "tokens.db"))
+		if err != nil {
+			return nil, err
+		}
+		a.tokenIndex = &tokenIndexManager{api: a, index: index}
+	}
+
 	if cfg.EthBlkCacheSize > 0 {
 		var err error
 		a.EthBlkCache, err = arc.NewARC[cid.Cid, *types.EthBlock](cfg.EthBlkCacheSize)
@@ -91,6 +114,12 @@ func newEthAPI(em *EthSubModule) (*ethAPI, error) {
 		}
 	}
 
+	policy, err := newUntrustedTxPolicy(cfg.UntrustedTx)
+	if err != nil {
+		return nil, err
+	}
+	a.untrustedTxPolicy.Store(policy)
+
 	return a, nil
 }
 
@@ -99,9 +128,15 @@ type ethAPI struct {
 	chain                v1.IChain
 	mpool                v1.IMessagePool
 	ethTxHashManager     *ethTxHashManager
+	contractIndex        *contractIndexManager
+	tokenIndex           *tokenIndexManager
+	evmStates            *evmStateCache
 	EthEventHandler      *ethEventAPI
 	MaxFilterHeightRange abi.ChainEpoch
 
+	// untrustedTxPolicy is replaced on a config reload
+	untrustedTxPolicy atomic.Pointer[untrustedTxPolicy]
+
 	EthBlkCache   *arc.ARCCache[cid.Cid, *types.EthBlock] // caches blocks by their CID but blocks only have the transaction hashes
 	EthBlkTxCache *arc.ARCCache[cid.Cid, *types.EthBlock] // caches blocks along with full transaction payload by their CID
 }
@@ -114,6 +149,12 @@ func (a *ethAPI) start(ctx context.Context) error {
 
 	// Tipset listener
 	_ = ev.Observe(a.ethTxHashManager)
+	if a.contractIndex != nil {
+		_ = ev.Observe(a.contractIndex)
+	}
+	if a.tokenIndex != nil {
+		_ = ev.Observe(a.tokenIndex)
+	}
 
 	ch, err := a.em.mpoolModule.MPool.Updates(ctx)
 	if err != nil {
@@ -126,9 +167,42 @@ func (a *ethAPI) start(ctx context.Context) error {
 }
 
 func (a *ethAPI) close() error {
+	if a.contractIndex != nil {
+		if err := a.contractIndex.index.Close(); err != nil {
+			return err
+		}
+	}
+	if a.tokenIndex != nil {
+		if err := a.tokenIndex.index.Close(); err != nil {
+			return err
+		}
+	}
 	return a.ethTxHashManager.TransactionHashLookup.Close()
 }
 
+func (a *ethAPI) reload(cfg *config.FevmConfig) error {
+	policy, err := newUntrustedTxPolicy(cfg.UntrustedTx)
+	if err != nil {
+		return err
+	}
+	a.untrustedTxPolicy.Store(policy)
+	return nil
+}
+
+func (a *ethAPI) vacuum(ctx context.Context) error {
+	if a.contractIndex != nil {
+		if err := a.contractIndex.index.Vacuum(ctx); err != nil {
+			return err
+		}
+	}
+	if a.tokenIndex != nil {
+		if err := a.tokenIndex.index.Vacuum(ctx); err != nil {
+			return err
+		}
+	}
+	return a.ethTxHashManager.TransactionHashLookup.Vacuum(ctx)
+}
+
 func (a *ethAPI) StateNetworkName(ctx context.Context) (types.NetworkName, error) {
 	return a.chain.StateNetworkName(ctx)
 }
@@ -198,6 +272,9 @@ func (a *ethAPI) EthGetBlockTransactionCountByHash(ctx context.Context, blkHash
 }
 
 func (a *ethAPI) EthGetBlockByHash(ctx context.Context, blkHash types.EthHash, fullTxInfo bool) (types.EthBlock, error) {
+	ctx, span := trace.StartSpan(ctx, "eth.EthGetBlockByHash")
+	defer span.End()
+
 	cache := a.EthBlkCache
 	if fullTxInfo {
 		cache = a.EthBlkTxCache
@@ -253,6 +330,10 @@ func (a *ethAPI) parseBlkParam(ctx context.Context, blkParam string, strict bool
 			return nil, errors.New("cannot get parent tipset")
 		}
 		return parent, nil
+	case "safe":
+		return getSafeTipSet(ctx, a.em.chainModule.ChainReader, a.em.f3API, head)
+	case "finalized":
+		return getFinalizedTipSet(ctx, a.em.chainModule.ChainReader, a.em.f3API, head)
 	default:
 		var num types.EthUint64
 		err := num.UnmarshalJSON([]byte(`"` + blkParam + `"`))
@@ -274,7 +355,10 @@ func (a *ethAPI) parseBlkParam(ctx context.Context, blkParam string, strict bool
 }
 
 func (a *ethAPI) EthGetBlockByNumber(ctx context.Context, blkParam string, fullTxInfo bool) (types.EthBlock, error) {
-	ts, err := getTipsetByBlockNumber(ctx, a.em.chainModule.ChainReader, blkParam, true)
+	ctx, span := trace.StartSpan(ctx, "eth.EthGetBlockByNumber")
+	defer span.End()
+
+	ts, err := getTipsetByBlockNumber(ctx, a.em.chainModule.ChainReader, a.em.f3API, blkParam, true)
 	if err != nil {
 		return types.EthBlock{}, err
 	}
@@ -408,7 +492,7 @@ func (a *ethAPI) EthGetTransactionCount(ctx context.Context, sender types.EthAdd
 	}
 
 	// For all other cases, get the tipset based on the block parameter
-	ts, err := getTipsetByEthBlockNumberOrHash(ctx, a.em.chainModule.ChainReader, blkParam)
+	ts, err := getTipsetByEthBlockNumberOrHash(ctx, a.em.chainModule.ChainReader, a.em.f3API, blkParam)
 	if err != nil {
 		return types.EthUint64(0), fmt.Errorf("failed to process block param: %v, %w", blkParam, err)
 	}
@@ -445,6 +529,9 @@ func (a *ethAPI) EthGetTransactionReceipt(ctx context.Context, txHash types.EthH
 }
 
 func (a *ethAPI) EthGetTransactionReceiptLimited(ctx context.Context, txHash types.EthHash, limit abi.ChainEpoch) (*types.EthTxReceipt, error) {
+	ctx, span := trace.StartSpan(ctx, "eth.EthGetTransactionReceiptLimited")
+	defer span.End()
+
 	c, err := a.ethTxHashManager.TransactionHashLookup.GetCidFromHash(txHash)
 	if err != nil {
 		log.Debug("could not find transaction hash %s in lookup table", txHash.String())
@@ -500,7 +587,7 @@ func (a *ethAPI) EthGetBlockReceipts(ctx context.Context, blockParam types.EthBl
 }
 
 func (a *ethAPI) EthGetBlockReceiptsLimited(ctx context.Context, blockParam types.EthBlockNumberOrHash, limit abi.ChainEpoch) ([]*types.EthTxReceipt, error) {
-	ts, err := getTipsetByEthBlockNumberOrHash(ctx, a.em.chainModule.ChainReader, blockParam)
+	ts, err := getTipsetByEthBlockNumberOrHash(ctx, a.em.chainModule.ChainReader, a.em.f3API, blockParam)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get tipset: %w", err)
 	}
@@ -558,91 +645,36 @@ func (a *ethAPI) EthGetTransactionByBlockNumberAndIndex(ctx context.Context, blk
 
 // EthGetCode returns string value of the compiled bytecode
 func (a *ethAPI) EthGetCode(ctx context.Context, ethAddr types.EthAddress, blkParam types.EthBlockNumberOrHash) (types.EthBytes, error) {
+	ctx, span := trace.StartSpan(ctx, "eth.EthGetCode")
+	defer span.End()
+
 	to, err := ethAddr.ToFilecoinAddress()
 	if err != nil {
 		return nil, fmt.Errorf("cannot get Filecoin address: %w", err)
 	}
 
-	ts, err := getTipsetByEthBlockNumberOrHash(ctx, a.em.chainModule.ChainReader, blkParam)
+	ts, err := getTipsetByEthBlockNumberOrHash(ctx, a.em.chainModule.ChainReader, a.em.f3API, blkParam)
 	if err != nil {
 		return nil, fmt.Errorf("failed to process block param: %v, %w", blkParam, err)
 	}
 
-	// StateManager.Call will panic if there is no parent
-	if ts.Height() == 0 {
-		return nil, errors.New("block param must not specify genesis block")
-	}
-
-	actor, err := a.em.chainModule.Stmgr.GetActorAt(ctx, to, ts)
+	contract, err := a.evmStates.contract(ctx, to, ts)
 	if err != nil {
-		if errors.Is(err, types.ErrActorNotFound) {
-			return nil, nil
-		}
-		return nil, fmt.Errorf("failed to lookup contract %s: %w", ethAddr, err)
-	}
-
-	// Not a contract. We could try to distinguish between accounts and "native" contracts here,
-	// but it's not worth it.
-	if !builtinactors.IsEvmActor(actor.Code) {
-		return nil, nil
-	}
-
-	msg := &types.Message{
-		From:       builtinactors.SystemActorAddr,
-		To:         to,
-		Value:      big.Zero(),
-		Method:     builtin.MethodsEVM.GetBytecode,
-		Params:     nil,
-		GasLimit:   constants.BlockGasLimit,
-		GasFeeCap:  big.Zero(),
-		GasPremium: big.Zero(),
-	}
-
-	// Try calling until we find a height with no migration.
-	var res *types.InvocResult
-	for {
-		res, err = a.em.chainModule.Stmgr.Call(ctx, msg, ts)
-		if err != fork.ErrExpensiveFork {
-			break
-		}
-		ts, err = a.chain.ChainGetTipSet(ctx, ts.Parents())
-		if err != nil {
-			return nil, fmt.Errorf("getting parent tipset: %w", err)
-		}
-	}
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to call GetBytecode: %w", err)
-	}
-
-	if res.MsgRct == nil {
-		return nil, errors.New("no message receipt")
-	}
-
-	if res.MsgRct.ExitCode.IsError() {
-		return nil, fmt.Errorf("GetBytecode failed: %s", res.Error)
-	}
-
-	var getBytecodeReturn evm.GetBytecodeReturn
-	if err := getBytecodeReturn.UnmarshalCBOR(bytes.NewReader(res.MsgRct.Return)); err != nil {
-		return nil, fmt.Errorf("failed to decode EVM bytecode CID: %w", err)
+		return nil, err
 	}
-
-	// The contract has selfdestructed, so the code is "empty".
-	if getBytecodeReturn.Cid == nil {
+	// Not a contract, or the contract has selfdestructed, so the code is "empty".
+	if !contract.evm || !contract.bytecode.Defined() {
 		return nil, nil
 	}
 
-	blk, err := a.em.chainModule.ChainReader.Blockstore().Get(ctx, *getBytecodeReturn.Cid)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get EVM bytecode: %w", err)
-	}
-
-	return blk.RawData(), nil
+	return a.evmStates.bytecode(ctx, contract.bytecode)
 }
 
 func (a *ethAPI) EthGetStorageAt(ctx context.Context, ethAddr types.EthAddress, position types.EthBytes, blkParam types.EthBlockNumberOrHash) (types.EthBytes, error) {
-	ts, err := getTipsetByEthBlockNumberOrHash(ctx, a.em.chainModule.ChainReader, blkParam)
+	ctx, span := trace.StartSpan(ctx, "eth.EthGetStorageAt")
+	defer span.End()
+
+	ts, err := getTipsetByEthBlockNumberOrHash(ctx, a.em.chainModule.ChainReader, a.em.f3API, blkParam)
 	if err != nil {
 		return nil, fmt.Errorf("failed to process block param: %v, %w", blkParam, err)
 	}
@@ -653,92 +685,39 @@ func (a *ethAPI) EthGetStorageAt(ctx context.Context, ethAddr types.EthAddress,
 	}
 
 	// pad with zero bytes if smaller than 32 bytes
-	position = append(make([]byte, 32-l, 32), position...)
+	var key types.EthHash
+	copy(key[32-l:], position)
 
 	to, err := ethAddr.ToFilecoinAddress()
 	if err != nil {
 		return nil, fmt.Errorf("cannot get Filecoin address: %w", err)
 	}
 
-	// use the system actor as the caller
-	from, err := address.NewIDAddress(0)
-	if err != nil {
-		return nil, fmt.Errorf("failed to construct system sender address: %w", err)
-	}
-
-	actor, err := a.em.chainModule.Stmgr.GetActorAt(ctx, to, ts)
+	contract, err := a.evmStates.contract(ctx, to, ts)
 	if err != nil {
-		if errors.Is(err, types.ErrActorNotFound) {
-			return types.EthBytes(make([]byte, 32)), nil
-		}
-		return nil, fmt.Errorf("failed to lookup contract %s: %w", ethAddr, err)
+		return nil, err
 	}
-
-	if !builtinactors.IsEvmActor(actor.Code) {
+	if !contract.evm {
 		return types.EthBytes(make([]byte, 32)), nil
 	}
 
-	params, err := actors.SerializeParams(&evm.GetStorageAtParams{
-		StorageKey: *(*[32]byte)(position),
-	})
+	value, _, err := lookupEVMStorage(ctx, a.em.chainModule.ChainReader.Blockstore(), contract.storageRoot, key)
 	if err != nil {
-		return nil, fmt.Errorf("failed to serialize parameters: %w", err)
-	}
-
-	msg := &types.Message{
-		From:       from,
-		To:         to,
-		Value:      big.Zero(),
-		Method:     builtin.MethodsEVM.GetStorageAt,
-		Params:     params,
-		GasLimit:   constants.BlockGasLimit,
-		GasFeeCap:  big.Zero(),
-		GasPremium: big.Zero(),
-	}
-
-	// Try calling until we find a height with no migration.
-	var res *types.InvocResult
-	for {
-		res, err = a.em.chainModule.Stmgr.Call(ctx, msg, ts)
-		if err != fork.ErrExpensiveFork {
-			break
-		}
-		ts, err = a.chain.ChainGetTipSet(ctx, ts.Parents())
-		if err != nil {
-			return nil, fmt.Errorf("getting parent tipset: %w", err)
-		}
-	}
-
-	if err != nil {
-		return nil, fmt.Errorf("call failed: %w", err)
-	}
-
-	if res.MsgRct == nil {
-		return nil, errors.New("no message receipt")
-	}
-
-	if res.MsgRct.ExitCode.IsError() {
-		return nil, fmt.Errorf("failed to lookup storage slot: %s", res.Error)
+		return nil, fmt.Errorf("failed to lookup storage slot: %w", err)
 	}
-
-	var ret abi.CborBytes
-	if err := ret.UnmarshalCBOR(bytes.NewReader(res.MsgRct.Return)); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal storage slot: %w", err)
-	}
-
-	// pad with zero bytes if smaller than 32 bytes
-	ret = append(make([]byte, 32-len(ret), 32), ret...)
-
-	return types.EthBytes(ret), nil
+	return types.EthBytes(value[:]), nil
 }
 
 func (a *ethAPI) EthGetBalance(ctx context.Context, address types.EthAddress, blkParam types.EthBlockNumberOrHash) (types.EthBigInt, error) {
+	ctx, span := trace.StartSpan(ctx, "eth.EthGetBalance")
+	defer span.End()
+
 	filAddr, err := address.ToFilecoinAddress()
 	if err != nil {
 		return types.EthBigInt{}, err
 	}
 
-	ts, err := getTipsetByEthBlockNumberOrHash(ctx, a.em.chainModule.ChainReader, blkParam)
+	ts, err := getTipsetByEthBlockNumberOrHash(ctx, a.em.chainModule.ChainReader, a.em.f3API, blkParam)
 	if err != nil {
 		return types.EthBigInt{}, fmt.Errorf("failed to process block param: %v, %w", blkParam, err)
 	}
@@ -943,6 +922,62 @@ func (a *ethAPI) EthGasPrice(ctx context.Context) (types.EthBigInt, error) {
 	return types.EthBigInt(gasPrice), nil
 }
 
+// EthNodeInfo returns the chain id, the latest, safe and finalized blocks, the gas price suggestions and the sync
+// status, estimating the gas premium once for both gas prices.
+func (a *ethAPI) EthNodeInfo(ctx context.Context) (*types.EthNodeInfo, error) {
+	store := a.em.chainModule.ChainReader
+	head := store.GetHead()
+
+	var blocks [3]*types.TipSet
+	for i, tag := range []string{"latest", "safe", "finalized"} {
+		ts, err := getTipsetByBlockNumber(ctx, store, a.em.f3API, tag, false)
+		if err != nil {
+			return nil, fmt.Errorf("loading the %s block: %w", tag, err)
+		}
+		blocks[i] = ts
+	}
+
+	premium, err := a.EthMaxPriorityFeePerGas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	syncing, err := a.EthSyncing(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return newEthNodeInfo(head, blocks[0], blocks[1], blocks[2], premium, syncing)
+}
+
+// newEthNodeInfo returns the node info of the head and of the latest, safe and finalized tipsets, the gas price being
+// the base fee of the pending block plus premium.
+func newEthNodeInfo(head, latest, safe, finalized *types.TipSet, premium types.EthBigInt, syncing types.EthSyncingResult) (*types.EthNodeInfo, error) {
+	info := &types.EthNodeInfo{
+		ChainID:              types.EthUint64(types2.Eip155ChainID),
+		NetVersion:           strconv.FormatInt(int64(types2.Eip155ChainID), 10),
+		ClientVersion:        constants.UserVersion(),
+		BaseFeePerGas:        types.EthBigInt(head.Blocks()[0].ParentBaseFee),
+		MaxPriorityFeePerGas: premium,
+		Syncing:              syncing,
+	}
+	info.GasPrice = types.EthBigInt(big.Add(big.Int(info.BaseFeePerGas), big.Int(premium)))
+
+	for _, block := range []struct {
+		ts  *types.TipSet
+		ref *types.EthBlockRef
+	}{{latest, &info.Latest}, {safe, &info.Safe}, {finalized, &info.Finalized}} {
+		hash, err := ethBlockHash(block.ts)
+		if err != nil {
+			return nil, err
+		}
+		*block.ref = types.EthBlockRef{
+			Number:    types.EthUint64(block.ts.Height()),
+			Hash:      hash,
+			Timestamp: types.EthUint64(block.ts.MinTimestamp()),
+		}
+	}
+	return info, nil
+}
+
 func (a *ethAPI) EthSendRawTransaction(ctx context.Context, rawTx types.EthBytes) (types.EthHash, error) {
 	txArgs, err := types.ParseEthTransaction(rawTx)
 	if err != nil {
@@ -961,10 +996,39 @@ func (a *ethAPI) EthSendRawTransaction(ctx context.Context, rawTx types.EthBytes
 	return types.EthHashFromTxBytes(rawTx), nil
 }
 
+func (a *ethAPI) EthSendRawTransactionUntrusted(ctx context.Context, rawTx types.EthBytes) (types.EthHash, error) {
+	txArgs, err := types.ParseEthTransaction(rawTx)
+	if err != nil {
+		return types.EmptyEthHash, err
+	}
+
+	smsg, err := types.ToSignedFilecoinMessage(txArgs)
+	if err != nil {
+		return types.EmptyEthHash, err
+	}
+
+	pending, err := a.em.mpoolModule.MPool.PendingCountFor(ctx, smsg.Message.From)
+	if err != nil {
+		return types.EmptyEthHash, fmt.Errorf("getting pending messages of %s: %w", smsg.Message.From, err)
+	}
+	if err := a.untrustedTxPolicy.Load().check(&smsg.Message, pending); err != nil {
+		return types.EmptyEthHash, fmt.Errorf("transaction rejected: %w", err)
+	}
+
+	_, err = a.mpool.MpoolPushUntrusted(ctx, smsg)
+	if err != nil {
+		return types.EmptyEthHash, err
+	}
+	return types.EthHashFromTxBytes(rawTx), nil
+}
+
 func (a *ethAPI) applyMessage(ctx context.Context, msg *types.Message, tsk types.TipSetKey) (*types.InvocResult, error) {
+	ctx, span := trace.StartSpan(ctx, "eth.applyMessage")
+	defer span.End()
+
 	ts, err := a.chain.ChainGetTipSet(ctx, tsk)
 	if err != nil {
-		return nil, fmt.Errorf("failed to got tipset %v", err)
+		return nil, fmt.Errorf("failed to got tipset %w", err)
 	}
 
 	if ts.Height() > 0 {
@@ -995,6 +1059,9 @@ func (a *ethAPI) applyMessage(ctx context.Context, msg *types.Message, tsk types
 }
 
 func (a *ethAPI) EthEstimateGas(ctx context.Context, p jsonrpc.RawParams) (types.EthUint64, error) {
+	ctx, span := trace.StartSpan(ctx, "eth.EthEstimateGas")
+	defer span.End()
+
 	params, err := jsonrpc.DecodeParams[types.EthEstimateGasParams](p)
 	if err != nil {
 		return types.EthUint64(0), fmt.Errorf("decoding params: %w", err)
@@ -1015,7 +1082,7 @@ func (a *ethAPI) EthEstimateGas(ctx context.Context, p jsonrpc.RawParams) (types
 			return types.EthUint64(0), err
 		}
 	} else {
-		ts, err = getTipsetByEthBlockNumberOrHash(ctx, a.em.chainModule.ChainReader, *params.BlkParam)
+		ts, err = getTipsetByEthBlockNumberOrHash(ctx, a.em.chainModule.ChainReader, a.em.f3API, *params.BlkParam)
 		if err != nil {
 			return types.EthUint64(0), fmt.Errorf("failed to process block param: %v; %w", params.BlkParam, err)
 		}
@@ -1166,11 +1233,14 @@ func ethGasSearch(
 }
 
 func (a *ethAPI) EthCall(ctx context.Context, tx types.EthCall, blkParam types.EthBlockNumberOrHash) (types.EthBytes, error) {
+	ctx, span := trace.StartSpan(ctx, "eth.EthCall")
+	defer span.End()
+
 	msg, err := tx.ToFilecoinMessage()
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert ethcall to filecoin message: %w", err)
 	}
-	ts, err := getTipsetByEthBlockNumberOrHash(ctx, a.em.chainModule.ChainReader, blkParam)
+	ts, err := getTipsetByEthBlockNumberOrHash(ctx, a.em.chainModule.ChainReader, a.em.f3API, blkParam)
 	if err != nil {
 		return nil, fmt.Errorf("failed to process block param: %v, %w", blkParam, err)
 	}
@@ -1195,7 +1265,7 @@ func (a *ethAPI) Web3ClientVersion(ctx context.Context) (string, error) {
 }
 
 func (a *ethAPI) EthTraceBlock(ctx context.Context, blkNum string) ([]*types.EthTraceBlock, error) {
-	ts, err := getTipsetByBlockNumber(ctx, a.em.chainModule.ChainReader, blkNum, false)
+	ts, err := getTipsetByBlockNumber(ctx, a.em.chainModule.ChainReader, a.em.f3API, blkNum, false)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get tipset: %w", err)
 	}
@@ -1267,7 +1337,7 @@ func (a *ethAPI) EthTraceReplayBlockTransactions(ctx context.Context, blkNum str
 		return nil, errors.New("only 'trace' is supported")
 	}
 
-	ts, err := getTipsetByBlockNumber(ctx, a.em.chainModule.ChainReader, blkNum, false)
+	ts, err := getTipsetByBlockNumber(ctx, a.em.chainModule.ChainReader, a.em.f3API, blkNum, false)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get tipset: %w", err)
 	}
@@ -1396,9 +1466,17 @@ func (a *ethAPI) EthTraceFilter(ctx context.Context, filter types.EthTraceFilter
 			}
 			return types.EthUint64(parent.Height()), nil
 		case "safe":
-			latestHeight := head.Height() - 1
-			safeHeight := latestHeight - types.SafeEpochDelay
-			return types.EthUint64(safeHeight), nil
+			ts, err := getSafeTipSet(ctx, a.em.chainModule.ChainReader, a.em.f3API, head)
+			if err != nil {
+				return 0, err
+			}
+			return types.EthUint64(ts.Height()), nil
+		case "finalized":
+			ts, err := getFinalizedTipSet(ctx, a.em.chainModule.ChainReader, a.em.f3API, head)
+			if err != nil {
+				return 0, err
+			}
+			return types.EthUint64(ts.Height()), nil
 		default:
 			blockNum, err := types.EthUint64FromHex(blockValue)
 			if err != nil {
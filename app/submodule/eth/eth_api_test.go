@@ -0,0 +1,53 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	types2 "github.com/filecoin-project/venus/venus-shared/actors/types"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestNewEthNodeInfo(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	builder := chain.NewBuilder(t, address.Undef)
+	finalized := builder.AppendManyOn(ctx, 2, builder.Genesis())
+	safe := builder.AppendManyOn(ctx, 3, finalized)
+	head := builder.AppendManyOn(ctx, 1, safe)
+
+	premium := types.EthBigInt(big.NewInt(100))
+	syncing := types.EthSyncingResult{StartingBlock: 1, CurrentBlock: 5, HighestBlock: 6}
+	info, err := newEthNodeInfo(head, head, safe, finalized, premium, syncing)
+	require.NoError(t, err)
+
+	assert.Equal(t, types.EthUint64(types2.Eip155ChainID), info.ChainID)
+	assert.NotEmpty(t, info.NetVersion)
+	for ts, ref := range map[*types.TipSet]types.EthBlockRef{head: info.Latest, safe: info.Safe, finalized: info.Finalized} {
+		hash, err := ethBlockHash(ts)
+		require.NoError(t, err)
+		assert.Equal(t, types.EthBlockRef{Number: types.EthUint64(ts.Height()), Hash: hash, Timestamp: types.EthUint64(ts.MinTimestamp())}, ref)
+	}
+	assert.Equal(t, types.EthBigInt(head.Blocks()[0].ParentBaseFee), info.BaseFeePerGas)
+	assert.Equal(t, premium, info.MaxPriorityFeePerGas)
+	assert.Equal(t, types.EthBigInt(big.Add(head.Blocks()[0].ParentBaseFee, big.NewInt(100))), info.GasPrice)
+	assert.Equal(t, syncing, info.Syncing)
+
+	// the fields are named like the Ethereum methods returning them
+	data, err := json.Marshal(info)
+	require.NoError(t, err)
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(data, &fields))
+	for _, name := range []string{"chainId", "netVersion", "clientVersion", "latest", "safe", "finalized", "baseFeePerGas", "maxPriorityFeePerGas", "gasPrice", "syncing"} {
+		assert.Contains(t, fields, name)
+	}
+	assert.JSONEq(t, `"0x64"`, string(fields["maxPriorityFeePerGas"]))
+}
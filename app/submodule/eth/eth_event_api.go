@@ -6,17 +6,21 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-jsonrpc"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/config"
 	"github.com/filecoin-project/venus/pkg/events"
 	"github.com/filecoin-project/venus/pkg/events/filter"
 	"github.com/filecoin-project/venus/pkg/statemanger"
+	"github.com/filecoin-project/venus/venus-shared/actors"
 	"github.com/filecoin-project/venus/venus-shared/api"
 	v1 "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 	"github.com/filecoin-project/venus/venus-shared/types"
@@ -24,6 +28,7 @@ import (
 	"github.com/ipfs/go-cid"
 	"github.com/multiformats/go-multicodec"
 	"github.com/zyedidia/generic/queue"
+	"go.opencensus.io/trace"
 )
 
 var (
@@ -37,12 +42,13 @@ func newEthEventAPI(ctx context.Context, em *EthSubModule) (*ethEventAPI, error)
 	chainAPI := em.chainModule.API()
 	cfg := em.cfg.FevmConfig
 	ee := &ethEventAPI{
-		em:                   em,
-		ChainAPI:             chainAPI,
-		MaxFilterHeightRange: abi.ChainEpoch(cfg.Event.MaxFilterHeightRange),
-		SubscribtionCtx:      ctx,
-		disable:              !cfg.EnableEthRPC || cfg.Event.DisableRealTimeFilterAPI,
+		em:              em,
+		ChainAPI:        chainAPI,
+		SubscribtionCtx: ctx,
+		limits:          newWSLimits(&cfg.Event),
+		disable:         !cfg.EnableEthRPC || cfg.Event.DisableRealTimeFilterAPI,
 	}
+	ee.maxFilterHeightRange.Store(int64(cfg.Event.MaxFilterHeightRange))
 
 	if ee.disable {
 		// all event functionality is disabled
@@ -54,8 +60,9 @@ func newEthEventAPI(ctx context.Context, em *EthSubModule) (*ethEventAPI, error)
 		ChainAPI:     chainAPI,
 		stmgr:        ee.em.chainModule.Stmgr,
 		messageStore: ee.em.chainModule.MessageStore,
+		limits:       ee.limits,
 	}
-	ee.FilterStore = filter.NewMemFilterStore(cfg.Event.MaxFilters)
+	ee.FilterStore = filter.NewMemFilterStore(cfg.Event.MaxFilters, cfg.Event.MaxFiltersPerToken)
 
 	// Enable indexing of actor events
 	var eventIndex *filter.EventIndex
@@ -68,10 +75,32 @@ func newEthEventAPI(ctx context.Context, em *EthSubModule) (*ethEventAPI, error)
 		}
 
 		var err error
-		eventIndex, err = filter.NewEventIndex(ctx, dbPath, em.chainModule.ChainReader)
+		if cfg.Event.ReadOnly {
+			if len(cfg.Event.DatabasePath) == 0 {
+				return nil, fmt.Errorf("a read only event index requires the database path of the replica")
+			}
+			eventIndex, err = filter.NewReadOnlyEventIndex(ctx, dbPath)
+		} else {
+			eventIndex, err = filter.NewEventIndex(ctx, dbPath, em.chainModule.ChainReader)
+		}
 		if err != nil {
 			return nil, err
 		}
+		eventIndex.SetActorTypeResolver(func(ctx context.Context, emitter abi.ActorID, ts *types.TipSet) string {
+			idAddr, err := address.NewIDAddress(uint64(emitter))
+			if err != nil {
+				return ""
+			}
+			actor, err := em.chainModule.Stmgr.GetActorAt(ctx, idAddr, ts)
+			if err != nil {
+				return ""
+			}
+			name, _, ok := actors.GetActorMetaByCode(actor.Code)
+			if !ok {
+				return ""
+			}
+			return actors.CanonicalName(name)
+		})
 	}
 
 	ee.EventFilterManager = &filter.EventFilterManager{
@@ -113,12 +142,27 @@ type ethEventAPI struct {
 	MemPoolFilterManager *filter.MemPoolFilterManager
 	FilterStore          filter.FilterStore
 	SubManager           *EthSubscriptionManager
-	MaxFilterHeightRange abi.ChainEpoch
 	SubscribtionCtx      context.Context
+	// maxFilterHeightRange is changed on a config reload
+	maxFilterHeightRange atomic.Int64
+	limits               *wsLimits
 
 	disable bool
 }
 
+// reload applies the filter and websocket limits of cfg, to the filters and subscriptions installed from now on.
+func (e *ethEventAPI) reload(cfg *config.EventConfig) {
+	e.maxFilterHeightRange.Store(int64(cfg.MaxFilterHeightRange))
+	e.limits.set(cfg)
+	if e.disable {
+		return
+	}
+	e.FilterStore.SetLimits(cfg.MaxFilters, cfg.MaxFiltersPerToken)
+	e.EventFilterManager.SetMaxFilterResults(cfg.MaxFilterResults)
+	e.TipSetFilterManager.SetMaxFilterResults(cfg.MaxFilterResults)
+	e.MemPoolFilterManager.SetMaxFilterResults(cfg.MaxFilterResults)
+}
+
 func (e *ethEventAPI) Start(ctx context.Context) error {
 	if e.disable {
 		return nil
@@ -174,6 +218,9 @@ func (e *ethEventAPI) getEthLogsForBlockAndTransaction(ctx context.Context, bloc
 }
 
 func (e *ethEventAPI) EthGetLogs(ctx context.Context, filterSpec *types.EthFilterSpec) (*types.EthFilterResult, error) {
+	ctx, span := trace.StartSpan(ctx, "eth.EthGetLogs")
+	defer span.End()
+
 	ces, err := e.ethGetEventsForFilter(ctx, filterSpec)
 	if err != nil {
 		return nil, err
@@ -384,13 +431,13 @@ func parseBlockRange(heaviest abi.ChainEpoch, fromBlock, toBlock *string, maxRan
 	} else if minHeight >= 0 && maxHeight == -1 {
 		// Here the client is looking for events between some time in the past and the current head
 		if heaviest-minHeight > maxRange {
-			return 0, 0, fmt.Errorf("invalid epoch range: from block is too far in the past (maximum: %d)", maxRange)
+			return 0, 0, types.NewLookbackBeyondLimitError("invalid epoch range: from block is too far in the past (maximum: %d)", maxRange)
 		}
 	} else if minHeight >= 0 && maxHeight >= 0 {
 		if minHeight > maxHeight {
 			return 0, 0, fmt.Errorf("invalid epoch range: to block (%d) must be after from block (%d)", minHeight, maxHeight)
 		} else if maxHeight-minHeight > maxRange {
-			return 0, 0, fmt.Errorf("invalid epoch range: range between to and from blocks is too large (maximum: %d)", maxRange)
+			return 0, 0, types.NewLookbackBeyondLimitError("invalid epoch range: range between to and from blocks is too large (maximum: %d)", maxRange)
 		}
 	}
 	return minHeight, maxHeight, nil
@@ -416,7 +463,7 @@ func (e *ethEventAPI) installEthFilterSpec(ctx context.Context, filterSpec *type
 		if err != nil {
 			return nil, err
 		}
-		minHeight, maxHeight, err = parseBlockRange(head.Height(), filterSpec.FromBlock, filterSpec.ToBlock, e.MaxFilterHeightRange)
+		minHeight, maxHeight, err = parseBlockRange(head.Height(), filterSpec.FromBlock, filterSpec.ToBlock, abi.ChainEpoch(e.maxFilterHeightRange.Load()))
 		if err != nil {
 			return nil, err
 		}
@@ -565,6 +612,64 @@ func (e *ethEventAPI) uninstallFilter(ctx context.Context, f filter.Filter) erro
 	return e.FilterStore.Remove(ctx, f.ID())
 }
 
+func (e *ethEventAPI) EthListFilters(ctx context.Context) ([]types.EthFilterInfo, error) {
+	if e.FilterStore == nil {
+		return nil, api.ErrNotSupported
+	}
+
+	infos := e.FilterStore.List(ctx)
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Installed.Before(infos[j].Installed)
+	})
+	out := make([]types.EthFilterInfo, 0, len(infos))
+	for _, info := range infos {
+		out = append(out, ethFilterInfo(info))
+	}
+	return out, nil
+}
+
+func (e *ethEventAPI) EthFilterStatus(ctx context.Context, id types.EthFilterID) (*types.EthFilterInfo, error) {
+	if e.FilterStore == nil {
+		return nil, api.ErrNotSupported
+	}
+
+	info, err := e.FilterStore.Info(ctx, types.FilterID(id))
+	if err != nil {
+		return nil, err
+	}
+	out := ethFilterInfo(info)
+	return &out, nil
+}
+
+func (e *ethEventAPI) EthListSubscriptions(ctx context.Context) ([]types.EthSubscriptionInfo, error) {
+	if e.SubManager == nil {
+		return nil, api.ErrNotSupported
+	}
+	return e.SubManager.list(), nil
+}
+
+func ethFilterInfo(info filter.FilterInfo) types.EthFilterInfo {
+	var typ string
+	switch info.Filter.(type) {
+	case filter.EventFilter:
+		typ = "logs"
+	case *filter.TipSetFilter:
+		typ = "blocks"
+	case *filter.MemPoolFilter:
+		typ = "pendingTransactions"
+	}
+	usage := info.Filter.Usage()
+	return types.EthFilterInfo{
+		ID:          types.EthFilterID(info.Filter.ID()),
+		Type:        typ,
+		Owner:       info.Owner,
+		Installed:   info.Installed,
+		LastTaken:   info.Filter.LastTaken(),
+		Collected:   usage.Collected,
+		MemoryUsage: usage.Bytes,
+	}
+}
+
 const (
 	EthSubscribeEventTypeHeads               = "newHeads"
 	EthSubscribeEventTypeLogs                = "logs"
@@ -587,7 +692,9 @@ func (e *ethEventAPI) EthSubscribe(ctx context.Context, p jsonrpc.RawParams) (ty
 		return types.EthSubscriptionID{}, fmt.Errorf("connection doesn't support callbacks")
 	}
 
-	sub, err := e.SubManager.StartSubscription(e.SubscribtionCtx, ethCb.EthSubscription, e.uninstallFilter)
+	// the calls made over a websocket connection of the rpc api are tagged by WebsocketLimits
+	connID, _ := wsConnID(ctx)
+	sub, err := e.SubManager.StartSubscription(e.SubscribtionCtx, connID, params.EventType, ethCb.EthSubscription, e.uninstallFilter)
 	if err != nil {
 		return types.EthSubscriptionID{}, err
 	}
@@ -619,12 +726,19 @@ func (e *ethEventAPI) EthSubscribe(ctx context.Context, p jsonrpc.RawParams) (ty
 			for _, ea := range params.Params.Address {
 				a, err := ea.ToFilecoinAddress()
 				if err != nil {
+					_, _ = e.EthUnsubscribe(ctx, sub.id)
 					return types.EthSubscriptionID{}, fmt.Errorf("invalid address %x", ea)
 				}
 				addresses = append(addresses, a)
 			}
 		}
 
+		replay := params.Params != nil && params.Params.FromBlock != nil
+		if replay {
+			// hold the new logs back until the missed ones are replayed
+			sub.in <- ethSubscriptionReplayStart{}
+		}
+
 		f, err := e.EventFilterManager.Install(ctx, -1, -1, cid.Undef, addresses, keysToKeysWithCodec(keys), true)
 		if err != nil {
 			// clean up any previous filters added and stop the sub
@@ -633,6 +747,13 @@ func (e *ethEventAPI) EthSubscribe(ctx context.Context, p jsonrpc.RawParams) (ty
 		}
 		sub.addFilter(ctx, f)
 
+		if replay {
+			if err := e.replayLogs(ctx, sub, params.Params); err != nil {
+				_, _ = e.EthUnsubscribe(ctx, sub.id)
+				return types.EthSubscriptionID{}, err
+			}
+		}
+
 	case EthSubscribeEventTypePendingTransactions:
 		f, err := e.MemPoolFilterManager.Install(ctx)
 		if err != nil {
@@ -643,12 +764,41 @@ func (e *ethEventAPI) EthSubscribe(ctx context.Context, p jsonrpc.RawParams) (ty
 
 		sub.addFilter(ctx, f)
 	default:
+		// the subscription counts towards the limit of the connection until it is stopped
+		_, _ = e.EthUnsubscribe(ctx, sub.id)
 		return types.EthSubscriptionID{}, fmt.Errorf("unsupported event type: %s", params.EventType)
 	}
 
 	return sub.id, nil
 }
 
+// replayLogs collects the logs matching params from params.FromBlock to the last executed tipset, and passes them
+// to sub which sends them before the new logs.
+func (e *ethEventAPI) replayLogs(ctx context.Context, sub *ethSubscription, params *types.EthSubscriptionParams) error {
+	until := e.em.chainModule.ChainReader.GetHead().Height() - 1
+	replay := &ethSubscriptionReplay{until: until}
+	if abi.ChainEpoch(*params.FromBlock) <= until {
+		from, to := params.FromBlock.Hex(), types.EthUint64(until).Hex()
+		ces, err := e.ethGetEventsForFilter(ctx, &types.EthFilterSpec{
+			FromBlock: &from,
+			ToBlock:   &to,
+			Address:   params.Address,
+			Topics:    params.Topics,
+		})
+		if err != nil {
+			return fmt.Errorf("replaying the logs from %d: %w", *params.FromBlock, err)
+		}
+		replay.events = ces
+	}
+
+	select {
+	case sub.in <- replay:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 func (e *ethEventAPI) EthUnsubscribe(ctx context.Context, id types.EthSubscriptionID) (bool, error) {
 	if e.SubManager == nil {
 		return false, api.ErrNotSupported
@@ -662,6 +812,68 @@ func (e *ethEventAPI) EthUnsubscribe(ctx context.Context, id types.EthSubscripti
 	return true, nil
 }
 
+// maxEventsBackfillRange bounds the number of epochs re-executed by a single EthEventsBackfill call.
+const maxEventsBackfillRange = 2880
+
+func (e *ethEventAPI) EthEventsBackfill(ctx context.Context, fromEpoch, toEpoch abi.ChainEpoch) (*types.EthEventsBackfillResult, error) {
+	if e.EventFilterManager == nil || e.EventFilterManager.EventIndex == nil {
+		return nil, fmt.Errorf("cannot backfill events if historical event index is disabled")
+	}
+	if e.EventFilterManager.EventIndex.ReadOnly() {
+		return nil, filter.ErrReadOnlyEventIndex
+	}
+	if fromEpoch < 0 || fromEpoch > toEpoch {
+		return nil, fmt.Errorf("invalid epoch range %d-%d", fromEpoch, toEpoch)
+	}
+	if toEpoch-fromEpoch >= maxEventsBackfillRange {
+		return nil, fmt.Errorf("epoch range %d-%d exceeds the maximum of %d epochs", fromEpoch, toEpoch, maxEventsBackfillRange)
+	}
+
+	cr := e.em.chainModule.ChainReader
+	head := cr.GetHead()
+	// the events of a tipset are in the receipts of its child
+	if toEpoch >= head.Height() {
+		return nil, fmt.Errorf("epoch %d has not been executed yet, head is at %d", toEpoch, head.Height())
+	}
+
+	res := &types.EthEventsBackfillResult{From: fromEpoch, To: toEpoch}
+	rctTS, err := cr.GetTipSetByHeight(ctx, head, toEpoch+1, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tipset at %d: %w", toEpoch+1, err)
+	}
+	for rctTS.Height() > fromEpoch {
+		msgTS, err := cr.GetTipSet(ctx, rctTS.Parents())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load parent of %s: %w", rctTS.Key(), err)
+		}
+		if msgTS.Height() < fromEpoch {
+			break
+		}
+		if msgTS.Height() <= toEpoch {
+			// re-execution rewrites the receipts and event AMTs the index is fed from
+			_, rctRoot, err := e.em.chainModule.Stmgr.RunStateTransition(ctx, msgTS, nil, false)
+			if err != nil {
+				return nil, fmt.Errorf("failed to execute tipset at %d: %w", msgTS.Height(), err)
+			}
+			if rctRoot != rctTS.Blocks()[0].ParentMessageReceipts {
+				return nil, fmt.Errorf("receipts of tipset at %d do not match the chain: %s != %s", msgTS.Height(), rctRoot, rctTS.Blocks()[0].ParentMessageReceipts)
+			}
+
+			stats, err := e.EventFilterManager.Reindex(ctx, msgTS, rctTS)
+			if err != nil {
+				return nil, fmt.Errorf("failed to index events of tipset at %d: %w", msgTS.Height(), err)
+			}
+			res.Tipsets++
+			res.Inserted += stats.Inserted
+			res.Skipped += stats.Skipped
+		}
+		rctTS = msgTS
+	}
+
+	log.Infof("backfilled events of epochs %d-%d: %d tipsets, %d inserted, %d skipped", fromEpoch, toEpoch, res.Tipsets, res.Inserted, res.Skipped)
+	return res, nil
+}
+
 // GC runs a garbage collection loop, deleting filters that have not been used within the ttl window
 func (e *ethEventAPI) GC(ctx context.Context, ttl time.Duration) {
 	if e.FilterStore == nil {
@@ -712,7 +924,7 @@ func (e *ethEventAPI) parseEthFilterSpec(filterSpec *types.EthFilterSpec) (*pars
 	} else {
 		var err error
 		head := e.em.chainModule.ChainReader.GetHead()
-		minHeight, maxHeight, err = parseBlockRange(head.Height(), filterSpec.FromBlock, filterSpec.ToBlock, e.MaxFilterHeightRange)
+		minHeight, maxHeight, err = parseBlockRange(head.Height(), filterSpec.FromBlock, filterSpec.ToBlock, abi.ChainEpoch(e.maxFilterHeightRange.Load()))
 		if err != nil {
 			return nil, err
 		}
@@ -917,11 +1129,16 @@ type EthSubscriptionManager struct { // nolint
 	ChainAPI     v1.IChain
 	messageStore *chain.MessageStore
 	stmgr        *statemanger.Stmgr
+	limits       *wsLimits
 	mu           sync.Mutex
 	subs         map[types.EthSubscriptionID]*ethSubscription
+	// connSubs counts the subscriptions of the websocket connections
+	connSubs map[uint64]int
 }
 
-func (e *EthSubscriptionManager) StartSubscription(ctx context.Context, out ethSubscriptionCallback, dropFilter func(context.Context, filter.Filter) error) (*ethSubscription, error) { // nolint
+// StartSubscription starts a subscription of type kind of the websocket connection connID, 0 when the connection
+// is not known, it fails when the connection has MaxSubscriptionsPerConnection subscriptions.
+func (e *EthSubscriptionManager) StartSubscription(ctx context.Context, connID uint64, kind string, out ethSubscriptionCallback, dropFilter func(context.Context, filter.Filter) error) (*ethSubscription, error) { // nolint
 	rawid, err := uuid.NewRandom()
 	if err != nil {
 		return nil, fmt.Errorf("new uuid: %w", err)
@@ -929,14 +1146,24 @@ func (e *EthSubscriptionManager) StartSubscription(ctx context.Context, out ethS
 	id := types.EthSubscriptionID{}
 	copy(id[:], rawid[:]) // uuid is 16 bytes
 
-	ctx, quit := context.WithCancel(ctx)
+	e.mu.Lock()
+	if limit := e.limits.maxSubscriptionsPerConn.Load(); connID != 0 && limit > 0 && int64(e.connSubs[connID]) >= limit {
+		e.mu.Unlock()
+		tickRejected(ctx, limitSubscriptions)
+		return nil, fmt.Errorf("too many subscriptions on the connection, the limit is %d", limit)
+	}
 
+	subCtx, quit := context.WithCancel(ctx)
 	sub := &ethSubscription{
 		chainAPI:        e.ChainAPI,
 		stmgr:           e.stmgr,
 		messageStore:    e.messageStore,
 		uninstallFilter: dropFilter,
 		id:              id,
+		kind:            kind,
+		connID:          connID,
+		started:         time.Now(),
+		limits:          e.limits,
 		in:              make(chan interface{}, 200),
 		out:             out,
 		quit:            quit,
@@ -944,36 +1171,102 @@ func (e *EthSubscriptionManager) StartSubscription(ctx context.Context, out ethS
 		toSend:   queue.New[[]byte](),
 		sendCond: make(chan struct{}, 1),
 	}
+	sub.onStop = func() {
+		e.removeSubscription(ctx, sub)
+	}
 
-	e.mu.Lock()
 	if e.subs == nil {
 		e.subs = make(map[types.EthSubscriptionID]*ethSubscription)
+		e.connSubs = make(map[uint64]int)
 	}
 	e.subs[sub.id] = sub
+	if connID != 0 {
+		e.connSubs[connID]++
+	}
+	ethSubscriptions.Set(ctx, int64(len(e.subs)))
 	e.mu.Unlock()
 
-	go sub.start(ctx)
-	go sub.startOut(ctx)
+	go sub.start(subCtx)
+	go sub.startOut(subCtx)
 
 	return sub, nil
 }
 
 func (e *EthSubscriptionManager) StopSubscription(ctx context.Context, id types.EthSubscriptionID) error {
 	e.mu.Lock()
-	defer e.mu.Unlock()
-
 	sub, ok := e.subs[id]
+	e.mu.Unlock()
 	if !ok {
 		return fmt.Errorf("subscription not found")
 	}
+	// stopping the subscription removes it
 	sub.stop()
-	delete(e.subs, id)
 
 	return nil
 }
 
+// stopConnection stops the subscriptions of the websocket connection connID once it is closed.
+func (e *EthSubscriptionManager) stopConnection(_ context.Context, connID uint64) {
+	e.mu.Lock()
+	var subs []*ethSubscription
+	for _, sub := range e.subs {
+		if sub.connID == connID {
+			subs = append(subs, sub)
+		}
+	}
+	e.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.stop()
+	}
+}
+
+// list returns the active subscriptions, ordered by start time.
+func (e *EthSubscriptionManager) list() []types.EthSubscriptionInfo {
+	e.mu.Lock()
+	subs := make([]*ethSubscription, 0, len(e.subs))
+	for _, sub := range e.subs {
+		subs = append(subs, sub)
+	}
+	e.mu.Unlock()
+
+	out := make([]types.EthSubscriptionInfo, 0, len(subs))
+	for _, sub := range subs {
+		out = append(out, sub.info())
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Started.Before(out[j].Started)
+	})
+	return out
+}
+
+func (e *EthSubscriptionManager) removeSubscription(ctx context.Context, sub *ethSubscription) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, ok := e.subs[sub.id]; !ok {
+		return
+	}
+	delete(e.subs, sub.id)
+	if sub.connID != 0 {
+		if e.connSubs[sub.connID]--; e.connSubs[sub.connID] <= 0 {
+			delete(e.connSubs, sub.connID)
+		}
+	}
+	ethSubscriptions.Set(ctx, int64(len(e.subs)))
+}
+
 type ethSubscriptionCallback func(context.Context, jsonrpc.RawParams) error
 
+// ethSubscriptionReplayStart makes a subscription hold the new logs back until the ethSubscriptionReplay.
+type ethSubscriptionReplayStart struct{}
+
+// ethSubscriptionReplay holds the logs replayed to a subscription, up to the height until.
+type ethSubscriptionReplay struct {
+	events []*filter.CollectedEvent
+	until  abi.ChainEpoch
+}
+
 const maxSendQueue = 20000
 
 type ethSubscription struct {
@@ -982,17 +1275,29 @@ type ethSubscription struct {
 	messageStore    *chain.MessageStore
 	uninstallFilter func(context.Context, filter.Filter) error
 	id              types.EthSubscriptionID
+	kind            string
+	connID          uint64
+	started         time.Time
+	limits          *wsLimits
 	in              chan interface{}
 	out             ethSubscriptionCallback
 
 	mu      sync.Mutex
 	filters []filter.Filter
 	quit    func()
+	// onStop removes the subscription from its manager
+	onStop func()
 
 	sendLk       sync.Mutex
 	sendQueueLen int
-	toSend       *queue.Queue[[]byte]
-	sendCond     chan struct{}
+	// sendQueueSize is the size of the responses in toSend, accounted in the send buffer of limits
+	sendQueueSize int64
+	toSend        *queue.Queue[[]byte]
+	sendCond      chan struct{}
+	sendClosed    bool
+	// dropped and coalesced count the responses removed from toSend by the backpressure policy
+	dropped   uint64
+	coalesced uint64
 
 	lastSentTipset *types.TipSetKey
 }
@@ -1018,6 +1323,8 @@ func (e *ethSubscription) startOut(ctx context.Context) {
 			for !e.toSend.Empty() {
 				front := e.toSend.Dequeue()
 				e.sendQueueLen--
+				e.sendQueueSize -= int64(len(front))
+				e.limits.releaseSendBuffer(ctx, int64(len(front)))
 
 				e.sendLk.Unlock()
 
@@ -1035,7 +1342,7 @@ func (e *ethSubscription) startOut(ctx context.Context) {
 	}
 }
 
-func (e *ethSubscription) send(_ context.Context, v interface{}) {
+func (e *ethSubscription) send(ctx context.Context, v interface{}) {
 	resp := types.EthSubscriptionResponse{
 		SubscriptionID: e.id,
 		Result:         v,
@@ -1048,24 +1355,98 @@ func (e *ethSubscription) send(_ context.Context, v interface{}) {
 	}
 
 	e.sendLk.Lock()
-	defer e.sendLk.Unlock()
-
-	e.toSend.Enqueue(outParam)
-
-	e.sendQueueLen++
-	if e.sendQueueLen > maxSendQueue {
-		log.Warnw("subscription send queue full, killing subscription", "sub", e.id)
+	if e.sendClosed {
+		e.sendLk.Unlock()
+		return
+	}
+	size := int64(len(outParam))
+	policy := e.limits.backpressurePolicy()
+	for e.sendQueueLen >= maxSendQueue || !e.limits.reserveSendBuffer(ctx, size) {
+		if e.makeRoom(ctx, policy) {
+			continue
+		}
+		queueFull, queued := e.sendQueueLen >= maxSendQueue, e.sendQueueSize
+		e.sendLk.Unlock()
+		if queueFull {
+			log.Warnw("subscription send queue full, killing subscription", "sub", e.id)
+		} else {
+			tickRejected(ctx, limitSendBuffer)
+			log.Warnw("subscription send buffer full, killing subscription", "sub", e.id, "queued", queued)
+		}
 		e.stop()
 		return
 	}
 
+	e.toSend.Enqueue(outParam)
+	e.sendQueueLen++
+	e.sendQueueSize += size
+
 	select {
 	case e.sendCond <- struct{}{}:
 	default: // already signalled, and we're holding the lock so we know that the event will be processed
 	}
+	e.sendLk.Unlock()
+}
+
+// makeRoom removes responses from toSend by policy when the send queue or buffer is full, false when the
+// subscription has to be stopped. The caller holds sendLk.
+func (e *ethSubscription) makeRoom(ctx context.Context, policy string) bool {
+	var n int
+	switch {
+	case e.toSend.Empty():
+		return false
+	case policy == config.BackpressureDropOldest:
+		n = 1
+		e.dropped++
+	case policy == config.BackpressureCoalesce && e.kind == EthSubscribeEventTypeHeads:
+		// the client gets the latest head once it catches up
+		n = e.sendQueueLen
+		e.coalesced += uint64(n)
+	default:
+		return false
+	}
+	for i := 0; i < n; i++ {
+		front := e.toSend.Dequeue()
+		e.sendQueueLen--
+		e.sendQueueSize -= int64(len(front))
+		e.limits.releaseSendBuffer(ctx, int64(len(front)))
+	}
+	recordDrops(ctx, policy, n)
+	return true
+}
+
+func (e *ethSubscription) info() types.EthSubscriptionInfo {
+	e.sendLk.Lock()
+	defer e.sendLk.Unlock()
+
+	return types.EthSubscriptionInfo{
+		ID:         e.id,
+		Type:       e.kind,
+		Connection: e.connID,
+		Started:    e.started,
+		QueueLen:   e.sendQueueLen,
+		QueueSize:  e.sendQueueSize,
+		Dropped:    e.dropped,
+		Coalesced:  e.coalesced,
+	}
+}
+
+// closeSend drops the responses not sent yet and releases their size from the send buffer.
+func (e *ethSubscription) closeSend() {
+	e.sendLk.Lock()
+	defer e.sendLk.Unlock()
+
+	e.sendClosed = true
+	e.limits.releaseSendBuffer(context.TODO(), e.sendQueueSize)
+	e.toSend = queue.New[[]byte]()
+	e.sendQueueLen, e.sendQueueSize = 0, 0
 }
 
 func (e *ethSubscription) start(ctx context.Context) {
+	var (
+		replaying bool
+		held      []*filter.CollectedEvent
+	)
 	if ctx.Err() == nil {
 		for {
 			select {
@@ -1073,15 +1454,25 @@ func (e *ethSubscription) start(ctx context.Context) {
 				return
 			case v := <-e.in:
 				switch vt := v.(type) {
+				case ethSubscriptionReplayStart:
+					replaying = true
+				case *ethSubscriptionReplay:
+					for _, ev := range vt.events {
+						e.sendEvent(ctx, ev)
+					}
+					// the logs held back which the replay covers are dropped, their reverts are not
+					for _, ev := range held {
+						if ev.Reverted || ev.Height > vt.until {
+							e.sendEvent(ctx, ev)
+						}
+					}
+					replaying, held = false, nil
 				case *filter.CollectedEvent:
-					evs, err := ethFilterResultFromEvents(ctx, []*filter.CollectedEvent{vt}, e.messageStore)
-					if err != nil {
+					if replaying {
+						held = append(held, vt)
 						continue
 					}
-
-					for _, r := range evs.Results {
-						e.send(ctx, r)
-					}
+					e.sendEvent(ctx, vt)
 				case *types.TipSet:
 					// Skip processing for tipset at epoch 0 as it has no parent
 					if vt.Height() == 0 {
@@ -1121,6 +1512,17 @@ func (e *ethSubscription) start(ctx context.Context) {
 	}
 }
 
+func (e *ethSubscription) sendEvent(ctx context.Context, ev *filter.CollectedEvent) {
+	evs, err := ethFilterResultFromEvents(ctx, []*filter.CollectedEvent{ev}, e.messageStore)
+	if err != nil {
+		return
+	}
+
+	for _, r := range evs.Results {
+		e.send(ctx, r)
+	}
+}
+
 func (e *ethSubscription) stop() {
 	e.mu.Lock()
 	if e.quit == nil {
@@ -1133,6 +1535,11 @@ func (e *ethSubscription) stop() {
 		e.quit = nil
 		e.mu.Unlock()
 
+		e.closeSend()
+		if e.onStop != nil {
+			e.onStop()
+		}
+
 		for _, f := range e.filters {
 			// note: the context in actually unused in uninstallFilter
 			if err := e.uninstallFilter(context.TODO(), f); err != nil {
@@ -0,0 +1,75 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/constants"
+	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// getF3FinalizedTipSet returns the head of the chain finalized by the latest F3 certificate,
+// nil when F3 is disabled, has no certificate yet, or the tipset is not on the chain of head.
+func getF3FinalizedTipSet(ctx context.Context, store *chain.Store, f3 v1api.IF3, head *types.TipSet) *types.TipSet {
+	if f3 == nil {
+		return nil
+	}
+	cert, err := f3.F3GetLatestCertificate(ctx)
+	if err != nil {
+		log.Debugf("F3 finality is unavailable, falling back to EC finality: %v", err)
+		return nil
+	}
+	if cert == nil || cert.ECChain.IsZero() {
+		return nil
+	}
+
+	tsk, err := types.TipSetKeyFromBytes(cert.ECChain.Head().Key)
+	if err != nil {
+		log.Warnf("invalid tipset key in F3 certificate %d: %v", cert.GPBFTInstance, err)
+		return nil
+	}
+	ts, err := store.GetTipSet(ctx, tsk)
+	if err != nil {
+		log.Debugf("tipset %s finalized by F3 is not available: %v", tsk, err)
+		return nil
+	}
+	if ts.Height() > head.Height() {
+		return nil
+	}
+	canonical, err := store.GetTipSetByHeight(ctx, head, ts.Height(), true)
+	if err != nil || !canonical.Equals(ts) {
+		log.Warnf("tipset %s finalized by F3 is not on the chain of head %s", tsk, head.Key())
+		return nil
+	}
+	return ts
+}
+
+// getFinalizedTipSet returns the tipset finalized by F3, or the tipset at EC finality when it
+// is more recent or F3 is unavailable.
+func getFinalizedTipSet(ctx context.Context, store *chain.Store, f3 v1api.IF3, head *types.TipSet) (*types.TipSet, error) {
+	return getTipSetAtLeastFinalized(ctx, store, f3, head, head.Height()-1-constants.Finality)
+}
+
+// getSafeTipSet returns the tipset SafeEpochDelay epochs behind latest, or the tipset finalized
+// by F3 when it is more recent.
+func getSafeTipSet(ctx context.Context, store *chain.Store, f3 v1api.IF3, head *types.TipSet) (*types.TipSet, error) {
+	return getTipSetAtLeastFinalized(ctx, store, f3, head, head.Height()-1-types.SafeEpochDelay)
+}
+
+func getTipSetAtLeastFinalized(ctx context.Context, store *chain.Store, f3 v1api.IF3, head *types.TipSet, height abi.ChainEpoch) (*types.TipSet, error) {
+	if height < 0 {
+		height = 0
+	}
+	if ts := getF3FinalizedTipSet(ctx, store, f3, head); ts != nil && ts.Height() >= height {
+		return ts, nil
+	}
+	ts, err := store.GetTipSetByHeight(ctx, head, height, true)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get tipset at height: %v", height)
+	}
+	return ts, nil
+}
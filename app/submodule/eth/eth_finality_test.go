@@ -0,0 +1,77 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-f3/certs"
+	"github.com/filecoin-project/go-f3/gpbft"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/constants"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+type fakeF3 struct {
+	v1api.IF3
+	cert *certs.FinalityCertificate
+}
+
+func (f *fakeF3) F3GetLatestCertificate(context.Context) (*certs.FinalityCertificate, error) {
+	if f.cert == nil {
+		return nil, errors.New("f3 is disabled")
+	}
+	return f.cert, nil
+}
+
+func TestSafeAndFinalizedTipSets(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	builder := chain.NewBuilder(t, address.Undef)
+	store := builder.Store()
+	head := builder.AppendManyOn(ctx, int(constants.Finality)+50, builder.Genesis())
+	require.NoError(t, store.SetHead(ctx, head))
+
+	certFor := func(ts *types.TipSet) *certs.FinalityCertificate {
+		return &certs.FinalityCertificate{
+			ECChain: &gpbft.ECChain{TipSets: []*gpbft.TipSet{{Epoch: int64(ts.Height()), Key: ts.Key().Bytes()}}},
+		}
+	}
+	heightOf := func(ts *types.TipSet, err error) int64 {
+		require.NoError(t, err)
+		return int64(ts.Height())
+	}
+	ecSafe := int64(head.Height() - 1 - types.SafeEpochDelay)
+	ecFinalized := int64(head.Height() - 1 - constants.Finality)
+
+	// without F3, EC finality is used
+	f3 := &fakeF3{}
+	assert.Equal(t, ecSafe, heightOf(getSafeTipSet(ctx, store, f3, head)))
+	assert.Equal(t, ecFinalized, heightOf(getFinalizedTipSet(ctx, store, f3, head)))
+	assert.Equal(t, ecFinalized, heightOf(getFinalizedTipSet(ctx, store, nil, head)))
+
+	// a recent F3 certificate finalizes more recent tipsets
+	recent, err := store.GetTipSetByHeight(ctx, head, head.Height()-5, true)
+	require.NoError(t, err)
+	f3.cert = certFor(recent)
+	assert.Equal(t, int64(recent.Height()), heightOf(getSafeTipSet(ctx, store, f3, head)))
+	assert.Equal(t, int64(recent.Height()), heightOf(getFinalizedTipSet(ctx, store, f3, head)))
+
+	// a lagging F3 certificate is superseded by EC finality
+	old, err := store.GetTipSetByHeight(ctx, head, 10, true)
+	require.NoError(t, err)
+	f3.cert = certFor(old)
+	assert.Equal(t, ecSafe, heightOf(getSafeTipSet(ctx, store, f3, head)))
+	assert.Equal(t, ecFinalized, heightOf(getFinalizedTipSet(ctx, store, f3, head)))
+
+	// a certificate for a tipset off the chain of head is ignored
+	fork := builder.AppendOn(ctx, recent, 2)
+	f3.cert = certFor(fork)
+	assert.Equal(t, ecFinalized, heightOf(getFinalizedTipSet(ctx, store, f3, head)))
+}
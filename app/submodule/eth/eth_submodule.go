@@ -20,6 +20,7 @@ func NewEthSubModule(ctx context.Context,
 	mpoolModule *mpool.MessagePoolSubmodule,
 	sqlitePath string,
 	syncAPI v1api.ISyncer,
+	f3API v1api.IF3,
 ) (*EthSubModule, error) {
 	ctx, cancel := context.WithCancel(ctx)
 	em := &EthSubModule{
@@ -30,6 +31,7 @@ func NewEthSubModule(ctx context.Context,
 		ctx:         ctx,
 		cancel:      cancel,
 		syncAPI:     syncAPI,
+		f3API:       f3API,
 	}
 	ee, err := newEthEventAPI(ctx, em)
 	if err != nil {
@@ -72,6 +74,8 @@ type EthSubModule struct { // nolint
 	ctx     context.Context
 	cancel  context.CancelFunc
 	syncAPI v1api.ISyncer
+	// f3API resolves the "safe" and "finalized" block tags
+	f3API v1api.IF3
 }
 
 func (em *EthSubModule) Start(_ context.Context) error {
@@ -93,6 +97,22 @@ func (em *EthSubModule) Close(ctx context.Context) error {
 	return em.ethAPIAdapter.close()
 }
 
+// Vacuum reclaims the space of the rows deleted from the sqlite indexes of the module.
+func (em *EthSubModule) Vacuum(ctx context.Context) error {
+	if fm := em.ethEventAPI.EventFilterManager; fm != nil && fm.EventIndex != nil {
+		if err := fm.EventIndex.Vacuum(ctx); err != nil {
+			return err
+		}
+	}
+	return em.ethAPIAdapter.vacuum(ctx)
+}
+
+// Reload applies the reloadable fields of cfg, the filter limits and the policy of the untrusted transactions.
+func (em *EthSubModule) Reload(cfg *config.Config) error {
+	em.ethEventAPI.reload(&cfg.FevmConfig.Event)
+	return em.ethAPIAdapter.reload(cfg.FevmConfig)
+}
+
 func (em *EthSubModule) GetEventFilterManager() *filter.EventFilterManager {
 	return em.ethEventAPI.EventFilterManager
 }
@@ -101,6 +121,8 @@ type ethAPIAdapter interface {
 	v1api.IETH
 	start(ctx context.Context) error
 	close() error
+	vacuum(ctx context.Context) error
+	reload(cfg *config.FevmConfig) error
 }
 
 type fullETHAPI struct {
@@ -2,18 +2,25 @@ package eth
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"sort"
 	"testing"
 
 	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
 	"github.com/multiformats/go-multicodec"
 	"github.com/stretchr/testify/require"
 	cbg "github.com/whyrusleeping/cbor-gen"
 
+	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/venus/pkg/config"
 	"github.com/filecoin-project/venus/pkg/messagepool"
+	"github.com/filecoin-project/venus/venus-shared/blockstore"
 	"github.com/filecoin-project/venus/venus-shared/types"
 )
 
@@ -292,3 +299,255 @@ func TestDecodePayload(t *testing.T) {
 	_, err = decodePayload(w.Bytes(), 42)
 	require.Error(t, err)
 }
+
+func TestUntrustedTxPolicy(t *testing.T) {
+	allowed, err := types.ParseEthAddress("0x5cbeecf99d3fdb3f25e309cc264f240bb0664031")
+	require.NoError(t, err)
+	allowedAddr, err := allowed.ToFilecoinAddress()
+	require.NoError(t, err)
+	denied, err := types.ParseEthAddress("0x0707070707070707070707070707070707070707")
+	require.NoError(t, err)
+	deniedAddr, err := denied.ToFilecoinAddress()
+	require.NoError(t, err)
+
+	_, err = newUntrustedTxPolicy(config.UntrustedTxConfig{AllowSenders: []string{"not an address"}})
+	require.Error(t, err)
+
+	policy, err := newUntrustedTxPolicy(config.UntrustedTxConfig{
+		AllowSenders:        []string{allowed.String(), deniedAddr.String()},
+		DenySenders:         []string{denied.String()},
+		MaxGasLimit:         1000,
+		MaxPendingPerSender: 2,
+	})
+	require.NoError(t, err)
+
+	other, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+
+	require.NoError(t, policy.check(&types.Message{From: allowedAddr, GasLimit: 1000}, 1))
+	require.ErrorContains(t, policy.check(&types.Message{From: deniedAddr, GasLimit: 1000}, 0), "not allowed")
+	require.ErrorContains(t, policy.check(&types.Message{From: other, GasLimit: 1000}, 0), "not allowed")
+	require.ErrorContains(t, policy.check(&types.Message{From: allowedAddr, GasLimit: 1001}, 0), "gas limit")
+	require.ErrorContains(t, policy.check(&types.Message{From: allowedAddr, GasLimit: 1000}, 2), "pending")
+
+	open, err := newUntrustedTxPolicy(config.UntrustedTxConfig{})
+	require.NoError(t, err)
+	require.NoError(t, open.check(&types.Message{From: other, GasLimit: 1 << 40}, 1000))
+}
+
+func TestListEVMStorage(t *testing.T) {
+	ctx := context.Background()
+	bs := blockstore.NewMemory()
+	put := func(obj interface{}) cid.Cid {
+		nd, err := cbor.WrapObject(obj, cbor.DefaultMultihash, -1)
+		require.NoError(t, err)
+		require.NoError(t, bs.Put(ctx, nd))
+		return nd.Cid()
+	}
+	key := func(b0, b1 byte) types.EthHash {
+		var h types.EthHash
+		h[0], h[1] = b0, b1
+		return h
+	}
+	entry := func(k types.EthHash, v byte) []interface{} {
+		return []interface{}{k[:], []byte{v}}
+	}
+
+	// a1, a2: a bucket at index 1 of the root, b: index 2 then index 1 of a plain child,
+	// c: index 4 then the extension 101 then index 3 of the child
+	a1 := key(0b00001000, 0)
+	a2 := key(0b00001000, 1)
+	b := key(0b00010000, 0b01000000)
+	c := key(0b00100101, 0b00011000)
+
+	child := put([]interface{}{[]byte{0b10}, []interface{}{[]interface{}{entry(b, 20)}}})
+	extChild := put([]interface{}{[]byte{0b1000}, []interface{}{[]interface{}{entry(c, 30)}}})
+	root := put([]interface{}{[]byte{0b10110}, []interface{}{
+		[]interface{}{entry(a1, 10), entry(a2, 11)},
+		child,
+		[]interface{}{extChild, []byte{3, 0b10100000}},
+	}})
+
+	var all []types.EthStorageSlot
+	var after *types.EthHash
+	for {
+		page, err := listEVMStorage(ctx, bs, root, after, 2)
+		require.NoError(t, err)
+		all = append(all, page.Slots...)
+		if page.Next == nil {
+			break
+		}
+		require.Len(t, page.Slots, 2)
+		after = page.Next
+	}
+	require.Len(t, all, 4)
+	for i, k := range []types.EthHash{a1, a2, b, c} {
+		require.Equal(t, k, all[i].Key)
+	}
+	require.Equal(t, byte(30), all[3].Value[31])
+
+	// every slot can be resumed from, even from within a bucket
+	for i, k := range []types.EthHash{a1, a2, b} {
+		page, err := listEVMStorage(ctx, bs, root, &k, 1)
+		require.NoError(t, err)
+		require.Equal(t, all[i+1:i+2], page.Slots)
+	}
+	page, err := listEVMStorage(ctx, bs, root, &c, 2)
+	require.NoError(t, err)
+	require.Empty(t, page.Slots)
+	require.Nil(t, page.Next)
+
+	for _, missing := range []types.EthHash{
+		key(0b00011000, 0),          // index 3 is empty
+		key(0b00001000, 2),          // not in the bucket
+		key(0b00100111, 0b00011000), // extension mismatch
+	} {
+		_, err := listEVMStorage(ctx, bs, root, &missing, 2)
+		require.ErrorContains(t, err, "not found")
+	}
+
+	rec := newRecordingStore(bs)
+	_, err = listEVMStorage(ctx, rec, root, nil, 10)
+	require.NoError(t, err)
+	require.Len(t, rec.blocks, 3)
+
+	// resuming from c skips the child holding b
+	rec = newRecordingStore(bs)
+	_, err = listEVMStorage(ctx, rec, root, &c, 10)
+	require.NoError(t, err)
+	require.Len(t, rec.blocks, 2)
+}
+
+func TestListEVMStoragePages(t *testing.T) {
+	ctx := context.Background()
+	bs := blockstore.NewMemory()
+	put := func(obj interface{}) cid.Cid {
+		nd, err := cbor.WrapObject(obj, cbor.DefaultMultihash, -1)
+		require.NoError(t, err)
+		require.NoError(t, bs.Put(ctx, nd))
+		return nd.Cid()
+	}
+
+	// build a storage of a few levels, buckets hold up to 3 slots
+	var build func(keys []types.EthHash, consumed int) cid.Cid
+	build = func(keys []types.EthHash, consumed int) cid.Cid {
+		groups := make(map[int][]types.EthHash)
+		for _, k := range keys {
+			idx := int(keyBits(k, consumed, evmStorageBitWidth))
+			groups[idx] = append(groups[idx], k)
+		}
+		bf := big.Zero()
+		pointers := []interface{}{}
+		for idx := 0; idx < 1<<evmStorageBitWidth; idx++ {
+			group := groups[idx]
+			if len(group) == 0 {
+				continue
+			}
+			bf.Int.SetBit(bf.Int, idx, 1)
+			if len(group) > 3 {
+				pointers = append(pointers, build(group, consumed+evmStorageBitWidth))
+				continue
+			}
+			sort.Slice(group, func(i, j int) bool { return bytes.Compare(group[i][:], group[j][:]) < 0 })
+			bucket := []interface{}{}
+			for _, k := range group {
+				bucket = append(bucket, []interface{}{k[:], k[:4]})
+			}
+			pointers = append(pointers, bucket)
+		}
+		return put([]interface{}{bf.Int.Bytes(), pointers})
+	}
+
+	keys := make([]types.EthHash, 500)
+	for i := range keys {
+		keys[i] = sha256.Sum256([]byte{byte(i), byte(i >> 8)})
+	}
+	root := build(keys, 0)
+
+	var want []types.EthHash
+	require.NoError(t, walkEVMStorage(ctx, bs, root, func(key, value types.EthHash) error {
+		want = append(want, key)
+		return nil
+	}))
+	require.ElementsMatch(t, keys, want)
+
+	var listed []types.EthHash
+	var after *types.EthHash
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 72)
+		page, err := listEVMStorage(ctx, bs, root, after, 7)
+		require.NoError(t, err)
+		for _, s := range page.Slots {
+			listed = append(listed, s.Key)
+			require.Equal(t, s.Key[:4], s.Value[28:])
+		}
+		if page.Next == nil {
+			break
+		}
+		require.Len(t, page.Slots, 7)
+		after = page.Next
+	}
+	require.Equal(t, want, listed)
+
+	// the last page only loads the nodes on the path of its cursor
+	full := newRecordingStore(bs)
+	require.NoError(t, walkEVMStorage(ctx, full, root, func(types.EthHash, types.EthHash) error { return nil }))
+	last := newRecordingStore(bs)
+	page, err := listEVMStorage(ctx, last, root, &want[len(want)-2], 7)
+	require.NoError(t, err)
+	require.Len(t, page.Slots, 1)
+	require.Less(t, len(last.blocks), len(full.blocks)/4)
+}
+
+func TestLookupEVMStorage(t *testing.T) {
+	ctx := context.Background()
+	bs := blockstore.NewMemory()
+	put := func(obj interface{}) cid.Cid {
+		nd, err := cbor.WrapObject(obj, cbor.DefaultMultihash, -1)
+		require.NoError(t, err)
+		require.NoError(t, bs.Put(ctx, nd))
+		return nd.Cid()
+	}
+	key := func(b0, b1 byte) types.EthHash {
+		var h types.EthHash
+		h[0], h[1] = b0, b1
+		return h
+	}
+	entry := func(k types.EthHash, v byte) []interface{} {
+		return []interface{}{[]interface{}{k[:], []byte{v}}}
+	}
+
+	// a: index 1 of the root, b: index 2 then index 1 of a plain child,
+	// c: index 4 then the extension 101 then index 3 of the child
+	a := key(0b00001000, 0)
+	b := key(0b00010000, 0b01000000)
+	c := key(0b00100101, 0b00011000)
+
+	child := put([]interface{}{[]byte{0b10}, []interface{}{entry(b, 20)}})
+	extChild := put([]interface{}{[]byte{0b1000}, []interface{}{entry(c, 30)}})
+	root := put([]interface{}{[]byte{0b10110}, []interface{}{
+		entry(a, 10),
+		child,
+		[]interface{}{extChild, []byte{3, 0b10100000}},
+	}})
+
+	for k, v := range map[types.EthHash]byte{a: 10, b: 20, c: 30} {
+		rec := newRecordingStore(bs)
+		val, found, err := lookupEVMStorage(ctx, rec, root, k)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, v, val[31])
+		require.Equal(t, k != a, len(rec.blocks) == 2)
+	}
+
+	for _, k := range []types.EthHash{
+		key(0b00011000, 0),          // index 3 is empty
+		key(0b00010000, 0),          // index 0 of the child is empty
+		key(0b00100111, 0b00011000), // extension mismatch
+		key(0b00001000, 1),          // same bucket, other key
+	} {
+		_, found, err := lookupEVMStorage(ctx, bs, root, k)
+		require.NoError(t, err)
+		require.False(t, found)
+	}
+}
@@ -18,11 +18,11 @@ import (
 	"github.com/filecoin-project/go-state-types/crypto"
 	"github.com/filecoin-project/go-state-types/exitcode"
 	"github.com/filecoin-project/venus/pkg/chain"
-	"github.com/filecoin-project/venus/pkg/constants"
 	"github.com/filecoin-project/venus/pkg/state/tree"
 	"github.com/filecoin-project/venus/pkg/statemanger"
 	"github.com/filecoin-project/venus/pkg/vm/gas"
 	types2 "github.com/filecoin-project/venus/venus-shared/actors/types"
+	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 	"github.com/filecoin-project/venus/venus-shared/types"
 )
 
@@ -38,7 +38,7 @@ func init() {
 	}
 }
 
-func getTipsetByBlockNumber(ctx context.Context, store *chain.Store, blkParam string, strict bool) (*types.TipSet, error) {
+func getTipsetByBlockNumber(ctx context.Context, store *chain.Store, f3 v1api.IF3, blkParam string, strict bool) (*types.TipSet, error) {
 	if blkParam == "earliest" {
 		return nil, errors.New("block param \"earliest\" is not supported")
 	}
@@ -54,21 +54,9 @@ func getTipsetByBlockNumber(ctx context.Context, store *chain.Store, blkParam st
 		}
 		return parent, nil
 	case "safe":
-		latestHeight := head.Height() - 1
-		safeHeight := latestHeight - types.SafeEpochDelay
-		ts, err := store.GetTipSetByHeight(ctx, head, safeHeight, true)
-		if err != nil {
-			return nil, fmt.Errorf("cannot get tipset at height: %v", safeHeight)
-		}
-		return ts, nil
+		return getSafeTipSet(ctx, store, f3, head)
 	case "finalized":
-		latestHeight := head.Height() - 1
-		safeHeight := latestHeight - constants.Finality
-		ts, err := store.GetTipSetByHeight(ctx, head, safeHeight, true)
-		if err != nil {
-			return nil, fmt.Errorf("cannot get tipset at height: %v", safeHeight)
-		}
-		return ts, nil
+		return getFinalizedTipSet(ctx, store, f3, head)
 	default:
 		var num types.EthUint64
 		err := num.UnmarshalJSON([]byte(`"` + blkParam + `"`))
@@ -89,7 +77,7 @@ func getTipsetByBlockNumber(ctx context.Context, store *chain.Store, blkParam st
 	}
 }
 
-func getTipsetByEthBlockNumberOrHash(ctx context.Context, store *chain.Store, blkParam types.EthBlockNumberOrHash) (*types.TipSet, error) {
+func getTipsetByEthBlockNumberOrHash(ctx context.Context, store *chain.Store, f3 v1api.IF3, blkParam types.EthBlockNumberOrHash) (*types.TipSet, error) {
 	head := store.GetHead()
 
 	predefined := blkParam.PredefinedBlock
@@ -104,6 +92,10 @@ func getTipsetByEthBlockNumberOrHash(ctx context.Context, store *chain.Store, bl
 				return nil, errors.New("cannot get parent tipset")
 			}
 			return parent, nil
+		} else if *predefined == "safe" {
+			return getSafeTipSet(ctx, store, f3, head)
+		} else if *predefined == "finalized" {
+			return getFinalizedTipSet(ctx, store, f3, head)
 		} else {
 			return nil, fmt.Errorf("unknown predefined block %s", *predefined)
 		}
@@ -124,7 +116,7 @@ func getTipsetByEthBlockNumberOrHash(ctx context.Context, store *chain.Store, bl
 	if blkParam.BlockHash != nil {
 		ts, err := store.GetTipSetByCid(ctx, blkParam.BlockHash.ToCid())
 		if err != nil {
-			return nil, fmt.Errorf("cannot get tipset by hash: %v", err)
+			return nil, fmt.Errorf("cannot get tipset by hash: %w", err)
 		}
 
 		// verify that the tipset is in the canonical chain
@@ -671,6 +663,12 @@ func newEthTxReceipt(ctx context.Context,
 	}
 	if msgReceipt.ExitCode.IsError() {
 		receipt.Status = 0
+		// eth transactions call or create contracts, or transfer value to an account
+		receipt.ExitCodeName = types.ExitCodeName(msgReceipt.ExitCode, true)
+		if data := types.RevertData(&msgReceipt); data != nil {
+			receipt.RevertData = data
+			receipt.RevertReason = parseEthRevert(msgReceipt.Return)
+		}
 	}
 
 	receipt.GasUsed = types.EthUint64(msgReceipt.GasUsed)
@@ -0,0 +1,161 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/hashicorp/golang-lru/arc/v2"
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+
+	"github.com/filecoin-project/venus/pkg/fork"
+	"github.com/filecoin-project/venus/pkg/state/tree"
+	"github.com/filecoin-project/venus/venus-shared/actors/adt"
+	builtinactors "github.com/filecoin-project/venus/venus-shared/actors/builtin"
+	builtinevm "github.com/filecoin-project/venus/venus-shared/actors/builtin/evm"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+const (
+	// evmStateCacheSize bounds the number of contracts cached by state root for EthGetCode and EthGetStorageAt.
+	evmStateCacheSize = 4096
+	// evmBytecodeCacheSize bounds the number of bytecodes cached by cid, they are shared by the states of a contract.
+	evmBytecodeCacheSize = 512
+	// evmStateRootCacheSize bounds the number of tipsets whose state root is cached, so the migrations of an epoch
+	// are not run again by every lookup.
+	evmStateRootCacheSize = 1024
+)
+
+type tipSetGetter interface {
+	GetTipSet(ctx context.Context, key types.TipSetKey) (*types.TipSet, error)
+}
+
+type evmStateKey struct {
+	addr      address.Address
+	stateRoot cid.Cid
+}
+
+// evmContractState is what EthGetCode and EthGetStorageAt read of an address at a state root, evm is false when the
+// address does not hold an EVM contract, bytecode is undefined once the contract self destructed.
+type evmContractState struct {
+	evm         bool
+	bytecode    cid.Cid
+	storageRoot cid.Cid
+}
+
+// evmStateCache caches the EVM contracts resolved from the delegated addresses at the states the calls at the
+// tipsets execute on, the states are read from the blockstore so historical tipsets are served without executing them.
+type evmStateCache struct {
+	bs        cbor.IpldBlockstore
+	chain     tipSetGetter
+	forks     fork.IFork
+	roots     *arc.ARCCache[types.TipSetKey, cid.Cid]
+	states    *arc.ARCCache[evmStateKey, *evmContractState]
+	bytecodes *arc.ARCCache[cid.Cid, []byte]
+}
+
+func newEVMStateCache(bs cbor.IpldBlockstore, chain tipSetGetter, forks fork.IFork) (*evmStateCache, error) {
+	roots, err := arc.NewARC[types.TipSetKey, cid.Cid](evmStateRootCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create evm state root cache: %w", err)
+	}
+	states, err := arc.NewARC[evmStateKey, *evmContractState](evmStateCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create evm state cache: %w", err)
+	}
+	bytecodes, err := arc.NewARC[cid.Cid, []byte](evmBytecodeCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create evm bytecode cache: %w", err)
+	}
+	return &evmStateCache{bs: bs, chain: chain, forks: forks, roots: roots, states: states, bytecodes: bytecodes}, nil
+}
+
+// stateRoot returns the state the calls at ts execute on, the parent state of ts with the migrations of its epoch
+// applied. As in Stmgr.Call, the tipsets following an expensive migration are read at their first ancestor which
+// does not.
+func (c *evmStateCache) stateRoot(ctx context.Context, ts *types.TipSet) (cid.Cid, error) {
+	if root, ok := c.roots.Get(ts.Key()); ok {
+		return root, nil
+	}
+
+	base := ts
+	for base.Height() > 0 {
+		pts, err := c.chain.GetTipSet(ctx, base.Parents())
+		if err != nil {
+			return cid.Undef, fmt.Errorf("failed to find a non-forking epoch: %w", err)
+		}
+		if !c.forks.HasExpensiveForkBetween(pts.Height(), base.Height()+1) {
+			break
+		}
+		base = pts
+	}
+	root, err := c.forks.HandleStateForks(ctx, base.ParentState(), base.Height(), base)
+	if err != nil {
+		return cid.Undef, fmt.Errorf("failed to handle state forks: %w", err)
+	}
+
+	c.roots.Add(ts.Key(), root)
+	return root, nil
+}
+
+// contract returns the EVM contract of addr at the state the calls at ts execute on.
+func (c *evmStateCache) contract(ctx context.Context, addr address.Address, ts *types.TipSet) (*evmContractState, error) {
+	root, err := c.stateRoot(ctx, ts)
+	if err != nil {
+		return nil, err
+	}
+	key := evmStateKey{addr: addr, stateRoot: root}
+	if st, ok := c.states.Get(key); ok {
+		return st, nil
+	}
+
+	store := cbor.NewCborStore(c.bs)
+	st, err := tree.LoadState(ctx, store, root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load state tree: %w", err)
+	}
+
+	out := &evmContractState{}
+	actor, found, err := st.GetActor(ctx, addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup contract %s: %w", addr, err)
+	}
+	// Not a contract. We could try to distinguish between accounts and "native" contracts here,
+	// but it's not worth it.
+	if found && builtinactors.IsEvmActor(actor.Code) {
+		evmState, err := builtinevm.Load(adt.WrapStore(ctx, store), actor)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load evm state: %w", err)
+		}
+		alive, err := evmState.IsAlive()
+		if err != nil {
+			return nil, err
+		}
+		if alive {
+			if out.bytecode, err = evmState.GetBytecodeCID(); err != nil {
+				return nil, fmt.Errorf("failed to load bytecode cid: %w", err)
+			}
+		}
+		if out.storageRoot, err = evmStorageRoot(ctx, c.bs, actor.Head); err != nil {
+			return nil, err
+		}
+		out.evm = true
+	}
+
+	c.states.Add(key, out)
+	return out, nil
+}
+
+// bytecode returns the bytecode of cid c.
+func (c *evmStateCache) bytecode(ctx context.Context, code cid.Cid) ([]byte, error) {
+	if b, ok := c.bytecodes.Get(code); ok {
+		return b, nil
+	}
+	blk, err := c.bs.Get(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get EVM bytecode: %w", err)
+	}
+	c.bytecodes.Add(code, blk.RawData())
+	return blk.RawData(), nil
+}
@@ -0,0 +1,166 @@
+package eth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	actorstypes "github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/manifest"
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/fork"
+	"github.com/filecoin-project/venus/pkg/state/tree"
+	"github.com/filecoin-project/venus/venus-shared/actors"
+	"github.com/filecoin-project/venus/venus-shared/actors/adt"
+	builtinevm "github.com/filecoin-project/venus/venus-shared/actors/builtin/evm"
+	"github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// countingBlockstore counts the blocks read through it.
+type countingBlockstore struct {
+	cbor.IpldBlockstore
+	gets int
+}
+
+func (bs *countingBlockstore) Get(ctx context.Context, c cid.Cid) (blocks.Block, error) {
+	bs.gets++
+	return bs.IpldBlockstore.Get(ctx, c)
+}
+
+// stubForks migrates the states at the migrations heights, the expensive ones make the calls step back.
+type stubForks struct {
+	*fork.MockFork
+	migrations map[abi.ChainEpoch]map[cid.Cid]cid.Cid
+	expensive  abi.ChainEpoch
+}
+
+func (f *stubForks) HandleStateForks(ctx context.Context, root cid.Cid, height abi.ChainEpoch, ts *types.TipSet) (cid.Cid, error) {
+	if migrated, ok := f.migrations[height][root]; ok {
+		return migrated, nil
+	}
+	return root, nil
+}
+
+func (f *stubForks) HasExpensiveForkBetween(parent, height abi.ChainEpoch) bool {
+	return parent <= f.expensive && f.expensive < height
+}
+
+type stubTipSets map[types.TipSetKey]*types.TipSet
+
+func (s stubTipSets) GetTipSet(ctx context.Context, key types.TipSetKey) (*types.TipSet, error) {
+	return s[key], nil
+}
+
+func TestEVMStateCache(t *testing.T) {
+	ctx := context.Background()
+	bs := &countingBlockstore{IpldBlockstore: blockstore.NewMemory()}
+	cst := cbor.NewCborStore(bs)
+
+	contractAddr, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+	evmCode, ok := actors.GetActorCodeID(actorstypes.Version(actors.LatestVersion), manifest.EvmKey)
+	require.True(t, ok)
+
+	// makeState returns a state root holding the contract with bytecode
+	makeState := func(bytecode string) cid.Cid {
+		code := blocks.NewBlock([]byte(bytecode))
+		require.NoError(t, bs.Put(ctx, code))
+		evmState, err := builtinevm.MakeState(adt.WrapStore(ctx, cst), actorstypes.Version(actors.LatestVersion), code.Cid())
+		require.NoError(t, err)
+		head, err := cst.Put(ctx, evmState.GetState())
+		require.NoError(t, err)
+
+		st, err := tree.NewState(cst, tree.StateTreeVersion5)
+		require.NoError(t, err)
+		require.NoError(t, st.SetActor(ctx, contractAddr, &types.Actor{Code: evmCode, Head: head, Balance: big.Zero()}))
+		root, err := st.Flush(ctx)
+		require.NoError(t, err)
+		return root
+	}
+	v1, v2, migrated := makeState("v1"), makeState("v2"), makeState("migrated")
+
+	chain := stubTipSets{}
+	var parent *types.TipSet
+	// makeTipSet appends a tipset at height to the chain, executing on state
+	makeTipSet := func(height abi.ChainEpoch, state cid.Cid) *types.TipSet {
+		blk := &types.BlockHeader{
+			Miner:                 contractAddr,
+			Height:                height,
+			ParentStateRoot:       state,
+			ParentMessageReceipts: state,
+			Messages:              state,
+			ParentWeight:          big.Zero(),
+			ParentBaseFee:         big.Zero(),
+		}
+		if parent != nil {
+			blk.Parents = parent.Key().Cids()
+		}
+		ts, err := types.NewTipSet([]*types.BlockHeader{blk})
+		require.NoError(t, err)
+		chain[ts.Key()] = ts
+		parent = ts
+		return ts
+	}
+
+	forks := &stubForks{
+		MockFork:   fork.NewMockFork(),
+		migrations: map[abi.ChainEpoch]map[cid.Cid]cid.Cid{12: {v2: migrated}},
+		expensive:  20,
+	}
+	cache, err := newEVMStateCache(bs, chain, forks)
+	require.NoError(t, err)
+
+	bytecode := func(ts *types.TipSet) string {
+		contract, err := cache.contract(ctx, contractAddr, ts)
+		require.NoError(t, err)
+		require.True(t, contract.evm)
+		code, err := cache.bytecode(ctx, contract.bytecode)
+		require.NoError(t, err)
+		return string(code)
+	}
+
+	makeTipSet(0, v1)
+	ts10 := makeTipSet(10, v1)
+	ts11 := makeTipSet(11, v2)
+
+	t.Run("miss then hit", func(t *testing.T) {
+		bs.gets = 0
+		require.Equal(t, "v1", bytecode(ts10))
+		require.NotZero(t, bs.gets)
+
+		bs.gets = 0
+		require.Equal(t, "v1", bytecode(ts10))
+		require.Zero(t, bs.gets)
+	})
+
+	t.Run("invalidated by a new state", func(t *testing.T) {
+		bs.gets = 0
+		require.Equal(t, "v2", bytecode(ts11))
+		require.NotZero(t, bs.gets)
+
+		// the previous state is still cached
+		bs.gets = 0
+		require.Equal(t, "v1", bytecode(ts10))
+		require.Zero(t, bs.gets)
+	})
+
+	t.Run("state forks", func(t *testing.T) {
+		// the migration at 12 applies to the calls at 12
+		ts12 := makeTipSet(12, v2)
+		require.Equal(t, "migrated", bytecode(ts12))
+
+		// the tipsets executing the expensive migration at 20 are read at the first ancestor which does not
+		makeTipSet(20, v1)
+		ts21 := makeTipSet(21, v2)
+		require.Equal(t, "migrated", bytecode(ts21))
+		ts22 := makeTipSet(22, v2)
+		require.Equal(t, "v2", bytecode(ts22))
+	})
+}
@@ -0,0 +1,582 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/multiformats/go-multihash"
+
+	"github.com/filecoin-project/venus/pkg/state/tree"
+	builtinactors "github.com/filecoin-project/venus/venus-shared/actors/builtin"
+	builtinevm "github.com/filecoin-project/venus/venus-shared/actors/builtin/evm"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// maxContractStorageSlots bounds the number of slots returned by a single EthGetContractStorage call.
+const maxContractStorageSlots = 1000
+
+// emptyCodeHash is the keccak256 hash of empty bytecode, the code hash of non contract accounts.
+var emptyCodeHash = types.EthHashFromTxBytes(nil)
+
+// errStopWalk stops a storage walk early without reporting an error.
+var errStopWalk = errors.New("stop walk")
+
+// recordingStore records every block read through it, the recorded blocks
+// prove the objects that were loaded against the root they were reached from.
+type recordingStore struct {
+	bs     cbor.IpldBlockstore
+	seen   map[cid.Cid]struct{}
+	blocks []types.IpldBlock
+}
+
+func newRecordingStore(bs cbor.IpldBlockstore) *recordingStore {
+	return &recordingStore{
+		bs:   bs,
+		seen: make(map[cid.Cid]struct{}),
+	}
+}
+
+func (r *recordingStore) Get(ctx context.Context, c cid.Cid) (blocks.Block, error) {
+	blk, err := r.bs.Get(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := r.seen[c]; !ok {
+		r.seen[c] = struct{}{}
+		r.blocks = append(r.blocks, types.IpldBlock{Cid: c, Data: blk.RawData()})
+	}
+	return blk, nil
+}
+
+func (r *recordingStore) Put(ctx context.Context, blk blocks.Block) error {
+	return errors.New("recording store is read only")
+}
+
+// evmStorageRoot returns the root of the storage KAMT of an EVM actor, the
+// contract state is the third field of the EVM actor state in every version.
+func evmStorageRoot(ctx context.Context, bs cbor.IpldBlockstore, head cid.Cid) (cid.Cid, error) {
+	blk, err := bs.Get(ctx, head)
+	if err != nil {
+		return cid.Undef, err
+	}
+
+	var st []interface{}
+	if err := cbor.DecodeInto(blk.RawData(), &st); err != nil {
+		return cid.Undef, fmt.Errorf("decoding evm state: %w", err)
+	}
+	if len(st) < 3 {
+		return cid.Undef, fmt.Errorf("unexpected evm state with %d fields", len(st))
+	}
+	root, ok := st[2].(cid.Cid)
+	if !ok {
+		return cid.Undef, fmt.Errorf("unexpected evm contract state %T", st[2])
+	}
+	return root, nil
+}
+
+// errSlotNotFound is returned when a storage walk should resume from a slot missing from the storage.
+var errSlotNotFound = errors.New("storage slot not found")
+
+// loadStorageNode loads a node of the storage KAMT, nodes are [bitfield, pointers], a pointer is
+// either a link (optionally paired with a key extension) or a bucket of [key, value] pairs.
+func loadStorageNode(ctx context.Context, bs cbor.IpldBlockstore, c cid.Cid) (*big.Int, []interface{}, error) {
+	blk, err := bs.Get(ctx, c)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var node []interface{}
+	if err := cbor.DecodeInto(blk.RawData(), &node); err != nil {
+		return nil, nil, fmt.Errorf("decoding storage node %s: %w", c, err)
+	}
+	if len(node) != 2 {
+		return nil, nil, fmt.Errorf("unexpected storage node %s with %d fields", c, len(node))
+	}
+	bitfield, ok := node[0].([]byte)
+	if !ok {
+		return nil, nil, fmt.Errorf("unexpected storage node %s bitfield %T", c, node[0])
+	}
+	pointers, ok := node[1].([]interface{})
+	if !ok {
+		return nil, nil, fmt.Errorf("unexpected storage node %s pointers %T", c, node[1])
+	}
+	return new(big.Int).SetBytes(bitfield), pointers, nil
+}
+
+// walkEVMStorage visits every slot of the storage KAMT rooted at root in a deterministic order.
+func walkEVMStorage(ctx context.Context, bs cbor.IpldBlockstore, root cid.Cid, cb func(key, value types.EthHash) error) error {
+	_, pointers, err := loadStorageNode(ctx, bs, root)
+	if err != nil {
+		return err
+	}
+	return walkStoragePointers(ctx, bs, pointers, cb)
+}
+
+func walkStoragePointers(ctx context.Context, bs cbor.IpldBlockstore, pointers []interface{}, cb func(key, value types.EthHash) error) error {
+	for _, p := range pointers {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if link, ok := pointerLink(p); ok {
+			if err := walkEVMStorage(ctx, bs, link, cb); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := walkStorageBucket(p, nil, cb); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// walkEVMStorageFrom visits the slots following the slot after in the order of walkEVMStorage, the
+// nodes are followed down to the slot by its key bits so the part of the storage preceding it is
+// not loaded. consumed is the number of key bits used by the levels above root.
+func walkEVMStorageFrom(ctx context.Context, bs cbor.IpldBlockstore, root cid.Cid, after types.EthHash, consumed int, cb func(key, value types.EthHash) error) error {
+	bf, pointers, err := loadStorageNode(ctx, bs, root)
+	if err != nil {
+		return err
+	}
+
+	idx := int(keyBits(after, consumed, evmStorageBitWidth))
+	consumed += evmStorageBitWidth
+	if bf.Bit(idx) == 0 {
+		return errSlotNotFound
+	}
+	pos := 0
+	for i := 0; i < idx; i++ {
+		pos += int(bf.Bit(i))
+	}
+	if pos >= len(pointers) {
+		return fmt.Errorf("storage node %s has %d pointers, want index %d", root, len(pointers), pos)
+	}
+
+	ptr := pointers[pos]
+	if link, ok := pointerLink(ptr); ok {
+		n, matches, err := linkExtension(ptr, after, consumed)
+		if err != nil {
+			return err
+		}
+		if !matches {
+			return errSlotNotFound
+		}
+		if err := walkEVMStorageFrom(ctx, bs, link, after, consumed+n, cb); err != nil {
+			return err
+		}
+	} else {
+		found, err := walkStorageBucket(ptr, &after, cb)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return errSlotNotFound
+		}
+	}
+	return walkStoragePointers(ctx, bs, pointers[pos+1:], cb)
+}
+
+// walkStorageBucket visits the slots of a bucket, only the ones following the slot after when after is
+// set, and reports whether after was found.
+func walkStorageBucket(p interface{}, after *types.EthHash, cb func(key, value types.EthHash) error) (bool, error) {
+	bucket, ok := p.([]interface{})
+	if !ok {
+		return false, fmt.Errorf("unexpected storage pointer %T", p)
+	}
+	started := after == nil
+	for _, kv := range bucket {
+		pair, ok := kv.([]interface{})
+		if !ok || len(pair) != 2 {
+			return false, fmt.Errorf("unexpected storage entry %v", kv)
+		}
+		key, err := storageWord(pair[0])
+		if err != nil {
+			return false, err
+		}
+		if !started {
+			started = key == *after
+			continue
+		}
+		value, err := storageWord(pair[1])
+		if err != nil {
+			return false, err
+		}
+		if err := cb(key, value); err != nil {
+			return true, err
+		}
+	}
+	return started, nil
+}
+
+// linkExtension returns the number of key bits consumed by the extension of a link pointer, and
+// whether they match the bits of key following consumed. A link extension is a byte holding the
+// number of extra bits it consumes followed by those bits, left aligned.
+func linkExtension(ptr interface{}, key types.EthHash, consumed int) (int, bool, error) {
+	ext, ok := ptr.([]interface{})
+	if !ok || len(ext) < 2 {
+		return 0, true, nil
+	}
+	path, ok := ext[1].([]byte)
+	if !ok || len(path) == 0 {
+		return 0, false, fmt.Errorf("unexpected storage link extension %v", ext[1])
+	}
+	n := int(path[0])
+	var want types.EthHash
+	copy(want[:], path[1:])
+	for i := 0; i < n; i++ {
+		if keyBits(key, consumed+i, 1) != keyBits(want, i, 1) {
+			return n, false, nil
+		}
+	}
+	return n, true, nil
+}
+
+func pointerLink(p interface{}) (cid.Cid, bool) {
+	switch v := p.(type) {
+	case cid.Cid:
+		return v, true
+	case []interface{}:
+		if len(v) > 0 {
+			if c, ok := v[0].(cid.Cid); ok {
+				return c, true
+			}
+		}
+	}
+	return cid.Undef, false
+}
+
+// storageWord converts a big endian, leading zero stripped, 256 bit word into a 32 byte hash.
+func storageWord(v interface{}) (types.EthHash, error) {
+	var out types.EthHash
+	b, ok := v.([]byte)
+	if !ok || len(b) > len(out) {
+		return out, fmt.Errorf("unexpected storage word %v", v)
+	}
+	copy(out[len(out)-len(b):], b)
+	return out, nil
+}
+
+// evmStorageBitWidth is the number of key bits consumed by every level of the storage KAMT.
+const evmStorageBitWidth = 5
+
+// keyBits returns n bits of key starting at bit offset, most significant first,
+// bits past the end of the key read as zero.
+func keyBits(key types.EthHash, offset, n int) uint64 {
+	var out uint64
+	for i := offset; i < offset+n; i++ {
+		out <<= 1
+		if i < len(key)*8 && key[i/8]&(0x80>>(i%8)) != 0 {
+			out |= 1
+		}
+	}
+	return out
+}
+
+// lookupEVMStorage finds the value of key in the storage KAMT rooted at root, the key
+// itself is the hash so every level indexes the pointers with the next bits of the key.
+// A link extension is a byte holding the number of extra bits it consumes followed by
+// those bits, left aligned.
+func lookupEVMStorage(ctx context.Context, bs cbor.IpldBlockstore, root cid.Cid, key types.EthHash) (types.EthHash, bool, error) {
+	var zero types.EthHash
+	consumed := 0
+	for {
+		bf, pointers, err := loadStorageNode(ctx, bs, root)
+		if err != nil {
+			return zero, false, err
+		}
+
+		idx := int(keyBits(key, consumed, evmStorageBitWidth))
+		consumed += evmStorageBitWidth
+		if bf.Bit(idx) == 0 {
+			return zero, false, nil
+		}
+		pos := 0
+		for i := 0; i < idx; i++ {
+			pos += int(bf.Bit(i))
+		}
+		if pos >= len(pointers) {
+			return zero, false, fmt.Errorf("storage node %s has %d pointers, want index %d", root, len(pointers), pos)
+		}
+
+		ptr := pointers[pos]
+		if link, ok := pointerLink(ptr); ok {
+			n, matches, err := linkExtension(ptr, key, consumed)
+			if err != nil || !matches {
+				return zero, false, err
+			}
+			consumed += n
+			root = link
+			continue
+		}
+
+		bucket, ok := ptr.([]interface{})
+		if !ok {
+			return zero, false, fmt.Errorf("unexpected storage pointer %T", ptr)
+		}
+		for _, kv := range bucket {
+			pair, ok := kv.([]interface{})
+			if !ok || len(pair) != 2 {
+				return zero, false, fmt.Errorf("unexpected storage entry %v", kv)
+			}
+			k, err := storageWord(pair[0])
+			if err != nil {
+				return zero, false, err
+			}
+			if k != key {
+				continue
+			}
+			v, err := storageWord(pair[1])
+			return v, err == nil, err
+		}
+		return zero, false, nil
+	}
+}
+
+// listEVMStorage returns at most limit slots following the slot `after`, or from
+// the start when after is nil.
+func listEVMStorage(ctx context.Context, bs cbor.IpldBlockstore, root cid.Cid, after *types.EthHash, limit int) (*types.EthContractStorage, error) {
+	out := &types.EthContractStorage{Slots: []types.EthStorageSlot{}}
+	collect := func(key, value types.EthHash) error {
+		if len(out.Slots) == limit {
+			last := out.Slots[len(out.Slots)-1].Key
+			out.Next = &last
+			return errStopWalk
+		}
+		out.Slots = append(out.Slots, types.EthStorageSlot{Key: key, Value: value})
+		return nil
+	}
+
+	var err error
+	if after == nil {
+		err = walkEVMStorage(ctx, bs, root, collect)
+	} else {
+		err = walkEVMStorageFrom(ctx, bs, root, *after, 0, collect)
+	}
+	if errors.Is(err, errSlotNotFound) {
+		return nil, fmt.Errorf("storage slot %s not found", after)
+	}
+	if err != nil && !errors.Is(err, errStopWalk) {
+		return nil, err
+	}
+	return out, nil
+}
+
+// actorAt is an actor resolved at the parent state of a tipset, actor is nil when
+// the address does not exist in that state.
+type actorAt struct {
+	ts    *types.TipSet
+	id    abi.ActorID
+	actor *types.Actor
+	rec   *recordingStore
+}
+
+// loadActorAt resolves the actor at the parent state of the tipset selected by blkParam,
+// every block read while resolving the actor is recorded in the returned store.
+func (a *ethAPI) loadActorAt(ctx context.Context, ethAddr types.EthAddress, blkParam types.EthBlockNumberOrHash) (*actorAt, error) {
+	addr, err := ethAddr.ToFilecoinAddress()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get Filecoin address: %w", err)
+	}
+
+	ts, err := getTipsetByEthBlockNumberOrHash(ctx, a.em.chainModule.ChainReader, a.em.f3API, blkParam)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process block param: %v, %w", blkParam, err)
+	}
+
+	rec := newRecordingStore(a.em.chainModule.ChainReader.Blockstore())
+	st, err := tree.LoadState(ctx, cbor.NewCborStore(rec), ts.ParentState())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load state tree: %w", err)
+	}
+	out := &actorAt{ts: ts, rec: rec}
+
+	idAddr, err := st.LookupID(addr)
+	if err != nil {
+		if errors.Is(err, types.ErrActorNotFound) {
+			return out, nil
+		}
+		return nil, fmt.Errorf("failed to lookup actor %s: %w", ethAddr, err)
+	}
+	actor, found, err := st.GetActor(ctx, idAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup actor %s: %w", ethAddr, err)
+	}
+	if !found {
+		return out, nil
+	}
+
+	id, err := address.IDFromAddress(idAddr)
+	if err != nil {
+		return nil, err
+	}
+	out.id = abi.ActorID(id)
+	out.actor = actor
+
+	return out, nil
+}
+
+// loadEVMActor is loadActorAt for addresses which must hold an EVM contract.
+func (a *ethAPI) loadEVMActor(ctx context.Context, ethAddr types.EthAddress, blkParam types.EthBlockNumberOrHash) (*actorAt, error) {
+	at, err := a.loadActorAt(ctx, ethAddr, blkParam)
+	if err != nil {
+		return nil, err
+	}
+	if at.actor == nil {
+		return nil, fmt.Errorf("contract %s: %w", ethAddr, types.ErrActorNotFound)
+	}
+	if !builtinactors.IsEvmActor(at.actor.Code) {
+		return nil, fmt.Errorf("%s is not an EVM contract", ethAddr)
+	}
+	return at, nil
+}
+
+// EthGetContractStorage lists up to limit storage slots of an EVM contract, starting after the slot `after`.
+func (a *ethAPI) EthGetContractStorage(ctx context.Context, ethAddr types.EthAddress, after *types.EthHash, limit int, blkParam types.EthBlockNumberOrHash) (*types.EthContractStorage, error) {
+	if limit <= 0 || limit > maxContractStorageSlots {
+		limit = maxContractStorageSlots
+	}
+
+	at, err := a.loadEVMActor(ctx, ethAddr, blkParam)
+	if err != nil {
+		return nil, err
+	}
+
+	bs := a.em.chainModule.ChainReader.Blockstore()
+	root, err := evmStorageRoot(ctx, bs, at.actor.Head)
+	if err != nil {
+		return nil, err
+	}
+	return listEVMStorage(ctx, bs, root, after, limit)
+}
+
+// EthGetContractState returns the bytecode and nonce of an EVM contract together with the
+// state tree blocks proving the actor against the parent state root of the tipset.
+func (a *ethAPI) EthGetContractState(ctx context.Context, ethAddr types.EthAddress, blkParam types.EthBlockNumberOrHash) (*types.EthContractState, error) {
+	at, err := a.loadEVMActor(ctx, ethAddr, blkParam)
+	if err != nil {
+		return nil, err
+	}
+
+	evmState, err := builtinevm.Load(a.em.chainModule.ChainReader.Store(ctx), at.actor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load evm state: %w", err)
+	}
+	nonce, err := evmState.Nonce()
+	if err != nil {
+		return nil, err
+	}
+	code, err := evmState.GetBytecode()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bytecode: %w", err)
+	}
+	codeHash, err := evmState.GetBytecodeHash()
+	if err != nil {
+		return nil, err
+	}
+	storageRoot, err := evmStorageRoot(ctx, a.em.chainModule.ChainReader.Blockstore(), at.actor.Head)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.EthContractState{
+		Address:      ethAddr,
+		ActorID:      types.EthUint64(at.id),
+		Nonce:        types.EthUint64(nonce),
+		Balance:      types.EthBigInt(at.actor.Balance),
+		Bytecode:     code,
+		BytecodeHash: codeHash,
+		StateRoot:    at.ts.ParentState(),
+		ActorHead:    at.actor.Head,
+		StorageRoot:  storageRoot,
+		Proof:        at.rec.blocks,
+	}, nil
+}
+
+// EthGetProof returns the account and the storage proofs of ethAddr at the tipset selected by blkParam,
+// addresses which are not EVM contracts get an empty storage.
+func (a *ethAPI) EthGetProof(ctx context.Context, ethAddr types.EthAddress, storageKeys []types.EthBytes, blkParam types.EthBlockNumberOrHash) (*types.EthProof, error) {
+	keys := make([]types.EthHash, 0, len(storageKeys))
+	for _, k := range storageKeys {
+		if len(k) > 32 {
+			return nil, errors.New("supplied storage key is too long")
+		}
+		var key types.EthHash
+		copy(key[32-len(k):], k)
+		keys = append(keys, key)
+	}
+
+	at, err := a.loadActorAt(ctx, ethAddr, blkParam)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &types.EthProof{
+		Address:      ethAddr,
+		Balance:      types.EthBigIntZero,
+		CodeHash:     emptyCodeHash,
+		StorageHash:  types.EmptyRootHash,
+		StorageProof: make([]types.EthStorageProof, 0, len(keys)),
+		StateRoot:    at.ts.ParentState(),
+	}
+
+	if at.actor != nil {
+		out.Balance = types.EthBigInt(at.actor.Balance)
+		out.Nonce = types.EthUint64(at.actor.Nonce)
+
+		if builtinactors.IsEvmActor(at.actor.Code) {
+			evmState, err := builtinevm.Load(a.em.chainModule.ChainReader.Store(ctx), at.actor)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load evm state: %w", err)
+			}
+			nonce, err := evmState.Nonce()
+			if err != nil {
+				return nil, err
+			}
+			codeHash, err := evmState.GetBytecodeHash()
+			if err != nil {
+				return nil, err
+			}
+			// reading the storage root through the recording store adds the actor head to the account proof
+			root, err := evmStorageRoot(ctx, at.rec, at.actor.Head)
+			if err != nil {
+				return nil, err
+			}
+			digest, err := multihash.Decode(root.Hash())
+			if err != nil {
+				return nil, err
+			}
+
+			out.Nonce = types.EthUint64(nonce)
+			out.CodeHash = codeHash
+			copy(out.StorageHash[:], digest.Digest)
+			out.StorageRoot = &root
+		}
+	}
+
+	for _, blk := range at.rec.blocks {
+		out.AccountProof = append(out.AccountProof, blk.Data)
+	}
+
+	for _, key := range keys {
+		sp := types.EthStorageProof{Key: key, Proof: []types.EthBytes{}}
+		if out.StorageRoot != nil {
+			rec := newRecordingStore(a.em.chainModule.ChainReader.Blockstore())
+			if sp.Value, _, err = lookupEVMStorage(ctx, rec, *out.StorageRoot, key); err != nil {
+				return nil, fmt.Errorf("failed to lookup storage slot %s: %w", key, err)
+			}
+			for _, blk := range rec.blocks {
+				sp.Proof = append(sp.Proof, blk.Data)
+			}
+		}
+		out.StorageProof = append(out.StorageProof, sp)
+	}
+
+	return out, nil
+}
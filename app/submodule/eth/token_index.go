@@ -0,0 +1,441 @@
+package eth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	datacap16 "github.com/filecoin-project/go-state-types/builtin/v16/datacap"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/filecoin-project/venus/pkg/state/tree"
+	"github.com/filecoin-project/venus/pkg/tokenindex"
+	builtinactors "github.com/filecoin-project/venus/venus-shared/actors/builtin"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/datacap"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// maxTokenTransfers bounds the number of transfers returned by a single EthGetTokenTransfers call.
+const maxTokenTransfers = 1000
+
+var errTokenIndexDisabled = errors.New("token index disabled, enable with Fevm.EnableTokenIndex")
+
+// The topics of the token transfer events. ERC-20 and ERC-721 share the Transfer event, the token id of an ERC-721
+// transfer is indexed where the amount of an ERC-20 transfer is in the data.
+var (
+	transferTopic       = eventTopic("Transfer(address,address,uint256)")
+	transferSingleTopic = eventTopic("TransferSingle(address,address,address,uint256,uint256)")
+	transferBatchTopic  = eventTopic("TransferBatch(address,address,address,uint256[],uint256[])")
+)
+
+func eventTopic(signature string) types.EthHash {
+	var h types.EthHash
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write([]byte(signature)) //nolint:errcheck
+	copy(h[:], hasher.Sum(nil))
+	return h
+}
+
+// tokenIndexManager indexes the ERC-20, ERC-721 and ERC-1155 transfer logs and the datacap transfers, mints and
+// burns of the applied tipsets.
+type tokenIndexManager struct {
+	api   *ethAPI
+	index *tokenindex.TokenIndex
+}
+
+func (m *tokenIndexManager) Apply(ctx context.Context, from, to *types.TipSet) error {
+	blkHash, transfers, nfts, err := m.tokenTransfers(ctx, to)
+	if err != nil {
+		return fmt.Errorf("collecting the token transfers at %d: %w", to.Height(), err)
+	}
+	return m.index.IndexBlock(blkHash, transfers, nfts)
+}
+
+func (m *tokenIndexManager) Revert(ctx context.Context, from, to *types.TipSet) error {
+	blkHash, err := ethBlockHash(from)
+	if err != nil {
+		return err
+	}
+	return m.index.RemoveBlock(blkHash)
+}
+
+// tokenTransfers returns the token transfers of the messages of ts, the ERC-20 transfers in the order of their logs
+// followed by the datacap transfers in execution order, and the NFT transfers in the order of their logs.
+func (m *tokenIndexManager) tokenTransfers(ctx context.Context, ts *types.TipSet) (types.EthHash, []types.EthTokenTransfer, []types.EthNFTTransfer, error) {
+	blkHash, err := ethBlockHash(ts)
+	if err != nil {
+		return types.EmptyEthHash, nil, nil, err
+	}
+	_, trace, err := m.api.em.chainModule.Stmgr.ExecutionTrace(ctx, ts)
+	if err != nil {
+		return types.EmptyEthHash, nil, nil, fmt.Errorf("failed when calling ExecutionTrace: %w", err)
+	}
+	state, err := m.api.em.chainModule.ChainReader.GetTipSetState(ctx, ts)
+	if err != nil {
+		return types.EmptyEthHash, nil, nil, fmt.Errorf("failed to get state view: %w", err)
+	}
+
+	var (
+		erc20    []types.EthTokenTransfer
+		datacaps []types.EthTokenTransfer
+		nfts     []types.EthNFTTransfer
+		logIndex uint64
+	)
+	for _, ir := range trace {
+		if ir.Msg.From == builtinactors.SystemActorAddr || ir.MsgRct == nil || !ir.MsgRct.ExitCode.IsSuccess() {
+			continue
+		}
+
+		var txHash *types.EthHash
+		getTxHash := func() (types.EthHash, error) {
+			if txHash == nil {
+				if txHash, err = m.api.EthGetTransactionHashByCid(ctx, ir.MsgCid); err != nil {
+					return types.EmptyEthHash, fmt.Errorf("failed to get transaction hash by cid: %w", err)
+				}
+				if txHash == nil {
+					return types.EmptyEthHash, fmt.Errorf("cannot find transaction hash for cid %s", ir.MsgCid)
+				}
+			}
+			return *txHash, nil
+		}
+		transfer := func(token, from, to types.EthAddress, amount abi.TokenAmount, standard string) (types.EthTokenTransfer, error) {
+			hash, err := getTxHash()
+			if err != nil {
+				return types.EthTokenTransfer{}, err
+			}
+			return types.EthTokenTransfer{
+				Token:           token,
+				From:            from,
+				To:              to,
+				Amount:          amount,
+				Standard:        standard,
+				TransactionHash: hash,
+				BlockHash:       blkHash,
+				BlockNumber:     types.EthUint64(ts.Height()),
+			}, nil
+		}
+
+		if ir.MsgRct.EventsRoot != nil {
+			events, err := m.api.chain.ChainGetEvents(ctx, *ir.MsgRct.EventsRoot)
+			if err != nil {
+				return types.EmptyEthHash, nil, nil, fmt.Errorf("failed to load the events of msg %s: %w", ir.MsgCid, err)
+			}
+			for _, ev := range events {
+				idx := logIndex
+				logIndex++
+
+				data, topics, ok := ethLogFromEvent(ev.Entries)
+				if !ok || len(topics) == 0 {
+					continue
+				}
+				isERC20 := topics[0] == transferTopic && len(topics) == 3 && len(data) == 32
+				nftItems := decodeNFTTransfer(topics, data)
+				if !isERC20 && len(nftItems) == 0 {
+					continue
+				}
+
+				emitter, err := address.NewIDAddress(uint64(ev.Emitter))
+				if err != nil {
+					return types.EmptyEthHash, nil, nil, err
+				}
+				token, err := lookupEthAddress(ctx, emitter, state)
+				if err != nil {
+					return types.EmptyEthHash, nil, nil, fmt.Errorf("failed to resolve the emitter %s: %w", emitter, err)
+				}
+				if isERC20 {
+					t, err := transfer(token, ethAddressFromTopic(topics[1]), ethAddressFromTopic(topics[2]),
+						big.PositiveFromUnsignedBytes(data), types.TokenStandardERC20)
+					if err != nil {
+						return types.EmptyEthHash, nil, nil, err
+					}
+					t.LogIndex = types.EthUint64(idx)
+					erc20 = append(erc20, t)
+					continue
+				}
+				hash, err := getTxHash()
+				if err != nil {
+					return types.EmptyEthHash, nil, nil, err
+				}
+				for _, item := range nftItems {
+					item.Collection = token
+					item.TransactionHash = hash
+					item.BlockHash = blkHash
+					item.BlockNumber = types.EthUint64(ts.Height())
+					item.LogIndex = types.EthUint64(idx)
+					nfts = append(nfts, item)
+				}
+			}
+		}
+
+		var calls []datacapCall
+		collectDatacapCalls(&ir.ExecutionTrace, &calls)
+		for _, call := range calls {
+			from, err := tokenHolder(ctx, call.from, state)
+			if err != nil {
+				return types.EmptyEthHash, nil, nil, fmt.Errorf("when processing message %s: %w", ir.MsgCid, err)
+			}
+			to, err := tokenHolder(ctx, call.to, state)
+			if err != nil {
+				return types.EmptyEthHash, nil, nil, fmt.Errorf("when processing message %s: %w", ir.MsgCid, err)
+			}
+			t, err := transfer(datacapEthAddress, from, to, call.amount, types.TokenStandardFRC46)
+			if err != nil {
+				return types.EmptyEthHash, nil, nil, err
+			}
+			datacaps = append(datacaps, t)
+		}
+	}
+
+	for i := range datacaps {
+		datacaps[i].LogIndex = types.EthUint64(logIndex + uint64(i))
+	}
+	return blkHash, append(erc20, datacaps...), nfts, nil
+}
+
+// decodeNFTTransfer returns the transfers of an ERC-721 Transfer log or of an ERC-1155 TransferSingle or
+// TransferBatch log, without their collection and position, or nil for the other logs.
+func decodeNFTTransfer(topics []types.EthHash, data []byte) []types.EthNFTTransfer {
+	if len(topics) != 4 {
+		return nil
+	}
+	switch topics[0] {
+	case transferTopic:
+		if len(data) != 0 {
+			return nil
+		}
+		return []types.EthNFTTransfer{{
+			TokenID:  big.PositiveFromUnsignedBytes(topics[3][:]),
+			From:     ethAddressFromTopic(topics[1]),
+			To:       ethAddressFromTopic(topics[2]),
+			Amount:   big.NewInt(1),
+			Standard: types.TokenStandardERC721,
+		}}
+	case transferSingleTopic:
+		if len(data) != 64 {
+			return nil
+		}
+		return []types.EthNFTTransfer{{
+			TokenID:  big.PositiveFromUnsignedBytes(data[:32]),
+			From:     ethAddressFromTopic(topics[2]),
+			To:       ethAddressFromTopic(topics[3]),
+			Amount:   big.PositiveFromUnsignedBytes(data[32:]),
+			Standard: types.TokenStandardERC1155,
+		}}
+	case transferBatchTopic:
+		ids, ok := decodeUint256Array(data, 0)
+		if !ok {
+			return nil
+		}
+		values, ok := decodeUint256Array(data, 32)
+		if !ok || len(values) != len(ids) {
+			return nil
+		}
+		out := make([]types.EthNFTTransfer, len(ids))
+		for i := range ids {
+			out[i] = types.EthNFTTransfer{
+				TokenID:  ids[i],
+				From:     ethAddressFromTopic(topics[2]),
+				To:       ethAddressFromTopic(topics[3]),
+				Amount:   values[i],
+				Standard: types.TokenStandardERC1155,
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// decodeUint256Array decodes the uint256[] argument whose offset is at pos in the abi encoded data.
+func decodeUint256Array(data []byte, pos int) ([]big.Int, bool) {
+	word := func(at uint64) (uint64, bool) {
+		if at > uint64(len(data)) || uint64(len(data))-at < 32 {
+			return 0, false
+		}
+		v := big.PositiveFromUnsignedBytes(data[at : at+32])
+		if !v.IsUint64() {
+			return 0, false
+		}
+		return v.Uint64(), true
+	}
+	offset, ok := word(uint64(pos))
+	if !ok {
+		return nil, false
+	}
+	n, ok := word(offset)
+	if !ok || n > uint64(len(data))/32 {
+		return nil, false
+	}
+	start := offset + 32
+	if uint64(len(data))-start < n*32 {
+		return nil, false
+	}
+	out := make([]big.Int, n)
+	for i := range out {
+		at := start + uint64(i)*32
+		out[i] = big.PositiveFromUnsignedBytes(data[at : at+32])
+	}
+	return out, true
+}
+
+// datacapEthAddress is the masked ID address of the datacap actor, the token of the datacap transfers.
+var datacapEthAddress = func() types.EthAddress {
+	addr, err := types.EthAddressFromFilecoinAddress(datacap.Address)
+	if err != nil {
+		panic(err)
+	}
+	return addr
+}()
+
+// datacapCall is a transfer of datacap, from or to is undefined for the mints and the burns.
+type datacapCall struct {
+	from, to address.Address
+	amount   abi.TokenAmount
+}
+
+// collectDatacapCalls appends the successful datacap transfers, mints and burns of et and of its subcalls to calls,
+// the subcalls of a failed call are reverted with it and left out.
+func collectDatacapCalls(et *types.ExecutionTrace, calls *[]datacapCall) {
+	if !et.MsgRct.ExitCode.IsSuccess() {
+		return
+	}
+	if et.Msg.To == datacap.Address {
+		if call, ok := decodeDatacapCall(&et.Msg); ok {
+			*calls = append(*calls, call)
+		}
+	}
+	for i := range et.Subcalls {
+		collectDatacapCalls(&et.Subcalls[i], calls)
+	}
+}
+
+func decodeDatacapCall(msg *types.MessageTrace) (datacapCall, bool) {
+	r := bytes.NewReader(msg.Params)
+	var (
+		call datacapCall
+		err  error
+	)
+	switch msg.Method {
+	case datacap.Methods.TransferExported:
+		var params datacap16.TransferParams
+		err = params.UnmarshalCBOR(r)
+		call = datacapCall{from: msg.From, to: params.To, amount: params.Amount}
+	case datacap.Methods.TransferFromExported:
+		var params datacap16.TransferFromParams
+		err = params.UnmarshalCBOR(r)
+		call = datacapCall{from: params.From, to: params.To, amount: params.Amount}
+	case datacap.Methods.MintExported:
+		var params datacap16.MintParams
+		err = params.UnmarshalCBOR(r)
+		call = datacapCall{to: params.To, amount: params.Amount}
+	case datacap.Methods.BurnExported:
+		var params datacap16.BurnParams
+		err = params.UnmarshalCBOR(r)
+		call = datacapCall{from: msg.From, amount: params.Amount}
+	case datacap.Methods.BurnFromExported:
+		var params datacap16.BurnFromParams
+		err = params.UnmarshalCBOR(r)
+		call = datacapCall{from: params.Owner, amount: params.Amount}
+	case datacap.Methods.DestroyExported:
+		var params datacap16.DestroyParams
+		err = params.UnmarshalCBOR(r)
+		call = datacapCall{from: params.Owner, amount: params.Amount}
+	default:
+		return datacapCall{}, false
+	}
+	if err != nil {
+		log.Warnw("failed to decode the params of a datacap call", "method", msg.Method, "error", err)
+		return datacapCall{}, false
+	}
+	return call, true
+}
+
+// tokenHolder returns the eth address of addr, or the zero address for the undefined side of a mint or a burn.
+func tokenHolder(ctx context.Context, addr address.Address, state tree.Tree) (types.EthAddress, error) {
+	if addr == address.Undef {
+		return types.EthAddress{}, nil
+	}
+	return lookupEthAddress(ctx, addr, state)
+}
+
+// ethAddressFromTopic returns the address held by the last 20 bytes of an indexed event argument.
+func ethAddressFromTopic(topic types.EthHash) types.EthAddress {
+	var addr types.EthAddress
+	copy(addr[:], topic[len(topic)-len(addr):])
+	return addr
+}
+
+func (a *ethAPI) EthGetTokenTransfers(ctx context.Context, holder types.EthAddress, token *types.EthAddress, after uint64, limit int) (*types.EthTokenTransfers, error) {
+	if a.tokenIndex == nil {
+		return nil, errTokenIndexDisabled
+	}
+	if limit <= 0 || limit > maxTokenTransfers {
+		return nil, fmt.Errorf("limit must be in [1, %d], got %d", maxTokenTransfers, limit)
+	}
+
+	transfers, err := a.tokenIndex.index.Transfers(holder, token, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	out := &types.EthTokenTransfers{Transfers: transfers}
+	if len(transfers) == limit {
+		out.Next = transfers[limit-1].ID
+	}
+	if out.Transfers == nil {
+		out.Transfers = []types.EthTokenTransfer{}
+	}
+	return out, nil
+}
+
+func (a *ethAPI) EthGetNFTTransfers(ctx context.Context, collection types.EthAddress, tokenID *types.BigInt, after uint64, limit int) (*types.EthNFTTransfers, error) {
+	if a.tokenIndex == nil {
+		return nil, errTokenIndexDisabled
+	}
+	if limit <= 0 || limit > maxTokenTransfers {
+		return nil, fmt.Errorf("limit must be in [1, %d], got %d", maxTokenTransfers, limit)
+	}
+
+	transfers, err := a.tokenIndex.index.NFTTransfers(collection, tokenID, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	out := &types.EthNFTTransfers{Transfers: transfers}
+	if len(transfers) == limit {
+		out.Next = transfers[limit-1].ID
+	}
+	if out.Transfers == nil {
+		out.Transfers = []types.EthNFTTransfer{}
+	}
+	return out, nil
+}
+
+func (a *ethAPI) EthGetNFTOwners(ctx context.Context, collection types.EthAddress, tokenID types.BigInt) ([]types.EthNFTHolding, error) {
+	if a.tokenIndex == nil {
+		return nil, errTokenIndexDisabled
+	}
+	return a.tokenIndex.index.NFTOwners(collection, tokenID)
+}
+
+func (a *ethAPI) EthGetNFTHoldings(ctx context.Context, owner types.EthAddress, collection *types.EthAddress) ([]types.EthNFTHolding, error) {
+	if a.tokenIndex == nil {
+		return nil, errTokenIndexDisabled
+	}
+	return a.tokenIndex.index.NFTHoldings(owner, collection)
+}
+
+func (a *ethAPI) EthGetTokenBalanceHistory(ctx context.Context, holder types.EthAddress, token types.EthAddress) ([]types.EthTokenBalanceChange, error) {
+	if a.tokenIndex == nil {
+		return nil, errTokenIndexDisabled
+	}
+	history, err := a.tokenIndex.index.BalanceHistory(holder, token)
+	if err != nil {
+		return nil, err
+	}
+	if history == nil {
+		history = []types.EthTokenBalanceChange{}
+	}
+	return history, nil
+}
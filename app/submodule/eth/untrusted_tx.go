@@ -0,0 +1,77 @@
+package eth
+
+import (
+	"fmt"
+
+	"github.com/filecoin-project/go-address"
+
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// untrustedTxPolicy decides whether a raw transaction submitted through
+// EthSendRawTransactionUntrusted may be handed to the message pool.
+type untrustedTxPolicy struct {
+	allow map[address.Address]struct{}
+	deny  map[address.Address]struct{}
+
+	maxGasLimit         int64
+	maxPendingPerSender int
+}
+
+func newUntrustedTxPolicy(cfg config.UntrustedTxConfig) (*untrustedTxPolicy, error) {
+	allow, err := parseSenderList(cfg.AllowSenders)
+	if err != nil {
+		return nil, fmt.Errorf("parsing allowed senders: %w", err)
+	}
+	deny, err := parseSenderList(cfg.DenySenders)
+	if err != nil {
+		return nil, fmt.Errorf("parsing denied senders: %w", err)
+	}
+
+	return &untrustedTxPolicy{
+		allow:               allow,
+		deny:                deny,
+		maxGasLimit:         cfg.MaxGasLimit,
+		maxPendingPerSender: cfg.MaxPendingPerSender,
+	}, nil
+}
+
+// parseSenderList accepts both 0x and f4 forms and keys the result by f4 address.
+func parseSenderList(senders []string) (map[address.Address]struct{}, error) {
+	out := make(map[address.Address]struct{}, len(senders))
+	for _, s := range senders {
+		addr, err := address.NewFromString(s)
+		if err != nil {
+			ethAddr, ethErr := types.ParseEthAddress(s)
+			if ethErr != nil {
+				return nil, fmt.Errorf("%s is neither a filecoin nor an eth address", s)
+			}
+			if addr, err = ethAddr.ToFilecoinAddress(); err != nil {
+				return nil, err
+			}
+		}
+		out[addr] = struct{}{}
+	}
+	return out, nil
+}
+
+// check returns an error when the message from sender must be rejected, pending
+// is the number of messages the sender already has in the message pool.
+func (p *untrustedTxPolicy) check(msg *types.Message, pending int) error {
+	if _, ok := p.deny[msg.From]; ok {
+		return fmt.Errorf("sender %s is not allowed", msg.From)
+	}
+	if len(p.allow) > 0 {
+		if _, ok := p.allow[msg.From]; !ok {
+			return fmt.Errorf("sender %s is not allowed", msg.From)
+		}
+	}
+	if p.maxGasLimit > 0 && msg.GasLimit > p.maxGasLimit {
+		return fmt.Errorf("gas limit %d exceeds the maximum %d", msg.GasLimit, p.maxGasLimit)
+	}
+	if p.maxPendingPerSender > 0 && pending >= p.maxPendingPerSender {
+		return fmt.Errorf("sender %s already has %d pending messages", msg.From, pending)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/ipfs-force-community/metrics"
+	"go.opencensus.io/tag"
+
+	"github.com/filecoin-project/venus/pkg/config"
+)
+
+var (
+	tagKeyLimit  = tag.MustNewKey("limit")
+	tagKeyPolicy = tag.MustNewKey("policy")
+
+	ethWSConnections      = metrics.NewInt64("eth/ws_connections", "Number of open websocket connections to the rpc api", "")
+	ethSubscriptions      = metrics.NewInt64("eth/subscriptions", "Number of active eth subscriptions", "")
+	ethSubscriptionBuffer = metrics.NewInt64("eth/subscription_send_buffer", "Size of the eth subscription responses waiting to be sent", "By")
+	ethWSRejected         = metrics.NewCounter("eth/ws_rejected", "Number of websocket connections and eth subscriptions rejected or dropped by a limit", tagKeyLimit)
+	ethSubscriptionDrops  = metrics.NewInt64WithCounter("eth/subscription_dropped", "Number of eth subscription responses dropped or coalesced by the backpressure policy", "", tagKeyPolicy)
+)
+
+const (
+	limitConnections   = "connections"
+	limitSubscriptions = "subscriptions"
+	limitSendBuffer    = "send_buffer"
+)
+
+func tickRejected(ctx context.Context, limit string) {
+	ctx, _ = tag.New(ctx, tag.Upsert(tagKeyLimit, limit))
+	ethWSRejected.Tick(ctx)
+}
+
+func recordDrops(ctx context.Context, policy string, n int) {
+	ctx, _ = tag.New(ctx, tag.Upsert(tagKeyPolicy, policy))
+	ethSubscriptionDrops.Set(ctx, int64(n))
+}
+
+// wsLimits bounds the websocket connections to the rpc api and the eth subscriptions, a zero limit is unlimited.
+// The limits are changed on a config reload, the connections and subscriptions already over them are kept.
+type wsLimits struct {
+	maxConnections             atomic.Int64
+	maxSubscriptionsPerConn    atomic.Int64
+	maxSubscriptionsSendBuffer atomic.Int64
+	// backpressure is the config.Backpressure* policy of the subscriptions whose send queue or buffer is full
+	backpressure atomic.Value
+
+	connections atomic.Int64
+	// sendBuffer is the size of the responses queued by all the subscriptions
+	sendBuffer atomic.Int64
+	lastConnID atomic.Uint64
+}
+
+func newWSLimits(cfg *config.EventConfig) *wsLimits {
+	l := &wsLimits{}
+	l.set(cfg)
+	return l
+}
+
+func (l *wsLimits) set(cfg *config.EventConfig) {
+	l.maxConnections.Store(int64(cfg.MaxWebsocketConnections))
+	l.maxSubscriptionsPerConn.Store(int64(cfg.MaxSubscriptionsPerConnection))
+	l.maxSubscriptionsSendBuffer.Store(cfg.MaxSubscriptionSendBuffer)
+	l.backpressure.Store(cfg.SubscriptionBackpressure)
+}
+
+// backpressurePolicy returns the policy of the subscriptions whose send queue or buffer is full, an unknown policy
+// is config.BackpressureKill.
+func (l *wsLimits) backpressurePolicy() string {
+	switch policy, _ := l.backpressure.Load().(string); policy {
+	case config.BackpressureDropOldest, config.BackpressureCoalesce:
+		return policy
+	default:
+		return config.BackpressureKill
+	}
+}
+
+// reserveSendBuffer adds size to the send buffer, false when it would go over the limit.
+func (l *wsLimits) reserveSendBuffer(ctx context.Context, size int64) bool {
+	limit := l.maxSubscriptionsSendBuffer.Load()
+	for {
+		cur := l.sendBuffer.Load()
+		if limit > 0 && cur+size > limit {
+			return false
+		}
+		if l.sendBuffer.CompareAndSwap(cur, cur+size) {
+			ethSubscriptionBuffer.Set(ctx, cur+size)
+			return true
+		}
+	}
+}
+
+func (l *wsLimits) releaseSendBuffer(ctx context.Context, size int64) {
+	ethSubscriptionBuffer.Set(ctx, l.sendBuffer.Add(-size))
+}
+
+type wsConnIDKey struct{}
+
+// wsConnID returns the id given by WebsocketLimits to the connection of a call, false when the call is not made
+// over a websocket connection of the rpc api.
+func wsConnID(ctx context.Context) (uint64, bool) {
+	id, ok := ctx.Value(wsConnIDKey{}).(uint64)
+	return id, ok
+}
+
+// WebsocketLimits wraps the rpc api handler next, it rejects the websocket connections over
+// MaxWebsocketConnections with 503 and gives an id to the others, so the eth subscriptions are limited per
+// connection and stopped with it.
+func (em *EthSubModule) WebsocketLimits(next http.Handler) http.Handler {
+	limits := em.ethEventAPI.limits
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := r.Context()
+		n := limits.connections.Add(1)
+		defer func() {
+			ethWSConnections.Set(ctx, limits.connections.Add(-1))
+		}()
+		if limit := limits.maxConnections.Load(); limit > 0 && n > limit {
+			tickRejected(ctx, limitConnections)
+			log.Warnw("rejecting websocket connection", "remote", r.RemoteAddr, "limit", limit)
+			http.Error(w, fmt.Sprintf("too many websocket connections, the limit is %d", limit), http.StatusServiceUnavailable)
+			return
+		}
+		ethWSConnections.Set(ctx, n)
+
+		id := limits.lastConnID.Add(1)
+		// serving a websocket connection returns once it is closed
+		next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, wsConnIDKey{}, id)))
+		if sm := em.ethEventAPI.SubManager; sm != nil {
+			sm.stopConnection(ctx, id)
+		}
+	})
+}
@@ -0,0 +1,221 @@
+package eth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-jsonrpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/pkg/events/filter"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func noDropFilter(context.Context, filter.Filter) error { return nil }
+
+func TestWebsocketConnectionLimit(t *testing.T) {
+	tf.UnitTest(t)
+
+	limits := newWSLimits(&config.EventConfig{MaxWebsocketConnections: 1})
+	em := &EthSubModule{ethEventAPI: &ethEventAPI{limits: limits}}
+
+	entered, release := make(chan uint64), make(chan struct{})
+	handler := em.WebsocketLimits(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, ok := wsConnID(r.Context())
+		if !ok {
+			// not a websocket connection
+			return
+		}
+		entered <- id
+		<-release
+	}))
+	wsRequest := func() *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/rpc/v1", nil)
+		r.Header.Set("Connection", "Upgrade")
+		return r
+	}
+
+	done := make(chan struct{})
+	go func() {
+		handler.ServeHTTP(httptest.NewRecorder(), wsRequest())
+		close(done)
+	}()
+	require.Equal(t, uint64(1), <-entered)
+
+	// the second connection is over the limit, the plain http requests are not limited
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, wsRequest())
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/rpc/v1", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	close(release)
+	<-done
+	require.Equal(t, int64(0), limits.connections.Load())
+
+	// closing the connection frees a slot
+	go handler.ServeHTTP(httptest.NewRecorder(), wsRequest())
+	require.Equal(t, uint64(2), <-entered)
+}
+
+func TestSubscriptionLimits(t *testing.T) {
+	tf.UnitTest(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	limits := newWSLimits(&config.EventConfig{MaxSubscriptionsPerConnection: 2})
+	sm := &EthSubscriptionManager{limits: limits}
+	out := func(context.Context, jsonrpc.RawParams) error { return nil }
+
+	first, err := sm.StartSubscription(ctx, 1, EthSubscribeEventTypeLogs, out, noDropFilter)
+	require.NoError(t, err)
+	_, err = sm.StartSubscription(ctx, 1, EthSubscribeEventTypeLogs, out, noDropFilter)
+	require.NoError(t, err)
+	_, err = sm.StartSubscription(ctx, 1, EthSubscribeEventTypeLogs, out, noDropFilter)
+	require.Error(t, err)
+	// the other connections and the calls out of a known connection are not limited by the connection
+	_, err = sm.StartSubscription(ctx, 2, EthSubscribeEventTypeLogs, out, noDropFilter)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		_, err = sm.StartSubscription(ctx, 0, EthSubscribeEventTypeLogs, out, noDropFilter)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, sm.StopSubscription(ctx, first.id))
+	require.Error(t, sm.StopSubscription(ctx, first.id))
+	_, err = sm.StartSubscription(ctx, 1, EthSubscribeEventTypeLogs, out, noDropFilter)
+	require.NoError(t, err)
+
+	// closing the connection stops its subscriptions
+	sm.stopConnection(ctx, 1)
+	sm.mu.Lock()
+	require.Len(t, sm.subs, 4)
+	require.Equal(t, map[uint64]int{2: 1}, sm.connSubs)
+	sm.mu.Unlock()
+}
+
+func TestSubscriptionSendBuffer(t *testing.T) {
+	tf.UnitTest(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	limits := newWSLimits(&config.EventConfig{MaxSubscriptionSendBuffer: 1 << 10})
+	sm := &EthSubscriptionManager{limits: limits}
+
+	// the client of slow does not read its responses
+	sending, release := make(chan struct{}, 1), make(chan struct{})
+	slow, err := sm.StartSubscription(ctx, 1, EthSubscribeEventTypeLogs, func(context.Context, jsonrpc.RawParams) error {
+		sending <- struct{}{}
+		<-release
+		return nil
+	}, noDropFilter)
+	require.NoError(t, err)
+	defer close(release)
+
+	slow.send(ctx, "first")
+	<-sending
+	// the responses of slow are queued until the send buffer is full, then it is dropped
+	var queued int64
+	for i := 0; i < 1<<10; i++ {
+		slow.send(ctx, "queued")
+		sm.mu.Lock()
+		_, ok := sm.subs[slow.id]
+		sm.mu.Unlock()
+		if !ok {
+			break
+		}
+		queued = limits.sendBuffer.Load()
+		require.LessOrEqual(t, queued, int64(1<<10))
+	}
+	require.Greater(t, queued, int64(0))
+	require.Eventually(t, func() bool {
+		return limits.sendBuffer.Load() == 0
+	}, time.Second, 10*time.Millisecond)
+	require.Error(t, sm.StopSubscription(ctx, slow.id))
+
+	// the space is available to the other subscriptions
+	received := make(chan jsonrpc.RawParams, 1)
+	fast, err := sm.StartSubscription(ctx, 2, EthSubscribeEventTypeLogs, func(_ context.Context, p jsonrpc.RawParams) error {
+		received <- p
+		return nil
+	}, noDropFilter)
+	require.NoError(t, err)
+	fast.send(ctx, "fast")
+	require.Contains(t, string(<-received), "fast")
+}
+
+func TestSubscriptionBackpressure(t *testing.T) {
+	tf.UnitTest(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	release := make(chan struct{})
+	defer close(release)
+	// the clients of the subscriptions do not read their responses
+	startSlow := func(sm *EthSubscriptionManager, kind string) *ethSubscription {
+		sending := make(chan struct{}, 1)
+		sub, err := sm.StartSubscription(ctx, 1, kind, func(context.Context, jsonrpc.RawParams) error {
+			sending <- struct{}{}
+			<-release
+			return nil
+		}, noDropFilter)
+		require.NoError(t, err)
+		sub.send(ctx, "first")
+		<-sending
+		return sub
+	}
+	active := func(sm *EthSubscriptionManager, sub *ethSubscription) bool {
+		sm.mu.Lock()
+		defer sm.mu.Unlock()
+		_, ok := sm.subs[sub.id]
+		return ok
+	}
+	const buffer = 1 << 10
+
+	// drop-oldest keeps the newest responses which fit
+	sm := &EthSubscriptionManager{limits: newWSLimits(&config.EventConfig{
+		MaxSubscriptionSendBuffer: buffer,
+		SubscriptionBackpressure:  config.BackpressureDropOldest,
+	})}
+	sub := startSlow(sm, EthSubscribeEventTypeLogs)
+	for i := 0; i < 100; i++ {
+		sub.send(ctx, "queued")
+	}
+	require.True(t, active(sm, sub))
+	infos, err := (&ethEventAPI{SubManager: sm}).EthListSubscriptions(ctx)
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	info := infos[0]
+	require.Equal(t, EthSubscribeEventTypeLogs, info.Type)
+	require.Equal(t, uint64(1), info.Connection)
+	require.Greater(t, info.Dropped, uint64(0))
+	require.Equal(t, 100, info.QueueLen+int(info.Dropped))
+	require.LessOrEqual(t, info.QueueSize, int64(buffer))
+	require.Equal(t, info.QueueSize, sm.limits.sendBuffer.Load())
+
+	// coalesce keeps the latest head and stops the other subscriptions
+	sm = &EthSubscriptionManager{limits: newWSLimits(&config.EventConfig{
+		MaxSubscriptionSendBuffer: buffer,
+		SubscriptionBackpressure:  config.BackpressureCoalesce,
+	})}
+	heads := startSlow(sm, EthSubscribeEventTypeHeads)
+	logs := startSlow(sm, EthSubscribeEventTypeLogs)
+	for i := 0; i < 100; i++ {
+		heads.send(ctx, "head")
+	}
+	require.True(t, active(sm, heads))
+	info = heads.info()
+	require.Greater(t, info.Coalesced, uint64(0))
+	require.Equal(t, 100, info.QueueLen+int(info.Coalesced))
+
+	for i := 0; i < 100 && active(sm, logs); i++ {
+		logs.send(ctx, "log")
+	}
+	require.False(t, active(sm, logs))
+	require.True(t, active(sm, heads))
+}
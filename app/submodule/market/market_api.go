@@ -2,59 +2,176 @@ package market
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/venus/pkg/market"
 	"github.com/filecoin-project/venus/pkg/statemanger"
+	"github.com/filecoin-project/venus/venus-shared/actors"
+	marketactor "github.com/filecoin-project/venus/venus-shared/actors/builtin/market"
 	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 	"github.com/filecoin-project/venus/venus-shared/types"
 )
 
+// the largest page of StateMarketParticipantsPage
+const maxMarketParticipantsPage = 10000
+
 type marketAPI struct {
 	chain v1api.IChain
+	mpool v1api.IMessagePool
 	stmgr statemanger.IStateManager
+	fmgr  *market.FundManager
 }
 
-func newMarketAPI(c v1api.IChain, stmgr statemanger.IStateManager) v1api.IMarket {
-	return &marketAPI{c, stmgr}
+func newMarketAPI(c v1api.IChain, mp v1api.IMessagePool, stmgr statemanger.IStateManager, fmgr *market.FundManager) *marketAPI {
+	return &marketAPI{chain: c, mpool: mp, stmgr: stmgr, fmgr: fmgr}
 }
 
 // StateMarketParticipants returns the Escrow and Locked balances of every participant in the Storage Market
 func (m *marketAPI) StateMarketParticipants(ctx context.Context, tsk types.TipSetKey) (map[string]types.MarketBalance, error) {
 	out := map[string]types.MarketBalance{}
-	ts, err := m.chain.ChainGetTipSet(ctx, tsk)
+	escrow, locked, err := m.balanceTables(ctx, tsk)
 	if err != nil {
-		return nil, fmt.Errorf("loading tipset %s: %w", tsk, err)
+		return nil, err
 	}
 
-	state, err := m.stmgr.GetMarketState(ctx, ts)
+	err = escrow.ForEach(func(a address.Address, es abi.TokenAmount) error {
+		lk, err := locked.Get(a)
+		if err != nil {
+			return err
+		}
+
+		out[a.String()] = types.MarketBalance{
+			Escrow: es,
+			Locked: lk,
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	escrow, err := state.EscrowTable()
+	return out, nil
+}
+
+// errPageFull stops the iteration of a balance table once a page is full
+var errPageFull = errors.New("page full")
+
+// StateMarketParticipantsPage returns up to limit participants of the Storage Market following the cursor, in the
+// order of the escrow table, the pages of a listing must be read at the same tipset
+func (m *marketAPI) StateMarketParticipantsPage(ctx context.Context, cursor address.Address, limit int, tsk types.TipSetKey) (*types.MarketParticipantsPage, error) {
+	if limit <= 0 || limit > maxMarketParticipantsPage {
+		return nil, fmt.Errorf("limit must be in [1, %d], got %d", maxMarketParticipantsPage, limit)
+	}
+	escrow, locked, err := m.balanceTables(ctx, tsk)
 	if err != nil {
 		return nil, err
 	}
-	locked, err := state.LockedTable()
+
+	out, err := marketParticipantsPage(escrow, locked, cursor, limit)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("listing the participants at %s: %w", tsk, err)
 	}
+	return out, nil
+}
+
+// marketParticipantsPage returns up to limit participants of the escrow table following the cursor
+func marketParticipantsPage(escrow, locked marketactor.BalanceTable, cursor address.Address, limit int) (*types.MarketParticipantsPage, error) {
+	out := &types.MarketParticipantsPage{Participants: make([]types.MarketParticipant, 0, limit)}
+	skipping := !cursor.Empty()
+	err := escrow.ForEach(func(a address.Address, es abi.TokenAmount) error {
+		if skipping {
+			skipping = a != cursor
+			return nil
+		}
+		if len(out.Participants) == limit {
+			out.Next = out.Participants[limit-1].Address
+			return errPageFull
+		}
 
-	err = escrow.ForEach(func(a address.Address, es abi.TokenAmount) error {
 		lk, err := locked.Get(a)
 		if err != nil {
 			return err
 		}
-
-		out[a.String()] = types.MarketBalance{
-			Escrow: es,
-			Locked: lk,
-		}
+		out.Participants = append(out.Participants, types.MarketParticipant{
+			Address: a,
+			MarketBalance: types.MarketBalance{
+				Escrow: es,
+				Locked: lk,
+			},
+		})
 		return nil
 	})
-	if err != nil {
+	if err != nil && !errors.Is(err, errPageFull) {
 		return nil, err
 	}
+	if skipping {
+		return nil, fmt.Errorf("cursor %s is not a participant", cursor)
+	}
 	return out, nil
 }
+
+func (m *marketAPI) balanceTables(ctx context.Context, tsk types.TipSetKey) (marketactor.BalanceTable, marketactor.BalanceTable, error) {
+	ts, err := m.chain.ChainGetTipSet(ctx, tsk)
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading tipset %s: %w", tsk, err)
+	}
+
+	state, err := m.stmgr.GetMarketState(ctx, ts)
+	if err != nil {
+		return nil, nil, err
+	}
+	escrow, err := state.EscrowTable()
+	if err != nil {
+		return nil, nil, err
+	}
+	locked, err := state.LockedTable()
+	if err != nil {
+		return nil, nil, err
+	}
+	return escrow, locked, nil
+}
+
+// MarketAddBalance adds funds to the market actor escrow of addr, without reserving them
+func (m *marketAPI) MarketAddBalance(ctx context.Context, wallet, addr address.Address, amt types.BigInt) (cid.Cid, error) {
+	params, aerr := actors.SerializeParams(&addr)
+	if aerr != nil {
+		return cid.Undef, aerr
+	}
+
+	smsg, err := m.mpool.MpoolPushMessage(ctx, &types.Message{
+		To:     marketactor.Address,
+		From:   wallet,
+		Value:  amt,
+		Method: marketactor.Methods.AddBalance,
+		Params: params,
+	}, nil)
+	if err != nil {
+		return cid.Undef, err
+	}
+
+	return smsg.Cid(), nil
+}
+
+// MarketGetReserved returns the funds reserved for addr, including the reservations still waiting for their message to land
+func (m *marketAPI) MarketGetReserved(ctx context.Context, addr address.Address) (types.BigInt, error) {
+	return m.fmgr.GetReserved(addr), nil
+}
+
+// MarketReserveFunds reserves amt for addr, topping up the escrow when the unreserved funds are not enough
+func (m *marketAPI) MarketReserveFunds(ctx context.Context, wallet address.Address, addr address.Address, amt types.BigInt) (cid.Cid, error) {
+	return m.fmgr.Reserve(ctx, wallet, addr, amt)
+}
+
+// MarketReleaseFunds releases amt previously reserved for addr
+func (m *marketAPI) MarketReleaseFunds(ctx context.Context, addr address.Address, amt types.BigInt) error {
+	return m.fmgr.Release(addr, amt)
+}
+
+// MarketWithdraw withdraws amt from the escrow of addr, it fails if amt exceeds the unreserved funds
+func (m *marketAPI) MarketWithdraw(ctx context.Context, wallet, addr address.Address, amt types.BigInt) (cid.Cid, error) {
+	return m.fmgr.Withdraw(ctx, wallet, addr, amt)
+}
@@ -0,0 +1,237 @@
+package market
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	ds_sync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	marketactor "github.com/filecoin-project/venus/venus-shared/actors/builtin/market"
+	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// testBalanceTable is a balance table iterated in the order of its addresses.
+type testBalanceTable struct {
+	addrs    []address.Address
+	balances map[address.Address]abi.TokenAmount
+	err      error
+}
+
+func (bt *testBalanceTable) ForEach(cb func(address.Address, abi.TokenAmount) error) error {
+	for _, addr := range bt.addrs {
+		if err := cb(addr, bt.balances[addr]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (bt *testBalanceTable) Get(addr address.Address) (abi.TokenAmount, error) {
+	if bt.err != nil {
+		return big.Zero(), bt.err
+	}
+	if balance, ok := bt.balances[addr]; ok {
+		return balance, nil
+	}
+	return big.Zero(), nil
+}
+
+func TestMarketParticipantsPage(t *testing.T) {
+	tf.UnitTest(t)
+
+	escrow := &testBalanceTable{balances: map[address.Address]abi.TokenAmount{}}
+	locked := &testBalanceTable{balances: map[address.Address]abi.TokenAmount{}}
+	var all []types.MarketParticipant
+	for i := 0; i < 25; i++ {
+		addr, err := address.NewIDAddress(uint64(1000 + i))
+		require.NoError(t, err)
+		escrow.addrs = append(escrow.addrs, addr)
+		escrow.balances[addr] = big.NewInt(int64(100 + i))
+		if i%2 == 0 {
+			locked.balances[addr] = big.NewInt(int64(i))
+		}
+		lk, err := locked.Get(addr)
+		require.NoError(t, err)
+		all = append(all, types.MarketParticipant{Address: addr, MarketBalance: types.MarketBalance{Escrow: escrow.balances[addr], Locked: lk}})
+	}
+
+	t.Run("cursor", func(t *testing.T) {
+		var listed []types.MarketParticipant
+		var sizes []int
+		cursor := address.Undef
+		for pages := 0; ; pages++ {
+			require.Less(t, pages, 3)
+			page, err := marketParticipantsPage(escrow, locked, cursor, 10)
+			require.NoError(t, err)
+			listed = append(listed, page.Participants...)
+			sizes = append(sizes, len(page.Participants))
+			if page.Next.Empty() {
+				break
+			}
+			assert.Equal(t, page.Participants[len(page.Participants)-1].Address, page.Next)
+			cursor = page.Next
+		}
+		assert.Equal(t, []int{10, 10, 5}, sizes)
+		assert.Equal(t, all, listed)
+	})
+
+	t.Run("last page full", func(t *testing.T) {
+		page, err := marketParticipantsPage(escrow, locked, all[14].Address, 10)
+		require.NoError(t, err)
+		assert.Equal(t, all[15:], page.Participants)
+		assert.True(t, page.Next.Empty())
+
+		page, err = marketParticipantsPage(escrow, locked, all[24].Address, 10)
+		require.NoError(t, err)
+		assert.Empty(t, page.Participants)
+		assert.True(t, page.Next.Empty())
+	})
+
+	t.Run("unknown cursor", func(t *testing.T) {
+		unknown, err := address.NewIDAddress(999)
+		require.NoError(t, err)
+		_, err = marketParticipantsPage(escrow, locked, unknown, 10)
+		assert.ErrorContains(t, err, "is not a participant")
+	})
+
+	t.Run("table error", func(t *testing.T) {
+		_, err := marketParticipantsPage(escrow, &testBalanceTable{err: errors.New("not found")}, address.Undef, 10)
+		assert.EqualError(t, err, "not found")
+	})
+}
+
+// testMarketChain serves the market balances and lands every message at once.
+type testMarketChain struct {
+	v1api.IChain
+
+	lk     sync.Mutex
+	escrow map[address.Address]abi.TokenAmount
+}
+
+func (c *testMarketChain) setEscrow(addr address.Address, amt abi.TokenAmount) {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+	c.escrow[addr] = amt
+}
+
+func (c *testMarketChain) StateMarketBalance(ctx context.Context, addr address.Address, tsk types.TipSetKey) (types.MarketBalance, error) {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+	escrow, ok := c.escrow[addr]
+	if !ok {
+		escrow = big.Zero()
+	}
+	return types.MarketBalance{Escrow: escrow, Locked: big.Zero()}, nil
+}
+
+func (c *testMarketChain) StateWaitMsg(ctx context.Context, msg cid.Cid, confidence uint64, limit abi.ChainEpoch, allowReplaced bool) (*types.MsgLookup, error) {
+	return &types.MsgLookup{Message: msg}, nil
+}
+
+// testMarketPool records the pushed messages.
+type testMarketPool struct {
+	v1api.IMessagePool
+
+	lk   sync.Mutex
+	msgs []*types.Message
+}
+
+func (mp *testMarketPool) MpoolPushMessage(ctx context.Context, msg *types.Message, spec *types.MessageSendSpec) (*types.SignedMessage, error) {
+	mp.lk.Lock()
+	defer mp.lk.Unlock()
+	mp.msgs = append(mp.msgs, msg)
+	return &types.SignedMessage{Message: *msg}, nil
+}
+
+func (mp *testMarketPool) pushed() []*types.Message {
+	mp.lk.Lock()
+	defer mp.lk.Unlock()
+	return append([]*types.Message(nil), mp.msgs...)
+}
+
+func TestMarketFunds(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	wallet, err := address.NewIDAddress(100)
+	require.NoError(t, err)
+	addr, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+
+	chain := &testMarketChain{escrow: map[address.Address]abi.TokenAmount{}}
+	mpool := &testMarketPool{}
+	ds := ds_sync.MutexWrap(datastore.NewMapDatastore())
+
+	ms := NewMarketModule(chain, mpool, nil, ds)
+	require.NoError(t, ms.Start(ctx))
+	defer ms.Stop()
+	api := ms.API()
+
+	// adding to the escrow does not reserve anything
+	c, err := api.MarketAddBalance(ctx, wallet, addr, big.NewInt(10))
+	require.NoError(t, err)
+	require.Len(t, mpool.pushed(), 1)
+	msg := mpool.pushed()[0]
+	assert.Equal(t, (&types.SignedMessage{Message: *msg}).Cid(), c)
+	assert.Equal(t, marketactor.Address, msg.To)
+	assert.Equal(t, wallet, msg.From)
+	assert.Equal(t, marketactor.Methods.AddBalance, msg.Method)
+	assert.Equal(t, big.NewInt(10), msg.Value)
+	var escrowAddr address.Address
+	require.NoError(t, escrowAddr.UnmarshalCBOR(bytes.NewReader(msg.Params)))
+	assert.Equal(t, addr, escrowAddr)
+	reserved, err := api.MarketGetReserved(ctx, addr)
+	require.NoError(t, err)
+	assert.True(t, reserved.IsZero())
+
+	// the escrow covers the reservation, no message is needed
+	chain.setEscrow(addr, big.NewInt(10))
+	c, err = api.MarketReserveFunds(ctx, wallet, addr, big.NewInt(6))
+	require.NoError(t, err)
+	assert.Equal(t, cid.Undef, c)
+	assert.Len(t, mpool.pushed(), 1)
+	reserved, err = api.MarketGetReserved(ctx, addr)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(6), reserved)
+
+	// the reserved funds cannot be withdrawn
+	_, err = api.MarketWithdraw(ctx, wallet, addr, big.NewInt(5))
+	assert.ErrorContains(t, err, "insufficient funds for withdrawal")
+	assert.Len(t, mpool.pushed(), 1)
+
+	c, err = api.MarketWithdraw(ctx, wallet, addr, big.NewInt(4))
+	require.NoError(t, err)
+	require.Len(t, mpool.pushed(), 2)
+	msg = mpool.pushed()[1]
+	assert.Equal(t, (&types.SignedMessage{Message: *msg}).Cid(), c)
+	assert.Equal(t, marketactor.Methods.WithdrawBalance, msg.Method)
+	var params types.MarketWithdrawBalanceParams
+	require.NoError(t, params.UnmarshalCBOR(bytes.NewReader(msg.Params)))
+	assert.Equal(t, addr, params.ProviderOrClientAddress)
+	assert.Equal(t, big.NewInt(4), params.Amount)
+
+	// the reservations survive a restart of the node
+	restarted := NewMarketModule(chain, mpool, nil, ds)
+	require.NoError(t, restarted.Start(ctx))
+	defer restarted.Stop()
+	reserved, err = restarted.API().MarketGetReserved(ctx, addr)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(6), reserved)
+
+	require.NoError(t, restarted.API().MarketReleaseFunds(ctx, addr, big.NewInt(6)))
+	reserved, err = restarted.API().MarketGetReserved(ctx, addr)
+	require.NoError(t, err)
+	assert.True(t, reserved.IsZero())
+}
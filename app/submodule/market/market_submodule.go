@@ -1,6 +1,10 @@
 package market
 
 import (
+	"context"
+
+	"github.com/filecoin-project/venus/pkg/market"
+	"github.com/filecoin-project/venus/pkg/repo"
 	"github.com/filecoin-project/venus/pkg/statemanger"
 	v0api "github.com/filecoin-project/venus/venus-shared/api/chain/v0"
 	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
@@ -8,19 +12,36 @@ import (
 
 // MarketSubmodule enhances the `Node` with market capabilities.
 type MarketSubmodule struct { //nolint
-	c  v1api.IChain
-	sm statemanger.IStateManager
+	c    v1api.IChain
+	mp   v1api.IMessagePool
+	sm   statemanger.IStateManager
+	fmgr *market.FundManager
 }
 
 // NewMarketModule create new market module
-func NewMarketModule(c v1api.IChain, sm statemanger.IStateManager) *MarketSubmodule { //nolint
-	return &MarketSubmodule{c, sm}
+func NewMarketModule(c v1api.IChain, mp v1api.IMessagePool, sm statemanger.IStateManager, ds repo.Datastore) *MarketSubmodule { //nolint
+	fmgr := market.NewFundManager(&market.FundManagerParams{
+		MP: mp,
+		CI: c,
+		MS: c,
+		DS: ds,
+	})
+	return &MarketSubmodule{c: c, mp: mp, sm: sm, fmgr: fmgr}
+}
+
+// Start loads the funds reserved before the node was restarted and resumes the pending messages
+func (ms *MarketSubmodule) Start(ctx context.Context) error {
+	return ms.fmgr.Start(ctx)
+}
+
+func (ms *MarketSubmodule) Stop() {
+	ms.fmgr.Stop()
 }
 
 func (ms *MarketSubmodule) API() v1api.IMarket {
-	return newMarketAPI(ms.c, ms.sm)
+	return newMarketAPI(ms.c, ms.mp, ms.sm, ms.fmgr)
 }
 
 func (ms *MarketSubmodule) V0API() v0api.IMarket {
-	return newMarketAPI(ms.c, ms.sm)
+	return newMarketAPI(ms.c, ms.mp, ms.sm, ms.fmgr)
 }
@@ -0,0 +1,154 @@
+package mining
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	acrypto "github.com/filecoin-project/go-state-types/crypto"
+	"github.com/filecoin-project/go-state-types/network"
+	logging "github.com/ipfs/go-log/v2"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/consensus"
+	"github.com/filecoin-project/venus/pkg/constants"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+var log = logging.Logger("mining")
+
+// WinningPoStProver computes the winning PoSt proofs of a miner, the venus-gateway proof client
+// implements it so the proofs can be computed by a remote venus-sealer or damocles.
+type WinningPoStProver interface {
+	ComputeProof(ctx context.Context, miner address.Address, sectorInfos []builtin.ExtendedSectorInfo, rand abi.PoStRandomness, height abi.ChainEpoch, nwVersion network.Version) ([]builtin.PoStProof, error)
+}
+
+// mineLoop tries to produce a block for each miner every epoch, on top of the heaviest tipset
+// known once the propagation delay has passed.
+func (miningModule *MiningModule) mineLoop(ctx context.Context, miners []address.Address) {
+	defer miningModule.wg.Done()
+
+	clk := miningModule.Config.ChainClock()
+	for {
+		round := clk.WaitNextEpoch(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-clk.After(miningModule.Config.PropagationDelay()):
+		}
+
+		base := miningModule.ChainModule.ChainReader.GetHead()
+		if base.Height() >= round {
+			continue
+		}
+
+		for _, maddr := range miners {
+			blk, err := miningModule.mineOne(ctx, base, round, maddr)
+			if err != nil {
+				log.Errorf("mining block for %s at %d failed: %v", maddr, round, err)
+				continue
+			}
+			if blk == nil {
+				continue
+			}
+
+			if err := miningModule.SyncModule.API().SyncSubmitBlock(ctx, blk); err != nil {
+				log.Errorf("submitting block %s of %s at %d failed: %v", blk.Header.Cid(), maddr, round, err)
+				continue
+			}
+			log.Infow("mined new block", "cid", blk.Header.Cid(), "height", round, "miner", maddr, "parents", base.Key())
+		}
+	}
+}
+
+// mineOne runs the election of maddr for round on top of base, and returns the block to submit
+// when it wins, or nil.
+func (miningModule *MiningModule) mineOne(ctx context.Context, base *types.TipSet, round abi.ChainEpoch, maddr address.Address) (*types.BlockMsg, error) {
+	api := miningModule.API()
+	signer := miningModule.Wallet.Signer
+
+	mbi, err := api.MinerGetBaseInfo(ctx, maddr, round, base.Key())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mining base info: %w", err)
+	}
+	if mbi == nil || !mbi.EligibleForMining {
+		return nil, nil
+	}
+
+	rbase := mbi.PrevBeaconEntry
+	if len(mbi.BeaconEntries) > 0 {
+		rbase = mbi.BeaconEntries[len(mbi.BeaconEntries)-1]
+	}
+
+	buf := new(bytes.Buffer)
+	if err := maddr.MarshalCBOR(buf); err != nil {
+		return nil, fmt.Errorf("failed to marshal miner address: %w", err)
+	}
+
+	vrfBase, err := chain.DrawRandomnessFromBase(rbase.Data, acrypto.DomainSeparationTag_ElectionProofProduction, round, buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to draw election randomness: %w", err)
+	}
+	vrfProof, err := signer.SignBytes(ctx, vrfBase, mbi.WorkerKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute election proof: %w", err)
+	}
+	eproof := &types.ElectionProof{VRFProof: vrfProof.Data}
+	eproof.WinCount = eproof.ComputeWinCount(mbi.MinerPower, mbi.NetworkPower)
+	if eproof.WinCount < 1 {
+		return nil, nil
+	}
+
+	upgrades := miningModule.Config.Repo().Config().NetworkParams.ForkUpgradeParam
+	tm := consensus.NewTicketMachine(miningModule.ChainModule.ChainReader)
+	ticket, err := tm.MakeTicket(ctx, base.Key(), round-constants.TicketRandomnessLookback, maddr, &rbase,
+		round > upgrades.UpgradeSmokeHeight, mbi.WorkerKey, signer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute ticket: %w", err)
+	}
+
+	prand, err := chain.DrawRandomnessFromBase(rbase.Data, acrypto.DomainSeparationTag_WinningPoStChallengeSeed, round, buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to draw winning post randomness: %w", err)
+	}
+	nv := miningModule.ChainModule.Fork.GetNetworkVersion(ctx, base.Height())
+	proofs, err := miningModule.prover.ComputeProof(ctx, maddr, mbi.Sectors, prand, round, nv)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute winning post proof: %w", err)
+	}
+
+	msgs, err := miningModule.MessagePool.MpoolSelect(ctx, base.Key(), ticket.Quality())
+	if err != nil {
+		return nil, fmt.Errorf("failed to select messages for block: %w", err)
+	}
+
+	return api.MinerCreateBlock(ctx, &types.BlockTemplate{
+		Miner:            maddr,
+		Parents:          base.Key(),
+		Ticket:           &ticket,
+		Eproof:           eproof,
+		BeaconValues:     mbi.BeaconEntries,
+		Messages:         msgs,
+		Epoch:            round,
+		Timestamp:        uint64(miningModule.Config.ChainClock().StartTimeOfEpoch(round).Unix()),
+		WinningPoStProof: proofs,
+	})
+}
+
+// fakeWinningPoStProver returns placeholder proofs, accepted by the fake verifier of the devnets
+// whose miners only have fake sectors.
+type fakeWinningPoStProver struct{}
+
+func (fakeWinningPoStProver) ComputeProof(_ context.Context, _ address.Address, sectorInfos []builtin.ExtendedSectorInfo, _ abi.PoStRandomness, _ abi.ChainEpoch, _ network.Version) ([]builtin.PoStProof, error) {
+	if len(sectorInfos) == 0 {
+		return nil, fmt.Errorf("no sector to prove")
+	}
+	proofType, err := sectorInfos[0].SealProof.RegisteredWinningPoStProof()
+	if err != nil {
+		return nil, err
+	}
+	return []builtin.PoStProof{{PoStProof: proofType, ProofBytes: []byte("valid proof")}}, nil
+}
@@ -0,0 +1,103 @@
+package mining
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/filecoin-project/go-state-types/big"
+	power6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/power"
+
+	"github.com/filecoin-project/venus/pkg/constants"
+	"github.com/filecoin-project/venus/venus-shared/actors"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/miner"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/power"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// MinerCreate builds the message creating a miner with the power actor, the window PoSt proof type
+// being chosen from the sector size and the current network version. When params.Push is set the
+// message is pushed and the addresses of the new miner are returned once it is executed.
+func (miningAPI *MiningAPI) MinerCreate(ctx context.Context, params *types.MinerCreateParams) (*types.MinerCreateResult, error) {
+	if params.Owner.Empty() {
+		return nil, fmt.Errorf("missing owner address")
+	}
+	if params.SectorSize == 0 {
+		return nil, fmt.Errorf("missing sector size")
+	}
+	worker := params.Worker
+	if worker.Empty() {
+		worker = params.Owner
+	}
+	from := params.From
+	if from.Empty() {
+		from = params.Owner
+	}
+
+	head := miningAPI.Ming.ChainModule.ChainReader.GetHead()
+	nv := miningAPI.Ming.ChainModule.Fork.GetNetworkVersion(ctx, head.Height())
+	spt, err := miner.WindowPoStProofTypeFromSectorSize(params.SectorSize, nv)
+	if err != nil {
+		return nil, fmt.Errorf("getting post proof type: %w", err)
+	}
+
+	enc, err := actors.SerializeParams(&power6.CreateMinerParams{
+		Owner:               params.Owner,
+		Worker:              worker,
+		WindowPoStProofType: spt,
+		Peer:                params.Peer,
+		Multiaddrs:          params.Multiaddrs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &types.Message{
+		To:     power.Address,
+		From:   from,
+		Value:  big.Zero(),
+		Method: power.Methods.CreateMiner,
+		Params: enc,
+	}
+	if !params.GasPremium.Nil() {
+		msg.GasPremium = params.GasPremium
+	}
+	if !params.Push {
+		return &types.MinerCreateResult{Message: msg}, nil
+	}
+
+	signed, err := miningAPI.Ming.MessagePool.MpoolPushMessage(ctx, msg, params.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("pushing createMiner message: %w", err)
+	}
+	res := &types.MinerCreateResult{
+		Message:    &signed.Message,
+		MessageCid: signed.Cid(),
+	}
+
+	mw, err := miningAPI.Ming.ChainModule.API().StateWaitMsg(ctx, res.MessageCid, constants.MessageConfidence, constants.LookbackNoLimit, true)
+	if err != nil {
+		return nil, fmt.Errorf("waiting for createMiner message %s: %w", res.MessageCid, err)
+	}
+	if err := setCreatedMiner(res, mw); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// setCreatedMiner sets the addresses of the miner created by the executed createMiner message mw in res.
+func setCreatedMiner(res *types.MinerCreateResult, mw *types.MsgLookup) error {
+	if mw.Receipt.ExitCode != 0 {
+		return fmt.Errorf("create miner message %s failed: exit code %d", mw.Message, mw.Receipt.ExitCode)
+	}
+	res.MessageCid = mw.Message
+
+	var ret power6.CreateMinerReturn
+	if err := ret.UnmarshalCBOR(bytes.NewReader(mw.Receipt.Return)); err != nil {
+		return fmt.Errorf("decoding createMiner return: %w", err)
+	}
+	res.IDAddress = ret.IDAddress
+	res.RobustAddress = ret.RobustAddress
+	return nil
+}
@@ -0,0 +1,42 @@
+package mining
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	power6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/power"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/actors"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestSetCreatedMiner(t *testing.T) {
+	tf.UnitTest(t)
+
+	idAddr, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+	robustAddr, err := address.NewActorAddress([]byte("miner"))
+	require.NoError(t, err)
+	ret, err := actors.SerializeParams(&power6.CreateMinerReturn{IDAddress: idAddr, RobustAddress: robustAddr})
+	require.NoError(t, err)
+
+	msg := &types.Message{To: idAddr, From: robustAddr}
+	// the message executed is a replacement of the pushed one
+	replaced := (&types.Message{To: idAddr, From: robustAddr, Nonce: 1}).Cid()
+
+	res := &types.MinerCreateResult{Message: msg, MessageCid: msg.Cid()}
+	require.NoError(t, setCreatedMiner(res, &types.MsgLookup{Message: replaced, Receipt: types.MessageReceipt{Return: ret}}))
+	assert.Equal(t, &types.MinerCreateResult{Message: msg, MessageCid: replaced, IDAddress: idAddr, RobustAddress: robustAddr}, res)
+
+	res = &types.MinerCreateResult{Message: msg, MessageCid: msg.Cid()}
+	err = setCreatedMiner(res, &types.MsgLookup{Message: replaced, Receipt: types.MessageReceipt{ExitCode: exitcode.ErrForbidden}})
+	assert.ErrorContains(t, err, "exit code 18")
+	assert.Equal(t, address.Undef, res.IDAddress)
+
+	err = setCreatedMiner(res, &types.MsgLookup{Message: replaced, Receipt: types.MessageReceipt{Return: ret[:len(ret)-1]}})
+	assert.ErrorContains(t, err, "decoding createMiner return")
+}
@@ -37,11 +37,11 @@ func (miningAPI *MiningAPI) MinerGetBaseInfo(ctx context.Context, maddr address.
 	chainStore := miningAPI.Ming.ChainModule.ChainReader
 	ts, err := chainStore.GetTipSet(ctx, tsk)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load tipset for mining base: %v", err)
+		return nil, fmt.Errorf("failed to load tipset for mining base: %w", err)
 	}
 	pt, _, err := miningAPI.Ming.Stmgr.RunStateTransition(ctx, ts, nil, false)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get tipset root for mining base: %v", err)
+		return nil, fmt.Errorf("failed to get tipset root for mining base: %w", err)
 	}
 	prev, err := chainStore.GetLatestBeaconEntry(ctx, ts)
 	if err != nil {
@@ -66,7 +66,7 @@ func (miningAPI *MiningAPI) MinerGetBaseInfo(ctx context.Context, maddr address.
 	version := miningAPI.Ming.ChainModule.Fork.GetNetworkVersion(ctx, round)
 	lbts, lbst, err := miningAPI.Ming.ChainModule.ChainReader.GetLookbackTipSetForRound(ctx, ts, round, version)
 	if err != nil {
-		return nil, fmt.Errorf("getting lookback miner actor state: %v", err)
+		return nil, fmt.Errorf("getting lookback miner actor state: %w", err)
 	}
 
 	view := state.NewView(chainStore.Store(ctx), lbst)
@@ -86,7 +86,7 @@ func (miningAPI *MiningAPI) MinerGetBaseInfo(ctx context.Context, maddr address.
 	}
 	mas, err := miner.Load(chainStore.Store(ctx), act)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load miner actor state: %v", err)
+		return nil, fmt.Errorf("failed to load miner actor state: %w", err)
 	}
 
 	buf := new(bytes.Buffer)
@@ -171,18 +171,18 @@ func (miningAPI *MiningAPI) minerCreateBlock(ctx context.Context, bt *types.Bloc
 	cfg := miningAPI.Ming.Config.Repo().Config()
 	pts, err := chainStore.GetTipSet(ctx, bt.Parents)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load parent tipset: %v", err)
+		return nil, fmt.Errorf("failed to load parent tipset: %w", err)
 	}
 
 	st, receiptCid, err := miningAPI.Ming.Stmgr.RunStateTransition(ctx, pts, nil, false)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load tipset state: %v", err)
+		return nil, fmt.Errorf("failed to load tipset state: %w", err)
 	}
 
 	version := miningAPI.Ming.ChainModule.Fork.GetNetworkVersion(ctx, bt.Epoch)
 	_, lbst, err := miningAPI.Ming.ChainModule.ChainReader.GetLookbackTipSetForRound(ctx, pts, bt.Epoch, version)
 	if err != nil {
-		return nil, fmt.Errorf("getting lookback miner actor state: %v", err)
+		return nil, fmt.Errorf("getting lookback miner actor state: %w", err)
 	}
 
 	viewer := state.NewView(cbor.NewCborStore(miningAPI.Ming.BlockStore.Blockstore), lbst)
@@ -1,20 +1,29 @@
 package mining
 
 import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
 	"github.com/filecoin-project/venus/app/submodule/blockstore"
 	chain2 "github.com/filecoin-project/venus/app/submodule/chain"
 	"github.com/filecoin-project/venus/app/submodule/syncer"
 	"github.com/filecoin-project/venus/app/submodule/wallet"
+	"github.com/filecoin-project/venus/pkg/clock"
 	"github.com/filecoin-project/venus/pkg/repo"
 	"github.com/filecoin-project/venus/pkg/statemanger"
 	"github.com/filecoin-project/venus/pkg/util/ffiwrapper"
 	v0api "github.com/filecoin-project/venus/venus-shared/api/chain/v0"
 	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
+	gateway "github.com/filecoin-project/venus/venus-shared/api/gateway/v2"
 )
 
 type miningConfig interface {
 	Repo() repo.Repo
 	Verifier() ffiwrapper.Verifier
+	ChainClock() clock.ChainEpochClock
+	PropagationDelay() time.Duration
 }
 
 // MiningModule enhances the `Node` with miner capabilities.
@@ -24,8 +33,14 @@ type MiningModule struct { //nolint
 	BlockStore    *blockstore.BlockstoreSubmodule
 	SyncModule    *syncer.SyncerSubmodule
 	Wallet        wallet.WalletSubmodule
+	MessagePool   v1api.IMessagePool
 	proofVerifier ffiwrapper.Verifier
 	Stmgr         *statemanger.Stmgr
+
+	prover WinningPoStProver
+	closer func()
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
 }
 
 // API create new miningAPi implement
@@ -45,6 +60,7 @@ func NewMiningModule(
 	blockStore *blockstore.BlockstoreSubmodule,
 	syncModule *syncer.SyncerSubmodule,
 	wallet wallet.WalletSubmodule,
+	messagePool v1api.IMessagePool,
 ) *MiningModule {
 	return &MiningModule{
 		Stmgr:         stmgr,
@@ -53,6 +69,57 @@ func NewMiningModule(
 		BlockStore:    blockStore,
 		SyncModule:    syncModule,
 		Wallet:        wallet,
+		MessagePool:   messagePool,
 		proofVerifier: conf.Verifier(),
 	}
 }
+
+// SetWinningPoStProver replaces the prover computing the winning PoSt proofs of the mined blocks,
+// it must be called before Start.
+func (miningModule *MiningModule) SetWinningPoStProver(prover WinningPoStProver) {
+	miningModule.prover = prover
+}
+
+// Start starts producing blocks for the configured miners, it does nothing unless mining is enabled.
+func (miningModule *MiningModule) Start(ctx context.Context) error {
+	cfg := miningModule.Config.Repo().Config().Mining
+	if cfg == nil || !cfg.Enable {
+		return nil
+	}
+	if len(cfg.Miners) == 0 {
+		return fmt.Errorf("mining is enabled but no miner is configured")
+	}
+
+	if devnet := miningModule.Config.Repo().Config().Devnet; miningModule.prover == nil && devnet != nil && devnet.Enable {
+		miningModule.prover = fakeWinningPoStProver{}
+	}
+	if miningModule.prover == nil {
+		if len(cfg.WinningPoStProverURL) == 0 {
+			return fmt.Errorf("mining is enabled but no winning PoSt prover is configured")
+		}
+		client, closer, err := gateway.DialIGatewayRPC(ctx, cfg.WinningPoStProverURL, cfg.WinningPoStProverToken, nil)
+		if err != nil {
+			return fmt.Errorf("connect to winning PoSt prover %s: %w", cfg.WinningPoStProverURL, err)
+		}
+		miningModule.prover = client
+		miningModule.closer = closer
+	}
+
+	ctx, miningModule.cancel = context.WithCancel(context.Background())
+	miningModule.wg.Add(1)
+	go miningModule.mineLoop(ctx, cfg.Miners)
+
+	return nil
+}
+
+// Stop stops producing blocks and waits for the current round to finish.
+func (miningModule *MiningModule) Stop() {
+	if miningModule.cancel == nil {
+		return
+	}
+	miningModule.cancel()
+	miningModule.wg.Wait()
+	if miningModule.closer != nil {
+		miningModule.closer()
+	}
+}
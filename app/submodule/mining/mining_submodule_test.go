@@ -0,0 +1,100 @@
+package mining
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/clock"
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/pkg/repo"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/pkg/util/ffiwrapper"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin"
+)
+
+type testMiningConfig struct {
+	repo  repo.Repo
+	clock clock.ChainEpochClock
+}
+
+func (cfg *testMiningConfig) Repo() repo.Repo                   { return cfg.repo }
+func (cfg *testMiningConfig) Verifier() ffiwrapper.Verifier     { return nil }
+func (cfg *testMiningConfig) ChainClock() clock.ChainEpochClock { return cfg.clock }
+func (cfg *testMiningConfig) PropagationDelay() time.Duration   { return 6 * time.Second }
+
+type testProver struct{}
+
+func (testProver) ComputeProof(context.Context, address.Address, []builtin.ExtendedSectorInfo, abi.PoStRandomness, abi.ChainEpoch, network.Version) ([]builtin.PoStProof, error) {
+	return nil, nil
+}
+
+// stopMining stops the mining module, failing when the loop does not return.
+func stopMining(t *testing.T, miningModule *MiningModule) {
+	stopped := make(chan struct{})
+	go func() {
+		miningModule.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the mining loop did not stop")
+	}
+}
+
+func TestMiningStartStop(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	maddr, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+	newMiningModule := func(mining *config.MiningConfig) (*MiningModule, clock.Fake) {
+		r := repo.NewInMemoryRepo()
+		r.Config().Mining = mining
+		// half way through the first epoch
+		fake, clk := clock.NewFakeChain(0, 30*time.Second, 15)
+		return &MiningModule{Config: &testMiningConfig{repo: r, clock: clk}}, fake
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		miningModule, _ := newMiningModule(&config.MiningConfig{Miners: []address.Address{maddr}})
+		require.NoError(t, miningModule.Start(ctx))
+		assert.Nil(t, miningModule.cancel)
+		stopMining(t, miningModule)
+	})
+
+	t.Run("misconfigured", func(t *testing.T) {
+		miningModule, _ := newMiningModule(&config.MiningConfig{Enable: true})
+		assert.ErrorContains(t, miningModule.Start(ctx), "no miner is configured")
+
+		miningModule, _ = newMiningModule(&config.MiningConfig{Enable: true, Miners: []address.Address{maddr}})
+		assert.ErrorContains(t, miningModule.Start(ctx), "no winning PoSt prover is configured")
+	})
+
+	t.Run("waiting for the next epoch", func(t *testing.T) {
+		miningModule, fake := newMiningModule(&config.MiningConfig{Enable: true, Miners: []address.Address{maddr}})
+		miningModule.SetWinningPoStProver(testProver{})
+		require.NoError(t, miningModule.Start(ctx))
+
+		fake.BlockUntil(1)
+		stopMining(t, miningModule)
+	})
+
+	t.Run("waiting for the propagation delay", func(t *testing.T) {
+		miningModule, fake := newMiningModule(&config.MiningConfig{Enable: true, Miners: []address.Address{maddr}})
+		miningModule.SetWinningPoStProver(testProver{})
+		require.NoError(t, miningModule.Start(ctx))
+
+		fake.BlockUntil(1)
+		fake.Advance(15 * time.Second)
+		fake.BlockUntil(1)
+		stopMining(t, miningModule)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/venus/pkg/messagepool"
+	"github.com/filecoin-project/venus/pkg/signaudit"
 	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 	"github.com/filecoin-project/venus/venus-shared/types"
 	"github.com/ipfs/go-cid"
@@ -42,6 +43,12 @@ func (a *MessagePoolAPI) MpoolPublishMessage(ctx context.Context, smsg *types.Si
 
 // MpoolPush pushes a signed message to mempool.
 func (a *MessagePoolAPI) MpoolPush(ctx context.Context, smsg *types.SignedMessage) (cid.Cid, error) {
+	c, err := a.push(ctx, smsg)
+	a.recordPush(ctx, "MpoolPush", smsg, err)
+	return c, err
+}
+
+func (a *MessagePoolAPI) push(ctx context.Context, smsg *types.SignedMessage) (cid.Cid, error) {
 	if err := sanityCheckOutgoingMessage(&smsg.Message); err != nil {
 		return cid.Undef, fmt.Errorf("message %s from %s with nonce %d failed sanity check: %w", smsg.Cid(),
 			smsg.Message.From, smsg.Message.Nonce, err)
@@ -49,6 +56,16 @@ func (a *MessagePoolAPI) MpoolPush(ctx context.Context, smsg *types.SignedMessag
 	return a.mp.MPool.Push(ctx, smsg)
 }
 
+// recordPush records the push of smsg by method to the signing audit log.
+func (a *MessagePoolAPI) recordPush(ctx context.Context, method string, smsg *types.SignedMessage, err error) {
+	rec := signaudit.MessageRecord(method, &smsg.Message)
+	rec.MsgCid = smsg.Cid()
+	if err != nil {
+		rec.Error = err.Error()
+	}
+	a.mp.auditLog.Record(ctx, rec)
+}
+
 // MpoolGetConfig returns (a copy of) the current mpool config
 func (a *MessagePoolAPI) MpoolGetConfig(context.Context) (*types.MpoolConfig, error) {
 	cfg := a.mp.MPool.GetConfig()
@@ -84,6 +101,21 @@ func (a *MessagePoolAPI) MpoolSelect(ctx context.Context, tsk types.TipSetKey, t
 	return a.mp.MPool.SelectMessages(ctx, ts, ticketQuality)
 }
 
+// MpoolSelectWithDetail returns the pending messages for inclusion in the next block, with the gas reward and dependency of each message
+func (a *MessagePoolAPI) MpoolSelectWithDetail(ctx context.Context, tsk types.TipSetKey, ticketQuality float64) (*types.MpoolSelection, error) {
+	ts, err := a.mp.chain.API().ChainGetTipSet(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("loading tipset %s: %w", tsk, err)
+	}
+
+	return a.mp.MPool.SelectMessagesWithDetail(ctx, ts, ticketQuality)
+}
+
+// MpoolStats returns the pending messages by sender, the percentiles of their premiums and the projected inclusion of a message at premiums
+func (a *MessagePoolAPI) MpoolStats(ctx context.Context, premiums []big.Int) (*types.MpoolStats, error) {
+	return a.mp.MPool.Stats(ctx, premiums)
+}
+
 // MpoolSelects The batch selection message is used when multiple blocks need to select messages at the same time
 func (a *MessagePoolAPI) MpoolSelects(ctx context.Context, tsk types.TipSetKey, ticketQualitys []float64) ([][]*types.SignedMessage, error) {
 	ts, err := a.mp.chain.API().ChainGetTipSet(ctx, tsk)
@@ -182,7 +214,9 @@ func (a *MessagePoolAPI) MpoolPushUntrusted(ctx context.Context, smsg *types.Sig
 		return cid.Undef, fmt.Errorf("message %s from %s with nonce %d failed sanity check: %w", smsg.Cid(),
 			smsg.Message.From, smsg.Message.Nonce, err)
 	}
-	return a.mp.MPool.PushUntrusted(ctx, smsg)
+	c, err := a.mp.MPool.PushUntrusted(ctx, smsg)
+	a.recordPush(ctx, "MpoolPushUntrusted", smsg, err)
+	return c, err
 }
 
 // MpoolPushMessage atomically assigns a nonce, signs, and pushes a message
@@ -250,12 +284,18 @@ func (a *MessagePoolAPI) MpoolPushMessage(ctx context.Context, msg *types.Messag
 	}
 
 	// Sign and push the message
-	return a.mp.msgSigner.SignMessage(ctx, msg, func(smsg *types.SignedMessage) error {
-		if _, err := a.MpoolPush(ctx, smsg); err != nil {
+	smsg, err := a.mp.msgSigner.SignMessage(ctx, msg, func(smsg *types.SignedMessage) error {
+		if _, err := a.push(ctx, smsg); err != nil {
 			return fmt.Errorf("mpool push: failed to push message: %w", err)
 		}
 		return nil
 	})
+	recorded := smsg
+	if recorded == nil {
+		recorded = &types.SignedMessage{Message: *msg}
+	}
+	a.recordPush(ctx, "MpoolPushMessage", recorded, err)
+	return smsg, err
 }
 
 // MpoolBatchPush batch pushes a unsigned message to mempool.
@@ -267,6 +307,7 @@ func (a *MessagePoolAPI) MpoolBatchPush(ctx context.Context, smsgs []*types.Sign
 				smsg.Message.From, smsg.Message.Nonce, err)
 		}
 		smsgCid, err := a.mp.MPool.Push(ctx, smsg)
+		a.recordPush(ctx, "MpoolBatchPush", smsg, err)
 		if err != nil {
 			return messageCids, err
 		}
@@ -284,6 +325,7 @@ func (a *MessagePoolAPI) MpoolBatchPushUntrusted(ctx context.Context, smsgs []*t
 				smsg.Message.From, smsg.Message.Nonce, err)
 		}
 		smsgCid, err := a.mp.MPool.PushUntrusted(ctx, smsg)
+		a.recordPush(ctx, "MpoolBatchPushUntrusted", smsg, err)
 		if err != nil {
 			return messageCids, err
 		}
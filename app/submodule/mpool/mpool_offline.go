@@ -0,0 +1,196 @@
+package mpool
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/venus/pkg/crypto"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// maxBundleMessages bounds the number of messages of a bundle prepared for offline signing.
+const maxBundleMessages = 1000
+
+// MpoolPrepareMessages sets the nonces and estimates the gas of msgs at the current head, and returns them with the
+// bytes to sign for each of them, the messages of a sender get consecutive nonces following its pending messages.
+func (a *MessagePoolAPI) MpoolPrepareMessages(ctx context.Context, msgs []*types.Message, spec *types.MessageSendSpec) (*types.MessageBundle, error) {
+	if len(msgs) == 0 || len(msgs) > maxBundleMessages {
+		return nil, fmt.Errorf("the number of messages must be in [1, %d], got %d", maxBundleMessages, len(msgs))
+	}
+	head, err := a.mp.chain.API().ChainHead(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &types.MessageBundle{
+		Head:     head.Key(),
+		Height:   head.Height(),
+		Messages: make([]types.BundleMessage, 0, len(msgs)),
+	}
+	nonces := make(map[address.Address]uint64)
+	for i, in := range msgs {
+		if err := sanityCheckOutgoingMessage(in); err != nil {
+			return nil, fmt.Errorf("message %d from %s failed sanity check: %w", i, in.From, err)
+		}
+		msg := *in
+		fromA, err := a.mp.chain.API().StateAccountKey(ctx, msg.From, head.Key())
+		if err != nil {
+			return nil, fmt.Errorf("getting key address of %s: %w", msg.From, err)
+		}
+		if msg.From.Protocol() == address.ID {
+			msg.From = fromA
+		}
+
+		nonce, ok := nonces[fromA]
+		if !ok {
+			if nonce, err = a.MpoolGetNonce(ctx, fromA); err != nil {
+				return nil, fmt.Errorf("getting nonce of %s: %w", fromA, err)
+			}
+		}
+		msg.Nonce = nonce
+		estimated, err := a.GasEstimateMessageGas(ctx, &msg, spec, head.Key())
+		if err != nil {
+			return nil, fmt.Errorf("estimating gas of message %d: %w", i, err)
+		}
+		nonces[fromA] = nonce + 1
+
+		sigType := types.AddressProtocol2SignType(fromA.Protocol())
+		sb, err := estimated.SigningBytes(sigType)
+		if err != nil {
+			return nil, fmt.Errorf("getting signing bytes of message %d: %w", i, err)
+		}
+		bundle.Messages = append(bundle.Messages, types.BundleMessage{
+			Message:      *estimated,
+			Cid:          estimated.Cid(),
+			SigType:      sigType,
+			SigningBytes: sb,
+		})
+	}
+	return bundle, nil
+}
+
+// MpoolPushSigned checks the messages of bundle are the ones prepared by MpoolPrepareMessages, their signatures, and
+// that their nonces and the balances of their senders still match the chain, before pushing them to the message
+// pool in order.
+func (a *MessagePoolAPI) MpoolPushSigned(ctx context.Context, bundle *types.MessageBundle) ([]cid.Cid, error) {
+	if bundle == nil || len(bundle.Messages) == 0 {
+		return nil, errors.New("empty message bundle")
+	}
+
+	smsgs, err := checkBundle(ctx, offlineState{a}, bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	cids := make([]cid.Cid, 0, len(smsgs))
+	for _, smsg := range smsgs {
+		c, err := a.push(ctx, smsg)
+		a.recordPush(ctx, "MpoolPushSigned", smsg, err)
+		if err != nil {
+			return cids, err
+		}
+		cids = append(cids, c)
+	}
+	return cids, nil
+}
+
+// bundleState is the chain and message pool state the signed bundles are checked against.
+type bundleState interface {
+	StateAccountKey(ctx context.Context, addr address.Address, tsk types.TipSetKey) (address.Address, error)
+	MpoolGetNonce(ctx context.Context, addr address.Address) (uint64, error)
+	WalletBalance(ctx context.Context, addr address.Address) (big.Int, error)
+}
+
+// offlineState is the bundleState of the node.
+type offlineState struct {
+	*MessagePoolAPI
+}
+
+func (s offlineState) StateAccountKey(ctx context.Context, addr address.Address, tsk types.TipSetKey) (address.Address, error) {
+	return s.mp.chain.API().StateAccountKey(ctx, addr, tsk)
+}
+
+func (s offlineState) WalletBalance(ctx context.Context, addr address.Address) (big.Int, error) {
+	return s.mp.walletAPI.WalletBalance(ctx, addr)
+}
+
+// checkBundle checks the messages of bundle against st, and returns them signed in the order of the bundle.
+func checkBundle(ctx context.Context, st bundleState, bundle *types.MessageBundle) ([]*types.SignedMessage, error) {
+	smsgs := make([]*types.SignedMessage, 0, len(bundle.Messages))
+	nonces := make(map[address.Address]uint64)
+	funds := make(map[address.Address]big.Int)
+	for i := range bundle.Messages {
+		bm := &bundle.Messages[i]
+		smsg, fromA, err := checkBundleMessage(ctx, st, bm)
+		if err != nil {
+			return nil, fmt.Errorf("message %d: %w", i, err)
+		}
+
+		expected, ok := nonces[fromA]
+		if !ok {
+			if expected, err = st.MpoolGetNonce(ctx, fromA); err != nil {
+				return nil, fmt.Errorf("getting nonce of %s: %w", fromA, err)
+			}
+		}
+		if bm.Message.Nonce != expected {
+			return nil, fmt.Errorf("message %d: nonce %d of %s doesn't follow the chain and the pending messages, expected %d, prepare the bundle again",
+				i, bm.Message.Nonce, fromA, expected)
+		}
+		nonces[fromA] = expected + 1
+
+		required, ok := funds[fromA]
+		if !ok {
+			required = big.Zero()
+		}
+		funds[fromA] = big.Sum(required, bm.Message.Value, bm.Message.RequiredFunds())
+		smsgs = append(smsgs, smsg)
+	}
+
+	for addr, required := range funds {
+		balance, err := st.WalletBalance(ctx, addr)
+		if err != nil {
+			return nil, fmt.Errorf("getting balance of %s: %w", addr, err)
+		}
+		if balance.LessThan(required) {
+			return nil, fmt.Errorf("not enough funds for the messages of %s: %s < %s", addr, types.FIL(balance), types.FIL(required))
+		}
+	}
+
+	return smsgs, nil
+}
+
+// checkBundleMessage checks bm was not changed since it was prepared and is signed by the key of its sender.
+func checkBundleMessage(ctx context.Context, st bundleState, bm *types.BundleMessage) (*types.SignedMessage, address.Address, error) {
+	if bm.Message.Cid() != bm.Cid {
+		return nil, address.Undef, fmt.Errorf("message cid %s doesn't match the prepared cid %s", bm.Message.Cid(), bm.Cid)
+	}
+	sb, err := bm.Message.SigningBytes(bm.SigType)
+	if err != nil {
+		return nil, address.Undef, err
+	}
+	if !bytes.Equal(sb, bm.SigningBytes) {
+		return nil, address.Undef, errors.New("signing bytes don't match the message")
+	}
+	if bm.Signature == nil {
+		return nil, address.Undef, errors.New("missing signature")
+	}
+	if bm.Signature.Type != bm.SigType {
+		return nil, address.Undef, fmt.Errorf("signature type %d doesn't match the signature type %d of the sender", bm.Signature.Type, bm.SigType)
+	}
+
+	fromA, err := st.StateAccountKey(ctx, bm.Message.From, types.EmptyTSK)
+	if err != nil {
+		return nil, address.Undef, fmt.Errorf("getting key address of %s: %w", bm.Message.From, err)
+	}
+	if err := crypto.Verify(bm.Signature, fromA, sb); err != nil {
+		return nil, address.Undef, fmt.Errorf("invalid signature of %s: %w", fromA, err)
+	}
+
+	return &types.SignedMessage{Message: bm.Message, Signature: *bm.Signature}, fromA, nil
+}
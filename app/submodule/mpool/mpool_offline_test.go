@@ -0,0 +1,188 @@
+package mpool
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/crypto"
+	_ "github.com/filecoin-project/venus/pkg/crypto/secp"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+type stubBundleState struct {
+	keys     map[address.Address]address.Address
+	nonces   map[address.Address]uint64
+	balances map[address.Address]big.Int
+}
+
+func (s *stubBundleState) StateAccountKey(_ context.Context, addr address.Address, _ types.TipSetKey) (address.Address, error) {
+	if addr.Protocol() != address.ID {
+		return addr, nil
+	}
+	key, ok := s.keys[addr]
+	if !ok {
+		return address.Undef, fmt.Errorf("actor %s not found", addr)
+	}
+	return key, nil
+}
+
+func (s *stubBundleState) MpoolGetNonce(_ context.Context, addr address.Address) (uint64, error) {
+	return s.nonces[addr], nil
+}
+
+func (s *stubBundleState) WalletBalance(_ context.Context, addr address.Address) (big.Int, error) {
+	if balance, ok := s.balances[addr]; ok {
+		return balance, nil
+	}
+	return big.Zero(), nil
+}
+
+func TestCheckBundle(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	pk, err := crypto.Generate(crypto.SigTypeSecp256k1)
+	require.NoError(t, err)
+	pub, err := crypto.ToPublic(crypto.SigTypeSecp256k1, pk)
+	require.NoError(t, err)
+	from, err := address.NewSecp256k1Address(pub)
+	require.NoError(t, err)
+	fromID, err := address.NewIDAddress(1001)
+	require.NoError(t, err)
+	to, err := address.NewIDAddress(1002)
+	require.NoError(t, err)
+
+	newState := func() *stubBundleState {
+		return &stubBundleState{
+			keys:     map[address.Address]address.Address{fromID: from},
+			nonces:   map[address.Address]uint64{from: 5},
+			balances: map[address.Address]big.Int{from: big.Int(types.MustParseFIL("10"))},
+		}
+	}
+
+	// sign returns the bundle message of msg signed by the key of from
+	sign := func(msg types.Message) types.BundleMessage {
+		sb, err := msg.SigningBytes(crypto.SigTypeSecp256k1)
+		require.NoError(t, err)
+		sig, err := crypto.Sign(sb, pk, crypto.SigTypeSecp256k1)
+		require.NoError(t, err)
+		return types.BundleMessage{
+			Message:      msg,
+			Cid:          msg.Cid(),
+			SigType:      crypto.SigTypeSecp256k1,
+			SigningBytes: sb,
+			Signature:    sig,
+		}
+	}
+	newMessage := func(sender address.Address, nonce uint64, value string) types.Message {
+		return types.Message{
+			From:       sender,
+			To:         to,
+			Nonce:      nonce,
+			Value:      big.Int(types.MustParseFIL(value)),
+			GasLimit:   1000000,
+			GasFeeCap:  big.NewInt(100),
+			GasPremium: big.NewInt(100),
+		}
+	}
+	newBundle := func(msgs ...types.BundleMessage) *types.MessageBundle {
+		return &types.MessageBundle{Messages: msgs}
+	}
+
+	t.Run("valid bundle", func(t *testing.T) {
+		bundle := newBundle(
+			sign(newMessage(from, 5, "1")),
+			sign(newMessage(fromID, 6, "2")),
+			sign(newMessage(from, 7, "3")),
+		)
+		smsgs, err := checkBundle(ctx, newState(), bundle)
+		require.NoError(t, err)
+		require.Len(t, smsgs, 3)
+		for i, smsg := range smsgs {
+			assert.Equal(t, bundle.Messages[i].Message, smsg.Message)
+			assert.Equal(t, *bundle.Messages[i].Signature, smsg.Signature)
+			assert.Equal(t, uint64(5+i), smsg.Message.Nonce)
+		}
+	})
+
+	t.Run("tampered message", func(t *testing.T) {
+		bm := sign(newMessage(from, 5, "1"))
+		bm.Message.Value = big.Int(types.MustParseFIL("2"))
+		_, err := checkBundle(ctx, newState(), newBundle(bm))
+		assert.ErrorContains(t, err, "doesn't match the prepared cid")
+
+		// the prepared cid and signing bytes follow the message, the signature doesn't
+		bm.Cid = bm.Message.Cid()
+		bm.SigningBytes, err = bm.Message.SigningBytes(crypto.SigTypeSecp256k1)
+		require.NoError(t, err)
+		_, err = checkBundle(ctx, newState(), newBundle(bm))
+		assert.ErrorContains(t, err, "invalid signature")
+	})
+
+	t.Run("tampered signature", func(t *testing.T) {
+		bm := sign(newMessage(from, 5, "1"))
+		bm.Signature.Data[len(bm.Signature.Data)-2] ^= 0xff
+		_, err := checkBundle(ctx, newState(), newBundle(bm))
+		assert.ErrorContains(t, err, "message 0: invalid signature")
+
+		bm = sign(newMessage(from, 5, "1"))
+		bm.Signature = nil
+		_, err = checkBundle(ctx, newState(), newBundle(bm))
+		assert.ErrorContains(t, err, "missing signature")
+
+		bm = sign(newMessage(from, 5, "1"))
+		bm.Signature.Type = crypto.SigTypeBLS
+		_, err = checkBundle(ctx, newState(), newBundle(bm))
+		assert.ErrorContains(t, err, "signature type")
+	})
+
+	t.Run("signed by another key", func(t *testing.T) {
+		other, err := crypto.Generate(crypto.SigTypeSecp256k1)
+		require.NoError(t, err)
+		bm := sign(newMessage(from, 5, "1"))
+		bm.Signature, err = crypto.Sign(bm.SigningBytes, other, crypto.SigTypeSecp256k1)
+		require.NoError(t, err)
+		_, err = checkBundle(ctx, newState(), newBundle(bm))
+		assert.ErrorContains(t, err, "invalid signature")
+	})
+
+	t.Run("wrong nonce", func(t *testing.T) {
+		// the chain moved on since the bundle was prepared
+		_, err := checkBundle(ctx, newState(), newBundle(sign(newMessage(from, 4, "1"))))
+		assert.ErrorContains(t, err, "nonce 4")
+
+		// a gap in the nonces of the bundle
+		_, err = checkBundle(ctx, newState(), newBundle(
+			sign(newMessage(from, 5, "1")),
+			sign(newMessage(from, 7, "1")),
+		))
+		assert.ErrorContains(t, err, "message 1: nonce 7")
+	})
+
+	t.Run("insufficient funds", func(t *testing.T) {
+		// each message fits the balance, the bundle doesn't
+		_, err := checkBundle(ctx, newState(), newBundle(
+			sign(newMessage(from, 5, "6")),
+			sign(newMessage(fromID, 6, "6")),
+		))
+		assert.ErrorContains(t, err, "not enough funds")
+
+		// the gas is required on top of the value
+		_, err = checkBundle(ctx, newState(), newBundle(sign(newMessage(from, 5, "10"))))
+		assert.ErrorContains(t, err, "not enough funds")
+	})
+
+	t.Run("unknown sender", func(t *testing.T) {
+		unknown, err := address.NewIDAddress(1003)
+		require.NoError(t, err)
+		_, err = checkBundle(ctx, newState(), newBundle(sign(newMessage(unknown, 5, "1"))))
+		assert.ErrorContains(t, err, "getting key address")
+	})
+}
@@ -0,0 +1,45 @@
+package mpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+var errSchedulerDisabled = errors.New("message scheduler disabled, enable with Mpool.EnableScheduler")
+
+// MpoolScheduleMessage holds smsg until trigger fires then pushes it, the scheduled message is identified by the cid
+// of smsg.
+func (a *MessagePoolAPI) MpoolScheduleMessage(ctx context.Context, smsg *types.SignedMessage, trigger types.ScheduleTrigger) (cid.Cid, error) {
+	if a.mp.scheduler == nil {
+		return cid.Undef, errSchedulerDisabled
+	}
+	if smsg == nil {
+		return cid.Undef, errors.New("missing message")
+	}
+	if err := sanityCheckOutgoingMessage(&smsg.Message); err != nil {
+		return cid.Undef, fmt.Errorf("message %s from %s with nonce %d failed sanity check: %w", smsg.Cid(),
+			smsg.Message.From, smsg.Message.Nonce, err)
+	}
+	return a.mp.scheduler.Schedule(ctx, smsg, trigger)
+}
+
+// MpoolListScheduled returns the messages held by the scheduler, in the order they were scheduled.
+func (a *MessagePoolAPI) MpoolListScheduled(ctx context.Context) ([]*types.ScheduledMessage, error) {
+	if a.mp.scheduler == nil {
+		return nil, errSchedulerDisabled
+	}
+	return a.mp.scheduler.List(), nil
+}
+
+// MpoolCancelScheduled drops the scheduled message c before it is pushed.
+func (a *MessagePoolAPI) MpoolCancelScheduled(ctx context.Context, c cid.Cid) error {
+	if a.mp.scheduler == nil {
+		return errSchedulerDisabled
+	}
+	return a.mp.scheduler.Cancel(ctx, c)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-cid"
 	logging "github.com/ipfs/go-log"
 
 	"github.com/filecoin-project/venus/app/submodule/chain"
@@ -24,9 +25,12 @@ import (
 	"github.com/filecoin-project/venus/pkg/constants"
 	"github.com/filecoin-project/venus/pkg/messagepool"
 	"github.com/filecoin-project/venus/pkg/messagepool/journal"
+	"github.com/filecoin-project/venus/pkg/msgscheduler"
 	"github.com/filecoin-project/venus/pkg/repo"
+	"github.com/filecoin-project/venus/pkg/signaudit"
 	v0api "github.com/filecoin-project/venus/venus-shared/api/chain/v0"
 	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
+	vlogging "github.com/filecoin-project/venus/venus-shared/logging"
 	"github.com/filecoin-project/venus/venus-shared/types"
 )
 
@@ -47,6 +51,7 @@ var log = logging.Logger("mpool")
 
 type messagepoolConfig interface {
 	Repo() repo.Repo
+	EventJournal() journal.Journal
 }
 
 // MessagePoolSubmodule enhances the `Node` with internal message capabilities.
@@ -54,24 +59,18 @@ type MessagePoolSubmodule struct { //nolint
 	// Network Fields
 	MessageSub *pubsub.Subscription
 
-	MPool        *messagepool.MessagePool
-	msgSigner    *messagepool.MessageSigner
-	chain        *chain.ChainSubmodule
-	network      *network.NetworkSubmodule
-	walletAPI    v1api.IWallet
+	MPool     *messagepool.MessagePool
+	msgSigner *messagepool.MessageSigner
+	chain     *chain.ChainSubmodule
+	network   *network.NetworkSubmodule
+	walletAPI v1api.IWallet
+	auditLog  *signaudit.AuditLog
+	// scheduler holds the scheduled messages, it is nil unless Mpool.EnableScheduler is set
+	scheduler    *msgscheduler.Scheduler
 	networkCfg   *config.NetworkParamsConfig
 	bootstrapper bool
 }
 
-func OpenFilesystemJournal(lr repo.Repo) (journal.Journal, error) {
-	jrnl, err := journal.OpenFSJournal(lr, journal.EnvDisabledEvents())
-	if err != nil {
-		return nil, err
-	}
-
-	return jrnl, err
-}
-
 func NewMpoolSubmodule(ctx context.Context,
 	cfg messagepoolConfig,
 	network *network.NetworkSubmodule,
@@ -80,25 +79,31 @@ func NewMpoolSubmodule(ctx context.Context,
 ) (*MessagePoolSubmodule, error) {
 	mpp := messagepool.NewProvider(chain.Stmgr, chain.ChainReader, chain.MessageStore, cfg.Repo().Config().NetworkParams, network.Pubsub)
 
-	j, err := OpenFilesystemJournal(cfg.Repo())
-	if err != nil {
-		return nil, err
-	}
 	mp, err := messagepool.New(ctx, mpp, chain.Stmgr, cfg.Repo().MetaDatastore(), cfg.Repo().Config().NetworkParams,
-		cfg.Repo().Config().Mpool, network.NetworkName, j)
+		cfg.Repo().Config().Mpool, network.NetworkName, cfg.EventJournal())
 	if err != nil {
 		return nil, fmt.Errorf("constructing mpool: %s", err)
 	}
 
-	return &MessagePoolSubmodule{
+	mpool := &MessagePoolSubmodule{
 		MPool:        mp,
 		chain:        chain,
 		walletAPI:    wallet.API(),
+		auditLog:     wallet.AuditLog,
 		network:      network,
 		networkCfg:   cfg.Repo().Config().NetworkParams,
 		msgSigner:    messagepool.NewMessageSigner(wallet.WalletIntersection(), mp, cfg.Repo().MetaDatastore()),
 		bootstrapper: cfg.Repo().Config().PubsubConfig.Bootstrapper,
-	}, nil
+	}
+
+	if cfg.Repo().Config().Mpool.EnableScheduler {
+		api := &MessagePoolAPI{mp: mpool, pushLocks: messagepool.NewMpoolLocker()}
+		if mpool.scheduler, err = msgscheduler.New(ctx, cfg.Repo().MetaDatastore(), chain.API(), api.MpoolPush); err != nil {
+			return nil, fmt.Errorf("loading the scheduled messages: %w", err)
+		}
+	}
+
+	return mpool, nil
 }
 
 func (mp *MessagePoolSubmodule) handleIncomingMessage(ctx context.Context) {
@@ -123,14 +128,17 @@ func (mp *MessagePoolSubmodule) Validate(ctx context.Context, pid peer.ID, msg *
 	m := &types.SignedMessage{}
 	if err := m.UnmarshalCBOR(bytes.NewReader(msg.GetData())); err != nil {
 		log.Warnf("failed to decode incoming message: %s", err)
+		mp.network.Quarantine.Reject(ctx, types.QuarantineMessage, cid.Undef, msg.ReceivedFrom, "decode", err, msg.GetData())
 		return pubsub.ValidationReject
 	}
 
 	log.Debugf("validate incoming msg:%s", m.Cid().String())
 
 	if err := mp.MPool.Add(ctx, m); err != nil {
-		log.Debugf("failed to add message from network to message pool (From: %s, To: %s, Nonce: %d, Value: %s): %s", m.Message.From, m.Message.To, m.Message.Nonce, types.FIL(m.Message.Value), err)
+		log.Debugw("failed to add message from network to message pool", append(vlogging.ActorFields(m.Message.From),
+			"to", m.Message.To, "nonce", m.Message.Nonce, "value", types.FIL(m.Message.Value), "error", err)...)
 
+		var reason string
 		switch {
 		case errors.Is(err, messagepool.ErrSoftValidationFailure):
 			fallthrough
@@ -150,14 +158,16 @@ func (mp *MessagePoolSubmodule) Validate(ctx context.Context, pid peer.ID, msg *
 			return pubsub.ValidationIgnore
 
 		case errors.Is(err, messagepool.ErrMessageTooBig):
-			fallthrough
+			reason = "message_too_big"
 		case errors.Is(err, messagepool.ErrMessageValueTooHigh):
-			fallthrough
+			reason = "value_too_high"
 		case errors.Is(err, messagepool.ErrInvalidToAddr):
-			fallthrough
+			reason = "invalid_to_addr"
 		default:
-			return pubsub.ValidationReject
+			reason = "invalid"
 		}
+		mp.network.Quarantine.Reject(ctx, types.QuarantineMessage, m.Cid(), msg.ReceivedFrom, reason, err, msg.GetData())
+		return pubsub.ValidationReject
 	}
 	return pubsub.ValidationAccept
 }
@@ -222,6 +232,14 @@ func (mp *MessagePoolSubmodule) Start(ctx context.Context) error {
 		return nil
 	}
 
+	if mp.scheduler != nil {
+		go mp.scheduler.Run(ctx)
+		mp.chain.ChainReader.SubscribeHeadChanges(func(rev, app []*types.TipSet) error {
+			mp.scheduler.HeadChanged()
+			return nil
+		})
+	}
+
 	// wait until we are synced within 10 epochs
 	go mp.waitForSync(pubsubMsgsSyncEpochs, subscribe)
 
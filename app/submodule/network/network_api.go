@@ -146,3 +146,27 @@ func (na *networkAPI) NetPubsubScores(context.Context) ([]types.PubsubScore, err
 
 	return out, nil
 }
+
+// NetQuarantineList returns the latest blocks or messages rejected by the pubsub validators
+func (na *networkAPI) NetQuarantineList(ctx context.Context, kind types.QuarantineKind, limit int) ([]*types.QuarantineEntry, error) {
+	switch kind {
+	case "", types.QuarantineBlock, types.QuarantineMessage:
+	default:
+		return nil, fmt.Errorf("unknown quarantine kind %q", kind)
+	}
+	return na.network.Quarantine.List(kind, limit), nil
+}
+
+// NetQuarantineGet returns the rejection of the block or message c
+func (na *networkAPI) NetQuarantineGet(ctx context.Context, c cid.Cid) (*types.QuarantineEntry, error) {
+	entry, ok := na.network.Quarantine.Get(c)
+	if !ok {
+		return nil, fmt.Errorf("%s is not in quarantine", c)
+	}
+	return entry, nil
+}
+
+// NetQuarantineStats returns the number of pubsub rejections by kind and reason
+func (na *networkAPI) NetQuarantineStats(ctx context.Context) ([]types.QuarantineStat, error) {
+	return na.network.Quarantine.Stats(), nil
+}
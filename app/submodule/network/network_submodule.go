@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/dchest/blake2b"
@@ -41,6 +42,7 @@ import (
 	dtnet "github.com/filecoin-project/go-data-transfer/v2/network"
 	dtgstransport "github.com/filecoin-project/go-data-transfer/v2/transport/graphsync"
 
+	"github.com/filecoin-project/venus/app/submodule/blockstore"
 	"github.com/filecoin-project/venus/pkg/chain"
 	"github.com/filecoin-project/venus/pkg/config"
 	"github.com/filecoin-project/venus/pkg/net"
@@ -87,9 +89,15 @@ type NetworkSubmodule struct { //nolint
 	DataTransferHost dtnet.DataTransferNetwork
 
 	ScoreKeeper *net.ScoreKeeper
+	// Quarantine keeps the blocks and messages rejected by the pubsub validators
+	Quarantine *net.Quarantine
 
 	cfg   networkConfig
 	F3Cfg *vf3.Config
+
+	// protectedLk guards protectedPeers, the peers of the config the connection manager never trims
+	protectedLk    sync.Mutex
+	protectedPeers []peer.ID
 }
 
 // API create a new network implement
@@ -125,6 +133,7 @@ type networkConfig interface {
 
 // NewNetworkSubmodule creates a new network submodule.
 func NewNetworkSubmodule(ctx context.Context,
+	blockstore *blockstore.BlockstoreSubmodule,
 	chainStore *chain.Store,
 	messageStore *chain.MessageStore,
 	config networkConfig,
@@ -137,7 +146,7 @@ func NewNetworkSubmodule(ctx context.Context,
 	if !cfg.NetworkParams.DevNet {
 		networkName = "testnetnet"
 	} else {
-		networkName, err = retrieveNetworkName(ctx, config.GenesisCid(), cbor.NewCborStore(config.Repo().Datastore()))
+		networkName, err = retrieveNetworkName(ctx, config.GenesisCid(), cbor.NewCborStore(blockstore.Blockstore))
 		if err != nil {
 			return nil, err
 		}
@@ -158,7 +167,11 @@ func NewNetworkSubmodule(ctx context.Context,
 	}
 
 	swarmCfg := cfg.Swarm
-	cm, err := connectionManager(swarmCfg.ConnMgrLow, swarmCfg.ConnMgrHigh, time.Duration(swarmCfg.ConnMgrGrace), swarmCfg.ProtectedPeers, bootNodes)
+	protectedPeers, err := parseProtectedPeers(swarmCfg.ProtectedPeers)
+	if err != nil {
+		return nil, err
+	}
+	cm, err := connectionManager(swarmCfg.ConnMgrLow, swarmCfg.ConnMgrHigh, time.Duration(swarmCfg.ConnMgrGrace), protectedPeers, bootNodes)
 	if err != nil {
 		return nil, err
 	}
@@ -195,11 +208,11 @@ func NewNetworkSubmodule(ctx context.Context,
 	// set up bitswap
 	nwork := bsnet.NewFromIpfsHost(peerHost, router, bsnet.Prefix("/chain"))
 	bitswapOptions := []bitswap.Option{bitswap.ProvideEnabled(false)}
-	bswap := bitswap.New(ctx, nwork, config.Repo().Datastore(), bitswapOptions...)
+	bswap := bitswap.New(ctx, nwork, blockstore.Blockstore, bitswapOptions...)
 
 	// set up graphsync
 	graphsyncNetwork := gsnet.NewFromLibp2pHost(peerHost)
-	lsys := storeutil.LinkSystemForBlockstore(config.Repo().Datastore())
+	lsys := storeutil.LinkSystemForBlockstore(blockstore.Blockstore)
 	gsync := graphsyncimpl.New(ctx, graphsyncNetwork, lsys, graphsyncimpl.RejectAllRequestsByDefault())
 
 	// dataTransger
@@ -240,7 +253,9 @@ func NewNetworkSubmodule(ctx context.Context,
 		HelloHandler:     helloHandler,
 		cfg:              config,
 		ScoreKeeper:      sk,
+		Quarantine:       net.NewQuarantine(cfg.PubsubConfig.QuarantineSize),
 		F3Cfg:            f3Cfg,
+		protectedPeers:   protectedPeers,
 	}, nil
 }
 
@@ -437,24 +452,54 @@ func HashMsgId(m *pubsub_pb.Message) string {
 	return string(hash[:])
 }
 
-func connectionManager(low, high uint, grace time.Duration, protected []string, bootstrapNodes []peer.AddrInfo) (*connmgr.BasicConnMgr, error) {
+func connectionManager(low, high uint, grace time.Duration, protected []peer.ID, bootstrapNodes []peer.AddrInfo) (*connmgr.BasicConnMgr, error) {
 	cm, err := connmgr.NewConnManager(int(low), int(high), connmgr.WithGracePeriod(grace))
 	if err != nil {
 		return nil, err
 	}
 
+	for _, pid := range protected {
+		cm.Protect(pid, protectedPeerTag)
+	}
+
+	for _, inf := range bootstrapNodes {
+		cm.Protect(inf.ID, "bootstrap")
+	}
+
+	return cm, nil
+}
+
+const protectedPeerTag = "config-prot"
+
+func parseProtectedPeers(protected []string) ([]peer.ID, error) {
+	pids := make([]peer.ID, 0, len(protected))
 	for _, p := range protected {
 		pid, err := peer.Decode(p)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse peer ID in protected peers array: %w", err)
 		}
-
-		cm.Protect(pid, "config-prot")
+		pids = append(pids, pid)
 	}
+	return pids, nil
+}
 
-	for _, inf := range bootstrapNodes {
-		cm.Protect(inf.ID, "bootstrap")
+// SetProtectedPeers replaces the peers of the config the connection manager never trims, on a config reload.
+func (networkSubmodule *NetworkSubmodule) SetProtectedPeers(protected []string) error {
+	pids, err := parseProtectedPeers(protected)
+	if err != nil {
+		return err
 	}
 
-	return cm, nil
+	networkSubmodule.protectedLk.Lock()
+	defer networkSubmodule.protectedLk.Unlock()
+
+	cm := networkSubmodule.Host.ConnManager()
+	for _, pid := range networkSubmodule.protectedPeers {
+		cm.Unprotect(pid, protectedPeerTag)
+	}
+	for _, pid := range pids {
+		cm.Protect(pid, protectedPeerTag)
+	}
+	networkSubmodule.protectedPeers = pids
+	return nil
 }
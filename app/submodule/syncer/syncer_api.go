@@ -2,6 +2,7 @@ package syncer
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync/atomic"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/filecoin-project/venus/pkg/fvm"
 	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 	"github.com/filecoin-project/venus/venus-shared/types"
+	"github.com/hashicorp/go-multierror"
 	logging "github.com/ipfs/go-log/v2"
 )
 
@@ -118,19 +120,9 @@ func (sa *syncerAPI) SyncSubmitBlock(ctx context.Context, blk *types.BlockMsg) e
 	}
 
 	// TODO: should we have some sort of fast path to adding a local block?
-	bmsgs, err := chainModule.MessageStore.LoadUnsignedMessagesFromCids(ctx, blk.BlsMessages)
+	fb, err := sa.loadFullBlock(ctx, blk)
 	if err != nil {
-		return fmt.Errorf("failed to load bls messages: %v", err)
-	}
-	smsgs, err := chainModule.MessageStore.LoadSignedMessagesFromCids(ctx, blk.SecpkMessages)
-	if err != nil {
-		return fmt.Errorf("failed to load secpk message: %v", err)
-	}
-
-	fb := &types.FullBlock{
-		Header:       blk.Header,
-		BLSMessages:  bmsgs,
-		SECPMessages: smsgs,
+		return err
 	}
 
 	if err := sa.syncer.BlockValidator.ValidateMsgMeta(ctx, fb); err != nil {
@@ -161,6 +153,63 @@ func (sa *syncerAPI) SyncSubmitBlock(ctx context.Context, blk *types.BlockMsg) e
 	return nil
 }
 
+// SyncSubmitBlockChecked validates a block like SyncValidateBlockHeader, with its messages matching the header, and
+// submits it with SyncSubmitBlock when it is valid, unless dryRun is set.
+func (sa *syncerAPI) SyncSubmitBlockChecked(ctx context.Context, blk *types.BlockMsg, dryRun bool) (*types.BlockHeaderValidation, error) {
+	if blk == nil || blk.Header == nil {
+		return nil, fmt.Errorf("block is required")
+	}
+
+	fb, err := sa.loadFullBlock(ctx, blk)
+	if err != nil {
+		return nil, err
+	}
+	metaErr := sa.syncer.BlockValidator.ValidateMsgMeta(ctx, fb)
+
+	res, err := sa.SyncValidateBlockHeader(ctx, blk.Header)
+	if err != nil {
+		return nil, err
+	}
+	return submitCheckedBlock(res, metaErr, dryRun, func() error {
+		return sa.SyncSubmitBlock(ctx, blk)
+	})
+}
+
+// submitCheckedBlock adds the failed check of the messages of a block, if any, to the validation of its header and
+// submits the block with submit when it is valid, unless dryRun is set.
+func submitCheckedBlock(res *types.BlockHeaderValidation, metaErr error, dryRun bool, submit func() error) (*types.BlockHeaderValidation, error) {
+	if metaErr != nil {
+		res.Valid = false
+		res.Errors = append([]string{fmt.Sprintf("provided messages did not match block: %v", metaErr)}, res.Errors...)
+	}
+	if !res.Valid || dryRun {
+		return res, nil
+	}
+
+	if err := submit(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (sa *syncerAPI) loadFullBlock(ctx context.Context, blk *types.BlockMsg) (*types.FullBlock, error) {
+	messageStore := sa.syncer.ChainModule.MessageStore
+	bmsgs, err := messageStore.LoadUnsignedMessagesFromCids(ctx, blk.BlsMessages)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bls messages: %v", err)
+	}
+	smsgs, err := messageStore.LoadSignedMessagesFromCids(ctx, blk.SecpkMessages)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load secpk message: %v", err)
+	}
+
+	return &types.FullBlock{
+		Header:       blk.Header,
+		BLSMessages:  bmsgs,
+		SECPMessages: smsgs,
+	}, nil
+}
+
 // SyncState just compatible code lotus
 func (sa *syncerAPI) SyncState(ctx context.Context) (*types.SyncState, error) {
 	tracker := sa.syncer.ChainSyncManager.BlockProposer().SyncTracker()
@@ -218,3 +267,36 @@ func (sa *syncerAPI) SyncIncomingBlocks(ctx context.Context) (<-chan *types.Bloc
 func (sa *syncerAPI) SyncCheckpoint(ctx context.Context, tsk types.TipSetKey) error {
 	return sa.syncer.SyncProvider.SyncCheckpoint(ctx, tsk)
 }
+
+// SyncValidateBlockHeader checks a block header the way the syncer checks the blocks it receives, except the checks
+// of the messages.
+func (sa *syncerAPI) SyncValidateBlockHeader(ctx context.Context, bh *types.BlockHeader) (*types.BlockHeaderValidation, error) {
+	if bh == nil {
+		return nil, fmt.Errorf("block header is required")
+	}
+
+	return headerValidation(ctx, sa.syncer.BlockValidator.ValidateBlockHeader(ctx, bh))
+}
+
+// headerValidation returns the result of the validation of a block header failing with err, listing the error of each
+// failed check.
+func headerValidation(ctx context.Context, err error) (*types.BlockHeaderValidation, error) {
+	if err == nil {
+		return &types.BlockHeaderValidation{Valid: true}, nil
+	}
+	// the validation is not finished when the request is canceled
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	res := &types.BlockHeaderValidation{}
+	var merr *multierror.Error
+	if errors.As(err, &merr) {
+		for _, e := range merr.Errors {
+			res.Errors = append(res.Errors, e.Error())
+		}
+	} else {
+		res.Errors = []string{err.Error()}
+	}
+	return res, nil
+}
@@ -0,0 +1,97 @@
+package syncer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestHeaderValidation(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	res, err := headerValidation(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, &types.BlockHeaderValidation{Valid: true}, res)
+
+	res, err = headerValidation(ctx, errors.New("load parent tipset failed"))
+	require.NoError(t, err)
+	assert.Equal(t, &types.BlockHeaderValidation{Errors: []string{"load parent tipset failed"}}, res)
+
+	// each of the checks run concurrently is listed, even when the error is wrapped
+	merr := multierror.Append(nil, errors.New("invalid ticket"), errors.New("invalid signature"))
+	res, err = headerValidation(ctx, fmt.Errorf("validating block: %w", merr))
+	require.NoError(t, err)
+	assert.Equal(t, &types.BlockHeaderValidation{Errors: []string{"invalid ticket", "invalid signature"}}, res)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = headerValidation(canceled, merr)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestSubmitCheckedBlock(t *testing.T) {
+	tf.UnitTest(t)
+
+	var submitted int
+	submit := func() error {
+		submitted++
+		return nil
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		submitted = 0
+		res, err := submitCheckedBlock(&types.BlockHeaderValidation{Valid: true}, nil, false, submit)
+		require.NoError(t, err)
+		assert.True(t, res.Valid)
+		assert.Equal(t, 1, submitted)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		submitted = 0
+		res, err := submitCheckedBlock(&types.BlockHeaderValidation{Valid: true}, nil, true, submit)
+		require.NoError(t, err)
+		assert.True(t, res.Valid)
+		assert.Equal(t, 0, submitted)
+	})
+
+	t.Run("invalid header", func(t *testing.T) {
+		submitted = 0
+		res, err := submitCheckedBlock(&types.BlockHeaderValidation{Errors: []string{"invalid ticket"}}, nil, false, submit)
+		require.NoError(t, err)
+		assert.Equal(t, &types.BlockHeaderValidation{Errors: []string{"invalid ticket"}}, res)
+		assert.Equal(t, 0, submitted)
+	})
+
+	t.Run("messages not matching", func(t *testing.T) {
+		submitted = 0
+		res, err := submitCheckedBlock(&types.BlockHeaderValidation{Errors: []string{"invalid ticket"}},
+			errors.New("bls messages mismatch"), false, submit)
+		require.NoError(t, err)
+		assert.False(t, res.Valid)
+		assert.Equal(t, []string{"provided messages did not match block: bls messages mismatch", "invalid ticket"}, res.Errors)
+		assert.Equal(t, 0, submitted)
+
+		// a valid header does not make up for the messages
+		res, err = submitCheckedBlock(&types.BlockHeaderValidation{Valid: true}, errors.New("bls messages mismatch"), false, submit)
+		require.NoError(t, err)
+		assert.False(t, res.Valid)
+		assert.Len(t, res.Errors, 1)
+		assert.Equal(t, 0, submitted)
+	})
+
+	t.Run("submit error", func(t *testing.T) {
+		_, err := submitCheckedBlock(&types.BlockHeaderValidation{Valid: true}, nil, false, func() error {
+			return errors.New("publish failed")
+		})
+		assert.EqualError(t, err, "publish failed")
+	})
+}
@@ -15,8 +15,10 @@ import (
 	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 	cbor "github.com/ipfs/go-ipld-cbor"
 
+	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/ipfs/go-cid"
 	logging "github.com/ipfs/go-log/v2"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/pkg/errors"
 	"go.opencensus.io/trace"
 
@@ -26,7 +28,9 @@ import (
 	"github.com/filecoin-project/venus/pkg/chain"
 	"github.com/filecoin-project/venus/pkg/chainsync"
 	"github.com/filecoin-project/venus/pkg/chainsync/slashfilter"
+	syncTypes "github.com/filecoin-project/venus/pkg/chainsync/types"
 	"github.com/filecoin-project/venus/pkg/consensus"
+	"github.com/filecoin-project/venus/pkg/messagepool/journal"
 	"github.com/filecoin-project/venus/pkg/net/blocksub"
 	"github.com/filecoin-project/venus/pkg/net/pubsub"
 	"github.com/filecoin-project/venus/pkg/repo"
@@ -38,6 +42,14 @@ import (
 
 var log = logging.Logger("sync.module") // nolint: deadcode
 
+// SyncErrorEvt is the journal event of a failed sync of the chain of Head received from Sender.
+type SyncErrorEvt struct {
+	Head   types.TipSetKey
+	Height abi.ChainEpoch
+	Sender peer.ID
+	Error  string
+}
+
 // SyncerSubmodule enhances the node with chain syncing capabilities
 type SyncerSubmodule struct { //nolint
 	BlockstoreModule *blockstore.BlockstoreSubmodule
@@ -66,6 +78,7 @@ type syncerConfig interface {
 	ChainClock() clock.ChainEpochClock
 	Repo() repo.Repo
 	Verifier() ffiwrapper.Verifier
+	EventJournal() journal.Journal
 }
 
 // NewSyncerSubmodule creates a new chain submodule.
@@ -80,7 +93,7 @@ func NewSyncerSubmodule(ctx context.Context,
 	gasPriceSchedule := gas.NewPricesSchedule(config.Repo().Config().NetworkParams.ForkUpgradeParam)
 
 	tickets := consensus.NewTicketMachine(chn.ChainReader)
-	cborStore := cbor.NewCborStore(config.Repo().Datastore())
+	cborStore := cbor.NewCborStore(blockstore.Blockstore)
 	stateViewer := consensus.AsDefaultStateViewer(state.NewViewer(cborStore))
 
 	blkValid := consensus.NewBlockValidator(tickets,
@@ -97,6 +110,7 @@ func NewSyncerSubmodule(ctx context.Context,
 
 	// register block validation on pubsub
 	btv := blocksub.NewBlockTopicValidator(blkValid)
+	btv.SetRejectRecorder(network.Quarantine)
 	if err := network.Pubsub.RegisterTopicValidator(btv.Topic(network.NetworkName), btv.Validator(), btv.Opts()...); err != nil {
 		return nil, errors.Wrap(err, "failed to register block validator")
 	}
@@ -121,6 +135,13 @@ func NewSyncerSubmodule(ctx context.Context,
 		return nil, err
 	}
 
+	if execCfg := config.Repo().Config().Execution; execCfg != nil {
+		stmgr.EnableCallVMPool(execCfg.CallVMPoolSize)
+		if err := stmgr.EnableCallCache(execCfg.CallCacheSize); err != nil {
+			return nil, err
+		}
+	}
+
 	blkValid.Stmgr = stmgr
 	chn.Stmgr = stmgr
 	chn.Waiter.Stmgr = stmgr
@@ -141,6 +162,23 @@ func NewSyncerSubmodule(ctx context.Context,
 		}
 	}
 
+	j := config.EventJournal()
+	evtSyncError := j.RegisterEventType("sync", "error")
+	chainSyncManager.RegisterCallback(func(target *syncTypes.Target, err error) {
+		// the syncs canceled by the shutdown or a lower concurrency are not errors of the chain
+		if err == nil || errors.Is(err, context.Canceled) {
+			return
+		}
+		j.RecordEvent(evtSyncError, func() interface{} {
+			return SyncErrorEvt{
+				Head:   target.Head.Key(),
+				Height: target.Head.Height(),
+				Sender: target.Sender,
+				Error:  err.Error(),
+			}
+		})
+	})
+
 	if err := network.HelloHandler.Register(ctx, func(ci *types.ChainInfo) {
 		err := chainSyncManager.BlockProposer().SendHello(ci)
 		if err != nil {
@@ -1,15 +1,18 @@
 package wallet
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
 
 	"github.com/filecoin-project/venus/app/submodule/wallet/remotewallet"
 	"github.com/filecoin-project/venus/pkg/crypto"
+	"github.com/filecoin-project/venus/pkg/signaudit"
 	"github.com/filecoin-project/venus/pkg/wallet"
 	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 	"github.com/filecoin-project/venus/venus-shared/types"
@@ -17,6 +20,14 @@ import (
 
 var _ v1api.IWallet = &WalletAPI{}
 
+// maxSignBatch bounds the number of messages signed by a single WalletSignBatch call.
+const maxSignBatch = 10000
+
+// maxAuditLogPage bounds the number of records returned by a single WalletAuditLog call.
+const maxAuditLogPage = 1000
+
+var errAuditLogDisabled = errors.New("signing audit log disabled, enable with Wallet.EnableAuditLog")
+
 var ErrNoDefaultFromAddress = errors.New("unable to determine a default walletModule address")
 
 type WalletAPI struct { // nolint
@@ -89,6 +100,46 @@ func (walletAPI *WalletAPI) WalletNewAddress(ctx context.Context, protocol addre
 	return walletAPI.adapter.NewAddress(ctx, protocol)
 }
 
+// WalletNew generates a new address of the given key type
+func (walletAPI *WalletAPI) WalletNew(ctx context.Context, kt types.KeyType) (address.Address, error) {
+	var protocol address.Protocol
+	switch kt {
+	case types.KTSecp256k1Ledger:
+		w, err := walletAPI.localWallet()
+		if err != nil {
+			return address.Undef, err
+		}
+		return w.NewLedgerAddress(ctx)
+	case types.KTBLS:
+		protocol = address.BLS
+	case types.KTSecp256k1:
+		protocol = address.SECP256K1
+	case types.KTDelegated:
+		protocol = address.Delegated
+	default:
+		return address.Undef, fmt.Errorf("unsupported key type %s", kt)
+	}
+	return walletAPI.adapter.NewAddress(ctx, protocol)
+}
+
+// WalletNewDelegatedFromSecp creates the f410 address controlled by the private key of a secp256k1 address
+func (walletAPI *WalletAPI) WalletNewDelegatedFromSecp(ctx context.Context, addr address.Address) (address.Address, error) {
+	w, err := walletAPI.localWallet()
+	if err != nil {
+		return address.Undef, err
+	}
+	return w.NewDelegatedFromSecp(ctx, addr)
+}
+
+// localWallet returns the local wallet, operations touching key material are not
+// available through a remote wallet
+func (walletAPI *WalletAPI) localWallet() (*wallet.Wallet, error) {
+	if walletAPI.adapter != wallet.WalletIntersection(walletAPI.walletModule.Wallet) {
+		return nil, errors.New("not supported by a remote wallet")
+	}
+	return walletAPI.walletModule.Wallet, nil
+}
+
 // WalletImport adds a given set of KeyInfos to the walletModule
 func (walletAPI *WalletAPI) WalletImport(ctx context.Context, key *types.KeyInfo) (address.Address, error) {
 	addr, err := walletAPI.adapter.Import(ctx, remotewallet.ConvertLocalKeyInfo(key))
@@ -114,6 +165,29 @@ func (walletAPI *WalletAPI) WalletDelete(ctx context.Context, addr address.Addre
 
 // WalletSign signs the given bytes using the given address.
 func (walletAPI *WalletAPI) WalletSign(ctx context.Context, k address.Address, msg []byte, meta types.MsgMeta) (*crypto.Signature, error) {
+	return walletAPI.sign(ctx, "WalletSign", "", k, msg, meta)
+}
+
+// sign signs msg with k and records the signature to the audit log as made by method for purpose.
+func (walletAPI *WalletAPI) sign(ctx context.Context, method, purpose string, k address.Address, msg []byte, meta types.MsgMeta) (*crypto.Signature, error) {
+	sig, err := walletAPI.signBytes(ctx, k, msg, meta)
+
+	rec := types.SignAuditRecord{Method: method, MsgType: meta.Type}
+	var chainMsg types.Message
+	if meta.Type == types.MTChainMsg && chainMsg.UnmarshalCBOR(bytes.NewReader(meta.Extra)) == nil {
+		rec = signaudit.MessageRecord(method, &chainMsg)
+	}
+	rec.Signer = k
+	rec.Purpose = purpose
+	if err != nil {
+		rec.Error = err.Error()
+	}
+	walletAPI.walletModule.AuditLog.Record(ctx, rec)
+
+	return sig, err
+}
+
+func (walletAPI *WalletAPI) signBytes(ctx context.Context, k address.Address, msg []byte, meta types.MsgMeta) (*crypto.Signature, error) {
 	keyAddr, err := walletAPI.walletModule.Chain.Stmgr.ResolveToDeterministicAddress(ctx, k, nil)
 	if err != nil {
 		return nil, fmt.Errorf("ResolveTokeyAddress failed:%v", err)
@@ -123,6 +197,10 @@ func (walletAPI *WalletAPI) WalletSign(ctx context.Context, k address.Address, m
 
 // WalletSignMessage signs the given message using the given address.
 func (walletAPI *WalletAPI) WalletSignMessage(ctx context.Context, k address.Address, msg *types.Message) (*types.SignedMessage, error) {
+	return walletAPI.signMessage(ctx, "WalletSignMessage", "", k, msg)
+}
+
+func (walletAPI *WalletAPI) signMessage(ctx context.Context, method, purpose string, k address.Address, msg *types.Message) (*types.SignedMessage, error) {
 	sb, err := msg.SigningBytes(types.AddressProtocol2SignType(k.Protocol()))
 	if err != nil {
 		return nil, err
@@ -132,7 +210,7 @@ func (walletAPI *WalletAPI) WalletSignMessage(ctx context.Context, k address.Add
 		return nil, fmt.Errorf("serializing message: %w", err)
 	}
 
-	sign, err := walletAPI.WalletSign(ctx, k, sb, types.MsgMeta{Type: types.MTChainMsg, Extra: mb.RawData()})
+	sign, err := walletAPI.sign(ctx, method, purpose, k, sb, types.MsgMeta{Type: types.MTChainMsg, Extra: mb.RawData()})
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign message: %w", err)
 	}
@@ -143,6 +221,80 @@ func (walletAPI *WalletAPI) WalletSignMessage(ctx context.Context, k address.Add
 	}, nil
 }
 
+// WalletSignBatch signs each message of requests with its sender, the error of a message is returned in its result
+// and every signature is recorded to the signing audit log with the purpose of the request.
+func (walletAPI *WalletAPI) WalletSignBatch(ctx context.Context, requests []types.WalletSignRequest) ([]types.WalletSignResult, error) {
+	if len(requests) > maxSignBatch {
+		return nil, fmt.Errorf("too many messages to sign: %d, the maximum is %d", len(requests), maxSignBatch)
+	}
+	return signBatch(requests, func(req types.WalletSignRequest) (*types.SignedMessage, error) {
+		return walletAPI.signMessage(ctx, "WalletSignBatch", req.Purpose, req.Message.From, req.Message)
+	}), nil
+}
+
+// signBatch signs the messages of requests with sign, the results are in the order of requests.
+func signBatch(requests []types.WalletSignRequest, sign func(types.WalletSignRequest) (*types.SignedMessage, error)) []types.WalletSignResult {
+	out := make([]types.WalletSignResult, len(requests))
+	for i, req := range requests {
+		if req.Message == nil {
+			out[i].Error = "missing message"
+			continue
+		}
+		smsg, err := sign(req)
+		if err != nil {
+			out[i].Error = err.Error()
+		}
+		out[i].SignedMessage = smsg
+	}
+	return out
+}
+
+// WalletAuditLog returns up to limit records of the signing audit log matching filter, following the record after.
+func (walletAPI *WalletAPI) WalletAuditLog(ctx context.Context, filter types.SignAuditFilter, after uint64, limit int) (*types.SignAuditPage, error) {
+	if walletAPI.walletModule.AuditLog == nil {
+		return nil, errAuditLogDisabled
+	}
+	if limit <= 0 || limit > maxAuditLogPage {
+		return nil, fmt.Errorf("limit must be in [1, %d], got %d", maxAuditLogPage, limit)
+	}
+	return walletAPI.walletModule.AuditLog.Query(ctx, filter, after, limit)
+}
+
+// WalletSignEthTransaction signs an EIP-1559 transaction with the delegated key behind `from`
+// and returns the RLP encoded signed transaction, ready for eth_sendRawTransaction.
+func (walletAPI *WalletAPI) WalletSignEthTransaction(ctx context.Context, from types.EthAddress, tx *types.Eth1559TxArgs) (types.EthBytes, error) {
+	faddr, err := from.ToFilecoinAddress()
+	if err != nil {
+		return nil, err
+	}
+	if faddr.Protocol() != address.Delegated {
+		return nil, fmt.Errorf("%s is not a delegated address", from)
+	}
+
+	msg, err := tx.ToUnsignedFilecoinMessage(faddr)
+	if err != nil {
+		return nil, err
+	}
+	mb, err := msg.ToStorageBlock()
+	if err != nil {
+		return nil, fmt.Errorf("serializing message: %w", err)
+	}
+	sb, err := tx.ToRlpUnsignedMsg()
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := walletAPI.sign(ctx, "WalletSignEthTransaction", "", faddr, sb, types.MsgMeta{Type: types.MTChainMsg, Extra: mb.RawData()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	if err := tx.InitialiseSignature(*sig); err != nil {
+		return nil, err
+	}
+
+	return tx.ToRlpSignedMsg()
+}
+
 // LockWallet lock wallet
 func (walletAPI *WalletAPI) LockWallet(ctx context.Context) error {
 	return walletAPI.walletModule.Wallet.LockWallet(ctx)
@@ -167,3 +319,40 @@ func (walletAPI *WalletAPI) HasPassword(ctx context.Context) bool {
 func (walletAPI *WalletAPI) WalletState(ctx context.Context) int {
 	return walletAPI.walletModule.Wallet.WalletState(ctx)
 }
+
+// WalletLock lock wallet
+func (walletAPI *WalletAPI) WalletLock(ctx context.Context) error {
+	return walletAPI.walletModule.Wallet.LockWallet(ctx)
+}
+
+// WalletUnlock unlock wallet and lock it again after timeout
+func (walletAPI *WalletAPI) WalletUnlock(ctx context.Context, password []byte, timeout time.Duration) error {
+	switch {
+	case timeout == 0:
+		return walletAPI.walletModule.Wallet.UnLockWallet(ctx, password)
+	case timeout < 0:
+		return walletAPI.walletModule.Wallet.UnLockWalletFor(ctx, password, 0)
+	default:
+		return walletAPI.walletModule.Wallet.UnLockWalletFor(ctx, password, timeout)
+	}
+}
+
+// WalletSetPassword set wallet password
+func (walletAPI *WalletAPI) WalletSetPassword(ctx context.Context, password []byte) error {
+	return walletAPI.walletModule.Wallet.SetPassword(ctx, password)
+}
+
+// AddressBookSet names addr label in the address book
+func (walletAPI *WalletAPI) AddressBookSet(ctx context.Context, label string, addr address.Address) error {
+	return walletAPI.walletModule.AddressBook.Set(ctx, label, addr)
+}
+
+// AddressBookRemove removes label from the address book
+func (walletAPI *WalletAPI) AddressBookRemove(ctx context.Context, label string) error {
+	return walletAPI.walletModule.AddressBook.Remove(ctx, label)
+}
+
+// AddressBookList returns the addresses of the address book, by label
+func (walletAPI *WalletAPI) AddressBookList(ctx context.Context) (map[string]address.Address, error) {
+	return walletAPI.walletModule.AddressBook.List(), nil
+}
@@ -0,0 +1,65 @@
+package wallet
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestSignBatch(t *testing.T) {
+	tf.UnitTest(t)
+
+	newAddr := func(id uint64) address.Address {
+		addr, err := address.NewIDAddress(id)
+		require.NoError(t, err)
+		return addr
+	}
+	locked := newAddr(1001)
+
+	var requests []types.WalletSignRequest
+	for i := uint64(0); i < 6; i++ {
+		from := newAddr(1000)
+		if i%3 == 1 {
+			from = locked
+		}
+		requests = append(requests, types.WalletSignRequest{
+			Message: &types.Message{From: from, To: newAddr(2000), Nonce: i},
+			Purpose: "test",
+		})
+	}
+	requests[4].Message = nil
+
+	var signed []uint64
+	out := signBatch(requests, func(req types.WalletSignRequest) (*types.SignedMessage, error) {
+		assert.Equal(t, "test", req.Purpose)
+		signed = append(signed, req.Message.Nonce)
+		if req.Message.From == locked {
+			return nil, errors.New("key locked")
+		}
+		return &types.SignedMessage{Message: *req.Message}, nil
+	})
+
+	// the failed and missing messages don't stop the batch
+	assert.Equal(t, []uint64{0, 1, 2, 3, 5}, signed)
+	require.Len(t, out, len(requests))
+	for i, res := range out {
+		switch {
+		case i == 4:
+			assert.Equal(t, "missing message", res.Error)
+			assert.Nil(t, res.SignedMessage)
+		case i%3 == 1:
+			assert.Equal(t, "key locked", res.Error)
+			assert.Nil(t, res.SignedMessage)
+		default:
+			assert.Empty(t, res.Error)
+			require.NotNil(t, res.SignedMessage)
+			assert.Equal(t, uint64(i), res.SignedMessage.Message.Nonce)
+		}
+	}
+}
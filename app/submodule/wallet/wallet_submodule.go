@@ -3,19 +3,25 @@ package wallet
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"strings"
+	"time"
 
 	v0api "github.com/filecoin-project/venus/venus-shared/api/chain/v0"
 	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
 	logging "github.com/ipfs/go-log"
 	"github.com/pkg/errors"
 
 	"github.com/filecoin-project/venus/app/submodule/chain"
 	"github.com/filecoin-project/venus/app/submodule/config"
 	"github.com/filecoin-project/venus/app/submodule/wallet/remotewallet"
+	"github.com/filecoin-project/venus/pkg/addrbook"
 	pconfig "github.com/filecoin-project/venus/pkg/config"
 	"github.com/filecoin-project/venus/pkg/repo"
+	"github.com/filecoin-project/venus/pkg/signaudit"
 	"github.com/filecoin-project/venus/pkg/state"
 	"github.com/filecoin-project/venus/pkg/wallet"
 	"github.com/filecoin-project/venus/pkg/wallet/gateway"
@@ -32,11 +38,16 @@ type WalletSubmodule struct { // nolint
 	Signer        types.Signer
 	Config        *config.ConfigModule
 	WalletGateway *gateway.WalletGateway
+	AddressBook   *addrbook.Book
+	// AuditLog records the signatures and the pushed messages, it is nil unless Wallet.EnableAuditLog is set
+	AuditLog *signaudit.AuditLog
 }
 
 type walletRepo interface {
 	Config() *pconfig.Config
 	WalletDatastore() repo.Datastore
+	MetaDatastore() repo.Datastore
+	SqlitePath() (string, error)
 }
 
 // NewWalletSubmodule creates a new storage protocol submodule.
@@ -54,7 +65,18 @@ func NewWalletSubmodule(ctx context.Context,
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to set up walletModule backend")
 	}
-	fcWallet := wallet.New(backend)
+	backend.SetAutoLockTimeout(time.Duration(repo.Config().Wallet.AutoLockTimeout))
+	backends := []wallet.Backend{backend}
+	if repo.Config().Wallet.EnableLedger {
+		ledgerDs := namespace.Wrap(repo.MetaDatastore(), ds.NewKey("/ledger"))
+		ledgerBackend, err := wallet.NewLedgerBackend(ctx, ledgerDs, wallet.OpenLedger)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to set up ledger backend")
+		}
+		backends = append(backends, ledgerBackend)
+		log.Info("ledger wallet set up")
+	}
+	fcWallet := wallet.New(backends...)
 	headSigner := state.NewHeadSignView(chain.ChainReader)
 
 	var adapter wallet.WalletIntersection
@@ -71,6 +93,24 @@ func NewWalletSubmodule(ctx context.Context,
 		adapter = fcWallet
 	}
 
+	book, err := addrbook.New(ctx, repo.MetaDatastore())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load address book")
+	}
+
+	var auditLog *signaudit.AuditLog
+	if repo.Config().Wallet.EnableAuditLog {
+		sqlitePath, err := repo.SqlitePath()
+		if err != nil {
+			return nil, err
+		}
+		auditLog, err = signaudit.NewAuditLog(filepath.Join(sqlitePath, "sign_audit.db"), time.Duration(repo.Config().Wallet.AuditLogRetention))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to open the signing audit log")
+		}
+		log.Info("signing audit log set up")
+	}
+
 	var wg *gateway.WalletGateway
 	if len(repo.Config().Wallet.GatewayBacked) != 0 {
 		// GatewayBacked token:url
@@ -89,9 +129,18 @@ func NewWalletSubmodule(ctx context.Context,
 		adapter:       adapter,
 		Signer:        state.NewSigner(headSigner, fcWallet),
 		WalletGateway: wg,
+		AddressBook:   book,
+		AuditLog:      auditLog,
 	}, nil
 }
 
+// Stop closes the signing audit log.
+func (wallet *WalletSubmodule) Stop(ctx context.Context) {
+	if err := wallet.AuditLog.Close(); err != nil {
+		log.Warnf("error closing the signing audit log: %s", err)
+	}
+}
+
 // API create a new wallet api implement
 func (wallet *WalletSubmodule) API() v1api.IWallet {
 	return &WalletAPI{
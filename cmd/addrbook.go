@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/filecoin-project/go-address"
+	cmds "github.com/ipfs/go-ipfs-cmds"
+
+	"github.com/filecoin-project/venus/app/node"
+	"github.com/filecoin-project/venus/cmd/tablewriter"
+)
+
+var addrBookCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Manage the labels of addresses",
+		ShortDescription: `The text outputs of the commands show the label of the addresses in the address book
+instead of the raw addresses, pass --no-labels to print the raw addresses.`,
+	},
+	Subcommands: map[string]*cmds.Command{
+		"set":  addrBookSetCmd,
+		"rm":   addrBookRemoveCmd,
+		"list": addrBookListCmd,
+	},
+}
+
+var addrBookSetCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Name an address, replacing its previous label",
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("label", true, false, "label made of letters, digits, '.', '-' or '_'"),
+		cmds.StringArg("address", true, false, "address to label"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		addr, err := address.NewFromString(req.Arguments[1])
+		if err != nil {
+			return err
+		}
+		if err := env.(*node.Env).WalletAPI.AddressBookSet(req.Context, req.Arguments[0], addr); err != nil {
+			return err
+		}
+
+		return printOneString(re, fmt.Sprintf("%s is now labeled %s", addr, req.Arguments[0]))
+	},
+}
+
+var addrBookRemoveCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Remove a label from the address book",
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("label", true, false, "label to remove"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		return env.(*node.Env).WalletAPI.AddressBookRemove(req.Context, req.Arguments[0])
+	},
+}
+
+var addrBookListCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "List the labeled addresses",
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		book, err := env.(*node.Env).WalletAPI.AddressBookList(req.Context)
+		if err != nil {
+			return err
+		}
+		labels := make([]string, 0, len(book))
+		for label := range book {
+			labels = append(labels, label)
+		}
+		sort.Strings(labels)
+
+		tw := tablewriter.New(tablewriter.Col("Label"), tablewriter.Col("Address"))
+		for _, label := range labels {
+			tw.Write(map[string]interface{}{
+				"Label":   label,
+				"Address": book[label].String(),
+			})
+		}
+
+		buf := new(bytes.Buffer)
+		if err := tw.Flush(buf); err != nil {
+			return err
+		}
+		return re.Emit(buf)
+	},
+}
+
+var addressRegexp = regexp.MustCompile(`\b[ft][0-4][0-9a-z]+\b`)
+
+// withAddressLabels makes the text outputs of the command and its subcommands show the labels of
+// the address book instead of the raw addresses. The objects emitted are left untouched, the
+// clients decode them into their types.
+func withAddressLabels(c *cmds.Command) {
+	for _, sub := range c.Subcommands {
+		withAddressLabels(sub)
+	}
+	if c.Run == nil {
+		return
+	}
+
+	run := c.Run
+	c.Run = func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		if noLabels, _ := req.Options[OptionNoLabels].(bool); noLabels {
+			return run(req, re, env)
+		}
+		e, ok := env.(*node.Env)
+		if !ok || e.WalletAPI == nil {
+			return run(req, re, env)
+		}
+		book, err := e.WalletAPI.AddressBookList(req.Context)
+		if err != nil || len(book) == 0 {
+			return run(req, re, env)
+		}
+
+		labels := make(map[string]string, len(book))
+		for label, addr := range book {
+			labels[addr.String()] = label
+		}
+		return run(req, &labelEmitter{ResponseEmitter: re, labels: labels}, env)
+	}
+}
+
+type labelEmitter struct {
+	cmds.ResponseEmitter
+	labels map[string]string
+}
+
+func (le *labelEmitter) Emit(v interface{}) error {
+	switch value := v.(type) {
+	case *bytes.Buffer:
+		v = bytes.NewBufferString(le.replace(value.String()))
+	case string:
+		v = le.replace(value)
+	}
+	return le.ResponseEmitter.Emit(v)
+}
+
+func (le *labelEmitter) replace(s string) string {
+	return addressRegexp.ReplaceAllStringFunc(s, func(addr string) string {
+		if label, ok := le.labels[addr]; ok {
+			return label
+		}
+		return addr
+	})
+}
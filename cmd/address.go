@@ -36,17 +36,18 @@ var walletCmd = &cmds.Command{
 		Tagline: "Manage your filecoin wallets",
 	},
 	Subcommands: map[string]*cmds.Command{
-		"balance":      balanceCmd,
-		"import":       walletImportCmd,
-		"export":       walletExportCmd,
-		"ls":           addrsLsCmd,
-		"new":          addrsNewCmd,
-		"default":      defaultAddressCmd,
-		"delete":       addrsDeleteCmd,
-		"set-default":  setDefaultAddressCmd,
-		"lock":         lockedCmd,
-		"unlock":       unlockedCmd,
-		"set-password": setWalletPassword,
+		"balance":       balanceCmd,
+		"import":        walletImportCmd,
+		"export":        walletExportCmd,
+		"ls":            addrsLsCmd,
+		"new":           addrsNewCmd,
+		"new-delegated": addrsNewDelegatedCmd,
+		"default":       defaultAddressCmd,
+		"delete":        addrsDeleteCmd,
+		"set-default":   setDefaultAddressCmd,
+		"lock":          lockedCmd,
+		"unlock":        unlockedCmd,
+		"set-password":  setWalletPassword,
 	},
 }
 
@@ -61,10 +62,18 @@ type AddressLsResult struct {
 
 var addrsNewCmd = &cmds.Command{
 	Options: []cmds.Option{
-		cmds.StringOption("type", "The type of address to create: bls (default) or secp256k1 or delegated").WithDefault("bls"),
+		cmds.StringOption("type", "The type of address to create: bls (default) or secp256k1 or delegated or ledger").WithDefault("bls"),
 	},
 	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
 		protocolName := req.Options["type"].(string)
+		if protocolName == "ledger" {
+			addr, err := env.(*node.Env).WalletAPI.WalletNew(req.Context, types.KTSecp256k1Ledger)
+			if err != nil {
+				return err
+			}
+			return printOneString(re, addr.String())
+		}
+
 		var protocol address.Protocol
 		switch protocolName {
 		case "secp256k1":
@@ -93,6 +102,36 @@ var addrsNewCmd = &cmds.Command{
 	},
 }
 
+var addrsNewDelegatedCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Create the f410 address controlled by the private key of a secp256k1 address",
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("address", true, false, "secp256k1 wallet address"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		addr, err := address.NewFromString(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+
+		if env.(*node.Env).WalletAPI.WalletState(req.Context) == wallet.Lock {
+			return errWalletLocked
+		}
+
+		delegated, err := env.(*node.Env).WalletAPI.WalletNewDelegatedFromSecp(req.Context, addr)
+		if err != nil {
+			return err
+		}
+		ethAddr, err := types.EthAddressFromFilecoinAddress(delegated)
+		if err != nil {
+			return err
+		}
+
+		return printOneString(re, fmt.Sprintf("%s %s", delegated, ethAddr))
+	},
+}
+
 var addrsDeleteCmd = &cmds.Command{
 	Arguments: []cmds.Argument{
 		cmds.StringArg("address", true, false, "wallet address"),
@@ -409,7 +448,7 @@ var setWalletPassword = &cmds.Command{
 			return re.Emit("Do not enter an empty string")
 		}
 
-		err := env.(*node.Env).WalletAPI.SetPassword(req.Context, []byte(pw))
+		err := env.(*node.Env).WalletAPI.WalletSetPassword(req.Context, []byte(pw))
 		if err != nil {
 			return err
 		}
@@ -421,7 +460,7 @@ var setWalletPassword = &cmds.Command{
 
 var lockedCmd = &cmds.Command{
 	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
-		err := env.(*node.Env).WalletAPI.LockWallet(req.Context)
+		err := env.(*node.Env).WalletAPI.WalletLock(req.Context)
 		if err != nil {
 			return err
 		}
@@ -434,6 +473,9 @@ var unlockedCmd = &cmds.Command{
 	Arguments: []cmds.Argument{
 		cmds.StringArg("password", false, false, "Password to be locked"),
 	},
+	Options: []cmds.Option{
+		cmds.StringOption("timeout", "lock the wallet again after this duration, eg. 30m, 0 keeps it unlocked, defaults to the configured auto lock timeout"),
+	},
 	PreRun: func(req *cmds.Request, env cmds.Environment) error {
 		pw, err := gopass.GetPasswdPrompt("Password:", true, os.Stdin, os.Stdout)
 		if err != nil {
@@ -450,7 +492,18 @@ var unlockedCmd = &cmds.Command{
 
 		pw := req.Arguments[0]
 
-		err := env.(*node.Env).WalletAPI.UnLockWallet(req.Context, []byte(pw))
+		var timeout time.Duration
+		if str, ok := req.Options["timeout"].(string); ok {
+			var err error
+			if timeout, err = time.ParseDuration(str); err != nil {
+				return fmt.Errorf("invalid timeout: %w", err)
+			}
+			if timeout == 0 {
+				timeout = -1
+			}
+		}
+
+		err := env.(*node.Env).WalletAPI.WalletUnlock(req.Context, []byte(pw), timeout)
 		if err != nil {
 			return err
 		}
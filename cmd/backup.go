@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	cmds "github.com/ipfs/go-ipfs-cmds"
+
+	"github.com/filecoin-project/venus/app/node"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+var backupCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Back up the config, the keys, the local messages and the sqlite indexes of the node",
+		ShortDescription: `The backup is written by the daemon to a file in the directory named by its VENUS_BACKUP_BASE_PATH
+environment variable, a relative path being relative to it, the file must not exist. A new node restores it with
+'venus daemon --restore <file>', the chain data is not part of the backup.
+The keystore and the wallet are encrypted in the backup when --password is given.`,
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("path", true, false, "the file the backup is written to, in the backup directory of the daemon"),
+	},
+	Options: []cmds.Option{
+		cmds.StringOption(Password, "encrypt the keys of the backup with a password"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		path := req.Arguments[0]
+		password, _ := req.Options[Password].(string)
+
+		res, err := env.(*node.Env).CommonAPI.NodeBackup(req.Context, types.NodeBackupOpts{Path: path, Password: password})
+		if err != nil {
+			return err
+		}
+
+		buf := new(bytes.Buffer)
+		writer := NewSilentWriter(buf)
+		writer.Printf("wrote %s to %s in %s\n", humanize.IBytes(uint64(res.Bytes)), res.Path, res.Took.Round(time.Millisecond))
+		writer.Printf("keys: %d, meta entries: %d, sqlite databases: %s\n", res.Keys, res.MetaEntries, strings.Join(res.SqliteFiles, ", "))
+		if password == "" {
+			writer.Println("the keys are not encrypted, keep the backup safe")
+		}
+
+		return re.Emit(buf)
+	},
+}
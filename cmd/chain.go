@@ -8,9 +8,11 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
@@ -18,6 +20,7 @@ import (
 	cmds "github.com/ipfs/go-ipfs-cmds"
 
 	"github.com/filecoin-project/venus/app/node"
+	"github.com/filecoin-project/venus/cmd/tablewriter"
 	"github.com/filecoin-project/venus/pkg/constants"
 	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 	"github.com/filecoin-project/venus/venus-shared/types"
@@ -37,7 +40,11 @@ var chainCmd = &cmds.Command{
 		"get-receipts":       chainGetReceiptsCmd,
 		"disputer":           chainDisputeSetCmd,
 		"export":             chainExportCmd,
+		"prune":              chainPruneCmd,
+		"reorgs":             chainReorgsCmd,
+		"report-fault":       chainReportFaultCmd,
 		"read-obj":           chainReadObjCmd,
+		"stat-obj":           chainStatObjCmd,
 	},
 }
 
@@ -74,6 +81,43 @@ var chainReadObjCmd = &cmds.Command{
 	},
 }
 
+var chainStatObjCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Collect size and block count statistics of the objects reachable from an object",
+		ShortDescription: `Walks the objects reachable from the object, leaving out the ones reachable from --base,
+eg. to estimate the size of an export of a state root, or the growth of a state since another one.`,
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("objectCid", true, false, "object cid"),
+	},
+	Options: []cmds.Option{
+		cmds.StringOption("base", "ignore the objects reachable from this object"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		objectCid, err := cid.Parse(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+		base := cid.Undef
+		if b, _ := req.Options["base"].(string); len(b) > 0 {
+			if base, err = cid.Parse(b); err != nil {
+				return fmt.Errorf("parsing base: %w", err)
+			}
+		}
+
+		stat, err := env.(*node.Env).BlockStoreAPI.ChainStatObj(ReqContext(req.Context), objectCid, base)
+		if err != nil {
+			return err
+		}
+
+		buf := &bytes.Buffer{}
+		writer := NewSilentWriter(buf)
+		writer.Printf("Links: %d\n", stat.Links)
+		writer.Printf("Size: %s (%d)\n", humanize.IBytes(stat.Size), stat.Size)
+		return re.Emit(buf)
+	},
+}
+
 var chainHeadCmd = &cmds.Command{
 	Helptext: cmds.HelpText{
 		Tagline: "Get heaviest tipset info",
@@ -410,6 +454,182 @@ var chainExportCmd = &cmds.Command{
 	},
 }
 
+var chainPruneCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Remove the messages, receipts and state of the tipsets below a final height",
+		ShortDescription: `The objects still referenced by the tipsets at or above the height are kept, as well as
+the block headers. Use --dry-run to see the space which would be reclaimed.`,
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("height", true, false, "the first height whose messages, receipts and state are kept"),
+	},
+	Options: []cmds.Option{
+		cmds.BoolOption("dry-run", "only report the objects which would be removed").WithDefault(false),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		height, err := strconv.ParseInt(req.Arguments[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid height %s: %w", req.Arguments[0], err)
+		}
+		dryRun, _ := req.Options["dry-run"].(bool)
+
+		res, err := env.(*node.Env).ChainAPI.ChainPrune(req.Context, types.ChainPruneOpts{Height: abi.ChainEpoch(height), DryRun: dryRun})
+		if err != nil {
+			return err
+		}
+
+		buf := new(bytes.Buffer)
+		writer := NewSilentWriter(buf)
+		verb := "removed"
+		if res.DryRun {
+			verb = "would remove"
+		}
+		writer.Printf("walked %d tipsets below %d in %s, %s %d objects, %s\n", res.Tipsets, res.Height,
+			res.Took.Round(time.Second), verb, res.Objects, humanize.IBytes(uint64(res.Bytes)))
+
+		return re.Emit(buf)
+	},
+}
+
+var chainReorgsCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "List the reorgs of the head observed by the node, from the latest one",
+	},
+	Options: []cmds.Option{
+		cmds.Int64Option("min-depth", "only list the reorgs at least this many epochs deep").WithDefault(int64(0)),
+		cmds.StringOption("since", "only list the reorgs observed in this duration, eg. 24h"),
+		cmds.IntOption("limit", "the number of reorgs listed, 0 lists all of them").WithDefault(20),
+		cmds.BoolOption("verbose", "print the tipsets dropped and added by the reorgs").WithDefault(false),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		minDepth, _ := req.Options["min-depth"].(int64)
+		limit, _ := req.Options["limit"].(int)
+		verbose, _ := req.Options["verbose"].(bool)
+		var since time.Time
+		if str, ok := req.Options["since"].(string); ok {
+			d, err := time.ParseDuration(str)
+			if err != nil {
+				return fmt.Errorf("invalid since: %w", err)
+			}
+			since = time.Now().Add(-d)
+		}
+
+		reorgs, err := env.(*node.Env).ChainAPI.ChainListReorgs(req.Context, abi.ChainEpoch(minDepth), since, limit)
+		if err != nil {
+			return err
+		}
+
+		buf := new(bytes.Buffer)
+		if verbose {
+			writer := NewSilentWriter(buf)
+			for _, r := range reorgs {
+				writer.Printf("#%d at %s: depth %d, %d -> %d, common ancestor at %d\n", r.ID,
+					r.Time.Format(time.RFC3339), r.Depth, r.OldHeight, r.NewHeight, r.CommonHeight)
+				for _, tsk := range r.Dropped {
+					writer.Printf("\t- %s\n", tsk)
+				}
+				for _, tsk := range r.Added {
+					writer.Printf("\t+ %s\n", tsk)
+				}
+			}
+			return re.Emit(buf)
+		}
+
+		tw := tablewriter.New(
+			tablewriter.Col("ID"),
+			tablewriter.Col("Time"),
+			tablewriter.Col("Depth"),
+			tablewriter.Col("From"),
+			tablewriter.Col("To"),
+			tablewriter.Col("Common"),
+			tablewriter.Col("Dropped"),
+			tablewriter.Col("Added"),
+		)
+		for _, r := range reorgs {
+			tw.Write(map[string]interface{}{
+				"ID":      r.ID,
+				"Time":    r.Time.Format(time.RFC3339),
+				"Depth":   r.Depth,
+				"From":    r.OldHeight,
+				"To":      r.NewHeight,
+				"Common":  r.CommonHeight,
+				"Dropped": len(r.Dropped),
+				"Added":   len(r.Added),
+			})
+		}
+		if err := tw.Flush(buf); err != nil {
+			return err
+		}
+		return re.Emit(buf)
+	},
+}
+
+var chainReportFaultCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Check the consensus fault proven by two blocks and report it",
+		ShortDescription: `The blocks of a parent grinding fault are followed by the extra block proving it. The fault
+is checked against the head, the ReportConsensusFault message is only sent with --send.`,
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("block1", true, false, "cid of the first block"),
+		cmds.StringArg("block2", true, false, "cid of the second block"),
+		cmds.StringArg("extra", false, false, "cid of the extra block of a parent grinding fault"),
+	},
+	Options: []cmds.Option{
+		cmds.StringOption("from", "the account reporting the fault, the default wallet address when empty"),
+		cmds.StringOption("max-fee", "spend up to X FIL for the message"),
+		cmds.BoolOption("send", "send the message to the message pool").WithDefault(false),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		ctx := req.Context
+		chainAPI := env.(*node.Env).ChainAPI
+
+		blocks := make([]*types.BlockHeader, 3)
+		for i, arg := range req.Arguments {
+			c, err := cid.Decode(arg)
+			if err != nil {
+				return fmt.Errorf("invalid block cid %s: %w", arg, err)
+			}
+			if blocks[i], err = chainAPI.ChainGetBlock(ctx, c); err != nil {
+				return fmt.Errorf("getting block %s: %w", c, err)
+			}
+		}
+
+		from, _ := req.Options["from"].(string)
+		fromAddr, err := getSender(ctx, env.(*node.Env).WalletAPI, from)
+		if err != nil {
+			return err
+		}
+
+		report, err := chainAPI.StateConsensusFaultReport(ctx, fromAddr, blocks[0], blocks[1], blocks[2], types.EmptyTSK)
+		if err != nil {
+			return err
+		}
+
+		buf := new(bytes.Buffer)
+		writer := NewSilentWriter(buf)
+		writer.Printf("Miner:   %s\n", report.Miner)
+		writer.Printf("Fault:   %s at %d\n", report.Type, report.Epoch)
+		writer.Printf("Penalty: %s\n", types.FIL(report.Penalty))
+		writer.Printf("Reward:  %s\n", types.FIL(report.Reward))
+
+		if send, _ := req.Options["send"].(bool); send {
+			maxFee, _ := req.Options["max-fee"].(string)
+			spec, err := getMaxFee(maxFee)
+			if err != nil {
+				return err
+			}
+			smsg, err := env.(*node.Env).MessagePoolAPI.MpoolPushMessage(ctx, report.Message, spec)
+			if err != nil {
+				return err
+			}
+			writer.Printf("Message: %s\n", smsg.Cid())
+		}
+
+		return re.Emit(buf)
+	},
+}
+
 // LoadTipSet gets the tipset from the context, or the head from the API.
 //
 // It always gets the head from the API so commands use a consistent tipset even if time pases.
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	cmds "github.com/ipfs/go-ipfs-cmds"
+
+	"github.com/filecoin-project/venus/app/node"
+	"github.com/filecoin-project/venus/app/paths"
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/pkg/maintenance"
+)
+
+var configCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Validate, migrate and change the config",
+	},
+	Subcommands: map[string]*cmds.Command{
+		"set":      configSetCmd,
+		"validate": configValidateCmd,
+		"migrate":  configMigrateCmd,
+	},
+}
+
+var configSetCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Set a config field, applied without restart when it is reloadable",
+		ShortDescription: `The config is saved, the log levels, the event filter limits, the policy of the untrusted
+transactions and the protected peers are applied to the running node, the other fields on the next start.
+
+   eg) venus config set fevm.event.maxFilters 200
+       venus config set observability.logging.levels '{"chainsync": "warn"}'
+`,
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("key", true, false, "the dotted json path of the field"),
+		cmds.StringArg("value", true, false, "the json value of the field"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		res, err := env.(*node.Env).CommonAPI.SetConfig(req.Context, req.Arguments[0], req.Arguments[1])
+		if err != nil {
+			return err
+		}
+
+		buf := new(bytes.Buffer)
+		writer := NewSilentWriter(buf)
+		if len(res.Applied) > 0 {
+			writer.Printf("applied: %s\n", strings.Join(res.Applied, ", "))
+		}
+		if len(res.RequiresRestart) > 0 {
+			writer.Printf("saved, applied on restart: %s\n", strings.Join(res.RequiresRestart, ", "))
+		}
+		if len(res.Applied) == 0 && len(res.RequiresRestart) == 0 {
+			writer.Println("nothing changed")
+		}
+
+		return re.Emit(buf)
+	},
+}
+
+// configChecks are the checks of the config values done by the packages importing the config.
+var configChecks = []config.Check{
+	func(cfg *config.Config) error {
+		if cfg.API == nil {
+			return nil
+		}
+		_, err := chain.ParseTipSetSelector(cfg.API.TipSetSelector)
+		return err
+	},
+	func(cfg *config.Config) error {
+		if cfg.Maintenance == nil {
+			return nil
+		}
+		_, err := maintenance.New(cfg.Maintenance)
+		return err
+	},
+}
+
+func configFilePath(req *cmds.Request) (string, error) {
+	if p, ok := req.Options["file"].(string); ok && p != "" {
+		return p, nil
+	}
+	repoDir, _ := req.Options[OptionRepoDir].(string)
+	repoDir, err := paths.GetRepoPath(repoDir)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(repoDir, "config.json"), nil
+}
+
+func printConfigReport(writer *SilentWriter, report *config.ValidationReport) {
+	for _, e := range report.Errors {
+		writer.Printf("error: %s\n", e)
+	}
+	for _, d := range report.Deprecated {
+		writer.Printf("deprecated: %s\n", d)
+	}
+	for _, d := range report.Defaults {
+		writer.Printf("default: %s\n", d)
+	}
+	for _, m := range report.Migrated {
+		writer.Printf("migrated: %s\n", m)
+	}
+}
+
+var configValidateCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Check the config file against the current layout",
+		ShortDescription: `Reports the unknown keys and the invalid values as errors, the keys of the old layouts as
+deprecated, and the fields missing from the file which get their default. Runs without the daemon.`,
+	},
+	Options: []cmds.Option{
+		cmds.StringOption("file", "path of the config file, defaults to <repo>/config.json"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		path, err := configFilePath(req)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		_, report, err := config.Validate(data, configChecks...)
+		if err != nil {
+			return err
+		}
+
+		buf := new(bytes.Buffer)
+		writer := NewSilentWriter(buf)
+		printConfigReport(writer, report)
+		if !report.Valid() {
+			writer.Printf("%s is invalid\n", path)
+			if err := re.Emit(buf); err != nil {
+				return err
+			}
+			return fmt.Errorf("config has %d errors", len(report.Errors))
+		}
+		if len(report.Deprecated) > 0 || len(report.Defaults) > 0 {
+			writer.Printf("%s is valid, 'venus config migrate' upgrades it to the current layout\n", path)
+		} else {
+			writer.Printf("%s is valid\n", path)
+		}
+		return re.Emit(buf)
+	},
+}
+
+var configMigrateCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Upgrade the config file to the current layout",
+		ShortDescription: `Drops the sections of the old layouts, moves the deprecated keys to their replacement and
+writes the missing fields with their default, the previous file is kept next to it with a .bak suffix. The config
+must have no error. Stop the daemon first, it runs without it.`,
+	},
+	Options: []cmds.Option{
+		cmds.StringOption("file", "path of the config file, defaults to <repo>/config.json"),
+		cmds.BoolOption("dry-run", "only report the changes").WithDefault(false),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		path, err := configFilePath(req)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		buf := new(bytes.Buffer)
+		writer := NewSilentWriter(buf)
+		cfg, report, err := config.Migrate(data, configChecks...)
+		if err != nil {
+			if report != nil {
+				printConfigReport(writer, report)
+				_ = re.Emit(buf)
+			}
+			return err
+		}
+		printConfigReport(writer, report)
+		if len(report.Migrated) == 0 {
+			writer.Printf("%s is up to date\n", path)
+			return re.Emit(buf)
+		}
+		if dryRun, _ := req.Options["dry-run"].(bool); dryRun {
+			return re.Emit(buf)
+		}
+
+		backup := fmt.Sprintf("%s.%d.bak", path, time.Now().Unix())
+		if err := os.WriteFile(backup, data, 0o644); err != nil {
+			return err
+		}
+		tmp := path + ".migrate"
+		if err := cfg.WriteFile(tmp); err != nil {
+			return err
+		}
+		if err := os.Rename(tmp, path); err != nil {
+			return err
+		}
+		writer.Printf("migrated %s, the previous config is saved to %s\n", path, backup)
+		return re.Emit(buf)
+	},
+}
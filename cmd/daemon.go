@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -9,8 +10,10 @@ import (
 	"github.com/filecoin-project/venus/fixtures/networks"
 	"github.com/filecoin-project/venus/venus-shared/actors"
 	types2 "github.com/filecoin-project/venus/venus-shared/actors/types"
+	vlogging "github.com/filecoin-project/venus/venus-shared/logging"
 	"github.com/filecoin-project/venus/venus-shared/utils"
 
+	"github.com/filecoin-project/venus/pkg/backup"
 	"github.com/filecoin-project/venus/pkg/chainsync/slashfilter"
 	"github.com/filecoin-project/venus/pkg/util/ulimit"
 
@@ -59,6 +62,12 @@ var daemonCmd = &cmds.Command{
 		cmds.StringOption(Password, "set wallet password"),
 		cmds.StringOption(Profile, "specify type of node, eg. bootstrapper"),
 		cmds.StringOption(WalletGateway, "set sophon gateway url and token, eg. token:url"),
+		cmds.StringOption(LogFormat, "output format of the logs, one of color, nocolor and json"),
+		cmds.BoolOption(BootstrapDevnet, "initialize a local 2k chain with funded accounts and a miner with fake sectors mined by this node"),
+		cmds.IntOption(DevnetAccounts, "number of funded accounts created by --bootstrap-devnet").WithDefault(3),
+		cmds.StringOption(Restore, "restore a backup made by 'venus backup' when initializing the repo, the network of the backup must be the one of --network"),
+		cmds.StringOption(RestorePassword, "password of the keys of the backup restored by --restore"),
+		cmds.StringOption(ConfigProfile, "apply a profile of the config, the profile sets the network of a new repo and must match the network of an existing one"),
 	},
 	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
 		if limit, _ := req.Options[ULimit].(bool); limit {
@@ -119,6 +128,13 @@ var daemonCmd = &cmds.Command{
 			if err = initRun(req, repoDir); err != nil {
 				return err
 			}
+		} else {
+			if devnet, _ := req.Options[BootstrapDevnet].(bool); devnet {
+				log.Warnf("repo %s already exists, --%s is ignored", repoDir, BootstrapDevnet)
+			}
+			if restorePath, _ := req.Options[Restore].(string); len(restorePath) != 0 {
+				log.Warnf("repo %s already exists, --%s is ignored", repoDir, Restore)
+			}
 		}
 
 		return daemonRun(req, re)
@@ -126,7 +142,8 @@ var daemonCmd = &cmds.Command{
 }
 
 func initRun(req *cmds.Request, repoDir string) error {
-	rep, err := getRepo(repoDir)
+	configProfile, _ := req.Options[ConfigProfile].(string)
+	rep, err := getRepo(repoDir, configProfile)
 	if err != nil {
 		return err
 	}
@@ -137,11 +154,31 @@ func initRun(req *cmds.Request, repoDir string) error {
 	var genesisFunc genesis.InitFunc
 	cfg := rep.Config()
 	network, _ := req.Options[Network].(string)
+	if len(configProfile) > 0 {
+		profileNetwork, err := cfg.ProfileNetwork(configProfile)
+		if err != nil {
+			return err
+		}
+		if len(profileNetwork) > 0 {
+			log.Infof("using network %s of config profile %s", profileNetwork, configProfile)
+			network = profileNetwork
+		}
+	}
+	devnet, _ := req.Options[BootstrapDevnet].(bool)
+	if devnet {
+		network = "2k"
+	}
 	if err := networks.SetConfigFromOptions(cfg, network); err != nil {
 		return fmt.Errorf("setting config: %v", err)
 	}
 	// genesis node
-	if mkGen, ok := req.Options[makeGenFlag].(string); ok {
+	if devnet {
+		accounts, _ := req.Options[DevnetAccounts].(int)
+		genesisFunc, err = bootstrapDevnet(req.Context, rep, accounts)
+		if err != nil {
+			return err
+		}
+	} else if mkGen, ok := req.Options[makeGenFlag].(string); ok {
 		preTp := req.Options[preTemplateFlag]
 		if preTp == nil {
 			return fmt.Errorf("must also pass file with genesis template to `--%s`", preTemplateFlag)
@@ -183,6 +220,15 @@ func initRun(req *cmds.Request, repoDir string) error {
 		return err
 	}
 
+	// restore argument only work when init, before the import which does not touch the restored data
+	if restorePath, _ := req.Options[Restore].(string); len(restorePath) != 0 {
+		password, _ := req.Options[RestorePassword].(string)
+		if err := restoreBackup(req.Context, rep, restorePath, password); err != nil {
+			log.Errorf("failed to restore backup, path: %s, error: %s", restorePath, err.Error())
+			return err
+		}
+	}
+
 	// import snapshot argument only work when init
 	importPath, _ := req.Options[ImportSnapshot].(string)
 	if len(importPath) != 0 {
@@ -198,12 +244,25 @@ func initRun(req *cmds.Request, repoDir string) error {
 
 func daemonRun(req *cmds.Request, re cmds.ResponseEmitter) error {
 	repoDir, _ := req.Options[OptionRepoDir].(string)
-	rep, err := getRepo(repoDir)
+	configProfile, _ := req.Options[ConfigProfile].(string)
+	rep, err := getRepo(repoDir, configProfile)
 	if err != nil {
 		return err
 	}
 
 	config := rep.Config()
+	if len(configProfile) > 0 {
+		if err := checkProfileNetwork(config, configProfile); err != nil {
+			return err
+		}
+	}
+	if logFormat, ok := req.Options[LogFormat].(string); ok && len(logFormat) > 0 {
+		config.Observability.Logging.Format = logFormat
+	}
+	if err := setupLogging(config.Observability.Logging); err != nil {
+		return err
+	}
+
 	if err := networks.SetConfigFromNetworkType(config, config.NetworkParams.NetworkType); err != nil {
 		return fmt.Errorf("set config failed %v %v", config.NetworkParams.NetworkType, err)
 	}
@@ -263,6 +322,9 @@ func daemonRun(req *cmds.Request, re cmds.ResponseEmitter) error {
 	if err != nil {
 		return err
 	}
+	if len(configProfile) > 0 {
+		opts = append(opts, node.ConfigProfile(configProfile))
+	}
 
 	if offlineMode, ok := req.Options[OfflineMode].(bool); ok { // nolint
 		opts = append(opts, node.OfflineMode(offlineMode))
@@ -274,6 +336,12 @@ func daemonRun(req *cmds.Request, re cmds.ResponseEmitter) error {
 
 	if password, _ := req.Options[Password].(string); len(password) > 0 {
 		opts = append(opts, node.SetWalletPassword([]byte(password)))
+	} else if config.Devnet != nil && config.Devnet.Enable {
+		password, err := devnetPassword(rep)
+		if err != nil {
+			return fmt.Errorf("read devnet wallet password: %w", err)
+		}
+		opts = append(opts, node.SetWalletPassword(password))
 	}
 
 	journal, err := journal.NewZapJournal(rep.JournalPath()) // nolint
@@ -332,7 +400,24 @@ func daemonRun(req *cmds.Request, re cmds.ResponseEmitter) error {
 	return fcn.RunRPCAndWait(req.Context, RootCmdDaemon, ready)
 }
 
-func getRepo(repoDir string) (repo.Repo, error) {
+// setupLogging applies the configured log format and subsystem levels, the colored
+// output is the default of go-log and is left untouched.
+func setupLogging(cfg *config.LoggingConfig) error {
+	if cfg.Format != "" && cfg.Format != vlogging.FormatColor {
+		if err := vlogging.SetupFormat(cfg.Format, os.Stderr); err != nil {
+			return err
+		}
+	}
+	for subsystem, level := range cfg.Levels {
+		if err := logging.SetLogLevel(subsystem, level); err != nil {
+			return fmt.Errorf("set log level of %s: %w", subsystem, err)
+		}
+	}
+	return nil
+}
+
+// getRepo opens the repo, applying the config profile when it is not empty.
+func getRepo(repoDir, profile string) (repo.Repo, error) {
 	repoDir, err := paths.GetRepoPath(repoDir)
 	if err != nil {
 		return nil, err
@@ -340,5 +425,47 @@ func getRepo(repoDir string) (repo.Repo, error) {
 	if err = migration.TryToMigrate(repoDir); err != nil {
 		return nil, err
 	}
-	return repo.OpenFSRepo(repoDir, repo.LatestVersion)
+	rep, err := repo.OpenFSRepo(repoDir, repo.LatestVersion)
+	if err != nil {
+		return nil, err
+	}
+	if len(profile) > 0 {
+		if err := rep.UseConfigProfile(profile); err != nil {
+			_ = rep.Close()
+			return nil, err
+		}
+	}
+	return rep, nil
+}
+
+// checkProfileNetwork checks the network of the config profile is the one the repo was initialized with.
+func checkProfileNetwork(cfg *config.Config, profile string) error {
+	profileNetwork, err := cfg.ProfileNetwork(profile)
+	if err != nil || len(profileNetwork) == 0 {
+		return err
+	}
+	networkType, err := networks.GetNetworkFromName(profileNetwork)
+	if err != nil {
+		return fmt.Errorf("network of config profile %s: %w", profile, err)
+	}
+	if networkType != cfg.NetworkParams.NetworkType {
+		return fmt.Errorf("config profile %s is for network %s, the repo is of network type %d", profile, profileNetwork,
+			cfg.NetworkParams.NetworkType)
+	}
+	return nil
+}
+
+func restoreBackup(ctx context.Context, rep repo.Repo, path, password string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close() // nolint: errcheck
+
+	res, err := backup.Restore(ctx, rep, f, password)
+	if err != nil {
+		return err
+	}
+	log.Infof("restored backup %s, keys: %d, meta entries: %d, sqlite databases: %v", path, res.Keys, res.MetaEntries, res.SqliteFiles)
+	return nil
 }
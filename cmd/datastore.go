@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	cmds "github.com/ipfs/go-ipfs-cmds"
+
+	"github.com/filecoin-project/venus/app/paths"
+	"github.com/filecoin-project/venus/pkg/repo"
+)
+
+var datastoreCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Manage the datastores of the repo offline",
+		ShortDescription: `
+The commands operate on the repo directly, stop the daemon before running migrate.`,
+	},
+	Subcommands: map[string]*cmds.Command{
+		"migrate": datastoreMigrateCmd,
+	},
+}
+
+var datastoreMigrateCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Copy the blockstore and the datastores to another datastore type",
+		ShortDescription: `
+Copies every store of the repo to the given datastore type and switches the config
+to it. The previous stores are kept, remove them once the node runs fine.`,
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("type", true, false, "datastore type to migrate to"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		to := req.Arguments[0]
+		if _, err := repo.GetDatastoreDriver(to); err != nil {
+			return fmt.Errorf("%w, available types: %s", err, strings.Join(repo.DatastoreDrivers(), ", "))
+		}
+		repoDir, _ := req.Options[OptionRepoDir].(string)
+		repoDir, err := paths.GetRepoPath(repoDir)
+		if err != nil {
+			return err
+		}
+
+		err = repo.MigrateDatastore(req.Context, repoDir, to, func(store string, copied int) {
+			fmt.Fprintf(os.Stderr, "%s: copied %d entries\n", store, copied)
+		})
+		if err != nil {
+			return err
+		}
+		return printOneString(re, fmt.Sprintf("%s migrated to %s", repoDir, to))
+	},
+}
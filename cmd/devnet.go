@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+
+	"github.com/filecoin-project/venus/app/node"
+	"github.com/filecoin-project/venus/pkg/constants"
+	"github.com/filecoin-project/venus/pkg/gen"
+	gengenesis "github.com/filecoin-project/venus/pkg/gen/genesis"
+	"github.com/filecoin-project/venus/pkg/genesis"
+	"github.com/filecoin-project/venus/pkg/repo"
+	"github.com/filecoin-project/venus/pkg/wallet"
+	"github.com/filecoin-project/venus/pkg/wallet/key"
+	"github.com/filecoin-project/venus/tools/seed"
+	"github.com/filecoin-project/venus/venus-shared/actors"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/miner"
+	"github.com/filecoin-project/venus/venus-shared/utils"
+)
+
+const (
+	// devnetDir is the directory of the repo holding the genesis, keys and sectors of a devnet
+	devnetDir          = "devnet"
+	devnetPasswordFile = "password"
+	devnetSectors      = 2
+)
+
+var devnetAccountBalance = big.Mul(big.NewInt(50_000_000), big.NewInt(int64(constants.FilecoinPrecision)))
+
+// bootstrapDevnet sets up the config of a new repo for a local chain mined by a single miner with
+// fake pre-sealed sectors, and returns the function generating its genesis with the funded accounts.
+// The keys of the accounts and of the miner worker are imported in the wallet and written to the
+// devnet directory of the repo, the wallet password is generated and saved there too.
+func bootstrapDevnet(ctx context.Context, rep repo.Repo, accounts int) (genesis.InitFunc, error) {
+	cfg := rep.Config()
+	repoPath, err := rep.Path()
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(repoPath, devnetDir)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+
+	nv := cfg.NetworkParams.GenesisNetworkVersion
+	spt, err := miner.SealProofTypeFromSectorSize(constants.DevSectorSize, nv, miner.SealProofVariant_Standard)
+	if err != nil {
+		return nil, err
+	}
+	maddr, err := address.NewIDAddress(gengenesis.MinerStart)
+	if err != nil {
+		return nil, err
+	}
+	sectorDir := filepath.Join(dir, "sectors")
+	gm, workerKey, err := seed.PreSeal(maddr, spt, 0, devnetSectors, sectorDir, []byte("venus devnet"), nil, true)
+	if err != nil {
+		return nil, fmt.Errorf("pre-sealing sectors: %w", err)
+	}
+	if err := seed.WriteGenesisMiner(maddr, sectorDir, gm, workerKey); err != nil {
+		return nil, err
+	}
+
+	template := gengenesis.NewTemplate("localnet-"+uuid.New().String(), nv, 0)
+	if err := template.SetVerifregRoot(gen.DefaultVerifregRootkeyActor); err != nil {
+		return nil, err
+	}
+	if err := template.SetRemainder(gen.DefaultRemainderAccountActor); err != nil {
+		return nil, err
+	}
+	if err := template.AddMiner(*gm); err != nil {
+		return nil, err
+	}
+	if err := template.AddAccount(gm.Owner, devnetAccountBalance); err != nil {
+		return nil, err
+	}
+
+	keys := []*key.KeyInfo{workerKey}
+	for i := 0; i < accounts; i++ {
+		ki, err := key.NewSecpKeyFromSeed(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		addr, err := ki.Address()
+		if err != nil {
+			return nil, err
+		}
+		if err := writeDevnetKey(dir, addr, &ki); err != nil {
+			return nil, err
+		}
+		if err := template.AddAccount(addr, devnetAccountBalance); err != nil {
+			return nil, err
+		}
+		keys = append(keys, &ki)
+		if i == 0 {
+			cfg.Wallet.DefaultAddress = addr
+		}
+	}
+
+	templateFile := filepath.Join(dir, "genesis.json")
+	b, err := template.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(templateFile, b, 0o644); err != nil {
+		return nil, err
+	}
+
+	password := make([]byte, 16)
+	if _, err := rand.Read(password); err != nil {
+		return nil, err
+	}
+	password = []byte(hex.EncodeToString(password))
+	if err := os.WriteFile(filepath.Join(dir, devnetPasswordFile), password, 0o600); err != nil {
+		return nil, err
+	}
+	backend, err := wallet.NewDSBackend(ctx, rep.WalletDatastore(), cfg.Wallet.PassphraseConfig, password)
+	if err != nil {
+		return nil, err
+	}
+	for _, ki := range keys {
+		if err := backend.ImportKey(ctx, ki); err != nil {
+			return nil, fmt.Errorf("importing key: %w", err)
+		}
+	}
+
+	cfg.Devnet.Enable = true
+	cfg.Mining.Enable = true
+	cfg.Mining.Miners = []address.Address{maddr}
+
+	node.SetNetParams(cfg.NetworkParams)
+	if err := actors.SetNetworkBundle(int(cfg.NetworkParams.NetworkType)); err != nil {
+		return nil, err
+	}
+	utils.ReloadMethodsMap()
+
+	return genesis.MakeGenesis(ctx, rep, filepath.Join(dir, "genesis.car"), templateFile, cfg.NetworkParams.ForkUpgradeParam), nil
+}
+
+// writeDevnetKey writes the key in the format of `venus wallet import`.
+func writeDevnetKey(dir string, addr address.Address, ki *key.KeyInfo) error {
+	b, err := json.Marshal(ki)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, addr.String()+".key"), []byte(hex.EncodeToString(b)), 0o600)
+}
+
+// devnetPassword reads the wallet password generated when the devnet was bootstrapped.
+func devnetPassword(rep repo.Repo) ([]byte, error) {
+	repoPath, err := rep.Path()
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(filepath.Join(repoPath, devnetDir, devnetPasswordFile))
+}
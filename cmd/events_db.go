@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+
+	cmds "github.com/ipfs/go-ipfs-cmds"
+
+	"github.com/filecoin-project/venus/app/paths"
+	"github.com/filecoin-project/venus/pkg/events/filter"
+)
+
+var eventsDBCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Manage the event index database offline",
+		ShortDescription: `
+The commands operate on the events.db of the repo directly, stop the daemon before
+running migrate.`,
+	},
+	Options: []cmds.Option{
+		cmds.StringOption("db", "path of the event index, defaults to <repo>/sqlite/events.db"),
+	},
+	Subcommands: map[string]*cmds.Command{
+		"status":  eventsDBStatusCmd,
+		"migrate": eventsDBMigrateCmd,
+		"verify":  eventsDBVerifyCmd,
+	},
+}
+
+func eventsDBPath(req *cmds.Request) (string, error) {
+	if p, ok := req.Options["db"].(string); ok && p != "" {
+		return p, nil
+	}
+	repoDir, _ := req.Options[OptionRepoDir].(string)
+	repoDir, err := paths.GetRepoPath(repoDir)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(repoDir, "sqlite", filter.DefaultDBFilename), nil
+}
+
+var eventsDBStatusCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Print the schema version of the event index",
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		path, err := eventsDBPath(req)
+		if err != nil {
+			return err
+		}
+		status, err := filter.VerifyEventIndex(req.Context, path)
+		if err != nil {
+			return err
+		}
+
+		state := "up to date"
+		if status.Version < status.Latest {
+			state = "needs migration"
+		}
+		return printOneString(re, fmt.Sprintf("%s: version %d, latest %d, %s", path, status.Version, status.Latest, state))
+	},
+}
+
+var eventsDBMigrateCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Upgrade the event index to the latest schema version",
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		path, err := eventsDBPath(req)
+		if err != nil {
+			return err
+		}
+		from, err := filter.MigrateEventIndex(req.Context, path)
+		if err != nil {
+			return err
+		}
+		if from == filter.EventIndexSchemaVersion {
+			return printOneString(re, fmt.Sprintf("%s is already at version %d", path, from))
+		}
+		return printOneString(re, fmt.Sprintf("migrated %s from version %d to %d", path, from, filter.EventIndexSchemaVersion))
+	},
+}
+
+var eventsDBVerifyCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Check the schema and the integrity of the event index",
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		path, err := eventsDBPath(req)
+		if err != nil {
+			return err
+		}
+		status, err := filter.VerifyEventIndex(req.Context, path)
+		if err != nil {
+			return err
+		}
+		if len(status.Problems) == 0 {
+			return printOneString(re, fmt.Sprintf("%s: version %d, ok", path, status.Version))
+		}
+
+		buf := &bytes.Buffer{}
+		fmt.Fprintf(buf, "%s: version %d, %d problem(s)\n", path, status.Version, len(status.Problems))
+		for _, p := range status.Problems {
+			fmt.Fprintf(buf, "  %s\n", p)
+		}
+		if err := re.Emit(buf); err != nil {
+			return err
+		}
+		return fmt.Errorf("event index verification failed")
+	},
+}
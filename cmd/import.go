@@ -89,7 +89,11 @@ func importChain(ctx context.Context, r repo.Repo, fname string) error {
 	}
 
 	bar.Start()
-	tip, genesisBlk, err := chainStore.Import(ctx, ir)
+	tip, genesisBlk, err := chainStore.Import(ctx, ir, chain.ImportOptions{
+		Progress: func(blocks, _ int64) {
+			bar.Postfix(fmt.Sprintf(" %d blocks", blocks))
+		},
+	})
 	if err != nil {
 		return fmt.Errorf("importing chain failed: %s", err)
 	}
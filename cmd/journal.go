@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	cmds "github.com/ipfs/go-ipfs-cmds"
+
+	"github.com/filecoin-project/venus/app/node"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+var journalCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Print the latest lifecycle events recorded by the journal of the node",
+		ShortDescription: `The events are printed as ndjson from the oldest one, eg. the head changes (chain:head_change),
+the sync errors (sync:error), the messages added to and removed from the message pool (mpool:add,
+mpool:remove) and the starts and stops of the api (api:start, api:stop). Only the events kept in memory
+by the daemon, up to journal.memoryEvents of the config, are printed, the files of the journal
+directory of the repo hold all of them.`,
+	},
+	Options: []cmds.Option{
+		cmds.StringsOption("type", "only print the events of these system or system:event types, eg. chain or sync:error"),
+		cmds.StringOption("since", "only print the events recorded in this duration, eg. 1h"),
+		cmds.IntOption("limit", "only print the latest events, 0 prints all of them").WithDefault(0),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		filter := types.JournalFilter{}
+		filter.Types, _ = req.Options["type"].([]string)
+		filter.Limit, _ = req.Options["limit"].(int)
+		if str, ok := req.Options["since"].(string); ok {
+			d, err := time.ParseDuration(str)
+			if err != nil {
+				return fmt.Errorf("invalid since: %w", err)
+			}
+			filter.Since = time.Now().Add(-d)
+		}
+
+		evts, err := env.(*node.Env).CommonAPI.JournalEvents(req.Context, filter)
+		if err != nil {
+			return err
+		}
+
+		buf := new(bytes.Buffer)
+		enc := json.NewEncoder(buf)
+		for _, evt := range evts {
+			if err := enc.Encode(evt); err != nil {
+				return err
+			}
+		}
+		return re.Emit(buf)
+	},
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"strings"
 
 	cmds "github.com/ipfs/go-ipfs-cmds"
@@ -30,6 +31,9 @@ const (
 
 	OptionLegacyRepoDir = "repodir"
 
+	// OptionNoLabels prints the raw addresses instead of their labels in the address book
+	OptionNoLabels = "no-labels"
+
 	// OptionSectorDir is the name of the option for specifying the directory into which staged and sealed sectors will be written.
 	// OptionSectorDir = "sectordir"
 
@@ -84,6 +88,24 @@ const (
 	BootstrapPeers = "bootstrap-peers"
 
 	Profile = "profile"
+
+	// LogFormat sets the output format of the daemon logs, overrides the config
+	LogFormat = "log-format"
+
+	// BootstrapDevnet initializes the repo for a local chain mined by the node itself
+	BootstrapDevnet = "bootstrap-devnet"
+
+	// DevnetAccounts is the number of funded accounts created in the genesis of the devnet
+	DevnetAccounts = "devnet-accounts"
+
+	// Restore restores a backup of the node metadata in the new repo
+	Restore = "restore"
+
+	// RestorePassword decrypts the keys of the restored backup
+	RestorePassword = "restore-password"
+
+	// ConfigProfile selects a profile of the config, which overrides a part of it, eg. for a network
+	ConfigProfile = "config-profile"
 )
 
 func init() {
@@ -105,7 +127,10 @@ var RootCmd = &cmds.Command{
 START RUNNING VENUS
   daemon                 - Start a venus daemon process
   wallet                 - Manage wallet
+  addrbook               - Manage the labels of addresses
   info                   - Print node info
+  backup                 - Back up the config, keys, local messages and sqlite indexes
+  config                 - Validate, migrate and change the config
 
 VIEW DATA STRUCTURES
   chain                  - Inspect the filecoin blockchain
@@ -140,9 +165,12 @@ F3 COMMANDS
 TOOL COMMANDS
   inspect                - Show info about the venus node
   log                    - Interact with the daemon event log output
+  journal                - Print the lifecycle events recorded by the journal of the node
   version                - Show venus version information
   seed                   - Seal sectors for genesis miner
   fetch                  - Fetch proving parameters
+  events-db              - Manage the event index database offline
+  datastore              - Manage the datastores of the repo offline
   rpc                    - Interact with the jsonrpc api
 `,
 	},
@@ -151,6 +179,7 @@ TOOL COMMANDS
 		cmds.StringOption(OptionAPI, "set the api port to use"),
 		cmds.StringOption(OptionRepoDir, OptionLegacyRepoDir, "set the repo directory, defaults to ~/.venus"),
 		cmds.StringOption(cmds.EncLong, cmds.EncShort, "The encoding type the output should be encoded with (pretty-json or json)").WithDefault("pretty-json"),
+		cmds.BoolOption(OptionNoLabels, "print the raw addresses instead of their labels in the address book"),
 		cmds.BoolOption("help", "Show the full command help text."),
 		cmds.BoolOption("h", "Show a short version of the command help text."),
 	},
@@ -159,37 +188,46 @@ TOOL COMMANDS
 
 // command object for the daemon
 var RootCmdDaemon = &cmds.Command{
+	Options: []cmds.Option{
+		cmds.BoolOption(OptionNoLabels, "print the raw addresses instead of their labels in the address book"),
+	},
 	Subcommands: make(map[string]*cmds.Command),
 }
 
 // all top level commands, not available to daemon
 var rootSubcmdsLocal = map[string]*cmds.Command{
-	"daemon":  daemonCmd,
-	"fetch":   fetchCmd,
-	"version": versionCmd,
-	"seed":    seedCmd,
-	"cid":     cidCmd,
-	"rpc":     rpcCmd,
+	"daemon":    daemonCmd,
+	"fetch":     fetchCmd,
+	"version":   versionCmd,
+	"seed":      seedCmd,
+	"cid":       cidCmd,
+	"events-db": eventsDBCmd,
+	"datastore": datastoreCmd,
+	"rpc":       rpcCmd,
 }
 
 // all top level commands, available on daemon. set during init() to avoid configuration loops.
 var rootSubcmdsDaemon = map[string]*cmds.Command{
-	"chain":   chainCmd,
-	"sync":    syncCmd,
-	"drand":   drandCmd,
-	"inspect": inspectCmd,
-	"log":     logCmd,
-	"send":    msgSendCmd,
-	"mpool":   mpoolCmd,
-	"swarm":   swarmCmd,
-	"wallet":  walletCmd,
-	"version": versionCmd,
-	"state":   stateCmd,
-	"miner":   minerCmd,
-	"paych":   paychCmd,
-	"info":    infoCmd,
-	"evm":     evmCmd,
-	"f3":      f3Cmd,
+	"chain":    chainCmd,
+	"sync":     syncCmd,
+	"drand":    drandCmd,
+	"inspect":  inspectCmd,
+	"log":      logCmd,
+	"send":     msgSendCmd,
+	"mpool":    mpoolCmd,
+	"swarm":    swarmCmd,
+	"wallet":   walletCmd,
+	"addrbook": addrBookCmd,
+	"version":  versionCmd,
+	"state":    stateCmd,
+	"miner":    minerCmd,
+	"paych":    paychCmd,
+	"info":     infoCmd,
+	"backup":   backupCmd,
+	"config":   configCmd,
+	"journal":  journalCmd,
+	"evm":      evmCmd,
+	"f3":       f3Cmd,
 }
 
 func init() {
@@ -198,6 +236,10 @@ func init() {
 	}
 
 	for k, v := range rootSubcmdsDaemon {
+		// the address book itself is listed with the raw addresses
+		if v != addrBookCmd {
+			withAddressLabels(v)
+		}
 		RootCmd.Subcommands[k] = v
 		RootCmdDaemon.Subcommands[k] = v
 	}
@@ -324,11 +366,22 @@ func getAPIInfo(req *cmds.Request) (*APIInfo, error) {
 	}, nil
 }
 
+// localSubcmds are the subcommands of the commands available on daemon which run without it.
+var localSubcmds = [][]string{
+	{"config", "validate"},
+	{"config", "migrate"},
+}
+
 func requiresDaemon(req *cmds.Request) bool {
 	for cmd := range rootSubcmdsLocal {
 		if len(req.Path) > 0 && req.Path[0] == cmd {
 			return false
 		}
 	}
+	for _, path := range localSubcmds {
+		if len(req.Path) >= len(path) && slices.Equal(req.Path[:len(path)], path) {
+			return false
+		}
+	}
 	return true
 }
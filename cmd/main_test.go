@@ -23,4 +23,12 @@ func TestRequiresDaemon(t *testing.T) {
 	reqSubcmdDaemon, err := cmds.NewRequest(context.Background(), []string{"version"}, nil, []string{}, nil, RootCmd)
 	assert.NoError(t, err)
 	assert.False(t, requiresDaemon(reqSubcmdDaemon))
+
+	reqLocalSubcmd, err := cmds.NewRequest(context.Background(), []string{"config", "validate"}, nil, []string{}, nil, RootCmd)
+	assert.NoError(t, err)
+	assert.False(t, requiresDaemon(reqLocalSubcmd))
+
+	reqDaemonSubcmd, err := cmds.NewRequest(context.Background(), []string{"config", "set"}, nil, []string{"a", "b"}, nil, RootCmd)
+	assert.NoError(t, err)
+	assert.True(t, requiresDaemon(reqDaemonSubcmd))
 }
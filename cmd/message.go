@@ -93,6 +93,8 @@ var msgSendCmd = &cmds.Command{
 		cmds.StringOption("params-json", "specify invocation parameters in json"),
 		cmds.StringOption("params-hex", "specify invocation parameters in hex"),
 		cmds.Uint64Option("method", "The method to invoke on the target actor"),
+		cmds.StringOption("max-fee", "Spend up to X FIL of gas for this message, the fee cap is lowered to fit it"),
+		cmds.BoolOption("max-fee-strict", "Fail instead of lowering the fee cap when the estimated fee exceeds --max-fee"),
 	},
 	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
 		ctx := req.Context
@@ -241,7 +243,18 @@ var msgSendCmd = &cmds.Command{
 			}
 			c = sm.Cid()
 		} else {
-			sm, err := env.(*node.Env).MessagePoolAPI.MpoolPushMessage(ctx, msg, nil)
+			maxFee, _ := req.Options["max-fee"].(string)
+			spec, err := getMaxFee(maxFee)
+			if err != nil {
+				return err
+			}
+			if strict, _ := req.Options["max-fee-strict"].(bool); strict {
+				if spec == nil {
+					return fmt.Errorf("--max-fee-strict requires --max-fee")
+				}
+				spec.MaxFeeStrict = true
+			}
+			sm, err := env.(*node.Env).MessagePoolAPI.MpoolPushMessage(ctx, msg, spec)
 			if err != nil {
 				return err
 			}
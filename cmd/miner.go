@@ -9,7 +9,6 @@ import (
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
-	power6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/power"
 	cmds "github.com/ipfs/go-ipfs-cmds"
 	cbor "github.com/ipfs/go-ipld-cbor"
 	logging "github.com/ipfs/go-log/v2"
@@ -17,10 +16,8 @@ import (
 	"github.com/filecoin-project/venus/app/node"
 	"github.com/filecoin-project/venus/pkg/constants"
 	"github.com/filecoin-project/venus/pkg/wallet"
-	"github.com/filecoin-project/venus/venus-shared/actors"
 	"github.com/filecoin-project/venus/venus-shared/actors/adt"
 	"github.com/filecoin-project/venus/venus-shared/actors/builtin/miner"
-	"github.com/filecoin-project/venus/venus-shared/actors/builtin/power"
 	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
 	"github.com/filecoin-project/venus/venus-shared/types"
 	"github.com/filecoin-project/venus/venus-shared/types/params"
@@ -129,30 +126,10 @@ var newMinerCmd = &cmds.Command{
 			}
 		}
 
-		nv, err := env.(*node.Env).ChainAPI.StateNetworkVersion(ctx, types.EmptyTSK)
-		if err != nil {
-			return fmt.Errorf("getting network version: %v", err)
-		}
-
-		spt, err := miner.WindowPoStProofTypeFromSectorSize(ssize, nv)
-		if err != nil {
-			return fmt.Errorf("getting post proof type: %v", err)
-		}
-
 		peerID, err := env.(*node.Env).NetworkAPI.ID(ctx)
 		if err != nil {
 			return err
 		}
-		params, err := actors.SerializeParams(&power6.CreateMinerParams{
-			Owner:               owner,
-			Worker:              worker,
-			WindowPoStProofType: spt,
-			Peer:                abi.PeerID(peerID),
-		})
-		if err != nil {
-			return err
-		}
-
 		minerCmdLog.Info("peer id: ", peerID.String())
 
 		sender := owner
@@ -165,41 +142,20 @@ var newMinerCmd = &cmds.Command{
 			sender = faddr
 		}
 
-		createStorageMinerMsg := &types.Message{
-			To:    power.Address,
-			From:  sender,
-			Value: big.Zero(),
-
-			Method: power.Methods.CreateMiner,
-			Params: params,
-
-			GasLimit:   0,
+		_ = re.Emit("Pushing CreateMiner message and waiting for confirmation")
+		retval, err := env.(*node.Env).MingingAPI.MinerCreate(ctx, &types.MinerCreateParams{
+			Owner:      owner,
+			Worker:     worker,
+			From:       sender,
+			SectorSize: ssize,
+			Peer:       abi.PeerID(peerID),
 			GasPremium: abi.TokenAmount{Int: gasPrice.Int},
-		}
-
-		signed, err := env.(*node.Env).MessagePoolAPI.MpoolPushMessage(ctx, createStorageMinerMsg, nil)
-		if err != nil {
-			return fmt.Errorf("pushing createMiner message: %w", err)
-		}
-
-		cid := signed.Cid()
-		minerCmdLog.Infof("Pushed CreateMiner message: %s", cid)
-		minerCmdLog.Infof("Waiting for confirmation")
-		_ = re.Emit("Pushed CreateMiner message: " + cid.String())
-
-		mw, err := env.(*node.Env).ChainAPI.StateWaitMsg(ctx, cid, constants.MessageConfidence, constants.LookbackNoLimit, true)
+			Push:       true,
+		})
 		if err != nil {
-			return fmt.Errorf("waiting for createMiner message: %v", err)
-		}
-
-		if mw.Receipt.ExitCode != 0 {
-			return fmt.Errorf("create miner failed: exit code %d", mw.Receipt.ExitCode)
-		}
-
-		var retval power6.CreateMinerReturn
-		if err := retval.UnmarshalCBOR(bytes.NewReader(mw.Receipt.Return)); err != nil {
 			return err
 		}
+		minerCmdLog.Infof("CreateMiner message: %s", retval.MessageCid)
 
 		s := fmt.Sprintf("New miners address is: %s (%s)", retval.IDAddress, retval.RobustAddress)
 		minerCmdLog.Info(s)
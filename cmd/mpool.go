@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -10,6 +11,7 @@ import (
 
 	stdbig "math/big"
 
+	"github.com/dustin/go-humanize"
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
@@ -17,6 +19,7 @@ import (
 	cmds "github.com/ipfs/go-ipfs-cmds"
 
 	"github.com/filecoin-project/venus/app/node"
+	"github.com/filecoin-project/venus/cmd/tablewriter"
 	"github.com/filecoin-project/venus/pkg/config"
 	"github.com/filecoin-project/venus/pkg/constants"
 	"github.com/filecoin-project/venus/pkg/messagepool"
@@ -28,17 +31,18 @@ var mpoolCmd = &cmds.Command{
 		Tagline: "Manage message pool",
 	},
 	Subcommands: map[string]*cmds.Command{
-		"pending":  mpoolPending,
-		"clear":    mpoolClear,
-		"sub":      mpoolSub,
-		"stat":     mpoolStat,
-		"replace":  mpoolReplaceCmd,
-		"find":     mpoolFindCmd,
-		"config":   mpoolConfig,
-		"gas-perf": mpoolGasPerfCmd,
-		"publish":  mpoolPublish,
-		"delete":   mpoolDeleteAddress,
-		"select":   mpoolSelect,
+		"pending":    mpoolPending,
+		"clear":      mpoolClear,
+		"sub":        mpoolSub,
+		"stat":       mpoolStat,
+		"replace":    mpoolReplaceCmd,
+		"find":       mpoolFindCmd,
+		"config":     mpoolConfig,
+		"gas-perf":   mpoolGasPerfCmd,
+		"publish":    mpoolPublish,
+		"delete":     mpoolDeleteAddress,
+		"select":     mpoolSelect,
+		"fee-market": mpoolFeeMarketCmd,
 	},
 }
 
@@ -101,6 +105,79 @@ var mpoolSelect = &cmds.Command{
 	},
 }
 
+var mpoolFeeMarketCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Print the pending messages, the premiums they pay and the projected inclusion of a message",
+		ShortDescription: `
+The projection assumes the pending messages are included by effective premium, the premium capped by the fee
+cap above the base fee, and each epoch includes the gas target of the expected blocks. Without --premium, the
+inclusion is projected at the premium percentiles of the pending messages.
+`,
+	},
+	Options: []cmds.Option{
+		cmds.StringsOption("premium", "project the inclusion of a message paying this premium, in attoFIL per gas unit"),
+		cmds.IntOption("senders", "number of senders with the most pending messages to print").WithDefault(10),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		var premiums []big.Int
+		opts, _ := req.Options["premium"].([]string)
+		for _, opt := range opts {
+			premium, err := big.FromString(opt)
+			if err != nil {
+				return fmt.Errorf("invalid premium %q: %w", opt, err)
+			}
+			premiums = append(premiums, premium)
+		}
+		senders, _ := req.Options["senders"].(int)
+
+		stats, err := env.(*node.Env).MessagePoolAPI.MpoolStats(req.Context, premiums)
+		if err != nil {
+			return err
+		}
+
+		buf := &bytes.Buffer{}
+		writer := NewSilentWriter(buf)
+		writer.Printf("Height %d, base fee %s\n", stats.Height, types.FIL(stats.BaseFee))
+		writer.Printf("%d pending messages from %d senders, %s, gas limit %d\n\n", stats.Pending, len(stats.Senders),
+			humanize.IBytes(uint64(stats.Bytes)), stats.GasLimit)
+
+		tw := tablewriter.New(tablewriter.Col("Percentile"), tablewriter.Col("Premium"))
+		for _, pct := range stats.Premiums {
+			tw.Write(map[string]interface{}{"Percentile": fmt.Sprintf("p%d", pct.Percentile), "Premium": pct.Premium})
+		}
+		if err := tw.Flush(buf); err != nil {
+			return err
+		}
+		writer.Println()
+
+		tw = tablewriter.New(tablewriter.Col("Premium"), tablewriter.Col("GasAhead"), tablewriter.Col("Epochs"))
+		for _, est := range stats.Inclusion {
+			tw.Write(map[string]interface{}{"Premium": est.Premium, "GasAhead": est.GasAhead, "Epochs": est.Epochs})
+		}
+		if err := tw.Flush(buf); err != nil {
+			return err
+		}
+		writer.Println()
+
+		tw = tablewriter.New(tablewriter.Col("Sender"), tablewriter.Col("Pending"), tablewriter.Col("Size"), tablewriter.Col("GasLimit"))
+		for i, sender := range stats.Senders {
+			if i == senders {
+				break
+			}
+			tw.Write(map[string]interface{}{
+				"Sender":   sender.Sender,
+				"Pending":  sender.Pending,
+				"Size":     humanize.IBytes(uint64(sender.Bytes)),
+				"GasLimit": sender.GasLimit,
+			})
+		}
+		if err := tw.Flush(buf); err != nil {
+			return err
+		}
+		return re.Emit(buf)
+	},
+}
+
 var mpoolPublish = &cmds.Command{
 	Helptext: cmds.HelpText{
 		Tagline:          "publish",
@@ -327,7 +404,9 @@ var mpoolReplaceCmd = &cmds.Command{
 				return abi.TokenAmount{Int: config.DefaultDefaultMaxFee.Int}, nil
 			}
 
-			messagepool.CapGasFee(mff, &msg, mss)
+			if err := messagepool.CapGasFee(mff, &msg, mss); err != nil {
+				return err
+			}
 		} else {
 			msg.GasFeeCap = abi.NewTokenAmount(0)
 			msg.GasPremium = abi.NewTokenAmount(0)
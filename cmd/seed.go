@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -38,6 +39,7 @@ var seedCmd = &cmds.Command{
 		"genesis": genesisCmd,
 
 		"pre-seal":            preSealCmd,
+		"import-sectors":      importSectorsCmd,
 		"aggregate-manifests": aggregateManifestsCmd,
 	},
 }
@@ -72,19 +74,18 @@ var genesisNewCmd = &cmds.Command{
 			return errors.New("seed genesis new [genesis.json]")
 		}
 		networkName, _ := req.Options["network-name"].(string)
-		out := genesis.Template{
-			NetworkVersion:   networks.Net2k().Network.GenesisNetworkVersion,
-			Accounts:         []genesis.Actor{},
-			Miners:           []genesis.Miner{},
-			VerifregRootKey:  gen.DefaultVerifregRootkeyActor,
-			RemainderAccount: gen.DefaultRemainderAccountActor,
-			NetworkName:      networkName,
+		if networkName == "" {
+			networkName = "localnet-" + uuid.New().String()
 		}
-		if out.NetworkName == "" {
-			out.NetworkName = "localnet-" + uuid.New().String()
+		out := genesis.NewTemplate(networkName, networks.Net2k().Network.GenesisNetworkVersion, 0)
+		if err := out.SetVerifregRoot(gen.DefaultVerifregRootkeyActor); err != nil {
+			return err
+		}
+		if err := out.SetRemainder(gen.DefaultRemainderAccountActor); err != nil {
+			return err
 		}
 
-		genb, err := json.MarshalIndent(&out, "", "  ")
+		genb, err := out.Marshal()
 		if err != nil {
 			return err
 		}
@@ -139,33 +140,29 @@ var genesisAddMinerCmd = &cmds.Command{
 			return fmt.Errorf("unmarshal miner info: %w", err)
 		}
 
-		for mn, miner := range miners {
+		names := make([]string, 0, len(miners))
+		for mn := range miners {
+			names = append(names, mn)
+		}
+		sort.Strings(names)
+		for _, mn := range names {
 			log.Infof("Adding miner %s to genesis template", mn)
-			{
-				id := uint64(genesis.MinerStart) + uint64(len(template.Miners))
-				maddr, err := address.NewFromString(mn)
-				if err != nil {
-					return fmt.Errorf("parsing miner address: %w", err)
-				}
-				mid, err := address.IDFromAddress(maddr)
-				if err != nil {
-					return fmt.Errorf("getting miner id from address: %w", err)
-				}
-				if mid != id {
-					return fmt.Errorf("tried to set miner t0%d as t0%d", mid, id)
-				}
+			miner := miners[mn]
+			maddr, err := address.NewFromString(mn)
+			if err != nil {
+				return fmt.Errorf("parsing miner address: %w", err)
+			}
+			miner.ID = maddr
+			if err := template.AddMiner(miner); err != nil {
+				return err
 			}
-
-			template.Miners = append(template.Miners, miner)
 			log.Infof("Giving %s some initial balance", miner.Owner)
-			template.Accounts = append(template.Accounts, genesis.Actor{
-				Type:    genesis.TAccount,
-				Balance: big.Mul(big.NewInt(50_000_000), big.NewInt(int64(constants.FilecoinPrecision))),
-				Meta:    (&genesis.AccountMeta{Owner: miner.Owner}).ActorMeta(),
-			})
+			if err := template.AddAccount(miner.Owner, big.Mul(big.NewInt(50_000_000), big.NewInt(int64(constants.FilecoinPrecision)))); err != nil {
+				return err
+			}
 		}
 
-		genb, err = json.MarshalIndent(&template, "", "  ")
+		genb, err = template.Marshal()
 		if err != nil {
 			return err
 		}
@@ -217,21 +214,18 @@ var genesisAddMsigsCmd = &cmds.Command{
 				return fmt.Errorf("entry %d had mismatch between 'N' and number of addresses", i)
 			}
 
-			msig := &genesis.MultisigMeta{
+			msig := genesis.MultisigMeta{
 				Signers:         e.Addresses,
 				Threshold:       e.M,
 				VestingDuration: monthsToBlocks(e.VestingMonths),
 				VestingStart:    0,
 			}
-
-			template.Accounts = append(template.Accounts, genesis.Actor{
-				Type:    genesis.TMultisig,
-				Balance: abi.TokenAmount(e.Amount),
-				Meta:    msig.ActorMeta(),
-			})
+			if err := template.AddMultisig(msig, abi.TokenAmount(e.Amount)); err != nil {
+				return fmt.Errorf("entry %d: %w", i, err)
+			}
 		}
 
-		b, err = json.MarshalIndent(&template, "", "  ")
+		b, err = template.Marshal()
 		if err != nil {
 			return err
 		}
@@ -570,20 +564,9 @@ var preSealCmd = &cmds.Command{
 			return err
 		}
 
-		var ki *key.KeyInfo
-		if keyPath, _ := req.Options["key"].(string); keyPath != "" {
-			ki = new(key.KeyInfo)
-			kh, err := os.ReadFile(keyPath)
-			if err != nil {
-				return err
-			}
-			kb, err := hex.DecodeString(string(kh))
-			if err != nil {
-				return err
-			}
-			if err := json.Unmarshal(kb, ki); err != nil {
-				return err
-			}
+		ki, err := readKeyFile(req.Options["key"].(string))
+		if err != nil {
+			return err
 		}
 
 		ssize, _ := req.Options["sector-size"].(string)
@@ -618,6 +601,84 @@ var preSealCmd = &cmds.Command{
 	},
 }
 
+var importSectorsCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Make the preseal manifest of a genesis miner from sectors sealed out of venus",
+		ShortDescription: `The sectors file is a JSON array of the CommR, CommD, SectorID and ProofType of the sectors.
+The manifest and the key of the miner are written to the sector directory, like pre-seal does, and are
+added to a genesis template with 'genesis add-miner'.`,
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("sectors-file", true, false, "pre-sealed sectors metadata"),
+	},
+	Options: []cmds.Option{
+		cmds.StringOption("sector-dir", "sector directory").WithDefault("~/.genesis-sectors"),
+		cmds.StringOption("miner-addr", "specify the future address of your miner").WithDefault("t01000"),
+		cmds.StringOption("key", "(optional) Key to use for signing / owner/worker addresses").WithDefault(""),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		sdir, _ := req.Options["sector-dir"].(string)
+		sbroot, err := homedir.Expand(sdir)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(sbroot, 0o775); err != nil { //nolint:gosec
+			return err
+		}
+
+		addr, _ := req.Options["miner-addr"].(string)
+		maddr, err := address.NewFromString(addr)
+		if err != nil {
+			return err
+		}
+
+		ki, err := readKeyFile(req.Options["key"].(string))
+		if err != nil {
+			return err
+		}
+
+		sectorsFile, err := homedir.Expand(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+		sectors, err := genesis.LoadPreSeals(sectorsFile)
+		if err != nil {
+			return err
+		}
+
+		gm, key, err := seed.GenesisMiner(maddr, sectors, ki)
+		if err != nil {
+			return err
+		}
+		if err := seed.WriteGenesisMiner(maddr, sbroot, gm, key); err != nil {
+			return err
+		}
+
+		return re.Emit(fmt.Sprintf("miner %s: %d sectors of %s, raw power %s, owner and worker %s",
+			maddr, len(gm.Sectors), units.BytesSize(float64(gm.SectorSize)), types.SizeStr(gm.RawPower()), gm.Owner))
+	},
+}
+
+// readKeyFile reads the hex encoded key info written by pre-seal, it returns nil when path is empty.
+func readKeyFile(path string) (*key.KeyInfo, error) {
+	if path == "" {
+		return nil, nil
+	}
+	kh, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	kb, err := hex.DecodeString(string(kh))
+	if err != nil {
+		return nil, err
+	}
+	ki := new(key.KeyInfo)
+	if err := json.Unmarshal(kb, ki); err != nil {
+		return nil, err
+	}
+	return ki, nil
+}
+
 var aggregateManifestsCmd = &cmds.Command{
 	Helptext: cmds.HelpText{
 		Tagline: "aggregate a set of preseal manifests into a single file",
@@ -9,7 +9,9 @@ import (
 	"reflect"
 	"sort"
 	"strconv"
+	"strings"
 
+	"github.com/dustin/go-humanize"
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
 	actorstypes "github.com/filecoin-project/go-state-types/actors"
@@ -40,22 +42,28 @@ var stateCmd = &cmds.Command{
 		Tagline: "Interact with and query venus chain state",
 	},
 	Subcommands: map[string]*cmds.Command{
-		"wait-msg":       stateWaitMsgCmd,
-		"search-msg":     stateSearchMsgCmd,
-		"power":          statePowerCmd,
-		"sectors":        stateSectorsCmd,
-		"active-sectors": stateActiveSectorsCmd,
-		"sector":         stateSectorCmd,
-		"get-actor":      stateGetActorCmd,
-		"lookup":         stateLookupIDCmd,
-		"sector-size":    stateSectorSizeCmd,
-		"get-deal":       stateGetDealSetCmd,
-		"miner-info":     stateMinerInfo,
-		"network-info":   stateNtwkInfoCmd,
-		"list-actor":     stateListActorCmd,
-		"actor-cids":     stateSysActorCIDsCmd,
-		"replay":         stateReplayCmd,
-		"compute-state":  StateComputeStateCmd,
+		"wait-msg":           stateWaitMsgCmd,
+		"search-msg":         stateSearchMsgCmd,
+		"power":              statePowerCmd,
+		"sectors":            stateSectorsCmd,
+		"active-sectors":     stateActiveSectorsCmd,
+		"sector":             stateSectorCmd,
+		"get-actor":          stateGetActorCmd,
+		"actor-stats":        stateActorStatsCmd,
+		"size-report":        stateSizeReportCmd,
+		"actor-head-history": stateActorHeadHistoryCmd,
+		"lookup":             stateLookupIDCmd,
+		"sector-size":        stateSectorSizeCmd,
+		"get-deal":           stateGetDealSetCmd,
+		"miner-info":         stateMinerInfo,
+		"network-info":       stateNtwkInfoCmd,
+		"list-actor":         stateListActorCmd,
+		"actor-cids":         stateSysActorCIDsCmd,
+		"actor-methods":      stateActorMethodsCmd,
+		"replay":             stateReplayCmd,
+		"compute-state":      StateComputeStateCmd,
+		"list-messages":      stateListMessagesCmd,
+		"register-abi":       stateRegisterABICmd,
 	},
 }
 
@@ -367,6 +375,197 @@ var stateGetActorCmd = &cmds.Command{
 	},
 }
 
+var stateActorStatsCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Print the size and the block count of the states of actors",
+		ShortDescription: `With --base, only the blocks which are not in the state of the actor at the base tipset are
+counted, to find the actors whose state grew the most.`,
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("addresses", true, true, "Addresses of the actors"),
+	},
+	Options: []cmds.Option{
+		cmds.StringOption("tipset", "tipset of the states, eg. @123 or a list of block cids, defaults to the head"),
+		cmds.StringOption("base", "leave out the blocks of the states at this tipset, eg. @123 or a list of block cids"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		addrs := make([]address.Address, 0, len(req.Arguments))
+		for _, arg := range req.Arguments {
+			addr, err := address.NewFromString(arg)
+			if err != nil {
+				return err
+			}
+			addrs = append(addrs, addr)
+		}
+
+		chainAPI := getEnv(env).ChainAPI
+		ts, err := LoadTipSet(req.Context, req, chainAPI)
+		if err != nil {
+			return err
+		}
+		base := types.EmptyTSK
+		if b, _ := req.Options["base"].(string); len(b) > 0 {
+			baseTS, err := ParseTipSetRef(req.Context, chainAPI, b)
+			if err != nil {
+				return fmt.Errorf("parsing base: %w", err)
+			}
+			base = baseTS.Key()
+		}
+
+		stats, err := chainAPI.StateActorStats(req.Context, addrs, base, ts.Key())
+		if err != nil {
+			return err
+		}
+
+		tw := tablewriter.New(tablewriter.Col("Address"), tablewriter.Col("Head"), tablewriter.Col("Blocks"), tablewriter.Col("Size"))
+		for _, stat := range stats {
+			row := map[string]interface{}{"Address": stat.Address}
+			if stat.Found {
+				row["Head"] = stat.Head
+				row["Blocks"] = stat.Links
+				row["Size"] = humanize.IBytes(stat.Size)
+			} else {
+				row["Head"] = "not found"
+			}
+			tw.Write(row)
+		}
+		buf := &bytes.Buffer{}
+		if err := tw.Flush(buf); err != nil {
+			return err
+		}
+		return re.Emit(buf)
+	},
+}
+
+var stateSizeReportCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Print which actors the blocks of the state are attributed to",
+		ShortDescription: `Walks the whole state, which takes a while on mainnet, and attributes each block to the first
+actor reaching it, and within the miners to the fields of their states, eg. Sectors and Deadlines.`,
+	},
+	Options: []cmds.Option{
+		cmds.StringOption("tipset", "tipset of the state, eg. @123 or a list of block cids, defaults to the head"),
+		cmds.IntOption("top", "number of the actors of the largest states to print").WithDefault(20),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		chainAPI := getEnv(env).ChainAPI
+		ts, err := LoadTipSet(req.Context, req, chainAPI)
+		if err != nil {
+			return err
+		}
+		top, _ := req.Options["top"].(int)
+
+		report, err := chainAPI.StateSizeReport(req.Context, top, ts.Key())
+		if err != nil {
+			return err
+		}
+
+		buf := &bytes.Buffer{}
+		writer := NewSilentWriter(buf)
+		writer.Printf("State %s: %s in %d blocks, %d actors\n", report.Root, humanize.IBytes(report.Total.Size), report.Total.Links, report.Actors)
+		writer.Printf("State tree: %s in %d blocks\n\n", humanize.IBytes(report.StateTree.Size), report.StateTree.Links)
+
+		actorTypes := make([]string, 0, len(report.ByType))
+		for name := range report.ByType {
+			actorTypes = append(actorTypes, name)
+		}
+		sort.Slice(actorTypes, func(i, j int) bool {
+			return report.ByType[actorTypes[i]].Size > report.ByType[actorTypes[j]].Size
+		})
+		tw := tablewriter.New(tablewriter.Col("Type"), tablewriter.Col("Blocks"), tablewriter.Col("Size"))
+		for _, name := range actorTypes {
+			stat := report.ByType[name]
+			tw.Write(map[string]interface{}{"Type": name, "Blocks": stat.Links, "Size": humanize.IBytes(stat.Size)})
+		}
+		if err := tw.Flush(buf); err != nil {
+			return err
+		}
+		writer.Println()
+
+		tw = tablewriter.New(tablewriter.Col("Address"), tablewriter.Col("Type"), tablewriter.Col("Blocks"), tablewriter.Col("Size"), tablewriter.Col("Fields"))
+		for _, actor := range report.Top {
+			fieldNames := make([]string, 0, len(actor.Fields))
+			for name := range actor.Fields {
+				fieldNames = append(fieldNames, name)
+			}
+			sort.Slice(fieldNames, func(i, j int) bool {
+				return actor.Fields[fieldNames[i]].Size > actor.Fields[fieldNames[j]].Size
+			})
+			fields := make([]string, 0, len(fieldNames))
+			for _, name := range fieldNames {
+				fields = append(fields, fmt.Sprintf("%s: %s", name, humanize.IBytes(actor.Fields[name].Size)))
+			}
+			tw.Write(map[string]interface{}{
+				"Address": actor.Address,
+				"Type":    actor.Type,
+				"Blocks":  actor.Links,
+				"Size":    humanize.IBytes(actor.Size),
+				"Fields":  strings.Join(fields, ", "),
+			})
+		}
+		if err := tw.Flush(buf); err != nil {
+			return err
+		}
+		return re.Emit(buf)
+	},
+}
+
+var stateActorHeadHistoryCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Print the changes of the head and the balance of an actor over a range of epochs",
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("address", true, false, "Address of the actor"),
+	},
+	Options: []cmds.Option{
+		cmds.StringOption("tipset", "tipset of the chain, eg. @123 or a list of block cids, defaults to the head"),
+		cmds.Int64Option("from", "first epoch of the range"),
+		cmds.Int64Option("to", "last epoch of the range, defaults to the height of the tipset").WithDefault(int64(-1)),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		addr, err := address.NewFromString(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+		from, ok := req.Options["from"].(int64)
+		if !ok {
+			return fmt.Errorf("--from is required")
+		}
+
+		chainAPI := getEnv(env).ChainAPI
+		ts, err := LoadTipSet(req.Context, req, chainAPI)
+		if err != nil {
+			return err
+		}
+		to := abi.ChainEpoch(req.Options["to"].(int64))
+		if to < 0 {
+			to = ts.Height()
+		}
+
+		changes, err := chainAPI.StateActorHeadHistory(req.Context, addr, abi.ChainEpoch(from), to, ts.Key())
+		if err != nil {
+			return err
+		}
+
+		tw := tablewriter.New(tablewriter.Col("Height"), tablewriter.Col("Head"), tablewriter.Col("Balance"))
+		for _, change := range changes {
+			row := map[string]interface{}{"Height": change.Height}
+			if change.Found {
+				row["Head"] = change.Head
+				row["Balance"] = types.FIL(change.Balance)
+			} else {
+				row["Head"] = "not found"
+			}
+			tw.Write(row)
+		}
+		buf := &bytes.Buffer{}
+		if err := tw.Flush(buf); err != nil {
+			return err
+		}
+		return re.Emit(buf)
+	},
+}
+
 var stateLookupIDCmd = &cmds.Command{
 	Helptext: cmds.HelpText{
 		Tagline: "Find corresponding ID address",
@@ -708,6 +907,76 @@ var stateSysActorCIDsCmd = &cmds.Command{
 	},
 }
 
+var stateActorMethodsCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "List the methods of a built-in actor",
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("actor", true, false, "actor code cid, or actor name like 'miner' for the actor of the current network version"),
+	},
+	Options: []cmds.Option{
+		cmds.BoolOption("schema", "print the schemas of the params and returns as json"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		ctx := req.Context
+		api := env.(*node.Env).ChainAPI
+
+		code, err := cid.Decode(req.Arguments[0])
+		if err != nil {
+			nv, err := api.StateNetworkVersion(ctx, types.EmptyTSK)
+			if err != nil {
+				return err
+			}
+			actorsCids, err := api.StateActorCodeCIDs(ctx, nv)
+			if err != nil {
+				return err
+			}
+			c, ok := actorsCids[req.Arguments[0]]
+			if !ok {
+				return fmt.Errorf("%s is neither a cid nor an actor name", req.Arguments[0])
+			}
+			code = c
+		}
+
+		methods, err := api.StateActorMethods(ctx, code)
+		if err != nil {
+			return err
+		}
+
+		buf := new(bytes.Buffer)
+		if schema, _ := req.Options["schema"].(bool); schema {
+			enc := json.NewEncoder(buf)
+			enc.SetIndent("", "\t")
+			if err := enc.Encode(methods); err != nil {
+				return err
+			}
+			return re.Emit(buf)
+		}
+
+		tw := tablewriter.New(tablewriter.Col("Num"), tablewriter.Col("Name"), tablewriter.Col("Params"), tablewriter.Col("Return"))
+		for _, m := range methods {
+			tw.Write(map[string]interface{}{
+				"Num":    m.Num,
+				"Name":   m.Name,
+				"Params": schemaName(m.Params),
+				"Return": schemaName(m.Return),
+			})
+		}
+		if err := tw.Flush(buf); err != nil {
+			return err
+		}
+
+		return re.Emit(buf)
+	},
+}
+
+func schemaName(s *types.TypeSchema) string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return s.Kind
+}
+
 var stateReplayCmd = &cmds.Command{
 	Helptext: cmds.HelpText{
 		Tagline: "Replay a particular message",
@@ -744,6 +1013,17 @@ var stateReplayCmd = &cmds.Command{
 		writer.Printf("Return: %x\n", res.MsgRct.Return)
 		writer.Printf("Gas Used: %d\n", res.MsgRct.GasUsed)
 
+		// the decoded params and return are printed when the actor method or the contract abi is known
+		chainAPI := env.(*node.Env).ChainAPI
+		if params, err := chainAPI.StateDecodeParams(ctx, res.Msg.To, res.Msg.Method, res.Msg.Params, types.EmptyTSK); err == nil {
+			writer.Printf("Decoded params: %s\n", decodedJSON(params))
+		}
+		if res.MsgRct.ExitCode.IsSuccess() {
+			if ret, err := chainAPI.StateDecodeReturn(ctx, res.Msg.To, res.Msg.Method, res.Msg.Params, res.MsgRct.Return, types.EmptyTSK); err == nil {
+				writer.Printf("Decoded return: %s\n", decodedJSON(ret))
+			}
+		}
+
 		if detailedGas, _ := req.Options["detailed-gas"].(bool); detailedGas {
 			writer.Printf("Base Fee Burn: %d\n", res.GasCost.BaseFeeBurn)
 			writer.Printf("Overestimaton Burn: %d\n", res.GasCost.OverEstimationBurn)
@@ -766,6 +1046,112 @@ var stateReplayCmd = &cmds.Command{
 	},
 }
 
+var stateListMessagesCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "List messages on chain matching given criteria",
+	},
+	Options: []cmds.Option{
+		cmds.StringOption("to", "return messages to a given address"),
+		cmds.StringOption("from", "return messages from a given address"),
+		cmds.StringOption("to-type", "return messages to actors of a given type, eg. storagemarket"),
+		cmds.Int64Option("method", "return messages calling a given method number"),
+		cmds.Int64Option("toheight", "don't look before given block height"),
+		cmds.BoolOption("cids", "print message CIDs instead of messages"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		ctx := req.Context
+		api := env.(*node.Env).ChainAPI
+
+		var toa, froma address.Address
+		if tos, _ := req.Options["to"].(string); tos != "" {
+			a, err := address.NewFromString(tos)
+			if err != nil {
+				return fmt.Errorf("given 'to' address %q was invalid: %w", tos, err)
+			}
+			toa = a
+		}
+		if froms, _ := req.Options["from"].(string); froms != "" {
+			a, err := address.NewFromString(froms)
+			if err != nil {
+				return fmt.Errorf("given 'from' address %q was invalid: %w", froms, err)
+			}
+			froma = a
+		}
+		toh, _ := req.Options["toheight"].(int64)
+
+		head, err := api.ChainHead(ctx)
+		if err != nil {
+			return err
+		}
+		match := &types.MessageMatch{To: toa, From: froma}
+		match.ToActorType, _ = req.Options["to-type"].(string)
+		if method, ok := req.Options["method"].(int64); ok {
+			m := abi.MethodNum(method)
+			match.Method = &m
+		}
+		msgs, err := api.StateListMessages(ctx, match, head.Key(), abi.ChainEpoch(toh))
+		if err != nil {
+			return err
+		}
+
+		buf := new(bytes.Buffer)
+		writer := NewSilentWriter(buf)
+		printCids, _ := req.Options["cids"].(bool)
+		for _, c := range msgs {
+			if printCids {
+				writer.Println(c.String())
+				continue
+			}
+
+			m, err := api.ChainGetMessage(ctx, c)
+			if err != nil {
+				return err
+			}
+			writer.Printf("%s\t%s\t%s\t%s\t%d\t%x\n", c, m.From, m.To, m.Value, m.Method, m.Params)
+			if params, err := api.StateDecodeParams(ctx, m.To, m.Method, m.Params, head.Key()); err == nil {
+				writer.Printf("\t%s\n", decodedJSON(params))
+			}
+		}
+
+		return re.Emit(buf)
+	},
+}
+
+var stateRegisterABICmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Register the solidity JSON ABI of an evm contract",
+		ShortDescription: `The ABI file holds either the array output by solc or a hardhat or foundry artifact.
+The calls to the contract are then decoded into named function calls by 'state replay' and 'state list-messages'.`,
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("address", true, false, "contract address"),
+		cmds.StringArg("abi", true, false, "path to the ABI JSON file"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		addr, err := address.NewFromString(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(req.Arguments[1])
+		if err != nil {
+			return err
+		}
+		if err := env.(*node.Env).ChainAPI.StateRegisterContractABI(req.Context, addr, data); err != nil {
+			return err
+		}
+
+		return re.Emit(fmt.Sprintf("registered the abi of %s", addr))
+	},
+}
+
+func decodedJSON(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
+
 func printInternalExecutions(writer *SilentWriter, prefix string, trace []types.ExecutionTrace) {
 	for _, im := range trace {
 		writer.Printf("%s%s\t%s\t%s\t%d\t%x\t%d\t%x\n", prefix, im.Msg.From, im.Msg.To, im.Msg.Value, im.Msg.Method, im.Msg.Params, im.MsgRct.ExitCode, im.MsgRct.Return)
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/venus/pkg/config"
 	"github.com/filecoin-project/venus/pkg/constants"
 	"github.com/filecoin-project/venus/venus-shared/types"
@@ -32,7 +33,11 @@ func SetConfigFromOptions(cfg *config.Config, networkName string) error {
 	if err != nil {
 		return err
 	}
+	overrides := cfg.NetworkParams.UpgradeHeightOverrides
 	cfg.NetworkParams = &netcfg.Network
+	if err := overrideUpgradeHeights(cfg.NetworkParams, overrides); err != nil {
+		return err
+	}
 	cfg.Bootstrap.Period = netcfg.Bootstrap.Period
 	cfg.Bootstrap.AddPeers(netcfg.Bootstrap.Addresses...)
 	return nil
@@ -44,9 +49,25 @@ func SetConfigFromNetworkType(cfg *config.Config, networkType types.NetworkType)
 		return err
 	}
 	oldAllowableClockDriftSecs := cfg.NetworkParams.AllowableClockDriftSecs
+	oldMigrationMaxWorkers := cfg.NetworkParams.MigrationMaxWorkers
+	overrides := cfg.NetworkParams.UpgradeHeightOverrides
 	cfg.NetworkParams = &netcfg.Network
 	// not change, expect to adjust the value through the configuration file
 	cfg.NetworkParams.AllowableClockDriftSecs = oldAllowableClockDriftSecs
+	cfg.NetworkParams.MigrationMaxWorkers = oldMigrationMaxWorkers
+	if err := overrideUpgradeHeights(cfg.NetworkParams, overrides); err != nil {
+		return err
+	}
+	if cfg.Devnet != nil && cfg.Devnet.Enable {
+		if networkType != types.Network2k {
+			return fmt.Errorf("devnet requires the 2k network, got %d", networkType)
+		}
+		if cfg.Devnet.BlockDelaySecs > 0 {
+			cfg.NetworkParams.BlockDelay = cfg.Devnet.BlockDelaySecs
+		}
+		// a single node, no need to wait for blocks of other miners
+		cfg.NetworkParams.PropagationDelaySecs = 0
+	}
 
 	if constants.DisableF3 {
 		cfg.NetworkParams.F3Enabled = false
@@ -55,6 +76,23 @@ func SetConfigFromNetworkType(cfg *config.Config, networkType types.NetworkType)
 	return nil
 }
 
+// overrideUpgradeHeights replaces the upgrade heights of the network with the ones of the config,
+// the schedule they make is validated when the node builds its fork schedule.
+func overrideUpgradeHeights(params *config.NetworkParamsConfig, overrides map[string]abi.ChainEpoch) error {
+	if len(overrides) == 0 {
+		return nil
+	}
+	if params.NetworkType == types.NetworkMainnet {
+		return fmt.Errorf("upgrade heights can not be overridden on mainnet")
+	}
+	if err := params.ForkUpgradeParam.Override(overrides); err != nil {
+		return fmt.Errorf("invalid upgrade height override: %w", err)
+	}
+	params.UpgradeHeightOverrides = overrides
+	log.Warnf("upgrade heights overridden by config: %v", overrides)
+	return nil
+}
+
 func GetNetworkConfigFromType(networkType types.NetworkType) (*NetworkConf, error) {
 	switch networkType {
 	case types.NetworkMainnet:
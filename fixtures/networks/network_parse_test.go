@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/venus/pkg/config"
 	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
 	"github.com/filecoin-project/venus/venus-shared/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetNetworkFromName(t *testing.T) {
@@ -127,3 +130,37 @@ func TestGetNetworkConfig(t *testing.T) {
 		assert.Equal(t, test.err, err)
 	}
 }
+
+func TestUpgradeHeightOverrides(t *testing.T) {
+	tf.UnitTest(t)
+
+	cfg := config.NewDefaultConfig()
+	cfg.NetworkParams.UpgradeHeightOverrides = map[string]abi.ChainEpoch{"upgradeTeepHeight": 100, "upgradeTockHeight": 200}
+	require.NoError(t, SetConfigFromNetworkType(cfg, types.Network2k))
+	assert.Equal(t, abi.ChainEpoch(100), cfg.NetworkParams.ForkUpgradeParam.UpgradeTeepHeight)
+	assert.Equal(t, abi.ChainEpoch(200), cfg.NetworkParams.ForkUpgradeParam.UpgradeTockHeight)
+	assert.Equal(t, Net2k().Network.ForkUpgradeParam.UpgradeWaffleHeight, cfg.NetworkParams.ForkUpgradeParam.UpgradeWaffleHeight)
+
+	// the overrides are kept when the network params are loaded again
+	require.NoError(t, SetConfigFromNetworkType(cfg, types.Network2k))
+	assert.Equal(t, abi.ChainEpoch(100), cfg.NetworkParams.ForkUpgradeParam.UpgradeTeepHeight)
+
+	cfg.NetworkParams.UpgradeHeightOverrides = map[string]abi.ChainEpoch{"upgradeUnknownHeight": 100}
+	assert.Error(t, SetConfigFromNetworkType(cfg, types.Network2k))
+
+	cfg.NetworkParams.UpgradeHeightOverrides = map[string]abi.ChainEpoch{"upgradeTeepHeight": 100}
+	assert.Error(t, SetConfigFromNetworkType(cfg, types.NetworkMainnet))
+}
+
+func TestDevnetParams(t *testing.T) {
+	tf.UnitTest(t)
+
+	cfg := config.NewDefaultConfig()
+	cfg.Devnet.Enable = true
+	cfg.Devnet.BlockDelaySecs = 2
+	require.NoError(t, SetConfigFromNetworkType(cfg, types.Network2k))
+	assert.Equal(t, uint64(2), cfg.NetworkParams.BlockDelay)
+	assert.Equal(t, uint64(0), cfg.NetworkParams.PropagationDelaySecs)
+
+	assert.Error(t, SetConfigFromNetworkType(cfg, types.NetworkCalibnet))
+}
@@ -0,0 +1,147 @@
+// Package addrbook maps the labels given by the operator to addresses, so the outputs show the
+// labels instead of the raw addresses.
+package addrbook
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+	"github.com/ipfs/go-datastore/query"
+)
+
+var bookPrefix = datastore.NewKey("/addrbook")
+
+var labelRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+
+// Book is the address book of the node, each label names one address and each address has at
+// most one label.
+type Book struct {
+	lk        sync.RWMutex
+	ds        datastore.Datastore
+	addresses map[string]address.Address
+	labels    map[address.Address]string
+}
+
+// New loads the address book stored in ds.
+func New(ctx context.Context, ds datastore.Datastore) (*Book, error) {
+	b := &Book{
+		ds:        namespace.Wrap(ds, bookPrefix),
+		addresses: make(map[string]address.Address),
+		labels:    make(map[address.Address]string),
+	}
+
+	res, err := b.ds.Query(ctx, query.Query{})
+	if err != nil {
+		return nil, err
+	}
+	entries, err := res.Rest()
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		addr, err := address.NewFromBytes(e.Value)
+		if err != nil {
+			return nil, fmt.Errorf("address of label %s: %w", e.Key, err)
+		}
+		label := datastore.RawKey(e.Key).BaseNamespace()
+		b.addresses[label] = addr
+		b.labels[addr] = label
+	}
+	return b, nil
+}
+
+// ValidateLabel checks label is made of letters, digits, dots, dashes and underscores, and can't
+// be mistaken for an address.
+func ValidateLabel(label string) error {
+	if !labelRegexp.MatchString(label) {
+		return fmt.Errorf("invalid label %q: expected up to 64 letters, digits, '.', '-' or '_'", label)
+	}
+	if _, err := address.NewFromString(label); err == nil {
+		return fmt.Errorf("invalid label %q: it is an address", label)
+	}
+	return nil
+}
+
+// Set labels addr, replacing the address previously named label and the previous label of addr.
+func (b *Book) Set(ctx context.Context, label string, addr address.Address) error {
+	if err := ValidateLabel(label); err != nil {
+		return err
+	}
+	if addr.Empty() {
+		return fmt.Errorf("can't label an empty address")
+	}
+
+	b.lk.Lock()
+	defer b.lk.Unlock()
+
+	if old, ok := b.labels[addr]; ok && old != label {
+		if err := b.remove(ctx, old); err != nil {
+			return err
+		}
+	}
+	if err := b.remove(ctx, label); err != nil {
+		return err
+	}
+	if err := b.ds.Put(ctx, datastore.NewKey(label), addr.Bytes()); err != nil {
+		return err
+	}
+	b.addresses[label] = addr
+	b.labels[addr] = label
+	return nil
+}
+
+// Remove deletes label from the book, it is not an error if there is no such label.
+func (b *Book) Remove(ctx context.Context, label string) error {
+	b.lk.Lock()
+	defer b.lk.Unlock()
+
+	return b.remove(ctx, label)
+}
+
+func (b *Book) remove(ctx context.Context, label string) error {
+	addr, ok := b.addresses[label]
+	if !ok {
+		return nil
+	}
+	if err := b.ds.Delete(ctx, datastore.NewKey(label)); err != nil {
+		return err
+	}
+	delete(b.addresses, label)
+	delete(b.labels, addr)
+	return nil
+}
+
+// Lookup returns the address named label.
+func (b *Book) Lookup(label string) (address.Address, bool) {
+	b.lk.RLock()
+	defer b.lk.RUnlock()
+
+	addr, ok := b.addresses[label]
+	return addr, ok
+}
+
+// Label returns the label of addr.
+func (b *Book) Label(addr address.Address) (string, bool) {
+	b.lk.RLock()
+	defer b.lk.RUnlock()
+
+	label, ok := b.labels[addr]
+	return label, ok
+}
+
+// List returns a copy of the book, by label.
+func (b *Book) List() map[string]address.Address {
+	b.lk.RLock()
+	defer b.lk.RUnlock()
+
+	out := make(map[string]address.Address, len(b.addresses))
+	for label, addr := range b.addresses {
+		out[label] = addr
+	}
+	return out
+}
@@ -0,0 +1,52 @@
+package addrbook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestBook(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	ds := datastore.NewMapDatastore()
+	owner, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+	worker, err := address.NewIDAddress(1001)
+	require.NoError(t, err)
+
+	b, err := New(ctx, ds)
+	require.NoError(t, err)
+	require.NoError(t, b.Set(ctx, "my-owner-wallet", owner))
+	require.NoError(t, b.Set(ctx, "worker", worker))
+
+	assert.Error(t, b.Set(ctx, "f01000", worker))
+	assert.Error(t, b.Set(ctx, "with space", worker))
+	assert.Error(t, b.Set(ctx, "", worker))
+
+	label, ok := b.Label(owner)
+	assert.True(t, ok)
+	assert.Equal(t, "my-owner-wallet", label)
+
+	// relabeling an address drops its previous label
+	require.NoError(t, b.Set(ctx, "owner", owner))
+	_, ok = b.Lookup("my-owner-wallet")
+	assert.False(t, ok)
+
+	// the book is reloaded from the datastore
+	b, err = New(ctx, ds)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]address.Address{"owner": owner, "worker": worker}, b.List())
+
+	require.NoError(t, b.Remove(ctx, "worker"))
+	require.NoError(t, b.Remove(ctx, "worker"))
+	_, ok = b.Label(worker)
+	assert.False(t, ok)
+}
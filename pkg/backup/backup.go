@@ -0,0 +1,432 @@
+// Package backup writes the metadata of a node, its config, keystore, wallet, metadata datastore, which holds
+// the local messages of the message pool, and sqlite indexes, to an archive restored in the repo of a new node,
+// so a node can be moved to another machine without its chain data.
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/pkg/repo"
+	"github.com/filecoin-project/venus/pkg/repo/fskeystore"
+)
+
+// Version is the version of the archive format.
+const Version = 1
+
+const (
+	manifestEntry = "manifest.json"
+	configEntry   = "config.json"
+	keysEntry     = "keys.json"
+	metaEntry     = "meta.json"
+	sqliteDir     = "sqlite/"
+)
+
+// BasePathEnv names the directory of the host of the node the backups are written to, the backups are
+// refused when it is not set.
+const BasePathEnv = "VENUS_BACKUP_BASE_PATH"
+
+// FilePath returns the file of the backup at path in the directory base, a relative path being relative to
+// it. The paths resolving outside of base, eg. through a symlink, are refused.
+func FilePath(base, path string) (string, error) {
+	if base == "" {
+		return "", fmt.Errorf("%s is not set on the node, the backups are disabled", BasePathEnv)
+	}
+	if path == "" {
+		return "", fmt.Errorf("backup path is required")
+	}
+	base, err := filepath.Abs(base)
+	if err != nil {
+		return "", err
+	}
+	if base, err = filepath.EvalSymlinks(base); err != nil {
+		return "", fmt.Errorf("resolving the backup directory: %w", err)
+	}
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(base, path)
+	}
+	path = filepath.Clean(path)
+	name := filepath.Base(path)
+	if name == string(filepath.Separator) || name == "." || name == ".." {
+		return "", fmt.Errorf("backup path %s is not a file", path)
+	}
+	dir, err := filepath.EvalSymlinks(filepath.Dir(path))
+	if err != nil {
+		return "", fmt.Errorf("resolving the directory of the backup: %w", err)
+	}
+	rel, err := filepath.Rel(base, dir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("backup path %s is outside of the backup directory %s", path, base)
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// Repo is the part of the repo saved in a backup.
+type Repo interface {
+	Config() *config.Config
+	ReplaceConfig(cfg *config.Config) error
+	Keystore() fskeystore.Keystore
+	WalletDatastore() repo.Datastore
+	MetaDatastore() repo.Datastore
+	SqlitePath() (string, error)
+}
+
+// Manifest describes a backup, it is the first entry of the archive.
+type Manifest struct {
+	Version     int
+	NetworkType int
+	Created     time.Time
+	// Encrypted is set when the keys are encrypted with a password
+	Encrypted bool
+}
+
+// Result counts what a backup or a restore saved.
+type Result struct {
+	Keys        int
+	MetaEntries int
+	SqliteFiles []string
+}
+
+// keys are the keystore and the wallet datastore, encrypted together.
+type keys struct {
+	Keystore map[string][]byte
+	Wallet   []entry
+}
+
+type entry struct {
+	Key   string
+	Value []byte
+}
+
+// Backup writes a gzipped tar archive of r to w, the keys are encrypted when password is not empty. The
+// sqlite databases are copied with VACUUM INTO, which is consistent while the node writes them.
+func Backup(ctx context.Context, r Repo, w io.Writer, password string) (*Result, error) {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+	res := &Result{}
+
+	cfg := r.Config()
+	manifest := Manifest{
+		Version:     Version,
+		NetworkType: int(cfg.NetworkParams.NetworkType),
+		Created:     now,
+		Encrypted:   password != "",
+	}
+	if err := writeJSON(tw, manifestEntry, manifest, now); err != nil {
+		return nil, err
+	}
+	if err := writeJSON(tw, configEntry, cfg, now); err != nil {
+		return nil, err
+	}
+
+	ks, err := readKeys(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	res.Keys = len(ks.Keystore) + len(ks.Wallet)
+	data, err := json.Marshal(ks)
+	if err != nil {
+		return nil, err
+	}
+	if password != "" {
+		if data, err = encrypt(data, []byte(password)); err != nil {
+			return nil, fmt.Errorf("encrypting the keys: %w", err)
+		}
+	}
+	if err := writeEntry(tw, keysEntry, data, now); err != nil {
+		return nil, err
+	}
+
+	meta, err := readDatastore(ctx, r.MetaDatastore())
+	if err != nil {
+		return nil, fmt.Errorf("reading the meta datastore: %w", err)
+	}
+	res.MetaEntries = len(meta)
+	if err := writeJSON(tw, metaEntry, meta, now); err != nil {
+		return nil, err
+	}
+
+	files, err := backupSqlite(ctx, r, tw, now)
+	if err != nil {
+		return nil, err
+	}
+	res.SqliteFiles = files
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func readKeys(ctx context.Context, r Repo) (*keys, error) {
+	ks := &keys{Keystore: make(map[string][]byte)}
+	names, err := r.Keystore().List()
+	if err != nil {
+		return nil, fmt.Errorf("listing the keystore: %w", err)
+	}
+	for _, name := range names {
+		key, err := r.Keystore().Get(name)
+		if err != nil {
+			return nil, fmt.Errorf("reading key %s: %w", name, err)
+		}
+		ks.Keystore[name] = key
+	}
+	if ks.Wallet, err = readDatastore(ctx, r.WalletDatastore()); err != nil {
+		return nil, fmt.Errorf("reading the wallet datastore: %w", err)
+	}
+	return ks, nil
+}
+
+func readDatastore(ctx context.Context, ds datastore.Datastore) ([]entry, error) {
+	res, err := ds.Query(ctx, query.Query{})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close() // nolint: errcheck
+
+	out := []entry{}
+	for r := range res.Next() {
+		if r.Error != nil {
+			return nil, r.Error
+		}
+		out = append(out, entry{Key: r.Key, Value: r.Value})
+	}
+	return out, nil
+}
+
+// backupSqlite copies every database of the sqlite directory of the repo to the archive.
+func backupSqlite(ctx context.Context, r Repo, tw *tar.Writer, now time.Time) ([]string, error) {
+	dir, err := r.SqlitePath()
+	if err != nil {
+		return nil, err
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.db"))
+	if err != nil {
+		return nil, err
+	}
+
+	tmp, err := os.MkdirTemp("", "venus-backup")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp) // nolint: errcheck
+
+	names := []string{}
+	for _, file := range files {
+		name := filepath.Base(file)
+		copyPath := filepath.Join(tmp, name)
+		if err := vacuumInto(ctx, file, copyPath); err != nil {
+			return nil, fmt.Errorf("copying %s: %w", name, err)
+		}
+		data, err := os.ReadFile(copyPath)
+		if err != nil {
+			return nil, err
+		}
+		if err := writeEntry(tw, sqliteDir+name, data, now); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func vacuumInto(ctx context.Context, file, copyPath string) error {
+	db, err := sql.Open("sqlite3", "file:"+file+"?mode=ro")
+	if err != nil {
+		return err
+	}
+	defer db.Close() // nolint: errcheck
+
+	_, err = db.ExecContext(ctx, "VACUUM INTO ?", copyPath)
+	return err
+}
+
+func writeJSON(tw *tar.Writer, name string, v interface{}, now time.Time) error {
+	data, err := json.MarshalIndent(v, "", "\t")
+	if err != nil {
+		return err
+	}
+	return writeEntry(tw, name, data, now)
+}
+
+func writeEntry(tw *tar.Writer, name string, data []byte, now time.Time) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0o600,
+		Size:    int64(len(data)),
+		ModTime: now,
+	}); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+// Restore writes the backup read from rd to r, replacing its config, its keys and the entries of its meta
+// datastore of the same keys, and copying the sqlite databases. The node of r must not run, and the backup must
+// be of the network of r.
+func Restore(ctx context.Context, r Repo, rd io.Reader, password string) (*Result, error) {
+	gz, err := gzip.NewReader(rd)
+	if err != nil {
+		return nil, fmt.Errorf("reading the backup: %w", err)
+	}
+	tr := tar.NewReader(gz)
+	res := &Result{}
+
+	var manifest *Manifest
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading the backup: %w", err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", hdr.Name, err)
+		}
+
+		if manifest == nil {
+			if hdr.Name != manifestEntry {
+				return nil, fmt.Errorf("backup has no manifest")
+			}
+			manifest = &Manifest{}
+			if err := json.Unmarshal(data, manifest); err != nil {
+				return nil, fmt.Errorf("decoding the manifest: %w", err)
+			}
+			if manifest.Version != Version {
+				return nil, fmt.Errorf("backup version %d is not supported, want %d", manifest.Version, Version)
+			}
+			if nt := int(r.Config().NetworkParams.NetworkType); manifest.NetworkType != nt {
+				return nil, fmt.Errorf("backup of network type %d, the repo is of network type %d", manifest.NetworkType, nt)
+			}
+			if manifest.Encrypted && password == "" {
+				return nil, fmt.Errorf("backup is encrypted, a password is required")
+			}
+			continue
+		}
+
+		switch {
+		case hdr.Name == configEntry:
+			cfg := config.NewDefaultConfig()
+			if err := json.Unmarshal(data, cfg); err != nil {
+				return nil, fmt.Errorf("decoding the config: %w", err)
+			}
+			if err := r.ReplaceConfig(cfg); err != nil {
+				return nil, fmt.Errorf("replacing the config: %w", err)
+			}
+		case hdr.Name == keysEntry:
+			if manifest.Encrypted {
+				if data, err = decrypt(data, []byte(password)); err != nil {
+					return nil, err
+				}
+			}
+			var ks keys
+			if err := json.Unmarshal(data, &ks); err != nil {
+				return nil, fmt.Errorf("decoding the keys: %w", err)
+			}
+			if err := restoreKeys(ctx, r, &ks); err != nil {
+				return nil, err
+			}
+			res.Keys = len(ks.Keystore) + len(ks.Wallet)
+		case hdr.Name == metaEntry:
+			var meta []entry
+			if err := json.Unmarshal(data, &meta); err != nil {
+				return nil, fmt.Errorf("decoding the meta datastore: %w", err)
+			}
+			if err := writeDatastore(ctx, r.MetaDatastore(), meta); err != nil {
+				return nil, fmt.Errorf("restoring the meta datastore: %w", err)
+			}
+			res.MetaEntries = len(meta)
+		case strings.HasPrefix(hdr.Name, sqliteDir):
+			name := strings.TrimPrefix(hdr.Name, sqliteDir)
+			if name == "" || name != filepath.Base(name) {
+				return nil, fmt.Errorf("invalid sqlite entry %s", hdr.Name)
+			}
+			if err := restoreSqlite(r, name, data); err != nil {
+				return nil, fmt.Errorf("restoring %s: %w", name, err)
+			}
+			res.SqliteFiles = append(res.SqliteFiles, name)
+		default:
+			return nil, fmt.Errorf("unknown backup entry %s", hdr.Name)
+		}
+	}
+	if manifest == nil {
+		return nil, fmt.Errorf("backup is empty")
+	}
+	return res, nil
+}
+
+func restoreKeys(ctx context.Context, r Repo, ks *keys) error {
+	for name, key := range ks.Keystore {
+		// the keys created by the init of the repo, like the one of the peer id, are replaced
+		has, err := r.Keystore().Has(name)
+		if err != nil {
+			return fmt.Errorf("reading key %s: %w", name, err)
+		}
+		if has {
+			if err := r.Keystore().Delete(name); err != nil {
+				return fmt.Errorf("replacing key %s: %w", name, err)
+			}
+		}
+		if err := r.Keystore().Put(name, key); err != nil {
+			return fmt.Errorf("restoring key %s: %w", name, err)
+		}
+	}
+	if err := writeDatastore(ctx, r.WalletDatastore(), ks.Wallet); err != nil {
+		return fmt.Errorf("restoring the wallet datastore: %w", err)
+	}
+	return nil
+}
+
+func writeDatastore(ctx context.Context, ds repo.Datastore, entries []entry) error {
+	batch, err := ds.Batch(ctx)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if err := batch.Put(ctx, datastore.NewKey(e.Key), e.Value); err != nil {
+			return err
+		}
+	}
+	return batch.Commit(ctx)
+}
+
+// restoreSqlite replaces the database name of the sqlite directory of r, with its journal files.
+func restoreSqlite(r Repo, name string, data []byte) error {
+	dir, err := r.SqlitePath()
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, name)
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Remove(path + suffix); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	tmp := path + ".restore"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,136 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/repo"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+type testRepo struct {
+	*repo.MemRepo
+	sqlite string
+}
+
+func (r *testRepo) SqlitePath() (string, error) {
+	return r.sqlite, nil
+}
+
+func newTestRepo(t *testing.T) *testRepo {
+	return &testRepo{MemRepo: repo.NewInMemoryRepo(), sqlite: t.TempDir()}
+}
+
+func TestBackupRestore(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	src := newTestRepo(t)
+	require.NoError(t, src.Keystore().Put("self", []byte("peer key")))
+	require.NoError(t, src.WalletDatastore().Put(ctx, datastore.NewKey("/wallet/key"), []byte("wallet key")))
+	require.NoError(t, src.MetaDatastore().Put(ctx, datastore.NewKey("/mpool/local/msg"), []byte("message")))
+
+	db, err := sql.Open("sqlite3", filepath.Join(src.sqlite, "events.db")+"?_journal_mode=WAL")
+	require.NoError(t, err)
+	_, err = db.Exec("CREATE TABLE event (id INTEGER PRIMARY KEY, value TEXT)")
+	require.NoError(t, err)
+	_, err = db.Exec("INSERT INTO event (value) VALUES ('a'), ('b')")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	res, err := Backup(ctx, src, &buf, "secret")
+	require.NoError(t, err)
+	// the database is copied while it is open
+	require.NoError(t, db.Close())
+	require.Equal(t, 2, res.Keys)
+	require.Equal(t, 1, res.MetaEntries)
+	require.Equal(t, []string{"events.db"}, res.SqliteFiles)
+
+	t.Run("wrong password", func(t *testing.T) {
+		_, err := Restore(ctx, newTestRepo(t), bytes.NewReader(buf.Bytes()), "wrong")
+		require.ErrorContains(t, err, "wrong password")
+
+		_, err = Restore(ctx, newTestRepo(t), bytes.NewReader(buf.Bytes()), "")
+		require.ErrorContains(t, err, "password is required")
+	})
+
+	t.Run("other network", func(t *testing.T) {
+		dst := newTestRepo(t)
+		dst.Config().NetworkParams.NetworkType = types.NetworkCalibnet
+		defer func() { dst.Config().NetworkParams.NetworkType = src.Config().NetworkParams.NetworkType }()
+
+		_, err := Restore(ctx, dst, bytes.NewReader(buf.Bytes()), "secret")
+		require.ErrorContains(t, err, "network type")
+	})
+
+	dst := newTestRepo(t)
+	// the key created by the init of the repo is replaced
+	require.NoError(t, dst.Keystore().Put("self", []byte("new peer key")))
+	res, err = Restore(ctx, dst, bytes.NewReader(buf.Bytes()), "secret")
+	require.NoError(t, err)
+	require.Equal(t, 2, res.Keys)
+	require.Equal(t, []string{"events.db"}, res.SqliteFiles)
+
+	key, err := dst.Keystore().Get("self")
+	require.NoError(t, err)
+	require.Equal(t, []byte("peer key"), key)
+	val, err := dst.WalletDatastore().Get(ctx, datastore.NewKey("/wallet/key"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("wallet key"), val)
+	val, err = dst.MetaDatastore().Get(ctx, datastore.NewKey("/mpool/local/msg"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("message"), val)
+
+	db, err = sql.Open("sqlite3", filepath.Join(dst.sqlite, "events.db"))
+	require.NoError(t, err)
+	defer db.Close() // nolint: errcheck
+	var count int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM event").Scan(&count))
+	require.Equal(t, 2, count)
+}
+
+func TestFilePath(t *testing.T) {
+	tf.UnitTest(t)
+
+	base := t.TempDir()
+	outside := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(base, "daily"), 0o700))
+	require.NoError(t, os.Symlink(outside, filepath.Join(base, "link")))
+
+	for path, want := range map[string]string{
+		"node.tar.gz":          filepath.Join(base, "node.tar.gz"),
+		"daily/node.tar.gz":    filepath.Join(base, "daily", "node.tar.gz"),
+		"daily/../node.tar.gz": filepath.Join(base, "node.tar.gz"),
+		filepath.Join(base, "daily", "node.tar.gz"):   filepath.Join(base, "daily", "node.tar.gz"),
+		filepath.Join(base, "link", "..", "x.tar.gz"): filepath.Join(base, "x.tar.gz"),
+	} {
+		got, err := FilePath(base, path)
+		require.NoError(t, err, path)
+		assert.Equal(t, want, got, path)
+	}
+
+	for path, reason := range map[string]string{
+		"":                                    "required",
+		"../node.tar.gz":                      "outside",
+		filepath.Join(outside, "node.tar.gz"): "outside",
+		"link/node.tar.gz":                    "outside",
+		"daily/..":                            "outside",
+		string(filepath.Separator):            "not a file",
+		"missing/node.tar.gz":                 "resolving",
+	} {
+		_, err := FilePath(base, path)
+		assert.ErrorContains(t, err, reason, path)
+	}
+
+	_, err := FilePath("", "node.tar.gz")
+	assert.ErrorContains(t, err, BasePathEnv)
+}
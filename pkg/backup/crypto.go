@@ -0,0 +1,70 @@
+package backup
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// the scrypt parameters of the key encrypting a backup
+const (
+	scryptN     = 1 << 15
+	scryptR     = 8
+	scryptP     = 1
+	scryptDKLen = 32
+	saltLen     = 16
+)
+
+// encrypt seals data with AES-GCM, with a key derived from password by scrypt, the output is the salt, the
+// nonce and the sealed data.
+func encrypt(data, password []byte) ([]byte, error) {
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(password, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	out := append(salt, nonce...)
+	return aead.Seal(out, nonce, data, nil), nil
+}
+
+func decrypt(data, password []byte) ([]byte, error) {
+	if len(data) < saltLen {
+		return nil, fmt.Errorf("encrypted keys are too short")
+	}
+	aead, err := newAEAD(password, data[:saltLen])
+	if err != nil {
+		return nil, err
+	}
+	data = data[saltLen:]
+	if len(data) < aead.NonceSize() {
+		return nil, fmt.Errorf("encrypted keys are too short")
+	}
+	plain, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting the keys, wrong password")
+	}
+	return plain, nil
+}
+
+func newAEAD(password, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(password, salt, scryptN, scryptR, scryptP, scryptDKLen)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
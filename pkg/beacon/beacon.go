@@ -35,6 +35,16 @@ type RandomBeacon interface {
 	IsChained() bool
 }
 
+// maxHealthCheckTimeout bounds the time a health check waits for a beacon server.
+const maxHealthCheckTimeout = 10 * time.Second
+
+// BeaconMonitor is implemented by the beacons reporting the health of their servers.
+type BeaconMonitor interface {
+	// StartHealthCheck probes the servers every interval until ctx is done.
+	StartHealthCheck(ctx context.Context, interval time.Duration)
+	Status() types.BeaconStatus
+}
+
 // ValidateBlockValues Verify that the beacon in the block header is correct, first get beacon server at block epoch and parent block epoch in schedule.
 // if paraent beacon is the same beacon server. value beacon normally but if not equal, means that the pre entry in another beacon chain, so just validate
 // beacon value in current block header. the first values is parent beacon the second value is current beacon.
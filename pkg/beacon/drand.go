@@ -35,6 +35,8 @@ import (
 type DrandBeacon struct {
 	isChained bool
 	client    drand.Client
+	servers   *failoverClient
+	chainHash string
 
 	pubkey kyber.Point
 
@@ -82,13 +84,18 @@ func NewDrandBeacon(genTimeStamp, interval uint64, config cfg.DrandConf) (*Drand
 	}
 
 	var clients []drand.Client
-	for _, url := range config.Servers {
-		hc, err := hclient.NewWithInfo(&logger{&log.SugaredLogger}, url, drandChain, nil)
-		if err != nil {
-			return nil, fmt.Errorf("could not create http drand client: %w", err)
+	var servers *failoverClient
+	if len(config.Servers) > 0 {
+		servers = &failoverClient{}
+		for _, url := range config.Servers {
+			hc, err := hclient.NewWithInfo(&logger{&log.SugaredLogger}, url, drandChain, nil)
+			if err != nil {
+				return nil, fmt.Errorf("could not create http drand client: %w", err)
+			}
+			hc.SetUserAgent("drand-client-lotus/" + constants.UserVersion())
+			servers.servers = append(servers.servers, newDrandServer(url, hc))
 		}
-		hc.SetUserAgent("drand-client-lotus/" + constants.UserVersion())
-		clients = append(clients, hc)
+		clients = append(clients, servers)
 	}
 
 	opts := []dclient.Option{
@@ -116,6 +123,8 @@ func NewDrandBeacon(genTimeStamp, interval uint64, config cfg.DrandConf) (*Drand
 	db := &DrandBeacon{
 		isChained:  config.IsChained,
 		client:     client,
+		servers:    servers,
+		chainHash:  drandChain.HashString(),
 		localCache: lc,
 	}
 
@@ -165,6 +174,49 @@ func (db *DrandBeacon) Entry(ctx context.Context, round uint64) <-chan Response
 	return out
 }
 
+// StartHealthCheck probes the drand servers every interval until ctx is done, so the requests go
+// to the healthy servers first.
+func (db *DrandBeacon) StartHealthCheck(ctx context.Context, interval time.Duration) {
+	if db.servers == nil || interval <= 0 {
+		return
+	}
+
+	timeout := interval
+	if timeout > maxHealthCheckTimeout {
+		timeout = maxHealthCheckTimeout
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			db.servers.check(ctx, timeout)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Status returns the latest round fetched and the health of the drand servers.
+func (db *DrandBeacon) Status() types.BeaconStatus {
+	st := types.BeaconStatus{
+		ChainHash: db.chainHash,
+		IsChained: db.isChained,
+		Servers:   []types.BeaconServerStatus{},
+	}
+	if db.servers != nil {
+		st.Servers = db.servers.status()
+	}
+	for _, s := range st.Servers {
+		if s.LatestRound > st.LatestRound {
+			st.LatestRound = s.LatestRound
+		}
+	}
+	return st
+}
+
 func (db *DrandBeacon) cacheValue(e types.BeaconEntry) {
 	db.localCache.Add(e.Round, &e)
 }
@@ -227,4 +279,7 @@ func (db *DrandBeacon) maxBeaconRoundV2(latestTS uint64) uint64 {
 	return fromGenesis/uint64(db.interval.Seconds()) + 1
 }
 
-var _ RandomBeacon = (*DrandBeacon)(nil)
+var (
+	_ RandomBeacon  = (*DrandBeacon)(nil)
+	_ BeaconMonitor = (*DrandBeacon)(nil)
+)
@@ -0,0 +1,181 @@
+package beacon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	dchain "github.com/drand/drand/v2/common/chain"
+	drand "github.com/drand/go-clients/drand"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// drandServer is the client of a drand server, with the health seen by its latest request.
+type drandServer struct {
+	drand.Client
+	url string
+
+	lk          sync.Mutex
+	healthy     bool
+	latency     time.Duration
+	latestRound uint64
+	lastErr     error
+	lastCheck   time.Time
+}
+
+func newDrandServer(url string, client drand.Client) *drandServer {
+	return &drandServer{Client: client, url: url, healthy: true}
+}
+
+func (s *drandServer) record(round uint64, latency time.Duration, err error) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	s.lastCheck = time.Now()
+	s.lastErr = err
+	s.healthy = err == nil
+	if err != nil {
+		return
+	}
+	s.latency = latency
+	if round > s.latestRound {
+		s.latestRound = round
+	}
+}
+
+func (s *drandServer) status() types.BeaconServerStatus {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	st := types.BeaconServerStatus{
+		URL:         s.url,
+		Healthy:     s.healthy,
+		Latency:     s.latency,
+		LatestRound: s.latestRound,
+		LastCheck:   s.lastCheck,
+	}
+	if s.lastErr != nil {
+		st.LastError = s.lastErr.Error()
+	}
+	return st
+}
+
+// failoverClient sends the requests to the healthy drand servers first, fastest first,
+// and moves on to the next server when a request fails.
+type failoverClient struct {
+	servers []*drandServer
+}
+
+var _ drand.Client = (*failoverClient)(nil)
+
+// ordered returns the healthy servers by latency, followed by the unhealthy ones.
+func (fc *failoverClient) ordered() []*drandServer {
+	type candidate struct {
+		server  *drandServer
+		healthy bool
+		latency time.Duration
+	}
+	candidates := make([]candidate, 0, len(fc.servers))
+	for _, s := range fc.servers {
+		s.lk.Lock()
+		candidates = append(candidates, candidate{server: s, healthy: s.healthy, latency: s.latency})
+		s.lk.Unlock()
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].healthy != candidates[j].healthy {
+			return candidates[i].healthy
+		}
+		return candidates[i].latency < candidates[j].latency
+	})
+
+	out := make([]*drandServer, 0, len(candidates))
+	for _, c := range candidates {
+		out = append(out, c.server)
+	}
+	return out
+}
+
+func (fc *failoverClient) Get(ctx context.Context, round uint64) (drand.Result, error) {
+	var errs []error
+	for _, s := range fc.ordered() {
+		start := time.Now()
+		res, err := s.Get(ctx, round)
+		if err == nil {
+			s.record(res.GetRound(), time.Since(start), nil)
+			return res, nil
+		}
+		s.record(0, 0, err)
+		errs = append(errs, fmt.Errorf("%s: %w", s.url, err))
+		if ctx.Err() != nil {
+			break
+		}
+		log.Warnf("drand server %s failed to get round %d, trying next server: %v", s.url, round, err)
+	}
+	return nil, errors.Join(errs...)
+}
+
+func (fc *failoverClient) Watch(ctx context.Context) <-chan drand.Result {
+	return fc.ordered()[0].Watch(ctx)
+}
+
+func (fc *failoverClient) Info(ctx context.Context) (*dchain.Info, error) {
+	var errs []error
+	for _, s := range fc.ordered() {
+		info, err := s.Info(ctx)
+		if err == nil {
+			return info, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", s.url, err))
+	}
+	return nil, errors.Join(errs...)
+}
+
+func (fc *failoverClient) RoundAt(t time.Time) uint64 {
+	return fc.servers[0].RoundAt(t)
+}
+
+func (fc *failoverClient) Close() error {
+	var errs []error
+	for _, s := range fc.servers {
+		if err := s.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", s.url, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// check requests the latest round from every server, to find out which ones are healthy.
+func (fc *failoverClient) check(ctx context.Context, timeout time.Duration) {
+	var wg sync.WaitGroup
+	for _, s := range fc.servers {
+		wg.Add(1)
+		go func(s *drandServer) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			start := time.Now()
+			res, err := s.Get(ctx, 0)
+			if err != nil {
+				log.Warnf("drand server %s is unhealthy: %v", s.url, err)
+				s.record(0, 0, err)
+				return
+			}
+			s.record(res.GetRound(), time.Since(start), nil)
+		}(s)
+	}
+	wg.Wait()
+}
+
+func (fc *failoverClient) status() []types.BeaconServerStatus {
+	out := make([]types.BeaconServerStatus, 0, len(fc.servers))
+	for _, s := range fc.servers {
+		out = append(out, s.status())
+	}
+	return out
+}
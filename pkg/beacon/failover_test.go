@@ -0,0 +1,92 @@
+// stm: ignore
+package beacon
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	dchain "github.com/drand/drand/v2/common/chain"
+	drand "github.com/drand/go-clients/drand"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+type fakeResult struct {
+	round uint64
+}
+
+func (r fakeResult) GetRound() uint64             { return r.round }
+func (r fakeResult) GetRandomness() []byte        { return nil }
+func (r fakeResult) GetPreviousSignature() []byte { return nil }
+func (r fakeResult) GetSignature() []byte         { return []byte{byte(r.round)} }
+
+type fakeDrandClient struct {
+	latest uint64
+	err    error
+	calls  int
+}
+
+func (c *fakeDrandClient) Get(_ context.Context, round uint64) (drand.Result, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	if round == 0 {
+		round = c.latest
+	}
+	return fakeResult{round: round}, nil
+}
+
+func (c *fakeDrandClient) Watch(context.Context) <-chan drand.Result  { return nil }
+func (c *fakeDrandClient) Info(context.Context) (*dchain.Info, error) { return nil, c.err }
+func (c *fakeDrandClient) RoundAt(time.Time) uint64                   { return c.latest }
+func (c *fakeDrandClient) Close() error                               { return nil }
+
+func TestFailoverClient(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	down := &fakeDrandClient{err: errors.New("connection refused")}
+	up := &fakeDrandClient{latest: 10}
+	fc := &failoverClient{servers: []*drandServer{
+		newDrandServer("http://down", down),
+		newDrandServer("http://up", up),
+	}}
+
+	// the first server fails, the request goes to the next one
+	res, err := fc.Get(ctx, 5)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), res.GetRound())
+	assert.Equal(t, 1, down.calls)
+	assert.Equal(t, 1, up.calls)
+
+	// the unhealthy server is only tried after the healthy ones
+	_, err = fc.Get(ctx, 6)
+	require.NoError(t, err)
+	assert.Equal(t, 1, down.calls)
+	assert.Equal(t, 2, up.calls)
+
+	fc.check(ctx, time.Second)
+	st := fc.status()
+	require.Len(t, st, 2)
+	assert.False(t, st[0].Healthy)
+	assert.Equal(t, "connection refused", st[0].LastError)
+	assert.True(t, st[1].Healthy)
+	assert.Equal(t, uint64(10), st[1].LatestRound)
+
+	// the server is back after a successful health check
+	down.err = nil
+	down.latest = 11
+	fc.check(ctx, time.Second)
+	assert.True(t, fc.status()[0].Healthy)
+
+	// every server failing fails the request
+	down.err = errors.New("timeout")
+	up.err = errors.New("timeout")
+	_, err = fc.Get(ctx, 7)
+	assert.Error(t, err)
+}
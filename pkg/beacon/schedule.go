@@ -1,12 +1,15 @@
 package beacon
 
 import (
+	"context"
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/filecoin-project/go-state-types/abi"
 
 	cfg "github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/venus-shared/types"
 )
 
 type Schedule []BeaconPoint
@@ -22,12 +25,15 @@ func (bs Schedule) BeaconForEpoch(e abi.ChainEpoch) RandomBeacon {
 	return bs[0].Beacon
 }
 
-// DrandConfigSchedule create new beacon schedule , used to select beacon server at specify chain height
-func DrandConfigSchedule(genTimeStamp uint64, blockDelay uint64, drandSchedule map[abi.ChainEpoch]cfg.DrandEnum) (Schedule, error) {
+// DrandConfigSchedule create new beacon schedule , used to select beacon server at specify chain height,
+// the extraServers are used along with the servers of every drand network.
+func DrandConfigSchedule(genTimeStamp uint64, blockDelay uint64, drandSchedule map[abi.ChainEpoch]cfg.DrandEnum, extraServers []string) (Schedule, error) {
 	shd := Schedule{}
 
 	for start, config := range drandSchedule {
-		bc, err := NewDrandBeacon(genTimeStamp, blockDelay, cfg.DrandConfigs[config])
+		drandCfg := cfg.DrandConfigs[config]
+		drandCfg.Servers = append(append([]string{}, drandCfg.Servers...), extraServers...)
+		bc, err := NewDrandBeacon(genTimeStamp, blockDelay, drandCfg)
 		if err != nil {
 			return nil, fmt.Errorf("creating drand beacon: %v", err)
 		}
@@ -41,3 +47,25 @@ func DrandConfigSchedule(genTimeStamp uint64, blockDelay uint64, drandSchedule m
 	log.Infof("Schedule: %v", shd)
 	return shd, nil
 }
+
+// StartHealthCheck probes the servers of the beacons every interval until ctx is done.
+func (bs Schedule) StartHealthCheck(ctx context.Context, interval time.Duration) {
+	for _, bp := range bs {
+		if m, ok := bp.Beacon.(BeaconMonitor); ok {
+			m.StartHealthCheck(ctx, interval)
+		}
+	}
+}
+
+// Status returns the status of the beacons reporting it, in schedule order.
+func (bs Schedule) Status() []types.BeaconStatus {
+	out := make([]types.BeaconStatus, 0, len(bs))
+	for _, bp := range bs {
+		if m, ok := bp.Beacon.(BeaconMonitor); ok {
+			st := m.Status()
+			st.Start = bp.Start
+			out = append(out, st)
+		}
+	}
+	return out
+}
@@ -0,0 +1,160 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"runtime"
+	"sync/atomic"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	carv2 "github.com/ipld/go-car/v2"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+const defaultImportBatchSize = 16 << 20
+
+// ImportOptions tunes the snapshot import, the zero value picks the defaults.
+type ImportOptions struct {
+	// Workers is the number of goroutines verifying and writing the blocks, defaults to the number of CPUs.
+	Workers int
+	// BatchSize is the size in bytes of the blocks written to the blockstore at once, defaults to 16MiB.
+	BatchSize int
+	// Progress is called after each written batch with the number of blocks and bytes imported so far.
+	Progress func(blocks, bytes int64)
+}
+
+func (opts ImportOptions) withDefaults() ImportOptions {
+	if opts.Workers <= 0 {
+		opts.Workers = runtime.NumCPU()
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultImportBatchSize
+	}
+	if opts.Progress == nil {
+		opts.Progress = func(int64, int64) {}
+	}
+	return opts
+}
+
+type importBatch struct {
+	blks []blocks.Block
+	size int
+}
+
+// importBlocks writes the blocks of the car to the blockstore and returns its roots with the
+// genesis block, found by following the parents of the first root. The car is read sequentially,
+// the hashes are verified and the batches written by the workers, the reader blocks while all
+// of them are busy so memory stays bounded by about two batches per worker.
+func (store *Store) importBlocks(ctx context.Context, r io.Reader, opts ImportOptions) ([]cid.Cid, *types.BlockHeader, error) {
+	opts = opts.withDefaults()
+
+	// hashes are verified by the workers
+	br, err := carv2.NewBlockReader(r, carv2.WithTrustedCAR(true))
+	if err != nil {
+		return nil, nil, fmt.Errorf("loadcar failed: %w", err)
+	}
+	if len(br.Roots) == 0 {
+		return nil, nil, fmt.Errorf("no roots in snapshot car file")
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	batches := make(chan importBatch, opts.Workers)
+
+	var importedBlocks, importedBytes atomic.Int64
+	for i := 0; i < opts.Workers; i++ {
+		g.Go(func() error {
+			for batch := range batches {
+				for _, blk := range batch.blks {
+					if err := verifyBlock(blk); err != nil {
+						return err
+					}
+				}
+				if err := store.bsstore.PutMany(gctx, batch.blks); err != nil {
+					return err
+				}
+				opts.Progress(importedBlocks.Add(int64(len(batch.blks))), importedBytes.Add(int64(batch.size)))
+			}
+			return nil
+		})
+	}
+
+	var tailBlock types.BlockHeader
+	tailBlock.Height = abi.ChainEpoch(-1)
+	g.Go(func() error {
+		defer close(batches)
+
+		nextTailCid := br.Roots[0]
+		var batch importBatch
+		send := func() error {
+			select {
+			case batches <- batch:
+				batch = importBatch{}
+				return nil
+			case <-gctx.Done():
+				return gctx.Err()
+			}
+		}
+		for {
+			blk, err := br.Next()
+			if err != nil {
+				if err == io.EOF {
+					break
+				}
+				return err
+			}
+
+			// check for header block, looking for genesis
+			if blk.Cid() == nextTailCid && tailBlock.Height != 0 {
+				if err := verifyBlock(blk); err != nil {
+					return err
+				}
+				if err := tailBlock.UnmarshalCBOR(bytes.NewReader(blk.RawData())); err != nil {
+					return fmt.Errorf("failed to unmarshal genesis block: %w", err)
+				}
+				if len(tailBlock.Parents) > 0 {
+					nextTailCid = tailBlock.Parents[0]
+				} else {
+					// note: even the 0th block has a parent linking to the cbor genesis block
+					return fmt.Errorf("current block (epoch %d cid %s) has no parents", tailBlock.Height, tailBlock.Cid())
+				}
+			}
+
+			batch.blks = append(batch.blks, blk)
+			batch.size += len(blk.RawData())
+			if batch.size >= opts.BatchSize {
+				if err := send(); err != nil {
+					return err
+				}
+			}
+		}
+		if len(batch.blks) > 0 {
+			return send()
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, nil, err
+	}
+	if tailBlock.Height != 0 {
+		return nil, nil, fmt.Errorf("expected genesis block to have height 0 (genesis), got %d: %s", tailBlock.Height, tailBlock.Cid())
+	}
+	return br.Roots, &tailBlock, nil
+}
+
+func verifyBlock(blk blocks.Block) error {
+	hashed, err := blk.Cid().Prefix().Sum(blk.RawData())
+	if err != nil {
+		return err
+	}
+	if !hashed.Equals(blk.Cid()) {
+		return fmt.Errorf("mismatch in content integrity, expected: %s, got: %s", blk.Cid(), hashed)
+	}
+	return nil
+}
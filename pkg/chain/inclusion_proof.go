@@ -0,0 +1,174 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	amt4 "github.com/filecoin-project/go-amt-ipld/v4"
+	"github.com/filecoin-project/specs-actors/actors/util/adt"
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// recordingBlockstore is a read only blockstore recording the blocks read through it.
+type recordingBlockstore struct {
+	bs     blockstoreutil.Blockstore
+	seen   map[cid.Cid]struct{}
+	blocks []types.ProofBlock
+}
+
+func newRecordingBlockstore(bs blockstoreutil.Blockstore) *recordingBlockstore {
+	return &recordingBlockstore{bs: bs, seen: make(map[cid.Cid]struct{})}
+}
+
+func (rb *recordingBlockstore) Get(ctx context.Context, c cid.Cid) (blocks.Block, error) {
+	blk, err := rb.bs.Get(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := rb.seen[c]; !ok {
+		rb.seen[c] = struct{}{}
+		rb.blocks = append(rb.blocks, types.ProofBlock{Cid: c, Data: blk.RawData()})
+	}
+	return blk, nil
+}
+
+func (rb *recordingBlockstore) Put(context.Context, blocks.Block) error {
+	return fmt.Errorf("recording blockstore is read only")
+}
+
+// ProveReceipt loads the receipt at index of the receipts AMT root, and returns it with the AMT
+// nodes on the path from root to the receipt.
+func ProveReceipt(ctx context.Context, bs blockstoreutil.Blockstore, root cid.Cid, index uint64) (*types.MessageReceipt, []types.ProofBlock, error) {
+	rb := newRecordingBlockstore(bs)
+	arr, err := adt.AsArray(adt.WrapStore(ctx, cbor.NewCborStore(rb)), root)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load receipts amt %s: %w", root, err)
+	}
+
+	var rec types.MessageReceipt
+	found, err := arr.Get(index, &rec)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load receipt %d: %w", index, err)
+	}
+	if !found {
+		return nil, nil, fmt.Errorf("receipt %d not found in %s", index, root)
+	}
+	return &rec, rb.blocks, nil
+}
+
+// ProveEvent loads the event at index of the events AMT root, and returns it with the AMT
+// nodes on the path from root to the event.
+func ProveEvent(ctx context.Context, bs blockstoreutil.Blockstore, root cid.Cid, index uint64) (*types.Event, []types.ProofBlock, error) {
+	rb := newRecordingBlockstore(bs)
+	arr, err := amt4.LoadAMT(ctx, cbor.NewCborStore(rb), root, amt4.UseTreeBitWidth(types.EventAMTBitwidth))
+	if err != nil {
+		return nil, nil, fmt.Errorf("load events amt %s: %w", root, err)
+	}
+
+	var evt types.Event
+	found, err := arr.Get(ctx, index, &evt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load event %d: %w", index, err)
+	}
+	if !found {
+		return nil, nil, fmt.Errorf("event %d not found in %s", index, root)
+	}
+	return &evt, rb.blocks, nil
+}
+
+// VerifyReceiptProof checks that the receipt of proof is committed to by proof.Header, and that
+// the header is part of proof.TipSet. It doesn't check that the tipset itself is canonical,
+// neither that proof.Message is the message at proof.Index, the caller must trust proof.TipSet.
+func VerifyReceiptProof(ctx context.Context, proof *types.ReceiptInclusionProof) error {
+	if proof.Header == nil {
+		return fmt.Errorf("missing block header")
+	}
+	headerCid := proof.Header.Cid()
+	inTipSet := false
+	for _, c := range proof.TipSet.Cids() {
+		if c == headerCid {
+			inTipSet = true
+			break
+		}
+	}
+	if !inTipSet {
+		return fmt.Errorf("block %s is not part of tipset %s", headerCid, proof.TipSet)
+	}
+	if proof.Header.ParentMessageReceipts != proof.ReceiptsRoot {
+		return fmt.Errorf("receipts root %s doesn't match the block receipts root %s", proof.ReceiptsRoot, proof.Header.ParentMessageReceipts)
+	}
+
+	bs, err := proofBlockstore(ctx, proof.Blocks)
+	if err != nil {
+		return err
+	}
+	rec, _, err := ProveReceipt(ctx, bs, proof.ReceiptsRoot, proof.Index)
+	if err != nil {
+		return fmt.Errorf("invalid receipt proof: %w", err)
+	}
+	return sameCBOR(rec, &proof.Receipt, "receipt")
+}
+
+// VerifyEventProof checks the receipt proof of proof, then that the event is part of the events
+// of the receipt.
+func VerifyEventProof(ctx context.Context, proof *types.EventInclusionProof) error {
+	if err := VerifyReceiptProof(ctx, &proof.Receipt); err != nil {
+		return err
+	}
+	if proof.Receipt.Receipt.EventsRoot == nil || *proof.Receipt.Receipt.EventsRoot != proof.EventsRoot {
+		return fmt.Errorf("events root %s doesn't match the receipt events root", proof.EventsRoot)
+	}
+
+	bs, err := proofBlockstore(ctx, proof.Blocks)
+	if err != nil {
+		return err
+	}
+	evt, _, err := ProveEvent(ctx, bs, proof.EventsRoot, proof.Index)
+	if err != nil {
+		return fmt.Errorf("invalid event proof: %w", err)
+	}
+	return sameCBOR(evt, &proof.Event, "event")
+}
+
+// proofBlockstore returns a blockstore holding the proof blocks, after checking their data hash to their cid.
+func proofBlockstore(ctx context.Context, proofBlocks []types.ProofBlock) (blockstoreutil.Blockstore, error) {
+	bs := blockstoreutil.NewMemory()
+	for _, pb := range proofBlocks {
+		c, err := pb.Cid.Prefix().Sum(pb.Data)
+		if err != nil {
+			return nil, fmt.Errorf("hash proof block %s: %w", pb.Cid, err)
+		}
+		if c != pb.Cid {
+			return nil, fmt.Errorf("proof block data doesn't match its cid %s", pb.Cid)
+		}
+		blk, err := blocks.NewBlockWithCid(pb.Data, pb.Cid)
+		if err != nil {
+			return nil, err
+		}
+		if err := bs.Put(ctx, blk); err != nil {
+			return nil, err
+		}
+	}
+	return bs, nil
+}
+
+func sameCBOR(proven, claimed cbg.CBORMarshaler, what string) error {
+	var a, b bytes.Buffer
+	if err := proven.MarshalCBOR(&a); err != nil {
+		return err
+	}
+	if err := claimed.MarshalCBOR(&b); err != nil {
+		return err
+	}
+	if !bytes.Equal(a.Bytes(), b.Bytes()) {
+		return fmt.Errorf("proven %s doesn't match the claimed %s", what, what)
+	}
+	return nil
+}
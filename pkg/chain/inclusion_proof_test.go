@@ -0,0 +1,117 @@
+package chain_test
+
+import (
+	"context"
+	"testing"
+
+	amt4 "github.com/filecoin-project/go-amt-ipld/v4"
+	"github.com/filecoin-project/go-state-types/abi"
+	tbig "github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/ipfs/go-datastore"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/testutil"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestInclusionProofs(t *testing.T) {
+	testflags.UnitTest(t)
+	ctx := context.Background()
+	bs := blockstoreutil.NewBlockstore(datastore.NewMapDatastore())
+	ms := chain.NewMessageStore(bs, config.DefaultForkUpgradeParam)
+
+	events, err := amt4.NewAMT(cbor.NewCborStore(bs), amt4.UseTreeBitWidth(types.EventAMTBitwidth))
+	require.NoError(t, err)
+	for i := 0; i < 50; i++ {
+		evt := types.Event{
+			Emitter: abi.ActorID(1000 + i),
+			Entries: []types.EventEntry{{Flags: 0x01, Key: "t1", Codec: 0x55, Value: []byte{byte(i)}}},
+		}
+		require.NoError(t, events.Set(ctx, uint64(i), &evt))
+	}
+	eventsRoot, err := events.Flush(ctx)
+	require.NoError(t, err)
+
+	// enough receipts for the AMT to have several levels
+	receipts := make([]types.MessageReceipt, 100)
+	for i := range receipts {
+		receipts[i] = types.NewMessageReceiptV1(exitcode.Ok, []byte{byte(i)}, int64(i), nil)
+	}
+	receipts[42] = types.NewMessageReceiptV1(exitcode.Ok, nil, 42, &eventsRoot)
+	receiptsRoot, err := ms.StoreReceipts(ctx, receipts)
+	require.NoError(t, err)
+
+	var header types.BlockHeader
+	testutil.Provide(t, &header)
+	header.ParentMessageReceipts = receiptsRoot
+	header.ParentWeight = tbig.NewInt(1)
+	header.ParentBaseFee = tbig.NewInt(1)
+
+	rec, recBlocks, err := chain.ProveReceipt(ctx, bs, receiptsRoot, 42)
+	require.NoError(t, err)
+	assert.Equal(t, receipts[42], *rec)
+	require.NotEmpty(t, recBlocks)
+
+	recProof := types.ReceiptInclusionProof{
+		TipSet:       types.NewTipSetKey(header.Cid()),
+		Header:       &header,
+		ReceiptsRoot: receiptsRoot,
+		Index:        42,
+		Receipt:      *rec,
+		Blocks:       recBlocks,
+	}
+	require.NoError(t, chain.VerifyReceiptProof(ctx, &recProof))
+
+	evt, evtBlocks, err := chain.ProveEvent(ctx, bs, eventsRoot, 7)
+	require.NoError(t, err)
+	assert.Equal(t, abi.ActorID(1007), evt.Emitter)
+
+	evtProof := types.EventInclusionProof{
+		Receipt:    recProof,
+		EventsRoot: eventsRoot,
+		Index:      7,
+		Event:      *evt,
+		Blocks:     evtBlocks,
+	}
+	require.NoError(t, chain.VerifyEventProof(ctx, &evtProof))
+
+	t.Run("tampered receipt", func(t *testing.T) {
+		bad := recProof
+		bad.Receipt.GasUsed++
+		assert.Error(t, chain.VerifyReceiptProof(ctx, &bad))
+	})
+
+	t.Run("wrong index", func(t *testing.T) {
+		bad := recProof
+		bad.Index = 43
+		assert.Error(t, chain.VerifyReceiptProof(ctx, &bad))
+	})
+
+	t.Run("header not in tipset", func(t *testing.T) {
+		bad := recProof
+		bad.TipSet = types.NewTipSetKey(receiptsRoot)
+		assert.Error(t, chain.VerifyReceiptProof(ctx, &bad))
+	})
+
+	t.Run("tampered proof block", func(t *testing.T) {
+		bad := recProof
+		bad.Blocks = append([]types.ProofBlock{}, recBlocks...)
+		data := append([]byte{}, bad.Blocks[0].Data...)
+		data[len(data)-1] ^= 0xff
+		bad.Blocks[0].Data = data
+		assert.Error(t, chain.VerifyReceiptProof(ctx, &bad))
+	})
+
+	t.Run("tampered event", func(t *testing.T) {
+		bad := evtProof
+		bad.Event.Emitter++
+		assert.Error(t, chain.VerifyEventProof(ctx, &bad))
+	})
+}
@@ -0,0 +1,424 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ipfs/boxo/datastore/dshelp"
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+	"github.com/ipfs/go-datastore/query"
+	ipld "github.com/ipfs/go-ipld-format"
+	"github.com/multiformats/go-multicodec"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin"
+
+	"github.com/filecoin-project/venus/pkg/constants"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// pruneBatchSize is the number of objects deleted from the blockstore at once.
+const pruneBatchSize = 1024
+
+var (
+	pruneMarkPrefix    = ds.NewKey("/prune/mark")
+	pruneWrittenPrefix = ds.NewKey("/prune/written")
+	pruneSweepPrefix   = ds.NewKey("/prune/sweep")
+)
+
+// ErrPruneRunning is returned when a prune or a blockstore gc is started while another one is running.
+var ErrPruneRunning = errors.New("a prune or a blockstore gc is already running")
+
+// TryLockPrune takes the lock held while pruning, so that the blockstore gc and the prunes don't overlap.
+// It returns ErrPruneRunning when the lock is already held.
+func (store *Store) TryLockPrune() (unlock func(), err error) {
+	if !store.pruneLk.TryLock() {
+		return nil, ErrPruneRunning
+	}
+	return store.pruneLk.Unlock, nil
+}
+
+// Prune deletes from the blockstore the messages, receipts and state objects of the tipsets of the chain
+// of head below height which are not referenced by the tipsets at or above height, by the computed state
+// of head, nor by the genesis. The block headers are kept, so the chain can still be walked back to the
+// genesis. With dryRun the objects are only counted.
+//
+// The objects of the kept tipsets are walked first, pruning is aborted when any of them is missing,
+// eg. when height is below the state imported from a snapshot. The objects already pruned are skipped.
+// The sets of walked objects are kept in the metadata datastore rather than in memory.
+//
+// The chain keeps syncing while pruning: the objects written or read back through the PruneBlockstore of
+// the store since the prune started, such as the states computed for the new tipsets and the blocks fetched
+// by bitswap, are marked and never deleted. Only a single prune or blockstore gc runs at a time, see TryLockPrune.
+func (store *Store) Prune(ctx context.Context, head *types.TipSet, height abi.ChainEpoch, dryRun bool) (*types.ChainPruneResult, error) {
+	if height <= 0 || height > head.Height() {
+		return nil, fmt.Errorf("prune height %d out of the chain of height %d", height, head.Height())
+	}
+	unlock, err := store.TryLockPrune()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	var sets []*pruneSet
+	defer func() {
+		for _, set := range sets {
+			if err := set.clear(context.Background()); err != nil {
+				log.Warnf("clearing prune set: %v", err)
+			}
+		}
+	}()
+	for _, prefix := range []ds.Key{pruneMarkPrefix, pruneWrittenPrefix, pruneSweepPrefix} {
+		set, err := newPruneSet(ctx, store.ds, prefix)
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, set)
+	}
+	marked, written, swept := sets[0], sets[1], sets[2]
+
+	if !dryRun {
+		store.pruneBs.protect(written)
+		defer store.pruneBs.protect(nil)
+	}
+
+	res := &types.ChainPruneResult{Height: height, DryRun: dryRun}
+	start := constants.Clock.Now()
+	log.Infow("prune started", "height", height, "dryRun", dryRun)
+
+	roots := func(ts *types.TipSet) []cid.Cid {
+		var out []cid.Cid
+		for _, b := range ts.Blocks() {
+			out = append(out, b.Messages, b.ParentStateRoot, b.ParentMessageReceipts)
+		}
+		return out
+	}
+
+	// mark the objects to keep
+	enterKept := func(c cid.Cid) (bool, error) {
+		return marked.visit(ctx, c)
+	}
+	mark := func(c cid.Cid, blk blocks.Block) error {
+		if blk == nil {
+			return fmt.Errorf("object %s of the kept chain is missing, the prune height may be below the imported state", c)
+		}
+		return nil
+	}
+	genesis, err := store.GetGenesisBlock(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading genesis block: %w", err)
+	}
+	keep := []cid.Cid{genesis.Messages, genesis.ParentStateRoot, genesis.ParentMessageReceipts}
+	// the state computed on head is only referenced by the tipsets which are not synced yet
+	if meta, err := store.tipIndex.Get(ctx, head); err == nil {
+		keep = append(keep, meta.StateRoot, meta.Receipts)
+	}
+
+	cur := head
+	for cur.Height() >= height {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		keep = append(keep, roots(cur)...)
+		for _, root := range keep {
+			if err := store.pruneWalk(ctx, root, enterKept, mark); err != nil {
+				return nil, err
+			}
+		}
+		keep = keep[:0]
+
+		if cur, err = store.GetTipSet(ctx, cur.Parents()); err != nil {
+			return nil, fmt.Errorf("loading parent tipset failed: %w", err)
+		}
+	}
+
+	// sweep the objects of the tipsets below height which are not marked
+	var batch []cid.Cid
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		n, err := store.pruneBs.deleteUnwritten(ctx, written, batch)
+		if err != nil {
+			return fmt.Errorf("deleting pruned objects: %w", err)
+		}
+		if n < len(batch) {
+			log.Debugw("prune kept objects written while sweeping", "objects", len(batch)-n)
+		}
+		batch = batch[:0]
+		return nil
+	}
+	enter := func(c cid.Cid) (bool, error) {
+		for _, kept := range []*pruneSet{marked, written} {
+			if has, err := kept.has(ctx, c); err != nil || has {
+				return false, err
+			}
+		}
+		return swept.visit(ctx, c)
+	}
+	sweep := func(c cid.Cid, blk blocks.Block) error {
+		if blk == nil {
+			// pruned already
+			return nil
+		}
+		res.Objects++
+		res.Bytes += int64(len(blk.RawData()))
+		if dryRun {
+			return nil
+		}
+		batch = append(batch, c)
+		if len(batch) < pruneBatchSize {
+			return nil
+		}
+		return flush()
+	}
+
+	for cur.Height() > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		for _, root := range roots(cur) {
+			if err := store.pruneWalk(ctx, root, enter, sweep); err != nil {
+				return nil, err
+			}
+		}
+		res.Tipsets++
+		if cur.Height()%builtin.EpochsInDay == 0 {
+			log.Infow("prune", "height", cur.Height(), "objects", res.Objects, "bytes", res.Bytes)
+		}
+
+		if cur, err = store.GetTipSet(ctx, cur.Parents()); err != nil {
+			return nil, fmt.Errorf("loading parent tipset failed: %w", err)
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	res.Took = constants.Clock.Since(start)
+	log.Infow("prune finished", "height", height, "dryRun", dryRun, "objects", res.Objects, "bytes", res.Bytes, "duration", res.Took.Seconds())
+
+	return res, nil
+}
+
+// pruneWalk walks the dag from c, entering the objects for which enter returns true, and calls leave with
+// each of them after their links, with a nil block when the object is missing from the blockstore.
+// Only the raw and cbor objects are walked, like in the snapshots.
+func (store *Store) pruneWalk(ctx context.Context, c cid.Cid, enter func(cid.Cid) (bool, error), leave func(cid.Cid, blocks.Block) error) error {
+	prefix := c.Prefix()
+	if multicodec.Code(prefix.MhType) == multicodec.Identity {
+		return nil
+	}
+	switch multicodec.Code(prefix.Codec) {
+	case multicodec.Cbor, multicodec.DagCbor, multicodec.Raw:
+	default:
+		return nil
+	}
+	if ok, err := enter(c); err != nil || !ok {
+		return err
+	}
+
+	// read below the PruneBlockstore, the objects walked aren't accessed by a writer
+	blk, err := store.pruneBs.Blockstore.Get(ctx, c)
+	if err != nil {
+		if ipld.IsNotFound(err) {
+			return leave(c, nil)
+		}
+		return fmt.Errorf("getting object %s: %w", c, err)
+	}
+
+	if multicodec.Code(prefix.Codec) == multicodec.DagCbor {
+		var rerr error
+		err = cbg.ScanForLinks(bytes.NewReader(blk.RawData()), func(link cid.Cid) {
+			if rerr != nil {
+				return
+			}
+			rerr = store.pruneWalk(ctx, link, enter, leave)
+		})
+		if err != nil {
+			return fmt.Errorf("scanning for links failed: %w", err)
+		}
+		if rerr != nil {
+			return rerr
+		}
+	}
+
+	return leave(c, blk)
+}
+
+// pruneSet is a set of objects stored under a prefix of a datastore, keyed by multihash like the
+// blockstores, so that the sets of a prune don't have to fit in memory.
+type pruneSet struct {
+	ds ds.Batching
+}
+
+// newPruneSet returns the set stored under prefix of base, emptied from what an interrupted prune left.
+func newPruneSet(ctx context.Context, base ds.Batching, prefix ds.Key) (*pruneSet, error) {
+	s := &pruneSet{ds: namespace.Wrap(base, prefix)}
+	if err := s.clear(ctx); err != nil {
+		return nil, fmt.Errorf("clearing prune set %s: %w", prefix, err)
+	}
+	return s, nil
+}
+
+func (s *pruneSet) has(ctx context.Context, c cid.Cid) (bool, error) {
+	return s.ds.Has(ctx, dshelp.MultihashToDsKey(c.Hash()))
+}
+
+func (s *pruneSet) add(ctx context.Context, c cid.Cid) error {
+	return s.ds.Put(ctx, dshelp.MultihashToDsKey(c.Hash()), nil)
+}
+
+// visit adds c to the set, and returns whether it was not in it yet.
+func (s *pruneSet) visit(ctx context.Context, c cid.Cid) (bool, error) {
+	has, err := s.has(ctx, c)
+	if err != nil || has {
+		return false, err
+	}
+	return true, s.add(ctx, c)
+}
+
+func (s *pruneSet) clear(ctx context.Context) error {
+	res, err := s.ds.Query(ctx, query.Query{KeysOnly: true})
+	if err != nil {
+		return err
+	}
+	defer res.Close() // nolint:errcheck
+
+	batch, err := s.ds.Batch(ctx)
+	if err != nil {
+		return err
+	}
+	n := 0
+	for r := range res.Next() {
+		if r.Error != nil {
+			return r.Error
+		}
+		if err := batch.Delete(ctx, ds.NewKey(r.Key)); err != nil {
+			return err
+		}
+		if n++; n%pruneBatchSize == 0 {
+			if err := batch.Commit(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	return batch.Commit(ctx)
+}
+
+// PruneBlockstore adds the objects written, or read back by the writers, to the blockstore during a prune to
+// a set, so that the objects referenced again by the states computed meanwhile are not swept. Every writer of
+// the chain objects must go through the same PruneBlockstore as the Store.
+type PruneBlockstore struct {
+	blockstoreutil.Blockstore
+
+	// lk orders the accesses and the deletions of the sweep
+	lk      sync.RWMutex
+	written *pruneSet
+}
+
+// NewPruneBlockstore wraps bs to protect its writes during the prunes.
+func NewPruneBlockstore(bs blockstoreutil.Blockstore) *PruneBlockstore {
+	return &PruneBlockstore{Blockstore: bs}
+}
+
+// protect adds the objects accessed from now on to written, a nil set stops adding them.
+func (bs *PruneBlockstore) protect(written *pruneSet) {
+	bs.lk.Lock()
+	defer bs.lk.Unlock()
+	bs.written = written
+}
+
+// track adds cids to written while protecting, with lk held. The objects a writer found in the blockstore,
+// eg. bitswap or the blockservice skipping the blocks they have already, are protected like the written ones.
+func (bs *PruneBlockstore) track(ctx context.Context, cids ...cid.Cid) error {
+	if bs.written == nil {
+		return nil
+	}
+	for _, c := range cids {
+		if err := bs.written.add(ctx, c); err != nil {
+			return fmt.Errorf("marking object accessed while pruning: %w", err)
+		}
+	}
+	return nil
+}
+
+func (bs *PruneBlockstore) Has(ctx context.Context, c cid.Cid) (bool, error) {
+	bs.lk.RLock()
+	defer bs.lk.RUnlock()
+	has, err := bs.Blockstore.Has(ctx, c)
+	if err != nil || !has {
+		return has, err
+	}
+	return true, bs.track(ctx, c)
+}
+
+func (bs *PruneBlockstore) Get(ctx context.Context, c cid.Cid) (blocks.Block, error) {
+	bs.lk.RLock()
+	defer bs.lk.RUnlock()
+	blk, err := bs.Blockstore.Get(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	return blk, bs.track(ctx, c)
+}
+
+func (bs *PruneBlockstore) View(ctx context.Context, c cid.Cid, callback func([]byte) error) error {
+	bs.lk.RLock()
+	defer bs.lk.RUnlock()
+	if err := bs.Blockstore.View(ctx, c, callback); err != nil {
+		return err
+	}
+	return bs.track(ctx, c)
+}
+
+func (bs *PruneBlockstore) Put(ctx context.Context, blk blocks.Block) error {
+	bs.lk.RLock()
+	defer bs.lk.RUnlock()
+	if err := bs.track(ctx, blk.Cid()); err != nil {
+		return err
+	}
+	return bs.Blockstore.Put(ctx, blk)
+}
+
+func (bs *PruneBlockstore) PutMany(ctx context.Context, blks []blocks.Block) error {
+	bs.lk.RLock()
+	defer bs.lk.RUnlock()
+	for _, blk := range blks {
+		if err := bs.track(ctx, blk.Cid()); err != nil {
+			return err
+		}
+	}
+	return bs.Blockstore.PutMany(ctx, blks)
+}
+
+// deleteUnwritten deletes the objects of cids which are not in written, the accesses wait for the deletion
+// so that an object is either added to written before or found missing after it. It returns the number of
+// deleted objects.
+func (bs *PruneBlockstore) deleteUnwritten(ctx context.Context, written *pruneSet, cids []cid.Cid) (int, error) {
+	bs.lk.Lock()
+	defer bs.lk.Unlock()
+
+	unwritten := make([]cid.Cid, 0, len(cids))
+	for _, c := range cids {
+		has, err := written.has(ctx, c)
+		if err != nil {
+			return 0, err
+		}
+		if !has {
+			unwritten = append(unwritten, c)
+		}
+	}
+	if len(unwritten) == 0 {
+		return 0, nil
+	}
+	return len(unwritten), bs.Blockstore.DeleteMany(ctx, unwritten)
+}
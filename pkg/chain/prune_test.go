@@ -0,0 +1,272 @@
+package chain_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	builtin2 "github.com/filecoin-project/specs-actors/v2/actors/builtin"
+	"github.com/ipfs/boxo/blockservice"
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/multiformats/go-multicodec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/pkg/consensus/chainselector"
+	"github.com/filecoin-project/venus/pkg/repo"
+	"github.com/filecoin-project/venus/pkg/state/tree"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+const pruneActorCount, pruneChainHeight, pruneHeight = 50, 20, 10
+
+// pruneChain is a chain of pruneChainHeight tipsets, the states of which update the balance of one of
+// their actors each.
+type pruneChain struct {
+	store    *chain.Store
+	mstore   *chain.MessageStore
+	cst      cbor.IpldStore
+	head     *types.TipSet
+	txMeta   cid.Cid
+	receipts cid.Cid
+	// states[i] is the parent state of the tipset at i
+	states []cid.Cid
+}
+
+// newPruneChain builds the chain on bs, the state computed on its head is the one of the tipset at 1, only
+// referenced by the tipset index.
+func newPruneChain(ctx context.Context, t *testing.T, r repo.Repo, bs blockstoreutil.Blockstore) *pruneChain {
+	pc := &pruneChain{cst: cbor.NewCborStore(bs)}
+	var err error
+
+	pc.mstore = chain.NewMessageStore(bs, config.DefaultForkUpgradeParam)
+	pc.txMeta, err = pc.mstore.StoreMessages(ctx, nil, nil)
+	require.NoError(t, err)
+	pc.receipts, err = pc.mstore.StoreReceipts(ctx, nil)
+	require.NoError(t, err)
+
+	st, err := tree.NewState(pc.cst, tree.StateTreeVersion5)
+	require.NoError(t, err)
+	for i := 0; i < pruneActorCount; i++ {
+		setPruneActor(ctx, t, pc.cst, st, i, 0)
+	}
+	for i := 0; i <= pruneChainHeight; i++ {
+		if i > 0 {
+			setPruneActor(ctx, t, pc.cst, st, i, int64(i))
+		}
+		root, err := st.Flush(ctx)
+		require.NoError(t, err)
+		pc.states = append(pc.states, root)
+	}
+
+	miner, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+	var tipsets []*types.TipSet
+	for i := 0; i <= pruneChainHeight; i++ {
+		blk := &types.BlockHeader{
+			Miner:                 miner,
+			Ticket:                &types.Ticket{VRFProof: []byte{byte(i)}},
+			ParentWeight:          big.NewInt(int64(i)),
+			Height:                abi.ChainEpoch(i),
+			ParentStateRoot:       pc.states[i],
+			ParentMessageReceipts: pc.receipts,
+			Messages:              pc.txMeta,
+			ParentBaseFee:         big.Zero(),
+		}
+		if i > 0 {
+			blk.Parents = tipsets[i-1].Cids()
+		}
+		_, err := pc.cst.Put(ctx, blk)
+		require.NoError(t, err)
+		ts, err := types.NewTipSet([]*types.BlockHeader{blk})
+		require.NoError(t, err)
+		tipsets = append(tipsets, ts)
+	}
+	pc.head = tipsets[pruneChainHeight]
+
+	pc.store = chain.NewStore(r.ChainDatastore(), bs, tipsets[0].At(0).Cid(), chainselector.Weight)
+	for i, ts := range tipsets {
+		computed := pc.states[1]
+		if i < pruneChainHeight {
+			computed = pc.states[i+1]
+		}
+		require.NoError(t, pc.store.PutTipSetMetadata(ctx, &chain.TipSetMetadata{TipSetStateRoot: computed, TipSet: ts, TipSetReceipts: pc.receipts}))
+	}
+	return pc
+}
+
+func setPruneActor(ctx context.Context, t *testing.T, cst cbor.IpldStore, st *tree.State, i int, balance int64) {
+	addr, err := address.NewIDAddress(uint64(100 + i%pruneActorCount))
+	require.NoError(t, err)
+	head, err := cst.Put(ctx, "head")
+	require.NoError(t, err)
+	require.NoError(t, st.SetActor(ctx, addr, &types.Actor{Code: builtin2.AccountActorCodeID, Head: head, Balance: big.NewInt(balance)}))
+}
+
+// loadState loads every actor of the state
+func (pc *pruneChain) loadState(ctx context.Context, t *testing.T, root cid.Cid) error {
+	st, err := tree.LoadState(ctx, pc.cst, root)
+	if err != nil {
+		return err
+	}
+	count := 0
+	if err := st.ForEach(func(tree.ActorKey, *types.Actor) error {
+		count++
+		return nil
+	}); err != nil {
+		return err
+	}
+	assert.Equal(t, pruneActorCount, count)
+	return nil
+}
+
+func (pc *pruneChain) requirePruned(ctx context.Context, t *testing.T, bs blockstoreutil.Blockstore, pruned ...int) {
+	for _, i := range pruned {
+		has, err := bs.Has(ctx, pc.states[i])
+		require.NoError(t, err)
+		assert.False(t, has, "state of tipset %d", i)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	r := repo.NewInMemoryRepo()
+	bs := r.Datastore()
+	pc := newPruneChain(ctx, t, r, bs)
+
+	_, err := pc.store.Prune(ctx, pc.head, pruneChainHeight+1, false)
+	require.Error(t, err)
+
+	dryRun, err := pc.store.Prune(ctx, pc.head, pruneHeight, true)
+	require.NoError(t, err)
+	assert.Equal(t, pruneHeight-1, dryRun.Tipsets)
+	assert.NotZero(t, dryRun.Objects)
+	for _, root := range pc.states {
+		has, err := bs.Has(ctx, root)
+		require.NoError(t, err)
+		assert.True(t, has)
+	}
+
+	// a prune or a blockstore gc is running
+	unlock, err := pc.store.TryLockPrune()
+	require.NoError(t, err)
+	_, err = pc.store.Prune(ctx, pc.head, pruneHeight, false)
+	assert.ErrorIs(t, err, chain.ErrPruneRunning)
+	unlock()
+
+	res, err := pc.store.Prune(ctx, pc.head, pruneHeight, false)
+	require.NoError(t, err)
+	assert.Equal(t, dryRun.Objects, res.Objects)
+	assert.Equal(t, dryRun.Bytes, res.Bytes)
+
+	// the states below the prune height are gone, except the genesis one and the one computed on the head
+	pc.requirePruned(ctx, t, bs, 2, 3, 4, 5, 6, 7, 8, 9)
+	// the kept states and the state computed on the head are still complete
+	require.NoError(t, pc.loadState(ctx, t, pc.states[0]))
+	require.NoError(t, pc.loadState(ctx, t, pc.states[1]))
+	for i := pruneHeight; i < len(pc.states); i++ {
+		require.NoError(t, pc.loadState(ctx, t, pc.states[i]), "state of tipset %d", i)
+	}
+	// the headers are kept
+	for ts := pc.head; ts.Height() > 0; {
+		ts, err = pc.store.GetTipSet(ctx, ts.Parents())
+		require.NoError(t, err)
+	}
+	_, _, err = pc.mstore.LoadMetaMessages(ctx, pc.txMeta)
+	require.NoError(t, err)
+
+	// pruning again finds the objects pruned already
+	res, err = pc.store.Prune(ctx, pc.head, pruneHeight, false)
+	require.NoError(t, err)
+	assert.Zero(t, res.Objects)
+}
+
+// hookBlockstore calls onGet the first time the object is read.
+type hookBlockstore struct {
+	blockstoreutil.Blockstore
+
+	object cid.Cid
+	onGet  func()
+}
+
+func (bs *hookBlockstore) Get(ctx context.Context, c cid.Cid) (blocks.Block, error) {
+	if bs.onGet != nil && c.Equals(bs.object) {
+		onGet := bs.onGet
+		bs.onGet = nil
+		onGet()
+	}
+	return bs.Blockstore.Get(ctx, c)
+}
+
+// dagBlocks returns the blocks of the dag of root.
+func dagBlocks(ctx context.Context, t *testing.T, bs blockstoreutil.Blockstore, root cid.Cid) []blocks.Block {
+	var out []blocks.Block
+	seen := cid.NewSet()
+	var walk func(c cid.Cid)
+	walk = func(c cid.Cid) {
+		if multicodec.Code(c.Prefix().MhType) == multicodec.Identity || !seen.Visit(c) {
+			return
+		}
+		blk, err := bs.Get(ctx, c)
+		require.NoError(t, err)
+		out = append(out, blk)
+		require.NoError(t, cbg.ScanForLinks(bytes.NewReader(blk.RawData()), walk))
+	}
+	walk(root)
+	return out
+}
+
+func TestPruneKeepsWritesDuringSweep(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	// the store and the writers share the blockstore, like the submodules of the node
+	r := repo.NewInMemoryRepo()
+	hook := &hookBlockstore{Blockstore: r.Datastore()}
+	bs := chain.NewPruneBlockstore(hook)
+	pc := newPruneChain(ctx, t, r, bs)
+
+	// the sweep starts from the tipset below the prune height, the sync writes meanwhile
+	var computed cid.Cid
+	hook.object = pc.states[pruneHeight-1]
+	hook.onGet = func() {
+		// bitswap fetches the state of the tipset at 9, the blockservice skips the blocks it has already
+		bsvc := blockservice.New(bs, nil)
+		require.NoError(t, bsvc.AddBlocks(ctx, dagBlocks(ctx, t, r.Datastore(), pc.states[9])))
+		// the syncer stores the state of the tipset at 8
+		require.NoError(t, bs.PutMany(ctx, dagBlocks(ctx, t, r.Datastore(), pc.states[8])))
+		// the state computed on the state of the tipset at 7
+		st, err := tree.LoadState(ctx, pc.cst, pc.states[7])
+		require.NoError(t, err)
+		require.NoError(t, st.ForEach(func(tree.ActorKey, *types.Actor) error { return nil }))
+		setPruneActor(ctx, t, pc.cst, st, 7, 1000)
+		computed, err = st.Flush(ctx)
+		require.NoError(t, err)
+	}
+
+	res, err := pc.store.Prune(ctx, pc.head, pruneHeight, false)
+	require.NoError(t, err)
+	require.Nil(t, hook.onGet, "the sweep didn't reach the state of the tipset at 9")
+	assert.NotZero(t, res.Objects)
+
+	// the states written or read while sweeping are complete, the other ones are gone
+	pc.requirePruned(ctx, t, r.Datastore(), 2, 3, 4, 5, 6)
+	for _, root := range []cid.Cid{pc.states[7], pc.states[8], pc.states[9], computed} {
+		require.NoError(t, pc.loadState(ctx, t, root))
+	}
+	for i := pruneHeight; i < len(pc.states); i++ {
+		require.NoError(t, pc.loadState(ctx, t, pc.states[i]), "state of tipset %d", i)
+	}
+}
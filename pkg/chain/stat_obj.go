@@ -0,0 +1,74 @@
+package chain
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ipfs/boxo/blockservice"
+	offline "github.com/ipfs/boxo/exchange/offline"
+	"github.com/ipfs/boxo/ipld/merkledag"
+	"github.com/ipfs/go-cid"
+	ipld "github.com/ipfs/go-ipld-format"
+
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// StatObj returns the size and the number of the blocks reachable from obj in bs, the blocks reachable from base
+// are left out when base is defined. The links to the sector commitments are not followed.
+func StatObj(ctx context.Context, bs blockstoreutil.Blockstore, obj cid.Cid, base cid.Cid) (types.ObjStat, error) {
+	w := NewObjWalker(bs)
+	if base.Defined() {
+		if _, err := w.Stat(ctx, base); err != nil {
+			return types.ObjStat{}, err
+		}
+	}
+	return w.Stat(ctx, obj)
+}
+
+// ObjWalker collects the size and the number of the blocks reachable from objects, a block is only counted by the
+// first walk reaching it.
+type ObjWalker struct {
+	dag  ipld.DAGService
+	seen *cid.Set
+}
+
+// NewObjWalker returns a walker of the objects of bs.
+func NewObjWalker(bs blockstoreutil.Blockstore) *ObjWalker {
+	bsvc := blockservice.New(bs, offline.Exchange(bs))
+	return &ObjWalker{
+		dag:  merkledag.NewDAGService(bsvc),
+		seen: cid.NewSet(),
+	}
+}
+
+// Stat returns the stats of the blocks reachable from obj which no previous walk reached. The links to the sector
+// commitments are not followed.
+func (w *ObjWalker) Stat(ctx context.Context, obj cid.Cid) (types.ObjStat, error) {
+	var statslk sync.Mutex
+	var stats types.ObjStat
+
+	walker := func(ctx context.Context, c cid.Cid) ([]*ipld.Link, error) {
+		if c.Prefix().Codec == cid.FilCommitmentSealed || c.Prefix().Codec == cid.FilCommitmentUnsealed {
+			return []*ipld.Link{}, nil
+		}
+
+		nd, err := w.dag.Get(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+
+		s := uint64(len(nd.RawData()))
+		statslk.Lock()
+		stats.Size = stats.Size + s
+		stats.Links = stats.Links + 1
+		statslk.Unlock()
+
+		return nd.Links(), nil
+	}
+
+	if err := merkledag.Walk(ctx, walker, obj, w.seen.Visit, merkledag.Concurrent()); err != nil {
+		return types.ObjStat{}, err
+	}
+	return stats, nil
+}
@@ -0,0 +1,48 @@
+package chain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestStatObj(t *testing.T) {
+	testflags.UnitTest(t)
+	ctx := context.Background()
+	bs := blockstoreutil.NewBlockstore(datastore.NewMapDatastore())
+
+	put := func(obj map[string]interface{}) (cid.Cid, uint64) {
+		nd, err := cbor.WrapObject(obj, mh.SHA2_256, -1)
+		require.NoError(t, err)
+		require.NoError(t, bs.Put(ctx, nd))
+		return nd.Cid(), uint64(len(nd.RawData()))
+	}
+	shared, sharedSize := put(map[string]interface{}{"name": "shared"})
+	leafA, leafASize := put(map[string]interface{}{"name": "a"})
+	leafB, leafBSize := put(map[string]interface{}{"name": "b"})
+	rootA, rootASize := put(map[string]interface{}{"links": []cid.Cid{leafA, shared}})
+	rootB, rootBSize := put(map[string]interface{}{"links": []cid.Cid{leafB, shared}})
+
+	stat, err := chain.StatObj(ctx, bs, rootA, cid.Undef)
+	require.NoError(t, err)
+	require.Equal(t, types.ObjStat{Size: rootASize + leafASize + sharedSize, Links: 3}, stat)
+
+	// the blocks reachable from the base are left out
+	stat, err = chain.StatObj(ctx, bs, rootB, rootA)
+	require.NoError(t, err)
+	require.Equal(t, types.ObjStat{Size: rootBSize + leafBSize, Links: 2}, stat)
+
+	stat, err = chain.StatObj(ctx, bs, rootA, rootA)
+	require.NoError(t, err)
+	require.Equal(t, types.ObjStat{}, stat)
+}
@@ -0,0 +1,136 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+
+	"github.com/filecoin-project/venus/pkg/state/tree"
+	"github.com/filecoin-project/venus/venus-shared/actors"
+	"github.com/filecoin-project/venus/venus-shared/actors/adt"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin"
+	lminer "github.com/filecoin-project/venus/venus-shared/actors/builtin/miner"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// minerHeadField is the field of a miner holding the blocks of its state reached by no other field.
+const minerHeadField = "Head"
+
+// StateSize walks the state root in bs and attributes its blocks to the actors, in the order of the state tree,
+// each block to the first actor reaching it. The blocks of a miner are attributed to the fields of its state, eg.
+// Sectors, Deadlines and PreCommittedSectors, and the blocks reached by no actor to the state tree. The report
+// keeps the top actors of the largest states.
+func StateSize(ctx context.Context, bs blockstoreutil.Blockstore, root cid.Cid, top int) (*types.StateSizeReport, error) {
+	cst := cbor.NewCborStore(bs)
+	st, err := tree.LoadState(ctx, cst, root)
+	if err != nil {
+		return nil, fmt.Errorf("load state tree: %w", err)
+	}
+
+	report := &types.StateSizeReport{
+		Root:   root,
+		ByType: make(map[string]types.ObjStat),
+	}
+	w := NewObjWalker(bs)
+	store := adt.WrapStore(ctx, cst)
+	err = st.ForEach(func(addr tree.ActorKey, act *types.Actor) error {
+		size := types.ActorStateSize{
+			Address: addr,
+			Type:    actors.CanonicalName(builtin.ActorNameByCode(act.Code)),
+		}
+		if builtin.IsStorageMinerActor(act.Code) {
+			fields, err := minerFieldSizes(ctx, w, store, act)
+			if err != nil {
+				return fmt.Errorf("miner %s: %w", addr, err)
+			}
+			size.Fields = fields
+		}
+		head, err := w.Stat(ctx, act.Head)
+		if err != nil {
+			return fmt.Errorf("actor %s: %w", addr, err)
+		}
+		if size.Fields != nil {
+			size.Fields[minerHeadField] = head
+		}
+		for _, field := range size.Fields {
+			addObjStat(&size.ObjStat, field)
+		}
+		if size.Fields == nil {
+			size.ObjStat = head
+		}
+
+		report.Actors++
+		byType := report.ByType[size.Type]
+		addObjStat(&byType, size.ObjStat)
+		report.ByType[size.Type] = byType
+		addObjStat(&report.Total, size.ObjStat)
+		report.Top = addTopActor(report.Top, size, top)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report.StateTree, err = w.Stat(ctx, root)
+	if err != nil {
+		return nil, fmt.Errorf("state tree: %w", err)
+	}
+	addObjStat(&report.Total, report.StateTree)
+	return report, nil
+}
+
+// minerFieldSizes walks the fields of the miner state holding a cid, in the order of the state.
+func minerFieldSizes(ctx context.Context, w *ObjWalker, store adt.Store, act *types.Actor) (map[string]types.ObjStat, error) {
+	mas, err := lminer.Load(store, act)
+	if err != nil {
+		return nil, err
+	}
+	v := reflect.Indirect(reflect.ValueOf(mas.GetState()))
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("unexpected miner state %T", mas.GetState())
+	}
+
+	fields := make(map[string]types.ObjStat)
+	for i := 0; i < v.NumField(); i++ {
+		c, ok := v.Field(i).Interface().(cid.Cid)
+		if !ok || !c.Defined() {
+			continue
+		}
+		name := v.Type().Field(i).Name
+		stat, err := w.Stat(ctx, c)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		fields[name] = stat
+	}
+	return fields, nil
+}
+
+// addTopActor inserts size into the actors sorted by size, largest first, keeping top of them.
+func addTopActor(sizes []types.ActorStateSize, size types.ActorStateSize, top int) []types.ActorStateSize {
+	if top <= 0 {
+		return sizes
+	}
+	i := sort.Search(len(sizes), func(i int) bool {
+		return sizes[i].Size < size.Size
+	})
+	if i >= top {
+		return sizes
+	}
+	if len(sizes) < top {
+		sizes = append(sizes, types.ActorStateSize{})
+	}
+	copy(sizes[i+1:], sizes[i:])
+	sizes[i] = size
+	return sizes
+}
+
+func addObjStat(to *types.ObjStat, stat types.ObjStat) {
+	to.Size += stat.Size
+	to.Links += stat.Links
+}
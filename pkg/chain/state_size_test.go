@@ -0,0 +1,70 @@
+package chain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	builtin2 "github.com/filecoin-project/specs-actors/v2/actors/builtin"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/state/tree"
+	"github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestStateSize(t *testing.T) {
+	testflags.UnitTest(t)
+	ctx := context.Background()
+	bs := blockstoreutil.NewBlockstore(datastore.NewMapDatastore())
+	cst := cbor.NewCborStore(bs)
+
+	put := func(obj map[string]interface{}) (cid.Cid, uint64) {
+		nd, err := cbor.WrapObject(obj, mh.SHA2_256, -1)
+		require.NoError(t, err)
+		require.NoError(t, bs.Put(ctx, nd))
+		return nd.Cid(), uint64(len(nd.RawData()))
+	}
+	shared, sharedSize := put(map[string]interface{}{"name": "shared"})
+	big, bigSize := put(map[string]interface{}{"name": "a large leaf, larger than the others"})
+	headA, headASize := put(map[string]interface{}{"links": []cid.Cid{shared}})
+	headB, headBSize := put(map[string]interface{}{"links": []cid.Cid{big, shared}})
+	headC, headCSize := put(map[string]interface{}{"links": []cid.Cid{}})
+
+	st, err := tree.NewState(cst, tree.StateTreeVersion4)
+	require.NoError(t, err)
+	addrs := make([]address.Address, 3)
+	for i, head := range []cid.Cid{headA, headB, headC} {
+		addrs[i], err = address.NewIDAddress(uint64(100 + i))
+		require.NoError(t, err)
+		require.NoError(t, st.SetActor(ctx, addrs[i], &types.Actor{Code: builtin2.AccountActorCodeID, Head: head}))
+	}
+	root, err := st.Flush(ctx)
+	require.NoError(t, err)
+	all, err := chain.StatObj(ctx, bs, root, cid.Undef)
+	require.NoError(t, err)
+
+	report, err := chain.StateSize(ctx, bs, root, 2)
+	require.NoError(t, err)
+	require.Equal(t, root, report.Root)
+	require.Equal(t, uint64(3), report.Actors)
+	require.Equal(t, all, report.Total)
+
+	// the shared block is attributed to the first actor of the state tree reaching it, only once
+	require.Len(t, report.Top, 2)
+	require.Equal(t, addrs[1], report.Top[0].Address)
+	require.Equal(t, addrs[0], report.Top[1].Address)
+	require.Equal(t, "account", report.Top[0].Type)
+	require.Equal(t, headASize+headBSize+bigSize+sharedSize, report.Top[0].Size+report.Top[1].Size)
+	require.Equal(t, uint64(4), report.Top[0].Links+report.Top[1].Links)
+	require.Equal(t, map[string]types.ObjStat{
+		"account": {Size: headASize + headBSize + headCSize + bigSize + sharedSize, Links: 5},
+	}, report.ByType)
+	require.Equal(t, types.ObjStat{Size: all.Size - report.ByType["account"].Size, Links: all.Links - 5}, report.StateTree)
+}
@@ -14,17 +14,16 @@ import (
 	"github.com/hashicorp/golang-lru/arc/v2"
 	"github.com/ipld/go-car"
 	carutil "github.com/ipld/go-car/util"
-	carv2 "github.com/ipld/go-car/v2"
 	"github.com/multiformats/go-multicodec"
 	"github.com/pkg/errors"
 	cbg "github.com/whyrusleeping/cbor-gen"
 	"go.opencensus.io/trace"
 
 	blockstore "github.com/ipfs/boxo/blockstore"
-	blocks "github.com/ipfs/go-block-format"
 	"github.com/ipfs/go-cid"
 	"github.com/ipfs/go-datastore"
 	cbor "github.com/ipfs/go-ipld-cbor"
+	ipld "github.com/ipfs/go-ipld-format"
 	logging "github.com/ipfs/go-log/v2"
 
 	"github.com/filecoin-project/go-address"
@@ -127,20 +126,34 @@ type Store struct {
 	tstLk   sync.Mutex
 	tipsets map[abi.ChainEpoch][]cid.Cid
 
+	// pruneLk allows a single Prune or blockstore gc at a time
+	pruneLk sync.Mutex
+	// pruneBs is bsstore, it marks the objects written during a Prune
+	pruneBs *PruneBlockstore
+
 	weight WeightFunc
+
+	// actorStateCache is shared by the state views, and purged when the head changes.
+	actorStateCache *state.ActorStateCache
 }
 
-// NewStore constructs a new default store.
+// NewStore constructs a new default store. bsstore is wrapped in a PruneBlockstore unless it is one,
+// the other writers of the chain objects must share it, see Prune.
 func NewStore(chainDs repo.Datastore,
 	bsstore blockstoreutil.Blockstore,
 	genesisCid cid.Cid,
 	weight WeightFunc,
 ) *Store {
 	tsCache, _ := arc.NewARC[types.TipSetKey, *types.TipSet](DefaultTipsetLruCacheSize)
+	pruneBs, ok := bsstore.(*PruneBlockstore)
+	if !ok {
+		pruneBs = NewPruneBlockstore(bsstore)
+	}
 	store := &Store{
-		stateAndBlockSource: cbor.NewCborStore(bsstore),
+		stateAndBlockSource: cbor.NewCborStore(pruneBs),
 		ds:                  chainDs,
-		bsstore:             bsstore,
+		bsstore:             pruneBs,
+		pruneBs:             pruneBs,
 		headEvents:          pubsub.New(64),
 
 		genesis:        genesisCid,
@@ -325,10 +338,11 @@ func (store *Store) PutObject(ctx context.Context, obj interface{}) (cid.Cid, er
 	return store.stateAndBlockSource.Put(ctx, obj)
 }
 
-// GetTipSet returns the tipset identified by `key`.
+// GetTipSet returns the tipset identified by `key`, the empty key refers to the tipset selected by
+// the selector of ctx, the head by default.
 func (store *Store) GetTipSet(ctx context.Context, key types.TipSetKey) (*types.TipSet, error) {
 	if key.IsEmpty() {
-		return store.GetHead(), nil
+		return store.selectTipSet(ctx)
 	}
 
 	return store.getTipSet(ctx, key)
@@ -344,6 +358,9 @@ func (store *Store) getTipSet(ctx context.Context, key types.TipSetKey) (*types.
 	for idx, c := range cids {
 		blk, err := store.GetBlock(ctx, c)
 		if err != nil {
+			if ipld.IsNotFound(err) {
+				return nil, types.NewAPIError(types.ErrCodeTipSetNotFound, err)
+			}
 			return nil, err
 		}
 
@@ -373,7 +390,7 @@ func (store *Store) GetTipSetByHeight(ctx context.Context, ts *types.TipSet, h a
 	}
 
 	if h > ts.Height() {
-		return nil, fmt.Errorf("looking for tipset with height greater than start point")
+		return nil, types.NewTipSetNotFoundError("looking for tipset with height %d greater than start point %d", h, ts.Height())
 	}
 
 	if h == ts.Height() {
@@ -535,7 +552,7 @@ func (store *Store) updateHead(ctx context.Context, newTS *types.TipSet) ([]*typ
 		}
 		// reorg
 		oldHead := store.head
-		dropped, added, err = CollectTipsToCommonAncestor(ctx, store, oldHead, newTS)
+		dropped, added, err = store.ReorgOps(ctx, oldHead, newTS)
 		if err != nil {
 			return nil, nil, false, err
 		}
@@ -578,6 +595,7 @@ func (store *Store) setHead(ctx context.Context, newTS *types.TipSet) error {
 	if !update {
 		return nil
 	}
+	store.actorStateCache.Purge()
 
 	store.PersistTipSetKey(ctx, newTS.Key())
 
@@ -606,20 +624,7 @@ const reorgChBuf = 32
 
 func (store *Store) reorgWorker(ctx context.Context) chan reorg {
 	headChangeNotifee := func(rev, app []*types.TipSet) error {
-		notif := make([]*types.HeadChange, len(rev)+len(app))
-		for i, revert := range rev {
-			notif[i] = &types.HeadChange{
-				Type: types.HCRevert,
-				Val:  revert,
-			}
-		}
-
-		for i, apply := range app {
-			notif[i+len(rev)] = &types.HeadChange{
-				Type: types.HCApply,
-				Val:  apply,
-			}
-		}
+		notif := headChanges(rev, app)
 
 		// Publish an event that we have a new head.
 		store.headEvents.Pub(notif, types.HeadChangeTopic)
@@ -729,6 +734,71 @@ func (store *Store) SubHeadChanges(ctx context.Context) chan []*types.HeadChange
 	return out
 }
 
+// SubStableHeadChanges is like SubHeadChanges, but only notifies the tipsets once they are confidence
+// epochs below the head. The reorgs above that depth are collapsed, so the subscriber only sees reverts
+// when a reorg is deeper than confidence.
+func (store *Store) SubStableHeadChanges(ctx context.Context, confidence abi.ChainEpoch) chan []*types.HeadChange {
+	ctx, cancel := context.WithCancel(ctx)
+	in := store.SubHeadChanges(ctx)
+	out := make(chan []*types.HeadChange, 16)
+
+	go func() {
+		defer func() {
+			close(out)
+			cancel()
+		}()
+
+		var last *types.TipSet
+		for changes := range in {
+			var head *types.TipSet
+			for _, change := range changes {
+				if change.Type != types.HCRevert {
+					head = change.Val
+				}
+			}
+			if head == nil {
+				continue
+			}
+
+			height := head.Height() - confidence
+			if height < 0 {
+				height = 0
+			}
+			stable, err := store.GetTipSetByHeight(ctx, head, height, true)
+			if err != nil {
+				log.Errorf("failed to get the tipset %d epochs below head %s: %v", confidence, head.Key(), err)
+				return
+			}
+
+			var notif []*types.HeadChange
+			if last == nil {
+				notif = []*types.HeadChange{{Type: types.HCCurrent, Val: stable}}
+			} else if !last.Equals(stable) {
+				notif, err = store.GetPath(ctx, last, stable)
+				if err != nil {
+					log.Errorf("failed to get the path from %s to %s: %v", last.Key(), stable.Key(), err)
+					return
+				}
+				if len(notif) > 0 && notif[0].Type == types.HCRevert {
+					log.Warnf("reorg reverting tipset %d deeper than the confidence of %d epochs", last.Height(), confidence)
+				}
+			}
+			if len(notif) == 0 {
+				continue
+			}
+			last = stable
+
+			select {
+			case out <- notif:
+			default:
+				log.Errorf("closing stable head change subscription due to slow reader")
+				return
+			}
+		}
+	}()
+	return out
+}
+
 // SubscribeHeadChanges subscribe head change event
 func (store *Store) SubscribeHeadChanges(f ReorgNotifee) {
 	store.reorgNotifeeCh <- f
@@ -868,6 +938,104 @@ func (store *Store) Export(ctx context.Context, ts *types.TipSet, inclRecentRoot
 	})
 }
 
+// ExportRange writes to w a car of the blocks with a height in (base, head], with their messages, receipts
+// and the state objects they reference which are not part of the parent state of base, so the cars of
+// consecutive ranges replicate the chain incrementally on top of a snapshot at base.
+func (store *Store) ExportRange(ctx context.Context, base, head *types.TipSet, w io.Writer) error {
+	if head.Height() <= base.Height() {
+		return fmt.Errorf("head height %d must be above base height %d", head.Height(), base.Height())
+	}
+
+	h := &car.CarHeader{
+		Roots:   head.Cids(),
+		Version: 1,
+	}
+	if err := car.WriteHeader(h, w); err != nil {
+		return fmt.Errorf("failed to write car header: %s", err)
+	}
+
+	write := func(c cid.Cid) error {
+		prefix := c.Prefix()
+		// Don't include identity CIDs.
+		if multicodec.Code(prefix.MhType) == multicodec.Identity {
+			return nil
+		}
+		// We only include raw, cbor, and dagcbor, for now.
+		switch multicodec.Code(prefix.Codec) {
+		case multicodec.Cbor, multicodec.DagCbor, multicodec.Raw:
+		default:
+			return nil
+		}
+
+		blk, err := store.bsstore.Get(ctx, c)
+		if err != nil {
+			return fmt.Errorf("writing object to car, bs.Get: %w", err)
+		}
+		if err := carutil.LdWrite(w, c.Bytes(), blk.RawData()); err != nil {
+			return fmt.Errorf("failed to write block to car output: %w", err)
+		}
+		return nil
+	}
+
+	// the parent state of base is known to whoever imports the range, mark it walked to only
+	// export the state objects changed since then
+	walked := cid.NewSet()
+	baseRoot := base.At(0).ParentStateRoot
+	walked.Visit(baseRoot)
+	if _, err := recurseLinks(ctx, store.bsstore, walked, baseRoot, nil); err != nil {
+		return fmt.Errorf("walking base state failed: %w", err)
+	}
+
+	walk := func(root cid.Cid) error {
+		if !walked.Visit(root) {
+			return nil
+		}
+		cids, err := recurseLinks(ctx, store.bsstore, walked, root, []cid.Cid{root})
+		if err != nil {
+			return err
+		}
+		for _, c := range cids {
+			if err := write(c); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	log.Infow("export range started", "from", base.Height(), "to", head.Height())
+	exportStart := constants.Clock.Now()
+
+	cur := head
+	for cur.Height() > base.Height() {
+		for _, b := range cur.Blocks() {
+			if err := write(b.Cid()); err != nil {
+				return err
+			}
+			if err := walk(b.Messages); err != nil {
+				return fmt.Errorf("recursing messages failed: %w", err)
+			}
+			if err := walk(b.ParentStateRoot); err != nil {
+				return fmt.Errorf("recursing state failed: %w", err)
+			}
+			if err := walk(b.ParentMessageReceipts); err != nil {
+				return fmt.Errorf("recursing receipts failed: %w", err)
+			}
+		}
+
+		var err error
+		if cur, err = store.GetTipSet(ctx, cur.Parents()); err != nil {
+			return fmt.Errorf("loading parent tipset failed: %w", err)
+		}
+	}
+	if !cur.Equals(base) {
+		return fmt.Errorf("tipset %s at height %d is not an ancestor of %s", base.Key(), base.Height(), head.Key())
+	}
+
+	log.Infow("export range finished", "duration", constants.Clock.Now().Sub(exportStart).Seconds())
+
+	return nil
+}
+
 func (store *Store) WalkSnapshot(ctx context.Context, ts *types.TipSet, inclRecentRoots abi.ChainEpoch, skipOldMsgs, skipMsgReceipts bool, cb func(cid.Cid) error) error {
 	if ts == nil {
 		ts = store.GetHead()
@@ -983,81 +1151,13 @@ func (store *Store) WalkSnapshot(ctx context.Context, ts *types.TipSet, inclRece
 }
 
 // Import import a car file into local db
-func (store *Store) Import(ctx context.Context, r io.Reader) (*types.TipSet, *types.BlockHeader, error) {
-	br, err := carv2.NewBlockReader(r)
+func (store *Store) Import(ctx context.Context, r io.Reader, opts ImportOptions) (*types.TipSet, *types.BlockHeader, error) {
+	roots, tailBlock, err := store.importBlocks(ctx, r, opts)
 	if err != nil {
-		return nil, nil, fmt.Errorf("loadcar failed: %w", err)
-	}
-
-	if len(br.Roots) == 0 {
-		return nil, nil, fmt.Errorf("no roots in snapshot car file")
-	}
-
-	var tailBlock types.BlockHeader
-	tailBlock.Height = abi.ChainEpoch(-1)
-	nextTailCid := br.Roots[0]
-
-	parallelPuts := 5
-	putThrottle := make(chan error, parallelPuts)
-	for i := 0; i < parallelPuts; i++ {
-		putThrottle <- nil
-	}
-
-	var buf []blocks.Block
-	for {
-		blk, err := br.Next()
-		if err != nil {
-			if err == io.EOF {
-				if len(buf) > 0 {
-					if err := store.bsstore.PutMany(ctx, buf); err != nil {
-						return nil, nil, err
-					}
-				}
-
-				break
-			}
-			return nil, nil, err
-		}
-
-		// check for header block, looking for genesis
-		if blk.Cid() == nextTailCid && tailBlock.Height != 0 {
-			if err := tailBlock.UnmarshalCBOR(bytes.NewReader(blk.RawData())); err != nil {
-				return nil, nil, fmt.Errorf("failed to unmarshal genesis block: %w", err)
-			}
-			if len(tailBlock.Parents) > 0 {
-				nextTailCid = tailBlock.Parents[0]
-			} else {
-				// note: even the 0th block has a parent linking to the cbor genesis block
-				return nil, nil, fmt.Errorf("current block (epoch %d cid %s) has no parents", tailBlock.Height, tailBlock.Cid())
-			}
-		}
-
-		buf = append(buf, blk)
-
-		if len(buf) > 1000 {
-			if lastErr := <-putThrottle; lastErr != nil { // consume one error to have the right to add one
-				return nil, nil, lastErr
-			}
-
-			go func(buf []blocks.Block) {
-				putThrottle <- store.bsstore.PutMany(ctx, buf)
-			}(buf)
-			buf = nil
-		}
+		return nil, nil, err
 	}
 
-	// check errors
-	for i := 0; i < parallelPuts; i++ {
-		if lastErr := <-putThrottle; lastErr != nil {
-			return nil, nil, lastErr
-		}
-	}
-
-	if tailBlock.Height != 0 {
-		return nil, nil, fmt.Errorf("expected genesis block to have height 0 (genesis), got %d: %s", tailBlock.Height, tailBlock.Cid())
-	}
-
-	root, err := store.GetTipSet(ctx, types.NewTipSetKey(br.Roots...))
+	root, err := store.GetTipSet(ctx, types.NewTipSetKey(roots...))
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to load root tipset from chainfile: %w", err)
 	}
@@ -1107,7 +1207,7 @@ func (store *Store) Import(ctx context.Context, r io.Reader) (*types.TipSet, *ty
 		curTipset = curParentTipset
 	}
 
-	return root, &tailBlock, nil
+	return root, tailBlock, nil
 }
 
 // SetCheckpoint will set a checkpoint past which the chainstore will not allow forks. If the new
@@ -1215,6 +1315,37 @@ func (store *Store) ReorgOps(ctx context.Context, a, b *types.TipSet) ([]*types.
 	return ReorgOps(ctx, store.GetTipSet, a, b)
 }
 
+// GetPath returns the revert/apply operations needed to get from the tipset from to the tipset to
+// through their common ancestor, the reverts go down from `from` and the applies go up to `to`.
+// It is the reorg computation shared by the head change notifications and the ChainGetPath API.
+func (store *Store) GetPath(ctx context.Context, from, to *types.TipSet) ([]*types.HeadChange, error) {
+	revert, apply, err := store.ReorgOps(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tipset branches: %w", err)
+	}
+	Reverse(apply)
+	return headChanges(revert, apply), nil
+}
+
+// headChanges returns the head changes reverting rev, from the highest tipset, then applying app,
+// from the lowest tipset.
+func headChanges(rev, app []*types.TipSet) []*types.HeadChange {
+	changes := make([]*types.HeadChange, len(rev)+len(app))
+	for i, revert := range rev {
+		changes[i] = &types.HeadChange{
+			Type: types.HCRevert,
+			Val:  revert,
+		}
+	}
+	for i, apply := range app {
+		changes[i+len(rev)] = &types.HeadChange{
+			Type: types.HCApply,
+			Val:  apply,
+		}
+	}
+	return changes
+}
+
 // ReorgOps takes two tipsets (which can be at different heights), and walks
 // their corresponding chains backwards one step at a time until we find
 // a common ancestor. It then returns the respective chain segments that fork
@@ -1270,6 +1401,11 @@ func (store *Store) PutMessage(ctx context.Context, m storable) (cid.Cid, error)
 	return PutMessage(ctx, store.bsstore, m)
 }
 
+// SetActorStateCache sets the actor state cache shared by the state views, it must be called before the store is used.
+func (store *Store) SetActorStateCache(cache *state.ActorStateCache) {
+	store.actorStateCache = cache
+}
+
 // Blockstore return local blockstore
 // todo remove this method, and code that need blockstore should get from blockstore submodule
 func (store *Store) Blockstore() blockstoreutil.Blockstore { // nolint
@@ -1409,7 +1545,7 @@ func (store *Store) StateView(ctx context.Context, ts *types.TipSet) (*state.Vie
 		return nil, errors.Wrapf(err, "failed to get state root for %s", ts.Key().String())
 	}
 
-	return state.NewView(store.stateAndBlockSource, root), nil
+	return state.NewView(store.stateAndBlockSource, root).WithCache(store.actorStateCache), nil
 }
 
 // AccountView return account view at ts state
@@ -1422,12 +1558,12 @@ func (store *Store) AccountView(ctx context.Context, ts *types.TipSet) (state.Ac
 		return nil, errors.Wrapf(err, "failed to get state root for %s", ts.Key().String())
 	}
 
-	return state.NewView(store.stateAndBlockSource, root), nil
+	return state.NewView(store.stateAndBlockSource, root).WithCache(store.actorStateCache), nil
 }
 
 // ParentStateView get parent state view of ts
 func (store *Store) ParentStateView(ts *types.TipSet) (*state.View, error) {
-	return state.NewView(store.stateAndBlockSource, ts.At(0).ParentStateRoot), nil
+	return state.NewView(store.stateAndBlockSource, ts.At(0).ParentStateRoot).WithCache(store.actorStateCache), nil
 }
 
 // Store wrap adt store
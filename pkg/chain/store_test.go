@@ -2,10 +2,12 @@
 package chain_test
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math/rand"
 	"os"
+	"sync/atomic"
 	"testing"
 
 	"github.com/filecoin-project/go-address"
@@ -20,6 +22,8 @@ import (
 	"github.com/ipfs/go-cid"
 	"github.com/ipfs/go-datastore"
 	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/ipld/go-car"
+	carutil "github.com/ipld/go-car/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -194,6 +198,94 @@ func TestRevertChange(t *testing.T) {
 	test.Equal(t, headChanges[5].Val, link6)
 }
 
+func TestGetPath(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.TODO()
+	builder := chain.NewBuilder(t, address.Undef)
+	genesis := builder.Genesis()
+	cs := newChainStore(builder.Repo(), genesis)
+
+	link1 := builder.AppendOn(ctx, genesis, 1)
+	link2 := builder.AppendOn(ctx, link1, 1)
+	link3 := builder.AppendOn(ctx, link2, 1)
+	fork1 := builder.AppendOn(ctx, link1, 2)
+	fork2 := builder.AppendOn(ctx, fork1, 2)
+	fork3 := builder.AppendOn(ctx, fork2, 2)
+
+	assertPath := func(path []*types.HeadChange, expected ...*types.HeadChange) {
+		require.Len(t, path, len(expected))
+		for i := range expected {
+			test.Equal(t, expected[i].Type, path[i].Type)
+			test.Equal(t, expected[i].Val, path[i].Val)
+		}
+	}
+	revert := func(ts *types.TipSet) *types.HeadChange { return &types.HeadChange{Type: types.HCRevert, Val: ts} }
+	apply := func(ts *types.TipSet) *types.HeadChange { return &types.HeadChange{Type: types.HCApply, Val: ts} }
+
+	path, err := cs.Store.GetPath(ctx, link3, fork3)
+	require.NoError(t, err)
+	assertPath(path, revert(link3), revert(link2), apply(fork1), apply(fork2), apply(fork3))
+
+	path, err = cs.Store.GetPath(ctx, fork2, link2)
+	require.NoError(t, err)
+	assertPath(path, revert(fork2), revert(fork1), apply(link2))
+
+	path, err = cs.Store.GetPath(ctx, genesis, link2)
+	require.NoError(t, err)
+	assertPath(path, apply(link1), apply(link2))
+
+	path, err = cs.Store.GetPath(ctx, link3, link3)
+	require.NoError(t, err)
+	assertPath(path)
+}
+
+func TestSubStableHeadChanges(t *testing.T) {
+	tf.UnitTest(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	builder := chain.NewBuilder(t, address.Undef)
+	genesis := builder.Genesis()
+	cs := newChainStore(builder.Repo(), genesis)
+
+	link1 := builder.AppendOn(ctx, genesis, 1)
+	link2 := builder.AppendOn(ctx, link1, 1)
+	link3 := builder.AppendOn(ctx, link2, 1)
+	fork1 := builder.AppendOn(ctx, link1, 2)
+	fork2 := builder.AppendOn(ctx, fork1, 2)
+	fork3 := builder.AppendOn(ctx, fork2, 2)
+	alt1 := builder.AppendOn(ctx, genesis, 3)
+	alt2 := builder.AppendOn(ctx, alt1, 3)
+	alt3 := builder.AppendOn(ctx, alt2, 3)
+	alt4 := builder.AppendOn(ctx, alt3, 3)
+	alt5 := builder.AppendOn(ctx, alt4, 3)
+
+	require.NoError(t, cs.Store.SetHead(ctx, link3))
+	ch := cs.Store.SubStableHeadChanges(ctx, 2)
+
+	assertNotif := func(expected ...*types.HeadChange) {
+		notif := <-ch
+		require.Len(t, notif, len(expected))
+		for i := range expected {
+			test.Equal(t, expected[i].Type, notif[i].Type)
+			test.Equal(t, expected[i].Val, notif[i].Val)
+		}
+	}
+	revert := func(ts *types.TipSet) *types.HeadChange { return &types.HeadChange{Type: types.HCRevert, Val: ts} }
+	apply := func(ts *types.TipSet) *types.HeadChange { return &types.HeadChange{Type: types.HCApply, Val: ts} }
+
+	assertNotif(&types.HeadChange{Type: types.HCCurrent, Val: link1})
+
+	// the reorg from link3 to fork2 stays above the confidence depth, nothing is delivered
+	require.NoError(t, cs.Store.SetHead(ctx, fork2))
+	require.NoError(t, cs.Store.SetHead(ctx, fork3))
+	assertNotif(apply(fork1))
+
+	// a reorg deeper than the confidence depth is delivered as reverts
+	require.NoError(t, cs.Store.SetHead(ctx, alt5))
+	assertNotif(revert(fork1), revert(link1), apply(alt1), apply(alt2), apply(alt3))
+	assertEmptyCh(t, ch)
+}
+
 /* Head and its state is set and notified properly. */
 
 // The constructor call sets the genesis cid for the chain store.
@@ -551,3 +643,60 @@ func requireGetTipSetStateRoot(ctx context.Context, t *testing.T, chainStore tip
 	require.NoError(t, err)
 	return stateCid
 }
+
+func TestImport(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	builder := chain.NewBuilder(t, address.Undef)
+	genTS := builder.Genesis()
+	link1 := builder.AppendOn(ctx, genTS, 2)
+	link2 := builder.AppendOn(ctx, link1, 3)
+	head := builder.AppendOn(ctx, link2, 1)
+
+	var headers []*types.BlockHeader
+	for _, ts := range []*types.TipSet{head, link2, link1, genTS} {
+		headers = append(headers, ts.Blocks()...)
+	}
+	writeCar := func(corrupt bool) *bytes.Buffer {
+		buf := new(bytes.Buffer)
+		require.NoError(t, car.WriteHeader(&car.CarHeader{Roots: head.Key().Cids(), Version: 1}, buf))
+		for i, blk := range headers {
+			data, err := blk.ToStorageBlock()
+			require.NoError(t, err)
+			raw := data.RawData()
+			if corrupt && i == len(headers)-1 {
+				raw = append([]byte{0}, raw...)
+			}
+			require.NoError(t, carutil.LdWrite(buf, data.Cid().Bytes(), raw))
+		}
+		return buf
+	}
+
+	r := repo.NewInMemoryRepo()
+	cs := chain.NewStore(r.ChainDatastore(), r.Datastore(), cid.Undef, chainselector.Weight)
+
+	var imported int64
+	root, genesis, err := cs.Import(ctx, writeCar(false), chain.ImportOptions{
+		Workers:   2,
+		BatchSize: 1,
+		Progress: func(blocks, _ int64) {
+			atomic.StoreInt64(&imported, blocks)
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, head.Key(), root.Key())
+	assert.Equal(t, genTS.At(0).Cid(), genesis.Cid())
+	assert.Equal(t, int64(len(headers)), atomic.LoadInt64(&imported))
+	for _, blk := range headers {
+		has, err := r.Datastore().Has(ctx, blk.Cid())
+		require.NoError(t, err)
+		assert.True(t, has)
+	}
+
+	// blocks not matching their cid are rejected
+	r = repo.NewInMemoryRepo()
+	cs = chain.NewStore(r.ChainDatastore(), r.Datastore(), cid.Undef, chainselector.Weight)
+	_, _, err = cs.Import(ctx, writeCar(true), chain.ImportOptions{})
+	assert.ErrorContains(t, err, "mismatch in content integrity")
+}
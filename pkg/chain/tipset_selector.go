@@ -0,0 +1,72 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/filecoin-project/venus/venus-shared/actors/policy"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// TipSetSelectorHeader is the header of the API requests selecting the tipset the empty tipset keys
+// refer to, it defaults to the selector of the API config.
+const TipSetSelectorHeader = "X-Tipset-Selector"
+
+// TipSetSelector selects the tipset an empty tipset key refers to.
+type TipSetSelector string
+
+const (
+	// SelectHead selects the head, whose messages may still be executing
+	SelectHead TipSetSelector = "head"
+	// SelectHeadParent selects the parent of the head, whose messages are executed
+	SelectHeadParent TipSetSelector = "head-1"
+	// SelectFinalized selects the tipset at the chain finality below the head
+	SelectFinalized TipSetSelector = "finalized"
+)
+
+// ParseTipSetSelector parses a selector, the empty string selecting the head.
+func ParseTipSetSelector(s string) (TipSetSelector, error) {
+	switch sel := TipSetSelector(s); sel {
+	case "":
+		return SelectHead, nil
+	case SelectHead, SelectHeadParent, SelectFinalized:
+		return sel, nil
+	default:
+		return "", fmt.Errorf("invalid tipset selector %q, expected %s, %s or %s", s, SelectHead, SelectHeadParent, SelectFinalized)
+	}
+}
+
+type tipSetSelectorKey struct{}
+
+// WithTipSetSelector makes the empty tipset keys looked up with ctx refer to the tipset selected by sel.
+func WithTipSetSelector(ctx context.Context, sel TipSetSelector) context.Context {
+	return context.WithValue(ctx, tipSetSelectorKey{}, sel)
+}
+
+// TipSetSelectorFromContext returns the selector of ctx, the head when ctx has none.
+func TipSetSelectorFromContext(ctx context.Context) TipSetSelector {
+	if sel, ok := ctx.Value(tipSetSelectorKey{}).(TipSetSelector); ok {
+		return sel
+	}
+	return SelectHead
+}
+
+// selectTipSet returns the tipset selected by the selector of ctx.
+func (store *Store) selectTipSet(ctx context.Context) (*types.TipSet, error) {
+	head := store.GetHead()
+	switch TipSetSelectorFromContext(ctx) {
+	case SelectHeadParent:
+		if head.Height() == 0 {
+			return head, nil
+		}
+		return store.getTipSet(ctx, head.Parents())
+	case SelectFinalized:
+		h := head.Height() - policy.ChainFinality
+		if h < 0 {
+			h = 0
+		}
+		return store.GetTipSetByHeight(ctx, head, h, true)
+	default:
+		return head, nil
+	}
+}
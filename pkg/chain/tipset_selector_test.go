@@ -0,0 +1,32 @@
+// stm: #unit
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestTipSetSelector(t *testing.T) {
+	tf.UnitTest(t)
+
+	for s, expect := range map[string]TipSetSelector{
+		"":          SelectHead,
+		"head":      SelectHead,
+		"head-1":    SelectHeadParent,
+		"finalized": SelectFinalized,
+	} {
+		sel, err := ParseTipSetSelector(s)
+		require.NoError(t, err)
+		require.Equal(t, expect, sel)
+	}
+	_, err := ParseTipSetSelector("safe")
+	require.Error(t, err)
+
+	ctx := context.Background()
+	require.Equal(t, SelectHead, TipSetSelectorFromContext(ctx))
+	require.Equal(t, SelectFinalized, TipSetSelectorFromContext(WithTipSetSelector(ctx, SelectFinalized)))
+}
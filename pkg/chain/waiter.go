@@ -69,6 +69,19 @@ func (w *Waiter) Find(ctx context.Context, msg types.ChainMsg, lookback abi.Chai
 	return w.findMessage(ctx, ts, msg, lookback, allowReplaced)
 }
 
+// FindByNonce searches the blockchain history for the message executed with the sender and nonce of msg,
+// which is either msg itself or a message that replaced it, whether or not it only differs in gas values.
+func (w *Waiter) FindByNonce(ctx context.Context, msg types.ChainMsg, lookback abi.ChainEpoch, ts *types.TipSet) (*types.ChainMessage, bool, error) {
+	if ts == nil {
+		ts = w.chainReader.GetHead()
+	}
+
+	return w.searchBack(ctx, ts, msg, lookback, func(cur *types.TipSet) (*types.ChainMessage, bool, error) {
+		found, err := w.receiptForNonce(ctx, cur, msg.VMMessage().From, msg.VMMessage().Nonce)
+		return found, found != nil, err
+	})
+}
+
 // WaitPredicate invokes the callback when the passed predicate succeeds.
 // See api description.
 //
@@ -105,6 +118,19 @@ func (w *Waiter) Wait(ctx context.Context, msg types.ChainMsg, confidence uint64
 // if now block with the given CID exists in the chain.
 // The lookback parameter is the number of tipsets in the past this method will check before giving up.
 func (w *Waiter) findMessage(ctx context.Context, from *types.TipSet, m types.ChainMsg, lookback abi.ChainEpoch, allowReplaced bool) (*types.ChainMessage, bool, error) {
+	return w.searchBack(ctx, from, m, lookback, func(cur *types.TipSet) (*types.ChainMessage, bool, error) {
+		return w.receiptForTipset(ctx, cur, m, allowReplaced)
+	})
+}
+
+// searchBack walks back the chain from the tipset from, and calls lookup on the tipset whose parent
+// state moved the nonce of the sender of m past the nonce of m.
+func (w *Waiter) searchBack(ctx context.Context,
+	from *types.TipSet,
+	m types.ChainMsg,
+	lookback abi.ChainEpoch,
+	lookup func(cur *types.TipSet) (*types.ChainMessage, bool, error),
+) (*types.ChainMessage, bool, error) {
 	limitHeight := from.Height() - lookback
 	noLimit := lookback == constants.LookbackNoLimit
 
@@ -149,7 +175,7 @@ func (w *Waiter) findMessage(ctx context.Context, from *types.TipSet, m types.Ch
 
 		// check that between cur and parent tipset the nonce fell into range of our message
 		if actorNoExist || (curActor.Nonce > mNonce && act.Nonce <= mNonce) {
-			msg, found, err := w.receiptForTipset(ctx, cur, m, allowReplaced)
+			msg, found, err := lookup(cur)
 			if err != nil {
 				log.Errorf("Waiter.Wait: %s", err)
 				return nil, false, err
@@ -268,54 +294,67 @@ func (w *Waiter) waitForMessage(ctx context.Context, ch <-chan []*types.HeadChan
 }
 
 func (w *Waiter) receiptForTipset(ctx context.Context, ts *types.TipSet, msg types.ChainMsg, allowReplaced bool) (*types.ChainMessage, bool, error) {
+	expectedMsg := msg.VMMessage()
+	expectedCid := msg.Cid()
+	expectedNonce := expectedMsg.Nonce
+	expectedFrom := expectedMsg.From
+
+	found, err := w.receiptForNonce(ctx, ts, expectedFrom, expectedNonce)
+	if err != nil || found == nil {
+		return nil, false, err
+	}
+
+	msgCid := found.Message.Cid()
+	if !found.Message.VMMessage().EqualCall(expectedMsg) {
+		// this is an entirely different message, fail
+		return nil, false, fmt.Errorf("found message with equal nonce as the one we are looking for that is NOT a valid replacement message (F:%s n %d, TS: %s n%d)",
+			expectedMsg.Cid(), expectedMsg.Nonce, msgCid, found.Message.VMMessage().Nonce)
+	}
+
+	if msgCid != expectedCid {
+		if !allowReplaced {
+			log.Warnw("found message with equal nonce and call params but different CID",
+				"wanted", expectedCid, "found", msgCid, "nonce", expectedNonce, "from", expectedFrom)
+			return nil, false, fmt.Errorf("found message with equal nonce as the one we are looking for (F:%s n %d, TS: %s n%d)",
+				expectedCid, expectedNonce, msgCid, found.Message.VMMessage().Nonce)
+		}
+	}
+
+	return found, true, nil
+}
+
+// receiptForNonce returns the message sent by from with nonce among the messages executed by ts,
+// with its receipt, or nil if there is none.
+func (w *Waiter) receiptForNonce(ctx context.Context, ts *types.TipSet, from address.Address, nonce uint64) (*types.ChainMessage, error) {
 	// The genesis block
 	if ts.Height() == 0 {
-		return nil, false, nil
+		return nil, nil
 	}
 
 	pts, err := w.chainReader.GetTipSet(ctx, ts.Parents())
 	if err != nil {
-		return nil, false, err
+		return nil, err
 	}
 	blockMessageInfos, err := w.messageProvider.LoadTipSetMessage(ctx, pts)
 	if err != nil {
-		return nil, false, err
+		return nil, err
 	}
-	expectedMsg := msg.VMMessage()
-	expectedCid := msg.Cid()
-	expectedNonce := msg.VMMessage().Nonce
-	expectedFrom := msg.VMMessage().From
 	for _, bms := range blockMessageInfos {
 		for _, msg := range append(bms.BlsMessages, bms.SecpkMessages...) {
-			msgCid := msg.Cid()
-			if msg.VMMessage().From == expectedFrom { // cheaper to just check origin first
-				if msg.VMMessage().Nonce == expectedNonce {
-					if !msg.VMMessage().EqualCall(expectedMsg) {
-						// this is an entirely different message, fail
-						return nil, false, fmt.Errorf("found message with equal nonce as the one we are looking for that is NOT a valid replacement message (F:%s n %d, TS: %s n%d)",
-							expectedMsg.Cid(), expectedMsg.Nonce, msg.Cid(), msg.VMMessage().Nonce)
-					}
-
-					if msgCid != expectedCid {
-						if !allowReplaced {
-							log.Warnw("found message with equal nonce and call params but different CID",
-								"wanted", expectedCid, "found", msgCid, "nonce", expectedNonce, "from", expectedFrom)
-							return nil, false, fmt.Errorf("found message with equal nonce as the one we are looking for (F:%s n %d, TS: %s n%d)",
-								expectedCid, expectedNonce, msgCid, msg.VMMessage().Nonce)
-						}
-					}
+			// cheaper to just check origin first
+			if msg.VMMessage().From != from || msg.VMMessage().Nonce != nonce {
+				continue
+			}
 
-					recpt, err := w.receiptByIndex(ctx, pts, msgCid, blockMessageInfos)
-					if err != nil {
-						return nil, false, errors.Wrap(err, "error retrieving receipt from tipset")
-					}
-					return &types.ChainMessage{TS: ts, Message: msg, Block: bms.Block, Receipt: recpt}, true, nil
-				}
+			recpt, err := w.receiptByIndex(ctx, pts, msg.Cid(), blockMessageInfos)
+			if err != nil {
+				return nil, errors.Wrap(err, "error retrieving receipt from tipset")
 			}
+			return &types.ChainMessage{TS: ts, Message: msg, Block: bms.Block, Receipt: recpt}, nil
 		}
 	}
 
-	return nil, false, nil
+	return nil, nil
 }
 
 func (w *Waiter) receiptByIndex(ctx context.Context, ts *types.TipSet, targetCid cid.Cid, blockMsgs []types.BlockMessagesInfo) (*types.MessageReceipt, error) {
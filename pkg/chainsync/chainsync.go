@@ -67,6 +67,12 @@ func (m *Manager) Start(ctx context.Context) error {
 	return nil
 }
 
+// RegisterCallback registers cb to be called after the sync of every target, with the error of the sync, it must
+// be called before Start.
+func (m *Manager) RegisterCallback(cb func(*types.Target, error)) {
+	m.dispatcher.RegisterCallback(cb)
+}
+
 // BlockProposer returns the block proposer.
 func (m *Manager) BlockProposer() BlockProposer {
 	return m.dispatcher
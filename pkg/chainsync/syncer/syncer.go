@@ -29,6 +29,7 @@ import (
 	"github.com/filecoin-project/venus/pkg/net/exchange"
 	"github.com/filecoin-project/venus/venus-shared/actors/policy"
 	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+	vlogging "github.com/filecoin-project/venus/venus-shared/logging"
 	"github.com/filecoin-project/venus/venus-shared/types"
 	blockstore "github.com/ipfs/boxo/blockstore"
 	"github.com/ipfs/go-cid"
@@ -189,13 +190,17 @@ func NewSyncer(stmgr *statemanger.Stmgr,
 // of the bsstore if this tipset is the heaviest.
 // todo mark bad-block
 func (syncer *Syncer) syncOne(ctx context.Context, parent, next *types.TipSet) error {
-	logSyncer.Infof("syncOne tipset, height:%d, blocks:%s", next.Height(), next.Key().String())
+	logSyncer.Infow("syncOne tipset", vlogging.TipSetFields(next)...)
 	priorHeadKey := syncer.chainStore.GetHead()
 	// if tipset is already priorHeadKey, we've been here before. do nothing.
 	if priorHeadKey.Equals(next) {
 		return nil
 	}
 
+	ctx, span := trace.StartSpan(ctx, "Syncer.syncOne")
+	span.AddAttributes(trace.Int64Attribute("height", int64(next.Height())))
+	defer span.End()
+
 	stopwatch := syncOneTimer.Start()
 	defer stopwatch(ctx)
 
@@ -279,7 +284,7 @@ func (syncer *Syncer) HandleNewTipSet(ctx context.Context, target *syncTypes.Tar
 		}
 		tracing.AddErrorEndSpan(ctx, span, &err)
 		span.End()
-		logSyncer.Infof("handle tipset height %d, count %d, took %.4f(s)", target.Head.Height(), target.Head.Len(), time.Since(now).Seconds())
+		logSyncer.Infow("handle tipset", append(vlogging.TipSetFields(target.Head), "count", target.Head.Len(), "took", time.Since(now))...)
 	}()
 
 	logSyncer.Debugf("begin fetch and sync of chain with head %v from %s at height %v", target.Head.Key(), target.Sender.String(), target.Head.Height())
@@ -388,6 +393,11 @@ func (syncer *Syncer) syncSegement(ctx context.Context, target *syncTypes.Target
 // if there is a fork, get the common root tipset of knowntip and targettip, and return the block data from root tipset to targettip
 // local(···->A->B) + incoming(C->D->E)  => ···->A->B->C->D->E
 func (syncer *Syncer) fetchChainBlocks(ctx context.Context, knownTip *types.TipSet, targetTip *types.TipSet, ignoreCheckpoint bool) ([]*types.TipSet, error) {
+	ctx, span := trace.StartSpan(ctx, "Syncer.fetchChainBlocks")
+	span.AddAttributes(trace.Int64Attribute("known_height", int64(knownTip.Height())),
+		trace.Int64Attribute("target_height", int64(targetTip.Height())))
+	defer span.End()
+
 	chainTipsets := []*types.TipSet{targetTip}
 	flushDB := func(saveTips []*types.TipSet) error {
 		bs := blockstoreutil.NewTemporary()
@@ -598,6 +608,9 @@ func (syncer *Syncer) fetchSegMessage(ctx context.Context, segTipset []*types.Ti
 	if len(segTipset) == 0 {
 		return []*types.FullTipSet{}, nil
 	}
+	ctx, span := trace.StartSpan(ctx, "Syncer.fetchSegMessage")
+	span.AddAttributes(trace.Int64Attribute("tipsets", int64(len(segTipset))))
+	defer span.End()
 
 	chain.Reverse(segTipset)
 	defer chain.Reverse(segTipset)
@@ -680,6 +693,10 @@ func (syncer *Syncer) getFullBlock(ctx context.Context, tipset *types.TipSet) (*
 
 // processTipSetSegment process a batch of tipset in turn，
 func (syncer *Syncer) processTipSetSegment(ctx context.Context, target *syncTypes.Target, parent *types.TipSet, segTipset []*types.TipSet) (*types.TipSet, error) {
+	ctx, span := trace.StartSpan(ctx, "Syncer.processTipSetSegment")
+	span.AddAttributes(trace.Int64Attribute("tipsets", int64(len(segTipset))))
+	defer span.End()
+
 	for i, ts := range segTipset {
 		err := syncer.syncOne(ctx, parent, ts)
 		if err != nil {
@@ -46,6 +46,15 @@ type Config struct {
 	EventsConfig  *EventsConfig        `json:"events"`
 	PubsubConfig  *PubsubConfig        `json:"pubsub"`
 	FaultReporter *FaultReporterConfig `json:"faultReporter"`
+	Health        *HealthConfig        `json:"health"`
+	Mining        *MiningConfig        `json:"mining"`
+	Beacon        *BeaconConfig        `json:"beacon"`
+	Devnet        *DevnetConfig        `json:"devnet"`
+	Execution     *ExecutionConfig     `json:"execution"`
+	Maintenance   *MaintenanceConfig   `json:"maintenance"`
+	Journal       *JournalConfig       `json:"journal"`
+	// Profiles are the overrides selected by name with `venus daemon --config-profile`
+	Profiles map[string]Profile `json:"profiles,omitempty"`
 }
 
 // APIConfig holds all configuration options related to the api.
@@ -57,6 +66,18 @@ type APIConfig struct {
 	AccessControlAllowOrigin      []string `json:"accessControlAllowOrigin"`
 	AccessControlAllowCredentials bool     `json:"accessControlAllowCredentials"`
 	AccessControlAllowMethods     []string `json:"accessControlAllowMethods"`
+	// ActorStateCacheSize is the number of market, power and verifreg actor states cached for the
+	// API handlers, the cache is purged when the head changes, 0 disables it.
+	ActorStateCacheSize int `json:"actorStateCacheSize"`
+	// TipSetSelector is the tipset the empty tipset keys of the API requests refer to: "head", "head-1" or
+	// "finalized", the requests may override it with the X-Tipset-Selector header.
+	TipSetSelector string `json:"tipSetSelector"`
+	// RPCVersions are the versions of the rpc api served, "v0" and "v1", the v0 api is served by shims over
+	// the v1 api and can be turned off once the clients moved to v1.
+	RPCVersions []string `json:"rpcVersions"`
+	// GRPCAddress is the multiaddr the grpc server of the chain, state and message pool apis listens on, empty
+	// disables it.
+	GRPCAddress string `json:"grpcAddress"`
 }
 
 type RateLimitCfg struct {
@@ -76,6 +97,9 @@ func newDefaultAPIConfig() *APIConfig {
 			"https://127.0.0.1:8080",
 		},
 		AccessControlAllowMethods: []string{"GET", "POST", "PUT"},
+		ActorStateCacheSize:       64,
+		TipSetSelector:            "head",
+		RPCVersions:               []string{"v0", "v1"},
 	}
 }
 
@@ -84,6 +108,9 @@ func newDefaultAPIConfig() *APIConfig {
 type DatastoreConfig struct {
 	Type string `json:"type"`
 	Path string `json:"path"`
+	// EnableMessageIndex indexes the messages of the new tipsets by recipient actor type and method, by recipient
+	// and by sender, StateListMessages scans the index instead of walking the tipsets when it covers the range.
+	EnableMessageIndex bool `json:"enableMessageIndex"`
 }
 
 // Validators hold the list of validation functions for each configuration
@@ -170,6 +197,15 @@ type WalletConfig struct {
 	RemoteEnable     bool             `json:"remoteEnable"`
 	RemoteBackend    string           `json:"remoteBackend"`
 	GatewayBacked    string           `json:"gatewayBacked"`
+	// EnableLedger allows addresses held by a ledger device to be created and used.
+	EnableLedger bool `json:"enableLedger"`
+	// AutoLockTimeout locks the wallet again once it has been unlocked for this long, zero disables it.
+	AutoLockTimeout Duration `json:"autoLockTimeout"`
+	// EnableAuditLog records every signature of the wallet and every message pushed through the api to the
+	// signing audit log, with the token of the caller.
+	EnableAuditLog bool `json:"enableAuditLog"`
+	// AuditLogRetention deletes the records of the signing audit log older than it, zero keeps them forever.
+	AuditLogRetention Duration `json:"auditLogRetention"`
 }
 
 type PassphraseConfig struct {
@@ -222,12 +258,14 @@ type HeartbeatConfig struct {
 type ObservabilityConfig struct {
 	Metrics *MetricsConfig `json:"metrics"`
 	Tracing *TraceConfig   `json:"tracing"`
+	Logging *LoggingConfig `json:"logging"`
 }
 
 func newDefaultObservabilityConfig() *ObservabilityConfig {
 	return &ObservabilityConfig{
 		Metrics: newDefaultMetricsConfig(),
 		Tracing: newDefaultTraceConfig(),
+		Logging: newDefaultLoggingConfig(),
 	}
 }
 
@@ -239,6 +277,10 @@ type MetricsConfig struct {
 	ReportInterval string `json:"reportInterval"`
 	// PrometheusEndpoint represents the address filecoin will expose prometheus metrics at.
 	PrometheusEndpoint string `json:"prometheusEndpoint"`
+	// APIMetricsEnabled records per-method latency, payload size and error metrics for the json-rpc api.
+	APIMetricsEnabled bool `json:"apiMetricsEnabled"`
+	// SlowCallThreshold logs json-rpc calls that take longer than it, zero disables the slow call log.
+	SlowCallThreshold Duration `json:"slowCallThreshold"`
 }
 
 func newDefaultMetricsConfig() *MetricsConfig {
@@ -246,6 +288,8 @@ func newDefaultMetricsConfig() *MetricsConfig {
 		PrometheusEnabled:  false,
 		ReportInterval:     "5s",
 		PrometheusEndpoint: "/ip4/0.0.0.0/tcp/9400",
+		APIMetricsEnabled:  true,
+		SlowCallThreshold:  Duration(5 * time.Second),
 	}
 }
 
@@ -259,6 +303,15 @@ type TraceConfig struct {
 	// JaegerEndpoint is the URL traces are collected on.
 	JaegerEndpoint string `json:"jaegerEndpoint"`
 	ServerName     string `json:"servername"`
+	// OTLPTracingEnabled will enable exporting traces to an OTLP/HTTP collector when true,
+	// it can not be enabled together with jaeger.
+	OTLPTracingEnabled bool `json:"otlpTracingEnabled"`
+	// OTLPEndpoint is the host:port of the OTLP/HTTP collector.
+	OTLPEndpoint string `json:"otlpEndpoint"`
+	// OTLPInsecure sends traces over plain http instead of https.
+	OTLPInsecure bool `json:"otlpInsecure"`
+	// OTLPHeaders are sent with every export request, e.g. for collector authentication.
+	OTLPHeaders map[string]string `json:"otlpHeaders"`
 }
 
 func newDefaultTraceConfig() *TraceConfig {
@@ -267,6 +320,22 @@ func newDefaultTraceConfig() *TraceConfig {
 		JaegerTracingEnabled: false,
 		ProbabilitySampler:   1.0,
 		ServerName:           "venus-node",
+		OTLPEndpoint:         "localhost:4318",
+		OTLPInsecure:         true,
+	}
+}
+
+// LoggingConfig holds all configuration options related to the daemon log output.
+type LoggingConfig struct {
+	// Format is the output format of the logs, one of color, nocolor and json.
+	Format string `json:"format"`
+	// Levels overrides the log level of subsystems at startup, e.g. {"chainsync": "warn"}.
+	Levels map[string]string `json:"levels"`
+}
+
+func newDefaultLoggingConfig() *LoggingConfig {
+	return &LoggingConfig{
+		Format: "color",
 	}
 }
 
@@ -276,17 +345,73 @@ type MessagePoolConfig struct {
 	MaxNonceGap uint64 `json:"maxNonceGap"`
 	// MaxFee
 	MaxFee types.FIL `json:"maxFee"`
+	// PremiumOracle configures how gas premiums are estimated
+	PremiumOracle PremiumOracleConfig `json:"premiumOracle"`
+	// AdmissionPolicy configures the rules a message must pass before it is validated and added to the mpool
+	AdmissionPolicy MpoolAdmissionPolicyConfig `json:"admissionPolicy"`
+	// EnableScheduler holds the messages scheduled with MpoolScheduleMessage until their trigger fires
+	EnableScheduler bool `json:"enableScheduler"`
+}
+
+// MpoolAdmissionPolicyConfig holds the operator rules evaluated on every message entering the mpool,
+// the zero value of each rule disables it.
+type MpoolAdmissionPolicyConfig struct {
+	// MaxGasLimit rejects messages with a higher gas limit
+	MaxGasLimit int64 `json:"maxGasLimit"`
+	// MinGasPremium rejects messages paying a lower gas premium, in attoFIL
+	MinGasPremium int64 `json:"minGasPremium"`
+	// DeniedRecipients rejects messages sent to any of these addresses
+	DeniedRecipients []address.Address `json:"deniedRecipients"`
+	// DeniedMethods rejects messages calling any of these method numbers
+	DeniedMethods []abi.MethodNum `json:"deniedMethods"`
+}
+
+// PremiumOracleConfig configures the gas premium oracle, when enabled premiums are estimated from
+// the premiums paid by recently included messages instead of a fixed target and floor.
+type PremiumOracleConfig struct {
+	Enable bool `json:"enable"`
+	// Window is the number of tipsets sampled, it must be positive
+	Window int `json:"window"`
+	// Quantile of the included gas, ordered by premium, to pay, in (0, 1], 0.5 pays the median premium
+	Quantile float64 `json:"quantile"`
+	// MinPremium is the lowest premium returned, in attoFIL
+	MinPremium int64 `json:"minPremium"`
 }
 
 var DefaultMessagePoolParam = &MessagePoolConfig{
-	MaxNonceGap: 100,
-	MaxFee:      DefaultDefaultMaxFee,
+	MaxNonceGap:   100,
+	MaxFee:        DefaultDefaultMaxFee,
+	PremiumOracle: newDefaultPremiumOracleConfig(),
 }
 
 func newDefaultMessagePoolConfig() *MessagePoolConfig {
 	return &MessagePoolConfig{
-		MaxNonceGap: 100,
-		MaxFee:      DefaultDefaultMaxFee,
+		MaxNonceGap:   100,
+		MaxFee:        DefaultDefaultMaxFee,
+		PremiumOracle: newDefaultPremiumOracleConfig(),
+	}
+}
+
+// Check returns an error when the oracle is enabled with a window or a quantile it can't sample.
+func (c PremiumOracleConfig) Check() error {
+	if !c.Enable {
+		return nil
+	}
+	if c.Window <= 0 {
+		return fmt.Errorf("mpool.premiumOracle.window %d must be positive", c.Window)
+	}
+	if c.Quantile <= 0 || c.Quantile > 1 {
+		return fmt.Errorf("mpool.premiumOracle.quantile %v must be in (0, 1]", c.Quantile)
+	}
+	return nil
+}
+
+func newDefaultPremiumOracleConfig() PremiumOracleConfig {
+	return PremiumOracleConfig{
+		Enable:     false,
+		Window:     10,
+		Quantile:   0.5,
+		MinPremium: 100e3,
 	}
 }
 
@@ -305,6 +430,11 @@ type NetworkParamsConfig struct {
 	PreCommitChallengeDelay abi.ChainEpoch               `json:"-"`
 	PropagationDelaySecs    uint64                       `json:"-"`
 	AllowableClockDriftSecs uint64                       `json:"allowableClockDriftSecs"`
+	// MigrationMaxWorkers is the number of workers of the state migrations, defaults to the number of CPUs.
+	MigrationMaxWorkers int `json:"migrationMaxWorkers"`
+	// UpgradeHeightOverrides replaces the upgrade heights of the network, by their name in ForkUpgradeConfig,
+	// so devnets can schedule their own upgrades. It is refused on mainnet.
+	UpgradeHeightOverrides map[string]abi.ChainEpoch `json:"upgradeHeightOverrides,omitempty"`
 	// ChainId defines the chain ID used in the Ethereum JSON-RPC endpoint.
 	// As per https://github.com/ethereum-lists/chains
 	Eip155ChainID int `json:"-"`
@@ -359,6 +489,34 @@ type ForkUpgradeConfig struct {
 	UpgradeTockHeight                    abi.ChainEpoch `json:"upgradeTockHeight"`
 }
 
+// Heights returns the upgrade heights and durations by their name.
+func (c *ForkUpgradeConfig) Heights() map[string]abi.ChainEpoch {
+	v := reflect.ValueOf(c).Elem()
+	out := make(map[string]abi.ChainEpoch, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		out[v.Type().Field(i).Tag.Get("json")] = abi.ChainEpoch(v.Field(i).Int())
+	}
+	return out
+}
+
+// Override sets the upgrade heights and durations by their name, it fails on unknown names.
+func (c *ForkUpgradeConfig) Override(heights map[string]abi.ChainEpoch) error {
+	v := reflect.ValueOf(c).Elem()
+	fields := make(map[string]reflect.Value, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		fields[v.Type().Field(i).Tag.Get("json")] = v.Field(i)
+	}
+	for name := range heights {
+		if _, ok := fields[name]; !ok {
+			return fmt.Errorf("unknown upgrade %s", name)
+		}
+	}
+	for name, height := range heights {
+		fields[name].SetInt(int64(height))
+	}
+	return nil
+}
+
 func IsNearUpgrade(epoch, upgradeEpoch abi.ChainEpoch) bool {
 	return epoch > upgradeEpoch-constants.Finality && epoch < upgradeEpoch+constants.Finality
 }
@@ -466,6 +624,10 @@ type EventConfig struct {
 	// MaxFilters specifies the maximum number of filters that may exist at any one time.
 	MaxFilters int `json:"maxFilters"`
 
+	// MaxFiltersPerToken specifies the maximum number of filters that may be installed with the same api token,
+	// 0 is unlimited.
+	MaxFiltersPerToken int `json:"maxFiltersPerToken"`
+
 	// MaxFilterResults specifies the maximum number of results that can be accumulated by an actor event filter.
 	MaxFilterResults int `json:"maxFilterResults"`
 
@@ -479,18 +641,54 @@ type EventConfig struct {
 	// relative to the CWD (current working directory).
 	DatabasePath string `json:"databasePath"`
 
+	// ReadOnly serves the historic filter APIs from the event index at DatabasePath without writing to it, eg. a
+	// replica of the events.db of a node indexing the events, kept up to date by a replication tool. The node does
+	// not index the events itself, so the heavy eth_getLogs traffic can be moved off the indexing node.
+	ReadOnly bool `json:"readOnly"`
+
+	// MaxWebsocketConnections specifies the maximum number of websocket connections to the rpc api open at any one
+	// time, the connections over it are rejected with 503. 0 is unlimited.
+	MaxWebsocketConnections int `json:"maxWebsocketConnections"`
+
+	// MaxSubscriptionsPerConnection specifies the maximum number of eth subscriptions of a websocket connection,
+	// 0 is unlimited.
+	MaxSubscriptionsPerConnection int `json:"maxSubscriptionsPerConnection"`
+
+	// MaxSubscriptionSendBuffer specifies the maximum size in bytes of the eth subscription responses waiting to be
+	// sent to slow clients, across all the subscriptions. A subscription whose response would go over it is dropped.
+	// 0 is unlimited.
+	MaxSubscriptionSendBuffer int64 `json:"maxSubscriptionSendBuffer"`
+
+	// SubscriptionBackpressure is what happens to an eth subscription whose send queue or the send buffer is full:
+	// kill stops the subscription, drop-oldest drops its oldest response not sent yet, coalesce replaces the heads
+	// not sent yet of a newHeads subscription by the new one and stops the other subscriptions.
+	SubscriptionBackpressure string `json:"subscriptionBackpressure"`
+
 	// Others, not implemented yet:
-	// Set a limit on the number of active websocket subscriptions (may be zero)
 	// Set a timeout for subscription clients
 	// Set upper bound on index size
 }
 
+// The values of EventConfig.SubscriptionBackpressure.
+const (
+	BackpressureKill       = "kill"
+	BackpressureDropOldest = "drop-oldest"
+	BackpressureCoalesce   = "coalesce"
+)
+
 type FevmConfig struct {
 	//EnableEthRPC enables eth_rpc, and enables storing a mapping of eth transaction hashes to filecoin message Cids.
 	EnableEthRPC bool `json:"enableEthRPC"`
 	// EthTxHashMappingLifetimeDays the transaction hash lookup database will delete mappings that have been stored for more than x days
 	// Set to 0 to keep all mappings
 	EthTxHashMappingLifetimeDays int `json:"ethTxHashMappingLifetimeDays"`
+	// EnableContractIndex indexes the creations of the EVM contracts from the execution traces of the new tipsets,
+	// serving EthGetContractCreation and EthGetContractsByCreator. It requires EnableEthRPC.
+	EnableContractIndex bool `json:"enableContractIndex"`
+	// EnableTokenIndex indexes the ERC-20, ERC-721 and ERC-1155 transfer logs and the datacap transfers of the new
+	// tipsets, serving EthGetTokenTransfers, EthGetTokenBalanceHistory and the EthGetNFT methods. It requires
+	// EnableEthRPC.
+	EnableTokenIndex bool `json:"enableTokenIndex"`
 
 	// EthTraceFilterMaxResults sets the maximum results returned per request by trace_filter
 	EthTraceFilterMaxResults uint64 `json:"ethTraceFilterMaxResults"`
@@ -503,6 +701,21 @@ type FevmConfig struct {
 	EthBlkCacheSize int
 
 	Event EventConfig `json:"event"`
+
+	// UntrustedTx limits what EthSendRawTransactionUntrusted accepts.
+	UntrustedTx UntrustedTxConfig `json:"untrustedTx"`
+}
+
+type UntrustedTxConfig struct {
+	// AllowSenders, when not empty, is the only set of senders accepted, both 0x and f4 forms are supported.
+	AllowSenders []string `json:"allowSenders"`
+	// DenySenders are always rejected, both 0x and f4 forms are supported.
+	DenySenders []string `json:"denySenders"`
+	// MaxGasLimit rejects transactions with a higher gas limit, 0 disables the check.
+	MaxGasLimit int64 `json:"maxGasLimit"`
+	// MaxPendingPerSender rejects transactions from senders which already have this many messages
+	// in the message pool, 0 disables the check.
+	MaxPendingPerSender int `json:"maxPendingPerSender"`
 }
 
 type EventsConfig struct {
@@ -530,6 +743,11 @@ func newFevmConfig() *FevmConfig {
 			MaxFilters:               100,
 			MaxFilterResults:         10000,
 			MaxFilterHeightRange:     2880, // conservative limit of one day
+			SubscriptionBackpressure: BackpressureKill,
+		},
+		UntrustedTx: UntrustedTxConfig{
+			MaxGasLimit:         constants.BlockGasLimit,
+			MaxPendingPerSender: 100,
 		},
 	}
 }
@@ -544,10 +762,12 @@ func newEventsConfig() *EventsConfig {
 type PubsubConfig struct {
 	// Run the node in bootstrap-node mode
 	Bootstrapper bool `json:"bootstrapper"`
+	// QuarantineSize is the number of blocks and messages rejected by the pubsub validators kept for inspection
+	QuarantineSize int `json:"quarantineSize"`
 }
 
 func newPubsubConfig() *PubsubConfig {
-	return &PubsubConfig{Bootstrapper: false}
+	return &PubsubConfig{Bootstrapper: false, QuarantineSize: 256}
 }
 
 type FaultReporterConfig struct {
@@ -574,6 +794,163 @@ func newFaultReporterConfig() *FaultReporterConfig {
 	return &FaultReporterConfig{}
 }
 
+// HealthConfig holds the thresholds of the readiness checks served at /readyz.
+type HealthConfig struct {
+	// MaxSyncLagEpochs is the number of epochs the chain head may lag behind the wall clock.
+	MaxSyncLagEpochs int64 `json:"maxSyncLagEpochs"`
+	// MinPeers is the minimum number of connected peers.
+	MinPeers int64 `json:"minPeers"`
+	// MaxMpoolPending is the maximum number of pending messages in the message pool.
+	MaxMpoolPending int64 `json:"maxMpoolPending"`
+	// MaxEventIndexLagEpochs is the number of epochs the event index may lag behind the chain head,
+	// only checked when the event index is enabled.
+	MaxEventIndexLagEpochs int64 `json:"maxEventIndexLagEpochs"`
+}
+
+func newDefaultHealthConfig() *HealthConfig {
+	return &HealthConfig{
+		MaxSyncLagEpochs:       10,
+		MinPeers:               1,
+		MaxMpoolPending:        30000,
+		MaxEventIndexLagEpochs: 10,
+	}
+}
+
+// MiningConfig holds the options of the block production loop.
+type MiningConfig struct {
+	// Enable turns on block production for Miners.
+	Enable bool `json:"enable"`
+	// Miners are the miner actors blocks are produced for, their workers must be in the local wallet.
+	Miners []address.Address `json:"miners"`
+	// WinningPoStProverURL is the api address of the venus-gateway computing the winning PoSt proofs.
+	WinningPoStProverURL string `json:"winningPoStProverURL"`
+	// WinningPoStProverToken is the token used to connect to WinningPoStProverURL.
+	WinningPoStProverToken string `json:"winningPoStProverToken"`
+}
+
+func newDefaultMiningConfig() *MiningConfig {
+	return &MiningConfig{
+		Enable: false,
+		Miners: []address.Address{},
+	}
+}
+
+// BeaconConfig holds the options of the drand clients.
+type BeaconConfig struct {
+	// ExtraServers are drand HTTP relays used along with the built-in servers of every drand network.
+	ExtraServers []string `json:"extraServers"`
+	// HealthCheckInterval is the interval the drand servers are probed at, the requests go to the
+	// healthy servers first, fastest first, 0 disables the probes.
+	HealthCheckInterval Duration `json:"healthCheckInterval"`
+}
+
+func newDefaultBeaconConfig() *BeaconConfig {
+	return &BeaconConfig{
+		ExtraServers:        []string{},
+		HealthCheckInterval: Duration(30 * time.Second),
+	}
+}
+
+// DevnetConfig holds the options of the local chains bootstrapped by `venus daemon --bootstrap-devnet`.
+type DevnetConfig struct {
+	// Enable runs the node on a local 2k chain, with a mock drand beacon and fake proofs.
+	Enable bool `json:"enable"`
+	// BlockDelaySecs is the duration of the epochs of the local chain.
+	BlockDelaySecs uint64 `json:"blockDelaySecs"`
+}
+
+func newDefaultDevnetConfig() *DevnetConfig {
+	return &DevnetConfig{
+		Enable:         false,
+		BlockDelaySecs: 1,
+	}
+}
+
+// ExecutionConfig holds the limits of the concurrent message executions of the FVM. The tipset
+// validation runs in the reserved lanes, the API calls (StateCall, eth_call, gas estimation, ...) in
+// their own lanes taken from the unreserved ones, so they can't delay the validation.
+type ExecutionConfig struct {
+	// Concurrency is the number of messages executed concurrently, 0 keeps LOTUS_FVM_CONCURRENCY or 4.
+	Concurrency int `json:"concurrency"`
+	// ReservedConcurrency is the number of lanes reserved for the tipset validation, 0 keeps
+	// LOTUS_FVM_CONCURRENCY_RESERVED or 2.
+	ReservedConcurrency int `json:"reservedConcurrency"`
+	// APIConcurrency is the number of messages of the API calls executed concurrently, 0 allows all the
+	// unreserved lanes.
+	APIConcurrency int `json:"apiConcurrency"`
+	// APIQueueSize is the number of API calls waiting for a lane, the calls beyond are refused.
+	APIQueueSize int `json:"apiQueueSize"`
+	// APIQueueTimeout is how long an API call waits for a lane before failing.
+	APIQueueTimeout Duration `json:"apiQueueTimeout"`
+	// CallVMPoolSize is the number of machines kept ready for the read-only calls on a recent state,
+	// like eth_call, 0 creates a machine per call.
+	CallVMPoolSize int `json:"callVMPoolSize"`
+	// CallCacheSize is the number of results of the calls (StateCall, eth_call, ...) kept for the
+	// identical calls on the same tipset, 0 disables the cache.
+	CallCacheSize int `json:"callCacheSize"`
+}
+
+func newDefaultExecutionConfig() *ExecutionConfig {
+	return &ExecutionConfig{
+		Concurrency:         0,
+		ReservedConcurrency: 0,
+		APIConcurrency:      0,
+		APIQueueSize:        128,
+		APIQueueTimeout:     Duration(time.Minute),
+		CallVMPoolSize:      2,
+		CallCacheSize:       0,
+	}
+}
+
+// MaintenanceConfig holds the windows the disk maintenance of the node, the blockstore garbage collection
+// and the vacuum of the sqlite indexes, is allowed to run in. They should be chosen away from the proving
+// deadlines of the miners using the node.
+type MaintenanceConfig struct {
+	// Windows are the maintenance windows, the maintenance never runs when there are none.
+	Windows []MaintenanceWindow `json:"windows"`
+	// FullGC collects the garbage of every value log file of the blockstore in a window, instead of
+	// the first one with enough garbage.
+	FullGC bool `json:"fullGC"`
+}
+
+// MaintenanceWindow is a window opening at the times matched by a cron expression.
+type MaintenanceWindow struct {
+	// Cron is the minute, hour, day of month, month and day of week the window opens at, in the local time
+	// of the node, eg. "0 3 * * 1-5" opens it at 03:00 on the weekdays.
+	Cron string `json:"cron"`
+	// Duration is how long the window stays open, the running maintenance is interrupted when it closes.
+	Duration Duration `json:"duration"`
+}
+
+func newDefaultMaintenanceConfig() *MaintenanceConfig {
+	return &MaintenanceConfig{
+		Windows: []MaintenanceWindow{},
+	}
+}
+
+// JournalConfig holds the journal of the node, recording its lifecycle events, eg. the head changes, the
+// messages added to and removed from the message pool, the sync errors and the starts and stops of the api.
+type JournalConfig struct {
+	// DisabledEvents are the "system:event" types not recorded, the VENUS_JOURNAL_DISABLED_EVENTS environment
+	// variable overrides them.
+	DisabledEvents []string `json:"disabledEvents"`
+	// EnableFile writes the events as ndjson to the files of the journal directory of the repo.
+	EnableFile bool `json:"enableFile"`
+	// FileSizeLimit is the size of a journal file above which the next one is started.
+	FileSizeLimit int64 `json:"fileSizeLimit"`
+	// MemoryEvents is the number of latest events kept in memory for JournalEvents, 0 disables it.
+	MemoryEvents int `json:"memoryEvents"`
+}
+
+func newDefaultJournalConfig() *JournalConfig {
+	return &JournalConfig{
+		DisabledEvents: []string{"mpool:add", "mpool:remove"},
+		EnableFile:     true,
+		FileSizeLimit:  1 << 30,
+		MemoryEvents:   1000,
+	}
+}
+
 // NewDefaultConfig returns a config object with all the fields filled out to
 // their default values
 func NewDefaultConfig() *Config {
@@ -592,6 +969,13 @@ func NewDefaultConfig() *Config {
 		EventsConfig:  newEventsConfig(),
 		PubsubConfig:  newPubsubConfig(),
 		FaultReporter: newFaultReporterConfig(),
+		Health:        newDefaultHealthConfig(),
+		Mining:        newDefaultMiningConfig(),
+		Beacon:        newDefaultBeaconConfig(),
+		Devnet:        newDefaultDevnetConfig(),
+		Execution:     newDefaultExecutionConfig(),
+		Maintenance:   newDefaultMaintenanceConfig(),
+		Journal:       newDefaultJournalConfig(),
 	}
 }
 
@@ -0,0 +1,151 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// profilesKey is the json key of the profiles, which the overrides of a profile can not set.
+const profilesKey = "profiles"
+
+// Profile is a set of overrides of the config, eg. for a network, selected with `venus daemon --config-profile`,
+// so the nodes of several networks share the bulk of their config file.
+type Profile struct {
+	// Inherits is the profile applied before this one, the overrides of this profile win
+	Inherits string `json:"inherits,omitempty"`
+	// Network is the network of the nodes running the profile, it is used by the initialization of the repo and
+	// checked at the start of the daemon
+	Network string `json:"network,omitempty"`
+	// Overrides is a part of the config, eg. {"api": {"apiAddress": "/ip4/127.0.0.1/tcp/3454"}}, the objects are
+	// merged into the config and the other values replace the ones of the config
+	Overrides json.RawMessage `json:"overrides,omitempty"`
+}
+
+// profileChain returns the profile name and the profiles it inherits, the inherited ones first.
+func (cfg *Config) profileChain(name string) ([]Profile, error) {
+	var chain []Profile
+	seen := make(map[string]bool)
+	for n, child := name, ""; n != ""; {
+		if seen[n] {
+			return nil, fmt.Errorf("profile %s inherits itself", n)
+		}
+		seen[n] = true
+		p, ok := cfg.Profiles[n]
+		if !ok {
+			if child == "" {
+				return nil, fmt.Errorf("unknown profile %s", n)
+			}
+			return nil, fmt.Errorf("profile %s inherits unknown profile %s", child, n)
+		}
+		chain = append([]Profile{p}, chain...)
+		n, child = p.Inherits, n
+	}
+	return chain, nil
+}
+
+// ApplyProfile applies the overrides of the profile name, and of the profiles it inherits, to cfg.
+func (cfg *Config) ApplyProfile(name string) error {
+	chain, err := cfg.profileChain(name)
+	if err != nil {
+		return err
+	}
+	for _, p := range chain {
+		if err := cfg.override(p.Overrides); err != nil {
+			return fmt.Errorf("applying profile %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// ProfileNetwork returns the network of the profile name, or of the nearest profile it inherits which has one.
+func (cfg *Config) ProfileNetwork(name string) (string, error) {
+	chain, err := cfg.profileChain(name)
+	if err != nil {
+		return "", err
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		if chain[i].Network != "" {
+			return chain[i].Network, nil
+		}
+	}
+	return "", nil
+}
+
+// SaveProfile sets the overrides of the profile name to the fields of applied which differ from cfg with the
+// profiles name inherits applied, so the changes of a node running the profile stay in the profile.
+func (cfg *Config) SaveProfile(name string, applied *Config) error {
+	chain, err := cfg.profileChain(name)
+	if err != nil {
+		return err
+	}
+	inherited, err := cfg.clone()
+	if err != nil {
+		return err
+	}
+	for _, p := range chain[:len(chain)-1] {
+		if err := inherited.override(p.Overrides); err != nil {
+			return fmt.Errorf("applying profile %s: %w", name, err)
+		}
+	}
+
+	var a, b map[string]interface{}
+	if err := remarshal(inherited, &a); err != nil {
+		return err
+	}
+	if err := remarshal(applied, &b); err != nil {
+		return err
+	}
+	delete(a, profilesKey)
+	delete(b, profilesKey)
+	overrides, err := json.Marshal(overrideMaps(a, b))
+	if err != nil {
+		return err
+	}
+
+	p := chain[len(chain)-1]
+	p.Overrides = overrides
+	cfg.Profiles[name] = p
+	return nil
+}
+
+// override merges the json object overrides into cfg.
+func (cfg *Config) override(overrides json.RawMessage) error {
+	if len(overrides) == 0 {
+		return nil
+	}
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(overrides, &keys); err != nil {
+		return fmt.Errorf("overrides are not a json object: %w", err)
+	}
+	for key := range keys {
+		if strings.EqualFold(key, profilesKey) {
+			return fmt.Errorf("overrides can not set the profiles")
+		}
+	}
+	dec := json.NewDecoder(bytes.NewReader(overrides))
+	dec.DisallowUnknownFields()
+	return dec.Decode(cfg)
+}
+
+// overrideMaps returns the entries of b which differ from a, the maps are compared entry by entry.
+func overrideMaps(a, b map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{})
+	for k, vb := range b {
+		va := a[k]
+		ma, okA := va.(map[string]interface{})
+		mb, okB := vb.(map[string]interface{})
+		if okA && okB {
+			if sub := overrideMaps(ma, mb); len(sub) > 0 {
+				out[k] = sub
+			}
+			continue
+		}
+		if !reflect.DeepEqual(va, vb) {
+			out[k] = vb
+		}
+	}
+	return out
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func newProfilesConfig() *Config {
+	cfg := NewDefaultConfig()
+	cfg.Swarm.ConnMgrHigh = 300
+	cfg.Profiles = map[string]Profile{
+		"common": {
+			Overrides: json.RawMessage(`{"swarm": {"connMgrLow": 100}, "observability": {"logging": {"levels": {"chainsync": "warn"}}}}`),
+		},
+		"calibration": {
+			Inherits:  "common",
+			Network:   "calibrationnet",
+			Overrides: json.RawMessage(`{"swarm": {"connMgrHigh": 200}, "api": {"apiAddress": "/ip4/127.0.0.1/tcp/3454"}}`),
+		},
+		"mainnet": {Inherits: "common", Network: "mainnet"},
+	}
+	return cfg
+}
+
+func TestApplyProfile(t *testing.T) {
+	tf.UnitTest(t)
+
+	cfg := newProfilesConfig()
+	require.NoError(t, cfg.ApplyProfile("calibration"))
+	require.Equal(t, uint(100), cfg.Swarm.ConnMgrLow)
+	require.Equal(t, uint(200), cfg.Swarm.ConnMgrHigh)
+	require.Equal(t, "/ip4/127.0.0.1/tcp/3454", cfg.API.APIAddress)
+	require.Equal(t, map[string]string{"chainsync": "warn"}, cfg.Observability.Logging.Levels)
+	// the fields left out of the overrides keep their value
+	require.Equal(t, NewDefaultConfig().Swarm.ProtectedPeers, cfg.Swarm.ProtectedPeers)
+
+	cfg = newProfilesConfig()
+	require.NoError(t, cfg.ApplyProfile("mainnet"))
+	require.Equal(t, uint(100), cfg.Swarm.ConnMgrLow)
+	require.Equal(t, uint(300), cfg.Swarm.ConnMgrHigh)
+
+	network, err := cfg.ProfileNetwork("calibration")
+	require.NoError(t, err)
+	require.Equal(t, "calibrationnet", network)
+	network, err = cfg.ProfileNetwork("common")
+	require.NoError(t, err)
+	require.Empty(t, network)
+
+	require.EqualError(t, cfg.ApplyProfile("butterfly"), "unknown profile butterfly")
+
+	cfg = newProfilesConfig()
+	cfg.Profiles["common"] = Profile{Inherits: "calibration"}
+	require.EqualError(t, cfg.ApplyProfile("calibration"), "profile calibration inherits itself")
+
+	cfg = newProfilesConfig()
+	cfg.Profiles["common"] = Profile{Inherits: "base"}
+	require.EqualError(t, cfg.ApplyProfile("calibration"), "profile common inherits unknown profile base")
+
+	cfg = newProfilesConfig()
+	cfg.Profiles["common"] = Profile{Overrides: json.RawMessage(`{"swarm": {"connMgrMid": 1}}`)}
+	require.Error(t, cfg.ApplyProfile("calibration"))
+
+	cfg = newProfilesConfig()
+	cfg.Profiles["common"] = Profile{Overrides: json.RawMessage(`{"profiles": {}}`)}
+	require.EqualError(t, cfg.ApplyProfile("calibration"), "applying profile calibration: overrides can not set the profiles")
+}
+
+func TestSaveProfile(t *testing.T) {
+	tf.UnitTest(t)
+
+	base := newProfilesConfig()
+	applied, err := base.clone()
+	require.NoError(t, err)
+	require.NoError(t, applied.ApplyProfile("calibration"))
+
+	applied.FevmConfig.Event.MaxFilters = 10
+	applied.Swarm.ConnMgrHigh = 250
+	require.NoError(t, base.SaveProfile("calibration", applied))
+
+	// the base and the inherited profile are left as is
+	require.Equal(t, uint(300), base.Swarm.ConnMgrHigh)
+	require.Equal(t, NewDefaultConfig().FevmConfig.Event.MaxFilters, base.FevmConfig.Event.MaxFilters)
+	require.Equal(t, newProfilesConfig().Profiles["common"], base.Profiles["common"])
+	require.Equal(t, "common", base.Profiles["calibration"].Inherits)
+	require.JSONEq(t, `{"swarm": {"connMgrHigh": 250}, "api": {"apiAddress": "/ip4/127.0.0.1/tcp/3454"}, "fevm": {"event": {"maxFilters": 10}}}`,
+		string(base.Profiles["calibration"].Overrides))
+
+	reloaded, err := base.clone()
+	require.NoError(t, err)
+	require.NoError(t, reloaded.ApplyProfile("calibration"))
+	paths, err := Diff(applied, reloaded)
+	require.NoError(t, err)
+	require.Empty(t, paths)
+}
+
+func TestValidateProfiles(t *testing.T) {
+	tf.UnitTest(t)
+
+	cfg := newProfilesConfig()
+	cfg.Profiles["calibration"] = Profile{
+		Inherits:  "common",
+		Overrides: json.RawMessage(`{"swarm": {"connMgrLow": 500, "connMgrMid": 1}}`),
+	}
+	cfg.Profiles["mainnet"] = Profile{Overrides: json.RawMessage(`{"swarm": {"connMgrLow": 500}}`)}
+	cfg.Profiles["butterfly"] = Profile{Inherits: "base"}
+	data, err := json.Marshal(cfg)
+	require.NoError(t, err)
+
+	_, report, err := Validate(data)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"profile butterfly: profile butterfly inherits unknown profile base",
+		"profile mainnet: swarm.connMgrLow 500 is above swarm.connMgrHigh 300",
+		"unknown key profiles.calibration.overrides.swarm.connMgrMid",
+	}, report.Errors)
+}
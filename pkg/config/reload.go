@@ -0,0 +1,295 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	logging "github.com/ipfs/go-log/v2"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+var log = logging.Logger("config")
+
+// reloadInterval is how often the config file is checked for changes.
+var reloadInterval = 5 * time.Second
+
+// reloadable are the json paths of the config fields applied to a running node, a path covers the fields below
+// it. The libp2p connection manager and the redis rate limiter are built once, so the connMgr fields of the swarm
+// and the rateLimit section require a restart.
+var reloadable = []string{
+	"observability.logging.levels",
+	"fevm.event.maxFilters",
+	"fevm.event.maxFiltersPerToken",
+	"fevm.event.maxFilterResults",
+	"fevm.event.maxFilterHeightRange",
+	"fevm.untrustedTx",
+	"swarm.protectedPeers",
+}
+
+// Reloadable returns whether the field at the json path path is applied to a running node.
+func Reloadable(path string) bool {
+	for _, p := range reloadable {
+		if path == p || strings.HasPrefix(path, p+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// Diff returns the sorted json paths of the fields which differ between a and b, the entries of the maps are
+// compared one by one, the slices as a whole. The profiles are left out, their changes show in the fields they set.
+func Diff(a, b *Config) ([]string, error) {
+	var ma, mb map[string]interface{}
+	if err := remarshal(a, &ma); err != nil {
+		return nil, err
+	}
+	if err := remarshal(b, &mb); err != nil {
+		return nil, err
+	}
+	delete(ma, profilesKey)
+	delete(mb, profilesKey)
+	var paths []string
+	diffMaps("", ma, mb, &paths)
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func diffMaps(prefix string, a, b map[string]interface{}, paths *[]string) {
+	for k, va := range a {
+		path := prefix + k
+		vb, ok := b[k]
+		if !ok {
+			*paths = append(*paths, path)
+			continue
+		}
+		ma, okA := va.(map[string]interface{})
+		mb, okB := vb.(map[string]interface{})
+		// a nil map, eg. the default of the log levels, is compared as an empty one
+		if okA && vb == nil {
+			mb, okB = map[string]interface{}{}, true
+		}
+		if okB && va == nil {
+			ma, okA = map[string]interface{}{}, true
+		}
+		if okA && okB {
+			diffMaps(path+".", ma, mb, paths)
+			continue
+		}
+		if !reflect.DeepEqual(va, vb) {
+			*paths = append(*paths, path)
+		}
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			*paths = append(*paths, prefix+k)
+		}
+	}
+}
+
+func remarshal(in, out interface{}) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+// ReloadFunc applies the reloadable fields of cfg to a running node, it is called with the whole config on
+// every change of a reloadable field.
+type ReloadFunc func(cfg *Config) error
+
+// Reloader applies the changes of the config file, and of Set, to the reloadable fields of a running node, the
+// changes of the other fields are saved and reported until a restart.
+type Reloader struct {
+	path    string
+	profile string
+	replace func(cfg *Config) error
+
+	lk      sync.Mutex
+	started *Config
+	current *Config
+	modTime time.Time
+	funcs   []ReloadFunc
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewReloader watches the config file at path, applying the profile when it is not empty, cfg is the config the
+// node started with and replace saves a config set with Set.
+func NewReloader(path, profile string, cfg *Config, replace func(cfg *Config) error) (*Reloader, error) {
+	started, err := cfg.clone()
+	if err != nil {
+		return nil, err
+	}
+	r := &Reloader{
+		path:    path,
+		profile: profile,
+		replace: replace,
+		started: started,
+		current: started,
+	}
+	if info, err := os.Stat(path); err == nil {
+		r.modTime = info.ModTime()
+	}
+	return r, nil
+}
+
+// Register adds a func applying reloadable fields.
+func (r *Reloader) Register(f ReloadFunc) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	r.funcs = append(r.funcs, f)
+}
+
+// Start watches the config file until Stop is called.
+func (r *Reloader) Start(ctx context.Context) {
+	ctx, r.cancel = context.WithCancel(ctx)
+	r.done = make(chan struct{})
+	go r.watch(ctx)
+}
+
+// Stop stops watching the config file.
+func (r *Reloader) Stop() {
+	if r.cancel == nil {
+		return
+	}
+	r.cancel()
+	<-r.done
+}
+
+func (r *Reloader) watch(ctx context.Context) {
+	defer close(r.done)
+
+	ticker := time.NewTicker(reloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		res, err := r.Reload()
+		if err != nil {
+			log.Errorf("reloading config %s: %v", r.path, err)
+			continue
+		}
+		if res != nil && len(res.Applied) > 0 {
+			log.Infow("config reloaded", "applied", res.Applied, "requiresRestart", res.RequiresRestart)
+		}
+	}
+}
+
+// Reload applies the config file when it changed since the last reload, it returns nil when it did not.
+func (r *Reloader) Reload() (*types.ConfigReload, error) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	info, err := os.Stat(r.path)
+	if os.IsNotExist(err) {
+		// the repo has no config file, eg. in memory
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if info.ModTime().Equal(r.modTime) {
+		return nil, nil
+	}
+	cfg, err := ReadFile(r.path)
+	if err != nil {
+		return nil, err
+	}
+	if r.profile != "" {
+		if err := cfg.ApplyProfile(r.profile); err != nil {
+			return nil, err
+		}
+	}
+	res, err := r.apply(cfg)
+	if err != nil {
+		return nil, err
+	}
+	r.modTime = info.ModTime()
+	if len(res.RequiresRestart) > 0 {
+		log.Warnw("config fields changed which require a restart", "fields", res.RequiresRestart)
+	}
+	return res, nil
+}
+
+// Set sets the field at the json path key to the json value, applies it when it is reloadable and saves the
+// config.
+func (r *Reloader) Set(key, value string) (*types.ConfigReload, error) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	cfg, err := r.current.clone()
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.Set(key, value); err != nil {
+		return nil, err
+	}
+	res, err := r.apply(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.replace(cfg); err != nil {
+		return nil, fmt.Errorf("saving the config: %w", err)
+	}
+	// the change is applied already
+	if info, err := os.Stat(r.path); err == nil {
+		r.modTime = info.ModTime()
+	}
+	return res, nil
+}
+
+// apply runs the reload funcs when a reloadable field of cfg changed. The caller holds the lock.
+func (r *Reloader) apply(cfg *Config) (*types.ConfigReload, error) {
+	changed, err := Diff(r.current, cfg)
+	if err != nil {
+		return nil, err
+	}
+	fromStart, err := Diff(r.started, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &types.ConfigReload{Applied: []string{}, RequiresRestart: []string{}}
+	for _, path := range changed {
+		if Reloadable(path) {
+			res.Applied = append(res.Applied, path)
+		}
+	}
+	for _, path := range fromStart {
+		if !Reloadable(path) {
+			res.RequiresRestart = append(res.RequiresRestart, path)
+		}
+	}
+
+	if len(res.Applied) > 0 {
+		for _, f := range r.funcs {
+			if err := f(cfg); err != nil {
+				return nil, fmt.Errorf("applying the config: %w", err)
+			}
+		}
+	}
+	r.current = cfg
+	return res, nil
+}
+
+func (cfg *Config) clone() (*Config, error) {
+	out := &Config{}
+	if err := remarshal(cfg, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestDiff(t *testing.T) {
+	tf.UnitTest(t)
+
+	a := NewDefaultConfig()
+	b := NewDefaultConfig()
+	paths, err := Diff(a, b)
+	require.NoError(t, err)
+	require.Empty(t, paths)
+
+	b.FevmConfig.Event.MaxFilters = 10
+	b.Observability.Logging.Levels = map[string]string{"chainsync": "warn"}
+	b.Swarm.ConnMgrHigh = 500
+	paths, err = Diff(a, b)
+	require.NoError(t, err)
+	require.Equal(t, []string{"fevm.event.maxFilters", "observability.logging.levels.chainsync", "swarm.connMgrHigh"}, paths)
+
+	require.True(t, Reloadable("observability.logging.levels.chainsync"))
+	require.True(t, Reloadable("fevm.untrustedTx.maxGasLimit"))
+	require.False(t, Reloadable("swarm.connMgrHigh"))
+	require.False(t, Reloadable("fevm.event.maxFiltersX"))
+}
+
+func TestReloader(t *testing.T) {
+	tf.UnitTest(t)
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	cfg := NewDefaultConfig()
+	require.NoError(t, cfg.WriteFile(path))
+
+	r, err := NewReloader(path, "", cfg, func(cfg *Config) error { return cfg.WriteFile(path) })
+	require.NoError(t, err)
+	var applied []*Config
+	r.Register(func(cfg *Config) error {
+		applied = append(applied, cfg)
+		return nil
+	})
+
+	res, err := r.Set("fevm.event.maxFilterResults", "20")
+	require.NoError(t, err)
+	require.Equal(t, []string{"fevm.event.maxFilterResults"}, res.Applied)
+	require.Empty(t, res.RequiresRestart)
+	require.Len(t, applied, 1)
+	require.Equal(t, 20, applied[0].FevmConfig.Event.MaxFilterResults)
+
+	// a field requiring a restart is saved and reported until the restart, without running the reload funcs
+	res, err = r.Set("swarm.connMgrLow", "10")
+	require.NoError(t, err)
+	require.Empty(t, res.Applied)
+	require.Equal(t, []string{"swarm.connMgrLow"}, res.RequiresRestart)
+	require.Len(t, applied, 1)
+
+	saved, err := ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, uint(10), saved.Swarm.ConnMgrLow)
+	require.Equal(t, 20, saved.FevmConfig.Event.MaxFilterResults)
+
+	// the file saved by Set is not reloaded
+	res, err = r.Reload()
+	require.NoError(t, err)
+	require.Nil(t, res)
+
+	// an edit of the file is
+	saved.Observability.Logging.Levels = map[string]string{"chainsync": "warn"}
+	require.NoError(t, saved.WriteFile(path))
+	later := time.Now().Add(time.Second)
+	require.NoError(t, os.Chtimes(path, later, later))
+	res, err = r.Reload()
+	require.NoError(t, err)
+	require.Equal(t, []string{"observability.logging.levels.chainsync"}, res.Applied)
+	require.Equal(t, []string{"swarm.connMgrLow"}, res.RequiresRestart)
+	require.Len(t, applied, 2)
+	require.Equal(t, "warn", applied[1].Observability.Logging.Levels["chainsync"])
+
+	_, err = r.Set("swarm.unknownField", "1")
+	require.Error(t, err)
+}
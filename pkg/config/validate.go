@@ -0,0 +1,331 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	vlogging "github.com/filecoin-project/venus/venus-shared/logging"
+)
+
+// removedSections are the sections of the old config layouts the node ignores, with the reason.
+var removedSections = map[string]string{
+	"heartbeat": "the heartbeat is no longer configured by the config file",
+	"drand":     "the drand servers are configured by parameters.drandSchedule",
+}
+
+// Check is a check of the values of a config, for the checks which need packages importing this one.
+type Check func(cfg *Config) error
+
+// ValidationReport is the result of the check of a config file against the current layout.
+type ValidationReport struct {
+	// Errors make the config unusable, eg. the unknown keys and the invalid values
+	Errors []string
+	// Deprecated are the warnings about the keys of the old layouts
+	Deprecated []string
+	// Defaults are the json paths of the fields missing from the file, which get their default
+	Defaults []string
+	// Migrated are the changes made by Migrate
+	Migrated []string
+}
+
+// Valid returns whether the config has no error.
+func (r *ValidationReport) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+// Validate checks the config file data against the current layout and runs the checks on its values, it returns
+// the config with the defaults filled. The error is only set when data is not a json object.
+func Validate(data []byte, checks ...Check) (*Config, *ValidationReport, error) {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, nil, fmt.Errorf("config is not a json object: %w", err)
+	}
+
+	report := &ValidationReport{}
+	for section, reason := range removedSections {
+		if _, ok := raw[section]; ok {
+			report.Deprecated = append(report.Deprecated, fmt.Sprintf("%s is ignored, %s", section, reason))
+			delete(raw, section)
+		}
+	}
+	unknownKeys(reflect.TypeOf(Config{}), raw, "", &report.Errors)
+	missingKeys(reflect.TypeOf(Config{}), raw, "", &report.Defaults)
+
+	cfg := NewDefaultConfig()
+	if err := json.Unmarshal(data, cfg); err != nil {
+		report.Errors = append(report.Errors, err.Error())
+		sortReport(report)
+		return nil, report, nil
+	}
+
+	if cfg.EventsConfig != nil && cfg.FevmConfig != nil &&
+		cfg.EventsConfig.MaxFilterHeightRange != cfg.FevmConfig.Event.MaxFilterHeightRange {
+		report.Deprecated = append(report.Deprecated, "events.MaxFilterHeightRange is ignored, the range is set by fevm.event.maxFilterHeightRange")
+	}
+
+	checks = append([]Check{checkValues}, checks...)
+	baseErrs := make(map[string]bool)
+	for _, check := range checks {
+		if err := check(cfg); err != nil {
+			report.Errors = append(report.Errors, err.Error())
+			baseErrs[err.Error()] = true
+		}
+	}
+	for name := range cfg.Profiles {
+		report.Errors = append(report.Errors, validateProfile(cfg, name, checks, baseErrs)...)
+	}
+	sortReport(report)
+	return cfg, report, nil
+}
+
+// validateProfile returns the errors of the profile name, its unknown keys and the errors of the checks of the
+// config with the profile applied, but the ones of the config itself.
+func validateProfile(cfg *Config, name string, checks []Check, baseErrs map[string]bool) []string {
+	var errs []string
+	if overrides := cfg.Profiles[name].Overrides; len(overrides) > 0 {
+		var raw interface{}
+		if err := json.Unmarshal(overrides, &raw); err != nil {
+			return []string{fmt.Sprintf("profile %s: overrides are not json: %v", name, err)}
+		}
+		unknownKeys(reflect.TypeOf(Config{}), raw, fmt.Sprintf("profiles.%s.overrides.", name), &errs)
+		if len(errs) > 0 {
+			return errs
+		}
+	}
+
+	applied, err := cfg.clone()
+	if err != nil {
+		return []string{fmt.Sprintf("profile %s: %v", name, err)}
+	}
+	if err := applied.ApplyProfile(name); err != nil {
+		return []string{fmt.Sprintf("profile %s: %v", name, err)}
+	}
+	for _, check := range checks {
+		if err := check(applied); err != nil && !baseErrs[err.Error()] {
+			errs = append(errs, fmt.Sprintf("profile %s: %v", name, err))
+		}
+	}
+	return errs
+}
+
+// Migrate upgrades the config file data of an old layout, it drops the removed sections, moves the deprecated
+// keys to their replacement and fills the defaults. It fails when the config has errors, which need a fix by hand.
+func Migrate(data []byte, checks ...Check) (*Config, *ValidationReport, error) {
+	cfg, report, err := Validate(data, checks...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !report.Valid() {
+		return nil, report, fmt.Errorf("config has %d errors, fix them before the migration", len(report.Errors))
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, nil, err
+	}
+	for section := range removedSections {
+		if _, ok := raw[section]; ok {
+			report.Migrated = append(report.Migrated, fmt.Sprintf("removed %s", section))
+		}
+	}
+
+	// the range of events only moves to fevm when fevm is left to its default
+	events, fevm := cfg.EventsConfig.MaxFilterHeightRange, cfg.FevmConfig.Event.MaxFilterHeightRange
+	if events != fevm {
+		if def := newFevmConfig().Event.MaxFilterHeightRange; fevm == def {
+			cfg.FevmConfig.Event.MaxFilterHeightRange = events
+			report.Migrated = append(report.Migrated, fmt.Sprintf("moved events.MaxFilterHeightRange %d to fevm.event.maxFilterHeightRange", events))
+		}
+		cfg.EventsConfig.MaxFilterHeightRange = cfg.FevmConfig.Event.MaxFilterHeightRange
+	}
+
+	for _, path := range report.Defaults {
+		report.Migrated = append(report.Migrated, fmt.Sprintf("added %s with its default", path))
+	}
+	sortReport(report)
+	return cfg, report, nil
+}
+
+// checkValues checks the values which the json decoding does not.
+func checkValues(cfg *Config) error {
+	var errs []string
+	if s := cfg.Swarm; s != nil && s.ConnMgrLow > s.ConnMgrHigh {
+		errs = append(errs, fmt.Sprintf("swarm.connMgrLow %d is above swarm.connMgrHigh %d", s.ConnMgrLow, s.ConnMgrHigh))
+	}
+	if cfg.API != nil {
+		for _, v := range cfg.API.RPCVersions {
+			if v != "v0" && v != "v1" {
+				errs = append(errs, fmt.Sprintf("api.rpcVersions has unknown version %q, want v0 or v1", v))
+			}
+		}
+	}
+	if o := cfg.Observability; o != nil && o.Logging != nil {
+		switch o.Logging.Format {
+		case "", vlogging.FormatColor, vlogging.FormatNoColor, vlogging.FormatJSON:
+		default:
+			errs = append(errs, fmt.Sprintf("observability.logging.format %q is unknown, want %s, %s or %s", o.Logging.Format,
+				vlogging.FormatColor, vlogging.FormatNoColor, vlogging.FormatJSON))
+		}
+	}
+	if f := cfg.FevmConfig; f != nil && f.Event.ReadOnly && f.Event.DatabasePath == "" {
+		errs = append(errs, "fevm.event.readOnly requires fevm.event.databasePath")
+	}
+	if f := cfg.FevmConfig; f != nil {
+		switch f.Event.SubscriptionBackpressure {
+		case "", BackpressureKill, BackpressureDropOldest, BackpressureCoalesce:
+		default:
+			errs = append(errs, fmt.Sprintf("fevm.event.subscriptionBackpressure %q is unknown, want %s, %s or %s",
+				f.Event.SubscriptionBackpressure, BackpressureKill, BackpressureDropOldest, BackpressureCoalesce))
+		}
+	}
+	if mp := cfg.Mpool; mp != nil {
+		if err := mp.PremiumOracle.Check(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if j := cfg.Journal; j != nil {
+		for _, evt := range j.DisabledEvents {
+			if system, event, ok := strings.Cut(evt, ":"); !ok || system == "" || event == "" {
+				errs = append(errs, fmt.Sprintf("journal.disabledEvents has invalid event type %q, want system:event", evt))
+			}
+		}
+		if j.FileSizeLimit < 0 || j.MemoryEvents < 0 {
+			errs = append(errs, "journal.fileSizeLimit and journal.memoryEvents can't be negative")
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+type jsonField struct {
+	typ reflect.Type
+	// omitEmpty fields are left out of the files written with their zero value
+	omitEmpty bool
+}
+
+// jsonFields returns the fields of the struct t by json name, the embedded structs are inlined.
+func jsonFields(t reflect.Type) map[string]jsonField {
+	fields := make(map[string]jsonField)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		tag := strings.Split(f.Tag.Get("json"), ",")
+		name := tag[0]
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && name == "" && indirect(f.Type).Kind() == reflect.Struct {
+			for n, ft := range jsonFields(indirect(f.Type)) {
+				fields[n] = ft
+			}
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		field := jsonField{typ: f.Type}
+		for _, opt := range tag[1:] {
+			field.omitEmpty = field.omitEmpty || opt == "omitempty"
+		}
+		fields[name] = field
+	}
+	return fields
+}
+
+// lookupField matches key to a field like encoding/json, exactly first and then case insensitively.
+func lookupField(fields map[string]jsonField, key string) (jsonField, bool) {
+	if f, ok := fields[key]; ok {
+		return f, true
+	}
+	for name, f := range fields {
+		if strings.EqualFold(name, key) {
+			return f, true
+		}
+	}
+	return jsonField{}, false
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// unknownKeys appends the keys of raw which match no field of t, the keys of the maps are free.
+func unknownKeys(t reflect.Type, raw interface{}, prefix string, errs *[]string) {
+	t = indirect(t)
+	switch t.Kind() {
+	case reflect.Struct:
+		m, ok := raw.(map[string]interface{})
+		if !ok {
+			return
+		}
+		// the types decoding their own json, like the addresses, are not walked
+		if reflect.PointerTo(t).Implements(reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()) {
+			return
+		}
+		fields := jsonFields(t)
+		for key, v := range m {
+			f, ok := lookupField(fields, key)
+			if !ok {
+				*errs = append(*errs, fmt.Sprintf("unknown key %s%s", prefix, key))
+				continue
+			}
+			unknownKeys(f.typ, v, prefix+key+".", errs)
+		}
+	case reflect.Map:
+		if m, ok := raw.(map[string]interface{}); ok {
+			for key, v := range m {
+				unknownKeys(t.Elem(), v, prefix+key+".", errs)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if s, ok := raw.([]interface{}); ok {
+			for i, v := range s {
+				unknownKeys(t.Elem(), v, fmt.Sprintf("%s%d.", prefix, i), errs)
+			}
+		}
+	}
+}
+
+// missingKeys appends the paths of the fields of t missing from raw.
+func missingKeys(t reflect.Type, raw map[string]interface{}, prefix string, paths *[]string) {
+	t = indirect(t)
+	if t.Kind() != reflect.Struct || reflect.PointerTo(t).Implements(reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()) {
+		return
+	}
+	for name, f := range jsonFields(t) {
+		var v interface{}
+		found := false
+		for key, kv := range raw {
+			if strings.EqualFold(key, name) {
+				v, found = kv, true
+				break
+			}
+		}
+		if !found {
+			if !f.omitEmpty {
+				*paths = append(*paths, prefix+name)
+			}
+			continue
+		}
+		if m, ok := v.(map[string]interface{}); ok {
+			missingKeys(f.typ, m, prefix+name+".", paths)
+		}
+	}
+}
+
+func sortReport(r *ValidationReport) {
+	sort.Strings(r.Errors)
+	sort.Strings(r.Deprecated)
+	sort.Strings(r.Defaults)
+	sort.Strings(r.Migrated)
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestValidate(t *testing.T) {
+	tf.UnitTest(t)
+
+	data, err := json.Marshal(NewDefaultConfig())
+	require.NoError(t, err)
+	_, report, err := Validate(data)
+	require.NoError(t, err)
+	require.True(t, report.Valid(), report.Errors)
+	require.Empty(t, report.Deprecated)
+	require.Empty(t, report.Defaults)
+
+	_, _, err = Validate([]byte("[]"))
+	require.Error(t, err)
+
+	cfg, report, err := Validate([]byte(`{
+		"api": {"apiAddress": "/ip4/127.0.0.1/tcp/1234", "rpcVersions": ["v2"]},
+		"swarm": {"connMgrLow": 200, "connMgrHigh": 100, "protectedPeer": []},
+		"fevm": {"EthBlkCacheSize": 10, "event": {"maxFilters": 5, "subscriptionBackpressure": "block"}},
+		"heartbeat": {"nickname": "node"},
+		"journal": {"disabledEvents": ["mpool"]},
+		"mpool": {"premiumOracle": {"enable": true, "window": 0, "quantile": 0.5}}
+	}`))
+	require.NoError(t, err)
+	require.Equal(t, "/ip4/127.0.0.1/tcp/1234", cfg.API.APIAddress)
+	require.Equal(t, 5, cfg.FevmConfig.Event.MaxFilters)
+	// the other fields keep their defaults
+	require.Equal(t, 10000, cfg.FevmConfig.Event.MaxFilterResults)
+	require.Contains(t, report.Defaults, "mpool.maxNonceGap")
+	require.Contains(t, report.Defaults, "fevm.event.maxFilterResults")
+	require.NotContains(t, report.Defaults, "fevm.EthBlkCacheSize")
+	require.Len(t, report.Deprecated, 1)
+	require.Contains(t, report.Deprecated[0], "heartbeat")
+	require.Len(t, report.Errors, 2)
+	require.Contains(t, report.Errors[0], "api.rpcVersions")
+	require.Contains(t, report.Errors[0], "swarm.connMgrLow")
+	require.Contains(t, report.Errors[0], "fevm.event.subscriptionBackpressure")
+	require.Contains(t, report.Errors[0], "journal.disabledEvents")
+	require.Contains(t, report.Errors[0], "mpool.premiumOracle.window")
+	require.Equal(t, "unknown key swarm.protectedPeer", report.Errors[1])
+
+	_, report, err = Validate([]byte(`{"api": {"apiAddress": 1}}`))
+	require.NoError(t, err)
+	require.False(t, report.Valid())
+}
+
+func TestPremiumOracleConfigCheck(t *testing.T) {
+	tf.UnitTest(t)
+
+	oracle := newDefaultPremiumOracleConfig()
+	require.NoError(t, oracle.Check())
+
+	// a disabled oracle is not sampled
+	oracle.Window = 0
+	require.NoError(t, oracle.Check())
+
+	oracle.Enable = true
+	require.EqualError(t, oracle.Check(), "mpool.premiumOracle.window 0 must be positive")
+
+	oracle.Window = 10
+	for _, q := range []float64{0.1, 0.5, 1} {
+		oracle.Quantile = q
+		require.NoError(t, oracle.Check())
+	}
+	for _, q := range []float64{-0.5, 0, 1.5} {
+		oracle.Quantile = q
+		require.ErrorContains(t, oracle.Check(), "must be in (0, 1]")
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	tf.UnitTest(t)
+
+	_, report, err := Migrate([]byte(`{"swarm": {"connMgrLow": 200, "connMgrHigh": 100}}`))
+	require.Error(t, err)
+	require.False(t, report.Valid())
+
+	cfg, report, err := Migrate([]byte(`{
+		"events": {"enableActorEventsAPI": true, "MaxFilterHeightRange": 100},
+		"drand": {"startTimeUnix": 1, "roundSeconds": 30}
+	}`))
+	require.NoError(t, err)
+	require.True(t, cfg.EventsConfig.EnableActorEventsAPI)
+	require.Equal(t, uint64(100), cfg.FevmConfig.Event.MaxFilterHeightRange)
+	require.Contains(t, report.Migrated, "removed drand")
+	require.Contains(t, report.Migrated, "moved events.MaxFilterHeightRange 100 to fevm.event.maxFilterHeightRange")
+	require.Contains(t, report.Migrated, "added mpool with its default")
+
+	// the migrated config is valid and up to date
+	data, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	_, report, err = Validate(data)
+	require.NoError(t, err)
+	require.True(t, report.Valid())
+	require.Empty(t, report.Deprecated)
+	require.Empty(t, report.Defaults)
+}
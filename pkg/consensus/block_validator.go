@@ -122,6 +122,20 @@ func (bv *BlockValidator) ValidateBlockMsg(ctx context.Context, blk *types.Block
 		logExpect.Debugw("validate block message", "Cid", blk.Cid(), "took", time.Since(validationStart), "height", blk.Header.Height, "age", time.Since(time.Unix(int64(blk.Header.Timestamp), 0)))
 	}()
 
+	res, _, _ := bv.validateBlockMsg(ctx, blk)
+	return res
+}
+
+// Reasons reported by ValidateBlockMsgWithReason for rejected blocks.
+const (
+	RejectInvalidMsgMeta   = "invalid_msg_meta"
+	RejectUnknownMiner     = "unknown_miner"
+	RejectInvalidSignature = "invalid_signature"
+	RejectNotWinning       = "not_winning"
+)
+
+// ValidateBlockMsgWithReason is ValidateBlockMsg also returning why a block was not accepted.
+func (bv *BlockValidator) ValidateBlockMsgWithReason(ctx context.Context, blk *types.BlockMsg) (pubsub.ValidationResult, string, error) {
 	return bv.validateBlockMsg(ctx, blk)
 }
 
@@ -132,7 +146,7 @@ func (bv *BlockValidator) ValidateFullBlock(ctx context.Context, blk *types.Bloc
 	if _, ok := bv.validateBlockCache.Get(blk.Cid()); ok {
 		return nil
 	}
-	err := bv.validateBlock(ctx, blk)
+	err := bv.validateBlock(ctx, blk, true)
 	if err != nil {
 		return err
 	}
@@ -143,7 +157,14 @@ func (bv *BlockValidator) ValidateFullBlock(ctx context.Context, blk *types.Bloc
 	return nil
 }
 
-func (bv *BlockValidator) validateBlock(ctx context.Context, blk *types.BlockHeader) error {
+// ValidateBlockHeader runs the checks of ValidateFullBlock except the checks of the messages of the block, which a
+// candidate block may not have stored yet. When the checks run concurrently fail, the error is a *multierror.Error
+// holding the error of each of them.
+func (bv *BlockValidator) ValidateBlockHeader(ctx context.Context, blk *types.BlockHeader) error {
+	return bv.validateBlock(ctx, blk, false)
+}
+
+func (bv *BlockValidator) validateBlock(ctx context.Context, blk *types.BlockHeader, withMessages bool) error {
 	parent, err := bv.chainState.GetTipSet(ctx, types.NewTipSetKey(blk.Parents...))
 	if err != nil {
 		return fmt.Errorf("load parent tipset failed %w", err)
@@ -263,6 +284,9 @@ func (bv *BlockValidator) validateBlock(ctx context.Context, blk *types.BlockHea
 	})
 
 	msgsCheck := async.Err(func() error {
+		if !withMessages {
+			return nil
+		}
 		stateRoot, _, err := bv.Stmgr.RunStateTransition(ctx, parent, nil, false)
 		if err != nil {
 			return err
@@ -334,12 +358,12 @@ func (bv *BlockValidator) validateBlock(ctx context.Context, blk *types.BlockHea
 	return nil
 }
 
-func (bv *BlockValidator) validateBlockMsg(ctx context.Context, blk *types.BlockMsg) pubsub.ValidationResult {
+func (bv *BlockValidator) validateBlockMsg(ctx context.Context, blk *types.BlockMsg) (pubsub.ValidationResult, string, error) {
 	// validate the block meta: the Message CID in the header must match the included messages
 	err := bv.validateMsgMeta(ctx, blk)
 	if err != nil {
 		logExpect.Warnf("error validating message metadata: %s", err)
-		return pubsub.ValidationReject
+		return pubsub.ValidationReject, RejectInvalidMsgMeta, err
 	}
 
 	// we want to ensure that it is a block from a known miner; we reject blocks from unknown miners
@@ -353,25 +377,25 @@ func (bv *BlockValidator) validateBlockMsg(ctx context.Context, blk *types.Block
 	if err != nil {
 		if err != ErrSoftFailure && bv.isChainNearSynced() {
 			logExpect.Errorf("received block from unknown miner or miner that doesn't meet min power over pubsub; rejecting message")
-			return pubsub.ValidationReject
+			return pubsub.ValidationReject, RejectUnknownMiner, err
 		}
 
 		logExpect.Errorf("cannot validate block message; unknown miner or miner that doesn't meet min power in unsynced chain")
-		return pubsub.ValidationIgnore
+		return pubsub.ValidationIgnore, RejectUnknownMiner, err
 	}
 
 	err = checkBlockSignature(ctx, blk.Header, key)
 	if err != nil {
 		logExpect.Errorf("block signature verification failed: %s", err)
-		return pubsub.ValidationReject
+		return pubsub.ValidationReject, RejectInvalidSignature, err
 	}
 
 	if blk.Header.ElectionProof.WinCount < 1 {
 		logExpect.Errorf("block is not claiming to be winning")
-		return pubsub.ValidationReject
+		return pubsub.ValidationReject, RejectNotWinning, errors.New("block is not claiming to be winning")
 	}
 
-	return pubsub.ValidationAccept
+	return pubsub.ValidationAccept, "", nil
 }
 
 func (bv *BlockValidator) isChainNearSynced() bool {
@@ -0,0 +1,197 @@
+package consensusfault
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-bitfield"
+	cborutil "github.com/filecoin-project/go-cbor-util"
+	"github.com/filecoin-project/go-state-types/abi"
+	actorstypes "github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/big"
+	miner16 "github.com/filecoin-project/go-state-types/builtin/v16/miner"
+	"github.com/filecoin-project/go-state-types/manifest"
+	"github.com/filecoin-project/go-state-types/network"
+	runtime7 "github.com/filecoin-project/specs-actors/v7/actors/runtime"
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/crypto"
+	_ "github.com/filecoin-project/venus/pkg/crypto/secp"
+	"github.com/filecoin-project/venus/pkg/fork"
+	"github.com/filecoin-project/venus/pkg/state"
+	"github.com/filecoin-project/venus/pkg/state/tree"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/pkg/vm"
+	"github.com/filecoin-project/venus/venus-shared/actors"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/miner"
+	"github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// stubFaultView resolves the worker of the miner to its key.
+type stubFaultView struct {
+	keys map[address.Address]address.Address
+}
+
+func (v *stubFaultView) ResolveToDeterministicAddress(_ context.Context, addr address.Address) (address.Address, error) {
+	if key, ok := v.keys[addr]; ok {
+		return key, nil
+	}
+	return address.Undef, errors.New("actor not found")
+}
+
+func (v *stubFaultView) MinerInfo(context.Context, address.Address, network.Version) (*miner.MinerInfo, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (v *stubFaultView) TotalFilCircSupply(abi.ChainEpoch, tree.Tree) (abi.TokenAmount, error) {
+	return big.Zero(), nil
+}
+
+func (v *stubFaultView) GetNetworkVersion(context.Context, abi.ChainEpoch) network.Version {
+	return network.Version0
+}
+
+func TestVerifyConsensusFault(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	cst := cbor.NewCborStore(blockstore.NewMemory())
+
+	newAddr := func(id uint64) address.Address {
+		addr, err := address.NewIDAddress(id)
+		require.NoError(t, err)
+		return addr
+	}
+	maddr, worker, otherMiner := newAddr(1000), newAddr(1001), newAddr(1002)
+
+	pk, err := crypto.Generate(crypto.SigTypeSecp256k1)
+	require.NoError(t, err)
+	pub, err := crypto.ToPublic(crypto.SigTypeSecp256k1, pk)
+	require.NoError(t, err)
+	workerKey, err := address.NewSecp256k1Address(pub)
+	require.NoError(t, err)
+
+	// the state of the lookback holds the miner with its worker
+	info, err := cst.Put(ctx, &miner16.MinerInfo{
+		Owner:           worker,
+		Worker:          worker,
+		Beneficiary:     worker,
+		BeneficiaryTerm: miner16.BeneficiaryTerm{Quota: big.Zero(), UsedQuota: big.Zero()},
+	})
+	require.NoError(t, err)
+	head, err := cst.Put(ctx, &miner16.State{
+		Info:                       info,
+		PreCommitDeposits:          big.Zero(),
+		LockedFunds:                big.Zero(),
+		FeeDebt:                    big.Zero(),
+		InitialPledge:              big.Zero(),
+		PreCommittedSectors:        info,
+		PreCommittedSectorsCleanUp: info,
+		AllocatedSectors:           info,
+		Sectors:                    info,
+		Deadlines:                  info,
+		EarlyTerminations:          bitfield.New(),
+	})
+	require.NoError(t, err)
+	minerCode, ok := actors.GetActorCodeID(actorstypes.Version16, manifest.MinerKey)
+	require.True(t, ok)
+	st, err := tree.NewState(cst, tree.StateTreeVersion5)
+	require.NoError(t, err)
+	require.NoError(t, st.SetActor(ctx, maddr, &types.Actor{Code: minerCode, Head: head, Balance: big.Zero()}))
+	root, err := st.Flush(ctx)
+	require.NoError(t, err)
+	lookback := func(context.Context, abi.ChainEpoch) (*state.View, error) {
+		return state.NewView(cst, root), nil
+	}
+	view := &stubFaultView{keys: map[address.Address]address.Address{worker: workerKey}}
+
+	// the blocks are above the finality window of the orange upgrade of the mock fork
+	checker := NewFaultChecker(nil, fork.NewMockFork())
+	const curEpoch = abi.ChainEpoch(2000)
+
+	// newBlock returns a block of miner at height on parents, signed by the worker, which differs by salt from the
+	// blocks with the same fields
+	newBlock := func(miner address.Address, height abi.ChainEpoch, parents []cid.Cid, salt uint64) *types.BlockHeader {
+		blk := &types.BlockHeader{
+			Miner:                 miner,
+			Parents:               parents,
+			ParentWeight:          big.Zero(),
+			Height:                height,
+			ParentStateRoot:       root,
+			ParentMessageReceipts: root,
+			Messages:              root,
+			Timestamp:             salt,
+			ParentBaseFee:         big.Zero(),
+		}
+		sd, err := blk.SignatureData()
+		require.NoError(t, err)
+		blk.BlockSig, err = crypto.Sign(sd, pk, crypto.SigTypeSecp256k1)
+		require.NoError(t, err)
+		return blk
+	}
+	verify := func(h1, h2, extra *types.BlockHeader) (*runtime7.ConsensusFault, error) {
+		b1, err := cborutil.Dump(h1)
+		require.NoError(t, err)
+		b2, err := cborutil.Dump(h2)
+		require.NoError(t, err)
+		var b3 []byte
+		if extra != nil {
+			b3, err = cborutil.Dump(extra)
+			require.NoError(t, err)
+		}
+		return checker.VerifyConsensusFault(ctx, b1, b2, b3, curEpoch, vm.VmMessage{To: maddr}, cst, view, lookback)
+	}
+
+	parent := newBlock(otherMiner, 1000, []cid.Cid{root}, 0)
+
+	t.Run("double-fork mining", func(t *testing.T) {
+		fault, err := verify(newBlock(maddr, 1001, parent.Parents, 1), newBlock(maddr, 1001, []cid.Cid{parent.Cid()}, 2), nil)
+		require.NoError(t, err)
+		assert.Equal(t, &runtime7.ConsensusFault{Target: maddr, Epoch: 1001, Type: runtime7.ConsensusFaultDoubleForkMining}, fault)
+	})
+
+	t.Run("time-offset mining", func(t *testing.T) {
+		parents := []cid.Cid{parent.Cid()}
+		fault, err := verify(newBlock(maddr, 1001, parents, 1), newBlock(maddr, 1002, parents, 2), nil)
+		require.NoError(t, err)
+		assert.Equal(t, &runtime7.ConsensusFault{Target: maddr, Epoch: 1002, Type: runtime7.ConsensusFaultTimeOffsetMining}, fault)
+	})
+
+	t.Run("parent grinding", func(t *testing.T) {
+		// b2 is mined on the sibling of b1 and leaves b1 out
+		b1 := newBlock(maddr, 1001, []cid.Cid{parent.Cid()}, 1)
+		sibling := newBlock(otherMiner, 1001, []cid.Cid{parent.Cid()}, 2)
+		b2 := newBlock(maddr, 1002, []cid.Cid{sibling.Cid()}, 3)
+		fault, err := verify(b1, b2, sibling)
+		require.NoError(t, err)
+		assert.Equal(t, &runtime7.ConsensusFault{Target: maddr, Epoch: 1002, Type: runtime7.ConsensusFaultParentGrinding}, fault)
+
+		// without the sibling the fault can't be proven
+		_, err = verify(b1, b2, nil)
+		assert.ErrorContains(t, err, "no consensus fault")
+	})
+
+	t.Run("no fault", func(t *testing.T) {
+		b1 := newBlock(maddr, 1001, []cid.Cid{parent.Cid()}, 1)
+		_, err := verify(b1, newBlock(maddr, 1002, []cid.Cid{b1.Cid()}, 2), nil)
+		assert.ErrorContains(t, err, "blocks are ok")
+
+		_, err = verify(b1, b1, nil)
+		assert.ErrorContains(t, err, "blocks identical")
+
+		_, err = verify(b1, newBlock(otherMiner, 1001, []cid.Cid{parent.Cid()}, 2), nil)
+		assert.ErrorContains(t, err, "miners differ")
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		b2 := newBlock(maddr, 1001, []cid.Cid{parent.Cid()}, 2)
+		b2.BlockSig.Data[len(b2.BlockSig.Data)-2] ^= 0xff
+		_, err := verify(newBlock(maddr, 1001, parent.Parents, 1), b2, nil)
+		assert.ErrorContains(t, err, "signature invalid")
+	})
+}
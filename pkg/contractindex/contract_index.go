@@ -0,0 +1,244 @@
+package contractindex
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+var ErrNotFound = errors.New("not found")
+
+var pragmas = []string{
+	"PRAGMA synchronous = normal",
+	"PRAGMA temp_store = memory",
+	"PRAGMA mmap_size = 30000000000",
+	"PRAGMA page_size = 32768",
+	"PRAGMA auto_vacuum = NONE",
+	"PRAGMA automatic_index = OFF",
+	"PRAGMA journal_mode = WAL",
+	"PRAGMA read_uncommitted = ON",
+}
+
+var ddls = []string{
+	`CREATE TABLE IF NOT EXISTS evm_contracts (
+		contract TEXT PRIMARY KEY NOT NULL,
+		creator TEXT NOT NULL,
+		init_code_hash TEXT NOT NULL,
+		tx_hash TEXT NOT NULL,
+		block_hash TEXT NOT NULL,
+		height INTEGER NOT NULL
+	)`,
+
+	`CREATE INDEX IF NOT EXISTS creator_index ON evm_contracts (creator, height, contract)`,
+
+	`CREATE INDEX IF NOT EXISTS block_hash_index ON evm_contracts (block_hash)`,
+
+	// metadata containing version of schema
+	`CREATE TABLE IF NOT EXISTS _meta (
+    	version UINT64 NOT NULL UNIQUE
+	)`,
+
+	// version 1.
+	`INSERT OR IGNORE INTO _meta (version) VALUES (1)`,
+}
+
+const schemaVersion = 1
+
+const (
+	insertContract = `INSERT OR REPLACE INTO evm_contracts
+	(contract, creator, init_code_hash, tx_hash, block_hash, height)
+	VALUES(?, ?, ?, ?, ?, ?)`
+
+	selectContracts = `SELECT contract, creator, init_code_hash, tx_hash, block_hash, height FROM evm_contracts`
+)
+
+// ContractIndex indexes the creations of the evm contracts by contract and by creator.
+type ContractIndex struct {
+	db *sql.DB
+}
+
+// IndexBlock replaces the creations of the contracts created in the tipset of blockHash with creations.
+func (ci *ContractIndex) IndexBlock(blockHash types.EthHash, creations []types.EthContractCreation) error {
+	tx, err := ci.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	// rollback is a no-op once the transaction is committed
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err := tx.Exec("DELETE FROM evm_contracts WHERE block_hash = ?", blockHash.String()); err != nil {
+		return err
+	}
+	stmt, err := tx.Prepare(insertContract)
+	if err != nil {
+		return fmt.Errorf("prepare insert contract: %w", err)
+	}
+	for _, c := range creations {
+		if c.BlockHash != blockHash {
+			return fmt.Errorf("creation of %s is in block %s, not %s", c.Contract, c.BlockHash, blockHash)
+		}
+		_, err := stmt.Exec(c.Contract.String(), c.Creator.String(), c.InitCodeHash.String(), c.TransactionHash.String(),
+			c.BlockHash.String(), int64(c.BlockNumber))
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// RemoveBlock removes the creations of the contracts created in the tipset of blockHash, when it is reverted.
+func (ci *ContractIndex) RemoveBlock(blockHash types.EthHash) error {
+	_, err := ci.db.Exec("DELETE FROM evm_contracts WHERE block_hash = ?", blockHash.String())
+	return err
+}
+
+// GetContract returns the creation of contract.
+func (ci *ContractIndex) GetContract(contract types.EthAddress) (*types.EthContractCreation, error) {
+	rows, err := ci.db.Query(selectContracts+" WHERE contract = ?", contract.String())
+	if err != nil {
+		return nil, err
+	}
+	creations, err := scanCreations(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(creations) == 0 {
+		return nil, ErrNotFound
+	}
+	return &creations[0], nil
+}
+
+// ListByCreator returns up to limit contracts deployed by creator ordered by height, following the contract after,
+// or from the first one when after is nil.
+func (ci *ContractIndex) ListByCreator(creator types.EthAddress, after *types.EthAddress, limit int) ([]types.EthContractCreation, error) {
+	if after == nil {
+		rows, err := ci.db.Query(selectContracts+" WHERE creator = ? ORDER BY height, contract LIMIT ?", creator.String(), limit)
+		if err != nil {
+			return nil, err
+		}
+		return scanCreations(rows)
+	}
+
+	cursor, err := ci.GetContract(*after)
+	if err != nil {
+		return nil, fmt.Errorf("loading cursor %s: %w", after, err)
+	}
+	if cursor.Creator != creator {
+		return nil, fmt.Errorf("cursor %s is not deployed by %s", after, creator)
+	}
+	rows, err := ci.db.Query(selectContracts+" WHERE creator = ? AND (height > ? OR (height = ? AND contract > ?)) ORDER BY height, contract LIMIT ?",
+		creator.String(), int64(cursor.BlockNumber), int64(cursor.BlockNumber), cursor.Contract.String(), limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanCreations(rows)
+}
+
+func scanCreations(rows *sql.Rows) ([]types.EthContractCreation, error) {
+	defer rows.Close() //nolint:errcheck
+
+	var out []types.EthContractCreation
+	for rows.Next() {
+		var (
+			contract, creator, initCodeHash, txHash, blockHash string
+			height                                             int64
+		)
+		if err := rows.Scan(&contract, &creator, &initCodeHash, &txHash, &blockHash, &height); err != nil {
+			return nil, err
+		}
+
+		var c types.EthContractCreation
+		var err error
+		if c.Contract, err = types.ParseEthAddress(contract); err != nil {
+			return nil, err
+		}
+		if c.Creator, err = types.ParseEthAddress(creator); err != nil {
+			return nil, err
+		}
+		if c.InitCodeHash, err = types.ParseEthHash(initCodeHash); err != nil {
+			return nil, err
+		}
+		if c.TransactionHash, err = types.ParseEthHash(txHash); err != nil {
+			return nil, err
+		}
+		if c.BlockHash, err = types.ParseEthHash(blockHash); err != nil {
+			return nil, err
+		}
+		c.BlockNumber = types.EthUint64(height)
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+func NewContractIndex(path string) (*ContractIndex, error) {
+	db, err := sql.Open("sqlite3", path+"?mode=rwc")
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite3 database: %w", err)
+	}
+
+	for _, pragma := range pragmas {
+		if _, err := db.Exec(pragma); err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("exec pragma %q: %w", pragma, err)
+		}
+	}
+
+	q, err := db.Query("SELECT name FROM sqlite_master WHERE type='table' AND name='_meta';")
+	if err == sql.ErrNoRows || (err == nil && !q.Next()) {
+		if q != nil {
+			_ = q.Close()
+		}
+		// empty database, create the schema
+		for _, ddl := range ddls {
+			if _, err := db.Exec(ddl); err != nil {
+				_ = db.Close()
+				return nil, fmt.Errorf("exec ddl %q: %w", ddl, err)
+			}
+		}
+	} else if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("looking for _meta table: %w", err)
+	} else {
+		_ = q.Close()
+		// Ensure we don't open a database from a different schema version
+
+		row := db.QueryRow("SELECT max(version) FROM _meta")
+		var version int
+		err := row.Scan(&version)
+		if err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("invalid database version: no version found")
+		}
+		if version != schemaVersion {
+			_ = db.Close()
+			return nil, fmt.Errorf("invalid database version: got %d, expected %d", version, schemaVersion)
+		}
+	}
+
+	return &ContractIndex{
+		db: db,
+	}, nil
+}
+
+// Vacuum rebuilds the database to reclaim the space of the deleted rows, then truncates its WAL.
+func (ci *ContractIndex) Vacuum(ctx context.Context) error {
+	if _, err := ci.db.ExecContext(ctx, "VACUUM"); err != nil {
+		return fmt.Errorf("vacuum contract index: %w", err)
+	}
+	if _, err := ci.db.ExecContext(ctx, "PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		return fmt.Errorf("checkpoint contract index: %w", err)
+	}
+	return nil
+}
+
+func (ci *ContractIndex) Close() error {
+	if ci.db == nil {
+		return nil
+	}
+	return ci.db.Close()
+}
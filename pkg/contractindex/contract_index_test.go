@@ -0,0 +1,68 @@
+package contractindex
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestContractIndex(t *testing.T) {
+	tf.UnitTest(t)
+
+	ci, err := NewContractIndex(filepath.Join(t.TempDir(), "contracts.db"))
+	require.NoError(t, err)
+	defer ci.Close() //nolint:errcheck
+
+	creator := types.EthAddress{1}
+	other := types.EthAddress{2}
+	block1 := types.EthHash{1}
+	block2 := types.EthHash{2}
+	creation := func(contract byte, creator types.EthAddress, block types.EthHash, height types.EthUint64) types.EthContractCreation {
+		return types.EthContractCreation{
+			Contract:        types.EthAddress{0xff, contract},
+			Creator:         creator,
+			InitCodeHash:    types.EthHashFromTxBytes([]byte{contract}),
+			TransactionHash: types.EthHash{0xff, contract},
+			BlockHash:       block,
+			BlockNumber:     height,
+		}
+	}
+
+	first := []types.EthContractCreation{creation(3, creator, block1, 10), creation(1, creator, block1, 10), creation(9, other, block1, 10)}
+	second := []types.EthContractCreation{creation(2, creator, block2, 11)}
+	require.NoError(t, ci.IndexBlock(block1, first))
+	require.NoError(t, ci.IndexBlock(block2, second))
+	require.Error(t, ci.IndexBlock(block1, second))
+
+	got, err := ci.GetContract(first[0].Contract)
+	require.NoError(t, err)
+	require.Equal(t, first[0], *got)
+	_, err = ci.GetContract(types.EthAddress{0xee})
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// ordered by height, then by contract
+	list, err := ci.ListByCreator(creator, nil, 2)
+	require.NoError(t, err)
+	require.Equal(t, []types.EthContractCreation{first[1], first[0]}, list)
+	list, err = ci.ListByCreator(creator, &list[1].Contract, 2)
+	require.NoError(t, err)
+	require.Equal(t, second, list)
+	_, err = ci.ListByCreator(creator, &first[2].Contract, 2)
+	require.Error(t, err)
+
+	// reverting a block removes its contracts
+	require.NoError(t, ci.RemoveBlock(block2))
+	list, err = ci.ListByCreator(creator, nil, 10)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+
+	// indexing a block again replaces its contracts
+	require.NoError(t, ci.IndexBlock(block1, first[:1]))
+	list, err = ci.ListByCreator(creator, nil, 10)
+	require.NoError(t, err)
+	require.Equal(t, first[:1], list)
+}
@@ -0,0 +1,250 @@
+// Package ethabi decodes the calls to evm contracts with their solidity JSON ABI.
+package ethabi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// ABI holds the functions of a contract, by selector.
+type ABI struct {
+	functions map[[4]byte]*Function
+}
+
+// Function is a function of a contract ABI.
+type Function struct {
+	Name string
+	// Signature is the canonical signature the selector is computed from, eg. transfer(address,uint256)
+	Signature string
+	Inputs    []Argument
+	Outputs   []Argument
+}
+
+// Argument is a named parameter or return value of a function.
+type Argument struct {
+	Name string
+	Type Type
+}
+
+type jsonArgument struct {
+	Name       string         `json:"name"`
+	Type       string         `json:"type"`
+	Components []jsonArgument `json:"components"`
+}
+
+type jsonEntry struct {
+	Type    string         `json:"type"`
+	Name    string         `json:"name"`
+	Inputs  []jsonArgument `json:"inputs"`
+	Outputs []jsonArgument `json:"outputs"`
+}
+
+// Parse reads the JSON ABI of a contract, either the array of entries output by solc or an object
+// holding it in its abi field, like the artifacts of hardhat and foundry. Only the functions are kept.
+func Parse(data []byte) (*ABI, error) {
+	var entries []jsonEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		var artifact struct {
+			ABI []jsonEntry `json:"abi"`
+		}
+		if err2 := json.Unmarshal(data, &artifact); err2 != nil || artifact.ABI == nil {
+			return nil, fmt.Errorf("parsing abi: %w", err)
+		}
+		entries = artifact.ABI
+	}
+
+	a := &ABI{functions: make(map[[4]byte]*Function)}
+	for _, e := range entries {
+		// entries without a type are functions
+		if e.Type != "function" && e.Type != "" {
+			continue
+		}
+		inputs, err := parseArguments(e.Inputs)
+		if err != nil {
+			return nil, fmt.Errorf("function %s inputs: %w", e.Name, err)
+		}
+		outputs, err := parseArguments(e.Outputs)
+		if err != nil {
+			return nil, fmt.Errorf("function %s outputs: %w", e.Name, err)
+		}
+		f := &Function{
+			Name:      e.Name,
+			Signature: e.Name + tupleSignature(inputs),
+			Inputs:    inputs,
+			Outputs:   outputs,
+		}
+		a.functions[Selector(f.Signature)] = f
+	}
+	return a, nil
+}
+
+// Selector returns the 4 bytes identifying the function with the canonical signature in the call data.
+func Selector(signature string) [4]byte {
+	var sel [4]byte
+	h := sha3.NewLegacyKeccak256()
+	_, _ = h.Write([]byte(signature))
+	copy(sel[:], h.Sum(nil))
+	return sel
+}
+
+// Function returns the function called by the call data, nil when the abi does not have it.
+func (a *ABI) Function(callData []byte) *Function {
+	if len(callData) < 4 {
+		return nil
+	}
+	var sel [4]byte
+	copy(sel[:], callData)
+	return a.functions[sel]
+}
+
+func parseArguments(args []jsonArgument) ([]Argument, error) {
+	out := make([]Argument, 0, len(args))
+	for _, arg := range args {
+		t, err := parseType(arg.Type, arg.Components)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, Argument{Name: arg.Name, Type: t})
+	}
+	return out, nil
+}
+
+func tupleSignature(args []Argument) string {
+	types := make([]string, len(args))
+	for i, arg := range args {
+		types[i] = arg.Type.String()
+	}
+	return "(" + strings.Join(types, ",") + ")"
+}
+
+type kind int
+
+const (
+	kindUint kind = iota
+	kindInt
+	kindAddress
+	kindBool
+	kindFixedBytes
+	kindBytes
+	kindString
+	kindSlice
+	kindArray
+	kindTuple
+)
+
+// Type is a solidity ABI type.
+type Type struct {
+	kind kind
+	// size is the number of bits of the integers, of bytes of the fixed bytes and of elements of the arrays
+	size   int
+	elem   *Type
+	fields []Argument
+	str    string
+}
+
+// String returns the canonical name of the type, used in the function signatures.
+func (t Type) String() string {
+	return t.str
+}
+
+func parseType(s string, components []jsonArgument) (Type, error) {
+	if strings.HasSuffix(s, "]") {
+		i := strings.LastIndexByte(s, '[')
+		if i < 0 {
+			return Type{}, fmt.Errorf("invalid type %s", s)
+		}
+		elem, err := parseType(s[:i], components)
+		if err != nil {
+			return Type{}, err
+		}
+		dim := s[i+1 : len(s)-1]
+		if dim == "" {
+			return Type{kind: kindSlice, elem: &elem, str: elem.str + "[]"}, nil
+		}
+		n, err := strconv.Atoi(dim)
+		if err != nil || n <= 0 {
+			return Type{}, fmt.Errorf("invalid array length in %s", s)
+		}
+		return Type{kind: kindArray, size: n, elem: &elem, str: elem.str + "[" + dim + "]"}, nil
+	}
+
+	switch {
+	case s == "tuple":
+		fields, err := parseArguments(components)
+		if err != nil {
+			return Type{}, err
+		}
+		return Type{kind: kindTuple, fields: fields, str: tupleSignature(fields)}, nil
+	case s == "address":
+		return Type{kind: kindAddress, str: s}, nil
+	case s == "bool":
+		return Type{kind: kindBool, str: s}, nil
+	case s == "string":
+		return Type{kind: kindString, str: s}, nil
+	case s == "bytes":
+		return Type{kind: kindBytes, str: s}, nil
+	case s == "function":
+		// an address followed by a selector
+		return Type{kind: kindFixedBytes, size: 24, str: s}, nil
+	case strings.HasPrefix(s, "bytes"):
+		n, err := strconv.Atoi(s[len("bytes"):])
+		if err != nil || n <= 0 || n > 32 {
+			return Type{}, fmt.Errorf("invalid type %s", s)
+		}
+		return Type{kind: kindFixedBytes, size: n, str: s}, nil
+	case strings.HasPrefix(s, "uint"), strings.HasPrefix(s, "int"):
+		k, prefix := kindInt, "int"
+		if strings.HasPrefix(s, "uint") {
+			k, prefix = kindUint, "uint"
+		}
+		bits := 256
+		if s != prefix {
+			n, err := strconv.Atoi(s[len(prefix):])
+			if err != nil || n <= 0 || n > 256 || n%8 != 0 {
+				return Type{}, fmt.Errorf("invalid type %s", s)
+			}
+			bits = n
+		}
+		return Type{kind: k, size: bits, str: prefix + strconv.Itoa(bits)}, nil
+	}
+	return Type{}, fmt.Errorf("unsupported type %s", s)
+}
+
+// dynamic tells whether the value is encoded after the head of the enclosing tuple, referenced by an offset.
+func (t Type) dynamic() bool {
+	switch t.kind {
+	case kindBytes, kindString, kindSlice:
+		return true
+	case kindArray:
+		return t.elem.dynamic()
+	case kindTuple:
+		for _, f := range t.fields {
+			if f.Type.dynamic() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// headSize is the size of the value in the head of the enclosing tuple.
+func (t Type) headSize() int {
+	if t.dynamic() {
+		return 32
+	}
+	switch t.kind {
+	case kindArray:
+		return t.size * t.elem.headSize()
+	case kindTuple:
+		size := 0
+		for _, f := range t.fields {
+			size += f.Type.headSize()
+		}
+		return size
+	}
+	return 32
+}
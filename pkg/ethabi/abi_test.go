@@ -0,0 +1,84 @@
+package ethabi
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+const testABI = `[
+	{"type": "function", "name": "transfer", "inputs": [{"name": "to", "type": "address"}, {"name": "amount", "type": "uint256"}], "outputs": [{"name": "", "type": "bool"}]},
+	{"type": "function", "name": "setName", "inputs": [{"name": "name", "type": "string"}, {"name": "ids", "type": "int64[]"}], "outputs": []},
+	{"type": "event", "name": "Transfer", "inputs": []}
+]`
+
+func word(s string) string {
+	return strings.Repeat("0", 64-len(s)) + s
+}
+
+func mustHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+func TestDecode(t *testing.T) {
+	tf.UnitTest(t)
+
+	a, err := Parse([]byte(testABI))
+	require.NoError(t, err)
+
+	// transfer(address,uint256) has the well known erc20 selector
+	sel := Selector("transfer(address,uint256)")
+	assert.Equal(t, "a9059cbb", hex.EncodeToString(sel[:]))
+
+	callData := mustHex(t, "a9059cbb"+word("ff00000000000000000000000000000000000001")+word("0de0b6b3a7640000"))
+	call, err := a.DecodeParams(callData)
+	require.NoError(t, err)
+	assert.Equal(t, &types.EthDecodedCall{
+		Function: "transfer(address,uint256)",
+		Params: []types.EthDecodedArg{
+			{Name: "to", Type: "address", Value: "0xff00000000000000000000000000000000000001"},
+			{Name: "amount", Type: "uint256", Value: "1000000000000000000"},
+		},
+	}, call)
+
+	ret, err := a.DecodeReturn(callData, mustHex(t, word("1")))
+	require.NoError(t, err)
+	assert.Equal(t, []types.EthDecodedArg{{Name: "", Type: "bool", Value: true}}, ret.Returns)
+
+	// dynamic values are referenced by their offsets
+	sel = Selector("setName(string,int64[])")
+	callData = mustHex(t, hex.EncodeToString(sel[:])+
+		word("40")+word("80")+
+		word("5")+"68656c6c6f"+strings.Repeat("0", 54)+
+		word("2")+word("7")+strings.Repeat("f", 64))
+	call, err = a.DecodeParams(callData)
+	require.NoError(t, err)
+	assert.Equal(t, "setName(string,int64[])", call.Function)
+	assert.Equal(t, "hello", call.Params[0].Value)
+	assert.Equal(t, []interface{}{"7", "-1"}, call.Params[1].Value)
+
+	// truncated data is refused
+	_, err = a.DecodeParams(callData[:len(callData)-32])
+	assert.Error(t, err)
+	_, err = a.DecodeParams(mustHex(t, "12345678"))
+	assert.Error(t, err)
+}
+
+func TestParseArtifact(t *testing.T) {
+	tf.UnitTest(t)
+
+	a, err := Parse([]byte(`{"contractName": "Token", "abi": ` + testABI + `}`))
+	require.NoError(t, err)
+	assert.NotNil(t, a.Function(mustHex(t, "a9059cbb")))
+
+	_, err = Parse([]byte(`[{"type": "function", "name": "f", "inputs": [{"name": "x", "type": "uint7"}]}]`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,185 @@
+package ethabi
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// DecodeParams decodes the arguments of the function called by the call data.
+func (a *ABI) DecodeParams(callData []byte) (*types.EthDecodedCall, error) {
+	f := a.Function(callData)
+	if f == nil {
+		return nil, fmt.Errorf("no function with selector %x in the abi", callData[:min(4, len(callData))])
+	}
+	params, err := decodeArguments(f.Inputs, callData[4:])
+	if err != nil {
+		return nil, fmt.Errorf("decoding the params of %s: %w", f.Signature, err)
+	}
+	return &types.EthDecodedCall{Function: f.Signature, Params: params}, nil
+}
+
+// DecodeReturn decodes the values returned by the function called by the call data.
+func (a *ABI) DecodeReturn(callData, ret []byte) (*types.EthDecodedCall, error) {
+	f := a.Function(callData)
+	if f == nil {
+		return nil, fmt.Errorf("no function with selector %x in the abi", callData[:min(4, len(callData))])
+	}
+	returns, err := decodeArguments(f.Outputs, ret)
+	if err != nil {
+		return nil, fmt.Errorf("decoding the return of %s: %w", f.Signature, err)
+	}
+	return &types.EthDecodedCall{Function: f.Signature, Returns: returns}, nil
+}
+
+func decodeArguments(args []Argument, data []byte) ([]types.EthDecodedArg, error) {
+	ts := make([]Type, len(args))
+	for i, arg := range args {
+		ts[i] = arg.Type
+	}
+	values, err := decodeTuple(ts, data)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]types.EthDecodedArg, len(args))
+	for i, arg := range args {
+		out[i] = types.EthDecodedArg{Name: arg.Name, Type: arg.Type.String(), Value: values[i]}
+	}
+	return out, nil
+}
+
+// decodeTuple decodes the values encoded one after the other in data, the dynamic ones are
+// referenced by their offset from the start of data.
+func decodeTuple(ts []Type, data []byte) ([]interface{}, error) {
+	values := make([]interface{}, len(ts))
+	pos := 0
+	for i, t := range ts {
+		if t.dynamic() {
+			offset, err := readLength(data, pos)
+			if err != nil {
+				return nil, err
+			}
+			if offset > len(data) {
+				return nil, fmt.Errorf("offset %d out of the %d bytes of data", offset, len(data))
+			}
+			if values[i], err = decodeValue(t, data[offset:]); err != nil {
+				return nil, err
+			}
+		} else {
+			if pos > len(data) {
+				return nil, fmt.Errorf("value at %d out of the %d bytes of data", pos, len(data))
+			}
+			var err error
+			if values[i], err = decodeValue(t, data[pos:]); err != nil {
+				return nil, err
+			}
+		}
+		pos += t.headSize()
+	}
+	return values, nil
+}
+
+// decodeValue decodes the value encoded at the start of data. The integers are returned as
+// decimal strings and the bytes and addresses as 0x prefixed hex strings, so they are printed
+// the way they are written in solidity.
+func decodeValue(t Type, data []byte) (interface{}, error) {
+	switch t.kind {
+	case kindBytes, kindString:
+		n, err := readLength(data, 0)
+		if err != nil {
+			return nil, err
+		}
+		b, err := read(data, 32, n)
+		if err != nil {
+			return nil, err
+		}
+		if t.kind == kindString {
+			return string(b), nil
+		}
+		return types.EthBytes(b).String(), nil
+	case kindSlice:
+		n, err := readLength(data, 0)
+		if err != nil {
+			return nil, err
+		}
+		// each element takes at least 32 bytes, don't allocate for more than the data can hold
+		if n > len(data)/32 {
+			return nil, fmt.Errorf("%d elements out of the %d bytes of data", n, len(data))
+		}
+		return decodeTuple(repeat(*t.elem, n), data[32:])
+	case kindArray:
+		return decodeTuple(repeat(*t.elem, t.size), data)
+	case kindTuple:
+		ts := make([]Type, len(t.fields))
+		for i, f := range t.fields {
+			ts[i] = f.Type
+		}
+		values, err := decodeTuple(ts, data)
+		if err != nil {
+			return nil, err
+		}
+		fields := make(map[string]interface{}, len(values))
+		for i, f := range t.fields {
+			name := f.Name
+			if name == "" {
+				name = strconv.Itoa(i)
+			}
+			fields[name] = values[i]
+		}
+		return fields, nil
+	}
+
+	word, err := read(data, 0, 32)
+	if err != nil {
+		return nil, err
+	}
+	switch t.kind {
+	case kindUint:
+		return new(big.Int).SetBytes(word).String(), nil
+	case kindInt:
+		v := new(big.Int).SetBytes(word)
+		if word[0]&0x80 != 0 {
+			v.Sub(v, new(big.Int).Lsh(big.NewInt(1), 256))
+		}
+		return v.String(), nil
+	case kindAddress:
+		var addr types.EthAddress
+		copy(addr[:], word[12:])
+		return addr.String(), nil
+	case kindBool:
+		return word[31] != 0, nil
+	case kindFixedBytes:
+		return types.EthBytes(word[:t.size]).String(), nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
+
+func repeat(t Type, n int) []Type {
+	ts := make([]Type, n)
+	for i := range ts {
+		ts[i] = t
+	}
+	return ts
+}
+
+func read(data []byte, pos, n int) ([]byte, error) {
+	if pos < 0 || n < 0 || pos+n > len(data) {
+		return nil, fmt.Errorf("reading %d bytes at %d out of the %d bytes of data", n, pos, len(data))
+	}
+	return data[pos : pos+n], nil
+}
+
+// readLength reads an offset or a length, which must fit in an int.
+func readLength(data []byte, pos int) (int, error) {
+	word, err := read(data, pos, 32)
+	if err != nil {
+		return 0, err
+	}
+	v := new(big.Int).SetBytes(word)
+	if !v.IsInt64() || v.Int64() > int64(len(data)) {
+		return 0, fmt.Errorf("length or offset %s out of the %d bytes of data", v, len(data))
+	}
+	return int(v.Int64()), nil
+}
@@ -0,0 +1,69 @@
+package ethabi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/filecoin-project/go-address"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+)
+
+var abiPrefix = datastore.NewKey("/eth/abi")
+
+// ErrNoABI is returned when no abi is registered for a contract.
+var ErrNoABI = errors.New("no abi registered for the contract")
+
+// Registry stores the abi registered for the contracts, by the id address of the contract.
+type Registry struct {
+	ds    datastore.Datastore
+	cache *lru.Cache[address.Address, *ABI]
+}
+
+// NewRegistry returns a registry storing the abis in ds.
+func NewRegistry(ds datastore.Datastore) *Registry {
+	cache, _ := lru.New[address.Address, *ABI](128)
+	return &Registry{
+		ds:    namespace.Wrap(ds, abiPrefix),
+		cache: cache,
+	}
+}
+
+// Register validates and stores the abi of the contract, replacing the previous one.
+func (r *Registry) Register(ctx context.Context, contract address.Address, data []byte) error {
+	if contract.Protocol() != address.ID {
+		return fmt.Errorf("contract %s is not an id address", contract)
+	}
+	a, err := Parse(data)
+	if err != nil {
+		return err
+	}
+	if err := r.ds.Put(ctx, datastore.NewKey(contract.String()), data); err != nil {
+		return err
+	}
+	r.cache.Add(contract, a)
+	return nil
+}
+
+// Get returns the abi registered for the contract, ErrNoABI when there is none.
+func (r *Registry) Get(ctx context.Context, contract address.Address) (*ABI, error) {
+	if a, ok := r.cache.Get(contract); ok {
+		return a, nil
+	}
+
+	data, err := r.ds.Get(ctx, datastore.NewKey(contract.String()))
+	if err != nil {
+		if errors.Is(err, datastore.ErrNotFound) {
+			return nil, ErrNoABI
+		}
+		return nil, err
+	}
+	a, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	r.cache.Add(contract, a)
+	return a, nil
+}
@@ -1,6 +1,7 @@
 package ethhashlookup
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"strconv"
@@ -149,6 +150,17 @@ func NewTransactionHashLookup(path string) (*EthTxHashLookup, error) {
 	}, nil
 }
 
+// Vacuum rebuilds the database to reclaim the space of the deleted rows, then truncates its WAL.
+func (ei *EthTxHashLookup) Vacuum(ctx context.Context) error {
+	if _, err := ei.db.ExecContext(ctx, "VACUUM"); err != nil {
+		return xerrors.Errorf("vacuum transaction hash lookup: %w", err)
+	}
+	if _, err := ei.db.ExecContext(ctx, "PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		return xerrors.Errorf("checkpoint transaction hash lookup: %w", err)
+	}
+	return nil
+}
+
 func (ei *EthTxHashLookup) Close() error {
 	if ei.db == nil {
 		return nil
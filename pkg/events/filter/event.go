@@ -3,6 +3,7 @@ package filter
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"sync"
@@ -168,6 +169,23 @@ func (f *eventFilter) TakeCollectedEvents(ctx context.Context) []*CollectedEvent
 	return collected
 }
 
+// collectedEventSize approximates the bytes held by a collected event besides its entries.
+const collectedEventSize = 160
+
+func (f *eventFilter) Usage() Usage {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	u := Usage{Collected: len(f.collected)}
+	for _, ev := range f.collected {
+		u.Bytes += collectedEventSize
+		for _, entry := range ev.Entries {
+			u.Bytes += len(entry.Key) + len(entry.Value)
+		}
+	}
+	return u
+}
+
 func (f *eventFilter) LastTaken() time.Time {
 	f.mu.Lock()
 	defer f.mu.Unlock()
@@ -331,7 +349,8 @@ func (m *EventFilterManager) Apply(ctx context.Context, from, to *types.TipSet)
 		load:  m.loadExecutedMessages,
 	}
 
-	if m.EventIndex != nil {
+	// a read only index is written by the node it is replicated from
+	if m.EventIndex != nil && !m.EventIndex.ReadOnly() {
 		if err := m.EventIndex.CollectEvents(ctx, tse, false, m.AddressResolver); err != nil {
 			return err
 		}
@@ -362,7 +381,8 @@ func (m *EventFilterManager) Revert(ctx context.Context, from, to *types.TipSet)
 		load:  m.loadExecutedMessages,
 	}
 
-	if m.EventIndex != nil {
+	// a read only index is written by the node it is replicated from
+	if m.EventIndex != nil && !m.EventIndex.ReadOnly() {
 		if err := m.EventIndex.CollectEvents(ctx, tse, true, m.AddressResolver); err != nil {
 			return err
 		}
@@ -378,6 +398,13 @@ func (m *EventFilterManager) Revert(ctx context.Context, from, to *types.TipSet)
 	return nil
 }
 
+// SetMaxFilterResults changes the maximum number of results of the filters installed from now on.
+func (m *EventFilterManager) SetMaxFilterResults(n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.MaxFilterResults = n
+}
+
 func (m *EventFilterManager) Install(ctx context.Context, minHeight, maxHeight abi.ChainEpoch, tipsetCid cid.Cid, addresses []address.Address,
 	keysWithCodec map[string][]types.ActorEventBlock, excludeReverted bool) (EventFilter, error) {
 	m.mu.Lock()
@@ -386,6 +413,7 @@ func (m *EventFilterManager) Install(ctx context.Context, minHeight, maxHeight a
 		m.currentHeight = m.ChainStore.GetHead().Height()
 	}
 	currentHeight := m.currentHeight
+	maxResults := m.MaxFilterResults
 	m.mu.Unlock()
 
 	if m.EventIndex == nil && minHeight != -1 && minHeight < currentHeight {
@@ -404,7 +432,7 @@ func (m *EventFilterManager) Install(ctx context.Context, minHeight, maxHeight a
 		tipsetCid:     tipsetCid,
 		addresses:     addresses,
 		keysWithCodec: keysWithCodec,
-		maxResults:    m.MaxFilterResults,
+		maxResults:    maxResults,
 	}
 
 	if m.EventIndex != nil && minHeight != -1 && minHeight < currentHeight {
@@ -434,6 +462,25 @@ func (m *EventFilterManager) Remove(ctx context.Context, id types.FilterID) erro
 	return nil
 }
 
+// Reindex collects the events of the messages in msgTS, executed in rctTS, into the event index,
+// events already indexed are only marked as not reverted.
+func (m *EventFilterManager) Reindex(ctx context.Context, msgTS, rctTS *types.TipSet) (*CollectStats, error) {
+	if m.EventIndex == nil {
+		return nil, errors.New("event index is disabled")
+	}
+
+	tse := &TipSetEvents{
+		msgTS: msgTS,
+		rctTS: rctTS,
+		load:  m.loadExecutedMessages,
+	}
+	stats := &CollectStats{}
+	if err := m.EventIndex.collectEvents(ctx, tse, false, m.AddressResolver, stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
 func (m *EventFilterManager) loadExecutedMessages(ctx context.Context, msgTS, rctTS *types.TipSet) ([]executedMessage, error) {
 	msgs, err := m.MessageStore.MessagesForTipset(msgTS)
 	if err != nil {
@@ -8,7 +8,9 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/ipfs-force-community/metrics"
 	"github.com/ipfs/go-cid"
 	logging "github.com/ipfs/go-log/v2"
 	_ "github.com/mattn/go-sqlite3"
@@ -34,11 +36,13 @@ var ddls = []string{
 		event_index INTEGER NOT NULL,
 		message_cid BLOB NOT NULL,
 		message_index INTEGER NOT NULL,
-		reverted INTEGER NOT NULL
+		reverted INTEGER NOT NULL,
+		emitter_actor_type TEXT
 	)`,
 
 	createIndexEventTipsetKeyCid,
 	createIndexEventHeight,
+	createIndexEventMessageCid,
 
 	`CREATE TABLE IF NOT EXISTS event_entry (
 		event_id INTEGER,
@@ -58,8 +62,26 @@ var ddls = []string{
 
 var (
 	log = logging.Logger("filter")
+
+	indexWriteDuration = metrics.NewTimerMs("events/index_write", "Duration of writing the events of a tipset to the event index in milliseconds")
+	indexTipsetEvents  = metrics.NewInt64("events/index_tipset_events", "Number of events of the last tipset written to the event index", "")
 )
 
+const (
+	// walAutocheckpoint is the number of pages of the WAL of the event index before it is checkpointed, 64MiB with
+	// 32KiB pages, so the tipsets with many events are written to the WAL without stalling on checkpoints
+	walAutocheckpoint = 2048
+	// entryBatchSize is the number of event entries inserted by a single statement
+	entryBatchSize = 100
+	// entryColumns is the number of columns inserted for an event entry
+	entryColumns = 6
+	// replicaPollInterval is how often a read only event index looks for the tipsets added to its replica
+	replicaPollInterval = 5 * time.Second
+)
+
+// ErrReadOnlyEventIndex is returned when writing to an event index opened read only.
+var ErrReadOnlyEventIndex = errors.New("the event index is read only")
+
 const (
 	createTableEventsSeen = `CREATE TABLE IF NOT EXISTS events_seen (
 		id INTEGER PRIMARY KEY,
@@ -100,6 +122,7 @@ const (
 
 	createIndexEventTipsetKeyCid = `CREATE INDEX IF NOT EXISTS event_tipset_key_cid ON event (tipset_key_cid);`
 	createIndexEventHeight       = `CREATE INDEX IF NOT EXISTS event_height ON event (height);`
+	createIndexEventMessageCid   = `CREATE INDEX IF NOT EXISTS event_message_cid ON event (message_cid);`
 
 	createIndexEventEntryEventID = `CREATE INDEX IF NOT EXISTS event_entry_event_id ON event_entry(event_id);`
 
@@ -113,7 +136,7 @@ const (
 // to ensure that the correct indexes are being used by SELECT queries.
 func preparedStatementMapping(ps *preparedStatements) map[**sql.Stmt]string {
 	return map[**sql.Stmt]string{
-		&ps.insertEvent:          `INSERT OR IGNORE INTO event(height, tipset_key, tipset_key_cid, emitter_addr, event_index, message_cid, message_index, reverted) VALUES(?, ?, ?, ?, ?, ?, ?, ?)`,
+		&ps.insertEvent:          `INSERT OR IGNORE INTO event(height, tipset_key, tipset_key_cid, emitter_addr, event_index, message_cid, message_index, reverted, emitter_actor_type) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		&ps.insertEntry:          `INSERT OR IGNORE INTO event_entry(event_id, indexed, flags, key, codec, value) VALUES(?, ?, ?, ?, ?, ?)`,
 		&ps.revertEventsInTipset: `UPDATE event SET reverted=true WHERE height=? AND tipset_key=?`,
 		&ps.restoreEvent:         `UPDATE event SET reverted=false WHERE height=? AND tipset_key=? AND tipset_key_cid=? AND emitter_addr=? AND event_index=? AND message_cid=? AND message_index=?`,
@@ -121,6 +144,7 @@ func preparedStatementMapping(ps *preparedStatements) map[**sql.Stmt]string {
 		&ps.restoreEventSeen:     `UPDATE events_seen SET reverted=false WHERE height=? AND tipset_key_cid=?`,
 		&ps.upsertEventsSeen:     `INSERT INTO events_seen(height, tipset_key_cid, reverted) VALUES(?, ?, false) ON CONFLICT(height, tipset_key_cid) DO UPDATE SET reverted=false`,
 		&ps.eventExists:          `SELECT MAX(id) FROM event WHERE height=? AND tipset_key=? AND tipset_key_cid=? AND emitter_addr=? AND event_index=? AND message_cid=? AND message_index=?`, // QUERY PLAN: SEARCH event USING INDEX event_height (height=?)
+		&ps.tipsetHasEvents:      `SELECT COUNT(*) > 0 FROM event WHERE tipset_key_cid=?`,                                                                                                     // QUERY PLAN: SEARCH event USING COVERING INDEX event_tipset_key_cid (tipset_key_cid=?)
 		&ps.isTipsetProcessed:    `SELECT COUNT(*) > 0 FROM events_seen WHERE tipset_key_cid=?`,                                                                                               // QUERY PLAN: SEARCH events_seen USING COVERING INDEX events_seen_tipset_key_cid (tipset_key_cid=?)
 		&ps.getMaxHeightInIndex:  `SELECT MAX(height) FROM events_seen`,                                                                                                                       // QUERY PLAN: SEARCH events_seen USING COVERING INDEX events_seen_height
 		&ps.isHeightProcessed:    `SELECT COUNT(*) > 0 FROM events_seen WHERE height=?`,                                                                                                       // QUERY PLAN: SEARCH events_seen USING COVERING INDEX events_seen_height (height=?)
@@ -137,16 +161,27 @@ type preparedStatements struct {
 	revertEventSeen      *sql.Stmt
 	restoreEventSeen     *sql.Stmt
 	eventExists          *sql.Stmt
+	tipsetHasEvents      *sql.Stmt
 	isTipsetProcessed    *sql.Stmt
 	getMaxHeightInIndex  *sql.Stmt
 	isHeightProcessed    *sql.Stmt
 }
 
+// ActorTypeResolver returns the canonical actor name (e.g. "evm") of the emitter at the tipset,
+// an empty name is stored as NULL.
+type ActorTypeResolver func(ctx context.Context, emitter abi.ActorID, ts *types.TipSet) string
+
 type EventIndex struct {
 	db *sql.DB
 
 	stmt *preparedStatements
 
+	actorTypeResolver ActorTypeResolver
+
+	// readOnly is set for the replicas of the event index of another node, stopReplica stops polling them
+	readOnly    bool
+	stopReplica context.CancelFunc
+
 	mu           sync.Mutex
 	subIDCounter uint64
 	updateSubs   map[uint64]*updateSub
@@ -165,15 +200,12 @@ func NewEventIndex(ctx context.Context, path string, chainStore *chain.Store) (*
 	if err != nil {
 		return nil, fmt.Errorf("failed to setup event index db: %w", err)
 	}
+	if _, err := db.Exec(fmt.Sprintf("PRAGMA wal_autocheckpoint = %d", walAutocheckpoint)); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to setup event index db: %w", err)
+	}
 
-	err = sqlite.InitDb(ctx, "event index", db, ddls, []sqlite.MigrationFunc{
-		migrationVersion2(db, chainStore),
-		migrationVersion3,
-		migrationVersion4,
-		migrationVersion5,
-		migrationVersion6,
-		migrationVersion7,
-	})
+	err = sqlite.InitDb(ctx, eventIndexName, db, ddls, eventIndexMigrations(db, chainStore))
 	if err != nil {
 		_ = db.Close()
 		return nil, fmt.Errorf("failed to setup event index db: %w", err)
@@ -194,6 +226,81 @@ func NewEventIndex(ctx context.Context, path string, chainStore *chain.Store) (*
 	return &eventIndex, nil
 }
 
+// NewReadOnlyEventIndex opens the event index at path read only, eg. a replica of the events.db of a
+// node indexing the events, kept up to date by a replication tool. The events are not collected in
+// a read only index, the subscribers are notified when the tipsets added to the replica are seen.
+func NewReadOnlyEventIndex(ctx context.Context, path string) (*EventIndex, error) {
+	db, err := sqlite.OpenReadOnly(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event index replica: %w", err)
+	}
+
+	version, err := sqlite.SchemaVersion(ctx, db)
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to open event index replica: %w", err)
+	}
+	if version != EventIndexSchemaVersion {
+		_ = db.Close()
+		return nil, fmt.Errorf("event index replica %s has schema version %d, want %d", path, version, EventIndexSchemaVersion)
+	}
+
+	eventIndex := EventIndex{
+		db:         db,
+		stmt:       &preparedStatements{},
+		readOnly:   true,
+		updateSubs: make(map[uint64]*updateSub),
+	}
+
+	if err = eventIndex.initStatements(); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("error preparing eventIndex database statements: %w", err)
+	}
+
+	replicaCtx, cancel := context.WithCancel(context.Background())
+	eventIndex.stopReplica = cancel
+	go eventIndex.pollReplica(replicaCtx)
+
+	return &eventIndex, nil
+}
+
+// pollReplica notifies the subscribers when the max height of the replica increases.
+func (ei *EventIndex) pollReplica(ctx context.Context) {
+	ticker := time.NewTicker(replicaPollInterval)
+	defer ticker.Stop()
+
+	var last uint64
+	for {
+		select {
+		case <-ticker.C:
+			height, err := ei.GetMaxHeightInIndex(ctx)
+			if err != nil {
+				log.Warnf("failed to read the max height of the event index replica: %s", err)
+				continue
+			}
+			if height <= last {
+				continue
+			}
+			last = height
+			if err := ei.notifyUpdateSubs(ctx); err != nil {
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// ReadOnly tells whether the event index is a read only replica.
+func (ei *EventIndex) ReadOnly() bool {
+	return ei.readOnly
+}
+
+// SetActorTypeResolver sets the resolver used to record the actor type of the emitter of new events.
+func (ei *EventIndex) SetActorTypeResolver(resolver ActorTypeResolver) {
+	ei.actorTypeResolver = resolver
+}
+
 func (ei *EventIndex) initStatements() error {
 	stmtMapping := preparedStatementMapping(ei.stmt)
 	for stmtPointer, query := range stmtMapping {
@@ -207,10 +314,28 @@ func (ei *EventIndex) initStatements() error {
 	return nil
 }
 
+// Vacuum rebuilds the database to reclaim the space of the deleted rows, then truncates its WAL.
+func (ei *EventIndex) Vacuum(ctx context.Context) error {
+	if ei.readOnly {
+		// a replica is vacuumed by the node writing it
+		return nil
+	}
+	if _, err := ei.db.ExecContext(ctx, "VACUUM"); err != nil {
+		return fmt.Errorf("vacuum event index: %w", err)
+	}
+	if _, err := ei.db.ExecContext(ctx, "PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		return fmt.Errorf("checkpoint event index: %w", err)
+	}
+	return nil
+}
+
 func (ei *EventIndex) Close() error {
 	if ei.db == nil {
 		return nil
 	}
+	if ei.stopReplica != nil {
+		ei.stopReplica()
+	}
 	return ei.db.Close()
 }
 
@@ -270,6 +395,25 @@ func (ei *EventIndex) IsTipsetProcessed(ctx context.Context, tipsetKeyCid []byte
 }
 
 func (ei *EventIndex) CollectEvents(ctx context.Context, te *TipSetEvents, revert bool, resolver func(ctx context.Context, emitter abi.ActorID, ts *types.TipSet) (address.Address, bool)) error {
+	return ei.collectEvents(ctx, te, revert, resolver, nil)
+}
+
+// CollectStats counts the events applied by a collection.
+type CollectStats struct {
+	// Inserted is the number of events missing from the index
+	Inserted int
+	// Skipped is the number of events already in the index
+	Skipped int
+}
+
+func (ei *EventIndex) collectEvents(ctx context.Context, te *TipSetEvents, revert bool, resolver func(ctx context.Context, emitter abi.ActorID, ts *types.TipSet) (address.Address, bool), stats *CollectStats) error {
+	if ei.readOnly {
+		return ErrReadOnlyEventIndex
+	}
+
+	stopwatch := indexWriteDuration.Start()
+	defer stopwatch(ctx)
+
 	tx, err := ei.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("begin transaction: %w", err)
@@ -322,12 +466,29 @@ func (ei *EventIndex) CollectEvents(ctx context.Context, te *TipSetEvents, rever
 
 	// cache of lookups between actor id and f4 address
 	addressLookups := make(map[abi.ActorID]address.Address)
+	// cache of lookups between actor id and actor type
+	actorTypeLookups := make(map[abi.ActorID]sql.NullString)
 
 	ems, err := te.messages(ctx)
 	if err != nil {
 		return fmt.Errorf("load executed messages: %w", err)
 	}
 
+	// the statements are bound to the transaction once for all the events of the tipset
+	var (
+		eventExists  = tx.Stmt(ei.stmt.eventExists)
+		insertEvent  = tx.Stmt(ei.stmt.insertEvent)
+		restoreEvent = tx.Stmt(ei.stmt.restoreEvent)
+		entries      = &entryBatch{tx: tx, insertEntry: tx.Stmt(ei.stmt.insertEntry)}
+	)
+
+	// none of the events of a tipset applied for the first time is in the index, they are inserted
+	// without looking each of them up
+	var hasEvents bool
+	if err := tx.Stmt(ei.stmt.tipsetHasEvents).QueryRow(tsKeyCid.Bytes()).Scan(&hasEvents); err != nil {
+		return fmt.Errorf("error checking if tipset has events: %w", err)
+	}
+
 	eventCount := 0
 	// iterate over all executed messages in this tipset and insert them into the database if they
 	// don't exist, otherwise mark them as not reverted
@@ -346,22 +507,31 @@ func (ei *EventIndex) CollectEvents(ctx context.Context, te *TipSetEvents, rever
 
 			// check if this event already exists in the database
 			var entryID sql.NullInt64
-			err = tx.Stmt(ei.stmt.eventExists).QueryRow(
-				te.msgTS.Height(),          // height
-				te.msgTS.Key().Bytes(),     // tipset_key
-				tsKeyCid.Bytes(),           // tipset_key_cid
-				addr.Bytes(),               // emitter_addr
-				eventCount,                 // event_index
-				em.Message().Cid().Bytes(), // message_cid
-				msgIdx,                     // message_index
-			).Scan(&entryID)
-			if err != nil {
-				return fmt.Errorf("error checking if event exists: %w", err)
+			if hasEvents {
+				err = eventExists.QueryRow(
+					te.msgTS.Height(),          // height
+					te.msgTS.Key().Bytes(),     // tipset_key
+					tsKeyCid.Bytes(),           // tipset_key_cid
+					addr.Bytes(),               // emitter_addr
+					eventCount,                 // event_index
+					em.Message().Cid().Bytes(), // message_cid
+					msgIdx,                     // message_index
+				).Scan(&entryID)
+				if err != nil {
+					return fmt.Errorf("error checking if event exists: %w", err)
+				}
 			}
 
 			if !entryID.Valid {
+				actorType, found := actorTypeLookups[ev.Emitter]
+				if !found && ei.actorTypeResolver != nil {
+					name := ei.actorTypeResolver(ctx, ev.Emitter, te.rctTS)
+					actorType = sql.NullString{String: name, Valid: name != ""}
+					actorTypeLookups[ev.Emitter] = actorType
+				}
+
 				// event does not exist, lets insert it
-				res, err := tx.Stmt(ei.stmt.insertEvent).Exec(
+				res, err := insertEvent.Exec(
 					te.msgTS.Height(),          // height
 					te.msgTS.Key().Bytes(),     // tipset_key
 					tsKeyCid.Bytes(),           // tipset_key_cid
@@ -370,6 +540,7 @@ func (ei *EventIndex) CollectEvents(ctx context.Context, te *TipSetEvents, rever
 					em.Message().Cid().Bytes(), // message_cid
 					msgIdx,                     // message_index
 					false,                      // reverted
+					actorType,                  // emitter_actor_type
 				)
 				if err != nil {
 					return fmt.Errorf("exec insert event: %w", err)
@@ -379,10 +550,13 @@ func (ei *EventIndex) CollectEvents(ctx context.Context, te *TipSetEvents, rever
 				if err != nil {
 					return fmt.Errorf("get last row id: %w", err)
 				}
+				if stats != nil {
+					stats.Inserted++
+				}
 
 				// insert all the entries for this event
 				for _, entry := range ev.Entries {
-					_, err = tx.Stmt(ei.stmt.insertEntry).Exec(
+					err = entries.add(
 						entryID.Int64,               // event_id
 						isIndexedValue(entry.Flags), // indexed
 						[]byte{entry.Flags},         // flags
@@ -396,7 +570,7 @@ func (ei *EventIndex) CollectEvents(ctx context.Context, te *TipSetEvents, rever
 				}
 			} else {
 				// event already exists, lets mark it as not reverted
-				res, err := tx.Stmt(ei.stmt.restoreEvent).Exec(
+				res, err := restoreEvent.Exec(
 					te.msgTS.Height(),          // height
 					te.msgTS.Key().Bytes(),     // tipset_key
 					tsKeyCid.Bytes(),           // tipset_key_cid
@@ -418,11 +592,19 @@ func (ei *EventIndex) CollectEvents(ctx context.Context, te *TipSetEvents, rever
 				if rowsAffected != 1 {
 					log.Warnf("restored %d events but expected only one to exist", rowsAffected)
 				}
+				if stats != nil {
+					stats.Skipped++
+				}
 			}
 			eventCount++
 		}
 	}
 
+	if err := entries.flush(); err != nil {
+		return fmt.Errorf("exec insert entry: %w", err)
+	}
+	indexTipsetEvents.Set(ctx, int64(eventCount))
+
 	// this statement will mark the tipset as processed and will insert a new row if it doesn't exist
 	// or update the reverted field to false if it does
 	_, err = tx.Stmt(ei.stmt.upsertEventsSeen).Exec(
@@ -438,6 +620,10 @@ func (ei *EventIndex) CollectEvents(ctx context.Context, te *TipSetEvents, rever
 		return fmt.Errorf("commit transaction: %w", err)
 	}
 
+	return ei.notifyUpdateSubs(ctx)
+}
+
+func (ei *EventIndex) notifyUpdateSubs(ctx context.Context) error {
 	ei.mu.Lock()
 	tSubs := make([]*updateSub, 0, len(ei.updateSubs))
 	for _, tSub := range ei.updateSubs {
@@ -459,6 +645,45 @@ func (ei *EventIndex) CollectEvents(ctx context.Context, te *TipSetEvents, rever
 	return nil
 }
 
+// entryBatch inserts the event entries of a tipset entryBatchSize at a time, the remaining ones are
+// inserted one by one when the batch is flushed.
+type entryBatch struct {
+	tx          *sql.Tx
+	insertEntry *sql.Stmt
+	insertBatch *sql.Stmt
+	args        []any
+}
+
+func (b *entryBatch) add(eventID int64, indexed bool, flags []byte, key string, codec uint64, value []byte) error {
+	b.args = append(b.args, eventID, indexed, flags, key, codec, value)
+	if len(b.args) < entryBatchSize*entryColumns {
+		return nil
+	}
+	if b.insertBatch == nil {
+		values := strings.TrimSuffix(strings.Repeat("(?, ?, ?, ?, ?, ?), ", entryBatchSize), ", ")
+		stmt, err := b.tx.Prepare("INSERT OR IGNORE INTO event_entry(event_id, indexed, flags, key, codec, value) VALUES " + values)
+		if err != nil {
+			return err
+		}
+		b.insertBatch = stmt
+	}
+	if _, err := b.insertBatch.Exec(b.args...); err != nil {
+		return err
+	}
+	b.args = b.args[:0]
+	return nil
+}
+
+func (b *entryBatch) flush() error {
+	for i := 0; i < len(b.args); i += entryColumns {
+		if _, err := b.insertEntry.Exec(b.args[i : i+entryColumns]...); err != nil {
+			return err
+		}
+	}
+	b.args = b.args[:0]
+	return nil
+}
+
 // prefillFilter fills a filter's collection of events from the historic index
 func (ei *EventIndex) prefillFilter(ctx context.Context, f *eventFilter, excludeReverted bool) error {
 	values, query := makePrefillFilterQuery(f, excludeReverted)
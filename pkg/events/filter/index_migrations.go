@@ -5,11 +5,31 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"os"
 
 	"github.com/filecoin-project/venus/pkg/chain"
 	"github.com/filecoin-project/venus/pkg/events/filter/sqlite"
 )
 
+const eventIndexName = "event index"
+
+// eventIndexMigrations returns the migrations of the event index, the migration to version i
+// is at index i-2.
+func eventIndexMigrations(db *sql.DB, chainStore *chain.Store) []sqlite.MigrationFunc {
+	return []sqlite.MigrationFunc{
+		migrationVersion2(db, chainStore),
+		migrationVersion3,
+		migrationVersion4,
+		migrationVersion5,
+		migrationVersion6,
+		migrationVersion7,
+		migrationVersion8,
+	}
+}
+
+// EventIndexSchemaVersion is the schema version of an up to date event index.
+var EventIndexSchemaVersion = len(eventIndexMigrations(nil, nil)) + 1
+
 func migrationVersion2(db *sql.DB, chainStore *chain.Store) sqlite.MigrationFunc {
 	return func(ctx context.Context, tx *sql.Tx) error {
 		// create some temporary indices to help speed up the migration
@@ -47,6 +67,9 @@ func migrationVersion2(db *sql.DB, chainStore *chain.Store) sqlite.MigrationFunc
 
 			return fmt.Errorf("query min height: %w", err)
 		}
+		if chainStore == nil {
+			return errors.New("the migration walks the chain, run it from the daemon")
+		}
 		log.Infof("Migrating events from head to %d", minHeight.Int64)
 
 		currTS := chainStore.GetHead()
@@ -256,3 +279,118 @@ func migrationVersion7(ctx context.Context, tx *sql.Tx) error {
 
 	return nil
 }
+
+// migrationVersion8 migrates the schema from version 7 to version 8 by adding the
+// event.emitter_actor_type column and an index on the event.message_cid column to look up the
+// events of a message. The actor type of events indexed before version 8 is left NULL, it is
+// recorded for every event indexed from now on.
+func migrationVersion8(ctx context.Context, tx *sql.Tx) error {
+	var hasColumn bool
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) > 0 FROM pragma_table_info('event') WHERE name='emitter_actor_type'").Scan(&hasColumn); err != nil {
+		return fmt.Errorf("check column emitter_actor_type: %w", err)
+	}
+	if !hasColumn {
+		if _, err := tx.ExecContext(ctx, "ALTER TABLE event ADD COLUMN emitter_actor_type TEXT"); err != nil {
+			return fmt.Errorf("add column emitter_actor_type: %w", err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, createIndexEventMessageCid); err != nil {
+		return fmt.Errorf("create index event_message_cid: %w", err)
+	}
+
+	return nil
+}
+
+// MigrateEventIndex upgrades the event index at path to EventIndexSchemaVersion without starting
+// the node, it returns the version found before the upgrade. Databases older than version 2 need
+// the chain store and must be migrated by the daemon.
+func MigrateEventIndex(ctx context.Context, path string) (int, error) {
+	if _, err := os.Stat(path); err != nil {
+		return 0, fmt.Errorf("event index %s: %w", path, err)
+	}
+	db, _, err := sqlite.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close() // nolint:errcheck
+
+	from, err := sqlite.SchemaVersion(ctx, db)
+	if err != nil {
+		return 0, err
+	}
+	if err := sqlite.InitDb(ctx, eventIndexName, db, ddls, eventIndexMigrations(db, nil)); err != nil {
+		return from, err
+	}
+	return from, nil
+}
+
+// EventIndexStatus is the result of VerifyEventIndex.
+type EventIndexStatus struct {
+	Path    string
+	Version int
+	Latest  int
+	// Problems lists every schema or integrity problem found, empty for a healthy index.
+	Problems []string
+}
+
+// VerifyEventIndex checks the schema version, the tables, columns and indices, and the sqlite
+// integrity of the event index at path, the database is left untouched.
+func VerifyEventIndex(ctx context.Context, path string) (*EventIndexStatus, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("sqlite3", "file:"+path+"?mode=ro")
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close() // nolint:errcheck
+
+	status := &EventIndexStatus{Path: path, Latest: EventIndexSchemaVersion}
+	if status.Version, err = sqlite.SchemaVersion(ctx, db); err != nil {
+		return nil, err
+	}
+	if status.Version != status.Latest {
+		status.Problems = append(status.Problems, fmt.Sprintf("schema version %d, want %d", status.Version, status.Latest))
+		// older schemas are expected to miss columns and indices
+		return status, nil
+	}
+
+	for _, t := range []struct {
+		table   string
+		columns []string
+	}{
+		{"event", []string{"id", "height", "tipset_key", "tipset_key_cid", "emitter_addr", "event_index", "message_cid", "message_index", "reverted", "emitter_actor_type"}},
+		{"event_entry", []string{"event_id", "indexed", "flags", "key", "codec", "value"}},
+		{"events_seen", []string{"id", "height", "tipset_key_cid", "reverted"}},
+	} {
+		for _, column := range t.columns {
+			var found bool
+			if err := db.QueryRowContext(ctx, "SELECT COUNT(*) > 0 FROM pragma_table_info(?) WHERE name=?", t.table, column).Scan(&found); err != nil {
+				return nil, fmt.Errorf("check column %s.%s: %w", t.table, column, err)
+			}
+			if !found {
+				status.Problems = append(status.Problems, fmt.Sprintf("missing column %s.%s", t.table, column))
+			}
+		}
+	}
+
+	for _, index := range []string{"event_tipset_key_cid", "event_height", "event_message_cid", "event_entry_event_id", "events_seen_height", "events_seen_tipset_key_cid"} {
+		var found bool
+		if err := db.QueryRowContext(ctx, "SELECT COUNT(*) > 0 FROM sqlite_master WHERE type='index' AND name=?", index).Scan(&found); err != nil {
+			return nil, fmt.Errorf("check index %s: %w", index, err)
+		}
+		if !found {
+			status.Problems = append(status.Problems, fmt.Sprintf("missing index %s", index))
+		}
+	}
+
+	var integrity string
+	if err := db.QueryRowContext(ctx, "PRAGMA integrity_check").Scan(&integrity); err != nil {
+		return nil, fmt.Errorf("integrity check: %w", err)
+	}
+	if integrity != "ok" {
+		status.Problems = append(status.Problems, "integrity check: "+integrity)
+	}
+
+	return status, nil
+}
@@ -0,0 +1,75 @@
+package filter
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/events/filter/sqlite"
+)
+
+func TestMigrateEventIndex(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), DefaultDBFilename)
+
+	// build a version 7 database, before emitter_actor_type and event_message_cid existed
+	db, _, err := sqlite.Open(path)
+	require.NoError(t, err)
+	for _, ddl := range ddls {
+		if strings.Contains(ddl, "event_message_cid") {
+			continue
+		}
+		ddl = strings.Replace(ddl, ",\n\t\temitter_actor_type TEXT", "", 1)
+		_, err := db.Exec(ddl)
+		require.NoError(t, err)
+	}
+	_, err = db.Exec(metaTableForTest)
+	require.NoError(t, err)
+	for v := 1; v <= 7; v++ {
+		_, err := db.Exec("INSERT INTO _meta (version) VALUES (?)", v)
+		require.NoError(t, err)
+	}
+	_, err = db.Exec(`INSERT INTO event(height, tipset_key, tipset_key_cid, emitter_addr, event_index, message_cid, message_index, reverted) VALUES(1, x'01', x'02', x'03', 0, x'04', 0, false)`)
+	require.NoError(t, err)
+	var hasColumn bool
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) > 0 FROM pragma_table_info('event') WHERE name='emitter_actor_type'").Scan(&hasColumn))
+	require.False(t, hasColumn)
+	require.NoError(t, db.Close())
+
+	status, err := VerifyEventIndex(ctx, path)
+	require.NoError(t, err)
+	require.Equal(t, 7, status.Version)
+	require.Len(t, status.Problems, 1)
+
+	from, err := MigrateEventIndex(ctx, path)
+	require.NoError(t, err)
+	require.Equal(t, 7, from)
+
+	status, err = VerifyEventIndex(ctx, path)
+	require.NoError(t, err)
+	require.Equal(t, EventIndexSchemaVersion, status.Version)
+	require.Empty(t, status.Problems)
+
+	// migrating again is a no-op
+	from, err = MigrateEventIndex(ctx, path)
+	require.NoError(t, err)
+	require.Equal(t, EventIndexSchemaVersion, from)
+
+	// events indexed before the migration keep a NULL actor type
+	db, _, err = sqlite.Open(path)
+	require.NoError(t, err)
+	defer db.Close() // nolint:errcheck
+	var count int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM event WHERE emitter_actor_type IS NULL").Scan(&count))
+	require.Equal(t, 1, count)
+
+	_, err = MigrateEventIndex(ctx, filepath.Join(t.TempDir(), DefaultDBFilename))
+	require.Error(t, err)
+}
+
+const metaTableForTest = `CREATE TABLE IF NOT EXISTS _meta (
+	version UINT64 NOT NULL UNIQUE
+)`
@@ -2,6 +2,7 @@ package filter
 
 import (
 	"context"
+	"fmt"
 	pseudo "math/rand"
 	"os"
 	"path/filepath"
@@ -965,6 +966,74 @@ func TestEventIndexPrefillFilterExcludeReverted(t *testing.T) {
 // queries hit undesirable indexes which are likely to slow down the query.
 // Changes that break this test need to be sure that the query plan is still efficient for the
 // expected query patterns.
+func TestEventIndexEntryBatches(t *testing.T) {
+	rng := pseudo.New(pseudo.NewSource(299792458))
+	a1 := randomF4Addr(t, rng)
+	a1ID := abi.ActorID(1)
+
+	addrMap := addressMap{}
+	addrMap.add(a1ID, a1)
+
+	// the entries of the tipset fill two batches and leave a partial one to the flush
+	const eventCount, entriesPerEvent = 25, 10
+	require.Greater(t, eventCount*entriesPerEvent, 2*entryBatchSize)
+	require.NotZero(t, eventCount*entriesPerEvent%entryBatchSize)
+
+	var events []*types.Event
+	for i := 0; i < eventCount; i++ {
+		var indexed []kv
+		for j := 0; j < entriesPerEvent; j++ {
+			indexed = append(indexed, kv{k: fmt.Sprintf("key%d", j), v: []byte(fmt.Sprintf("value%d-%d", i, j))})
+		}
+		events = append(events, fakeEvent(a1ID, indexed, nil))
+	}
+
+	st := newStore()
+	em := executedMessage{
+		msg: fakeMessage(randomF4Addr(t, rng), randomF4Addr(t, rng)),
+		rct: fakeReceipt(t, rng, st, events),
+		evs: events,
+	}
+	events14000 := buildTipSetEvents(t, rng, 14000, em)
+
+	ei, err := NewEventIndex(context.Background(), filepath.Join(t.TempDir(), "actorevents.db"), nil)
+	require.NoError(t, err, "create event index")
+	defer ei.Close() // nolint:errcheck
+
+	require.NoError(t, ei.CollectEvents(context.Background(), events14000, false, addrMap.ResolveAddress))
+
+	var entries int
+	require.NoError(t, ei.db.QueryRow("SELECT COUNT(*) FROM event_entry").Scan(&entries))
+	require.Equal(t, eventCount*entriesPerEvent, entries)
+
+	want := make([]*CollectedEvent, 0, len(events))
+	for i, ev := range events {
+		want = append(want, &CollectedEvent{
+			Entries:     ev.Entries,
+			EmitterAddr: a1,
+			EventIdx:    i,
+			Height:      14000,
+			TipSetKey:   events14000.msgTS.Key(),
+			MsgCid:      em.msg.Cid(),
+		})
+	}
+
+	f := &eventFilter{minHeight: -1, maxHeight: -1}
+	require.NoError(t, ei.prefillFilter(context.Background(), f, false), "prefill filter events")
+	require.ElementsMatch(t, want, f.TakeCollectedEvents(context.Background()))
+
+	// an entry of the partial batch is matched by its value
+	f = &eventFilter{
+		minHeight: -1,
+		maxHeight: -1,
+		keysWithCodec: keysToKeysWithCodec(map[string][][]byte{
+			"key9": {[]byte(fmt.Sprintf("value%d-9", eventCount-1))},
+		}),
+	}
+	require.NoError(t, ei.prefillFilter(context.Background(), f, false), "prefill filter events")
+	require.Equal(t, want[eventCount-1:], f.TakeCollectedEvents(context.Background()))
+}
+
 func TestQueryPlan(t *testing.T) {
 	ei, err := NewEventIndex(context.Background(), filepath.Join(t.TempDir(), "actorevents.db"), nil)
 	require.NoError(t, err, "create event index")
@@ -1043,3 +1112,45 @@ func TestQueryPlan(t *testing.T) {
 		verifyQueryPlan(query)
 	}
 }
+
+func TestEventIndexCollectStats(t *testing.T) {
+	rng := pseudo.New(pseudo.NewSource(299792458))
+	a1 := randomF4Addr(t, rng)
+	a1ID := abi.ActorID(1)
+
+	addrMap := addressMap{}
+	addrMap.add(a1ID, a1)
+
+	ev1 := fakeEvent(a1ID, []kv{{k: "type", v: []byte("approval")}}, []kv{{k: "amount", v: []byte("2988181")}})
+	ev2 := fakeEvent(a1ID, []kv{{k: "type", v: []byte("transfer")}}, nil)
+
+	st := newStore()
+	events := []*types.Event{ev1, ev2}
+	em := executedMessage{
+		msg: fakeMessage(randomF4Addr(t, rng), randomF4Addr(t, rng)),
+		rct: fakeReceipt(t, rng, st, events),
+		evs: events,
+	}
+	events14000 := buildTipSetEvents(t, rng, 14000, em)
+
+	ei, err := NewEventIndex(context.Background(), filepath.Join(t.TempDir(), "actorevents.db"), nil)
+	require.NoError(t, err, "create event index")
+	defer ei.Close() // nolint:errcheck
+
+	ei.SetActorTypeResolver(func(ctx context.Context, emitter abi.ActorID, ts *types.TipSet) string {
+		return "evm"
+	})
+
+	stats := &CollectStats{}
+	require.NoError(t, ei.collectEvents(context.Background(), events14000, false, addrMap.ResolveAddress, stats))
+	require.Equal(t, CollectStats{Inserted: 2}, *stats)
+
+	var actorTypes int
+	require.NoError(t, ei.db.QueryRow("SELECT COUNT(*) FROM event WHERE emitter_actor_type='evm'").Scan(&actorTypes))
+	require.Equal(t, 2, actorTypes)
+
+	// collecting again only restores the existing events
+	stats = &CollectStats{}
+	require.NoError(t, ei.collectEvents(context.Background(), events14000, false, addrMap.ResolveAddress, stats))
+	require.Equal(t, CollectStats{Skipped: 2}, *stats)
+}
@@ -65,6 +65,17 @@ func (f *MemPoolFilter) TakeCollectedMessages(context.Context) []*types.SignedMe
 	return collected
 }
 
+func (f *MemPoolFilter) Usage() Usage {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	u := Usage{Collected: len(f.collected)}
+	for _, msg := range f.collected {
+		u.Bytes += msg.ChainLength()
+	}
+	return u
+}
+
 func (f *MemPoolFilter) LastTaken() time.Time {
 	f.mu.Lock()
 	defer f.mu.Unlock()
@@ -108,18 +119,24 @@ func (m *MemPoolFilterManager) processUpdate(ctx context.Context, u types.MpoolU
 	}
 }
 
+// SetMaxFilterResults changes the maximum number of results of the filters installed from now on.
+func (m *MemPoolFilterManager) SetMaxFilterResults(n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.MaxFilterResults = n
+}
+
 func (m *MemPoolFilterManager) Install(ctx context.Context) (*MemPoolFilter, error) {
 	id, err := newFilterID()
 	if err != nil {
 		return nil, fmt.Errorf("new filter id: %w", err)
 	}
 
+	m.mu.Lock()
 	f := &MemPoolFilter{
 		id:         id,
 		maxResults: m.MaxFilterResults,
 	}
-
-	m.mu.Lock()
 	if m.filters == nil {
 		m.filters = make(map[types.FilterID]*MemPoolFilter)
 	}
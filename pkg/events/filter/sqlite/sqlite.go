@@ -30,6 +30,15 @@ var pragmas = []string{
 	"PRAGMA journal_size_limit = 0",   // always reset journal and wal files
 }
 
+// readOnlyPragmas are the pragmas of a database opened read only, the journal and checkpoint
+// settings are left to the process writing it.
+var readOnlyPragmas = []string{
+	"PRAGMA temp_store = memory",
+	"PRAGMA mmap_size = 30000000000",
+	"PRAGMA automatic_index = OFF",
+	"PRAGMA query_only = true",
+}
+
 const metaTableDdl = `CREATE TABLE IF NOT EXISTS _meta (
 	version UINT64 NOT NULL UNIQUE
 )`
@@ -71,6 +80,47 @@ func Open(path string) (*sql.DB, bool, error) {
 	return db, exists, nil
 }
 
+// OpenReadOnly opens the existing database at the given path read only, eg. a replica of a database
+// written by another process.
+func OpenReadOnly(path string) (*sql.DB, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, xerrors.Errorf("error checking file status for database [@ %s]: %w", path, err)
+	}
+
+	db, err := sql.Open("sqlite3", "file:"+path+"?mode=ro")
+	if err != nil {
+		return nil, xerrors.Errorf("error opening database [@ %s]: %w", path, err)
+	}
+
+	for _, pragma := range readOnlyPragmas {
+		if _, err := db.Exec(pragma); err != nil {
+			_ = db.Close()
+			return nil, xerrors.Errorf("error setting database pragma %q: %w", pragma, err)
+		}
+	}
+
+	return db, nil
+}
+
+// SchemaVersion returns the schema version recorded in the _meta table, 0 when the database has
+// not been initialized yet.
+func SchemaVersion(ctx context.Context, db *sql.DB) (int, error) {
+	var name string
+	err := db.QueryRowContext(ctx, "SELECT name FROM sqlite_master WHERE type='table' AND name='_meta';").Scan(&name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, xerrors.Errorf("error looking for database _meta table: %w", err)
+	}
+
+	var version sql.NullInt64
+	if err := db.QueryRowContext(ctx, "SELECT max(version) FROM _meta").Scan(&version); err != nil {
+		return 0, xerrors.Errorf("error reading database version: %w", err)
+	}
+	return int(version.Int64), nil
+}
+
 // InitDb initializes the database by checking whether it needs to be created or upgraded.
 // The ddls are the DDL statements to create the tables in the database and their initial required
 // content. The schemaVersion will be set inside the database if it is newly created. Otherwise, the
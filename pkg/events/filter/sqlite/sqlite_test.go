@@ -242,3 +242,37 @@ type tabledata struct {
 	cols []string
 	data [][]interface{}
 }
+
+func TestOpenReadOnly(t *testing.T) {
+	tf.UnitTest(t)
+	req := require.New(t)
+
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	_, err := sqlite.OpenReadOnly(dbPath)
+	req.Error(err)
+
+	db, _, err := sqlite.Open(dbPath)
+	req.NoError(err)
+	req.NoError(sqlite.InitDb(context.Background(), "testdb", db, []string{`CREATE TABLE IF NOT EXISTS blip (blip_name TEXT NOT NULL)`}, nil))
+	_, err = db.Exec("INSERT INTO blip (blip_name) VALUES ('blip1')")
+	req.NoError(err)
+
+	replica, err := sqlite.OpenReadOnly(dbPath)
+	req.NoError(err)
+	defer replica.Close() // nolint:errcheck
+
+	version, err := sqlite.SchemaVersion(context.Background(), replica)
+	req.NoError(err)
+	req.Equal(1, version)
+
+	// the writes of the other connection are seen, the replica can't be written
+	_, err = db.Exec("INSERT INTO blip (blip_name) VALUES ('blip2')")
+	req.NoError(err)
+	var count int
+	req.NoError(replica.QueryRow("SELECT COUNT(*) FROM blip").Scan(&count))
+	req.Equal(2, count)
+	_, err = replica.Exec("INSERT INTO blip (blip_name) VALUES ('blip3')")
+	req.Error(err)
+
+	req.NoError(db.Close())
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/filecoin-project/venus/venus-shared/types"
 	"github.com/google/uuid"
+	"github.com/ipfs-force-community/sophon-auth/core"
 )
 
 type Filter interface {
@@ -16,6 +17,14 @@ type Filter interface {
 	LastTaken() time.Time
 	SetSubChannel(chan<- interface{})
 	ClearSubChannel()
+	Usage() Usage // returns the results collected and not taken yet
+}
+
+// Usage is the number of results collected by a filter and not taken yet, with the approximate number of bytes
+// they hold.
+type Usage struct {
+	Collected int
+	Bytes     int
 }
 
 type FilterStore interface {
@@ -23,12 +32,24 @@ type FilterStore interface {
 	Get(context.Context, types.FilterID) (Filter, error)
 	Remove(context.Context, types.FilterID) error
 	NotTakenSince(when time.Time) []Filter // returns a list of filters that have not had their collected results taken
+	Info(context.Context, types.FilterID) (FilterInfo, error)
+	List(context.Context) []FilterInfo
+	// SetLimits changes the maximum number of filters, in total and per token, the installed filters are kept
+	SetLimits(maxFilters, maxFiltersPerOwner int)
+}
+
+// FilterInfo is a filter with the token which installed it, empty when the api is not authenticated.
+type FilterInfo struct {
+	Filter    Filter
+	Owner     string
+	Installed time.Time
 }
 
 var (
-	ErrFilterAlreadyRegistered = errors.New("filter already registered")
-	ErrFilterNotFound          = errors.New("filter not found")
-	ErrMaximumNumberOfFilters  = errors.New("maximum number of filters registered")
+	ErrFilterAlreadyRegistered        = errors.New("filter already registered")
+	ErrFilterNotFound                 = errors.New("filter not found")
+	ErrMaximumNumberOfFilters         = errors.New("maximum number of filters registered")
+	ErrMaximumNumberOfFiltersPerOwner = errors.New("maximum number of filters registered by the token")
 )
 
 func newFilterID() (types.FilterID, error) {
@@ -42,53 +63,97 @@ func newFilterID() (types.FilterID, error) {
 }
 
 type memFilterStore struct {
-	max     int
-	mu      sync.Mutex
-	filters map[types.FilterID]Filter
+	max         int
+	maxPerOwner int // maximum number of filters of a token, 0 is unlimited
+	mu          sync.Mutex
+	filters     map[types.FilterID]*FilterInfo
+	owners      map[string]int
 }
 
 var _ FilterStore = (*memFilterStore)(nil)
 
-func NewMemFilterStore(maxFilters int) FilterStore {
+func NewMemFilterStore(maxFilters, maxFiltersPerOwner int) FilterStore {
 	return &memFilterStore{
-		max:     maxFilters,
-		filters: make(map[types.FilterID]Filter),
+		max:         maxFilters,
+		maxPerOwner: maxFiltersPerOwner,
+		filters:     make(map[types.FilterID]*FilterInfo),
+		owners:      make(map[string]int),
 	}
 }
 
-func (m *memFilterStore) Add(_ context.Context, f Filter) error {
+func (m *memFilterStore) SetLimits(maxFilters, maxFiltersPerOwner int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.max = maxFilters
+	m.maxPerOwner = maxFiltersPerOwner
+}
+
+// Add records f as installed by the token of ctx.
+func (m *memFilterStore) Add(ctx context.Context, f Filter) error {
+	owner, _ := core.CtxGetName(ctx)
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	if len(m.filters) >= m.max {
 		return ErrMaximumNumberOfFilters
 	}
+	if m.maxPerOwner > 0 && m.owners[owner] >= m.maxPerOwner {
+		return ErrMaximumNumberOfFiltersPerOwner
+	}
 
 	if _, exists := m.filters[f.ID()]; exists {
 		return ErrFilterAlreadyRegistered
 	}
-	m.filters[f.ID()] = f
+	m.filters[f.ID()] = &FilterInfo{Filter: f, Owner: owner, Installed: time.Now()}
+	m.owners[owner]++
 	return nil
 }
 
 func (m *memFilterStore) Get(_ context.Context, id types.FilterID) (Filter, error) {
 	m.mu.Lock()
-	f, found := m.filters[id]
+	info, found := m.filters[id]
 	m.mu.Unlock()
 	if !found {
 		return nil, ErrFilterNotFound
 	}
-	return f, nil
+	return info.Filter, nil
+}
+
+func (m *memFilterStore) Info(_ context.Context, id types.FilterID) (FilterInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	info, found := m.filters[id]
+	if !found {
+		return FilterInfo{}, ErrFilterNotFound
+	}
+	return *info, nil
+}
+
+func (m *memFilterStore) List(_ context.Context) []FilterInfo {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	res := make([]FilterInfo, 0, len(m.filters))
+	for _, info := range m.filters {
+		res = append(res, *info)
+	}
+	return res
 }
 
 func (m *memFilterStore) Remove(_ context.Context, id types.FilterID) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	if _, exists := m.filters[id]; !exists {
+	info, exists := m.filters[id]
+	if !exists {
 		return ErrFilterNotFound
 	}
 	delete(m.filters, id)
+	if m.owners[info.Owner]--; m.owners[info.Owner] == 0 {
+		delete(m.owners, info.Owner)
+	}
 	return nil
 }
 
@@ -97,9 +162,9 @@ func (m *memFilterStore) NotTakenSince(when time.Time) []Filter {
 	defer m.mu.Unlock()
 
 	var res []Filter
-	for _, f := range m.filters {
-		if f.LastTaken().Before(when) {
-			res = append(res, f)
+	for _, info := range m.filters {
+		if info.Filter.LastTaken().Before(when) {
+			res = append(res, info.Filter)
 		}
 	}
 
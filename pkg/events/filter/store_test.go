@@ -0,0 +1,42 @@
+package filter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ipfs-force-community/sophon-auth/core"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemFilterStoreOwners(t *testing.T) {
+	ctx := context.Background()
+	alice := core.CtxWithName(ctx, "alice")
+	bob := core.CtxWithName(ctx, "bob")
+
+	newFilter := func() Filter {
+		id, err := newFilterID()
+		require.NoError(t, err)
+		return &TipSetFilter{id: id}
+	}
+
+	store := NewMemFilterStore(3, 2)
+	first, second := newFilter(), newFilter()
+	require.NoError(t, store.Add(alice, first))
+	require.NoError(t, store.Add(alice, second))
+	require.ErrorIs(t, store.Add(alice, newFilter()), ErrMaximumNumberOfFiltersPerOwner)
+	require.NoError(t, store.Add(bob, newFilter()))
+	require.ErrorIs(t, store.Add(ctx, newFilter()), ErrMaximumNumberOfFilters)
+	require.Len(t, store.List(ctx), 3)
+
+	info, err := store.Info(ctx, first.ID())
+	require.NoError(t, err)
+	require.Equal(t, "alice", info.Owner)
+	require.Equal(t, first, info.Filter)
+	require.Equal(t, Usage{}, info.Filter.Usage())
+
+	// removing a filter gives its slot back to its owner
+	require.NoError(t, store.Remove(ctx, first.ID()))
+	_, err = store.Info(ctx, first.ID())
+	require.ErrorIs(t, err, ErrFilterNotFound)
+	require.NoError(t, store.Add(alice, newFilter()))
+}
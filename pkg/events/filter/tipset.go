@@ -65,6 +65,17 @@ func (f *TipSetFilter) TakeCollectedTipSets(context.Context) []types.TipSetKey {
 	return collected
 }
 
+func (f *TipSetFilter) Usage() Usage {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	u := Usage{Collected: len(f.collected)}
+	for _, key := range f.collected {
+		u.Bytes += len(key.Bytes())
+	}
+	return u
+}
+
 func (f *TipSetFilter) LastTaken() time.Time {
 	f.mu.Lock()
 	defer f.mu.Unlock()
@@ -97,18 +108,24 @@ func (m *TipSetFilterManager) Revert(ctx context.Context, from, to *types.TipSet
 	return nil
 }
 
+// SetMaxFilterResults changes the maximum number of results of the filters installed from now on.
+func (m *TipSetFilterManager) SetMaxFilterResults(n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.MaxFilterResults = n
+}
+
 func (m *TipSetFilterManager) Install(ctx context.Context) (*TipSetFilter, error) {
 	id, err := newFilterID()
 	if err != nil {
 		return nil, fmt.Errorf("new filter id: %w", err)
 	}
 
+	m.mu.Lock()
 	f := &TipSetFilter{
 		id:         id,
 		maxResults: m.MaxFilterResults,
 	}
-
-	m.mu.Lock()
 	if m.filters == nil {
 		m.filters = make(map[types.FilterID]*TipSetFilter)
 	}
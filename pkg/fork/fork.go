@@ -22,7 +22,6 @@ import (
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/go-state-types/manifest"
 	"github.com/filecoin-project/go-state-types/network"
-	"github.com/filecoin-project/go-state-types/rt"
 	gstStore "github.com/filecoin-project/go-state-types/store"
 	blockstore "github.com/ipfs/boxo/blockstore"
 	ipfsblock "github.com/ipfs/go-block-format"
@@ -178,21 +177,6 @@ type Upgrade struct {
 
 type UpgradeSchedule []Upgrade
 
-type migrationLogger struct{}
-
-func (ml migrationLogger) Log(level rt.LogLevel, msg string, args ...interface{}) {
-	switch level {
-	case rt.DEBUG:
-		log.Debugf(msg, args...)
-	case rt.INFO:
-		log.Infof(msg, args...)
-	case rt.WARN:
-		log.Warnf(msg, args...)
-	case rt.ERROR:
-		log.Errorf(msg, args...)
-	}
-}
-
 func DefaultUpgradeSchedule(cf *ChainFork, upgradeHeight *config.ForkUpgradeConfig) UpgradeSchedule {
 	var us UpgradeSchedule
 
@@ -561,6 +545,7 @@ type IFork interface {
 	HasExpensiveForkBetween(parent, height abi.ChainEpoch) bool
 	GetForkUpgrade() *config.ForkUpgradeConfig
 	Start(ctx context.Context) error
+	MigrationStatus() []types.MigrationStatus
 }
 
 var _ = IFork((*ChainFork)(nil))
@@ -575,6 +560,7 @@ type Migration struct {
 	preMigrations        []PreMigration
 	cache                *nv16.MemMigrationCache
 	migrationResultCache *migrationResultCache
+	status               *migrationStatus
 }
 
 type migrationResultCache struct {
@@ -653,6 +639,12 @@ func NewChainFork(ctx context.Context,
 		metadataDs:  metadataDs,
 	}
 
+	// the environment takes precedence over the config
+	if networkParams.MigrationMaxWorkers > 0 && os.Getenv(EnvMigrationMaxWorkerCount) == "" {
+		MigrationMaxWorkerCount = networkParams.MigrationMaxWorkers
+		log.Infof("migration worker count set from config: %d", MigrationMaxWorkerCount)
+	}
+
 	// If we have upgrades, make sure they're in-order and make sense.
 	us := DefaultUpgradeSchedule(fork, networkParams.ForkUpgradeParam)
 	if err := us.Validate(); err != nil {
@@ -675,6 +667,7 @@ func NewChainFork(ctx context.Context,
 						keyPrefix: fmt.Sprintf("/migration-cache/nv%d", upgrade.Network),
 						ds:        metadataDs,
 					},
+					status: newMigrationStatus(upgrade.Network, upgrade.Height),
 				}
 				stateMigrations[upgrade.Height] = migration
 			}
@@ -716,6 +709,7 @@ func (c *ChainFork) HandleStateForks(ctx context.Context, root cid.Cid, height a
 			migCid, ok, err := u.migrationResultCache.Get(ctx, root)
 			if err == nil && ok && !constants.NoMigrationResultCache {
 				log.Infow("CACHED migration", "height", height, "from", root, "to", migCid)
+				u.status.cached(root, migCid)
 				return migCid, nil
 			} else if !errors.Is(err, dstore.ErrNotFound) {
 				log.Errorw("failed to lookup previous migration result", "err", err)
@@ -730,7 +724,9 @@ func (c *ChainFork) HandleStateForks(ctx context.Context, root cid.Cid, height a
 		// Yes, we clone the cache, even for the final upgrade epoch. Why? Reverts. We may
 		// have to migrate multiple times.
 		tmpCache := u.cache.Clone()
-		retCid, err = u.upgrade(ctx, tmpCache, root, height, ts)
+		u.status.start(types.MigrationMigrating, root)
+		retCid, err = u.upgrade(withMigrationStatus(ctx, u.status), tmpCache, root, height, ts)
+		u.status.finish(retCid, err)
 		if err != nil {
 			log.Errorw("FAILED migration", "height", height, "from", root, "error", err)
 			return cid.Undef, err
@@ -780,7 +776,7 @@ func (c *ChainFork) GetNetworkVersion(ctx context.Context, height abi.ChainEpoch
 	return c.latestVersion
 }
 
-func runPreMigration(ctx context.Context, fn PreMigrationFunc, cache *nv16.MemMigrationCache, ts *types.TipSet) {
+func runPreMigration(ctx context.Context, fn PreMigrationFunc, cache *nv16.MemMigrationCache, status *migrationStatus, ts *types.TipSet) {
 	height := ts.Height()
 	parent := ts.Blocks()[0].ParentStateRoot
 
@@ -792,7 +788,9 @@ func runPreMigration(ctx context.Context, fn PreMigrationFunc, cache *nv16.MemMi
 	// migration to use the cache may assume that
 	// certain blocks exist, even if they don't.
 	tmpCache := cache.Clone()
-	err := fn(ctx, tmpCache, parent, height, ts)
+	status.start(types.MigrationPreMigrating, parent)
+	err := fn(withMigrationStatus(ctx, status), tmpCache, parent, height, ts)
+	status.finish(cid.Undef, err)
 	if err != nil {
 		log.Errorw("FAILED pre-migration", "error", err)
 		return
@@ -819,6 +817,7 @@ func (c *ChainFork) preMigrationWorker(ctx context.Context) {
 	var schedule []op
 	for upgradeEpoch, migration := range c.stateMigrations {
 		cache := migration.cache
+		status := migration.status
 		for _, prem := range migration.preMigrations {
 			preCtx, preCancel := context.WithCancel(ctx)
 			migrationFunc := prem.PreMigration
@@ -841,7 +840,7 @@ func (c *ChainFork) preMigrationWorker(ctx context.Context) {
 					wg.Add(1)
 					go func() {
 						defer wg.Done()
-						runPreMigration(preCtx, migrationFunc, cache, ts)
+						runPreMigration(preCtx, migrationFunc, cache, status, ts)
 					}()
 				},
 			})
@@ -1886,7 +1885,7 @@ func (c *ChainFork) upgradeActorsV3Common(
 	}
 
 	// Perform the migration
-	newHamtRoot, err := nv10.MigrateStateTree(ctx, store, stateRoot.Actors, epoch, config, migrationLogger{}, cache)
+	newHamtRoot, err := nv10.MigrateStateTree(ctx, store, stateRoot.Actors, epoch, config, newMigrationLogger(ctx), cache)
 	if err != nil {
 		return cid.Undef, fmt.Errorf("upgrading to actors v3: %v", err)
 	}
@@ -1972,7 +1971,7 @@ func (c *ChainFork) upgradeActorsV4Common(
 	}
 
 	// Perform the migration
-	newHamtRoot, err := nv12.MigrateStateTree(ctx, store, stateRoot.Actors, epoch, config, migrationLogger{}, cache)
+	newHamtRoot, err := nv12.MigrateStateTree(ctx, store, stateRoot.Actors, epoch, config, newMigrationLogger(ctx), cache)
 	if err != nil {
 		return cid.Undef, fmt.Errorf("upgrading to actors v4: %v", err)
 	}
@@ -2058,7 +2057,7 @@ func (c *ChainFork) upgradeActorsV5Common(
 	}
 
 	// Perform the migration
-	newHamtRoot, err := nv13.MigrateStateTree(ctx, store, stateRoot.Actors, epoch, config, migrationLogger{}, cache)
+	newHamtRoot, err := nv13.MigrateStateTree(ctx, store, stateRoot.Actors, epoch, config, newMigrationLogger(ctx), cache)
 	if err != nil {
 		return cid.Undef, fmt.Errorf("upgrading to actors v5: %v", err)
 	}
@@ -2146,7 +2145,7 @@ func (c *ChainFork) upgradeActorsV6Common(
 	}
 
 	// Perform the migration
-	newHamtRoot, err := nv14.MigrateStateTree(ctx, store, stateRoot.Actors, epoch, config, migrationLogger{}, cache)
+	newHamtRoot, err := nv14.MigrateStateTree(ctx, store, stateRoot.Actors, epoch, config, newMigrationLogger(ctx), cache)
 	if err != nil {
 		return cid.Undef, fmt.Errorf("upgrading to actors v5: %w", err)
 	}
@@ -2246,7 +2245,7 @@ func (c *ChainFork) upgradeActorsV7Common(
 	}
 
 	// Perform the migration
-	newHamtRoot, err := nv15.MigrateStateTree(ctx, store, stateRoot.Actors, epoch, config, migrationLogger{}, cache)
+	newHamtRoot, err := nv15.MigrateStateTree(ctx, store, stateRoot.Actors, epoch, config, newMigrationLogger(ctx), cache)
 	if err != nil {
 		return cid.Undef, fmt.Errorf("upgrading to actors v7: %w", err)
 	}
@@ -2354,7 +2353,7 @@ func (c *ChainFork) upgradeActorsV8Common(
 	}
 
 	// Perform the migration
-	newHamtRoot, err := nv16.MigrateStateTree(ctx, store, manifest, stateRoot.Actors, epoch, config, migrationLogger{}, cache)
+	newHamtRoot, err := nv16.MigrateStateTree(ctx, store, manifest, stateRoot.Actors, epoch, config, newMigrationLogger(ctx), cache)
 	if err != nil {
 		return cid.Undef, fmt.Errorf("upgrading to actors v8: %w", err)
 	}
@@ -2466,7 +2465,7 @@ func (c *ChainFork) upgradeActorsV9Common(ctx context.Context,
 
 	// Perform the migration
 	newHamtRoot, err := nv17.MigrateStateTree(ctx, store, manifest, stateRoot.Actors, epoch, config,
-		migrationLogger{}, cache)
+		newMigrationLogger(ctx), cache)
 	if err != nil {
 		return cid.Undef, fmt.Errorf("upgrading to actors v9: %w", err)
 	}
@@ -2585,7 +2584,7 @@ func (c *ChainFork) upgradeActorsV10Common(
 
 	// Perform the migration
 	newHamtRoot, err := nv18.MigrateStateTree(ctx, store, manifest, stateRoot.Actors, epoch, config,
-		migrationLogger{}, cache)
+		newMigrationLogger(ctx), cache)
 	if err != nil {
 		return cid.Undef, fmt.Errorf("upgrading to actors v10: %w", err)
 	}
@@ -2691,7 +2690,7 @@ func (c *ChainFork) upgradeActorsV11Common(
 
 	// Perform the migration
 	newHamtRoot, err := nv19.MigrateStateTree(ctx, store, manifest, stateRoot.Actors, epoch, config,
-		migrationLogger{}, cache)
+		newMigrationLogger(ctx), cache)
 	if err != nil {
 		return cid.Undef, fmt.Errorf("upgrading to actors v11: %w", err)
 	}
@@ -2865,7 +2864,7 @@ func (c *ChainFork) upgradeActorsV12Common(
 
 	// Perform the migration
 	newHamtRoot, err := nv21.MigrateStateTree(ctx, adtStore, manifestCid, stateRoot.Actors, epoch, config,
-		migrationLogger{}, cache)
+		newMigrationLogger(ctx), cache)
 	if err != nil {
 		return cid.Undef, fmt.Errorf("upgrading to actors v12: %w", err)
 	}
@@ -3021,7 +3020,7 @@ func (c *ChainFork) upgradeActorsV13Common(ctx context.Context,
 
 	// Perform the migration
 	newHamtRoot, err := nv22.MigrateStateTree(ctx, adtStore, manifestCid, stateRoot.Actors, epoch, config,
-		migrationLogger{}, cache)
+		newMigrationLogger(ctx), cache)
 	if err != nil {
 		return cid.Undef, fmt.Errorf("upgrading to actors v13: %w", err)
 	}
@@ -3457,7 +3456,7 @@ func (c *ChainFork) upgradeActorsV14Common(
 
 	// Perform the migration
 	newHamtRoot, err := nv23.MigrateStateTree(ctx, adtStore, manifest, stateRoot.Actors, epoch, config,
-		migrationLogger{}, cache)
+		newMigrationLogger(ctx), cache)
 	if err != nil {
 		return cid.Undef, fmt.Errorf("upgrading to actors v14: %w", err)
 	}
@@ -3575,7 +3574,7 @@ func (c *ChainFork) upgradeActorsV15Common(
 		int64(c.forkUpgrade.UpgradeTuktukHeight),                   // powerRampStartEpoch
 		uint64(c.forkUpgrade.UpgradeTuktukPowerRampDurationEpochs), // powerRampDurationEpochs
 		config,
-		migrationLogger{},
+		newMigrationLogger(ctx),
 		cache,
 	)
 	if err != nil {
@@ -3686,7 +3685,7 @@ func (c *ChainFork) upgradeActorsV16Common(
 
 	// Perform the migration
 	newHamtRoot, err := nv25.MigrateStateTree(ctx, adtStore, manifest, stateRoot.Actors, epoch, config,
-		migrationLogger{}, cache)
+		newMigrationLogger(ctx), cache)
 	if err != nil {
 		return cid.Undef, fmt.Errorf("upgrading to actors v16: %w", err)
 	}
@@ -0,0 +1,181 @@
+package fork
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/network"
+	"github.com/filecoin-project/go-state-types/rt"
+	"github.com/ipfs-force-community/metrics"
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+var (
+	migrationRunning   = metrics.NewInt64("fork/migration_running", "Whether a state migration, or pre-migration, is running. 0 = idle, 1 = running", "")
+	migrationJobsDone  = metrics.NewInt64("fork/migration_jobs_done", "Number of actor migration jobs done by the running state migration", "")
+	migrationJobsTotal = metrics.NewInt64("fork/migration_jobs_total", "Number of actor migration jobs created by the running state migration", "")
+	migrationDuration  = metrics.NewTimerWithBuckets("fork/migration_duration", "Duration of the state migrations and pre-migrations in milliseconds", "ms",
+		[]float64{1e3, 1e4, 3e4, 6e4, 3e5, 6e5, 1.8e6, 3.6e6})
+)
+
+// the progress logs of the go-state-types and specs-actors migration runners
+const (
+	migrationProgressLog       = "Performing migration: %d of %d jobs processed (%.0f/s) [%v elapsed]"
+	legacyMigrationProgressLog = "%d jobs created, %d done, %d pending after %v (%.0f/s)"
+)
+
+// migrationStatus tracks the pre-migrations and the migration of the state tree for an upgrade.
+type migrationStatus struct {
+	lk     sync.Mutex
+	status types.MigrationStatus
+	stop   func(context.Context) time.Duration
+}
+
+func newMigrationStatus(nv network.Version, height abi.ChainEpoch) *migrationStatus {
+	return &migrationStatus{status: types.MigrationStatus{
+		Network: nv,
+		Height:  height,
+		Phase:   types.MigrationScheduled,
+	}}
+}
+
+func (s *migrationStatus) get() types.MigrationStatus {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	st := s.status
+	if st.Phase == types.MigrationPreMigrating || st.Phase == types.MigrationMigrating {
+		st.Duration = time.Since(st.StartedAt)
+	}
+	return st
+}
+
+func (s *migrationStatus) start(phase types.MigrationPhase, from cid.Cid) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	s.status.Phase = phase
+	s.status.From = from
+	s.status.To = cid.Undef
+	s.status.Cached = false
+	s.status.JobsDone, s.status.JobsTotal = 0, 0
+	s.status.StartedAt = time.Now()
+	s.status.Duration = 0
+	s.status.Error = ""
+	s.stop = migrationDuration.Start()
+
+	ctx := context.Background()
+	migrationRunning.Set(ctx, 1)
+	migrationJobsDone.Set(ctx, 0)
+	migrationJobsTotal.Set(ctx, 0)
+}
+
+func (s *migrationStatus) progress(done, total uint64) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	s.status.JobsDone, s.status.JobsTotal = done, total
+
+	ctx := context.Background()
+	migrationJobsDone.Set(ctx, int64(done))
+	migrationJobsTotal.Set(ctx, int64(total))
+}
+
+// finish records the end of the running pre-migration or migration, to is the migrated state root.
+func (s *migrationStatus) finish(to cid.Cid, err error) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	s.status.Duration = s.stop(context.Background())
+	migrationRunning.Set(context.Background(), 0)
+
+	if err != nil {
+		s.status.Phase = types.MigrationFailed
+		s.status.Error = err.Error()
+		return
+	}
+	if s.status.Phase == types.MigrationPreMigrating {
+		s.status.Phase = types.MigrationPreMigrated
+		s.status.PreMigrations++
+		return
+	}
+	s.status.Phase = types.MigrationDone
+	s.status.To = to
+}
+
+// cached records a migration served by the result of a previous one.
+func (s *migrationStatus) cached(from, to cid.Cid) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	s.status.Phase = types.MigrationDone
+	s.status.From = from
+	s.status.To = to
+	s.status.Cached = true
+	s.status.Error = ""
+}
+
+type migrationStatusKey struct{}
+
+func withMigrationStatus(ctx context.Context, s *migrationStatus) context.Context {
+	return context.WithValue(ctx, migrationStatusKey{}, s)
+}
+
+// migrationLogger forwards the logs of the migrations to the fork logger, and the progress
+// they report to the status of the running migration.
+type migrationLogger struct {
+	status *migrationStatus
+}
+
+func newMigrationLogger(ctx context.Context) migrationLogger {
+	s, _ := ctx.Value(migrationStatusKey{}).(*migrationStatus)
+	return migrationLogger{status: s}
+}
+
+func (ml migrationLogger) Log(level rt.LogLevel, msg string, args ...interface{}) {
+	if ml.status != nil {
+		switch {
+		case msg == migrationProgressLog && len(args) >= 2:
+			ml.status.progress(toUint64(args[0]), toUint64(args[1]))
+		case msg == legacyMigrationProgressLog && len(args) >= 2:
+			ml.status.progress(toUint64(args[1]), toUint64(args[0]))
+		}
+	}
+
+	switch level {
+	case rt.DEBUG:
+		log.Debugf(msg, args...)
+	case rt.INFO:
+		log.Infof(msg, args...)
+	case rt.WARN:
+		log.Warnf(msg, args...)
+	case rt.ERROR:
+		log.Errorf(msg, args...)
+	}
+}
+
+func toUint64(v interface{}) uint64 {
+	switch n := v.(type) {
+	case uint32:
+		return uint64(n)
+	case uint64:
+		return n
+	case int:
+		return uint64(n)
+	case int64:
+		return uint64(n)
+	}
+	return 0
+}
+
+// MigrationStatus returns the status of the state migrations of the scheduled upgrades, by height.
+func (c *ChainFork) MigrationStatus() []types.MigrationStatus {
+	out := make([]types.MigrationStatus, 0, len(c.stateMigrations))
+	for _, m := range c.stateMigrations {
+		out = append(out, m.status.get())
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Height < out[j].Height
+	})
+	return out
+}
@@ -0,0 +1,100 @@
+package fork
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/network"
+	"github.com/filecoin-project/go-state-types/rt"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestMigrationStatus(t *testing.T) {
+	tf.UnitTest(t)
+
+	from, err := abi.CidBuilder.Sum([]byte("from"))
+	require.NoError(t, err)
+	to, err := abi.CidBuilder.Sum([]byte("to"))
+	require.NoError(t, err)
+
+	s := newMigrationStatus(network.Version21, 100)
+	assert.Equal(t, types.MigrationStatus{Network: network.Version21, Height: 100, Phase: types.MigrationScheduled}, s.get())
+
+	// the progress is reported by the logs of the migration running with the status
+	ctx := withMigrationStatus(context.Background(), s)
+	s.start(types.MigrationPreMigrating, from)
+	newMigrationLogger(ctx).Log(rt.INFO, migrationProgressLog, uint64(3), uint64(10), 1.5, "2s")
+	st := s.get()
+	assert.Equal(t, types.MigrationPreMigrating, st.Phase)
+	assert.Equal(t, from, st.From)
+	assert.Equal(t, uint64(3), st.JobsDone)
+	assert.Equal(t, uint64(10), st.JobsTotal)
+	assert.False(t, st.StartedAt.IsZero())
+
+	// the specs-actors migrations log the created jobs first
+	newMigrationLogger(ctx).Log(rt.INFO, legacyMigrationProgressLog, 12, 5, 7, "2s", 2.5)
+	st = s.get()
+	assert.Equal(t, uint64(5), st.JobsDone)
+	assert.Equal(t, uint64(12), st.JobsTotal)
+
+	// the other logs, or the logs of migrations without status, are only logged
+	newMigrationLogger(ctx).Log(rt.INFO, "migrating %d actors", 20)
+	newMigrationLogger(context.Background()).Log(rt.INFO, migrationProgressLog, uint64(9), uint64(10), 1.5, "2s")
+	assert.Equal(t, uint64(5), s.get().JobsDone)
+
+	s.finish(cid.Undef, nil)
+	st = s.get()
+	assert.Equal(t, types.MigrationPreMigrated, st.Phase)
+	assert.Equal(t, 1, st.PreMigrations)
+	assert.Equal(t, cid.Undef, st.To)
+
+	s.start(types.MigrationMigrating, from)
+	st = s.get()
+	assert.Equal(t, types.MigrationMigrating, st.Phase)
+	assert.Zero(t, st.JobsDone)
+	assert.Zero(t, st.JobsTotal)
+
+	s.finish(to, nil)
+	st = s.get()
+	assert.Equal(t, types.MigrationDone, st.Phase)
+	assert.Equal(t, 1, st.PreMigrations)
+	assert.Equal(t, to, st.To)
+	assert.False(t, st.Cached)
+	duration := st.Duration
+	assert.Equal(t, duration, s.get().Duration, "the duration of a finished migration does not change")
+
+	s.start(types.MigrationMigrating, from)
+	s.finish(cid.Undef, errors.New("state tree version mismatch"))
+	st = s.get()
+	assert.Equal(t, types.MigrationFailed, st.Phase)
+	assert.Equal(t, "state tree version mismatch", st.Error)
+
+	s.cached(from, to)
+	st = s.get()
+	assert.Equal(t, types.MigrationDone, st.Phase)
+	assert.Equal(t, to, st.To)
+	assert.True(t, st.Cached)
+	assert.Empty(t, st.Error)
+}
+
+func TestChainForkMigrationStatus(t *testing.T) {
+	tf.UnitTest(t)
+
+	c := &ChainFork{stateMigrations: map[abi.ChainEpoch]*Migration{
+		300: {status: newMigrationStatus(network.Version22, 300)},
+		100: {status: newMigrationStatus(network.Version20, 100)},
+		200: {status: newMigrationStatus(network.Version21, 200)},
+	}}
+	var heights []abi.ChainEpoch
+	for _, st := range c.MigrationStatus() {
+		heights = append(heights, st.Height)
+	}
+	assert.Equal(t, []abi.ChainEpoch{100, 200, 300}, heights)
+}
@@ -58,3 +58,7 @@ func (mockFork *MockFork) GetForkUpgrade() *config.ForkUpgradeConfig {
 func (mockFork *MockFork) Start(ctx context.Context) error {
 	return nil
 }
+
+func (mockFork *MockFork) MigrationStatus() []types.MigrationStatus {
+	return nil
+}
@@ -675,7 +675,7 @@ var useFvmDebug = os.Getenv("VENUS_FVM_DEVELOPER_DEBUG") == "1"
 func NewVM(ctx context.Context, opts vm.VmOption) (vm.Interface, error) {
 
 	switch opts.ExecutionLane {
-	case vmcontext.ExecutionLaneDefault, vmcontext.ExecutionLanePriority:
+	case vmcontext.ExecutionLaneDefault, vmcontext.ExecutionLanePriority, vmcontext.ExecutionLaneAPI:
 	default:
 		return nil, fmt.Errorf("invalid execution lane: %d", opts.ExecutionLane)
 	}
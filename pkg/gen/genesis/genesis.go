@@ -1,11 +1,15 @@
 package genesis
 
 import (
+	"bytes"
 	"context"
-	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
 	"encoding/json"
 	"errors"
 	"fmt"
+	mrand "math/rand"
+	"sort"
 
 	"github.com/filecoin-project/venus/pkg/consensus/chainselector"
 	"github.com/filecoin-project/venus/pkg/consensusfault"
@@ -142,6 +146,17 @@ Genesis: {
 
 */
 
+// templateRand returns the source of the random values of the genesis, seeded by the template so
+// the same template always gives the same genesis block.
+func templateRand(template Template) (*mrand.Rand, error) {
+	b, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(b)
+	return mrand.New(mrand.NewSource(int64(binary.BigEndian.Uint64(sum[:])))), nil //nolint:gosec
+}
+
 func MakeInitialStateTree(ctx context.Context, bs bstore.Blockstore, template Template) (*tree.State, map[address.Address]address.Address, error) {
 	// Create empty state tree
 	cst := cbor.NewCborStore(bs)
@@ -304,7 +319,11 @@ func MakeInitialStateTree(ctx context.Context, bs bstore.Blockstore, template Te
 
 	// Setup the first verifier as ID-address 81
 	// TODO: remove this
-	skBytes, err := sigs.Generate(crypto.SigTypeBLS)
+	rnd, err := templateRand(template)
+	if err != nil {
+		return nil, nil, err
+	}
+	skBytes, err := sigs.GenerateFromSeed(crypto.SigTypeBLS, rnd)
 	if err != nil {
 		return nil, nil, fmt.Errorf("creating random verifier secret key: %w", err)
 	}
@@ -556,11 +575,19 @@ func VerifyPreSealedData(ctx context.Context, cs *chain.Store, stateroot cid.Cid
 		return cid.Undef, fmt.Errorf("failed to create verifier: %w", err)
 	}
 
-	for c, amt := range verifNeeds {
+	// add the clients in order, so the same template always gives the same state
+	clients := make([]address.Address, 0, len(verifNeeds))
+	for c := range verifNeeds {
+		clients = append(clients, c)
+	}
+	sort.Slice(clients, func(i, j int) bool {
+		return bytes.Compare(clients[i].Bytes(), clients[j].Bytes()) < 0
+	})
+	for _, c := range clients {
 		// Note: This is brittle, if the methodNum / param changes, it could break things
 		_, err := doExecValue(ctx, vm, verifreg.Address, verifier, types.NewInt(0), builtin0.MethodsVerifiedRegistry.AddVerifiedClient, mustEnc(&verifreg0.AddVerifiedClientParams{
 			Address:   c,
-			Allowance: abi.NewStoragePower(int64(amt)),
+			Allowance: abi.NewStoragePower(int64(verifNeeds[c])),
 		}))
 		if err != nil {
 			return cid.Undef, fmt.Errorf("failed to add verified client: %w", err)
@@ -640,8 +667,12 @@ func MakeGenesisBlock(ctx context.Context, rep repo.Repo, bs bstore.Blockstore,
 
 	log.Infof("Empty Genesis root: %s", emptyroot)
 
+	rnd, err := templateRand(template)
+	if err != nil {
+		return nil, err
+	}
 	tickBuf := make([]byte, 32)
-	_, _ = rand.Read(tickBuf)
+	_, _ = rnd.Read(tickBuf)
 	genesisticket := &types.Ticket{
 		VRFProof: tickBuf,
 	}
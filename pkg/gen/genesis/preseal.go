@@ -0,0 +1,63 @@
+package genesis
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	commcid "github.com/filecoin-project/go-fil-commcid"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+)
+
+// LoadPreSeals reads the metadata of sectors sealed out of the seed tooling, a JSON array of PreSeal with their
+// CommR, CommD, SectorID and ProofType, and checks a genesis miner can be given these sectors. Their deals are made
+// when the miner is.
+func LoadPreSeals(path string) ([]*PreSeal, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read pre-sealed sectors: %w", err)
+	}
+	var sectors []*PreSeal
+	if err := json.Unmarshal(b, &sectors); err != nil {
+		return nil, fmt.Errorf("unmarshal pre-sealed sectors: %w", err)
+	}
+	if _, err := CheckPreSeals(sectors); err != nil {
+		return nil, err
+	}
+	return sectors, nil
+}
+
+// CheckPreSeals checks the sectors are distinct sectors of the same size with valid commitments, and returns their
+// size.
+func CheckPreSeals(sectors []*PreSeal) (abi.SectorSize, error) {
+	if len(sectors) == 0 {
+		return 0, fmt.Errorf("no pre-sealed sector")
+	}
+	size, err := sectors[0].ProofType.SectorSize()
+	if err != nil {
+		return 0, fmt.Errorf("sector %d: %w", sectors[0].SectorID, err)
+	}
+	seen := make(map[abi.SectorNumber]struct{}, len(sectors))
+	for _, s := range sectors {
+		if s.ProofType != sectors[0].ProofType {
+			return 0, fmt.Errorf("sector %d has proof type %d, expected %d", s.SectorID, s.ProofType, sectors[0].ProofType)
+		}
+		if _, ok := seen[s.SectorID]; ok {
+			return 0, fmt.Errorf("duplicate sector %d", s.SectorID)
+		}
+		seen[s.SectorID] = struct{}{}
+		if _, err := commcid.CIDToReplicaCommitmentV1(s.CommR); err != nil {
+			return 0, fmt.Errorf("sector %d has an invalid CommR: %w", s.SectorID, err)
+		}
+		if _, err := commcid.CIDToDataCommitmentV1(s.CommD); err != nil {
+			return 0, fmt.Errorf("sector %d has an invalid CommD: %w", s.SectorID, err)
+		}
+	}
+	return size, nil
+}
+
+// RawPower is the raw byte power the miner is given at genesis by its pre-sealed sectors.
+func (m *Miner) RawPower() abi.StoragePower {
+	return big.Mul(big.NewIntUnsigned(uint64(m.SectorSize)), big.NewInt(int64(len(m.Sectors))))
+}
@@ -0,0 +1,63 @@
+package genesis
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	commcid "github.com/filecoin-project/go-fil-commcid"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestLoadPreSeals(t *testing.T) {
+	tf.UnitTest(t)
+
+	commR, err := commcid.ReplicaCommitmentV1ToCID(make([]byte, 32))
+	require.NoError(t, err)
+	commD, err := commcid.DataCommitmentV1ToCID(make([]byte, 32))
+	require.NoError(t, err)
+	sector := func(id abi.SectorNumber) *PreSeal {
+		return &PreSeal{CommR: commR, CommD: commD, SectorID: id, ProofType: abi.RegisteredSealProof_StackedDrg2KiBV1_1}
+	}
+
+	path := filepath.Join(t.TempDir(), "sectors.json")
+	write := func(sectors ...*PreSeal) {
+		b, err := json.Marshal(sectors)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, b, 0o644))
+	}
+
+	write(sector(0), sector(1))
+	sectors, err := LoadPreSeals(path)
+	require.NoError(t, err)
+	require.Len(t, sectors, 2)
+	size, err := CheckPreSeals(sectors)
+	require.NoError(t, err)
+	assert.Equal(t, abi.SectorSize(2048), size)
+	m := Miner{SectorSize: size, Sectors: sectors}
+	assert.Equal(t, big.NewInt(4096), m.RawPower())
+
+	// the sectors must be distinct sectors of the same size with valid commitments
+	write()
+	_, err = LoadPreSeals(path)
+	assert.Error(t, err)
+	write(sector(0), sector(0))
+	_, err = LoadPreSeals(path)
+	assert.Error(t, err)
+	other := sector(1)
+	other.ProofType = abi.RegisteredSealProof_StackedDrg8MiBV1_1
+	write(sector(0), other)
+	_, err = LoadPreSeals(path)
+	assert.Error(t, err)
+	swapped := sector(1)
+	swapped.CommR, swapped.CommD = commD, commR
+	write(sector(0), swapped)
+	_, err = LoadPreSeals(path)
+	assert.Error(t, err)
+}
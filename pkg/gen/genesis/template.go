@@ -0,0 +1,235 @@
+package genesis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	actorstypes "github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/network"
+
+	"github.com/filecoin-project/venus/pkg/constants"
+)
+
+// NewTemplate returns an empty template of the network named networkName whose genesis state is at
+// network version nv, which also picks the version of the actors. A zero timestamp is replaced by
+// the current time when the genesis block is made, set it for a reproducible genesis.
+func NewTemplate(networkName string, nv network.Version, timestamp uint64) *Template {
+	return &Template{
+		NetworkVersion: nv,
+		Accounts:       []Actor{},
+		Miners:         []Miner{},
+		NetworkName:    networkName,
+		Timestamp:      timestamp,
+	}
+}
+
+// AddAccount allocates balance to a new account actor of the key address owner.
+func (t *Template) AddAccount(owner address.Address, balance abi.TokenAmount) error {
+	a := Actor{
+		Type:    TAccount,
+		Balance: balance,
+		Meta:    (&AccountMeta{Owner: owner}).ActorMeta(),
+	}
+	if err := validateActor(a); err != nil {
+		return err
+	}
+	t.Accounts = append(t.Accounts, a)
+	return nil
+}
+
+// AddMultisig allocates balance to a new multisig actor.
+func (t *Template) AddMultisig(meta MultisigMeta, balance abi.TokenAmount) error {
+	a := Actor{
+		Type:    TMultisig,
+		Balance: balance,
+		Meta:    meta.ActorMeta(),
+	}
+	if err := validateActor(a); err != nil {
+		return err
+	}
+	t.Accounts = append(t.Accounts, a)
+	return nil
+}
+
+// AddMiner adds a genesis miner, the miners get the IDs following MinerStart in the order they are
+// added, so an undefined ID is set to the next one.
+func (t *Template) AddMiner(m Miner) error {
+	id := MinerAddress(uint64(len(t.Miners)))
+	if m.ID == address.Undef {
+		m.ID = id
+	}
+	if m.ID != id {
+		return fmt.Errorf("miner %s must be added as %s", m.ID, id)
+	}
+	if err := validateMiner(m); err != nil {
+		return err
+	}
+	t.Miners = append(t.Miners, m)
+	return nil
+}
+
+// SetVerifregRoot sets the root key holder of the verified registry.
+func (t *Template) SetVerifregRoot(a Actor) error {
+	if err := validateActor(a); err != nil {
+		return fmt.Errorf("verifreg root: %w", err)
+	}
+	t.VerifregRootKey = a
+	return nil
+}
+
+// SetRemainder sets the actor receiving the filecoin not allocated to the other actors.
+func (t *Template) SetRemainder(a Actor) error {
+	if err := validateActor(a); err != nil {
+		return fmt.Errorf("remainder account: %w", err)
+	}
+	t.RemainderAccount = a
+	return nil
+}
+
+// Validate checks the template describes a genesis state MakeGenesisBlock can create.
+func (t *Template) Validate() error {
+	if t.NetworkName == "" {
+		return fmt.Errorf("network name is empty")
+	}
+	if _, err := actorstypes.VersionForNetwork(t.NetworkVersion); err != nil {
+		return fmt.Errorf("network version %d: %w", t.NetworkVersion, err)
+	}
+	if len(t.Accounts) > MaxAccounts {
+		return fmt.Errorf("%d accounts, at most %d are supported", len(t.Accounts), MaxAccounts)
+	}
+
+	allocated := big.Zero()
+	owners := make(map[address.Address]struct{}, len(t.Accounts))
+	for i, a := range t.Accounts {
+		if err := validateActor(a); err != nil {
+			return fmt.Errorf("account %d: %w", i, err)
+		}
+		if a.Type == TAccount {
+			var meta AccountMeta
+			if err := json.Unmarshal(a.Meta, &meta); err != nil {
+				return err
+			}
+			if _, ok := owners[meta.Owner]; ok {
+				return fmt.Errorf("account %d: %s already has an account", i, meta.Owner)
+			}
+			owners[meta.Owner] = struct{}{}
+		}
+		allocated = big.Add(allocated, a.Balance)
+	}
+	if err := validateActor(t.VerifregRootKey); err != nil {
+		return fmt.Errorf("verifreg root: %w", err)
+	}
+	if err := validateActor(t.RemainderAccount); err != nil {
+		return fmt.Errorf("remainder account: %w", err)
+	}
+	allocated = big.Add(allocated, t.VerifregRootKey.Balance)
+
+	for i, m := range t.Miners {
+		if id := MinerAddress(uint64(i)); m.ID != id {
+			return fmt.Errorf("miner %d has id %s, expected %s", i, m.ID, id)
+		}
+		if err := validateMiner(m); err != nil {
+			return err
+		}
+		allocated = big.Add(allocated, big.Add(m.MarketBalance, m.PowerBalance))
+	}
+
+	totalFil := big.Mul(big.NewInt(int64(constants.FilBase)), big.NewInt(int64(constants.FilecoinPrecision)))
+	if allocated.GreaterThan(totalFil) {
+		return fmt.Errorf("%s allocated, more than the %s of the network", allocated, totalFil)
+	}
+	return nil
+}
+
+// Marshal validates the template and encodes it as the JSON read by `venus daemon --genesis-template`,
+// the same template always gives the same bytes.
+func (t *Template) Marshal() ([]byte, error) {
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(t, "", "  ")
+}
+
+func validateActor(a Actor) error {
+	if a.Balance.Nil() || a.Balance.Sign() < 0 {
+		return fmt.Errorf("invalid balance %v", a.Balance)
+	}
+	switch a.Type {
+	case TAccount:
+		var meta AccountMeta
+		if err := json.Unmarshal(a.Meta, &meta); err != nil {
+			return fmt.Errorf("unmarshaling account meta: %w", err)
+		}
+		if err := validateKeyAddress(meta.Owner); err != nil {
+			return fmt.Errorf("account owner: %w", err)
+		}
+	case TMultisig:
+		var meta MultisigMeta
+		if err := json.Unmarshal(a.Meta, &meta); err != nil {
+			return fmt.Errorf("unmarshaling multisig meta: %w", err)
+		}
+		if len(meta.Signers) == 0 {
+			return fmt.Errorf("multisig has no signer")
+		}
+		signers := make(map[address.Address]struct{}, len(meta.Signers))
+		for _, s := range meta.Signers {
+			if err := validateKeyAddress(s); err != nil {
+				return fmt.Errorf("multisig signer: %w", err)
+			}
+			if _, ok := signers[s]; ok {
+				return fmt.Errorf("duplicate multisig signer %s", s)
+			}
+			signers[s] = struct{}{}
+		}
+		if meta.Threshold < 1 || meta.Threshold > len(meta.Signers) {
+			return fmt.Errorf("multisig threshold %d out of range for %d signers", meta.Threshold, len(meta.Signers))
+		}
+		if meta.VestingDuration < 0 || meta.VestingStart < 0 {
+			return fmt.Errorf("negative multisig vesting")
+		}
+	default:
+		return fmt.Errorf("unsupported actor type: %q", a.Type)
+	}
+	return nil
+}
+
+func validateKeyAddress(addr address.Address) error {
+	switch addr.Protocol() {
+	case address.SECP256K1, address.BLS, address.Delegated:
+		return nil
+	}
+	return fmt.Errorf("%s is not a key address", addr)
+}
+
+func validateMiner(m Miner) error {
+	if err := validateKeyAddress(m.Owner); err != nil {
+		return fmt.Errorf("miner %s owner: %w", m.ID, err)
+	}
+	if m.Worker.Protocol() != address.BLS {
+		return fmt.Errorf("miner %s worker %s must be a bls address", m.ID, m.Worker)
+	}
+	if m.MarketBalance.Nil() || m.MarketBalance.Sign() < 0 || m.PowerBalance.Nil() || m.PowerBalance.Sign() < 0 {
+		return fmt.Errorf("miner %s has an invalid balance", m.ID)
+	}
+	sectors := make(map[abi.SectorNumber]struct{}, len(m.Sectors))
+	for _, s := range m.Sectors {
+		size, err := s.ProofType.SectorSize()
+		if err != nil {
+			return fmt.Errorf("miner %s sector %d: %w", m.ID, s.SectorID, err)
+		}
+		if size != m.SectorSize {
+			return fmt.Errorf("miner %s sector %d has size %d, expected %d", m.ID, s.SectorID, size, m.SectorSize)
+		}
+		if _, ok := sectors[s.SectorID]; ok {
+			return fmt.Errorf("miner %s has duplicate sector %d", m.ID, s.SectorID)
+		}
+		sectors[s.SectorID] = struct{}{}
+		if s.Deal.Provider != m.ID {
+			return fmt.Errorf("miner %s sector %d has a deal with provider %s", m.ID, s.SectorID, s.Deal.Provider)
+		}
+	}
+	return nil
+}
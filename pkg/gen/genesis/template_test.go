@@ -0,0 +1,72 @@
+package genesis
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestTemplate(t *testing.T) {
+	tf.UnitTest(t)
+
+	owner, err := address.NewSecp256k1Address([]byte("owner"))
+	require.NoError(t, err)
+	worker, err := address.NewBLSAddress(make([]byte, address.BlsPublicKeyBytes))
+	require.NoError(t, err)
+	signer, err := address.NewSecp256k1Address([]byte("signer"))
+	require.NoError(t, err)
+
+	newTemplate := func() *Template {
+		tmpl := NewTemplate("localnet", network.Version21, 1700000000)
+		root := Actor{Type: TMultisig, Balance: big.Zero(), Meta: (&MultisigMeta{Signers: []address.Address{signer}, Threshold: 1}).ActorMeta()}
+		require.NoError(t, tmpl.SetVerifregRoot(root))
+		require.NoError(t, tmpl.SetRemainder(root))
+		require.NoError(t, tmpl.AddAccount(owner, big.NewInt(100)))
+		require.NoError(t, tmpl.AddMultisig(MultisigMeta{Signers: []address.Address{owner, signer}, Threshold: 2}, big.NewInt(100)))
+		require.NoError(t, tmpl.AddMiner(Miner{
+			Owner:         owner,
+			Worker:        worker,
+			MarketBalance: big.Zero(),
+			PowerBalance:  big.Zero(),
+			SectorSize:    2048,
+			Sectors: []*PreSeal{{
+				SectorID:  0,
+				ProofType: abi.RegisteredSealProof_StackedDrg2KiBV1_1,
+				Deal:      types.DealProposal{Provider: MinerAddress(0)},
+			}},
+		}))
+		return tmpl
+	}
+
+	tmpl := newTemplate()
+	assert.Equal(t, MinerAddress(0), tmpl.Miners[0].ID)
+	b, err := tmpl.Marshal()
+	require.NoError(t, err)
+	b2, err := newTemplate().Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, b, b2)
+
+	// invalid allocations are refused
+	assert.Error(t, tmpl.AddAccount(address.Undef, big.NewInt(1)))
+	assert.Error(t, tmpl.AddAccount(MinerAddress(1), big.NewInt(1)))
+	assert.Error(t, tmpl.AddAccount(signer, big.NewInt(-1)))
+	assert.Error(t, tmpl.AddMultisig(MultisigMeta{Signers: []address.Address{signer}, Threshold: 2}, big.Zero()))
+	assert.Error(t, tmpl.AddMiner(Miner{ID: MinerAddress(5), Owner: owner, Worker: worker}))
+	assert.Error(t, tmpl.AddMiner(Miner{Owner: owner, Worker: owner, MarketBalance: big.Zero(), PowerBalance: big.Zero()}))
+
+	// an account can only be allocated once
+	tmpl.Accounts = append(tmpl.Accounts, tmpl.Accounts[0])
+	assert.Error(t, tmpl.Validate())
+
+	tmpl = newTemplate()
+	tmpl.Accounts[0].Balance = big.Mul(big.NewInt(3_000_000_000), big.NewInt(1e18))
+	assert.Error(t, tmpl.Validate())
+}
@@ -0,0 +1,263 @@
+// Package maintenance runs the disk maintenance of the node, like the blockstore garbage collection and the
+// vacuum of the sqlite indexes, in the maintenance windows of the config only, so it never slows the node down
+// while the miners using it are proving.
+package maintenance
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	logging "github.com/ipfs/go-log/v2"
+
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+var log = logging.Logger("maintenance")
+
+// checkInterval is how often the scheduler checks whether a window opened.
+var checkInterval = time.Minute
+
+// TaskFunc runs a maintenance task, ctx is cancelled when the window closes.
+type TaskFunc func(ctx context.Context) error
+
+type window struct {
+	cron     string
+	spec     *cronSpec
+	duration time.Duration
+}
+
+type task struct {
+	run    TaskFunc
+	status types.MaintenanceTaskStatus
+}
+
+// Scheduler runs the registered tasks once per maintenance window, in the order they were registered.
+type Scheduler struct {
+	windows []window
+	now     func() time.Time
+
+	lk       sync.Mutex
+	tasks    []*task
+	override *types.MaintenanceOverride
+	// overrideAt is when the override was set, the start of the window it opens
+	overrideAt time.Time
+	// ran is the start of the window the tasks last ran in
+	ran time.Time
+	// interrupt cancels the running tasks
+	interrupt context.CancelFunc
+
+	wake   chan struct{}
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// New parses the windows of cfg.
+func New(cfg *config.MaintenanceConfig) (*Scheduler, error) {
+	s := &Scheduler{
+		now:  time.Now,
+		wake: make(chan struct{}, 1),
+	}
+	for _, w := range cfg.Windows {
+		spec, err := parseCron(w.Cron)
+		if err != nil {
+			return nil, err
+		}
+		if w.Duration <= 0 {
+			return nil, fmt.Errorf("maintenance window %q has no duration", w.Cron)
+		}
+		s.windows = append(s.windows, window{cron: w.Cron, spec: spec, duration: time.Duration(w.Duration)})
+	}
+	return s, nil
+}
+
+// Register adds a task run in the maintenance windows.
+func (s *Scheduler) Register(name string, run TaskFunc) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	s.tasks = append(s.tasks, &task{run: run, status: types.MaintenanceTaskStatus{Name: name}})
+}
+
+// Start runs the tasks in the windows until Stop is called.
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.done = make(chan struct{})
+	go s.loop(ctx)
+}
+
+// Stop interrupts the running tasks and waits for them.
+func (s *Scheduler) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	<-s.done
+}
+
+func (s *Scheduler) loop(ctx context.Context) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+	for {
+		s.runDue(ctx)
+		select {
+		case <-ticker.C:
+		case <-s.wake:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// runDue runs the tasks when a window is open and they have not run in it yet.
+func (s *Scheduler) runDue(ctx context.Context) {
+	s.lk.Lock()
+	open, start, end := s.window(s.now())
+	if !open || start.Equal(s.ran) {
+		s.lk.Unlock()
+		return
+	}
+	s.ran = start
+	tasks := append([]*task(nil), s.tasks...)
+	ctx, cancel := context.WithTimeout(ctx, end.Sub(s.now()))
+	s.interrupt = cancel
+	s.lk.Unlock()
+
+	defer func() {
+		s.lk.Lock()
+		s.interrupt = nil
+		s.lk.Unlock()
+		cancel()
+	}()
+	log.Infow("maintenance window open", "start", start, "end", end)
+	for _, t := range tasks {
+		if ctx.Err() != nil {
+			log.Warnw("maintenance window closed before running all the tasks", "task", t.status.Name)
+			return
+		}
+
+		s.lk.Lock()
+		t.status.Running = true
+		t.status.LastStart = s.now()
+		s.lk.Unlock()
+
+		err := t.run(ctx)
+
+		s.lk.Lock()
+		t.status.Running = false
+		t.status.LastEnd = s.now()
+		t.status.LastError = ""
+		if err != nil {
+			t.status.LastError = err.Error()
+		}
+		s.lk.Unlock()
+
+		if err != nil {
+			log.Errorw("maintenance task failed", "task", t.status.Name, "error", err)
+		} else {
+			log.Infow("maintenance task done", "task", t.status.Name, "duration", t.status.LastEnd.Sub(t.status.LastStart))
+		}
+	}
+}
+
+// window returns whether the maintenance is allowed at now with the bounds of the current window, the
+// bounds of the next window otherwise. The caller holds the lock.
+func (s *Scheduler) window(now time.Time) (bool, time.Time, time.Time) {
+	if s.override != nil && !now.Before(s.override.Until) {
+		s.override = nil
+	}
+	if s.override != nil {
+		switch s.override.Mode {
+		case types.MaintenanceOpen:
+			return true, s.overrideAt, s.override.Until
+		case types.MaintenanceClosed:
+			// the next window after the override
+			_, start, end := s.configured(s.override.Until)
+			return false, start, end
+		}
+	}
+	return s.configured(now)
+}
+
+// configured returns whether a configured window is open at now with its bounds, the bounds of the next
+// one otherwise. Overlapping open windows are merged.
+func (s *Scheduler) configured(now time.Time) (bool, time.Time, time.Time) {
+	var open bool
+	var start, end time.Time
+	for _, w := range s.windows {
+		// the first opening of the window in (now-duration, now] is the start of the current one
+		if st := w.spec.next(now.Add(-w.duration)); !st.IsZero() && !st.After(now) {
+			if !open || st.Before(start) {
+				start = st
+			}
+			if !open || st.Add(w.duration).After(end) {
+				end = st.Add(w.duration)
+			}
+			open = true
+			continue
+		}
+		if open {
+			continue
+		}
+		if st := w.spec.next(now); !st.IsZero() && (start.IsZero() || st.Before(start)) {
+			start, end = st, st.Add(w.duration)
+		}
+	}
+	return open, start, end
+}
+
+// Override overrides the windows until o.Until, an empty mode removes the override. Closing the maintenance
+// interrupts the running tasks.
+func (s *Scheduler) Override(o types.MaintenanceOverride) error {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	switch o.Mode {
+	case "":
+		s.override = nil
+		return nil
+	case types.MaintenanceOpen, types.MaintenanceClosed:
+	default:
+		return fmt.Errorf("unknown maintenance override mode %q, want %s or %s", o.Mode, types.MaintenanceOpen, types.MaintenanceClosed)
+	}
+	now := s.now()
+	if !o.Until.After(now) {
+		return fmt.Errorf("maintenance override until %s is in the past", o.Until)
+	}
+	s.override = &o
+	s.overrideAt = now
+	if o.Mode == types.MaintenanceClosed && s.interrupt != nil {
+		s.interrupt()
+	}
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Schedule reports the windows, the override and the tasks.
+func (s *Scheduler) Schedule() *types.MaintenanceSchedule {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	out := &types.MaintenanceSchedule{
+		Windows: make([]types.MaintenanceWindow, 0, len(s.windows)),
+		Tasks:   make([]types.MaintenanceTaskStatus, 0, len(s.tasks)),
+	}
+	out.Open, out.Start, out.End = s.window(s.now())
+	if s.override != nil {
+		o := *s.override
+		out.Override = &o
+	}
+	for _, w := range s.windows {
+		out.Windows = append(out.Windows, types.MaintenanceWindow{Cron: w.cron, Duration: w.duration})
+	}
+	for _, t := range s.tasks {
+		out.Tasks = append(out.Tasks, t.status)
+	}
+	return out
+}
@@ -0,0 +1,106 @@
+package maintenance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/config"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestCron(t *testing.T) {
+	tf.UnitTest(t)
+
+	at := func(s string) time.Time {
+		v, err := time.ParseInLocation("2006-01-02 15:04", s, time.UTC)
+		require.NoError(t, err)
+		return v
+	}
+	// 2024-01-01 is a monday
+	for _, c := range []struct {
+		expr, from, next string
+	}{
+		{"* * * * *", "2024-01-01 10:00", "2024-01-01 10:01"},
+		{"30 3 * * *", "2024-01-01 10:00", "2024-01-02 03:30"},
+		{"0 3 * * 1-5", "2024-01-05 04:00", "2024-01-08 03:00"},
+		{"0 3 * * 0", "2024-01-01 00:00", "2024-01-07 03:00"},
+		{"0 3 * * 7", "2024-01-01 00:00", "2024-01-07 03:00"},
+		{"*/15 * * * *", "2024-01-01 10:07", "2024-01-01 10:15"},
+		{"0 0 1 */3 *", "2024-02-10 00:00", "2024-04-01 00:00"},
+		{"0 12 15 * 5", "2024-01-01 00:00", "2024-01-05 12:00"},
+		{"0 0 29 2 *", "2024-03-01 00:00", "2028-02-29 00:00"},
+		{"5,10 1 * * *", "2024-01-01 01:05", "2024-01-01 01:10"},
+	} {
+		spec, err := parseCron(c.expr)
+		require.NoError(t, err, c.expr)
+		require.Equal(t, at(c.next), spec.next(at(c.from)), c.expr)
+	}
+
+	spec, err := parseCron("0 0 31 2 *")
+	require.NoError(t, err)
+	require.True(t, spec.next(at("2024-01-01 00:00")).IsZero())
+
+	for _, expr := range []string{"* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "5-1 * * * *", "*/0 * * * *", "a * * * *"} {
+		_, err := parseCron(expr)
+		require.Error(t, err, expr)
+	}
+}
+
+func TestScheduler(t *testing.T) {
+	tf.UnitTest(t)
+
+	s, err := New(&config.MaintenanceConfig{Windows: []config.MaintenanceWindow{
+		{Cron: "0 3 * * *", Duration: config.Duration(2 * time.Hour)},
+	}})
+	require.NoError(t, err)
+	now := time.Date(2024, 1, 1, 2, 0, 0, 0, time.Local)
+	s.now = func() time.Time { return now }
+
+	var runs int
+	s.Register("count", func(ctx context.Context) error {
+		runs++
+		return nil
+	})
+
+	ctx := context.Background()
+	s.runDue(ctx)
+	require.Equal(t, 0, runs)
+	schedule := s.Schedule()
+	require.False(t, schedule.Open)
+	require.Equal(t, time.Date(2024, 1, 1, 3, 0, 0, 0, time.Local), schedule.Start)
+
+	// the tasks run once per window
+	now = time.Date(2024, 1, 1, 4, 0, 0, 0, time.Local)
+	s.runDue(ctx)
+	s.runDue(ctx)
+	require.Equal(t, 1, runs)
+	schedule = s.Schedule()
+	require.True(t, schedule.Open)
+	require.Equal(t, time.Date(2024, 1, 1, 5, 0, 0, 0, time.Local), schedule.End)
+	require.Equal(t, now, schedule.Tasks[0].LastStart)
+
+	// closed until the end of the next window
+	now = time.Date(2024, 1, 2, 1, 0, 0, 0, time.Local)
+	require.NoError(t, s.Override(types.MaintenanceOverride{Mode: types.MaintenanceClosed, Until: now.Add(5 * time.Hour)}))
+	now = time.Date(2024, 1, 2, 3, 30, 0, 0, time.Local)
+	s.runDue(ctx)
+	require.Equal(t, 1, runs)
+	require.Equal(t, time.Date(2024, 1, 3, 3, 0, 0, 0, time.Local), s.Schedule().Start)
+
+	// open now
+	require.NoError(t, s.Override(types.MaintenanceOverride{Mode: types.MaintenanceOpen, Until: now.Add(time.Hour)}))
+	s.runDue(ctx)
+	require.Equal(t, 2, runs)
+
+	require.Error(t, s.Override(types.MaintenanceOverride{Mode: "later", Until: now.Add(time.Hour)}))
+	require.Error(t, s.Override(types.MaintenanceOverride{Mode: types.MaintenanceOpen, Until: now}))
+	require.NoError(t, s.Override(types.MaintenanceOverride{}))
+	require.Nil(t, s.Schedule().Override)
+
+	_, err = New(&config.MaintenanceConfig{Windows: []config.MaintenanceWindow{{Cron: "0 3 * * *"}}})
+	require.Error(t, err)
+}
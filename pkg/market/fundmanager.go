@@ -48,7 +48,7 @@ func NewFundManager(p *FundManagerParams) *FundManager {
 		ctx:         ctx,
 		shutdown:    cancel,
 		api:         fmgrapi,
-		str:         &Store{p.DS},
+		str:         newStore(p.DS),
 		fundedAddrs: make(map[address.Address]*fundedAddress),
 	}
 }
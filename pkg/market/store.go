@@ -20,7 +20,6 @@ type Store struct {
 	ds datastore.Batching
 }
 
-// for test
 func newStore(ds repo.Datastore) *Store {
 	ds = namespace.Wrap(ds, datastore.NewKey("/fundmgr/"))
 	return &Store{
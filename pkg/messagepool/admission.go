@@ -0,0 +1,107 @@
+package messagepool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/ipfs-force-community/metrics"
+	"go.opencensus.io/tag"
+
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// ErrAdmissionDenied is returned for messages rejected by the mpool admission policy.
+var ErrAdmissionDenied = errors.New("message denied by mpool admission policy")
+
+// admission policy rules, used as the rejection reason and the metrics tag
+const (
+	AdmissionRuleMaxGasLimit     = "max_gas_limit"
+	AdmissionRuleMinGasPremium   = "min_gas_premium"
+	AdmissionRuleDeniedRecipient = "denied_recipient"
+	AdmissionRuleDeniedMethod    = "denied_method"
+)
+
+var (
+	tagKeyAdmissionRule = tag.MustNewKey("rule")
+
+	mpoolAdmissionRejected = metrics.NewCounter("mpool/admission_rejected", "Number of messages rejected by the mpool admission policy", tagKeyAdmissionRule)
+)
+
+// admissionPolicy evaluates the operator configured rules on messages entering the mpool,
+// before they are validated, and counts the rejections by rule.
+type admissionPolicy struct {
+	maxGasLimit      int64
+	minGasPremium    big.Int
+	deniedRecipients map[address.Address]struct{}
+	deniedMethods    map[abi.MethodNum]struct{}
+
+	lk       sync.Mutex
+	rejected map[string]uint64
+}
+
+func newAdmissionPolicy(cfg config.MpoolAdmissionPolicyConfig) *admissionPolicy {
+	p := &admissionPolicy{
+		maxGasLimit:      cfg.MaxGasLimit,
+		minGasPremium:    big.NewInt(cfg.MinGasPremium),
+		deniedRecipients: make(map[address.Address]struct{}, len(cfg.DeniedRecipients)),
+		deniedMethods:    make(map[abi.MethodNum]struct{}, len(cfg.DeniedMethods)),
+		rejected:         make(map[string]uint64),
+	}
+	for _, addr := range cfg.DeniedRecipients {
+		p.deniedRecipients[addr] = struct{}{}
+	}
+	for _, method := range cfg.DeniedMethods {
+		p.deniedMethods[method] = struct{}{}
+	}
+	return p
+}
+
+// check returns an error wrapping ErrAdmissionDenied if msg breaks one of the rules.
+func (p *admissionPolicy) check(ctx context.Context, msg *types.Message) error {
+	if p == nil {
+		return nil
+	}
+
+	var rule, reason string
+	if p.maxGasLimit > 0 && msg.GasLimit > p.maxGasLimit {
+		rule, reason = AdmissionRuleMaxGasLimit, fmt.Sprintf("gas limit %d exceeds %d", msg.GasLimit, p.maxGasLimit)
+	} else if p.minGasPremium.Sign() > 0 && msg.GasPremium.LessThan(p.minGasPremium) {
+		rule, reason = AdmissionRuleMinGasPremium, fmt.Sprintf("gas premium %s below %s", msg.GasPremium, p.minGasPremium)
+	} else if _, ok := p.deniedRecipients[msg.To]; ok {
+		rule, reason = AdmissionRuleDeniedRecipient, fmt.Sprintf("recipient %s is denied", msg.To)
+	} else if _, ok := p.deniedMethods[msg.Method]; ok {
+		rule, reason = AdmissionRuleDeniedMethod, fmt.Sprintf("method %d is denied", msg.Method)
+	} else {
+		return nil
+	}
+
+	p.lk.Lock()
+	p.rejected[rule]++
+	p.lk.Unlock()
+
+	ctx, _ = tag.New(ctx, tag.Upsert(tagKeyAdmissionRule, rule))
+	mpoolAdmissionRejected.Tick(ctx)
+
+	return fmt.Errorf("%w: %s", ErrAdmissionDenied, reason)
+}
+
+// rejections returns the number of messages rejected by each rule.
+func (p *admissionPolicy) rejections() map[string]uint64 {
+	out := make(map[string]uint64)
+	if p == nil {
+		return out
+	}
+
+	p.lk.Lock()
+	defer p.lk.Unlock()
+	for rule, n := range p.rejected {
+		out[rule] = n
+	}
+	return out
+}
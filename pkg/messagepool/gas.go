@@ -122,6 +122,79 @@ func medianGasPremium(prices []GasMeta, blocks int) abi.TokenAmount {
 	return premium
 }
 
+// quantileGasPremium returns the premium paid for the gas at quantile q of the
+// included gas, ordered by ascending premium
+func quantileGasPremium(prices []GasMeta, q float64) abi.TokenAmount {
+	sort.Slice(prices, func(i, j int) bool {
+		return prices[i].Price.LessThan(prices[j].Price)
+	})
+
+	var total int64
+	for _, price := range prices {
+		total += price.Limit
+	}
+
+	at := int64(math.Ceil(float64(total) * math.Min(q, 1)))
+	for _, price := range prices {
+		at -= price.Limit
+		if at <= 0 {
+			return price.Price
+		}
+	}
+
+	return big.Zero()
+}
+
+// oracleGasPremium samples the premiums of the messages included in the configured window of tipsets, the
+// premium is raised like the one of GasEstimateGasPremium for the messages to include within 1 or 2 blocks
+func (mp *MessagePool) oracleGasPremium(ctx context.Context, nblocksincl uint64, cache *GasPriceCache) (big.Int, error) {
+	var prices []GasMeta
+
+	ts, err := mp.api.ChainHead(ctx)
+	if err != nil {
+		return big.Int{}, err
+	}
+
+	for i := 0; i < mp.premiumOracle.Window; i++ {
+		if ts.Height() == 0 {
+			break // genesis
+		}
+
+		pts, err := mp.api.LoadTipSet(ctx, ts.Parents())
+		if err != nil {
+			return types.BigInt{}, err
+		}
+
+		meta, err := cache.GetTSGasStats(ctx, mp.api, pts)
+		if err != nil {
+			return types.BigInt{}, err
+		}
+		prices = append(prices, meta...)
+
+		ts = pts
+	}
+
+	premium := quantileGasPremium(prices, mp.premiumOracle.Quantile)
+	if minPremium := big.NewInt(mp.premiumOracle.MinPremium); premium.LessThan(minPremium) {
+		premium = minPremium
+	}
+
+	return scaleGasPremium(premium, nblocksincl), nil
+}
+
+// scaleGasPremium raises premium for the messages to include within nblocksincl blocks, by the factors
+// GasEstimateGasPremium applies to its floor
+func scaleGasPremium(premium big.Int, nblocksincl uint64) big.Int {
+	switch nblocksincl {
+	case 0, 1:
+		return big.Mul(premium, big.NewInt(2))
+	case 2:
+		return big.Div(big.Mul(premium, big.NewInt(3)), big.NewInt(2))
+	default:
+		return premium
+	}
+}
+
 func (mp *MessagePool) GasEstimateGasPremium(
 	ctx context.Context,
 	nblocksincl uint64,
@@ -130,6 +203,14 @@ func (mp *MessagePool) GasEstimateGasPremium(
 	_ types.TipSetKey,
 	cache *GasPriceCache,
 ) (big.Int, error) {
+	if mp.premiumOracle.Enable {
+		premium, err := mp.oracleGasPremium(ctx, nblocksincl, cache)
+		if err != nil {
+			return big.Int{}, err
+		}
+		return addPremiumNoise(premium), nil
+	}
+
 	if nblocksincl == 0 {
 		nblocksincl = 1
 	}
@@ -175,13 +256,16 @@ func (mp *MessagePool) GasEstimateGasPremium(
 		}
 	}
 
-	// add some noise to normalize behaviour of message selection
+	return addPremiumNoise(premium), nil
+}
+
+// addPremiumNoise adds some noise to normalize behaviour of message selection
+func addPremiumNoise(premium big.Int) big.Int {
 	const precision = 32
 	// mean 1, stddev 0.005 => 95% within +-1%
 	noise := 1 + rand.NormFloat64()*0.005
 	premium = types.BigMul(premium, types.NewInt(uint64(noise*(1<<precision))+1))
-	premium = types.BigDiv(premium, types.NewInt(1<<precision))
-	return premium, nil
+	return types.BigDiv(premium, types.NewInt(1<<precision))
 }
 
 func (mp *MessagePool) GasEstimateGasLimit(ctx context.Context, msgIn *types.Message, tsk types.TipSetKey) (int64, error) {
@@ -398,7 +482,9 @@ func (mp *MessagePool) GasEstimateMessageGas(ctx context.Context, estimateMessag
 		estimateMessage.Msg.GasFeeCap = feeCap
 	}
 
-	CapGasFee(mp.GetMaxFee, estimateMessage.Msg, estimateMessage.Spec)
+	if err := CapGasFee(mp.GetMaxFee, estimateMessage.Msg, estimateMessage.Spec); err != nil {
+		return nil, err
+	}
 
 	return estimateMessage.Msg, nil
 }
@@ -481,7 +567,14 @@ func (mp *MessagePool) GasBatchEstimateMessageGas(ctx context.Context, estimateM
 			estimateMsg.GasFeeCap = feeCap
 		}
 
-		CapGasFee(mp.GetMaxFee, estimateMsg, estimateMessage.Spec)
+		if err := CapGasFee(mp.GetMaxFee, estimateMsg, estimateMessage.Spec); err != nil {
+			estimateMsg.Nonce = 0
+			estimateResults = append(estimateResults, &types.EstimateResult{
+				Msg: estimateMsg,
+				Err: err.Error(),
+			})
+			continue
+		}
 
 		estimateResults = append(estimateResults, &types.EstimateResult{
 			Msg: estimateMsg,
@@ -2,6 +2,10 @@ package journal
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/filecoin-project/venus/pkg/repo"
 )
 
 // envJournalDisabledEvents is the environment variable through which disabled
@@ -17,3 +21,46 @@ func EnvDisabledEvents() DisabledEvents {
 	// fallback if env variable is not set, or if it failed to parse.
 	return DefaultDisabledEvents
 }
+
+// Open constructs the journal of the node configured by the journal config of the repo, the memory sink is nil
+// unless MemoryEvents is set.
+func Open(lr repo.Repo) (Journal, *MemorySink, error) {
+	cfg := lr.Config().Journal
+	if cfg == nil {
+		return NilJournal(), nil, nil
+	}
+
+	disabled := DefaultDisabledEvents
+	if _, ok := os.LookupEnv(envDisabledEvents); ok {
+		disabled = EnvDisabledEvents()
+	} else if len(cfg.DisabledEvents) == 0 {
+		disabled = DisabledEvents{}
+	} else {
+		var err error
+		if disabled, err = ParseDisabledEvents(strings.Join(cfg.DisabledEvents, ",")); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var sinks []Sink
+	if cfg.EnableFile {
+		path, err := lr.Path()
+		if err != nil {
+			return nil, nil, err
+		}
+		sink, err := NewFileSink(filepath.Join(path, "journal"), cfg.FileSizeLimit)
+		if err != nil {
+			return nil, nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	var memory *MemorySink
+	if cfg.MemoryEvents > 0 {
+		memory = NewMemorySink(cfg.MemoryEvents)
+		sinks = append(sinks, memory)
+	}
+	if len(sinks) == 0 {
+		return NilJournal(), nil, nil
+	}
+	return New(disabled, sinks...), memory, nil
+}
@@ -12,20 +12,13 @@ import (
 
 const RFC3339nocolon = "2006-01-02T150405Z0700"
 
-// fsJournal is a basic journal backed by files on a filesystem.
-type fsJournal struct {
-	EventTypeRegistry
-
+// fsSink is a basic journal sink backed by files on a filesystem.
+type fsSink struct {
 	dir       string
 	sizeLimit int64
 
 	fi    *os.File
 	fSize int64
-
-	incoming chan *Event
-
-	closing chan struct{}
-	closed  chan struct{}
 }
 
 // OpenFSJournal constructs a rolling filesystem journal, with a default
@@ -35,59 +28,33 @@ func OpenFSJournal(lr repo.Repo, disabled DisabledEvents) (Journal, error) {
 	if err != nil {
 		return nil, err
 	}
-	dir := filepath.Join(path, "journal")
+	sink, err := NewFileSink(filepath.Join(path, "journal"), 1<<30)
+	if err != nil {
+		return nil, err
+	}
+	return New(disabled, sink), nil
+}
+
+// NewFileSink constructs a sink writing the events as ndjson to the files of dir, a new file is started once
+// the current one is over sizeLimit.
+func NewFileSink(dir string, sizeLimit int64) (Sink, error) {
 	if err := os.MkdirAll(dir, 0o755); err != nil {
 		return nil, fmt.Errorf("failed to mk directory %s for file journal: %w", dir, err)
 	}
 
-	f := &fsJournal{
-		EventTypeRegistry: NewEventTypeRegistry(disabled),
-		dir:               dir,
-		sizeLimit:         1 << 30,
-		incoming:          make(chan *Event, 32),
-		closing:           make(chan struct{}),
-		closed:            make(chan struct{}),
+	f := &fsSink{
+		dir:       dir,
+		sizeLimit: sizeLimit,
 	}
 
 	if err := f.rollJournalFile(); err != nil {
 		return nil, err
 	}
 
-	go f.runLoop()
-
 	return f, nil
 }
 
-func (f *fsJournal) RecordEvent(evtType EventType, supplier func() interface{}) {
-	defer func() {
-		if r := recover(); r != nil {
-			log.Warnf("recovered from panic while recording journal event; type=%s, err=%v", evtType, r)
-		}
-	}()
-
-	if !evtType.Enabled() {
-		return
-	}
-
-	je := &Event{
-		EventType: evtType,
-		Timestamp: constants.Clock.Now(),
-		Data:      supplier(),
-	}
-	select {
-	case f.incoming <- je:
-	case <-f.closing:
-		log.Warnw("journal closed but tried to log event", "event", je)
-	}
-}
-
-func (f *fsJournal) Close() error {
-	close(f.closing)
-	<-f.closed
-	return nil
-}
-
-func (f *fsJournal) putEvent(evt *Event) error {
+func (f *fsSink) Put(evt *Event) error {
 	b, err := json.Marshal(evt)
 	if err != nil {
 		return err
@@ -106,7 +73,11 @@ func (f *fsJournal) putEvent(evt *Event) error {
 	return nil
 }
 
-func (f *fsJournal) rollJournalFile() error {
+func (f *fsSink) Close() error {
+	return f.fi.Close()
+}
+
+func (f *fsSink) rollJournalFile() error {
 	if f.fi != nil {
 		_ = f.fi.Close()
 	}
@@ -120,19 +91,3 @@ func (f *fsJournal) rollJournalFile() error {
 	f.fSize = 0
 	return nil
 }
-
-func (f *fsJournal) runLoop() {
-	defer close(f.closed)
-
-	for {
-		select {
-		case je := <-f.incoming:
-			if err := f.putEvent(je); err != nil {
-				log.Errorw("failed to write out journal event", "event", je, "err", err)
-			}
-		case <-f.closing:
-			_ = f.fi.Close()
-			return
-		}
-	}
-}
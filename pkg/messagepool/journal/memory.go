@@ -0,0 +1,88 @@
+package journal
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// MemorySink keeps the latest events in memory, so the support tooling can read them from the api of the node.
+type MemorySink struct {
+	lk sync.Mutex
+	// events is a ring, next is the position of the next event and of the oldest one once it is full
+	events []types.JournalEvent
+	next   int
+	full   bool
+}
+
+// NewMemorySink constructs a sink keeping the latest size events.
+func NewMemorySink(size int) *MemorySink {
+	return &MemorySink{events: make([]types.JournalEvent, size)}
+}
+
+func (m *MemorySink) Put(evt *Event) error {
+	// the payload is encoded now, it may be changed by its component later
+	data, err := json.Marshal(evt.Data)
+	if err != nil {
+		return err
+	}
+
+	m.lk.Lock()
+	defer m.lk.Unlock()
+
+	m.events[m.next] = types.JournalEvent{
+		System:    evt.System,
+		Event:     evt.Event,
+		Timestamp: evt.Timestamp,
+		Data:      data,
+	}
+	m.next++
+	if m.next == len(m.events) {
+		m.next = 0
+		m.full = true
+	}
+	return nil
+}
+
+func (m *MemorySink) Close() error {
+	return nil
+}
+
+// Events returns the events selected by filter, from the oldest one.
+func (m *MemorySink) Events(filter types.JournalFilter) []types.JournalEvent {
+	m.lk.Lock()
+	defer m.lk.Unlock()
+
+	ordered := m.events[:m.next]
+	if m.full {
+		ordered = append(append([]types.JournalEvent{}, m.events[m.next:]...), ordered...)
+	}
+
+	out := []types.JournalEvent{}
+	for _, evt := range ordered {
+		if evt.Timestamp.Before(filter.Since) || !matchTypes(filter.Types, evt) {
+			continue
+		}
+		out = append(out, evt)
+	}
+	if filter.Limit > 0 && len(out) > filter.Limit {
+		out = out[len(out)-filter.Limit:]
+	}
+	return out
+}
+
+// matchTypes matches the events whose system or "system:event" type is in selected, all of them when it is empty.
+func matchTypes(selected []string, evt types.JournalEvent) bool {
+	if len(selected) == 0 {
+		return true
+	}
+	for _, t := range selected {
+		system, event, ok := strings.Cut(t, ":")
+		if system == evt.System && (!ok || event == evt.Event) {
+			return true
+		}
+	}
+	return false
+}
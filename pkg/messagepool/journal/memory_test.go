@@ -0,0 +1,48 @@
+package journal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestMemorySink(t *testing.T) {
+	tf.UnitTest(t)
+
+	memory := NewMemorySink(3)
+	j := New(DisabledEvents{EventType{System: "mpool", Event: "add"}}, memory)
+	headChange := j.RegisterEventType("chain", "head_change")
+	syncError := j.RegisterEventType("sync", "error")
+	mpoolAdd := j.RegisterEventType("mpool", "add")
+
+	j.RecordEvent(mpoolAdd, func() interface{} { return 0 })
+	for i := 1; i <= 4; i++ {
+		i := i
+		j.RecordEvent(headChange, func() interface{} { return i })
+	}
+	j.RecordEvent(syncError, func() interface{} { return "failed" })
+	// the events are written out by a background goroutine
+	require.Eventually(t, func() bool {
+		evts := memory.Events(types.JournalFilter{})
+		return len(evts) == 3 && evts[2].System == "sync"
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, j.Close())
+
+	data := func(evts []types.JournalEvent) []string {
+		out := []string{}
+		for _, evt := range evts {
+			out = append(out, string(evt.Data))
+		}
+		return out
+	}
+	// the oldest events are dropped
+	require.Equal(t, []string{"3", "4", `"failed"`}, data(memory.Events(types.JournalFilter{})))
+	require.Equal(t, []string{"3", "4"}, data(memory.Events(types.JournalFilter{Types: []string{"chain"}})))
+	require.Equal(t, []string{"4"}, data(memory.Events(types.JournalFilter{Types: []string{"chain:head_change"}, Limit: 1})))
+	require.Equal(t, []string{`"failed"`}, data(memory.Events(types.JournalFilter{Types: []string{"sync:error", "mpool"}})))
+	require.Empty(t, memory.Events(types.JournalFilter{Since: time.Now().Add(time.Hour)}))
+}
@@ -0,0 +1,90 @@
+package journal
+
+import (
+	"github.com/filecoin-project/venus/pkg/constants"
+)
+
+// Sink receives the events recorded by a journal, one at a time in the order they are recorded.
+type Sink interface {
+	Put(evt *Event) error
+	Close() error
+}
+
+// sinkJournal writes the events to its sinks from a background goroutine, so the recording components don't wait
+// on the sinks.
+type sinkJournal struct {
+	EventTypeRegistry
+
+	sinks []Sink
+
+	incoming chan *Event
+
+	closing chan struct{}
+	closed  chan struct{}
+}
+
+// New constructs a journal writing the enabled events to sinks, the sinks are closed with the journal.
+func New(disabled DisabledEvents, sinks ...Sink) Journal {
+	j := &sinkJournal{
+		EventTypeRegistry: NewEventTypeRegistry(disabled),
+		sinks:             sinks,
+		incoming:          make(chan *Event, 32),
+		closing:           make(chan struct{}),
+		closed:            make(chan struct{}),
+	}
+
+	go j.runLoop()
+
+	return j
+}
+
+func (j *sinkJournal) RecordEvent(evtType EventType, supplier func() interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Warnf("recovered from panic while recording journal event; type=%s, err=%v", evtType, r)
+		}
+	}()
+
+	if !evtType.Enabled() {
+		return
+	}
+
+	je := &Event{
+		EventType: evtType,
+		Timestamp: constants.Clock.Now(),
+		Data:      supplier(),
+	}
+	select {
+	case j.incoming <- je:
+	case <-j.closing:
+		log.Warnw("journal closed but tried to log event", "event", je)
+	}
+}
+
+func (j *sinkJournal) Close() error {
+	close(j.closing)
+	<-j.closed
+	return nil
+}
+
+func (j *sinkJournal) runLoop() {
+	defer close(j.closed)
+
+	for {
+		select {
+		case je := <-j.incoming:
+			for _, sink := range j.sinks {
+				if err := sink.Put(je); err != nil {
+					log.Errorw("failed to write out journal event", "event", je, "err", err)
+				}
+			}
+		case <-j.closing:
+			for _, sink := range j.sinks {
+				if err := sink.Close(); err != nil {
+					log.Warnw("failed to close journal sink", "err", err)
+				}
+			}
+			return
+		}
+	}
+}
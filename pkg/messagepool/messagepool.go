@@ -94,6 +94,7 @@ var (
 	ErrTooManyPendingMessages = errors.New("too many pending messages for actor")
 	ErrNonceGap               = errors.New("unfulfilled nonce gap")
 	ErrExistingNonce          = errors.New("message with nonce already exists")
+	ErrMaxFeeExceeded         = errors.New("message fee exceeds the max fee")
 )
 
 const (
@@ -183,6 +184,10 @@ type MessagePool struct {
 
 	GetMaxFee  DefaultMaxFeeFunc
 	PriceCache *GasPriceCache
+
+	premiumOracle config.PremiumOracleConfig
+
+	admission *admissionPolicy
 }
 
 type stateNonceCacheKey struct {
@@ -222,7 +227,10 @@ func ComputeRBF(curPrem abi.TokenAmount, replaceByFeeRatio types.Percent) abi.To
 	return types.BigAdd(minPrice, types.NewInt(1))
 }
 
-func CapGasFee(mff DefaultMaxFeeFunc, msg *types.Message, sendSepc *types.MessageSendSpec) {
+// CapGasFee lowers the fee cap of msg so that GasLimit*GasFeeCap doesn't exceed the max fee of sendSepc, or the
+// default max fee, and the premium so that it doesn't exceed the fee cap. It fails instead of lowering the fee cap
+// when the spec sets MaxFeeStrict.
+func CapGasFee(mff DefaultMaxFeeFunc, msg *types.Message, sendSepc *types.MessageSendSpec) error {
 	var maxFee abi.TokenAmount
 	if sendSepc != nil {
 		maxFee = sendSepc.MaxFee
@@ -241,11 +249,15 @@ func CapGasFee(mff DefaultMaxFeeFunc, msg *types.Message, sendSepc *types.Messag
 
 	if totalFee.LessThanEqual(maxFee) {
 		msg.GasPremium = big.Min(msg.GasFeeCap, msg.GasPremium)
-		return
+		return nil
+	}
+	if sendSepc != nil && sendSepc.MaxFeeStrict {
+		return fmt.Errorf("%w: %s > %s", ErrMaxFeeExceeded, types.FIL(totalFee), types.FIL(maxFee))
 	}
 
 	msg.GasFeeCap = big.Div(maxFee, gl)
 	msg.GasPremium = big.Min(msg.GasFeeCap, msg.GasPremium) // cap premium at FeeCap
+	return nil
 }
 
 func (ms *msgSet) add(m *types.SignedMessage, mp *MessagePool, strict, untrusted bool) (bool, error) {
@@ -405,6 +417,9 @@ func New(ctx context.Context,
 	if err != nil {
 		return nil, fmt.Errorf("error loading mpool config: %v", err)
 	}
+	if err := mpoolCfg.PremiumOracle.Check(); err != nil {
+		return nil, err
+	}
 
 	if j == nil {
 		j = journal.NilJournal()
@@ -443,6 +458,8 @@ func New(ctx context.Context,
 		gasPriceSchedule: gas.NewPricesSchedule(networkParams.ForkUpgradeParam),
 		GetMaxFee:        newDefaultMaxFeeFunc(mpoolCfg.MaxFee),
 		PriceCache:       NewGasPriceCache(),
+		premiumOracle:    mpoolCfg.PremiumOracle,
+		admission:        newAdmissionPolicy(mpoolCfg.AdmissionPolicy),
 	}
 
 	// enable initial prunes
@@ -647,9 +664,10 @@ func (mp *MessagePool) PublishMsg(ctx context.Context, smsg *types.SignedMessage
 	return nil
 }
 
+// Close stops the message pool, the journal is closed by its owner.
 func (mp *MessagePool) Close() error {
 	close(mp.closer)
-	return mp.journal.Close()
+	return nil
 }
 
 func (mp *MessagePool) Prune() {
@@ -798,6 +816,11 @@ func (mp *MessagePool) Push(ctx context.Context, m *types.SignedMessage) (cid.Ci
 }
 
 func (mp *MessagePool) checkMessage(ctx context.Context, m *types.SignedMessage) error {
+	// operator admission rules go first, so denied messages are not worth validating
+	if err := mp.admission.check(ctx, &m.Message); err != nil {
+		return err
+	}
+
 	// big messages are bad, anti DOS
 	if m.ChainLength() > MaxMessageSize {
 		return fmt.Errorf("mpool message too large (%dB): %w", m.ChainLength(), ErrMessageTooBig)
@@ -828,6 +851,11 @@ func (mp *MessagePool) checkMessage(ctx context.Context, m *types.SignedMessage)
 	return nil
 }
 
+// AdmissionRejections returns the number of messages rejected by each admission policy rule
+func (mp *MessagePool) AdmissionRejections() map[string]uint64 {
+	return mp.admission.rejections()
+}
+
 func (mp *MessagePool) Add(ctx context.Context, m *types.SignedMessage) error {
 	err := mp.checkMessage(ctx, m)
 	if err != nil {
@@ -1299,6 +1327,14 @@ func (mp *MessagePool) Pending(ctx context.Context) ([]*types.SignedMessage, *ty
 	return mp.allPending(ctx)
 }
 
+// PendingCount returns the number of messages waiting in the pool.
+func (mp *MessagePool) PendingCount() int {
+	mp.lk.RLock()
+	defer mp.lk.RUnlock()
+
+	return mp.currentSize
+}
+
 func (mp *MessagePool) allPending(ctx context.Context) ([]*types.SignedMessage, *types.TipSet) {
 	out := make([]*types.SignedMessage, 0)
 	mp.forEachPending(func(a address.Address, mset *msgSet) {
@@ -1317,6 +1353,24 @@ func (mp *MessagePool) PendingFor(ctx context.Context, a address.Address) ([]*ty
 	return mp.pendingFor(ctx, a), mp.curTS
 }
 
+// PendingCountFor returns the number of pending messages of a, unlike PendingFor it fails when the
+// pending messages of a can't be looked up.
+func (mp *MessagePool) PendingCountFor(ctx context.Context, a address.Address) (int, error) {
+	mp.curTSLk.RLock()
+	defer mp.curTSLk.RUnlock()
+
+	mp.lk.RLock()
+	defer mp.lk.RUnlock()
+	mset, ok, err := mp.getPendingMset(ctx, a)
+	if err != nil {
+		return 0, err
+	}
+	if mset == nil || !ok {
+		return 0, nil
+	}
+	return len(mset.msgs), nil
+}
+
 func (mp *MessagePool) pendingFor(ctx context.Context, a address.Address) []*types.SignedMessage {
 	mset, ok, err := mp.getPendingMset(ctx, a)
 	if err != nil {
@@ -957,4 +957,98 @@ func TestCapGasFee(t *testing.T) {
 		assert.Equal(t, msg.GasFeeCap.Int64(), int64(100_000))
 		assert.Equal(t, msg.GasPremium.Int.Int64(), int64(100_000))
 	})
+
+	t.Run("reject fee over strict maxfee", func(t *testing.T) {
+		msg := &types.Message{
+			GasLimit:   100_000_000,
+			GasFeeCap:  abi.NewTokenAmount(100_000_000),
+			GasPremium: abi.NewTokenAmount(100_000),
+		}
+		spec := &types.MessageSendSpec{MaxFee: abi.NewTokenAmount(100_000_000_000), MaxFeeStrict: true}
+		assert.ErrorIs(t, CapGasFee(nil, msg, spec), ErrMaxFeeExceeded)
+		assert.Equal(t, msg.GasFeeCap.Int64(), int64(100_000_000))
+
+		spec.MaxFee = abi.NewTokenAmount(100_000_000 * 100_000_000)
+		assert.NoError(t, CapGasFee(nil, msg, spec))
+		assert.Equal(t, msg.GasFeeCap.Int64(), int64(100_000_000))
+	})
+}
+
+func TestQuantileGasPremium(t *testing.T) {
+	tf.UnitTest(t)
+
+	prices := []GasMeta{
+		{Price: tbig.NewInt(300), Limit: 10},
+		{Price: tbig.NewInt(100), Limit: 50},
+		{Price: tbig.NewInt(200), Limit: 40},
+	}
+
+	assert.Equal(t, tbig.NewInt(100), quantileGasPremium(prices, 0.5))
+	assert.Equal(t, tbig.NewInt(200), quantileGasPremium(prices, 0.6))
+	assert.Equal(t, tbig.NewInt(300), quantileGasPremium(prices, 0.95))
+	assert.Equal(t, tbig.NewInt(300), quantileGasPremium(prices, 2))
+	assert.Equal(t, tbig.Zero(), quantileGasPremium(nil, 0.5))
+}
+
+func TestAdmissionPolicy(t *testing.T) {
+	tf.UnitTest(t)
+
+	tma := newTestMpoolAPI()
+
+	w := newWallet(t)
+	sender, err := w.NewAddress(context.Background(), address.SECP256K1)
+	assert.NoError(t, err)
+	target, err := w.NewAddress(context.Background(), address.SECP256K1)
+	assert.NoError(t, err)
+	denied, err := w.NewAddress(context.Background(), address.SECP256K1)
+	assert.NoError(t, err)
+
+	// makeTestMessage always calls method 2
+	mpoolCfg := *config.DefaultMessagePoolParam
+	mpoolCfg.AdmissionPolicy = config.MpoolAdmissionPolicyConfig{
+		MaxGasLimit:      10_000_000,
+		MinGasPremium:    2,
+		DeniedRecipients: []address.Address{denied},
+		DeniedMethods:    []abi.MethodNum{2},
+	}
+	mp, err := New(context.Background(), tma, nil, datastore.NewMapDatastore(), config.NewDefaultConfig().NetworkParams, &mpoolCfg, "mptest", nil)
+	assert.NoError(t, err)
+
+	tma.setBalance(sender, 1) // in FIL
+	tma.setStateNonce(sender, 0)
+	ts := tma.nextBlock()
+	tma.applyBlock(t, ts)
+
+	gasLimit := gasguess.Costs[gasguess.CostKey{Code: builtin2.StorageMarketActorCodeID, M: 2}]
+
+	for _, m := range []*types.SignedMessage{
+		makeTestMessage(w, sender, target, 0, 10_000_001, 2),
+		makeTestMessage(w, sender, target, 0, gasLimit, 1),
+		makeTestMessage(w, sender, denied, 0, gasLimit, 2),
+		makeTestMessage(w, sender, target, 0, gasLimit, 2),
+	} {
+		assert.ErrorIs(t, mp.Add(context.TODO(), m), ErrAdmissionDenied)
+	}
+	assert.Equal(t, map[string]uint64{
+		AdmissionRuleMaxGasLimit:     1,
+		AdmissionRuleMinGasPremium:   1,
+		AdmissionRuleDeniedRecipient: 1,
+		AdmissionRuleDeniedMethod:    1,
+	}, mp.AdmissionRejections())
+
+	mpoolCfg.AdmissionPolicy.DeniedMethods = nil
+	mp.admission = newAdmissionPolicy(mpoolCfg.AdmissionPolicy)
+	mustAdd(t, mp, makeTestMessage(w, sender, target, 0, gasLimit, 2))
+	assert.Empty(t, mp.AdmissionRejections())
+}
+
+func TestScaleGasPremium(t *testing.T) {
+	tf.UnitTest(t)
+
+	premium := tbig.NewInt(100_000)
+	assert.Equal(t, int64(200_000), scaleGasPremium(premium, 0).Int64())
+	assert.Equal(t, int64(200_000), scaleGasPremium(premium, 1).Int64())
+	assert.Equal(t, int64(150_000), scaleGasPremium(premium, 2).Int64())
+	assert.Equal(t, int64(100_000), scaleGasPremium(premium, 3).Int64())
+	assert.Equal(t, int64(100_000), scaleGasPremium(premium, 10).Int64())
 }
@@ -9,10 +9,12 @@ import (
 	"time"
 
 	cbg "github.com/whyrusleeping/cbor-gen"
+	"go.opencensus.io/trace"
 
 	"github.com/filecoin-project/go-address"
 	tbig "github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/ipfs/go-cid"
 
 	"github.com/filecoin-project/venus/pkg/constants"
 	"github.com/filecoin-project/venus/pkg/messagepool/gasguess"
@@ -39,6 +41,10 @@ type msgChain struct {
 }
 
 func (mp *MessagePool) SelectMessages(ctx context.Context, ts *types.TipSet, tq float64) ([]*types.SignedMessage, error) {
+	ctx, span := trace.StartSpan(ctx, "MessagePool.SelectMessages")
+	span.AddAttributes(trace.Int64Attribute("height", int64(ts.Height())), trace.Float64Attribute("ticket_quality", tq))
+	defer span.End()
+
 	mp.curTSLk.RLock()
 	defer mp.curTSLk.RUnlock()
 
@@ -62,6 +68,7 @@ func (mp *MessagePool) SelectMessages(ctx context.Context, ts *types.TipSet, tq
 	if err != nil {
 		return nil, err
 	}
+	span.AddAttributes(trace.Int64Attribute("pending_senders", int64(len(pending))))
 	// if the ticket quality is high enough that the first block has higher probability
 	// than any other block, then we don't bother with optimal selection because the
 	// first block will always have higher effective performance
@@ -85,10 +92,71 @@ func (mp *MessagePool) SelectMessages(ctx context.Context, ts *types.TipSet, tq
 		log.Errorf("message selection chose too many messages %d > %d", len(sm.msgs), constants.BlockMessageLimit)
 		sm.msgs = sm.msgs[:constants.BlockMessageLimit]
 	}
+	span.AddAttributes(trace.Int64Attribute("selected", int64(len(sm.msgs))))
 
 	return sm.msgs, nil
 }
 
+// SelectMessagesWithDetail runs the same selection as SelectMessages, and reports for each selected message
+// the gas reward and performance it was ranked by and the message of the same sender it depends on.
+func (mp *MessagePool) SelectMessagesWithDetail(ctx context.Context, ts *types.TipSet, tq float64) (*types.MpoolSelection, error) {
+	msgs, err := mp.SelectMessages(ctx, ts, tq)
+	if err != nil {
+		return nil, err
+	}
+
+	baseFee, err := mp.api.ChainComputeBaseFee(ctx, ts)
+	if err != nil {
+		return nil, fmt.Errorf("computing basefee: %w", err)
+	}
+
+	priority := make(map[address.Address]struct{})
+	for _, actor := range mp.GetConfig().PriorityAddrs {
+		pk, err := mp.resolveToKey(ctx, actor)
+		if err != nil {
+			return nil, fmt.Errorf("resolving priority address %s: %w", actor, err)
+		}
+		priority[pk] = struct{}{}
+	}
+
+	type senderNonce struct {
+		from  address.Address
+		nonce uint64
+	}
+	selected := make(map[senderNonce]cid.Cid, len(msgs))
+	res := &types.MpoolSelection{
+		BaseFee:   baseFee,
+		GasReward: tbig.Zero(),
+		Messages:  make([]*types.MpoolSelectedMessage, 0, len(msgs)),
+	}
+	for _, msg := range msgs {
+		from, err := mp.resolveToKey(ctx, msg.Message.From)
+		if err != nil {
+			return nil, fmt.Errorf("resolving sender %s: %w", msg.Message.From, err)
+		}
+
+		gasReward := mp.getGasReward(msg, baseFee)
+		detail := &types.MpoolSelectedMessage{
+			Message:   msg,
+			GasReward: tbig.NewFromGo(gasReward),
+			GasPerf:   mp.getGasPerf(gasReward, msg.Message.GasLimit),
+		}
+		_, detail.Priority = priority[from]
+		if msg.Message.Nonce > 0 {
+			if dep, ok := selected[senderNonce{from: from, nonce: msg.Message.Nonce - 1}]; ok {
+				detail.DependsOn = &dep
+			}
+		}
+		selected[senderNonce{from: from, nonce: msg.Message.Nonce}] = msg.Cid()
+
+		res.GasLimit += msg.Message.GasLimit
+		res.GasReward = tbig.Add(res.GasReward, detail.GasReward)
+		res.Messages = append(res.Messages, detail)
+	}
+
+	return res, nil
+}
+
 type selectedMessages struct {
 	msgs      []*types.SignedMessage
 	gasLimit  int64
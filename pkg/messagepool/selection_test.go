@@ -813,6 +813,73 @@ func TestPriorityMessageSelection(t *testing.T) {
 	}
 }
 
+func TestMessageSelectionWithDetail(t *testing.T) {
+	tf.UnitTest(t)
+
+	mp, tma := makeTestMpool()
+
+	// the actors
+	w1 := newWallet(t)
+	a1, err := w1.NewAddress(context.Background(), address.SECP256K1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w2 := newWallet(t)
+	a2, err := w2.NewAddress(context.Background(), address.SECP256K1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	block := tma.nextBlock()
+	ts := mkTipSet(block)
+	tma.applyBlock(t, block)
+
+	gasLimit := gasguess.Costs[gasguess.CostKey{Code: builtin2.StorageMarketActorCodeID, M: 2}]
+
+	tma.setBalance(a1, 1) // in FIL
+	tma.setBalance(a2, 1) // in FIL
+
+	mp.cfg.PriorityAddrs = []address.Address{a1}
+
+	for i := 0; i < 3; i++ {
+		mustAdd(t, mp, makeTestMessage(w1, a1, a2, uint64(i), gasLimit, 1))
+		mustAdd(t, mp, makeTestMessage(w2, a2, a1, uint64(i), gasLimit, 2))
+	}
+
+	msgs, err := mp.SelectMessages(context.Background(), ts, 1.0)
+	require.NoError(t, err)
+
+	sel, err := mp.SelectMessagesWithDetail(context.Background(), ts, 1.0)
+	require.NoError(t, err)
+	require.Len(t, sel.Messages, len(msgs))
+	require.True(t, tma.baseFee.Equals(sel.BaseFee))
+	require.Equal(t, int64(len(msgs))*gasLimit, sel.GasLimit)
+
+	totalReward := tbig.Zero()
+	prev := make(map[address.Address]cid.Cid)
+	for i, detail := range sel.Messages {
+		m := detail.Message
+		require.Equal(t, msgs[i].Cid(), m.Cid())
+
+		// the base fee is covered by the fee cap, so the whole premium goes to the miner
+		expReward := tbig.Mul(m.Message.GasPremium, tbig.NewInt(gasLimit))
+		require.True(t, expReward.Equals(detail.GasReward))
+		require.Equal(t, mp.getGasPerf(expReward.Int, gasLimit), detail.GasPerf)
+		require.Equal(t, m.Message.From == a1, detail.Priority)
+
+		if m.Message.Nonce == 0 {
+			require.Nil(t, detail.DependsOn)
+		} else {
+			require.NotNil(t, detail.DependsOn)
+			require.Equal(t, prev[m.Message.From], *detail.DependsOn)
+		}
+		prev[m.Message.From] = m.Cid()
+		totalReward = tbig.Add(totalReward, detail.GasReward)
+	}
+	require.True(t, totalReward.Equals(sel.GasReward))
+}
+
 func TestPriorityMessageSelection2(t *testing.T) {
 	tf.UnitTest(t)
 
@@ -0,0 +1,117 @@
+package messagepool
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/filecoin-project/go-address"
+	tbig "github.com/filecoin-project/go-state-types/big"
+
+	"github.com/filecoin-project/venus/pkg/constants"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// statsPercentiles are the percentiles of the premiums reported by Stats
+var statsPercentiles = []int{10, 25, 50, 75, 90, 99}
+
+// Stats reports the pending messages and the premiums they pay above the base fee of the next block, and projects
+// the inclusion of a message paying each of premiums, or each of the premium percentiles when premiums is empty.
+func (mp *MessagePool) Stats(ctx context.Context, premiums []tbig.Int) (*types.MpoolStats, error) {
+	pending, ts := mp.Pending(ctx)
+	if ts == nil {
+		return nil, fmt.Errorf("the message pool has no head yet")
+	}
+	baseFee, err := mp.api.ChainComputeBaseFee(ctx, ts)
+	if err != nil {
+		return nil, fmt.Errorf("computing basefee: %w", err)
+	}
+
+	stats := newMpoolStats(pending, baseFee, premiums, constants.BlockGasTarget*constants.ExpectedLeadersPerEpoch)
+	stats.Height = ts.Height()
+	return stats, nil
+}
+
+// newMpoolStats computes the stats of the pending messages at baseFee, an epoch includes epochGas of the messages
+// paying the highest effective premiums.
+func newMpoolStats(pending []*types.SignedMessage, baseFee tbig.Int, premiums []tbig.Int, epochGas int64) *types.MpoolStats {
+	stats := &types.MpoolStats{
+		BaseFee:   baseFee,
+		Pending:   len(pending),
+		Senders:   []types.MpoolSenderStats{},
+		Premiums:  []types.MpoolPremiumPercentile{},
+		Inclusion: []types.MpoolInclusionEstimate{},
+	}
+
+	type pendingPremium struct {
+		premium  tbig.Int
+		gasLimit int64
+	}
+	paid := make([]pendingPremium, 0, len(pending))
+	senders := make(map[address.Address]*types.MpoolSenderStats)
+	for _, msg := range pending {
+		size := int64(msg.ChainLength())
+		stats.Bytes += size
+		stats.GasLimit += msg.Message.GasLimit
+
+		sender, ok := senders[msg.Message.From]
+		if !ok {
+			sender = &types.MpoolSenderStats{Sender: msg.Message.From}
+			senders[msg.Message.From] = sender
+		}
+		sender.Pending++
+		sender.Bytes += size
+		sender.GasLimit += msg.Message.GasLimit
+
+		premium := tbig.Sub(msg.Message.GasFeeCap, baseFee)
+		if premium.GreaterThan(msg.Message.GasPremium) {
+			premium = msg.Message.GasPremium
+		}
+		paid = append(paid, pendingPremium{premium: premium, gasLimit: msg.Message.GasLimit})
+	}
+
+	for _, sender := range senders {
+		stats.Senders = append(stats.Senders, *sender)
+	}
+	sort.Slice(stats.Senders, func(i, j int) bool {
+		if stats.Senders[i].Pending != stats.Senders[j].Pending {
+			return stats.Senders[i].Pending > stats.Senders[j].Pending
+		}
+		return stats.Senders[i].Sender.String() < stats.Senders[j].Sender.String()
+	})
+
+	sort.Slice(paid, func(i, j int) bool {
+		return paid[i].premium.LessThan(paid[j].premium)
+	})
+	if len(paid) > 0 {
+		for _, pct := range statsPercentiles {
+			stats.Premiums = append(stats.Premiums, types.MpoolPremiumPercentile{
+				Percentile: pct,
+				Premium:    paid[(len(paid)-1)*pct/100].premium,
+			})
+		}
+	}
+
+	if len(premiums) == 0 {
+		for _, pct := range stats.Premiums {
+			premiums = append(premiums, pct.Premium)
+		}
+	}
+	// gasAbove[i] is the gas limit of the messages from paid[i] on
+	gasAbove := make([]int64, len(paid)+1)
+	for i := len(paid) - 1; i >= 0; i-- {
+		gasAbove[i] = gasAbove[i+1] + paid[i].gasLimit
+	}
+	for _, premium := range premiums {
+		// the messages paying more are included first
+		above := sort.Search(len(paid), func(i int) bool {
+			return paid[i].premium.GreaterThan(premium)
+		})
+		stats.Inclusion = append(stats.Inclusion, types.MpoolInclusionEstimate{
+			Premium:  premium,
+			GasAhead: gasAbove[above],
+			Epochs:   gasAbove[above]/epochGas + 1,
+		})
+	}
+	return stats
+}
@@ -0,0 +1,88 @@
+package messagepool
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	tbig "github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestMpoolStats(t *testing.T) {
+	tf.UnitTest(t)
+
+	mkAddr := func(id uint64) address.Address {
+		addr, err := address.NewIDAddress(id)
+		require.NoError(t, err)
+		return addr
+	}
+	a1, a2, to := mkAddr(100), mkAddr(101), mkAddr(1000)
+	baseFee := tbig.NewInt(100)
+	msg := func(from address.Address, nonce uint64, feeCap, premium int64) *types.SignedMessage {
+		return &types.SignedMessage{
+			Message: types.Message{
+				From:       from,
+				To:         to,
+				Nonce:      nonce,
+				Value:      tbig.Zero(),
+				GasLimit:   1000,
+				GasFeeCap:  tbig.NewInt(feeCap),
+				GasPremium: tbig.NewInt(premium),
+			},
+			Signature: crypto.Signature{Type: crypto.SigTypeBLS},
+		}
+	}
+	pending := []*types.SignedMessage{
+		msg(a1, 0, 150, 10),
+		msg(a1, 1, 150, 20),
+		// the premium is capped by the fee cap above the base fee
+		msg(a1, 2, 130, 50),
+		msg(a2, 0, 200, 40),
+		// the fee cap is below the base fee
+		msg(a2, 1, 90, 40),
+	}
+
+	// an epoch includes two messages
+	stats := newMpoolStats(pending, baseFee, []tbig.Int{tbig.NewInt(5), tbig.NewInt(25), tbig.NewInt(40)}, 2000)
+	require.Equal(t, 5, stats.Pending)
+	require.Equal(t, int64(5000), stats.GasLimit)
+	var size int64
+	for _, m := range pending {
+		size += int64(m.ChainLength())
+	}
+	require.Equal(t, size, stats.Bytes)
+
+	require.Len(t, stats.Senders, 2)
+	require.Equal(t, a1, stats.Senders[0].Sender)
+	require.Equal(t, 3, stats.Senders[0].Pending)
+	require.Equal(t, int64(3000), stats.Senders[0].GasLimit)
+	require.Equal(t, a2, stats.Senders[1].Sender)
+	require.Equal(t, stats.Bytes, stats.Senders[0].Bytes+stats.Senders[1].Bytes)
+
+	// the effective premiums are -10, 10, 20, 30 and 40
+	require.Len(t, stats.Premiums, len(statsPercentiles))
+	require.Equal(t, types.MpoolPremiumPercentile{Percentile: 10, Premium: tbig.NewInt(-10)}, stats.Premiums[0])
+	require.Equal(t, types.MpoolPremiumPercentile{Percentile: 50, Premium: tbig.NewInt(20)}, stats.Premiums[2])
+	require.Equal(t, types.MpoolPremiumPercentile{Percentile: 99, Premium: tbig.NewInt(30)}, stats.Premiums[5])
+
+	require.Equal(t, []types.MpoolInclusionEstimate{
+		{Premium: tbig.NewInt(5), GasAhead: 4000, Epochs: 3},
+		{Premium: tbig.NewInt(25), GasAhead: 2000, Epochs: 2},
+		{Premium: tbig.NewInt(40), GasAhead: 0, Epochs: 1},
+	}, stats.Inclusion)
+
+	// without premiums, the inclusion is projected at the percentiles
+	stats = newMpoolStats(pending, baseFee, nil, 2000)
+	require.Len(t, stats.Inclusion, len(statsPercentiles))
+	require.Equal(t, stats.Premiums[2].Premium, stats.Inclusion[2].Premium)
+	require.Equal(t, int64(2000), stats.Inclusion[2].GasAhead)
+
+	stats = newMpoolStats(nil, baseFee, nil, 2000)
+	require.Empty(t, stats.Senders)
+	require.Empty(t, stats.Premiums)
+	require.Empty(t, stats.Inclusion)
+}
@@ -12,6 +12,7 @@ import (
 	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/otel/bridge/opencensus"
 	"go.opentelemetry.io/otel/exporters/jaeger"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/sdk/resource"
 	tracesdk "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
@@ -80,9 +81,39 @@ func SetupJaegerTracing(serviceName string, cfg *config.TraceConfig) (*tracesdk.
 	if err != nil {
 		return nil, err
 	}
+	return installTracerProvider(je, serviceName, cfg), nil
+}
+
+// SetupOTLPTracing setups an OTLP/HTTP exporter towards the configured collector
+// and names the tracer.
+func SetupOTLPTracing(ctx context.Context, serviceName string, cfg *config.TraceConfig) (*tracesdk.TracerProvider, error) {
+	if !cfg.OTLPTracingEnabled {
+		return nil, nil
+	}
+
+	if len(cfg.ServerName) != 0 {
+		serviceName = cfg.ServerName
+	}
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.OTLPEndpoint)}
+	if cfg.OTLPInsecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	if len(cfg.OTLPHeaders) > 0 {
+		opts = append(opts, otlptracehttp.WithHeaders(cfg.OTLPHeaders))
+	}
+	oe, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return installTracerProvider(oe, serviceName, cfg), nil
+}
+
+// installTracerProvider builds a tracer provider around the exporter and bridges the
+// opencensus spans used throughout venus to it.
+func installTracerProvider(exporter tracesdk.SpanExporter, serviceName string, cfg *config.TraceConfig) *tracesdk.TracerProvider {
 	tp := tracesdk.NewTracerProvider(
 		// Always be sure to batch in production.
-		tracesdk.WithBatcher(je),
+		tracesdk.WithBatcher(exporter),
 		// Record information about this application in an Resource.
 		tracesdk.WithResource(resource.NewWithAttributes(
 			semconv.SchemaURL,
@@ -91,12 +122,18 @@ func SetupJaegerTracing(serviceName string, cfg *config.TraceConfig) (*tracesdk.
 		tracesdk.WithSampler(tracesdk.TraceIDRatioBased(cfg.ProbabilitySampler)),
 	)
 	opencensus.InstallTraceBridge(opencensus.WithTracerProvider(tp))
-	return tp, nil
+	return tp
 }
 
+// ShutdownJaeger is kept for compatibility, use ShutdownTracing instead.
 func ShutdownJaeger(ctx context.Context, je *tracesdk.TracerProvider) error {
-	if err := je.ForceFlush(ctx); err != nil {
-		log.Warnf("failed to flush jaeger: %w", err)
+	return ShutdownTracing(ctx, je)
+}
+
+// ShutdownTracing flushes pending spans of the tracer provider and stops it.
+func ShutdownTracing(ctx context.Context, tp *tracesdk.TracerProvider) error {
+	if err := tp.ForceFlush(ctx); err != nil {
+		log.Warnf("failed to flush traces: %w", err)
 	}
-	return je.Shutdown(ctx)
+	return tp.Shutdown(ctx)
 }
@@ -0,0 +1,49 @@
+package metrics_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/trace"
+
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/pkg/metrics"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestSetupOTLPTracing(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	cfg := config.NewDefaultConfig().Observability.Tracing
+	tp, err := metrics.SetupOTLPTracing(ctx, "venus-test", cfg)
+	require.NoError(t, err)
+	assert.Nil(t, tp)
+
+	var exports int32
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/traces" {
+			atomic.AddInt32(&exports, 1)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer collector.Close()
+
+	cfg.OTLPTracingEnabled = true
+	cfg.OTLPEndpoint = strings.TrimPrefix(collector.URL, "http://")
+	tp, err = metrics.SetupOTLPTracing(ctx, "venus-test", cfg)
+	require.NoError(t, err)
+	require.NotNil(t, tp)
+
+	_, span := trace.StartSpan(ctx, "test.span")
+	span.End()
+
+	require.NoError(t, metrics.ShutdownTracing(ctx, tp))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&exports))
+}
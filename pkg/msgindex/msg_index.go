@@ -0,0 +1,294 @@
+package msgindex
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+var pragmas = []string{
+	"PRAGMA synchronous = normal",
+	"PRAGMA temp_store = memory",
+	"PRAGMA mmap_size = 30000000000",
+	"PRAGMA page_size = 32768",
+	"PRAGMA auto_vacuum = NONE",
+	"PRAGMA automatic_index = OFF",
+	"PRAGMA journal_mode = WAL",
+	"PRAGMA read_uncommitted = ON",
+}
+
+var ddls = []string{
+	`CREATE TABLE IF NOT EXISTS tipsets (
+		tipset TEXT PRIMARY KEY NOT NULL,
+		height INTEGER NOT NULL
+	)`,
+
+	`CREATE INDEX IF NOT EXISTS tipset_height_index ON tipsets (height)`,
+
+	`CREATE TABLE IF NOT EXISTS messages (
+		tipset TEXT NOT NULL,
+		idx INTEGER NOT NULL,
+		cid TEXT NOT NULL,
+		height INTEGER NOT NULL,
+		sender TEXT NOT NULL,
+		recipient TEXT NOT NULL,
+		to_type TEXT NOT NULL,
+		method INTEGER NOT NULL,
+		PRIMARY KEY (tipset, idx)
+	)`,
+
+	`CREATE INDEX IF NOT EXISTS to_type_index ON messages (to_type, method, height)`,
+
+	`CREATE INDEX IF NOT EXISTS method_index ON messages (method, height)`,
+
+	`CREATE INDEX IF NOT EXISTS recipient_index ON messages (recipient, height)`,
+
+	`CREATE INDEX IF NOT EXISTS sender_index ON messages (sender, height)`,
+
+	// metadata containing version of schema
+	`CREATE TABLE IF NOT EXISTS _meta (
+    	version UINT64 NOT NULL UNIQUE
+	)`,
+
+	// version 1.
+	`INSERT OR IGNORE INTO _meta (version) VALUES (1)`,
+}
+
+const schemaVersion = 1
+
+const (
+	insertMessage = `INSERT INTO messages
+	(tipset, idx, cid, height, sender, recipient, to_type, method)
+	VALUES(?, ?, ?, ?, ?, ?, ?, ?)`
+
+	selectMessages = `SELECT tipset, cid, height, sender, recipient, to_type, method FROM messages`
+)
+
+// Message is a message of a tipset in the index.
+type Message struct {
+	Cid cid.Cid
+	// TipSet is the cid of the key of the tipset including the message
+	TipSet cid.Cid
+	Height abi.ChainEpoch
+	From   address.Address
+	To     address.Address
+	// ToActorType is the type of the recipient in the parent state of the tipset, eg. storagemarket, it is empty
+	// when the recipient is created by the tipset
+	ToActorType string
+	Method      abi.MethodNum
+}
+
+// MsgIndex indexes the messages of the tipsets by recipient actor type and method, by recipient and by sender.
+type MsgIndex struct {
+	db *sql.DB
+}
+
+// IndexTipSet replaces the messages of the tipset of key tipset with msgs, in the order of the tipset.
+func (mi *MsgIndex) IndexTipSet(tipset cid.Cid, height abi.ChainEpoch, msgs []Message) error {
+	tx, err := mi.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	// rollback is a no-op once the transaction is committed
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err := tx.Exec("DELETE FROM messages WHERE tipset = ?", tipset.String()); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("INSERT OR REPLACE INTO tipsets (tipset, height) VALUES(?, ?)", tipset.String(), int64(height)); err != nil {
+		return err
+	}
+	stmt, err := tx.Prepare(insertMessage)
+	if err != nil {
+		return fmt.Errorf("prepare insert message: %w", err)
+	}
+	for i, msg := range msgs {
+		if msg.TipSet != tipset {
+			return fmt.Errorf("message %s is in tipset %s, not %s", msg.Cid, msg.TipSet, tipset)
+		}
+		_, err := stmt.Exec(tipset.String(), i, msg.Cid.String(), int64(height), msg.From.String(), msg.To.String(),
+			msg.ToActorType, int64(msg.Method))
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// RemoveTipSet removes the messages of the tipset of key tipset, when it is reverted.
+func (mi *MsgIndex) RemoveTipSet(tipset cid.Cid) error {
+	tx, err := mi.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	// rollback is a no-op once the transaction is committed
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err := tx.Exec("DELETE FROM messages WHERE tipset = ?", tipset.String()); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM tipsets WHERE tipset = ?", tipset.String()); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// HasTipSet tells whether the tipset of key tipset is indexed.
+func (mi *MsgIndex) HasTipSet(tipset cid.Cid) (bool, error) {
+	var exists bool
+	if err := mi.db.QueryRow("SELECT EXISTS(SELECT 1 FROM tipsets WHERE tipset = ?)", tipset.String()).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// IndexedFrom returns the height of the first tipset indexed, false when no tipset is indexed. The tipsets are
+// indexed from the time the index is enabled, the messages below that height are not in the index.
+func (mi *MsgIndex) IndexedFrom() (abi.ChainEpoch, bool, error) {
+	var height sql.NullInt64
+	if err := mi.db.QueryRow("SELECT MIN(height) FROM tipsets").Scan(&height); err != nil {
+		return 0, false, err
+	}
+	return abi.ChainEpoch(height.Int64), height.Valid, nil
+}
+
+// ListMessages returns the messages matching match with a height in [from, to], from the highest, in the order
+// of their tipsets. The addresses of match are compared as they are in the messages.
+func (mi *MsgIndex) ListMessages(match *types.MessageMatch, from, to abi.ChainEpoch) ([]Message, error) {
+	conds := []string{"height >= ?", "height <= ?"}
+	args := []interface{}{int64(from), int64(to)}
+	if match.ToActorType != "" {
+		conds = append(conds, "to_type = ?")
+		args = append(args, match.ToActorType)
+	}
+	if match.Method != nil {
+		conds = append(conds, "method = ?")
+		args = append(args, int64(*match.Method))
+	}
+	if match.To != address.Undef {
+		conds = append(conds, "recipient = ?")
+		args = append(args, match.To.String())
+	}
+	if match.From != address.Undef {
+		conds = append(conds, "sender = ?")
+		args = append(args, match.From.String())
+	}
+
+	rows, err := mi.db.Query(selectMessages+" WHERE "+strings.Join(conds, " AND ")+" ORDER BY height DESC, tipset, idx", args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanMessages(rows)
+}
+
+func scanMessages(rows *sql.Rows) ([]Message, error) {
+	defer rows.Close() //nolint:errcheck
+
+	var out []Message
+	for rows.Next() {
+		var (
+			tipset, msgCid, sender, recipient, toType string
+			height, method                            int64
+		)
+		if err := rows.Scan(&tipset, &msgCid, &height, &sender, &recipient, &toType, &method); err != nil {
+			return nil, err
+		}
+
+		msg := Message{
+			Height:      abi.ChainEpoch(height),
+			ToActorType: toType,
+			Method:      abi.MethodNum(method),
+		}
+		var err error
+		if msg.TipSet, err = cid.Decode(tipset); err != nil {
+			return nil, err
+		}
+		if msg.Cid, err = cid.Decode(msgCid); err != nil {
+			return nil, err
+		}
+		if msg.From, err = address.NewFromString(sender); err != nil {
+			return nil, err
+		}
+		if msg.To, err = address.NewFromString(recipient); err != nil {
+			return nil, err
+		}
+		out = append(out, msg)
+	}
+	return out, rows.Err()
+}
+
+func NewMsgIndex(path string) (*MsgIndex, error) {
+	db, err := sql.Open("sqlite3", path+"?mode=rwc")
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite3 database: %w", err)
+	}
+
+	for _, pragma := range pragmas {
+		if _, err := db.Exec(pragma); err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("exec pragma %q: %w", pragma, err)
+		}
+	}
+
+	q, err := db.Query("SELECT name FROM sqlite_master WHERE type='table' AND name='_meta';")
+	if err == sql.ErrNoRows || (err == nil && !q.Next()) {
+		if q != nil {
+			_ = q.Close()
+		}
+		// empty database, create the schema
+		for _, ddl := range ddls {
+			if _, err := db.Exec(ddl); err != nil {
+				_ = db.Close()
+				return nil, fmt.Errorf("exec ddl %q: %w", ddl, err)
+			}
+		}
+	} else if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("looking for _meta table: %w", err)
+	} else {
+		_ = q.Close()
+		// Ensure we don't open a database from a different schema version
+
+		row := db.QueryRow("SELECT max(version) FROM _meta")
+		var version int
+		err := row.Scan(&version)
+		if err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("invalid database version: no version found")
+		}
+		if version != schemaVersion {
+			_ = db.Close()
+			return nil, fmt.Errorf("invalid database version: got %d, expected %d", version, schemaVersion)
+		}
+	}
+
+	return &MsgIndex{
+		db: db,
+	}, nil
+}
+
+// Vacuum rebuilds the database to reclaim the space of the deleted rows, then truncates its WAL.
+func (mi *MsgIndex) Vacuum(ctx context.Context) error {
+	if _, err := mi.db.ExecContext(ctx, "VACUUM"); err != nil {
+		return fmt.Errorf("vacuum message index: %w", err)
+	}
+	if _, err := mi.db.ExecContext(ctx, "PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		return fmt.Errorf("checkpoint message index: %w", err)
+	}
+	return nil
+}
+
+func (mi *MsgIndex) Close() error {
+	if mi.db == nil {
+		return nil
+	}
+	return mi.db.Close()
+}
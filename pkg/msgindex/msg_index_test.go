@@ -0,0 +1,96 @@
+package msgindex
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestMsgIndex(t *testing.T) {
+	tf.UnitTest(t)
+
+	mi, err := NewMsgIndex(filepath.Join(t.TempDir(), "messages.db"))
+	require.NoError(t, err)
+	defer mi.Close() //nolint:errcheck
+
+	_, ok, err := mi.IndexedFrom()
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	mkCid := func(s string) cid.Cid {
+		c, err := abi.CidBuilder.Sum([]byte(s))
+		require.NoError(t, err)
+		return c
+	}
+	mkAddr := func(id uint64) address.Address {
+		addr, err := address.NewIDAddress(id)
+		require.NoError(t, err)
+		return addr
+	}
+	market, miner, sender := mkAddr(5), mkAddr(1000), mkAddr(100)
+	ts1, ts2 := mkCid("ts1"), mkCid("ts2")
+	msg := func(name string, ts cid.Cid, height abi.ChainEpoch, to address.Address, toType string, method abi.MethodNum) Message {
+		return Message{Cid: mkCid(name), TipSet: ts, Height: height, From: sender, To: to, ToActorType: toType, Method: method}
+	}
+
+	first := []Message{
+		msg("publish1", ts1, 10, market, "storagemarket", 4),
+		msg("submit", ts1, 10, miner, "storageminer", 5),
+		msg("publish2", ts1, 10, market, "storagemarket", 4),
+	}
+	second := []Message{msg("publish3", ts2, 11, market, "storagemarket", 4)}
+	require.NoError(t, mi.IndexTipSet(ts1, 10, first))
+	require.NoError(t, mi.IndexTipSet(ts2, 11, second))
+	require.Error(t, mi.IndexTipSet(ts1, 10, second))
+
+	from, ok, err := mi.IndexedFrom()
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, abi.ChainEpoch(10), from)
+	has, err := mi.HasTipSet(ts2)
+	require.NoError(t, err)
+	require.True(t, has)
+
+	// from the highest, in the order of the tipsets
+	publish := abi.MethodNum(4)
+	list, err := mi.ListMessages(&types.MessageMatch{ToActorType: "storagemarket", Method: &publish}, 0, 20)
+	require.NoError(t, err)
+	require.Equal(t, []Message{second[0], first[0], first[2]}, list)
+
+	list, err = mi.ListMessages(&types.MessageMatch{ToActorType: "storagemarket"}, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, []Message{first[0], first[2]}, list)
+
+	list, err = mi.ListMessages(&types.MessageMatch{To: miner, From: sender}, 0, 20)
+	require.NoError(t, err)
+	require.Equal(t, []Message{first[1]}, list)
+
+	list, err = mi.ListMessages(&types.MessageMatch{From: market}, 0, 20)
+	require.NoError(t, err)
+	require.Empty(t, list)
+
+	// reverting a tipset removes its messages
+	require.NoError(t, mi.RemoveTipSet(ts1))
+	list, err = mi.ListMessages(&types.MessageMatch{From: sender}, 0, 20)
+	require.NoError(t, err)
+	require.Equal(t, second, list)
+	from, _, err = mi.IndexedFrom()
+	require.NoError(t, err)
+	require.Equal(t, abi.ChainEpoch(11), from)
+	has, err = mi.HasTipSet(ts1)
+	require.NoError(t, err)
+	require.False(t, has)
+
+	// indexing a tipset again replaces its messages
+	require.NoError(t, mi.IndexTipSet(ts2, 11, nil))
+	list, err = mi.ListMessages(&types.MessageMatch{From: sender}, 0, 20)
+	require.NoError(t, err)
+	require.Empty(t, list)
+}
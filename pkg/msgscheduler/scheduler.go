@@ -0,0 +1,246 @@
+// Package msgscheduler holds signed messages until their trigger fires, a height, a base fee or another message
+// landing on chain, then pushes them to the message pool.
+package msgscheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+	"github.com/ipfs/go-datastore/query"
+	logging "github.com/ipfs/go-log/v2"
+
+	"github.com/filecoin-project/venus/venus-shared/actors/policy"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+var log = logging.Logger("msgscheduler")
+
+var ErrNotFound = errors.New("scheduled message not found")
+
+var scheduledPrefix = datastore.NewKey("/mpool/scheduled")
+
+// ChainAPI is the part of the chain api the scheduler checks the triggers with.
+type ChainAPI interface {
+	ChainHead(ctx context.Context) (*types.TipSet, error)
+	StateSearchMsg(ctx context.Context, from types.TipSetKey, msg cid.Cid, limit abi.ChainEpoch, allowReplaced bool) (*types.MsgLookup, error)
+}
+
+// PushFunc pushes a message whose trigger fired to the message pool.
+type PushFunc func(ctx context.Context, smsg *types.SignedMessage) (cid.Cid, error)
+
+// Scheduler holds the scheduled messages, they are stored in a datastore to survive restarts.
+type Scheduler struct {
+	chain ChainAPI
+	push  PushFunc
+	ds    datastore.Datastore
+
+	lk   sync.Mutex
+	msgs map[cid.Cid]*types.ScheduledMessage
+
+	notify chan struct{}
+}
+
+// New loads the scheduled messages stored in ds.
+func New(ctx context.Context, ds datastore.Datastore, chain ChainAPI, push PushFunc) (*Scheduler, error) {
+	s := &Scheduler{
+		chain:  chain,
+		push:   push,
+		ds:     namespace.Wrap(ds, scheduledPrefix),
+		msgs:   make(map[cid.Cid]*types.ScheduledMessage),
+		notify: make(chan struct{}, 1),
+	}
+
+	res, err := s.ds.Query(ctx, query.Query{})
+	if err != nil {
+		return nil, err
+	}
+	entries, err := res.Rest()
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		var sm types.ScheduledMessage
+		if err := json.Unmarshal(e.Value, &sm); err != nil {
+			return nil, fmt.Errorf("scheduled message %s: %w", e.Key, err)
+		}
+		s.msgs[sm.Message.Cid()] = &sm
+	}
+	return s, nil
+}
+
+// Schedule holds smsg until trigger fires, it returns the cid of smsg which identifies the scheduled message.
+func (s *Scheduler) Schedule(ctx context.Context, smsg *types.SignedMessage, trigger types.ScheduleTrigger) (cid.Cid, error) {
+	if smsg == nil {
+		return cid.Undef, errors.New("missing message")
+	}
+	if trigger.Epoch <= 0 && (trigger.MaxBaseFee.Int == nil || trigger.MaxBaseFee.IsZero()) && trigger.After == nil {
+		return cid.Undef, errors.New("the trigger has no condition, push the message instead")
+	}
+	head, err := s.chain.ChainHead(ctx)
+	if err != nil {
+		return cid.Undef, err
+	}
+
+	c := smsg.Cid()
+	sm := &types.ScheduledMessage{
+		Message: smsg,
+		Trigger: trigger,
+		Height:  head.Height(),
+		Created: time.Now(),
+	}
+
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	if _, ok := s.msgs[c]; ok {
+		return cid.Undef, fmt.Errorf("message %s is already scheduled", c)
+	}
+	if err := s.save(ctx, c, sm); err != nil {
+		return cid.Undef, err
+	}
+	s.msgs[c] = sm
+	s.HeadChanged()
+	return c, nil
+}
+
+// List returns the scheduled messages, in the order they were scheduled.
+func (s *Scheduler) List() []*types.ScheduledMessage {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	out := make([]*types.ScheduledMessage, 0, len(s.msgs))
+	for _, sm := range s.msgs {
+		cp := *sm
+		out = append(out, &cp)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Created.Before(out[j].Created)
+	})
+	return out
+}
+
+// Cancel drops the scheduled message c.
+func (s *Scheduler) Cancel(ctx context.Context, c cid.Cid) error {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	if _, ok := s.msgs[c]; !ok {
+		return fmt.Errorf("%w: %s", ErrNotFound, c)
+	}
+	if err := s.ds.Delete(ctx, datastore.NewKey(c.String())); err != nil {
+		return err
+	}
+	delete(s.msgs, c)
+	return nil
+}
+
+// HeadChanged wakes the scheduler up to check the triggers against the new head, it never blocks.
+func (s *Scheduler) HeadChanged() {
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Run checks the triggers each time the head changes until ctx is done.
+func (s *Scheduler) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.notify:
+		}
+		if err := s.process(ctx); err != nil {
+			log.Errorf("failed to process the scheduled messages: %s", err)
+		}
+	}
+}
+
+func (s *Scheduler) process(ctx context.Context) error {
+	head, err := s.chain.ChainHead(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, sm := range s.List() {
+		if sm.Error != "" {
+			continue
+		}
+		c := sm.Message.Cid()
+		fired, err := s.fired(ctx, head, sm)
+		if err != nil {
+			log.Warnf("failed to check the trigger of scheduled message %s: %s", c, err)
+			continue
+		}
+		if !fired {
+			continue
+		}
+
+		_, err = s.push(ctx, sm.Message)
+		if err := s.done(ctx, c, err); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fired tells whether the trigger of sm is met at head.
+func (s *Scheduler) fired(ctx context.Context, head *types.TipSet, sm *types.ScheduledMessage) (bool, error) {
+	t := sm.Trigger
+	if head.Height() < t.Epoch {
+		return false, nil
+	}
+	if t.MaxBaseFee.Int != nil && !t.MaxBaseFee.IsZero() && head.Blocks()[0].ParentBaseFee.GreaterThan(t.MaxBaseFee) {
+		return false, nil
+	}
+	if t.After != nil {
+		// look back to the height the message was scheduled at, and a finality before for the messages that
+		// landed just before
+		limit := head.Height() - sm.Height + policy.ChainFinality
+		lookup, err := s.chain.StateSearchMsg(ctx, head.Key(), *t.After, limit, true)
+		if err != nil {
+			return false, err
+		}
+		if lookup == nil {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// done drops the scheduled message c once it is pushed, or keeps it with the error pushing it.
+func (s *Scheduler) done(ctx context.Context, c cid.Cid, pushErr error) error {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	sm, ok := s.msgs[c]
+	if !ok {
+		// canceled while it was pushed
+		return nil
+	}
+	if pushErr == nil {
+		log.Infof("pushed scheduled message %s", c)
+		delete(s.msgs, c)
+		return s.ds.Delete(ctx, datastore.NewKey(c.String()))
+	}
+
+	log.Warnf("failed to push scheduled message %s: %s", c, pushErr)
+	sm.Error = pushErr.Error()
+	return s.save(ctx, c, sm)
+}
+
+func (s *Scheduler) save(ctx context.Context, c cid.Cid, sm *types.ScheduledMessage) error {
+	b, err := json.Marshal(sm)
+	if err != nil {
+		return err
+	}
+	return s.ds.Put(ctx, datastore.NewKey(c.String()), b)
+}
@@ -0,0 +1,119 @@
+package msgscheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/testutil"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+type fakeChain struct {
+	t      *testing.T
+	head   *types.TipSet
+	landed map[cid.Cid]bool
+}
+
+func (c *fakeChain) setHead(height abi.ChainEpoch, baseFee int64) {
+	var bh types.BlockHeader
+	testutil.Provide(c.t, &bh, testutil.IntRangedProvider(0, 1<<48))
+	bh.Height = height
+	bh.ParentBaseFee = abi.NewTokenAmount(baseFee)
+	ts, err := types.NewTipSet([]*types.BlockHeader{&bh})
+	require.NoError(c.t, err)
+	c.head = ts
+}
+
+func (c *fakeChain) ChainHead(ctx context.Context) (*types.TipSet, error) {
+	return c.head, nil
+}
+
+func (c *fakeChain) StateSearchMsg(ctx context.Context, from types.TipSetKey, msg cid.Cid, limit abi.ChainEpoch, allowReplaced bool) (*types.MsgLookup, error) {
+	if c.landed[msg] {
+		return &types.MsgLookup{Message: msg}, nil
+	}
+	return nil, nil
+}
+
+func TestScheduler(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	chain := &fakeChain{t: t, landed: make(map[cid.Cid]bool)}
+	chain.setHead(10, 100)
+
+	var pushed []cid.Cid
+	var pushErr error
+	push := func(ctx context.Context, smsg *types.SignedMessage) (cid.Cid, error) {
+		if pushErr != nil {
+			return cid.Undef, pushErr
+		}
+		pushed = append(pushed, smsg.Cid())
+		return smsg.Cid(), nil
+	}
+
+	ds := dssync.MutexWrap(datastore.NewMapDatastore())
+	s, err := New(ctx, ds, chain, push)
+	require.NoError(t, err)
+
+	var msgs [4]types.SignedMessage
+	testutil.Provide(t, &msgs)
+	after := msgs[3].Cid()
+
+	_, err = s.Schedule(ctx, &msgs[0], types.ScheduleTrigger{})
+	require.Error(t, err)
+	atEpoch, err := s.Schedule(ctx, &msgs[0], types.ScheduleTrigger{Epoch: 20})
+	require.NoError(t, err)
+	cheap, err := s.Schedule(ctx, &msgs[1], types.ScheduleTrigger{MaxBaseFee: abi.NewTokenAmount(50)})
+	require.NoError(t, err)
+	following, err := s.Schedule(ctx, &msgs[2], types.ScheduleTrigger{After: &after})
+	require.NoError(t, err)
+	_, err = s.Schedule(ctx, &msgs[2], types.ScheduleTrigger{Epoch: 1})
+	require.Error(t, err)
+	require.Len(t, s.List(), 3)
+
+	// nothing fired yet
+	require.NoError(t, s.process(ctx))
+	require.Empty(t, pushed)
+
+	// the scheduled messages are kept across restarts
+	s, err = New(ctx, ds, chain, push)
+	require.NoError(t, err)
+	require.Len(t, s.List(), 3)
+
+	chain.setHead(20, 100)
+	require.NoError(t, s.process(ctx))
+	require.Equal(t, []cid.Cid{atEpoch}, pushed)
+
+	chain.setHead(21, 50)
+	require.NoError(t, s.process(ctx))
+	require.Equal(t, []cid.Cid{atEpoch, cheap}, pushed)
+
+	// a failed push is kept with its error and not retried
+	chain.landed[after] = true
+	pushErr = errors.New("nonce too low")
+	require.NoError(t, s.process(ctx))
+	list := s.List()
+	require.Len(t, list, 1)
+	require.Equal(t, following, list[0].Message.Cid())
+	require.Equal(t, "nonce too low", list[0].Error)
+	pushErr = nil
+	require.NoError(t, s.process(ctx))
+	require.Len(t, pushed, 2)
+
+	require.NoError(t, s.Cancel(ctx, following))
+	require.Empty(t, s.List())
+	require.ErrorIs(t, s.Cancel(ctx, following), ErrNotFound)
+
+	s, err = New(ctx, ds, chain, push)
+	require.NoError(t, err)
+	require.Empty(t, s.List())
+}
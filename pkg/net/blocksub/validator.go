@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 
+	"github.com/ipfs/go-cid"
 	"github.com/ipfs/go-log/v2"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -17,35 +18,69 @@ var (
 	mDecodeBlkFail   = metrics.NewCounter("net/pubsub_block_decode_failure", "Number of blocks that fail to decode seen on block pubsub channel")
 )
 
+// RejectDecode is the reason recorded for payloads which are not a block.
+const RejectDecode = "decode"
+
 // BlockTopicValidator may be registered on go-libp2p-pubsub to validate blocksub messages.
 type BlockTopicValidator struct {
 	validator pubsub.ValidatorEx
 	opts      []pubsub.ValidatorOpt
+	recorder  RejectRecorder
 }
 
 type BlockHeaderValidator interface {
 	ValidateBlockMsg(context.Context, *types.BlockMsg) pubsub.ValidationResult
 }
 
+// ReasonedBlockValidator is implemented by validators able to tell why a block is not accepted.
+type ReasonedBlockValidator interface {
+	ValidateBlockMsgWithReason(context.Context, *types.BlockMsg) (pubsub.ValidationResult, string, error)
+}
+
+// RejectRecorder records the blocks rejected by the validator.
+type RejectRecorder interface {
+	Reject(ctx context.Context, kind types.QuarantineKind, c cid.Cid, from peer.ID, reason string, err error, data []byte)
+}
+
 // NewBlockTopicValidator returns a BlockTopicValidator using `bv` for message validation
 func NewBlockTopicValidator(bv BlockHeaderValidator, opts ...pubsub.ValidatorOpt) *BlockTopicValidator {
-	return &BlockTopicValidator{
-		opts: opts,
-		validator: func(ctx context.Context, p peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
-			var bm types.BlockMsg
-			err := bm.UnmarshalCBOR(bytes.NewReader(msg.GetData()))
-			if err != nil {
-				blockTopicLogger.Warnf("failed to decode blocksub payload from peer %s: %s", p.String(), err.Error())
-				mDecodeBlkFail.Tick(ctx)
-				return pubsub.ValidationIgnore
-			}
+	btv := &BlockTopicValidator{opts: opts}
+	btv.validator = func(ctx context.Context, p peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
+		var bm types.BlockMsg
+		err := bm.UnmarshalCBOR(bytes.NewReader(msg.GetData()))
+		if err != nil {
+			blockTopicLogger.Warnf("failed to decode blocksub payload from peer %s: %s", p.String(), err.Error())
+			mDecodeBlkFail.Tick(ctx)
+			btv.reject(ctx, cid.Undef, msg, RejectDecode, err)
+			return pubsub.ValidationIgnore
+		}
 
-			validateResult := bv.ValidateBlockMsg(ctx, &bm)
-			if validateResult == pubsub.ValidationAccept {
-				msg.ValidatorData = bm
+		var validateResult pubsub.ValidationResult
+		if rbv, ok := bv.(ReasonedBlockValidator); ok {
+			var reason string
+			validateResult, reason, err = rbv.ValidateBlockMsgWithReason(ctx, &bm)
+			if validateResult == pubsub.ValidationReject {
+				btv.reject(ctx, bm.Cid(), msg, reason, err)
 			}
-			return validateResult
-		},
+		} else {
+			validateResult = bv.ValidateBlockMsg(ctx, &bm)
+		}
+		if validateResult == pubsub.ValidationAccept {
+			msg.ValidatorData = bm
+		}
+		return validateResult
+	}
+	return btv
+}
+
+// SetRejectRecorder sets the recorder notified of every rejected block.
+func (btv *BlockTopicValidator) SetRejectRecorder(recorder RejectRecorder) {
+	btv.recorder = recorder
+}
+
+func (btv *BlockTopicValidator) reject(ctx context.Context, c cid.Cid, msg *pubsub.Message, reason string, err error) {
+	if btv.recorder != nil {
+		btv.recorder.Reject(ctx, types.QuarantineBlock, c, msg.ReceivedFrom, reason, err, msg.GetData())
 	}
 }
 
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/net"
 	"github.com/filecoin-project/venus/pkg/net/blocksub"
 	th "github.com/filecoin-project/venus/pkg/testhelpers"
 	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
@@ -42,6 +43,39 @@ func TestBlockTopicValidator(t *testing.T) {
 	assert.True(t, validator(ctx, pid1, blkToPubSub(t, goodBlk)) == pubsub.ValidationAccept)
 	assert.False(t, validator(ctx, pid1, blkToPubSub(t, badBlk)) == pubsub.ValidationAccept)
 	assert.False(t, validator(ctx, pid1, nonBlkPubSubMsg()) == pubsub.ValidationAccept)
+
+	t.Run("quarantine", func(t *testing.T) {
+		q := net.NewQuarantine(2)
+		tv.SetRejectRecorder(q)
+
+		assert.Equal(t, pubsub.ValidationAccept, validator(ctx, pid1, blkToPubSub(t, goodBlk)))
+		assert.Empty(t, q.List("", 0))
+
+		assert.Equal(t, pubsub.ValidationReject, validator(ctx, pid1, blkToPubSub(t, badBlk)))
+		entry, ok := q.Get(badBlk.Cid())
+		require.True(t, ok)
+		assert.Equal(t, types.QuarantineBlock, entry.Kind)
+		assert.Equal(t, "stub", entry.Reason)
+		assert.Equal(t, "invalid block", entry.Error)
+
+		assert.Equal(t, pubsub.ValidationIgnore, validator(ctx, pid1, nonBlkPubSubMsg()))
+		entries := q.List(types.QuarantineBlock, 0)
+		require.Len(t, entries, 2)
+		assert.Equal(t, blocksub.RejectDecode, entries[0].Reason)
+		assert.False(t, entries[0].Cid.Defined())
+		assert.Len(t, q.List(types.QuarantineMessage, 0), 0)
+
+		// the oldest entry is evicted once the quarantine is full
+		assert.Equal(t, pubsub.ValidationIgnore, validator(ctx, pid1, nonBlkPubSubMsg()))
+		_, ok = q.Get(badBlk.Cid())
+		assert.False(t, ok)
+		assert.Len(t, q.List("", 1), 1)
+
+		assert.Equal(t, []types.QuarantineStat{
+			{Kind: types.QuarantineBlock, Reason: blocksub.RejectDecode, Count: 2},
+			{Kind: types.QuarantineBlock, Reason: "stub", Count: 1},
+		}, q.Stats())
+	})
 }
 
 // convert a types.BlockHeader to a pubsub message
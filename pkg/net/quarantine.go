@@ -0,0 +1,125 @@
+package net
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ipfs-force-community/metrics"
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// DefaultQuarantineSize is the number of rejected objects kept when the size is not configured.
+const DefaultQuarantineSize = 256
+
+var mPubsubRejected = metrics.NewInt64WithCategory("net/pubsub_rejected", "Number of pubsub objects rejected by validators, by kind and reason", "")
+
+// Quarantine keeps the latest blocks and messages rejected by the pubsub validators together
+// with the rejection reason, the oldest entry is evicted once the store is full.
+type Quarantine struct {
+	lk      sync.Mutex
+	size    int
+	entries []*types.QuarantineEntry
+	byCid   map[cid.Cid]*types.QuarantineEntry
+	counts  map[types.QuarantineKind]map[string]uint64
+}
+
+// NewQuarantine creates a quarantine holding at most size entries.
+func NewQuarantine(size int) *Quarantine {
+	if size <= 0 {
+		size = DefaultQuarantineSize
+	}
+	return &Quarantine{
+		size:   size,
+		byCid:  make(map[cid.Cid]*types.QuarantineEntry),
+		counts: make(map[types.QuarantineKind]map[string]uint64),
+	}
+}
+
+// Reject records an object rejected for reason, c may be undefined when the payload did not decode.
+func (q *Quarantine) Reject(ctx context.Context, kind types.QuarantineKind, c cid.Cid, from peer.ID, reason string, err error, data []byte) {
+	if q == nil {
+		return
+	}
+	entry := &types.QuarantineEntry{
+		Kind:     kind,
+		Cid:      c,
+		From:     from,
+		Reason:   reason,
+		Received: time.Now(),
+		Data:     data,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	q.lk.Lock()
+	if len(q.entries) == q.size {
+		evicted := q.entries[0]
+		q.entries = q.entries[1:]
+		if evicted.Cid.Defined() && q.byCid[evicted.Cid] == evicted {
+			delete(q.byCid, evicted.Cid)
+		}
+	}
+	q.entries = append(q.entries, entry)
+	if c.Defined() {
+		q.byCid[c] = entry
+	}
+	if q.counts[kind] == nil {
+		q.counts[kind] = make(map[string]uint64)
+	}
+	q.counts[kind][reason]++
+	q.lk.Unlock()
+
+	mPubsubRejected.Inc(ctx, string(kind)+"/"+reason, 1)
+}
+
+// List returns up to limit entries of kind, newest first, an empty kind lists every kind.
+func (q *Quarantine) List(kind types.QuarantineKind, limit int) []*types.QuarantineEntry {
+	q.lk.Lock()
+	defer q.lk.Unlock()
+
+	out := make([]*types.QuarantineEntry, 0)
+	for i := len(q.entries) - 1; i >= 0; i-- {
+		if limit > 0 && len(out) == limit {
+			break
+		}
+		if kind == "" || q.entries[i].Kind == kind {
+			out = append(out, q.entries[i])
+		}
+	}
+	return out
+}
+
+// Get returns the latest entry of the object c.
+func (q *Quarantine) Get(c cid.Cid) (*types.QuarantineEntry, bool) {
+	q.lk.Lock()
+	defer q.lk.Unlock()
+
+	entry, ok := q.byCid[c]
+	return entry, ok
+}
+
+// Stats returns the number of rejections by kind and reason since the node started.
+func (q *Quarantine) Stats() []types.QuarantineStat {
+	q.lk.Lock()
+	defer q.lk.Unlock()
+
+	out := make([]types.QuarantineStat, 0)
+	for kind, reasons := range q.counts {
+		for reason, count := range reasons {
+			out = append(out, types.QuarantineStat{Kind: kind, Reason: reason, Count: count})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Kind != out[j].Kind {
+			return out[i].Kind < out[j].Kind
+		}
+		return out[i].Reason < out[j].Reason
+	})
+	return out
+}
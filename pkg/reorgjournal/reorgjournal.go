@@ -0,0 +1,140 @@
+// Package reorgjournal persists the reorgs of the head observed by the node, so the downstream inconsistencies
+// following a deep reorg can be traced back to it.
+package reorgjournal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+	"github.com/ipfs/go-datastore/query"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+var journalPrefix = datastore.NewKey("/reorgs")
+
+// MaxEntries is the number of reorgs kept by the journal, the oldest ones are dropped.
+const MaxEntries = 10000
+
+// Journal stores the reorgs by id, the ids are given in the order the reorgs are recorded.
+type Journal struct {
+	lk         sync.Mutex
+	ds         datastore.Datastore
+	maxEntries uint64
+	// firstID and lastID are the ids of the oldest and the latest reorgs stored, zero when there are none
+	firstID uint64
+	lastID  uint64
+}
+
+// New loads the journal stored in ds.
+func New(ctx context.Context, ds datastore.Datastore) (*Journal, error) {
+	return newJournal(ctx, ds, MaxEntries)
+}
+
+func newJournal(ctx context.Context, ds datastore.Datastore, maxEntries uint64) (*Journal, error) {
+	j := &Journal{
+		ds:         namespace.Wrap(ds, journalPrefix),
+		maxEntries: maxEntries,
+	}
+
+	res, err := j.ds.Query(ctx, query.Query{KeysOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	entries, err := res.Rest()
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		id, err := parseKey(e.Key)
+		if err != nil {
+			return nil, err
+		}
+		if j.firstID == 0 || id < j.firstID {
+			j.firstID = id
+		}
+		if id > j.lastID {
+			j.lastID = id
+		}
+	}
+	return j, nil
+}
+
+// the ids are zero padded so the keys are ordered as the ids
+func idKey(id uint64) datastore.Key {
+	return datastore.NewKey(fmt.Sprintf("%020d", id))
+}
+
+func parseKey(key string) (uint64, error) {
+	id, err := strconv.ParseUint(datastore.RawKey(key).BaseNamespace(), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid reorg key %s: %w", key, err)
+	}
+	return id, nil
+}
+
+// Record gives the next id to r and stores it, dropping the oldest reorgs over MaxEntries.
+func (j *Journal) Record(ctx context.Context, r *types.ChainReorg) error {
+	j.lk.Lock()
+	defer j.lk.Unlock()
+
+	r.ID = j.lastID + 1
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if err := j.ds.Put(ctx, idKey(r.ID), data); err != nil {
+		return err
+	}
+	j.lastID = r.ID
+	if j.firstID == 0 {
+		j.firstID = r.ID
+	}
+
+	for ; j.lastID-j.firstID >= j.maxEntries; j.firstID++ {
+		if err := j.ds.Delete(ctx, idKey(j.firstID)); err != nil {
+			return fmt.Errorf("dropping reorg %d: %w", j.firstID, err)
+		}
+	}
+	return nil
+}
+
+// List returns the reorgs at least minDepth deep recorded at or after since, from the latest one, at most limit
+// of them or all of them when limit is not positive.
+func (j *Journal) List(ctx context.Context, minDepth abi.ChainEpoch, since time.Time, limit int) ([]types.ChainReorg, error) {
+	res, err := j.ds.Query(ctx, query.Query{Orders: []query.Order{query.OrderByKeyDescending{}}})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close() //nolint:errcheck
+
+	out := []types.ChainReorg{}
+	for e := range res.Next() {
+		if e.Error != nil {
+			return nil, e.Error
+		}
+		var r types.ChainReorg
+		if err := json.Unmarshal(e.Value, &r); err != nil {
+			return nil, fmt.Errorf("decoding reorg %s: %w", e.Key, err)
+		}
+		// the reorgs are recorded as they are observed, the next ones are older
+		if r.Time.Before(since) {
+			break
+		}
+		if r.Depth < minDepth {
+			continue
+		}
+		out = append(out, r)
+		if limit > 0 && len(out) >= limit {
+			break
+		}
+	}
+	return out, nil
+}
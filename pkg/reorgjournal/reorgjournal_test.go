@@ -0,0 +1,72 @@
+package reorgjournal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestJournal(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	ds := datastore.NewMapDatastore()
+	j, err := newJournal(ctx, ds, 3)
+	require.NoError(t, err)
+
+	start := time.Now()
+	record := func(depth abi.ChainEpoch) {
+		r := &types.ChainReorg{Time: start.Add(time.Duration(depth) * time.Second), Depth: depth}
+		require.NoError(t, j.Record(ctx, r))
+	}
+	ids := func(reorgs []types.ChainReorg) []uint64 {
+		out := []uint64{}
+		for _, r := range reorgs {
+			out = append(out, r.ID)
+		}
+		return out
+	}
+
+	list, err := j.List(ctx, 0, time.Time{}, 0)
+	require.NoError(t, err)
+	require.Empty(t, list)
+
+	record(1)
+	record(2)
+	list, err = j.List(ctx, 0, time.Time{}, 0)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2, 1}, ids(list))
+	require.Equal(t, abi.ChainEpoch(2), list[0].Depth)
+
+	// the oldest reorgs over the limit are dropped
+	record(3)
+	record(4)
+	list, err = j.List(ctx, 0, time.Time{}, 0)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{4, 3, 2}, ids(list))
+
+	// the journal is reloaded from the datastore
+	j, err = newJournal(ctx, ds, 3)
+	require.NoError(t, err)
+	record(5)
+	list, err = j.List(ctx, 0, time.Time{}, 0)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{5, 4, 3}, ids(list))
+
+	list, err = j.List(ctx, 4, time.Time{}, 0)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{5, 4}, ids(list))
+	list, err = j.List(ctx, 0, start.Add(4*time.Second), 0)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{5, 4}, ids(list))
+	list, err = j.List(ctx, 0, time.Time{}, 1)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{5}, ids(list))
+}
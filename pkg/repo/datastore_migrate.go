@@ -0,0 +1,184 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	lockfile "github.com/ipfs/go-fs-lock"
+	"github.com/mitchellh/go-homedir"
+)
+
+// migrateBatchSize is the number of entries written per batch by a datastore migration.
+const migrateBatchSize = 1024
+
+// MigrateDatastore copies the blockstore and the datastores of the repo at repoPath to the datastore
+// type to, and switches the config to it. The repo must not be in use, the previous stores are kept
+// and can be removed once the node runs fine on the new ones. progress is called with the number of
+// entries copied so far for each store.
+func MigrateDatastore(ctx context.Context, repoPath string, to string, progress func(store string, copied int)) error {
+	repoPath, err := homedir.Expand(repoPath)
+	if err != nil {
+		return err
+	}
+
+	lk, err := lockfile.Lock(repoPath, lockFile)
+	if err != nil {
+		return fmt.Errorf("failed to take repo lock, is the daemon running? %w", err)
+	}
+	defer lk.Close() //nolint:errcheck
+
+	cfg, err := LoadConfig(repoPath)
+	if err != nil {
+		return err
+	}
+	from := cfg.Datastore.Type
+	if from == to {
+		return fmt.Errorf("repo already uses datastore type %s", to)
+	}
+	src, err := GetDatastoreDriver(from)
+	if err != nil {
+		return err
+	}
+	dst, err := GetDatastoreDriver(to)
+	if err != nil {
+		return err
+	}
+
+	names := []string{chainDatastorePrefix, metaDatastorePrefix, paychDatastorePrefix, walletDatastorePrefix}
+	blockstorePath := filepath.Join(repoPath, to)
+	for _, p := range append([]string{blockstorePath}, datastorePaths(repoPath, to, names)...) {
+		if _, err := os.Stat(p); err == nil {
+			return fmt.Errorf("%s already exists, remove it to migrate to %s", p, to)
+		}
+	}
+
+	if err := copyBlockstore(ctx, src, filepath.Join(repoPath, cfg.Datastore.Path), dst, blockstorePath, progress); err != nil {
+		return fmt.Errorf("copying blockstore: %w", err)
+	}
+	for _, name := range names {
+		if err := copyDatastore(ctx, name, src, datastorePath(repoPath, from, name), dst, datastorePath(repoPath, to, name), progress); err != nil {
+			return fmt.Errorf("copying %s datastore: %w", name, err)
+		}
+	}
+
+	cfg.Datastore.Type = to
+	cfg.Datastore.Path = to
+	return cfg.WriteFile(filepath.Join(repoPath, configFilename))
+}
+
+func datastorePaths(repoPath, dsType string, names []string) []string {
+	out := make([]string, 0, len(names))
+	for _, name := range names {
+		out = append(out, datastorePath(repoPath, dsType, name))
+	}
+	return out
+}
+
+func copyBlockstore(ctx context.Context, src DatastoreDriver, srcPath string, dst DatastoreDriver, dstPath string, progress func(string, int)) error {
+	from, err := src.OpenBlockstore(srcPath, true)
+	if err != nil {
+		return err
+	}
+	defer from.Close() //nolint:errcheck
+
+	to, err := dst.OpenBlockstore(dstPath, false)
+	if err != nil {
+		return err
+	}
+	defer to.Close() //nolint:errcheck
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	keys, err := from.AllKeysChan(ctx)
+	if err != nil {
+		return err
+	}
+
+	copied := 0
+	batch := make([]blocks.Block, 0, migrateBatchSize)
+	flush := func() error {
+		if err := to.PutMany(ctx, batch); err != nil {
+			return err
+		}
+		copied += len(batch)
+		batch = batch[:0]
+		progress("blockstore", copied)
+		return nil
+	}
+	for c := range keys {
+		blk, err := from.Get(ctx, c)
+		if err != nil {
+			return fmt.Errorf("getting %s: %w", c, err)
+		}
+		batch = append(batch, blk)
+		if len(batch) == migrateBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+	return to.Flush(ctx)
+}
+
+func copyDatastore(ctx context.Context, name string, src DatastoreDriver, srcPath string, dst DatastoreDriver, dstPath string, progress func(string, int)) error {
+	from, err := src.OpenDatastore(srcPath)
+	if err != nil {
+		return err
+	}
+	defer from.Close() //nolint:errcheck
+
+	to, err := dst.OpenDatastore(dstPath)
+	if err != nil {
+		return err
+	}
+	defer to.Close() //nolint:errcheck
+
+	res, err := from.Query(ctx, query.Query{})
+	if err != nil {
+		return err
+	}
+	defer res.Close() //nolint:errcheck
+
+	copied := 0
+	batch, err := to.Batch(ctx)
+	if err != nil {
+		return err
+	}
+	commit := func() error {
+		if err := batch.Commit(ctx); err != nil {
+			return err
+		}
+		progress(name, copied)
+		batch, err = to.Batch(ctx)
+		return err
+	}
+	for r := range res.Next() {
+		if r.Error != nil {
+			return r.Error
+		}
+		if err := batch.Put(ctx, datastore.NewKey(r.Key), r.Value); err != nil {
+			return err
+		}
+		copied++
+		if copied%migrateBatchSize == 0 {
+			if err := commit(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := commit(); err != nil {
+		return err
+	}
+	return to.Sync(ctx, datastore.NewKey("/"))
+}
@@ -0,0 +1,129 @@
+package repo
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	bstore "github.com/ipfs/boxo/blockstore"
+	badgerds "github.com/ipfs/go-ds-badger2"
+	levelds "github.com/ipfs/go-ds-leveldb"
+	ldbopts "github.com/syndtr/goleveldb/leveldb/opt"
+
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+)
+
+// datastore types, selected by the type of the datastore config
+const (
+	BadgerDatastore  = "badgerds"
+	LevelDBDatastore = "levelds"
+	PebbleDatastore  = "pebbleds"
+)
+
+// ClosableBlockstore is a blockstore owning the store it is backed by.
+type ClosableBlockstore interface {
+	blockstoreutil.Blockstore
+	io.Closer
+}
+
+// DatastoreDriver opens the key value stores backing the blockstore and the datastores of the repo.
+type DatastoreDriver interface {
+	// OpenBlockstore opens the blockstore of the chain objects stored at path.
+	OpenBlockstore(path string, readonly bool) (ClosableBlockstore, error)
+	// OpenDatastore opens the datastore stored at path.
+	OpenDatastore(path string) (Datastore, error)
+}
+
+var (
+	driversLk sync.RWMutex
+	drivers   = map[string]DatastoreDriver{
+		BadgerDatastore:  badgerDriver{},
+		LevelDBDatastore: levelDBDriver{},
+		PebbleDatastore:  pebbleDriver{},
+	}
+)
+
+// RegisterDatastoreDriver makes the driver available to the repos with the datastore type name,
+// so builds including other key value stores can plug them in.
+func RegisterDatastoreDriver(name string, driver DatastoreDriver) {
+	driversLk.Lock()
+	defer driversLk.Unlock()
+	drivers[name] = driver
+}
+
+// GetDatastoreDriver returns the driver registered with the datastore type name.
+func GetDatastoreDriver(name string) (DatastoreDriver, error) {
+	driversLk.RLock()
+	defer driversLk.RUnlock()
+	driver, ok := drivers[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown datastore type in config: %s", name)
+	}
+	return driver, nil
+}
+
+// DatastoreDrivers returns the names of the registered drivers.
+func DatastoreDrivers() []string {
+	driversLk.RLock()
+	defer driversLk.RUnlock()
+	names := make([]string, 0, len(drivers))
+	for name := range drivers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// datastorePath returns the directory of the datastore name, the badger datastores keep the
+// historical layout, the other types get their own directories so a migration never overwrites them.
+func datastorePath(repoPath, dsType, name string) string {
+	if dsType == BadgerDatastore {
+		return filepath.Join(repoPath, name)
+	}
+	return filepath.Join(repoPath, name+"-"+dsType)
+}
+
+type badgerDriver struct{}
+
+func (badgerDriver) OpenBlockstore(path string, readonly bool) (ClosableBlockstore, error) {
+	opts, err := blockstoreutil.BadgerBlockstoreOptions(path, readonly)
+	if err != nil {
+		return nil, err
+	}
+	opts.Prefix = bstore.BlockPrefix.String()
+	return blockstoreutil.Open(opts)
+}
+
+func (badgerDriver) OpenDatastore(path string) (Datastore, error) {
+	return badgerds.NewDatastore(path, badgerOptions())
+}
+
+type levelDBDriver struct{}
+
+// datastoreBlockstore is a blockstore over a datastore it closes with it.
+type datastoreBlockstore struct {
+	blockstoreutil.Blockstore
+	io.Closer
+}
+
+func (d levelDBDriver) OpenBlockstore(path string, readonly bool) (ClosableBlockstore, error) {
+	ds, err := d.open(path, readonly)
+	if err != nil {
+		return nil, err
+	}
+	return &datastoreBlockstore{Blockstore: blockstoreutil.NewBlockstore(ds), Closer: ds}, nil
+}
+
+func (d levelDBDriver) OpenDatastore(path string) (Datastore, error) {
+	return d.open(path, false)
+}
+
+func (levelDBDriver) open(path string, readonly bool) (*levelds.Datastore, error) {
+	return levelds.NewDatastore(path, &levelds.Options{
+		Compression: ldbopts.NoCompression,
+		Strict:      ldbopts.StrictAll,
+		ReadOnly:    readonly,
+	})
+}
@@ -0,0 +1,174 @@
+package repo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cockroachdb/pebble"
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+)
+
+type pebbleDriver struct{}
+
+func (d pebbleDriver) OpenBlockstore(path string, readonly bool) (ClosableBlockstore, error) {
+	db, err := openPebble(path, readonly)
+	if err != nil {
+		return nil, err
+	}
+	return &datastoreBlockstore{Blockstore: blockstoreutil.NewBlockstore(db), Closer: db}, nil
+}
+
+func (d pebbleDriver) OpenDatastore(path string) (Datastore, error) {
+	return openPebble(path, false)
+}
+
+// pebbleDatastore implements a batching datastore over a pebble database.
+type pebbleDatastore struct {
+	db *pebble.DB
+}
+
+var _ ds.Batching = (*pebbleDatastore)(nil)
+
+func openPebble(path string, readonly bool) (*pebbleDatastore, error) {
+	db, err := pebble.Open(path, &pebble.Options{ReadOnly: readonly})
+	if err != nil {
+		return nil, err
+	}
+	return &pebbleDatastore{db: db}, nil
+}
+
+func (d *pebbleDatastore) Get(_ context.Context, key ds.Key) ([]byte, error) {
+	val, closer, err := d.db.Get(key.Bytes())
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, ds.ErrNotFound
+		}
+		return nil, err
+	}
+	defer closer.Close() // nolint:errcheck
+
+	// the value is only valid until the closer is closed
+	out := make([]byte, len(val))
+	copy(out, val)
+	return out, nil
+}
+
+func (d *pebbleDatastore) Has(_ context.Context, key ds.Key) (bool, error) {
+	_, closer, err := d.db.Get(key.Bytes())
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, closer.Close()
+}
+
+func (d *pebbleDatastore) GetSize(_ context.Context, key ds.Key) (int, error) {
+	val, closer, err := d.db.Get(key.Bytes())
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return -1, ds.ErrNotFound
+		}
+		return -1, err
+	}
+	size := len(val)
+	return size, closer.Close()
+}
+
+func (d *pebbleDatastore) Put(_ context.Context, key ds.Key, value []byte) error {
+	return d.db.Set(key.Bytes(), value, pebble.NoSync)
+}
+
+func (d *pebbleDatastore) Delete(_ context.Context, key ds.Key) error {
+	return d.db.Delete(key.Bytes(), pebble.NoSync)
+}
+
+// Sync syncs the write ahead log, which holds every write made so far.
+func (d *pebbleDatastore) Sync(_ context.Context, _ ds.Key) error {
+	return d.db.LogData(nil, pebble.Sync)
+}
+
+func (d *pebbleDatastore) Query(_ context.Context, q dsq.Query) (dsq.Results, error) {
+	// the prefix and the key orders are served by the iterator, the rest is applied naively
+	qNaive := q
+	opts := &pebble.IterOptions{}
+	if prefix := ds.NewKey(q.Prefix).String(); prefix != "/" {
+		// '0' follows '/', so the bounds cover exactly the keys below prefix
+		opts.LowerBound = []byte(prefix + "/")
+		opts.UpperBound = []byte(prefix + "0")
+		qNaive.Prefix = ""
+	}
+	iter, err := d.db.NewIter(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	first, next := iter.First, iter.Next
+	if len(q.Orders) > 0 {
+		switch q.Orders[0].(type) {
+		case dsq.OrderByKey, *dsq.OrderByKey:
+			qNaive.Orders = nil
+		case dsq.OrderByKeyDescending, *dsq.OrderByKeyDescending:
+			first, next = iter.Last, iter.Prev
+			qNaive.Orders = nil
+		}
+	}
+
+	started := false
+	r := dsq.ResultsFromIterator(q, dsq.Iterator{
+		Next: func() (dsq.Result, bool) {
+			var ok bool
+			if !started {
+				started = true
+				ok = first()
+			} else {
+				ok = next()
+			}
+			if !ok {
+				if err := iter.Error(); err != nil {
+					return dsq.Result{Error: err}, true
+				}
+				return dsq.Result{}, false
+			}
+
+			e := dsq.Entry{Key: string(iter.Key()), Size: len(iter.Value())}
+			if !q.KeysOnly {
+				e.Value = append([]byte(nil), iter.Value()...)
+			}
+			return dsq.Result{Entry: e}, true
+		},
+		Close: iter.Close,
+	})
+	return dsq.NaiveQueryApply(qNaive, r), nil
+}
+
+func (d *pebbleDatastore) Batch(_ context.Context) (ds.Batch, error) {
+	return &pebbleBatch{b: d.db.NewBatch()}, nil
+}
+
+func (d *pebbleDatastore) Close() error {
+	return d.db.Close()
+}
+
+type pebbleBatch struct {
+	b *pebble.Batch
+}
+
+func (b *pebbleBatch) Put(_ context.Context, key ds.Key, value []byte) error {
+	return b.b.Set(key.Bytes(), value, nil)
+}
+
+func (b *pebbleBatch) Delete(_ context.Context, key ds.Key) error {
+	return b.b.Delete(key.Bytes(), nil)
+}
+
+func (b *pebbleBatch) Commit(_ context.Context) error {
+	if err := b.b.Commit(pebble.NoSync); err != nil {
+		return err
+	}
+	return b.b.Close()
+}
@@ -0,0 +1,55 @@
+package repo
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	blocks "github.com/ipfs/go-block-format"
+	ds "github.com/ipfs/go-datastore"
+	dstest "github.com/ipfs/go-datastore/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/config"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestPebbleDatastore(t *testing.T) {
+	tf.UnitTest(t)
+
+	d, err := openPebble(t.TempDir(), false)
+	require.NoError(t, err)
+	defer d.Close() // nolint:errcheck
+
+	dstest.SubtestAll(t, d)
+}
+
+func TestMigrateDatastorePebble(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	repoPath := path.Join(t.TempDir(), "repo")
+	require.NoError(t, InitFSRepo(repoPath, 42, config.NewDefaultConfig()))
+
+	r, err := OpenFSRepo(repoPath, 42)
+	require.NoError(t, err)
+	blk := blocks.NewBlock([]byte("block"))
+	require.NoError(t, r.Datastore().Put(ctx, blk))
+	require.NoError(t, r.ChainDatastore().Put(ctx, ds.NewKey("beep"), []byte("boop")))
+	require.NoError(t, r.Close())
+
+	require.NoError(t, MigrateDatastore(ctx, repoPath, PebbleDatastore, func(string, int) {}))
+
+	r, err = OpenFSRepo(repoPath, 42)
+	require.NoError(t, err)
+	defer r.Close() // nolint:errcheck
+	assert.Equal(t, PebbleDatastore, r.Config().Datastore.Type)
+
+	got, err := r.Datastore().Get(ctx, blk.Cid())
+	require.NoError(t, err)
+	assert.Equal(t, blk.RawData(), got.RawData())
+	val, err := r.ChainDatastore().Get(ctx, ds.NewKey("beep"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("boop"), val)
+}
@@ -13,7 +13,6 @@ import (
 	"github.com/filecoin-project/venus/pkg/repo/fskeystore"
 
 	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
-	bstore "github.com/ipfs/boxo/blockstore"
 
 	badgerds "github.com/ipfs/go-ds-badger2"
 	lockfile "github.com/ipfs/go-fs-lock"
@@ -51,8 +50,10 @@ type FSRepo struct {
 
 	// lk protects the config file
 	lk sync.RWMutex
+	// profile is the config profile applied to the config, the changes of the config are saved to it
+	profile string
 
-	ds       *blockstoreutil.BadgerBlockstore
+	ds       ClosableBlockstore
 	keystore fskeystore.Keystore
 	walletDs Datastore
 	chainDs  Datastore
@@ -245,18 +246,48 @@ func (r *FSRepo) Config() *config.Config {
 	return Config
 }
 
+// UseConfigProfile applies the config profile name to the config, the config replaced later is saved to the
+// overrides of the profile, leaving the rest of the config file as is.
+func (r *FSRepo) UseConfigProfile(name string) error {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	cfg, err := LoadConfig(r.path)
+	if err != nil {
+		return err
+	}
+	if err := cfg.ApplyProfile(name); err != nil {
+		return errors.Wrapf(err, "failed to apply config profile %s", name)
+	}
+	Config = cfg
+	r.profile = name
+	return nil
+}
+
 // ReplaceConfig replaces the current config with the newly passed in one.
 func (r *FSRepo) ReplaceConfig(cfg *config.Config) error {
 	r.lk.Lock()
 	defer r.lk.Unlock()
 
+	file := cfg
+	if r.profile != "" {
+		base, err := LoadConfig(r.path)
+		if err != nil {
+			return err
+		}
+		if err := base.SaveProfile(r.profile, cfg); err != nil {
+			return errors.Wrapf(err, "failed to save config profile %s", r.profile)
+		}
+		file = base
+	}
+
 	Config = cfg
 	tmp := filepath.Join(r.path, tempConfigFilename)
 	err := os.RemoveAll(tmp)
 	if err != nil {
 		return err
 	}
-	err = Config.WriteFile(tmp)
+	err = file.WriteFile(tmp)
 	if err != nil {
 		return err
 	}
@@ -377,24 +408,21 @@ func (r *FSRepo) readVersion() (uint, error) {
 }
 
 func (r *FSRepo) openDatastore() error {
-	switch Config.Datastore.Type {
-	case "badgerds":
-		path := filepath.Join(r.path, Config.Datastore.Path)
-		opts, err := blockstoreutil.BadgerBlockstoreOptions(path, false)
-		if err != nil {
-			return err
-		}
-		opts.Prefix = bstore.BlockPrefix.String()
-		ds, err := blockstoreutil.Open(opts)
-		if err != nil {
-			return err
-		}
-		r.ds = ds
-	default:
-		return fmt.Errorf("unknown datastore type in config: %s", Config.Datastore.Type)
+	driver, err := GetDatastoreDriver(Config.Datastore.Type)
+	if err != nil {
+		return err
 	}
+	r.ds, err = driver.OpenBlockstore(filepath.Join(r.path, Config.Datastore.Path), false)
+	return err
+}
 
-	return nil
+// openMetadataDatastore opens the datastore name with the driver of the datastore type.
+func (r *FSRepo) openMetadataDatastore(name string) (Datastore, error) {
+	driver, err := GetDatastoreDriver(Config.Datastore.Type)
+	if err != nil {
+		return nil, err
+	}
+	return driver.OpenDatastore(datastorePath(r.path, Config.Datastore.Type, name))
 }
 
 func (r *FSRepo) openKeystore() error {
@@ -411,7 +439,7 @@ func (r *FSRepo) openKeystore() error {
 }
 
 func (r *FSRepo) openChainDatastore() error {
-	ds, err := badgerds.NewDatastore(filepath.Join(r.path, chainDatastorePrefix), badgerOptions())
+	ds, err := r.openMetadataDatastore(chainDatastorePrefix)
 	if err != nil {
 		return err
 	}
@@ -422,7 +450,7 @@ func (r *FSRepo) openChainDatastore() error {
 }
 
 func (r *FSRepo) openMetaDatastore() error {
-	ds, err := badgerds.NewDatastore(filepath.Join(r.path, metaDatastorePrefix), badgerOptions())
+	ds, err := r.openMetadataDatastore(metaDatastorePrefix)
 	if err != nil {
 		return err
 	}
@@ -434,7 +462,7 @@ func (r *FSRepo) openMetaDatastore() error {
 
 func (r *FSRepo) openPaychDataStore() error {
 	var err error
-	r.paychDs, err = badgerds.NewDatastore(filepath.Join(r.path, paychDatastorePrefix), badgerOptions())
+	r.paychDs, err = r.openMetadataDatastore(paychDatastorePrefix)
 	if err != nil {
 		return err
 	}
@@ -443,7 +471,7 @@ func (r *FSRepo) openPaychDataStore() error {
 
 func (r *FSRepo) openWalletDatastore() error {
 	// TODO: read wallet datastore info from config, use that to open it up
-	ds, err := badgerds.NewDatastore(filepath.Join(r.path, walletDatastorePrefix), badgerOptions())
+	ds, err := r.openMetadataDatastore(walletDatastorePrefix)
 	if err != nil {
 		return err
 	}
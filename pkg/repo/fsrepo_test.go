@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"testing"
 
+	blocks "github.com/ipfs/go-block-format"
 	ds "github.com/ipfs/go-datastore"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -141,6 +142,39 @@ func TestFSRepoReplaceAndSnapshotConfig(t *testing.T) {
 	assert.NoError(t, r2.Close())
 }
 
+func TestFSRepoConfigProfile(t *testing.T) {
+	tf.UnitTest(t)
+
+	repoPath := t.TempDir()
+	cfg := config.NewDefaultConfig()
+	cfg.Profiles = map[string]config.Profile{
+		"calibration": {Network: "calibrationnet", Overrides: []byte(`{"swarm": {"connMgrHigh": 200}}`)},
+	}
+	require.NoError(t, InitFSRepo(repoPath, 42, cfg))
+
+	r, err := OpenFSRepo(repoPath, 42)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, r.Close()) }()
+
+	require.Error(t, r.UseConfigProfile("butterfly"))
+	require.NoError(t, r.UseConfigProfile("calibration"))
+	require.Equal(t, uint(200), r.Config().Swarm.ConnMgrHigh)
+
+	newCfg := r.Config()
+	newCfg.API.APIAddress = "/ip4/127.0.0.1/tcp/3454"
+	require.NoError(t, r.ReplaceConfig(newCfg))
+	require.Equal(t, "/ip4/127.0.0.1/tcp/3454", r.Config().API.APIAddress)
+
+	// the change is saved to the profile, the rest of the file is left as is
+	saved, err := LoadConfig(repoPath)
+	require.NoError(t, err)
+	require.Equal(t, cfg.API.APIAddress, saved.API.APIAddress)
+	require.Equal(t, cfg.Swarm.ConnMgrHigh, saved.Swarm.ConnMgrHigh)
+	require.NoError(t, saved.ApplyProfile("calibration"))
+	require.Equal(t, "/ip4/127.0.0.1/tcp/3454", saved.API.APIAddress)
+	require.Equal(t, uint(200), saved.Swarm.ConnMgrHigh)
+}
+
 func TestRepoLock(t *testing.T) {
 	tf.UnitTest(t)
 
@@ -310,3 +344,48 @@ func ConfigExists(dir string) bool {
 	}
 	return err == nil
 }
+
+func TestMigrateDatastore(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	repoPath := path.Join(t.TempDir(), "repo")
+	assert.NoError(t, InitFSRepo(repoPath, 42, config.NewDefaultConfig()))
+
+	r, err := OpenFSRepo(repoPath, 42)
+	require.NoError(t, err)
+	blk := blocks.NewBlock([]byte("block"))
+	require.NoError(t, r.Datastore().Put(ctx, blk))
+	require.NoError(t, r.ChainDatastore().Put(ctx, ds.NewKey("beep"), []byte("boop")))
+	require.NoError(t, r.WalletDatastore().Put(ctx, ds.NewKey("key"), []byte("secret")))
+
+	// the repo is locked while in use
+	assert.Error(t, MigrateDatastore(ctx, repoPath, LevelDBDatastore, func(string, int) {}))
+	require.NoError(t, r.Close())
+
+	assert.Error(t, MigrateDatastore(ctx, repoPath, BadgerDatastore, func(string, int) {}))
+	copied := map[string]int{}
+	require.NoError(t, MigrateDatastore(ctx, repoPath, LevelDBDatastore, func(store string, n int) {
+		copied[store] = n
+	}))
+	assert.Equal(t, 1, copied["blockstore"])
+	assert.Equal(t, 1, copied[chainDatastorePrefix])
+
+	r, err = OpenFSRepo(repoPath, 42)
+	require.NoError(t, err)
+	assert.Equal(t, LevelDBDatastore, r.Config().Datastore.Type)
+
+	got, err := r.Datastore().Get(ctx, blk.Cid())
+	require.NoError(t, err)
+	assert.Equal(t, blk.RawData(), got.RawData())
+	val, err := r.ChainDatastore().Get(ctx, ds.NewKey("beep"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("boop"), val)
+	val, err = r.WalletDatastore().Get(ctx, ds.NewKey("key"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("secret"), val)
+	require.NoError(t, r.Close())
+
+	// the new stores are never overwritten
+	assert.Error(t, MigrateDatastore(ctx, repoPath, LevelDBDatastore, func(string, int) {}))
+}
@@ -0,0 +1,312 @@
+// Package signaudit keeps the history of the signatures made by the wallet and of the messages pushed to the
+// message pool through the api, for the deployments holding the keys of their users.
+package signaudit
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/ipfs-force-community/sophon-auth/core"
+	"github.com/ipfs/go-cid"
+	logging "github.com/ipfs/go-log/v2"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+var log = logging.Logger("signaudit")
+
+// pruneInterval is how often the records older than the retention are deleted.
+var pruneInterval = time.Hour
+
+var pragmas = []string{
+	"PRAGMA synchronous = normal",
+	"PRAGMA temp_store = memory",
+	"PRAGMA journal_mode = WAL",
+}
+
+var ddls = []string{
+	`CREATE TABLE IF NOT EXISTS sign_audit (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		time INTEGER NOT NULL,
+		token TEXT NOT NULL,
+		method TEXT NOT NULL,
+		signer TEXT NOT NULL,
+		msg_type TEXT NOT NULL,
+		msg_cid TEXT NOT NULL,
+		to_addr TEXT NOT NULL,
+		nonce INTEGER NOT NULL,
+		value TEXT NOT NULL,
+		method_num INTEGER NOT NULL,
+		purpose TEXT NOT NULL,
+		error TEXT NOT NULL
+	)`,
+
+	`CREATE INDEX IF NOT EXISTS time_index ON sign_audit (time)`,
+
+	`CREATE INDEX IF NOT EXISTS signer_index ON sign_audit (signer, id)`,
+
+	// metadata containing version of schema
+	`CREATE TABLE IF NOT EXISTS _meta (
+		version UINT64 NOT NULL UNIQUE
+	)`,
+
+	// version 1.
+	`INSERT OR IGNORE INTO _meta (version) VALUES (1)`,
+}
+
+const schemaVersion = 1
+
+const (
+	insertRecord = `INSERT INTO sign_audit
+	(time, token, method, signer, msg_type, msg_cid, to_addr, nonce, value, method_num, purpose, error)
+	VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	selectRecords = `SELECT id, time, token, method, signer, msg_type, msg_cid, to_addr, nonce, value, method_num, purpose, error
+	FROM sign_audit`
+)
+
+// AuditLog is the signing audit log, a nil AuditLog records nothing.
+type AuditLog struct {
+	db        *sql.DB
+	retention time.Duration
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+// NewAuditLog opens the audit log stored at path, the records older than retention are deleted, a zero retention
+// keeps them forever.
+func NewAuditLog(path string, retention time.Duration) (*AuditLog, error) {
+	db, err := sql.Open("sqlite3", path+"?mode=rwc")
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite3 database: %w", err)
+	}
+
+	for _, pragma := range pragmas {
+		if _, err := db.Exec(pragma); err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("exec pragma %q: %w", pragma, err)
+		}
+	}
+	for _, ddl := range ddls {
+		if _, err := db.Exec(ddl); err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("exec ddl %q: %w", ddl, err)
+		}
+	}
+	var version int
+	if err := db.QueryRow("SELECT max(version) FROM _meta").Scan(&version); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("invalid database version: no version found")
+	}
+	if version != schemaVersion {
+		_ = db.Close()
+		return nil, fmt.Errorf("invalid database version: got %d, expected %d", version, schemaVersion)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	l := &AuditLog{
+		db:        db,
+		retention: retention,
+		cancel:    cancel,
+		done:      make(chan struct{}),
+	}
+	go l.pruneLoop(ctx)
+	return l, nil
+}
+
+// MessageRecord returns the record of a chain message handled by method.
+func MessageRecord(method string, msg *types.Message) types.SignAuditRecord {
+	return types.SignAuditRecord{
+		Method:    method,
+		Signer:    msg.From,
+		MsgType:   types.MTChainMsg,
+		MsgCid:    msg.Cid(),
+		To:        msg.To,
+		Nonce:     msg.Nonce,
+		Value:     msg.Value,
+		MethodNum: msg.Method,
+	}
+}
+
+// Record appends rec to the log, its time and token are taken from now and from the caller of the api in ctx.
+// Failing to write the record is logged, the signature it records has already been made.
+func (l *AuditLog) Record(ctx context.Context, rec types.SignAuditRecord) {
+	if l == nil {
+		return
+	}
+	rec.Time = time.Now()
+	rec.Token, _ = core.CtxGetName(ctx)
+	if err := l.insert(rec); err != nil {
+		log.Errorw("failed to write the signing audit log", "method", rec.Method, "signer", rec.Signer,
+			"msg", rec.MsgCid, "error", err)
+	}
+}
+
+func (l *AuditLog) insert(rec types.SignAuditRecord) error {
+	msgCid := ""
+	if rec.MsgCid.Defined() {
+		msgCid = rec.MsgCid.String()
+	}
+	signer := ""
+	if rec.Signer != address.Undef {
+		signer = rec.Signer.String()
+	}
+	to := ""
+	if rec.To != address.Undef {
+		to = rec.To.String()
+	}
+	value := ""
+	if rec.Value.Int != nil {
+		value = rec.Value.String()
+	}
+	_, err := l.db.Exec(insertRecord, rec.Time.UnixNano(), rec.Token, rec.Method, signer, string(rec.MsgType),
+		msgCid, to, int64(rec.Nonce), value, int64(rec.MethodNum), rec.Purpose, rec.Error)
+	return err
+}
+
+// Query returns up to limit records matching filter following the record after, or from the first one when after
+// is 0, in the order they were written.
+func (l *AuditLog) Query(ctx context.Context, filter types.SignAuditFilter, after uint64, limit int) (*types.SignAuditPage, error) {
+	conds := []string{"id > ?"}
+	args := []interface{}{int64(after)}
+	if filter.Signer != address.Undef {
+		conds = append(conds, "signer = ?")
+		args = append(args, filter.Signer.String())
+	}
+	if filter.Token != "" {
+		conds = append(conds, "token = ?")
+		args = append(args, filter.Token)
+	}
+	if filter.Method != "" {
+		conds = append(conds, "method = ?")
+		args = append(args, filter.Method)
+	}
+	if !filter.Since.IsZero() {
+		conds = append(conds, "time >= ?")
+		args = append(args, filter.Since.UnixNano())
+	}
+	if !filter.Until.IsZero() {
+		conds = append(conds, "time < ?")
+		args = append(args, filter.Until.UnixNano())
+	}
+	args = append(args, limit)
+
+	rows, err := l.db.QueryContext(ctx, selectRecords+" WHERE "+strings.Join(conds, " AND ")+" ORDER BY id LIMIT ?", args...)
+	if err != nil {
+		return nil, err
+	}
+	records, err := scanRecords(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &types.SignAuditPage{Records: records}
+	if len(records) == limit {
+		page.Next = records[limit-1].ID
+	}
+	if page.Records == nil {
+		page.Records = []types.SignAuditRecord{}
+	}
+	return page, nil
+}
+
+func scanRecords(rows *sql.Rows) ([]types.SignAuditRecord, error) {
+	defer rows.Close() //nolint:errcheck
+
+	var out []types.SignAuditRecord
+	for rows.Next() {
+		var (
+			id, ts, nonce, methodNum                                      int64
+			token, method, signer, msgType, msgCid, to, value, purpose, e string
+		)
+		if err := rows.Scan(&id, &ts, &token, &method, &signer, &msgType, &msgCid, &to, &nonce, &value, &methodNum,
+			&purpose, &e); err != nil {
+			return nil, err
+		}
+
+		rec := types.SignAuditRecord{
+			ID:        uint64(id),
+			Time:      time.Unix(0, ts),
+			Token:     token,
+			Method:    method,
+			MsgType:   types.MsgType(msgType),
+			Nonce:     uint64(nonce),
+			MethodNum: abi.MethodNum(methodNum),
+			Purpose:   purpose,
+			Error:     e,
+		}
+		var err error
+		if signer != "" {
+			if rec.Signer, err = address.NewFromString(signer); err != nil {
+				return nil, err
+			}
+		}
+		if msgCid != "" {
+			if rec.MsgCid, err = cid.Decode(msgCid); err != nil {
+				return nil, err
+			}
+		}
+		if to != "" {
+			if rec.To, err = address.NewFromString(to); err != nil {
+				return nil, err
+			}
+		}
+		if value != "" {
+			if rec.Value, err = big.FromString(value); err != nil {
+				return nil, err
+			}
+		}
+		out = append(out, rec)
+	}
+	return out, rows.Err()
+}
+
+// Prune deletes the records written before before.
+func (l *AuditLog) Prune(before time.Time) (int64, error) {
+	res, err := l.db.Exec("DELETE FROM sign_audit WHERE time < ?", before.UnixNano())
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func (l *AuditLog) pruneLoop(ctx context.Context) {
+	defer close(l.done)
+	if l.retention <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+	for {
+		n, err := l.Prune(time.Now().Add(-l.retention))
+		if err != nil {
+			log.Errorf("failed to prune the signing audit log: %s", err)
+		} else if n > 0 {
+			log.Infof("pruned %d records of the signing audit log", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Close stops pruning the records and closes the database.
+func (l *AuditLog) Close() error {
+	if l == nil {
+		return nil
+	}
+	l.cancel()
+	<-l.done
+	return l.db.Close()
+}
@@ -0,0 +1,98 @@
+package signaudit
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs-force-community/sophon-auth/core"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestAuditLog(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "sign_audit.db")
+	l, err := NewAuditLog(path, 0)
+	require.NoError(t, err)
+
+	msg := &types.Message{
+		From:   address.TestAddress,
+		To:     address.TestAddress2,
+		Nonce:  3,
+		Value:  abi.NewTokenAmount(100),
+		Method: 2,
+	}
+	rec := MessageRecord("MpoolPush", msg)
+	rec.Purpose = "payout"
+	l.Record(core.CtxWithName(ctx, "exchange"), rec)
+	l.Record(ctx, types.SignAuditRecord{Method: "WalletSign", Signer: address.TestAddress2, MsgType: types.MTBlock, Error: "locked"})
+	for i := 0; i < 3; i++ {
+		l.Record(ctx, MessageRecord("WalletSignMessage", msg))
+	}
+
+	page, err := l.Query(ctx, types.SignAuditFilter{}, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, page.Records, 5)
+	require.Zero(t, page.Next)
+	got := page.Records[0]
+	require.Equal(t, uint64(1), got.ID)
+	require.Equal(t, "exchange", got.Token)
+	require.Equal(t, msg.Cid(), got.MsgCid)
+	require.Equal(t, msg.To, got.To)
+	require.Equal(t, msg.Nonce, got.Nonce)
+	require.Equal(t, msg.Value, got.Value)
+	require.Equal(t, msg.Method, got.MethodNum)
+	require.Equal(t, "payout", got.Purpose)
+	got = page.Records[1]
+	require.Equal(t, types.MTBlock, got.MsgType)
+	require.Equal(t, address.Undef, got.To)
+	require.Equal(t, "locked", got.Error)
+
+	// filters
+	page, err = l.Query(ctx, types.SignAuditFilter{Signer: address.TestAddress2}, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, page.Records, 1)
+	page, err = l.Query(ctx, types.SignAuditFilter{Token: "exchange"}, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, page.Records, 1)
+	page, err = l.Query(ctx, types.SignAuditFilter{Until: got.Time}, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, page.Records, 1)
+
+	// paging
+	page, err = l.Query(ctx, types.SignAuditFilter{Method: "WalletSignMessage"}, 0, 2)
+	require.NoError(t, err)
+	require.Len(t, page.Records, 2)
+	require.Equal(t, uint64(4), page.Next)
+	page, err = l.Query(ctx, types.SignAuditFilter{Method: "WalletSignMessage"}, page.Next, 2)
+	require.NoError(t, err)
+	require.Len(t, page.Records, 1)
+	require.Equal(t, uint64(5), page.Records[0].ID)
+
+	// retention
+	n, err := l.Prune(got.Time.Add(time.Nanosecond))
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+	require.NoError(t, l.Close())
+
+	// the records older than the retention are pruned once the log is opened
+	l, err = NewAuditLog(path, time.Nanosecond)
+	require.NoError(t, err)
+	defer l.Close() //nolint:errcheck
+	require.Eventually(t, func() bool {
+		page, err := l.Query(ctx, types.SignAuditFilter{}, 0, 10)
+		return err == nil && len(page.Records) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	var nilLog *AuditLog
+	nilLog.Record(ctx, rec)
+	require.NoError(t, nilLog.Close())
+}
@@ -0,0 +1,69 @@
+package state
+
+import (
+	"context"
+
+	addr "github.com/filecoin-project/go-address"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/ipfs-force-community/metrics"
+	"github.com/ipfs/go-cid"
+)
+
+var (
+	actorStateCacheHit  = metrics.NewCounter("state/actor_state_cache_hit", "Number of market, power and verifreg actor states served from the cache")
+	actorStateCacheMiss = metrics.NewCounter("state/actor_state_cache_miss", "Number of market, power and verifreg actor states loaded from the blockstore")
+)
+
+type actorStateKey struct {
+	root  cid.Cid
+	actor addr.Address
+}
+
+// ActorStateCache caches the loaded states of the market, power and verifreg actors by state root,
+// so that the views of the same tipset share them across API calls instead of loading them again.
+type ActorStateCache struct {
+	cache *lru.Cache[actorStateKey, interface{}]
+}
+
+// NewActorStateCache returns a cache holding up to size actor states, nil if size is not positive,
+// a nil cache is valid and caches nothing.
+func NewActorStateCache(size int) (*ActorStateCache, error) {
+	if size <= 0 {
+		return nil, nil
+	}
+	cache, err := lru.New[actorStateKey, interface{}](size)
+	if err != nil {
+		return nil, err
+	}
+	return &ActorStateCache{cache: cache}, nil
+}
+
+// Purge drops all the cached states, it is called when the chain head changes.
+func (c *ActorStateCache) Purge() {
+	if c == nil {
+		return
+	}
+	c.cache.Purge()
+}
+
+// cachedActorState returns the state of actor at root from c, or loads it. The states are loaded
+// with a background context, since they outlive the call loading them.
+func cachedActorState[T any](ctx context.Context, c *ActorStateCache, root cid.Cid, actor addr.Address, load func(context.Context) (T, error)) (T, error) {
+	if c == nil {
+		return load(ctx)
+	}
+
+	key := actorStateKey{root: root, actor: actor}
+	if v, ok := c.cache.Get(key); ok {
+		actorStateCacheHit.Tick(ctx)
+		return v.(T), nil
+	}
+	actorStateCacheMiss.Tick(ctx)
+
+	st, err := load(context.Background())
+	if err != nil {
+		return st, err
+	}
+	c.cache.Add(key, st)
+	return st, nil
+}
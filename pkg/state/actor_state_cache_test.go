@@ -0,0 +1,62 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/testutil"
+)
+
+func TestActorStateCache(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	root := testutil.CidProvider(32)(t)
+
+	loads := 0
+	load := func(context.Context) (int, error) {
+		loads++
+		return loads, nil
+	}
+
+	cache, err := NewActorStateCache(8)
+	require.NoError(t, err)
+
+	v, err := cachedActorState(ctx, cache, root, address.TestAddress, load)
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+	v, err = cachedActorState(ctx, cache, root, address.TestAddress, load)
+	require.NoError(t, err)
+	assert.Equal(t, 1, v, "second load is served from the cache")
+
+	v, err = cachedActorState(ctx, cache, root, address.TestAddress2, load)
+	require.NoError(t, err)
+	assert.Equal(t, 2, v, "other actors are loaded")
+
+	cache.Purge()
+	v, err = cachedActorState(ctx, cache, root, address.TestAddress, load)
+	require.NoError(t, err)
+	assert.Equal(t, 3, v, "purged states are loaded again")
+
+	_, err = cachedActorState(ctx, cache, root, address.Undef, func(context.Context) (int, error) {
+		return 0, errors.New("boom")
+	})
+	require.Error(t, err)
+	_, ok := cache.cache.Get(actorStateKey{root: root, actor: address.Undef})
+	assert.False(t, ok, "errors are not cached")
+
+	disabled, err := NewActorStateCache(0)
+	require.NoError(t, err)
+	assert.Nil(t, disabled)
+	disabled.Purge()
+	for i := 4; i < 6; i++ {
+		v, err = cachedActorState(ctx, disabled, root, address.TestAddress, load)
+		require.NoError(t, err)
+		assert.Equal(t, i, v)
+	}
+}
@@ -0,0 +1,124 @@
+package state
+
+import (
+	"bytes"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/ipfs/go-cid"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/venus/pkg/constants"
+	lminer "github.com/filecoin-project/venus/venus-shared/actors/builtin/miner"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// SectorCountCacheSize is the number of miner states and deadlines whose sector counts are cached.
+const SectorCountCacheSize = 1 << 14
+
+// SectorCountCache caches the sector counts of the miner states and of their deadlines by the cid of the state
+// and of the deadline, so that the sectors of a miner are only counted again in the deadlines which changed,
+// instead of walking every partition of the miner on every call.
+type SectorCountCache struct {
+	cache *lru.Cache[cid.Cid, types.MinerSectors]
+}
+
+// NewSectorCountCache returns a cache holding up to size counts, a nil cache is valid and caches nothing.
+func NewSectorCountCache(size int) (*SectorCountCache, error) {
+	cache, err := lru.New[cid.Cid, types.MinerSectors](size)
+	if err != nil {
+		return nil, err
+	}
+	return &SectorCountCache{cache: cache}, nil
+}
+
+// MinerSectors returns the number of live, active and faulty sectors of the miner state mas.
+func (c *SectorCountCache) MinerSectors(mas lminer.State) (types.MinerSectors, error) {
+	key, cached := c.get(mas)
+	if cached != nil {
+		return *cached, nil
+	}
+
+	var out types.MinerSectors
+	if err := mas.ForEachDeadline(func(_ uint64, dl lminer.Deadline) error {
+		counts, err := c.deadlineSectors(dl)
+		if err != nil {
+			return err
+		}
+		out.Live += counts.Live
+		out.Active += counts.Active
+		out.Faulty += counts.Faulty
+		return nil
+	}); err != nil {
+		return types.MinerSectors{}, err
+	}
+	c.add(key, out)
+	return out, nil
+}
+
+func (c *SectorCountCache) deadlineSectors(dl lminer.Deadline) (types.MinerSectors, error) {
+	key, cached := c.get(dl)
+	if cached != nil {
+		return *cached, nil
+	}
+
+	var out types.MinerSectors
+	if err := dl.ForEachPartition(func(_ uint64, part lminer.Partition) error {
+		if active, err := part.ActiveSectors(); err != nil {
+			return err
+		} else if count, err := active.Count(); err != nil {
+			return err
+		} else {
+			out.Active += count
+		}
+		if live, err := part.LiveSectors(); err != nil {
+			return err
+		} else if count, err := live.Count(); err != nil {
+			return err
+		} else {
+			out.Live += count
+		}
+		if faulty, err := part.FaultySectors(); err != nil {
+			return err
+		} else if count, err := faulty.Count(); err != nil {
+			return err
+		} else {
+			out.Faulty += count
+		}
+		return nil
+	}); err != nil {
+		return types.MinerSectors{}, err
+	}
+	c.add(key, out)
+	return out, nil
+}
+
+// get returns the cid of the encoding of obj, which is the cid of the object in the state tree, and the counts
+// cached for it. The cid is undefined when c is nil or obj has no encoding.
+func (c *SectorCountCache) get(obj interface{}) (cid.Cid, *types.MinerSectors) {
+	if c == nil {
+		return cid.Undef, nil
+	}
+	m, ok := obj.(cbg.CBORMarshaler)
+	if !ok {
+		return cid.Undef, nil
+	}
+	buf := new(bytes.Buffer)
+	if err := m.MarshalCBOR(buf); err != nil {
+		return cid.Undef, nil
+	}
+	key, err := constants.DefaultCidBuilder.Sum(buf.Bytes())
+	if err != nil {
+		return cid.Undef, nil
+	}
+	if counts, ok := c.cache.Get(key); ok {
+		return key, &counts
+	}
+	return key, nil
+}
+
+func (c *SectorCountCache) add(key cid.Cid, counts types.MinerSectors) {
+	if c == nil || !key.Defined() {
+		return
+	}
+	c.cache.Add(key, counts)
+}
@@ -0,0 +1,122 @@
+package state
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	lminer "github.com/filecoin-project/venus/venus-shared/actors/builtin/miner"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+type fakePartition struct {
+	lminer.Partition
+	live, active, faulty bitfield.BitField
+}
+
+func (p *fakePartition) LiveSectors() (bitfield.BitField, error)   { return p.live, nil }
+func (p *fakePartition) ActiveSectors() (bitfield.BitField, error) { return p.active, nil }
+func (p *fakePartition) FaultySectors() (bitfield.BitField, error) { return p.faulty, nil }
+
+// fakeDeadline is encoded as its name, the deadlines of the same name have the same cid.
+type fakeDeadline struct {
+	lminer.Deadline
+	name       string
+	partitions []*fakePartition
+	walks      *int
+}
+
+func (d *fakeDeadline) ForEachPartition(cb func(uint64, lminer.Partition) error) error {
+	*d.walks++
+	for i, p := range d.partitions {
+		if err := cb(uint64(i), p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *fakeDeadline) MarshalCBOR(w io.Writer) error {
+	_, err := w.Write([]byte(d.name))
+	return err
+}
+
+type fakeMinerState struct {
+	lminer.State
+	deadlines []*fakeDeadline
+}
+
+func (s *fakeMinerState) ForEachDeadline(cb func(uint64, lminer.Deadline) error) error {
+	for i, dl := range s.deadlines {
+		if err := cb(uint64(i), dl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *fakeMinerState) MarshalCBOR(w io.Writer) error {
+	for _, dl := range s.deadlines {
+		if _, err := fmt.Fprintf(w, "%s,", dl.name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newFakeDeadline(name string, walks *int, live, faulty []uint64) *fakeDeadline {
+	liveBf, faultyBf := bitfield.NewFromSet(live), bitfield.NewFromSet(faulty)
+	active, err := bitfield.SubtractBitField(liveBf, faultyBf)
+	if err != nil {
+		panic(err)
+	}
+	return &fakeDeadline{
+		name:       name,
+		partitions: []*fakePartition{{live: liveBf, active: active, faulty: faultyBf}},
+		walks:      walks,
+	}
+}
+
+func TestSectorCountCache(t *testing.T) {
+	tf.UnitTest(t)
+
+	cache, err := NewSectorCountCache(16)
+	require.NoError(t, err)
+
+	var walks int
+	mas := &fakeMinerState{deadlines: []*fakeDeadline{
+		newFakeDeadline("a", &walks, []uint64{1, 2, 3}, []uint64{2}),
+		newFakeDeadline("b", &walks, []uint64{4, 5}, nil),
+	}}
+	counts, err := cache.MinerSectors(mas)
+	require.NoError(t, err)
+	assert.Equal(t, types.MinerSectors{Live: 5, Active: 4, Faulty: 1}, counts)
+	assert.Equal(t, 2, walks)
+
+	// the same state is not counted again
+	_, err = cache.MinerSectors(mas)
+	require.NoError(t, err)
+	assert.Equal(t, 2, walks)
+
+	// a new state only counts the deadline which changed
+	mas = &fakeMinerState{deadlines: []*fakeDeadline{
+		mas.deadlines[0],
+		newFakeDeadline("b2", &walks, []uint64{4, 5, 6}, []uint64{6}),
+	}}
+	counts, err = cache.MinerSectors(mas)
+	require.NoError(t, err)
+	assert.Equal(t, types.MinerSectors{Live: 6, Active: 4, Faulty: 2}, counts)
+	assert.Equal(t, 3, walks)
+
+	// a nil cache counts every time
+	var nilCache *SectorCountCache
+	counts, err = nilCache.MinerSectors(mas)
+	require.NoError(t, err)
+	assert.Equal(t, types.MinerSectors{Live: 6, Active: 4, Faulty: 2}, counts)
+	assert.Equal(t, 5, walks)
+}
@@ -0,0 +1,143 @@
+package tree
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/filecoin-project/go-address"
+	hamt "github.com/filecoin-project/go-hamt-ipld/v3"
+	builtintypes "github.com/filecoin-project/go-state-types/builtin"
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// ErrCursorNotFound is returned by ForEachFrom when the cursor is not an actor of the state.
+var ErrCursorNotFound = errors.New("cursor is not an actor of the state")
+
+// ForEachFrom calls f on the actors following cursor, in the order of ForEach. The state trees of
+// version 2 and above without pending changes are seeked to the cursor, so a large state can be
+// walked by pages without loading the part preceding each page.
+func (st *State) ForEachFrom(ctx context.Context, cursor ActorKey, f func(ActorKey, *types.Actor) error) error {
+	if st.version < StateTreeVersion2 || st.hasPendingChanges() {
+		found := false
+		err := st.ForEach(func(addr ActorKey, act *types.Actor) error {
+			if !found {
+				found = addr == cursor
+				return nil
+			}
+			return f(addr, act)
+		})
+		if err == nil && !found {
+			return ErrCursorNotFound
+		}
+		return err
+	}
+
+	root, err := st.root.Root()
+	if err != nil {
+		return err
+	}
+	return forEachHAMTFrom(ctx, st.Store, root, builtintypes.DefaultHamtBitwidth, cursor.Bytes(), func(k []byte, val *cbg.Deferred) error {
+		addr, err := address.NewFromBytes(k)
+		if err != nil {
+			return fmt.Errorf("invalid address (%x) found in state tree key: %w", k, err)
+		}
+		if st.version <= StateTreeVersion4 {
+			var act types.ActorV4
+			if err := act.UnmarshalCBOR(bytes.NewReader(val.Raw)); err != nil {
+				return err
+			}
+			return f(addr, types.AsActorV5(&act))
+		}
+		var act types.Actor
+		if err := act.UnmarshalCBOR(bytes.NewReader(val.Raw)); err != nil {
+			return err
+		}
+		return f(addr, &act)
+	})
+}
+
+func (st *State) hasPendingChanges() bool {
+	for _, layer := range st.snaps.layers {
+		if len(layer.actors) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// forEachHAMTFrom calls f on the pairs following key in the sha256 keyed hamt rooted at root, in the
+// order of hamt.Node.ForEach. Only the nodes on the path of key and after it are loaded.
+func forEachHAMTFrom(ctx context.Context, cst cbor.IpldStore, root cid.Cid, bitWidth int, key []byte, f func(k []byte, val *cbg.Deferred) error) error {
+	var nd hamt.Node
+	if err := cst.Get(ctx, root, &nd); err != nil {
+		return err
+	}
+	hv := sha256.Sum256(key)
+	return walkHAMTFrom(ctx, cst, &nd, bitWidth, hv[:], 0, key, f)
+}
+
+// walkHAMTFrom walks the pairs of nd at depth following key, the pairs of nd are all walked when key is nil.
+func walkHAMTFrom(ctx context.Context, cst cbor.IpldStore, nd *hamt.Node, bitWidth int, hv []byte, depth int, key []byte, f func(k []byte, val *cbg.Deferred) error) error {
+	start := 0
+	if key != nil {
+		// the pointers are ordered by the index of their hash bits, the ones before key's are skipped
+		idx := hashIndex(hv, depth, bitWidth)
+		if nd.Bitfield.Bit(idx) == 0 {
+			return ErrCursorNotFound
+		}
+		for i := 0; i < idx; i++ {
+			start += int(nd.Bitfield.Bit(i))
+		}
+	}
+
+	for i := start; i < len(nd.Pointers); i++ {
+		p := nd.Pointers[i]
+		from := key
+		if i != start {
+			from = nil
+		}
+
+		if p.Link.Defined() {
+			var child hamt.Node
+			if err := cst.Get(ctx, p.Link, &child); err != nil {
+				return err
+			}
+			if err := walkHAMTFrom(ctx, cst, &child, bitWidth, hv, depth+1, from, f); err != nil {
+				return err
+			}
+			continue
+		}
+
+		kvs := p.KVs
+		if from != nil {
+			at := slices.IndexFunc(kvs, func(kv *hamt.KV) bool { return bytes.Equal(kv.Key, from) })
+			if at < 0 {
+				return ErrCursorNotFound
+			}
+			kvs = kvs[at+1:]
+		}
+		for _, kv := range kvs {
+			if err := f(kv.Key, kv.Value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// hashIndex returns the bitWidth bits of hv used at depth, read from the most significant bit as the hamt does.
+func hashIndex(hv []byte, depth, bitWidth int) int {
+	idx := 0
+	for b := depth * bitWidth; b < (depth+1)*bitWidth; b++ {
+		idx = idx<<1 | int(hv[b/8]>>(7-b%8)&1)
+	}
+	return idx
+}
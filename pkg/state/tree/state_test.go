@@ -234,3 +234,50 @@ func TestStateTreeConsistency(t *testing.T) {
 		t.Fatalf("state state Mismatch. Expected: bafy2bzaceamis23jp44ofm4fh6jwc4gkxlzhnvxrdw4zsn3v2fj6at6pf2m4y Actual: %s", root.String())
 	}
 }
+
+func TestStateForEachFrom(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	cst := cbor.NewMemCborStore()
+	st, err := NewState(cst, StateTreeVersion5)
+	require.NoError(t, err)
+
+	code, err := cid.Decode("bafy2bzacecu7n7wbtogznrtuuvf73dsz7wasgyneqasksdblxupnyovmtwxxu")
+	require.NoError(t, err)
+	for i := 0; i < 500; i++ {
+		addr, err := address.NewIDAddress(uint64(1000 + i))
+		require.NoError(t, err)
+		require.NoError(t, st.SetActor(ctx, addr, &types.Actor{Code: code, Head: code, Balance: abi.NewTokenAmount(int64(i))}))
+	}
+
+	collect := func(walk func(f func(ActorKey, *types.Actor) error) error) []address.Address {
+		var out []address.Address
+		require.NoError(t, walk(func(addr ActorKey, _ *types.Actor) error {
+			out = append(out, addr)
+			return nil
+		}))
+		return out
+	}
+	from := func(st *State, cursor ActorKey) func(f func(ActorKey, *types.Actor) error) error {
+		return func(f func(ActorKey, *types.Actor) error) error {
+			return st.ForEachFrom(ctx, cursor, f)
+		}
+	}
+
+	root, err := st.Flush(ctx)
+	require.NoError(t, err)
+	st, err = LoadState(ctx, cst, root)
+	require.NoError(t, err)
+
+	all := collect(st.ForEach)
+	require.Len(t, all, 500)
+	for i := 0; i < len(all); i += 23 {
+		assert.Equal(t, all[i+1:], collect(from(st, all[i])), "cursor %s", all[i])
+	}
+	assert.Empty(t, collect(from(st, all[len(all)-1])))
+
+	missing, err := address.NewIDAddress(1)
+	require.NoError(t, err)
+	assert.ErrorIs(t, st.ForEachFrom(ctx, missing, func(ActorKey, *types.Actor) error { return nil }), ErrCursorNotFound)
+}
@@ -56,6 +56,7 @@ func (c *Viewer) StateView(root cid.Cid) *View {
 type View struct {
 	ipldStore cbor.IpldStore
 	root      cid.Cid
+	cache     *ActorStateCache
 }
 
 // NewView creates a new state view
@@ -66,6 +67,12 @@ func NewView(store cbor.IpldStore, root cid.Cid) *View {
 	}
 }
 
+// WithCache makes the view share the market, power and verifreg actor states through cache.
+func (v *View) WithCache(cache *ActorStateCache) *View {
+	v.cache = cache
+	return v
+}
+
 // InitNetworkName Returns the network name from the init actor state.
 func (v *View) InitNetworkName(ctx context.Context) (string, error) {
 	initState, err := v.LoadInitState(ctx)
@@ -91,7 +98,7 @@ func (v *View) InitResolveAddress(ctx context.Context, a addr.Address) (addr.Add
 	}
 
 	if !found {
-		return addr.Undef, fmt.Errorf("not found resolve address %v", a)
+		return addr.Undef, fmt.Errorf("not found resolve address %v: %w", a, types.ErrActorNotFound)
 	}
 
 	return rAddr, nil
@@ -607,38 +614,44 @@ func (v *View) StateMinerPower(ctx context.Context, maddr addr.Address, tsk type
 // StateMarketDeals returns information about every deal in the Storage Market
 func (v *View) StateMarketDeals(ctx context.Context, tsk types.TipSetKey) (map[string]*types.MarketDeal, error) {
 	out := map[string]*types.MarketDeal{}
+	if err := v.ForEachMarketDeal(ctx, func(dealID abi.DealID, deal *types.MarketDeal) error {
+		out[strconv.FormatInt(int64(dealID), 10)] = deal
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
+// ForEachMarketDeal calls cb on the deals of the market by increasing id.
+func (v *View) ForEachMarketDeal(ctx context.Context, cb func(dealID abi.DealID, deal *types.MarketDeal) error) error {
 	state, err := v.LoadMarketState(ctx)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	da, err := state.Proposals()
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	sa, err := state.States()
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	if err := da.ForEach(func(dealID abi.DealID, d market.DealProposal) error {
+	return da.ForEach(func(dealID abi.DealID, d market.DealProposal) error {
 		s, found, err := sa.Get(dealID)
 		if err != nil {
 			return fmt.Errorf("failed to get state for deal in proposals array: %v", err)
 		} else if !found {
 			s = market.EmptyDealState()
 		}
-		out[strconv.FormatInt(int64(dealID), 10)] = &types.MarketDeal{
+		return cb(dealID, &types.MarketDeal{
 			Proposal: d,
 			State:    types.MakeDealState(s),
-		}
-		return nil
-	}); err != nil {
-		return nil, err
-	}
-	return out, nil
+		})
+	})
 }
 
 // StateMinerActiveSectors returns info about sectors that a given miner is actively proving.
@@ -743,21 +756,25 @@ func (v *View) LoadMinerState(ctx context.Context, maddr addr.Address) (lminer.S
 }
 
 func (v *View) LoadPowerActor(ctx context.Context) (power.State, error) {
-	actr, err := v.loadActor(ctx, power.Address)
-	if err != nil {
-		return nil, err
-	}
+	return cachedActorState(ctx, v.cache, v.root, power.Address, func(ctx context.Context) (power.State, error) {
+		actr, err := v.loadActor(ctx, power.Address)
+		if err != nil {
+			return nil, err
+		}
 
-	return power.Load(adt.WrapStore(ctx, v.ipldStore), actr)
+		return power.Load(adt.WrapStore(ctx, v.ipldStore), actr)
+	})
 }
 
 func (v *View) LoadVerifregActor(ctx context.Context) (verifreg.State, error) {
-	actr, err := v.loadActor(ctx, verifreg.Address)
-	if err != nil {
-		return nil, err
-	}
+	return cachedActorState(ctx, v.cache, v.root, verifreg.Address, func(ctx context.Context) (verifreg.State, error) {
+		actr, err := v.loadActor(ctx, verifreg.Address)
+		if err != nil {
+			return nil, err
+		}
 
-	return verifreg.Load(adt.WrapStore(ctx, v.ipldStore), actr)
+		return verifreg.Load(adt.WrapStore(ctx, v.ipldStore), actr)
+	})
 }
 
 // nolint
@@ -772,21 +789,25 @@ func (v *View) LoadRewardState(ctx context.Context) (reward.State, error) {
 
 // nolint
 func (v *View) LoadPowerState(ctx context.Context) (power.State, error) {
-	actr, err := v.loadActor(ctx, power.Address)
-	if err != nil {
-		return nil, err
-	}
+	return cachedActorState(ctx, v.cache, v.root, power.Address, func(ctx context.Context) (power.State, error) {
+		actr, err := v.loadActor(ctx, power.Address)
+		if err != nil {
+			return nil, err
+		}
 
-	return power.Load(adt.WrapStore(ctx, v.ipldStore), actr)
+		return power.Load(adt.WrapStore(ctx, v.ipldStore), actr)
+	})
 }
 
 func (v *View) LoadMarketState(ctx context.Context) (market.State, error) {
-	actr, err := v.loadActor(ctx, market.Address)
-	if err != nil {
-		return nil, err
-	}
+	return cachedActorState(ctx, v.cache, v.root, market.Address, func(ctx context.Context) (market.State, error) {
+		actr, err := v.loadActor(ctx, market.Address)
+		if err != nil {
+			return nil, err
+		}
 
-	return market.Load(adt.WrapStore(ctx, v.ipldStore), actr)
+		return market.Load(adt.WrapStore(ctx, v.ipldStore), actr)
+	})
 }
 
 func (v *View) LoadDatacapState(ctx context.Context) (datacap.State, error) {
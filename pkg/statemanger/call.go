@@ -55,7 +55,22 @@ func (s *Stmgr) Call(ctx context.Context, msg *types.Message, ts *types.TipSet)
 		msg.Value = types.NewInt(0)
 	}
 
-	return s.callInternal(ctx, msg, nil, ts, cid.Undef, s.GetNetworkVersion, false, execSameSenderMessages)
+	// the head moves, only the calls on a given tipset are cached
+	cache := s.callCache
+	if cache == nil || ts == nil {
+		return s.callInternal(ctx, msg, nil, ts, cid.Undef, s.GetNetworkVersion, false, execSameSenderMessages)
+	}
+
+	msgCid := msg.Cid()
+	if res, ok := cache.get(ctx, msgCid, ts); ok {
+		return res, nil
+	}
+	res, err := s.callInternal(ctx, msg, nil, ts, cid.Undef, s.GetNetworkVersion, false, execSameSenderMessages)
+	if err != nil {
+		return nil, err
+	}
+	cache.add(msgCid, ts, res)
+	return res, nil
 }
 
 // ApplyOnStateWithGas applies the given message on top of the given state root with gas tracing enabled
@@ -182,12 +197,8 @@ func (s *Stmgr) callInternal(ctx context.Context,
 		TipSetGetter:        vmcontext.TipSetGetterForTipset(s.cs.GetTipSetByHeight, ts),
 		Tracing:             true,
 		ActorDebugging:      s.actorDebugging,
+		ExecutionLane:       vmcontext.ExecutionLaneAPI,
 	}
-	vmi, err := fvm.NewVM(ctx, vmopt)
-	if err != nil {
-		return nil, fmt.Errorf("failed to set up vm: %w", err)
-	}
-
 	switch strategy {
 	case execNoMessages:
 		// Do nothing
@@ -208,6 +219,14 @@ func (s *Stmgr) callInternal(ctx context.Context,
 			}
 			priorMsgs = append(filteredTSMsgs, priorMsgs...)
 		}
+	}
+
+	var vmi vm.Interface
+	if len(priorMsgs) > 0 {
+		vmi, err = fvm.NewVM(ctx, vmopt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set up vm: %w", err)
+		}
 		for i, m := range priorMsgs {
 			_, err = vmi.ApplyMessage(ctx, m)
 			if err != nil {
@@ -240,10 +259,18 @@ func (s *Stmgr) callInternal(ctx context.Context,
 		// Now estimate with a new VM with no base fee.
 		vmopt.BaseFee = big.Zero()
 		vmopt.PRoot = stateCid
-
-		vmi, err = fvm.NewVM(ctx, vmopt)
+		vmi = nil
+	}
+	if vmi == nil {
+		// the state of the pooled machines only lives in the chain blockstore, the state of the
+		// prior messages is only in the buffer of this call
+		if pool := s.callVMPool; pool != nil && len(priorMsgs) == 0 {
+			vmi, err = pool.get(ctx, ts.Key(), vmopt)
+		} else {
+			vmi, err = fvm.NewVM(ctx, vmopt)
+		}
 		if err != nil {
-			return nil, fmt.Errorf("failed to set up estimation vm: %w", err)
+			return nil, fmt.Errorf("failed to set up vm: %w", err)
 		}
 	}
 
@@ -295,7 +322,7 @@ func (s *Stmgr) callInternal(ctx context.Context,
 		errs = ret.ActorErr.Error()
 	}
 
-	return &types.InvocResult{
+	ir := &types.InvocResult{
 		MsgCid:         msg.Cid(),
 		Msg:            msg,
 		MsgRct:         &ret.Receipt,
@@ -303,5 +330,7 @@ func (s *Stmgr) callInternal(ctx context.Context,
 		ExecutionTrace: ret.GasTracker.ExecutionTrace,
 		Error:          errs,
 		Duration:       ret.Duration,
-	}, err
+	}
+	ir.DecodeExit()
+	return ir, err
 }
@@ -0,0 +1,69 @@
+package statemanger
+
+import (
+	"context"
+	"encoding/json"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/ipfs-force-community/metrics"
+	"github.com/ipfs/go-cid"
+	"go.opencensus.io/tag"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+var callCacheRequests = metrics.NewCounter("stmgr/call_cache", "Number of calls looked up in the call result cache, by hit or miss", tagKeyResult)
+
+type callCacheKey struct {
+	msg cid.Cid
+	tsk types.TipSetKey
+}
+
+// callCache keeps the results of the calls, the result of a message being only determined by the
+// message and the tipset it is executed on. The results are kept encoded, so that the callers each get
+// a result of their own, down to the receipt and the execution trace.
+type callCache struct {
+	results *lru.Cache[callCacheKey, []byte]
+}
+
+func newCallCache(size int) (*callCache, error) {
+	results, err := lru.New[callCacheKey, []byte](size)
+	if err != nil {
+		return nil, err
+	}
+	return &callCache{results: results}, nil
+}
+
+// get returns the result of msg on ts, decoded for the caller.
+func (c *callCache) get(ctx context.Context, msg cid.Cid, ts *types.TipSet) (*types.InvocResult, bool) {
+	data, ok := c.results.Get(callCacheKey{msg: msg, tsk: ts.Key()})
+
+	var res types.InvocResult
+	if ok {
+		if err := json.Unmarshal(data, &res); err != nil {
+			log.Warnf("decoding the cached result of %s: %v", msg, err)
+			ok = false
+		}
+	}
+
+	result := "miss"
+	if ok {
+		result = "hit"
+	}
+	ctx, _ = tag.New(ctx, tag.Upsert(tagKeyResult, result))
+	callCacheRequests.Tick(ctx)
+
+	if !ok {
+		return nil, false
+	}
+	return &res, true
+}
+
+func (c *callCache) add(msg cid.Cid, ts *types.TipSet, res *types.InvocResult) {
+	data, err := json.Marshal(res)
+	if err != nil {
+		log.Warnf("encoding the result of %s: %v", msg, err)
+		return
+	}
+	c.results.Add(callCacheKey{msg: msg, tsk: ts.Key()}, data)
+}
@@ -0,0 +1,106 @@
+package statemanger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestCallCache(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	from, err := address.NewIDAddress(100)
+	require.NoError(t, err)
+	to, err := address.NewIDAddress(101)
+	require.NoError(t, err)
+	root, err := abi.CidBuilder.Sum([]byte("state"))
+	require.NoError(t, err)
+
+	newTipSet := func(height abi.ChainEpoch) *types.TipSet {
+		ts, err := types.NewTipSet([]*types.BlockHeader{{
+			Miner:                 from,
+			Ticket:                &types.Ticket{VRFProof: []byte{byte(height)}},
+			ParentWeight:          big.Zero(),
+			Height:                height,
+			ParentStateRoot:       root,
+			ParentMessageReceipts: root,
+			Messages:              root,
+			ParentBaseFee:         big.Zero(),
+		}})
+		require.NoError(t, err)
+		return ts
+	}
+	ts, other := newTipSet(1), newTipSet(2)
+
+	msg := &types.Message{From: from, To: to, Value: big.NewInt(10), GasLimit: 1000, GasFeeCap: big.Zero(), GasPremium: big.Zero()}
+	newResult := func() *types.InvocResult {
+		return &types.InvocResult{
+			MsgCid: msg.Cid(),
+			Msg:    msg,
+			MsgRct: &types.MessageReceipt{ExitCode: exitcode.Ok, Return: []byte{1, 2}, GasUsed: 100},
+			GasCost: types.MsgGasCost{
+				Message:            msg.Cid(),
+				GasUsed:            big.NewInt(100),
+				BaseFeeBurn:        big.NewInt(100),
+				OverEstimationBurn: big.Zero(),
+				MinerPenalty:       big.Zero(),
+				MinerTip:           big.Zero(),
+				Refund:             big.NewInt(900),
+				TotalCost:          big.NewInt(1000),
+			},
+			ExecutionTrace: types.ExecutionTrace{
+				Msg:        types.MessageTrace{From: from, To: to, Value: big.NewInt(10)},
+				MsgRct:     types.ReturnTrace{Return: []byte{1, 2}},
+				GasCharges: []*types.GasTrace{{Name: "OnChainMessage", TotalGas: 100}},
+				Subcalls: []types.ExecutionTrace{{
+					Msg:        types.MessageTrace{From: to, To: from, Value: big.Zero()},
+					GasCharges: []*types.GasTrace{{Name: "OnMethodInvocation", TotalGas: 10}},
+				}},
+			},
+			Duration: time.Second,
+		}
+	}
+
+	cache, err := newCallCache(8)
+	require.NoError(t, err)
+
+	// miss
+	_, ok := cache.get(ctx, msg.Cid(), ts)
+	assert.False(t, ok)
+
+	res := newResult()
+	cache.add(msg.Cid(), ts, res)
+
+	// hit
+	got, ok := cache.get(ctx, msg.Cid(), ts)
+	require.True(t, ok)
+	assert.Equal(t, newResult(), got)
+
+	// the results are cached by tipset and message
+	_, ok = cache.get(ctx, msg.Cid(), other)
+	assert.False(t, ok)
+	_, ok = cache.get(ctx, (&types.Message{From: to, To: from}).Cid(), ts)
+	assert.False(t, ok)
+
+	// the callers changing their results don't change the cached one
+	res.MsgRct.GasUsed = 1
+	got.Msg.Nonce = 5
+	got.MsgRct.Return[0] = 9
+	got.GasCost.TotalCost.Int.SetInt64(1)
+	got.ExecutionTrace.GasCharges[0].TotalGas = 1
+	got.ExecutionTrace.Subcalls[0].GasCharges = nil
+	got, ok = cache.get(ctx, msg.Cid(), ts)
+	require.True(t, ok)
+	assert.Equal(t, newResult(), got)
+}
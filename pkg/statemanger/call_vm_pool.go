@@ -0,0 +1,230 @@
+package statemanger
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/network"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/ipfs-force-community/metrics"
+	"github.com/ipfs/go-cid"
+	"go.opencensus.io/tag"
+
+	"github.com/filecoin-project/venus/pkg/state"
+	"github.com/filecoin-project/venus/pkg/state/tree"
+	"github.com/filecoin-project/venus/pkg/vm"
+	"github.com/filecoin-project/venus/pkg/vm/vmcontext"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+var (
+	tagKeyResult = tag.MustNewKey("result")
+
+	callVMPoolRequests = metrics.NewCounter("stmgr/call_vm_pool", "Number of machines requested from the call machine pool, by hit or miss", tagKeyResult)
+)
+
+// the number of states the call machine pool keeps machines for, the calls mostly run on the head
+const callVMPoolStates = 4
+
+// the attempts at creating a machine in the background, and the delay before the first retry
+const (
+	callVMPoolAttempts   = 3
+	callVMPoolRetryDelay = time.Second
+)
+
+// callVMKey identifies the machines which may run a call: the state root with the overrides the call is
+// executed with, and the options of the machine. The functions of the options, bound to the tipset, are
+// the ones of the call the machine is handed to, see callVMExterns.
+type callVMKey struct {
+	tsk            types.TipSetKey
+	root           cid.Cid
+	epoch          abi.ChainEpoch
+	timestamp      uint64
+	baseFee        string
+	nv             network.Version
+	tracing        bool
+	actorDebugging bool
+	returnEvents   bool
+	lane           vmcontext.ExecutionLane
+}
+
+func newCallVMKey(tsk types.TipSetKey, opts vm.VmOption) callVMKey {
+	return callVMKey{
+		tsk:            tsk,
+		root:           opts.PRoot,
+		epoch:          opts.Epoch,
+		timestamp:      opts.Timestamp,
+		baseFee:        opts.BaseFee.String(),
+		nv:             opts.NetworkVersion,
+		tracing:        opts.Tracing,
+		actorDebugging: opts.ActorDebugging,
+		returnEvents:   opts.ReturnEvents,
+		lane:           opts.ExecutionLane,
+	}
+}
+
+// callVMExterns are the functions of the options a pooled machine is created with, they call the
+// functions of the call the machine is handed to, which were unknown when the machine was created.
+// The circulating supply is only computed when creating the machine, from the epoch and the state root
+// of the key.
+type callVMExterns struct {
+	opts vm.VmOption
+}
+
+// bind returns opts with its functions calling the ones of e.
+func (e *callVMExterns) bind(opts vm.VmOption) vm.VmOption {
+	if opts.CircSupplyCalculator != nil {
+		opts.CircSupplyCalculator = func(ctx context.Context, epoch abi.ChainEpoch, tree tree.Tree) (abi.TokenAmount, error) {
+			return e.opts.CircSupplyCalculator(ctx, epoch, tree)
+		}
+	}
+	if opts.LookbackStateGetter != nil {
+		opts.LookbackStateGetter = func(ctx context.Context, round abi.ChainEpoch) (*state.View, error) {
+			return e.opts.LookbackStateGetter(ctx, round)
+		}
+	}
+	if opts.TipSetGetter != nil {
+		opts.TipSetGetter = func(ctx context.Context, round abi.ChainEpoch) (types.TipSetKey, error) {
+			return e.opts.TipSetGetter(ctx, round)
+		}
+	}
+	if opts.Rnd != nil {
+		opts.Rnd = e
+	}
+	return opts
+}
+
+func (e *callVMExterns) GetChainRandomness(ctx context.Context, round abi.ChainEpoch) ([32]byte, error) {
+	return e.opts.Rnd.GetChainRandomness(ctx, round)
+}
+
+func (e *callVMExterns) GetBeaconEntry(ctx context.Context, round abi.ChainEpoch) (*types.BeaconEntry, error) {
+	return e.opts.Rnd.GetBeaconEntry(ctx, round)
+}
+
+func (e *callVMExterns) GetBeaconRandomness(ctx context.Context, round abi.ChainEpoch) ([32]byte, error) {
+	return e.opts.Rnd.GetBeaconRandomness(ctx, round)
+}
+
+type callVM struct {
+	vmi     vm.Interface
+	externs *callVMExterns
+}
+
+type callVMState struct {
+	opts  vm.VmOption
+	ready chan *callVM
+}
+
+// callVMPool keeps machines ready for the read-only calls, like eth_call, executed on the same state.
+// A machine only runs one call, since the call changes the state of the machine, so the pool creates
+// the machine of the next call in the background when one is taken, off the path of the calls.
+type callVMPool struct {
+	size  int
+	newVM func(context.Context, vm.VmOption) (vm.Interface, error)
+	bs    blockstoreutil.Blockstore
+
+	retryDelay time.Duration
+
+	lk     sync.Mutex
+	states *lru.Cache[callVMKey, *callVMState]
+	// at most one machine is created in the background at a time
+	building chan struct{}
+}
+
+func newCallVMPool(size int, bs blockstoreutil.Blockstore, newVM func(context.Context, vm.VmOption) (vm.Interface, error)) *callVMPool {
+	states, _ := lru.New[callVMKey, *callVMState](callVMPoolStates)
+	return &callVMPool{
+		size:       size,
+		newVM:      newVM,
+		bs:         bs,
+		retryDelay: callVMPoolRetryDelay,
+		states:     states,
+		building:   make(chan struct{}, 1),
+	}
+}
+
+// get returns a machine executing on the state of opts, which must read the state from the
+// blockstore of the pool, the Bsstore of opts is replaced by a buffer of its own.
+func (p *callVMPool) get(ctx context.Context, tsk types.TipSetKey, opts vm.VmOption) (vm.Interface, error) {
+	key := newCallVMKey(tsk, opts)
+
+	p.lk.Lock()
+	st, ok := p.states.Get(key)
+	if !ok {
+		st = &callVMState{opts: opts, ready: make(chan *callVM, p.size)}
+		p.states.Add(key, st)
+	}
+	p.lk.Unlock()
+
+	defer p.refill(st)
+
+	select {
+	case cvm := <-st.ready:
+		p.tick(ctx, "hit")
+		cvm.externs.opts = opts
+		return cvm.vmi, nil
+	default:
+		p.tick(ctx, "miss")
+		cvm, err := p.create(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		return cvm.vmi, nil
+	}
+}
+
+// create returns a machine with the functions of opts.
+func (p *callVMPool) create(ctx context.Context, opts vm.VmOption) (*callVM, error) {
+	externs := &callVMExterns{opts: opts}
+	opts = externs.bind(opts)
+	opts.Bsstore = blockstoreutil.NewTieredBstore(p.bs, blockstoreutil.NewTemporarySync())
+	vmi, err := p.newVM(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &callVM{vmi: vmi, externs: externs}, nil
+}
+
+// refill creates the machines missing in the pool of st in the background, retrying the failed ones.
+func (p *callVMPool) refill(st *callVMState) {
+	select {
+	case p.building <- struct{}{}:
+	default:
+		// the next call refills the pool
+		return
+	}
+
+	go func() {
+		defer func() { <-p.building }()
+
+		attempt, delay := 0, p.retryDelay
+		for len(st.ready) < cap(st.ready) {
+			cvm, err := p.create(context.Background(), st.opts)
+			if err != nil {
+				if attempt++; attempt >= callVMPoolAttempts {
+					log.Warnf("creating a machine for the call pool: %v", err)
+					return
+				}
+				log.Debugf("creating a machine for the call pool, retrying in %s: %v", delay, err)
+				time.Sleep(delay)
+				delay *= 2
+				continue
+			}
+			attempt, delay = 0, p.retryDelay
+			select {
+			case st.ready <- cvm:
+			default:
+				return
+			}
+		}
+	}()
+}
+
+func (p *callVMPool) tick(ctx context.Context, result string) {
+	ctx, _ = tag.New(ctx, tag.Upsert(tagKeyResult, result))
+	callVMPoolRequests.Tick(ctx)
+}
@@ -0,0 +1,201 @@
+package statemanger
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/state"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/pkg/vm"
+	"github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// stubCallVM is a machine recording the options it was created with.
+type stubCallVM struct {
+	vm.Interface
+
+	id   int
+	opts vm.VmOption
+}
+
+// stubCallVMs creates the stub machines, failing the first failures ones.
+type stubCallVMs struct {
+	lk       sync.Mutex
+	created  int
+	failures int
+}
+
+func (s *stubCallVMs) newVM(_ context.Context, opts vm.VmOption) (vm.Interface, error) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return nil, errors.New("no state")
+	}
+	s.created++
+	return &stubCallVM{id: s.created, opts: opts}, nil
+}
+
+func (s *stubCallVMs) count() int {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	return s.created
+}
+
+func newTestCallVMPool(size int, vms *stubCallVMs) *callVMPool {
+	p := newCallVMPool(size, blockstore.NewMemory(), vms.newVM)
+	p.retryDelay = time.Millisecond
+	return p
+}
+
+// waitRefilled waits for the machines of opts to be created in the background.
+func waitRefilled(t *testing.T, p *callVMPool, opts vm.VmOption, ready int) {
+	require.Eventually(t, func() bool {
+		st, ok := p.states.Peek(newCallVMKey(types.EmptyTSK, opts))
+		return ok && len(st.ready) == ready && len(p.building) == 0
+	}, 5*time.Second, time.Millisecond)
+}
+
+func testCallVMOptions(epoch abi.ChainEpoch) vm.VmOption {
+	return vm.VmOption{
+		PRoot:   cid.Undef,
+		Epoch:   epoch,
+		BaseFee: big.NewInt(100),
+	}
+}
+
+func TestCallVMPool(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	t.Run("miss then hit", func(t *testing.T) {
+		vms := &stubCallVMs{}
+		p := newTestCallVMPool(2, vms)
+		opts := testCallVMOptions(10)
+
+		// the first call creates its machine, the pool is filled up to its size in the background
+		vmi, err := p.get(ctx, types.EmptyTSK, opts)
+		require.NoError(t, err)
+		assert.Equal(t, 1, vmi.(*stubCallVM).id)
+		waitRefilled(t, p, opts, 2)
+		assert.Equal(t, 3, vms.count())
+
+		// the next calls take the machines of the pool, each machine is handed out once
+		seen := map[int]bool{1: true}
+		for i := 0; i < 2; i++ {
+			vmi, err := p.get(ctx, types.EmptyTSK, opts)
+			require.NoError(t, err)
+			id := vmi.(*stubCallVM).id
+			assert.False(t, seen[id], "machine %d handed out twice", id)
+			seen[id] = true
+			waitRefilled(t, p, opts, 2)
+		}
+		assert.Equal(t, 5, vms.count())
+	})
+
+	t.Run("different options", func(t *testing.T) {
+		vms := &stubCallVMs{}
+		p := newTestCallVMPool(1, vms)
+
+		opts := testCallVMOptions(10)
+		_, err := p.get(ctx, types.EmptyTSK, opts)
+		require.NoError(t, err)
+		waitRefilled(t, p, opts, 1)
+
+		// the pooled machine doesn't trace, the call with tracing gets a machine of its own
+		traced := opts
+		traced.Tracing = true
+		vmi, err := p.get(ctx, types.EmptyTSK, traced)
+		require.NoError(t, err)
+		assert.True(t, vmi.(*stubCallVM).opts.Tracing)
+		waitRefilled(t, p, traced, 1)
+
+		for _, other := range []vm.VmOption{testCallVMOptions(11), func() vm.VmOption {
+			o := opts
+			o.BaseFee = big.Zero()
+			return o
+		}()} {
+			vmi, err := p.get(ctx, types.EmptyTSK, other)
+			require.NoError(t, err)
+			assert.Equal(t, other.Epoch, vmi.(*stubCallVM).opts.Epoch)
+			assert.Equal(t, other.BaseFee, vmi.(*stubCallVM).opts.BaseFee)
+			waitRefilled(t, p, other, 1)
+		}
+		assert.Equal(t, 8, vms.count())
+	})
+
+	t.Run("machines call the functions of their call", func(t *testing.T) {
+		vms := &stubCallVMs{}
+		p := newTestCallVMPool(1, vms)
+
+		lookback := func(name string) vm.LookbackStateGetter {
+			return func(context.Context, abi.ChainEpoch) (*state.View, error) {
+				return nil, errors.New(name)
+			}
+		}
+		first := testCallVMOptions(10)
+		first.LookbackStateGetter = lookback("first")
+		_, err := p.get(ctx, types.EmptyTSK, first)
+		require.NoError(t, err)
+		waitRefilled(t, p, first, 1)
+
+		// the pooled machine was created for the first call, and is handed to the second one
+		second := testCallVMOptions(10)
+		second.LookbackStateGetter = lookback("second")
+		vmi, err := p.get(ctx, types.EmptyTSK, second)
+		require.NoError(t, err)
+		assert.Equal(t, 2, vmi.(*stubCallVM).id)
+		_, err = vmi.(*stubCallVM).opts.LookbackStateGetter(ctx, 5)
+		assert.EqualError(t, err, "second")
+	})
+
+	t.Run("least recently used states are evicted", func(t *testing.T) {
+		vms := &stubCallVMs{}
+		p := newTestCallVMPool(1, vms)
+
+		for i := 0; i <= callVMPoolStates; i++ {
+			opts := testCallVMOptions(abi.ChainEpoch(i))
+			_, err := p.get(ctx, types.EmptyTSK, opts)
+			require.NoError(t, err)
+			waitRefilled(t, p, opts, 1)
+		}
+		assert.Equal(t, callVMPoolStates, p.states.Len())
+		assert.False(t, p.states.Contains(newCallVMKey(types.EmptyTSK, testCallVMOptions(0))))
+		assert.True(t, p.states.Contains(newCallVMKey(types.EmptyTSK, testCallVMOptions(1))))
+	})
+
+	t.Run("failed machines are retried", func(t *testing.T) {
+		vms := &stubCallVMs{}
+		p := newTestCallVMPool(2, vms)
+		opts := testCallVMOptions(10)
+
+		_, err := p.get(ctx, types.EmptyTSK, opts)
+		require.NoError(t, err)
+		waitRefilled(t, p, opts, 2)
+
+		// the background creation fails less than callVMPoolAttempts times in a row
+		vms.lk.Lock()
+		vms.failures = callVMPoolAttempts - 1
+		vms.lk.Unlock()
+		_, err = p.get(ctx, types.EmptyTSK, opts)
+		require.NoError(t, err)
+		waitRefilled(t, p, opts, 2)
+
+		// the call creating its own machine fails with the error
+		vms.lk.Lock()
+		vms.failures = 1
+		vms.lk.Unlock()
+		_, err = p.get(ctx, types.EmptyTSK, testCallVMOptions(11))
+		assert.EqualError(t, err, "no state")
+	})
+}
@@ -83,6 +83,11 @@ type Stmgr struct {
 	// We need a lock while making the copy as to prevent other callers
 	// overwrite the cache while making the copy
 	execTraceCacheLock sync.Mutex
+
+	// machines kept ready for the read-only calls, nil when disabled
+	callVMPool *callVMPool
+	// results of the calls on a given tipset, nil when disabled
+	callCache *callCache
 }
 
 func NewStateManager(cs *chain.Store,
@@ -132,6 +137,31 @@ func init() {
 	}
 }
 
+// EnableCallVMPool keeps size machines ready for the read-only calls on the recent states, a size
+// of zero creating a machine per call.
+func (s *Stmgr) EnableCallVMPool(size int) {
+	if size <= 0 {
+		s.callVMPool = nil
+		return
+	}
+	s.callVMPool = newCallVMPool(size, s.cs.Blockstore(), fvm.NewVM)
+}
+
+// EnableCallCache keeps the results of the last size calls made with Call on a given tipset, a size
+// of zero executing every call.
+func (s *Stmgr) EnableCallCache(size int) error {
+	if size <= 0 {
+		s.callCache = nil
+		return nil
+	}
+	cache, err := newCallCache(size)
+	if err != nil {
+		return err
+	}
+	s.callCache = cache
+	return nil
+}
+
 func (s *Stmgr) ResolveToDeterministicAddress(ctx context.Context, addr address.Address, ts *types.TipSet) (address.Address, error) {
 	switch addr.Protocol() {
 	case address.BLS, address.SECP256K1, address.Delegated:
@@ -188,6 +218,10 @@ func (s *Stmgr) ParentState(ctx context.Context, ts *types.TipSet) (*types.TipSe
 	if ts == nil {
 		ts = s.cs.GetHead()
 	}
+	ctx, span := trace.StartSpan(ctx, "statemanager.ParentState")
+	span.AddAttributes(trace.Int64Attribute("height", int64(ts.Height())))
+	defer span.End()
+
 	parent, err := s.cs.GetTipSet(ctx, ts.Parents())
 	if err != nil {
 		return nil, nil, fmt.Errorf("find tipset(%s) parent failed:%w",
@@ -219,6 +253,10 @@ func (s *Stmgr) TipsetStateTsk(ctx context.Context, tsk types.TipSetKey) (*types
 }
 
 func (s *Stmgr) TipsetState(ctx context.Context, ts *types.TipSet) (*tree.State, error) {
+	ctx, span := trace.StartSpan(ctx, "statemanager.TipsetState")
+	span.AddAttributes(trace.Int64Attribute("height", int64(ts.Height())))
+	defer span.End()
+
 	root, _, err := s.RunStateTransition(ctx, ts, nil, false)
 	if err != nil {
 		return nil, err
@@ -318,6 +356,9 @@ func (s *Stmgr) GetActorAt(ctx context.Context, addr address.Address, ts *types.
 	if addr.Empty() {
 		return nil, types.ErrActorNotFound
 	}
+	ctx, span := trace.StartSpan(ctx, "statemanager.GetActorAt")
+	span.AddAttributes(trace.StringAttribute("address", addr.String()))
+	defer span.End()
 
 	_, state, err := s.ParentState(ctx, ts)
 	if err != nil {
@@ -335,6 +376,33 @@ func (s *Stmgr) GetActorAt(ctx context.Context, addr address.Address, ts *types.
 	return actor, nil
 }
 
+// GetActorsAt looks up addrs in the parent state of ts, loading the state tree only once.
+func (s *Stmgr) GetActorsAt(ctx context.Context, addrs []address.Address, ts *types.TipSet) ([]types.ActorLookup, error) {
+	ctx, span := trace.StartSpan(ctx, "statemanager.GetActorsAt")
+	span.AddAttributes(trace.Int64Attribute("addresses", int64(len(addrs))))
+	defer span.End()
+
+	_, state, err := s.ParentState(ctx, ts)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]types.ActorLookup, len(addrs))
+	for i, addr := range addrs {
+		out[i].Address = addr
+		if addr.Empty() {
+			continue
+		}
+		actor, find, err := state.GetActor(ctx, addr)
+		if err != nil {
+			return nil, fmt.Errorf("loading actor %s: %w", addr, err)
+		}
+		out[i].Found = find
+		out[i].Actor = actor
+	}
+	return out, nil
+}
+
 // deprecated: in future use.
 func (s *Stmgr) RunStateTransitionV2(ctx context.Context, ts *types.TipSet) (cid.Cid, cid.Cid, error) {
 	ctx, span := trace.StartSpan(ctx, "Exected.RunStateTransition")
@@ -432,6 +500,10 @@ func (s *Stmgr) StateViewTsk(ctx context.Context, tsk types.TipSetKey) (*types.T
 }
 
 func (s *Stmgr) StateView(ctx context.Context, ts *types.TipSet) (cid.Cid, *appstate.View, error) {
+	ctx, span := trace.StartSpan(ctx, "statemanager.StateView")
+	span.AddAttributes(trace.Int64Attribute("height", int64(ts.Height())))
+	defer span.End()
+
 	stateCid, _, err := s.RunStateTransition(ctx, ts, nil, false)
 	if err != nil {
 		return cid.Undef, nil, err
@@ -508,6 +580,7 @@ func (s *Stmgr) ExecutionTrace(ctx context.Context, ts *types.TipSet) (cid.Cid,
 		if !ret.OutPuts.Refund.Nil() {
 			ir.GasCost = MakeMsgGasCost(msg, ret)
 		}
+		ir.DecodeExit()
 
 		invocTrace = append(invocTrace, ir)
 
@@ -601,6 +674,7 @@ func ComputeState(ctx context.Context, s *Stmgr, height abi.ChainEpoch, msgs []*
 		TipSetGetter:        vmcontext.TipSetGetterForTipset(s.cs.GetTipSetByHeight, ts),
 		Tracing:             true,
 		ActorDebugging:      s.actorDebugging,
+		ExecutionLane:       vmcontext.ExecutionLaneAPI,
 	}
 
 	vmi, err := fvm.NewVM(ctx, vmopt)
@@ -631,6 +705,7 @@ func ComputeState(ctx context.Context, s *Stmgr, height abi.ChainEpoch, msgs []*
 		if !ret.OutPuts.Refund.Nil() {
 			ir.GasCost = MakeMsgGasCost(msg, ret)
 		}
+		ir.DecodeExit()
 		trace = append(trace, ir)
 	}
 
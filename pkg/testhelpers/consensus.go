@@ -63,6 +63,14 @@ func (mbv *StubBlockValidator) ValidateBlockMsg(ctx context.Context, blk *types.
 	return pubsub.ValidationReject
 }
 
+// ValidateBlockMsgWithReason returns the stubbed error of block `blk` as the rejection reason.
+func (mbv *StubBlockValidator) ValidateBlockMsgWithReason(ctx context.Context, blk *types.BlockMsg) (pubsub.ValidationResult, string, error) {
+	if err := mbv.syntaxStubs[blk.Header.Cid()]; err != nil {
+		return pubsub.ValidationReject, "stub", err
+	}
+	return pubsub.ValidationAccept, "", nil
+}
+
 // StubSyntaxValidationForBlock stubs an error when the ValidateSyntax is called
 // on the with the given block.
 func (mbv *StubBlockValidator) StubSyntaxValidationForBlock(blk *types.BlockHeader, err error) {
@@ -0,0 +1,486 @@
+// Package tokenindex stores the token transfers in a sqlite database, by holder, and the NFT transfers by collection.
+package tokenindex
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/filecoin-project/go-state-types/big"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+var pragmas = []string{
+	"PRAGMA synchronous = normal",
+	"PRAGMA temp_store = memory",
+	"PRAGMA mmap_size = 30000000000",
+	"PRAGMA page_size = 32768",
+	"PRAGMA auto_vacuum = NONE",
+	"PRAGMA automatic_index = OFF",
+	"PRAGMA journal_mode = WAL",
+	"PRAGMA read_uncommitted = ON",
+}
+
+var ddls = []string{
+	`CREATE TABLE IF NOT EXISTS token_transfers (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		token TEXT NOT NULL,
+		from_addr TEXT NOT NULL,
+		to_addr TEXT NOT NULL,
+		amount TEXT NOT NULL,
+		standard TEXT NOT NULL,
+		tx_hash TEXT NOT NULL,
+		block_hash TEXT NOT NULL,
+		height INTEGER NOT NULL,
+		log_index INTEGER NOT NULL
+	)`,
+
+	`CREATE INDEX IF NOT EXISTS from_index ON token_transfers (from_addr, token, id)`,
+
+	`CREATE INDEX IF NOT EXISTS to_index ON token_transfers (to_addr, token, id)`,
+
+	`CREATE INDEX IF NOT EXISTS block_hash_index ON token_transfers (block_hash)`,
+
+	// metadata containing version of schema
+	`CREATE TABLE IF NOT EXISTS _meta (
+    	version UINT64 NOT NULL UNIQUE
+	)`,
+
+	// version 1.
+	`INSERT OR IGNORE INTO _meta (version) VALUES (1)`,
+}
+
+// nftDDLs migrate the schema from version 1 to version 2, adding the NFT transfers.
+var nftDDLs = []string{
+	`CREATE TABLE IF NOT EXISTS nft_transfers (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		collection TEXT NOT NULL,
+		token_id TEXT NOT NULL,
+		from_addr TEXT NOT NULL,
+		to_addr TEXT NOT NULL,
+		amount TEXT NOT NULL,
+		standard TEXT NOT NULL,
+		tx_hash TEXT NOT NULL,
+		block_hash TEXT NOT NULL,
+		height INTEGER NOT NULL,
+		log_index INTEGER NOT NULL
+	)`,
+
+	`CREATE INDEX IF NOT EXISTS nft_collection_index ON nft_transfers (collection, token_id, id)`,
+
+	`CREATE INDEX IF NOT EXISTS nft_from_index ON nft_transfers (from_addr, collection)`,
+
+	`CREATE INDEX IF NOT EXISTS nft_to_index ON nft_transfers (to_addr, collection)`,
+
+	`CREATE INDEX IF NOT EXISTS nft_block_hash_index ON nft_transfers (block_hash)`,
+
+	// version 2.
+	`INSERT OR IGNORE INTO _meta (version) VALUES (2)`,
+}
+
+const schemaVersion = 2
+
+const (
+	insertTransfer = `INSERT INTO token_transfers
+	(token, from_addr, to_addr, amount, standard, tx_hash, block_hash, height, log_index)
+	VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	selectTransfers = `SELECT id, token, from_addr, to_addr, amount, standard, tx_hash, block_hash, height, log_index FROM token_transfers`
+
+	insertNFTTransfer = `INSERT INTO nft_transfers
+	(collection, token_id, from_addr, to_addr, amount, standard, tx_hash, block_hash, height, log_index)
+	VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	selectNFTTransfers = `SELECT id, collection, token_id, from_addr, to_addr, amount, standard, tx_hash, block_hash, height, log_index FROM nft_transfers`
+)
+
+// TokenIndex indexes the token transfers by sender and by recipient, and the NFT transfers by collection.
+type TokenIndex struct {
+	db *sql.DB
+}
+
+// IndexBlock replaces the transfers of the tipset of blockHash with transfers and nfts, which are stored in order.
+func (ti *TokenIndex) IndexBlock(blockHash types.EthHash, transfers []types.EthTokenTransfer, nfts []types.EthNFTTransfer) error {
+	tx, err := ti.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	// rollback is a no-op once the transaction is committed
+	defer tx.Rollback() //nolint:errcheck
+
+	if err := removeBlock(tx, blockHash); err != nil {
+		return err
+	}
+	stmt, err := tx.Prepare(insertTransfer)
+	if err != nil {
+		return fmt.Errorf("prepare insert transfer: %w", err)
+	}
+	for _, t := range transfers {
+		if t.BlockHash != blockHash {
+			return fmt.Errorf("transfer %s is in block %s, not %s", t.TransactionHash, t.BlockHash, blockHash)
+		}
+		_, err := stmt.Exec(t.Token.String(), t.From.String(), t.To.String(), t.Amount.String(), t.Standard,
+			t.TransactionHash.String(), t.BlockHash.String(), int64(t.BlockNumber), int64(t.LogIndex))
+		if err != nil {
+			return err
+		}
+	}
+	nftStmt, err := tx.Prepare(insertNFTTransfer)
+	if err != nil {
+		return fmt.Errorf("prepare insert nft transfer: %w", err)
+	}
+	for _, t := range nfts {
+		if t.BlockHash != blockHash {
+			return fmt.Errorf("nft transfer %s is in block %s, not %s", t.TransactionHash, t.BlockHash, blockHash)
+		}
+		_, err := nftStmt.Exec(t.Collection.String(), t.TokenID.String(), t.From.String(), t.To.String(), t.Amount.String(),
+			t.Standard, t.TransactionHash.String(), t.BlockHash.String(), int64(t.BlockNumber), int64(t.LogIndex))
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// RemoveBlock removes the transfers of the tipset of blockHash, when it is reverted.
+func (ti *TokenIndex) RemoveBlock(blockHash types.EthHash) error {
+	tx, err := ti.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	// rollback is a no-op once the transaction is committed
+	defer tx.Rollback() //nolint:errcheck
+
+	if err := removeBlock(tx, blockHash); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func removeBlock(tx *sql.Tx, blockHash types.EthHash) error {
+	if _, err := tx.Exec("DELETE FROM token_transfers WHERE block_hash = ?", blockHash.String()); err != nil {
+		return err
+	}
+	_, err := tx.Exec("DELETE FROM nft_transfers WHERE block_hash = ?", blockHash.String())
+	return err
+}
+
+// Transfers returns up to limit transfers from or to holder following the transfer after, of token or of all the
+// tokens when token is nil, in the order they were indexed.
+func (ti *TokenIndex) Transfers(holder types.EthAddress, token *types.EthAddress, after uint64, limit int) ([]types.EthTokenTransfer, error) {
+	var (
+		rows *sql.Rows
+		err  error
+	)
+	if token == nil {
+		rows, err = ti.db.Query(selectTransfers+" WHERE (from_addr = ? OR to_addr = ?) AND id > ? ORDER BY id LIMIT ?",
+			holder.String(), holder.String(), after, limit)
+	} else {
+		rows, err = ti.db.Query(selectTransfers+" WHERE (from_addr = ? OR to_addr = ?) AND token = ? AND id > ? ORDER BY id LIMIT ?",
+			holder.String(), holder.String(), token.String(), after, limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return scanTransfers(rows)
+}
+
+// BalanceHistory returns the changes of the balance of token held by holder, one per block with transfers, summing
+// the indexed transfers.
+func (ti *TokenIndex) BalanceHistory(holder, token types.EthAddress) ([]types.EthTokenBalanceChange, error) {
+	rows, err := ti.db.Query(selectTransfers+" WHERE (from_addr = ? OR to_addr = ?) AND token = ? ORDER BY id",
+		holder.String(), holder.String(), token.String())
+	if err != nil {
+		return nil, err
+	}
+	transfers, err := scanTransfers(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []types.EthTokenBalanceChange
+	balance := big.Zero()
+	for _, t := range transfers {
+		if len(out) == 0 || out[len(out)-1].BlockHash != t.BlockHash {
+			out = append(out, types.EthTokenBalanceChange{
+				BlockHash:   t.BlockHash,
+				BlockNumber: t.BlockNumber,
+				Received:    big.Zero(),
+				Sent:        big.Zero(),
+			})
+		}
+		change := &out[len(out)-1]
+		if t.To == holder {
+			change.Received = big.Add(change.Received, t.Amount)
+			balance = big.Add(balance, t.Amount)
+		}
+		if t.From == holder {
+			change.Sent = big.Add(change.Sent, t.Amount)
+			balance = big.Sub(balance, t.Amount)
+		}
+		change.Balance = balance
+	}
+	return out, nil
+}
+
+// NFTTransfers returns up to limit transfers of the tokens of collection following the transfer after, of tokenID or
+// of all the tokens of the collection when tokenID is nil, in the order they were indexed.
+func (ti *TokenIndex) NFTTransfers(collection types.EthAddress, tokenID *big.Int, after uint64, limit int) ([]types.EthNFTTransfer, error) {
+	var (
+		rows *sql.Rows
+		err  error
+	)
+	if tokenID == nil {
+		rows, err = ti.db.Query(selectNFTTransfers+" WHERE collection = ? AND id > ? ORDER BY id LIMIT ?",
+			collection.String(), after, limit)
+	} else {
+		rows, err = ti.db.Query(selectNFTTransfers+" WHERE collection = ? AND token_id = ? AND id > ? ORDER BY id LIMIT ?",
+			collection.String(), tokenID.String(), after, limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return scanNFTTransfers(rows)
+}
+
+// NFTOwners returns the holders of the token tokenID of collection.
+func (ti *TokenIndex) NFTOwners(collection types.EthAddress, tokenID big.Int) ([]types.EthNFTHolding, error) {
+	rows, err := ti.db.Query(selectNFTTransfers+" WHERE collection = ? AND token_id = ? ORDER BY id",
+		collection.String(), tokenID.String())
+	if err != nil {
+		return nil, err
+	}
+	transfers, err := scanNFTTransfers(rows)
+	if err != nil {
+		return nil, err
+	}
+	return holdings(transfers, nil), nil
+}
+
+// NFTHoldings returns the tokens held by owner, of collection or of all the collections when collection is nil.
+func (ti *TokenIndex) NFTHoldings(owner types.EthAddress, collection *types.EthAddress) ([]types.EthNFTHolding, error) {
+	var (
+		rows *sql.Rows
+		err  error
+	)
+	if collection == nil {
+		rows, err = ti.db.Query(selectNFTTransfers+" WHERE from_addr = ? OR to_addr = ? ORDER BY id",
+			owner.String(), owner.String())
+	} else {
+		rows, err = ti.db.Query(selectNFTTransfers+" WHERE (from_addr = ? OR to_addr = ?) AND collection = ? ORDER BY id",
+			owner.String(), owner.String(), collection.String())
+	}
+	if err != nil {
+		return nil, err
+	}
+	transfers, err := scanNFTTransfers(rows)
+	if err != nil {
+		return nil, err
+	}
+	return holdings(transfers, &owner), nil
+}
+
+// holdings sums transfers into the amounts held, of owner only when it is not nil, in the order the holdings were
+// first seen. The zero address and the emptied holdings are left out.
+func holdings(transfers []types.EthNFTTransfer, owner *types.EthAddress) []types.EthNFTHolding {
+	type key struct {
+		collection types.EthAddress
+		tokenID    string
+		owner      types.EthAddress
+	}
+	var (
+		order []key
+		held  = make(map[key]*types.EthNFTHolding)
+	)
+	add := func(t *types.EthNFTTransfer, holder types.EthAddress, amount big.Int) {
+		if holder == (types.EthAddress{}) || (owner != nil && holder != *owner) {
+			return
+		}
+		k := key{collection: t.Collection, tokenID: t.TokenID.String(), owner: holder}
+		h, ok := held[k]
+		if !ok {
+			h = &types.EthNFTHolding{Collection: t.Collection, TokenID: t.TokenID, Owner: holder, Amount: big.Zero()}
+			held[k] = h
+			order = append(order, k)
+		}
+		h.Amount = big.Add(h.Amount, amount)
+		h.BlockNumber = t.BlockNumber
+	}
+	for i := range transfers {
+		t := &transfers[i]
+		add(t, t.From, big.Sub(big.Zero(), t.Amount))
+		add(t, t.To, t.Amount)
+	}
+
+	out := []types.EthNFTHolding{}
+	for _, k := range order {
+		if h := held[k]; h.Amount.GreaterThan(big.Zero()) {
+			out = append(out, *h)
+		}
+	}
+	return out
+}
+
+func scanNFTTransfers(rows *sql.Rows) ([]types.EthNFTTransfer, error) {
+	defer rows.Close() //nolint:errcheck
+
+	var out []types.EthNFTTransfer
+	for rows.Next() {
+		var (
+			id                                                                 uint64
+			collection, tokenID, from, to, amount, standard, txHash, blockHash string
+			height, logIndex                                                   int64
+		)
+		if err := rows.Scan(&id, &collection, &tokenID, &from, &to, &amount, &standard, &txHash, &blockHash, &height, &logIndex); err != nil {
+			return nil, err
+		}
+
+		t := types.EthNFTTransfer{ID: id, Standard: standard}
+		var err error
+		if t.Collection, err = types.ParseEthAddress(collection); err != nil {
+			return nil, err
+		}
+		if t.TokenID, err = big.FromString(tokenID); err != nil {
+			return nil, err
+		}
+		if t.From, err = types.ParseEthAddress(from); err != nil {
+			return nil, err
+		}
+		if t.To, err = types.ParseEthAddress(to); err != nil {
+			return nil, err
+		}
+		if t.Amount, err = big.FromString(amount); err != nil {
+			return nil, err
+		}
+		if t.TransactionHash, err = types.ParseEthHash(txHash); err != nil {
+			return nil, err
+		}
+		if t.BlockHash, err = types.ParseEthHash(blockHash); err != nil {
+			return nil, err
+		}
+		t.BlockNumber = types.EthUint64(height)
+		t.LogIndex = types.EthUint64(logIndex)
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
+
+func scanTransfers(rows *sql.Rows) ([]types.EthTokenTransfer, error) {
+	defer rows.Close() //nolint:errcheck
+
+	var out []types.EthTokenTransfer
+	for rows.Next() {
+		var (
+			id                                                   uint64
+			token, from, to, amount, standard, txHash, blockHash string
+			height, logIndex                                     int64
+		)
+		if err := rows.Scan(&id, &token, &from, &to, &amount, &standard, &txHash, &blockHash, &height, &logIndex); err != nil {
+			return nil, err
+		}
+
+		t := types.EthTokenTransfer{ID: id, Standard: standard}
+		var err error
+		if t.Token, err = types.ParseEthAddress(token); err != nil {
+			return nil, err
+		}
+		if t.From, err = types.ParseEthAddress(from); err != nil {
+			return nil, err
+		}
+		if t.To, err = types.ParseEthAddress(to); err != nil {
+			return nil, err
+		}
+		if t.Amount, err = big.FromString(amount); err != nil {
+			return nil, err
+		}
+		if t.TransactionHash, err = types.ParseEthHash(txHash); err != nil {
+			return nil, err
+		}
+		if t.BlockHash, err = types.ParseEthHash(blockHash); err != nil {
+			return nil, err
+		}
+		t.BlockNumber = types.EthUint64(height)
+		t.LogIndex = types.EthUint64(logIndex)
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
+
+func NewTokenIndex(path string) (*TokenIndex, error) {
+	db, err := sql.Open("sqlite3", path+"?mode=rwc")
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite3 database: %w", err)
+	}
+
+	for _, pragma := range pragmas {
+		if _, err := db.Exec(pragma); err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("exec pragma %q: %w", pragma, err)
+		}
+	}
+
+	q, err := db.Query("SELECT name FROM sqlite_master WHERE type='table' AND name='_meta';")
+	if err == sql.ErrNoRows || (err == nil && !q.Next()) {
+		if q != nil {
+			_ = q.Close()
+		}
+		// empty database, create the schema
+		for _, ddl := range append(ddls, nftDDLs...) {
+			if _, err := db.Exec(ddl); err != nil {
+				_ = db.Close()
+				return nil, fmt.Errorf("exec ddl %q: %w", ddl, err)
+			}
+		}
+	} else if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("looking for _meta table: %w", err)
+	} else {
+		_ = q.Close()
+		// Ensure we don't open a database from a different schema version
+
+		row := db.QueryRow("SELECT max(version) FROM _meta")
+		var version int
+		err := row.Scan(&version)
+		if err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("invalid database version: no version found")
+		}
+		if version == 1 {
+			for _, ddl := range nftDDLs {
+				if _, err := db.Exec(ddl); err != nil {
+					_ = db.Close()
+					return nil, fmt.Errorf("migrating to version 2, exec ddl %q: %w", ddl, err)
+				}
+			}
+			version = 2
+		}
+		if version != schemaVersion {
+			_ = db.Close()
+			return nil, fmt.Errorf("invalid database version: got %d, expected %d", version, schemaVersion)
+		}
+	}
+
+	return &TokenIndex{
+		db: db,
+	}, nil
+}
+
+// Vacuum rebuilds the database to reclaim the space of the deleted rows, then truncates its WAL.
+func (ti *TokenIndex) Vacuum(ctx context.Context) error {
+	if _, err := ti.db.ExecContext(ctx, "VACUUM"); err != nil {
+		return fmt.Errorf("vacuum token index: %w", err)
+	}
+	if _, err := ti.db.ExecContext(ctx, "PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		return fmt.Errorf("checkpoint token index: %w", err)
+	}
+	return nil
+}
+
+func (ti *TokenIndex) Close() error {
+	if ti.db == nil {
+		return nil
+	}
+	return ti.db.Close()
+}
@@ -0,0 +1,191 @@
+package tokenindex
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestTokenIndex(t *testing.T) {
+	tf.UnitTest(t)
+
+	ti, err := NewTokenIndex(filepath.Join(t.TempDir(), "tokens.db"))
+	require.NoError(t, err)
+	defer ti.Close() //nolint:errcheck
+
+	token := types.EthAddress{0xaa}
+	other := types.EthAddress{0xbb}
+	holder := types.EthAddress{1}
+	peer := types.EthAddress{2}
+	block1 := types.EthHash{1}
+	block2 := types.EthHash{2}
+	transfer := func(token, from, to types.EthAddress, amount int64, block types.EthHash, height, logIndex types.EthUint64) types.EthTokenTransfer {
+		return types.EthTokenTransfer{
+			Token:           token,
+			From:            from,
+			To:              to,
+			Amount:          big.NewInt(amount),
+			Standard:        types.TokenStandardERC20,
+			TransactionHash: types.EthHash{0xff, byte(logIndex)},
+			BlockHash:       block,
+			BlockNumber:     height,
+			LogIndex:        logIndex,
+		}
+	}
+
+	first := []types.EthTokenTransfer{
+		transfer(token, types.EthAddress{}, holder, 100, block1, 10, 0),
+		transfer(other, peer, holder, 7, block1, 10, 1),
+		transfer(token, holder, peer, 30, block1, 10, 2),
+	}
+	second := []types.EthTokenTransfer{
+		transfer(token, peer, holder, 5, block2, 11, 0),
+		transfer(token, holder, types.EthAddress{}, 50, block2, 11, 1),
+	}
+	require.NoError(t, ti.IndexBlock(block1, first, nil))
+	require.NoError(t, ti.IndexBlock(block2, second, nil))
+	require.Error(t, ti.IndexBlock(block1, second, nil))
+
+	withID := func(ts []types.EthTokenTransfer, ids ...uint64) []types.EthTokenTransfer {
+		out := make([]types.EthTokenTransfer, len(ts))
+		for i := range ts {
+			out[i] = ts[i]
+			out[i].ID = ids[i]
+		}
+		return out
+	}
+
+	list, err := ti.Transfers(holder, nil, 0, 2)
+	require.NoError(t, err)
+	require.Equal(t, withID(first[:2], 1, 2), list)
+	list, err = ti.Transfers(holder, &token, 2, 10)
+	require.NoError(t, err)
+	require.Equal(t, append(withID(first[2:], 3), withID(second, 4, 5)...), list)
+
+	history, err := ti.BalanceHistory(holder, token)
+	require.NoError(t, err)
+	require.Equal(t, []types.EthTokenBalanceChange{
+		{BlockHash: block1, BlockNumber: 10, Received: big.NewInt(100), Sent: big.NewInt(30), Balance: big.NewInt(70)},
+		{BlockHash: block2, BlockNumber: 11, Received: big.NewInt(5), Sent: big.NewInt(50), Balance: big.NewInt(25)},
+	}, history)
+
+	// reverting a block removes its transfers
+	require.NoError(t, ti.RemoveBlock(block2))
+	list, err = ti.Transfers(holder, &token, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, withID([]types.EthTokenTransfer{first[0], first[2]}, 1, 3), list)
+	history, err = ti.BalanceHistory(peer, token)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	require.Equal(t, big.NewInt(30), history[0].Balance)
+}
+
+func TestNFTIndex(t *testing.T) {
+	tf.UnitTest(t)
+
+	ti, err := NewTokenIndex(filepath.Join(t.TempDir(), "tokens.db"))
+	require.NoError(t, err)
+	defer ti.Close() //nolint:errcheck
+
+	collection := types.EthAddress{0xaa}
+	items := types.EthAddress{0xbb}
+	alice := types.EthAddress{1}
+	bob := types.EthAddress{2}
+	block1 := types.EthHash{1}
+	block2 := types.EthHash{2}
+	transfer := func(collection types.EthAddress, tokenID int64, from, to types.EthAddress, amount int64, block types.EthHash, height types.EthUint64) types.EthNFTTransfer {
+		standard := types.TokenStandardERC721
+		if collection == items {
+			standard = types.TokenStandardERC1155
+		}
+		return types.EthNFTTransfer{
+			Collection:      collection,
+			TokenID:         big.NewInt(tokenID),
+			From:            from,
+			To:              to,
+			Amount:          big.NewInt(amount),
+			Standard:        standard,
+			TransactionHash: types.EthHash{0xff, byte(tokenID)},
+			BlockHash:       block,
+			BlockNumber:     height,
+		}
+	}
+
+	first := []types.EthNFTTransfer{
+		transfer(collection, 1, types.EthAddress{}, alice, 1, block1, 10),
+		transfer(collection, 2, types.EthAddress{}, alice, 1, block1, 10),
+		transfer(items, 7, types.EthAddress{}, alice, 10, block1, 10),
+	}
+	second := []types.EthNFTTransfer{
+		transfer(collection, 1, alice, bob, 1, block2, 11),
+		transfer(items, 7, alice, bob, 4, block2, 11),
+	}
+	require.NoError(t, ti.IndexBlock(block1, nil, first))
+	require.NoError(t, ti.IndexBlock(block2, nil, second))
+
+	list, err := ti.NFTTransfers(collection, nil, 0, 2)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, uint64(1), list[0].ID)
+	require.Equal(t, first[1].TokenID, list[1].TokenID)
+	one := big.NewInt(1)
+	list, err = ti.NFTTransfers(collection, &one, list[0].ID, 10)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, bob, list[0].To)
+
+	owners, err := ti.NFTOwners(collection, big.NewInt(1))
+	require.NoError(t, err)
+	require.Equal(t, []types.EthNFTHolding{{Collection: collection, TokenID: big.NewInt(1), Owner: bob, Amount: big.NewInt(1), BlockNumber: 11}}, owners)
+	owners, err = ti.NFTOwners(items, big.NewInt(7))
+	require.NoError(t, err)
+	require.Equal(t, []types.EthNFTHolding{
+		{Collection: items, TokenID: big.NewInt(7), Owner: alice, Amount: big.NewInt(6), BlockNumber: 11},
+		{Collection: items, TokenID: big.NewInt(7), Owner: bob, Amount: big.NewInt(4), BlockNumber: 11},
+	}, owners)
+
+	held, err := ti.NFTHoldings(alice, &collection)
+	require.NoError(t, err)
+	require.Equal(t, []types.EthNFTHolding{{Collection: collection, TokenID: big.NewInt(2), Owner: alice, Amount: big.NewInt(1), BlockNumber: 10}}, held)
+	held, err = ti.NFTHoldings(alice, nil)
+	require.NoError(t, err)
+	require.Len(t, held, 2)
+
+	// reverting a block gives the tokens back
+	require.NoError(t, ti.RemoveBlock(block2))
+	held, err = ti.NFTHoldings(bob, nil)
+	require.NoError(t, err)
+	require.Empty(t, held)
+	held, err = ti.NFTHoldings(alice, &collection)
+	require.NoError(t, err)
+	require.Len(t, held, 2)
+}
+
+func TestMigrateVersion1(t *testing.T) {
+	tf.UnitTest(t)
+
+	path := filepath.Join(t.TempDir(), "tokens.db")
+	db, err := sql.Open("sqlite3", path+"?mode=rwc")
+	require.NoError(t, err)
+	for _, ddl := range ddls {
+		_, err := db.Exec(ddl)
+		require.NoError(t, err)
+	}
+	require.NoError(t, db.Close())
+
+	ti, err := NewTokenIndex(path)
+	require.NoError(t, err)
+	require.NoError(t, ti.IndexBlock(types.EthHash{1}, nil, nil))
+	require.NoError(t, ti.Close())
+
+	// the migrated database opens at version 2
+	ti, err = NewTokenIndex(path)
+	require.NoError(t, err)
+	require.NoError(t, ti.Close())
+}
@@ -2,9 +2,13 @@ package vmcontext
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"strconv"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/ipfs/go-cid"
 
@@ -22,8 +26,28 @@ const (
 	DefaultPriorityExecutionLanes = 2
 )
 
+// ErrExecutionQueueFull is returned by the executions of the api lane when too many of them wait for a lane.
+var ErrExecutionQueueFull = errors.New("too many api calls waiting for an execution lane")
+
+// ExecutionLanes configures the concurrent executions, the zero values keep the current limits but
+// for API, which then allows all the unreserved lanes.
+type ExecutionLanes struct {
+	// Available is the bound of concurrent executions.
+	Available int
+	// Reserved is the number of lanes reserved for the priority executions.
+	Reserved int
+	// API is the bound of concurrent executions of the api lane, taken from the unreserved lanes.
+	API int
+	// APIQueueSize is the number of executions of the api lane which may wait for a lane, the others fail
+	// with ErrExecutionQueueFull.
+	APIQueueSize int
+	// APIQueueTimeout is how long an execution of the api lane waits for a lane, a negative value waits
+	// until the context is done.
+	APIQueueTimeout time.Duration
+}
+
 // the execution environment; see below for definition, methods, and initialization
-var execution *executionEnv
+var execution atomic.Pointer[executionEnv]
 
 // implementation of vm executor with simple sanity check preventing use after free.
 type vmExecutor struct {
@@ -38,14 +62,20 @@ func NewVMExecutor(vmi Interface, lane ExecutionLane) Interface {
 }
 
 func (e *vmExecutor) ApplyMessage(ctx context.Context, cmsg types.ChainMsg) (*Ret, error) {
-	token := execution.getToken(e.lane)
+	token, err := execution.Load().getToken(ctx, e.lane)
+	if err != nil {
+		return nil, err
+	}
 	defer token.Done()
 
 	return e.vmi.ApplyMessage(ctx, cmsg)
 }
 
 func (e *vmExecutor) ApplyImplicitMessage(ctx context.Context, msg types.ChainMsg) (*Ret, error) {
-	token := execution.getToken(e.lane)
+	token, err := execution.Load().getToken(ctx, e.lane)
+	if err != nil {
+		return nil, err
+	}
 	defer token.Done()
 
 	return e.vmi.ApplyImplicitMessage(ctx, msg)
@@ -56,12 +86,13 @@ func (e *vmExecutor) Flush(ctx context.Context) (cid.Cid, error) {
 }
 
 type executionToken struct {
+	env      *executionEnv
 	lane     ExecutionLane
 	reserved int
 }
 
 func (token *executionToken) Done() {
-	execution.putToken(token)
+	token.env.putToken(token)
 }
 
 type executionEnv struct {
@@ -72,13 +103,60 @@ type executionEnv struct {
 	available int
 	// reserved executors
 	reserved int
+
+	// the lanes of the api executions
+	api apiLanes
+}
+
+// apiLanes bounds the concurrent executions of the api lane and the number of them waiting.
+type apiLanes struct {
+	slots        chan struct{}
+	waiting      atomic.Int64
+	queueSize    int64
+	queueTimeout time.Duration
+}
+
+func (l *apiLanes) acquire(ctx context.Context) error {
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	default:
+	}
+
+	if l.waiting.Add(1) > l.queueSize {
+		l.waiting.Add(-1)
+		return ErrExecutionQueueFull
+	}
+	defer l.waiting.Add(-1)
+
+	if l.queueTimeout >= 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, l.queueTimeout)
+		defer cancel()
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for an api execution lane: %w", ctx.Err())
+	}
 }
 
-func (e *executionEnv) getToken(lane ExecutionLane) *executionToken {
+func (l *apiLanes) release() {
+	<-l.slots
+}
+
+func (e *executionEnv) getToken(ctx context.Context, lane ExecutionLane) (*executionToken, error) {
+	if lane == ExecutionLaneAPI {
+		if err := e.api.acquire(ctx); err != nil {
+			return nil, err
+		}
+	}
+
 	e.mx.Lock()
 
 	reserving := 0
-	if lane == ExecutionLaneDefault {
+	if lane != ExecutionLanePriority {
 		for e.available <= e.reserved {
 			e.cond.Wait()
 		}
@@ -96,10 +174,14 @@ func (e *executionEnv) getToken(lane ExecutionLane) *executionToken {
 	e.available--
 	e.mx.Unlock()
 
-	return &executionToken{lane: lane, reserved: reserving}
+	return &executionToken{env: e, lane: lane, reserved: reserving}, nil
 }
 
 func (e *executionEnv) putToken(token *executionToken) {
+	if token.lane == ExecutionLaneAPI {
+		defer e.api.release()
+	}
+
 	e.mx.Lock()
 	defer e.mx.Unlock()
 
@@ -111,6 +193,67 @@ func (e *executionEnv) putToken(token *executionToken) {
 	e.cond.Broadcast()
 }
 
+// ConfigureExecutionLanes replaces the limits of the concurrent executions, the executions running
+// keep the previous limits.
+func ConfigureExecutionLanes(cfg ExecutionLanes) error {
+	current := execution.Load()
+	available := cfg.Available
+	if available == 0 {
+		available = current.available
+	}
+	priority := cfg.Reserved
+	if priority == 0 {
+		priority = current.reserved
+	}
+	api := cfg.API
+	if api == 0 {
+		api = available - priority
+	}
+	queueSize := int64(cfg.APIQueueSize)
+	if queueSize == 0 {
+		queueSize = current.api.queueSize
+	}
+	queueTimeout := cfg.APIQueueTimeout
+	if queueTimeout == 0 {
+		queueTimeout = current.api.queueTimeout
+	}
+
+	env, err := newExecutionEnv(available, priority, api, queueSize, queueTimeout)
+	if err != nil {
+		return err
+	}
+	execution.Store(env)
+	return nil
+}
+
+func newExecutionEnv(available, priority, api int, queueSize int64, queueTimeout time.Duration) (*executionEnv, error) {
+	// some sanity checks
+	if available < 2 {
+		return nil, fmt.Errorf("insufficient execution concurrency")
+	}
+	if available <= priority {
+		return nil, fmt.Errorf("insufficient default execution concurrency")
+	}
+	if api < 1 || queueSize < 0 {
+		return nil, fmt.Errorf("invalid api execution concurrency %d or queue size %d", api, queueSize)
+	}
+
+	mx := &sync.Mutex{}
+	cond := sync.NewCond(mx)
+
+	return &executionEnv{
+		mx:        mx,
+		cond:      cond,
+		available: available,
+		reserved:  priority,
+		api: apiLanes{
+			slots:        make(chan struct{}, api),
+			queueSize:    queueSize,
+			queueTimeout: queueTimeout,
+		},
+	}, nil
+}
+
 func init() {
 	var err error
 
@@ -134,22 +277,10 @@ func init() {
 		}
 	}
 
-	// some sanity checks
-	if available < 2 {
-		panic("insufficient execution concurrency")
-	}
-
-	if available <= priority {
-		panic("insufficient default execution concurrency")
-	}
-
-	mx := &sync.Mutex{}
-	cond := sync.NewCond(mx)
-
-	execution = &executionEnv{
-		mx:        mx,
-		cond:      cond,
-		available: available,
-		reserved:  priority,
+	// the api calls may use all the unreserved lanes unless configured otherwise
+	env, err := newExecutionEnv(available, priority, available-priority, 128, time.Minute)
+	if err != nil {
+		panic(err)
 	}
+	execution.Store(env)
 }
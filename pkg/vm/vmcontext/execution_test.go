@@ -0,0 +1,58 @@
+package vmcontext
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestAPIExecutionLanes(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	env, err := newExecutionEnv(3, 1, 1, 1, 50*time.Millisecond)
+	require.NoError(t, err)
+
+	api, err := env.getToken(ctx, ExecutionLaneAPI)
+	require.NoError(t, err)
+
+	// the api lane is busy, one call waits and the next ones are refused
+	waited := make(chan error)
+	go func() {
+		token, err := env.getToken(ctx, ExecutionLaneAPI)
+		if err == nil {
+			token.Done()
+		}
+		waited <- err
+	}()
+	require.Eventually(t, func() bool { return env.api.waiting.Load() == 1 }, time.Second, time.Millisecond)
+	_, err = env.getToken(ctx, ExecutionLaneAPI)
+	assert.ErrorIs(t, err, ErrExecutionQueueFull)
+
+	// the default and priority lanes are not delayed by the api calls
+	def, err := env.getToken(ctx, ExecutionLaneDefault)
+	require.NoError(t, err)
+	prio, err := env.getToken(ctx, ExecutionLanePriority)
+	require.NoError(t, err)
+	prio.Done()
+	def.Done()
+
+	api.Done()
+	require.NoError(t, <-waited)
+
+	// the calls time out waiting for a lane
+	api, err = env.getToken(ctx, ExecutionLaneAPI)
+	require.NoError(t, err)
+	_, err = env.getToken(ctx, ExecutionLaneAPI)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	api.Done()
+
+	_, err = newExecutionEnv(2, 2, 1, 1, 0)
+	assert.Error(t, err)
+}
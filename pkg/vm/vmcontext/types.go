@@ -42,6 +42,9 @@ const (
 	ExecutionLaneDefault ExecutionLane = iota
 	// ExecutionLanePriority signifies a prioritized execution lane with reserved resources.
 	ExecutionLanePriority
+	// ExecutionLaneAPI signifies the executions of the API calls, a default lane bounded by its own
+	// concurrency limit and queue.
+	ExecutionLaneAPI
 )
 
 type VmOption struct { //nolint
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/awnumar/memguard"
 	"github.com/filecoin-project/go-address"
@@ -52,6 +53,10 @@ type DSBackend struct {
 	unLocked map[address.Address]*key.KeyInfo
 
 	state atomic.Int64
+
+	// autoLock is the default duration an unlocked wallet stays unlocked, zero disables it.
+	autoLock    time.Duration
+	relockTimer *time.Timer
 }
 
 var _ Backend = (*DSBackend)(nil)
@@ -105,6 +110,10 @@ func (backend *DSBackend) Addresses(ctx context.Context) []address.Address {
 	backend.lk.RLock()
 	defer backend.lk.RUnlock()
 
+	return backend.addressesLocked()
+}
+
+func (backend *DSBackend) addressesLocked() []address.Address {
 	var addrs []address.Address
 	for addr := range backend.cache {
 		addrs = append(addrs, addr)
@@ -283,52 +292,108 @@ func (backend *DSBackend) getKey(ctx context.Context, addr address.Address, pass
 }
 
 func (backend *DSBackend) LockWallet(ctx context.Context) error {
+	backend.lk.Lock()
+	defer backend.lk.Unlock()
+
 	if backend.state.Load() == Lock {
 		return fmt.Errorf("already locked")
 	}
 
-	if len(backend.Addresses(ctx)) == 0 {
+	if len(backend.cache) == 0 {
 		return fmt.Errorf("no address need lock")
 	}
 
-	for _, addr := range backend.Addresses(ctx) {
-		backend.lk.Lock()
+	backend.lockLocked()
+
+	return nil
+}
+
+// lockLocked forgets the unlocked keys and the password, the caller must hold lk.
+func (backend *DSBackend) lockLocked() {
+	for addr := range backend.unLocked {
 		delete(backend.unLocked, addr)
-		backend.lk.Unlock()
 	}
-	backend.cleanPassword()
+	if backend.relockTimer != nil {
+		backend.relockTimer.Stop()
+		backend.relockTimer = nil
+	}
+	backend.password = nil
 	backend.state.Store(Lock)
+}
 
-	return nil
+// SetAutoLockTimeout sets the default duration after which an unlocked wallet is locked again.
+func (backend *DSBackend) SetAutoLockTimeout(timeout time.Duration) {
+	backend.lk.Lock()
+	defer backend.lk.Unlock()
+
+	backend.autoLock = timeout
 }
 
-// UnLockWallet unlock wallet with password, decrypt local key in db and save to protected memory
+// UnLockWallet unlock wallet with password, the wallet is locked again after the auto lock timeout
 func (backend *DSBackend) UnLockWallet(ctx context.Context, password []byte) error {
+	backend.lk.RLock()
+	timeout := backend.autoLock
+	backend.lk.RUnlock()
+
+	return backend.UnLockWalletFor(ctx, password, timeout)
+}
+
+// UnLockWalletFor unlock wallet with password, decrypt local key in db and save to protected memory,
+// the wallet is locked again after timeout unless it is zero
+func (backend *DSBackend) UnLockWalletFor(ctx context.Context, password []byte, timeout time.Duration) error {
 	defer func() {
 		for i := range password {
 			password[i] = 0
 		}
 	}()
-	if backend.state.Load() == Unlock {
+	if backend.WalletState(ctx) == Unlock {
 		return fmt.Errorf("already unlocked")
 	}
 
-	if len(backend.Addresses(ctx)) == 0 {
+	addrs := backend.Addresses(ctx)
+	if len(addrs) == 0 {
 		return fmt.Errorf("no address need unlock")
 	}
 
-	for _, addr := range backend.Addresses(ctx) {
+	// decrypt the keys without holding the lock, it is slow
+	unLocked := make(map[address.Address]*key.KeyInfo, len(addrs))
+	for _, addr := range addrs {
 		ki, err := backend.GetKeyInfoPassphrase(ctx, addr, password)
 		if err != nil {
 			return err
 		}
+		unLocked[addr] = ki
+	}
 
-		backend.lk.Lock()
+	backend.lk.Lock()
+	defer backend.lk.Unlock()
+
+	// the wallet may have been unlocked concurrently
+	if backend.state.Load() == Unlock {
+		return fmt.Errorf("already unlocked")
+	}
+	for addr, ki := range unLocked {
 		backend.unLocked[addr] = ki
-		backend.lk.Unlock()
 	}
+	backend.password = memguard.NewEnclave(password)
 	backend.state.Store(Unlock)
 
+	if timeout > 0 {
+		var timer *time.Timer
+		timer = time.AfterFunc(timeout, func() {
+			backend.lk.Lock()
+			defer backend.lk.Unlock()
+
+			// the wallet was locked, and maybe unlocked again, since the timer was set
+			if backend.relockTimer != timer {
+				return
+			}
+			backend.lockLocked()
+			walletLog.Infof("wallet locked after %s", timeout)
+		})
+		backend.relockTimer = timer
+	}
+
 	return nil
 }
 
@@ -383,9 +448,3 @@ func (backend *DSBackend) UsePassword(f func(password []byte) error) error {
 
 	return f(buf.Bytes())
 }
-
-func (backend *DSBackend) cleanPassword() {
-	backend.lk.Lock()
-	defer backend.lk.Unlock()
-	backend.password = nil
-}
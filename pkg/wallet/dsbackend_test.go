@@ -6,6 +6,7 @@ import (
 	"io"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/filecoin-project/venus/pkg/crypto"
 
@@ -181,6 +182,49 @@ func TestDSBackendParallel(t *testing.T) {
 	assert.Len(t, fs.Addresses(ctx), 10)
 }
 
+func TestDSBackendAutoLock(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	// the password is wiped once it is set
+	fs, err := NewDSBackend(ctx, datastore.NewMapDatastore(), config.TestPassphraseConfig(), append([]byte{}, TestPassword...))
+	require.NoError(t, err)
+
+	addr, err := fs.NewAddress(ctx, address.SECP256K1)
+	require.NoError(t, err)
+
+	require.NoError(t, fs.LockWallet(ctx))
+	assert.False(t, fs.HasPassword())
+	_, err = fs.SignBytes(ctx, []byte("data"), addr)
+	assert.Error(t, err)
+
+	t.Log("unlock restores the password and relocks after the timeout")
+	require.NoError(t, fs.UnLockWalletFor(ctx, append([]byte{}, TestPassword...), 50*time.Millisecond))
+	assert.True(t, fs.HasPassword())
+	_, err = fs.SignBytes(ctx, []byte("data"), addr)
+	assert.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		return fs.WalletState(ctx) == Lock
+	}, 5*time.Second, 10*time.Millisecond)
+	_, err = fs.SignBytes(ctx, []byte("data"), addr)
+	assert.Error(t, err)
+
+	t.Log("manual lock stops the pending relock")
+	fs.SetAutoLockTimeout(time.Hour)
+	require.NoError(t, fs.UnLockWallet(ctx, append([]byte{}, TestPassword...)))
+	require.NoError(t, fs.LockWallet(ctx))
+	assert.Nil(t, fs.relockTimer)
+
+	t.Log("the relock of a previous unlock does not lock the wallet unlocked again")
+	require.NoError(t, fs.UnLockWalletFor(ctx, append([]byte{}, TestPassword...), 50*time.Millisecond))
+	require.NoError(t, fs.LockWallet(ctx))
+	require.NoError(t, fs.UnLockWalletFor(ctx, append([]byte{}, TestPassword...), time.Hour))
+	time.Sleep(200 * time.Millisecond)
+	assert.Equal(t, Unlock, fs.WalletState(ctx))
+	assert.EqualError(t, fs.UnLockWalletFor(ctx, append([]byte{}, TestPassword...), time.Hour), "already unlocked")
+}
+
 func BenchmarkDSBackendSimple(b *testing.B) {
 	ds := datastore.NewMapDatastore()
 	defer func() {
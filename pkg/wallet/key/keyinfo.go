@@ -237,3 +237,21 @@ func NewDelegatedKeyFromSeed(seed io.Reader) (KeyInfo, error) {
 	copy(k, make([]byte, len(k))) // wipe with zero bytes
 	return *ki, nil
 }
+
+// NewDelegatedKeyFromSecp returns a delegated key sharing the private key of the given
+// secp256k1 key, the same secret then controls both the f1 and the f410 address.
+func NewDelegatedKeyFromSecp(secp *KeyInfo) (KeyInfo, error) {
+	if secp.SigType != types.SigTypeSecp256k1 {
+		return KeyInfo{}, fmt.Errorf("expected a secp256k1 key, got sig type %d", secp.SigType)
+	}
+	ki := &KeyInfo{
+		SigType: types.SigTypeDelegated,
+	}
+	if err := secp.UsePrivateKey(func(privateKey []byte) error {
+		ki.SetPrivateKey(append([]byte{}, privateKey...))
+		return nil
+	}); err != nil {
+		return KeyInfo{}, err
+	}
+	return *ki, nil
+}
@@ -182,6 +182,30 @@ func TestDelegatedSigning(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestDelegatedKeyFromSecp(t *testing.T) {
+	tf.UnitTest(t)
+
+	token := bytes.Repeat([]byte{42}, 512)
+	secpKi, err := NewSecpKeyFromSeed(bytes.NewReader(token))
+	require.NoError(t, err)
+
+	ki, err := NewDelegatedKeyFromSecp(&secpKi)
+	require.NoError(t, err)
+	assert.Equal(t, secpKi.Key(), ki.Key())
+
+	addr, err := ki.Address()
+	require.NoError(t, err)
+	assert.Equal(t, address.Delegated, addr.Protocol())
+
+	data := []byte("data to be signed")
+	signature, err := crypto.Sign(data, ki.Key(), crypto.SigTypeDelegated)
+	require.NoError(t, err)
+	require.NoError(t, crypto.Verify(signature, addr, data))
+
+	_, err = NewDelegatedKeyFromSecp(&ki)
+	require.Error(t, err)
+}
+
 func aggregateSignatures(sigs []*crypto.Signature) (*crypto.Signature, error) {
 	sigsS := make([]ffi.Signature, len(sigs))
 	for i := 0; i < len(sigs); i++ {
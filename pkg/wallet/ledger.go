@@ -0,0 +1,282 @@
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-cid"
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+	"github.com/pkg/errors"
+
+	"github.com/filecoin-project/venus/pkg/crypto"
+	"github.com/filecoin-project/venus/pkg/repo"
+	"github.com/filecoin-project/venus/pkg/wallet/key"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// hdHard marks a bip44 path component as hardened.
+const hdHard = 0x80000000
+
+// LedgerFilecoinBasePath is the bip44 base path used by the Filecoin ledger app,
+// m/44'/461'/0'/0, the address index is appended to it.
+var LedgerFilecoinBasePath = []uint32{hdHard | 44, hdHard | 461, hdHard, 0}
+
+var (
+	ErrLedgerUnavailable = errors.New("no ledger device found")
+	ErrLedgerNoPrivate   = errors.New("private keys never leave the ledger device")
+)
+
+// LedgerBackendType is the reflect type of the LedgerBackend.
+var LedgerBackendType = reflect.TypeOf(&LedgerBackend{})
+
+// LedgerDevice is the subset of the Filecoin ledger app used by the backend.
+type LedgerDevice interface {
+	// GetPublicKeySECP256K1 returns the uncompressed secp256k1 public key derived at path.
+	GetPublicKeySECP256K1(path []uint32) ([]byte, error)
+	// SignSECP256K1 asks the device to sign the cbor encoded unsigned message,
+	// the returned signature is in the 65 byte r|s|v form.
+	SignSECP256K1(path []uint32, unsignedMsg []byte) ([]byte, error)
+	Close() error
+}
+
+// LedgerOpener opens a connection to the first available ledger device.
+type LedgerOpener func() (LedgerDevice, error)
+
+// OpenLedger is the opener used by the node, it connects to the first ledger
+// device plugged in over USB.
+var OpenLedger LedgerOpener = openLedgerHID
+
+// LedgerKeyInfo records the derivation path of an address held by a ledger device.
+type LedgerKeyInfo struct {
+	Address address.Address
+	Path    []uint32
+}
+
+// MetaSigner is implemented by backends which need the message meta to sign,
+// hardware wallets display and sign the raw message rather than its digest.
+type MetaSigner interface {
+	SignWithMeta(ctx context.Context, data []byte, addr address.Address, meta types.MsgMeta) (*crypto.Signature, error)
+}
+
+// LedgerBackend is a wallet backend whose keys live on a ledger device, only the
+// derivation path of every address is stored in the datastore.
+type LedgerBackend struct {
+	lk sync.RWMutex
+
+	ds   repo.Datastore
+	open LedgerOpener
+
+	keys map[address.Address]*LedgerKeyInfo
+}
+
+var (
+	_ Backend    = (*LedgerBackend)(nil)
+	_ MetaSigner = (*LedgerBackend)(nil)
+)
+
+// NewLedgerBackend constructs a ledger backend, restoring known addresses from `ds`.
+func NewLedgerBackend(ctx context.Context, ds repo.Datastore, open LedgerOpener) (*LedgerBackend, error) {
+	result, err := ds.Query(ctx, dsq.Query{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query datastore")
+	}
+
+	list, err := result.Rest()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read query results")
+	}
+
+	keys := make(map[address.Address]*LedgerKeyInfo, len(list))
+	for _, el := range list {
+		var lki LedgerKeyInfo
+		if err := json.Unmarshal(el.Value, &lki); err != nil {
+			return nil, errors.Wrapf(err, "trying to restore invalid ledger key: %s", strings.Trim(el.Key, "/"))
+		}
+		keys[lki.Address] = &lki
+	}
+
+	return &LedgerBackend{
+		ds:   ds,
+		open: open,
+		keys: keys,
+	}, nil
+}
+
+// Addresses returns a list of all addresses that are stored in this backend.
+func (backend *LedgerBackend) Addresses(ctx context.Context) []address.Address {
+	backend.lk.RLock()
+	defer backend.lk.RUnlock()
+
+	addrs := make([]address.Address, 0, len(backend.keys))
+	for addr := range backend.keys {
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
+
+// HasAddress checks if the passed in address is stored in this backend.
+func (backend *LedgerBackend) HasAddress(ctx context.Context, addr address.Address) bool {
+	backend.lk.RLock()
+	defer backend.lk.RUnlock()
+
+	_, ok := backend.keys[addr]
+	return ok
+}
+
+// NewAddress derives the next secp256k1 address on the device and stores its path.
+func (backend *LedgerBackend) NewAddress(ctx context.Context) (address.Address, error) {
+	backend.lk.Lock()
+	defer backend.lk.Unlock()
+
+	var next uint32
+	for _, lki := range backend.keys {
+		if idx := lki.Path[len(lki.Path)-1]; idx >= next {
+			next = idx + 1
+		}
+	}
+
+	path := append(append([]uint32{}, LedgerFilecoinBasePath...), next)
+	return backend.importPath(ctx, path)
+}
+
+// ImportPath stores the address derived at `path`, the path must be under LedgerFilecoinBasePath.
+func (backend *LedgerBackend) ImportPath(ctx context.Context, path []uint32) (address.Address, error) {
+	if len(path) != len(LedgerFilecoinBasePath)+1 {
+		return address.Undef, fmt.Errorf("invalid derivation path length %d", len(path))
+	}
+	for i, p := range LedgerFilecoinBasePath {
+		if path[i] != p {
+			return address.Undef, fmt.Errorf("derivation path must start with m/44'/461'/0'/0")
+		}
+	}
+
+	backend.lk.Lock()
+	defer backend.lk.Unlock()
+
+	return backend.importPath(ctx, path)
+}
+
+func (backend *LedgerBackend) importPath(ctx context.Context, path []uint32) (address.Address, error) {
+	dev, err := backend.open()
+	if err != nil {
+		return address.Undef, err
+	}
+	defer dev.Close() // nolint:errcheck
+
+	pubKey, err := dev.GetPublicKeySECP256K1(path)
+	if err != nil {
+		return address.Undef, errors.Wrap(err, "getting public key from ledger")
+	}
+
+	addr, err := address.NewSecp256k1Address(pubKey)
+	if err != nil {
+		return address.Undef, err
+	}
+
+	lki := &LedgerKeyInfo{Address: addr, Path: path}
+	b, err := json.Marshal(lki)
+	if err != nil {
+		return address.Undef, err
+	}
+	if err := backend.ds.Put(ctx, ds.NewKey(addr.String()), b); err != nil {
+		return address.Undef, errors.Wrapf(err, "failed to store ledger address: %s", addr)
+	}
+	backend.keys[addr] = lki
+
+	return addr, nil
+}
+
+// DeleteAddress forgets the derivation path of `addr`, the key itself stays on the device.
+func (backend *LedgerBackend) DeleteAddress(ctx context.Context, addr address.Address) error {
+	backend.lk.Lock()
+	defer backend.lk.Unlock()
+
+	if _, ok := backend.keys[addr]; !ok {
+		return errors.New("backend does not contain address")
+	}
+	if err := backend.ds.Delete(ctx, ds.NewKey(addr.String())); err != nil {
+		return err
+	}
+	delete(backend.keys, addr)
+	return nil
+}
+
+// SignBytes is not supported, the ledger app only signs complete messages.
+func (backend *LedgerBackend) SignBytes(ctx context.Context, data []byte, addr address.Address) (*crypto.Signature, error) {
+	return nil, fmt.Errorf("ledger can only sign chain messages")
+}
+
+// SignWithMeta signs a chain message carried in `meta.Extra`, `data` must be the
+// signing bytes of that message.
+func (backend *LedgerBackend) SignWithMeta(ctx context.Context, data []byte, addr address.Address, meta types.MsgMeta) (*crypto.Signature, error) {
+	if meta.Type != types.MTChainMsg {
+		return nil, fmt.Errorf("ledger can only sign chain messages")
+	}
+
+	var msg types.Message
+	if err := msg.UnmarshalCBOR(bytes.NewReader(meta.Extra)); err != nil {
+		return nil, errors.Wrap(err, "unmarshalling message")
+	}
+	_, c, err := cid.CidFromBytes(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing signing bytes")
+	}
+	if !msg.Cid().Equals(c) {
+		return nil, fmt.Errorf("signing bytes do not match message %s", msg.Cid())
+	}
+
+	backend.lk.RLock()
+	lki, ok := backend.keys[addr]
+	backend.lk.RUnlock()
+	if !ok {
+		return nil, errors.New("backend does not contain address")
+	}
+
+	dev, err := backend.open()
+	if err != nil {
+		return nil, err
+	}
+	defer dev.Close() // nolint:errcheck
+
+	sig, err := dev.SignSECP256K1(lki.Path, meta.Extra)
+	if err != nil {
+		return nil, errors.Wrap(err, "ledger signing")
+	}
+
+	return &crypto.Signature{
+		Type: crypto.SigTypeSecp256k1,
+		Data: sig,
+	}, nil
+}
+
+// GetKeyInfo always fails, see ErrLedgerNoPrivate.
+func (backend *LedgerBackend) GetKeyInfo(context.Context, address.Address) (*key.KeyInfo, error) {
+	return nil, ErrLedgerNoPrivate
+}
+
+// GetKeyInfoPassphrase always fails, see ErrLedgerNoPrivate.
+func (backend *LedgerBackend) GetKeyInfoPassphrase(context.Context, address.Address, []byte) (*key.KeyInfo, error) {
+	return nil, ErrLedgerNoPrivate
+}
+
+// LockWallet is a no-op, the device holds its own lock.
+func (backend *LedgerBackend) LockWallet(context.Context) error {
+	return nil
+}
+
+// UnLockWallet is a no-op, the device holds its own lock.
+func (backend *LedgerBackend) UnLockWallet(context.Context, []byte) error {
+	return nil
+}
+
+// WalletState always reports unlocked.
+func (backend *LedgerBackend) WalletState(context.Context) int {
+	return Unlock
+}
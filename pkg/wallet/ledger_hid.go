@@ -0,0 +1,110 @@
+package wallet
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	ledger "github.com/zondax/ledger-go"
+)
+
+// APDUs of the Filecoin ledger app, https://github.com/Zondax/ledger-filecoin.
+const (
+	ledgerCLA                 = 0x06
+	ledgerINSGetAddrSECP256K1 = 0x01
+	ledgerINSSignSECP256K1    = 0x02
+
+	// the message to sign is sent in chunks following the one holding the path
+	ledgerChunkInit = 0
+	ledgerChunkAdd  = 1
+	ledgerChunkLast = 2
+	ledgerChunkSize = 250
+
+	ledgerPubKeyLen = 65
+	// the signature is returned as r|s|v followed by its DER encoding
+	ledgerSignatureLen = 65
+)
+
+// ledgerApp talks to the Filecoin app of a ledger device.
+type ledgerApp struct {
+	dev ledger.LedgerDevice
+}
+
+// openLedgerHID connects to the first ledger device found on the USB HID buses.
+func openLedgerHID() (LedgerDevice, error) {
+	admin := ledger.NewLedgerAdmin()
+	if admin.CountDevices() == 0 {
+		return nil, ErrLedgerUnavailable
+	}
+	dev, err := admin.Connect(0)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to ledger: %w", err)
+	}
+	return &ledgerApp{dev: dev}, nil
+}
+
+func (app *ledgerApp) GetPublicKeySECP256K1(path []uint32) ([]byte, error) {
+	pathBytes, err := ledgerPathBytes(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// the address is not shown on the device
+	apdu := append([]byte{ledgerCLA, ledgerINSGetAddrSECP256K1, 0, 0, byte(len(pathBytes))}, pathBytes...)
+	resp, err := app.dev.Exchange(apdu)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp) < ledgerPubKeyLen {
+		return nil, fmt.Errorf("invalid ledger response length %d", len(resp))
+	}
+	return resp[:ledgerPubKeyLen], nil
+}
+
+func (app *ledgerApp) SignSECP256K1(path []uint32, unsignedMsg []byte) ([]byte, error) {
+	pathBytes, err := ledgerPathBytes(path)
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := [][]byte{pathBytes}
+	for len(unsignedMsg) > ledgerChunkSize {
+		chunks = append(chunks, unsignedMsg[:ledgerChunkSize])
+		unsignedMsg = unsignedMsg[ledgerChunkSize:]
+	}
+	chunks = append(chunks, unsignedMsg)
+
+	var resp []byte
+	for i, chunk := range chunks {
+		desc := byte(ledgerChunkAdd)
+		switch i {
+		case 0:
+			desc = ledgerChunkInit
+		case len(chunks) - 1:
+			desc = ledgerChunkLast
+		}
+		apdu := append([]byte{ledgerCLA, ledgerINSSignSECP256K1, desc, 0, byte(len(chunk))}, chunk...)
+		if resp, err = app.dev.Exchange(apdu); err != nil {
+			return nil, err
+		}
+	}
+	if len(resp) < ledgerSignatureLen {
+		return nil, fmt.Errorf("invalid ledger signature length %d", len(resp))
+	}
+	return resp[:ledgerSignatureLen], nil
+}
+
+func (app *ledgerApp) Close() error {
+	return app.dev.Close()
+}
+
+// ledgerPathBytes encodes a bip44 path as the app expects it, five little endian uint32.
+func ledgerPathBytes(path []uint32) ([]byte, error) {
+	if len(path) != 5 {
+		return nil, fmt.Errorf("invalid derivation path length %d", len(path))
+	}
+	out := make([]byte, 4*len(path))
+	for i, p := range path {
+		binary.LittleEndian.PutUint32(out[4*i:], p)
+	}
+	return out, nil
+}
@@ -0,0 +1,179 @@
+// stm: #unit
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/crypto"
+	_ "github.com/filecoin-project/venus/pkg/crypto/secp" // enable secp signatures
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// fakeLedger derives a deterministic private key per address index.
+type fakeLedger struct {
+	keys map[uint32][]byte
+}
+
+func (l *fakeLedger) priv(path []uint32) ([]byte, error) {
+	idx := path[len(path)-1]
+	if k, ok := l.keys[idx]; ok {
+		return k, nil
+	}
+	k, err := crypto.Generate(crypto.SigTypeSecp256k1)
+	if err != nil {
+		return nil, err
+	}
+	l.keys[idx] = k
+	return k, nil
+}
+
+func (l *fakeLedger) GetPublicKeySECP256K1(path []uint32) ([]byte, error) {
+	k, err := l.priv(path)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.ToPublic(crypto.SigTypeSecp256k1, k)
+}
+
+func (l *fakeLedger) SignSECP256K1(path []uint32, unsignedMsg []byte) ([]byte, error) {
+	k, err := l.priv(path)
+	if err != nil {
+		return nil, err
+	}
+	var msg types.Message
+	if err := msg.UnmarshalCBOR(bytes.NewReader(unsignedMsg)); err != nil {
+		return nil, err
+	}
+	sig, err := crypto.Sign(msg.Cid().Bytes(), k, crypto.SigTypeSecp256k1)
+	if err != nil {
+		return nil, err
+	}
+	return sig.Data, nil
+}
+
+func (l *fakeLedger) Close() error { return nil }
+
+func TestLedgerBackend(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	dev := &fakeLedger{keys: map[uint32][]byte{}}
+	open := func() (LedgerDevice, error) { return dev, nil }
+	mds := datastore.NewMapDatastore()
+
+	backend, err := NewLedgerBackend(ctx, mds, open)
+	require.NoError(t, err)
+	w := New(backend)
+
+	addr1, err := w.NewLedgerAddress(ctx)
+	require.NoError(t, err)
+	addr2, err := w.NewLedgerAddress(ctx)
+	require.NoError(t, err)
+	assert.NotEqual(t, addr1, addr2)
+	assert.Equal(t, address.SECP256K1, addr1.Protocol())
+	assert.ElementsMatch(t, []address.Address{addr1, addr2}, w.Addresses(ctx))
+
+	t.Run("restore from datastore", func(t *testing.T) {
+		restored, err := NewLedgerBackend(ctx, mds, open)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []address.Address{addr1, addr2}, restored.Addresses(ctx))
+	})
+
+	t.Run("sign chain message", func(t *testing.T) {
+		from, err := address.NewIDAddress(100)
+		require.NoError(t, err)
+		msg := &types.Message{From: addr1, To: from, Value: big.NewInt(1), GasFeeCap: big.Zero(), GasPremium: big.Zero()}
+		mb, err := msg.ToStorageBlock()
+		require.NoError(t, err)
+
+		sig, err := w.WalletSign(ctx, addr1, msg.Cid().Bytes(), types.MsgMeta{Type: types.MTChainMsg, Extra: mb.RawData()})
+		require.NoError(t, err)
+		assert.NoError(t, crypto.Verify(sig, addr1, msg.Cid().Bytes()))
+
+		_, err = w.WalletSign(ctx, addr1, []byte("other"), types.MsgMeta{Type: types.MTChainMsg, Extra: mb.RawData()})
+		assert.Error(t, err)
+		_, err = w.WalletSign(ctx, addr1, msg.Cid().Bytes(), types.MsgMeta{Type: types.MTUnknown})
+		assert.Error(t, err)
+	})
+
+	t.Run("private key never exported", func(t *testing.T) {
+		_, err := w.Export(ctx, addr1, "")
+		assert.ErrorIs(t, err, ErrLedgerNoPrivate)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, w.DeleteAddress(ctx, addr2))
+		assert.False(t, w.HasAddress(ctx, addr2))
+	})
+
+	t.Run("import path", func(t *testing.T) {
+		_, err := backend.ImportPath(ctx, []uint32{hdHard | 44, hdHard | 60, hdHard, 0, 0})
+		assert.Error(t, err)
+
+		addr, err := backend.ImportPath(ctx, append(append([]uint32{}, LedgerFilecoinBasePath...), 7))
+		require.NoError(t, err)
+		assert.True(t, w.HasAddress(ctx, addr))
+	})
+}
+
+// fakeAPDUDevice records the apdus it receives and answers them with resp.
+type fakeAPDUDevice struct {
+	apdus [][]byte
+	resp  []byte
+}
+
+func (d *fakeAPDUDevice) Exchange(apdu []byte) ([]byte, error) {
+	d.apdus = append(d.apdus, apdu)
+	return d.resp, nil
+}
+
+func (d *fakeAPDUDevice) Close() error { return nil }
+
+func TestLedgerApp(t *testing.T) {
+	tf.UnitTest(t)
+
+	path := append(append([]uint32{}, LedgerFilecoinBasePath...), 3)
+	pathBytes := []byte{
+		44, 0, 0, 0x80,
+		0xcd, 0x01, 0, 0x80,
+		0, 0, 0, 0x80,
+		0, 0, 0, 0,
+		3, 0, 0, 0,
+	}
+
+	t.Run("public key", func(t *testing.T) {
+		dev := &fakeAPDUDevice{resp: bytes.Repeat([]byte{1}, 100)}
+		pub, err := (&ledgerApp{dev: dev}).GetPublicKeySECP256K1(path)
+		require.NoError(t, err)
+		assert.Len(t, pub, ledgerPubKeyLen)
+		require.Len(t, dev.apdus, 1)
+		assert.Equal(t, append([]byte{ledgerCLA, ledgerINSGetAddrSECP256K1, 0, 0, 20}, pathBytes...), dev.apdus[0])
+
+		_, err = (&ledgerApp{dev: &fakeAPDUDevice{resp: []byte{1}}}).GetPublicKeySECP256K1(path)
+		assert.Error(t, err)
+		_, err = (&ledgerApp{dev: dev}).GetPublicKeySECP256K1(LedgerFilecoinBasePath)
+		assert.Error(t, err)
+	})
+
+	t.Run("sign in chunks", func(t *testing.T) {
+		dev := &fakeAPDUDevice{resp: bytes.Repeat([]byte{2}, 135)}
+		msg := bytes.Repeat([]byte{9}, ledgerChunkSize+10)
+		sig, err := (&ledgerApp{dev: dev}).SignSECP256K1(path, msg)
+		require.NoError(t, err)
+		assert.Len(t, sig, ledgerSignatureLen)
+
+		require.Len(t, dev.apdus, 3)
+		assert.Equal(t, append([]byte{ledgerCLA, ledgerINSSignSECP256K1, ledgerChunkInit, 0, 20}, pathBytes...), dev.apdus[0])
+		assert.Equal(t, append([]byte{ledgerCLA, ledgerINSSignSECP256K1, ledgerChunkAdd, 0, ledgerChunkSize}, msg[:ledgerChunkSize]...), dev.apdus[1])
+		assert.Equal(t, append([]byte{ledgerCLA, ledgerINSSignSECP256K1, ledgerChunkLast, 0, 10}, msg[ledgerChunkSize:]...), dev.apdus[2])
+	})
+}
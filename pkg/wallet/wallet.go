@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/filecoin-project/venus/venus-shared/types"
 
@@ -239,10 +240,41 @@ func (w *Wallet) WalletSign(ctx context.Context, addr address.Address, msg []byt
 	if ki == nil {
 		return nil, errors.Errorf("signing using key '%s': %v", addr.String(), ErrKeyInfoNotFound)
 	}
+	if ms, ok := ki.(MetaSigner); ok {
+		return ms.SignWithMeta(ctx, msg, addr, meta)
+	}
 
 	return ki.SignBytes(ctx, msg, addr)
 }
 
+// NewDelegatedFromSecp imports the private key behind a secp256k1 address as a delegated key
+// and returns the f410 address it controls.
+func (w *Wallet) NewDelegatedFromSecp(ctx context.Context, addr address.Address) (address.Address, error) {
+	if addr.Protocol() != address.SECP256K1 {
+		return address.Undef, errors.Errorf("%s is not a secp256k1 address", addr)
+	}
+	ki, err := w.keyInfoForAddr(ctx, addr)
+	if err != nil {
+		return address.Undef, err
+	}
+	dki, err := key.NewDelegatedKeyFromSecp(ki)
+	if err != nil {
+		return address.Undef, err
+	}
+
+	return w.Import(ctx, &dki)
+}
+
+// NewLedgerAddress derives a new secp256k1 address on the ledger backend.
+func (w *Wallet) NewLedgerAddress(ctx context.Context) (address.Address, error) {
+	backends := w.Backends(LedgerBackendType)
+	if len(backends) == 0 {
+		return address.Undef, errors.Errorf("ledger wallet is not enabled")
+	}
+
+	return (backends[0]).(*LedgerBackend).NewAddress(ctx)
+}
+
 // DSBacked return the first wallet backend
 // todo support multi wallet backend
 func (w *Wallet) DSBacked() (*DSBackend, error) {
@@ -274,6 +306,15 @@ func (w *Wallet) UnLockWallet(ctx context.Context, password []byte) error {
 	return backend.UnLockWallet(ctx, password)
 }
 
+// UnLockWalletFor unlock local wallet with password and lock it again after timeout
+func (w *Wallet) UnLockWalletFor(ctx context.Context, password []byte, timeout time.Duration) error {
+	backend, err := w.DSBacked()
+	if err != nil {
+		return err
+	}
+	return backend.UnLockWalletFor(ctx, password, timeout)
+}
+
 // SetPassword
 func (w *Wallet) SetPassword(ctx context.Context, password []byte) error {
 	backend, err := w.DSBacked()
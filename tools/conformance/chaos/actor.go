@@ -3,6 +3,7 @@ package chaos
 import (
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/go-state-types/cbor"
 	"github.com/filecoin-project/go-state-types/exitcode"
 	"github.com/filecoin-project/go-state-types/rt"
@@ -25,8 +26,13 @@ import (
 // It cannot be instantiated via the init actor, and its constructor panics.
 //
 // Test vectors relying on the chaos actor being deployed will carry selector
-// "chaos_actor:true".
-type Actor struct{}
+// "chaos_actor:true", vectors relying on the fuzzing methods (NestedSend and
+// BurnGas) will additionally carry selector "chaos_actor_fuzzing:true".
+type Actor struct {
+	// Fuzzing exports the methods that exercise deep nested sends and gas
+	// exhaustion.
+	Fuzzing bool
+}
 
 // CallerValidationBranch is an enum used to select a branch in the
 // CallerValidation method.
@@ -53,6 +59,21 @@ const (
 	MutateReadonly
 	// MutateAfterTransaction ILLEGALLY mutates state after a transaction.
 	MutateAfterTransaction
+	// MutateThenAbort legally mutates state within a transaction and aborts
+	// afterwards, the mutation must be reverted.
+	MutateThenAbort
+)
+
+// BurnGasPoint is an enum used to select the point at which BurnGas charges gas.
+type BurnGasPoint int64
+
+const (
+	// BurnGasBeforeMutation charges gas before the state is mutated.
+	BurnGasBeforeMutation BurnGasPoint = iota
+	// BurnGasInTransaction charges gas inside the state transaction.
+	BurnGasInTransaction
+	// BurnGasAfterMutation charges gas after the state transaction is committed.
+	BurnGasAfterMutation
 )
 
 const (
@@ -73,6 +94,12 @@ const (
 	// MethodInspectRuntime is the identifier for the method that returns the
 	// current runtime values.
 	MethodInspectRuntime
+	// MethodNestedSend is the identifier for the method that recursively sends
+	// to itself.
+	MethodNestedSend
+	// MethodBurnGas is the identifier for the method that charges an arbitrary
+	// amount of gas around a state mutation.
+	MethodBurnGas
 )
 
 // Exports defines the methods this actor exposes publicly.
@@ -87,9 +114,19 @@ func (a Actor) Exports() []interface{} {
 		MethodMutateState:         a.MutateState,
 		MethodAbortWith:           a.AbortWith,
 		MethodInspectRuntime:      a.InspectRuntime,
+		MethodNestedSend:          a.fuzzing(a.NestedSend),
+		MethodBurnGas:             a.fuzzing(a.BurnGas),
 	}
 }
 
+// fuzzing hides method unless the fuzzing methods are enabled.
+func (a Actor) fuzzing(method interface{}) interface{} {
+	if !a.Fuzzing {
+		return nil
+	}
+	return method
+}
+
 func (a Actor) Code() cid.Cid     { return ChaosActorCodeCID }
 func (a Actor) State() cbor.Er    { return new(State) }
 func (a Actor) IsSingleton() bool { return true }
@@ -252,6 +289,11 @@ func (a Actor) MutateState(rt runtime2.Runtime, args *MutateStateArgs) *abi.Empt
 			st.Value = args.Value + "-in"
 		})
 		st.Value = args.Value
+	case MutateThenAbort:
+		rt.StateTransaction(&st, func() {
+			st.Value = args.Value
+		})
+		rt.Abortf(exitcode.ErrIllegalState, "aborting after state mutation")
 	default:
 		panic("unknown mutation type")
 	}
@@ -300,3 +342,85 @@ func (a Actor) InspectRuntime(rt runtime2.Runtime, _ *abi.EmptyValue) *InspectRu
 		State:          st,
 	}
 }
+
+// NestedSendArgs are the arguments to the Actor.NestedSend method.
+type NestedSendArgs struct {
+	// Depth is the number of nested sends still to perform.
+	Depth uint64
+	// AbortCode, if not zero, is the exit code the innermost call aborts with.
+	AbortCode exitcode.ExitCode
+}
+
+// NestedSendReturn is the return value for the Actor.NestedSend method.
+type NestedSendReturn struct {
+	// Levels is the number of calls that returned successfully, including this one.
+	Levels uint64
+	// Code is the exit code of the nested send that failed, if any.
+	Code exitcode.ExitCode
+}
+
+// NestedSend sends to itself until Depth is exhausted, the innermost call
+// optionally aborts with AbortCode. The failure of a nested send is reported
+// in the return value rather than propagated.
+func (a Actor) NestedSend(rt runtime2.Runtime, args *NestedSendArgs) *NestedSendReturn {
+	rt.ValidateImmediateCallerAcceptAny()
+	if args.Depth == 0 {
+		if args.AbortCode != exitcode.Ok {
+			rt.Abortf(args.AbortCode, "aborting at the innermost nested send")
+		}
+		return &NestedSendReturn{Levels: 1}
+	}
+
+	var out NestedSendReturn
+	code := rt.Send(
+		rt.Receiver(),
+		MethodNestedSend,
+		&NestedSendArgs{Depth: args.Depth - 1, AbortCode: args.AbortCode},
+		big.Zero(),
+		&out,
+	)
+	if code != exitcode.Ok {
+		return &NestedSendReturn{Levels: 1, Code: code}
+	}
+	return &NestedSendReturn{Levels: out.Levels + 1, Code: out.Code}
+}
+
+// BurnGasArgs are the arguments to the Actor.BurnGas method.
+type BurnGasArgs struct {
+	Gas   int64
+	Point BurnGasPoint
+	// Value is stored in the state by the mutation.
+	Value string
+}
+
+// BurnGas charges Gas at the selected point around a state mutation, making it
+// possible to exhaust the gas limit of a message precisely before, during or
+// after the state is written.
+func (a Actor) BurnGas(rt runtime2.Runtime, args *BurnGasArgs) *abi.EmptyValue {
+	rt.ValidateImmediateCallerAcceptAny()
+	burn := func() {
+		rt.ChargeGas("OnChaosBurnGas", args.Gas, 0)
+	}
+
+	var st State
+	switch args.Point {
+	case BurnGasBeforeMutation:
+		burn()
+		rt.StateTransaction(&st, func() {
+			st.Value = args.Value
+		})
+	case BurnGasInTransaction:
+		rt.StateTransaction(&st, func() {
+			st.Value = args.Value
+			burn()
+		})
+	case BurnGasAfterMutation:
+		rt.StateTransaction(&st, func() {
+			st.Value = args.Value
+		})
+		burn()
+	default:
+		panic("unknown burn gas point")
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/go-state-types/exitcode"
 	"github.com/ipfs/go-cid"
 
@@ -226,6 +227,27 @@ func TestMutateStateReadonly(t *testing.T) {
 	})
 }
 
+func TestMutateStateThenAbort(t *testing.T) {
+	tf.UnitTest(t)
+	receiver := atesting2.NewIDAddr(t, 100)
+	builder := mock2.NewBuilder(context.Background(), receiver)
+
+	rt := builder.Build(t)
+	var a Actor
+
+	rt.ExpectValidateCallerAny()
+	rt.Call(a.CreateState, nil)
+
+	rt.ExpectValidateCallerAny()
+	rt.ExpectAbort(exitcode.ErrIllegalState, func() {
+		rt.Call(a.MutateState, &MutateStateArgs{
+			Value:  "__mutstat test",
+			Branch: MutateThenAbort,
+		})
+	})
+	rt.Verify()
+}
+
 func TestMutateStateInvalidBranch(t *testing.T) {
 	tf.UnitTest(t)
 	receiver := atesting2.NewIDAddr(t, 100)
@@ -305,3 +327,79 @@ func TestInspectRuntime(t *testing.T) {
 	}
 	rt.Verify()
 }
+
+func TestFuzzingExports(t *testing.T) {
+	tf.UnitTest(t)
+	exports := Actor{}.Exports()
+	if exports[MethodNestedSend] != nil || exports[MethodBurnGas] != nil {
+		t.Fatal("fuzzing methods exported without being enabled")
+	}
+
+	exports = Actor{Fuzzing: true}.Exports()
+	if exports[MethodNestedSend] == nil || exports[MethodBurnGas] == nil {
+		t.Fatal("fuzzing methods not exported")
+	}
+}
+
+func TestNestedSend(t *testing.T) {
+	tf.UnitTest(t)
+	receiver := atesting2.NewIDAddr(t, 100)
+	builder := mock2.NewBuilder(context.Background(), receiver)
+
+	rt := builder.Build(t)
+	var a Actor
+
+	rt.ExpectValidateCallerAny()
+	rt.ExpectSend(receiver, MethodNestedSend, &NestedSendArgs{Depth: 1}, big.Zero(), &NestedSendReturn{Levels: 2}, exitcode.Ok)
+	ret := rt.Call(a.NestedSend, &NestedSendArgs{Depth: 2}).(*NestedSendReturn)
+	if ret.Levels != 3 || ret.Code != exitcode.Ok {
+		t.Fatalf("unexpected return: %+v", ret)
+	}
+	rt.Verify()
+
+	rt.ExpectValidateCallerAny()
+	rt.ExpectSend(receiver, MethodNestedSend, &NestedSendArgs{Depth: 0, AbortCode: exitcode.ErrForbidden}, big.Zero(), &NestedSendReturn{}, exitcode.ErrForbidden)
+	ret = rt.Call(a.NestedSend, &NestedSendArgs{Depth: 1, AbortCode: exitcode.ErrForbidden}).(*NestedSendReturn)
+	if ret.Levels != 1 || ret.Code != exitcode.ErrForbidden {
+		t.Fatalf("unexpected return: %+v", ret)
+	}
+	rt.Verify()
+
+	rt.ExpectValidateCallerAny()
+	rt.ExpectAbort(exitcode.ErrForbidden, func() {
+		rt.Call(a.NestedSend, &NestedSendArgs{AbortCode: exitcode.ErrForbidden})
+	})
+	rt.Verify()
+}
+
+func TestBurnGas(t *testing.T) {
+	tf.UnitTest(t)
+	receiver := atesting2.NewIDAddr(t, 100)
+	builder := mock2.NewBuilder(context.Background(), receiver)
+
+	rt := builder.Build(t)
+	var a Actor
+
+	rt.ExpectValidateCallerAny()
+	rt.Call(a.CreateState, nil)
+
+	var charged int64
+	for _, point := range []BurnGasPoint{BurnGasBeforeMutation, BurnGasInTransaction, BurnGasAfterMutation} {
+		rt.ExpectValidateCallerAny()
+		rt.Call(a.BurnGas, &BurnGasArgs{Gas: 1000, Point: point, Value: "__burn gas"})
+		charged += 1000
+		rt.ExpectGasCharged(charged)
+
+		var st State
+		rt.GetState(&st)
+		if st.Value != "__burn gas" {
+			t.Fatal("state was not updated")
+		}
+	}
+
+	rt.ExpectValidateCallerAny()
+	rt.ExpectAssertionFailure("unknown burn gas point", func() {
+		rt.Call(a.BurnGas, &BurnGasArgs{Point: -1})
+	})
+	rt.Verify()
+}
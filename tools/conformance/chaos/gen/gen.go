@@ -17,6 +17,9 @@ func main() {
 		chaos.MutateStateArgs{},
 		chaos.AbortWithArgs{},
 		chaos.InspectRuntimeReturn{},
+		chaos.NestedSendArgs{},
+		chaos.NestedSendReturn{},
+		chaos.BurnGasArgs{},
 	); err != nil {
 		panic(err)
 	}
@@ -12,7 +12,7 @@ import (
 	"github.com/filecoin-project/test-vectors/schema"
 )
 
-var invokees = map[schema.Class]func(Reporter, string, *schema.TestVector, *schema.Variant){
+var invokees = map[schema.Class]func(Reporter, string, *Vector, *schema.Variant){
 	schema.ClassMessage: ExecuteMessageVector,
 	schema.ClassTipset:  ExecuteTipsetVector,
 }
@@ -110,7 +110,7 @@ func TestConformance(t *testing.T) {
 			t.Fatalf("failed to read test raw file: %s", path)
 		}
 
-		var vector schema.TestVector
+		var vector Vector
 		err = json.Unmarshal(raw, &vector)
 		if err != nil {
 			t.Errorf("failed to parse test vector %s: %s; skipping", path, err)
@@ -2,6 +2,7 @@ package conformance
 
 import (
 	"context"
+	"fmt"
 	gobig "math/big"
 	"os"
 
@@ -213,10 +214,33 @@ type ExecuteMessageParams struct {
 	// Lookback is the LookbackStateGetter; returns the state tree at a given epoch.
 	Lookback vm.LookbackStateGetter
 
-	// TipSetGetter returns the tipset key at any given epoch.
+	// LookbackRoots are the state roots at the epochs looked back by the message, used to
+	// build Lookback when it is not set.
+	LookbackRoots map[abi.ChainEpoch]cid.Cid
+
+	// TipSetGetter returns the tipset key at any given epoch, use NewRecordingTipSetGetter
+	// to capture the lookups and NewReplayingTipSetGetter to replay them.
 	TipSetGetter vm.TipSetGetter
 }
 
+// LookbackStateGetterForRoots returns the state at the closest epoch in roots not after the requested
+// one, null rounds carry the state of the last non-null epoch.
+func LookbackStateGetterForRoots(bs blockstoreutil.Blockstore, roots map[abi.ChainEpoch]cid.Cid) vm.LookbackStateGetter {
+	return func(ctx context.Context, epoch abi.ChainEpoch) (*state.View, error) {
+		found := false
+		var closest abi.ChainEpoch
+		for e := range roots {
+			if e <= epoch && (!found || e > closest) {
+				closest, found = e, true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no lookback state recorded at or before epoch %d", epoch)
+		}
+		return state.NewView(cbor.NewCborStore(bs), roots[closest]), nil
+	}
+}
+
 // ExecuteMessage executes a conformance test vector message in a temporary LegacyVM.
 func (d *Driver) ExecuteMessage(bs blockstoreutil.Blockstore, params ExecuteMessageParams) (*vm.Ret, cid.Cid, error) {
 	if !d.vmFlush {
@@ -232,20 +256,20 @@ func (d *Driver) ExecuteMessage(bs blockstoreutil.Blockstore, params ExecuteMess
 		params.Rand = NewFixedRand()
 	}
 	if params.TipSetGetter == nil {
-		// TODO: If/when we start writing conformance tests against the EVM, we'll need to
-		// actually implement this and (unfortunately) capture any tipsets looked up by
-		// messages.
+		// vectors without recorded tipset lookups are only executed against non-EVM actors,
+		// which never look up tipsets; see NewReplayingTipSetGetter.
 		params.TipSetGetter = func(context.Context, abi.ChainEpoch) (types.TipSetKey, error) {
 			return types.EmptyTSK, nil
 		}
 	}
+	if params.Lookback == nil && len(params.LookbackRoots) > 0 {
+		params.Lookback = LookbackStateGetterForRoots(bs, params.LookbackRoots)
+	}
 	if params.Lookback == nil {
-		// TODO: This lookback state returns the supplied precondition state tree, unconditionally.
-		//  This is obviously not correct, but the lookback state tree is only used to validate the
+		// Vectors without lookback states get the supplied precondition state tree, unconditionally.
+		//  This is not correct, but the lookback state tree is only used to validate the
 		//  worker key when verifying a consensus fault. If the worker key hasn't changed in the
 		//  current finality window, this workaround is enough.
-		//  The correct solutions are documented in https://github.com/filecoin-project/ref-fvm/issues/381,
-		//  but they're much harder to implement, and the tradeoffs aren't clear.
 		params.Lookback = func(ctx context.Context, epoch abi.ChainEpoch) (*state.View, error) {
 			cst := cbor.NewCborStore(bs)
 			return state.NewView(cst, params.Preroot), nil
@@ -289,10 +313,12 @@ func (d *Driver) ExecuteMessage(bs blockstoreutil.Blockstore, params ExecuteMess
 	)
 
 	var vmi vm.Interface
-	// register the chaos actor if required by the vector.
+	// register the chaos actor if required by the vector, together with its
+	// fuzzing methods if the vector requires them too.
 	if chaosOn, ok := d.selector["chaos_actor"]; ok && chaosOn == "true" {
 		av, _ := actorstypes.VersionForNetwork(params.NetworkVersion)
-		actorBuilder.AddMany(av, nil, builtin.MakeRegistryLegacy([]rtt.VMActor{chaos.Actor{}}))
+		actor := chaos.Actor{Fuzzing: d.selector["chaos_actor_fuzzing"] == "true"}
+		actorBuilder.AddMany(av, nil, builtin.MakeRegistryLegacy([]rtt.VMActor{actor}))
 		coderLoader = actorBuilder.Build()
 		vmOption.ActorCodeLoader = &coderLoader
 		vmi, err = vm.NewLegacyVM(ctx, vmOption)
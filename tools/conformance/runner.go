@@ -9,6 +9,7 @@ import (
 	"os"
 	"os/exec"
 	"strconv"
+	"strings"
 
 	"github.com/filecoin-project/go-state-types/network"
 	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
@@ -30,7 +31,7 @@ import (
 )
 
 // ExecuteMessageVector executes a message-class test vector.
-func ExecuteMessageVector(r Reporter, v string, vector *schema.TestVector, variant *schema.Variant) {
+func ExecuteMessageVector(r Reporter, v string, vector *Vector, variant *schema.Variant) {
 	var (
 		ctx       = context.Background()
 		baseEpoch = variant.Epoch
@@ -67,6 +68,8 @@ func ExecuteMessageVector(r Reporter, v string, vector *schema.TestVector, varia
 			BaseFee:        BaseFeeOrDefault(vector.Pre.BaseFee),
 			CircSupply:     CircSupplyOrDefault(vector.Pre.CircSupply),
 			Rand:           NewReplayingRand(r, vector.Randomness),
+			TipSetGetter:   NewReplayingTipSetGetter(r, vector.TipSets).GetTipSetKey,
+			LookbackRoots:  vector.LookbackRoots(),
 			NetworkVersion: nv,
 		})
 		if err != nil {
@@ -81,13 +84,13 @@ func ExecuteMessageVector(r Reporter, v string, vector *schema.TestVector, varia
 	// the expected postcondition root.
 	if expected, actual := vector.Post.StateTree.RootCID, root; expected != actual {
 		r.Errorf("wrong post root cid; expected %v, but got %v", expected, actual)
-		dumpThreeWayStateDiff(r, vector, bs, root)
+		dumpThreeWayStateDiff(r, &vector.TestVector, bs, root)
 		r.FailNow()
 	}
 }
 
 // ExecuteTipsetVector executes a tipset-class test vector.
-func ExecuteTipsetVector(r Reporter, v string, vector *schema.TestVector, variant *schema.Variant) {
+func ExecuteTipsetVector(r Reporter, v string, vector *Vector, variant *schema.Variant) {
 	var (
 		ctx       = context.Background()
 		baseEpoch = abi.ChainEpoch(variant.Epoch)
@@ -133,7 +136,7 @@ func ExecuteTipsetVector(r Reporter, v string, vector *schema.TestVector, varian
 	// the expected postcondition root.
 	if expected, actual := vector.Post.StateTree.RootCID, root; expected != actual {
 		r.Errorf("wrong post root cid; expected %v, but got %v", expected, actual)
-		dumpThreeWayStateDiff(r, vector, bs, root)
+		dumpThreeWayStateDiff(r, &vector.TestVector, bs, root)
 		r.FailNow()
 	}
 }
@@ -156,6 +159,15 @@ func AssertMsgResult(r Reporter, expected *schema.Receipt, actual *vm.Ret, label
 }
 
 func dumpThreeWayStateDiff(r Reporter, vector *schema.TestVector, bs blockstoreutil.Blockstore, actual cid.Cid) {
+	// report the actors and state nodes that differ between the expected and actual final state.
+	if diff, err := DiffStateTrees(context.TODO(), bs, vector.Post.StateTree.RootCID, actual); err != nil {
+		r.Logf("failed to diff expected and actual final state: %s", err)
+	} else {
+		var report strings.Builder
+		_ = diff.WriteReport(&report)
+		r.Log(report.String())
+	}
+
 	// check if statediff exists; if not, skip.
 	if err := exec.Command("statediff", "--help").Run(); err != nil {
 		r.Log("could not dump 3-way state tree diff upon test failure: statediff command not found")
@@ -0,0 +1,252 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	format "github.com/ipfs/go-ipld-format"
+
+	"github.com/filecoin-project/venus/pkg/state/tree"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// maxNodeDiffs bounds the number of IPLD nodes reported for a single actor.
+const maxNodeDiffs = 64
+
+// Kinds of ActorDiff.
+const (
+	ActorAdded   = "added"
+	ActorRemoved = "removed"
+	ActorChanged = "changed"
+)
+
+// NodeDiff is an IPLD node of an actor state that differs between two state trees,
+// Path is the sequence of link names from the actor head to the node.
+type NodeDiff struct {
+	Path  string
+	Left  cid.Cid
+	Right cid.Cid
+}
+
+// ActorDiff is an actor that differs between two state trees.
+type ActorDiff struct {
+	Address address.Address
+	Kind    string
+	Left    *types.Actor
+	Right   *types.Actor
+	// Nodes are the differing IPLD nodes of the actor state, only set for changed actors.
+	Nodes []NodeDiff
+	// Truncated is set when more than maxNodeDiffs nodes differ.
+	Truncated bool
+}
+
+// StateDiff is the difference between two state trees, actor by actor.
+type StateDiff struct {
+	Left   cid.Cid
+	Right  cid.Cid
+	Actors []ActorDiff
+}
+
+// DiffStateTrees compares the state trees rooted at left and right, first actor by actor and
+// then, for the actors whose state differs, IPLD node by IPLD node. Nodes missing from bs are
+// reported but not descended into.
+func DiffStateTrees(ctx context.Context, bs blockstoreutil.Blockstore, left, right cid.Cid) (*StateDiff, error) {
+	cst := cbor.NewCborStore(bs)
+	lt, err := tree.LoadState(ctx, cst, left)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load left state tree %s: %w", left, err)
+	}
+	rt, err := tree.LoadState(ctx, cst, right)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load right state tree %s: %w", right, err)
+	}
+
+	leftActors, err := collectActors(lt)
+	if err != nil {
+		return nil, err
+	}
+	rightActors, err := collectActors(rt)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &StateDiff{Left: left, Right: right}
+	for addr, la := range leftActors {
+		ra, ok := rightActors[addr]
+		if !ok {
+			diff.Actors = append(diff.Actors, ActorDiff{Address: addr, Kind: ActorRemoved, Left: la})
+			continue
+		}
+		if actorsEqual(la, ra) {
+			continue
+		}
+		ad := ActorDiff{Address: addr, Kind: ActorChanged, Left: la, Right: ra}
+		if la.Head != ra.Head {
+			if err := diffNodes(ctx, bs, "", la.Head, ra.Head, &ad); err != nil {
+				return nil, fmt.Errorf("failed to diff state of actor %s: %w", addr, err)
+			}
+		}
+		diff.Actors = append(diff.Actors, ad)
+	}
+	for addr, ra := range rightActors {
+		if _, ok := leftActors[addr]; !ok {
+			diff.Actors = append(diff.Actors, ActorDiff{Address: addr, Kind: ActorAdded, Right: ra})
+		}
+	}
+	sort.Slice(diff.Actors, func(i, j int) bool {
+		return diff.Actors[i].Address.String() < diff.Actors[j].Address.String()
+	})
+
+	return diff, nil
+}
+
+func collectActors(st *tree.State) (map[address.Address]*types.Actor, error) {
+	actors := make(map[address.Address]*types.Actor)
+	err := st.ForEach(func(addr tree.ActorKey, act *types.Actor) error {
+		actors[addr] = act
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk state tree: %w", err)
+	}
+	return actors, nil
+}
+
+func actorsEqual(a, b *types.Actor) bool {
+	if a.Code != b.Code || a.Head != b.Head || a.Nonce != b.Nonce || !a.Balance.Equals(b.Balance) {
+		return false
+	}
+	if a.DelegatedAddress == nil || b.DelegatedAddress == nil {
+		return a.DelegatedAddress == b.DelegatedAddress
+	}
+	return *a.DelegatedAddress == *b.DelegatedAddress
+}
+
+// diffNodes walks the links of left and right by name, and records the deepest nodes that differ.
+func diffNodes(ctx context.Context, bs blockstoreutil.Blockstore, path string, left, right cid.Cid, ad *ActorDiff) error {
+	if left == right {
+		return nil
+	}
+	if len(ad.Nodes) >= maxNodeDiffs {
+		ad.Truncated = true
+		return nil
+	}
+
+	leftLinks, err := nodeLinks(ctx, bs, left)
+	if err != nil {
+		return err
+	}
+	rightLinks, err := nodeLinks(ctx, bs, right)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(leftLinks)+len(rightLinks))
+	for name := range leftLinks {
+		names = append(names, name)
+	}
+	for name := range rightLinks {
+		if _, ok := leftLinks[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	descended := false
+	for _, name := range names {
+		l, r := leftLinks[name], rightLinks[name]
+		if l == r {
+			continue
+		}
+		descended = true
+		if !l.Defined() || !r.Defined() {
+			ad.Nodes = append(ad.Nodes, NodeDiff{Path: path + "/" + name, Left: l, Right: r})
+			continue
+		}
+		if err := diffNodes(ctx, bs, path+"/"+name, l, r, ad); err != nil {
+			return err
+		}
+	}
+	// the links are the same, the node data itself differs.
+	if !descended {
+		if path == "" {
+			path = "/"
+		}
+		ad.Nodes = append(ad.Nodes, NodeDiff{Path: path, Left: left, Right: right})
+	}
+	return nil
+}
+
+// nodeLinks returns the links of a dag-cbor node by name, nodes of other codecs or missing
+// from bs have no links.
+func nodeLinks(ctx context.Context, bs blockstoreutil.Blockstore, c cid.Cid) (map[string]cid.Cid, error) {
+	links := make(map[string]cid.Cid)
+	if c.Prefix().Codec != cid.DagCBOR {
+		return links, nil
+	}
+	blk, err := bs.Get(ctx, c)
+	if err != nil {
+		if format.IsNotFound(err) {
+			return links, nil
+		}
+		return nil, err
+	}
+	nd, err := cbor.DecodeBlock(blk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode node %s: %w", c, err)
+	}
+	for _, link := range nd.Links() {
+		links[link.Name] = link.Cid
+	}
+	return links, nil
+}
+
+// WriteReport writes a human-readable report of the diff to w.
+func (d *StateDiff) WriteReport(w io.Writer) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "state diff left: %s, right: %s, %d actor(s) differ\n", d.Left, d.Right, len(d.Actors))
+	for _, ad := range d.Actors {
+		switch ad.Kind {
+		case ActorAdded:
+			fmt.Fprintf(&sb, "+ %s code=%s head=%s nonce=%d balance=%s\n", ad.Address, ad.Right.Code, ad.Right.Head, ad.Right.Nonce, ad.Right.Balance)
+		case ActorRemoved:
+			fmt.Fprintf(&sb, "- %s code=%s head=%s nonce=%d balance=%s\n", ad.Address, ad.Left.Code, ad.Left.Head, ad.Left.Nonce, ad.Left.Balance)
+		case ActorChanged:
+			fmt.Fprintf(&sb, "~ %s\n", ad.Address)
+			if ad.Left.Code != ad.Right.Code {
+				fmt.Fprintf(&sb, "    code: %s -> %s\n", ad.Left.Code, ad.Right.Code)
+			}
+			if ad.Left.Nonce != ad.Right.Nonce {
+				fmt.Fprintf(&sb, "    nonce: %d -> %d\n", ad.Left.Nonce, ad.Right.Nonce)
+			}
+			if !ad.Left.Balance.Equals(ad.Right.Balance) {
+				fmt.Fprintf(&sb, "    balance: %s -> %s\n", ad.Left.Balance, ad.Right.Balance)
+			}
+			if ad.Left.Head != ad.Right.Head {
+				fmt.Fprintf(&sb, "    head: %s -> %s\n", ad.Left.Head, ad.Right.Head)
+			}
+			for _, nd := range ad.Nodes {
+				fmt.Fprintf(&sb, "      %s: %s -> %s\n", nd.Path, cidOrNone(nd.Left), cidOrNone(nd.Right))
+			}
+			if ad.Truncated {
+				fmt.Fprintf(&sb, "      ... more than %d nodes differ\n", maxNodeDiffs)
+			}
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func cidOrNone(c cid.Cid) string {
+	if !c.Defined() {
+		return "<none>"
+	}
+	return c.String()
+}
@@ -0,0 +1,85 @@
+package conformance
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/ipfs/go-cid"
+	ds "github.com/ipfs/go-datastore"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/state/tree"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/tools/conformance/chaos"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestDiffStateTrees(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	bs := blockstoreutil.NewBlockstore(ds.NewMapDatastore())
+	cst := cbor.NewCborStore(bs)
+
+	head := func(value string) cid.Cid {
+		c, err := cst.Put(ctx, &chaos.State{Value: value})
+		require.NoError(t, err)
+		return c
+	}
+	id := func(i uint64) address.Address {
+		addr, err := address.NewIDAddress(i)
+		require.NoError(t, err)
+		return addr
+	}
+	flush := func(actors map[address.Address]*types.Actor) cid.Cid {
+		st, err := tree.NewState(cst, tree.StateTreeVersion5)
+		require.NoError(t, err)
+		for addr, act := range actors {
+			require.NoError(t, st.SetActor(ctx, addr, act))
+		}
+		root, err := st.Flush(ctx)
+		require.NoError(t, err)
+		return root
+	}
+
+	unchanged := &types.Actor{Code: chaos.ChaosActorCodeCID, Head: head("same"), Balance: big.NewInt(1)}
+	left := flush(map[address.Address]*types.Actor{
+		id(100): unchanged,
+		id(101): {Code: chaos.ChaosActorCodeCID, Head: head("left"), Nonce: 1, Balance: big.NewInt(1)},
+		id(102): {Code: chaos.ChaosActorCodeCID, Head: head("removed"), Balance: big.NewInt(1)},
+	})
+	right := flush(map[address.Address]*types.Actor{
+		id(100): unchanged,
+		id(101): {Code: chaos.ChaosActorCodeCID, Head: head("right"), Nonce: 2, Balance: big.NewInt(1)},
+		id(103): {Code: chaos.ChaosActorCodeCID, Head: head("added"), Balance: big.NewInt(1)},
+	})
+
+	diff, err := DiffStateTrees(ctx, bs, left, right)
+	require.NoError(t, err)
+	require.Len(t, diff.Actors, 3)
+
+	changed := diff.Actors[0]
+	assert.Equal(t, id(101), changed.Address)
+	assert.Equal(t, ActorChanged, changed.Kind)
+	require.Len(t, changed.Nodes, 1)
+	assert.Equal(t, NodeDiff{Path: "/", Left: head("left"), Right: head("right")}, changed.Nodes[0])
+
+	assert.Equal(t, id(102), diff.Actors[1].Address)
+	assert.Equal(t, ActorRemoved, diff.Actors[1].Kind)
+	assert.Equal(t, id(103), diff.Actors[2].Address)
+	assert.Equal(t, ActorAdded, diff.Actors[2].Kind)
+
+	var report strings.Builder
+	require.NoError(t, diff.WriteReport(&report))
+	assert.Contains(t, report.String(), "3 actor(s) differ")
+	assert.Contains(t, report.String(), "nonce: 1 -> 2")
+
+	same, err := DiffStateTrees(ctx, bs, left, left)
+	require.NoError(t, err)
+	assert.Empty(t, same.Actors)
+}
@@ -0,0 +1,69 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/filecoin-project/go-state-types/abi"
+
+	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// TipSetLookup is a tipset key returned to the VM for an epoch.
+type TipSetLookup struct {
+	Epoch int64           `json:"epoch"`
+	Key   types.TipSetKey `json:"tsk"`
+}
+
+// TipSetLookups are the tipset lookups performed by the messages of a vector, the test-vectors
+// schema has no room for them so they are stored under the "tipsets" key of the vector.
+type TipSetLookups []TipSetLookup
+
+type RecordingTipSetGetter struct {
+	reporter Reporter
+	api      v1api.FullNode
+	once     sync.Once
+	head     types.TipSetKey
+	lk       sync.Mutex
+	recorded TipSetLookups
+}
+
+// NewRecordingTipSetGetter returns a vm.TipSetGetter source that proxies calls to a full node
+// via JSON-RPC, and records the lookups so they can later be embedded in test vectors.
+func NewRecordingTipSetGetter(reporter Reporter, api v1api.FullNode) *RecordingTipSetGetter {
+	return &RecordingTipSetGetter{reporter: reporter, api: api}
+}
+
+func (r *RecordingTipSetGetter) loadHead() {
+	head, err := r.api.ChainHead(context.TODO())
+	if err != nil {
+		panic(fmt.Sprintf("could not fetch chain head while fetching tipset: %s", err))
+	}
+	r.head = head.Key()
+}
+
+// GetTipSetKey satisfies vm.TipSetGetter.
+func (r *RecordingTipSetGetter) GetTipSetKey(ctx context.Context, epoch abi.ChainEpoch) (types.TipSetKey, error) {
+	r.once.Do(r.loadHead)
+	ts, err := r.api.ChainGetTipSetByHeight(ctx, epoch, r.head)
+	if err != nil {
+		return types.EmptyTSK, err
+	}
+
+	r.reporter.Logf("fetched and recorded tipset: epoch=%d, result=%s", epoch, ts.Key())
+
+	r.lk.Lock()
+	r.recorded = append(r.recorded, TipSetLookup{Epoch: int64(epoch), Key: ts.Key()})
+	r.lk.Unlock()
+
+	return ts.Key(), nil
+}
+
+func (r *RecordingTipSetGetter) Recorded() TipSetLookups {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	return r.recorded
+}
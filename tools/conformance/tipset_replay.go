@@ -0,0 +1,38 @@
+package conformance
+
+import (
+	"context"
+
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+type ReplayingTipSetGetter struct {
+	reporter Reporter
+	recorded TipSetLookups
+}
+
+// NewReplayingTipSetGetter replays recorded tipset lookups when requested, falling back to
+// the empty tipset key if the epoch cannot be found; hence vectors recorded without lookups
+// behave as before.
+func NewReplayingTipSetGetter(reporter Reporter, recorded TipSetLookups) *ReplayingTipSetGetter {
+	return &ReplayingTipSetGetter{
+		reporter: reporter,
+		recorded: recorded,
+	}
+}
+
+// GetTipSetKey satisfies vm.TipSetGetter.
+func (r *ReplayingTipSetGetter) GetTipSetKey(ctx context.Context, epoch abi.ChainEpoch) (types.TipSetKey, error) {
+	for _, other := range r.recorded {
+		if other.Epoch == int64(epoch) {
+			r.reporter.Logf("returning saved tipset: epoch=%d, result=%s", epoch, other.Key)
+			return other.Key, nil
+		}
+	}
+
+	r.reporter.Logf("returning fallback tipset: epoch=%d", epoch)
+
+	return types.EmptyTSK, nil
+}
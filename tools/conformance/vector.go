@@ -0,0 +1,38 @@
+package conformance
+
+import (
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/test-vectors/schema"
+	"github.com/ipfs/go-cid"
+)
+
+// Vector is a test vector together with the venus extensions that are not part of the schema.
+type Vector struct {
+	schema.TestVector
+
+	// TipSets are the tipset lookups recorded while extracting the vector, they are
+	// required to execute messages looking up tipsets, eg. EVM ones.
+	TipSets TipSetLookups `json:"tipsets,omitempty"`
+
+	// LookbackStates are the state roots of the epochs looked back by the messages, eg. to
+	// resolve the worker key of a miner when verifying a consensus fault.
+	LookbackStates []LookbackState `json:"lookback_states,omitempty"`
+}
+
+// LookbackState is the state root at an epoch, its CAR blocks are part of the vector CAR.
+type LookbackState struct {
+	Epoch int64   `json:"epoch"`
+	Root  cid.Cid `json:"root"`
+}
+
+// LookbackRoots indexes the lookback states of the vector by epoch.
+func (v *Vector) LookbackRoots() map[abi.ChainEpoch]cid.Cid {
+	if len(v.LookbackStates) == 0 {
+		return nil
+	}
+	roots := make(map[abi.ChainEpoch]cid.Cid, len(v.LookbackStates))
+	for _, s := range v.LookbackStates {
+		roots[abi.ChainEpoch(s.Epoch)] = s.Root
+	}
+	return roots
+}
@@ -84,6 +84,38 @@ func PreSeal(maddr address.Address, spt abi.RegisteredSealProof, offset abi.Sect
 		sealedSectors = append(sealedSectors, preseal)
 	}
 
+	miner, ki, err := GenesisMiner(maddr, sealedSectors, ki)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	{
+		b, err := json.MarshalIndent(&LocalStorageMeta{
+			ID:       ID(uuid.New().String()),
+			Weight:   0, // read-only
+			CanSeal:  false,
+			CanStore: false,
+		}, "", "  ")
+		if err != nil {
+			return nil, nil, fmt.Errorf("marshaling storage config: %w", err)
+		}
+
+		if err := os.WriteFile(filepath.Join(sbroot, "sectorstore.json"), b, 0o644); err != nil {
+			return nil, nil, fmt.Errorf("persisting storage metadata (%s): %w", filepath.Join(sbroot, "storage.json"), err)
+		}
+	}
+
+	return miner, ki, nil
+}
+
+// GenesisMiner makes the genesis miner maddr of the pre-sealed sectors, whose owner and worker are the address of ki,
+// a new bls key when it is nil. The sectors get deals of this key, they give the miner its power at genesis.
+func GenesisMiner(maddr address.Address, sectors []*genesis.PreSeal, ki *key.KeyInfo) (*genesis.Miner, *key.KeyInfo, error) {
+	ssize, err := genesis.CheckPreSeals(sectors)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	var minerAddr address.Address
 	if ki != nil {
 		minerAddr, err = ki.Address()
@@ -123,7 +155,7 @@ func PreSeal(maddr address.Address, spt abi.RegisteredSealProof, offset abi.Sect
 		MarketBalance: big.Zero(),
 		PowerBalance:  big.Zero(),
 		SectorSize:    ssize,
-		Sectors:       sealedSectors,
+		Sectors:       sectors,
 		PeerID:        pid,
 	}
 
@@ -131,22 +163,6 @@ func PreSeal(maddr address.Address, spt abi.RegisteredSealProof, offset abi.Sect
 		return nil, nil, fmt.Errorf("creating deals: %w", err)
 	}
 
-	{
-		b, err := json.MarshalIndent(&LocalStorageMeta{
-			ID:       ID(uuid.New().String()),
-			Weight:   0, // read-only
-			CanSeal:  false,
-			CanStore: false,
-		}, "", "  ")
-		if err != nil {
-			return nil, nil, fmt.Errorf("marshaling storage config: %w", err)
-		}
-
-		if err := os.WriteFile(filepath.Join(sbroot, "sectorstore.json"), b, 0o644); err != nil {
-			return nil, nil, fmt.Errorf("persisting storage metadata (%s): %w", filepath.Join(sbroot, "storage.json"), err)
-		}
-	}
-
 	return miner, ki, nil
 }
 
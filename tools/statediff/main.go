@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ipfs/go-cid"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipld/go-car"
+
+	"github.com/filecoin-project/venus/tools/conformance"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+)
+
+func main() {
+	carPath := flag.String("car", "", "path of a CAR file holding both state trees")
+	vectorPath := flag.String("vector", "", "path of a test vector, its pre and post state trees are diffed unless roots are given")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s (-car <file> | -vector <file>) [<left root> <right root>]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := run(*carPath, *vectorPath, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(carPath, vectorPath string, args []string) error {
+	var (
+		ctx         = context.Background()
+		bs          blockstoreutil.Blockstore
+		left, right cid.Cid
+		err         error
+	)
+
+	switch {
+	case carPath != "" && vectorPath == "":
+		if len(args) != 2 {
+			return fmt.Errorf("expected the left and right state roots to diff")
+		}
+		f, err := os.Open(carPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close() //nolint:errcheck
+
+		bs = blockstoreutil.NewBlockstore(ds.NewMapDatastore())
+		if _, err := car.LoadCar(ctx, bs, f); err != nil {
+			return fmt.Errorf("failed to load CAR: %w", err)
+		}
+	case vectorPath != "" && carPath == "":
+		raw, err := os.ReadFile(vectorPath)
+		if err != nil {
+			return err
+		}
+		var vector conformance.Vector
+		if err := json.Unmarshal(raw, &vector); err != nil {
+			return fmt.Errorf("failed to parse test vector: %w", err)
+		}
+		if bs, err = conformance.LoadVectorCAR(vector.CAR); err != nil {
+			return err
+		}
+		if len(args) == 0 {
+			left, right = vector.Pre.StateTree.RootCID, vector.Post.StateTree.RootCID
+		} else if len(args) != 2 {
+			return fmt.Errorf("expected either no state roots or the left and right state roots to diff")
+		}
+	default:
+		return fmt.Errorf("exactly one of -car and -vector must be set")
+	}
+
+	if len(args) == 2 {
+		if left, err = cid.Decode(args[0]); err != nil {
+			return fmt.Errorf("invalid left state root: %w", err)
+		}
+		if right, err = cid.Decode(args[1]); err != nil {
+			return fmt.Errorf("invalid right state root: %w", err)
+		}
+	}
+
+	diff, err := conformance.DiffStateTrees(ctx, bs, left, right)
+	if err != nil {
+		return err
+	}
+	return diff.WriteReport(os.Stdout)
+}
@@ -225,6 +225,7 @@ func init() {
 	addExample(types.CheckStatusCode(0))
 	addExample(map[string]interface{}{"abc": 123})
 	addExample(types.HCApply)
+	addExample(types.QuarantineBlock)
 	addExample(lminer.SectorOnChainInfoFlags(0))
 
 	// messager
@@ -395,6 +396,14 @@ func init() {
 		},
 		Input: ecchain,
 	})
+	addExample(types.MigrationPreMigrated)
+	addExample(types.TypeSchema{
+		Kind: types.SchemaKindStruct,
+		Name: "miner.ChangeWorkerAddressParams",
+		Fields: []types.SchemaField{
+			{Name: "NewWorker", Schema: &types.TypeSchema{Kind: types.SchemaKindAddress, Name: "address.Address"}},
+		},
+	})
 }
 
 func ExampleValue(method string, t, parent reflect.Type) interface{} {
@@ -22,6 +22,7 @@ func main() {
 			clientCmd,
 			docGenCmd,
 			mockCmd,
+			protoCmd,
 		},
 	}
 
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/filecoin-project/venus/venus-shared/api/grpcapi"
+)
+
+var protoCmd = &cli.Command{
+	Name:  "proto",
+	Usage: "generate the protobuf definitions of the grpc api",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "dst",
+			Value: "../venus-shared/api/grpcapi",
+		},
+	},
+	Action: func(cctx *cli.Context) error {
+		return os.WriteFile(filepath.Join(cctx.String("dst"), "api.proto"), []byte(grpcapi.ProtoFile()), 0o644)
+	},
+}
@@ -62,6 +62,9 @@ func main() {
 				types.ReturnTrace{},
 				types.ExecutionTrace{},
 				types.F3ParticipationLease{},
+				types.MarketDealState{},
+				types.MarketDeal{},
+				types.MarketDealEntry{},
 			},
 		},
 		{
@@ -23,6 +23,7 @@ import (
 	builtin7 "github.com/filecoin-project/specs-actors/v7/actors/builtin"
 
 	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/go-state-types/builtin"
 	"github.com/filecoin-project/go-state-types/manifest"
 	"github.com/filecoin-project/go-state-types/proof"
@@ -30,6 +31,7 @@ import (
 	"github.com/filecoin-project/venus/venus-shared/actors"
 
 	minertypes "github.com/filecoin-project/go-state-types/builtin/v15/miner"
+	minersmoothing "github.com/filecoin-project/go-state-types/builtin/v15/util/smoothing"
 	smoothingtypes "github.com/filecoin-project/go-state-types/builtin/v8/util/smoothing"
 )
 
@@ -47,6 +49,12 @@ var (
 	ExpectedLeadersPerEpoch = builtin.ExpectedLeadersPerEpoch
 )
 
+// The consensus fault penalty and reporter reward parameters of the miner actor, which are not exported.
+const (
+	consensusFaultFactor               = 5
+	consensusFaultReporterDefaultShare = 4
+)
+
 const (
 	EpochDurationSeconds = builtin.EpochDurationSeconds
 	EpochsInDay          = builtin.EpochsInDay
@@ -70,6 +78,25 @@ func QAPowerForWeight(size abi.SectorSize, duration abi.ChainEpoch, verifiedWeig
 	return minertypes.QAPowerForWeight(size, duration, verifiedWeight)
 }
 
+// ExpectedRewardForPower projects the block reward earned by qaSectorPower over projectionDuration.
+func ExpectedRewardForPower(rewardEstimate, networkQAPowerEstimate FilterEstimate, qaSectorPower abi.StoragePower, projectionDuration abi.ChainEpoch) abi.TokenAmount {
+	return minertypes.ExpectedRewardForPower(minersmoothing.FilterEstimate(rewardEstimate), minersmoothing.FilterEstimate(networkQAPowerEstimate), qaSectorPower, projectionDuration)
+}
+
+// ConsensusFaultPenalty is the penalty charged to a miner for a consensus fault, the reward of ConsensusFaultFactor
+// blocks estimated by rewardEstimate.
+func ConsensusFaultPenalty(rewardEstimate FilterEstimate) abi.TokenAmount {
+	fe := minersmoothing.FilterEstimate(rewardEstimate)
+	return big.Div(big.Mul(minersmoothing.Estimate(&fe), big.NewInt(consensusFaultFactor)), big.NewInt(int64(ExpectedLeadersPerEpoch)))
+}
+
+// RewardForConsensusSlashReport is the reward paid to the reporter of a consensus fault, a share of the reward of a
+// block estimated by rewardEstimate. The reporter is paid no more than the penalty the miner actually pays.
+func RewardForConsensusSlashReport(rewardEstimate FilterEstimate) abi.TokenAmount {
+	fe := minersmoothing.FilterEstimate(rewardEstimate)
+	return big.Div(minersmoothing.Estimate(&fe), big.NewInt(int64(ExpectedLeadersPerEpoch)*consensusFaultReporterDefaultShare))
+}
+
 func ActorNameByCode(c cid.Cid) string {
 	if name, version, ok := actors.GetActorMetaByCode(c); ok {
 		return fmt.Sprintf("fil/%d/%s", version, name)
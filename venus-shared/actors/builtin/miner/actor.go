@@ -221,9 +221,17 @@ type Deadline interface {
 
 	PartitionsChanged(Deadline) (bool, error)
 	DisputableProofCount() (uint64, error)
+	DisputableProofs() ([]DisputableProof, error)
 	DailyFee() (abi.TokenAmount, error)
 }
 
+// DisputableProof is a window PoSt optimistically accepted in a deadline, it may be disputed with
+// its index until the dispute window of the deadline ends.
+type DisputableProof struct {
+	Index      uint64
+	Partitions bitfield.BitField
+}
+
 type Partition interface {
 	// AllSectors returns all sector numbers in this partition, including faulty, unproven, and terminated sectors
 	AllSectors() (bitfield.BitField, error)
@@ -346,6 +354,7 @@ type SectorClaim = minertypes.SectorClaim
 type ExpirationExtension2 = minertypes.ExpirationExtension2
 type CompactPartitionsParams = minertypes.CompactPartitionsParams
 type WithdrawBalanceParams = minertypes.WithdrawBalanceParams
+type ReportConsensusFaultParams = minertypes.ReportConsensusFaultParams
 
 type PieceActivationManifest = minertypes13.PieceActivationManifest
 type ProveCommitSectors3Params = minertypes13.ProveCommitSectors3Params
@@ -361,6 +370,7 @@ type WindowPostVerifyInfo = proof.WindowPoStVerifyInfo
 
 var WPoStProvingPeriod = func() abi.ChainEpoch { return minertypes.WPoStProvingPeriod }
 var WPoStChallengeWindow = func() abi.ChainEpoch { return minertypes.WPoStChallengeWindow }
+var WPoStDisputeWindow = func() abi.ChainEpoch { return minertypes.WPoStDisputeWindow }
 
 const WPoStPeriodDeadlines = minertypes.WPoStPeriodDeadlines
 const WPoStChallengeLookback = minertypes.WPoStChallengeLookback
@@ -481,6 +481,13 @@ func (d *deadline0) DisputableProofCount() (uint64, error) {
 
 }
 
+func (d *deadline0) DisputableProofs() ([]DisputableProof, error) {
+
+	// field doesn't exist until v3
+	return nil, nil
+
+}
+
 func (d *deadline0) DailyFee() (abi.TokenAmount, error) {
 	return big.Zero(), nil
 }
@@ -516,6 +516,25 @@ func (d *deadline12) DisputableProofCount() (uint64, error) {
 
 }
 
+func (d *deadline12) DisputableProofs() ([]DisputableProof, error) {
+
+	ops, err := d.OptimisticProofsSnapshotArray(d.store)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []DisputableProof
+	var post miner12.WindowedPoSt
+	if err := ops.ForEach(&post, func(i int64) error {
+		out = append(out, DisputableProof{Index: uint64(i), Partitions: post.Partitions})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return out, nil
+
+}
+
 func (d *deadline12) DailyFee() (abi.TokenAmount, error) {
 	return big.Zero(), nil
 }
@@ -516,6 +516,25 @@ func (d *deadline16) DisputableProofCount() (uint64, error) {
 
 }
 
+func (d *deadline16) DisputableProofs() ([]DisputableProof, error) {
+
+	ops, err := d.OptimisticProofsSnapshotArray(d.store)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []DisputableProof
+	var post miner16.WindowedPoSt
+	if err := ops.ForEach(&post, func(i int64) error {
+		out = append(out, DisputableProof{Index: uint64(i), Partitions: post.Partitions})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return out, nil
+
+}
+
 func (d *deadline16) DailyFee() (abi.TokenAmount, error) {
 	if d.Deadline.DailyFee.Int != nil {
 		return d.Deadline.DailyFee, nil
@@ -513,6 +513,13 @@ func (d *deadline2) DisputableProofCount() (uint64, error) {
 
 }
 
+func (d *deadline2) DisputableProofs() ([]DisputableProof, error) {
+
+	// field doesn't exist until v3
+	return nil, nil
+
+}
+
 func (d *deadline2) DailyFee() (abi.TokenAmount, error) {
 	return big.Zero(), nil
 }
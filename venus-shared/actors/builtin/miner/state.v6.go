@@ -514,6 +514,25 @@ func (d *deadline6) DisputableProofCount() (uint64, error) {
 
 }
 
+func (d *deadline6) DisputableProofs() ([]DisputableProof, error) {
+
+	ops, err := d.OptimisticProofsSnapshotArray(d.store)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []DisputableProof
+	var post miner6.WindowedPoSt
+	if err := ops.ForEach(&post, func(i int64) error {
+		out = append(out, DisputableProof{Index: uint64(i), Partitions: post.Partitions})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return out, nil
+
+}
+
 func (d *deadline6) DailyFee() (abi.TokenAmount, error) {
 	return big.Zero(), nil
 }
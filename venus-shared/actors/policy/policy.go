@@ -16,6 +16,7 @@ import (
 	market0 "github.com/filecoin-project/specs-actors/actors/builtin/market"
 	miner0 "github.com/filecoin-project/specs-actors/actors/builtin/miner"
 	verifreg0 "github.com/filecoin-project/specs-actors/actors/builtin/verifreg"
+	smoothing0 "github.com/filecoin-project/specs-actors/actors/util/smoothing"
 
 	power0 "github.com/filecoin-project/specs-actors/actors/builtin/power"
 
@@ -24,83 +25,100 @@ import (
 	market2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/market"
 	miner2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/miner"
 	verifreg2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/verifreg"
+	smoothing2 "github.com/filecoin-project/specs-actors/v2/actors/util/smoothing"
 
 	builtin3 "github.com/filecoin-project/specs-actors/v3/actors/builtin"
 
 	market3 "github.com/filecoin-project/specs-actors/v3/actors/builtin/market"
 	miner3 "github.com/filecoin-project/specs-actors/v3/actors/builtin/miner"
 	verifreg3 "github.com/filecoin-project/specs-actors/v3/actors/builtin/verifreg"
+	smoothing3 "github.com/filecoin-project/specs-actors/v3/actors/util/smoothing"
 
 	builtin4 "github.com/filecoin-project/specs-actors/v4/actors/builtin"
 
 	market4 "github.com/filecoin-project/specs-actors/v4/actors/builtin/market"
 	miner4 "github.com/filecoin-project/specs-actors/v4/actors/builtin/miner"
 	verifreg4 "github.com/filecoin-project/specs-actors/v4/actors/builtin/verifreg"
+	smoothing4 "github.com/filecoin-project/specs-actors/v4/actors/util/smoothing"
 
 	builtin5 "github.com/filecoin-project/specs-actors/v5/actors/builtin"
 
 	market5 "github.com/filecoin-project/specs-actors/v5/actors/builtin/market"
 	miner5 "github.com/filecoin-project/specs-actors/v5/actors/builtin/miner"
 	verifreg5 "github.com/filecoin-project/specs-actors/v5/actors/builtin/verifreg"
+	smoothing5 "github.com/filecoin-project/specs-actors/v5/actors/util/smoothing"
 
 	builtin6 "github.com/filecoin-project/specs-actors/v6/actors/builtin"
 
 	market6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/market"
 	miner6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/miner"
 	verifreg6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/verifreg"
+	smoothing6 "github.com/filecoin-project/specs-actors/v6/actors/util/smoothing"
 
 	builtin7 "github.com/filecoin-project/specs-actors/v7/actors/builtin"
 
 	market7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/market"
 	miner7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
 	verifreg7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/verifreg"
+	smoothing7 "github.com/filecoin-project/specs-actors/v7/actors/util/smoothing"
 
 	builtin8 "github.com/filecoin-project/go-state-types/builtin"
 	market8 "github.com/filecoin-project/go-state-types/builtin/v8/market"
 	miner8 "github.com/filecoin-project/go-state-types/builtin/v8/miner"
+	smoothing8 "github.com/filecoin-project/go-state-types/builtin/v8/util/smoothing"
 	verifreg8 "github.com/filecoin-project/go-state-types/builtin/v8/verifreg"
 
 	builtin9 "github.com/filecoin-project/go-state-types/builtin"
 	market9 "github.com/filecoin-project/go-state-types/builtin/v9/market"
 	miner9 "github.com/filecoin-project/go-state-types/builtin/v9/miner"
+	smoothing9 "github.com/filecoin-project/go-state-types/builtin/v9/util/smoothing"
 	verifreg9 "github.com/filecoin-project/go-state-types/builtin/v9/verifreg"
 
 	builtin10 "github.com/filecoin-project/go-state-types/builtin"
 	market10 "github.com/filecoin-project/go-state-types/builtin/v10/market"
 	miner10 "github.com/filecoin-project/go-state-types/builtin/v10/miner"
+	smoothing10 "github.com/filecoin-project/go-state-types/builtin/v10/util/smoothing"
 	verifreg10 "github.com/filecoin-project/go-state-types/builtin/v10/verifreg"
 
 	builtin11 "github.com/filecoin-project/go-state-types/builtin"
 	market11 "github.com/filecoin-project/go-state-types/builtin/v11/market"
 	miner11 "github.com/filecoin-project/go-state-types/builtin/v11/miner"
+	smoothing11 "github.com/filecoin-project/go-state-types/builtin/v11/util/smoothing"
 	verifreg11 "github.com/filecoin-project/go-state-types/builtin/v11/verifreg"
 
 	builtin12 "github.com/filecoin-project/go-state-types/builtin"
 	market12 "github.com/filecoin-project/go-state-types/builtin/v12/market"
 	miner12 "github.com/filecoin-project/go-state-types/builtin/v12/miner"
+	smoothing12 "github.com/filecoin-project/go-state-types/builtin/v12/util/smoothing"
 	verifreg12 "github.com/filecoin-project/go-state-types/builtin/v12/verifreg"
 
 	builtin13 "github.com/filecoin-project/go-state-types/builtin"
 	market13 "github.com/filecoin-project/go-state-types/builtin/v13/market"
 	miner13 "github.com/filecoin-project/go-state-types/builtin/v13/miner"
+	smoothing13 "github.com/filecoin-project/go-state-types/builtin/v13/util/smoothing"
 	verifreg13 "github.com/filecoin-project/go-state-types/builtin/v13/verifreg"
 
 	builtin14 "github.com/filecoin-project/go-state-types/builtin"
 	market14 "github.com/filecoin-project/go-state-types/builtin/v14/market"
 	miner14 "github.com/filecoin-project/go-state-types/builtin/v14/miner"
+	smoothing14 "github.com/filecoin-project/go-state-types/builtin/v14/util/smoothing"
 	verifreg14 "github.com/filecoin-project/go-state-types/builtin/v14/verifreg"
 
 	builtin15 "github.com/filecoin-project/go-state-types/builtin"
 	market15 "github.com/filecoin-project/go-state-types/builtin/v15/market"
 	miner15 "github.com/filecoin-project/go-state-types/builtin/v15/miner"
+	smoothing15 "github.com/filecoin-project/go-state-types/builtin/v15/util/smoothing"
 	verifreg15 "github.com/filecoin-project/go-state-types/builtin/v15/verifreg"
 
 	builtin16 "github.com/filecoin-project/go-state-types/builtin"
 	market16 "github.com/filecoin-project/go-state-types/builtin/v16/market"
 	miner16 "github.com/filecoin-project/go-state-types/builtin/v16/miner"
+	smoothing16 "github.com/filecoin-project/go-state-types/builtin/v16/util/smoothing"
 	verifreg16 "github.com/filecoin-project/go-state-types/builtin/v16/verifreg"
 
 	paych16 "github.com/filecoin-project/go-state-types/builtin/v16/paych"
+
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin"
 )
 
 const (
@@ -1104,6 +1122,100 @@ func AggregatePreCommitNetworkFee(nwVer network.Version, aggregateSize int, base
 	}
 }
 
+// The continued fault fee of the builtin actors, which go-state-types doesn't export.
+const (
+	continuedFaultFactorNum   = 351
+	continuedFaultFactorDenom = 100
+)
+
+// PledgePenaltyForContinuedFault is the fee charged each proving period a sector of qaSectorPower stays faulty,
+// FF(t) = BR(t, ContinuedFaultProjectionPeriod) in the miner actor of the network version.
+func PledgePenaltyForContinuedFault(nwVer network.Version, rewardEstimate, networkQAPowerEstimate builtin.FilterEstimate, qaSectorPower abi.StoragePower) (abi.TokenAmount, error) {
+	v, err := actorstypes.VersionForNetwork(nwVer)
+	if err != nil {
+		return big.Zero(), err
+	}
+	switch v {
+
+	case actorstypes.Version0:
+
+		reward0, power0 := smoothing0.FilterEstimate(rewardEstimate), smoothing0.FilterEstimate(networkQAPowerEstimate)
+		return miner0.PledgePenaltyForDeclaredFault(&reward0, &power0, qaSectorPower, nwVer), nil
+
+	case actorstypes.Version2:
+
+		return miner2.PledgePenaltyForContinuedFault(smoothing2.FilterEstimate(rewardEstimate), smoothing2.FilterEstimate(networkQAPowerEstimate), qaSectorPower), nil
+
+	case actorstypes.Version3:
+
+		return miner3.PledgePenaltyForContinuedFault(smoothing3.FilterEstimate(rewardEstimate), smoothing3.FilterEstimate(networkQAPowerEstimate), qaSectorPower), nil
+
+	case actorstypes.Version4:
+
+		return miner4.PledgePenaltyForContinuedFault(smoothing4.FilterEstimate(rewardEstimate), smoothing4.FilterEstimate(networkQAPowerEstimate), qaSectorPower), nil
+
+	case actorstypes.Version5:
+
+		return miner5.PledgePenaltyForContinuedFault(smoothing5.FilterEstimate(rewardEstimate), smoothing5.FilterEstimate(networkQAPowerEstimate), qaSectorPower), nil
+
+	case actorstypes.Version6:
+
+		return miner6.PledgePenaltyForContinuedFault(smoothing6.FilterEstimate(rewardEstimate), smoothing6.FilterEstimate(networkQAPowerEstimate), qaSectorPower), nil
+
+	case actorstypes.Version7:
+
+		return miner7.PledgePenaltyForContinuedFault(smoothing7.FilterEstimate(rewardEstimate), smoothing7.FilterEstimate(networkQAPowerEstimate), qaSectorPower), nil
+
+	case actorstypes.Version8:
+
+		return miner8.ExpectedRewardForPower(smoothing8.FilterEstimate(rewardEstimate), smoothing8.FilterEstimate(networkQAPowerEstimate),
+			qaSectorPower, abi.ChainEpoch((builtin8.EpochsInDay*continuedFaultFactorNum)/continuedFaultFactorDenom)), nil
+
+	case actorstypes.Version9:
+
+		return miner9.ExpectedRewardForPower(smoothing9.FilterEstimate(rewardEstimate), smoothing9.FilterEstimate(networkQAPowerEstimate),
+			qaSectorPower, abi.ChainEpoch((builtin9.EpochsInDay*continuedFaultFactorNum)/continuedFaultFactorDenom)), nil
+
+	case actorstypes.Version10:
+
+		return miner10.ExpectedRewardForPower(smoothing10.FilterEstimate(rewardEstimate), smoothing10.FilterEstimate(networkQAPowerEstimate),
+			qaSectorPower, abi.ChainEpoch((builtin10.EpochsInDay*continuedFaultFactorNum)/continuedFaultFactorDenom)), nil
+
+	case actorstypes.Version11:
+
+		return miner11.ExpectedRewardForPower(smoothing11.FilterEstimate(rewardEstimate), smoothing11.FilterEstimate(networkQAPowerEstimate),
+			qaSectorPower, abi.ChainEpoch((builtin11.EpochsInDay*continuedFaultFactorNum)/continuedFaultFactorDenom)), nil
+
+	case actorstypes.Version12:
+
+		return miner12.ExpectedRewardForPower(smoothing12.FilterEstimate(rewardEstimate), smoothing12.FilterEstimate(networkQAPowerEstimate),
+			qaSectorPower, abi.ChainEpoch((builtin12.EpochsInDay*continuedFaultFactorNum)/continuedFaultFactorDenom)), nil
+
+	case actorstypes.Version13:
+
+		return miner13.ExpectedRewardForPower(smoothing13.FilterEstimate(rewardEstimate), smoothing13.FilterEstimate(networkQAPowerEstimate),
+			qaSectorPower, abi.ChainEpoch((builtin13.EpochsInDay*continuedFaultFactorNum)/continuedFaultFactorDenom)), nil
+
+	case actorstypes.Version14:
+
+		return miner14.ExpectedRewardForPower(smoothing14.FilterEstimate(rewardEstimate), smoothing14.FilterEstimate(networkQAPowerEstimate),
+			qaSectorPower, abi.ChainEpoch((builtin14.EpochsInDay*continuedFaultFactorNum)/continuedFaultFactorDenom)), nil
+
+	case actorstypes.Version15:
+
+		return miner15.ExpectedRewardForPower(smoothing15.FilterEstimate(rewardEstimate), smoothing15.FilterEstimate(networkQAPowerEstimate),
+			qaSectorPower, abi.ChainEpoch((builtin15.EpochsInDay*continuedFaultFactorNum)/continuedFaultFactorDenom)), nil
+
+	case actorstypes.Version16:
+
+		return miner16.ExpectedRewardForPower(smoothing16.FilterEstimate(rewardEstimate), smoothing16.FilterEstimate(networkQAPowerEstimate),
+			qaSectorPower, abi.ChainEpoch((builtin16.EpochsInDay*continuedFaultFactorNum)/continuedFaultFactorDenom)), nil
+
+	default:
+		return big.Zero(), fmt.Errorf("unsupported network version")
+	}
+}
+
 var PoStToSealMap map[abi.RegisteredPoStProof]abi.RegisteredSealProof
 
 func init() {
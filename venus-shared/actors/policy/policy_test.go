@@ -0,0 +1,51 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	miner16 "github.com/filecoin-project/go-state-types/builtin/v16/miner"
+	smoothing16 "github.com/filecoin-project/go-state-types/builtin/v16/util/smoothing"
+	"github.com/filecoin-project/go-state-types/network"
+	miner7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+	smoothing7 "github.com/filecoin-project/specs-actors/v7/actors/util/smoothing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin"
+)
+
+func TestPledgePenaltyForContinuedFault(t *testing.T) {
+	// a network of 1 EiB rewarding 1000 * 2^30 attoFIL each epoch, and a 32 GiB sector of 10x quality
+	rewardEstimate := builtin.FilterEstimate{PositionEstimate: big.Lsh(big.NewInt(1000<<30), 128), VelocityEstimate: big.Zero()}
+	powerEstimate := builtin.FilterEstimate{PositionEstimate: big.Lsh(big.NewInt(1<<60), 128), VelocityEstimate: big.Zero()}
+	qaPower := big.NewInt(10 << 35)
+	// the reward of the sector for 3.51 days
+	expected := big.NewInt(1000 * 10 << 5 * ((builtin.EpochsInDay * 351) / 100))
+
+	// the builtin actors
+	penalty, err := PledgePenaltyForContinuedFault(network.Version25, rewardEstimate, powerEstimate, qaPower)
+	require.NoError(t, err)
+	assert.Equal(t, expected, penalty)
+	assert.Equal(t, miner16.ExpectedRewardForPower(smoothing16.FilterEstimate(rewardEstimate), smoothing16.FilterEstimate(powerEstimate),
+		qaPower, abi.ChainEpoch((builtin.EpochsInDay*351)/100)), penalty)
+
+	penalty, err = PledgePenaltyForContinuedFault(network.Version16, rewardEstimate, powerEstimate, qaPower)
+	require.NoError(t, err)
+	assert.Equal(t, expected, penalty)
+
+	// the specs actors
+	penalty, err = PledgePenaltyForContinuedFault(network.Version15, rewardEstimate, powerEstimate, qaPower)
+	require.NoError(t, err)
+	assert.Equal(t, expected, penalty)
+	assert.Equal(t, miner7.PledgePenaltyForContinuedFault(smoothing7.FilterEstimate(rewardEstimate), smoothing7.FilterEstimate(powerEstimate), qaPower), penalty)
+
+	// the declared fault fee of the first actors was 2.14 days of reward
+	penalty, err = PledgePenaltyForContinuedFault(network.Version0, rewardEstimate, powerEstimate, qaPower)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1000*10<<5*((builtin.EpochsInDay*214)/100)), penalty)
+
+	_, err = PledgePenaltyForContinuedFault(network.Version(1000), rewardEstimate, powerEstimate, qaPower)
+	assert.Error(t, err)
+}
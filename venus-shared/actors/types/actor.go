@@ -1,14 +1,35 @@
 package types
 
 import (
-	"errors"
-
 	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-jsonrpc"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/ipfs/go-cid"
 )
 
-var ErrActorNotFound = errors.New("actor not found")
+// ErrCodeActorNotFound is the code of the JSON-RPC errors of the API wrapping ErrActorNotFound.
+const ErrCodeActorNotFound jsonrpc.ErrorCode = 1000
+
+var ErrActorNotFound error = errActorNotFound{}
+
+type errActorNotFound struct{}
+
+func (errActorNotFound) Error() string { return "actor not found" }
+
+// Is reports whether target is ErrCodeActorNotFound.
+func (errActorNotFound) Is(target error) bool {
+	code, ok := any(target).(jsonrpc.ErrorCode)
+	return ok && code == ErrCodeActorNotFound
+}
+
+// As sets the JSON-RPC error code of the errors wrapping ErrActorNotFound.
+func (errActorNotFound) As(target interface{}) bool {
+	code, ok := target.(*jsonrpc.ErrorCode)
+	if ok {
+		*code = ErrCodeActorNotFound
+	}
+	return ok
+}
 
 // Actor is the central abstraction of entities in the system.
 //
@@ -687,6 +687,12 @@ type EthTxReceipt struct {
 	LogsBloom         EthBytes    `json:"logsBloom"`
 	Logs              []EthLog    `json:"logs"`
 	Type              EthUint64   `json:"type"`
+	// ExitCodeName is the name of the exit code of a failed transaction, eg. SysErrOutOfGas
+	ExitCodeName string `json:"exitCodeName,omitempty"`
+	// RevertReason is the decoded revert reason of a reverted contract call
+	RevertReason string `json:"revertReason,omitempty"`
+	// RevertData is the data returned by a reverted contract call
+	RevertData EthBytes `json:"revertData,omitempty"`
 }
 
 type EthFilterID EthHash
@@ -916,6 +922,11 @@ type EthSubscriptionParams struct {
 	// The JSON decoding must treat a string as equivalent to an array with one value, for example
 	// "0x8888f1f195afa192cfee86069858" must be decoded as [ "0x8888f1f195afa192cfee86069858" ]
 	Address EthAddressList `json:"address"`
+
+	// Epoch to replay the matching logs from before the new ones are sent, so a client reconnecting recovers the
+	// logs it missed. The range to the head is bounded by Event.MaxFilterHeightRange.
+	// Optional, default nil: no replay.
+	FromBlock *EthUint64 `json:"fromBlock,omitempty"`
 }
 
 type EthSubscriptionResponse struct {
@@ -3,9 +3,23 @@ package v0
 import (
 	"context"
 
+	"github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-cid"
+
 	"github.com/filecoin-project/venus/venus-shared/types"
 )
 
 type IMarket interface {
 	StateMarketParticipants(ctx context.Context, tsk types.TipSetKey) (map[string]types.MarketBalance, error) //perm:read
+	// MarketAddBalance adds funds to the market actor escrow of addr
+	MarketAddBalance(ctx context.Context, wallet, addr address.Address, amt types.BigInt) (cid.Cid, error) //perm:sign
+	// MarketGetReserved gets the amount of funds that are currently reserved for addr
+	MarketGetReserved(ctx context.Context, addr address.Address) (types.BigInt, error) //perm:sign
+	// MarketReserveFunds reserves funds for a deal, adding funds to the market actor escrow of addr
+	// when the unreserved funds are not enough, the returned cid is undefined if nothing was sent
+	MarketReserveFunds(ctx context.Context, wallet address.Address, addr address.Address, amt types.BigInt) (cid.Cid, error) //perm:sign
+	// MarketReleaseFunds releases funds reserved by MarketReserveFunds
+	MarketReleaseFunds(ctx context.Context, addr address.Address, amt types.BigInt) error //perm:sign
+	// MarketWithdraw withdraws unreserved funds from the market actor escrow of addr
+	MarketWithdraw(ctx context.Context, wallet, addr address.Address, amt types.BigInt) (cid.Cid, error) //perm:sign
 }
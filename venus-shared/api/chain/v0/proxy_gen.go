@@ -441,10 +441,30 @@ type IChainStruct struct {
 
 type IMarketStruct struct {
 	Internal struct {
-		StateMarketParticipants func(ctx context.Context, tsk types.TipSetKey) (map[string]types.MarketBalance, error) `perm:"read"`
+		MarketAddBalance        func(ctx context.Context, wallet, addr address.Address, amt types.BigInt) (cid.Cid, error)                 `perm:"sign"`
+		MarketGetReserved       func(ctx context.Context, addr address.Address) (types.BigInt, error)                                      `perm:"sign"`
+		MarketReleaseFunds      func(ctx context.Context, addr address.Address, amt types.BigInt) error                                    `perm:"sign"`
+		MarketReserveFunds      func(ctx context.Context, wallet address.Address, addr address.Address, amt types.BigInt) (cid.Cid, error) `perm:"sign"`
+		MarketWithdraw          func(ctx context.Context, wallet, addr address.Address, amt types.BigInt) (cid.Cid, error)                 `perm:"sign"`
+		StateMarketParticipants func(ctx context.Context, tsk types.TipSetKey) (map[string]types.MarketBalance, error)                     `perm:"read"`
 	}
 }
 
+func (s *IMarketStruct) MarketAddBalance(p0 context.Context, p1, p2 address.Address, p3 types.BigInt) (cid.Cid, error) {
+	return s.Internal.MarketAddBalance(p0, p1, p2, p3)
+}
+func (s *IMarketStruct) MarketGetReserved(p0 context.Context, p1 address.Address) (types.BigInt, error) {
+	return s.Internal.MarketGetReserved(p0, p1)
+}
+func (s *IMarketStruct) MarketReleaseFunds(p0 context.Context, p1 address.Address, p2 types.BigInt) error {
+	return s.Internal.MarketReleaseFunds(p0, p1, p2)
+}
+func (s *IMarketStruct) MarketReserveFunds(p0 context.Context, p1 address.Address, p2 address.Address, p3 types.BigInt) (cid.Cid, error) {
+	return s.Internal.MarketReserveFunds(p0, p1, p2, p3)
+}
+func (s *IMarketStruct) MarketWithdraw(p0 context.Context, p1, p2 address.Address, p3 types.BigInt) (cid.Cid, error) {
+	return s.Internal.MarketWithdraw(p0, p1, p2, p3)
+}
 func (s *IMarketStruct) StateMarketParticipants(p0 context.Context, p1 types.TipSetKey) (map[string]types.MarketBalance, error) {
 	return s.Internal.StateMarketParticipants(p0, p1)
 }
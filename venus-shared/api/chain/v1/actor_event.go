@@ -33,4 +33,14 @@ type IActorEvent interface {
 	// Note: this API is only available via websocket connections.
 	// This is an EXPERIMENTAL API and may be subject to change.
 	SubscribeActorEventsRaw(ctx context.Context, filter *types.ActorEventFilter) (<-chan *types.ActorEvent, error) //perm:read
+
+	// GetDealEvents returns the deal lifecycle events (published, activated, terminated and completed) emitted by
+	// the builtin market actor and matching the filter, decoded from the actor events.
+	GetDealEvents(ctx context.Context, filter *types.DealEventFilter) ([]*types.DealEvent, error) //perm:read
+
+	// SubscribeDealEvents returns a long-lived stream of the deal lifecycle events emitted by the builtin market
+	// actor and matching the filter, like SubscribeActorEventsRaw.
+	//
+	// Note: this API is only available via websocket connections.
+	SubscribeDealEvents(ctx context.Context, filter *types.DealEventFilter) (<-chan *types.DealEvent, error) //perm:read
 }
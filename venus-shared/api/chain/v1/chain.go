@@ -24,6 +24,7 @@ type IChain interface {
 	IActor
 	IMinerState
 	IChainInfo
+	IBitfield
 }
 
 type IAccount interface {
@@ -33,18 +34,35 @@ type IAccount interface {
 type IActor interface {
 	StateGetActor(ctx context.Context, actor address.Address, tsk types.TipSetKey) (*types.Actor, error) //perm:read
 	ListActor(ctx context.Context) (map[address.Address]*types.Actor, error)                             //perm:read
+	// StateGetActors looks up all of addrs in the state of tsk, the results are in the order of addrs.
+	StateGetActors(ctx context.Context, addrs []address.Address, tsk types.TipSetKey) ([]types.ActorLookup, error) //perm:read
+	// StateActorStats returns the size and the number of blocks of the state of each actor of addrs at tsk. When
+	// base is not empty, the blocks reachable from the state of the actor at base are left out, so the stats are
+	// the growth of the states since base, eg. to find the actors bloating the state.
+	StateActorStats(ctx context.Context, addrs []address.Address, base types.TipSetKey, tsk types.TipSetKey) ([]types.ActorStat, error) //perm:read
+	// StateSizeReport walks the whole state of tsk and attributes its blocks to the actors, and within the miners
+	// to the fields of their states, eg. Sectors, Deadlines and PreCommittedSectors, to find which actors drive
+	// the growth of the state. The report keeps the top actors of the largest states.
+	StateSizeReport(ctx context.Context, top int, tsk types.TipSetKey) (*types.StateSizeReport, error) //perm:admin
+	// StateActorHeadHistory returns the head and the balance of the actor addr in the tipsets of the chain of tsk with
+	// a height in [from, to], the first at from and then one each time the head or the balance changed.
+	StateActorHeadHistory(ctx context.Context, addr address.Address, from, to abi.ChainEpoch, tsk types.TipSetKey) ([]types.ActorHeadChange, error) //perm:read
 }
 
 type IChainInfo interface {
-	BlockTime(ctx context.Context) time.Duration                                                                                                                                          //perm:read
-	ChainList(ctx context.Context, tsKey types.TipSetKey, count int) ([]types.TipSetKey, error)                                                                                           //perm:read
-	ChainHead(ctx context.Context) (*types.TipSet, error)                                                                                                                                 //perm:read
-	ChainSetHead(ctx context.Context, key types.TipSetKey) error                                                                                                                          //perm:admin
-	ChainGetTipSet(ctx context.Context, key types.TipSetKey) (*types.TipSet, error)                                                                                                       //perm:read
-	ChainGetTipSetByHeight(ctx context.Context, height abi.ChainEpoch, tsk types.TipSetKey) (*types.TipSet, error)                                                                        //perm:read
-	ChainGetTipSetAfterHeight(ctx context.Context, height abi.ChainEpoch, tsk types.TipSetKey) (*types.TipSet, error)                                                                     //perm:read
+	BlockTime(ctx context.Context) time.Duration                                                                      //perm:read
+	ChainList(ctx context.Context, tsKey types.TipSetKey, count int) ([]types.TipSetKey, error)                       //perm:read
+	ChainHead(ctx context.Context) (*types.TipSet, error)                                                             //perm:read
+	ChainSetHead(ctx context.Context, key types.TipSetKey) error                                                      //perm:admin
+	ChainGetTipSet(ctx context.Context, key types.TipSetKey) (*types.TipSet, error)                                   //perm:read
+	ChainGetTipSetByHeight(ctx context.Context, height abi.ChainEpoch, tsk types.TipSetKey) (*types.TipSet, error)    //perm:read
+	ChainGetTipSetAfterHeight(ctx context.Context, height abi.ChainEpoch, tsk types.TipSetKey) (*types.TipSet, error) //perm:read
+	// StateGetRandomnessFromTickets is used to sample the chain for randomness, it returns the same
+	// randomness the VM draws for an actor executing in the tipset tsk.
 	StateGetRandomnessFromTickets(ctx context.Context, personalization crypto.DomainSeparationTag, randEpoch abi.ChainEpoch, entropy []byte, tsk types.TipSetKey) (abi.Randomness, error) //perm:read
-	StateGetRandomnessFromBeacon(ctx context.Context, personalization crypto.DomainSeparationTag, randEpoch abi.ChainEpoch, entropy []byte, tsk types.TipSetKey) (abi.Randomness, error)  //perm:read
+	// StateGetRandomnessFromBeacon is used to sample the beacon for randomness, it returns the same
+	// randomness the VM draws for an actor executing in the tipset tsk.
+	StateGetRandomnessFromBeacon(ctx context.Context, personalization crypto.DomainSeparationTag, randEpoch abi.ChainEpoch, entropy []byte, tsk types.TipSetKey) (abi.Randomness, error) //perm:read
 	// StateGetRandomnessDigestFromTickets is used to sample the chain for randomness.
 	StateGetRandomnessDigestFromTickets(ctx context.Context, randEpoch abi.ChainEpoch, tsk types.TipSetKey) (abi.Randomness, error) //perm:read
 	// StateGetRandomnessDigestFromBeacon is used to sample the beacon for randomness.
@@ -64,13 +82,20 @@ type IChainInfo interface {
 	StateVerifiedRegistryRootKey(ctx context.Context, tsk types.TipSetKey) (address.Address, error)                //perm:read
 	StateVerifierStatus(ctx context.Context, addr address.Address, tsk types.TipSetKey) (*abi.StoragePower, error) //perm:read
 	ChainNotify(ctx context.Context) (<-chan []*types.HeadChange, error)                                           //perm:read
-	GetFullBlock(ctx context.Context, id cid.Cid) (*types.FullBlock, error)                                        //perm:read
-	GetActor(ctx context.Context, addr address.Address) (*types.Actor, error)                                      //perm:read
-	GetParentStateRootActor(ctx context.Context, ts *types.TipSet, addr address.Address) (*types.Actor, error)     //perm:read
-	GetEntry(ctx context.Context, height abi.ChainEpoch, round uint64) (*types.BeaconEntry, error)                 //perm:read
-	ProtocolParameters(ctx context.Context) (*types.ProtocolParams, error)                                         //perm:read
-	ResolveToKeyAddr(ctx context.Context, addr address.Address, ts *types.TipSet) (address.Address, error)         //perm:read
-	StateNetworkName(ctx context.Context) (types.NetworkName, error)                                               //perm:read
+	// ChainNotifyStable is like ChainNotify, but only delivers the tipsets once they are confidence epochs deep,
+	// collapsing the reorgs above that depth, reverts are only delivered for reorgs deeper than confidence.
+	ChainNotifyStable(ctx context.Context, confidence abi.ChainEpoch) (<-chan []*types.HeadChange, error)      //perm:read
+	GetFullBlock(ctx context.Context, id cid.Cid) (*types.FullBlock, error)                                    //perm:read
+	GetActor(ctx context.Context, addr address.Address) (*types.Actor, error)                                  //perm:read
+	GetParentStateRootActor(ctx context.Context, ts *types.TipSet, addr address.Address) (*types.Actor, error) //perm:read
+	// GetEntry returns the raw drand entry of round, fetched from the beacon network active at height.
+	GetEntry(ctx context.Context, height abi.ChainEpoch, round uint64) (*types.BeaconEntry, error) //perm:read
+	// BeaconStatus returns, for every drand network of the beacon schedule, the latest round fetched
+	// and the health and latency of its servers.
+	BeaconStatus(ctx context.Context) ([]types.BeaconStatus, error)                                        //perm:read
+	ProtocolParameters(ctx context.Context) (*types.ProtocolParams, error)                                 //perm:read
+	ResolveToKeyAddr(ctx context.Context, addr address.Address, ts *types.TipSet) (address.Address, error) //perm:read
+	StateNetworkName(ctx context.Context) (types.NetworkName, error)                                       //perm:read
 	// StateSearchMsg looks back up to limit epochs in the chain for a message, and returns its receipt and the tipset where it was executed
 	//
 	// NOTE: If a replacing message is found on chain, this method will return
@@ -88,6 +113,11 @@ type IChainInfo interface {
 	// different signature, but with all other parameters matching (source/destination,
 	// nonce, params, etc.)
 	StateSearchMsg(ctx context.Context, from types.TipSetKey, msg cid.Cid, limit abi.ChainEpoch, allowReplaced bool) (*types.MsgLookup, error) //perm:read
+	// StateSearchMsgWithReplacement looks back up to limit epochs in the chain for the message executed with the sender and
+	// nonce of msg, and returns its receipt and the tipset where it was executed. Unlike StateSearchMsg, it never fails when
+	// the nonce was taken by another message, but flags whether the message was replaced and whether the replacement only
+	// changed the gas values, so a fee bumped message is not mistaken for a lost one.
+	StateSearchMsgWithReplacement(ctx context.Context, from types.TipSetKey, msg cid.Cid, limit abi.ChainEpoch) (*types.MsgReplacementLookup, error) //perm:read
 	// StateWaitMsg looks back up to limit epochs in the chain for a message.
 	// If not found, it blocks until the message arrives on chain, and gets to the
 	// indicated confidence depth.
@@ -123,6 +153,15 @@ type IChainInfo interface {
 	StateReplay(context.Context, types.TipSetKey, cid.Cid) (*types.InvocResult, error)                  //perm:read
 	// ChainGetEvents returns the events under an event AMT root CID.
 	ChainGetEvents(context.Context, cid.Cid) ([]types.Event, error) //perm:read
+	// ChainGetEventsDecoded returns the events under an event AMT root CID, typically the EventsRoot of a receipt,
+	// with their cbor entry values decoded to dag-json.
+	ChainGetEventsDecoded(ctx context.Context, root cid.Cid) ([]types.DecodedEvent, error) //perm:read
+	// ChainGetReceiptProof searches the chain back from the tipset from for msg, and returns an inclusion proof of
+	// its receipt in the receipts committed to by the tipset following the one which executed msg.
+	ChainGetReceiptProof(ctx context.Context, from types.TipSetKey, msg cid.Cid) (*types.ReceiptInclusionProof, error) //perm:read
+	// ChainGetEventProof is like ChainGetReceiptProof, and also proves the inclusion of the event at eventIndex
+	// in the events emitted by msg.
+	ChainGetEventProof(ctx context.Context, from types.TipSetKey, msg cid.Cid, eventIndex uint64) (*types.EventInclusionProof, error) //perm:read
 	// StateCompute is a flexible command that applies the given messages on the given tipset.
 	// The messages are run as though the VM were at the provided height.
 	//
@@ -158,14 +197,40 @@ type IChainInfo interface {
 	StateCompute(context.Context, abi.ChainEpoch, []*types.Message, types.TipSetKey) (*types.ComputeStateOutput, error) //perm:read
 	// StateMarketProposalPending returns whether a given proposal CID is marked as pending in the market actor
 	StateMarketProposalPending(ctx context.Context, proposalCid cid.Cid, tsk types.TipSetKey) (bool, error) //perm:read
+	// ChainExportRange exports as a car the blocks with a height in (from, to] of the chain of tsk, with their
+	// messages, receipts and the state objects changed since the tipset at from, so the chain can be replicated
+	// incrementally on top of a snapshot at from. The tipsets at null rounds are the previous non-null ones.
+	ChainExportRange(ctx context.Context, from, to abi.ChainEpoch, tsk types.TipSetKey) (<-chan []byte, error) //perm:read
+	// StateMigrationStatus returns, for every network upgrade migrating the state tree, the progress of its
+	// pre-migrations, run ahead of the upgrade epoch to warm up the migration cache, and of its migration.
+	StateMigrationStatus(ctx context.Context) ([]types.MigrationStatus, error) //perm:read
+	// StateUpgradeSchedule returns the upgrade heights of the network by height, flagging the ones
+	// overridden by the config. Negative heights are upgrades disabled on the network.
+	StateUpgradeSchedule(ctx context.Context) ([]types.UpgradeHeight, error) //perm:read
+	// StateActorMethods returns the methods exported by the builtin actor with the code, of any actor version,
+	// with the schemas of the JSON params and return accepted by StateEncodeParams and returned by StateDecodeParams.
+	StateActorMethods(ctx context.Context, code cid.Cid) ([]types.ActorMethod, error) //perm:read
+	// ChainPrune removes from the blockstore the messages, receipts and state of the tipsets below the height
+	// which are not referenced by the tipsets at or above it, the block headers are kept. The height must be
+	// final, and the state of the chain above it complete, eg. not below the state imported from a snapshot.
+	// A dry run only reports the objects which would be removed and their size. It fails while another prune
+	// or the blockstore gc is running.
+	ChainPrune(ctx context.Context, opts types.ChainPruneOpts) (*types.ChainPruneResult, error) //perm:admin
+	// ChainListReorgs returns the reorgs of the head observed by the node, at least minDepth epochs deep and
+	// observed at or after since, from the latest one. At most limit reorgs are returned, all of them when limit
+	// is not positive.
+	ChainListReorgs(ctx context.Context, minDepth abi.ChainEpoch, since time.Time, limit int) ([]types.ChainReorg, error) //perm:read
 }
 
 type IMinerState interface {
 	StateReadState(ctx context.Context, actor address.Address, tsk types.TipSetKey) (*types.ActorState, error)                                    //perm:read
 	StateListMessages(ctx context.Context, match *types.MessageMatch, tsk types.TipSetKey, toht abi.ChainEpoch) ([]cid.Cid, error)                //perm:read
 	StateDecodeParams(ctx context.Context, toAddr address.Address, method abi.MethodNum, params []byte, tsk types.TipSetKey) (interface{}, error) //perm:read
-	StateEncodeParams(ctx context.Context, toActCode cid.Cid, method abi.MethodNum, params json.RawMessage) ([]byte, error)                       //perm:read
-	StateMinerSectorAllocated(ctx context.Context, maddr address.Address, s abi.SectorNumber, tsk types.TipSetKey) (bool, error)                  //perm:read
+	// StateEncodeParams encodes to cbor the JSON params of a method of a builtin actor. The big integers may be
+	// numbers, decimal strings or FIL strings like "1.5 FIL", the bitfields strings of bits and ranges like "0-3,7"
+	// and the cids strings.
+	StateEncodeParams(ctx context.Context, toActCode cid.Cid, method abi.MethodNum, params json.RawMessage) ([]byte, error)      //perm:read
+	StateMinerSectorAllocated(ctx context.Context, maddr address.Address, s abi.SectorNumber, tsk types.TipSetKey) (bool, error) //perm:read
 	// StateSectorPreCommitInfo returns the PreCommit info for the specified miner's sector.
 	// Returns nil and no error if the sector isn't precommitted.
 	//
@@ -206,6 +271,28 @@ type IMinerState interface {
 	// StateComputeDataCID computes DataCID from a set of on-chain deals
 	StateComputeDataCID(ctx context.Context, maddr address.Address, sectorType abi.RegisteredSealProof, deals []abi.DealID, tsk types.TipSetKey) (cid.Cid, error) //perm:read
 	StateMinerPreCommitDepositForPower(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (big.Int, error)           //perm:read
+	// StateMinerPreCommitDepositForPowerBatch returns the precommit deposit of each sector, together with the
+	// sector weight and network conditions it is computed from.
+	StateMinerPreCommitDepositForPowerBatch(ctx context.Context, maddr address.Address, pcis []types.SectorPreCommitInfo, tsk types.TipSetKey) ([]types.PreCommitDepositBreakdown, error) //perm:read
+	// StateSectorPenaltyForFaults estimates the fee charged to the miner for each WindowPoSt the given
+	// sectors miss, based on the reward and power state at the given tipset.
+	StateSectorPenaltyForFaults(ctx context.Context, maddr address.Address, sectors bitfield.BitField, tsk types.TipSetKey) (*types.SectorFaultPenalty, error) //perm:read
+	// StateMinerPendingBeneficiaryChange returns the pending beneficiary change of the miner and who still has to approve it.
+	StateMinerPendingBeneficiaryChange(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (*types.PendingBeneficiaryChangeStatus, error) //perm:read
+	// MinerProposeChangeBeneficiary builds the message the owner sends to propose newBeneficiary with the given quota
+	// and expiration. Proposing the owner itself requires a zero quota and expiration.
+	MinerProposeChangeBeneficiary(ctx context.Context, maddr address.Address, newBeneficiary address.Address, quota abi.TokenAmount, expiration abi.ChainEpoch) (*types.MessagePrototype, error) //perm:read
+	// MinerApproveChangeBeneficiary builds the message approver sends to approve the pending beneficiary change,
+	// approver must be either the current beneficiary or the nominee.
+	MinerApproveChangeBeneficiary(ctx context.Context, maddr address.Address, approver address.Address) (*types.MessagePrototype, error) //perm:read
+	// MinerChangeWorkerAddress builds the message the owner sends to change the worker, which must be a BLS account,
+	// and the control addresses, which must be account or multisig actors.
+	MinerChangeWorkerAddress(ctx context.Context, maddr address.Address, newWorker address.Address, controlAddrs []address.Address) (*types.MessagePrototype, error) //perm:read
+	// MinerConfirmChangeWorker builds the message the owner sends to confirm the pending worker change once its epoch is reached.
+	MinerConfirmChangeWorker(ctx context.Context, maddr address.Address) (*types.MessagePrototype, error) //perm:read
+	// MinerChangeOwnerAddress builds the message sender sends to change the owner, the current owner proposes newOwner
+	// and newOwner confirms the change with the same call.
+	MinerChangeOwnerAddress(ctx context.Context, maddr address.Address, sender address.Address, newOwner address.Address) (*types.MessagePrototype, error) //perm:read
 	// StateMinerInitialPledgeCollateral attempts to calculate the initial pledge collateral based on a SectorPreCommitInfo.
 	// This method uses the DealIDs field in SectorPreCommitInfo to determine the amount of verified
 	// deal space in the sector in order to perform a QAP calculation. Since network version 22 and
@@ -239,4 +326,55 @@ type IMinerState interface {
 	StateVerifiedClientStatus(ctx context.Context, addr address.Address, tsk types.TipSetKey) (*abi.StoragePower, error)                                     //perm:read
 	// StateMinerAllocated returns a bitfield containing all sector numbers marked as allocated in miner state
 	StateMinerAllocated(context.Context, address.Address, types.TipSetKey) (*bitfield.BitField, error) //perm:read
+	// StateRegisterContractABI registers the solidity JSON ABI of an evm contract, either the array output
+	// by solc or an artifact holding it in its abi field. StateDecodeParams and StateDecodeReturn then decode
+	// the contract calls into named function calls.
+	StateRegisterContractABI(ctx context.Context, contract address.Address, contractABI json.RawMessage) error //perm:write
+	// StateDecodeReturn decodes the return of a message, like StateDecodeParams decodes its params.
+	StateDecodeReturn(ctx context.Context, toAddr address.Address, method abi.MethodNum, params []byte, ret []byte, tsk types.TipSetKey) (interface{}, error) //perm:read
+	// StateGetDisputableWindowedPoSts returns the deadlines of the miner holding window PoSts which may still be
+	// disputed with DisputeWindowedPoSt at the tipset, with the partitions proven by each proof.
+	StateGetDisputableWindowedPoSts(ctx context.Context, maddr address.Address, tsk types.TipSetKey) ([]types.DisputableDeadline, error) //perm:read
+	// StateMinerCompactionPlan returns the CompactPartitions messages compacting, in the deadlines which may be
+	// compacted at the tipset, the partitions whose ratio of terminated sectors is at least minDeadRatio, with the
+	// gas they are estimated to use.
+	StateMinerCompactionPlan(ctx context.Context, maddr address.Address, minDeadRatio float64, tsk types.TipSetKey) ([]types.CompactionPlan, error) //perm:read
+	// StateMinerDigestSubscribe emits a digest of each deadline of the miners when it closes, with the sectors which
+	// became faulty, the partitions which missed their window PoSt and the live sectors expiring within expiringWithin
+	// epochs, 0 leaving out the expiring sectors.
+	StateMinerDigestSubscribe(ctx context.Context, miners []address.Address, expiringWithin abi.ChainEpoch) (<-chan *types.MinerDeadlineDigest, error) //perm:read
+	// StateFindSectorForDeal returns the sector of the miner holding the deal, precommitted or activated, from the
+	// deal state or the precommits of the miner.
+	StateFindSectorForDeal(ctx context.Context, maddr address.Address, dealID abi.DealID, tsk types.TipSetKey) (*types.DealSector, error) //perm:read
+	// StateListActorsPage returns up to limit actors of the state selected by filter, following cursor, the Next of
+	// the previous page, or from the first one when cursor is empty. The pages of a listing must be read at the same
+	// tipset with the same filter.
+	StateListActorsPage(ctx context.Context, filter types.ActorFilter, cursor address.Address, limit int, tsk types.TipSetKey) (*types.ActorsPage, error) //perm:read
+	// StateAddressActivitySubscribe notifies the messages from or to addrs once they are executed, with their receipts,
+	// and again with Reverted set when the tipset executing them is reverted. The ID and robust addresses of the
+	// watched actors both match.
+	StateAddressActivitySubscribe(ctx context.Context, addrs []address.Address) (<-chan *types.AddressActivity, error) //perm:read
+	// StateConsensusFaultReport checks the consensus fault proven by the two block headers, and the optional extra one
+	// for parent grinding, against the state of the tipset, and returns the ReportConsensusFault message from the
+	// reporter with the penalty charged to the miner and the expected reward of the reporter.
+	StateConsensusFaultReport(ctx context.Context, from address.Address, h1, h2, extra *types.BlockHeader, tsk types.TipSetKey) (*types.ConsensusFaultReport, error) //perm:read
+}
+
+// IBitfield computes on the RLE+ encoded bitfields, eg. of the sectors of the miners, for the clients without an
+// RLE+ codec. The bitfields are the raw RLE+ bytes, without the cbor header of the chain state.
+type IBitfield interface {
+	// BitfieldUnion returns the bits set in any of the bitfields
+	BitfieldUnion(ctx context.Context, bitfields [][]byte) ([]byte, error) //perm:read
+	// BitfieldIntersect returns the bits set in both a and b
+	BitfieldIntersect(ctx context.Context, a, b []byte) ([]byte, error) //perm:read
+	// BitfieldSubtract returns the bits set in a and not in b
+	BitfieldSubtract(ctx context.Context, a, b []byte) ([]byte, error) //perm:read
+	// BitfieldCount returns the number of bits set in the bitfield
+	BitfieldCount(ctx context.Context, bf []byte) (uint64, error) //perm:read
+	// BitfieldSlice returns count bits set in the bitfield, skipping the first start of them
+	BitfieldSlice(ctx context.Context, bf []byte, start, count uint64) ([]byte, error) //perm:read
+	// BitfieldEncode returns the bitfield of the bits, eg. sector numbers
+	BitfieldEncode(ctx context.Context, bits []uint64) ([]byte, error) //perm:read
+	// BitfieldDecode returns the bits set in the bitfield, it fails when more than max bits are set
+	BitfieldDecode(ctx context.Context, bf []byte, max uint64) ([]uint64, error) //perm:read
 }
@@ -12,6 +12,41 @@ type ICommon interface {
 	api.Version
 
 	NodeStatus(ctx context.Context, inclChainStatus bool) (types.NodeStatus, error) //perm:read
+	// NodeHealth reports the liveness of the node and the readiness checks against the configured thresholds
+	NodeHealth(ctx context.Context) (types.NodeHealth, error) //perm:read
 	// StartTime returns node start time
 	StartTime(context.Context) (time.Time, error) //perm:read
+
+	// LogList returns the names of the logging subsystems of the node
+	LogList(context.Context) ([]string, error) //perm:write
+	// LogSetLevel changes the level of a logging subsystem at runtime, "*" targets all subsystems
+	LogSetLevel(ctx context.Context, subsystem, level string) error //perm:write
+
+	// APIHandshake returns the versions and the deprecated methods of the rpc apis served by the node, and whether
+	// a client built against the v1 api version clientVersion can use the v1 api of the node
+	APIHandshake(ctx context.Context, clientVersion types.APIVersion) (types.APIHandshake, error) //perm:read
+
+	// MaintenanceSchedule returns the maintenance windows of the config, whether the disk maintenance is allowed
+	// now with the bounds of the current or next window, and the last run of the maintenance tasks
+	MaintenanceSchedule(ctx context.Context) (*types.MaintenanceSchedule, error) //perm:read
+	// MaintenanceOverride opens or closes the maintenance until a time regardless of the windows, eg. to keep it
+	// away from an unusual proving deadline, closing it interrupts the running tasks. An empty mode removes the override
+	MaintenanceOverride(ctx context.Context, override types.MaintenanceOverride) error //perm:admin
+
+	// NodeBackup writes the config, the keystore and the wallet, the metadata datastore with the local messages of
+	// the message pool, and the sqlite indexes to a new file in the directory named by VENUS_BACKUP_BASE_PATH on
+	// the host of the node, restored by a new node with `venus daemon --restore`. The keys are encrypted when a
+	// password is given
+	NodeBackup(ctx context.Context, opts types.NodeBackupOpts) (*types.NodeBackupResult, error) //perm:admin
+
+	// SetConfig sets the config field at the dotted json path key, eg. "fevm.event.maxFilters", to the json value
+	// and saves the config. The reloadable fields, the log levels, the event filter limits, the policy of the
+	// untrusted transactions and the protected peers, are applied to the running node, the result lists the
+	// changed fields which are only applied by a restart. The edits of the config file are applied the same way
+	SetConfig(ctx context.Context, key string, value string) (*types.ConfigReload, error) //perm:admin
+
+	// JournalEvents returns the latest lifecycle events recorded by the journal of the node, eg. the head changes,
+	// the sync errors and the starts and stops of the api, selected by filter and from the oldest one. Only the
+	// events kept in memory, up to journal.memoryEvents of the config, are returned
+	JournalEvents(ctx context.Context, filter types.JournalFilter) ([]types.JournalEvent, error) //perm:admin
 }
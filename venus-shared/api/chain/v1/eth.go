@@ -47,20 +47,31 @@ type IETH interface {
 
 	EthGetCode(ctx context.Context, address types.EthAddress, blkParam types.EthBlockNumberOrHash) (types.EthBytes, error)                               //perm:read
 	EthGetStorageAt(ctx context.Context, address types.EthAddress, position types.EthBytes, blkParam types.EthBlockNumberOrHash) (types.EthBytes, error) //perm:read
-	EthGetBalance(ctx context.Context, address types.EthAddress, blkParam types.EthBlockNumberOrHash) (types.EthBigInt, error)                           //perm:read
-	EthChainId(ctx context.Context) (types.EthUint64, error)                                                                                             //perm:read
-	EthSyncing(ctx context.Context) (types.EthSyncingResult, error)                                                                                      //perm:read
-	NetVersion(ctx context.Context) (string, error)                                                                                                      //perm:read
-	NetListening(ctx context.Context) (bool, error)                                                                                                      //perm:read
-	EthProtocolVersion(ctx context.Context) (types.EthUint64, error)                                                                                     //perm:read
-	EthGasPrice(ctx context.Context) (types.EthBigInt, error)                                                                                            //perm:read
-	EthFeeHistory(ctx context.Context, p jsonrpc.RawParams) (types.EthFeeHistory, error)                                                                 //perm:read
+	// EthGetContractStorage lists up to limit storage slots of an EVM contract following the slot `after`,
+	// or from the first slot when after is null, Next of the result resumes the listing
+	EthGetContractStorage(ctx context.Context, address types.EthAddress, after *types.EthHash, limit int, blkParam types.EthBlockNumberOrHash) (*types.EthContractStorage, error) //perm:read
+	// EthGetContractState returns the bytecode, nonce and balance of an EVM contract together with
+	// the state tree blocks proving the actor against the parent state root of the tipset
+	EthGetContractState(ctx context.Context, address types.EthAddress, blkParam types.EthBlockNumberOrHash) (*types.EthContractState, error) //perm:read
+	// EthGetProof returns the account and storage proofs of an address, see types.EthProof for the proof format
+	EthGetProof(ctx context.Context, address types.EthAddress, storageKeys []types.EthBytes, blkParam types.EthBlockNumberOrHash) (*types.EthProof, error) //perm:read
+	EthGetBalance(ctx context.Context, address types.EthAddress, blkParam types.EthBlockNumberOrHash) (types.EthBigInt, error)                             //perm:read
+	EthChainId(ctx context.Context) (types.EthUint64, error)                                                                                               //perm:read
+	EthSyncing(ctx context.Context) (types.EthSyncingResult, error)                                                                                        //perm:read
+	NetVersion(ctx context.Context) (string, error)                                                                                                        //perm:read
+	NetListening(ctx context.Context) (bool, error)                                                                                                        //perm:read
+	EthProtocolVersion(ctx context.Context) (types.EthUint64, error)                                                                                       //perm:read
+	EthGasPrice(ctx context.Context) (types.EthBigInt, error)                                                                                              //perm:read
+	EthFeeHistory(ctx context.Context, p jsonrpc.RawParams) (types.EthFeeHistory, error)                                                                   //perm:read
 
 	EthMaxPriorityFeePerGas(ctx context.Context) (types.EthBigInt, error)                                       //perm:read
 	EthEstimateGas(ctx context.Context, p jsonrpc.RawParams) (types.EthUint64, error)                           //perm:read
 	EthCall(ctx context.Context, tx types.EthCall, blkParam types.EthBlockNumberOrHash) (types.EthBytes, error) //perm:read
 
 	EthSendRawTransaction(ctx context.Context, rawTx types.EthBytes) (types.EthHash, error) //perm:read
+	// EthSendRawTransactionUntrusted submits a raw transaction after checking the sender allow/deny lists,
+	// the gas limit and the per-sender pending cap configured in fevm.untrustedTx, suitable for public exposure
+	EthSendRawTransactionUntrusted(ctx context.Context, rawTx types.EthBytes) (types.EthHash, error) //perm:read
 
 	// Returns the client version
 	Web3ClientVersion(ctx context.Context) (string, error) //perm:read
@@ -91,6 +102,32 @@ type IETH interface {
 	EthTraceTransaction(ctx context.Context, txHash string) ([]*types.EthTraceTransaction, error) //perm:read
 	// Implements OpenEthereum-compatible API method trace_filter
 	EthTraceFilter(ctx context.Context, filter types.EthTraceFilterCriteria) ([]*types.EthTraceFilterResult, error) //perm:read
+	// EthGetContractCreation returns the creator, init code hash and creation transaction of an EVM contract from the
+	// contract index enabled with Fevm.EnableContractIndex, or null when the contract is not indexed
+	EthGetContractCreation(ctx context.Context, contract types.EthAddress) (*types.EthContractCreation, error) //perm:read
+	// EthGetContractsByCreator lists up to limit contracts deployed by creator from the contract index, ordered by
+	// block number, following the contract `after`, or from the first one when after is null, Next of the result
+	// resumes the listing
+	EthGetContractsByCreator(ctx context.Context, creator types.EthAddress, after *types.EthAddress, limit int) (*types.EthContractCreations, error) //perm:read
+	// EthGetTokenTransfers lists up to limit ERC-20 and datacap transfers from or to holder from the token index
+	// enabled with Fevm.EnableTokenIndex, of token or of all the tokens when token is null, following the transfer
+	// with the ID `after`, or from the first one when after is 0, Next of the result resumes the listing
+	EthGetTokenTransfers(ctx context.Context, holder types.EthAddress, token *types.EthAddress, after uint64, limit int) (*types.EthTokenTransfers, error) //perm:read
+	// EthGetTokenBalanceHistory returns the changes of the balance of token held by holder, one per block with
+	// transfers, the balances sum the indexed transfers only
+	EthGetTokenBalanceHistory(ctx context.Context, holder types.EthAddress, token types.EthAddress) ([]types.EthTokenBalanceChange, error) //perm:read
+	// EthGetNFTTransfers lists up to limit ERC-721 and ERC-1155 transfers of collection from the token index, of the
+	// token tokenID or of all the tokens when tokenID is null, following the transfer with the ID `after`, or from
+	// the first one when after is 0, Next of the result resumes the listing
+	EthGetNFTTransfers(ctx context.Context, collection types.EthAddress, tokenID *types.BigInt, after uint64, limit int) (*types.EthNFTTransfers, error) //perm:read
+	// EthGetNFTOwners returns the holders of the token tokenID of collection, summed from the indexed transfers
+	EthGetNFTOwners(ctx context.Context, collection types.EthAddress, tokenID types.BigInt) ([]types.EthNFTHolding, error) //perm:read
+	// EthGetNFTHoldings returns the tokens held by owner, of collection or of all the collections when collection
+	// is null, summed from the indexed transfers
+	EthGetNFTHoldings(ctx context.Context, owner types.EthAddress, collection *types.EthAddress) ([]types.EthNFTHolding, error) //perm:read
+	// EthNodeInfo returns in one call the chain id, the latest, safe and finalized blocks, the gas price suggestions
+	// and the sync status, which the Ethereum SDKs otherwise query one by one on startup
+	EthNodeInfo(ctx context.Context) (*types.EthNodeInfo, error) //perm:read
 }
 
 type IETHEvent interface {
@@ -122,12 +159,26 @@ type IETHEvent interface {
 	//  - newHeads: notify when new blocks arrive.
 	//  - pendingTransactions: notify when new messages arrive in the message pool.
 	//  - logs: notify new event logs that match a criteria
-	// params contains additional parameters used with the log event type
+	// params contains additional parameters used with the log event type, its fromBlock replays the matching logs
+	// since that epoch before the new ones, for the clients reconnecting
 	// The client will receive a stream of EthSubscriptionResponse values until EthUnsubscribe is called.
 	EthSubscribe(ctx context.Context, params jsonrpc.RawParams) (types.EthSubscriptionID, error) //perm:read
 
 	// Unsubscribe from a websocket subscription
 	EthUnsubscribe(ctx context.Context, id types.EthSubscriptionID) (bool, error) //perm:read
+
+	// EthEventsBackfill re-executes the tipsets from fromEpoch to toEpoch included and repopulates
+	// the event index with their events, to repair an index damaged by a crash
+	EthEventsBackfill(ctx context.Context, fromEpoch, toEpoch abi.ChainEpoch) (*types.EthEventsBackfillResult, error) //perm:admin
+
+	// EthListFilters returns the installed filters, ordered by install time, with the token which installed them
+	// and the results they collected and hold until they are taken
+	EthListFilters(ctx context.Context) ([]types.EthFilterInfo, error) //perm:admin
+	// EthFilterStatus returns the filter with given id, like EthListFilters
+	EthFilterStatus(ctx context.Context, id types.EthFilterID) (*types.EthFilterInfo, error) //perm:admin
+	// EthListSubscriptions returns the active subscriptions, ordered by start time, with the responses waiting in
+	// their send queue, to find the slow clients
+	EthListSubscriptions(ctx context.Context) ([]types.EthSubscriptionInfo, error) //perm:admin
 }
 
 // reverse interface to the client, called after EthSubscribe
@@ -12,4 +12,8 @@ import (
 type IMining interface {
 	MinerGetBaseInfo(ctx context.Context, maddr address.Address, round abi.ChainEpoch, tsk types.TipSetKey) (*types.MiningBaseInfo, error) //perm:read
 	MinerCreateBlock(ctx context.Context, bt *types.BlockTemplate) (*types.BlockMsg, error)                                                //perm:write
+	// MinerCreate builds the power actor message creating a miner, the window PoSt proof type being chosen from the
+	// sector size and the network version. When Push is set, the message is pushed and the call returns the addresses
+	// of the miner once the message is executed.
+	MinerCreate(ctx context.Context, params *types.MinerCreateParams) (*types.MinerCreateResult, error) //perm:sign
 }
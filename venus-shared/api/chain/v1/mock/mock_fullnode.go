@@ -6,7 +6,7 @@ package mock
 
 import (
 	context "context"
-	json "encoding/json"
+	jsontext "encoding/json/jsontext"
 	reflect "reflect"
 	time "time"
 
@@ -60,6 +60,184 @@ func (m *MockFullNode) EXPECT() *MockFullNodeMockRecorder {
 	return m.recorder
 }
 
+// APIHandshake mocks base method.
+func (m *MockFullNode) APIHandshake(arg0 context.Context, arg1 types0.APIVersion) (types0.APIHandshake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "APIHandshake", arg0, arg1)
+	ret0, _ := ret[0].(types0.APIHandshake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// APIHandshake indicates an expected call of APIHandshake.
+func (mr *MockFullNodeMockRecorder) APIHandshake(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIHandshake", reflect.TypeOf((*MockFullNode)(nil).APIHandshake), arg0, arg1)
+}
+
+// AddressBookList mocks base method.
+func (m *MockFullNode) AddressBookList(arg0 context.Context) (map[string]address.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddressBookList", arg0)
+	ret0, _ := ret[0].(map[string]address.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddressBookList indicates an expected call of AddressBookList.
+func (mr *MockFullNodeMockRecorder) AddressBookList(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddressBookList", reflect.TypeOf((*MockFullNode)(nil).AddressBookList), arg0)
+}
+
+// AddressBookRemove mocks base method.
+func (m *MockFullNode) AddressBookRemove(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddressBookRemove", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddressBookRemove indicates an expected call of AddressBookRemove.
+func (mr *MockFullNodeMockRecorder) AddressBookRemove(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddressBookRemove", reflect.TypeOf((*MockFullNode)(nil).AddressBookRemove), arg0, arg1)
+}
+
+// AddressBookSet mocks base method.
+func (m *MockFullNode) AddressBookSet(arg0 context.Context, arg1 string, arg2 address.Address) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddressBookSet", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddressBookSet indicates an expected call of AddressBookSet.
+func (mr *MockFullNodeMockRecorder) AddressBookSet(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddressBookSet", reflect.TypeOf((*MockFullNode)(nil).AddressBookSet), arg0, arg1, arg2)
+}
+
+// BeaconStatus mocks base method.
+func (m *MockFullNode) BeaconStatus(arg0 context.Context) ([]types0.BeaconStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeaconStatus", arg0)
+	ret0, _ := ret[0].([]types0.BeaconStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeaconStatus indicates an expected call of BeaconStatus.
+func (mr *MockFullNodeMockRecorder) BeaconStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeaconStatus", reflect.TypeOf((*MockFullNode)(nil).BeaconStatus), arg0)
+}
+
+// BitfieldCount mocks base method.
+func (m *MockFullNode) BitfieldCount(arg0 context.Context, arg1 []byte) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BitfieldCount", arg0, arg1)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BitfieldCount indicates an expected call of BitfieldCount.
+func (mr *MockFullNodeMockRecorder) BitfieldCount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BitfieldCount", reflect.TypeOf((*MockFullNode)(nil).BitfieldCount), arg0, arg1)
+}
+
+// BitfieldDecode mocks base method.
+func (m *MockFullNode) BitfieldDecode(arg0 context.Context, arg1 []byte, arg2 uint64) ([]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BitfieldDecode", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BitfieldDecode indicates an expected call of BitfieldDecode.
+func (mr *MockFullNodeMockRecorder) BitfieldDecode(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BitfieldDecode", reflect.TypeOf((*MockFullNode)(nil).BitfieldDecode), arg0, arg1, arg2)
+}
+
+// BitfieldEncode mocks base method.
+func (m *MockFullNode) BitfieldEncode(arg0 context.Context, arg1 []uint64) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BitfieldEncode", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BitfieldEncode indicates an expected call of BitfieldEncode.
+func (mr *MockFullNodeMockRecorder) BitfieldEncode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BitfieldEncode", reflect.TypeOf((*MockFullNode)(nil).BitfieldEncode), arg0, arg1)
+}
+
+// BitfieldIntersect mocks base method.
+func (m *MockFullNode) BitfieldIntersect(arg0 context.Context, arg1, arg2 []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BitfieldIntersect", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BitfieldIntersect indicates an expected call of BitfieldIntersect.
+func (mr *MockFullNodeMockRecorder) BitfieldIntersect(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BitfieldIntersect", reflect.TypeOf((*MockFullNode)(nil).BitfieldIntersect), arg0, arg1, arg2)
+}
+
+// BitfieldSlice mocks base method.
+func (m *MockFullNode) BitfieldSlice(arg0 context.Context, arg1 []byte, arg2, arg3 uint64) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BitfieldSlice", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BitfieldSlice indicates an expected call of BitfieldSlice.
+func (mr *MockFullNodeMockRecorder) BitfieldSlice(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BitfieldSlice", reflect.TypeOf((*MockFullNode)(nil).BitfieldSlice), arg0, arg1, arg2, arg3)
+}
+
+// BitfieldSubtract mocks base method.
+func (m *MockFullNode) BitfieldSubtract(arg0 context.Context, arg1, arg2 []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BitfieldSubtract", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BitfieldSubtract indicates an expected call of BitfieldSubtract.
+func (mr *MockFullNodeMockRecorder) BitfieldSubtract(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BitfieldSubtract", reflect.TypeOf((*MockFullNode)(nil).BitfieldSubtract), arg0, arg1, arg2)
+}
+
+// BitfieldUnion mocks base method.
+func (m *MockFullNode) BitfieldUnion(arg0 context.Context, arg1 [][]byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BitfieldUnion", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BitfieldUnion indicates an expected call of BitfieldUnion.
+func (mr *MockFullNodeMockRecorder) BitfieldUnion(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BitfieldUnion", reflect.TypeOf((*MockFullNode)(nil).BitfieldUnion), arg0, arg1)
+}
+
 // BlockTime mocks base method.
 func (m *MockFullNode) BlockTime(arg0 context.Context) time.Duration {
 	m.ctrl.T.Helper()
@@ -103,6 +281,21 @@ func (mr *MockFullNodeMockRecorder) ChainExport(arg0, arg1, arg2, arg3 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainExport", reflect.TypeOf((*MockFullNode)(nil).ChainExport), arg0, arg1, arg2, arg3)
 }
 
+// ChainExportRange mocks base method.
+func (m *MockFullNode) ChainExportRange(arg0 context.Context, arg1, arg2 abi.ChainEpoch, arg3 types0.TipSetKey) (<-chan []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainExportRange", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(<-chan []byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainExportRange indicates an expected call of ChainExportRange.
+func (mr *MockFullNodeMockRecorder) ChainExportRange(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainExportRange", reflect.TypeOf((*MockFullNode)(nil).ChainExportRange), arg0, arg1, arg2, arg3)
+}
+
 // ChainGetBlock mocks base method.
 func (m *MockFullNode) ChainGetBlock(arg0 context.Context, arg1 cid.Cid) (*types0.BlockHeader, error) {
 	m.ctrl.T.Helper()
@@ -133,6 +326,21 @@ func (mr *MockFullNodeMockRecorder) ChainGetBlockMessages(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainGetBlockMessages", reflect.TypeOf((*MockFullNode)(nil).ChainGetBlockMessages), arg0, arg1)
 }
 
+// ChainGetEventProof mocks base method.
+func (m *MockFullNode) ChainGetEventProof(arg0 context.Context, arg1 types0.TipSetKey, arg2 cid.Cid, arg3 uint64) (*types0.EventInclusionProof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainGetEventProof", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*types0.EventInclusionProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainGetEventProof indicates an expected call of ChainGetEventProof.
+func (mr *MockFullNodeMockRecorder) ChainGetEventProof(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainGetEventProof", reflect.TypeOf((*MockFullNode)(nil).ChainGetEventProof), arg0, arg1, arg2, arg3)
+}
+
 // ChainGetEvents mocks base method.
 func (m *MockFullNode) ChainGetEvents(arg0 context.Context, arg1 cid.Cid) ([]types0.Event, error) {
 	m.ctrl.T.Helper()
@@ -148,6 +356,21 @@ func (mr *MockFullNodeMockRecorder) ChainGetEvents(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainGetEvents", reflect.TypeOf((*MockFullNode)(nil).ChainGetEvents), arg0, arg1)
 }
 
+// ChainGetEventsDecoded mocks base method.
+func (m *MockFullNode) ChainGetEventsDecoded(arg0 context.Context, arg1 cid.Cid) ([]types0.DecodedEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainGetEventsDecoded", arg0, arg1)
+	ret0, _ := ret[0].([]types0.DecodedEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainGetEventsDecoded indicates an expected call of ChainGetEventsDecoded.
+func (mr *MockFullNodeMockRecorder) ChainGetEventsDecoded(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainGetEventsDecoded", reflect.TypeOf((*MockFullNode)(nil).ChainGetEventsDecoded), arg0, arg1)
+}
+
 // ChainGetGenesis mocks base method.
 func (m *MockFullNode) ChainGetGenesis(arg0 context.Context) (*types0.TipSet, error) {
 	m.ctrl.T.Helper()
@@ -238,6 +461,21 @@ func (mr *MockFullNodeMockRecorder) ChainGetPath(arg0, arg1, arg2 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainGetPath", reflect.TypeOf((*MockFullNode)(nil).ChainGetPath), arg0, arg1, arg2)
 }
 
+// ChainGetReceiptProof mocks base method.
+func (m *MockFullNode) ChainGetReceiptProof(arg0 context.Context, arg1 types0.TipSetKey, arg2 cid.Cid) (*types0.ReceiptInclusionProof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainGetReceiptProof", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types0.ReceiptInclusionProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainGetReceiptProof indicates an expected call of ChainGetReceiptProof.
+func (mr *MockFullNodeMockRecorder) ChainGetReceiptProof(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainGetReceiptProof", reflect.TypeOf((*MockFullNode)(nil).ChainGetReceiptProof), arg0, arg1, arg2)
+}
+
 // ChainGetReceipts mocks base method.
 func (m *MockFullNode) ChainGetReceipts(arg0 context.Context, arg1 cid.Cid) ([]types0.MessageReceipt, error) {
 	m.ctrl.T.Helper()
@@ -343,6 +581,21 @@ func (mr *MockFullNodeMockRecorder) ChainList(arg0, arg1, arg2 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainList", reflect.TypeOf((*MockFullNode)(nil).ChainList), arg0, arg1, arg2)
 }
 
+// ChainListReorgs mocks base method.
+func (m *MockFullNode) ChainListReorgs(arg0 context.Context, arg1 abi.ChainEpoch, arg2 time.Time, arg3 int) ([]types0.ChainReorg, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainListReorgs", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]types0.ChainReorg)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainListReorgs indicates an expected call of ChainListReorgs.
+func (mr *MockFullNodeMockRecorder) ChainListReorgs(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainListReorgs", reflect.TypeOf((*MockFullNode)(nil).ChainListReorgs), arg0, arg1, arg2, arg3)
+}
+
 // ChainNotify mocks base method.
 func (m *MockFullNode) ChainNotify(arg0 context.Context) (<-chan []*types0.HeadChange, error) {
 	m.ctrl.T.Helper()
@@ -358,6 +611,36 @@ func (mr *MockFullNodeMockRecorder) ChainNotify(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainNotify", reflect.TypeOf((*MockFullNode)(nil).ChainNotify), arg0)
 }
 
+// ChainNotifyStable mocks base method.
+func (m *MockFullNode) ChainNotifyStable(arg0 context.Context, arg1 abi.ChainEpoch) (<-chan []*types0.HeadChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainNotifyStable", arg0, arg1)
+	ret0, _ := ret[0].(<-chan []*types0.HeadChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainNotifyStable indicates an expected call of ChainNotifyStable.
+func (mr *MockFullNodeMockRecorder) ChainNotifyStable(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainNotifyStable", reflect.TypeOf((*MockFullNode)(nil).ChainNotifyStable), arg0, arg1)
+}
+
+// ChainPrune mocks base method.
+func (m *MockFullNode) ChainPrune(arg0 context.Context, arg1 types0.ChainPruneOpts) (*types0.ChainPruneResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainPrune", arg0, arg1)
+	ret0, _ := ret[0].(*types0.ChainPruneResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainPrune indicates an expected call of ChainPrune.
+func (mr *MockFullNodeMockRecorder) ChainPrune(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainPrune", reflect.TypeOf((*MockFullNode)(nil).ChainPrune), arg0, arg1)
+}
+
 // ChainPutObj mocks base method.
 func (m *MockFullNode) ChainPutObj(arg0 context.Context, arg1 blocks.Block) error {
 	m.ctrl.T.Helper()
@@ -549,6 +832,21 @@ func (mr *MockFullNodeMockRecorder) EthEstimateGas(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthEstimateGas", reflect.TypeOf((*MockFullNode)(nil).EthEstimateGas), arg0, arg1)
 }
 
+// EthEventsBackfill mocks base method.
+func (m *MockFullNode) EthEventsBackfill(arg0 context.Context, arg1, arg2 abi.ChainEpoch) (*types0.EthEventsBackfillResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthEventsBackfill", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types0.EthEventsBackfillResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthEventsBackfill indicates an expected call of EthEventsBackfill.
+func (mr *MockFullNodeMockRecorder) EthEventsBackfill(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthEventsBackfill", reflect.TypeOf((*MockFullNode)(nil).EthEventsBackfill), arg0, arg1, arg2)
+}
+
 // EthFeeHistory mocks base method.
 func (m *MockFullNode) EthFeeHistory(arg0 context.Context, arg1 jsonrpc.RawParams) (types.EthFeeHistory, error) {
 	m.ctrl.T.Helper()
@@ -564,6 +862,21 @@ func (mr *MockFullNodeMockRecorder) EthFeeHistory(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthFeeHistory", reflect.TypeOf((*MockFullNode)(nil).EthFeeHistory), arg0, arg1)
 }
 
+// EthFilterStatus mocks base method.
+func (m *MockFullNode) EthFilterStatus(arg0 context.Context, arg1 types.EthFilterID) (*types0.EthFilterInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthFilterStatus", arg0, arg1)
+	ret0, _ := ret[0].(*types0.EthFilterInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthFilterStatus indicates an expected call of EthFilterStatus.
+func (mr *MockFullNodeMockRecorder) EthFilterStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthFilterStatus", reflect.TypeOf((*MockFullNode)(nil).EthFilterStatus), arg0, arg1)
+}
+
 // EthGasPrice mocks base method.
 func (m *MockFullNode) EthGasPrice(arg0 context.Context) (types.EthBigInt, error) {
 	m.ctrl.T.Helper()
@@ -699,6 +1012,66 @@ func (mr *MockFullNodeMockRecorder) EthGetCode(arg0, arg1, arg2 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthGetCode", reflect.TypeOf((*MockFullNode)(nil).EthGetCode), arg0, arg1, arg2)
 }
 
+// EthGetContractCreation mocks base method.
+func (m *MockFullNode) EthGetContractCreation(arg0 context.Context, arg1 types.EthAddress) (*types0.EthContractCreation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthGetContractCreation", arg0, arg1)
+	ret0, _ := ret[0].(*types0.EthContractCreation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthGetContractCreation indicates an expected call of EthGetContractCreation.
+func (mr *MockFullNodeMockRecorder) EthGetContractCreation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthGetContractCreation", reflect.TypeOf((*MockFullNode)(nil).EthGetContractCreation), arg0, arg1)
+}
+
+// EthGetContractState mocks base method.
+func (m *MockFullNode) EthGetContractState(arg0 context.Context, arg1 types.EthAddress, arg2 types.EthBlockNumberOrHash) (*types0.EthContractState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthGetContractState", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types0.EthContractState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthGetContractState indicates an expected call of EthGetContractState.
+func (mr *MockFullNodeMockRecorder) EthGetContractState(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthGetContractState", reflect.TypeOf((*MockFullNode)(nil).EthGetContractState), arg0, arg1, arg2)
+}
+
+// EthGetContractStorage mocks base method.
+func (m *MockFullNode) EthGetContractStorage(arg0 context.Context, arg1 types.EthAddress, arg2 *types.EthHash, arg3 int, arg4 types.EthBlockNumberOrHash) (*types0.EthContractStorage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthGetContractStorage", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*types0.EthContractStorage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthGetContractStorage indicates an expected call of EthGetContractStorage.
+func (mr *MockFullNodeMockRecorder) EthGetContractStorage(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthGetContractStorage", reflect.TypeOf((*MockFullNode)(nil).EthGetContractStorage), arg0, arg1, arg2, arg3, arg4)
+}
+
+// EthGetContractsByCreator mocks base method.
+func (m *MockFullNode) EthGetContractsByCreator(arg0 context.Context, arg1 types.EthAddress, arg2 *types.EthAddress, arg3 int) (*types0.EthContractCreations, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthGetContractsByCreator", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*types0.EthContractCreations)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthGetContractsByCreator indicates an expected call of EthGetContractsByCreator.
+func (mr *MockFullNodeMockRecorder) EthGetContractsByCreator(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthGetContractsByCreator", reflect.TypeOf((*MockFullNode)(nil).EthGetContractsByCreator), arg0, arg1, arg2, arg3)
+}
+
 // EthGetFilterChanges mocks base method.
 func (m *MockFullNode) EthGetFilterChanges(arg0 context.Context, arg1 types.EthFilterID) (*types.EthFilterResult, error) {
 	m.ctrl.T.Helper()
@@ -759,6 +1132,66 @@ func (mr *MockFullNodeMockRecorder) EthGetMessageCidByTransactionHash(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthGetMessageCidByTransactionHash", reflect.TypeOf((*MockFullNode)(nil).EthGetMessageCidByTransactionHash), arg0, arg1)
 }
 
+// EthGetNFTHoldings mocks base method.
+func (m *MockFullNode) EthGetNFTHoldings(arg0 context.Context, arg1 types.EthAddress, arg2 *types.EthAddress) ([]types0.EthNFTHolding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthGetNFTHoldings", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]types0.EthNFTHolding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthGetNFTHoldings indicates an expected call of EthGetNFTHoldings.
+func (mr *MockFullNodeMockRecorder) EthGetNFTHoldings(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthGetNFTHoldings", reflect.TypeOf((*MockFullNode)(nil).EthGetNFTHoldings), arg0, arg1, arg2)
+}
+
+// EthGetNFTOwners mocks base method.
+func (m *MockFullNode) EthGetNFTOwners(arg0 context.Context, arg1 types.EthAddress, arg2 big.Int) ([]types0.EthNFTHolding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthGetNFTOwners", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]types0.EthNFTHolding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthGetNFTOwners indicates an expected call of EthGetNFTOwners.
+func (mr *MockFullNodeMockRecorder) EthGetNFTOwners(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthGetNFTOwners", reflect.TypeOf((*MockFullNode)(nil).EthGetNFTOwners), arg0, arg1, arg2)
+}
+
+// EthGetNFTTransfers mocks base method.
+func (m *MockFullNode) EthGetNFTTransfers(arg0 context.Context, arg1 types.EthAddress, arg2 *big.Int, arg3 uint64, arg4 int) (*types0.EthNFTTransfers, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthGetNFTTransfers", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*types0.EthNFTTransfers)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthGetNFTTransfers indicates an expected call of EthGetNFTTransfers.
+func (mr *MockFullNodeMockRecorder) EthGetNFTTransfers(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthGetNFTTransfers", reflect.TypeOf((*MockFullNode)(nil).EthGetNFTTransfers), arg0, arg1, arg2, arg3, arg4)
+}
+
+// EthGetProof mocks base method.
+func (m *MockFullNode) EthGetProof(arg0 context.Context, arg1 types.EthAddress, arg2 []types.EthBytes, arg3 types.EthBlockNumberOrHash) (*types0.EthProof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthGetProof", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*types0.EthProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthGetProof indicates an expected call of EthGetProof.
+func (mr *MockFullNodeMockRecorder) EthGetProof(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthGetProof", reflect.TypeOf((*MockFullNode)(nil).EthGetProof), arg0, arg1, arg2, arg3)
+}
+
 // EthGetStorageAt mocks base method.
 func (m *MockFullNode) EthGetStorageAt(arg0 context.Context, arg1 types.EthAddress, arg2 types.EthBytes, arg3 types.EthBlockNumberOrHash) (types.EthBytes, error) {
 	m.ctrl.T.Helper()
@@ -774,6 +1207,36 @@ func (mr *MockFullNodeMockRecorder) EthGetStorageAt(arg0, arg1, arg2, arg3 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthGetStorageAt", reflect.TypeOf((*MockFullNode)(nil).EthGetStorageAt), arg0, arg1, arg2, arg3)
 }
 
+// EthGetTokenBalanceHistory mocks base method.
+func (m *MockFullNode) EthGetTokenBalanceHistory(arg0 context.Context, arg1, arg2 types.EthAddress) ([]types0.EthTokenBalanceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthGetTokenBalanceHistory", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]types0.EthTokenBalanceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthGetTokenBalanceHistory indicates an expected call of EthGetTokenBalanceHistory.
+func (mr *MockFullNodeMockRecorder) EthGetTokenBalanceHistory(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthGetTokenBalanceHistory", reflect.TypeOf((*MockFullNode)(nil).EthGetTokenBalanceHistory), arg0, arg1, arg2)
+}
+
+// EthGetTokenTransfers mocks base method.
+func (m *MockFullNode) EthGetTokenTransfers(arg0 context.Context, arg1 types.EthAddress, arg2 *types.EthAddress, arg3 uint64, arg4 int) (*types0.EthTokenTransfers, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthGetTokenTransfers", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*types0.EthTokenTransfers)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthGetTokenTransfers indicates an expected call of EthGetTokenTransfers.
+func (mr *MockFullNodeMockRecorder) EthGetTokenTransfers(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthGetTokenTransfers", reflect.TypeOf((*MockFullNode)(nil).EthGetTokenTransfers), arg0, arg1, arg2, arg3, arg4)
+}
+
 // EthGetTransactionByBlockHashAndIndex mocks base method.
 func (m *MockFullNode) EthGetTransactionByBlockHashAndIndex(arg0 context.Context, arg1 types.EthHash, arg2 types.EthUint64) (types.EthTx, error) {
 	m.ctrl.T.Helper()
@@ -894,6 +1357,36 @@ func (mr *MockFullNodeMockRecorder) EthGetTransactionReceiptLimited(arg0, arg1,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthGetTransactionReceiptLimited", reflect.TypeOf((*MockFullNode)(nil).EthGetTransactionReceiptLimited), arg0, arg1, arg2)
 }
 
+// EthListFilters mocks base method.
+func (m *MockFullNode) EthListFilters(arg0 context.Context) ([]types0.EthFilterInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthListFilters", arg0)
+	ret0, _ := ret[0].([]types0.EthFilterInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthListFilters indicates an expected call of EthListFilters.
+func (mr *MockFullNodeMockRecorder) EthListFilters(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthListFilters", reflect.TypeOf((*MockFullNode)(nil).EthListFilters), arg0)
+}
+
+// EthListSubscriptions mocks base method.
+func (m *MockFullNode) EthListSubscriptions(arg0 context.Context) ([]types0.EthSubscriptionInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthListSubscriptions", arg0)
+	ret0, _ := ret[0].([]types0.EthSubscriptionInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthListSubscriptions indicates an expected call of EthListSubscriptions.
+func (mr *MockFullNodeMockRecorder) EthListSubscriptions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthListSubscriptions", reflect.TypeOf((*MockFullNode)(nil).EthListSubscriptions), arg0)
+}
+
 // EthMaxPriorityFeePerGas mocks base method.
 func (m *MockFullNode) EthMaxPriorityFeePerGas(arg0 context.Context) (types.EthBigInt, error) {
 	m.ctrl.T.Helper()
@@ -954,6 +1447,21 @@ func (mr *MockFullNodeMockRecorder) EthNewPendingTransactionFilter(arg0 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthNewPendingTransactionFilter", reflect.TypeOf((*MockFullNode)(nil).EthNewPendingTransactionFilter), arg0)
 }
 
+// EthNodeInfo mocks base method.
+func (m *MockFullNode) EthNodeInfo(arg0 context.Context) (*types0.EthNodeInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthNodeInfo", arg0)
+	ret0, _ := ret[0].(*types0.EthNodeInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthNodeInfo indicates an expected call of EthNodeInfo.
+func (mr *MockFullNodeMockRecorder) EthNodeInfo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthNodeInfo", reflect.TypeOf((*MockFullNode)(nil).EthNodeInfo), arg0)
+}
+
 // EthProtocolVersion mocks base method.
 func (m *MockFullNode) EthProtocolVersion(arg0 context.Context) (types.EthUint64, error) {
 	m.ctrl.T.Helper()
@@ -984,6 +1492,21 @@ func (mr *MockFullNodeMockRecorder) EthSendRawTransaction(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthSendRawTransaction", reflect.TypeOf((*MockFullNode)(nil).EthSendRawTransaction), arg0, arg1)
 }
 
+// EthSendRawTransactionUntrusted mocks base method.
+func (m *MockFullNode) EthSendRawTransactionUntrusted(arg0 context.Context, arg1 types.EthBytes) (types.EthHash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthSendRawTransactionUntrusted", arg0, arg1)
+	ret0, _ := ret[0].(types.EthHash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthSendRawTransactionUntrusted indicates an expected call of EthSendRawTransactionUntrusted.
+func (mr *MockFullNodeMockRecorder) EthSendRawTransactionUntrusted(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthSendRawTransactionUntrusted", reflect.TypeOf((*MockFullNode)(nil).EthSendRawTransactionUntrusted), arg0, arg1)
+}
+
 // EthSubscribe mocks base method.
 func (m *MockFullNode) EthSubscribe(arg0 context.Context, arg1 jsonrpc.RawParams) (types.EthSubscriptionID, error) {
 	m.ctrl.T.Helper()
@@ -1317,164 +1840,401 @@ func (mr *MockFullNodeMockRecorder) GasEstimateGasLimit(arg0, arg1, arg2 interfa
 // GasEstimateGasPremium mocks base method.
 func (m *MockFullNode) GasEstimateGasPremium(arg0 context.Context, arg1 uint64, arg2 address.Address, arg3 int64, arg4 types0.TipSetKey) (big.Int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GasEstimateGasPremium", arg0, arg1, arg2, arg3, arg4)
-	ret0, _ := ret[0].(big.Int)
+	ret := m.ctrl.Call(m, "GasEstimateGasPremium", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GasEstimateGasPremium indicates an expected call of GasEstimateGasPremium.
+func (mr *MockFullNodeMockRecorder) GasEstimateGasPremium(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GasEstimateGasPremium", reflect.TypeOf((*MockFullNode)(nil).GasEstimateGasPremium), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GasEstimateMessageGas mocks base method.
+func (m *MockFullNode) GasEstimateMessageGas(arg0 context.Context, arg1 *types.Message, arg2 *types0.MessageSendSpec, arg3 types0.TipSetKey) (*types.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GasEstimateMessageGas", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*types.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GasEstimateMessageGas indicates an expected call of GasEstimateMessageGas.
+func (mr *MockFullNodeMockRecorder) GasEstimateMessageGas(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GasEstimateMessageGas", reflect.TypeOf((*MockFullNode)(nil).GasEstimateMessageGas), arg0, arg1, arg2, arg3)
+}
+
+// GetActor mocks base method.
+func (m *MockFullNode) GetActor(arg0 context.Context, arg1 address.Address) (*types.ActorV5, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActor", arg0, arg1)
+	ret0, _ := ret[0].(*types.ActorV5)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActor indicates an expected call of GetActor.
+func (mr *MockFullNodeMockRecorder) GetActor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActor", reflect.TypeOf((*MockFullNode)(nil).GetActor), arg0, arg1)
+}
+
+// GetActorEventsRaw mocks base method.
+func (m *MockFullNode) GetActorEventsRaw(arg0 context.Context, arg1 *types0.ActorEventFilter) ([]*types0.ActorEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActorEventsRaw", arg0, arg1)
+	ret0, _ := ret[0].([]*types0.ActorEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActorEventsRaw indicates an expected call of GetActorEventsRaw.
+func (mr *MockFullNodeMockRecorder) GetActorEventsRaw(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActorEventsRaw", reflect.TypeOf((*MockFullNode)(nil).GetActorEventsRaw), arg0, arg1)
+}
+
+// GetDealEvents mocks base method.
+func (m *MockFullNode) GetDealEvents(arg0 context.Context, arg1 *types0.DealEventFilter) ([]*types0.DealEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDealEvents", arg0, arg1)
+	ret0, _ := ret[0].([]*types0.DealEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDealEvents indicates an expected call of GetDealEvents.
+func (mr *MockFullNodeMockRecorder) GetDealEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDealEvents", reflect.TypeOf((*MockFullNode)(nil).GetDealEvents), arg0, arg1)
+}
+
+// GetEntry mocks base method.
+func (m *MockFullNode) GetEntry(arg0 context.Context, arg1 abi.ChainEpoch, arg2 uint64) (*types0.BeaconEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntry", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types0.BeaconEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntry indicates an expected call of GetEntry.
+func (mr *MockFullNodeMockRecorder) GetEntry(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntry", reflect.TypeOf((*MockFullNode)(nil).GetEntry), arg0, arg1, arg2)
+}
+
+// GetFullBlock mocks base method.
+func (m *MockFullNode) GetFullBlock(arg0 context.Context, arg1 cid.Cid) (*types0.FullBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFullBlock", arg0, arg1)
+	ret0, _ := ret[0].(*types0.FullBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFullBlock indicates an expected call of GetFullBlock.
+func (mr *MockFullNodeMockRecorder) GetFullBlock(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFullBlock", reflect.TypeOf((*MockFullNode)(nil).GetFullBlock), arg0, arg1)
+}
+
+// GetParentStateRootActor mocks base method.
+func (m *MockFullNode) GetParentStateRootActor(arg0 context.Context, arg1 *types0.TipSet, arg2 address.Address) (*types.ActorV5, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetParentStateRootActor", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types.ActorV5)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetParentStateRootActor indicates an expected call of GetParentStateRootActor.
+func (mr *MockFullNodeMockRecorder) GetParentStateRootActor(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParentStateRootActor", reflect.TypeOf((*MockFullNode)(nil).GetParentStateRootActor), arg0, arg1, arg2)
+}
+
+// HasPassword mocks base method.
+func (m *MockFullNode) HasPassword(arg0 context.Context) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasPassword", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasPassword indicates an expected call of HasPassword.
+func (mr *MockFullNodeMockRecorder) HasPassword(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPassword", reflect.TypeOf((*MockFullNode)(nil).HasPassword), arg0)
+}
+
+// ID mocks base method.
+func (m *MockFullNode) ID(arg0 context.Context) (peer.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ID", arg0)
+	ret0, _ := ret[0].(peer.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ID indicates an expected call of ID.
+func (mr *MockFullNodeMockRecorder) ID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ID", reflect.TypeOf((*MockFullNode)(nil).ID), arg0)
+}
+
+// JournalEvents mocks base method.
+func (m *MockFullNode) JournalEvents(arg0 context.Context, arg1 types0.JournalFilter) ([]types0.JournalEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JournalEvents", arg0, arg1)
+	ret0, _ := ret[0].([]types0.JournalEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// JournalEvents indicates an expected call of JournalEvents.
+func (mr *MockFullNodeMockRecorder) JournalEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JournalEvents", reflect.TypeOf((*MockFullNode)(nil).JournalEvents), arg0, arg1)
+}
+
+// ListActor mocks base method.
+func (m *MockFullNode) ListActor(arg0 context.Context) (map[address.Address]*types.ActorV5, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActor", arg0)
+	ret0, _ := ret[0].(map[address.Address]*types.ActorV5)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActor indicates an expected call of ListActor.
+func (mr *MockFullNodeMockRecorder) ListActor(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActor", reflect.TypeOf((*MockFullNode)(nil).ListActor), arg0)
+}
+
+// LockWallet mocks base method.
+func (m *MockFullNode) LockWallet(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockWallet", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockWallet indicates an expected call of LockWallet.
+func (mr *MockFullNodeMockRecorder) LockWallet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockWallet", reflect.TypeOf((*MockFullNode)(nil).LockWallet), arg0)
+}
+
+// LogList mocks base method.
+func (m *MockFullNode) LogList(arg0 context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogList", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LogList indicates an expected call of LogList.
+func (mr *MockFullNodeMockRecorder) LogList(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogList", reflect.TypeOf((*MockFullNode)(nil).LogList), arg0)
+}
+
+// LogSetLevel mocks base method.
+func (m *MockFullNode) LogSetLevel(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogSetLevel", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogSetLevel indicates an expected call of LogSetLevel.
+func (mr *MockFullNodeMockRecorder) LogSetLevel(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogSetLevel", reflect.TypeOf((*MockFullNode)(nil).LogSetLevel), arg0, arg1, arg2)
+}
+
+// MaintenanceOverride mocks base method.
+func (m *MockFullNode) MaintenanceOverride(arg0 context.Context, arg1 types0.MaintenanceOverride) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaintenanceOverride", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MaintenanceOverride indicates an expected call of MaintenanceOverride.
+func (mr *MockFullNodeMockRecorder) MaintenanceOverride(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaintenanceOverride", reflect.TypeOf((*MockFullNode)(nil).MaintenanceOverride), arg0, arg1)
+}
+
+// MaintenanceSchedule mocks base method.
+func (m *MockFullNode) MaintenanceSchedule(arg0 context.Context) (*types0.MaintenanceSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaintenanceSchedule", arg0)
+	ret0, _ := ret[0].(*types0.MaintenanceSchedule)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GasEstimateGasPremium indicates an expected call of GasEstimateGasPremium.
-func (mr *MockFullNodeMockRecorder) GasEstimateGasPremium(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+// MaintenanceSchedule indicates an expected call of MaintenanceSchedule.
+func (mr *MockFullNodeMockRecorder) MaintenanceSchedule(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GasEstimateGasPremium", reflect.TypeOf((*MockFullNode)(nil).GasEstimateGasPremium), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaintenanceSchedule", reflect.TypeOf((*MockFullNode)(nil).MaintenanceSchedule), arg0)
 }
 
-// GasEstimateMessageGas mocks base method.
-func (m *MockFullNode) GasEstimateMessageGas(arg0 context.Context, arg1 *types.Message, arg2 *types0.MessageSendSpec, arg3 types0.TipSetKey) (*types.Message, error) {
+// MarketAddBalance mocks base method.
+func (m *MockFullNode) MarketAddBalance(arg0 context.Context, arg1, arg2 address.Address, arg3 big.Int) (cid.Cid, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GasEstimateMessageGas", arg0, arg1, arg2, arg3)
-	ret0, _ := ret[0].(*types.Message)
+	ret := m.ctrl.Call(m, "MarketAddBalance", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(cid.Cid)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GasEstimateMessageGas indicates an expected call of GasEstimateMessageGas.
-func (mr *MockFullNodeMockRecorder) GasEstimateMessageGas(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+// MarketAddBalance indicates an expected call of MarketAddBalance.
+func (mr *MockFullNodeMockRecorder) MarketAddBalance(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GasEstimateMessageGas", reflect.TypeOf((*MockFullNode)(nil).GasEstimateMessageGas), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarketAddBalance", reflect.TypeOf((*MockFullNode)(nil).MarketAddBalance), arg0, arg1, arg2, arg3)
 }
 
-// GetActor mocks base method.
-func (m *MockFullNode) GetActor(arg0 context.Context, arg1 address.Address) (*types.ActorV5, error) {
+// MarketGetReserved mocks base method.
+func (m *MockFullNode) MarketGetReserved(arg0 context.Context, arg1 address.Address) (big.Int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetActor", arg0, arg1)
-	ret0, _ := ret[0].(*types.ActorV5)
+	ret := m.ctrl.Call(m, "MarketGetReserved", arg0, arg1)
+	ret0, _ := ret[0].(big.Int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetActor indicates an expected call of GetActor.
-func (mr *MockFullNodeMockRecorder) GetActor(arg0, arg1 interface{}) *gomock.Call {
+// MarketGetReserved indicates an expected call of MarketGetReserved.
+func (mr *MockFullNodeMockRecorder) MarketGetReserved(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActor", reflect.TypeOf((*MockFullNode)(nil).GetActor), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarketGetReserved", reflect.TypeOf((*MockFullNode)(nil).MarketGetReserved), arg0, arg1)
 }
 
-// GetActorEventsRaw mocks base method.
-func (m *MockFullNode) GetActorEventsRaw(arg0 context.Context, arg1 *types0.ActorEventFilter) ([]*types0.ActorEvent, error) {
+// MarketReleaseFunds mocks base method.
+func (m *MockFullNode) MarketReleaseFunds(arg0 context.Context, arg1 address.Address, arg2 big.Int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetActorEventsRaw", arg0, arg1)
-	ret0, _ := ret[0].([]*types0.ActorEvent)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "MarketReleaseFunds", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetActorEventsRaw indicates an expected call of GetActorEventsRaw.
-func (mr *MockFullNodeMockRecorder) GetActorEventsRaw(arg0, arg1 interface{}) *gomock.Call {
+// MarketReleaseFunds indicates an expected call of MarketReleaseFunds.
+func (mr *MockFullNodeMockRecorder) MarketReleaseFunds(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActorEventsRaw", reflect.TypeOf((*MockFullNode)(nil).GetActorEventsRaw), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarketReleaseFunds", reflect.TypeOf((*MockFullNode)(nil).MarketReleaseFunds), arg0, arg1, arg2)
 }
 
-// GetEntry mocks base method.
-func (m *MockFullNode) GetEntry(arg0 context.Context, arg1 abi.ChainEpoch, arg2 uint64) (*types0.BeaconEntry, error) {
+// MarketReserveFunds mocks base method.
+func (m *MockFullNode) MarketReserveFunds(arg0 context.Context, arg1, arg2 address.Address, arg3 big.Int) (cid.Cid, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEntry", arg0, arg1, arg2)
-	ret0, _ := ret[0].(*types0.BeaconEntry)
+	ret := m.ctrl.Call(m, "MarketReserveFunds", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(cid.Cid)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetEntry indicates an expected call of GetEntry.
-func (mr *MockFullNodeMockRecorder) GetEntry(arg0, arg1, arg2 interface{}) *gomock.Call {
+// MarketReserveFunds indicates an expected call of MarketReserveFunds.
+func (mr *MockFullNodeMockRecorder) MarketReserveFunds(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntry", reflect.TypeOf((*MockFullNode)(nil).GetEntry), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarketReserveFunds", reflect.TypeOf((*MockFullNode)(nil).MarketReserveFunds), arg0, arg1, arg2, arg3)
 }
 
-// GetFullBlock mocks base method.
-func (m *MockFullNode) GetFullBlock(arg0 context.Context, arg1 cid.Cid) (*types0.FullBlock, error) {
+// MarketWithdraw mocks base method.
+func (m *MockFullNode) MarketWithdraw(arg0 context.Context, arg1, arg2 address.Address, arg3 big.Int) (cid.Cid, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFullBlock", arg0, arg1)
-	ret0, _ := ret[0].(*types0.FullBlock)
+	ret := m.ctrl.Call(m, "MarketWithdraw", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(cid.Cid)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetFullBlock indicates an expected call of GetFullBlock.
-func (mr *MockFullNodeMockRecorder) GetFullBlock(arg0, arg1 interface{}) *gomock.Call {
+// MarketWithdraw indicates an expected call of MarketWithdraw.
+func (mr *MockFullNodeMockRecorder) MarketWithdraw(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFullBlock", reflect.TypeOf((*MockFullNode)(nil).GetFullBlock), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarketWithdraw", reflect.TypeOf((*MockFullNode)(nil).MarketWithdraw), arg0, arg1, arg2, arg3)
 }
 
-// GetParentStateRootActor mocks base method.
-func (m *MockFullNode) GetParentStateRootActor(arg0 context.Context, arg1 *types0.TipSet, arg2 address.Address) (*types.ActorV5, error) {
+// MinerApproveChangeBeneficiary mocks base method.
+func (m *MockFullNode) MinerApproveChangeBeneficiary(arg0 context.Context, arg1, arg2 address.Address) (*types0.MessagePrototype, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetParentStateRootActor", arg0, arg1, arg2)
-	ret0, _ := ret[0].(*types.ActorV5)
+	ret := m.ctrl.Call(m, "MinerApproveChangeBeneficiary", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types0.MessagePrototype)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetParentStateRootActor indicates an expected call of GetParentStateRootActor.
-func (mr *MockFullNodeMockRecorder) GetParentStateRootActor(arg0, arg1, arg2 interface{}) *gomock.Call {
+// MinerApproveChangeBeneficiary indicates an expected call of MinerApproveChangeBeneficiary.
+func (mr *MockFullNodeMockRecorder) MinerApproveChangeBeneficiary(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParentStateRootActor", reflect.TypeOf((*MockFullNode)(nil).GetParentStateRootActor), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MinerApproveChangeBeneficiary", reflect.TypeOf((*MockFullNode)(nil).MinerApproveChangeBeneficiary), arg0, arg1, arg2)
 }
 
-// HasPassword mocks base method.
-func (m *MockFullNode) HasPassword(arg0 context.Context) bool {
+// MinerChangeOwnerAddress mocks base method.
+func (m *MockFullNode) MinerChangeOwnerAddress(arg0 context.Context, arg1, arg2, arg3 address.Address) (*types0.MessagePrototype, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HasPassword", arg0)
-	ret0, _ := ret[0].(bool)
-	return ret0
+	ret := m.ctrl.Call(m, "MinerChangeOwnerAddress", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*types0.MessagePrototype)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// HasPassword indicates an expected call of HasPassword.
-func (mr *MockFullNodeMockRecorder) HasPassword(arg0 interface{}) *gomock.Call {
+// MinerChangeOwnerAddress indicates an expected call of MinerChangeOwnerAddress.
+func (mr *MockFullNodeMockRecorder) MinerChangeOwnerAddress(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPassword", reflect.TypeOf((*MockFullNode)(nil).HasPassword), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MinerChangeOwnerAddress", reflect.TypeOf((*MockFullNode)(nil).MinerChangeOwnerAddress), arg0, arg1, arg2, arg3)
 }
 
-// ID mocks base method.
-func (m *MockFullNode) ID(arg0 context.Context) (peer.ID, error) {
+// MinerChangeWorkerAddress mocks base method.
+func (m *MockFullNode) MinerChangeWorkerAddress(arg0 context.Context, arg1, arg2 address.Address, arg3 []address.Address) (*types0.MessagePrototype, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ID", arg0)
-	ret0, _ := ret[0].(peer.ID)
+	ret := m.ctrl.Call(m, "MinerChangeWorkerAddress", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*types0.MessagePrototype)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ID indicates an expected call of ID.
-func (mr *MockFullNodeMockRecorder) ID(arg0 interface{}) *gomock.Call {
+// MinerChangeWorkerAddress indicates an expected call of MinerChangeWorkerAddress.
+func (mr *MockFullNodeMockRecorder) MinerChangeWorkerAddress(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ID", reflect.TypeOf((*MockFullNode)(nil).ID), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MinerChangeWorkerAddress", reflect.TypeOf((*MockFullNode)(nil).MinerChangeWorkerAddress), arg0, arg1, arg2, arg3)
 }
 
-// ListActor mocks base method.
-func (m *MockFullNode) ListActor(arg0 context.Context) (map[address.Address]*types.ActorV5, error) {
+// MinerConfirmChangeWorker mocks base method.
+func (m *MockFullNode) MinerConfirmChangeWorker(arg0 context.Context, arg1 address.Address) (*types0.MessagePrototype, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListActor", arg0)
-	ret0, _ := ret[0].(map[address.Address]*types.ActorV5)
+	ret := m.ctrl.Call(m, "MinerConfirmChangeWorker", arg0, arg1)
+	ret0, _ := ret[0].(*types0.MessagePrototype)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListActor indicates an expected call of ListActor.
-func (mr *MockFullNodeMockRecorder) ListActor(arg0 interface{}) *gomock.Call {
+// MinerConfirmChangeWorker indicates an expected call of MinerConfirmChangeWorker.
+func (mr *MockFullNodeMockRecorder) MinerConfirmChangeWorker(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActor", reflect.TypeOf((*MockFullNode)(nil).ListActor), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MinerConfirmChangeWorker", reflect.TypeOf((*MockFullNode)(nil).MinerConfirmChangeWorker), arg0, arg1)
 }
 
-// LockWallet mocks base method.
-func (m *MockFullNode) LockWallet(arg0 context.Context) error {
+// MinerCreate mocks base method.
+func (m *MockFullNode) MinerCreate(arg0 context.Context, arg1 *types0.MinerCreateParams) (*types0.MinerCreateResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "LockWallet", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "MinerCreate", arg0, arg1)
+	ret0, _ := ret[0].(*types0.MinerCreateResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// LockWallet indicates an expected call of LockWallet.
-func (mr *MockFullNodeMockRecorder) LockWallet(arg0 interface{}) *gomock.Call {
+// MinerCreate indicates an expected call of MinerCreate.
+func (mr *MockFullNodeMockRecorder) MinerCreate(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockWallet", reflect.TypeOf((*MockFullNode)(nil).LockWallet), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MinerCreate", reflect.TypeOf((*MockFullNode)(nil).MinerCreate), arg0, arg1)
 }
 
 // MinerCreateBlock mocks base method.
@@ -1507,6 +2267,21 @@ func (mr *MockFullNodeMockRecorder) MinerGetBaseInfo(arg0, arg1, arg2, arg3 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MinerGetBaseInfo", reflect.TypeOf((*MockFullNode)(nil).MinerGetBaseInfo), arg0, arg1, arg2, arg3)
 }
 
+// MinerProposeChangeBeneficiary mocks base method.
+func (m *MockFullNode) MinerProposeChangeBeneficiary(arg0 context.Context, arg1, arg2 address.Address, arg3 big.Int, arg4 abi.ChainEpoch) (*types0.MessagePrototype, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MinerProposeChangeBeneficiary", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*types0.MessagePrototype)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MinerProposeChangeBeneficiary indicates an expected call of MinerProposeChangeBeneficiary.
+func (mr *MockFullNodeMockRecorder) MinerProposeChangeBeneficiary(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MinerProposeChangeBeneficiary", reflect.TypeOf((*MockFullNode)(nil).MinerProposeChangeBeneficiary), arg0, arg1, arg2, arg3, arg4)
+}
+
 // MpoolBatchPush mocks base method.
 func (m *MockFullNode) MpoolBatchPush(arg0 context.Context, arg1 []*types.SignedMessage) ([]cid.Cid, error) {
 	m.ctrl.T.Helper()
@@ -1552,6 +2327,20 @@ func (mr *MockFullNodeMockRecorder) MpoolBatchPushUntrusted(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolBatchPushUntrusted", reflect.TypeOf((*MockFullNode)(nil).MpoolBatchPushUntrusted), arg0, arg1)
 }
 
+// MpoolCancelScheduled mocks base method.
+func (m *MockFullNode) MpoolCancelScheduled(arg0 context.Context, arg1 cid.Cid) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MpoolCancelScheduled", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MpoolCancelScheduled indicates an expected call of MpoolCancelScheduled.
+func (mr *MockFullNodeMockRecorder) MpoolCancelScheduled(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolCancelScheduled", reflect.TypeOf((*MockFullNode)(nil).MpoolCancelScheduled), arg0, arg1)
+}
+
 // MpoolCheckMessages mocks base method.
 func (m *MockFullNode) MpoolCheckMessages(arg0 context.Context, arg1 []*types0.MessagePrototype) ([][]types0.MessageCheckStatus, error) {
 	m.ctrl.T.Helper()
@@ -1655,6 +2444,21 @@ func (mr *MockFullNodeMockRecorder) MpoolGetNonce(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolGetNonce", reflect.TypeOf((*MockFullNode)(nil).MpoolGetNonce), arg0, arg1)
 }
 
+// MpoolListScheduled mocks base method.
+func (m *MockFullNode) MpoolListScheduled(arg0 context.Context) ([]*types0.ScheduledMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MpoolListScheduled", arg0)
+	ret0, _ := ret[0].([]*types0.ScheduledMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MpoolListScheduled indicates an expected call of MpoolListScheduled.
+func (mr *MockFullNodeMockRecorder) MpoolListScheduled(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolListScheduled", reflect.TypeOf((*MockFullNode)(nil).MpoolListScheduled), arg0)
+}
+
 // MpoolPending mocks base method.
 func (m *MockFullNode) MpoolPending(arg0 context.Context, arg1 types0.TipSetKey) ([]*types.SignedMessage, error) {
 	m.ctrl.T.Helper()
@@ -1670,6 +2474,21 @@ func (mr *MockFullNodeMockRecorder) MpoolPending(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolPending", reflect.TypeOf((*MockFullNode)(nil).MpoolPending), arg0, arg1)
 }
 
+// MpoolPrepareMessages mocks base method.
+func (m *MockFullNode) MpoolPrepareMessages(arg0 context.Context, arg1 []*types.Message, arg2 *types0.MessageSendSpec) (*types0.MessageBundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MpoolPrepareMessages", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types0.MessageBundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MpoolPrepareMessages indicates an expected call of MpoolPrepareMessages.
+func (mr *MockFullNodeMockRecorder) MpoolPrepareMessages(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolPrepareMessages", reflect.TypeOf((*MockFullNode)(nil).MpoolPrepareMessages), arg0, arg1, arg2)
+}
+
 // MpoolPublishByAddr mocks base method.
 func (m *MockFullNode) MpoolPublishByAddr(arg0 context.Context, arg1 address.Address) error {
 	m.ctrl.T.Helper()
@@ -1728,6 +2547,21 @@ func (mr *MockFullNodeMockRecorder) MpoolPushMessage(arg0, arg1, arg2 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolPushMessage", reflect.TypeOf((*MockFullNode)(nil).MpoolPushMessage), arg0, arg1, arg2)
 }
 
+// MpoolPushSigned mocks base method.
+func (m *MockFullNode) MpoolPushSigned(arg0 context.Context, arg1 *types0.MessageBundle) ([]cid.Cid, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MpoolPushSigned", arg0, arg1)
+	ret0, _ := ret[0].([]cid.Cid)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MpoolPushSigned indicates an expected call of MpoolPushSigned.
+func (mr *MockFullNodeMockRecorder) MpoolPushSigned(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolPushSigned", reflect.TypeOf((*MockFullNode)(nil).MpoolPushSigned), arg0, arg1)
+}
+
 // MpoolPushUntrusted mocks base method.
 func (m *MockFullNode) MpoolPushUntrusted(arg0 context.Context, arg1 *types.SignedMessage) (cid.Cid, error) {
 	m.ctrl.T.Helper()
@@ -1743,6 +2577,21 @@ func (mr *MockFullNodeMockRecorder) MpoolPushUntrusted(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolPushUntrusted", reflect.TypeOf((*MockFullNode)(nil).MpoolPushUntrusted), arg0, arg1)
 }
 
+// MpoolScheduleMessage mocks base method.
+func (m *MockFullNode) MpoolScheduleMessage(arg0 context.Context, arg1 *types.SignedMessage, arg2 types0.ScheduleTrigger) (cid.Cid, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MpoolScheduleMessage", arg0, arg1, arg2)
+	ret0, _ := ret[0].(cid.Cid)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MpoolScheduleMessage indicates an expected call of MpoolScheduleMessage.
+func (mr *MockFullNodeMockRecorder) MpoolScheduleMessage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolScheduleMessage", reflect.TypeOf((*MockFullNode)(nil).MpoolScheduleMessage), arg0, arg1, arg2)
+}
+
 // MpoolSelect mocks base method.
 func (m *MockFullNode) MpoolSelect(arg0 context.Context, arg1 types0.TipSetKey, arg2 float64) ([]*types.SignedMessage, error) {
 	m.ctrl.T.Helper()
@@ -1758,6 +2607,21 @@ func (mr *MockFullNodeMockRecorder) MpoolSelect(arg0, arg1, arg2 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolSelect", reflect.TypeOf((*MockFullNode)(nil).MpoolSelect), arg0, arg1, arg2)
 }
 
+// MpoolSelectWithDetail mocks base method.
+func (m *MockFullNode) MpoolSelectWithDetail(arg0 context.Context, arg1 types0.TipSetKey, arg2 float64) (*types0.MpoolSelection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MpoolSelectWithDetail", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types0.MpoolSelection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MpoolSelectWithDetail indicates an expected call of MpoolSelectWithDetail.
+func (mr *MockFullNodeMockRecorder) MpoolSelectWithDetail(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolSelectWithDetail", reflect.TypeOf((*MockFullNode)(nil).MpoolSelectWithDetail), arg0, arg1, arg2)
+}
+
 // MpoolSelects mocks base method.
 func (m *MockFullNode) MpoolSelects(arg0 context.Context, arg1 types0.TipSetKey, arg2 []float64) ([][]*types.SignedMessage, error) {
 	m.ctrl.T.Helper()
@@ -1787,6 +2651,21 @@ func (mr *MockFullNodeMockRecorder) MpoolSetConfig(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolSetConfig", reflect.TypeOf((*MockFullNode)(nil).MpoolSetConfig), arg0, arg1)
 }
 
+// MpoolStats mocks base method.
+func (m *MockFullNode) MpoolStats(arg0 context.Context, arg1 []big.Int) (*types0.MpoolStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MpoolStats", arg0, arg1)
+	ret0, _ := ret[0].(*types0.MpoolStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MpoolStats indicates an expected call of MpoolStats.
+func (mr *MockFullNodeMockRecorder) MpoolStats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolStats", reflect.TypeOf((*MockFullNode)(nil).MpoolStats), arg0, arg1)
+}
+
 // MpoolSub mocks base method.
 func (m *MockFullNode) MpoolSub(arg0 context.Context) (<-chan types0.MpoolUpdate, error) {
 	m.ctrl.T.Helper()
@@ -2097,6 +2976,51 @@ func (mr *MockFullNodeMockRecorder) NetPubsubScores(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetPubsubScores", reflect.TypeOf((*MockFullNode)(nil).NetPubsubScores), arg0)
 }
 
+// NetQuarantineGet mocks base method.
+func (m *MockFullNode) NetQuarantineGet(arg0 context.Context, arg1 cid.Cid) (*types0.QuarantineEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetQuarantineGet", arg0, arg1)
+	ret0, _ := ret[0].(*types0.QuarantineEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NetQuarantineGet indicates an expected call of NetQuarantineGet.
+func (mr *MockFullNodeMockRecorder) NetQuarantineGet(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetQuarantineGet", reflect.TypeOf((*MockFullNode)(nil).NetQuarantineGet), arg0, arg1)
+}
+
+// NetQuarantineList mocks base method.
+func (m *MockFullNode) NetQuarantineList(arg0 context.Context, arg1 types0.QuarantineKind, arg2 int) ([]*types0.QuarantineEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetQuarantineList", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*types0.QuarantineEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NetQuarantineList indicates an expected call of NetQuarantineList.
+func (mr *MockFullNodeMockRecorder) NetQuarantineList(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetQuarantineList", reflect.TypeOf((*MockFullNode)(nil).NetQuarantineList), arg0, arg1, arg2)
+}
+
+// NetQuarantineStats mocks base method.
+func (m *MockFullNode) NetQuarantineStats(arg0 context.Context) ([]types0.QuarantineStat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetQuarantineStats", arg0)
+	ret0, _ := ret[0].([]types0.QuarantineStat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NetQuarantineStats indicates an expected call of NetQuarantineStats.
+func (mr *MockFullNodeMockRecorder) NetQuarantineStats(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetQuarantineStats", reflect.TypeOf((*MockFullNode)(nil).NetQuarantineStats), arg0)
+}
+
 // NetVersion mocks base method.
 func (m *MockFullNode) NetVersion(arg0 context.Context) (string, error) {
 	m.ctrl.T.Helper()
@@ -2112,6 +3036,36 @@ func (mr *MockFullNodeMockRecorder) NetVersion(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetVersion", reflect.TypeOf((*MockFullNode)(nil).NetVersion), arg0)
 }
 
+// NodeBackup mocks base method.
+func (m *MockFullNode) NodeBackup(arg0 context.Context, arg1 types0.NodeBackupOpts) (*types0.NodeBackupResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NodeBackup", arg0, arg1)
+	ret0, _ := ret[0].(*types0.NodeBackupResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NodeBackup indicates an expected call of NodeBackup.
+func (mr *MockFullNodeMockRecorder) NodeBackup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NodeBackup", reflect.TypeOf((*MockFullNode)(nil).NodeBackup), arg0, arg1)
+}
+
+// NodeHealth mocks base method.
+func (m *MockFullNode) NodeHealth(arg0 context.Context) (types0.NodeHealth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NodeHealth", arg0)
+	ret0, _ := ret[0].(types0.NodeHealth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NodeHealth indicates an expected call of NodeHealth.
+func (mr *MockFullNodeMockRecorder) NodeHealth(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NodeHealth", reflect.TypeOf((*MockFullNode)(nil).NodeHealth), arg0)
+}
+
 // NodeStatus mocks base method.
 func (m *MockFullNode) NodeStatus(arg0 context.Context, arg1 bool) (types0.NodeStatus, error) {
 	m.ctrl.T.Helper()
@@ -2425,6 +3379,21 @@ func (mr *MockFullNodeMockRecorder) SetConcurrent(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetConcurrent", reflect.TypeOf((*MockFullNode)(nil).SetConcurrent), arg0, arg1)
 }
 
+// SetConfig mocks base method.
+func (m *MockFullNode) SetConfig(arg0 context.Context, arg1, arg2 string) (*types0.ConfigReload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetConfig", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types0.ConfigReload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetConfig indicates an expected call of SetConfig.
+func (mr *MockFullNodeMockRecorder) SetConfig(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetConfig", reflect.TypeOf((*MockFullNode)(nil).SetConfig), arg0, arg1, arg2)
+}
+
 // SetPassword mocks base method.
 func (m *MockFullNode) SetPassword(arg0 context.Context, arg1 []byte) error {
 	m.ctrl.T.Helper()
@@ -2448,55 +3417,115 @@ func (m *MockFullNode) StartTime(arg0 context.Context) (time.Time, error) {
 	return ret0, ret1
 }
 
-// StartTime indicates an expected call of StartTime.
-func (mr *MockFullNodeMockRecorder) StartTime(arg0 interface{}) *gomock.Call {
+// StartTime indicates an expected call of StartTime.
+func (mr *MockFullNodeMockRecorder) StartTime(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartTime", reflect.TypeOf((*MockFullNode)(nil).StartTime), arg0)
+}
+
+// StateAccountKey mocks base method.
+func (m *MockFullNode) StateAccountKey(arg0 context.Context, arg1 address.Address, arg2 types0.TipSetKey) (address.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateAccountKey", arg0, arg1, arg2)
+	ret0, _ := ret[0].(address.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateAccountKey indicates an expected call of StateAccountKey.
+func (mr *MockFullNodeMockRecorder) StateAccountKey(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateAccountKey", reflect.TypeOf((*MockFullNode)(nil).StateAccountKey), arg0, arg1, arg2)
+}
+
+// StateActorCodeCIDs mocks base method.
+func (m *MockFullNode) StateActorCodeCIDs(arg0 context.Context, arg1 network.Version) (map[string]cid.Cid, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateActorCodeCIDs", arg0, arg1)
+	ret0, _ := ret[0].(map[string]cid.Cid)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateActorCodeCIDs indicates an expected call of StateActorCodeCIDs.
+func (mr *MockFullNodeMockRecorder) StateActorCodeCIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateActorCodeCIDs", reflect.TypeOf((*MockFullNode)(nil).StateActorCodeCIDs), arg0, arg1)
+}
+
+// StateActorHeadHistory mocks base method.
+func (m *MockFullNode) StateActorHeadHistory(arg0 context.Context, arg1 address.Address, arg2, arg3 abi.ChainEpoch, arg4 types0.TipSetKey) ([]types0.ActorHeadChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateActorHeadHistory", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]types0.ActorHeadChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateActorHeadHistory indicates an expected call of StateActorHeadHistory.
+func (mr *MockFullNodeMockRecorder) StateActorHeadHistory(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateActorHeadHistory", reflect.TypeOf((*MockFullNode)(nil).StateActorHeadHistory), arg0, arg1, arg2, arg3, arg4)
+}
+
+// StateActorManifestCID mocks base method.
+func (m *MockFullNode) StateActorManifestCID(arg0 context.Context, arg1 network.Version) (cid.Cid, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateActorManifestCID", arg0, arg1)
+	ret0, _ := ret[0].(cid.Cid)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateActorManifestCID indicates an expected call of StateActorManifestCID.
+func (mr *MockFullNodeMockRecorder) StateActorManifestCID(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartTime", reflect.TypeOf((*MockFullNode)(nil).StartTime), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateActorManifestCID", reflect.TypeOf((*MockFullNode)(nil).StateActorManifestCID), arg0, arg1)
 }
 
-// StateAccountKey mocks base method.
-func (m *MockFullNode) StateAccountKey(arg0 context.Context, arg1 address.Address, arg2 types0.TipSetKey) (address.Address, error) {
+// StateActorMethods mocks base method.
+func (m *MockFullNode) StateActorMethods(arg0 context.Context, arg1 cid.Cid) ([]types0.ActorMethod, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StateAccountKey", arg0, arg1, arg2)
-	ret0, _ := ret[0].(address.Address)
+	ret := m.ctrl.Call(m, "StateActorMethods", arg0, arg1)
+	ret0, _ := ret[0].([]types0.ActorMethod)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// StateAccountKey indicates an expected call of StateAccountKey.
-func (mr *MockFullNodeMockRecorder) StateAccountKey(arg0, arg1, arg2 interface{}) *gomock.Call {
+// StateActorMethods indicates an expected call of StateActorMethods.
+func (mr *MockFullNodeMockRecorder) StateActorMethods(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateAccountKey", reflect.TypeOf((*MockFullNode)(nil).StateAccountKey), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateActorMethods", reflect.TypeOf((*MockFullNode)(nil).StateActorMethods), arg0, arg1)
 }
 
-// StateActorCodeCIDs mocks base method.
-func (m *MockFullNode) StateActorCodeCIDs(arg0 context.Context, arg1 network.Version) (map[string]cid.Cid, error) {
+// StateActorStats mocks base method.
+func (m *MockFullNode) StateActorStats(arg0 context.Context, arg1 []address.Address, arg2, arg3 types0.TipSetKey) ([]types0.ActorStat, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StateActorCodeCIDs", arg0, arg1)
-	ret0, _ := ret[0].(map[string]cid.Cid)
+	ret := m.ctrl.Call(m, "StateActorStats", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]types0.ActorStat)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// StateActorCodeCIDs indicates an expected call of StateActorCodeCIDs.
-func (mr *MockFullNodeMockRecorder) StateActorCodeCIDs(arg0, arg1 interface{}) *gomock.Call {
+// StateActorStats indicates an expected call of StateActorStats.
+func (mr *MockFullNodeMockRecorder) StateActorStats(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateActorCodeCIDs", reflect.TypeOf((*MockFullNode)(nil).StateActorCodeCIDs), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateActorStats", reflect.TypeOf((*MockFullNode)(nil).StateActorStats), arg0, arg1, arg2, arg3)
 }
 
-// StateActorManifestCID mocks base method.
-func (m *MockFullNode) StateActorManifestCID(arg0 context.Context, arg1 network.Version) (cid.Cid, error) {
+// StateAddressActivitySubscribe mocks base method.
+func (m *MockFullNode) StateAddressActivitySubscribe(arg0 context.Context, arg1 []address.Address) (<-chan *types0.AddressActivity, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StateActorManifestCID", arg0, arg1)
-	ret0, _ := ret[0].(cid.Cid)
+	ret := m.ctrl.Call(m, "StateAddressActivitySubscribe", arg0, arg1)
+	ret0, _ := ret[0].(<-chan *types0.AddressActivity)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// StateActorManifestCID indicates an expected call of StateActorManifestCID.
-func (mr *MockFullNodeMockRecorder) StateActorManifestCID(arg0, arg1 interface{}) *gomock.Call {
+// StateAddressActivitySubscribe indicates an expected call of StateAddressActivitySubscribe.
+func (mr *MockFullNodeMockRecorder) StateAddressActivitySubscribe(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateActorManifestCID", reflect.TypeOf((*MockFullNode)(nil).StateActorManifestCID), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateAddressActivitySubscribe", reflect.TypeOf((*MockFullNode)(nil).StateAddressActivitySubscribe), arg0, arg1)
 }
 
 // StateAllMinerFaults mocks base method.
@@ -2589,6 +3618,21 @@ func (mr *MockFullNodeMockRecorder) StateComputeDataCID(arg0, arg1, arg2, arg3,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateComputeDataCID", reflect.TypeOf((*MockFullNode)(nil).StateComputeDataCID), arg0, arg1, arg2, arg3, arg4)
 }
 
+// StateConsensusFaultReport mocks base method.
+func (m *MockFullNode) StateConsensusFaultReport(arg0 context.Context, arg1 address.Address, arg2, arg3, arg4 *types0.BlockHeader, arg5 types0.TipSetKey) (*types0.ConsensusFaultReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateConsensusFaultReport", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(*types0.ConsensusFaultReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateConsensusFaultReport indicates an expected call of StateConsensusFaultReport.
+func (mr *MockFullNodeMockRecorder) StateConsensusFaultReport(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateConsensusFaultReport", reflect.TypeOf((*MockFullNode)(nil).StateConsensusFaultReport), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // StateDealProviderCollateralBounds mocks base method.
 func (m *MockFullNode) StateDealProviderCollateralBounds(arg0 context.Context, arg1 abi.PaddedPieceSize, arg2 bool, arg3 types0.TipSetKey) (types0.DealCollateralBounds, error) {
 	m.ctrl.T.Helper()
@@ -2619,8 +3663,23 @@ func (mr *MockFullNodeMockRecorder) StateDecodeParams(arg0, arg1, arg2, arg3, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateDecodeParams", reflect.TypeOf((*MockFullNode)(nil).StateDecodeParams), arg0, arg1, arg2, arg3, arg4)
 }
 
+// StateDecodeReturn mocks base method.
+func (m *MockFullNode) StateDecodeReturn(arg0 context.Context, arg1 address.Address, arg2 abi.MethodNum, arg3, arg4 []byte, arg5 types0.TipSetKey) (interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateDecodeReturn", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateDecodeReturn indicates an expected call of StateDecodeReturn.
+func (mr *MockFullNodeMockRecorder) StateDecodeReturn(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateDecodeReturn", reflect.TypeOf((*MockFullNode)(nil).StateDecodeReturn), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // StateEncodeParams mocks base method.
-func (m *MockFullNode) StateEncodeParams(arg0 context.Context, arg1 cid.Cid, arg2 abi.MethodNum, arg3 json.RawMessage) ([]byte, error) {
+func (m *MockFullNode) StateEncodeParams(arg0 context.Context, arg1 cid.Cid, arg2 abi.MethodNum, arg3 jsontext.Value) ([]byte, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "StateEncodeParams", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].([]byte)
@@ -2634,6 +3693,21 @@ func (mr *MockFullNodeMockRecorder) StateEncodeParams(arg0, arg1, arg2, arg3 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateEncodeParams", reflect.TypeOf((*MockFullNode)(nil).StateEncodeParams), arg0, arg1, arg2, arg3)
 }
 
+// StateFindSectorForDeal mocks base method.
+func (m *MockFullNode) StateFindSectorForDeal(arg0 context.Context, arg1 address.Address, arg2 abi.DealID, arg3 types0.TipSetKey) (*types0.DealSector, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateFindSectorForDeal", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*types0.DealSector)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateFindSectorForDeal indicates an expected call of StateFindSectorForDeal.
+func (mr *MockFullNodeMockRecorder) StateFindSectorForDeal(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateFindSectorForDeal", reflect.TypeOf((*MockFullNode)(nil).StateFindSectorForDeal), arg0, arg1, arg2, arg3)
+}
+
 // StateGetActor mocks base method.
 func (m *MockFullNode) StateGetActor(arg0 context.Context, arg1 address.Address, arg2 types0.TipSetKey) (*types.ActorV5, error) {
 	m.ctrl.T.Helper()
@@ -2649,6 +3723,21 @@ func (mr *MockFullNodeMockRecorder) StateGetActor(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateGetActor", reflect.TypeOf((*MockFullNode)(nil).StateGetActor), arg0, arg1, arg2)
 }
 
+// StateGetActors mocks base method.
+func (m *MockFullNode) StateGetActors(arg0 context.Context, arg1 []address.Address, arg2 types0.TipSetKey) ([]types0.ActorLookup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateGetActors", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]types0.ActorLookup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateGetActors indicates an expected call of StateGetActors.
+func (mr *MockFullNodeMockRecorder) StateGetActors(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateGetActors", reflect.TypeOf((*MockFullNode)(nil).StateGetActors), arg0, arg1, arg2)
+}
+
 // StateGetAllAllocations mocks base method.
 func (m *MockFullNode) StateGetAllAllocations(arg0 context.Context, arg1 types0.TipSetKey) (map[verifreg.AllocationId]verifreg.Allocation, error) {
 	m.ctrl.T.Helper()
@@ -2784,6 +3873,21 @@ func (mr *MockFullNodeMockRecorder) StateGetClaims(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateGetClaims", reflect.TypeOf((*MockFullNode)(nil).StateGetClaims), arg0, arg1, arg2)
 }
 
+// StateGetDisputableWindowedPoSts mocks base method.
+func (m *MockFullNode) StateGetDisputableWindowedPoSts(arg0 context.Context, arg1 address.Address, arg2 types0.TipSetKey) ([]types0.DisputableDeadline, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateGetDisputableWindowedPoSts", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]types0.DisputableDeadline)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateGetDisputableWindowedPoSts indicates an expected call of StateGetDisputableWindowedPoSts.
+func (mr *MockFullNodeMockRecorder) StateGetDisputableWindowedPoSts(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateGetDisputableWindowedPoSts", reflect.TypeOf((*MockFullNode)(nil).StateGetDisputableWindowedPoSts), arg0, arg1, arg2)
+}
+
 // StateGetNetworkParams mocks base method.
 func (m *MockFullNode) StateGetNetworkParams(arg0 context.Context) (*types0.NetworkParams, error) {
 	m.ctrl.T.Helper()
@@ -2874,6 +3978,21 @@ func (mr *MockFullNodeMockRecorder) StateListActors(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateListActors", reflect.TypeOf((*MockFullNode)(nil).StateListActors), arg0, arg1)
 }
 
+// StateListActorsPage mocks base method.
+func (m *MockFullNode) StateListActorsPage(arg0 context.Context, arg1 types0.ActorFilter, arg2 address.Address, arg3 int, arg4 types0.TipSetKey) (*types0.ActorsPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateListActorsPage", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*types0.ActorsPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateListActorsPage indicates an expected call of StateListActorsPage.
+func (mr *MockFullNodeMockRecorder) StateListActorsPage(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateListActorsPage", reflect.TypeOf((*MockFullNode)(nil).StateListActorsPage), arg0, arg1, arg2, arg3, arg4)
+}
+
 // StateListMessages mocks base method.
 func (m *MockFullNode) StateListMessages(arg0 context.Context, arg1 *types0.MessageMatch, arg2 types0.TipSetKey, arg3 abi.ChainEpoch) ([]cid.Cid, error) {
 	m.ctrl.T.Helper()
@@ -2979,6 +4098,21 @@ func (mr *MockFullNodeMockRecorder) StateMarketParticipants(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateMarketParticipants", reflect.TypeOf((*MockFullNode)(nil).StateMarketParticipants), arg0, arg1)
 }
 
+// StateMarketParticipantsPage mocks base method.
+func (m *MockFullNode) StateMarketParticipantsPage(arg0 context.Context, arg1 address.Address, arg2 int, arg3 types0.TipSetKey) (*types0.MarketParticipantsPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateMarketParticipantsPage", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*types0.MarketParticipantsPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateMarketParticipantsPage indicates an expected call of StateMarketParticipantsPage.
+func (mr *MockFullNodeMockRecorder) StateMarketParticipantsPage(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateMarketParticipantsPage", reflect.TypeOf((*MockFullNode)(nil).StateMarketParticipantsPage), arg0, arg1, arg2, arg3)
+}
+
 // StateMarketProposalPending mocks base method.
 func (m *MockFullNode) StateMarketProposalPending(arg0 context.Context, arg1 cid.Cid, arg2 types0.TipSetKey) (bool, error) {
 	m.ctrl.T.Helper()
@@ -3009,6 +4143,21 @@ func (mr *MockFullNodeMockRecorder) StateMarketStorageDeal(arg0, arg1, arg2 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateMarketStorageDeal", reflect.TypeOf((*MockFullNode)(nil).StateMarketStorageDeal), arg0, arg1, arg2)
 }
 
+// StateMigrationStatus mocks base method.
+func (m *MockFullNode) StateMigrationStatus(arg0 context.Context) ([]types0.MigrationStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateMigrationStatus", arg0)
+	ret0, _ := ret[0].([]types0.MigrationStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateMigrationStatus indicates an expected call of StateMigrationStatus.
+func (mr *MockFullNodeMockRecorder) StateMigrationStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateMigrationStatus", reflect.TypeOf((*MockFullNode)(nil).StateMigrationStatus), arg0)
+}
+
 // StateMinerActiveSectors mocks base method.
 func (m *MockFullNode) StateMinerActiveSectors(arg0 context.Context, arg1 address.Address, arg2 types0.TipSetKey) ([]*miner.SectorOnChainInfo, error) {
 	m.ctrl.T.Helper()
@@ -3054,6 +4203,21 @@ func (mr *MockFullNodeMockRecorder) StateMinerAvailableBalance(arg0, arg1, arg2
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateMinerAvailableBalance", reflect.TypeOf((*MockFullNode)(nil).StateMinerAvailableBalance), arg0, arg1, arg2)
 }
 
+// StateMinerCompactionPlan mocks base method.
+func (m *MockFullNode) StateMinerCompactionPlan(arg0 context.Context, arg1 address.Address, arg2 float64, arg3 types0.TipSetKey) ([]types0.CompactionPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateMinerCompactionPlan", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]types0.CompactionPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateMinerCompactionPlan indicates an expected call of StateMinerCompactionPlan.
+func (mr *MockFullNodeMockRecorder) StateMinerCompactionPlan(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateMinerCompactionPlan", reflect.TypeOf((*MockFullNode)(nil).StateMinerCompactionPlan), arg0, arg1, arg2, arg3)
+}
+
 // StateMinerDeadlines mocks base method.
 func (m *MockFullNode) StateMinerDeadlines(arg0 context.Context, arg1 address.Address, arg2 types0.TipSetKey) ([]types0.Deadline, error) {
 	m.ctrl.T.Helper()
@@ -3069,6 +4233,21 @@ func (mr *MockFullNodeMockRecorder) StateMinerDeadlines(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateMinerDeadlines", reflect.TypeOf((*MockFullNode)(nil).StateMinerDeadlines), arg0, arg1, arg2)
 }
 
+// StateMinerDigestSubscribe mocks base method.
+func (m *MockFullNode) StateMinerDigestSubscribe(arg0 context.Context, arg1 []address.Address, arg2 abi.ChainEpoch) (<-chan *types0.MinerDeadlineDigest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateMinerDigestSubscribe", arg0, arg1, arg2)
+	ret0, _ := ret[0].(<-chan *types0.MinerDeadlineDigest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateMinerDigestSubscribe indicates an expected call of StateMinerDigestSubscribe.
+func (mr *MockFullNodeMockRecorder) StateMinerDigestSubscribe(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateMinerDigestSubscribe", reflect.TypeOf((*MockFullNode)(nil).StateMinerDigestSubscribe), arg0, arg1, arg2)
+}
+
 // StateMinerFaults mocks base method.
 func (m *MockFullNode) StateMinerFaults(arg0 context.Context, arg1 address.Address, arg2 types0.TipSetKey) (bitfield.BitField, error) {
 	m.ctrl.T.Helper()
@@ -3144,6 +4323,21 @@ func (mr *MockFullNodeMockRecorder) StateMinerPartitions(arg0, arg1, arg2, arg3
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateMinerPartitions", reflect.TypeOf((*MockFullNode)(nil).StateMinerPartitions), arg0, arg1, arg2, arg3)
 }
 
+// StateMinerPendingBeneficiaryChange mocks base method.
+func (m *MockFullNode) StateMinerPendingBeneficiaryChange(arg0 context.Context, arg1 address.Address, arg2 types0.TipSetKey) (*types0.PendingBeneficiaryChangeStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateMinerPendingBeneficiaryChange", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types0.PendingBeneficiaryChangeStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateMinerPendingBeneficiaryChange indicates an expected call of StateMinerPendingBeneficiaryChange.
+func (mr *MockFullNodeMockRecorder) StateMinerPendingBeneficiaryChange(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateMinerPendingBeneficiaryChange", reflect.TypeOf((*MockFullNode)(nil).StateMinerPendingBeneficiaryChange), arg0, arg1, arg2)
+}
+
 // StateMinerPower mocks base method.
 func (m *MockFullNode) StateMinerPower(arg0 context.Context, arg1 address.Address, arg2 types0.TipSetKey) (*types0.MinerPower, error) {
 	m.ctrl.T.Helper()
@@ -3174,6 +4368,21 @@ func (mr *MockFullNodeMockRecorder) StateMinerPreCommitDepositForPower(arg0, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateMinerPreCommitDepositForPower", reflect.TypeOf((*MockFullNode)(nil).StateMinerPreCommitDepositForPower), arg0, arg1, arg2, arg3)
 }
 
+// StateMinerPreCommitDepositForPowerBatch mocks base method.
+func (m *MockFullNode) StateMinerPreCommitDepositForPowerBatch(arg0 context.Context, arg1 address.Address, arg2 []miner0.SectorPreCommitInfo, arg3 types0.TipSetKey) ([]types0.PreCommitDepositBreakdown, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateMinerPreCommitDepositForPowerBatch", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]types0.PreCommitDepositBreakdown)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateMinerPreCommitDepositForPowerBatch indicates an expected call of StateMinerPreCommitDepositForPowerBatch.
+func (mr *MockFullNodeMockRecorder) StateMinerPreCommitDepositForPowerBatch(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateMinerPreCommitDepositForPowerBatch", reflect.TypeOf((*MockFullNode)(nil).StateMinerPreCommitDepositForPowerBatch), arg0, arg1, arg2, arg3)
+}
+
 // StateMinerProvingDeadline mocks base method.
 func (m *MockFullNode) StateMinerProvingDeadline(arg0 context.Context, arg1 address.Address, arg2 types0.TipSetKey) (*dline.Info, error) {
 	m.ctrl.T.Helper()
@@ -3324,6 +4533,20 @@ func (mr *MockFullNodeMockRecorder) StateReadState(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateReadState", reflect.TypeOf((*MockFullNode)(nil).StateReadState), arg0, arg1, arg2)
 }
 
+// StateRegisterContractABI mocks base method.
+func (m *MockFullNode) StateRegisterContractABI(arg0 context.Context, arg1 address.Address, arg2 jsontext.Value) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateRegisterContractABI", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StateRegisterContractABI indicates an expected call of StateRegisterContractABI.
+func (mr *MockFullNodeMockRecorder) StateRegisterContractABI(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateRegisterContractABI", reflect.TypeOf((*MockFullNode)(nil).StateRegisterContractABI), arg0, arg1, arg2)
+}
+
 // StateReplay mocks base method.
 func (m *MockFullNode) StateReplay(arg0 context.Context, arg1 types0.TipSetKey, arg2 cid.Cid) (*types0.InvocResult, error) {
 	m.ctrl.T.Helper()
@@ -3354,6 +4577,21 @@ func (mr *MockFullNodeMockRecorder) StateSearchMsg(arg0, arg1, arg2, arg3, arg4
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateSearchMsg", reflect.TypeOf((*MockFullNode)(nil).StateSearchMsg), arg0, arg1, arg2, arg3, arg4)
 }
 
+// StateSearchMsgWithReplacement mocks base method.
+func (m *MockFullNode) StateSearchMsgWithReplacement(arg0 context.Context, arg1 types0.TipSetKey, arg2 cid.Cid, arg3 abi.ChainEpoch) (*types0.MsgReplacementLookup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateSearchMsgWithReplacement", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*types0.MsgReplacementLookup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateSearchMsgWithReplacement indicates an expected call of StateSearchMsgWithReplacement.
+func (mr *MockFullNodeMockRecorder) StateSearchMsgWithReplacement(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateSearchMsgWithReplacement", reflect.TypeOf((*MockFullNode)(nil).StateSearchMsgWithReplacement), arg0, arg1, arg2, arg3)
+}
+
 // StateSectorExpiration mocks base method.
 func (m *MockFullNode) StateSectorExpiration(arg0 context.Context, arg1 address.Address, arg2 abi.SectorNumber, arg3 types0.TipSetKey) (*miner1.SectorExpiration, error) {
 	m.ctrl.T.Helper()
@@ -3399,6 +4637,21 @@ func (mr *MockFullNodeMockRecorder) StateSectorPartition(arg0, arg1, arg2, arg3
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateSectorPartition", reflect.TypeOf((*MockFullNode)(nil).StateSectorPartition), arg0, arg1, arg2, arg3)
 }
 
+// StateSectorPenaltyForFaults mocks base method.
+func (m *MockFullNode) StateSectorPenaltyForFaults(arg0 context.Context, arg1 address.Address, arg2 bitfield.BitField, arg3 types0.TipSetKey) (*types0.SectorFaultPenalty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateSectorPenaltyForFaults", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*types0.SectorFaultPenalty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateSectorPenaltyForFaults indicates an expected call of StateSectorPenaltyForFaults.
+func (mr *MockFullNodeMockRecorder) StateSectorPenaltyForFaults(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateSectorPenaltyForFaults", reflect.TypeOf((*MockFullNode)(nil).StateSectorPenaltyForFaults), arg0, arg1, arg2, arg3)
+}
+
 // StateSectorPreCommitInfo mocks base method.
 func (m *MockFullNode) StateSectorPreCommitInfo(arg0 context.Context, arg1 address.Address, arg2 abi.SectorNumber, arg3 types0.TipSetKey) (*miner0.SectorPreCommitOnChainInfo, error) {
 	m.ctrl.T.Helper()
@@ -3414,6 +4667,36 @@ func (mr *MockFullNodeMockRecorder) StateSectorPreCommitInfo(arg0, arg1, arg2, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateSectorPreCommitInfo", reflect.TypeOf((*MockFullNode)(nil).StateSectorPreCommitInfo), arg0, arg1, arg2, arg3)
 }
 
+// StateSizeReport mocks base method.
+func (m *MockFullNode) StateSizeReport(arg0 context.Context, arg1 int, arg2 types0.TipSetKey) (*types0.StateSizeReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateSizeReport", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types0.StateSizeReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateSizeReport indicates an expected call of StateSizeReport.
+func (mr *MockFullNodeMockRecorder) StateSizeReport(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateSizeReport", reflect.TypeOf((*MockFullNode)(nil).StateSizeReport), arg0, arg1, arg2)
+}
+
+// StateUpgradeSchedule mocks base method.
+func (m *MockFullNode) StateUpgradeSchedule(arg0 context.Context) ([]types0.UpgradeHeight, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateUpgradeSchedule", arg0)
+	ret0, _ := ret[0].([]types0.UpgradeHeight)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateUpgradeSchedule indicates an expected call of StateUpgradeSchedule.
+func (mr *MockFullNodeMockRecorder) StateUpgradeSchedule(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateUpgradeSchedule", reflect.TypeOf((*MockFullNode)(nil).StateUpgradeSchedule), arg0)
+}
+
 // StateVMCirculatingSupplyInternal mocks base method.
 func (m *MockFullNode) StateVMCirculatingSupplyInternal(arg0 context.Context, arg1 types0.TipSetKey) (types0.CirculatingSupply, error) {
 	m.ctrl.T.Helper()
@@ -3504,6 +4787,21 @@ func (mr *MockFullNodeMockRecorder) SubscribeActorEventsRaw(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeActorEventsRaw", reflect.TypeOf((*MockFullNode)(nil).SubscribeActorEventsRaw), arg0, arg1)
 }
 
+// SubscribeDealEvents mocks base method.
+func (m *MockFullNode) SubscribeDealEvents(arg0 context.Context, arg1 *types0.DealEventFilter) (<-chan *types0.DealEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeDealEvents", arg0, arg1)
+	ret0, _ := ret[0].(<-chan *types0.DealEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeDealEvents indicates an expected call of SubscribeDealEvents.
+func (mr *MockFullNodeMockRecorder) SubscribeDealEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeDealEvents", reflect.TypeOf((*MockFullNode)(nil).SubscribeDealEvents), arg0, arg1)
+}
+
 // SyncCheckpoint mocks base method.
 func (m *MockFullNode) SyncCheckpoint(arg0 context.Context, arg1 types0.TipSetKey) error {
 	m.ctrl.T.Helper()
@@ -3562,6 +4860,36 @@ func (mr *MockFullNodeMockRecorder) SyncSubmitBlock(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncSubmitBlock", reflect.TypeOf((*MockFullNode)(nil).SyncSubmitBlock), arg0, arg1)
 }
 
+// SyncSubmitBlockChecked mocks base method.
+func (m *MockFullNode) SyncSubmitBlockChecked(arg0 context.Context, arg1 *types0.BlockMsg, arg2 bool) (*types0.BlockHeaderValidation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncSubmitBlockChecked", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types0.BlockHeaderValidation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncSubmitBlockChecked indicates an expected call of SyncSubmitBlockChecked.
+func (mr *MockFullNodeMockRecorder) SyncSubmitBlockChecked(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncSubmitBlockChecked", reflect.TypeOf((*MockFullNode)(nil).SyncSubmitBlockChecked), arg0, arg1, arg2)
+}
+
+// SyncValidateBlockHeader mocks base method.
+func (m *MockFullNode) SyncValidateBlockHeader(arg0 context.Context, arg1 *types0.BlockHeader) (*types0.BlockHeaderValidation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncValidateBlockHeader", arg0, arg1)
+	ret0, _ := ret[0].(*types0.BlockHeaderValidation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncValidateBlockHeader indicates an expected call of SyncValidateBlockHeader.
+func (mr *MockFullNodeMockRecorder) SyncValidateBlockHeader(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncValidateBlockHeader", reflect.TypeOf((*MockFullNode)(nil).SyncValidateBlockHeader), arg0, arg1)
+}
+
 // SyncerTracker mocks base method.
 func (m *MockFullNode) SyncerTracker(arg0 context.Context) *types0.TargetTracker {
 	m.ctrl.T.Helper()
@@ -3633,6 +4961,21 @@ func (mr *MockFullNodeMockRecorder) WalletAddresses(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletAddresses", reflect.TypeOf((*MockFullNode)(nil).WalletAddresses), arg0)
 }
 
+// WalletAuditLog mocks base method.
+func (m *MockFullNode) WalletAuditLog(arg0 context.Context, arg1 types0.SignAuditFilter, arg2 uint64, arg3 int) (*types0.SignAuditPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalletAuditLog", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*types0.SignAuditPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WalletAuditLog indicates an expected call of WalletAuditLog.
+func (mr *MockFullNodeMockRecorder) WalletAuditLog(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletAuditLog", reflect.TypeOf((*MockFullNode)(nil).WalletAuditLog), arg0, arg1, arg2, arg3)
+}
+
 // WalletBalance mocks base method.
 func (m *MockFullNode) WalletBalance(arg0 context.Context, arg1 address.Address) (big.Int, error) {
 	m.ctrl.T.Helper()
@@ -3722,6 +5065,35 @@ func (mr *MockFullNodeMockRecorder) WalletImport(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletImport", reflect.TypeOf((*MockFullNode)(nil).WalletImport), arg0, arg1)
 }
 
+// WalletLock mocks base method.
+func (m *MockFullNode) WalletLock(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalletLock", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WalletLock indicates an expected call of WalletLock.
+func (mr *MockFullNodeMockRecorder) WalletLock(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletLock", reflect.TypeOf((*MockFullNode)(nil).WalletLock), arg0)
+}
+
+// WalletNew mocks base method.
+func (m *MockFullNode) WalletNew(arg0 context.Context, arg1 types0.KeyType) (address.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalletNew", arg0, arg1)
+	ret0, _ := ret[0].(address.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WalletNew indicates an expected call of WalletNew.
+func (mr *MockFullNodeMockRecorder) WalletNew(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletNew", reflect.TypeOf((*MockFullNode)(nil).WalletNew), arg0, arg1)
+}
+
 // WalletNewAddress mocks base method.
 func (m *MockFullNode) WalletNewAddress(arg0 context.Context, arg1 byte) (address.Address, error) {
 	m.ctrl.T.Helper()
@@ -3737,6 +5109,21 @@ func (mr *MockFullNodeMockRecorder) WalletNewAddress(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletNewAddress", reflect.TypeOf((*MockFullNode)(nil).WalletNewAddress), arg0, arg1)
 }
 
+// WalletNewDelegatedFromSecp mocks base method.
+func (m *MockFullNode) WalletNewDelegatedFromSecp(arg0 context.Context, arg1 address.Address) (address.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalletNewDelegatedFromSecp", arg0, arg1)
+	ret0, _ := ret[0].(address.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WalletNewDelegatedFromSecp indicates an expected call of WalletNewDelegatedFromSecp.
+func (mr *MockFullNodeMockRecorder) WalletNewDelegatedFromSecp(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletNewDelegatedFromSecp", reflect.TypeOf((*MockFullNode)(nil).WalletNewDelegatedFromSecp), arg0, arg1)
+}
+
 // WalletSetDefault mocks base method.
 func (m *MockFullNode) WalletSetDefault(arg0 context.Context, arg1 address.Address) error {
 	m.ctrl.T.Helper()
@@ -3751,6 +5138,20 @@ func (mr *MockFullNodeMockRecorder) WalletSetDefault(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletSetDefault", reflect.TypeOf((*MockFullNode)(nil).WalletSetDefault), arg0, arg1)
 }
 
+// WalletSetPassword mocks base method.
+func (m *MockFullNode) WalletSetPassword(arg0 context.Context, arg1 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalletSetPassword", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WalletSetPassword indicates an expected call of WalletSetPassword.
+func (mr *MockFullNodeMockRecorder) WalletSetPassword(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletSetPassword", reflect.TypeOf((*MockFullNode)(nil).WalletSetPassword), arg0, arg1)
+}
+
 // WalletSign mocks base method.
 func (m *MockFullNode) WalletSign(arg0 context.Context, arg1 address.Address, arg2 []byte, arg3 types0.MsgMeta) (*crypto.Signature, error) {
 	m.ctrl.T.Helper()
@@ -3766,6 +5167,36 @@ func (mr *MockFullNodeMockRecorder) WalletSign(arg0, arg1, arg2, arg3 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletSign", reflect.TypeOf((*MockFullNode)(nil).WalletSign), arg0, arg1, arg2, arg3)
 }
 
+// WalletSignBatch mocks base method.
+func (m *MockFullNode) WalletSignBatch(arg0 context.Context, arg1 []types0.WalletSignRequest) ([]types0.WalletSignResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalletSignBatch", arg0, arg1)
+	ret0, _ := ret[0].([]types0.WalletSignResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WalletSignBatch indicates an expected call of WalletSignBatch.
+func (mr *MockFullNodeMockRecorder) WalletSignBatch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletSignBatch", reflect.TypeOf((*MockFullNode)(nil).WalletSignBatch), arg0, arg1)
+}
+
+// WalletSignEthTransaction mocks base method.
+func (m *MockFullNode) WalletSignEthTransaction(arg0 context.Context, arg1 types.EthAddress, arg2 *types.Eth1559TxArgs) (types.EthBytes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalletSignEthTransaction", arg0, arg1, arg2)
+	ret0, _ := ret[0].(types.EthBytes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WalletSignEthTransaction indicates an expected call of WalletSignEthTransaction.
+func (mr *MockFullNodeMockRecorder) WalletSignEthTransaction(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletSignEthTransaction", reflect.TypeOf((*MockFullNode)(nil).WalletSignEthTransaction), arg0, arg1, arg2)
+}
+
 // WalletSignMessage mocks base method.
 func (m *MockFullNode) WalletSignMessage(arg0 context.Context, arg1 address.Address, arg2 *types.Message) (*types.SignedMessage, error) {
 	m.ctrl.T.Helper()
@@ -3795,6 +5226,20 @@ func (mr *MockFullNodeMockRecorder) WalletState(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletState", reflect.TypeOf((*MockFullNode)(nil).WalletState), arg0)
 }
 
+// WalletUnlock mocks base method.
+func (m *MockFullNode) WalletUnlock(arg0 context.Context, arg1 []byte, arg2 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalletUnlock", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WalletUnlock indicates an expected call of WalletUnlock.
+func (mr *MockFullNodeMockRecorder) WalletUnlock(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletUnlock", reflect.TypeOf((*MockFullNode)(nil).WalletUnlock), arg0, arg1, arg2)
+}
+
 // Web3ClientVersion mocks base method.
 func (m *MockFullNode) Web3ClientVersion(arg0 context.Context) (string, error) {
 	m.ctrl.T.Helper()
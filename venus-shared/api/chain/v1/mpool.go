@@ -39,4 +39,23 @@ type IMessagePool interface {
 	MpoolCheckPendingMessages(ctx context.Context, addr address.Address) ([][]types.MessageCheckStatus, error) //perm:read
 	// MpoolCheckReplaceMessages performs logical checks on pending messages with replacement
 	MpoolCheckReplaceMessages(ctx context.Context, msg []*types.Message) ([][]types.MessageCheckStatus, error) //perm:read
+	// MpoolSelectWithDetail simulates the message selection for the next block like MpoolSelect, and reports for each selected
+	// message the gas reward and performance it was ranked by, and the message of the same sender it depends on
+	MpoolSelectWithDetail(ctx context.Context, tsk types.TipSetKey, ticketQuality float64) (*types.MpoolSelection, error) //perm:read
+	// MpoolPrepareMessages sets the nonces and estimates the gas of msgs, and returns them in a bundle with the bytes
+	// to sign for each of them, so they can be signed on an air-gapped machine
+	MpoolPrepareMessages(ctx context.Context, msgs []*types.Message, spec *types.MessageSendSpec) (*types.MessageBundle, error) //perm:read
+	// MpoolPushSigned pushes the messages of a bundle prepared by MpoolPrepareMessages and signed offline, after checking
+	// the messages were not changed, their signatures, and that their nonces and the balances still match the chain
+	MpoolPushSigned(ctx context.Context, bundle *types.MessageBundle) ([]cid.Cid, error) //perm:write
+	// MpoolScheduleMessage holds smsg until all the conditions of trigger are met then pushes it, the scheduled
+	// messages are kept across restarts and identified by the cid of the signed message
+	MpoolScheduleMessage(ctx context.Context, smsg *types.SignedMessage, trigger types.ScheduleTrigger) (cid.Cid, error) //perm:write
+	// MpoolListScheduled returns the messages waiting for their trigger, and the ones that failed to be pushed
+	MpoolListScheduled(ctx context.Context) ([]*types.ScheduledMessage, error) //perm:read
+	// MpoolCancelScheduled drops a scheduled message before it is pushed
+	MpoolCancelScheduled(ctx context.Context, c cid.Cid) error //perm:write
+	// MpoolStats returns the pending messages by sender, the percentiles of the premiums they pay and the epochs
+	// before the inclusion of a message paying each of premiums, or the premium percentiles when premiums is empty
+	MpoolStats(ctx context.Context, premiums []big.Int) (*types.MpoolStats, error) //perm:read
 }
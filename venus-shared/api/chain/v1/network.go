@@ -44,4 +44,12 @@ type INetwork interface {
 	NetProtectAdd(ctx context.Context, acl []peer.ID) error    //perm:admin
 	NetProtectRemove(ctx context.Context, acl []peer.ID) error //perm:admin
 	NetProtectList(ctx context.Context) ([]peer.ID, error)     //perm:read
+
+	// NetQuarantineList returns up to limit blocks or messages rejected by the pubsub validators,
+	// newest first, an empty kind lists both
+	NetQuarantineList(ctx context.Context, kind types.QuarantineKind, limit int) ([]*types.QuarantineEntry, error) //perm:read
+	// NetQuarantineGet returns the rejection of the block or message c
+	NetQuarantineGet(ctx context.Context, c cid.Cid) (*types.QuarantineEntry, error) //perm:read
+	// NetQuarantineStats returns the number of pubsub rejections by kind and reason since the node started
+	NetQuarantineStats(ctx context.Context) ([]types.QuarantineStat, error) //perm:read
 }
@@ -67,71 +67,122 @@ func (s *IAccountStruct) StateAccountKey(p0 context.Context, p1 address.Address,
 
 type IActorStruct struct {
 	Internal struct {
-		ListActor     func(ctx context.Context) (map[address.Address]*types.Actor, error)                         `perm:"read"`
-		StateGetActor func(ctx context.Context, actor address.Address, tsk types.TipSetKey) (*types.Actor, error) `perm:"read"`
+		ListActor             func(ctx context.Context) (map[address.Address]*types.Actor, error)                                                            `perm:"read"`
+		StateActorHeadHistory func(ctx context.Context, addr address.Address, from, to abi.ChainEpoch, tsk types.TipSetKey) ([]types.ActorHeadChange, error) `perm:"read"`
+		StateActorStats       func(ctx context.Context, addrs []address.Address, base types.TipSetKey, tsk types.TipSetKey) ([]types.ActorStat, error)       `perm:"read"`
+		StateGetActor         func(ctx context.Context, actor address.Address, tsk types.TipSetKey) (*types.Actor, error)                                    `perm:"read"`
+		StateGetActors        func(ctx context.Context, addrs []address.Address, tsk types.TipSetKey) ([]types.ActorLookup, error)                           `perm:"read"`
+		StateSizeReport       func(ctx context.Context, top int, tsk types.TipSetKey) (*types.StateSizeReport, error)                                        `perm:"admin"`
 	}
 }
 
 func (s *IActorStruct) ListActor(p0 context.Context) (map[address.Address]*types.Actor, error) {
 	return s.Internal.ListActor(p0)
 }
+func (s *IActorStruct) StateActorHeadHistory(p0 context.Context, p1 address.Address, p2, p3 abi.ChainEpoch, p4 types.TipSetKey) ([]types.ActorHeadChange, error) {
+	return s.Internal.StateActorHeadHistory(p0, p1, p2, p3, p4)
+}
+func (s *IActorStruct) StateActorStats(p0 context.Context, p1 []address.Address, p2 types.TipSetKey, p3 types.TipSetKey) ([]types.ActorStat, error) {
+	return s.Internal.StateActorStats(p0, p1, p2, p3)
+}
 func (s *IActorStruct) StateGetActor(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (*types.Actor, error) {
 	return s.Internal.StateGetActor(p0, p1, p2)
 }
+func (s *IActorStruct) StateGetActors(p0 context.Context, p1 []address.Address, p2 types.TipSetKey) ([]types.ActorLookup, error) {
+	return s.Internal.StateGetActors(p0, p1, p2)
+}
+func (s *IActorStruct) StateSizeReport(p0 context.Context, p1 int, p2 types.TipSetKey) (*types.StateSizeReport, error) {
+	return s.Internal.StateSizeReport(p0, p1, p2)
+}
 
 type IMinerStateStruct struct {
 	Internal struct {
-		StateAllMinerFaults                func(ctx context.Context, lookback abi.ChainEpoch, ts types.TipSetKey) ([]*types.Fault, error)                                                      `perm:"read"`
-		StateChangedActors                 func(context.Context, cid.Cid, cid.Cid) (map[string]types.Actor, error)                                                                             `perm:"read"`
-		StateCirculatingSupply             func(ctx context.Context, tsk types.TipSetKey) (abi.TokenAmount, error)                                                                             `perm:"read"`
-		StateComputeDataCID                func(ctx context.Context, maddr address.Address, sectorType abi.RegisteredSealProof, deals []abi.DealID, tsk types.TipSetKey) (cid.Cid, error)      `perm:"read"`
-		StateDealProviderCollateralBounds  func(ctx context.Context, size abi.PaddedPieceSize, verified bool, tsk types.TipSetKey) (types.DealCollateralBounds, error)                         `perm:"read"`
-		StateDecodeParams                  func(ctx context.Context, toAddr address.Address, method abi.MethodNum, params []byte, tsk types.TipSetKey) (interface{}, error)                    `perm:"read"`
-		StateEncodeParams                  func(ctx context.Context, toActCode cid.Cid, method abi.MethodNum, params json.RawMessage) ([]byte, error)                                          `perm:"read"`
-		StateGetAllAllocations             func(ctx context.Context, tsk types.TipSetKey) (map[verifreg.AllocationId]verifreg.Allocation, error)                                               `perm:"read"`
-		StateGetAllClaims                  func(ctx context.Context, tsk types.TipSetKey) (map[verifreg.ClaimId]verifreg.Claim, error)                                                         `perm:"read"`
-		StateGetAllocation                 func(ctx context.Context, clientAddr address.Address, allocationID verifreg.AllocationId, tsk types.TipSetKey) (*verifreg.Allocation, error)        `perm:"read"`
-		StateGetAllocationForPendingDeal   func(ctx context.Context, dealID abi.DealID, tsk types.TipSetKey) (*verifreg.Allocation, error)                                                     `perm:"read"`
-		StateGetAllocationIdForPendingDeal func(ctx context.Context, dealID abi.DealID, tsk types.TipSetKey) (verifreg.AllocationId, error)                                                    `perm:"read"`
-		StateGetAllocations                func(ctx context.Context, clientAddr address.Address, tsk types.TipSetKey) (map[verifreg.AllocationId]verifreg.Allocation, error)                   `perm:"read"`
-		StateGetClaim                      func(ctx context.Context, providerAddr address.Address, claimID verifreg.ClaimId, tsk types.TipSetKey) (*verifreg.Claim, error)                     `perm:"read"`
-		StateGetClaims                     func(ctx context.Context, providerAddr address.Address, tsk types.TipSetKey) (map[verifreg.ClaimId]verifreg.Claim, error)                           `perm:"read"`
-		StateListActors                    func(ctx context.Context, tsk types.TipSetKey) ([]address.Address, error)                                                                           `perm:"read"`
-		StateListMessages                  func(ctx context.Context, match *types.MessageMatch, tsk types.TipSetKey, toht abi.ChainEpoch) ([]cid.Cid, error)                                   `perm:"read"`
-		StateListMiners                    func(ctx context.Context, tsk types.TipSetKey) ([]address.Address, error)                                                                           `perm:"read"`
-		StateLookupID                      func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (address.Address, error)                                                       `perm:"read"`
-		StateLookupRobustAddress           func(context.Context, address.Address, types.TipSetKey) (address.Address, error)                                                                    `perm:"read"`
-		StateMarketBalance                 func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (types.MarketBalance, error)                                                   `perm:"read"`
-		StateMarketDeals                   func(ctx context.Context, tsk types.TipSetKey) (map[string]*types.MarketDeal, error)                                                                `perm:"read"`
-		StateMarketStorageDeal             func(ctx context.Context, dealID abi.DealID, tsk types.TipSetKey) (*types.MarketDeal, error)                                                        `perm:"read"`
-		StateMinerActiveSectors            func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) ([]*lminer.SectorOnChainInfo, error)                                          `perm:"read"`
-		StateMinerAllocated                func(context.Context, address.Address, types.TipSetKey) (*bitfield.BitField, error)                                                                 `perm:"read"`
-		StateMinerAvailableBalance         func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (big.Int, error)                                                              `perm:"read"`
-		StateMinerDeadlines                func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) ([]types.Deadline, error)                                                     `perm:"read"`
-		StateMinerFaults                   func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (bitfield.BitField, error)                                                    `perm:"read"`
-		StateMinerInfo                     func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (types.MinerInfo, error)                                                      `perm:"read"`
-		StateMinerInitialPledgeCollateral  func(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (big.Int, error)                               `perm:"read"`
-		StateMinerInitialPledgeForSector   func(ctx context.Context, sectorDuration abi.ChainEpoch, sectorSize abi.SectorSize, verifiedSize uint64, tsk types.TipSetKey) (types.BigInt, error) `perm:"read"`
-		StateMinerPartitions               func(ctx context.Context, maddr address.Address, dlIdx uint64, tsk types.TipSetKey) ([]types.Partition, error)                                      `perm:"read"`
-		StateMinerPower                    func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (*types.MinerPower, error)                                                     `perm:"read"`
-		StateMinerPreCommitDepositForPower func(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (big.Int, error)                               `perm:"read"`
-		StateMinerProvingDeadline          func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (*dline.Info, error)                                                          `perm:"read"`
-		StateMinerRecoveries               func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (bitfield.BitField, error)                                                    `perm:"read"`
-		StateMinerSectorAllocated          func(ctx context.Context, maddr address.Address, s abi.SectorNumber, tsk types.TipSetKey) (bool, error)                                             `perm:"read"`
-		StateMinerSectorCount              func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (types.MinerSectors, error)                                                    `perm:"read"`
-		StateMinerSectorSize               func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (abi.SectorSize, error)                                                       `perm:"read"`
-		StateMinerSectors                  func(ctx context.Context, maddr address.Address, sectorNos *bitfield.BitField, tsk types.TipSetKey) ([]*lminer.SectorOnChainInfo, error)            `perm:"read"`
-		StateMinerWorkerAddress            func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (address.Address, error)                                                      `perm:"read"`
-		StateReadState                     func(ctx context.Context, actor address.Address, tsk types.TipSetKey) (*types.ActorState, error)                                                    `perm:"read"`
-		StateSectorExpiration              func(ctx context.Context, maddr address.Address, sectorNumber abi.SectorNumber, tsk types.TipSetKey) (*lminer.SectorExpiration, error)              `perm:"read"`
-		StateSectorGetInfo                 func(ctx context.Context, maddr address.Address, n abi.SectorNumber, tsk types.TipSetKey) (*lminer.SectorOnChainInfo, error)                        `perm:"read"`
-		StateSectorPartition               func(ctx context.Context, maddr address.Address, sectorNumber abi.SectorNumber, tsk types.TipSetKey) (*lminer.SectorLocation, error)                `perm:"read"`
-		StateSectorPreCommitInfo           func(ctx context.Context, maddr address.Address, n abi.SectorNumber, tsk types.TipSetKey) (*types.SectorPreCommitOnChainInfo, error)                `perm:"read"`
-		StateVMCirculatingSupplyInternal   func(ctx context.Context, tsk types.TipSetKey) (types.CirculatingSupply, error)                                                                     `perm:"read"`
-		StateVerifiedClientStatus          func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (*abi.StoragePower, error)                                                     `perm:"read"`
+		MinerApproveChangeBeneficiary           func(ctx context.Context, maddr address.Address, approver address.Address) (*types.MessagePrototype, error)                                                         `perm:"read"`
+		MinerChangeOwnerAddress                 func(ctx context.Context, maddr address.Address, sender address.Address, newOwner address.Address) (*types.MessagePrototype, error)                                 `perm:"read"`
+		MinerChangeWorkerAddress                func(ctx context.Context, maddr address.Address, newWorker address.Address, controlAddrs []address.Address) (*types.MessagePrototype, error)                        `perm:"read"`
+		MinerConfirmChangeWorker                func(ctx context.Context, maddr address.Address) (*types.MessagePrototype, error)                                                                                   `perm:"read"`
+		MinerProposeChangeBeneficiary           func(ctx context.Context, maddr address.Address, newBeneficiary address.Address, quota abi.TokenAmount, expiration abi.ChainEpoch) (*types.MessagePrototype, error) `perm:"read"`
+		StateAddressActivitySubscribe           func(ctx context.Context, addrs []address.Address) (<-chan *types.AddressActivity, error)                                                                           `perm:"read"`
+		StateAllMinerFaults                     func(ctx context.Context, lookback abi.ChainEpoch, ts types.TipSetKey) ([]*types.Fault, error)                                                                      `perm:"read"`
+		StateChangedActors                      func(context.Context, cid.Cid, cid.Cid) (map[string]types.Actor, error)                                                                                             `perm:"read"`
+		StateCirculatingSupply                  func(ctx context.Context, tsk types.TipSetKey) (abi.TokenAmount, error)                                                                                             `perm:"read"`
+		StateComputeDataCID                     func(ctx context.Context, maddr address.Address, sectorType abi.RegisteredSealProof, deals []abi.DealID, tsk types.TipSetKey) (cid.Cid, error)                      `perm:"read"`
+		StateConsensusFaultReport               func(ctx context.Context, from address.Address, h1, h2, extra *types.BlockHeader, tsk types.TipSetKey) (*types.ConsensusFaultReport, error)                         `perm:"read"`
+		StateDealProviderCollateralBounds       func(ctx context.Context, size abi.PaddedPieceSize, verified bool, tsk types.TipSetKey) (types.DealCollateralBounds, error)                                         `perm:"read"`
+		StateDecodeParams                       func(ctx context.Context, toAddr address.Address, method abi.MethodNum, params []byte, tsk types.TipSetKey) (interface{}, error)                                    `perm:"read"`
+		StateDecodeReturn                       func(ctx context.Context, toAddr address.Address, method abi.MethodNum, params []byte, ret []byte, tsk types.TipSetKey) (interface{}, error)                        `perm:"read"`
+		StateEncodeParams                       func(ctx context.Context, toActCode cid.Cid, method abi.MethodNum, params json.RawMessage) ([]byte, error)                                                          `perm:"read"`
+		StateFindSectorForDeal                  func(ctx context.Context, maddr address.Address, dealID abi.DealID, tsk types.TipSetKey) (*types.DealSector, error)                                                 `perm:"read"`
+		StateGetAllAllocations                  func(ctx context.Context, tsk types.TipSetKey) (map[verifreg.AllocationId]verifreg.Allocation, error)                                                               `perm:"read"`
+		StateGetAllClaims                       func(ctx context.Context, tsk types.TipSetKey) (map[verifreg.ClaimId]verifreg.Claim, error)                                                                         `perm:"read"`
+		StateGetAllocation                      func(ctx context.Context, clientAddr address.Address, allocationID verifreg.AllocationId, tsk types.TipSetKey) (*verifreg.Allocation, error)                        `perm:"read"`
+		StateGetAllocationForPendingDeal        func(ctx context.Context, dealID abi.DealID, tsk types.TipSetKey) (*verifreg.Allocation, error)                                                                     `perm:"read"`
+		StateGetAllocationIdForPendingDeal      func(ctx context.Context, dealID abi.DealID, tsk types.TipSetKey) (verifreg.AllocationId, error)                                                                    `perm:"read"`
+		StateGetAllocations                     func(ctx context.Context, clientAddr address.Address, tsk types.TipSetKey) (map[verifreg.AllocationId]verifreg.Allocation, error)                                   `perm:"read"`
+		StateGetClaim                           func(ctx context.Context, providerAddr address.Address, claimID verifreg.ClaimId, tsk types.TipSetKey) (*verifreg.Claim, error)                                     `perm:"read"`
+		StateGetClaims                          func(ctx context.Context, providerAddr address.Address, tsk types.TipSetKey) (map[verifreg.ClaimId]verifreg.Claim, error)                                           `perm:"read"`
+		StateGetDisputableWindowedPoSts         func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) ([]types.DisputableDeadline, error)                                                           `perm:"read"`
+		StateListActors                         func(ctx context.Context, tsk types.TipSetKey) ([]address.Address, error)                                                                                           `perm:"read"`
+		StateListActorsPage                     func(ctx context.Context, filter types.ActorFilter, cursor address.Address, limit int, tsk types.TipSetKey) (*types.ActorsPage, error)                              `perm:"read"`
+		StateListMessages                       func(ctx context.Context, match *types.MessageMatch, tsk types.TipSetKey, toht abi.ChainEpoch) ([]cid.Cid, error)                                                   `perm:"read"`
+		StateListMiners                         func(ctx context.Context, tsk types.TipSetKey) ([]address.Address, error)                                                                                           `perm:"read"`
+		StateLookupID                           func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (address.Address, error)                                                                       `perm:"read"`
+		StateLookupRobustAddress                func(context.Context, address.Address, types.TipSetKey) (address.Address, error)                                                                                    `perm:"read"`
+		StateMarketBalance                      func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (types.MarketBalance, error)                                                                   `perm:"read"`
+		StateMarketDeals                        func(ctx context.Context, tsk types.TipSetKey) (map[string]*types.MarketDeal, error)                                                                                `perm:"read"`
+		StateMarketStorageDeal                  func(ctx context.Context, dealID abi.DealID, tsk types.TipSetKey) (*types.MarketDeal, error)                                                                        `perm:"read"`
+		StateMinerActiveSectors                 func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) ([]*lminer.SectorOnChainInfo, error)                                                          `perm:"read"`
+		StateMinerAllocated                     func(context.Context, address.Address, types.TipSetKey) (*bitfield.BitField, error)                                                                                 `perm:"read"`
+		StateMinerAvailableBalance              func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (big.Int, error)                                                                              `perm:"read"`
+		StateMinerCompactionPlan                func(ctx context.Context, maddr address.Address, minDeadRatio float64, tsk types.TipSetKey) ([]types.CompactionPlan, error)                                         `perm:"read"`
+		StateMinerDeadlines                     func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) ([]types.Deadline, error)                                                                     `perm:"read"`
+		StateMinerDigestSubscribe               func(ctx context.Context, miners []address.Address, expiringWithin abi.ChainEpoch) (<-chan *types.MinerDeadlineDigest, error)                                       `perm:"read"`
+		StateMinerFaults                        func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (bitfield.BitField, error)                                                                    `perm:"read"`
+		StateMinerInfo                          func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (types.MinerInfo, error)                                                                      `perm:"read"`
+		StateMinerInitialPledgeCollateral       func(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (big.Int, error)                                               `perm:"read"`
+		StateMinerInitialPledgeForSector        func(ctx context.Context, sectorDuration abi.ChainEpoch, sectorSize abi.SectorSize, verifiedSize uint64, tsk types.TipSetKey) (types.BigInt, error)                 `perm:"read"`
+		StateMinerPartitions                    func(ctx context.Context, maddr address.Address, dlIdx uint64, tsk types.TipSetKey) ([]types.Partition, error)                                                      `perm:"read"`
+		StateMinerPendingBeneficiaryChange      func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (*types.PendingBeneficiaryChangeStatus, error)                                                `perm:"read"`
+		StateMinerPower                         func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (*types.MinerPower, error)                                                                     `perm:"read"`
+		StateMinerPreCommitDepositForPower      func(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (big.Int, error)                                               `perm:"read"`
+		StateMinerPreCommitDepositForPowerBatch func(ctx context.Context, maddr address.Address, pcis []types.SectorPreCommitInfo, tsk types.TipSetKey) ([]types.PreCommitDepositBreakdown, error)                  `perm:"read"`
+		StateMinerProvingDeadline               func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (*dline.Info, error)                                                                          `perm:"read"`
+		StateMinerRecoveries                    func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (bitfield.BitField, error)                                                                    `perm:"read"`
+		StateMinerSectorAllocated               func(ctx context.Context, maddr address.Address, s abi.SectorNumber, tsk types.TipSetKey) (bool, error)                                                             `perm:"read"`
+		StateMinerSectorCount                   func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (types.MinerSectors, error)                                                                    `perm:"read"`
+		StateMinerSectorSize                    func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (abi.SectorSize, error)                                                                       `perm:"read"`
+		StateMinerSectors                       func(ctx context.Context, maddr address.Address, sectorNos *bitfield.BitField, tsk types.TipSetKey) ([]*lminer.SectorOnChainInfo, error)                            `perm:"read"`
+		StateMinerWorkerAddress                 func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (address.Address, error)                                                                      `perm:"read"`
+		StateReadState                          func(ctx context.Context, actor address.Address, tsk types.TipSetKey) (*types.ActorState, error)                                                                    `perm:"read"`
+		StateRegisterContractABI                func(ctx context.Context, contract address.Address, contractABI json.RawMessage) error                                                                              `perm:"write"`
+		StateSectorExpiration                   func(ctx context.Context, maddr address.Address, sectorNumber abi.SectorNumber, tsk types.TipSetKey) (*lminer.SectorExpiration, error)                              `perm:"read"`
+		StateSectorGetInfo                      func(ctx context.Context, maddr address.Address, n abi.SectorNumber, tsk types.TipSetKey) (*lminer.SectorOnChainInfo, error)                                        `perm:"read"`
+		StateSectorPartition                    func(ctx context.Context, maddr address.Address, sectorNumber abi.SectorNumber, tsk types.TipSetKey) (*lminer.SectorLocation, error)                                `perm:"read"`
+		StateSectorPenaltyForFaults             func(ctx context.Context, maddr address.Address, sectors bitfield.BitField, tsk types.TipSetKey) (*types.SectorFaultPenalty, error)                                 `perm:"read"`
+		StateSectorPreCommitInfo                func(ctx context.Context, maddr address.Address, n abi.SectorNumber, tsk types.TipSetKey) (*types.SectorPreCommitOnChainInfo, error)                                `perm:"read"`
+		StateVMCirculatingSupplyInternal        func(ctx context.Context, tsk types.TipSetKey) (types.CirculatingSupply, error)                                                                                     `perm:"read"`
+		StateVerifiedClientStatus               func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (*abi.StoragePower, error)                                                                     `perm:"read"`
 	}
 }
 
+func (s *IMinerStateStruct) MinerApproveChangeBeneficiary(p0 context.Context, p1 address.Address, p2 address.Address) (*types.MessagePrototype, error) {
+	return s.Internal.MinerApproveChangeBeneficiary(p0, p1, p2)
+}
+func (s *IMinerStateStruct) MinerChangeOwnerAddress(p0 context.Context, p1 address.Address, p2 address.Address, p3 address.Address) (*types.MessagePrototype, error) {
+	return s.Internal.MinerChangeOwnerAddress(p0, p1, p2, p3)
+}
+func (s *IMinerStateStruct) MinerChangeWorkerAddress(p0 context.Context, p1 address.Address, p2 address.Address, p3 []address.Address) (*types.MessagePrototype, error) {
+	return s.Internal.MinerChangeWorkerAddress(p0, p1, p2, p3)
+}
+func (s *IMinerStateStruct) MinerConfirmChangeWorker(p0 context.Context, p1 address.Address) (*types.MessagePrototype, error) {
+	return s.Internal.MinerConfirmChangeWorker(p0, p1)
+}
+func (s *IMinerStateStruct) MinerProposeChangeBeneficiary(p0 context.Context, p1 address.Address, p2 address.Address, p3 abi.TokenAmount, p4 abi.ChainEpoch) (*types.MessagePrototype, error) {
+	return s.Internal.MinerProposeChangeBeneficiary(p0, p1, p2, p3, p4)
+}
+func (s *IMinerStateStruct) StateAddressActivitySubscribe(p0 context.Context, p1 []address.Address) (<-chan *types.AddressActivity, error) {
+	return s.Internal.StateAddressActivitySubscribe(p0, p1)
+}
 func (s *IMinerStateStruct) StateAllMinerFaults(p0 context.Context, p1 abi.ChainEpoch, p2 types.TipSetKey) ([]*types.Fault, error) {
 	return s.Internal.StateAllMinerFaults(p0, p1, p2)
 }
@@ -144,15 +195,24 @@ func (s *IMinerStateStruct) StateCirculatingSupply(p0 context.Context, p1 types.
 func (s *IMinerStateStruct) StateComputeDataCID(p0 context.Context, p1 address.Address, p2 abi.RegisteredSealProof, p3 []abi.DealID, p4 types.TipSetKey) (cid.Cid, error) {
 	return s.Internal.StateComputeDataCID(p0, p1, p2, p3, p4)
 }
+func (s *IMinerStateStruct) StateConsensusFaultReport(p0 context.Context, p1 address.Address, p2, p3, p4 *types.BlockHeader, p5 types.TipSetKey) (*types.ConsensusFaultReport, error) {
+	return s.Internal.StateConsensusFaultReport(p0, p1, p2, p3, p4, p5)
+}
 func (s *IMinerStateStruct) StateDealProviderCollateralBounds(p0 context.Context, p1 abi.PaddedPieceSize, p2 bool, p3 types.TipSetKey) (types.DealCollateralBounds, error) {
 	return s.Internal.StateDealProviderCollateralBounds(p0, p1, p2, p3)
 }
 func (s *IMinerStateStruct) StateDecodeParams(p0 context.Context, p1 address.Address, p2 abi.MethodNum, p3 []byte, p4 types.TipSetKey) (interface{}, error) {
 	return s.Internal.StateDecodeParams(p0, p1, p2, p3, p4)
 }
+func (s *IMinerStateStruct) StateDecodeReturn(p0 context.Context, p1 address.Address, p2 abi.MethodNum, p3 []byte, p4 []byte, p5 types.TipSetKey) (interface{}, error) {
+	return s.Internal.StateDecodeReturn(p0, p1, p2, p3, p4, p5)
+}
 func (s *IMinerStateStruct) StateEncodeParams(p0 context.Context, p1 cid.Cid, p2 abi.MethodNum, p3 json.RawMessage) ([]byte, error) {
 	return s.Internal.StateEncodeParams(p0, p1, p2, p3)
 }
+func (s *IMinerStateStruct) StateFindSectorForDeal(p0 context.Context, p1 address.Address, p2 abi.DealID, p3 types.TipSetKey) (*types.DealSector, error) {
+	return s.Internal.StateFindSectorForDeal(p0, p1, p2, p3)
+}
 func (s *IMinerStateStruct) StateGetAllAllocations(p0 context.Context, p1 types.TipSetKey) (map[verifreg.AllocationId]verifreg.Allocation, error) {
 	return s.Internal.StateGetAllAllocations(p0, p1)
 }
@@ -177,9 +237,15 @@ func (s *IMinerStateStruct) StateGetClaim(p0 context.Context, p1 address.Address
 func (s *IMinerStateStruct) StateGetClaims(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (map[verifreg.ClaimId]verifreg.Claim, error) {
 	return s.Internal.StateGetClaims(p0, p1, p2)
 }
+func (s *IMinerStateStruct) StateGetDisputableWindowedPoSts(p0 context.Context, p1 address.Address, p2 types.TipSetKey) ([]types.DisputableDeadline, error) {
+	return s.Internal.StateGetDisputableWindowedPoSts(p0, p1, p2)
+}
 func (s *IMinerStateStruct) StateListActors(p0 context.Context, p1 types.TipSetKey) ([]address.Address, error) {
 	return s.Internal.StateListActors(p0, p1)
 }
+func (s *IMinerStateStruct) StateListActorsPage(p0 context.Context, p1 types.ActorFilter, p2 address.Address, p3 int, p4 types.TipSetKey) (*types.ActorsPage, error) {
+	return s.Internal.StateListActorsPage(p0, p1, p2, p3, p4)
+}
 func (s *IMinerStateStruct) StateListMessages(p0 context.Context, p1 *types.MessageMatch, p2 types.TipSetKey, p3 abi.ChainEpoch) ([]cid.Cid, error) {
 	return s.Internal.StateListMessages(p0, p1, p2, p3)
 }
@@ -210,9 +276,15 @@ func (s *IMinerStateStruct) StateMinerAllocated(p0 context.Context, p1 address.A
 func (s *IMinerStateStruct) StateMinerAvailableBalance(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (big.Int, error) {
 	return s.Internal.StateMinerAvailableBalance(p0, p1, p2)
 }
+func (s *IMinerStateStruct) StateMinerCompactionPlan(p0 context.Context, p1 address.Address, p2 float64, p3 types.TipSetKey) ([]types.CompactionPlan, error) {
+	return s.Internal.StateMinerCompactionPlan(p0, p1, p2, p3)
+}
 func (s *IMinerStateStruct) StateMinerDeadlines(p0 context.Context, p1 address.Address, p2 types.TipSetKey) ([]types.Deadline, error) {
 	return s.Internal.StateMinerDeadlines(p0, p1, p2)
 }
+func (s *IMinerStateStruct) StateMinerDigestSubscribe(p0 context.Context, p1 []address.Address, p2 abi.ChainEpoch) (<-chan *types.MinerDeadlineDigest, error) {
+	return s.Internal.StateMinerDigestSubscribe(p0, p1, p2)
+}
 func (s *IMinerStateStruct) StateMinerFaults(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (bitfield.BitField, error) {
 	return s.Internal.StateMinerFaults(p0, p1, p2)
 }
@@ -228,12 +300,18 @@ func (s *IMinerStateStruct) StateMinerInitialPledgeForSector(p0 context.Context,
 func (s *IMinerStateStruct) StateMinerPartitions(p0 context.Context, p1 address.Address, p2 uint64, p3 types.TipSetKey) ([]types.Partition, error) {
 	return s.Internal.StateMinerPartitions(p0, p1, p2, p3)
 }
+func (s *IMinerStateStruct) StateMinerPendingBeneficiaryChange(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (*types.PendingBeneficiaryChangeStatus, error) {
+	return s.Internal.StateMinerPendingBeneficiaryChange(p0, p1, p2)
+}
 func (s *IMinerStateStruct) StateMinerPower(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (*types.MinerPower, error) {
 	return s.Internal.StateMinerPower(p0, p1, p2)
 }
 func (s *IMinerStateStruct) StateMinerPreCommitDepositForPower(p0 context.Context, p1 address.Address, p2 types.SectorPreCommitInfo, p3 types.TipSetKey) (big.Int, error) {
 	return s.Internal.StateMinerPreCommitDepositForPower(p0, p1, p2, p3)
 }
+func (s *IMinerStateStruct) StateMinerPreCommitDepositForPowerBatch(p0 context.Context, p1 address.Address, p2 []types.SectorPreCommitInfo, p3 types.TipSetKey) ([]types.PreCommitDepositBreakdown, error) {
+	return s.Internal.StateMinerPreCommitDepositForPowerBatch(p0, p1, p2, p3)
+}
 func (s *IMinerStateStruct) StateMinerProvingDeadline(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (*dline.Info, error) {
 	return s.Internal.StateMinerProvingDeadline(p0, p1, p2)
 }
@@ -258,6 +336,9 @@ func (s *IMinerStateStruct) StateMinerWorkerAddress(p0 context.Context, p1 addre
 func (s *IMinerStateStruct) StateReadState(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (*types.ActorState, error) {
 	return s.Internal.StateReadState(p0, p1, p2)
 }
+func (s *IMinerStateStruct) StateRegisterContractABI(p0 context.Context, p1 address.Address, p2 json.RawMessage) error {
+	return s.Internal.StateRegisterContractABI(p0, p1, p2)
+}
 func (s *IMinerStateStruct) StateSectorExpiration(p0 context.Context, p1 address.Address, p2 abi.SectorNumber, p3 types.TipSetKey) (*lminer.SectorExpiration, error) {
 	return s.Internal.StateSectorExpiration(p0, p1, p2, p3)
 }
@@ -267,6 +348,9 @@ func (s *IMinerStateStruct) StateSectorGetInfo(p0 context.Context, p1 address.Ad
 func (s *IMinerStateStruct) StateSectorPartition(p0 context.Context, p1 address.Address, p2 abi.SectorNumber, p3 types.TipSetKey) (*lminer.SectorLocation, error) {
 	return s.Internal.StateSectorPartition(p0, p1, p2, p3)
 }
+func (s *IMinerStateStruct) StateSectorPenaltyForFaults(p0 context.Context, p1 address.Address, p2 bitfield.BitField, p3 types.TipSetKey) (*types.SectorFaultPenalty, error) {
+	return s.Internal.StateSectorPenaltyForFaults(p0, p1, p2, p3)
+}
 func (s *IMinerStateStruct) StateSectorPreCommitInfo(p0 context.Context, p1 address.Address, p2 abi.SectorNumber, p3 types.TipSetKey) (*types.SectorPreCommitOnChainInfo, error) {
 	return s.Internal.StateSectorPreCommitInfo(p0, p1, p2, p3)
 }
@@ -279,24 +363,32 @@ func (s *IMinerStateStruct) StateVerifiedClientStatus(p0 context.Context, p1 add
 
 type IChainInfoStruct struct {
 	Internal struct {
+		BeaconStatus                        func(ctx context.Context) ([]types.BeaconStatus, error)                                                                                                      `perm:"read"`
 		BlockTime                           func(ctx context.Context) time.Duration                                                                                                                      `perm:"read"`
 		ChainExport                         func(context.Context, abi.ChainEpoch, bool, types.TipSetKey) (<-chan []byte, error)                                                                          `perm:"read"`
+		ChainExportRange                    func(ctx context.Context, from, to abi.ChainEpoch, tsk types.TipSetKey) (<-chan []byte, error)                                                               `perm:"read"`
 		ChainGetBlock                       func(ctx context.Context, id cid.Cid) (*types.BlockHeader, error)                                                                                            `perm:"read"`
 		ChainGetBlockMessages               func(ctx context.Context, bid cid.Cid) (*types.BlockMessages, error)                                                                                         `perm:"read"`
+		ChainGetEventProof                  func(ctx context.Context, from types.TipSetKey, msg cid.Cid, eventIndex uint64) (*types.EventInclusionProof, error)                                          `perm:"read"`
 		ChainGetEvents                      func(context.Context, cid.Cid) ([]types.Event, error)                                                                                                        `perm:"read"`
+		ChainGetEventsDecoded               func(ctx context.Context, root cid.Cid) ([]types.DecodedEvent, error)                                                                                        `perm:"read"`
 		ChainGetGenesis                     func(context.Context) (*types.TipSet, error)                                                                                                                 `perm:"read"`
 		ChainGetMessage                     func(ctx context.Context, msgID cid.Cid) (*types.Message, error)                                                                                             `perm:"read"`
 		ChainGetMessagesInTipset            func(ctx context.Context, key types.TipSetKey) ([]types.MessageCID, error)                                                                                   `perm:"read"`
 		ChainGetParentMessages              func(ctx context.Context, bcid cid.Cid) ([]types.MessageCID, error)                                                                                          `perm:"read"`
 		ChainGetParentReceipts              func(ctx context.Context, bcid cid.Cid) ([]*types.MessageReceipt, error)                                                                                     `perm:"read"`
 		ChainGetPath                        func(ctx context.Context, from types.TipSetKey, to types.TipSetKey) ([]*types.HeadChange, error)                                                             `perm:"read"`
+		ChainGetReceiptProof                func(ctx context.Context, from types.TipSetKey, msg cid.Cid) (*types.ReceiptInclusionProof, error)                                                           `perm:"read"`
 		ChainGetReceipts                    func(ctx context.Context, id cid.Cid) ([]types.MessageReceipt, error)                                                                                        `perm:"read"`
 		ChainGetTipSet                      func(ctx context.Context, key types.TipSetKey) (*types.TipSet, error)                                                                                        `perm:"read"`
 		ChainGetTipSetAfterHeight           func(ctx context.Context, height abi.ChainEpoch, tsk types.TipSetKey) (*types.TipSet, error)                                                                 `perm:"read"`
 		ChainGetTipSetByHeight              func(ctx context.Context, height abi.ChainEpoch, tsk types.TipSetKey) (*types.TipSet, error)                                                                 `perm:"read"`
 		ChainHead                           func(ctx context.Context) (*types.TipSet, error)                                                                                                             `perm:"read"`
 		ChainList                           func(ctx context.Context, tsKey types.TipSetKey, count int) ([]types.TipSetKey, error)                                                                       `perm:"read"`
+		ChainListReorgs                     func(ctx context.Context, minDepth abi.ChainEpoch, since time.Time, limit int) ([]types.ChainReorg, error)                                                   `perm:"read"`
 		ChainNotify                         func(ctx context.Context) (<-chan []*types.HeadChange, error)                                                                                                `perm:"read"`
+		ChainNotifyStable                   func(ctx context.Context, confidence abi.ChainEpoch) (<-chan []*types.HeadChange, error)                                                                     `perm:"read"`
+		ChainPrune                          func(ctx context.Context, opts types.ChainPruneOpts) (*types.ChainPruneResult, error)                                                                        `perm:"admin"`
 		ChainSetHead                        func(ctx context.Context, key types.TipSetKey) error                                                                                                         `perm:"admin"`
 		GetActor                            func(ctx context.Context, addr address.Address) (*types.Actor, error)                                                                                        `perm:"read"`
 		GetEntry                            func(ctx context.Context, height abi.ChainEpoch, round uint64) (*types.BeaconEntry, error)                                                                   `perm:"read"`
@@ -306,6 +398,7 @@ type IChainInfoStruct struct {
 		ResolveToKeyAddr                    func(ctx context.Context, addr address.Address, ts *types.TipSet) (address.Address, error)                                                                   `perm:"read"`
 		StateActorCodeCIDs                  func(context.Context, network.Version) (map[string]cid.Cid, error)                                                                                           `perm:"read"`
 		StateActorManifestCID               func(context.Context, network.Version) (cid.Cid, error)                                                                                                      `perm:"read"`
+		StateActorMethods                   func(ctx context.Context, code cid.Cid) ([]types.ActorMethod, error)                                                                                         `perm:"read"`
 		StateCall                           func(ctx context.Context, msg *types.Message, tsk types.TipSetKey) (*types.InvocResult, error)                                                               `perm:"read"`
 		StateCompute                        func(context.Context, abi.ChainEpoch, []*types.Message, types.TipSetKey) (*types.ComputeStateOutput, error)                                                  `perm:"read"`
 		StateGetBeaconEntry                 func(ctx context.Context, epoch abi.ChainEpoch) (*types.BeaconEntry, error)                                                                                  `perm:"read"`
@@ -315,10 +408,13 @@ type IChainInfoStruct struct {
 		StateGetRandomnessFromBeacon        func(ctx context.Context, personalization crypto.DomainSeparationTag, randEpoch abi.ChainEpoch, entropy []byte, tsk types.TipSetKey) (abi.Randomness, error) `perm:"read"`
 		StateGetRandomnessFromTickets       func(ctx context.Context, personalization crypto.DomainSeparationTag, randEpoch abi.ChainEpoch, entropy []byte, tsk types.TipSetKey) (abi.Randomness, error) `perm:"read"`
 		StateMarketProposalPending          func(ctx context.Context, proposalCid cid.Cid, tsk types.TipSetKey) (bool, error)                                                                            `perm:"read"`
+		StateMigrationStatus                func(ctx context.Context) ([]types.MigrationStatus, error)                                                                                                   `perm:"read"`
 		StateNetworkName                    func(ctx context.Context) (types.NetworkName, error)                                                                                                         `perm:"read"`
 		StateNetworkVersion                 func(ctx context.Context, tsk types.TipSetKey) (network.Version, error)                                                                                      `perm:"read"`
 		StateReplay                         func(context.Context, types.TipSetKey, cid.Cid) (*types.InvocResult, error)                                                                                  `perm:"read"`
 		StateSearchMsg                      func(ctx context.Context, from types.TipSetKey, msg cid.Cid, limit abi.ChainEpoch, allowReplaced bool) (*types.MsgLookup, error)                             `perm:"read"`
+		StateSearchMsgWithReplacement       func(ctx context.Context, from types.TipSetKey, msg cid.Cid, limit abi.ChainEpoch) (*types.MsgReplacementLookup, error)                                      `perm:"read"`
+		StateUpgradeSchedule                func(ctx context.Context) ([]types.UpgradeHeight, error)                                                                                                     `perm:"read"`
 		StateVerifiedRegistryRootKey        func(ctx context.Context, tsk types.TipSetKey) (address.Address, error)                                                                                      `perm:"read"`
 		StateVerifierStatus                 func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (*abi.StoragePower, error)                                                              `perm:"read"`
 		StateWaitMsg                        func(ctx context.Context, cid cid.Cid, confidence uint64, limit abi.ChainEpoch, allowReplaced bool) (*types.MsgLookup, error)                                `perm:"read"`
@@ -326,21 +422,33 @@ type IChainInfoStruct struct {
 	}
 }
 
+func (s *IChainInfoStruct) BeaconStatus(p0 context.Context) ([]types.BeaconStatus, error) {
+	return s.Internal.BeaconStatus(p0)
+}
 func (s *IChainInfoStruct) BlockTime(p0 context.Context) time.Duration {
 	return s.Internal.BlockTime(p0)
 }
 func (s *IChainInfoStruct) ChainExport(p0 context.Context, p1 abi.ChainEpoch, p2 bool, p3 types.TipSetKey) (<-chan []byte, error) {
 	return s.Internal.ChainExport(p0, p1, p2, p3)
 }
+func (s *IChainInfoStruct) ChainExportRange(p0 context.Context, p1, p2 abi.ChainEpoch, p3 types.TipSetKey) (<-chan []byte, error) {
+	return s.Internal.ChainExportRange(p0, p1, p2, p3)
+}
 func (s *IChainInfoStruct) ChainGetBlock(p0 context.Context, p1 cid.Cid) (*types.BlockHeader, error) {
 	return s.Internal.ChainGetBlock(p0, p1)
 }
 func (s *IChainInfoStruct) ChainGetBlockMessages(p0 context.Context, p1 cid.Cid) (*types.BlockMessages, error) {
 	return s.Internal.ChainGetBlockMessages(p0, p1)
 }
+func (s *IChainInfoStruct) ChainGetEventProof(p0 context.Context, p1 types.TipSetKey, p2 cid.Cid, p3 uint64) (*types.EventInclusionProof, error) {
+	return s.Internal.ChainGetEventProof(p0, p1, p2, p3)
+}
 func (s *IChainInfoStruct) ChainGetEvents(p0 context.Context, p1 cid.Cid) ([]types.Event, error) {
 	return s.Internal.ChainGetEvents(p0, p1)
 }
+func (s *IChainInfoStruct) ChainGetEventsDecoded(p0 context.Context, p1 cid.Cid) ([]types.DecodedEvent, error) {
+	return s.Internal.ChainGetEventsDecoded(p0, p1)
+}
 func (s *IChainInfoStruct) ChainGetGenesis(p0 context.Context) (*types.TipSet, error) {
 	return s.Internal.ChainGetGenesis(p0)
 }
@@ -359,6 +467,9 @@ func (s *IChainInfoStruct) ChainGetParentReceipts(p0 context.Context, p1 cid.Cid
 func (s *IChainInfoStruct) ChainGetPath(p0 context.Context, p1 types.TipSetKey, p2 types.TipSetKey) ([]*types.HeadChange, error) {
 	return s.Internal.ChainGetPath(p0, p1, p2)
 }
+func (s *IChainInfoStruct) ChainGetReceiptProof(p0 context.Context, p1 types.TipSetKey, p2 cid.Cid) (*types.ReceiptInclusionProof, error) {
+	return s.Internal.ChainGetReceiptProof(p0, p1, p2)
+}
 func (s *IChainInfoStruct) ChainGetReceipts(p0 context.Context, p1 cid.Cid) ([]types.MessageReceipt, error) {
 	return s.Internal.ChainGetReceipts(p0, p1)
 }
@@ -377,9 +488,18 @@ func (s *IChainInfoStruct) ChainHead(p0 context.Context) (*types.TipSet, error)
 func (s *IChainInfoStruct) ChainList(p0 context.Context, p1 types.TipSetKey, p2 int) ([]types.TipSetKey, error) {
 	return s.Internal.ChainList(p0, p1, p2)
 }
+func (s *IChainInfoStruct) ChainListReorgs(p0 context.Context, p1 abi.ChainEpoch, p2 time.Time, p3 int) ([]types.ChainReorg, error) {
+	return s.Internal.ChainListReorgs(p0, p1, p2, p3)
+}
 func (s *IChainInfoStruct) ChainNotify(p0 context.Context) (<-chan []*types.HeadChange, error) {
 	return s.Internal.ChainNotify(p0)
 }
+func (s *IChainInfoStruct) ChainNotifyStable(p0 context.Context, p1 abi.ChainEpoch) (<-chan []*types.HeadChange, error) {
+	return s.Internal.ChainNotifyStable(p0, p1)
+}
+func (s *IChainInfoStruct) ChainPrune(p0 context.Context, p1 types.ChainPruneOpts) (*types.ChainPruneResult, error) {
+	return s.Internal.ChainPrune(p0, p1)
+}
 func (s *IChainInfoStruct) ChainSetHead(p0 context.Context, p1 types.TipSetKey) error {
 	return s.Internal.ChainSetHead(p0, p1)
 }
@@ -407,6 +527,9 @@ func (s *IChainInfoStruct) StateActorCodeCIDs(p0 context.Context, p1 network.Ver
 func (s *IChainInfoStruct) StateActorManifestCID(p0 context.Context, p1 network.Version) (cid.Cid, error) {
 	return s.Internal.StateActorManifestCID(p0, p1)
 }
+func (s *IChainInfoStruct) StateActorMethods(p0 context.Context, p1 cid.Cid) ([]types.ActorMethod, error) {
+	return s.Internal.StateActorMethods(p0, p1)
+}
 func (s *IChainInfoStruct) StateCall(p0 context.Context, p1 *types.Message, p2 types.TipSetKey) (*types.InvocResult, error) {
 	return s.Internal.StateCall(p0, p1, p2)
 }
@@ -434,6 +557,9 @@ func (s *IChainInfoStruct) StateGetRandomnessFromTickets(p0 context.Context, p1
 func (s *IChainInfoStruct) StateMarketProposalPending(p0 context.Context, p1 cid.Cid, p2 types.TipSetKey) (bool, error) {
 	return s.Internal.StateMarketProposalPending(p0, p1, p2)
 }
+func (s *IChainInfoStruct) StateMigrationStatus(p0 context.Context) ([]types.MigrationStatus, error) {
+	return s.Internal.StateMigrationStatus(p0)
+}
 func (s *IChainInfoStruct) StateNetworkName(p0 context.Context) (types.NetworkName, error) {
 	return s.Internal.StateNetworkName(p0)
 }
@@ -446,6 +572,12 @@ func (s *IChainInfoStruct) StateReplay(p0 context.Context, p1 types.TipSetKey, p
 func (s *IChainInfoStruct) StateSearchMsg(p0 context.Context, p1 types.TipSetKey, p2 cid.Cid, p3 abi.ChainEpoch, p4 bool) (*types.MsgLookup, error) {
 	return s.Internal.StateSearchMsg(p0, p1, p2, p3, p4)
 }
+func (s *IChainInfoStruct) StateSearchMsgWithReplacement(p0 context.Context, p1 types.TipSetKey, p2 cid.Cid, p3 abi.ChainEpoch) (*types.MsgReplacementLookup, error) {
+	return s.Internal.StateSearchMsgWithReplacement(p0, p1, p2, p3)
+}
+func (s *IChainInfoStruct) StateUpgradeSchedule(p0 context.Context) ([]types.UpgradeHeight, error) {
+	return s.Internal.StateUpgradeSchedule(p0)
+}
 func (s *IChainInfoStruct) StateVerifiedRegistryRootKey(p0 context.Context, p1 types.TipSetKey) (address.Address, error) {
 	return s.Internal.StateVerifiedRegistryRootKey(p0, p1)
 }
@@ -459,30 +591,93 @@ func (s *IChainInfoStruct) VerifyEntry(p0, p1 *types.BeaconEntry, p2 abi.ChainEp
 	return s.Internal.VerifyEntry(p0, p1, p2)
 }
 
+type IBitfieldStruct struct {
+	Internal struct {
+		BitfieldCount     func(ctx context.Context, bf []byte) (uint64, error)                      `perm:"read"`
+		BitfieldDecode    func(ctx context.Context, bf []byte, max uint64) ([]uint64, error)        `perm:"read"`
+		BitfieldEncode    func(ctx context.Context, bits []uint64) ([]byte, error)                  `perm:"read"`
+		BitfieldIntersect func(ctx context.Context, a, b []byte) ([]byte, error)                    `perm:"read"`
+		BitfieldSlice     func(ctx context.Context, bf []byte, start, count uint64) ([]byte, error) `perm:"read"`
+		BitfieldSubtract  func(ctx context.Context, a, b []byte) ([]byte, error)                    `perm:"read"`
+		BitfieldUnion     func(ctx context.Context, bitfields [][]byte) ([]byte, error)             `perm:"read"`
+	}
+}
+
+func (s *IBitfieldStruct) BitfieldCount(p0 context.Context, p1 []byte) (uint64, error) {
+	return s.Internal.BitfieldCount(p0, p1)
+}
+func (s *IBitfieldStruct) BitfieldDecode(p0 context.Context, p1 []byte, p2 uint64) ([]uint64, error) {
+	return s.Internal.BitfieldDecode(p0, p1, p2)
+}
+func (s *IBitfieldStruct) BitfieldEncode(p0 context.Context, p1 []uint64) ([]byte, error) {
+	return s.Internal.BitfieldEncode(p0, p1)
+}
+func (s *IBitfieldStruct) BitfieldIntersect(p0 context.Context, p1, p2 []byte) ([]byte, error) {
+	return s.Internal.BitfieldIntersect(p0, p1, p2)
+}
+func (s *IBitfieldStruct) BitfieldSlice(p0 context.Context, p1 []byte, p2, p3 uint64) ([]byte, error) {
+	return s.Internal.BitfieldSlice(p0, p1, p2, p3)
+}
+func (s *IBitfieldStruct) BitfieldSubtract(p0 context.Context, p1, p2 []byte) ([]byte, error) {
+	return s.Internal.BitfieldSubtract(p0, p1, p2)
+}
+func (s *IBitfieldStruct) BitfieldUnion(p0 context.Context, p1 [][]byte) ([]byte, error) {
+	return s.Internal.BitfieldUnion(p0, p1)
+}
+
 type IChainStruct struct {
 	IAccountStruct
 	IActorStruct
 	IMinerStateStruct
 	IChainInfoStruct
+	IBitfieldStruct
 }
 
 type IMarketStruct struct {
 	Internal struct {
-		StateMarketParticipants func(ctx context.Context, tsk types.TipSetKey) (map[string]types.MarketBalance, error) `perm:"read"`
+		MarketAddBalance            func(ctx context.Context, wallet, addr address.Address, amt types.BigInt) (cid.Cid, error)                               `perm:"sign"`
+		MarketGetReserved           func(ctx context.Context, addr address.Address) (types.BigInt, error)                                                    `perm:"sign"`
+		MarketReleaseFunds          func(ctx context.Context, addr address.Address, amt types.BigInt) error                                                  `perm:"sign"`
+		MarketReserveFunds          func(ctx context.Context, wallet address.Address, addr address.Address, amt types.BigInt) (cid.Cid, error)               `perm:"sign"`
+		MarketWithdraw              func(ctx context.Context, wallet, addr address.Address, amt types.BigInt) (cid.Cid, error)                               `perm:"sign"`
+		StateMarketParticipants     func(ctx context.Context, tsk types.TipSetKey) (map[string]types.MarketBalance, error)                                   `perm:"read"`
+		StateMarketParticipantsPage func(ctx context.Context, cursor address.Address, limit int, tsk types.TipSetKey) (*types.MarketParticipantsPage, error) `perm:"read"`
 	}
 }
 
+func (s *IMarketStruct) MarketAddBalance(p0 context.Context, p1, p2 address.Address, p3 types.BigInt) (cid.Cid, error) {
+	return s.Internal.MarketAddBalance(p0, p1, p2, p3)
+}
+func (s *IMarketStruct) MarketGetReserved(p0 context.Context, p1 address.Address) (types.BigInt, error) {
+	return s.Internal.MarketGetReserved(p0, p1)
+}
+func (s *IMarketStruct) MarketReleaseFunds(p0 context.Context, p1 address.Address, p2 types.BigInt) error {
+	return s.Internal.MarketReleaseFunds(p0, p1, p2)
+}
+func (s *IMarketStruct) MarketReserveFunds(p0 context.Context, p1 address.Address, p2 address.Address, p3 types.BigInt) (cid.Cid, error) {
+	return s.Internal.MarketReserveFunds(p0, p1, p2, p3)
+}
+func (s *IMarketStruct) MarketWithdraw(p0 context.Context, p1, p2 address.Address, p3 types.BigInt) (cid.Cid, error) {
+	return s.Internal.MarketWithdraw(p0, p1, p2, p3)
+}
 func (s *IMarketStruct) StateMarketParticipants(p0 context.Context, p1 types.TipSetKey) (map[string]types.MarketBalance, error) {
 	return s.Internal.StateMarketParticipants(p0, p1)
 }
+func (s *IMarketStruct) StateMarketParticipantsPage(p0 context.Context, p1 address.Address, p2 int, p3 types.TipSetKey) (*types.MarketParticipantsPage, error) {
+	return s.Internal.StateMarketParticipantsPage(p0, p1, p2, p3)
+}
 
 type IMiningStruct struct {
 	Internal struct {
+		MinerCreate      func(ctx context.Context, params *types.MinerCreateParams) (*types.MinerCreateResult, error)                               `perm:"sign"`
 		MinerCreateBlock func(ctx context.Context, bt *types.BlockTemplate) (*types.BlockMsg, error)                                                `perm:"write"`
 		MinerGetBaseInfo func(ctx context.Context, maddr address.Address, round abi.ChainEpoch, tsk types.TipSetKey) (*types.MiningBaseInfo, error) `perm:"read"`
 	}
 }
 
+func (s *IMiningStruct) MinerCreate(p0 context.Context, p1 *types.MinerCreateParams) (*types.MinerCreateResult, error) {
+	return s.Internal.MinerCreate(p0, p1)
+}
 func (s *IMiningStruct) MinerCreateBlock(p0 context.Context, p1 *types.BlockTemplate) (*types.BlockMsg, error) {
 	return s.Internal.MinerCreateBlock(p0, p1)
 }
@@ -500,6 +695,7 @@ type IMessagePoolStruct struct {
 		MpoolBatchPush             func(ctx context.Context, smsgs []*types.SignedMessage) ([]cid.Cid, error)                                                                   `perm:"write"`
 		MpoolBatchPushMessage      func(ctx context.Context, msgs []*types.Message, spec *types.MessageSendSpec) ([]*types.SignedMessage, error)                                `perm:"sign"`
 		MpoolBatchPushUntrusted    func(ctx context.Context, smsgs []*types.SignedMessage) ([]cid.Cid, error)                                                                   `perm:"write"`
+		MpoolCancelScheduled       func(ctx context.Context, c cid.Cid) error                                                                                                   `perm:"write"`
 		MpoolCheckMessages         func(ctx context.Context, protos []*types.MessagePrototype) ([][]types.MessageCheckStatus, error)                                            `perm:"read"`
 		MpoolCheckPendingMessages  func(ctx context.Context, addr address.Address) ([][]types.MessageCheckStatus, error)                                                        `perm:"read"`
 		MpoolCheckReplaceMessages  func(ctx context.Context, msg []*types.Message) ([][]types.MessageCheckStatus, error)                                                        `perm:"read"`
@@ -507,15 +703,21 @@ type IMessagePoolStruct struct {
 		MpoolDeleteByAdress        func(ctx context.Context, addr address.Address) error                                                                                        `perm:"admin"`
 		MpoolGetConfig             func(context.Context) (*types.MpoolConfig, error)                                                                                            `perm:"read"`
 		MpoolGetNonce              func(ctx context.Context, addr address.Address) (uint64, error)                                                                              `perm:"read"`
+		MpoolListScheduled         func(ctx context.Context) ([]*types.ScheduledMessage, error)                                                                                 `perm:"read"`
 		MpoolPending               func(ctx context.Context, tsk types.TipSetKey) ([]*types.SignedMessage, error)                                                               `perm:"read"`
+		MpoolPrepareMessages       func(ctx context.Context, msgs []*types.Message, spec *types.MessageSendSpec) (*types.MessageBundle, error)                                  `perm:"read"`
 		MpoolPublishByAddr         func(context.Context, address.Address) error                                                                                                 `perm:"write"`
 		MpoolPublishMessage        func(ctx context.Context, smsg *types.SignedMessage) error                                                                                   `perm:"write"`
 		MpoolPush                  func(ctx context.Context, smsg *types.SignedMessage) (cid.Cid, error)                                                                        `perm:"write"`
 		MpoolPushMessage           func(ctx context.Context, msg *types.Message, spec *types.MessageSendSpec) (*types.SignedMessage, error)                                     `perm:"sign"`
+		MpoolPushSigned            func(ctx context.Context, bundle *types.MessageBundle) ([]cid.Cid, error)                                                                    `perm:"write"`
 		MpoolPushUntrusted         func(ctx context.Context, smsg *types.SignedMessage) (cid.Cid, error)                                                                        `perm:"write"`
+		MpoolScheduleMessage       func(ctx context.Context, smsg *types.SignedMessage, trigger types.ScheduleTrigger) (cid.Cid, error)                                         `perm:"write"`
 		MpoolSelect                func(context.Context, types.TipSetKey, float64) ([]*types.SignedMessage, error)                                                              `perm:"read"`
+		MpoolSelectWithDetail      func(ctx context.Context, tsk types.TipSetKey, ticketQuality float64) (*types.MpoolSelection, error)                                         `perm:"read"`
 		MpoolSelects               func(context.Context, types.TipSetKey, []float64) ([][]*types.SignedMessage, error)                                                          `perm:"read"`
 		MpoolSetConfig             func(ctx context.Context, cfg *types.MpoolConfig) error                                                                                      `perm:"admin"`
+		MpoolStats                 func(ctx context.Context, premiums []big.Int) (*types.MpoolStats, error)                                                                     `perm:"read"`
 		MpoolSub                   func(ctx context.Context) (<-chan types.MpoolUpdate, error)                                                                                  `perm:"read"`
 	}
 }
@@ -544,6 +746,9 @@ func (s *IMessagePoolStruct) MpoolBatchPushMessage(p0 context.Context, p1 []*typ
 func (s *IMessagePoolStruct) MpoolBatchPushUntrusted(p0 context.Context, p1 []*types.SignedMessage) ([]cid.Cid, error) {
 	return s.Internal.MpoolBatchPushUntrusted(p0, p1)
 }
+func (s *IMessagePoolStruct) MpoolCancelScheduled(p0 context.Context, p1 cid.Cid) error {
+	return s.Internal.MpoolCancelScheduled(p0, p1)
+}
 func (s *IMessagePoolStruct) MpoolCheckMessages(p0 context.Context, p1 []*types.MessagePrototype) ([][]types.MessageCheckStatus, error) {
 	return s.Internal.MpoolCheckMessages(p0, p1)
 }
@@ -565,9 +770,15 @@ func (s *IMessagePoolStruct) MpoolGetConfig(p0 context.Context) (*types.MpoolCon
 func (s *IMessagePoolStruct) MpoolGetNonce(p0 context.Context, p1 address.Address) (uint64, error) {
 	return s.Internal.MpoolGetNonce(p0, p1)
 }
+func (s *IMessagePoolStruct) MpoolListScheduled(p0 context.Context) ([]*types.ScheduledMessage, error) {
+	return s.Internal.MpoolListScheduled(p0)
+}
 func (s *IMessagePoolStruct) MpoolPending(p0 context.Context, p1 types.TipSetKey) ([]*types.SignedMessage, error) {
 	return s.Internal.MpoolPending(p0, p1)
 }
+func (s *IMessagePoolStruct) MpoolPrepareMessages(p0 context.Context, p1 []*types.Message, p2 *types.MessageSendSpec) (*types.MessageBundle, error) {
+	return s.Internal.MpoolPrepareMessages(p0, p1, p2)
+}
 func (s *IMessagePoolStruct) MpoolPublishByAddr(p0 context.Context, p1 address.Address) error {
 	return s.Internal.MpoolPublishByAddr(p0, p1)
 }
@@ -580,44 +791,59 @@ func (s *IMessagePoolStruct) MpoolPush(p0 context.Context, p1 *types.SignedMessa
 func (s *IMessagePoolStruct) MpoolPushMessage(p0 context.Context, p1 *types.Message, p2 *types.MessageSendSpec) (*types.SignedMessage, error) {
 	return s.Internal.MpoolPushMessage(p0, p1, p2)
 }
+func (s *IMessagePoolStruct) MpoolPushSigned(p0 context.Context, p1 *types.MessageBundle) ([]cid.Cid, error) {
+	return s.Internal.MpoolPushSigned(p0, p1)
+}
 func (s *IMessagePoolStruct) MpoolPushUntrusted(p0 context.Context, p1 *types.SignedMessage) (cid.Cid, error) {
 	return s.Internal.MpoolPushUntrusted(p0, p1)
 }
+func (s *IMessagePoolStruct) MpoolScheduleMessage(p0 context.Context, p1 *types.SignedMessage, p2 types.ScheduleTrigger) (cid.Cid, error) {
+	return s.Internal.MpoolScheduleMessage(p0, p1, p2)
+}
 func (s *IMessagePoolStruct) MpoolSelect(p0 context.Context, p1 types.TipSetKey, p2 float64) ([]*types.SignedMessage, error) {
 	return s.Internal.MpoolSelect(p0, p1, p2)
 }
+func (s *IMessagePoolStruct) MpoolSelectWithDetail(p0 context.Context, p1 types.TipSetKey, p2 float64) (*types.MpoolSelection, error) {
+	return s.Internal.MpoolSelectWithDetail(p0, p1, p2)
+}
 func (s *IMessagePoolStruct) MpoolSelects(p0 context.Context, p1 types.TipSetKey, p2 []float64) ([][]*types.SignedMessage, error) {
 	return s.Internal.MpoolSelects(p0, p1, p2)
 }
 func (s *IMessagePoolStruct) MpoolSetConfig(p0 context.Context, p1 *types.MpoolConfig) error {
 	return s.Internal.MpoolSetConfig(p0, p1)
 }
+func (s *IMessagePoolStruct) MpoolStats(p0 context.Context, p1 []big.Int) (*types.MpoolStats, error) {
+	return s.Internal.MpoolStats(p0, p1)
+}
 func (s *IMessagePoolStruct) MpoolSub(p0 context.Context) (<-chan types.MpoolUpdate, error) {
 	return s.Internal.MpoolSub(p0)
 }
 
 type INetworkStruct struct {
 	Internal struct {
-		ID                          func(ctx context.Context) (peer.ID, error)                             `perm:"read"`
-		NetAddrsListen              func(ctx context.Context) (peer.AddrInfo, error)                       `perm:"read"`
-		NetAgentVersion             func(ctx context.Context, p peer.ID) (string, error)                   `perm:"read"`
-		NetAutoNatStatus            func(context.Context) (types.NatInfo, error)                           `perm:"read"`
-		NetBandwidthStats           func(ctx context.Context) (metrics.Stats, error)                       `perm:"read"`
-		NetBandwidthStatsByPeer     func(ctx context.Context) (map[string]metrics.Stats, error)            `perm:"read"`
-		NetBandwidthStatsByProtocol func(ctx context.Context) (map[protocol.ID]metrics.Stats, error)       `perm:"read"`
-		NetConnect                  func(ctx context.Context, pi peer.AddrInfo) error                      `perm:"admin"`
-		NetConnectedness            func(context.Context, peer.ID) (network2.Connectedness, error)         `perm:"read"`
-		NetDisconnect               func(ctx context.Context, p peer.ID) error                             `perm:"admin"`
-		NetFindPeer                 func(ctx context.Context, p peer.ID) (peer.AddrInfo, error)            `perm:"read"`
-		NetFindProvidersAsync       func(ctx context.Context, key cid.Cid, count int) <-chan peer.AddrInfo `perm:"read"`
-		NetGetClosestPeers          func(ctx context.Context, key string) ([]peer.ID, error)               `perm:"read"`
-		NetPeerInfo                 func(ctx context.Context, p peer.ID) (*types.ExtendedPeerInfo, error)  `perm:"read"`
-		NetPeers                    func(ctx context.Context) ([]peer.AddrInfo, error)                     `perm:"read"`
-		NetPing                     func(ctx context.Context, p peer.ID) (time.Duration, error)            `perm:"read"`
-		NetProtectAdd               func(ctx context.Context, acl []peer.ID) error                         `perm:"admin"`
-		NetProtectList              func(ctx context.Context) ([]peer.ID, error)                           `perm:"read"`
-		NetProtectRemove            func(ctx context.Context, acl []peer.ID) error                         `perm:"admin"`
-		NetPubsubScores             func(context.Context) ([]types.PubsubScore, error)                     `perm:"read"`
+		ID                          func(ctx context.Context) (peer.ID, error)                                                        `perm:"read"`
+		NetAddrsListen              func(ctx context.Context) (peer.AddrInfo, error)                                                  `perm:"read"`
+		NetAgentVersion             func(ctx context.Context, p peer.ID) (string, error)                                              `perm:"read"`
+		NetAutoNatStatus            func(context.Context) (types.NatInfo, error)                                                      `perm:"read"`
+		NetBandwidthStats           func(ctx context.Context) (metrics.Stats, error)                                                  `perm:"read"`
+		NetBandwidthStatsByPeer     func(ctx context.Context) (map[string]metrics.Stats, error)                                       `perm:"read"`
+		NetBandwidthStatsByProtocol func(ctx context.Context) (map[protocol.ID]metrics.Stats, error)                                  `perm:"read"`
+		NetConnect                  func(ctx context.Context, pi peer.AddrInfo) error                                                 `perm:"admin"`
+		NetConnectedness            func(context.Context, peer.ID) (network2.Connectedness, error)                                    `perm:"read"`
+		NetDisconnect               func(ctx context.Context, p peer.ID) error                                                        `perm:"admin"`
+		NetFindPeer                 func(ctx context.Context, p peer.ID) (peer.AddrInfo, error)                                       `perm:"read"`
+		NetFindProvidersAsync       func(ctx context.Context, key cid.Cid, count int) <-chan peer.AddrInfo                            `perm:"read"`
+		NetGetClosestPeers          func(ctx context.Context, key string) ([]peer.ID, error)                                          `perm:"read"`
+		NetPeerInfo                 func(ctx context.Context, p peer.ID) (*types.ExtendedPeerInfo, error)                             `perm:"read"`
+		NetPeers                    func(ctx context.Context) ([]peer.AddrInfo, error)                                                `perm:"read"`
+		NetPing                     func(ctx context.Context, p peer.ID) (time.Duration, error)                                       `perm:"read"`
+		NetProtectAdd               func(ctx context.Context, acl []peer.ID) error                                                    `perm:"admin"`
+		NetProtectList              func(ctx context.Context) ([]peer.ID, error)                                                      `perm:"read"`
+		NetProtectRemove            func(ctx context.Context, acl []peer.ID) error                                                    `perm:"admin"`
+		NetPubsubScores             func(context.Context) ([]types.PubsubScore, error)                                                `perm:"read"`
+		NetQuarantineGet            func(ctx context.Context, c cid.Cid) (*types.QuarantineEntry, error)                              `perm:"read"`
+		NetQuarantineList           func(ctx context.Context, kind types.QuarantineKind, limit int) ([]*types.QuarantineEntry, error) `perm:"read"`
+		NetQuarantineStats          func(ctx context.Context) ([]types.QuarantineStat, error)                                         `perm:"read"`
 	}
 }
 
@@ -679,6 +905,15 @@ func (s *INetworkStruct) NetProtectRemove(p0 context.Context, p1 []peer.ID) erro
 func (s *INetworkStruct) NetPubsubScores(p0 context.Context) ([]types.PubsubScore, error) {
 	return s.Internal.NetPubsubScores(p0)
 }
+func (s *INetworkStruct) NetQuarantineGet(p0 context.Context, p1 cid.Cid) (*types.QuarantineEntry, error) {
+	return s.Internal.NetQuarantineGet(p0, p1)
+}
+func (s *INetworkStruct) NetQuarantineList(p0 context.Context, p1 types.QuarantineKind, p2 int) ([]*types.QuarantineEntry, error) {
+	return s.Internal.NetQuarantineList(p0, p1, p2)
+}
+func (s *INetworkStruct) NetQuarantineStats(p0 context.Context) ([]types.QuarantineStat, error) {
+	return s.Internal.NetQuarantineStats(p0)
+}
 
 type IPaychanStruct struct {
 	Internal struct {
@@ -756,15 +991,17 @@ func (s *IPaychanStruct) PaychVoucherSubmit(p0 context.Context, p1 address.Addre
 
 type ISyncerStruct struct {
 	Internal struct {
-		ChainSyncHandleNewTipSet func(ctx context.Context, ci *types.ChainInfo) error            `perm:"write"`
-		ChainTipSetWeight        func(ctx context.Context, tsk types.TipSetKey) (big.Int, error) `perm:"read"`
-		Concurrent               func(ctx context.Context) int64                                 `perm:"read"`
-		SetConcurrent            func(ctx context.Context, concurrent int64) error               `perm:"admin"`
-		SyncCheckpoint           func(ctx context.Context, tsk types.TipSetKey) error            `perm:"admin"`
-		SyncIncomingBlocks       func(ctx context.Context) (<-chan *types.BlockHeader, error)    `perm:"read"`
-		SyncState                func(ctx context.Context) (*types.SyncState, error)             `perm:"read"`
-		SyncSubmitBlock          func(ctx context.Context, blk *types.BlockMsg) error            `perm:"write"`
-		SyncerTracker            func(ctx context.Context) *types.TargetTracker                  `perm:"read"`
+		ChainSyncHandleNewTipSet func(ctx context.Context, ci *types.ChainInfo) error                                              `perm:"write"`
+		ChainTipSetWeight        func(ctx context.Context, tsk types.TipSetKey) (big.Int, error)                                   `perm:"read"`
+		Concurrent               func(ctx context.Context) int64                                                                   `perm:"read"`
+		SetConcurrent            func(ctx context.Context, concurrent int64) error                                                 `perm:"admin"`
+		SyncCheckpoint           func(ctx context.Context, tsk types.TipSetKey) error                                              `perm:"admin"`
+		SyncIncomingBlocks       func(ctx context.Context) (<-chan *types.BlockHeader, error)                                      `perm:"read"`
+		SyncState                func(ctx context.Context) (*types.SyncState, error)                                               `perm:"read"`
+		SyncSubmitBlock          func(ctx context.Context, blk *types.BlockMsg) error                                              `perm:"write"`
+		SyncSubmitBlockChecked   func(ctx context.Context, blk *types.BlockMsg, dryRun bool) (*types.BlockHeaderValidation, error) `perm:"write"`
+		SyncValidateBlockHeader  func(ctx context.Context, bh *types.BlockHeader) (*types.BlockHeaderValidation, error)            `perm:"read"`
+		SyncerTracker            func(ctx context.Context) *types.TargetTracker                                                    `perm:"read"`
 	}
 }
 
@@ -790,31 +1027,57 @@ func (s *ISyncerStruct) SyncState(p0 context.Context) (*types.SyncState, error)
 func (s *ISyncerStruct) SyncSubmitBlock(p0 context.Context, p1 *types.BlockMsg) error {
 	return s.Internal.SyncSubmitBlock(p0, p1)
 }
+func (s *ISyncerStruct) SyncSubmitBlockChecked(p0 context.Context, p1 *types.BlockMsg, p2 bool) (*types.BlockHeaderValidation, error) {
+	return s.Internal.SyncSubmitBlockChecked(p0, p1, p2)
+}
+func (s *ISyncerStruct) SyncValidateBlockHeader(p0 context.Context, p1 *types.BlockHeader) (*types.BlockHeaderValidation, error) {
+	return s.Internal.SyncValidateBlockHeader(p0, p1)
+}
 func (s *ISyncerStruct) SyncerTracker(p0 context.Context) *types.TargetTracker {
 	return s.Internal.SyncerTracker(p0)
 }
 
 type IWalletStruct struct {
 	Internal struct {
-		HasPassword          func(ctx context.Context) bool                                                                          `perm:"admin"`
-		LockWallet           func(ctx context.Context) error                                                                         `perm:"admin"`
-		SetPassword          func(ctx context.Context, password []byte) error                                                        `perm:"admin"`
-		UnLockWallet         func(ctx context.Context, password []byte) error                                                        `perm:"admin"`
-		WalletAddresses      func(ctx context.Context) []address.Address                                                             `perm:"admin"`
-		WalletBalance        func(ctx context.Context, addr address.Address) (abi.TokenAmount, error)                                `perm:"read"`
-		WalletDefaultAddress func(ctx context.Context) (address.Address, error)                                                      `perm:"write"`
-		WalletDelete         func(ctx context.Context, addr address.Address) error                                                   `perm:"admin"`
-		WalletExport         func(ctx context.Context, addr address.Address, password string) (*types.KeyInfo, error)                `perm:"admin"`
-		WalletHas            func(ctx context.Context, addr address.Address) (bool, error)                                           `perm:"write"`
-		WalletImport         func(ctx context.Context, key *types.KeyInfo) (address.Address, error)                                  `perm:"admin"`
-		WalletNewAddress     func(ctx context.Context, protocol address.Protocol) (address.Address, error)                           `perm:"write"`
-		WalletSetDefault     func(ctx context.Context, addr address.Address) error                                                   `perm:"write"`
-		WalletSign           func(ctx context.Context, k address.Address, msg []byte, meta types.MsgMeta) (*crypto.Signature, error) `perm:"sign"`
-		WalletSignMessage    func(ctx context.Context, k address.Address, msg *types.Message) (*types.SignedMessage, error)          `perm:"sign"`
-		WalletState          func(ctx context.Context) int                                                                           `perm:"admin"`
+		AddressBookList            func(ctx context.Context) (map[string]address.Address, error)                                                  `perm:"read"`
+		AddressBookRemove          func(ctx context.Context, label string) error                                                                  `perm:"write"`
+		AddressBookSet             func(ctx context.Context, label string, addr address.Address) error                                            `perm:"write"`
+		HasPassword                func(ctx context.Context) bool                                                                                 `perm:"admin"`
+		LockWallet                 func(ctx context.Context) error                                                                                `perm:"admin"`
+		SetPassword                func(ctx context.Context, password []byte) error                                                               `perm:"admin"`
+		UnLockWallet               func(ctx context.Context, password []byte) error                                                               `perm:"admin"`
+		WalletAddresses            func(ctx context.Context) []address.Address                                                                    `perm:"admin"`
+		WalletAuditLog             func(ctx context.Context, filter types.SignAuditFilter, after uint64, limit int) (*types.SignAuditPage, error) `perm:"admin"`
+		WalletBalance              func(ctx context.Context, addr address.Address) (abi.TokenAmount, error)                                       `perm:"read"`
+		WalletDefaultAddress       func(ctx context.Context) (address.Address, error)                                                             `perm:"write"`
+		WalletDelete               func(ctx context.Context, addr address.Address) error                                                          `perm:"admin"`
+		WalletExport               func(ctx context.Context, addr address.Address, password string) (*types.KeyInfo, error)                       `perm:"admin"`
+		WalletHas                  func(ctx context.Context, addr address.Address) (bool, error)                                                  `perm:"write"`
+		WalletImport               func(ctx context.Context, key *types.KeyInfo) (address.Address, error)                                         `perm:"admin"`
+		WalletLock                 func(ctx context.Context) error                                                                                `perm:"admin"`
+		WalletNew                  func(ctx context.Context, kt types.KeyType) (address.Address, error)                                           `perm:"write"`
+		WalletNewAddress           func(ctx context.Context, protocol address.Protocol) (address.Address, error)                                  `perm:"write"`
+		WalletNewDelegatedFromSecp func(ctx context.Context, addr address.Address) (address.Address, error)                                       `perm:"admin"`
+		WalletSetDefault           func(ctx context.Context, addr address.Address) error                                                          `perm:"write"`
+		WalletSetPassword          func(ctx context.Context, password []byte) error                                                               `perm:"admin"`
+		WalletSign                 func(ctx context.Context, k address.Address, msg []byte, meta types.MsgMeta) (*crypto.Signature, error)        `perm:"sign"`
+		WalletSignBatch            func(ctx context.Context, requests []types.WalletSignRequest) ([]types.WalletSignResult, error)                `perm:"sign"`
+		WalletSignEthTransaction   func(ctx context.Context, from types.EthAddress, tx *types.Eth1559TxArgs) (types.EthBytes, error)              `perm:"sign"`
+		WalletSignMessage          func(ctx context.Context, k address.Address, msg *types.Message) (*types.SignedMessage, error)                 `perm:"sign"`
+		WalletState                func(ctx context.Context) int                                                                                  `perm:"admin"`
+		WalletUnlock               func(ctx context.Context, password []byte, timeout time.Duration) error                                        `perm:"admin"`
 	}
 }
 
+func (s *IWalletStruct) AddressBookList(p0 context.Context) (map[string]address.Address, error) {
+	return s.Internal.AddressBookList(p0)
+}
+func (s *IWalletStruct) AddressBookRemove(p0 context.Context, p1 string) error {
+	return s.Internal.AddressBookRemove(p0, p1)
+}
+func (s *IWalletStruct) AddressBookSet(p0 context.Context, p1 string, p2 address.Address) error {
+	return s.Internal.AddressBookSet(p0, p1, p2)
+}
 func (s *IWalletStruct) HasPassword(p0 context.Context) bool { return s.Internal.HasPassword(p0) }
 func (s *IWalletStruct) LockWallet(p0 context.Context) error { return s.Internal.LockWallet(p0) }
 func (s *IWalletStruct) SetPassword(p0 context.Context, p1 []byte) error {
@@ -826,6 +1089,9 @@ func (s *IWalletStruct) UnLockWallet(p0 context.Context, p1 []byte) error {
 func (s *IWalletStruct) WalletAddresses(p0 context.Context) []address.Address {
 	return s.Internal.WalletAddresses(p0)
 }
+func (s *IWalletStruct) WalletAuditLog(p0 context.Context, p1 types.SignAuditFilter, p2 uint64, p3 int) (*types.SignAuditPage, error) {
+	return s.Internal.WalletAuditLog(p0, p1, p2, p3)
+}
 func (s *IWalletStruct) WalletBalance(p0 context.Context, p1 address.Address) (abi.TokenAmount, error) {
 	return s.Internal.WalletBalance(p0, p1)
 }
@@ -844,31 +1110,84 @@ func (s *IWalletStruct) WalletHas(p0 context.Context, p1 address.Address) (bool,
 func (s *IWalletStruct) WalletImport(p0 context.Context, p1 *types.KeyInfo) (address.Address, error) {
 	return s.Internal.WalletImport(p0, p1)
 }
+func (s *IWalletStruct) WalletLock(p0 context.Context) error { return s.Internal.WalletLock(p0) }
+func (s *IWalletStruct) WalletNew(p0 context.Context, p1 types.KeyType) (address.Address, error) {
+	return s.Internal.WalletNew(p0, p1)
+}
 func (s *IWalletStruct) WalletNewAddress(p0 context.Context, p1 address.Protocol) (address.Address, error) {
 	return s.Internal.WalletNewAddress(p0, p1)
 }
+func (s *IWalletStruct) WalletNewDelegatedFromSecp(p0 context.Context, p1 address.Address) (address.Address, error) {
+	return s.Internal.WalletNewDelegatedFromSecp(p0, p1)
+}
 func (s *IWalletStruct) WalletSetDefault(p0 context.Context, p1 address.Address) error {
 	return s.Internal.WalletSetDefault(p0, p1)
 }
+func (s *IWalletStruct) WalletSetPassword(p0 context.Context, p1 []byte) error {
+	return s.Internal.WalletSetPassword(p0, p1)
+}
 func (s *IWalletStruct) WalletSign(p0 context.Context, p1 address.Address, p2 []byte, p3 types.MsgMeta) (*crypto.Signature, error) {
 	return s.Internal.WalletSign(p0, p1, p2, p3)
 }
+func (s *IWalletStruct) WalletSignBatch(p0 context.Context, p1 []types.WalletSignRequest) ([]types.WalletSignResult, error) {
+	return s.Internal.WalletSignBatch(p0, p1)
+}
+func (s *IWalletStruct) WalletSignEthTransaction(p0 context.Context, p1 types.EthAddress, p2 *types.Eth1559TxArgs) (types.EthBytes, error) {
+	return s.Internal.WalletSignEthTransaction(p0, p1, p2)
+}
 func (s *IWalletStruct) WalletSignMessage(p0 context.Context, p1 address.Address, p2 *types.Message) (*types.SignedMessage, error) {
 	return s.Internal.WalletSignMessage(p0, p1, p2)
 }
 func (s *IWalletStruct) WalletState(p0 context.Context) int { return s.Internal.WalletState(p0) }
+func (s *IWalletStruct) WalletUnlock(p0 context.Context, p1 []byte, p2 time.Duration) error {
+	return s.Internal.WalletUnlock(p0, p1, p2)
+}
 
 type ICommonStruct struct {
 	Internal struct {
-		NodeStatus func(ctx context.Context, inclChainStatus bool) (types.NodeStatus, error) `perm:"read"`
-		StartTime  func(context.Context) (time.Time, error)                                  `perm:"read"`
-		Version    func(ctx context.Context) (types.Version, error)                          `perm:"read"`
+		APIHandshake        func(ctx context.Context, clientVersion types.APIVersion) (types.APIHandshake, error) `perm:"read"`
+		JournalEvents       func(ctx context.Context, filter types.JournalFilter) ([]types.JournalEvent, error)   `perm:"admin"`
+		LogList             func(context.Context) ([]string, error)                                               `perm:"write"`
+		LogSetLevel         func(ctx context.Context, subsystem, level string) error                              `perm:"write"`
+		MaintenanceOverride func(ctx context.Context, override types.MaintenanceOverride) error                   `perm:"admin"`
+		MaintenanceSchedule func(ctx context.Context) (*types.MaintenanceSchedule, error)                         `perm:"read"`
+		NodeBackup          func(ctx context.Context, opts types.NodeBackupOpts) (*types.NodeBackupResult, error) `perm:"admin"`
+		NodeHealth          func(ctx context.Context) (types.NodeHealth, error)                                   `perm:"read"`
+		NodeStatus          func(ctx context.Context, inclChainStatus bool) (types.NodeStatus, error)             `perm:"read"`
+		SetConfig           func(ctx context.Context, key string, value string) (*types.ConfigReload, error)      `perm:"admin"`
+		StartTime           func(context.Context) (time.Time, error)                                              `perm:"read"`
+		Version             func(ctx context.Context) (types.Version, error)                                      `perm:"read"`
 	}
 }
 
+func (s *ICommonStruct) APIHandshake(p0 context.Context, p1 types.APIVersion) (types.APIHandshake, error) {
+	return s.Internal.APIHandshake(p0, p1)
+}
+func (s *ICommonStruct) JournalEvents(p0 context.Context, p1 types.JournalFilter) ([]types.JournalEvent, error) {
+	return s.Internal.JournalEvents(p0, p1)
+}
+func (s *ICommonStruct) LogList(p0 context.Context) ([]string, error) { return s.Internal.LogList(p0) }
+func (s *ICommonStruct) LogSetLevel(p0 context.Context, p1, p2 string) error {
+	return s.Internal.LogSetLevel(p0, p1, p2)
+}
+func (s *ICommonStruct) MaintenanceOverride(p0 context.Context, p1 types.MaintenanceOverride) error {
+	return s.Internal.MaintenanceOverride(p0, p1)
+}
+func (s *ICommonStruct) MaintenanceSchedule(p0 context.Context) (*types.MaintenanceSchedule, error) {
+	return s.Internal.MaintenanceSchedule(p0)
+}
+func (s *ICommonStruct) NodeBackup(p0 context.Context, p1 types.NodeBackupOpts) (*types.NodeBackupResult, error) {
+	return s.Internal.NodeBackup(p0, p1)
+}
+func (s *ICommonStruct) NodeHealth(p0 context.Context) (types.NodeHealth, error) {
+	return s.Internal.NodeHealth(p0)
+}
 func (s *ICommonStruct) NodeStatus(p0 context.Context, p1 bool) (types.NodeStatus, error) {
 	return s.Internal.NodeStatus(p0, p1)
 }
+func (s *ICommonStruct) SetConfig(p0 context.Context, p1 string, p2 string) (*types.ConfigReload, error) {
+	return s.Internal.SetConfig(p0, p1, p2)
+}
 func (s *ICommonStruct) StartTime(p0 context.Context) (time.Time, error) {
 	return s.Internal.StartTime(p0)
 }
@@ -878,44 +1197,56 @@ func (s *ICommonStruct) Version(p0 context.Context) (types.Version, error) {
 
 type IETHStruct struct {
 	Internal struct {
-		EthAccounts                            func(ctx context.Context) ([]types.EthAddress, error)                                                                                     `perm:"read"`
-		EthAddressToFilecoinAddress            func(ctx context.Context, ethAddress types.EthAddress) (address.Address, error)                                                           `perm:"read"`
-		EthBlockNumber                         func(ctx context.Context) (types.EthUint64, error)                                                                                        `perm:"read"`
-		EthCall                                func(ctx context.Context, tx types.EthCall, blkParam types.EthBlockNumberOrHash) (types.EthBytes, error)                                  `perm:"read"`
-		EthChainId                             func(ctx context.Context) (types.EthUint64, error)                                                                                        `perm:"read"`
-		EthEstimateGas                         func(ctx context.Context, p jsonrpc.RawParams) (types.EthUint64, error)                                                                   `perm:"read"`
-		EthFeeHistory                          func(ctx context.Context, p jsonrpc.RawParams) (types.EthFeeHistory, error)                                                               `perm:"read"`
-		EthGasPrice                            func(ctx context.Context) (types.EthBigInt, error)                                                                                        `perm:"read"`
-		EthGetBalance                          func(ctx context.Context, address types.EthAddress, blkParam types.EthBlockNumberOrHash) (types.EthBigInt, error)                         `perm:"read"`
-		EthGetBlockByHash                      func(ctx context.Context, blkHash types.EthHash, fullTxInfo bool) (types.EthBlock, error)                                                 `perm:"read"`
-		EthGetBlockByNumber                    func(ctx context.Context, blkNum string, fullTxInfo bool) (types.EthBlock, error)                                                         `perm:"read"`
-		EthGetBlockReceipts                    func(ctx context.Context, blkParam types.EthBlockNumberOrHash) ([]*types.EthTxReceipt, error)                                             `perm:"read"`
-		EthGetBlockReceiptsLimited             func(ctx context.Context, blkParam types.EthBlockNumberOrHash, limit abi.ChainEpoch) ([]*types.EthTxReceipt, error)                       `perm:"read"`
-		EthGetBlockTransactionCountByHash      func(ctx context.Context, blkHash types.EthHash) (types.EthUint64, error)                                                                 `perm:"read"`
-		EthGetBlockTransactionCountByNumber    func(ctx context.Context, blkNum types.EthUint64) (types.EthUint64, error)                                                                `perm:"read"`
-		EthGetCode                             func(ctx context.Context, address types.EthAddress, blkParam types.EthBlockNumberOrHash) (types.EthBytes, error)                          `perm:"read"`
-		EthGetMessageCidByTransactionHash      func(ctx context.Context, txHash *types.EthHash) (*cid.Cid, error)                                                                        `perm:"read"`
-		EthGetStorageAt                        func(ctx context.Context, address types.EthAddress, position types.EthBytes, blkParam types.EthBlockNumberOrHash) (types.EthBytes, error) `perm:"read"`
-		EthGetTransactionByBlockHashAndIndex   func(ctx context.Context, blkHash types.EthHash, txIndex types.EthUint64) (types.EthTx, error)                                            `perm:"read"`
-		EthGetTransactionByBlockNumberAndIndex func(ctx context.Context, blkNum types.EthUint64, txIndex types.EthUint64) (types.EthTx, error)                                           `perm:"read"`
-		EthGetTransactionByHash                func(ctx context.Context, txHash *types.EthHash) (*types.EthTx, error)                                                                    `perm:"read"`
-		EthGetTransactionByHashLimited         func(ctx context.Context, txHash *types.EthHash, limit abi.ChainEpoch) (*types.EthTx, error)                                              `perm:"read"`
-		EthGetTransactionCount                 func(ctx context.Context, sender types.EthAddress, blkParam types.EthBlockNumberOrHash) (types.EthUint64, error)                          `perm:"read"`
-		EthGetTransactionHashByCid             func(ctx context.Context, cid cid.Cid) (*types.EthHash, error)                                                                            `perm:"read"`
-		EthGetTransactionReceipt               func(ctx context.Context, txHash types.EthHash) (*types.EthTxReceipt, error)                                                              `perm:"read"`
-		EthGetTransactionReceiptLimited        func(ctx context.Context, txHash types.EthHash, limit abi.ChainEpoch) (*types.EthTxReceipt, error)                                        `perm:"read"`
-		EthMaxPriorityFeePerGas                func(ctx context.Context) (types.EthBigInt, error)                                                                                        `perm:"read"`
-		EthProtocolVersion                     func(ctx context.Context) (types.EthUint64, error)                                                                                        `perm:"read"`
-		EthSendRawTransaction                  func(ctx context.Context, rawTx types.EthBytes) (types.EthHash, error)                                                                    `perm:"read"`
-		EthSyncing                             func(ctx context.Context) (types.EthSyncingResult, error)                                                                                 `perm:"read"`
-		EthTraceBlock                          func(ctx context.Context, blkNum string) ([]*types.EthTraceBlock, error)                                                                  `perm:"read"`
-		EthTraceFilter                         func(ctx context.Context, filter types.EthTraceFilterCriteria) ([]*types.EthTraceFilterResult, error)                                     `perm:"read"`
-		EthTraceReplayBlockTransactions        func(ctx context.Context, blkNum string, traceTypes []string) ([]*types.EthTraceReplayBlockTransaction, error)                            `perm:"read"`
-		EthTraceTransaction                    func(ctx context.Context, txHash string) ([]*types.EthTraceTransaction, error)                                                            `perm:"read"`
-		FilecoinAddressToEthAddress            func(ctx context.Context, filecoinAddress address.Address) (types.EthAddress, error)                                                      `perm:"read"`
-		NetListening                           func(ctx context.Context) (bool, error)                                                                                                   `perm:"read"`
-		NetVersion                             func(ctx context.Context) (string, error)                                                                                                 `perm:"read"`
-		Web3ClientVersion                      func(ctx context.Context) (string, error)                                                                                                 `perm:"read"`
+		EthAccounts                            func(ctx context.Context) ([]types.EthAddress, error)                                                                                                        `perm:"read"`
+		EthAddressToFilecoinAddress            func(ctx context.Context, ethAddress types.EthAddress) (address.Address, error)                                                                              `perm:"read"`
+		EthBlockNumber                         func(ctx context.Context) (types.EthUint64, error)                                                                                                           `perm:"read"`
+		EthCall                                func(ctx context.Context, tx types.EthCall, blkParam types.EthBlockNumberOrHash) (types.EthBytes, error)                                                     `perm:"read"`
+		EthChainId                             func(ctx context.Context) (types.EthUint64, error)                                                                                                           `perm:"read"`
+		EthEstimateGas                         func(ctx context.Context, p jsonrpc.RawParams) (types.EthUint64, error)                                                                                      `perm:"read"`
+		EthFeeHistory                          func(ctx context.Context, p jsonrpc.RawParams) (types.EthFeeHistory, error)                                                                                  `perm:"read"`
+		EthGasPrice                            func(ctx context.Context) (types.EthBigInt, error)                                                                                                           `perm:"read"`
+		EthGetBalance                          func(ctx context.Context, address types.EthAddress, blkParam types.EthBlockNumberOrHash) (types.EthBigInt, error)                                            `perm:"read"`
+		EthGetBlockByHash                      func(ctx context.Context, blkHash types.EthHash, fullTxInfo bool) (types.EthBlock, error)                                                                    `perm:"read"`
+		EthGetBlockByNumber                    func(ctx context.Context, blkNum string, fullTxInfo bool) (types.EthBlock, error)                                                                            `perm:"read"`
+		EthGetBlockReceipts                    func(ctx context.Context, blkParam types.EthBlockNumberOrHash) ([]*types.EthTxReceipt, error)                                                                `perm:"read"`
+		EthGetBlockReceiptsLimited             func(ctx context.Context, blkParam types.EthBlockNumberOrHash, limit abi.ChainEpoch) ([]*types.EthTxReceipt, error)                                          `perm:"read"`
+		EthGetBlockTransactionCountByHash      func(ctx context.Context, blkHash types.EthHash) (types.EthUint64, error)                                                                                    `perm:"read"`
+		EthGetBlockTransactionCountByNumber    func(ctx context.Context, blkNum types.EthUint64) (types.EthUint64, error)                                                                                   `perm:"read"`
+		EthGetCode                             func(ctx context.Context, address types.EthAddress, blkParam types.EthBlockNumberOrHash) (types.EthBytes, error)                                             `perm:"read"`
+		EthGetContractCreation                 func(ctx context.Context, contract types.EthAddress) (*types.EthContractCreation, error)                                                                     `perm:"read"`
+		EthGetContractState                    func(ctx context.Context, address types.EthAddress, blkParam types.EthBlockNumberOrHash) (*types.EthContractState, error)                                    `perm:"read"`
+		EthGetContractStorage                  func(ctx context.Context, address types.EthAddress, after *types.EthHash, limit int, blkParam types.EthBlockNumberOrHash) (*types.EthContractStorage, error) `perm:"read"`
+		EthGetContractsByCreator               func(ctx context.Context, creator types.EthAddress, after *types.EthAddress, limit int) (*types.EthContractCreations, error)                                 `perm:"read"`
+		EthGetMessageCidByTransactionHash      func(ctx context.Context, txHash *types.EthHash) (*cid.Cid, error)                                                                                           `perm:"read"`
+		EthGetNFTHoldings                      func(ctx context.Context, owner types.EthAddress, collection *types.EthAddress) ([]types.EthNFTHolding, error)                                               `perm:"read"`
+		EthGetNFTOwners                        func(ctx context.Context, collection types.EthAddress, tokenID types.BigInt) ([]types.EthNFTHolding, error)                                                  `perm:"read"`
+		EthGetNFTTransfers                     func(ctx context.Context, collection types.EthAddress, tokenID *types.BigInt, after uint64, limit int) (*types.EthNFTTransfers, error)                       `perm:"read"`
+		EthGetProof                            func(ctx context.Context, address types.EthAddress, storageKeys []types.EthBytes, blkParam types.EthBlockNumberOrHash) (*types.EthProof, error)              `perm:"read"`
+		EthGetStorageAt                        func(ctx context.Context, address types.EthAddress, position types.EthBytes, blkParam types.EthBlockNumberOrHash) (types.EthBytes, error)                    `perm:"read"`
+		EthGetTokenBalanceHistory              func(ctx context.Context, holder types.EthAddress, token types.EthAddress) ([]types.EthTokenBalanceChange, error)                                            `perm:"read"`
+		EthGetTokenTransfers                   func(ctx context.Context, holder types.EthAddress, token *types.EthAddress, after uint64, limit int) (*types.EthTokenTransfers, error)                       `perm:"read"`
+		EthGetTransactionByBlockHashAndIndex   func(ctx context.Context, blkHash types.EthHash, txIndex types.EthUint64) (types.EthTx, error)                                                               `perm:"read"`
+		EthGetTransactionByBlockNumberAndIndex func(ctx context.Context, blkNum types.EthUint64, txIndex types.EthUint64) (types.EthTx, error)                                                              `perm:"read"`
+		EthGetTransactionByHash                func(ctx context.Context, txHash *types.EthHash) (*types.EthTx, error)                                                                                       `perm:"read"`
+		EthGetTransactionByHashLimited         func(ctx context.Context, txHash *types.EthHash, limit abi.ChainEpoch) (*types.EthTx, error)                                                                 `perm:"read"`
+		EthGetTransactionCount                 func(ctx context.Context, sender types.EthAddress, blkParam types.EthBlockNumberOrHash) (types.EthUint64, error)                                             `perm:"read"`
+		EthGetTransactionHashByCid             func(ctx context.Context, cid cid.Cid) (*types.EthHash, error)                                                                                               `perm:"read"`
+		EthGetTransactionReceipt               func(ctx context.Context, txHash types.EthHash) (*types.EthTxReceipt, error)                                                                                 `perm:"read"`
+		EthGetTransactionReceiptLimited        func(ctx context.Context, txHash types.EthHash, limit abi.ChainEpoch) (*types.EthTxReceipt, error)                                                           `perm:"read"`
+		EthMaxPriorityFeePerGas                func(ctx context.Context) (types.EthBigInt, error)                                                                                                           `perm:"read"`
+		EthNodeInfo                            func(ctx context.Context) (*types.EthNodeInfo, error)                                                                                                        `perm:"read"`
+		EthProtocolVersion                     func(ctx context.Context) (types.EthUint64, error)                                                                                                           `perm:"read"`
+		EthSendRawTransaction                  func(ctx context.Context, rawTx types.EthBytes) (types.EthHash, error)                                                                                       `perm:"read"`
+		EthSendRawTransactionUntrusted         func(ctx context.Context, rawTx types.EthBytes) (types.EthHash, error)                                                                                       `perm:"read"`
+		EthSyncing                             func(ctx context.Context) (types.EthSyncingResult, error)                                                                                                    `perm:"read"`
+		EthTraceBlock                          func(ctx context.Context, blkNum string) ([]*types.EthTraceBlock, error)                                                                                     `perm:"read"`
+		EthTraceFilter                         func(ctx context.Context, filter types.EthTraceFilterCriteria) ([]*types.EthTraceFilterResult, error)                                                        `perm:"read"`
+		EthTraceReplayBlockTransactions        func(ctx context.Context, blkNum string, traceTypes []string) ([]*types.EthTraceReplayBlockTransaction, error)                                               `perm:"read"`
+		EthTraceTransaction                    func(ctx context.Context, txHash string) ([]*types.EthTraceTransaction, error)                                                                               `perm:"read"`
+		FilecoinAddressToEthAddress            func(ctx context.Context, filecoinAddress address.Address) (types.EthAddress, error)                                                                         `perm:"read"`
+		NetListening                           func(ctx context.Context) (bool, error)                                                                                                                      `perm:"read"`
+		NetVersion                             func(ctx context.Context) (string, error)                                                                                                                    `perm:"read"`
+		Web3ClientVersion                      func(ctx context.Context) (string, error)                                                                                                                    `perm:"read"`
 	}
 }
 
@@ -967,12 +1298,42 @@ func (s *IETHStruct) EthGetBlockTransactionCountByNumber(p0 context.Context, p1
 func (s *IETHStruct) EthGetCode(p0 context.Context, p1 types.EthAddress, p2 types.EthBlockNumberOrHash) (types.EthBytes, error) {
 	return s.Internal.EthGetCode(p0, p1, p2)
 }
+func (s *IETHStruct) EthGetContractCreation(p0 context.Context, p1 types.EthAddress) (*types.EthContractCreation, error) {
+	return s.Internal.EthGetContractCreation(p0, p1)
+}
+func (s *IETHStruct) EthGetContractState(p0 context.Context, p1 types.EthAddress, p2 types.EthBlockNumberOrHash) (*types.EthContractState, error) {
+	return s.Internal.EthGetContractState(p0, p1, p2)
+}
+func (s *IETHStruct) EthGetContractStorage(p0 context.Context, p1 types.EthAddress, p2 *types.EthHash, p3 int, p4 types.EthBlockNumberOrHash) (*types.EthContractStorage, error) {
+	return s.Internal.EthGetContractStorage(p0, p1, p2, p3, p4)
+}
+func (s *IETHStruct) EthGetContractsByCreator(p0 context.Context, p1 types.EthAddress, p2 *types.EthAddress, p3 int) (*types.EthContractCreations, error) {
+	return s.Internal.EthGetContractsByCreator(p0, p1, p2, p3)
+}
 func (s *IETHStruct) EthGetMessageCidByTransactionHash(p0 context.Context, p1 *types.EthHash) (*cid.Cid, error) {
 	return s.Internal.EthGetMessageCidByTransactionHash(p0, p1)
 }
+func (s *IETHStruct) EthGetNFTHoldings(p0 context.Context, p1 types.EthAddress, p2 *types.EthAddress) ([]types.EthNFTHolding, error) {
+	return s.Internal.EthGetNFTHoldings(p0, p1, p2)
+}
+func (s *IETHStruct) EthGetNFTOwners(p0 context.Context, p1 types.EthAddress, p2 types.BigInt) ([]types.EthNFTHolding, error) {
+	return s.Internal.EthGetNFTOwners(p0, p1, p2)
+}
+func (s *IETHStruct) EthGetNFTTransfers(p0 context.Context, p1 types.EthAddress, p2 *types.BigInt, p3 uint64, p4 int) (*types.EthNFTTransfers, error) {
+	return s.Internal.EthGetNFTTransfers(p0, p1, p2, p3, p4)
+}
+func (s *IETHStruct) EthGetProof(p0 context.Context, p1 types.EthAddress, p2 []types.EthBytes, p3 types.EthBlockNumberOrHash) (*types.EthProof, error) {
+	return s.Internal.EthGetProof(p0, p1, p2, p3)
+}
 func (s *IETHStruct) EthGetStorageAt(p0 context.Context, p1 types.EthAddress, p2 types.EthBytes, p3 types.EthBlockNumberOrHash) (types.EthBytes, error) {
 	return s.Internal.EthGetStorageAt(p0, p1, p2, p3)
 }
+func (s *IETHStruct) EthGetTokenBalanceHistory(p0 context.Context, p1 types.EthAddress, p2 types.EthAddress) ([]types.EthTokenBalanceChange, error) {
+	return s.Internal.EthGetTokenBalanceHistory(p0, p1, p2)
+}
+func (s *IETHStruct) EthGetTokenTransfers(p0 context.Context, p1 types.EthAddress, p2 *types.EthAddress, p3 uint64, p4 int) (*types.EthTokenTransfers, error) {
+	return s.Internal.EthGetTokenTransfers(p0, p1, p2, p3, p4)
+}
 func (s *IETHStruct) EthGetTransactionByBlockHashAndIndex(p0 context.Context, p1 types.EthHash, p2 types.EthUint64) (types.EthTx, error) {
 	return s.Internal.EthGetTransactionByBlockHashAndIndex(p0, p1, p2)
 }
@@ -1000,12 +1361,18 @@ func (s *IETHStruct) EthGetTransactionReceiptLimited(p0 context.Context, p1 type
 func (s *IETHStruct) EthMaxPriorityFeePerGas(p0 context.Context) (types.EthBigInt, error) {
 	return s.Internal.EthMaxPriorityFeePerGas(p0)
 }
+func (s *IETHStruct) EthNodeInfo(p0 context.Context) (*types.EthNodeInfo, error) {
+	return s.Internal.EthNodeInfo(p0)
+}
 func (s *IETHStruct) EthProtocolVersion(p0 context.Context) (types.EthUint64, error) {
 	return s.Internal.EthProtocolVersion(p0)
 }
 func (s *IETHStruct) EthSendRawTransaction(p0 context.Context, p1 types.EthBytes) (types.EthHash, error) {
 	return s.Internal.EthSendRawTransaction(p0, p1)
 }
+func (s *IETHStruct) EthSendRawTransactionUntrusted(p0 context.Context, p1 types.EthBytes) (types.EthHash, error) {
+	return s.Internal.EthSendRawTransactionUntrusted(p0, p1)
+}
 func (s *IETHStruct) EthSyncing(p0 context.Context) (types.EthSyncingResult, error) {
 	return s.Internal.EthSyncing(p0)
 }
@@ -1034,18 +1401,28 @@ func (s *IETHStruct) Web3ClientVersion(p0 context.Context) (string, error) {
 
 type IETHEventStruct struct {
 	Internal struct {
-		EthGetFilterChanges            func(ctx context.Context, id types.EthFilterID) (*types.EthFilterResult, error)        `perm:"read"`
-		EthGetFilterLogs               func(ctx context.Context, id types.EthFilterID) (*types.EthFilterResult, error)        `perm:"read"`
-		EthGetLogs                     func(ctx context.Context, filter *types.EthFilterSpec) (*types.EthFilterResult, error) `perm:"read"`
-		EthNewBlockFilter              func(ctx context.Context) (types.EthFilterID, error)                                   `perm:"read"`
-		EthNewFilter                   func(ctx context.Context, filter *types.EthFilterSpec) (types.EthFilterID, error)      `perm:"read"`
-		EthNewPendingTransactionFilter func(ctx context.Context) (types.EthFilterID, error)                                   `perm:"read"`
-		EthSubscribe                   func(ctx context.Context, params jsonrpc.RawParams) (types.EthSubscriptionID, error)   `perm:"read"`
-		EthUninstallFilter             func(ctx context.Context, id types.EthFilterID) (bool, error)                          `perm:"read"`
-		EthUnsubscribe                 func(ctx context.Context, id types.EthSubscriptionID) (bool, error)                    `perm:"read"`
+		EthEventsBackfill              func(ctx context.Context, fromEpoch, toEpoch abi.ChainEpoch) (*types.EthEventsBackfillResult, error) `perm:"admin"`
+		EthFilterStatus                func(ctx context.Context, id types.EthFilterID) (*types.EthFilterInfo, error)                        `perm:"admin"`
+		EthGetFilterChanges            func(ctx context.Context, id types.EthFilterID) (*types.EthFilterResult, error)                      `perm:"read"`
+		EthGetFilterLogs               func(ctx context.Context, id types.EthFilterID) (*types.EthFilterResult, error)                      `perm:"read"`
+		EthGetLogs                     func(ctx context.Context, filter *types.EthFilterSpec) (*types.EthFilterResult, error)               `perm:"read"`
+		EthListFilters                 func(ctx context.Context) ([]types.EthFilterInfo, error)                                             `perm:"admin"`
+		EthListSubscriptions           func(ctx context.Context) ([]types.EthSubscriptionInfo, error)                                       `perm:"admin"`
+		EthNewBlockFilter              func(ctx context.Context) (types.EthFilterID, error)                                                 `perm:"read"`
+		EthNewFilter                   func(ctx context.Context, filter *types.EthFilterSpec) (types.EthFilterID, error)                    `perm:"read"`
+		EthNewPendingTransactionFilter func(ctx context.Context) (types.EthFilterID, error)                                                 `perm:"read"`
+		EthSubscribe                   func(ctx context.Context, params jsonrpc.RawParams) (types.EthSubscriptionID, error)                 `perm:"read"`
+		EthUninstallFilter             func(ctx context.Context, id types.EthFilterID) (bool, error)                                        `perm:"read"`
+		EthUnsubscribe                 func(ctx context.Context, id types.EthSubscriptionID) (bool, error)                                  `perm:"read"`
 	}
 }
 
+func (s *IETHEventStruct) EthEventsBackfill(p0 context.Context, p1, p2 abi.ChainEpoch) (*types.EthEventsBackfillResult, error) {
+	return s.Internal.EthEventsBackfill(p0, p1, p2)
+}
+func (s *IETHEventStruct) EthFilterStatus(p0 context.Context, p1 types.EthFilterID) (*types.EthFilterInfo, error) {
+	return s.Internal.EthFilterStatus(p0, p1)
+}
 func (s *IETHEventStruct) EthGetFilterChanges(p0 context.Context, p1 types.EthFilterID) (*types.EthFilterResult, error) {
 	return s.Internal.EthGetFilterChanges(p0, p1)
 }
@@ -1055,6 +1432,12 @@ func (s *IETHEventStruct) EthGetFilterLogs(p0 context.Context, p1 types.EthFilte
 func (s *IETHEventStruct) EthGetLogs(p0 context.Context, p1 *types.EthFilterSpec) (*types.EthFilterResult, error) {
 	return s.Internal.EthGetLogs(p0, p1)
 }
+func (s *IETHEventStruct) EthListFilters(p0 context.Context) ([]types.EthFilterInfo, error) {
+	return s.Internal.EthListFilters(p0)
+}
+func (s *IETHEventStruct) EthListSubscriptions(p0 context.Context) ([]types.EthSubscriptionInfo, error) {
+	return s.Internal.EthListSubscriptions(p0)
+}
 func (s *IETHEventStruct) EthNewBlockFilter(p0 context.Context) (types.EthFilterID, error) {
 	return s.Internal.EthNewBlockFilter(p0)
 }
@@ -1082,16 +1465,24 @@ type FullETHStruct struct {
 type IActorEventStruct struct {
 	Internal struct {
 		GetActorEventsRaw       func(ctx context.Context, filter *types.ActorEventFilter) ([]*types.ActorEvent, error)      `perm:"read"`
+		GetDealEvents           func(ctx context.Context, filter *types.DealEventFilter) ([]*types.DealEvent, error)        `perm:"read"`
 		SubscribeActorEventsRaw func(ctx context.Context, filter *types.ActorEventFilter) (<-chan *types.ActorEvent, error) `perm:"read"`
+		SubscribeDealEvents     func(ctx context.Context, filter *types.DealEventFilter) (<-chan *types.DealEvent, error)   `perm:"read"`
 	}
 }
 
 func (s *IActorEventStruct) GetActorEventsRaw(p0 context.Context, p1 *types.ActorEventFilter) ([]*types.ActorEvent, error) {
 	return s.Internal.GetActorEventsRaw(p0, p1)
 }
+func (s *IActorEventStruct) GetDealEvents(p0 context.Context, p1 *types.DealEventFilter) ([]*types.DealEvent, error) {
+	return s.Internal.GetDealEvents(p0, p1)
+}
 func (s *IActorEventStruct) SubscribeActorEventsRaw(p0 context.Context, p1 *types.ActorEventFilter) (<-chan *types.ActorEvent, error) {
 	return s.Internal.SubscribeActorEventsRaw(p0, p1)
 }
+func (s *IActorEventStruct) SubscribeDealEvents(p0 context.Context, p1 *types.DealEventFilter) (<-chan *types.DealEvent, error) {
+	return s.Internal.SubscribeDealEvents(p0, p1)
+}
 
 type IF3Struct struct {
 	Internal struct {
@@ -21,4 +21,12 @@ type ISyncer interface {
 	SyncIncomingBlocks(ctx context.Context) (<-chan *types.BlockHeader, error) //perm:read
 	// SyncCheckpoint marks a blocks as checkpointed, meaning that it won't ever fork away from it.
 	SyncCheckpoint(ctx context.Context, tsk types.TipSetKey) error //perm:admin
+	// SyncValidateBlockHeader checks a block header the way the syncer checks the blocks it receives, except the
+	// checks of the messages: the parent weight and state, the timestamp, the ticket, the beacon entries, the election and the
+	// winning PoSt, and the signature. It lets block producers check their candidate blocks before submitting them.
+	SyncValidateBlockHeader(ctx context.Context, bh *types.BlockHeader) (*types.BlockHeaderValidation, error) //perm:read
+	// SyncSubmitBlockChecked validates an externally mined block like SyncValidateBlockHeader, with its messages
+	// matching the header, before submitting it like SyncSubmitBlock. An invalid block is not submitted, nor a valid
+	// one when dryRun is set, so it can be checked before it is broadcast.
+	SyncSubmitBlockChecked(ctx context.Context, blk *types.BlockMsg, dryRun bool) (*types.BlockHeaderValidation, error) //perm:write
 }
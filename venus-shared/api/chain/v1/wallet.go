@@ -2,6 +2,7 @@ package v1
 
 import (
 	"context"
+	"time"
 
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
@@ -17,14 +18,41 @@ type IWallet interface {
 	WalletDelete(ctx context.Context, addr address.Address) error                                                 //perm:admin
 	WalletHas(ctx context.Context, addr address.Address) (bool, error)                                            //perm:write
 	WalletNewAddress(ctx context.Context, protocol address.Protocol) (address.Address, error)                     //perm:write
-	WalletBalance(ctx context.Context, addr address.Address) (abi.TokenAmount, error)                             //perm:read
-	WalletDefaultAddress(ctx context.Context) (address.Address, error)                                            //perm:write
-	WalletAddresses(ctx context.Context) []address.Address                                                        //perm:admin
-	WalletSetDefault(ctx context.Context, addr address.Address) error                                             //perm:write
-	WalletSignMessage(ctx context.Context, k address.Address, msg *types.Message) (*types.SignedMessage, error)   //perm:sign
-	LockWallet(ctx context.Context) error                                                                         //perm:admin
-	UnLockWallet(ctx context.Context, password []byte) error                                                      //perm:admin
-	SetPassword(ctx context.Context, password []byte) error                                                       //perm:admin
-	HasPassword(ctx context.Context) bool                                                                         //perm:admin
-	WalletState(ctx context.Context) int                                                                          //perm:admin
+	// WalletNew creates an address of the given key type, `secp256k1-ledger` derives it on a ledger device
+	WalletNew(ctx context.Context, kt types.KeyType) (address.Address, error)                                   //perm:write
+	WalletBalance(ctx context.Context, addr address.Address) (abi.TokenAmount, error)                           //perm:read
+	WalletDefaultAddress(ctx context.Context) (address.Address, error)                                          //perm:write
+	WalletAddresses(ctx context.Context) []address.Address                                                      //perm:admin
+	WalletSetDefault(ctx context.Context, addr address.Address) error                                           //perm:write
+	WalletSignMessage(ctx context.Context, k address.Address, msg *types.Message) (*types.SignedMessage, error) //perm:sign
+	LockWallet(ctx context.Context) error                                                                       //perm:admin
+	UnLockWallet(ctx context.Context, password []byte) error                                                    //perm:admin
+	SetPassword(ctx context.Context, password []byte) error                                                     //perm:admin
+	HasPassword(ctx context.Context) bool                                                                       //perm:admin
+	WalletState(ctx context.Context) int                                                                        //perm:admin
+	// WalletLock drops every decrypted key from memory until the wallet is unlocked again
+	WalletLock(ctx context.Context) error //perm:admin
+	// WalletUnlock decrypts the keystore with password and locks it again after timeout,
+	// a zero timeout uses the configured auto lock timeout and a negative one never locks
+	WalletUnlock(ctx context.Context, password []byte, timeout time.Duration) error //perm:admin
+	// WalletSetPassword sets the passphrase used to encrypt keys at rest
+	WalletSetPassword(ctx context.Context, password []byte) error //perm:admin
+	// WalletNewDelegatedFromSecp creates the f410 address controlled by the private key of a secp256k1 address
+	WalletNewDelegatedFromSecp(ctx context.Context, addr address.Address) (address.Address, error) //perm:admin
+	// WalletSignEthTransaction signs an EIP-1559 transaction with the delegated key behind `from`
+	// and returns the RLP encoded signed transaction, ready for eth_sendRawTransaction
+	WalletSignEthTransaction(ctx context.Context, from types.EthAddress, tx *types.Eth1559TxArgs) (types.EthBytes, error) //perm:sign
+	// AddressBookSet names addr label in the address book of the node, the text outputs of the
+	// commands show the label instead of the address
+	AddressBookSet(ctx context.Context, label string, addr address.Address) error //perm:write
+	// AddressBookRemove removes label from the address book
+	AddressBookRemove(ctx context.Context, label string) error //perm:write
+	// AddressBookList returns the addresses of the address book, by label
+	AddressBookList(ctx context.Context) (map[string]address.Address, error) //perm:read
+	// WalletSignBatch signs each message with its sender in one call, a message failing to be signed
+	// has the error in its result, the purpose and metadata of the messages are logged to the audit log
+	WalletSignBatch(ctx context.Context, requests []types.WalletSignRequest) ([]types.WalletSignResult, error) //perm:sign
+	// WalletAuditLog returns up to limit records of the signing audit log matching filter, following the
+	// record after or from the first one when after is 0
+	WalletAuditLog(ctx context.Context, filter types.SignAuditFilter, after uint64, limit int) (*types.SignAuditPage, error) //perm:admin
 }
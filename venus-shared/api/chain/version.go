@@ -7,3 +7,25 @@ var (
 	FullAPIVersion0 = types.NewVer(1, 5, 0)
 	FullAPIVersion1 = types.NewVer(2, 3, 0)
 )
+
+// the paths the versions of the rpc api are served on
+const (
+	FullAPIPath0 = "/rpc/v0"
+	FullAPIPath1 = "/rpc/v1"
+)
+
+// FullAPIDeprecations0 are the deprecated methods of the v0 rpc api, which is served by shims over the v1 api.
+var FullAPIDeprecations0 = []types.APIDeprecation{
+	{Method: "BeaconGetEntry", Replacement: "StateGetBeaconEntry"},
+	{Method: "ChainGetRandomnessFromBeacon", Replacement: "StateGetRandomnessFromBeacon"},
+	{Method: "ChainGetRandomnessFromTickets", Replacement: "StateGetRandomnessFromTickets"},
+	{Method: "StateGetReceipt", Replacement: "StateSearchMsg"},
+	{Method: "StateSearchMsgLimited", Replacement: "StateSearchMsg", Notice: "the lookback limit is a parameter of StateSearchMsg"},
+	{Method: "StateWaitMsgLimited", Replacement: "StateWaitMsg", Notice: "the lookback limit is a parameter of StateWaitMsg"},
+	{Method: "StateMinerInitialPledgeCollateral", Replacement: "StateMinerInitialPledgeForSector", Notice: "the deal ids of the precommit can not determine the verified deal space since network version 22"},
+}
+
+// FullAPIDeprecations1 are the deprecated methods of the v1 rpc api.
+var FullAPIDeprecations1 = []types.APIDeprecation{
+	{Method: "StateMinerInitialPledgeCollateral", Replacement: "StateMinerInitialPledgeForSector", Notice: "the deal ids of the precommit can not determine the verified deal space since network version 22"},
+}
@@ -0,0 +1,147 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	logging "github.com/ipfs/go-log/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+var log = logging.Logger("grpcapi")
+
+// Client calls the methods of the Services over a gRPC connection.
+type Client struct {
+	cc  grpc.ClientConnInterface
+	enc Encoding
+}
+
+// NewClient returns a client encoding the params and the results with enc.
+func NewClient(cc grpc.ClientConnInterface, enc Encoding) *Client {
+	return &Client{cc: cc, enc: enc}
+}
+
+func findMethod(name string) (*Service, *Method, error) {
+	for _, svc := range Services {
+		for i := range svc.Methods {
+			if svc.Methods[i].Name == name {
+				return svc, &svc.Methods[i], nil
+			}
+		}
+	}
+	return nil, nil, fmt.Errorf("no grpc method %s", name)
+}
+
+func (c *Client) request(m *Method, params []interface{}) (*dynamicpb.Message, error) {
+	if len(params) != len(m.Params) {
+		return nil, fmt.Errorf("%s expects %d params, got %d", m.Name, len(m.Params), len(params))
+	}
+	encoded := make([][]byte, len(params))
+	for i, p := range params {
+		b, err := encode(c.enc, m.Params[i], p)
+		if err != nil {
+			return nil, fmt.Errorf("encoding param %d: %w", i, err)
+		}
+		encoded[i] = b
+	}
+	return newRequest(c.enc, encoded), nil
+}
+
+// Call calls the method with params and decodes its result into result, a pointer to the result type of the
+// method, nil for the methods only returning an error.
+func (c *Client) Call(ctx context.Context, method string, result interface{}, params ...interface{}) error {
+	svc, m, err := findMethod(method)
+	if err != nil {
+		return err
+	}
+	if m.Stream {
+		return fmt.Errorf("%s is a stream, use Subscribe", method)
+	}
+	req, err := c.request(m, params)
+	if err != nil {
+		return err
+	}
+
+	resp := dynamicpb.NewMessage(responseDesc)
+	if err := c.cc.Invoke(ctx, svc.FullMethod(m), req, resp); err != nil {
+		return err
+	}
+	if result == nil || m.Result == nil {
+		return nil
+	}
+	return setResult(c.enc, m, responseResult(resp), result)
+}
+
+// Subscribe calls the stream method with params, out is a channel of the element type of the channel returned by
+// the method, it is closed when the stream ends.
+func (c *Client) Subscribe(ctx context.Context, method string, out interface{}, params ...interface{}) error {
+	svc, m, err := findMethod(method)
+	if err != nil {
+		return err
+	}
+	if !m.Stream {
+		return fmt.Errorf("%s is not a stream, use Call", method)
+	}
+	ch := reflect.ValueOf(out)
+	if ch.Kind() != reflect.Chan || ch.Type().Elem() != m.Result {
+		return fmt.Errorf("out must be a channel of %s", m.Result)
+	}
+	req, err := c.request(m, params)
+	if err != nil {
+		return err
+	}
+
+	stream, err := c.cc.NewStream(ctx, &grpc.StreamDesc{StreamName: m.Name, ServerStreams: true}, svc.FullMethod(m))
+	if err != nil {
+		return err
+	}
+	if err := stream.SendMsg(req); err != nil {
+		return err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return err
+	}
+
+	go func() {
+		defer ch.Close()
+		for {
+			resp := dynamicpb.NewMessage(responseDesc)
+			if err := stream.RecvMsg(resp); err != nil {
+				if !errors.Is(err, io.EOF) && ctx.Err() == nil {
+					log.Warnf("receiving from %s: %v", method, err)
+				}
+				return
+			}
+			v, err := decode(c.enc, m.Result, responseResult(resp))
+			if err != nil {
+				log.Warnf("decoding the result of %s: %v", method, err)
+				return
+			}
+			chosen, _, _ := reflect.Select([]reflect.SelectCase{
+				{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+				{Dir: reflect.SelectSend, Chan: ch, Send: v},
+			})
+			if chosen == 0 {
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func setResult(enc Encoding, m *Method, data []byte, result interface{}) error {
+	rv := reflect.ValueOf(result)
+	if rv.Kind() != reflect.Ptr || rv.Type().Elem() != m.Result {
+		return fmt.Errorf("result must be a *%s", m.Result)
+	}
+	v, err := decode(enc, m.Result, data)
+	if err != nil {
+		return fmt.Errorf("decoding the result: %w", err)
+	}
+	rv.Elem().Set(v)
+	return nil
+}
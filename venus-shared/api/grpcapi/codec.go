@@ -0,0 +1,123 @@
+package grpcapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+var (
+	paramsField   = requestDesc.Fields().ByName("params")
+	encodingField = requestDesc.Fields().ByName("encoding")
+	resultField   = responseDesc.Fields().ByName("result")
+)
+
+var (
+	cborMarshalerType   = reflect.TypeOf((*cbg.CBORMarshaler)(nil)).Elem()
+	cborUnmarshalerType = reflect.TypeOf((*cbg.CBORUnmarshaler)(nil)).Elem()
+)
+
+// hasCBOR tells whether the values of typ have a cbor encoding, the cbor marshalers being implemented by the
+// pointers.
+func hasCBOR(typ reflect.Type) bool {
+	ptr := typ
+	if typ.Kind() != reflect.Ptr {
+		ptr = reflect.PointerTo(typ)
+	}
+	return ptr.Implements(cborMarshalerType) && ptr.Implements(cborUnmarshalerType)
+}
+
+// encode encodes v, whose type is typ, with enc, the values without cbor encoding are encoded in JSON.
+func encode(enc Encoding, typ reflect.Type, v interface{}) ([]byte, error) {
+	switch enc {
+	case EncodingJSON:
+		return json.Marshal(v)
+	case EncodingCBOR:
+		if !hasCBOR(typ) {
+			return json.Marshal(v)
+		}
+		rv := reflect.ValueOf(v)
+		if !rv.IsValid() {
+			rv = reflect.Zero(typ)
+		}
+		if typ.Kind() != reflect.Ptr {
+			ptr := reflect.New(typ)
+			ptr.Elem().Set(rv)
+			rv = ptr
+		} else if rv.IsNil() {
+			return nil, nil
+		}
+		var buf bytes.Buffer
+		if err := rv.Interface().(cbg.CBORMarshaler).MarshalCBOR(&buf); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unknown encoding %s", enc)
+	}
+}
+
+// decode decodes data into a new value of typ with enc.
+func decode(enc Encoding, typ reflect.Type, data []byte) (reflect.Value, error) {
+	if enc != EncodingJSON && enc != EncodingCBOR {
+		return reflect.Value{}, fmt.Errorf("unknown encoding %s", enc)
+	}
+
+	if enc == EncodingCBOR && hasCBOR(typ) {
+		if typ.Kind() == reflect.Ptr {
+			if len(data) == 0 {
+				return reflect.Zero(typ), nil
+			}
+			v := reflect.New(typ.Elem())
+			if err := v.Interface().(cbg.CBORUnmarshaler).UnmarshalCBOR(bytes.NewReader(data)); err != nil {
+				return reflect.Value{}, err
+			}
+			return v, nil
+		}
+		ptr := reflect.New(typ)
+		if err := ptr.Interface().(cbg.CBORUnmarshaler).UnmarshalCBOR(bytes.NewReader(data)); err != nil {
+			return reflect.Value{}, err
+		}
+		return ptr.Elem(), nil
+	}
+
+	ptr := reflect.New(typ)
+	if err := json.Unmarshal(data, ptr.Interface()); err != nil {
+		return reflect.Value{}, err
+	}
+	return ptr.Elem(), nil
+}
+
+func newRequest(enc Encoding, params [][]byte) *dynamicpb.Message {
+	req := dynamicpb.NewMessage(requestDesc)
+	list := req.Mutable(paramsField).List()
+	for _, p := range params {
+		list.Append(protoreflect.ValueOfBytes(p))
+	}
+	req.Set(encodingField, protoreflect.ValueOfEnum(protoreflect.EnumNumber(enc)))
+	return req
+}
+
+func requestParams(req *dynamicpb.Message) (Encoding, [][]byte) {
+	list := req.Get(paramsField).List()
+	params := make([][]byte, list.Len())
+	for i := range params {
+		params[i] = list.Get(i).Bytes()
+	}
+	return Encoding(req.Get(encodingField).Enum()), params
+}
+
+func newResponse(result []byte) *dynamicpb.Message {
+	resp := dynamicpb.NewMessage(responseDesc)
+	resp.Set(resultField, protoreflect.ValueOfBytes(result))
+	return resp
+}
+
+func responseResult(resp *dynamicpb.Message) []byte {
+	return resp.Get(resultField).Bytes()
+}
@@ -0,0 +1,232 @@
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
+)
+
+// ProtoPackage is the protobuf package of the services.
+const ProtoPackage = "venus.v1"
+
+const protoFileName = "venus/v1/api.proto"
+
+// Encoding is the encoding of the params and the results of the calls.
+type Encoding int32
+
+const (
+	// EncodingJSON encodes the values as the JSON-RPC api does
+	EncodingJSON Encoding = 0
+	// EncodingCBOR encodes the values having a cbor encoding, like the blocks, messages and receipts, with their
+	// cbor marshalers, and the others in JSON
+	EncodingCBOR Encoding = 1
+)
+
+func (e Encoding) String() string {
+	switch e {
+	case EncodingJSON:
+		return "JSON"
+	case EncodingCBOR:
+		return "CBOR"
+	default:
+		return fmt.Sprintf("Encoding(%d)", int32(e))
+	}
+}
+
+// Service is a gRPC service exposing the methods of an api interface.
+type Service struct {
+	Name    string
+	Methods []Method
+}
+
+// Method is a method of a service, it takes a Request and returns a Response, or a stream of Response when the
+// method of the api returns a channel.
+type Method struct {
+	Name   string
+	Params []reflect.Type
+	// Result is nil for the methods only returning an error
+	Result reflect.Type
+	// HasError tells whether the method of the api returns an error
+	HasError bool
+	Stream   bool
+}
+
+// FullMethod returns the gRPC path of m in svc.
+func (svc *Service) FullMethod(m *Method) string {
+	return "/" + ProtoPackage + "." + svc.Name + "/" + m.Name
+}
+
+// Services are the services of the gRPC api: the chain and state methods, and the message pool methods of the v1 api.
+var Services = []*Service{
+	newService("Chain", reflect.TypeOf((*v1api.IChain)(nil)).Elem()),
+	newService("MessagePool", reflect.TypeOf((*v1api.IMessagePool)(nil)).Elem()),
+}
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+func newService(name string, api reflect.Type) *Service {
+	svc := &Service{Name: name}
+	for i := 0; i < api.NumMethod(); i++ {
+		m, ok := newMethod(api.Method(i))
+		if ok {
+			svc.Methods = append(svc.Methods, m)
+		}
+	}
+	sort.Slice(svc.Methods, func(i, j int) bool { return svc.Methods[i].Name < svc.Methods[j].Name })
+	return svc
+}
+
+// newMethod returns the gRPC method of m, the methods without context are not exposed.
+func newMethod(m reflect.Method) (Method, bool) {
+	ft := m.Type
+	if ft.NumIn() == 0 || ft.In(0) != contextType || ft.IsVariadic() || ft.NumOut() == 0 || ft.NumOut() > 2 {
+		return Method{}, false
+	}
+
+	method := Method{Name: m.Name}
+	for i := 1; i < ft.NumIn(); i++ {
+		method.Params = append(method.Params, ft.In(i))
+	}
+	outs := ft.NumOut()
+	if ft.Out(outs-1) == errorType {
+		method.HasError = true
+		outs--
+	}
+	if outs == 1 {
+		method.Result = ft.Out(0)
+		if method.Result.Kind() == reflect.Chan {
+			method.Stream = true
+			method.Result = method.Result.Elem()
+		}
+	}
+	return method, true
+}
+
+var (
+	fileDescriptor = mustNewFile()
+	requestDesc    = fileDescriptor.Messages().ByName("Request")
+	responseDesc   = fileDescriptor.Messages().ByName("Response")
+)
+
+func mustNewFile() protoreflect.FileDescriptor {
+	fd, err := protodesc.NewFile(fileDescriptorProto(), nil)
+	if err != nil {
+		panic(fmt.Errorf("building the descriptor of the grpc api: %w", err))
+	}
+	return fd
+}
+
+// fileDescriptorProto describes the services, every method takes the encoded params in a Request and returns the
+// encoded result in a Response.
+func fileDescriptorProto() *descriptorpb.FileDescriptorProto {
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:    strPtr(protoFileName),
+		Package: strPtr(ProtoPackage),
+		Syntax:  strPtr("proto3"),
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name: strPtr("Encoding"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: strPtr(EncodingJSON.String()), Number: int32Ptr(int32(EncodingJSON))},
+				{Name: strPtr(EncodingCBOR.String()), Number: int32Ptr(int32(EncodingCBOR))},
+			},
+		}},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: strPtr("Request"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("params", 1, descriptorpb.FieldDescriptorProto_TYPE_BYTES, descriptorpb.FieldDescriptorProto_LABEL_REPEATED),
+					enumField("encoding", 2, "."+ProtoPackage+".Encoding"),
+				},
+			},
+			{
+				Name: strPtr("Response"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("result", 1, descriptorpb.FieldDescriptorProto_TYPE_BYTES, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL),
+				},
+			},
+		},
+	}
+
+	for _, svc := range Services {
+		sdp := &descriptorpb.ServiceDescriptorProto{Name: strPtr(svc.Name)}
+		for _, m := range svc.Methods {
+			sdp.Method = append(sdp.Method, &descriptorpb.MethodDescriptorProto{
+				Name:            strPtr(m.Name),
+				InputType:       strPtr("." + ProtoPackage + ".Request"),
+				OutputType:      strPtr("." + ProtoPackage + ".Response"),
+				ServerStreaming: boolPtr(m.Stream),
+			})
+		}
+		fdp.Service = append(fdp.Service, sdp)
+	}
+
+	return fdp
+}
+
+// ProtoFile returns the protobuf definitions of the services, the clients in other languages generate their stubs
+// from it.
+func ProtoFile() string {
+	var sb strings.Builder
+	sb.WriteString("// Code generated by github.com/filecoin-project/venus/venus-devtool/api-gen. DO NOT EDIT.\n\n")
+	sb.WriteString("syntax = \"proto3\";\n\n")
+	fmt.Fprintf(&sb, "package %s;\n\n", ProtoPackage)
+
+	sb.WriteString("// Encoding is the encoding of the params and the result: JSON as the JSON-RPC api, or CBOR for the\n")
+	sb.WriteString("// types having a cbor encoding, like the blocks, messages and receipts, and JSON for the others.\n")
+	sb.WriteString("enum Encoding {\n")
+	fmt.Fprintf(&sb, "  %s = %d;\n", EncodingJSON, EncodingJSON)
+	fmt.Fprintf(&sb, "  %s = %d;\n", EncodingCBOR, EncodingCBOR)
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("// Request holds the params of the method of the JSON-RPC api, without the context, in their order.\n")
+	sb.WriteString("message Request {\n  repeated bytes params = 1;\n  Encoding encoding = 2;\n}\n\n")
+	sb.WriteString("// Response holds the result of the method, empty for the methods only returning an error.\n")
+	sb.WriteString("message Response {\n  bytes result = 1;\n}\n")
+
+	for _, svc := range Services {
+		fmt.Fprintf(&sb, "\nservice %s {\n", svc.Name)
+		for _, m := range svc.Methods {
+			stream := ""
+			if m.Stream {
+				stream = "stream "
+			}
+			fmt.Fprintf(&sb, "  rpc %s(Request) returns (%sResponse);\n", m.Name, stream)
+		}
+		sb.WriteString("}\n")
+	}
+
+	return sb.String()
+}
+
+func field(name string, num int32, typ descriptorpb.FieldDescriptorProto_Type, label descriptorpb.FieldDescriptorProto_Label) *descriptorpb.FieldDescriptorProto {
+	return &descriptorpb.FieldDescriptorProto{
+		Name:     strPtr(name),
+		JsonName: strPtr(name),
+		Number:   int32Ptr(num),
+		Type:     typ.Enum(),
+		Label:    label.Enum(),
+	}
+}
+
+func enumField(name string, num int32, typeName string) *descriptorpb.FieldDescriptorProto {
+	f := field(name, num, descriptorpb.FieldDescriptorProto_TYPE_ENUM, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL)
+	f.TypeName = strPtr(typeName)
+	return f
+}
+
+func strPtr(s string) *string { return &s }
+
+func int32Ptr(i int32) *int32 { return &i }
+
+func boolPtr(b bool) *bool { return &b }
@@ -0,0 +1,94 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
+	"github.com/filecoin-project/venus/venus-shared/testutil"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func newTestConn(t *testing.T, impl *v1api.FullNodeStruct) *grpc.ClientConn {
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	require.NoError(t, Register(srv, impl))
+	go srv.Serve(lis) // nolint: errcheck
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func TestGRPCAPI(t *testing.T) {
+	tf.UnitTest(t)
+
+	var bh types.BlockHeader
+	testutil.Provide(t, &bh, testutil.IntRangedProvider(0, 1<<48))
+	ts, err := types.NewTipSet([]*types.BlockHeader{&bh})
+	require.NoError(t, err)
+
+	var impl v1api.FullNodeStruct
+	impl.IChainInfoStruct.Internal.ChainGetTipSetByHeight = func(ctx context.Context, h abi.ChainEpoch, tsk types.TipSetKey) (*types.TipSet, error) {
+		if h != ts.Height() || !tsk.IsEmpty() {
+			return nil, types.NewTipSetNotFoundError("no tipset at %d", h)
+		}
+		return ts, nil
+	}
+	impl.IChainInfoStruct.Internal.ChainNotify = func(ctx context.Context) (<-chan []*types.HeadChange, error) {
+		ch := make(chan []*types.HeadChange, 1)
+		ch <- []*types.HeadChange{{Type: types.HCCurrent, Val: ts}}
+		close(ch)
+		return ch, nil
+	}
+	conn := newTestConn(t, &impl)
+	ctx := context.Background()
+
+	for _, enc := range []Encoding{EncodingJSON, EncodingCBOR} {
+		client := NewClient(conn, enc)
+
+		var got *types.TipSet
+		require.NoError(t, client.Call(ctx, "ChainGetTipSetByHeight", &got, ts.Height(), types.EmptyTSK), enc)
+		require.True(t, ts.Equals(got), enc)
+
+		err = client.Call(ctx, "ChainGetTipSetByHeight", &got, ts.Height()+1, types.EmptyTSK)
+		require.Equal(t, codes.NotFound, status.Code(err), enc)
+
+		err = client.Call(ctx, "ChainGetTipSetByHeight", &got, ts.Height())
+		require.Error(t, err, enc)
+
+		changes := make(chan []*types.HeadChange)
+		require.NoError(t, client.Subscribe(ctx, "ChainNotify", changes), enc)
+		var received [][]*types.HeadChange
+		for c := range changes {
+			received = append(received, c)
+		}
+		require.Len(t, received, 1, enc)
+		require.Equal(t, types.HCCurrent, received[0][0].Type)
+		require.True(t, ts.Equals(received[0][0].Val), enc)
+	}
+}
+
+func TestProtoFileUpToDate(t *testing.T) {
+	tf.UnitTest(t)
+
+	data, err := os.ReadFile("api.proto")
+	require.NoError(t, err)
+	require.Equal(t, ProtoFile(), string(data), "api.proto is stale, regenerate it with `make api-gen`")
+}
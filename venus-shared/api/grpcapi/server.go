@@ -0,0 +1,162 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/filecoin-project/go-jsonrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// Register registers the Services on s, the calls are served by the methods of impl, which implements the v1 api.
+func Register(s grpc.ServiceRegistrar, impl interface{}) error {
+	rv := reflect.ValueOf(impl)
+	for _, svc := range Services {
+		desc := &grpc.ServiceDesc{
+			ServiceName: ProtoPackage + "." + svc.Name,
+			HandlerType: (*interface{})(nil),
+			Metadata:    protoFileName,
+		}
+		for i := range svc.Methods {
+			m := &svc.Methods[i]
+			fn := rv.MethodByName(m.Name)
+			if !fn.IsValid() {
+				return fmt.Errorf("%T has no method %s", impl, m.Name)
+			}
+			if m.Stream {
+				desc.Streams = append(desc.Streams, grpc.StreamDesc{
+					StreamName:    m.Name,
+					Handler:       streamHandler(m, fn),
+					ServerStreams: true,
+				})
+			} else {
+				desc.Methods = append(desc.Methods, grpc.MethodDesc{
+					MethodName: m.Name,
+					Handler:    unaryHandler(svc, m, fn),
+				})
+			}
+		}
+		s.RegisterService(desc, impl)
+	}
+	return nil
+}
+
+func unaryHandler(svc *Service, m *Method, fn reflect.Value) func(interface{}, context.Context, func(interface{}) error, grpc.UnaryServerInterceptor) (interface{}, error) {
+	call := func(ctx context.Context, req interface{}) (interface{}, error) {
+		enc, outs, err := invoke(ctx, m, fn, req.(*dynamicpb.Message))
+		if err != nil {
+			return nil, err
+		}
+		var result []byte
+		if m.Result != nil {
+			if result, err = encode(enc, m.Result, outs[0].Interface()); err != nil {
+				return nil, status.Errorf(codes.Internal, "encoding the result: %v", err)
+			}
+		}
+		return newResponse(result), nil
+	}
+
+	return func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+		req := dynamicpb.NewMessage(requestDesc)
+		if err := dec(req); err != nil {
+			return nil, err
+		}
+		if interceptor == nil {
+			return call(ctx, req)
+		}
+		return interceptor(ctx, req, &grpc.UnaryServerInfo{Server: srv, FullMethod: svc.FullMethod(m)}, call)
+	}
+}
+
+func streamHandler(m *Method, fn reflect.Value) grpc.StreamHandler {
+	return func(_ interface{}, stream grpc.ServerStream) error {
+		req := dynamicpb.NewMessage(requestDesc)
+		if err := stream.RecvMsg(req); err != nil {
+			return err
+		}
+		ctx := stream.Context()
+		enc, outs, err := invoke(ctx, m, fn, req)
+		if err != nil {
+			return err
+		}
+
+		ch := outs[0]
+		if ch.IsNil() {
+			return nil
+		}
+		cases := []reflect.SelectCase{
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+			{Dir: reflect.SelectRecv, Chan: ch},
+		}
+		for {
+			chosen, v, ok := reflect.Select(cases)
+			if chosen == 0 {
+				return status.FromContextError(ctx.Err()).Err()
+			}
+			if !ok {
+				return nil
+			}
+			result, err := encode(enc, m.Result, v.Interface())
+			if err != nil {
+				return status.Errorf(codes.Internal, "encoding the result: %v", err)
+			}
+			if err := stream.SendMsg(newResponse(result)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// invoke decodes the params of req and calls fn, it returns the results without the error.
+func invoke(ctx context.Context, m *Method, fn reflect.Value, req *dynamicpb.Message) (Encoding, []reflect.Value, error) {
+	enc, params := requestParams(req)
+	if len(params) != len(m.Params) {
+		return enc, nil, status.Errorf(codes.InvalidArgument, "%s expects %d params, got %d", m.Name, len(m.Params), len(params))
+	}
+
+	args := make([]reflect.Value, 0, len(params)+1)
+	args = append(args, reflect.ValueOf(ctx))
+	for i, p := range params {
+		v, err := decode(enc, m.Params[i], p)
+		if err != nil {
+			return enc, nil, status.Errorf(codes.InvalidArgument, "decoding param %d: %v", i, err)
+		}
+		args = append(args, v)
+	}
+
+	outs := fn.Call(args)
+	if m.HasError {
+		if err, _ := outs[len(outs)-1].Interface().(error); err != nil {
+			return enc, nil, toStatus(err)
+		}
+		outs = outs[:len(outs)-1]
+	}
+	return enc, outs, nil
+}
+
+// toStatus returns err with the gRPC code of its api error code.
+func toStatus(err error) error {
+	c := codes.Unknown
+	var code jsonrpc.ErrorCode
+	if errors.As(err, &code) {
+		switch code {
+		case types.ErrCodeActorNotFound, types.ErrCodeTipSetNotFound:
+			c = codes.NotFound
+		case types.ErrCodeLookbackBeyondLimit:
+			c = codes.OutOfRange
+		}
+	}
+	if errors.Is(err, context.Canceled) {
+		c = codes.Canceled
+	} else if errors.Is(err, context.DeadlineExceeded) {
+		c = codes.DeadlineExceeded
+	}
+	return status.Error(c, err.Error())
+}
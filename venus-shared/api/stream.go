@@ -0,0 +1,94 @@
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	cbg "github.com/whyrusleeping/cbor-gen"
+)
+
+// The content types of the streamed responses, a json rpc request of a streamed method asking for one of them
+// with its Accept header gets the elements of the result one by one instead of a single json response:
+//   - StateMarketDeals streams the types.MarketDealEntry of the deals, ordered by deal id
+//   - StateListActors streams the address.Address of the actors
+//   - ChainExport and ChainExportRange stream the []byte chunks of the car, an empty chunk marks its end
+const (
+	ContentTypeNDJSON = "application/x-ndjson"
+	ContentTypeCBOR   = "application/cbor"
+)
+
+// StreamEncoder writes the elements of a streamed response, each element is a line of json, or a cbor item.
+type StreamEncoder struct {
+	w       io.Writer
+	jsonEnc *json.Encoder
+}
+
+func NewStreamEncoder(contentType string, w io.Writer) (*StreamEncoder, error) {
+	switch contentType {
+	case ContentTypeNDJSON:
+		return &StreamEncoder{w: w, jsonEnc: json.NewEncoder(w)}, nil
+	case ContentTypeCBOR:
+		return &StreamEncoder{w: w}, nil
+	default:
+		return nil, fmt.Errorf("unsupported stream content type %q", contentType)
+	}
+}
+
+func (e *StreamEncoder) Encode(v interface{}) error {
+	if e.jsonEnc != nil {
+		return e.jsonEnc.Encode(v)
+	}
+
+	switch v := v.(type) {
+	case []byte:
+		return cbg.WriteByteArray(e.w, v)
+	case cbg.CBORMarshaler:
+		return v.MarshalCBOR(e.w)
+	default:
+		return fmt.Errorf("%T has no cbor encoding", v)
+	}
+}
+
+// StreamDecoder reads the elements of a streamed response.
+type StreamDecoder struct {
+	r       *bufio.Reader
+	jsonDec *json.Decoder
+}
+
+func NewStreamDecoder(contentType string, r io.Reader) (*StreamDecoder, error) {
+	switch contentType {
+	case ContentTypeNDJSON:
+		return &StreamDecoder{jsonDec: json.NewDecoder(r)}, nil
+	case ContentTypeCBOR:
+		return &StreamDecoder{r: bufio.NewReader(r)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported stream content type %q", contentType)
+	}
+}
+
+// Decode reads the next element into v, a *[]byte or a cbor unmarshaler for the cbor streams, it returns io.EOF
+// after the last element.
+func (d *StreamDecoder) Decode(v interface{}) error {
+	if d.jsonDec != nil {
+		return d.jsonDec.Decode(v)
+	}
+
+	if _, err := d.r.Peek(1); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case *[]byte:
+		b, err := cbg.ReadByteArray(d.r, cbg.ByteArrayMaxLen)
+		if err != nil {
+			return err
+		}
+		*v = b
+		return nil
+	case cbg.CBORUnmarshaler:
+		return v.UnmarshalCBOR(d.r)
+	default:
+		return fmt.Errorf("%T has no cbor encoding", v)
+	}
+}
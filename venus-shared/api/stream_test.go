@@ -0,0 +1,61 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/venus-shared/testutil"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestStreamEncoding(t *testing.T) {
+	var deals []types.MarketDealEntry
+	testutil.Provide(t, &deals, testutil.WithSliceLen(3))
+	var addrs []address.Address
+	testutil.Provide(t, &addrs, testutil.WithSliceLen(3))
+	chunks := [][]byte{[]byte("car"), {}}
+
+	for _, ct := range []string{ContentTypeNDJSON, ContentTypeCBOR} {
+		var buf bytes.Buffer
+		enc, err := NewStreamEncoder(ct, &buf)
+		require.NoError(t, err)
+		for i := range deals {
+			require.NoError(t, enc.Encode(&deals[i]), ct)
+		}
+		for i := range addrs {
+			require.NoError(t, enc.Encode(&addrs[i]), ct)
+		}
+		for _, c := range chunks {
+			require.NoError(t, enc.Encode(c), ct)
+		}
+
+		dec, err := NewStreamDecoder(ct, &buf)
+		require.NoError(t, err)
+		for i := range deals {
+			var d types.MarketDealEntry
+			require.NoError(t, dec.Decode(&d), ct)
+			require.Equal(t, deals[i].DealID, d.DealID, ct)
+			require.Equal(t, deals[i].Deal.State, d.Deal.State, ct)
+			require.Equal(t, deals[i].Deal.Proposal.PieceCID, d.Deal.Proposal.PieceCID, ct)
+		}
+		for i := range addrs {
+			var a address.Address
+			require.NoError(t, dec.Decode(&a), ct)
+			require.Equal(t, addrs[i], a, ct)
+		}
+		for _, c := range chunks {
+			var b []byte
+			require.NoError(t, dec.Decode(&b), ct)
+			require.Equal(t, len(c), len(b), ct)
+			require.Equal(t, string(c), string(b), ct)
+		}
+		require.Equal(t, io.EOF, dec.Decode(&[]byte{}), ct)
+	}
+
+	_, err := NewStreamEncoder("application/json", io.Discard)
+	require.Error(t, err)
+}
@@ -2,6 +2,7 @@ package blockstore
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"sync/atomic"
@@ -132,8 +133,12 @@ var (
 	_ blockstore.Blockstore = (*BadgerBlockstore)(nil)
 	_ blockstore.Viewer     = (*BadgerBlockstore)(nil)
 	_ io.Closer             = (*BadgerBlockstore)(nil)
+	_ BlockstoreGC          = (*BadgerBlockstore)(nil)
 )
 
+// gcDiscardRatio is the part of a value log file which must be garbage for it to be rewritten.
+const gcDiscardRatio = 0.5
+
 // Open creates a new badger-backed blockstore, with the supplied options.
 func Open(opts Options) (*BadgerBlockstore, error) {
 	opts.Logger = &badgerLogger{
@@ -204,6 +209,30 @@ func (b *BadgerBlockstore) Flush(context.Context) error {
 	return b.DB.Sync()
 }
 
+// CollectGarbage rewrites the first value log file with enough garbage, every one of them with a full gc.
+func (b *BadgerBlockstore) CollectGarbage(options ...BlockstoreGCOption) error {
+	if atomic.LoadInt64(&b.state) != stateOpen {
+		return ErrBlockstoreClosed
+	}
+
+	var opts BlockstoreGCOptions
+	for _, opt := range options {
+		if err := opt(&opts); err != nil {
+			return err
+		}
+	}
+
+	for {
+		err := b.DB.RunValueLogGC(gcDiscardRatio)
+		if errors.Is(err, badger.ErrNoRewrite) {
+			return nil
+		}
+		if err != nil || !opts.FullGC {
+			return err
+		}
+	}
+}
+
 // Has implements blockstore.Has.
 func (b *BadgerBlockstore) Has(ctx context.Context, cid cid.Cid) (bool, error) {
 	if atomic.LoadInt64(&b.state) != stateOpen {
@@ -436,6 +465,9 @@ func (b *BadgerBlockstore) AllKeysChan(ctx context.Context) (<-chan cid.Cid, err
 
 	txn := b.DB.NewTransaction(false)
 	opts := badger.IteratorOptions{PrefetchSize: 100}
+	if prefix := b.keyTransform.Prefix.String(); prefix != "/" {
+		opts.Prefix = []byte(prefix + "/")
+	}
 	iter := txn.NewIterator(opts)
 
 	ch := make(chan cid.Cid)
@@ -455,7 +487,7 @@ func (b *BadgerBlockstore) AllKeysChan(ctx context.Context) (<-chan cid.Cid, err
 			}
 			k := iter.Item().Key()
 			// need to convert to key.Key using key.KeyFromDsKey.
-			bk, err := dshelp.BinaryFromDsKey(datastore.RawKey(string(k)))
+			bk, err := dshelp.BinaryFromDsKey(b.keyTransform.InvertKey(datastore.RawKey(string(k))))
 			if err != nil {
 				log.Warnf("error parsing key from binary: %s", err)
 				continue
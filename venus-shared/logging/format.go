@@ -0,0 +1,64 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/filecoin-project/go-address"
+	logging2 "github.com/ipfs/go-log/v2"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// Keys of the structured fields attached to log lines, aggregation systems can filter on them.
+const (
+	KeyModule = "module"
+	KeyTipSet = "tipset"
+	KeyHeight = "height"
+	KeyActor  = "actor"
+)
+
+// Output formats accepted by SetupFormat.
+const (
+	FormatColor   = "color"
+	FormatNoColor = "nocolor"
+	FormatJSON    = "json"
+)
+
+// SetupFormat replaces the output format of all loggers without touching their levels.
+// In json mode the subsystem name of a logger is reported under KeyModule.
+func SetupFormat(format string, w io.Writer) error {
+	encCfg := zap.NewProductionEncoderConfig()
+	encCfg.EncodeTime = zapcore.ISO8601TimeEncoder
+
+	var encoder zapcore.Encoder
+	switch format {
+	case FormatColor, "":
+		encCfg.EncodeLevel = zapcore.CapitalColorLevelEncoder
+		encoder = zapcore.NewConsoleEncoder(encCfg)
+	case FormatNoColor:
+		encCfg.EncodeLevel = zapcore.CapitalLevelEncoder
+		encoder = zapcore.NewConsoleEncoder(encCfg)
+	case FormatJSON:
+		encCfg.NameKey = KeyModule
+		encoder = zapcore.NewJSONEncoder(encCfg)
+	default:
+		return fmt.Errorf("unknown log format %q, expect one of %s, %s, %s", format, FormatColor, FormatNoColor, FormatJSON)
+	}
+
+	// levels are enforced per logger, the primary core has to accept everything
+	logging2.SetPrimaryCore(zapcore.NewCore(encoder, zapcore.AddSync(w), zap.NewAtomicLevelAt(zapcore.DebugLevel)))
+	return nil
+}
+
+// TipSetFields returns the structured fields identifying ts.
+func TipSetFields(ts *types.TipSet) []interface{} {
+	return []interface{}{KeyTipSet, ts.Key().String(), KeyHeight, int64(ts.Height())}
+}
+
+// ActorFields returns the structured fields identifying the actor at addr.
+func ActorFields(addr address.Address) []interface{} {
+	return []interface{}{KeyActor, addr.String()}
+}
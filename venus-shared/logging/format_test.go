@@ -0,0 +1,41 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	logging2 "github.com/ipfs/go-log/v2"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/testutil"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestSetupFormatJSON(t *testing.T) {
+	tf.UnitTest(t)
+	defer func() {
+		require.NoError(t, SetupFormat(FormatColor, os.Stderr))
+	}()
+
+	var buf bytes.Buffer
+	require.NoError(t, SetupFormat(FormatJSON, &buf))
+	require.Error(t, SetupFormat("xml", &buf))
+
+	var ts *types.TipSet
+	testutil.Provide(t, &ts)
+
+	l := logging2.Logger("format-test")
+	require.NoError(t, logging2.SetLogLevel("format-test", "info"))
+	l.Debugw("filtered by level")
+	l.Infow("synced", TipSetFields(ts)...)
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	require.Equal(t, "format-test", entry[KeyModule])
+	require.Equal(t, "synced", entry["msg"])
+	require.Equal(t, ts.Key().String(), entry[KeyTipSet])
+	require.Equal(t, float64(ts.Height()), entry[KeyHeight])
+}
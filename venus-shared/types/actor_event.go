@@ -65,3 +65,39 @@ type ActorEvent struct {
 	// CID of message that produced this event.
 	MsgCid cid.Cid `json:"msgCid"`
 }
+
+// The kinds of the deal events emitted by the builtin market actor.
+const (
+	DealPublished  = "deal-published"
+	DealActivated  = "deal-activated"
+	DealTerminated = "deal-terminated"
+	DealCompleted  = "deal-completed"
+)
+
+// DealEventFilter selects the deal events emitted by the builtin market actor.
+type DealEventFilter struct {
+	// Kinds are the kinds of the events, all the kinds if empty.
+	Kinds []string `json:"kinds,omitempty"`
+
+	// Provider and Client restrict the events to the deals of the provider or the client, given as ID addresses.
+	Provider *address.Address `json:"provider,omitempty"`
+	Client   *address.Address `json:"client,omitempty"`
+
+	// FromHeight and ToHeight bound the heights of the events like in ActorEventFilter.
+	FromHeight *abi.ChainEpoch `json:"fromHeight,omitempty"`
+	ToHeight   *abi.ChainEpoch `json:"toHeight,omitempty"`
+}
+
+// DealEvent is a deal lifecycle event emitted by the builtin market actor. The terminated deals are slashed
+// when they are terminated before their end epoch.
+type DealEvent struct {
+	Kind     string          `json:"kind"`
+	DealID   abi.DealID      `json:"dealId"`
+	Client   address.Address `json:"client"`
+	Provider address.Address `json:"provider"`
+
+	Reverted  bool           `json:"reverted"`
+	Height    abi.ChainEpoch `json:"height"`
+	TipSetKey TipSetKey      `json:"tipsetKey"`
+	MsgCid    cid.Cid        `json:"msgCid"`
+}
@@ -0,0 +1,57 @@
+package types
+
+import (
+	"github.com/filecoin-project/go-state-types/abi"
+	actorstypes "github.com/filecoin-project/go-state-types/actors"
+)
+
+// The kinds of TypeSchema.
+const (
+	SchemaKindEmpty    = "empty"
+	SchemaKindBool     = "bool"
+	SchemaKindInt      = "int"
+	SchemaKindUint     = "uint"
+	SchemaKindString   = "string"
+	SchemaKindBytes    = "bytes"
+	SchemaKindBigInt   = "bigint"
+	SchemaKindAddress  = "address"
+	SchemaKindCid      = "cid"
+	SchemaKindBitField = "bitfield"
+	SchemaKindRaw      = "raw"
+	SchemaKindArray    = "array"
+	SchemaKindMap      = "map"
+	SchemaKindStruct   = "struct"
+	SchemaKindAny      = "any"
+)
+
+// ActorMethod describes a method exported by a builtin actor, its params and return are the
+// JSON values accepted by StateEncodeParams and returned by StateDecodeParams.
+type ActorMethod struct {
+	Num     abi.MethodNum
+	Name    string
+	Version actorstypes.Version
+	Params  *TypeSchema
+	Return  *TypeSchema
+}
+
+// TypeSchema describes the JSON encoding of a value.
+type TypeSchema struct {
+	// Kind is one of the SchemaKind constants
+	Kind string
+	// Name is the go type of the value, eg. miner.ChangeWorkerAddressParams, empty for the unnamed types
+	Name string `json:",omitempty"`
+	// Nullable is set when the value may be null
+	Nullable bool `json:",omitempty"`
+	// Fields are the fields of a struct
+	Fields []SchemaField `json:",omitempty"`
+	// Key is the key of a map
+	Key *TypeSchema `json:",omitempty"`
+	// Elem is the element of an array or the value of a map
+	Elem *TypeSchema `json:",omitempty"`
+}
+
+// SchemaField is a field of a struct.
+type SchemaField struct {
+	Name   string
+	Schema *TypeSchema
+}
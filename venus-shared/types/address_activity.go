@@ -0,0 +1,21 @@
+package types
+
+import (
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+)
+
+// AddressActivity notifies that a message from or to a watched address was executed on chain, or that its
+// execution was reverted with the tipset holding its receipt.
+type AddressActivity struct {
+	// Address is the watched address, as it was given to the subscription
+	Address address.Address
+	MsgCid  cid.Cid
+	Message *Message
+	Receipt *MessageReceipt
+	// TipSet is the tipset including the message, it is executed at the next tipset
+	TipSet   TipSetKey
+	Height   abi.ChainEpoch
+	Reverted bool
+}
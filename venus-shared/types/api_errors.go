@@ -0,0 +1,62 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/filecoin-project/go-jsonrpc"
+
+	"github.com/filecoin-project/venus/venus-shared/actors/types"
+)
+
+// The codes of the errors of the API, sent as the codes of the JSON-RPC errors. The clients check the kind of
+// an error with errors.Is(err, ErrCodeXXX).
+const (
+	// ErrCodeActorNotFound is the code of the errors of the actors missing in the state
+	ErrCodeActorNotFound = types.ErrCodeActorNotFound
+	// ErrCodeTipSetNotFound is the code of the errors of the tipsets missing in the chain
+	ErrCodeTipSetNotFound jsonrpc.ErrorCode = 1001
+	// ErrCodeLookbackBeyondLimit is the code of the errors of the requests looking further back than allowed
+	ErrCodeLookbackBeyondLimit jsonrpc.ErrorCode = 1002
+)
+
+// APIError is an error of the API carrying the code of its kind.
+type APIError struct {
+	Code jsonrpc.ErrorCode
+	Err  error
+}
+
+var _ error = (*APIError)(nil)
+
+// NewAPIError returns err with the code code.
+func NewAPIError(code jsonrpc.ErrorCode, err error) error {
+	return &APIError{Code: code, Err: err}
+}
+
+// NewTipSetNotFoundError returns an error with the code ErrCodeTipSetNotFound.
+func NewTipSetNotFoundError(format string, args ...interface{}) error {
+	return NewAPIError(ErrCodeTipSetNotFound, fmt.Errorf(format, args...))
+}
+
+// NewLookbackBeyondLimitError returns an error with the code ErrCodeLookbackBeyondLimit.
+func NewLookbackBeyondLimitError(format string, args ...interface{}) error {
+	return NewAPIError(ErrCodeLookbackBeyondLimit, fmt.Errorf(format, args...))
+}
+
+func (e *APIError) Error() string { return e.Err.Error() }
+
+func (e *APIError) Unwrap() error { return e.Err }
+
+// Is reports whether target is the code of e.
+func (e *APIError) Is(target error) bool {
+	code, ok := any(target).(jsonrpc.ErrorCode)
+	return ok && code == e.Code
+}
+
+// As sets the JSON-RPC error code of the errors wrapping e.
+func (e *APIError) As(target interface{}) bool {
+	code, ok := target.(*jsonrpc.ErrorCode)
+	if ok {
+		*code = e.Code
+	}
+	return ok
+}
@@ -0,0 +1,65 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/filecoin-project/go-jsonrpc"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestAPIErrorCodes(t *testing.T) {
+	tf.UnitTest(t)
+
+	for code, err := range map[jsonrpc.ErrorCode]error{
+		ErrCodeActorNotFound:       fmt.Errorf("failed to load miner actor state: %w", pkgerrors.Wrapf(ErrActorNotFound, "address is :%s", "f01000")),
+		ErrCodeTipSetNotFound:      fmt.Errorf("loading tipset: %w", NewTipSetNotFoundError("tipset %d not found", 10)),
+		ErrCodeLookbackBeyondLimit: NewLookbackBeyondLimitError("too far in the past"),
+	} {
+		// the code the JSON-RPC server sends
+		var got jsonrpc.ErrorCode
+		require.True(t, xerrors.As(err, &got))
+		require.Equal(t, code, got)
+		require.True(t, errors.Is(err, code))
+	}
+
+	require.True(t, errors.Is(fmt.Errorf("state: %w", ErrActorNotFound), ErrActorNotFound))
+	require.False(t, errors.Is(NewTipSetNotFoundError("not found"), ErrCodeActorNotFound))
+
+	var got jsonrpc.ErrorCode
+	require.False(t, xerrors.As(errors.New("plain"), &got))
+}
+
+type errorCodeHandler struct{}
+
+func (errorCodeHandler) Load(_ context.Context) error {
+	return fmt.Errorf("loading tipset: %w", NewTipSetNotFoundError("tipset %d not found", 10))
+}
+
+func TestAPIErrorCodesOverJSONRPC(t *testing.T) {
+	tf.UnitTest(t)
+
+	rpcServer := jsonrpc.NewServer()
+	rpcServer.Register("Test", errorCodeHandler{})
+	srv := httptest.NewServer(rpcServer)
+	defer srv.Close()
+
+	var client struct {
+		Load func(context.Context) error
+	}
+	closer, err := jsonrpc.NewMergeClient(context.Background(), "ws://"+srv.Listener.Addr().String(), "Test", []interface{}{&client}, nil)
+	require.NoError(t, err)
+	defer closer()
+
+	err = client.Load(context.Background())
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrCodeTipSetNotFound))
+	require.False(t, errors.Is(err, ErrCodeActorNotFound))
+}
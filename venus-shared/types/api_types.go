@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	exitcode "github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/filecoin-project/go-state-types/network"
 	"github.com/ipfs/go-cid"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -45,6 +47,39 @@ type ObjStat struct {
 	Links uint64
 }
 
+// ActorStat is the size of the state of an actor, with the number of its blocks in Links.
+type ActorStat struct {
+	Address address.Address
+	// Found is false when the actor is not in the state, the stat is then empty
+	Found bool
+	Head  cid.Cid
+	ObjStat
+}
+
+// StateSizeReport attributes the blocks of a state to its actors, each block to the first actor reaching it.
+type StateSizeReport struct {
+	Root cid.Cid
+	// Total is the size of all the blocks of the state
+	Total ObjStat
+	// StateTree is the size of the nodes of the state tree holding the actors
+	StateTree ObjStat
+	Actors    uint64
+	// ByType is the size of the states of the actors by actor type, eg. storageminer
+	ByType map[string]ObjStat
+	// Top are the actors of the largest states, largest first
+	Top []ActorStateSize
+}
+
+// ActorStateSize is the size of the blocks of a state attributed to an actor.
+type ActorStateSize struct {
+	Address address.Address
+	Type    string
+	ObjStat
+	// Fields is the size of the fields of the state of a miner, eg. Sectors, Deadlines and PreCommittedSectors,
+	// Head is the size of the blocks reached by no other field
+	Fields map[string]ObjStat `json:",omitempty"`
+}
+
 // ChainMessage is an on-chain message with its block and receipt.
 type ChainMessage struct { //nolint
 	TS      *TipSet
@@ -120,6 +155,11 @@ type Fault struct {
 type MessageMatch struct {
 	To   address.Address
 	From address.Address
+	// ToActorType matches the type of the recipient in the parent state of the tipset of the message, eg.
+	// storagemarket
+	ToActorType string `json:",omitempty"`
+	// Method matches the method of the message when it is not nil
+	Method *abi.MethodNum `json:",omitempty"`
 }
 
 type MsigTransaction struct {
@@ -172,8 +212,111 @@ type Deadline struct {
 	DailyFee             abi.TokenAmount
 }
 
+// DisputableDeadline holds the window PoSts of the last instance of a deadline which may be disputed.
+type DisputableDeadline struct {
+	Deadline uint64
+	// Close is the epoch the deadline closed
+	Close abi.ChainEpoch
+	// DisputeEnd is the first epoch the proofs can't be disputed anymore
+	DisputeEnd abi.ChainEpoch
+	Proofs     []DisputableWindowedPoSt
+}
+
+// CompactionPlan is a CompactPartitions message of the miner worker removing the terminated sectors of
+// partitions of a deadline.
+type CompactionPlan struct {
+	Deadline   uint64
+	Partitions bitfield.BitField
+	// LiveSectors and DeadSectors are the sectors of the compacted partitions
+	LiveSectors uint64
+	DeadSectors uint64
+	Message     *Message
+	// GasUsed is the gas used by the message executed on the tipset, zero when the execution failed
+	GasUsed int64
+	// Error is set when the execution of the message failed
+	Error string `json:",omitempty"`
+}
+
+// ConsensusFaultReport is a ReportConsensusFault message proving a consensus fault of a miner, checked against a
+// tipset.
+type ConsensusFaultReport struct {
+	Miner address.Address
+	// Type is the kind of fault: "double-fork mining", "parent grinding" or "time-offset mining"
+	Type  string
+	Epoch abi.ChainEpoch
+	// Penalty is the penalty the miner is charged, Reward the share of it paid to the reporter, capped by the
+	// funds the miner holds
+	Penalty abi.TokenAmount
+	Reward  abi.TokenAmount
+	Message *Message
+}
+
+// MinerDeadlineDigest summarizes a deadline of a miner which just closed.
+type MinerDeadlineDigest struct {
+	Miner    address.Address
+	Deadline uint64
+	// TipSet is the first head whose state has the deadline closed
+	TipSet TipSetKey
+	Height abi.ChainEpoch
+	// NewFaults are the sectors of the deadline which became faulty while it was open or when it closed
+	NewFaults bitfield.BitField
+	// MissedPartitions are the partitions of the deadline whose sectors were marked faulty for missing the window PoSt
+	MissedPartitions bitfield.BitField
+	// ExpiringSectors are the live sectors of the deadline expiring within the requested epochs
+	ExpiringSectors bitfield.BitField
+}
+
+// DealSector is the sector of a miner holding a deal.
+type DealSector struct {
+	Sector abi.SectorNumber
+	// Activated is false while the sector is only precommitted
+	Activated bool
+	// Location is the deadline and partition of an activated sector, nil once the sector is terminated
+	Location *SectorPartition
+}
+
+// SectorPartition locates a sector in the deadlines of its miner.
+type SectorPartition struct {
+	Deadline  uint64
+	Partition uint64
+}
+
+// DisputableWindowedPoSt is an optimistically accepted window PoSt, the ProofIndex of
+// DisputeWindowedPoSt being its Index.
+type DisputableWindowedPoSt struct {
+	Index      uint64
+	Partitions bitfield.BitField
+}
+
 var MarketBalanceNil = MarketBalance{}
 
+// MarketParticipant is a participant of the storage market with its balances.
+type MarketParticipant struct {
+	Address address.Address
+	MarketBalance
+}
+
+// MarketParticipantsPage is a page of the participants of the storage market.
+type MarketParticipantsPage struct {
+	Participants []MarketParticipant
+	// Next is the cursor of the next page, empty on the last page
+	Next address.Address
+}
+
+// ActorFilter selects the actors of a listing by their code cids or by the names of their actor types, such as
+// "storageminer" or "evm", the actors matching either are selected, the empty filter selects every actor.
+type ActorFilter struct {
+	Codes []cid.Cid
+	Types []string
+}
+
+// ActorsPage is a page of the actors of the state tree.
+type ActorsPage struct {
+	Actors []address.Address
+	// Next is the cursor of the next page, empty on the last page
+	Next address.Address
+}
+
 type MarketDealState struct {
 	SectorNumber     abi.SectorNumber // 0 if not yet included in proven sector (0 is also a valid sector number).
 	SectorStartEpoch abi.ChainEpoch   // -1 if not yet included in proven sector
@@ -223,6 +366,12 @@ type MarketDeal struct {
 	State    MarketDealState
 }
 
+// MarketDealEntry is a deal of the streamed responses of StateMarketDeals.
+type MarketDealEntry struct {
+	DealID abi.DealID
+	Deal   MarketDeal
+}
+
 type MinerPower struct {
 	MinerPower  power.Claim
 	TotalPower  power.Claim
@@ -248,6 +397,66 @@ type DealCollateralBounds struct {
 	Max abi.TokenAmount
 }
 
+// PreCommitDepositBreakdown is the precommit deposit of a sector together with the inputs it is derived from.
+type PreCommitDepositBreakdown struct {
+	SectorNumber abi.SectorNumber
+	Deposit      abi.TokenAmount
+	// SectorWeight is the quality adjusted power of the sector the deposit is computed for.
+	SectorWeight abi.StoragePower
+	// QualityAdjPowerSmoothed is the smoothed estimate of the network quality adjusted power.
+	QualityAdjPowerSmoothed builtin.FilterEstimate
+	// RewardSmoothed is the smoothed estimate of the block reward per epoch.
+	RewardSmoothed builtin.FilterEstimate
+	// BaselinePower is the network baseline power at the epoch.
+	BaselinePower abi.StoragePower
+}
+
+// SectorFaultPenalty is the fault fee charged to a miner for each WindowPoSt missed by a set of sectors.
+type SectorFaultPenalty struct {
+	// Sectors are the requested sectors found in the miner state.
+	Sectors bitfield.BitField
+	// Missing are the requested sectors not found in the miner state, they are not penalized.
+	Missing bitfield.BitField
+	// QAPower is the quality adjusted power of Sectors.
+	QAPower abi.StoragePower
+	// Penalty is the fee charged per missed WindowPoSt, ie. per day the sectors stay faulty.
+	Penalty abi.TokenAmount
+	// RewardSmoothed is the smoothed estimate of the block reward per epoch.
+	RewardSmoothed builtin.FilterEstimate
+	// QualityAdjPowerSmoothed is the smoothed estimate of the network quality adjusted power.
+	QualityAdjPowerSmoothed builtin.FilterEstimate
+}
+
+// PendingBeneficiaryChangeStatus is the progress of the proposed change of the beneficiary of a miner.
+type PendingBeneficiaryChangeStatus struct {
+	// Beneficiary is the current beneficiary.
+	Beneficiary address.Address
+	// Pending is the proposed change, nil if there is none.
+	Pending *PendingBeneficiaryChange
+	// WaitingFor are the addresses that still have to approve the change before it takes effect.
+	WaitingFor []address.Address
+	// Status describes the progress of the change.
+	Status string
+}
+
+// ActorLookup is the result of looking up an actor by address, Actor is nil when it is not Found.
+type ActorLookup struct {
+	Address address.Address
+	Found   bool
+	Actor   *Actor
+}
+
+// ActorHeadChange is the head and the balance of an actor in the parent state of the tipset at Height, they are
+// the same until the next change.
+type ActorHeadChange struct {
+	Height abi.ChainEpoch
+	TipSet TipSetKey
+	// Found is false when the actor is not in the state, eg. before its creation
+	Found   bool
+	Head    cid.Cid
+	Balance abi.TokenAmount
+}
+
 type MsgLookup struct {
 	Message   cid.Cid // Can be different than requested, in case it was replaced, but only gas values changed
 	Receipt   MessageReceipt
@@ -256,6 +465,65 @@ type MsgLookup struct {
 	Height    abi.ChainEpoch
 }
 
+// MsgReplacementLookup is the result of searching for the message executed with the sender and nonce of a message
+type MsgReplacementLookup struct {
+	MsgLookup
+	// Replaced is set when the message executed with the sender and nonce has a different CID than the searched one
+	Replaced bool
+	// GasReplacement is set when the replacing message only differs in its gas values, as after a fee bump,
+	// otherwise the nonce was taken by an entirely different message
+	GasReplacement bool
+}
+
+// BeaconServerStatus is the health of a drand server, as seen by its latest request.
+type BeaconServerStatus struct {
+	URL         string
+	Healthy     bool
+	Latency     time.Duration
+	LatestRound uint64
+	LastError   string
+	LastCheck   time.Time
+}
+
+// BeaconStatus is the status of the drand network used from the epoch Start.
+type BeaconStatus struct {
+	Start       abi.ChainEpoch
+	ChainHash   string
+	IsChained   bool
+	LatestRound uint64
+	Servers     []BeaconServerStatus
+}
+
+// MigrationPhase is the stage reached by the state migration of a network upgrade.
+type MigrationPhase string
+
+const (
+	MigrationScheduled    MigrationPhase = "scheduled"
+	MigrationPreMigrating MigrationPhase = "pre-migrating"
+	MigrationPreMigrated  MigrationPhase = "pre-migrated"
+	MigrationMigrating    MigrationPhase = "migrating"
+	MigrationDone         MigrationPhase = "done"
+	MigrationFailed       MigrationPhase = "failed"
+)
+
+// MigrationStatus is the progress of the state migration of the upgrade to Network at Height,
+// the fields other than PreMigrations describe its latest pre-migration or migration.
+type MigrationStatus struct {
+	Network       network.Version
+	Height        abi.ChainEpoch
+	Phase         MigrationPhase
+	PreMigrations int
+	From          cid.Cid
+	To            cid.Cid
+	// Cached is set when the migrated state root comes from a previous migration of From.
+	Cached    bool
+	JobsDone  uint64
+	JobsTotal uint64
+	StartedAt time.Time
+	Duration  time.Duration
+	Error     string
+}
+
 type MiningBaseInfo struct { //nolint
 	MinerPower        abi.StoragePower
 	NetworkPower      abi.StoragePower
@@ -290,9 +558,13 @@ type EstimateResult struct {
 }
 
 type MessageSendSpec struct {
+	// MaxFee caps GasLimit*GasFeeCap of the message, the fee cap of the message is lowered to fit it, the default
+	// max fee of the message pool is used when it is zero
 	MaxFee            abi.TokenAmount
 	GasOverEstimation float64
 	GasOverPremium    float64
+	// MaxFeeStrict rejects the message when its fee exceeds MaxFee instead of lowering its fee cap
+	MaxFeeStrict bool
 }
 
 // Version provides various build-time information
@@ -336,6 +608,13 @@ type SyncState struct {
 	VMApplied uint64
 }
 
+// BlockHeaderValidation is the result of the validation of a block header against its parent tipset.
+type BlockHeaderValidation struct {
+	Valid bool
+	// Errors are the failed checks of an invalid header
+	Errors []string `json:",omitempty"`
+}
+
 // just compatible code lotus
 type SyncStateStage int
 
@@ -422,6 +701,10 @@ type InvocResult struct {
 	ExecutionTrace ExecutionTrace
 	Error          string
 	Duration       time.Duration
+	// ExitCodeName is the name of the exit code of a failed message, eg. SysErrOutOfGas
+	ExitCodeName string `json:",omitempty"`
+	// RevertData is the data returned by a reverted evm call
+	RevertData []byte `json:",omitempty"`
 }
 
 type MinerInfo struct {
@@ -452,6 +735,14 @@ type NetworkParams struct {
 	Eip155ChainID           int
 }
 
+// UpgradeHeight is the height of a network upgrade, by its name in the upgrade config.
+type UpgradeHeight struct {
+	Name   string
+	Height abi.ChainEpoch
+	// Overridden is set when the height comes from the upgradeHeightOverrides of the config.
+	Overridden bool
+}
+
 type ForkUpgradeParams struct {
 	UpgradeSmokeHeight       abi.ChainEpoch
 	UpgradeBreezeHeight      abi.ChainEpoch
@@ -507,6 +798,22 @@ type NodeChainStatus struct {
 	BlocksPerTipsetLastFinality float64
 }
 
+// NodeHealthCheck is the result of a single readiness check, Value is compared against Threshold.
+type NodeHealthCheck struct {
+	Name      string
+	OK        bool
+	Value     int64
+	Threshold int64
+	Message   string
+}
+
+// NodeHealth reports whether the node is alive and ready to serve traffic.
+type NodeHealth struct {
+	Live   bool
+	Ready  bool
+	Checks []NodeHealthCheck
+}
+
 // F3ParticipationTicket represents a ticket that authorizes a miner to
 // participate in the F3 consensus.
 type F3ParticipationTicket []byte
@@ -654,3 +961,204 @@ func (e *ErrNullRound) Is(target error) bool {
 	_, ok := target.(*ErrNullRound)
 	return ok
 }
+
+// EthEventsBackfillResult reports the work done by EthEventsBackfill.
+type EthEventsBackfillResult struct {
+	From abi.ChainEpoch
+	To   abi.ChainEpoch
+	// Tipsets is the number of tipsets re-executed, null rounds are not counted
+	Tipsets int
+	// Inserted is the number of events which were missing from the index
+	Inserted int
+	// Skipped is the number of events which were already indexed
+	Skipped int
+}
+
+// EthFilterInfo reports a filter installed with EthNewFilter, EthNewBlockFilter or EthNewPendingTransactionFilter.
+type EthFilterInfo struct {
+	ID EthFilterID
+	// Type is logs, blocks or pendingTransactions
+	Type string
+	// Owner is the api token which installed the filter, empty when the api is not authenticated
+	Owner     string
+	Installed time.Time
+	LastTaken time.Time
+	// Collected is the number of results collected and not taken yet, MemoryUsage approximates the bytes they hold
+	Collected   int
+	MemoryUsage int
+}
+
+// EthSubscriptionInfo reports a subscription started with EthSubscribe and the depth of its send queue.
+type EthSubscriptionInfo struct {
+	ID EthSubscriptionID
+	// Type is newHeads, logs or newPendingTransactions
+	Type string
+	// Connection is the id of the websocket connection of the subscription, 0 when it is unknown
+	Connection uint64
+	Started    time.Time
+	// QueueLen is the number of responses not sent yet to the client, QueueSize is their size in bytes
+	QueueLen  int
+	QueueSize int64
+	// Dropped is the number of responses dropped by the drop-oldest policy, Coalesced the number of heads
+	// replaced by a newer one by the coalesce policy
+	Dropped   uint64
+	Coalesced uint64
+}
+
+// ProofBlock is an IPLD block of an inclusion proof.
+type ProofBlock struct {
+	Cid  cid.Cid
+	Data []byte
+}
+
+// ReceiptInclusionProof proves that a receipt is committed to by the blocks of a tipset.
+type ReceiptInclusionProof struct {
+	// TipSet is the tipset whose blocks commit to the receipts of its parent tipset,
+	// which executed the message.
+	TipSet TipSetKey
+	// Header is one of the blocks of TipSet, its ParentMessageReceipts is ReceiptsRoot.
+	Header       *BlockHeader
+	ReceiptsRoot cid.Cid
+	// Index is the position of the message among the deduplicated messages of the parent tipset.
+	Index   uint64
+	Message cid.Cid
+	Receipt MessageReceipt
+	// Blocks are the AMT nodes on the path from ReceiptsRoot to the receipt.
+	Blocks []ProofBlock
+}
+
+// EventInclusionProof proves that an event was emitted by a message whose receipt is committed
+// to by the blocks of a tipset.
+type EventInclusionProof struct {
+	Receipt    ReceiptInclusionProof
+	EventsRoot cid.Cid
+	// Index is the position of the event in the events AMT of the receipt.
+	Index uint64
+	Event Event
+	// Blocks are the AMT nodes on the path from EventsRoot to the event.
+	Blocks []ProofBlock
+}
+
+// ChainPruneOpts are the options of ChainPrune.
+type ChainPruneOpts struct {
+	// Height is the first height whose messages, receipts and state are kept
+	Height abi.ChainEpoch
+	// DryRun only reports the objects which would be removed
+	DryRun bool
+}
+
+// ChainPruneResult reports the objects removed by ChainPrune, or which would be removed by a dry run.
+type ChainPruneResult struct {
+	Height abi.ChainEpoch
+	DryRun bool
+	// Tipsets is the number of tipsets below Height which were walked
+	Tipsets int
+	// Objects is the number of blockstore objects removed, Bytes is their size
+	Objects int64
+	Bytes   int64
+	Took    time.Duration
+}
+
+// ChainReorg is a reorg of the head observed by the node, from OldHead to NewHead through their common ancestor
+// at CommonHeight.
+type ChainReorg struct {
+	ID   uint64
+	Time time.Time
+	// Depth is the number of epochs reverted, from OldHeight down to CommonHeight
+	Depth        abi.ChainEpoch
+	OldHead      TipSetKey
+	OldHeight    abi.ChainEpoch
+	NewHead      TipSetKey
+	NewHeight    abi.ChainEpoch
+	CommonHeight abi.ChainEpoch
+	// Dropped are the tipsets reverted from OldHead down, Added the tipsets applied up to NewHead
+	Dropped []TipSetKey
+	Added   []TipSetKey
+}
+
+const (
+	// MaintenanceOpen allows the maintenance until the end of an override
+	MaintenanceOpen = "open"
+	// MaintenanceClosed defers the maintenance until the end of an override, even in the windows
+	MaintenanceClosed = "closed"
+)
+
+// MaintenanceOverride overrides the maintenance windows until Until, with the mode MaintenanceOpen or
+// MaintenanceClosed, an empty mode removes the override.
+type MaintenanceOverride struct {
+	Mode  string
+	Until time.Time
+}
+
+// MaintenanceWindow is a maintenance window opening at the times matched by the cron expression Cron.
+type MaintenanceWindow struct {
+	Cron     string
+	Duration time.Duration
+}
+
+// MaintenanceTaskStatus reports the last run of a maintenance task.
+type MaintenanceTaskStatus struct {
+	Name      string
+	Running   bool
+	LastStart time.Time
+	LastEnd   time.Time
+	LastError string
+}
+
+// MaintenanceSchedule reports the maintenance windows of the node and its maintenance tasks.
+type MaintenanceSchedule struct {
+	Windows  []MaintenanceWindow
+	Override *MaintenanceOverride
+	// Open tells whether the maintenance is allowed now, Start and End bound the current window when it
+	// is open, the next one otherwise, they are zero when there is none.
+	Open  bool
+	Start time.Time
+	End   time.Time
+	Tasks []MaintenanceTaskStatus
+}
+
+// NodeBackupOpts are the options of a backup of the node metadata.
+type NodeBackupOpts struct {
+	// Path is the file the backup is written to, in the directory named by VENUS_BACKUP_BASE_PATH on the
+	// host of the node, a relative path being relative to it
+	Path string
+	// Password encrypts the keystore and the wallet in the backup when it is not empty
+	Password string
+}
+
+// NodeBackupResult reports what a backup of the node metadata saved.
+type NodeBackupResult struct {
+	Path        string
+	Keys        int
+	MetaEntries int
+	SqliteFiles []string
+	Bytes       int64
+	Took        time.Duration
+}
+
+// ConfigReload reports a change of the config of a running node.
+type ConfigReload struct {
+	// Applied are the json paths of the changed fields applied to the running node
+	Applied []string
+	// RequiresRestart are the json paths of the fields which differ from the config the node started with and
+	// are only applied by a restart
+	RequiresRestart []string
+}
+
+// JournalEvent is an event recorded by the journal of the node, Data is its JSON payload.
+type JournalEvent struct {
+	System    string
+	Event     string
+	Timestamp time.Time
+	Data      json.RawMessage
+}
+
+// JournalFilter selects the journal events returned by JournalEvents.
+type JournalFilter struct {
+	// Types are the "system" or "system:event" types of the events, all of them when empty
+	Types []string
+	// Since is the oldest timestamp of the events
+	Since time.Time
+	// Limit keeps the latest events, all of them when not positive
+	Limit int
+}
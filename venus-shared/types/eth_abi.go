@@ -0,0 +1,16 @@
+package types
+
+// EthDecodedArg is a parameter or a return value of an evm contract call, decoded with the ABI of the contract.
+type EthDecodedArg struct {
+	Name  string      `json:"name"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// EthDecodedCall is an evm contract call decoded with the ABI registered for the contract.
+type EthDecodedCall struct {
+	// Function is the signature of the called function, eg. transfer(address,uint256)
+	Function string          `json:"function"`
+	Params   []EthDecodedArg `json:"params,omitempty"`
+	Returns  []EthDecodedArg `json:"returns,omitempty"`
+}
@@ -0,0 +1,81 @@
+package types
+
+import (
+	"github.com/ipfs/go-cid"
+)
+
+// EthStorageSlot is a single slot of an EVM contract storage.
+type EthStorageSlot struct {
+	Key   EthHash `json:"key"`
+	Value EthHash `json:"value"`
+}
+
+// EthContractStorage is a page of the storage of an EVM contract.
+type EthContractStorage struct {
+	Slots []EthStorageSlot `json:"slots"`
+	// Next is the key to resume the listing after, nil once the storage is exhausted.
+	Next *EthHash `json:"next"`
+}
+
+// IpldBlock is a raw IPLD block.
+type IpldBlock struct {
+	Cid  cid.Cid `json:"cid"`
+	Data []byte  `json:"data"`
+}
+
+// EthContractState is the state of an EVM contract at a tipset.
+type EthContractState struct {
+	Address      EthAddress `json:"address"`
+	ActorID      EthUint64  `json:"actorId"`
+	Nonce        EthUint64  `json:"nonce"`
+	Balance      EthBigInt  `json:"balance"`
+	Bytecode     EthBytes   `json:"bytecode"`
+	BytecodeHash EthHash    `json:"bytecodeHash"`
+	// StateRoot is the parent state root of the requested tipset.
+	StateRoot   cid.Cid `json:"stateRoot"`
+	ActorHead   cid.Cid `json:"actorHead"`
+	StorageRoot cid.Cid `json:"storageRoot"`
+	// Proof holds every state tree block read while resolving the actor from StateRoot.
+	Proof []IpldBlock `json:"proof"`
+}
+
+// EthStorageProof proves the value of a single storage slot, Proof holds the raw
+// blocks of the storage KAMT from the storage root down to the slot.
+type EthStorageProof struct {
+	Key   EthHash    `json:"key"`
+	Value EthHash    `json:"value"`
+	Proof []EthBytes `json:"proof"`
+}
+
+// EthProof is the result of eth_getProof. The proofs are Filecoin native: AccountProof holds
+// the raw state tree blocks from StateRoot to the actor followed by the actor head, every
+// block is a dag-cbor blake2b-256 IPLD block. StorageHash is the digest of StorageRoot.
+type EthProof struct {
+	Address      EthAddress        `json:"address"`
+	AccountProof []EthBytes        `json:"accountProof"`
+	Balance      EthBigInt         `json:"balance"`
+	CodeHash     EthHash           `json:"codeHash"`
+	Nonce        EthUint64         `json:"nonce"`
+	StorageHash  EthHash           `json:"storageHash"`
+	StorageProof []EthStorageProof `json:"storageProof"`
+	StateRoot    cid.Cid           `json:"stateRoot"`
+	StorageRoot  *cid.Cid          `json:"storageRoot"`
+}
+
+// EthContractCreation is the creation of an EVM contract, indexed from the execution traces. Creator is the
+// sender of the creating transaction, or the contract which created it.
+type EthContractCreation struct {
+	Contract        EthAddress `json:"contract"`
+	Creator         EthAddress `json:"creator"`
+	InitCodeHash    EthHash    `json:"initCodeHash"`
+	TransactionHash EthHash    `json:"transactionHash"`
+	BlockHash       EthHash    `json:"blockHash"`
+	BlockNumber     EthUint64  `json:"blockNumber"`
+}
+
+// EthContractCreations is a page of the contracts deployed by a creator.
+type EthContractCreations struct {
+	Creations []EthContractCreation `json:"creations"`
+	// Next is the contract to resume the listing after, nil once the contracts are exhausted.
+	Next *EthAddress `json:"next"`
+}
@@ -0,0 +1,23 @@
+package types
+
+// EthBlockRef is the number, hash and timestamp of a block.
+type EthBlockRef struct {
+	Number    EthUint64 `json:"number"`
+	Hash      EthHash   `json:"hash"`
+	Timestamp EthUint64 `json:"timestamp"`
+}
+
+// EthNodeInfo gathers what the Ethereum tooling queries from a node on startup.
+type EthNodeInfo struct {
+	ChainID       EthUint64   `json:"chainId"`
+	NetVersion    string      `json:"netVersion"`
+	ClientVersion string      `json:"clientVersion"`
+	Latest        EthBlockRef `json:"latest"`
+	Safe          EthBlockRef `json:"safe"`
+	Finalized     EthBlockRef `json:"finalized"`
+	// BaseFeePerGas is the base fee of the pending block, GasPrice is the base fee plus MaxPriorityFeePerGas
+	BaseFeePerGas        EthBigInt        `json:"baseFeePerGas"`
+	MaxPriorityFeePerGas EthBigInt        `json:"maxPriorityFeePerGas"`
+	GasPrice             EthBigInt        `json:"gasPrice"`
+	Syncing              EthSyncingResult `json:"syncing"`
+}
@@ -0,0 +1,87 @@
+package types
+
+// The token standards of the indexed transfers.
+const (
+	// TokenStandardERC20 is an ERC-20 Transfer log emitted by an EVM contract.
+	TokenStandardERC20 = "erc20"
+	// TokenStandardFRC46 is a datacap transfer, mint or burn, read from the execution traces.
+	TokenStandardFRC46 = "frc46"
+	// TokenStandardERC721 is an ERC-721 Transfer log.
+	TokenStandardERC721 = "erc721"
+	// TokenStandardERC1155 is an ERC-1155 TransferSingle log, or an item of a TransferBatch log.
+	TokenStandardERC1155 = "erc1155"
+)
+
+// EthTokenTransfer is a token transfer indexed from the ERC-20 Transfer logs and the datacap actor calls. The mints
+// are transfers from the zero address and the burns are transfers to it, the actors without an eth address are
+// identified by their masked ID address.
+type EthTokenTransfer struct {
+	// ID orders the transfers in the index.
+	ID       uint64     `json:"id"`
+	Token    EthAddress `json:"token"`
+	From     EthAddress `json:"from"`
+	To       EthAddress `json:"to"`
+	Amount   BigInt     `json:"amount"`
+	Standard string     `json:"standard"`
+
+	TransactionHash EthHash   `json:"transactionHash"`
+	BlockHash       EthHash   `json:"blockHash"`
+	BlockNumber     EthUint64 `json:"blockNumber"`
+	// LogIndex is the index of the log in the block for the ERC-20 transfers, the FRC-46 transfers which have no
+	// log are numbered after the logs of the block, in execution order.
+	LogIndex EthUint64 `json:"logIndex"`
+}
+
+// EthTokenTransfers is a page of the token transfers of a holder.
+type EthTokenTransfers struct {
+	Transfers []EthTokenTransfer `json:"transfers"`
+	// Next is the ID to resume the listing after, 0 once the transfers are exhausted.
+	Next uint64 `json:"next"`
+}
+
+// EthTokenBalanceChange is the change of the balance of a holder in a block. Balance sums the indexed transfers
+// only, it differs from the balance of the token when transfers happened before the index was enabled.
+type EthTokenBalanceChange struct {
+	BlockHash   EthHash   `json:"blockHash"`
+	BlockNumber EthUint64 `json:"blockNumber"`
+	Received    BigInt    `json:"received"`
+	Sent        BigInt    `json:"sent"`
+	Balance     BigInt    `json:"balance"`
+}
+
+// EthNFTTransfer is a transfer of an ERC-721 or ERC-1155 token indexed from the Transfer, TransferSingle and
+// TransferBatch logs, the items of a TransferBatch log share its log index. The mints are transfers from the zero
+// address and the burns are transfers to it.
+type EthNFTTransfer struct {
+	// ID orders the transfers in the index.
+	ID         uint64     `json:"id"`
+	Collection EthAddress `json:"collection"`
+	TokenID    BigInt     `json:"tokenId"`
+	From       EthAddress `json:"from"`
+	To         EthAddress `json:"to"`
+	// Amount is always 1 for the ERC-721 transfers.
+	Amount   BigInt `json:"amount"`
+	Standard string `json:"standard"`
+
+	TransactionHash EthHash   `json:"transactionHash"`
+	BlockHash       EthHash   `json:"blockHash"`
+	BlockNumber     EthUint64 `json:"blockNumber"`
+	LogIndex        EthUint64 `json:"logIndex"`
+}
+
+// EthNFTTransfers is a page of the transfers of a collection.
+type EthNFTTransfers struct {
+	Transfers []EthNFTTransfer `json:"transfers"`
+	// Next is the ID to resume the listing after, 0 once the transfers are exhausted.
+	Next uint64 `json:"next"`
+}
+
+// EthNFTHolding is an amount of a token of a collection held by Owner, summed from the indexed transfers.
+// BlockNumber is the block of the last transfer of the token from or to Owner.
+type EthNFTHolding struct {
+	Collection  EthAddress `json:"collection"`
+	TokenID     BigInt     `json:"tokenId"`
+	Owner       EthAddress `json:"owner"`
+	Amount      BigInt     `json:"amount"`
+	BlockNumber EthUint64  `json:"blockNumber"`
+}
@@ -1,7 +1,15 @@
 package types
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
 	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipld/go-ipld-prime/codec/dagcbor"
+	"github.com/ipld/go-ipld-prime/codec/dagjson"
+	"github.com/ipld/go-ipld-prime/node/basicnode"
+	"github.com/multiformats/go-multicodec"
 )
 
 // EventEntry flags defined in fvm_shared
@@ -10,6 +18,9 @@ const (
 	EventFlagIndexedValue = 0b00000010
 )
 
+// EventTypeKey is the key of the entry holding the type of builtin actor events, eg. "sector-activated".
+const EventTypeKey = "$type"
+
 type Event struct {
 	// The ID of the actor that emitted this event.
 	Emitter abi.ActorID
@@ -32,4 +43,68 @@ type EventEntry struct {
 	Value []byte
 }
 
+// DecodedEvent is an Event whose entry values are decoded according to their codec.
+type DecodedEvent struct {
+	Emitter abi.ActorID
+	// Type is the value of the EventTypeKey entry, empty if there is none.
+	Type    string
+	Entries []DecodedEventEntry
+}
+
+type DecodedEventEntry struct {
+	Flags uint8
+	Key   string
+	Codec uint64
+	// Value is the dag-json representation of cbor and dag-cbor values, nil for other codecs.
+	Value json.RawMessage
+	// Raw is the undecoded value.
+	Raw []byte
+	// Error is set when the value could not be decoded.
+	Error string `json:",omitempty"`
+}
+
+// DecodeEvent decodes the cbor and dag-cbor values of the entries of evt.
+func DecodeEvent(evt Event) DecodedEvent {
+	decoded := DecodedEvent{
+		Emitter: evt.Emitter,
+		Entries: make([]DecodedEventEntry, 0, len(evt.Entries)),
+	}
+	for _, entry := range evt.Entries {
+		de := DecodedEventEntry{
+			Flags: entry.Flags,
+			Key:   entry.Key,
+			Codec: entry.Codec,
+			Raw:   entry.Value,
+		}
+		if value, err := decodeEventValue(entry.Codec, entry.Value); err != nil {
+			de.Error = err.Error()
+		} else {
+			de.Value = value
+		}
+		if entry.Key == EventTypeKey && de.Value != nil {
+			_ = json.Unmarshal(de.Value, &decoded.Type)
+		}
+		decoded.Entries = append(decoded.Entries, de)
+	}
+	return decoded
+}
+
+func decodeEventValue(codec uint64, value []byte) (json.RawMessage, error) {
+	switch multicodec.Code(codec) {
+	case multicodec.Cbor, multicodec.DagCbor:
+	default:
+		return nil, nil
+	}
+
+	nb := basicnode.Prototype.Any.NewBuilder()
+	if err := dagcbor.Decode(nb, bytes.NewReader(value)); err != nil {
+		return nil, fmt.Errorf("decode cbor value: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := dagjson.Encode(nb.Build(), &buf); err != nil {
+		return nil, fmt.Errorf("encode dag-json value: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
 type FilterID [32]byte // compatible with EthHash
@@ -0,0 +1,49 @@
+package types
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	"github.com/multiformats/go-multicodec"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestDecodeEvent(t *testing.T) {
+	tf.UnitTest(t)
+
+	cborValue := func(v cbg.CBORMarshaler) []byte {
+		buf := new(bytes.Buffer)
+		require.NoError(t, v.MarshalCBOR(buf))
+		return buf.Bytes()
+	}
+	pieceCid, err := cid.Decode("bafk2bzacea3wsdh6y3a36tb3skempjoxqpuyompjbmfeyf34fi3uy6uue42v4")
+	require.NoError(t, err)
+	piece := cbg.CborCid(pieceCid)
+	var eventType bytes.Buffer
+	cw := cbg.NewCborWriter(&eventType)
+	require.NoError(t, cw.WriteMajorTypeHeader(cbg.MajTextString, uint64(len("sector-activated"))))
+	_, err = cw.WriteString("sector-activated")
+	require.NoError(t, err)
+
+	evt := DecodeEvent(Event{
+		Emitter: 1000,
+		Entries: []EventEntry{
+			{Flags: EventFlagIndexedKey | EventFlagIndexedValue, Key: EventTypeKey, Codec: uint64(multicodec.Cbor), Value: eventType.Bytes()},
+			{Key: "piece", Codec: uint64(multicodec.Cbor), Value: cborValue(&piece)},
+			{Key: "raw", Codec: uint64(multicodec.Raw), Value: []byte{1, 2}},
+			{Key: "broken", Codec: uint64(multicodec.Cbor), Value: []byte{0xff}},
+		},
+	})
+
+	require.Equal(t, "sector-activated", evt.Type)
+	require.Len(t, evt.Entries, 4)
+	require.JSONEq(t, `"sector-activated"`, string(evt.Entries[0].Value))
+	require.JSONEq(t, `{"/":"`+pieceCid.String()+`"}`, string(evt.Entries[1].Value))
+	require.Nil(t, evt.Entries[2].Value)
+	require.Equal(t, []byte{1, 2}, evt.Entries[2].Raw)
+	require.NotEmpty(t, evt.Entries[3].Error)
+}
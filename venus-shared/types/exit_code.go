@@ -0,0 +1,85 @@
+package types
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	gstbuiltin "github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/exitcode"
+
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/evm"
+)
+
+var evmExitCodeNames = map[exitcode.ExitCode]string{
+	evm.ErrReverted:             "EVMErrReverted",
+	evm.ErrInvalidInstruction:   "EVMErrInvalidInstruction",
+	evm.ErrUndefinedInstruction: "EVMErrUndefinedInstruction",
+	evm.ErrStackUnderflow:       "EVMErrStackUnderflow",
+	evm.ErrStackOverflow:        "EVMErrStackOverflow",
+	evm.ErrIllegalMemoryAccess:  "EVMErrIllegalMemoryAccess",
+	evm.ErrBadJumpdest:          "EVMErrBadJumpdest",
+	evm.ErrSelfdestructFailed:   "EVMErrSelfdestructFailed",
+}
+
+// ExitCodeName returns the name of a system or common exit code, eg. SysErrOutOfGas. The actor
+// specific codes are only named for the evm actor, when isEvm is set, the others are formatted
+// as numbers.
+func ExitCodeName(code exitcode.ExitCode, isEvm bool) string {
+	if isEvm {
+		if name, ok := evmExitCodeNames[code]; ok {
+			return name
+		}
+	}
+	if code >= exitcode.FirstActorSpecificExitCode {
+		return strconv.FormatInt(int64(code), 10)
+	}
+	// the names of go-state-types are formatted as Name(code)
+	name := code.String()
+	if i := strings.IndexByte(name, '('); i > 0 {
+		return name[:i]
+	}
+	return name
+}
+
+// IsEvmMessage tells whether the message runs evm code, to call a contract or create one, so the
+// actor specific exit codes of its receipt are the ones of the evm actor.
+func IsEvmMessage(msg *Message) bool {
+	if msg.Method == evm.Methods.InvokeContract {
+		return true
+	}
+	if msg.To != builtin.EthereumAddressManagerActorAddr {
+		return false
+	}
+	switch msg.Method {
+	case gstbuiltin.MethodsEAM.Create, gstbuiltin.MethodsEAM.Create2, gstbuiltin.MethodsEAM.CreateExternal:
+		return true
+	}
+	return false
+}
+
+// RevertData returns the data returned by a reverted evm call, nil if the call did not revert.
+func RevertData(rct *MessageReceipt) []byte {
+	if rct.ExitCode != evm.ErrReverted || len(rct.Return) == 0 {
+		return nil
+	}
+	var data abi.CborBytes
+	if err := data.UnmarshalCBOR(bytes.NewReader(rct.Return)); err != nil {
+		return nil
+	}
+	return data
+}
+
+// DecodeExit sets the name of the exit code and the revert data of a failed message.
+func (ir *InvocResult) DecodeExit() {
+	if ir.Msg == nil || ir.MsgRct == nil || ir.MsgRct.ExitCode.IsSuccess() {
+		return
+	}
+	isEvm := IsEvmMessage(ir.Msg)
+	ir.ExitCodeName = ExitCodeName(ir.MsgRct.ExitCode, isEvm)
+	if isEvm {
+		ir.RevertData = RevertData(ir.MsgRct)
+	}
+}
@@ -0,0 +1,50 @@
+package types
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/evm"
+)
+
+func TestExitCodeName(t *testing.T) {
+	tf.UnitTest(t)
+
+	assert.Equal(t, "SysErrOutOfGas", ExitCodeName(exitcode.SysErrOutOfGas, false))
+	assert.Equal(t, "ErrIllegalArgument", ExitCodeName(exitcode.ErrIllegalArgument, true))
+	assert.Equal(t, "33", ExitCodeName(evm.ErrReverted, false))
+	assert.Equal(t, "EVMErrReverted", ExitCodeName(evm.ErrReverted, true))
+	assert.Equal(t, "100", ExitCodeName(100, true))
+}
+
+func TestInvocResultDecodeExit(t *testing.T) {
+	tf.UnitTest(t)
+
+	var ret bytes.Buffer
+	data := abi.CborBytes("revert")
+	require.NoError(t, data.MarshalCBOR(&ret))
+
+	ir := &InvocResult{
+		Msg:    &Message{To: builtin.EthereumAddressManagerActorAddr, Method: 4},
+		MsgRct: &MessageReceipt{ExitCode: evm.ErrReverted, Return: ret.Bytes()},
+	}
+	ir.DecodeExit()
+	assert.Equal(t, "EVMErrReverted", ir.ExitCodeName)
+	assert.Equal(t, []byte("revert"), ir.RevertData)
+
+	// the method numbers of the eam are only evm creations when sent to the eam
+	ir = &InvocResult{
+		Msg:    &Message{To: builtin.InitActorAddr, Method: 4},
+		MsgRct: &MessageReceipt{ExitCode: evm.ErrReverted, Return: ret.Bytes()},
+	}
+	ir.DecodeExit()
+	assert.Equal(t, "33", ir.ExitCodeName)
+	assert.Nil(t, ir.RevertData)
+}
@@ -0,0 +1,26 @@
+package types
+
+import (
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/ipfs/go-cid"
+)
+
+// MessageBundle is a batch of messages prepared by MpoolPrepareMessages to be signed away from the node, their nonces
+// and gas are set. The signer signs the SigningBytes of each message, fills its Signature, and the bundle is pushed
+// back with MpoolPushSigned.
+type MessageBundle struct {
+	// Head is the tipset the nonces and the gas were estimated at
+	Head     TipSetKey
+	Height   abi.ChainEpoch
+	Messages []BundleMessage
+}
+
+// BundleMessage is a message of a MessageBundle, SigningBytes are the bytes to sign with the key of the sender.
+type BundleMessage struct {
+	Message      Message
+	Cid          cid.Cid
+	SigType      SigType
+	SigningBytes []byte
+	Signature    *crypto.Signature
+}
@@ -0,0 +1,34 @@
+package types
+
+import (
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+)
+
+// MinerCreateParams describes the miner created by MinerCreate.
+type MinerCreateParams struct {
+	Owner address.Address
+	// Worker defaults to the owner
+	Worker address.Address
+	// From is the sender of the CreateMiner message, it defaults to the owner
+	From address.Address
+	// SectorSize selects the window PoSt proof type of the miner with the network version
+	SectorSize abi.SectorSize
+	Peer       abi.PeerID
+	Multiaddrs []abi.Multiaddrs
+	// GasPremium is estimated when empty or zero
+	GasPremium abi.TokenAmount
+	// Push signs and pushes the message then waits for its receipt, otherwise the message is only
+	// returned to be signed elsewhere
+	Push bool
+	Spec *MessageSendSpec
+}
+
+// MinerCreateResult is the result of MinerCreate, the addresses are only set when the message was pushed.
+type MinerCreateResult struct {
+	Message       *Message
+	MessageCid    cid.Cid
+	IDAddress     address.Address
+	RobustAddress address.Address
+}
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/ipfs/go-cid"
 )
 
@@ -52,3 +54,68 @@ type MpoolUpdate struct {
 	Type    MpoolChange
 	Message *SignedMessage
 }
+
+// MpoolSelectedMessage is a message picked by the message selection, along with the figures it was ranked by
+type MpoolSelectedMessage struct {
+	Message *SignedMessage
+	// GasReward is the premium paid to the block miner, capped by the fee cap above the base fee, times the gas limit
+	GasReward BigInt
+	// GasPerf is the gas reward per unit of block gas limit
+	GasPerf float64
+	// Priority is set when the sender is one of the configured priority addresses
+	Priority bool
+	// DependsOn is the selected message from the same sender with the preceding nonce, which must be
+	// included first, nil if the preceding nonce is already on chain
+	DependsOn *cid.Cid
+}
+
+// MpoolSelection is the result of simulating the message selection for the next block
+type MpoolSelection struct {
+	BaseFee   BigInt
+	GasLimit  int64
+	GasReward BigInt
+	Messages  []*MpoolSelectedMessage
+}
+
+// MpoolStats reports the pending messages of the message pool and the premiums they pay, to estimate the inclusion
+// of a new message
+type MpoolStats struct {
+	// Height is the height of the head the pool is based on, BaseFee the base fee of the next block
+	Height  abi.ChainEpoch
+	BaseFee BigInt
+	Pending int
+	// Bytes is the size of the pending messages, GasLimit the sum of their gas limits
+	Bytes    int64
+	GasLimit int64
+	// Senders are the senders of the pending messages, from the one with the most
+	Senders []MpoolSenderStats
+	// Premiums are the percentiles of the effective premiums of the pending messages, the premium capped by the
+	// fee cap above the base fee
+	Premiums []MpoolPremiumPercentile
+	// Inclusion projects the inclusion of a message at various premiums
+	Inclusion []MpoolInclusionEstimate
+}
+
+// MpoolSenderStats is the pending messages of a sender
+type MpoolSenderStats struct {
+	Sender   address.Address
+	Pending  int
+	Bytes    int64
+	GasLimit int64
+}
+
+// MpoolPremiumPercentile is the effective premium which Percentile percent of the pending messages pay at most
+type MpoolPremiumPercentile struct {
+	Percentile int
+	Premium    BigInt
+}
+
+// MpoolInclusionEstimate projects the inclusion of a message paying Premium, assuming the pending messages are
+// included by effective premium and each epoch includes the gas target of the expected blocks
+type MpoolInclusionEstimate struct {
+	Premium BigInt
+	// GasAhead is the gas limit of the pending messages paying a higher effective premium
+	GasAhead int64
+	// Epochs is the number of epochs before the message is included, 1 for the next one
+	Epochs int64
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"time"
 
+	"github.com/ipfs/go-cid"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -29,3 +30,31 @@ type NatInfo struct {
 	Reachability network.Reachability
 	PublicAddrs  []string
 }
+
+// QuarantineKind is the pubsub topic a quarantined object was received on.
+type QuarantineKind string
+
+const (
+	QuarantineBlock   QuarantineKind = "block"
+	QuarantineMessage QuarantineKind = "message"
+)
+
+// QuarantineEntry is a block or message rejected by a pubsub validator.
+type QuarantineEntry struct {
+	Kind QuarantineKind
+	// Cid is undefined when the payload could not be decoded
+	Cid      cid.Cid
+	From     peer.ID
+	Reason   string
+	Error    string
+	Received time.Time
+	// Data is the raw pubsub payload
+	Data []byte
+}
+
+// QuarantineStat counts the rejections of a kind for a reason since the node started.
+type QuarantineStat struct {
+	Kind   QuarantineKind
+	Reason string
+	Count  uint64
+}
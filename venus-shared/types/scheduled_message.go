@@ -0,0 +1,29 @@
+package types
+
+import (
+	"time"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+)
+
+// ScheduleTrigger holds a scheduled message until all of its conditions are met, its zero values disable them.
+type ScheduleTrigger struct {
+	// Epoch holds the message until the chain reaches this height
+	Epoch abi.ChainEpoch
+	// MaxBaseFee holds the message until the base fee of the head is at most MaxBaseFee
+	MaxBaseFee abi.TokenAmount
+	// After holds the message until the message with this cid landed on chain
+	After *cid.Cid
+}
+
+// ScheduledMessage is a signed message held by the message scheduler until its trigger fires.
+type ScheduledMessage struct {
+	Message *SignedMessage
+	Trigger ScheduleTrigger
+	// Height is the height of the head when the message was scheduled
+	Height  abi.ChainEpoch
+	Created time.Time
+	// Error is the error pushing the message once its trigger fired, the message is kept until it is canceled
+	Error string
+}
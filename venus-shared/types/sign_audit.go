@@ -0,0 +1,44 @@
+package types
+
+import (
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+)
+
+// SignAuditRecord is an entry of the signing audit log, written for each signature made by the wallet and each
+// message pushed to the message pool through the api.
+type SignAuditRecord struct {
+	ID   uint64
+	Time time.Time
+	// Token is the name of the account of the token the api was called with
+	Token  string
+	Method string
+	Signer address.Address
+	// MsgType is the type of the signed data, the message fields are only set for chain messages
+	MsgType   MsgType
+	MsgCid    cid.Cid
+	To        address.Address
+	Nonce     uint64
+	Value     abi.TokenAmount
+	MethodNum abi.MethodNum
+	Purpose   string
+	Error     string
+}
+
+// SignAuditFilter selects the records of the signing audit log, the zero values match every record.
+type SignAuditFilter struct {
+	Signer address.Address
+	Token  string
+	Method string
+	Since  time.Time
+	Until  time.Time
+}
+
+// SignAuditPage is a page of the signing audit log, Next is the cursor of the following page and 0 on the last one.
+type SignAuditPage struct {
+	Records []SignAuditRecord
+	Next    uint64
+}
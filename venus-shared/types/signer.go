@@ -12,3 +12,16 @@ type Signer interface {
 	SignBytes(ctx context.Context, data []byte, addr address.Address) (*crypto.Signature, error)
 	HasAddress(ctx context.Context, addr address.Address) (bool, error)
 }
+
+// WalletSignRequest is a message of WalletSignBatch, signed by its sender.
+type WalletSignRequest struct {
+	Message *Message
+	// Purpose tells why the message is signed, it is recorded to the signing audit log
+	Purpose string
+}
+
+// WalletSignResult is the signed message of a WalletSignRequest, or the error signing it.
+type WalletSignResult struct {
+	SignedMessage *SignedMessage
+	Error         string
+}
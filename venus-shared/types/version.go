@@ -35,3 +35,43 @@ const (
 	minorOnlyMask = 0x00ff00
 	patchOnlyMask = 0x0000ff
 )
+
+// Compatible reports whether a client built against the version client can use an api of version ve, the minor
+// versions of an api only add methods.
+func (ve APIVersion) Compatible(client APIVersion) bool {
+	return ve&majorMask == client&majorMask && ve&minorOnlyMask >= client&minorOnlyMask
+}
+
+// APIDeprecation is a deprecated method of an api.
+type APIDeprecation struct {
+	Method string
+	// Replacement is the method to call instead, in the namespace of the latest api version
+	Replacement string
+	Notice      string
+}
+
+// APINamespaceVersion is the version of an api served by the node.
+type APINamespaceVersion struct {
+	// Path is the http path the api is served on, like /rpc/v1
+	Path       string
+	Namespace  string
+	APIVersion APIVersion
+	// Shim tells whether the api is served by the compatibility shims over the latest api version
+	Shim         bool
+	Deprecations []APIDeprecation
+}
+
+// APIVersions lists the apis served by the node.
+type APIVersions struct {
+	Version string
+	APIs    []APINamespaceVersion
+}
+
+// APIHandshake is the answer of the node to a client announcing the api version it is built against.
+type APIHandshake struct {
+	APIVersions
+
+	// Compatible tells whether the client can use the api it is connected to
+	Compatible bool
+	Notice     string
+}
@@ -0,0 +1,21 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestAPIVersionCompatible(t *testing.T) {
+	tf.UnitTest(t)
+
+	node := NewVer(2, 3, 0)
+	require.True(t, node.Compatible(NewVer(2, 3, 0)))
+	require.True(t, node.Compatible(NewVer(2, 3, 5)))
+	require.True(t, node.Compatible(NewVer(2, 1, 0)))
+	require.False(t, node.Compatible(NewVer(2, 4, 0)))
+	require.False(t, node.Compatible(NewVer(1, 3, 0)))
+	require.False(t, node.Compatible(NewVer(3, 0, 0)))
+}
@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/ipfs/go-cid"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// the types encoded as JSON scalars by their own marshaler
+var schemaKinds = map[reflect.Type]string{
+	reflect.TypeOf(abi.EmptyValue{}):    types.SchemaKindEmpty,
+	reflect.TypeOf(address.Address{}):   types.SchemaKindAddress,
+	reflect.TypeOf(big.Int{}):           types.SchemaKindBigInt,
+	reflect.TypeOf(cid.Cid{}):           types.SchemaKindCid,
+	reflect.TypeOf(bitfield.BitField{}): types.SchemaKindBitField,
+	reflect.TypeOf(cbg.Deferred{}):      types.SchemaKindRaw,
+}
+
+// ActorMethods returns the methods of the builtin actor with code, sorted by number.
+func ActorMethods(code cid.Cid) ([]types.ActorMethod, error) {
+	methods, ok := MethodsMap[code]
+	if !ok {
+		return nil, fmt.Errorf("unknown actor code %s", code)
+	}
+
+	out := make([]types.ActorMethod, 0, len(methods))
+	for num, meta := range methods {
+		out = append(out, types.ActorMethod{
+			Num:     num,
+			Name:    meta.Name,
+			Version: meta.Version,
+			Params:  TypeSchemaOf(meta.Params),
+			Return:  TypeSchemaOf(meta.Ret),
+		})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Num < out[j].Num
+	})
+	return out, nil
+}
+
+// TypeSchemaOf describes the JSON encoding of the values of type t.
+func TypeSchemaOf(t reflect.Type) *types.TypeSchema {
+	return typeSchema(t, map[reflect.Type]bool{})
+}
+
+// typeSchema describes t, the named types being described are in parents so the recursive types
+// only refer to themselves by name.
+func typeSchema(t reflect.Type, parents map[reflect.Type]bool) *types.TypeSchema {
+	nullable := false
+	for t.Kind() == reflect.Ptr {
+		nullable = true
+		t = t.Elem()
+	}
+	s := &types.TypeSchema{Name: typeName(t), Nullable: nullable}
+
+	if kind, ok := schemaKinds[t]; ok {
+		s.Kind = kind
+		return s
+	}
+	if parents[t] {
+		s.Kind = types.SchemaKindStruct
+		return s
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		s.Kind = types.SchemaKindBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		s.Kind = types.SchemaKindInt
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		s.Kind = types.SchemaKindUint
+	case reflect.String:
+		s.Kind = types.SchemaKindString
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			s.Kind = types.SchemaKindBytes
+			break
+		}
+		s.Kind = types.SchemaKindArray
+		s.Nullable = s.Nullable || t.Kind() == reflect.Slice
+		s.Elem = typeSchema(t.Elem(), parents)
+	case reflect.Map:
+		s.Kind = types.SchemaKindMap
+		s.Key = typeSchema(t.Key(), parents)
+		s.Elem = typeSchema(t.Elem(), parents)
+	case reflect.Struct:
+		s.Kind = types.SchemaKindStruct
+		parents[t] = true
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name := f.Name
+			if tag := strings.Split(f.Tag.Get("json"), ",")[0]; tag == "-" {
+				continue
+			} else if tag != "" {
+				name = tag
+			}
+			s.Fields = append(s.Fields, types.SchemaField{Name: name, Schema: typeSchema(f.Type, parents)})
+		}
+		delete(parents, t)
+	default:
+		s.Kind = types.SchemaKindAny
+	}
+	return s
+}
+
+// typeName returns the package qualified name of a named type, eg. miner.ChangeWorkerAddressParams.
+func typeName(t reflect.Type) string {
+	if t.Name() == "" || t.PkgPath() == "" {
+		return ""
+	}
+	return t.String()
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"testing"
+
+	actorstypes "github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/manifest"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/actors"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestActorMethods(t *testing.T) {
+	tf.UnitTest(t)
+
+	code, ok := actors.GetActorCodeID(actorstypes.Version16, manifest.MinerKey)
+	require.True(t, ok)
+	methods, err := ActorMethods(code)
+	require.NoError(t, err)
+
+	require.Equal(t, builtin.MethodSend, methods[0].Num)
+	assert.Equal(t, types.SchemaKindEmpty, methods[0].Params.Kind)
+
+	var changeWorker *types.ActorMethod
+	for i := range methods {
+		if i > 0 {
+			assert.Less(t, methods[i-1].Num, methods[i].Num)
+		}
+		if methods[i].Num == builtin.MethodsMiner.ChangeWorkerAddress {
+			changeWorker = &methods[i]
+		}
+	}
+	require.NotNil(t, changeWorker)
+	assert.Equal(t, "ChangeWorkerAddress", changeWorker.Name)
+	assert.Equal(t, actorstypes.Version16, changeWorker.Version)
+	assert.Equal(t, &types.TypeSchema{
+		Kind:     types.SchemaKindStruct,
+		Name:     "miner.ChangeWorkerAddressParams",
+		Nullable: true,
+		Fields: []types.SchemaField{
+			{Name: "NewWorker", Schema: &types.TypeSchema{Kind: types.SchemaKindAddress, Name: "address.Address"}},
+			{Name: "NewControlAddrs", Schema: &types.TypeSchema{
+				Kind:     types.SchemaKindArray,
+				Nullable: true,
+				Elem:     &types.TypeSchema{Kind: types.SchemaKindAddress, Name: "address.Address"},
+			}},
+		},
+	}, changeWorker.Params)
+
+	_, err = ActorMethods(cid.Undef)
+	assert.Error(t, err)
+}
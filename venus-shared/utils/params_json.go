@@ -0,0 +1,240 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/ipfs/go-cid"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+var (
+	bigIntType   = reflect.TypeOf(big.Int{})
+	bitFieldType = reflect.TypeOf(bitfield.BitField{})
+	cidType      = reflect.TypeOf(cid.Cid{})
+
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// EncodeParams encodes to cbor the params of the method of the builtin actor with code, given as
+// JSON. Besides the encoding of the types, the JSON may hold:
+//   - the big integers, like the token amounts, as numbers, decimal strings or FIL strings like "1.5 FIL"
+//   - the integers as decimal strings
+//   - the bitfields as their sorted set bits and ranges, like "0-3,7", or their RLE+ runs
+//   - the cids as strings
+func EncodeParams(code cid.Cid, method abi.MethodNum, params []byte) ([]byte, error) {
+	methodMeta, found := MethodsMap[code][method]
+	if !found {
+		return nil, fmt.Errorf("method %d not found on actor %s", method, code)
+	}
+
+	v := reflect.New(methodMeta.Params.Elem())
+	if err := decodeJSON(params, v.Elem()); err != nil {
+		return nil, fmt.Errorf("json unmarshal: %w", err)
+	}
+	m, ok := v.Interface().(cbg.CBORMarshaler)
+	if !ok {
+		return nil, fmt.Errorf("params %s can't be encoded to cbor", methodMeta.Params)
+	}
+
+	var buf bytes.Buffer
+	if err := m.MarshalCBOR(&buf); err != nil {
+		return nil, fmt.Errorf("cbor marshal: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeJSON decodes data into v, which must be settable.
+func decodeJSON(data []byte, v reflect.Value) error {
+	data = bytes.TrimSpace(data)
+	t := v.Type()
+
+	switch t {
+	case bigIntType:
+		return decodeBigInt(data, v)
+	case bitFieldType:
+		return decodeBitField(data, v)
+	case cidType:
+		return decodeCid(data, v)
+	}
+
+	if t.Kind() == reflect.Ptr {
+		if bytes.Equal(data, []byte("null")) {
+			v.Set(reflect.Zero(t))
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(t.Elem()))
+		}
+		return decodeJSON(data, v.Elem())
+	}
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) {
+		return json.Unmarshal(data, v.Addr().Interface())
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		// the integers may be quoted
+		var s string
+		if json.Unmarshal(data, &s) == nil {
+			data = []byte(s)
+		}
+		return json.Unmarshal(data, v.Addr().Interface())
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 || bytes.Equal(data, []byte("null")) {
+			return json.Unmarshal(data, v.Addr().Interface())
+		}
+		var elems []json.RawMessage
+		if err := json.Unmarshal(data, &elems); err != nil {
+			return fmt.Errorf("%s: %w", t, err)
+		}
+		s := reflect.MakeSlice(t, len(elems), len(elems))
+		for i, elem := range elems {
+			if err := decodeJSON(elem, s.Index(i)); err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		v.Set(s)
+		return nil
+	case reflect.Struct:
+		return decodeStruct(data, v)
+	}
+	return json.Unmarshal(data, v.Addr().Interface())
+}
+
+// decodeStruct decodes the fields of a struct like encoding/json, matching the names case
+// insensitively, but refuses the unknown fields.
+func decodeStruct(data []byte, v reflect.Value) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return fmt.Errorf("%s: %w", v.Type(), err)
+	}
+
+	t := v.Type()
+	for name, value := range fields {
+		idx := -1
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			fieldName := f.Name
+			if tag := strings.Split(f.Tag.Get("json"), ",")[0]; tag == "-" {
+				continue
+			} else if tag != "" {
+				fieldName = tag
+			}
+			if fieldName == name {
+				idx = i
+				break
+			}
+			if idx < 0 && strings.EqualFold(fieldName, name) {
+				idx = i
+			}
+		}
+		if idx < 0 {
+			return fmt.Errorf("%s has no field %s", t, name)
+		}
+		if err := decodeJSON(value, v.Field(idx)); err != nil {
+			return fmt.Errorf("field %s: %w", t.Field(idx).Name, err)
+		}
+	}
+	return nil
+}
+
+func decodeBigInt(data []byte, v reflect.Value) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		var n json.Number
+		if err := json.Unmarshal(data, &n); err != nil {
+			return fmt.Errorf("big int must be a number or a string: %w", err)
+		}
+		s = n.String()
+	}
+	s = strings.TrimSpace(s)
+
+	if i, err := big.FromString(s); err == nil {
+		v.Set(reflect.ValueOf(i))
+		return nil
+	}
+	// only the values with a unit are parsed as FIL, the bare decimals being attoFIL
+	if strings.TrimLeft(s, "-.0123456789") == "" {
+		return fmt.Errorf("failed to parse big int %q", s)
+	}
+	f, err := types.ParseFIL(s)
+	if err != nil {
+		return err
+	}
+	v.Set(reflect.ValueOf(big.Int{Int: f.Int}))
+	return nil
+}
+
+func decodeBitField(data []byte, v reflect.Value) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		var bf bitfield.BitField
+		if err := bf.UnmarshalJSON(data); err != nil {
+			return fmt.Errorf("bitfield must be a string of bits or an array of runs: %w", err)
+		}
+		v.Set(reflect.ValueOf(bf))
+		return nil
+	}
+
+	var bits []uint64
+	for _, r := range strings.Split(s, ",") {
+		r = strings.TrimSpace(r)
+		if r == "" {
+			continue
+		}
+		from, to, isRange := strings.Cut(r, "-")
+		start, err := strconv.ParseUint(strings.TrimSpace(from), 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid bit %q: %w", r, err)
+		}
+		end := start
+		if isRange {
+			if end, err = strconv.ParseUint(strings.TrimSpace(to), 10, 64); err != nil {
+				return fmt.Errorf("invalid range %q: %w", r, err)
+			}
+			if end < start {
+				return fmt.Errorf("invalid range %q", r)
+			}
+		}
+		if end-start >= 1<<20 {
+			return fmt.Errorf("range %q is too large", r)
+		}
+		for i := start; i <= end; i++ {
+			bits = append(bits, i)
+		}
+	}
+	v.Set(reflect.ValueOf(bitfield.NewFromSet(bits)))
+	return nil
+}
+
+func decodeCid(data []byte, v reflect.Value) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		var c cid.Cid
+		if err := c.UnmarshalJSON(data); err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(c))
+		return nil
+	}
+	c, err := cid.Decode(s)
+	if err != nil {
+		return err
+	}
+	v.Set(reflect.ValueOf(c))
+	return nil
+}
@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/abi"
+	actorstypes "github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/builtin/v16/miner"
+	"github.com/filecoin-project/go-state-types/manifest"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/actors"
+)
+
+func TestEncodeParams(t *testing.T) {
+	tf.UnitTest(t)
+
+	minerCode, ok := actors.GetActorCodeID(actorstypes.Version16, manifest.MinerKey)
+	require.True(t, ok)
+	powerCode, ok := actors.GetActorCodeID(actorstypes.Version16, manifest.PowerKey)
+	require.True(t, ok)
+
+	b, err := EncodeParams(minerCode, builtin.MethodsMiner.WithdrawBalance, []byte(`{"AmountRequested": "1.5 FIL"}`))
+	require.NoError(t, err)
+	var withdraw miner.WithdrawBalanceParams
+	require.NoError(t, withdraw.UnmarshalCBOR(bytes.NewReader(b)))
+	assert.Equal(t, big.NewInt(15e17), withdraw.AmountRequested)
+
+	b, err = EncodeParams(minerCode, builtin.MethodsMiner.WithdrawBalance, []byte(`{"amountrequested": 1000}`))
+	require.NoError(t, err)
+	require.NoError(t, withdraw.UnmarshalCBOR(bytes.NewReader(b)))
+	assert.Equal(t, big.NewInt(1000), withdraw.AmountRequested)
+
+	_, err = EncodeParams(minerCode, builtin.MethodsMiner.WithdrawBalance, []byte(`{"Amount": "1"}`))
+	assert.Error(t, err)
+	_, err = EncodeParams(minerCode, builtin.MethodsMiner.WithdrawBalance, []byte(`{"AmountRequested": "1.5"}`))
+	assert.Error(t, err)
+
+	// params which are not structs
+	b, err = EncodeParams(powerCode, builtin.MethodsPower.UpdatePledgeTotal, []byte(`"-42"`))
+	require.NoError(t, err)
+	var pledge abi.TokenAmount
+	require.NoError(t, pledge.UnmarshalCBOR(bytes.NewReader(b)))
+	assert.Equal(t, big.NewInt(-42), pledge)
+
+	_, err = EncodeParams(cid.Undef, 2, []byte(`{}`))
+	assert.Error(t, err)
+}
+
+func TestDecodeJSON(t *testing.T) {
+	tf.UnitTest(t)
+
+	type params struct {
+		Sectors  bitfield.BitField
+		Runs     bitfield.BitField
+		Root     cid.Cid
+		Linked   *cid.Cid
+		Epoch    abi.ChainEpoch
+		Addrs    []address.Address
+		Renamed  uint64 `json:"other"`
+		Optional *params
+	}
+
+	c, err := cid.Decode("bafy2bzacea3wsdh6y3a36tb3skempjoxqpuyompjbmfeyf34fi3uy6uue42v4")
+	require.NoError(t, err)
+	addr, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+
+	var p params
+	require.NoError(t, decodeJSON([]byte(`{
+		"Sectors": "0-2, 7",
+		"Runs": [1, 2],
+		"Root": "`+c.String()+`",
+		"Linked": {"/": "`+c.String()+`"},
+		"Epoch": "100",
+		"Addrs": ["f01000"],
+		"other": 3,
+		"Optional": null
+	}`), reflect.ValueOf(&p).Elem()))
+
+	bits, err := p.Sectors.All(10)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{0, 1, 2, 7}, bits)
+	bits, err = p.Runs.All(10)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2}, bits)
+	assert.Equal(t, c, p.Root)
+	assert.Equal(t, &c, p.Linked)
+	assert.Equal(t, abi.ChainEpoch(100), p.Epoch)
+	assert.Equal(t, []address.Address{addr}, p.Addrs)
+	assert.Equal(t, uint64(3), p.Renamed)
+	assert.Nil(t, p.Optional)
+
+	assert.Error(t, decodeJSON([]byte(`{"Sectors": "3-1"}`), reflect.ValueOf(&p).Elem()))
+	assert.Error(t, decodeJSON([]byte(`{"Renamed": 1}`), reflect.ValueOf(&p).Elem()))
+}